@@ -0,0 +1,324 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/audit"
+	"app/internal/model"
+	"app/internal/temporal"
+	"app/internal/temporal/workflows"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ==============================================
+// SELF-SERVE ACCOUNT DELETION
+// ==============================================
+
+// RequestAccountDeletion deactivates the caller's account and starts the deletion hold,
+// served as DELETE /api/v1/users/me and POST /api/v1/account/deletion. Login is blocked
+// immediately; the user is emailed an export of their data, and the data is erased when
+// the hold expires unless reactivated. The password confirming the request is recorded
+// in the audit log as the user's consent to erasure.
+func RequestAccountDeletion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req model.AccountDeletionBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Password == "" {
+		RespondWithValidationError(w, &ValidationError{Field: "password", Message: "is required to confirm deletion"})
+		return
+	}
+	if len(req.Reason) > 1000 {
+		RespondWithValidationError(w, &ValidationError{Field: "reason", Message: "must not exceed 1000 characters"})
+		return
+	}
+
+	var passwordHash sql.NullString
+	err := config.DB.QueryRow(`SELECT password_hash FROM people WHERE id = $1 AND is_active = true`, userID).Scan(&passwordHash)
+	if err == sql.ErrNoRows {
+		respondError(w, http.StatusNotFound, model.ErrCodeUserNotFound, "User not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting user for deletion", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if !passwordHash.Valid || bcrypt.CompareHashAndPassword([]byte(passwordHash.String), []byte(req.Password)) != nil {
+		respondError(w, http.StatusUnauthorized, model.ErrCodeInvalidCredentials, "Invalid password")
+		return
+	}
+
+	// Jobs in flight must be finished or cancelled first
+	var activeJobs int
+	err = config.DB.QueryRow(`
+		SELECT COUNT(*) FROM jobs
+		WHERE (consumer_id = $1 OR gig_worker_id = $1)
+		  AND status IN ('accepted', 'worker_assigned', 'scheduled', 'in_progress')
+	`, userID).Scan(&activeJobs)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error counting active jobs", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if activeJobs > 0 {
+		RespondWithError(w, http.StatusConflict, "Complete or cancel your active jobs before deleting your account")
+		return
+	}
+
+	tx, err := config.DB.Begin()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error starting transaction", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer tx.Rollback()
+
+	var requestID int
+	var purgeAfter time.Time
+	err = tx.QueryRow(`
+		INSERT INTO account_deletion_requests (user_id, reason, purge_after)
+		VALUES ($1, $2, NOW() + $3 * INTERVAL '1 second')
+		RETURNING id, purge_after
+	`, userID, nullString(req.Reason), workflows.AccountDeletionHold.Seconds()).Scan(&requestID, &purgeAfter)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error creating deletion request", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to request account deletion")
+		return
+	}
+
+	if _, err := tx.Exec(`UPDATE people SET is_active = false, updated_at = NOW() WHERE id = $1`, userID); err != nil {
+		slog.ErrorContext(r.Context(), "Database error deactivating user", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to request account deletion")
+		return
+	}
+
+	// Held accounts are logged out everywhere; their refresh tokens stop working
+	if _, err := tx.Exec(`UPDATE user_sessions SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`, userID); err != nil {
+		slog.ErrorContext(r.Context(), "Database error revoking sessions", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to request account deletion")
+		return
+	}
+
+	entry := newAuditEntry(r, audit.ActionAccountDeletionRequested, audit.EntityUser, userID)
+	entry.Metadata = map[string]interface{}{
+		"deletion_request_id": requestID,
+		"purge_after":         purgeAfter,
+		"confirmed_with":      "password",
+	}
+	if err := audit.Record(r.Context(), tx, entry); err != nil {
+		slog.ErrorContext(r.Context(), "Database error recording deletion consent", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to request account deletion")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(r.Context(), "Database error committing deletion request", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to request account deletion")
+		return
+	}
+
+	// The purge is only guaranteed once the workflow is running. The workflow starts
+	// after the request is committed so it always finds it; if it cannot start, the
+	// request is withdrawn and the account stays active.
+	workflowID, err := startAccountDeletionWorkflow(r.Context(), userID, requestID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to start account deletion workflow for user", "user_id", userID, "error", err)
+		withdrawDeletionRequest(r.Context(), userID, requestID)
+		RespondWithError(w, http.StatusServiceUnavailable, "Account deletion is temporarily unavailable")
+		return
+	}
+	if _, err := config.DB.Exec(`UPDATE account_deletion_requests SET temporal_workflow_id = $1 WHERE id = $2`, workflowID, requestID); err != nil {
+		slog.ErrorContext(r.Context(), "Database error saving deletion workflow", "error", err)
+	}
+
+	slog.InfoContext(r.Context(), "User requested account deletion", "user_id", userID, "deletion_request_id", requestID, "purge_after", purgeAfter.Format(time.RFC3339))
+
+	RespondWithJSON(w, http.StatusAccepted, map[string]interface{}{
+		"success":     true,
+		"message":     "Your account has been deactivated and will be permanently deleted after the hold period. We'll email you a copy of your data. You can reactivate it with your email and password before then.",
+		"purge_after": purgeAfter,
+	})
+}
+
+// startAccountDeletionWorkflow starts the workflow that purges the account when its
+// hold expires and returns its ID
+func startAccountDeletionWorkflow(ctx context.Context, userID, requestID int) (string, error) {
+	temporalClient, err := temporal.NewClient()
+	if err != nil {
+		return "", fmt.Errorf("failed to create Temporal client: %w", err)
+	}
+	defer temporalClient.Close()
+
+	run, err := temporalClient.StartAccountDeletionWorkflow(ctx, userID, requestID)
+	if err != nil {
+		return "", err
+	}
+	return run.GetID(), nil
+}
+
+// withdrawDeletionRequest undoes a deletion request whose workflow could not start,
+// reactivating the account. The user has to log in again, since their sessions were
+// ended.
+func withdrawDeletionRequest(ctx context.Context, userID, requestID int) {
+	tx, err := config.DB.Begin()
+	if err != nil {
+		slog.ErrorContext(ctx, "Database error starting transaction", "error", err)
+		return
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`DELETE FROM account_deletion_requests WHERE id = $1 AND status = 'pending'`, requestID)
+	if err == nil {
+		_, err = tx.Exec(`UPDATE people SET is_active = true, updated_at = NOW() WHERE id = $1`, userID)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Database error withdrawing deletion request", "user_id", userID, "deletion_request_id", requestID, "error", err)
+	}
+}
+
+// ReactivateAccount restores an account during its deletion hold. Login is blocked
+// while the account is held, so the user authenticates with email and password here.
+func ReactivateAccount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req model.AccountReactivationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	if req.Email == "" || req.Password == "" {
+		RespondWithError(w, http.StatusBadRequest, "Email and password are required")
+		return
+	}
+
+	var user model.User
+	var passwordHash sql.NullString
+	var requestID int
+	var workflowID sql.NullString
+	err := config.DB.QueryRow(`
+		SELECT p.id, p.uuid, p.email, p.role, p.password_hash, d.id, d.temporal_workflow_id
+		FROM people p
+		JOIN account_deletion_requests d ON d.user_id = p.id AND d.status = 'pending'
+		WHERE p.email = $1 AND p.is_active = false
+	`, strings.ToLower(strings.TrimSpace(req.Email))).Scan(
+		&user.ID, &user.Uuid, &user.Email, &user.Role, &passwordHash, &requestID, &workflowID,
+	)
+	if err == sql.ErrNoRows {
+		respondError(w, http.StatusUnauthorized, model.ErrCodeInvalidCredentials, "Invalid email or password")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error during reactivation", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if !passwordHash.Valid || bcrypt.CompareHashAndPassword([]byte(passwordHash.String), []byte(req.Password)) != nil {
+		respondError(w, http.StatusUnauthorized, model.ErrCodeInvalidCredentials, "Invalid email or password")
+		return
+	}
+
+	tx, err := config.DB.Begin()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error starting transaction", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer tx.Rollback()
+
+	// The status guard makes this race-safe against a purge in progress
+	result, err := tx.Exec(`
+		UPDATE account_deletion_requests SET status = 'reactivated', reactivated_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+	`, requestID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error reactivating account", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to reactivate account")
+		return
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		RespondWithError(w, http.StatusConflict, "Account can no longer be reactivated")
+		return
+	}
+
+	if _, err := tx.Exec(`UPDATE people SET is_active = true, updated_at = NOW() WHERE id = $1`, user.ID); err != nil {
+		slog.ErrorContext(r.Context(), "Database error reactivating user", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to reactivate account")
+		return
+	}
+
+	// The caller is not logged in, so the entry names them as the actor explicitly
+	entry := newAuditEntry(r, audit.ActionAccountReactivated, audit.EntityUser, user.ID)
+	entry.ActorID, entry.ActorRole = &user.ID, user.Role
+	entry.Metadata = map[string]interface{}{"deletion_request_id": requestID}
+	if err := audit.Record(r.Context(), tx, entry); err != nil {
+		slog.ErrorContext(r.Context(), "Database error recording reactivation", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to reactivate account")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(r.Context(), "Database error committing reactivation", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to reactivate account")
+		return
+	}
+
+	// The workflow also re-checks the request status, so a lost signal cannot purge the account
+	if workflowID.Valid && workflowID.String != "" {
+		go func() {
+			temporalClient, err := temporal.NewClient()
+			if err != nil {
+				slog.ErrorContext(r.Context(), "Failed to create Temporal client", "error", err)
+				return
+			}
+			defer temporalClient.Close()
+
+			if err := temporalClient.SignalAccountReactivated(context.WithoutCancel(r.Context()), workflowID.String); err != nil {
+				slog.ErrorContext(r.Context(), "Failed to signal reactivation for user", "user_id", user.ID, "error", err)
+			}
+		}()
+	}
+
+	token, refreshToken, err := issueSessionTokens(r, user.ID, user.Uuid, user.Email, user.Role)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to start session", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to generate authentication token")
+		return
+	}
+
+	slog.InfoContext(r.Context(), "User reactivated their account", "user_id", user.ID, "deletion_request_id", requestID)
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success":       true,
+		"message":       "Welcome back! Your account has been reactivated.",
+		"token":         token,
+		"refresh_token": refreshToken,
+	})
+}
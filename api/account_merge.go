@@ -0,0 +1,325 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/model"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// ==============================================
+// DUPLICATE ACCOUNT MERGES (SUPPORT TEAM)
+// ==============================================
+
+// mergedColumns are the user references a merge moves to the surviving account
+var mergedColumns = []struct{ table, column string }{
+	{"jobs", "consumer_id"},
+	{"jobs", "gig_worker_id"},
+	{"job_reviews", "reviewer_id"},
+	{"job_reviews", "reviewee_id"},
+	{"transactions", "consumer_id"},
+	{"transactions", "gig_worker_id"},
+	{"user_payment_methods", "user_id"},
+	{"notifications", "user_id"},
+	{"account_credits", "user_id"},
+}
+
+// mergeAccount is one side of a merge
+type mergeAccount struct {
+	ID       int
+	Email    string
+	Role     string
+	IsActive bool
+}
+
+// MergeAccounts moves a duplicate account's jobs, reviews, transactions, payment
+// methods, notifications and account credit to the account the user keeps, then
+// deactivates the duplicate and ends its sessions, all in one transaction. With
+// dry_run the same changes are made and rolled back, reporting what would move.
+// Merges and dry runs are both recorded in account_merges.
+func MergeAccounts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	adminID := GetUserIDFromContext(r)
+	if adminID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req model.AccountMergeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.SourceUserID <= 0 {
+		RespondWithValidationError(w, &ValidationError{Field: "source_user_id", Message: "is required"})
+		return
+	}
+	if req.TargetUserID <= 0 {
+		RespondWithValidationError(w, &ValidationError{Field: "target_user_id", Message: "is required"})
+		return
+	}
+	if req.SourceUserID == req.TargetUserID {
+		RespondWithValidationError(w, &ValidationError{Field: "target_user_id", Message: "must differ from source_user_id"})
+		return
+	}
+	if len(req.Reason) < 10 {
+		RespondWithValidationError(w, &ValidationError{Field: "reason", Message: "must describe why the accounts are duplicates (at least 10 characters)"})
+		return
+	}
+
+	source, err := getMergeAccount(req.SourceUserID)
+	if err == sql.ErrNoRows {
+		respondError(w, http.StatusNotFound, model.ErrCodeUserNotFound, "Source user not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting merge source", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	target, err := getMergeAccount(req.TargetUserID)
+	if err == sql.ErrNoRows {
+		respondError(w, http.StatusNotFound, model.ErrCodeUserNotFound, "Target user not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting merge target", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	if !target.IsActive {
+		RespondWithError(w, http.StatusConflict, "The surviving account is deactivated")
+		return
+	}
+	if source.Role != target.Role || source.Role == "admin" {
+		RespondWithError(w, http.StatusConflict, "Only consumer or gig worker accounts of the same role can be merged")
+		return
+	}
+
+	// A job with both accounts on it would end up with the user working for themselves
+	var sharedJobs int
+	err = config.DB.QueryRow(`
+		SELECT COUNT(*) FROM jobs
+		WHERE (consumer_id = $1 AND gig_worker_id = $2) OR (consumer_id = $2 AND gig_worker_id = $1)
+	`, source.ID, target.ID).Scan(&sharedJobs)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error checking shared jobs", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if sharedJobs > 0 {
+		RespondWithError(w, http.StatusConflict, "The accounts share a job as consumer and worker and cannot be merged")
+		return
+	}
+
+	tx, err := config.DB.Begin()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error starting transaction", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer tx.Rollback()
+
+	moved, err := moveAccountRecords(tx, source.ID, target.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error merging users", "source_id", source.ID, "target_id", target.ID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to merge accounts")
+		return
+	}
+
+	if !req.DryRun {
+		if _, err := tx.Exec(`UPDATE people SET is_active = false, updated_at = NOW() WHERE id = $1`, source.ID); err != nil {
+			slog.ErrorContext(r.Context(), "Database error deactivating merged user", "source_id", source.ID, "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Failed to merge accounts")
+			return
+		}
+		if _, err := tx.Exec(`UPDATE user_sessions SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`, source.ID); err != nil {
+			slog.ErrorContext(r.Context(), "Database error revoking sessions of merged user", "source_id", source.ID, "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Failed to merge accounts")
+			return
+		}
+	}
+
+	// Dry runs are recorded after the preview is rolled back
+	var recorder queryRower = tx
+	if req.DryRun {
+		tx.Rollback()
+		recorder = config.DB
+	}
+
+	merge := model.AccountMerge{
+		AdminID:      adminID,
+		SourceUserID: source.ID,
+		TargetUserID: target.ID,
+		SourceEmail:  source.Email,
+		TargetEmail:  target.Email,
+		Reason:       req.Reason,
+		DryRun:       req.DryRun,
+		Moved:        moved,
+	}
+	movedJSON, _ := json.Marshal(moved)
+	err = recorder.QueryRow(`
+		INSERT INTO account_merges (admin_id, source_user_id, target_user_id, source_email, target_email, reason, dry_run, moved, ip_address, user_agent)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, uuid, merged_at
+	`, adminID, source.ID, target.ID, source.Email, target.Email, req.Reason, req.DryRun, movedJSON,
+		r.RemoteAddr, r.UserAgent()).Scan(&merge.ID, &merge.UUID, &merge.MergedAt)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		RespondWithError(w, http.StatusConflict, "The source account was already merged")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error writing account merge audit record", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to merge accounts")
+		return
+	}
+
+	message := fmt.Sprintf("Dry run: user %d would be merged into user %d", source.ID, target.ID)
+	if !req.DryRun {
+		if err := tx.Commit(); err != nil {
+			slog.ErrorContext(r.Context(), "Database error committing account merge", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Failed to merge accounts")
+			return
+		}
+		message = fmt.Sprintf("User %d was merged into user %d and deactivated", source.ID, target.ID)
+		slog.InfoContext(r.Context(), "Admin merged accounts", "merge_id", merge.ID, "admin_id", adminID, "source_id", source.ID, "target_id", target.ID, "moved", moved)
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": message,
+		"merge":   merge,
+	})
+}
+
+// queryRower is a *sql.DB or *sql.Tx
+type queryRower interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+func getMergeAccount(userID int) (*mergeAccount, error) {
+	var a mergeAccount
+	err := config.DB.QueryRow(`SELECT id, email, role, is_active FROM people WHERE id = $1`, userID).
+		Scan(&a.ID, &a.Email, &a.Role, &a.IsActive)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// moveAccountRecords re-points every merged column from source to target and returns
+// the rows moved per table.column. Moved payment methods lose their default flag when
+// the target already has a default card.
+func moveAccountRecords(tx *sql.Tx, sourceID, targetID int) (map[string]int64, error) {
+	_, err := tx.Exec(`
+		UPDATE user_payment_methods SET is_default = false
+		WHERE user_id = $1 AND EXISTS (
+			SELECT 1 FROM user_payment_methods WHERE user_id = $2 AND is_default = true AND is_active = true
+		)
+	`, sourceID, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear default payment method: %w", err)
+	}
+
+	moved := make(map[string]int64, len(mergedColumns))
+	for _, c := range mergedColumns {
+		result, err := tx.Exec(fmt.Sprintf(`UPDATE %s SET %s = $1 WHERE %s = $2`, c.table, c.column, c.column), targetID, sourceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to move %s.%s: %w", c.table, c.column, err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		moved[c.table+"."+c.column] = n
+	}
+	return moved, nil
+}
+
+// GetAccountMerges lists account merges and dry runs for audit review, newest first
+func GetAccountMerges(w http.ResponseWriter, r *http.Request) {
+	page, err := ParseIntParam(r, "page", 1, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	limit, err := ParseIntParam(r, "limit", DefaultPageSize, MinPageSize, MaxPageSize)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	whereClause := ""
+	var args []any
+	if userParam := r.URL.Query().Get("user_id"); userParam != "" {
+		userID, err := ParseIntParam(r, "user_id", 0, 1, 0)
+		if err != nil {
+			RespondWithValidationError(w, err.(*ValidationError))
+			return
+		}
+		whereClause = " WHERE source_user_id = $1 OR target_user_id = $1"
+		args = append(args, userID)
+	}
+
+	var total int
+	if err := config.DB.QueryRow("SELECT COUNT(*) FROM account_merges"+whereClause, args...).Scan(&total); err != nil {
+		slog.ErrorContext(r.Context(), "Database error counting account merges", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	query := `
+		SELECT id, uuid, admin_id, source_user_id, target_user_id, source_email, target_email,
+		       reason, dry_run, moved, merged_at
+		FROM account_merges` + whereClause +
+		fmt.Sprintf(" ORDER BY merged_at DESC, id DESC LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, limit, (page-1)*limit)
+
+	rows, err := config.DB.Query(query, args...)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying account merges", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	merges := []model.AccountMerge{}
+	for rows.Next() {
+		var m model.AccountMerge
+		var moved []byte
+		if err := rows.Scan(&m.ID, &m.UUID, &m.AdminID, &m.SourceUserID, &m.TargetUserID, &m.SourceEmail,
+			&m.TargetEmail, &m.Reason, &m.DryRun, &moved, &m.MergedAt); err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning account merge row", "error", err)
+			continue
+		}
+		if err := json.Unmarshal(moved, &m.Moved); err != nil {
+			slog.ErrorContext(r.Context(), "Error decoding moved rows of account merge", "id", m.ID, "error", err)
+		}
+		merges = append(merges, m)
+	}
+
+	pages := (total + limit - 1) / limit
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"merges": merges,
+		"pagination": model.Pagination{
+			Page:    page,
+			Limit:   limit,
+			Total:   total,
+			Pages:   pages,
+			HasNext: page < pages,
+			HasPrev: page > 1,
+		},
+	})
+}
@@ -0,0 +1,842 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/accounting"
+	"app/internal/auth"
+	"app/internal/model"
+	"app/internal/vault"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// accountingSyncBatchSize caps the transactions pushed per sync request
+const accountingSyncBatchSize = 100
+
+// spendReceiptColumns selects a captured transaction as a receipt
+const spendReceiptColumns = `
+	t.id, t.uuid, j.id, j.title, COALESCE(j.category, 'other'), w.name,
+	COALESCE(t.capture_amount, t.amount), COALESCE(t.platform_fee, 0), COALESCE(t.currency, 'USD'),
+	t.payment_method, t.last_four, t.captured_at, t.refunded_at, t.refund_amount
+`
+
+// spendReceiptFrom joins transactions to their job and worker
+const spendReceiptFrom = `
+	FROM transactions t
+	JOIN jobs j ON j.id = t.job_id
+	LEFT JOIN people w ON w.id = t.gig_worker_id
+	WHERE t.captured_at IS NOT NULL
+`
+
+// scanSpendReceipt scans a row selected with spendReceiptColumns
+func scanSpendReceipt(row rowScanner) (int, *model.SpendReceipt, error) {
+	var id int
+	var receipt model.SpendReceipt
+	var workerName, paymentMethod, lastFour sql.NullString
+	var refundedAt sql.NullTime
+	var refundAmount sql.NullFloat64
+
+	err := row.Scan(
+		&id, &receipt.TransactionUUID, &receipt.JobID, &receipt.JobTitle, &receipt.JobCategory, &workerName,
+		&receipt.Amount, &receipt.PlatformFee, &receipt.Currency,
+		&paymentMethod, &lastFour, &receipt.CapturedAt, &refundedAt, &refundAmount,
+	)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	receipt.WorkerName = stringPtrFromNull(workerName)
+	receipt.PaymentMethod = stringPtrFromNull(paymentMethod)
+	receipt.LastFour = stringPtrFromNull(lastFour)
+	receipt.RefundedAt = timePtrFromNull(refundedAt)
+	receipt.RefundAmount = float64PtrFromNull(refundAmount)
+	return id, &receipt, nil
+}
+
+// ==============================================
+// RECEIPTS AND SPEND EXPORT
+// ==============================================
+
+// GetTransactionReceipt returns the itemized receipt for one of the consumer's captured
+// payments. With format=pdf it downloads the PDF instead, which is the consumer's
+// receipt or the worker's earnings statement.
+func GetTransactionReceipt(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	transactionID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid transaction ID format")
+		return
+	}
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "json":
+	case "pdf":
+		respondTransactionDocument(w, r, userID, transactionID)
+		return
+	default:
+		RespondWithValidationError(w, &ValidationError{Field: "format", Message: "must be json or pdf", Value: format})
+		return
+	}
+
+	_, receipt, err := scanSpendReceipt(config.DB.QueryRow(
+		`SELECT `+spendReceiptColumns+spendReceiptFrom+` AND t.id = $1 AND t.consumer_id = $2`,
+		transactionID, userID,
+	))
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Receipt not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting receipt", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	receipt.LineItems, err = receiptLineItems(transactionID, receipt)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting receipt line items", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, receipt)
+}
+
+// receiptLineItems itemizes a capture into the service charge, approved parts and reimbursed expenses
+func receiptLineItems(transactionID int, receipt *model.SpendReceipt) ([]model.ReceiptLineItem, error) {
+	rows, err := config.DB.Query(`
+		SELECT id, 'materials', item_name, quantity, unit_price, total_amount, created_at
+		FROM job_parts_requests WHERE transaction_id = $1
+		UNION ALL
+		SELECT id, 'expense', description, 1, amount, amount, incurred_at
+		FROM job_expenses WHERE transaction_id = $1
+		ORDER BY 2 DESC, 7
+	`, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var extras []model.ReceiptLineItem
+	var extrasTotal float64
+	for rows.Next() {
+		var item model.ReceiptLineItem
+		var id int
+		var createdAt time.Time
+		if err := rows.Scan(&id, &item.Type, &item.Description, &item.Quantity, &item.UnitPrice, &item.Amount, &createdAt); err != nil {
+			return nil, err
+		}
+		item.ID = &id
+		extras = append(extras, item)
+		extrasTotal += item.Amount
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	service := math.Round((receipt.Amount-extrasTotal)*100) / 100
+	items := []model.ReceiptLineItem{{
+		Type:        model.ReceiptLineService,
+		Description: receipt.JobTitle,
+		Quantity:    1,
+		UnitPrice:   service,
+		Amount:      service,
+	}}
+	return append(items, extras...), nil
+}
+
+// ExportSpend downloads the consumer's captured payments as CSV for a date range
+func ExportSpend(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	to := appFrom(r.Context()).Clock.Now()
+	from := to.AddDate(0, -12, 0)
+	if v := r.URL.Query().Get("from"); v != "" {
+		parsed, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			RespondWithValidationError(w, &ValidationError{Field: "from", Message: "must be a date in YYYY-MM-DD format", Value: v})
+			return
+		}
+		from = parsed
+	}
+	if v := r.URL.Query().Get("to"); v != "" {
+		parsed, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			RespondWithValidationError(w, &ValidationError{Field: "to", Message: "must be a date in YYYY-MM-DD format", Value: v})
+			return
+		}
+		to = parsed.AddDate(0, 0, 1)
+	}
+
+	rows, err := config.DB.Query(
+		`SELECT `+spendReceiptColumns+spendReceiptFrom+`
+		  AND t.consumer_id = $1 AND t.captured_at >= $2 AND t.captured_at < $3
+		ORDER BY t.captured_at`,
+		userID, from, to,
+	)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error exporting spend", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=gigco-spend-%s-to-%s.csv",
+		from.Format("2006-01-02"), to.AddDate(0, 0, -1).Format("2006-01-02")))
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"date", "reference", "job_id", "job_title", "category", "expense_category", "worker", "amount", "platform_fee", "refund_amount", "currency"})
+	for rows.Next() {
+		_, receipt, err := scanSpendReceipt(rows)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning spend row", "error", err)
+			continue
+		}
+
+		worker := ""
+		if receipt.WorkerName != nil {
+			worker = *receipt.WorkerName
+		}
+		refund := ""
+		if receipt.RefundAmount != nil {
+			refund = fmt.Sprintf("%.2f", *receipt.RefundAmount)
+		}
+
+		writer.Write([]string{
+			receipt.CapturedAt.Format("2006-01-02"),
+			receipt.TransactionUUID,
+			strconv.Itoa(receipt.JobID),
+			receipt.JobTitle,
+			receipt.JobCategory,
+			accounting.DefaultExpenseCategory(receipt.JobCategory),
+			worker,
+			fmt.Sprintf("%.2f", receipt.Amount),
+			fmt.Sprintf("%.2f", receipt.PlatformFee),
+			refund,
+			receipt.Currency,
+		})
+	}
+	writer.Flush()
+}
+
+// ==============================================
+// ACCOUNTING CONNECTIONS (QUICKBOOKS / XERO)
+// ==============================================
+
+// ConnectAccounting starts the OAuth flow for an accounting provider
+func ConnectAccounting(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	providerName := chi.URLParam(r, "provider")
+	provider, err := accounting.NewProvider(providerName, appFrom(r.Context()).Clock)
+	if err == accounting.ErrNotConfigured {
+		RespondWithError(w, http.StatusServiceUnavailable, "Accounting provider is not available")
+		return
+	}
+	if err != nil {
+		RespondWithError(w, http.StatusNotFound, "Unsupported accounting provider")
+		return
+	}
+
+	state, err := auth.GenerateOAuthState()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to generate OAuth state", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	_, err = config.DB.Exec(`
+		INSERT INTO accounting_oauth_states (state, user_id, provider, expires_at)
+		VALUES ($1, $2, $3, NOW() + INTERVAL '15 minutes')
+	`, state, userID, providerName)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error saving OAuth state", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"authorization_url": provider.AuthURL(state),
+	})
+}
+
+// AccountingOAuthCallback completes the OAuth flow and stores the sealed tokens.
+// It is public because the provider redirects the browser here; the state ties it to the user.
+func AccountingOAuthCallback(w http.ResponseWriter, r *http.Request) {
+	providerName := chi.URLParam(r, "provider")
+	query := r.URL.Query()
+
+	appBaseURL := os.Getenv("APP_BASE_URL")
+	if appBaseURL == "" {
+		appBaseURL = "https://app.gigco.com"
+	}
+	redirect := func(status string) {
+		http.Redirect(w, r, fmt.Sprintf("%s/settings/accounting?provider=%s&status=%s", appBaseURL, providerName, status), http.StatusFound)
+	}
+
+	if query.Get("error") != "" || query.Get("code") == "" {
+		redirect("cancelled")
+		return
+	}
+
+	var userID int
+	err := config.DB.QueryRow(`
+		DELETE FROM accounting_oauth_states
+		WHERE state = $1 AND provider = $2 AND expires_at > NOW()
+		RETURNING user_id
+	`, query.Get("state"), providerName).Scan(&userID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			slog.ErrorContext(r.Context(), "Database error validating OAuth state", "error", err)
+		}
+		redirect("invalid_state")
+		return
+	}
+
+	provider, err := accounting.NewProvider(providerName, appFrom(r.Context()).Clock)
+	if err != nil {
+		redirect("unavailable")
+		return
+	}
+
+	token, err := provider.ExchangeCode(r.Context(), query.Get("code"), query.Get("realmId"))
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to complete OAuth for user", "provider_name", providerName, "user_id", userID, "error", err)
+		redirect("failed")
+		return
+	}
+
+	if _, err := saveAccountingToken(userID, providerName, token); err != nil {
+		slog.ErrorContext(r.Context(), "Failed to save connection for user", "provider_name", providerName, "user_id", userID, "error", err)
+		redirect("failed")
+		return
+	}
+
+	slog.InfoContext(r.Context(), "User connected accounting provider", "user_id", userID, "provider", providerName, "tenant_id", token.TenantID)
+	redirect("connected")
+}
+
+// saveAccountingToken seals and upserts a connection's OAuth token
+func saveAccountingToken(userID int, providerName string, token *accounting.Token) (int, error) {
+	v, err := vault.NewVaultFromEnv()
+	if err != nil {
+		return 0, err
+	}
+
+	sealed, err := v.SealJSON(token, vault.RecordAAD("accounting_connections:"+providerName, userID))
+	if err != nil {
+		return 0, err
+	}
+
+	var connectionID int
+	err = config.DB.QueryRow(`
+		INSERT INTO accounting_connections (user_id, provider, tenant_id, sealed_token, key_id)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, provider) DO UPDATE SET
+			tenant_id = EXCLUDED.tenant_id,
+			sealed_token = EXCLUDED.sealed_token,
+			key_id = EXCLUDED.key_id,
+			is_active = true
+		RETURNING id
+	`, userID, providerName, token.TenantID, sealed, v.KeyID()).Scan(&connectionID)
+	return connectionID, err
+}
+
+// loadAccountingToken opens a connection's token, refreshing and re-saving it if expired
+func loadAccountingToken(ctx context.Context, userID int, provider accounting.Provider, sealed string) (*accounting.Token, error) {
+	v, err := vault.NewVaultFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	var token accounting.Token
+	if err := v.OpenJSON(sealed, vault.RecordAAD("accounting_connections:"+provider.Name(), userID), &token); err != nil {
+		return nil, err
+	}
+	if !token.Expired(appFrom(ctx).Clock.Now()) {
+		return &token, nil
+	}
+
+	refreshed, err := provider.Refresh(ctx, &token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh %s token: %w", provider.Name(), err)
+	}
+	if _, err := saveAccountingToken(userID, provider.Name(), refreshed); err != nil {
+		return nil, err
+	}
+	return refreshed, nil
+}
+
+// lockAccountingSync takes a connection's sync lock, an advisory lock held on a
+// reserved database connection until unlock is called. It reports false without
+// waiting if another sync holds the lock.
+func lockAccountingSync(ctx context.Context, connectionID int) (unlock func(), locked bool, err error) {
+	dbConn, err := config.DB.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	scope := fmt.Sprintf("accounting_sync:%d", connectionID)
+	if err := dbConn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, scope).Scan(&locked); err != nil || !locked {
+		dbConn.Close()
+		return nil, false, err
+	}
+
+	return func() {
+		if _, err := dbConn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock(hashtext($1))`, scope); err != nil {
+			slog.ErrorContext(ctx, "Failed to release accounting sync lock", "connection_id", connectionID, "error", err)
+		}
+		dbConn.Close()
+	}, true, nil
+}
+
+// getAccountingConnection loads one of the user's connections by ID
+func getAccountingConnection(w http.ResponseWriter, r *http.Request, userID int) (*model.AccountingConnection, string, bool) {
+	connectionID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid connection ID format")
+		return nil, "", false
+	}
+
+	var conn model.AccountingConnection
+	var sealed string
+	var lastSyncedAt sql.NullTime
+	var lastSyncError sql.NullString
+	err = config.DB.QueryRow(`
+		SELECT id, uuid, provider, tenant_id, is_active, connected_at, last_synced_at, last_sync_error, sealed_token
+		FROM accounting_connections
+		WHERE id = $1 AND user_id = $2
+	`, connectionID, userID).Scan(
+		&conn.ID, &conn.UUID, &conn.Provider, &conn.TenantID, &conn.IsActive,
+		&conn.ConnectedAt, &lastSyncedAt, &lastSyncError, &sealed,
+	)
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Accounting connection not found")
+		return nil, "", false
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting accounting connection", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return nil, "", false
+	}
+
+	conn.LastSyncedAt = timePtrFromNull(lastSyncedAt)
+	conn.LastSyncError = stringPtrFromNull(lastSyncError)
+	return &conn, sealed, true
+}
+
+// GetAccountingConnections lists the consumer's accounting connections
+func GetAccountingConnections(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	rows, err := config.DB.Query(`
+		SELECT id, uuid, provider, tenant_id, is_active, connected_at, last_synced_at, last_sync_error
+		FROM accounting_connections
+		WHERE user_id = $1
+		ORDER BY connected_at
+	`, userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying accounting connections", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	connections := []model.AccountingConnection{}
+	for rows.Next() {
+		var conn model.AccountingConnection
+		var lastSyncedAt sql.NullTime
+		var lastSyncError sql.NullString
+		if err := rows.Scan(&conn.ID, &conn.UUID, &conn.Provider, &conn.TenantID, &conn.IsActive,
+			&conn.ConnectedAt, &lastSyncedAt, &lastSyncError); err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning accounting connection", "error", err)
+			continue
+		}
+		conn.LastSyncedAt = timePtrFromNull(lastSyncedAt)
+		conn.LastSyncError = stringPtrFromNull(lastSyncError)
+		connections = append(connections, conn)
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"connections": connections,
+	})
+}
+
+// DisconnectAccounting removes a connection and its stored tokens
+func DisconnectAccounting(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	conn, _, ok := getAccountingConnection(w, r, userID)
+	if !ok {
+		return
+	}
+
+	if _, err := config.DB.Exec(`DELETE FROM accounting_connections WHERE id = $1`, conn.ID); err != nil {
+		slog.ErrorContext(r.Context(), "Database error deleting accounting connection", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to disconnect")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Accounting connection removed",
+	})
+}
+
+// accountingMappings returns the effective account for each job category the user has spend in
+func accountingMappings(conn *model.AccountingConnection, userID int) ([]model.AccountingCategoryMapping, error) {
+	rows, err := config.DB.Query(`
+		SELECT c.category, m.account_ref
+		FROM (
+			SELECT DISTINCT COALESCE(j.category, 'other') AS category
+			FROM transactions t JOIN jobs j ON j.id = t.job_id
+			WHERE t.consumer_id = $2
+			UNION
+			SELECT job_category FROM accounting_category_mappings WHERE connection_id = $1
+		) c
+		LEFT JOIN accounting_category_mappings m ON m.connection_id = $1 AND m.job_category = c.category
+		ORDER BY c.category
+	`, conn.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mappings := []model.AccountingCategoryMapping{}
+	for rows.Next() {
+		var category string
+		var accountRef sql.NullString
+		if err := rows.Scan(&category, &accountRef); err != nil {
+			return nil, err
+		}
+		mapping := model.AccountingCategoryMapping{JobCategory: category, AccountRef: accountRef.String}
+		if !accountRef.Valid {
+			mapping.AccountRef = accounting.DefaultAccountRef(conn.Provider, category)
+			mapping.IsDefault = true
+		}
+		mappings = append(mappings, mapping)
+	}
+	return mappings, rows.Err()
+}
+
+// GetAccountingMappings returns the expense account used for each job category
+func GetAccountingMappings(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	conn, _, ok := getAccountingConnection(w, r, userID)
+	if !ok {
+		return
+	}
+
+	mappings, err := accountingMappings(conn, userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting accounting mappings", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"mappings": mappings,
+	})
+}
+
+// UpdateAccountingMappings sets the expense account for job categories.
+// An empty account_ref reverts the category to the default account.
+func UpdateAccountingMappings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	conn, _, ok := getAccountingConnection(w, r, userID)
+	if !ok {
+		return
+	}
+
+	var req model.AccountingMappingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	for _, m := range req.Mappings {
+		if strings.TrimSpace(m.JobCategory) == "" {
+			RespondWithValidationError(w, &ValidationError{Field: "mappings.job_category", Message: "is required"})
+			return
+		}
+		if len(m.AccountRef) > 255 {
+			RespondWithValidationError(w, &ValidationError{Field: "mappings.account_ref", Message: "must not exceed 255 characters", Value: m.JobCategory})
+			return
+		}
+	}
+
+	tx, err := config.DB.Begin()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error starting transaction", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer tx.Rollback()
+
+	for _, m := range req.Mappings {
+		category := strings.TrimSpace(m.JobCategory)
+		accountRef := strings.TrimSpace(m.AccountRef)
+		if accountRef == "" {
+			_, err = tx.Exec(`DELETE FROM accounting_category_mappings WHERE connection_id = $1 AND job_category = $2`, conn.ID, category)
+		} else {
+			_, err = tx.Exec(`
+				INSERT INTO accounting_category_mappings (connection_id, job_category, account_ref)
+				VALUES ($1, $2, $3)
+				ON CONFLICT (connection_id, job_category) DO UPDATE SET account_ref = EXCLUDED.account_ref
+			`, conn.ID, category, accountRef)
+		}
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Database error saving accounting mapping", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Failed to save mappings")
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(r.Context(), "Database error committing accounting mappings", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to save mappings")
+		return
+	}
+
+	mappings, err := accountingMappings(conn, userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting accounting mappings", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"mappings": mappings,
+	})
+}
+
+// SyncAccounting pushes captured payments not yet exported to the connected ledger.
+// Failed transactions are retried on the next sync.
+func SyncAccounting(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	conn, sealed, ok := getAccountingConnection(w, r, userID)
+	if !ok {
+		return
+	}
+	if !conn.IsActive {
+		RespondWithError(w, http.StatusConflict, "Accounting connection is inactive; reconnect to sync")
+		return
+	}
+
+	provider, err := accounting.NewProvider(conn.Provider, appFrom(r.Context()).Clock)
+	if err != nil {
+		RespondWithError(w, http.StatusServiceUnavailable, "Accounting provider is not available")
+		return
+	}
+
+	ctx := r.Context()
+
+	// One sync per connection at a time: concurrent syncs would push the same expenses
+	// twice, and would both refresh the token, the second with a refresh token the
+	// first already spent
+	unlock, locked, err := lockAccountingSync(ctx, conn.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error locking accounting connection", "connection_id", conn.ID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if !locked {
+		RespondWithError(w, http.StatusConflict, "A sync is already running for this connection")
+		return
+	}
+	defer unlock()
+
+	// Reload the token, which a sync that just finished may have refreshed
+	err = config.DB.QueryRow(`SELECT is_active, sealed_token FROM accounting_connections WHERE id = $1`, conn.ID).Scan(&conn.IsActive, &sealed)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting accounting connection", "connection_id", conn.ID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if !conn.IsActive {
+		RespondWithError(w, http.StatusConflict, "Accounting connection is inactive; reconnect to sync")
+		return
+	}
+
+	token, err := loadAccountingToken(ctx, userID, provider, sealed)
+	if errors.Is(err, accounting.ErrInvalidGrant) {
+		slog.WarnContext(r.Context(), "Accounting authorization revoked for connection", "provider", conn.Provider, "connection_id", conn.ID, "error", err)
+		if _, err := config.DB.Exec(`UPDATE accounting_connections SET is_active = false, last_sync_error = $1 WHERE id = $2`,
+			"authorization expired; reconnect required", conn.ID); err != nil {
+			slog.ErrorContext(r.Context(), "Database error deactivating accounting connection", "connection_id", conn.ID, "error", err)
+		}
+		RespondWithError(w, http.StatusConflict, "Accounting authorization expired; reconnect to sync")
+		return
+	}
+	if err != nil {
+		// Outages and misconfiguration leave the connection as it is, to retry later
+		slog.ErrorContext(r.Context(), "Failed to load token for connection", "provider", conn.Provider, "connection_id", conn.ID, "error", err)
+		RespondWithError(w, http.StatusServiceUnavailable, "Accounting provider is not available; try again later")
+		return
+	}
+
+	mappings, err := accountingMappings(conn, userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting accounting mappings", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	accountFor := make(map[string]string, len(mappings))
+	for _, m := range mappings {
+		accountFor[m.JobCategory] = m.AccountRef
+	}
+
+	rows, err := config.DB.Query(
+		`SELECT `+spendReceiptColumns+spendReceiptFrom+`
+		  AND t.consumer_id = $1
+		  AND t.refunded_at IS NULL
+		  AND NOT EXISTS (
+			SELECT 1 FROM accounting_sync_log s
+			WHERE s.connection_id = $2 AND s.transaction_id = t.id AND s.status = 'synced'
+		  )
+		ORDER BY t.captured_at
+		LIMIT $3`,
+		userID, conn.ID, accountingSyncBatchSize,
+	)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying transactions to sync", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	type pending struct {
+		id      int
+		receipt *model.SpendReceipt
+	}
+	var toSync []pending
+	for rows.Next() {
+		id, receipt, err := scanSpendReceipt(rows)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning transaction to sync", "error", err)
+			continue
+		}
+		toSync = append(toSync, pending{id, receipt})
+	}
+	rows.Close()
+
+	var result model.AccountingSyncResult
+	for _, p := range toSync {
+		accountRef := accountFor[p.receipt.JobCategory]
+		if accountRef == "" {
+			accountRef = accounting.DefaultAccountRef(conn.Provider, p.receipt.JobCategory)
+		}
+
+		vendor := "GigCo"
+		if p.receipt.WorkerName != nil {
+			vendor = "GigCo - " + *p.receipt.WorkerName
+		}
+
+		externalID, err := provider.PushExpense(ctx, token, accounting.Expense{
+			Reference:   p.receipt.TransactionUUID,
+			Date:        p.receipt.CapturedAt,
+			Amount:      p.receipt.Amount,
+			Currency:    p.receipt.Currency,
+			Description: fmt.Sprintf("GigCo job #%d: %s", p.receipt.JobID, p.receipt.JobTitle),
+			Vendor:      vendor,
+			AccountRef:  accountRef,
+		})
+
+		status, errMsg := "synced", sql.NullString{}
+		if err != nil {
+			status = "failed"
+			errMsg = nullString(err.Error())
+			result.Failed++
+			if len(result.Errors) < 5 {
+				result.Errors = append(result.Errors, fmt.Sprintf("job %d: %v", p.receipt.JobID, err))
+			}
+		} else {
+			result.Synced++
+		}
+
+		_, dbErr := config.DB.Exec(`
+			INSERT INTO accounting_sync_log (connection_id, transaction_id, external_id, status, error_message, synced_at)
+			VALUES ($1, $2, $3, $4, $5, NOW())
+			ON CONFLICT (connection_id, transaction_id) DO UPDATE SET
+				external_id = EXCLUDED.external_id,
+				status = EXCLUDED.status,
+				error_message = EXCLUDED.error_message,
+				synced_at = EXCLUDED.synced_at
+		`, conn.ID, p.id, nullString(externalID), status, errMsg)
+		if dbErr != nil {
+			slog.ErrorContext(r.Context(), "Database error recording accounting sync for transaction", "id", p.id, "error", dbErr)
+		}
+	}
+
+	var lastError sql.NullString
+	if len(result.Errors) > 0 {
+		lastError = nullString(result.Errors[0])
+	}
+	if _, err := config.DB.Exec(`UPDATE accounting_connections SET last_synced_at = NOW(), last_sync_error = $1 WHERE id = $2`, lastError, conn.ID); err != nil {
+		slog.ErrorContext(r.Context(), "Database error updating accounting connection", "connection_id", conn.ID, "error", err)
+	}
+
+	RespondWithJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,946 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/model"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lib/pq"
+)
+
+// adminExportLimit caps the rows an export (format=csv or format=ndjson) returns
+const adminExportLimit = 10000
+
+// adminQuery collects the filters of an admin list endpoint as SQL conditions
+type adminQuery struct {
+	where []string
+	args  []any
+}
+
+// add appends a condition; each ? in cond is replaced by the next placeholder
+func (q *adminQuery) add(cond string, values ...any) {
+	for _, v := range values {
+		q.args = append(q.args, v)
+		cond = strings.Replace(cond, "?", fmt.Sprintf("$%d", len(q.args)), 1)
+	}
+	q.where = append(q.where, cond)
+}
+
+func (q *adminQuery) clause() string {
+	if len(q.where) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(q.where, " AND ")
+}
+
+// page appends LIMIT and OFFSET placeholders, returning the clause and its args
+func (q *adminQuery) page(limit, offset int) (string, []any) {
+	n := len(q.args)
+	return fmt.Sprintf(" LIMIT $%d OFFSET $%d", n+1, n+2), append(append([]any{}, q.args...), limit, offset)
+}
+
+// adminListing is the paging of an admin list request. Exports stream the first
+// adminExportLimit rows instead of a page.
+type adminListing struct {
+	page   int
+	limit  int
+	export string // exportCSV or exportNDJSON; "" for a JSON page
+}
+
+func parseAdminListing(w http.ResponseWriter, r *http.Request) (adminListing, bool) {
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "json":
+	case exportCSV, exportNDJSON:
+		return adminListing{page: 1, limit: adminExportLimit, export: format}, true
+	default:
+		RespondWithValidationError(w, &ValidationError{Field: "format", Message: "must be json, csv or ndjson", Value: format})
+		return adminListing{}, false
+	}
+
+	page, err := ParseIntParam(r, "page", 1, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return adminListing{}, false
+	}
+	limit, err := ParseIntParam(r, "limit", DefaultPageSize, MinPageSize, MaxPageSize)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return adminListing{}, false
+	}
+	return adminListing{page: page, limit: limit}, true
+}
+
+func (l adminListing) pagination(total int) model.Pagination {
+	pages := (total + l.limit - 1) / l.limit
+	return model.Pagination{
+		Page:    l.page,
+		Limit:   l.limit,
+		Total:   total,
+		Pages:   pages,
+		HasNext: l.page < pages,
+		HasPrev: l.page > 1,
+	}
+}
+
+// writeAdminCSV sends records as a CSV download named after the export
+func writeAdminCSV(w http.ResponseWriter, r *http.Request, name string, header []string, records [][]string) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=gigco-%s-%s.csv", name, appFrom(r.Context()).Clock.Now().Format("2006-01-02")))
+
+	writer := csv.NewWriter(w)
+	writer.Write(header)
+	writer.WriteAll(records)
+}
+
+// addDateRange filters column to [from, to] from the from/to query parameters. A
+// date-only to includes the whole of that day.
+func addDateRange(w http.ResponseWriter, r *http.Request, q *adminQuery, column string) bool {
+	from, err := ParseDateParam(r, "from")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return false
+	}
+	to, err := ParseDateParam(r, "to")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return false
+	}
+	if from != nil {
+		q.add(column+" >= ?", *from)
+	}
+	if to != nil && len(r.URL.Query().Get("to")) == len(time.DateOnly) {
+		q.add(column+" < ?", to.AddDate(0, 0, 1))
+	} else if to != nil {
+		q.add(column+" <= ?", *to)
+	}
+	return true
+}
+
+// addIntFilter filters column by an integer query parameter when it is given
+func addIntFilter(w http.ResponseWriter, r *http.Request, q *adminQuery, param, cond string) bool {
+	if r.URL.Query().Get(param) == "" {
+		return true
+	}
+	value, err := ParseIntParam(r, param, 0, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return false
+	}
+	values := make([]any, strings.Count(cond, "?"))
+	for i := range values {
+		values[i] = value
+	}
+	q.add(cond, values...)
+	return true
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func formatOptionalFloat(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.2f", *f)
+}
+
+func formatOptionalString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// ==============================================
+// ADMIN: USER MANAGEMENT
+// ==============================================
+
+// AdminGetUsers lists and searches users with their activity. Updates and deactivation
+// go through PUT and DELETE /api/v1/users/{id}.
+func AdminGetUsers(w http.ResponseWriter, r *http.Request) {
+	listing, ok := parseAdminListing(w, r)
+	if !ok {
+		return
+	}
+
+	var q adminQuery
+	if role := r.URL.Query().Get("role"); role != "" {
+		switch role {
+		case "admin", "consumer", "gig_worker":
+		default:
+			RespondWithValidationError(w, &ValidationError{Field: "role", Message: "must be admin, consumer or gig_worker", Value: role})
+			return
+		}
+		q.add("p.role::text = ?", role)
+	}
+	isActive, provided, err := ParseBoolParam(r, "is_active")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	if provided {
+		q.add("COALESCE(p.is_active, true) = ?", isActive)
+	}
+	if search := strings.TrimSpace(r.URL.Query().Get("q")); search != "" {
+		q.add("(p.name ILIKE '%' || ? || '%' OR p.email ILIKE '%' || ? || '%')", search, search)
+	}
+	if !addDateRange(w, r, &q, "p.created_at") {
+		return
+	}
+
+	// Exports have no pagination, so skip the count and start the download sooner
+	var total int
+	if listing.export == "" {
+		if err := config.DB.QueryRow("SELECT COUNT(*) FROM people p"+q.clause(), q.args...).Scan(&total); err != nil {
+			slog.ErrorContext(r.Context(), "Database error counting users", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+	}
+
+	limitClause, args := q.page(listing.limit, (listing.page-1)*listing.limit)
+	rows, err := config.DB.Query(`
+		SELECT p.id, p.uuid, p.name, p.email, p.role, COALESCE(p.is_active, true), COALESCE(p.email_verified, false),
+		       (SELECT COUNT(*) FROM jobs j WHERE j.consumer_id = p.id),
+		       (SELECT COUNT(*) FROM jobs j WHERE j.gig_worker_id = p.id),
+		       (SELECT MAX(s.last_used_at) FROM user_sessions s WHERE s.user_id = p.id),
+		       p.created_at
+		FROM people p`+q.clause()+`
+		ORDER BY p.created_at DESC, p.id DESC`+limitClause, args...)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying users", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	var export *rowExport
+	if listing.export != "" {
+		export = listing.startExport(w, r, "users", []string{"id", "name", "email", "role", "is_active", "email_verified",
+			"jobs_posted", "jobs_worked", "last_active_at", "created_at"})
+		defer export.finish()
+	}
+
+	users := []model.AdminUser{}
+	for rows.Next() {
+		var u model.AdminUser
+		var lastActive sql.NullTime
+		if err := rows.Scan(&u.ID, &u.UUID, &u.Name, &u.Email, &u.Role, &u.IsActive, &u.EmailVerified,
+			&u.JobsPosted, &u.JobsWorked, &lastActive, &u.CreatedAt); err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning user row", "error", err)
+			continue
+		}
+		u.LastActiveAt = timePtrFromNull(lastActive)
+		if export != nil {
+			if err := export.write(u, func() []string {
+				return []string{
+					strconv.Itoa(u.ID), u.Name, u.Email, u.Role, strconv.FormatBool(u.IsActive), strconv.FormatBool(u.EmailVerified),
+					strconv.Itoa(u.JobsPosted), strconv.Itoa(u.JobsWorked), formatOptionalTime(u.LastActiveAt), u.CreatedAt.Format(time.RFC3339),
+				}
+			}); err != nil {
+				return
+			}
+			continue
+		}
+		users = append(users, u)
+	}
+	if export != nil {
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"users":      users,
+		"pagination": listing.pagination(total),
+	})
+}
+
+// ==============================================
+// ADMIN: JOB OVERSIGHT
+// ==============================================
+
+// AdminGetJobs lists jobs across all consumers and workers, newest first
+func AdminGetJobs(w http.ResponseWriter, r *http.Request) {
+	listing, ok := parseAdminListing(w, r)
+	if !ok {
+		return
+	}
+
+	var q adminQuery
+	if status := r.URL.Query().Get("status"); status != "" {
+		q.add("j.status::text = ?", status)
+	}
+	if category := r.URL.Query().Get("category"); category != "" {
+		q.add("j.category = ?", category)
+	}
+	if search := strings.TrimSpace(r.URL.Query().Get("q")); search != "" {
+		q.add("j.title ILIKE '%' || ? || '%'", search)
+	}
+	if !addIntFilter(w, r, &q, "consumer_id", "j.consumer_id = ?") ||
+		!addIntFilter(w, r, &q, "worker_id", "j.gig_worker_id = ?") ||
+		!addDateRange(w, r, &q, "j.created_at") {
+		return
+	}
+
+	// Exports have no pagination, so skip the count and start the download sooner
+	var total int
+	if listing.export == "" {
+		if err := config.DB.QueryRow("SELECT COUNT(*) FROM jobs j"+q.clause(), q.args...).Scan(&total); err != nil {
+			slog.ErrorContext(r.Context(), "Database error counting jobs", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+	}
+
+	limitClause, args := q.page(listing.limit, (listing.page-1)*listing.limit)
+	rows, err := config.DB.Query(`
+		SELECT j.id, j.uuid, j.title, j.category, j.status, j.consumer_id, c.name, j.gig_worker_id, wk.name,
+		       j.total_pay, j.scheduled_start, j.created_at, j.updated_at
+		FROM jobs j
+		JOIN people c ON c.id = j.consumer_id
+		LEFT JOIN people wk ON wk.id = j.gig_worker_id`+q.clause()+`
+		ORDER BY j.created_at DESC, j.id DESC`+limitClause, args...)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying jobs", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	var export *rowExport
+	if listing.export != "" {
+		export = listing.startExport(w, r, "jobs", []string{"id", "title", "category", "status", "consumer_id", "consumer_name",
+			"worker_id", "worker_name", "total_pay", "scheduled_start", "created_at"})
+		defer export.finish()
+	}
+
+	jobs := []model.AdminJob{}
+	for rows.Next() {
+		var j model.AdminJob
+		var category, workerName sql.NullString
+		var workerID sql.NullInt64
+		var totalPay sql.NullFloat64
+		var scheduledStart sql.NullTime
+		if err := rows.Scan(&j.ID, &j.UUID, &j.Title, &category, &j.Status, &j.ConsumerID, &j.ConsumerName,
+			&workerID, &workerName, &totalPay, &scheduledStart, &j.CreatedAt, &j.UpdatedAt); err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning job row", "error", err)
+			continue
+		}
+		j.Category = stringPtrFromNull(category)
+		j.WorkerID = intPtrFromNull(workerID)
+		j.WorkerName = stringPtrFromNull(workerName)
+		j.TotalPay = float64PtrFromNull(totalPay)
+		j.ScheduledStart = timePtrFromNull(scheduledStart)
+		if export != nil {
+			if err := export.write(j, func() []string {
+				workerID := ""
+				if j.WorkerID != nil {
+					workerID = strconv.Itoa(*j.WorkerID)
+				}
+				return []string{
+					strconv.Itoa(j.ID), j.Title, formatOptionalString(j.Category), j.Status, strconv.Itoa(j.ConsumerID), j.ConsumerName,
+					workerID, formatOptionalString(j.WorkerName), formatOptionalFloat(j.TotalPay), formatOptionalTime(j.ScheduledStart), j.CreatedAt.Format(time.RFC3339),
+				}
+			}); err != nil {
+				return
+			}
+			continue
+		}
+		jobs = append(jobs, j)
+	}
+	if export != nil {
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"jobs":       jobs,
+		"pagination": listing.pagination(total),
+	})
+}
+
+// ==============================================
+// ADMIN: TRANSACTION SEARCH
+// ==============================================
+
+// AdminGetTransactions searches payments by status, party, job, amount and date
+func AdminGetTransactions(w http.ResponseWriter, r *http.Request) {
+	listing, ok := parseAdminListing(w, r)
+	if !ok {
+		return
+	}
+
+	var q adminQuery
+	if status := r.URL.Query().Get("status"); status != "" {
+		q.add("t.status::text = ?", status)
+	}
+	if provider := r.URL.Query().Get("provider"); provider != "" {
+		q.add("t.payment_provider = ?", provider)
+	}
+	if search := strings.TrimSpace(r.URL.Query().Get("q")); search != "" {
+		q.add("(t.uuid::text = ? OR t.provider_charge_id = ?)", search, search)
+	}
+	if !addIntFilter(w, r, &q, "user_id", "(t.consumer_id = ? OR t.gig_worker_id = ?)") ||
+		!addIntFilter(w, r, &q, "job_id", "t.job_id = ?") ||
+		!addDateRange(w, r, &q, "t.created_at") {
+		return
+	}
+	for _, bound := range []struct{ param, cond string }{
+		{"min_amount", "t.amount >= ?"},
+		{"max_amount", "t.amount <= ?"},
+	} {
+		v := r.URL.Query().Get(bound.param)
+		if v == "" {
+			continue
+		}
+		amount, err := strconv.ParseFloat(v, 64)
+		if err != nil || amount < 0 {
+			RespondWithValidationError(w, &ValidationError{Field: bound.param, Message: "must be a non-negative number", Value: v})
+			return
+		}
+		q.add(bound.cond, amount)
+	}
+
+	// Exports have no pagination, so skip the count and start the download sooner
+	var total int
+	if listing.export == "" {
+		if err := config.DB.QueryRow("SELECT COUNT(*) FROM transactions t"+q.clause(), q.args...).Scan(&total); err != nil {
+			slog.ErrorContext(r.Context(), "Database error counting transactions", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+	}
+
+	limitClause, args := q.page(listing.limit, (listing.page-1)*listing.limit)
+	rows, err := config.DB.Query(`
+		SELECT t.id, t.uuid, t.job_id, j.title, t.consumer_id, c.name, t.gig_worker_id, wk.name,
+		       COALESCE(t.capture_amount, t.amount), COALESCE(t.platform_fee, 0), t.refund_amount,
+		       COALESCE(t.currency, 'USD'), t.status, t.payment_provider, t.captured_at, t.created_at
+		FROM transactions t
+		JOIN jobs j ON j.id = t.job_id
+		JOIN people c ON c.id = t.consumer_id
+		JOIN people wk ON wk.id = t.gig_worker_id`+q.clause()+`
+		ORDER BY t.created_at DESC, t.id DESC`+limitClause, args...)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying transactions", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	var export *rowExport
+	if listing.export != "" {
+		export = listing.startExport(w, r, "transactions", []string{"id", "reference", "job_id", "job_title", "consumer", "worker",
+			"amount", "platform_fee", "refund_amount", "currency", "status", "provider", "captured_at", "created_at"})
+		defer export.finish()
+	}
+
+	transactions := []model.AdminTransaction{}
+	for rows.Next() {
+		var t model.AdminTransaction
+		var refundAmount sql.NullFloat64
+		var capturedAt sql.NullTime
+		if err := rows.Scan(&t.ID, &t.UUID, &t.JobID, &t.JobTitle, &t.ConsumerID, &t.ConsumerName, &t.WorkerID, &t.WorkerName,
+			&t.Amount, &t.PlatformFee, &refundAmount, &t.Currency, &t.Status, &t.PaymentProvider, &capturedAt, &t.CreatedAt); err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning transaction row", "error", err)
+			continue
+		}
+		t.RefundAmount = float64PtrFromNull(refundAmount)
+		t.CapturedAt = timePtrFromNull(capturedAt)
+		if export != nil {
+			if err := export.write(t, func() []string {
+				return []string{
+					strconv.Itoa(t.ID), t.UUID, strconv.Itoa(t.JobID), t.JobTitle, t.ConsumerName, t.WorkerName,
+					fmt.Sprintf("%.2f", t.Amount), fmt.Sprintf("%.2f", t.PlatformFee), formatOptionalFloat(t.RefundAmount),
+					t.Currency, t.Status, t.PaymentProvider, formatOptionalTime(t.CapturedAt), t.CreatedAt.Format(time.RFC3339),
+				}
+			}); err != nil {
+				return
+			}
+			continue
+		}
+		transactions = append(transactions, t)
+	}
+	if export != nil {
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"transactions": transactions,
+		"pagination":   listing.pagination(total),
+	})
+}
+
+// AdminGetTransactionEvents returns everything recorded about a payment's provider
+// calls, oldest first, for support: each event's outcome, error code, who made the
+// request and a summary of the provider's response with card data redacted
+func AdminGetTransactionEvents(w http.ResponseWriter, r *http.Request) {
+	transactionID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid transaction ID format")
+		return
+	}
+
+	var t model.AdminTransaction
+	err = config.DB.QueryRowContext(r.Context(), `
+		SELECT id, uuid, job_id, COALESCE(capture_amount, amount), COALESCE(currency, 'USD'), status, payment_provider, created_at
+		FROM transactions WHERE id = $1
+	`, transactionID).Scan(&t.ID, &t.UUID, &t.JobID, &t.Amount, &t.Currency, &t.Status, &t.PaymentProvider, &t.CreatedAt)
+	if err == sql.ErrNoRows {
+		respondError(w, http.StatusNotFound, model.ErrCodeNotFound, "Transaction not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting transaction", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	if paymentService == nil {
+		InitPaymentService()
+	}
+	events, err := paymentService.PaymentEvents(transactionID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to get payment events for transaction", "transaction_id", transactionID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to get payment events")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"transaction": map[string]interface{}{
+			"id":               t.ID,
+			"uuid":             t.UUID,
+			"job_id":           t.JobID,
+			"amount":           t.Amount,
+			"currency":         t.Currency,
+			"status":           t.Status,
+			"payment_provider": t.PaymentProvider,
+			"created_at":       t.CreatedAt,
+		},
+		"events": events,
+	})
+}
+
+// ==============================================
+// ADMIN: QUEUES
+// ==============================================
+
+// AdminGetVerificationQueue lists worker applications in screening, oldest first
+func AdminGetVerificationQueue(w http.ResponseWriter, r *http.Request) {
+	listing, ok := parseAdminListing(w, r)
+	if !ok {
+		return
+	}
+
+	var q adminQuery
+	q.add("a.status NOT IN ('approved', 'denied')")
+	if status := r.URL.Query().Get("status"); status != "" {
+		switch status {
+		case model.ApplicationStatusSubmitted, model.ApplicationStatusDocsPending, model.ApplicationStatusBackgroundCheck:
+		default:
+			RespondWithValidationError(w, &ValidationError{
+				Field:   "status",
+				Message: "must be submitted, docs_pending or background_check",
+				Value:   status,
+			})
+			return
+		}
+		q.add("a.status = ?", status)
+	}
+
+	// Exports have no pagination, so skip the count and start the download sooner
+	var total int
+	if listing.export == "" {
+		if err := config.DB.QueryRow("SELECT COUNT(*) FROM worker_applications a"+q.clause(), q.args...).Scan(&total); err != nil {
+			slog.ErrorContext(r.Context(), "Database error counting verification queue", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+	}
+
+	limitClause, args := q.page(listing.limit, (listing.page-1)*listing.limit)
+	rows, err := config.DB.Query(`
+		SELECT `+workerApplicationColumns+`
+		FROM worker_applications a
+		JOIN people p ON p.id = a.user_id`+q.clause()+`
+		ORDER BY a.submitted_at ASC, a.id ASC`+limitClause, args...)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying verification queue", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	var export *rowExport
+	if listing.export != "" {
+		export = listing.startExport(w, r, "verification-queue", []string{"application_id", "user_id", "name", "email", "status",
+			"submitted_at", "updated_at"})
+		defer export.finish()
+	}
+
+	applications := []model.WorkerApplication{}
+	for rows.Next() {
+		app, err := scanWorkerApplication(rows)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning worker application row", "error", err)
+			continue
+		}
+		if export != nil {
+			if err := export.write(app, func() []string {
+				return []string{
+					strconv.Itoa(app.ID), strconv.Itoa(app.UserID), app.ApplicantName, app.ApplicantEmail, app.Status,
+					app.SubmittedAt.Format(time.RFC3339), app.UpdatedAt.Format(time.RFC3339),
+				}
+			}); err != nil {
+				return
+			}
+			continue
+		}
+		applications = append(applications, *app)
+	}
+	if export != nil {
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"applications": applications,
+		"pagination":   listing.pagination(total),
+	})
+}
+
+// AdminGetDisputeQueue lists unresolved disputes, oldest first
+func AdminGetDisputeQueue(w http.ResponseWriter, r *http.Request) {
+	listing, ok := parseAdminListing(w, r)
+	if !ok {
+		return
+	}
+
+	var q adminQuery
+	q.add("status IN ('open', 'under_review')")
+	unassigned, _, err := ParseBoolParam(r, "unassigned")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	if unassigned {
+		q.add("assigned_to IS NULL")
+	}
+
+	// Exports have no pagination, so skip the count and start the download sooner
+	var total int
+	if listing.export == "" {
+		if err := config.DB.QueryRow("SELECT COUNT(*) FROM job_disputes"+q.clause(), q.args...).Scan(&total); err != nil {
+			slog.ErrorContext(r.Context(), "Database error counting dispute queue", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+	}
+
+	limitClause, args := q.page(listing.limit, (listing.page-1)*listing.limit)
+	rows, err := config.DB.Query(`
+		SELECT `+disputeColumns+`,
+		       (SELECT title FROM jobs WHERE jobs.id = job_disputes.job_id),
+		       (SELECT name FROM people WHERE people.id = job_disputes.opened_by)
+		FROM job_disputes`+q.clause()+`
+		ORDER BY created_at ASC, id ASC`+limitClause, args...)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying dispute queue", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	var export *rowExport
+	if listing.export != "" {
+		export = listing.startExport(w, r, "dispute-queue", []string{"dispute_id", "job_id", "job_title", "opened_by", "reason", "status",
+			"assigned_to", "created_at"})
+		defer export.finish()
+	}
+
+	disputes := []model.AdminDispute{}
+	for rows.Next() {
+		var d model.AdminDispute
+		dispute, err := scanDispute(rows, &d.JobTitle, &d.OpenedByName)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning dispute row", "error", err)
+			continue
+		}
+		d.Dispute = *dispute
+		if export != nil {
+			if err := export.write(d, func() []string {
+				assignedTo := ""
+				if d.AssignedTo != nil {
+					assignedTo = strconv.Itoa(*d.AssignedTo)
+				}
+				return []string{
+					strconv.Itoa(d.ID), strconv.Itoa(d.JobID), d.JobTitle, d.OpenedByName, d.Reason, d.Status,
+					assignedTo, d.CreatedAt.Format(time.RFC3339),
+				}
+			}); err != nil {
+				return
+			}
+			continue
+		}
+		disputes = append(disputes, d)
+	}
+	if export != nil {
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"disputes":   disputes,
+		"pagination": listing.pagination(total),
+	})
+}
+
+// ==============================================
+// ADMIN: PLATFORM METRICS
+// ==============================================
+
+// AdminGetMetrics reports jobs by status, GMV and take rate for a date range (default:
+// the last 30 days)
+func AdminGetMetrics(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		RespondWithValidationError(w, &ValidationError{Field: "format", Message: "must be json or csv", Value: format})
+		return
+	}
+	fromParam, err := ParseDateParam(r, "from")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	toParam, err := ParseDateParam(r, "to")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	to := appFrom(r.Context()).Clock.Now().UTC()
+	if toParam != nil {
+		to = *toParam
+	}
+	from := to.AddDate(0, 0, -30)
+	if fromParam != nil {
+		from = *fromParam
+	}
+	if !to.After(from) {
+		RespondWithValidationError(w, &ValidationError{Field: "to", Message: "must be after from"})
+		return
+	}
+
+	metrics := model.PlatformMetrics{From: from, To: to, JobsByStatus: map[string]int{}}
+
+	rows, err := config.DB.Query(`
+		SELECT status::text, COUNT(*) FROM jobs WHERE created_at >= $1 AND created_at < $2 GROUP BY status
+	`, from, to)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error counting jobs by status", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning job status count", "error", err)
+			continue
+		}
+		metrics.JobsByStatus[status] = count
+		metrics.JobsCreated += count
+	}
+
+	err = config.DB.QueryRow(`
+		SELECT COUNT(*), COALESCE(SUM(COALESCE(capture_amount, amount)), 0), COALESCE(SUM(platform_fee), 0),
+		       COALESCE(SUM(refund_amount), 0)
+		FROM transactions
+		WHERE captured_at >= $1 AND captured_at < $2
+	`, from, to).Scan(&metrics.CapturedTransactions, &metrics.GMV, &metrics.PlatformFees, &metrics.Refunds)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error summing transactions", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if metrics.GMV > 0 {
+		metrics.TakeRate = metrics.PlatformFees / metrics.GMV
+	}
+
+	err = config.DB.QueryRow(`SELECT COUNT(*) FROM people WHERE created_at >= $1 AND created_at < $2`, from, to).Scan(&metrics.NewUsers)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error counting new users", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	if format == "csv" {
+		records := [][]string{
+			{"jobs_created", strconv.Itoa(metrics.JobsCreated)},
+			{"captured_transactions", strconv.Itoa(metrics.CapturedTransactions)},
+			{"gmv", fmt.Sprintf("%.2f", metrics.GMV)},
+			{"platform_fees", fmt.Sprintf("%.2f", metrics.PlatformFees)},
+			{"refunds", fmt.Sprintf("%.2f", metrics.Refunds)},
+			{"take_rate", fmt.Sprintf("%.4f", metrics.TakeRate)},
+			{"new_users", strconv.Itoa(metrics.NewUsers)},
+		}
+		statuses := make([]string, 0, len(metrics.JobsByStatus))
+		for status := range metrics.JobsByStatus {
+			statuses = append(statuses, status)
+		}
+		sort.Strings(statuses)
+		for _, status := range statuses {
+			records = append(records, []string{"jobs_" + status, strconv.Itoa(metrics.JobsByStatus[status])})
+		}
+		writeAdminCSV(w, r, "metrics", []string{"metric", "value"}, records)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, metrics)
+}
+
+// ==============================================
+// ADMIN: OVERVIEW
+// ==============================================
+
+// overviewActiveStatuses are the job statuses still in progress, counted by the overview
+var overviewActiveStatuses = []string{"posted", "offer_sent", "accepted", "worker_assigned", "scheduled", "in_progress", "payment_failed"}
+
+// AdminGetOverview returns the ops dashboard summary for a range of UTC days (default:
+// the last 30 days including today) in one response. to is exclusive.
+func AdminGetOverview(w http.ResponseWriter, r *http.Request) {
+	fromParam, err := ParseDateParam(r, "from")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	toParam, err := ParseDateParam(r, "to")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	to := appFrom(r.Context()).Clock.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	if toParam != nil {
+		to = toParam.UTC().Truncate(24 * time.Hour)
+	}
+	from := to.AddDate(0, 0, -30)
+	if fromParam != nil {
+		from = fromParam.UTC().Truncate(24 * time.Hour)
+	}
+	if !to.After(from) {
+		RespondWithValidationError(w, &ValidationError{Field: "to", Message: "must be after from"})
+		return
+	}
+
+	overview := model.AdminOverview{
+		From:               from,
+		To:                 to,
+		ActiveJobsByStatus: map[string]int{},
+		NewSignupsByRole:   map[string]int{},
+	}
+
+	var refreshedAt sql.NullTime
+	err = config.DB.QueryRow(`
+		SELECT MIN(refreshed_at) FROM materialized_view_refreshes
+		WHERE view_name IN ('admin_daily_jobs', 'admin_daily_payments', 'admin_daily_signups')
+	`).Scan(&refreshedAt)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error reading overview refresh time", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	overview.RefreshedAt = timePtrFromNull(refreshedAt)
+
+	err = config.DB.QueryRow(`
+		SELECT COALESCE(SUM(attempts), 0), COALESCE(SUM(failed), 0), COALESCE(SUM(gmv), 0), COALESCE(SUM(platform_fees), 0)
+		FROM admin_daily_payments
+		WHERE day >= $1::date AND day < $2::date
+	`, from, to).Scan(&overview.PaymentAttempts, &overview.PaymentsFailed, &overview.GMV, &overview.PlatformFees)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error summing daily payments", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if overview.GMV > 0 {
+		overview.TakeRate = overview.PlatformFees / overview.GMV
+	}
+	if overview.PaymentAttempts > 0 {
+		overview.PaymentFailureRate = float64(overview.PaymentsFailed) / float64(overview.PaymentAttempts)
+	}
+
+	err = config.DB.QueryRow(`
+		SELECT COALESCE(SUM(jobs_posted), 0), COALESCE(SUM(jobs_filled), 0)
+		FROM admin_daily_jobs
+		WHERE day >= $1::date AND day < $2::date
+	`, from, to).Scan(&overview.JobsPosted, &overview.JobsFilled)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error summing daily jobs", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if overview.JobsPosted > 0 {
+		overview.FillRate = float64(overview.JobsFilled) / float64(overview.JobsPosted)
+	}
+
+	counts := []struct {
+		name   string
+		query  string
+		args   []interface{}
+		counts map[string]int
+	}{
+		{"daily signups", `
+			SELECT role, SUM(signups) FROM admin_daily_signups
+			WHERE day >= $1::date AND day < $2::date
+			GROUP BY role
+		`, []interface{}{from, to}, overview.NewSignupsByRole},
+		{"active jobs", `
+			SELECT status::text, COUNT(*) FROM jobs WHERE status::text = ANY($1) GROUP BY status
+		`, []interface{}{pq.Array(overviewActiveStatuses)}, overview.ActiveJobsByStatus},
+	}
+	for _, c := range counts {
+		if err := scanCounts(c.counts, c.query, c.args...); err != nil {
+			slog.ErrorContext(r.Context(), "Database error counting", "name", c.name, "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+	}
+	for _, n := range overview.NewSignupsByRole {
+		overview.NewSignups += n
+	}
+
+	err = config.DB.QueryRow(`SELECT COUNT(*) FROM job_disputes WHERE status IN ('open', 'under_review')`).Scan(&overview.OpenDisputes)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error counting open disputes", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, overview)
+}
+
+// scanCounts reads name, count rows into counts
+func scanCounts(counts map[string]int, query string, args ...interface{}) error {
+	rows, err := config.DB.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		var count int
+		if err := rows.Scan(&name, &count); err != nil {
+			return err
+		}
+		counts[name] = count
+	}
+	return rows.Err()
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAdminQuery(t *testing.T) {
+	var q adminQuery
+	if got := q.clause(); got != "" {
+		t.Errorf("clause() with no filters = %q, want empty", got)
+	}
+
+	q.add("status IN ('open', 'under_review')")
+	q.add("j.status::text = ?", "posted")
+	q.add("(t.consumer_id = ? OR t.gig_worker_id = ?)", 7, 7)
+
+	wantClause := " WHERE status IN ('open', 'under_review') AND j.status::text = $1 AND (t.consumer_id = $2 OR t.gig_worker_id = $3)"
+	if got := q.clause(); got != wantClause {
+		t.Errorf("clause() = %q, want %q", got, wantClause)
+	}
+
+	limitClause, args := q.page(20, 40)
+	if limitClause != " LIMIT $4 OFFSET $5" {
+		t.Errorf("page() clause = %q, want %q", limitClause, " LIMIT $4 OFFSET $5")
+	}
+	if want := []any{"posted", 7, 7, 20, 40}; !reflect.DeepEqual(args, want) {
+		t.Errorf("page() args = %v, want %v", args, want)
+	}
+	if len(q.args) != 3 {
+		t.Errorf("page() changed the filter args to %v", q.args)
+	}
+}
+
+func TestAddDateRange(t *testing.T) {
+	tests := []struct {
+		query      string
+		wantClause string
+		wantArgs   []any
+	}{
+		{"", "", nil},
+		{"from=2026-03-01", " WHERE created_at >= $1", []any{time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)}},
+		{"to=2026-03-31", " WHERE created_at < $1", []any{time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)}},
+		{"to=2026-03-31T12:00:00Z", " WHERE created_at <= $1", []any{time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			var q adminQuery
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users?"+tt.query, nil)
+			if !addDateRange(httptest.NewRecorder(), r, &q, "created_at") {
+				t.Fatal("addDateRange() rejected the query")
+			}
+			if got := q.clause(); got != tt.wantClause || !reflect.DeepEqual(q.args, tt.wantArgs) {
+				t.Errorf("addDateRange() = %q %v, want %q %v", got, q.args, tt.wantClause, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestParseAdminListing(t *testing.T) {
+	tests := []struct {
+		query      string
+		want       adminListing
+		wantOK     bool
+		wantStatus int
+	}{
+		{"", adminListing{page: 1, limit: DefaultPageSize}, true, 0},
+		{"page=3&limit=50", adminListing{page: 3, limit: 50}, true, 0},
+		{"format=csv&page=3", adminListing{page: 1, limit: adminExportLimit, export: exportCSV}, true, 0},
+		{"format=ndjson", adminListing{page: 1, limit: adminExportLimit, export: exportNDJSON}, true, 0},
+		{"format=xml", adminListing{}, false, http.StatusBadRequest},
+		{"limit=0", adminListing{}, false, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users?"+tt.query, nil)
+
+			got, ok := parseAdminListing(w, r)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("parseAdminListing() = %+v, %v, want %+v, %v", got, ok, tt.want, tt.wantOK)
+			}
+			if !ok && w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
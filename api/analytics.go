@@ -0,0 +1,66 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/analytics"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// defaultFunnelReportWindow is the report window when from is not given
+const defaultFunnelReportWindow = 30 * 24 * time.Hour
+
+// trackFunnel records a funnel event for the job, attributed to the caller. Failures
+// are logged; they only leave a gap in the funnel report.
+func trackFunnel(r *http.Request, jobID int, stage string, properties map[string]interface{}) {
+	event := analytics.FunnelEvent{
+		JobID:      jobID,
+		Stage:      stage,
+		Source:     analytics.SourceAPI,
+		Properties: properties,
+	}
+	if userID := GetUserIDFromContext(r); userID != 0 {
+		event.ActorID = &userID
+	}
+	if err := analytics.Track(r.Context(), config.DB, event); err != nil {
+		slog.WarnContext(r.Context(), "Failed to track funnel event", "error", err)
+	}
+}
+
+// GetFunnelReport reports how many jobs posted in the window reached each lifecycle
+// stage and how long they spent getting there
+func GetFunnelReport(w http.ResponseWriter, r *http.Request) {
+	from, err := ParseDateParam(r, "from")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	to, err := ParseDateParam(r, "to")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	end := appFrom(r.Context()).Clock.Now()
+	if to != nil {
+		end = *to
+	}
+	start := end.Add(-defaultFunnelReportWindow)
+	if from != nil {
+		start = *from
+	}
+	if !start.Before(end) {
+		RespondWithError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	report, err := analytics.Report(r.Context(), config.DB, start, end)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error building funnel report", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, report)
+}
@@ -2,13 +2,19 @@ package api
 
 import (
 	"app/config"
+	"app/internal/analytics"
+	"app/internal/jobevents"
+	"app/internal/jobquality"
 	"app/internal/model"
+	"app/internal/realtime"
+	"app/internal/recurrence"
 	"app/internal/temporal"
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
@@ -41,30 +47,34 @@ func HealthCheck(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]any{
+	body := map[string]any{
 		"status":    "healthy",
 		"database":  "connected",
-		"timestamp": time.Now(),
-	})
+		"timestamp": appFrom(r.Context()).Clock.Now(),
+	}
+	if _, version := checkSchema(r.Context()); version != nil {
+		body["schema_version"] = *version
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(body)
 }
 
 func CreateUser(w http.ResponseWriter, r *http.Request) {
 	var user model.User
 
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	err := json.NewDecoder(r.Body).Decode(&user)
 	if err != nil {
-		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
 		return
 	}
 
-	if user.Name == "" || user.Address == "" {
-		http.Error(w, "Name and address are required", http.StatusBadRequest)
+	if err := validateCreateUserRequest(&user); err != nil {
+		RespondWithValidationErrors(w, err)
 		return
 	}
 
@@ -73,10 +83,10 @@ func CreateUser(w http.ResponseWriter, r *http.Request) {
 		VALUES ($1, $2, $3) 
 		RETURNING id, created_at`
 
-	err = config.DB.QueryRow(query, user.Name, user.Address, time.Now()).Scan(&user.ID, &user.CreatedAt)
+	err = config.DB.QueryRow(query, user.Name, user.Address, appFrom(r.Context()).Clock.Now()).Scan(&user.ID, &user.CreatedAt)
 	if err != nil {
 		fmt.Printf("Database error: %v\n", err)
-		http.Error(w, "Failed to create user", http.StatusInternalServerError)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to create user")
 		return
 	}
 
@@ -92,8 +102,7 @@ func GetCustomerByID(w http.ResponseWriter, r *http.Request) {
 
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(model.ErrorResponse{Error: "Invalid ID format"})
+		RespondWithError(w, http.StatusBadRequest, "Invalid ID format")
 		return
 	}
 
@@ -102,13 +111,11 @@ func GetCustomerByID(w http.ResponseWriter, r *http.Request) {
 	err = config.DB.QueryRow(query, id).Scan(&customer.ID, &customer.Name, &customer.Address)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(model.ErrorResponse{Error: "Customer not found"})
+			RespondWithError(w, http.StatusNotFound, "Customer not found")
 			return
 		}
-		log.Printf("Database error: %v", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(model.ErrorResponse{Error: "Internal server error"})
+		slog.ErrorContext(r.Context(), "Database error", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -119,7 +126,7 @@ func GetCustomerByID(w http.ResponseWriter, r *http.Request) {
 func CreateSchedule(w http.ResponseWriter, r *http.Request) {
 	// Check if the request method is POST
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -127,25 +134,22 @@ func CreateSchedule(w http.ResponseWriter, r *http.Request) {
 	var schedule model.Schedule
 	err := json.NewDecoder(r.Body).Decode(&schedule)
 	if err != nil {
-		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
 		return
 	}
 
-	// Validate required fields
-	if schedule.GigWorkerID <= 0 {
-		http.Error(w, "Gig worker ID is required", http.StatusBadRequest)
-		return
-	}
-	if schedule.StartTime.IsZero() {
-		http.Error(w, "Start time is required", http.StatusBadRequest)
-		return
-	}
-	if schedule.EndTime.IsZero() {
-		http.Error(w, "End time is required", http.StatusBadRequest)
-		return
+	// Workers manage their own availability; admins may schedule any worker
+	if GetUserRoleFromContext(r) != "admin" {
+		userID, ok := RequireUserID(w, r, schedule.GigWorkerID)
+		if !ok {
+			return
+		}
+		schedule.GigWorkerID = userID
 	}
-	if schedule.StartTime.After(schedule.EndTime) {
-		http.Error(w, "Start time must be before end time", http.StatusBadRequest)
+
+	rule, err := validateScheduleRequest(&schedule)
+	if err != nil {
+		RespondWithValidationErrors(w, err)
 		return
 	}
 
@@ -154,13 +158,32 @@ func CreateSchedule(w http.ResponseWriter, r *http.Request) {
 		var exists bool
 		err := config.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM jobs WHERE id = $1)", *schedule.JobID).Scan(&exists)
 		if err != nil {
-			log.Printf("Error checking job existence: %v", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			slog.ErrorContext(r.Context(), "Error checking job existence", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 			return
 		}
 		if !exists {
-			log.Printf("Invalid job_id: %d does not exist", *schedule.JobID)
-			http.Error(w, "Invalid job_id: the specified job does not exist", http.StatusBadRequest)
+			slog.InfoContext(r.Context(), "Invalid job_id: does not exist", "job_id", *schedule.JobID)
+			respondError(w, http.StatusBadRequest, model.ErrCodeJobNotFound, "Invalid job_id: the specified job does not exist")
+			return
+		}
+	}
+
+	// Booked slots may not overlap the worker's other booked slots; open availability may
+	if !schedule.IsAvailable || schedule.JobID != nil {
+		conflicts, err := recurrence.FindConflicts(r.Context(), config.DB, recurrence.Proposal{
+			WorkerID: schedule.GigWorkerID,
+			Slot:     recurrence.Slot{Start: schedule.StartTime, End: schedule.EndTime},
+			Rule:     rule,
+			Until:    schedule.RecurringUntil,
+		})
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error checking schedule conflicts", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		if len(conflicts) > 0 {
+			respondScheduleConflict(w, "Schedule conflicts with the worker's booked slots", conflicts)
 			return
 		}
 	}
@@ -192,8 +215,8 @@ func CreateSchedule(w http.ResponseWriter, r *http.Request) {
 		schedule.Notes,
 	).Scan(&id, &uuid, &createdAt, &updatedAt)
 	if err != nil {
-		log.Printf("Database error: %v", err)
-		http.Error(w, "Failed to create schedule", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to create schedule")
 		return
 	}
 
@@ -221,8 +244,8 @@ func CreateSchedule(w http.ResponseWriter, r *http.Request) {
 // @Param end_date query string false "Filter by end date (YYYY-MM-DD)"
 // @Param limit query int false "Number of results per page (default: 20, max: 100)"
 // @Success 200 {object} model.SchedulesListResponse
-// @Failure 400 {object} model.ErrorResponse
-// @Failure 500 {object} model.ErrorResponse
+// @Failure 400 {object} model.ErrorResponse "VALIDATION_ERROR"
+// @Failure 500 {object} model.ErrorResponse "INTERNAL_ERROR"
 // @Router /api/v1/schedules [get]
 func GetSchedules(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -339,7 +362,7 @@ func GetSchedules(w http.ResponseWriter, r *http.Request) {
 	// Execute query
 	rows, err := config.DB.QueryContext(ctx, baseQuery, args...)
 	if err != nil {
-		log.Printf("Error querying schedules: %v | Query: %s | Args: %v", err, baseQuery, args)
+		slog.ErrorContext(r.Context(), "Error querying schedules", "error", err, "query", baseQuery, "args", args)
 		RespondWithError(w, http.StatusInternalServerError, "Unable to retrieve schedules")
 		return
 	}
@@ -359,7 +382,7 @@ func GetSchedules(w http.ResponseWriter, r *http.Request) {
 		)
 
 		if err != nil {
-			log.Printf("Error scanning schedule row: %v", err)
+			slog.ErrorContext(r.Context(), "Error scanning schedule row", "error", err)
 			RespondWithError(w, http.StatusInternalServerError, "Error processing schedule data")
 			return
 		}
@@ -369,7 +392,7 @@ func GetSchedules(w http.ResponseWriter, r *http.Request) {
 
 	// Check for errors during iteration
 	if err := rows.Err(); err != nil {
-		log.Printf("Error iterating schedule rows: %v", err)
+		slog.ErrorContext(r.Context(), "Error iterating schedule rows", "error", err)
 		RespondWithError(w, http.StatusInternalServerError, "Error processing schedules")
 		return
 	}
@@ -385,43 +408,23 @@ func GetSchedules(w http.ResponseWriter, r *http.Request) {
 
 func CreateTransaction(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	var transaction model.Transaction
 	err := json.NewDecoder(r.Body).Decode(&transaction)
 	if err != nil {
-		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
 		return
 	}
 
-	// Validate required fields
-	if transaction.JobID <= 0 {
-		http.Error(w, "Job ID is required", http.StatusBadRequest)
-		return
-	}
-	if transaction.ConsumerID <= 0 {
-		http.Error(w, "Consumer ID is required", http.StatusBadRequest)
-		return
-	}
-	if transaction.GigWorkerID <= 0 {
-		http.Error(w, "Gig worker ID is required", http.StatusBadRequest)
-		return
-	}
-	if transaction.Amount <= 0 {
-		http.Error(w, "Amount must be greater than zero", http.StatusBadRequest)
-		return
-	}
-	if transaction.PaymentMethod == "" {
-		http.Error(w, "Payment method is required", http.StatusBadRequest)
+	if err := validateTransactionRequest(&transaction); err != nil {
+		RespondWithValidationErrors(w, err)
 		return
 	}
 
 	// Set default values
-	if transaction.Currency == "" {
-		transaction.Currency = "USD"
-	}
 	if transaction.Status == "" {
 		transaction.Status = "pending"
 	}
@@ -432,36 +435,36 @@ func CreateTransaction(w http.ResponseWriter, r *http.Request) {
 	// Check if job exists
 	err = config.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM jobs WHERE id = $1)", transaction.JobID).Scan(&exists)
 	if err != nil {
-		log.Printf("Error checking job existence: %v", err)
-		http.Error(w, "Database error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Error checking job existence", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Database error")
 		return
 	}
 	if !exists {
-		http.Error(w, "Job not found", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeJobNotFound, "Job not found")
 		return
 	}
 
 	// Check if consumer exists
 	err = config.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM people WHERE id = $1)", transaction.ConsumerID).Scan(&exists)
 	if err != nil {
-		log.Printf("Error checking consumer existence: %v", err)
-		http.Error(w, "Database error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Error checking consumer existence", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Database error")
 		return
 	}
 	if !exists {
-		http.Error(w, "Consumer not found", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeUserNotFound, "Consumer not found")
 		return
 	}
 
 	// Check if gig worker exists
 	err = config.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM people WHERE id = $1)", transaction.GigWorkerID).Scan(&exists)
 	if err != nil {
-		log.Printf("Error checking gig worker existence: %v", err)
-		http.Error(w, "Database error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Error checking gig worker existence", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Database error")
 		return
 	}
 	if !exists {
-		http.Error(w, "Gig worker not found", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeGigWorkerNotFound, "Gig worker not found")
 		return
 	}
 
@@ -491,8 +494,8 @@ func CreateTransaction(w http.ResponseWriter, r *http.Request) {
 		transaction.Notes,
 	).Scan(&id, &uuid, &createdAt, &updatedAt)
 	if err != nil {
-		log.Printf("Database error creating transaction: %v", err)
-		http.Error(w, "Failed to create transaction", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error creating transaction", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to create transaction")
 		return
 	}
 
@@ -510,35 +513,64 @@ func CreateTransaction(w http.ResponseWriter, r *http.Request) {
 // CreateJob handles job creation
 func CreateJob(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	var req model.JobCreateRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
-		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
 		return
 	}
 
 	// Validate required fields
 	if err := validateJobCreateRequest(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		RespondWithValidationErrors(w, err)
 		return
 	}
 
-	// Get consumer_id from JWT token
-	consumerID := GetUserIDFromContext(r)
-	if consumerID == 0 {
-		// Fallback to request body for testing purposes
-		if req.ConsumerID != 0 {
-			consumerID = req.ConsumerID
-		} else {
-			http.Error(w, "Unauthorized - Consumer ID required", http.StatusUnauthorized)
+	// Get consumer_id from JWT token; admins may post on behalf of a consumer
+	consumerID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+	if req.ConsumerID != 0 && req.ConsumerID != consumerID {
+		if GetUserRoleFromContext(r) != "admin" {
+			RequireUserID(w, r, req.ConsumerID)
 			return
 		}
+		consumerID = req.ConsumerID
+	}
+
+	// Rebookings name one of the consumer's favorite workers
+	if req.PreferredWorkerID != nil && !requireFavoriteWorker(w, r, consumerID, *req.PreferredWorkerID) {
+		return
 	}
+	postJob(w, r, consumerID, &req)
+}
+
+// requireFavoriteWorker reports whether the worker is one of the consumer's favorites,
+// writing the error response when not
+func requireFavoriteWorker(w http.ResponseWriter, r *http.Request, consumerID, workerID int) bool {
+	var favorite bool
+	err := config.DB.QueryRow(`SELECT EXISTS (SELECT 1 FROM favorite_workers WHERE consumer_id = $1 AND worker_id = $2)`,
+		consumerID, workerID).Scan(&favorite)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error checking favorite worker", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to create job")
+		return false
+	}
+	if !favorite {
+		RespondWithValidationError(w, &ValidationError{Field: "preferred_worker_id", Message: "must be one of your favorite workers"})
+		return false
+	}
+	return true
+}
 
+// postJob posts a validated job for the consumer, starts its workflow and writes the
+// job. Jobs posted directly, from templates and rebooked all go through it.
+func postJob(w http.ResponseWriter, r *http.Request, consumerID int, req *model.JobCreateRequest) {
 	// Handle alternative field names for backward compatibility
 	locationAddress := req.LocationAddress
 	if locationAddress == "" && req.Location != "" {
@@ -559,19 +591,35 @@ func CreateJob(w http.ResponseWriter, r *http.Request) {
 		payRate = req.PayRate
 	}
 
+	// Postings missing what a worker needs for this category are rejected
+	completeness := jobquality.Check(createRequestPosting(req))
+	if !completeness.Complete() {
+		respondJobIncomplete(w, completeness)
+		return
+	}
+
 	// Insert job into database
 	query := `
 		INSERT INTO jobs (
 			consumer_id, title, description, category, location_address,
 			location_latitude, location_longitude, estimated_duration_hours,
-			pay_rate_per_hour, total_pay, scheduled_start, scheduled_end, notes
+			pay_rate_per_hour, total_pay, scheduled_start, scheduled_end, notes,
+			access_instructions, completeness_score, preferred_worker_id, currency
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
 		) RETURNING id, uuid, created_at, updated_at
 	`
 
+	tx, err := config.DB.Begin()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error creating job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to create job")
+		return
+	}
+	defer tx.Rollback()
+
 	var job model.Job
-	err = config.DB.QueryRow(
+	err = tx.QueryRow(
 		query,
 		consumerID,
 		req.Title,
@@ -586,11 +634,21 @@ func CreateJob(w http.ResponseWriter, r *http.Request) {
 		nullTimePtr(req.ScheduledStart),
 		nullTimePtr(req.ScheduledEnd),
 		nullStringInterface(req.Notes),
+		nullStringInterface(req.AccessInstructions),
+		completeness.Score,
+		req.PreferredWorkerID,
+		req.Currency,
 	).Scan(&job.ID, &job.UUID, &job.CreatedAt, &job.UpdatedAt)
+	if err == nil {
+		_, err = recordJobEventTx(r, tx, jobevents.Transition{JobID: job.ID, Type: jobevents.TypePosted})
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
 
 	if err != nil {
-		log.Printf("Database error creating job: %v", err)
-		http.Error(w, "Failed to create job", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error creating job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to create job")
 		return
 	}
 
@@ -608,20 +666,29 @@ func CreateJob(w http.ResponseWriter, r *http.Request) {
 	job.ScheduledStart = req.ScheduledStart
 	job.ScheduledEnd = req.ScheduledEnd
 	job.Notes = customNullString(req.Notes)
+	if req.AccessInstructions != "" {
+		job.AccessInstructions = &req.AccessInstructions
+	}
+	job.CompletenessScore = &completeness.Score
+	job.PreferredWorkerID = req.PreferredWorkerID
+	job.Currency = req.Currency
 	job.Status = "posted"
 
+	// Recorded before the workflow starts so its stages follow posted
+	trackFunnel(r, job.ID, analytics.StagePosted, map[string]interface{}{"category": job.Category})
+
 	// Start Temporal workflow for the job asynchronously to avoid blocking the response
 	go func() {
 		temporalClient, err := temporal.NewClient()
 		if err != nil {
-			log.Printf("Failed to create Temporal client: %v", err)
+			slog.ErrorContext(r.Context(), "Failed to create Temporal client", "error", err)
 			return
 		}
 		defer temporalClient.Close()
 
 		we, err := temporalClient.StartJobWorkflow(r.Context(), job.ID, job.ConsumerID)
 		if err != nil {
-			log.Printf("Failed to start job workflow: %v", err)
+			slog.ErrorContext(r.Context(), "Failed to start job workflow", "error", err)
 			return
 		}
 
@@ -633,9 +700,9 @@ func CreateJob(w http.ResponseWriter, r *http.Request) {
 		`
 		_, err = config.DB.Exec(updateQuery, we.GetID(), we.GetRunID(), job.ID)
 		if err != nil {
-			log.Printf("Failed to update job with workflow IDs: %v", err)
+			slog.ErrorContext(r.Context(), "Failed to update job with workflow IDs", "error", err)
 		} else {
-			log.Printf("Started workflow for job %d: %s", job.ID, we.GetID())
+			slog.InfoContext(r.Context(), "Started workflow for job", "job_id", job.ID, "workflow_id", we.GetID())
 		}
 	}()
 
@@ -656,8 +723,8 @@ func CreateJob(w http.ResponseWriter, r *http.Request) {
 // @Param status query string false "Job status filter"
 // @Param location query string false "Location filter"
 // @Success 200 {object} map[string]interface{}
-// @Failure 401 {object} map[string]string "Unauthorized"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 401 {object} model.ErrorResponse "UNAUTHORIZED"
+// @Failure 500 {object} model.ErrorResponse "INTERNAL_ERROR"
 // @Router /jobs [get]
 func GetJobs(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -736,8 +803,8 @@ func GetJobs(w http.ResponseWriter, r *http.Request) {
 	var total int
 	err := config.DB.QueryRow(countQuery, args...).Scan(&total)
 	if err != nil {
-		log.Printf("Error counting jobs: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Error counting jobs", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -749,8 +816,8 @@ func GetJobs(w http.ResponseWriter, r *http.Request) {
 	// Execute query
 	rows, err := config.DB.Query(baseQuery, args...)
 	if err != nil {
-		log.Printf("Error querying jobs: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Error querying jobs", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 	defer rows.Close()
@@ -770,7 +837,7 @@ func GetJobs(w http.ResponseWriter, r *http.Request) {
 			&consumerName, &consumerUUID,
 		)
 		if err != nil {
-			log.Printf("Error scanning job row: %v", err)
+			slog.ErrorContext(r.Context(), "Error scanning job row", "error", err)
 			continue
 		}
 
@@ -788,23 +855,20 @@ func GetJobs(w http.ResponseWriter, r *http.Request) {
 
 		jobs = append(jobs, jobResponse)
 	}
+	attachJobMedia(r, jobs)
 
 	// Calculate pagination metadata
 	pages := (total + limit - 1) / limit
-	response := model.JobsListResponse{
-		Jobs: jobs,
-		Pagination: model.Pagination{
-			Page:    page,
-			Limit:   limit,
-			Total:   total,
-			Pages:   pages,
-			HasNext: page < pages,
-			HasPrev: page > 1,
-		},
-	}
 
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(response)
+	// Encoded a job at a time, in the shape of model.JobsListResponse
+	writeJSONList(w, "jobs", jobs, model.Pagination{
+		Page:    page,
+		Limit:   limit,
+		Total:   total,
+		Pages:   pages,
+		HasNext: page < pages,
+		HasPrev: page > 1,
+	})
 }
 
 // GetJobByID retrieves a specific job by ID
@@ -814,8 +878,7 @@ func GetJobByID(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(model.ErrorResponse{Error: "Invalid job ID format"})
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
 		return
 	}
 
@@ -824,7 +887,7 @@ func GetJobByID(w http.ResponseWriter, r *http.Request) {
 			   j.category, j.location_address, j.location_latitude, j.location_longitude,
 			   j.estimated_duration_hours, j.pay_rate_per_hour, j.total_pay, j.status,
 			   j.scheduled_start, j.scheduled_end, j.actual_start, j.actual_end,
-			   j.notes, j.created_at, j.updated_at,
+			   j.notes, j.currency, j.created_at, j.updated_at,
 			   c.name as consumer_name, c.uuid as consumer_uuid,
 			   w.name as worker_name, w.uuid as worker_uuid
 		FROM jobs j
@@ -842,20 +905,18 @@ func GetJobByID(w http.ResponseWriter, r *http.Request) {
 		&job.Category, &job.LocationAddress, &job.LocationLatitude, &job.LocationLongitude,
 		&job.EstimatedDurationHours, &job.PayRatePerHour, &job.TotalPay, &job.Status,
 		&job.ScheduledStart, &job.ScheduledEnd, &job.ActualStart, &job.ActualEnd,
-		&job.Notes, &job.CreatedAt, &job.UpdatedAt,
+		&job.Notes, &job.Currency, &job.CreatedAt, &job.UpdatedAt,
 		&consumerName, &consumerUUID,
 		&workerName, &workerUUID,
 	)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(model.ErrorResponse{Error: "Job not found"})
+			respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
 			return
 		}
-		log.Printf("Database error: %v", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(model.ErrorResponse{Error: "Internal server error"})
+		slog.ErrorContext(r.Context(), "Database error", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -877,6 +938,22 @@ func GetJobByID(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Workers viewing a job see consumer trust signals, and parties to the job its
+	// before and after photos
+	responses := []model.JobResponse{jobResponse}
+	if GetUserRoleFromContext(r) == "gig_worker" {
+		attachConsumerTrustSignals(r.Context(), responses)
+	}
+	attachJobMedia(r, responses)
+	jobResponse = responses[0]
+
+	// Outdoor jobs carry their latest forecast advisory
+	if advisory, err := loadWeatherAdvisory(job.ID); err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting weather advisory for job", "job_id", job.ID, "error", err)
+	} else {
+		jobResponse.Weather = advisory
+	}
+
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(jobResponse)
 }
@@ -884,111 +961,80 @@ func GetJobByID(w http.ResponseWriter, r *http.Request) {
 // AcceptJob allows a gig worker to accept a posted job
 func AcceptJob(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		respondError(w, http.StatusMethodNotAllowed, model.ErrCodeMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	idParam := chi.URLParam(r, "id")
 	jobID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid job ID format", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeBadRequest, "Invalid job ID format")
 		return
 	}
 
-	// Get gig worker ID from JWT token (preferred) or request body (mobile app compatibility)
-	userID := GetUserIDFromContext(r)
-	var gigWorkerID int
-
-	if userID != 0 {
-		// Try to get gig worker ID from authenticated user
-		err = config.DB.QueryRow(`
-			SELECT id FROM gigworkers WHERE user_id = $1 AND is_active = true
-		`, userID).Scan(&gigWorkerID)
-		if err != nil && err != sql.ErrNoRows {
-			log.Printf("Error fetching gig worker: %v", err)
-			http.Error(w, "Failed to fetch gig worker profile", http.StatusInternalServerError)
+	// Older mobile builds still send gig_worker_id; it must match the token
+	var req model.JobAcceptRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
 			return
 		}
 	}
 
-	// Fallback to request body for mobile app compatibility
-	if gigWorkerID == 0 {
-		var req struct {
-			GigWorkerID int `json:"gig_worker_id"`
-		}
-		if err := json.NewDecoder(r.Body).Decode(&req); err == nil && req.GigWorkerID > 0 {
-			gigWorkerID = req.GigWorkerID
-			log.Printf("Using gig_worker_id from request body: %d", gigWorkerID)
-		}
-	}
-
-	if gigWorkerID <= 0 {
-		http.Error(w, "Gig worker ID is required", http.StatusBadRequest)
+	gigWorkerID, ok := RequireUserID(w, r, req.GigWorkerID)
+	if !ok {
 		return
 	}
 
-	// Check if job exists first
-	var existingStatus sql.NullString
-	var existingGigWorkerID sql.NullInt32
-	checkQuery := "SELECT status, gig_worker_id FROM jobs WHERE id = $1"
-	err = config.DB.QueryRow(checkQuery, jobID).Scan(&existingStatus, &existingGigWorkerID)
+	// Check and assign with the job locked, so two workers accepting at once cannot both
+	// pass the checks; the second waits for the first and then finds the job taken
+	tx, err := config.DB.BeginTx(r.Context(), nil)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			http.Error(w, "Job not found", http.StatusNotFound)
-			return
-		}
-		log.Printf("Database error checking job: %v", err)
-		http.Error(w, "Failed to check job status", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Failed to begin transaction", "error", err)
+		respondError(w, http.StatusInternalServerError, model.ErrCodeInternal, "Failed to accept job")
 		return
 	}
+	defer tx.Rollback()
 
-	// Check if job is in correct status and available
-	if !existingStatus.Valid {
-		http.Error(w, "Job status is invalid", http.StatusInternalServerError)
+	uuid, state, err := lockJobTx(r, tx, jobID)
+	if errors.Is(err, jobevents.ErrJobNotFound) {
+		respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
 		return
 	}
-
-	// More flexible status checking - allow 'posted' status or jobs without a worker assigned
-	if existingStatus.String != "posted" && existingGigWorkerID.Valid {
-		if existingStatus.String == "accepted" {
-			http.Error(w, "Job has already been accepted by another worker", http.StatusConflict)
-			return
-		}
-		http.Error(w, fmt.Sprintf("Job is not available for acceptance (current status: %s)", existingStatus.String), http.StatusConflict)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error checking job", "error", err)
+		respondError(w, http.StatusInternalServerError, model.ErrCodeInternal, "Failed to check job status")
 		return
 	}
-
-	if existingGigWorkerID.Valid {
-		http.Error(w, "Job has already been accepted by another worker", http.StatusConflict)
+	if conflict := acceptJobConflict(state, gigWorkerID); conflict != "" {
+		respondError(w, http.StatusConflict, model.ErrCodeInvalidJobStatus, conflict)
 		return
 	}
 
-	// Update job with gig worker and change status
-	query := `
-		UPDATE jobs 
-		SET gig_worker_id = $1, status = 'accepted', updated_at = NOW()
-		WHERE id = $2 AND gig_worker_id IS NULL
-		RETURNING id, uuid, updated_at
-	`
-
-	var id int
-	var uuid string
-	var updatedAt time.Time
+	if !requireWorkerFree(w, r, gigWorkerID, jobID) {
+		return
+	}
 
-	err = config.DB.QueryRow(query, gigWorkerID, jobID).Scan(&id, &uuid, &updatedAt)
+	event, err := recordJobEventTx(r, tx, jobevents.Transition{
+		JobID:    jobID,
+		Type:     jobevents.TypeAccepted,
+		WorkerID: &gigWorkerID,
+		Allowed:  func(s jobevents.State) bool { return acceptJobConflict(s, gigWorkerID) == "" },
+	})
+	if err == nil {
+		err = tx.Commit()
+	}
 	if err != nil {
-		if err == sql.ErrNoRows {
-			http.Error(w, "Job acceptance failed due to concurrent update", http.StatusConflict)
-			return
-		}
-		log.Printf("Database error accepting job: %v", err)
-		http.Error(w, "Failed to accept job", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error accepting job", "error", err)
+		respondError(w, http.StatusInternalServerError, model.ErrCodeInternal, "Failed to accept job")
 		return
 	}
+	id, updatedAt := jobID, event.OccurredAt
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]interface{}{
+	publishJobStatus(r, id, "accepted")
+	trackFunnel(r, id, analytics.StageAccepted, nil)
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
 		"success":    true,
 		"message":    "Job accepted successfully",
 		"job_id":     id,
@@ -997,6 +1043,20 @@ func AcceptJob(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// acceptJobConflict explains why a worker cannot accept a job in state s, or returns ""
+// when they can: only a posted job without a worker can be accepted
+func acceptJobConflict(s jobevents.State, workerID int) string {
+	switch {
+	case s.Status == "posted" && s.GigWorkerID == nil:
+		return ""
+	case s.Status == "accepted" && s.GigWorkerID != nil && *s.GigWorkerID == workerID:
+		return "You have already accepted this job"
+	case s.Status == "accepted" && s.GigWorkerID != nil:
+		return "Job has already been accepted by another worker"
+	}
+	return fmt.Sprintf("Job is not available for acceptance (current status: %s)", s.Status)
+}
+
 // Helper functions for handling nullable database fields
 func nullTimePtr(t *time.Time) interface{} {
 	if t == nil {
@@ -1027,128 +1087,61 @@ func nullFloat64Interface(f float64) interface{} {
 	return f
 }
 
-// CreateGigWorker handles gig worker creation
-func CreateGigWorker(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	var gigWorker model.GigWorker
-	err := json.NewDecoder(r.Body).Decode(&gigWorker)
-	if err != nil {
-		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
-		return
-	}
-
-	// Validate required fields
-	if err := validateGigWorkerRequest(&gigWorker); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	// Set defaults
-	gigWorker.Role = "gig_worker"
-	// Default to active (JSON decoder sets bool to false, so we override)
-	gigWorker.IsActive = true
-	if gigWorker.VerificationStatus == "" {
-		gigWorker.VerificationStatus = "pending"
-	}
-
-	// Insert into gigworkers table
-	query := `
-		INSERT INTO gigworkers (
-			name, email, phone, address, latitude, longitude, place_id, 
-			role, is_active, email_verified, phone_verified, bio, hourly_rate, 
-			experience_years, verification_status, background_check_date, 
-			service_radius_miles, availability_notes, emergency_contact_name, 
-			emergency_contact_phone, emergency_contact_relationship, created_at, updated_at
-		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23
-		) RETURNING id, uuid, created_at, updated_at`
-
-	var id int
-	var uuid string
-	var createdAt, updatedAt time.Time
-	now := time.Now()
-
-	err = config.DB.QueryRow(
-		query,
-		gigWorker.Name,
-		gigWorker.Email,
-		nullStringInterface(gigWorker.Phone),
-		gigWorker.Address,
-		nullFloat64Interface(gigWorker.Latitude),
-		nullFloat64Interface(gigWorker.Longitude),
-		nullStringInterface(gigWorker.PlaceID),
-		gigWorker.Role,
-		gigWorker.IsActive,
-		gigWorker.EmailVerified,
-		gigWorker.PhoneVerified,
-		nullStringInterface(gigWorker.Bio),
-		nullFloat64Ptr(gigWorker.HourlyRate),
-		nullIntPtr(gigWorker.ExperienceYears),
-		gigWorker.VerificationStatus,
-		nullTimePtr(gigWorker.BackgroundCheckDate),
-		nullFloat64Ptr(gigWorker.ServiceRadiusMiles),
-		nullStringInterface(gigWorker.AvailabilityNotes),
-		nullStringInterface(gigWorker.EmergencyContactName),
-		nullStringInterface(gigWorker.EmergencyContactPhone),
-		nullStringInterface(gigWorker.EmergencyContactRelationship),
-		now,
-		now,
-	).Scan(&id, &uuid, &createdAt, &updatedAt)
+// gigWorkerQuery selects gig workers: gig_worker accounts with their worker profile,
+// which is absent until the worker fills it in
+const gigWorkerQuery = `
+	SELECT p.id, p.uuid, p.name, p.email, p.phone, COALESCE(p.address, ''), p.latitude, p.longitude, p.place_id,
+		   p.role, COALESCE(p.is_active, false), COALESCE(p.email_verified, false), COALESCE(p.phone_verified, false),
+		   wp.bio, wp.hourly_rate, wp.experience_years, COALESCE(wp.verification_status::text, 'pending'),
+		   wp.background_check_date, wp.service_radius_miles, wp.availability_notes, wp.last_seen_at,
+		   p.created_at, p.updated_at
+	FROM people p
+	LEFT JOIN worker_profiles wp ON wp.worker_id = p.id
+	WHERE p.role = 'gig_worker'`
+
+// scanGigWorker scans a row selected by gigWorkerQuery
+func scanGigWorker(row rowScanner, now time.Time) (model.GigWorker, error) {
+	var gw model.GigWorker
+	var phone, placeID, bio, availabilityNotes sql.NullString
+	var latitude, longitude sql.NullFloat64
+	var hourlyRate, serviceRadiusMiles sql.NullFloat64
+	var experienceYears sql.NullInt32
+	var backgroundCheckDate, lastSeen sql.NullTime
 
+	err := row.Scan(
+		&gw.ID, &gw.Uuid, &gw.Name, &gw.Email, &phone, &gw.Address,
+		&latitude, &longitude, &placeID, &gw.Role, &gw.IsActive,
+		&gw.EmailVerified, &gw.PhoneVerified, &bio, &hourlyRate,
+		&experienceYears, &gw.VerificationStatus, &backgroundCheckDate,
+		&serviceRadiusMiles, &availabilityNotes, &lastSeen,
+		&gw.CreatedAt, &gw.UpdatedAt,
+	)
 	if err != nil {
-		log.Printf("Database error creating gig worker: %v", err)
-		http.Error(w, "Failed to create gig worker", http.StatusInternalServerError)
-		return
+		return gw, err
 	}
 
-	// Set response fields
-	gigWorker.ID = id
-	gigWorker.Uuid = uuid
-	gigWorker.CreatedAt = createdAt
-	gigWorker.UpdatedAt = updatedAt
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(gigWorker)
-}
-
-// validateGigWorkerRequest validates the gig worker creation request
-func validateGigWorkerRequest(gw *model.GigWorker) error {
-	if gw.Name == "" {
-		return fmt.Errorf("name is required")
-	}
-	if len(gw.Name) < 2 || len(gw.Name) > 255 {
-		return fmt.Errorf("name must be between 2 and 255 characters")
-	}
-
-	if gw.Email == "" {
-		return fmt.Errorf("email is required")
-	}
-	if len(gw.Email) > 255 {
-		return fmt.Errorf("email must be less than 255 characters")
-	}
-
-	if gw.Address == "" {
-		return fmt.Errorf("address is required")
+	// Handle nullable fields
+	gw.Phone = phone.String
+	gw.PlaceID = placeID.String
+	gw.Latitude = latitude.Float64
+	gw.Longitude = longitude.Float64
+	gw.Bio = bio.String
+	gw.AvailabilityNotes = availabilityNotes.String
+	if hourlyRate.Valid {
+		gw.HourlyRate = &hourlyRate.Float64
 	}
-
-	if gw.HourlyRate != nil && *gw.HourlyRate <= 0 {
-		return fmt.Errorf("hourly rate must be greater than 0")
+	if experienceYears.Valid {
+		years := int(experienceYears.Int32)
+		gw.ExperienceYears = &years
 	}
-
-	if gw.ExperienceYears != nil && (*gw.ExperienceYears < 0 || *gw.ExperienceYears > 50) {
-		return fmt.Errorf("experience years must be between 0 and 50")
+	if backgroundCheckDate.Valid {
+		gw.BackgroundCheckDate = &backgroundCheckDate.Time
 	}
-
-	if gw.ServiceRadiusMiles != nil && (*gw.ServiceRadiusMiles < 1 || *gw.ServiceRadiusMiles > 100) {
-		return fmt.Errorf("service radius must be between 1 and 100 miles")
+	if serviceRadiusMiles.Valid {
+		gw.ServiceRadiusMiles = &serviceRadiusMiles.Float64
 	}
-
-	return nil
+	gw.Presence, gw.OnlineNow = workerPresence(lastSeen, now)
+	return gw, nil
 }
 
 // GetGigWorkers handles retrieving all gig workers with optional filtering
@@ -1170,16 +1163,13 @@ func GetGigWorkers(w http.ResponseWriter, r *http.Request) {
 	isActive := r.URL.Query().Get("is_active")
 
 	// Build dynamic query
-	baseQuery := `
-		SELECT id, uuid, name, email, phone, address, latitude, longitude, place_id,
-			   role, is_active, email_verified, phone_verified, bio, hourly_rate,
-			   experience_years, verification_status, background_check_date,
-			   service_radius_miles, availability_notes, emergency_contact_name,
-			   emergency_contact_phone, emergency_contact_relationship, created_at, updated_at
-		FROM gigworkers
-	`
+	baseQuery := gigWorkerQuery
 
-	countQuery := "SELECT COUNT(*) FROM gigworkers"
+	countQuery := `
+		SELECT COUNT(*)
+		FROM people p
+		LEFT JOIN worker_profiles wp ON wp.worker_id = p.id
+		WHERE p.role = 'gig_worker'`
 
 	var whereClauses []string
 	var args []interface{}
@@ -1187,25 +1177,25 @@ func GetGigWorkers(w http.ResponseWriter, r *http.Request) {
 
 	// Add filters
 	if verificationStatus != "" {
-		whereClauses = append(whereClauses, fmt.Sprintf("verification_status = $%d", argIndex))
+		whereClauses = append(whereClauses, fmt.Sprintf("COALESCE(wp.verification_status::text, 'pending') = $%d", argIndex))
 		args = append(args, verificationStatus)
 		argIndex++
 	}
 
 	if isActive != "" {
 		if isActive == "true" {
-			whereClauses = append(whereClauses, fmt.Sprintf("is_active = $%d", argIndex))
+			whereClauses = append(whereClauses, fmt.Sprintf("p.is_active = $%d", argIndex))
 			args = append(args, true)
 		} else if isActive == "false" {
-			whereClauses = append(whereClauses, fmt.Sprintf("is_active = $%d", argIndex))
+			whereClauses = append(whereClauses, fmt.Sprintf("p.is_active = $%d", argIndex))
 			args = append(args, false)
 		}
 		argIndex++
 	}
 
-	// Add WHERE clause if we have filters
+	// Add filters to the gig_worker role condition
 	if len(whereClauses) > 0 {
-		whereClause := " WHERE " + strings.Join(whereClauses, " AND ")
+		whereClause := " AND " + strings.Join(whereClauses, " AND ")
 		baseQuery += whereClause
 		countQuery += whereClause
 	}
@@ -1214,374 +1204,266 @@ func GetGigWorkers(w http.ResponseWriter, r *http.Request) {
 	var total int
 	err := config.DB.QueryRow(countQuery, args...).Scan(&total)
 	if err != nil {
-		log.Printf("Error counting gig workers: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Error counting gig workers", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
 	// Add pagination
 	offset := (page - 1) * limit
-	baseQuery += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	baseQuery += fmt.Sprintf(" ORDER BY p.created_at DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
 	args = append(args, limit, offset)
 
 	// Execute query
 	rows, err := config.DB.Query(baseQuery, args...)
 	if err != nil {
-		log.Printf("Error querying gig workers: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Error querying gig workers", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 	defer rows.Close()
 
 	var gigWorkers []model.GigWorker
 	for rows.Next() {
-		var gw model.GigWorker
-		var phone, placeID, bio, availabilityNotes sql.NullString
-		var latitude, longitude sql.NullFloat64
-		var hourlyRate, serviceRadiusMiles sql.NullFloat64
-		var experienceYears sql.NullInt32
-		var backgroundCheckDate sql.NullTime
-		var emergencyContactName, emergencyContactPhone, emergencyContactRelationship sql.NullString
-
-		err := rows.Scan(
-			&gw.ID, &gw.Uuid, &gw.Name, &gw.Email, &phone, &gw.Address,
-			&latitude, &longitude, &placeID, &gw.Role, &gw.IsActive,
-			&gw.EmailVerified, &gw.PhoneVerified, &bio, &hourlyRate,
-			&experienceYears, &gw.VerificationStatus, &backgroundCheckDate,
-			&serviceRadiusMiles, &availabilityNotes, &emergencyContactName,
-			&emergencyContactPhone, &emergencyContactRelationship,
-			&gw.CreatedAt, &gw.UpdatedAt,
-		)
+		gw, err := scanGigWorker(rows, appFrom(r.Context()).Clock.Now())
 		if err != nil {
-			log.Printf("Error scanning gig worker row: %v", err)
+			slog.ErrorContext(r.Context(), "Error scanning gig worker row", "error", err)
 			continue
 		}
-
-		// Handle nullable fields
-		if phone.Valid {
-			gw.Phone = phone.String
-		}
-		if placeID.Valid {
-			gw.PlaceID = placeID.String
-		}
-		if latitude.Valid {
-			gw.Latitude = latitude.Float64
-		}
-		if longitude.Valid {
-			gw.Longitude = longitude.Float64
-		}
-		if bio.Valid {
-			gw.Bio = bio.String
-		}
-		if hourlyRate.Valid {
-			gw.HourlyRate = &hourlyRate.Float64
-		}
-		if experienceYears.Valid {
-			years := int(experienceYears.Int32)
-			gw.ExperienceYears = &years
-		}
-		if backgroundCheckDate.Valid {
-			gw.BackgroundCheckDate = &backgroundCheckDate.Time
-		}
-		if serviceRadiusMiles.Valid {
-			gw.ServiceRadiusMiles = &serviceRadiusMiles.Float64
-		}
-		if availabilityNotes.Valid {
-			gw.AvailabilityNotes = availabilityNotes.String
-		}
-		if emergencyContactName.Valid {
-			gw.EmergencyContactName = emergencyContactName.String
-		}
-		if emergencyContactPhone.Valid {
-			gw.EmergencyContactPhone = emergencyContactPhone.String
-		}
-		if emergencyContactRelationship.Valid {
-			gw.EmergencyContactRelationship = emergencyContactRelationship.String
-		}
-
 		gigWorkers = append(gigWorkers, gw)
 	}
 
 	// Calculate pagination metadata
 	pages := (total + limit - 1) / limit
-	response := map[string]interface{}{
-		"gigworkers": gigWorkers,
-		"pagination": model.Pagination{
-			Page:    page,
-			Limit:   limit,
-			Total:   total,
-			Pages:   pages,
-			HasNext: page < pages,
-			HasPrev: page > 1,
-		},
-	}
 
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(response)
+	writeJSONList(w, "gigworkers", gigWorkers, model.Pagination{
+		Page:    page,
+		Limit:   limit,
+		Total:   total,
+		Pages:   pages,
+		HasNext: page < pages,
+		HasPrev: page > 1,
+	})
 }
 
-// GetGigWorkerByID retrieves a specific gig worker by ID
+// GetGigWorkerByID retrieves a specific gig worker by user ID
 func GetGigWorkerByID(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
 	idParam := chi.URLParam(r, "id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(model.ErrorResponse{Error: "Invalid gig worker ID format"})
+		RespondWithError(w, http.StatusBadRequest, "Invalid gig worker ID format")
 		return
 	}
 
-	query := `
-		SELECT id, uuid, name, email, phone, address, latitude, longitude, place_id,
-			   role, is_active, email_verified, phone_verified, bio, hourly_rate,
-			   experience_years, verification_status, background_check_date,
-			   service_radius_miles, availability_notes, emergency_contact_name,
-			   emergency_contact_phone, emergency_contact_relationship, created_at, updated_at
-		FROM gigworkers
-		WHERE id = $1
-	`
-
-	var gw model.GigWorker
-	var phone, placeID, bio, availabilityNotes sql.NullString
-	var latitude, longitude sql.NullFloat64
-	var hourlyRate, serviceRadiusMiles sql.NullFloat64
-	var experienceYears sql.NullInt32
-	var backgroundCheckDate sql.NullTime
-	var emergencyContactName, emergencyContactPhone, emergencyContactRelationship sql.NullString
-
-	err = config.DB.QueryRow(query, id).Scan(
-		&gw.ID, &gw.Uuid, &gw.Name, &gw.Email, &phone, &gw.Address,
-		&latitude, &longitude, &placeID, &gw.Role, &gw.IsActive,
-		&gw.EmailVerified, &gw.PhoneVerified, &bio, &hourlyRate,
-		&experienceYears, &gw.VerificationStatus, &backgroundCheckDate,
-		&serviceRadiusMiles, &availabilityNotes, &emergencyContactName,
-		&emergencyContactPhone, &emergencyContactRelationship,
-		&gw.CreatedAt, &gw.UpdatedAt,
-	)
-
+	gw, err := scanGigWorker(config.DB.QueryRow(gigWorkerQuery+" AND p.id = $1", id), appFrom(r.Context()).Clock.Now())
 	if err != nil {
 		if err == sql.ErrNoRows {
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(model.ErrorResponse{Error: "Gig worker not found"})
+			respondError(w, http.StatusNotFound, model.ErrCodeGigWorkerNotFound, "Gig worker not found")
 			return
 		}
-		log.Printf("Database error: %v", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(model.ErrorResponse{Error: "Internal server error"})
+		slog.ErrorContext(r.Context(), "Database error", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	// Handle nullable fields
-	if phone.Valid {
-		gw.Phone = phone.String
-	}
-	if placeID.Valid {
-		gw.PlaceID = placeID.String
-	}
-	if latitude.Valid {
-		gw.Latitude = latitude.Float64
-	}
-	if longitude.Valid {
-		gw.Longitude = longitude.Float64
-	}
-	if bio.Valid {
-		gw.Bio = bio.String
-	}
-	if hourlyRate.Valid {
-		gw.HourlyRate = &hourlyRate.Float64
-	}
-	if experienceYears.Valid {
-		years := int(experienceYears.Int32)
-		gw.ExperienceYears = &years
-	}
-	if backgroundCheckDate.Valid {
-		gw.BackgroundCheckDate = &backgroundCheckDate.Time
-	}
-	if serviceRadiusMiles.Valid {
-		gw.ServiceRadiusMiles = &serviceRadiusMiles.Float64
-	}
-	if availabilityNotes.Valid {
-		gw.AvailabilityNotes = availabilityNotes.String
-	}
-	if emergencyContactName.Valid {
-		gw.EmergencyContactName = emergencyContactName.String
-	}
-	if emergencyContactPhone.Valid {
-		gw.EmergencyContactPhone = emergencyContactPhone.String
-	}
-	if emergencyContactRelationship.Valid {
-		gw.EmergencyContactRelationship = emergencyContactRelationship.String
-	}
-
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(gw)
 }
 
-// UpdateGigWorker updates a gig worker by ID
+// setClause collects the column assignments of a dynamic UPDATE
+type setClause struct {
+	parts []string
+	args  []interface{}
+}
+
+func (s *setClause) add(column string, value interface{}) {
+	s.args = append(s.args, value)
+	s.parts = append(s.parts, fmt.Sprintf("%s = $%d", column, len(s.args)))
+}
+
+// UpdateGigWorker updates a gig worker's account details and worker profile
 func UpdateGigWorker(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPut {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	idParam := chi.URLParam(r, "id")
 	gigWorkerID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid gig worker ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid gig worker ID format")
 		return
 	}
 
-	var updateReq struct {
-		Name                         *string    `json:"name,omitempty"`
-		Phone                        *string    `json:"phone,omitempty"`
-		Address                      *string    `json:"address,omitempty"`
-		Latitude                     *float64   `json:"latitude,omitempty"`
-		Longitude                    *float64   `json:"longitude,omitempty"`
-		PlaceID                      *string    `json:"place_id,omitempty"`
-		IsActive                     *bool      `json:"is_active,omitempty"`
-		EmailVerified                *bool      `json:"email_verified,omitempty"`
-		PhoneVerified                *bool      `json:"phone_verified,omitempty"`
-		Bio                          *string    `json:"bio,omitempty"`
-		HourlyRate                   *float64   `json:"hourly_rate,omitempty"`
-		ExperienceYears              *int       `json:"experience_years,omitempty"`
-		VerificationStatus           *string    `json:"verification_status,omitempty"`
-		BackgroundCheckDate          *time.Time `json:"background_check_date,omitempty"`
-		ServiceRadiusMiles           *float64   `json:"service_radius_miles,omitempty"`
-		AvailabilityNotes            *string    `json:"availability_notes,omitempty"`
-		EmergencyContactName         *string    `json:"emergency_contact_name,omitempty"`
-		EmergencyContactPhone        *string    `json:"emergency_contact_phone,omitempty"`
-		EmergencyContactRelationship *string    `json:"emergency_contact_relationship,omitempty"`
+	// Gig worker ids are user ids; only the worker or an admin may edit
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+	isAdmin := GetUserRoleFromContext(r) == "admin"
+	if !isAdmin && userID != gigWorkerID {
+		RespondWithError(w, http.StatusForbidden, "You can only update your own gig worker profile")
+		return
 	}
 
+	var updateReq model.GigWorkerUpdateRequest
+
 	err = json.NewDecoder(r.Body).Decode(&updateReq)
 	if err != nil {
-		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	if err := validateGigWorkerUpdateRequest(&updateReq); err != nil {
+		RespondWithValidationErrors(w, err)
 		return
 	}
 
-	// Build dynamic update query
-	var setParts []string
-	var args []interface{}
-	argIndex := 1
+	// Account status and verification are the admins' to set
+	if !isAdmin && (updateReq.IsActive != nil || updateReq.EmailVerified != nil || updateReq.PhoneVerified != nil ||
+		updateReq.VerificationStatus != nil || updateReq.BackgroundCheckDate != nil) {
+		RespondWithError(w, http.StatusForbidden, "Only admins can change account status or verification")
+		return
+	}
+
+	var exists bool
+	err = config.DB.QueryRow(`SELECT EXISTS(SELECT 1 FROM people WHERE id = $1 AND role = 'gig_worker')`, gigWorkerID).Scan(&exists)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error checking gig worker", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if !exists {
+		respondError(w, http.StatusNotFound, model.ErrCodeGigWorkerNotFound, "Gig worker not found")
+		return
+	}
+
+	// Build dynamic update queries for the account and the worker profile
+	var account, profile setClause
 
 	if updateReq.Name != nil {
-		setParts = append(setParts, fmt.Sprintf("name = $%d", argIndex))
-		args = append(args, *updateReq.Name)
-		argIndex++
+		account.add("name", *updateReq.Name)
 	}
 	if updateReq.Phone != nil {
-		setParts = append(setParts, fmt.Sprintf("phone = $%d", argIndex))
-		args = append(args, nullStringInterface(*updateReq.Phone))
-		argIndex++
+		account.add("phone", nullStringInterface(*updateReq.Phone))
+		if updateReq.PhoneVerified == nil {
+			// A new number has to be verified again
+			account.parts = append(account.parts, fmt.Sprintf("phone_verified = phone_verified AND phone IS NOT DISTINCT FROM $%d", len(account.args)))
+		}
 	}
 	if updateReq.Address != nil {
-		setParts = append(setParts, fmt.Sprintf("address = $%d", argIndex))
-		args = append(args, *updateReq.Address)
-		argIndex++
+		account.add("address", *updateReq.Address)
 	}
 	if updateReq.Latitude != nil {
-		setParts = append(setParts, fmt.Sprintf("latitude = $%d", argIndex))
-		args = append(args, nullFloat64Interface(*updateReq.Latitude))
-		argIndex++
+		account.add("latitude", nullFloat64Interface(*updateReq.Latitude))
 	}
 	if updateReq.Longitude != nil {
-		setParts = append(setParts, fmt.Sprintf("longitude = $%d", argIndex))
-		args = append(args, nullFloat64Interface(*updateReq.Longitude))
-		argIndex++
+		account.add("longitude", nullFloat64Interface(*updateReq.Longitude))
 	}
 	if updateReq.PlaceID != nil {
-		setParts = append(setParts, fmt.Sprintf("place_id = $%d", argIndex))
-		args = append(args, nullStringInterface(*updateReq.PlaceID))
-		argIndex++
+		account.add("place_id", nullStringInterface(*updateReq.PlaceID))
 	}
 	if updateReq.IsActive != nil {
-		setParts = append(setParts, fmt.Sprintf("is_active = $%d", argIndex))
-		args = append(args, *updateReq.IsActive)
-		argIndex++
+		account.add("is_active", *updateReq.IsActive)
 	}
 	if updateReq.EmailVerified != nil {
-		setParts = append(setParts, fmt.Sprintf("email_verified = $%d", argIndex))
-		args = append(args, *updateReq.EmailVerified)
-		argIndex++
+		account.add("email_verified", *updateReq.EmailVerified)
 	}
 	if updateReq.PhoneVerified != nil {
-		setParts = append(setParts, fmt.Sprintf("phone_verified = $%d", argIndex))
-		args = append(args, *updateReq.PhoneVerified)
-		argIndex++
+		account.add("phone_verified", *updateReq.PhoneVerified)
 	}
 	if updateReq.Bio != nil {
-		setParts = append(setParts, fmt.Sprintf("bio = $%d", argIndex))
-		args = append(args, nullStringInterface(*updateReq.Bio))
-		argIndex++
+		profile.add("bio", nullStringInterface(*updateReq.Bio))
 	}
 	if updateReq.HourlyRate != nil {
-		setParts = append(setParts, fmt.Sprintf("hourly_rate = $%d", argIndex))
-		args = append(args, nullFloat64Ptr(updateReq.HourlyRate))
-		argIndex++
+		profile.add("hourly_rate", nullFloat64Ptr(updateReq.HourlyRate))
 	}
 	if updateReq.ExperienceYears != nil {
-		setParts = append(setParts, fmt.Sprintf("experience_years = $%d", argIndex))
-		args = append(args, nullIntPtr(updateReq.ExperienceYears))
-		argIndex++
+		profile.add("experience_years", nullIntPtr(updateReq.ExperienceYears))
 	}
 	if updateReq.VerificationStatus != nil {
-		setParts = append(setParts, fmt.Sprintf("verification_status = $%d", argIndex))
-		args = append(args, *updateReq.VerificationStatus)
-		argIndex++
+		profile.add("verification_status", *updateReq.VerificationStatus)
 	}
 	if updateReq.BackgroundCheckDate != nil {
-		setParts = append(setParts, fmt.Sprintf("background_check_date = $%d", argIndex))
-		args = append(args, nullTimePtr(updateReq.BackgroundCheckDate))
-		argIndex++
+		profile.add("background_check_date", nullTimePtr(updateReq.BackgroundCheckDate))
 	}
 	if updateReq.ServiceRadiusMiles != nil {
-		setParts = append(setParts, fmt.Sprintf("service_radius_miles = $%d", argIndex))
-		args = append(args, nullFloat64Ptr(updateReq.ServiceRadiusMiles))
-		argIndex++
+		profile.add("service_radius_miles", nullFloat64Ptr(updateReq.ServiceRadiusMiles))
 	}
 	if updateReq.AvailabilityNotes != nil {
-		setParts = append(setParts, fmt.Sprintf("availability_notes = $%d", argIndex))
-		args = append(args, nullStringInterface(*updateReq.AvailabilityNotes))
-		argIndex++
-	}
-	if updateReq.EmergencyContactName != nil {
-		setParts = append(setParts, fmt.Sprintf("emergency_contact_name = $%d", argIndex))
-		args = append(args, nullStringInterface(*updateReq.EmergencyContactName))
-		argIndex++
+		profile.add("availability_notes", nullStringInterface(*updateReq.AvailabilityNotes))
 	}
-	if updateReq.EmergencyContactPhone != nil {
-		setParts = append(setParts, fmt.Sprintf("emergency_contact_phone = $%d", argIndex))
-		args = append(args, nullStringInterface(*updateReq.EmergencyContactPhone))
-		argIndex++
-	}
-	if updateReq.EmergencyContactRelationship != nil {
-		setParts = append(setParts, fmt.Sprintf("emergency_contact_relationship = $%d", argIndex))
-		args = append(args, nullStringInterface(*updateReq.EmergencyContactRelationship))
-		argIndex++
+
+	before := profileSnapshot(r, gigWorkerID)
+
+	// Emergency contact changes are written to the encrypted vault
+	contactUpdated := updateReq.EmergencyContactName != nil || updateReq.EmergencyContactPhone != nil ||
+		updateReq.EmergencyContactRelationship != nil
+	if contactUpdated {
+		if err := updateEmergencyContact(gigWorkerID, updateReq.EmergencyContactName,
+			updateReq.EmergencyContactPhone, updateReq.EmergencyContactRelationship); err != nil {
+			slog.ErrorContext(r.Context(), "Failed to store emergency contact for gig worker", "gig_worker_id", gigWorkerID, "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Failed to update emergency contact")
+			return
+		}
 	}
 
-	if len(setParts) == 0 {
-		http.Error(w, "No fields to update", http.StatusBadRequest)
+	if len(account.parts) == 0 && len(profile.parts) == 0 {
+		if contactUpdated {
+			auditProfileUpdate(r, gigWorkerID, before, updateReq)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": true,
+				"message": "Gig worker updated successfully",
+			})
+			return
+		}
+		RespondWithError(w, http.StatusBadRequest, "No fields to update")
 		return
 	}
 
-	// Add updated_at and gig_worker_id
-	setParts = append(setParts, fmt.Sprintf("updated_at = $%d", argIndex))
-	args = append(args, time.Now())
-	argIndex++
+	tx, err := config.DB.Begin()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error starting transaction", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update gig worker")
+		return
+	}
+	defer tx.Rollback()
 
-	// Add WHERE clause
-	args = append(args, gigWorkerID)
+	if len(account.parts) > 0 {
+		account.add("updated_at", appFrom(r.Context()).Clock.Now())
+		query := fmt.Sprintf("UPDATE people SET %s WHERE id = $%d", strings.Join(account.parts, ", "), len(account.args)+1)
+		if _, err := tx.Exec(query, append(account.args, gigWorkerID)...); err != nil {
+			slog.ErrorContext(r.Context(), "Database error updating gig worker account", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Failed to update gig worker")
+			return
+		}
+	}
 
-	query := fmt.Sprintf("UPDATE gigworkers SET %s WHERE id = $%d", strings.Join(setParts, ", "), argIndex)
+	if len(profile.parts) > 0 {
+		// Workers who registered but never created a profile get one now
+		if _, err := tx.Exec(`INSERT INTO worker_profiles (worker_id) VALUES ($1) ON CONFLICT (worker_id) DO NOTHING`, gigWorkerID); err != nil {
+			slog.ErrorContext(r.Context(), "Database error creating worker profile", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Failed to update gig worker")
+			return
+		}
+		profile.add("updated_at", appFrom(r.Context()).Clock.Now())
+		query := fmt.Sprintf("UPDATE worker_profiles SET %s WHERE worker_id = $%d", strings.Join(profile.parts, ", "), len(profile.args)+1)
+		if _, err := tx.Exec(query, append(profile.args, gigWorkerID)...); err != nil {
+			slog.ErrorContext(r.Context(), "Database error updating worker profile", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Failed to update gig worker")
+			return
+		}
+	}
 
-	_, err = config.DB.Exec(query, args...)
-	if err != nil {
-		log.Printf("Database error updating gig worker: %v", err)
-		http.Error(w, "Failed to update gig worker", http.StatusInternalServerError)
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(r.Context(), "Database error committing gig worker update", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update gig worker")
 		return
 	}
+	auditProfileUpdate(r, gigWorkerID, before, updateReq)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -1591,27 +1473,34 @@ func UpdateGigWorker(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// DeactivateGigWorker deactivates a gig worker account
+// DeactivateGigWorker deactivates a gig worker account and ends its sessions
 func DeactivateGigWorker(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	idParam := chi.URLParam(r, "id")
 	gigWorkerID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid gig worker ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid gig worker ID format")
 		return
 	}
 
-	query := "UPDATE gigworkers SET is_active = false, updated_at = NOW() WHERE id = $1"
-	_, err = config.DB.Exec(query, gigWorkerID)
+	query := "UPDATE people SET is_active = false, updated_at = NOW() WHERE id = $1 AND role = 'gig_worker'"
+	result, err := config.DB.Exec(query, gigWorkerID)
 	if err != nil {
-		log.Printf("Database error deactivating gig worker: %v", err)
-		http.Error(w, "Failed to deactivate gig worker", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error deactivating gig worker", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to deactivate gig worker")
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		respondError(w, http.StatusNotFound, model.ErrCodeGigWorkerNotFound, "Gig worker not found")
 		return
 	}
+	if _, err := config.DB.Exec(`UPDATE user_sessions SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`, gigWorkerID); err != nil {
+		slog.ErrorContext(r.Context(), "Failed to revoke sessions of deactivated gig worker", "gig_worker_id", gigWorkerID, "error", err)
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -1624,21 +1513,29 @@ func DeactivateGigWorker(w http.ResponseWriter, r *http.Request) {
 // UpdateJob updates a job by ID
 func UpdateJob(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPut {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	idParam := chi.URLParam(r, "id")
 	jobID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid job ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	if !requireJobOwner(w, r, jobID) {
 		return
 	}
 
 	var updateReq model.JobUpdateRequest
 	err = json.NewDecoder(r.Body).Decode(&updateReq)
 	if err != nil {
-		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	if err := validateJobUpdateRequest(&updateReq); err != nil {
+		RespondWithValidationErrors(w, err)
 		return
 	}
 
@@ -1707,48 +1604,111 @@ func UpdateJob(w http.ResponseWriter, r *http.Request) {
 		args = append(args, nullStringInterface(*updateReq.Notes))
 		argIndex++
 	}
+	if updateReq.AccessInstructions != nil {
+		setParts = append(setParts, fmt.Sprintf("access_instructions = $%d", argIndex))
+		args = append(args, nullStringInterface(*updateReq.AccessInstructions))
+		argIndex++
+	}
 
 	if len(setParts) == 0 {
-		http.Error(w, "No fields to update", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "No fields to update")
 		return
 	}
 
 	// Add updated_at and job_id
 	setParts = append(setParts, fmt.Sprintf("updated_at = $%d", argIndex))
-	args = append(args, time.Now())
+	args = append(args, appFrom(r.Context()).Clock.Now())
 	argIndex++
 
 	// Add WHERE clause
 	args = append(args, jobID)
 
-	query := fmt.Sprintf("UPDATE jobs SET %s WHERE id = $%d", strings.Join(setParts, ", "), argIndex)
+	query := fmt.Sprintf("UPDATE jobs SET %s WHERE id = $%d RETURNING status", strings.Join(setParts, ", "), argIndex)
 
-	_, err = config.DB.Exec(query, args...)
+	tx, err := config.DB.Begin()
 	if err != nil {
-		log.Printf("Database error updating job: %v", err)
-		http.Error(w, "Failed to update job", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error updating job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update job")
+		return
+	}
+	defer tx.Rollback()
+
+	// The edited job is rescored; a job still on the board can't lose a required field
+	var status string
+	var completeness jobquality.Report
+	err = tx.QueryRow(query, args...).Scan(&status)
+	if err == nil {
+		var posting *jobquality.Posting
+		posting, err = loadJobPosting(tx, jobID)
+		if err == nil {
+			completeness = jobquality.Check(*posting)
+			if status == "posted" && !completeness.Complete() {
+				respondJobIncomplete(w, completeness)
+				return
+			}
+			_, err = tx.Exec("UPDATE jobs SET completeness_score = $1 WHERE id = $2", completeness.Score, jobID)
+		}
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error updating job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update job")
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
-		"message": "Job updated successfully",
+		"success":      true,
+		"message":      "Job updated successfully",
+		"completeness": completeness,
 	})
 }
 
+// requireJobOwner checks the caller is the consumer who posted the job, or an admin.
+// On failure the error response has already been written.
+func requireJobOwner(w http.ResponseWriter, r *http.Request, jobID int) bool {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return false
+	}
+
+	var consumerID int
+	err := config.DB.QueryRow("SELECT consumer_id FROM jobs WHERE id = $1", jobID).Scan(&consumerID)
+	if err == sql.ErrNoRows {
+		respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
+		return false
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error checking job owner", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return false
+	}
+
+	if consumerID != userID && GetUserRoleFromContext(r) != "admin" {
+		RespondWithError(w, http.StatusForbidden, "Only the job owner can modify this job")
+		return false
+	}
+	return true
+}
+
 // CancelJob cancels a job by ID
 func CancelJob(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	idParam := chi.URLParam(r, "id")
 	jobID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid job ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	if !requireJobOwner(w, r, jobID) {
 		return
 	}
 
@@ -1758,29 +1718,38 @@ func CancelJob(w http.ResponseWriter, r *http.Request) {
 	err = config.DB.QueryRow(checkQuery, jobID).Scan(&currentStatus)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			http.Error(w, "Job not found", http.StatusNotFound)
+			respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
 			return
 		}
-		log.Printf("Database error checking job status: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error checking job status", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
 	// Only allow cancellation of certain statuses
 	if currentStatus == "completed" {
-		http.Error(w, "Cannot cancel a completed job", http.StatusConflict)
+		respondError(w, http.StatusConflict, model.ErrCodeInvalidJobStatus, "Cannot cancel a completed job")
 		return
 	}
 	if currentStatus == "cancelled" {
-		http.Error(w, "Job is already cancelled", http.StatusConflict)
+		respondError(w, http.StatusConflict, model.ErrCodeInvalidJobStatus, "Job is already cancelled")
 		return
 	}
 
-	query := "UPDATE jobs SET status = 'cancelled', updated_at = NOW() WHERE id = $1"
-	_, err = config.DB.Exec(query, jobID)
+	_, err = recordJobEvent(r, jobevents.Transition{
+		JobID: jobID,
+		Type:  jobevents.TypeCancelled,
+		Allowed: func(s jobevents.State) bool {
+			return s.Status != "completed" && s.Status != "cancelled"
+		},
+	})
 	if err != nil {
-		log.Printf("Database error cancelling job: %v", err)
-		http.Error(w, "Failed to cancel job", http.StatusInternalServerError)
+		if errors.Is(err, jobevents.ErrNotAllowed) {
+			RespondWithError(w, http.StatusConflict, "Job status changed, please retry")
+			return
+		}
+		slog.ErrorContext(r.Context(), "Database error cancelling job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to cancel job")
 		return
 	}
 
@@ -1801,29 +1770,28 @@ func CancelJob(w http.ResponseWriter, r *http.Request) {
 // @Param id path int true "Job ID"
 // @Security BearerAuth
 // @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]string "Invalid request"
-// @Failure 403 {object} map[string]string "Unauthorized"
-// @Failure 404 {object} map[string]string "Job not found"
-// @Failure 409 {object} map[string]string "Cannot delete job in current status"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} model.ErrorResponse "BAD_REQUEST"
+// @Failure 403 {object} model.ErrorResponse "FORBIDDEN"
+// @Failure 404 {object} model.ErrorResponse "JOB_NOT_FOUND"
+// @Failure 409 {object} model.ErrorResponse "INVALID_JOB_STATUS: cannot delete job in current status"
+// @Failure 500 {object} model.ErrorResponse "INTERNAL_ERROR"
 // @Router /jobs/{id} [delete]
 func DeleteJob(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	// Get user ID from context (set by JWT middleware)
-	userID, ok := r.Context().Value("user_id").(int)
+	userID, ok := RequireUserID(w, r, 0)
 	if !ok {
-		http.Error(w, "User not authenticated", http.StatusUnauthorized)
 		return
 	}
 
 	idParam := chi.URLParam(r, "id")
 	jobID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid job ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
 		return
 	}
 
@@ -1838,31 +1806,31 @@ func DeleteJob(w http.ResponseWriter, r *http.Request) {
 	err = config.DB.QueryRow(query, jobID).Scan(&job.ID, &consumerID, &job.Status)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			http.Error(w, "Job not found", http.StatusNotFound)
+			respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
 			return
 		}
-		log.Printf("Database error checking job: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error checking job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
 	// Check if user is the job creator
 	if consumerID != userID {
-		http.Error(w, "You can only delete your own jobs", http.StatusForbidden)
+		RespondWithError(w, http.StatusForbidden, "You can only delete your own jobs")
 		return
 	}
 
 	// Only allow deletion of posted or cancelled jobs
 	if job.Status != "posted" && job.Status != "cancelled" {
-		http.Error(w, "Cannot delete job that is in progress or completed", http.StatusConflict)
+		respondError(w, http.StatusConflict, model.ErrCodeInvalidJobStatus, "Cannot delete job that is in progress or completed")
 		return
 	}
 
 	// Begin transaction to ensure data consistency
 	tx, err := config.DB.Begin()
 	if err != nil {
-		log.Printf("Failed to begin transaction: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Failed to begin transaction", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 	defer tx.Rollback()
@@ -1871,24 +1839,24 @@ func DeleteJob(w http.ResponseWriter, r *http.Request) {
 	// Delete job reviews
 	_, err = tx.Exec("DELETE FROM job_reviews WHERE job_id = $1", jobID)
 	if err != nil {
-		log.Printf("Failed to delete job reviews: %v", err)
-		http.Error(w, "Failed to delete job", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Failed to delete job reviews", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to delete job")
 		return
 	}
 
 	// Delete transactions
 	_, err = tx.Exec("DELETE FROM transactions WHERE job_id = $1", jobID)
 	if err != nil {
-		log.Printf("Failed to delete transactions: %v", err)
-		http.Error(w, "Failed to delete job", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Failed to delete transactions", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to delete job")
 		return
 	}
 
 	// Delete schedule entries
 	_, err = tx.Exec("DELETE FROM schedules WHERE job_id = $1", jobID)
 	if err != nil {
-		log.Printf("Failed to delete schedules: %v", err)
-		http.Error(w, "Failed to delete job", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Failed to delete schedules", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to delete job")
 		return
 	}
 
@@ -1896,26 +1864,26 @@ func DeleteJob(w http.ResponseWriter, r *http.Request) {
 	deleteQuery := "DELETE FROM jobs WHERE id = $1"
 	result, err := tx.Exec(deleteQuery, jobID)
 	if err != nil {
-		log.Printf("Database error deleting job: %v", err)
-		http.Error(w, "Failed to delete job", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error deleting job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to delete job")
 		return
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil || rowsAffected == 0 {
-		log.Printf("No rows affected when deleting job: %v", err)
-		http.Error(w, "Failed to delete job", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "No rows affected when deleting job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to delete job")
 		return
 	}
 
 	// Commit the transaction
 	if err = tx.Commit(); err != nil {
-		log.Printf("Failed to commit transaction: %v", err)
-		http.Error(w, "Failed to delete job", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Failed to commit transaction", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to delete job")
 		return
 	}
 
-	log.Printf("Job %d deleted successfully by user %d", jobID, userID)
+	slog.InfoContext(r.Context(), "Job deleted successfully by user", "job_id", jobID, "user_id", userID)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -1928,67 +1896,85 @@ func DeleteJob(w http.ResponseWriter, r *http.Request) {
 // SendJobOffer sends a job offer to a specific gig worker
 func SendJobOffer(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	idParam := chi.URLParam(r, "id")
 	jobID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid job ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
 		return
 	}
 
-	var offerReq struct {
-		GigWorkerID int    `json:"gig_worker_id"`
-		Message     string `json:"message,omitempty"`
+	if !requireJobOwner(w, r, jobID) {
+		return
 	}
 
+	var offerReq model.JobOfferRequest
+
 	err = json.NewDecoder(r.Body).Decode(&offerReq)
 	if err != nil {
-		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
 		return
 	}
 
 	if offerReq.GigWorkerID <= 0 {
-		http.Error(w, "Gig worker ID is required", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Gig worker ID is required")
 		return
 	}
 
-	// Check if job exists and is in the right status
-	var currentStatus string
-	checkQuery := "SELECT status FROM jobs WHERE id = $1"
-	err = config.DB.QueryRow(checkQuery, jobID).Scan(&currentStatus)
+	// Check and record the offer with the job locked, so a worker accepting the job or
+	// a second offer cannot slip in between
+	tx, err := config.DB.BeginTx(r.Context(), nil)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			http.Error(w, "Job not found", http.StatusNotFound)
-			return
-		}
-		log.Printf("Database error checking job status: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Failed to begin transaction", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
+	defer tx.Rollback()
 
-	if currentStatus != "posted" {
-		http.Error(w, "Job must be in posted status to send offers", http.StatusConflict)
+	_, state, err := lockJobTx(r, tx, jobID)
+	if errors.Is(err, jobevents.ErrJobNotFound) {
+		respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error checking job status", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if state.Status != "posted" || state.GigWorkerID != nil {
+		respondError(w, http.StatusConflict, model.ErrCodeInvalidJobStatus, "Job must be in posted status to send offers")
 		return
 	}
 
-	// Update job with gig worker and change status to offer_sent
-	query := `
-		UPDATE jobs 
-		SET gig_worker_id = $1, status = 'offer_sent', updated_at = NOW()
-		WHERE id = $2
-	`
-	_, err = config.DB.Exec(query, offerReq.GigWorkerID, jobID)
+	if !requireWorkerFree(w, r, offerReq.GigWorkerID, jobID) {
+		return
+	}
+
+	// Offer the job to the gig worker and change status to offer_sent
+	_, err = recordJobEventTx(r, tx, jobevents.Transition{
+		JobID:    jobID,
+		Type:     jobevents.TypeOfferSent,
+		WorkerID: &offerReq.GigWorkerID,
+		Allowed:  jobevents.StatusIn("posted"),
+	})
+	if err == nil {
+		err = tx.Commit()
+	}
 	if err != nil {
-		log.Printf("Database error sending job offer: %v", err)
-		http.Error(w, "Failed to send job offer", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error sending job offer", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to send job offer")
 		return
 	}
 
-	// TODO: Send notification to gig worker
-	log.Printf("Job offer sent to gig worker %d for job %d", offerReq.GigWorkerID, jobID)
+	slog.InfoContext(r.Context(), "Job offer sent to gig worker for job", "gig_worker_id", offerReq.GigWorkerID, "job_id", jobID)
+	err = realtime.PublishJobEvent(r.Context(), config.DB, appFrom(r.Context()).Clock, realtime.Event{Type: realtime.EventJobOffer, JobID: jobID, Status: "offer_sent"})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to publish job offer for job", "job_id", jobID, "error", err)
+	}
+	trackFunnel(r, jobID, analytics.StageOfferSent, map[string]interface{}{"gig_worker_id": offerReq.GigWorkerID})
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -2002,19 +1988,14 @@ func SendJobOffer(w http.ResponseWriter, r *http.Request) {
 func GetMyJobs(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	// TODO: Get user ID and role from JWT token
-	// For now, accept user_id and role as query parameters for testing
-	userIDStr := r.URL.Query().Get("user_id")
-	role := r.URL.Query().Get("role")
-
-	if userIDStr == "" || role == "" {
-		http.Error(w, "User ID and role are required", http.StatusBadRequest)
+	// Identity comes from the JWT; legacy user_id/role parameters must agree with it
+	userID, ok := RequireUserID(w, r, ClaimedUserIDParam(r, "user_id"))
+	if !ok {
 		return
 	}
-
-	userID, err := strconv.Atoi(userIDStr)
-	if err != nil {
-		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+	role := GetUserRoleFromContext(r)
+	if claimedRole := r.URL.Query().Get("role"); claimedRole != "" && claimedRole != role {
+		RespondWithError(w, http.StatusForbidden, "Role does not match the authenticated user")
 		return
 	}
 
@@ -2072,7 +2053,7 @@ func GetMyJobs(w http.ResponseWriter, r *http.Request) {
 		args = append(args, userID)
 		argIndex++
 	} else {
-		http.Error(w, "Invalid role", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid role")
 		return
 	}
 
@@ -2086,10 +2067,10 @@ func GetMyJobs(w http.ResponseWriter, r *http.Request) {
 
 	// Get total count
 	var total int
-	err = config.DB.QueryRow(countQuery, args...).Scan(&total)
+	err := config.DB.QueryRow(countQuery, args...).Scan(&total)
 	if err != nil {
-		log.Printf("Error counting user jobs: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Error counting user jobs", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -2101,8 +2082,8 @@ func GetMyJobs(w http.ResponseWriter, r *http.Request) {
 	// Execute query
 	rows, err := config.DB.Query(baseQuery, args...)
 	if err != nil {
-		log.Printf("Error querying user jobs: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Error querying user jobs", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 	defer rows.Close()
@@ -2123,7 +2104,7 @@ func GetMyJobs(w http.ResponseWriter, r *http.Request) {
 			&workerName, &workerUUID,
 		)
 		if err != nil {
-			log.Printf("Error scanning job row: %v", err)
+			slog.ErrorContext(r.Context(), "Error scanning job row", "error", err)
 			continue
 		}
 
@@ -2147,23 +2128,20 @@ func GetMyJobs(w http.ResponseWriter, r *http.Request) {
 
 		jobs = append(jobs, jobResponse)
 	}
+	attachJobMedia(r, jobs)
 
 	// Calculate pagination metadata
 	pages := (total + limit - 1) / limit
-	response := model.JobsListResponse{
-		Jobs: jobs,
-		Pagination: model.Pagination{
-			Page:    page,
-			Limit:   limit,
-			Total:   total,
-			Pages:   pages,
-			HasNext: page < pages,
-			HasPrev: page > 1,
-		},
-	}
 
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(response)
+	// Encoded a job at a time, in the shape of model.JobsListResponse
+	writeJSONList(w, "jobs", jobs, model.Pagination{
+		Page:    page,
+		Limit:   limit,
+		Total:   total,
+		Pages:   pages,
+		HasNext: page < pages,
+		HasPrev: page > 1,
+	})
 }
 
 // GetAvailableJobs retrieves available jobs for gig workers
@@ -2221,7 +2199,7 @@ func GetAvailableJobs(w http.ResponseWriter, r *http.Request) {
 
 	// TODO: Add distance filtering based on location
 	if maxDistance != "" {
-		log.Printf("Distance filtering requested: %s km (not yet implemented)", maxDistance)
+		slog.InfoContext(r.Context(), "Distance filtering requested but not yet implemented", "max_distance_km", maxDistance)
 	}
 
 	// Add WHERE clauses if we have filters
@@ -2235,8 +2213,8 @@ func GetAvailableJobs(w http.ResponseWriter, r *http.Request) {
 	var total int
 	err := config.DB.QueryRow(countQuery, args...).Scan(&total)
 	if err != nil {
-		log.Printf("Error counting available jobs: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Error counting available jobs", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -2248,8 +2226,8 @@ func GetAvailableJobs(w http.ResponseWriter, r *http.Request) {
 	// Execute query
 	rows, err := config.DB.Query(baseQuery, args...)
 	if err != nil {
-		log.Printf("Error querying available jobs: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Error querying available jobs", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 	defer rows.Close()
@@ -2268,7 +2246,7 @@ func GetAvailableJobs(w http.ResponseWriter, r *http.Request) {
 			&consumerName, &consumerUUID,
 		)
 		if err != nil {
-			log.Printf("Error scanning job row: %v", err)
+			slog.ErrorContext(r.Context(), "Error scanning job row", "error", err)
 			continue
 		}
 
@@ -2284,22 +2262,22 @@ func GetAvailableJobs(w http.ResponseWriter, r *http.Request) {
 		jobs = append(jobs, jobResponse)
 	}
 
+	// Let workers vet the consumers behind each job
+	attachConsumerTrustSignals(r.Context(), jobs)
+	attachJobMedia(r, jobs)
+
 	// Calculate pagination metadata
 	pages := (total + limit - 1) / limit
-	response := model.JobsListResponse{
-		Jobs: jobs,
-		Pagination: model.Pagination{
-			Page:    page,
-			Limit:   limit,
-			Total:   total,
-			Pages:   pages,
-			HasNext: page < pages,
-			HasPrev: page > 1,
-		},
-	}
 
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(response)
+	// Encoded a job at a time, in the shape of model.JobsListResponse
+	writeJSONList(w, "jobs", jobs, model.Pagination{
+		Page:    page,
+		Limit:   limit,
+		Total:   total,
+		Pages:   pages,
+		HasNext: page < pages,
+		HasPrev: page > 1,
+	})
 }
 
 // Helper functions for handling nullable database fields
@@ -2310,44 +2288,6 @@ func nullIntPtr(i *int) interface{} {
 	return *i
 }
 
-// validateJobCreateRequest validates the job creation request
-func validateJobCreateRequest(req *model.JobCreateRequest) error {
-	if req.Title == "" {
-		return fmt.Errorf("title is required")
-	}
-	if len(req.Title) < 3 || len(req.Title) > 255 {
-		return fmt.Errorf("title must be between 3 and 255 characters")
-	}
-
-	if req.Description == "" {
-		return fmt.Errorf("description is required")
-	}
-	if len(req.Description) < 10 {
-		return fmt.Errorf("description must be at least 10 characters")
-	}
-
-	if req.EstimatedDurationHours != nil && *req.EstimatedDurationHours <= 0 {
-		return fmt.Errorf("estimated duration must be greater than 0")
-	}
-
-	if req.PayRatePerHour != nil && *req.PayRatePerHour <= 0 {
-		return fmt.Errorf("pay rate must be greater than 0")
-	}
-
-	if req.TotalPay != nil && *req.TotalPay <= 0 {
-		return fmt.Errorf("total pay must be greater than 0")
-	}
-
-	// Validate time constraints
-	if req.ScheduledStart != nil && req.ScheduledEnd != nil {
-		if req.ScheduledEnd.Before(*req.ScheduledStart) {
-			return fmt.Errorf("scheduled end time must be after start time")
-		}
-	}
-
-	return nil
-}
-
 // GetUserProfile godoc
 // @Summary Get current user profile
 // @Description Get the profile of the currently authenticated user
@@ -2356,24 +2296,15 @@ func validateJobCreateRequest(req *model.JobCreateRequest) error {
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {object} model.User
-// @Failure 401 {object} map[string]string "Unauthorized"
-// @Failure 404 {object} map[string]string "User not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 401 {object} model.ErrorResponse "UNAUTHORIZED"
+// @Failure 404 {object} model.ErrorResponse "USER_NOT_FOUND"
+// @Failure 500 {object} model.ErrorResponse "INTERNAL_ERROR"
 // @Router /users/profile [get]
 func GetUserProfile(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	// TODO: Get user ID from JWT token
-	// For now, accept user_id as query parameter for testing
-	userIDStr := r.URL.Query().Get("user_id")
-	if userIDStr == "" {
-		http.Error(w, "User ID is required", http.StatusBadRequest)
-		return
-	}
-
-	userID, err := strconv.Atoi(userIDStr)
-	if err != nil {
-		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+	userID, ok := RequireUserID(w, r, ClaimedUserIDParam(r, "user_id"))
+	if !ok {
 		return
 	}
 
@@ -2387,7 +2318,7 @@ func GetUserProfile(w http.ResponseWriter, r *http.Request) {
 	var phone, placeID sql.NullString
 	var latitude, longitude sql.NullFloat64
 
-	err = config.DB.QueryRow(query, userID).Scan(
+	err := config.DB.QueryRow(query, userID).Scan(
 		&user.ID, &user.Uuid, &user.Name, &user.Email, &phone, &user.Address,
 		&latitude, &longitude, &placeID, &user.Role, &user.IsActive,
 		&user.EmailVerified, &user.PhoneVerified, &user.CreatedAt, &user.UpdatedAt,
@@ -2395,13 +2326,11 @@ func GetUserProfile(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(model.ErrorResponse{Error: "User not found"})
+			respondError(w, http.StatusNotFound, model.ErrCodeUserNotFound, "User not found")
 			return
 		}
-		log.Printf("Database error: %v", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(model.ErrorResponse{Error: "Internal server error"})
+		slog.ErrorContext(r.Context(), "Database error", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -2426,36 +2355,24 @@ func GetUserProfile(w http.ResponseWriter, r *http.Request) {
 // UpdateUserProfile updates the current user's profile
 func UpdateUserProfile(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPut {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	// TODO: Get user ID from JWT token
-	// For now, accept user_id as query parameter for testing
-	userIDStr := r.URL.Query().Get("user_id")
-	if userIDStr == "" {
-		http.Error(w, "User ID is required", http.StatusBadRequest)
+	userID, ok := RequireUserID(w, r, ClaimedUserIDParam(r, "user_id"))
+	if !ok {
 		return
 	}
 
-	userID, err := strconv.Atoi(userIDStr)
+	var updateReq model.UserProfileUpdateRequest
+
+	err := json.NewDecoder(r.Body).Decode(&updateReq)
 	if err != nil {
-		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
 		return
 	}
-
-	var updateReq struct {
-		Name      *string  `json:"name,omitempty"`
-		Phone     *string  `json:"phone,omitempty"`
-		Address   *string  `json:"address,omitempty"`
-		Latitude  *float64 `json:"latitude,omitempty"`
-		Longitude *float64 `json:"longitude,omitempty"`
-		PlaceID   *string  `json:"place_id,omitempty"`
-	}
-
-	err = json.NewDecoder(r.Body).Decode(&updateReq)
-	if err != nil {
-		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
+	if err := validateUserProfileUpdateRequest(&updateReq); err != nil {
+		RespondWithValidationErrors(w, err)
 		return
 	}
 
@@ -2471,6 +2388,8 @@ func UpdateUserProfile(w http.ResponseWriter, r *http.Request) {
 	}
 	if updateReq.Phone != nil {
 		setParts = append(setParts, fmt.Sprintf("phone = $%d", argIndex))
+		// A new number has to be verified again
+		setParts = append(setParts, fmt.Sprintf("phone_verified = phone_verified AND phone IS NOT DISTINCT FROM $%d", argIndex))
 		args = append(args, nullStringInterface(*updateReq.Phone))
 		argIndex++
 	}
@@ -2496,13 +2415,13 @@ func UpdateUserProfile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if len(setParts) == 0 {
-		http.Error(w, "No fields to update", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "No fields to update")
 		return
 	}
 
 	// Add updated_at and user_id
 	setParts = append(setParts, fmt.Sprintf("updated_at = $%d", argIndex))
-	args = append(args, time.Now())
+	args = append(args, appFrom(r.Context()).Clock.Now())
 	argIndex++
 
 	// Add WHERE clause
@@ -2510,12 +2429,14 @@ func UpdateUserProfile(w http.ResponseWriter, r *http.Request) {
 
 	query := fmt.Sprintf("UPDATE people SET %s WHERE id = $%d", strings.Join(setParts, ", "), argIndex)
 
+	before := profileSnapshot(r, userID)
 	_, err = config.DB.Exec(query, args...)
 	if err != nil {
-		log.Printf("Database error updating user: %v", err)
-		http.Error(w, "Failed to update user", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error updating user", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update user")
 		return
 	}
+	auditProfileUpdate(r, userID, before, updateReq)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -2532,8 +2453,7 @@ func GetUserByID(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
 	userID, err := strconv.Atoi(idParam)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(model.ErrorResponse{Error: "Invalid user ID format"})
+		RespondWithError(w, http.StatusBadRequest, "Invalid user ID format")
 		return
 	}
 
@@ -2555,13 +2475,11 @@ func GetUserByID(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(model.ErrorResponse{Error: "User not found"})
+			respondError(w, http.StatusNotFound, model.ErrCodeUserNotFound, "User not found")
 			return
 		}
-		log.Printf("Database error: %v", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(model.ErrorResponse{Error: "Internal server error"})
+		slog.ErrorContext(r.Context(), "Database error", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -2587,32 +2505,26 @@ func GetUserByID(w http.ResponseWriter, r *http.Request) {
 // UpdateUser updates a user by ID (admin function)
 func UpdateUser(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPut {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	idParam := chi.URLParam(r, "id")
 	userID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid user ID format")
 		return
 	}
 
-	var updateReq struct {
-		Name          *string  `json:"name,omitempty"`
-		Phone         *string  `json:"phone,omitempty"`
-		Address       *string  `json:"address,omitempty"`
-		Latitude      *float64 `json:"latitude,omitempty"`
-		Longitude     *float64 `json:"longitude,omitempty"`
-		PlaceID       *string  `json:"place_id,omitempty"`
-		IsActive      *bool    `json:"is_active,omitempty"`
-		EmailVerified *bool    `json:"email_verified,omitempty"`
-		PhoneVerified *bool    `json:"phone_verified,omitempty"`
-	}
+	var updateReq model.UserUpdateRequest
 
 	err = json.NewDecoder(r.Body).Decode(&updateReq)
 	if err != nil {
-		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	if err := validateUserUpdateRequest(&updateReq); err != nil {
+		RespondWithValidationErrors(w, err)
 		return
 	}
 
@@ -2628,6 +2540,10 @@ func UpdateUser(w http.ResponseWriter, r *http.Request) {
 	}
 	if updateReq.Phone != nil {
 		setParts = append(setParts, fmt.Sprintf("phone = $%d", argIndex))
+		if updateReq.PhoneVerified == nil {
+			// A new number has to be verified again
+			setParts = append(setParts, fmt.Sprintf("phone_verified = phone_verified AND phone IS NOT DISTINCT FROM $%d", argIndex))
+		}
 		args = append(args, nullStringInterface(*updateReq.Phone))
 		argIndex++
 	}
@@ -2668,13 +2584,13 @@ func UpdateUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if len(setParts) == 0 {
-		http.Error(w, "No fields to update", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "No fields to update")
 		return
 	}
 
 	// Add updated_at and user_id
 	setParts = append(setParts, fmt.Sprintf("updated_at = $%d", argIndex))
-	args = append(args, time.Now())
+	args = append(args, appFrom(r.Context()).Clock.Now())
 	argIndex++
 
 	// Add WHERE clause
@@ -2682,12 +2598,14 @@ func UpdateUser(w http.ResponseWriter, r *http.Request) {
 
 	query := fmt.Sprintf("UPDATE people SET %s WHERE id = $%d", strings.Join(setParts, ", "), argIndex)
 
+	before := profileSnapshot(r, userID)
 	_, err = config.DB.Exec(query, args...)
 	if err != nil {
-		log.Printf("Database error updating user: %v", err)
-		http.Error(w, "Failed to update user", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error updating user", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update user")
 		return
 	}
+	auditProfileUpdate(r, userID, before, updateReq)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -2700,22 +2618,22 @@ func UpdateUser(w http.ResponseWriter, r *http.Request) {
 // DeactivateUser deactivates a user account
 func DeactivateUser(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	idParam := chi.URLParam(r, "id")
 	userID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid user ID format")
 		return
 	}
 
 	query := "UPDATE people SET is_active = false, updated_at = NOW() WHERE id = $1"
 	_, err = config.DB.Exec(query, userID)
 	if err != nil {
-		log.Printf("Database error deactivating user: %v", err)
-		http.Error(w, "Failed to deactivate user", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error deactivating user", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to deactivate user")
 		return
 	}
 
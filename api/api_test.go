@@ -0,0 +1,61 @@
+package api
+
+import (
+	"testing"
+
+	"app/internal/jobevents"
+)
+
+func TestAcceptJobConflict(t *testing.T) {
+	worker, other := 7, 9
+
+	tests := []struct {
+		name  string
+		state jobevents.State
+		want  string
+	}{
+		{name: "posted", state: jobevents.State{Status: "posted"}},
+		{name: "accepted by the caller", state: jobevents.State{Status: "accepted", GigWorkerID: &worker}, want: "You have already accepted this job"},
+		{name: "accepted by another worker", state: jobevents.State{Status: "accepted", GigWorkerID: &other}, want: "Job has already been accepted by another worker"},
+		{name: "offered to another worker", state: jobevents.State{Status: "offer_sent", GigWorkerID: &other}, want: "Job is not available for acceptance (current status: offer_sent)"},
+		{name: "cancelled without a worker", state: jobevents.State{Status: "cancelled"}, want: "Job is not available for acceptance (current status: cancelled)"},
+		{name: "handed off", state: jobevents.State{Status: "accepted"}, want: "Job is not available for acceptance (current status: accepted)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := acceptJobConflict(tt.state, worker); got != tt.want {
+				t.Errorf("acceptJobConflict() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestAcceptJobInTurn checks what workers accepting the same job are told. AcceptJob
+// holds the job's row lock while it checks and accepts, so accepts run one after
+// another, each seeing the state the previous one left; the lock itself is
+// Postgres's and is not exercised here.
+func TestAcceptJobInTurn(t *testing.T) {
+	const workers = 5
+	state := jobevents.State{Status: "posted"}
+
+	for workerID := 1; workerID <= workers; workerID++ {
+		conflict := acceptJobConflict(state, workerID)
+		switch {
+		case workerID == 1 && conflict != "":
+			t.Fatalf("first worker got %q; want the job", conflict)
+		case workerID > 1 && conflict != "Job has already been accepted by another worker":
+			t.Errorf("worker %d got %q; want the job taken", workerID, conflict)
+		}
+		if conflict == "" {
+			state = jobevents.Apply(state, jobevents.Event{Type: jobevents.TypeAccepted, Status: "accepted", WorkerID: &workerID})
+		}
+	}
+
+	if state.GigWorkerID == nil || *state.GigWorkerID != 1 {
+		t.Errorf("job went to worker %v; want worker 1", state.GigWorkerID)
+	}
+	if got := acceptJobConflict(state, 1); got != "You have already accepted this job" {
+		t.Errorf("first worker accepting again got %q", got)
+	}
+}
@@ -0,0 +1,390 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/auth"
+	"app/internal/model"
+	"app/internal/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// attachmentURLTTL is how long attachment download links stay valid
+const attachmentURLTTL = 15 * time.Minute
+
+// storageRedirectTTL is how long the storage URL a download link redirects to stays
+// valid; it only needs to outlive the redirect
+const storageRedirectTTL = time.Minute
+
+const attachmentColumns = `
+	id, uuid, owner_type, owner_id, kind, object_key, content_type, size_bytes, uploaded_by,
+	scan_status, scanner, scan_threat, scanned_at, width, height, thumbnail_key, created_at
+`
+
+// scanAttachmentRow scans an attachments row selected with attachmentColumns
+func scanAttachmentRow(row rowScanner) (*model.Attachment, error) {
+	var a model.Attachment
+	var uploadedBy sql.NullInt64
+	var scanner, threat sql.NullString
+	var scannedAt sql.NullTime
+
+	err := row.Scan(
+		&a.ID, &a.UUID, &a.OwnerType, &a.OwnerID, &a.Kind, &a.ObjectKey, &a.ContentType,
+		&a.SizeBytes, &uploadedBy, &a.ScanStatus, &scanner, &threat, &scannedAt,
+		&a.Width, &a.Height, &a.ThumbnailKey, &a.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	a.UploadedBy = intPtrFromNull(uploadedBy)
+	a.Scanner = stringPtrFromNull(scanner)
+	a.ScanThreat = stringPtrFromNull(threat)
+	a.ScannedAt = timePtrFromNull(scannedAt)
+	return &a, nil
+}
+
+// attachmentServable reports whether an attachment may be downloaded. Infected files
+// are quarantined and failed scans must be rescanned first.
+func attachmentServable(a *model.Attachment) bool {
+	return a.ScanStatus == storage.ScanClean || a.ScanStatus == storage.ScanSkipped
+}
+
+// saveAttachment scans and stores the multipart "file" field of r and records it
+// against its owner. The attachment is returned even when it was quarantined;
+// callers check ScanStatus. Returns false after writing an error response.
+func saveAttachment(w http.ResponseWriter, r *http.Request, kind, ownerType string, ownerID int, prefix string) (*model.Attachment, bool) {
+	return saveUpload(w, r, kind, ownerType, ownerID, prefix, storage.Save)
+}
+
+// savePhoto is saveAttachment for photos: it also checks that the photo's shorter side
+// is at least minSide pixels and stores a thumbnail
+func savePhoto(w http.ResponseWriter, r *http.Request, ownerType string, ownerID int, prefix string, minSide int) (*model.Attachment, bool) {
+	return saveUpload(w, r, storage.KindPhoto, ownerType, ownerID, prefix, func(ctx context.Context, store storage.Store, u storage.Upload) (*storage.Object, error) {
+		return storage.SavePhoto(ctx, store, u, minSide)
+	})
+}
+
+// saveUpload stores the multipart "file" field of r with save and records it
+func saveUpload(w http.ResponseWriter, r *http.Request, kind, ownerType string, ownerID int, prefix string,
+	save func(context.Context, storage.Store, storage.Upload) (*storage.Object, error)) (*model.Attachment, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, storage.MaxBytes(kind)+1<<20)
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Upload must be multipart/form-data with a file of at most %d MB", storage.MaxBytes(kind)>>20))
+		return nil, false
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		RespondWithValidationError(w, &ValidationError{Field: "file", Message: "is required"})
+		return nil, false
+	}
+	defer file.Close()
+
+	store, err := getAttachmentStore()
+	if err != nil {
+		RespondWithError(w, http.StatusServiceUnavailable, "Uploads are temporarily unavailable")
+		return nil, false
+	}
+	scanner, err := getAttachmentScanner()
+	if err != nil {
+		RespondWithError(w, http.StatusServiceUnavailable, "Uploads are temporarily unavailable")
+		return nil, false
+	}
+
+	obj, err := save(r.Context(), store, storage.Upload{
+		Kind:         kind,
+		Prefix:       prefix,
+		Body:         file,
+		Size:         header.Size,
+		DeclaredType: header.Header.Get("Content-Type"),
+		Scanner:      scanner,
+	})
+	switch {
+	case errors.Is(err, storage.ErrTooLarge):
+		RespondWithError(w, http.StatusRequestEntityTooLarge, err.Error())
+		return nil, false
+	case errors.Is(err, storage.ErrUnsupportedType), errors.Is(err, storage.ErrTypeMismatch):
+		RespondWithError(w, http.StatusUnsupportedMediaType, err.Error())
+		return nil, false
+	case errors.Is(err, storage.ErrImageDimensions):
+		RespondWithError(w, http.StatusUnprocessableEntity, err.Error())
+		return nil, false
+	case err != nil:
+		slog.ErrorContext(r.Context(), "Failed to store attachment", "kind", kind, "owner_type", ownerType, "owner_id", ownerID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return nil, false
+	}
+
+	attachment, err := recordAttachment(config.DB, obj, kind, ownerType, ownerID, GetUserIDFromContext(r), scanner, appFrom(r.Context()).Clock.Now())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error recording attachment", "key", obj.Key, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return nil, false
+	}
+
+	if attachment.ScanStatus == storage.ScanInfected {
+		slog.WarnContext(r.Context(), "Attachment quarantined", "attachment_id", attachment.ID, "user_id", GetUserIDFromContext(r), "threat", obj.Threat)
+	}
+	return attachment, true
+}
+
+// recordAttachment inserts the attachments row for a stored object. scanner is the
+// one the object was scanned with, or nil when scanning is turned off.
+func recordAttachment(q queryRower, obj *storage.Object, kind, ownerType string, ownerID, uploadedBy int, scanner storage.Scanner, now time.Time) (*model.Attachment, error) {
+	var scannerName sql.NullString
+	var scannedAt sql.NullTime
+	if scanner != nil {
+		scannerName = sql.NullString{String: scanner.Name(), Valid: true}
+		if obj.ScanStatus != storage.ScanFailed {
+			scannedAt = sql.NullTime{Time: now, Valid: true}
+		}
+	}
+	return scanAttachmentRow(q.QueryRow(`
+		INSERT INTO attachments (owner_type, owner_id, kind, object_key, content_type, size_bytes, uploaded_by,
+			scan_status, scanner, scan_threat, scanned_at, width, height, thumbnail_key)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11, NULLIF($12, 0), NULLIF($13, 0), NULLIF($14, ''))
+		RETURNING `+attachmentColumns,
+		ownerType, ownerID, kind, obj.Key, obj.ContentType, obj.Size, uploadedBy,
+		obj.ScanStatus, scannerName, obj.Threat, scannedAt, obj.Width, obj.Height, obj.ThumbnailKey,
+	))
+}
+
+// UploadExpenseReceipt attaches a receipt file to one of the worker's expenses
+func UploadExpenseReceipt(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+	expenseID, err := strconv.Atoi(chi.URLParam(r, "expenseId"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid expense ID format")
+		return
+	}
+
+	var workerID int
+	err = config.DB.QueryRow(`SELECT gig_worker_id FROM job_expenses WHERE id = $1 AND job_id = $2`, expenseID, jobID).Scan(&workerID)
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Expense not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting expense", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if workerID != userID {
+		RespondWithError(w, http.StatusForbidden, "Only the worker who logged the expense can attach receipts")
+		return
+	}
+
+	attachment, ok := saveAttachment(w, r, storage.KindReceipt, model.AttachmentOwnerExpense, expenseID,
+		fmt.Sprintf("jobs/%d/expenses/%d", jobID, expenseID))
+	if !ok {
+		return
+	}
+	if attachment.ScanStatus == storage.ScanInfected {
+		RespondWithError(w, http.StatusUnprocessableEntity, "The file failed a malware scan and was not accepted")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, attachment)
+}
+
+// GetAttachment returns an attachment's record and scan status, with a short-lived
+// download link when it is safe to serve
+func GetAttachment(w http.ResponseWriter, r *http.Request) {
+	attachmentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid attachment ID format")
+		return
+	}
+
+	attachment, err := scanAttachmentRow(config.DB.QueryRow(`SELECT `+attachmentColumns+` FROM attachments WHERE id = $1`, attachmentID))
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Attachment not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting attachment", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	response := map[string]interface{}{"attachment": attachment}
+	if attachmentServable(attachment) {
+		downloadURL, err := attachmentDownloadURL(attachment)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Failed to sign attachment", "attachment_id", attachment.ID, "error", err)
+			RespondWithError(w, http.StatusServiceUnavailable, "Downloads are temporarily unavailable")
+			return
+		}
+		response["download_url"] = downloadURL
+		response["expires_at"] = appFrom(r.Context()).Clock.Now().Add(attachmentURLTTL)
+	}
+
+	RespondWithJSON(w, http.StatusOK, response)
+}
+
+// RescanAttachment scans a stored attachment again, e.g. after the scanner was down
+// on upload. Attachments found infected are moved to the quarantine.
+func RescanAttachment(w http.ResponseWriter, r *http.Request) {
+	attachmentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid attachment ID format")
+		return
+	}
+
+	attachment, err := scanAttachmentRow(config.DB.QueryRow(`SELECT `+attachmentColumns+` FROM attachments WHERE id = $1`, attachmentID))
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Attachment not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting attachment", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if attachment.ScanStatus == storage.ScanInfected {
+		RespondWithError(w, http.StatusConflict, "Attachment is already quarantined")
+		return
+	}
+
+	store, err := getAttachmentStore()
+	if err != nil {
+		RespondWithError(w, http.StatusServiceUnavailable, "Attachment storage is temporarily unavailable")
+		return
+	}
+	scanner, err := getAttachmentScanner()
+	if err != nil || scanner == nil {
+		RespondWithError(w, http.StatusServiceUnavailable, "Malware scanning is not configured")
+		return
+	}
+
+	obj, err := storage.Rescan(r.Context(), store, scanner, attachment.ObjectKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		RespondWithError(w, http.StatusNotFound, "Attachment file is missing")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to rescan attachment", "attachment_id", attachment.ID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	attachment, err = scanAttachmentRow(config.DB.QueryRow(`
+		UPDATE attachments
+		SET object_key = $2, scan_status = $3, scanner = $4, scan_threat = NULLIF($5, ''),
+			scanned_at = CASE WHEN $3 = 'failed' THEN scanned_at ELSE NOW() END
+		WHERE id = $1
+		RETURNING `+attachmentColumns,
+		attachment.ID, obj.Key, obj.ScanStatus, scanner.Name(), obj.Threat,
+	))
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error updating attachment scan", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, attachment)
+}
+
+// attachmentDownloadURL returns a link to DownloadAttachment carrying a token scoped
+// to the attachment
+func attachmentDownloadURL(a *model.Attachment) (string, error) {
+	token, err := auth.IssueScopedToken(auth.ScopeDownloadAttachment(a.ID), 0, attachmentURLTTL)
+	if err != nil {
+		return "", err
+	}
+	baseURL := strings.TrimRight(config.LoadStorageConfig().PublicURL, "/")
+	return fmt.Sprintf("%s/api/v1/attachments/%d/download?token=%s", baseURL, a.ID, url.QueryEscape(token)), nil
+}
+
+// photoURLs returns download links for a photo and its thumbnail, or nils when the
+// photo has not passed the malware scan. Photos without a thumbnail use the full image.
+func photoURLs(a *model.Attachment) (photoURL, thumbnailURL *string, err error) {
+	if !attachmentServable(a) {
+		return nil, nil, nil
+	}
+	downloadURL, err := attachmentDownloadURL(a)
+	if err != nil {
+		return nil, nil, err
+	}
+	thumbnail := downloadURL
+	if a.ThumbnailKey != nil {
+		thumbnail += "&size=" + thumbnailSize
+	}
+	return &downloadURL, &thumbnail, nil
+}
+
+// thumbnailSize is the download link size parameter selecting a photo's thumbnail
+const thumbnailSize = "thumbnail"
+
+// DownloadAttachment redirects a download link to the stored file, or to a photo's
+// thumbnail with size=thumbnail. The link's token is scoped to this attachment, and the
+// scan status is checked again, so files quarantined after the link was issued are not
+// served.
+func DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	attachmentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid attachment ID format")
+		return
+	}
+
+	if _, err := auth.ValidateScopedToken(r.URL.Query().Get("token"), auth.ScopeDownloadAttachment(attachmentID)); err != nil {
+		RespondWithError(w, http.StatusForbidden, "Link is invalid or has expired")
+		return
+	}
+
+	attachment, err := scanAttachmentRow(config.DB.QueryRow(`SELECT `+attachmentColumns+` FROM attachments WHERE id = $1`, attachmentID))
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Attachment not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting attachment", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if !attachmentServable(attachment) {
+		RespondWithError(w, http.StatusNotFound, "Attachment not found")
+		return
+	}
+
+	store, err := getAttachmentStore()
+	if err != nil {
+		RespondWithError(w, http.StatusServiceUnavailable, "Downloads are temporarily unavailable")
+		return
+	}
+	key := attachment.ObjectKey
+	if r.URL.Query().Get("size") == thumbnailSize && attachment.ThumbnailKey != nil {
+		key = *attachment.ThumbnailKey
+	}
+	storageURL, err := store.SignedURL(r.Context(), key, storageRedirectTTL)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to sign attachment", "attachment_id", attachment.ID, "error", err)
+		RespondWithError(w, http.StatusServiceUnavailable, "Downloads are temporarily unavailable")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, storageURL, http.StatusFound)
+}
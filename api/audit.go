@@ -0,0 +1,192 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/audit"
+	"app/internal/middleware"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ==============================================
+// AUDIT LOG
+// ==============================================
+
+// newAuditEntry starts an audit entry for the caller acting on an entity through this
+// request's route
+func newAuditEntry(r *http.Request, action, entityType string, entityID int) audit.Entry {
+	e := audit.Entry{
+		ActorRole:  GetUserRoleFromContext(r),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   strconv.Itoa(entityID),
+		Method:     r.Method,
+		IP:         middleware.ClientIP(r),
+	}
+	if userID := GetUserIDFromContext(r); userID > 0 {
+		e.ActorID = &userID
+	}
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		e.Route = rctx.RoutePattern()
+	}
+	return e
+}
+
+// recordAudit records an entry for a change that has already been made. Failures are
+// logged rather than failing the request.
+func recordAudit(r *http.Request, e audit.Entry) {
+	if err := audit.Record(r.Context(), config.DB, e); err != nil {
+		slog.ErrorContext(r.Context(), "Failed to record audit event", "action", e.Action, "entity_type", e.EntityType, "entity_id", e.EntityID, "error", err)
+	}
+}
+
+// profileAuditColumns are the account and worker profile columns profile edits can
+// change, named as in the update requests
+const profileAuditColumns = `
+	p.name, p.phone, p.address, p.latitude, p.longitude, p.place_id, p.is_active,
+	p.email_verified, p.phone_verified, wp.bio, wp.hourly_rate, wp.experience_years,
+	wp.verification_status, wp.background_check_date, wp.service_radius_miles,
+	wp.availability_notes`
+
+// profileSnapshot reads a user's profile before an edit, for auditing the fields the
+// edit changes. A failed read is logged and audits the edit without previous values.
+func profileSnapshot(r *http.Request, userID int) map[string]interface{} {
+	snapshot, err := audit.Snapshot(r.Context(), config.DB, `
+		SELECT`+profileAuditColumns+`
+		FROM people p
+		LEFT JOIN worker_profiles wp ON wp.worker_id = p.id
+		WHERE p.id = $1
+	`, userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to read profile for audit", "user_id", userID, "error", err)
+		return nil
+	}
+	return snapshot
+}
+
+// auditProfileUpdate records the fields an update request changed on a user's profile
+func auditProfileUpdate(r *http.Request, userID int, before map[string]interface{}, updateReq interface{}) {
+	changes, err := audit.Patch(before, updateReq)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to diff profile for audit", "user_id", userID, "error", err)
+	}
+	// Emergency contacts live in the vault; the log only notes that they changed
+	audit.Redact(changes, "emergency_contact_name", "emergency_contact_phone", "emergency_contact_relationship")
+
+	e := newAuditEntry(r, audit.ActionProfileUpdated, audit.EntityUser, userID)
+	e.Changes = changes
+	recordAudit(r, e)
+}
+
+// paymentSnapshot reads the audited columns of a transaction. A failed read is logged
+// and audits the payment without previous values.
+func paymentSnapshot(r *http.Request, transactionID int) map[string]interface{} {
+	snapshot, err := audit.Snapshot(r.Context(), config.DB, `
+		SELECT status, amount, capture_amount, captured_at, refund_amount, refunded_at, refund_reason
+		FROM transactions
+		WHERE id = $1
+	`, transactionID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to read transaction for audit", "transaction_id", transactionID, "error", err)
+		return nil
+	}
+	return snapshot
+}
+
+// auditPayment records how a payment operation changed a transaction. before is nil
+// for a transaction the operation created.
+func auditPayment(r *http.Request, action string, transactionID int, before map[string]interface{}, metadata map[string]interface{}) {
+	changes, err := audit.Diff(before, paymentSnapshot(r, transactionID))
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to diff transaction for audit", "transaction_id", transactionID, "error", err)
+	}
+	e := newAuditEntry(r, action, audit.EntityTransaction, transactionID)
+	e.Changes = changes
+	e.Metadata = metadata
+	recordAudit(r, e)
+}
+
+// GetAuditEvents searches the audit log, newest first. format=csv or format=ndjson
+// streams the matches as a download.
+func GetAuditEvents(w http.ResponseWriter, r *http.Request) {
+	listing, ok := parseAdminListing(w, r)
+	if !ok {
+		return
+	}
+
+	var filter audit.Filter
+	actorID, err := ParseIntParam(r, "actor_id", 0, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	filter.ActorID = actorID
+	filter.Action = r.URL.Query().Get("action")
+	filter.EntityType = r.URL.Query().Get("entity_type")
+	filter.EntityID = r.URL.Query().Get("entity_id")
+	if filter.EntityID != "" && filter.EntityType == "" {
+		RespondWithValidationError(w, &ValidationError{Field: "entity_type", Message: "is required with entity_id"})
+		return
+	}
+	if filter.From, err = ParseDateParam(r, "from"); err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	if filter.To, err = ParseDateParam(r, "to"); err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	if listing.export != "" {
+		header := []string{"id", "created_at", "actor_id", "actor_role", "action",
+			"entity_type", "entity_id", "method", "route", "ip_address", "changed_fields"}
+		// The download starts with the first event, so a failed query still gets an error response
+		var export *rowExport
+		err := audit.Each(r.Context(), config.DB, filter, listing.limit, 0, func(e audit.Event) error {
+			if export == nil {
+				export = listing.startExport(w, r, "audit-events", header)
+			}
+			return export.write(e, func() []string {
+				actorID := ""
+				if e.ActorID != nil {
+					actorID = strconv.Itoa(*e.ActorID)
+				}
+				return []string{
+					strconv.FormatInt(e.ID, 10), e.CreatedAt.Format(time.RFC3339), actorID, formatOptionalString(e.ActorRole),
+					e.Action, e.EntityType, formatOptionalString(e.EntityID), formatOptionalString(e.Method), formatOptionalString(e.Route),
+					formatOptionalString(e.IP), strings.Join(audit.ChangedFields(e.Changes), " "),
+				}
+			})
+		})
+		if export == nil {
+			if err != nil {
+				slog.ErrorContext(r.Context(), "Database error exporting audit events", "error", err)
+				RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+				return
+			}
+			export = listing.startExport(w, r, "audit-events", header)
+		}
+		export.finish()
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Audit event export stopped", "rows", export.rows, "error", err)
+		}
+		return
+	}
+
+	events, total, err := audit.List(r.Context(), config.DB, filter, listing.limit, (listing.page-1)*listing.limit)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing audit events", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"events":     events,
+		"pagination": listing.pagination(total),
+	})
+}
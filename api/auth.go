@@ -3,15 +3,21 @@ package api
 import (
 	"app/config"
 	"app/internal/auth"
+	"app/internal/email"
+	"app/internal/links"
+	"app/internal/middleware"
 	"app/internal/model"
+	"app/internal/validate"
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
-	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/lib/pq"
@@ -46,7 +52,8 @@ type RegisterResponse struct {
 	EmailVerified bool      `json:"email_verified"`
 	PhoneVerified bool      `json:"phone_verified"`
 	CreatedAt     time.Time `json:"created_at"`
-	Token         string    `json:"token,omitempty"` // JWT token (placeholder for now)
+	Token         string    `json:"token,omitempty"`         // Access token
+	RefreshToken  string    `json:"refresh_token,omitempty"` // Exchanged at /auth/refresh for a new access token
 }
 
 // LoginRequest represents the login request payload
@@ -66,14 +73,47 @@ type LoginResponse struct {
 	EmailVerified bool      `json:"email_verified"`
 	PhoneVerified bool      `json:"phone_verified"`
 	CreatedAt     time.Time `json:"created_at"`
-	Token         string    `json:"token"`
+	Token         string    `json:"token"`         // Access token, valid for auth.TokenLifetime
+	RefreshToken  string    `json:"refresh_token"` // Exchanged at /auth/refresh for a new access token
 }
 
-// Email validation regex
-var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+// RefreshTokenRequest represents the token refresh request payload
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// LogoutRequest represents the logout request payload
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty"` // Ends the token's session
+}
+
+// VerifyEmailRequest represents the email verification request payload
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+	Email string `json:"email,omitempty"` // Optional; when given it must match the token's account
+}
+
+// ResendVerificationRequest represents the request for a new verification email
+type ResendVerificationRequest struct {
+	Email string `json:"email"`
+}
+
+// ForgotPasswordRequest represents the password reset request payload
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// ResetPasswordRequest represents the new password payload for a reset token
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
 
-// Phone validation regex (basic US format)
-var phoneRegex = regexp.MustCompile(`^\+?1?[-.\s]?\(?[0-9]{3}\)?[-.\s]?[0-9]{3}[-.\s]?[0-9]{4}$`)
+// ChangePasswordRequest represents the payload for changing a signed-in user's password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
 
 // RegisterUser handles user registration with role selection
 // RegisterUser godoc
@@ -84,30 +124,36 @@ var phoneRegex = regexp.MustCompile(`^\+?1?[-.\s]?\(?[0-9]{3}\)?[-.\s]?[0-9]{3}[
 // @Produce json
 // @Param user body RegisterRequest true "Registration data"
 // @Success 201 {object} RegisterResponse
-// @Failure 400 {object} map[string]string "Invalid request data"
-// @Failure 409 {object} map[string]string "Email already exists"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} model.ErrorResponse "VALIDATION_ERROR or INVALID_JSON"
+// @Failure 409 {object} model.ErrorResponse "EMAIL_TAKEN"
+// @Failure 500 {object} model.ErrorResponse "INTERNAL_ERROR"
 // @Router /auth/register [post]
 func RegisterUser(w http.ResponseWriter, r *http.Request) {
 	var req RegisterRequest
 
 	// Only allow POST method
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	// Parse JSON request body
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
-		log.Printf("JSON decode error: %v", err)
-		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
+		slog.ErrorContext(r.Context(), "JSON decode error", "error", err)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
 		return
 	}
 
 	// Validate required fields
 	if err := validateRegistrationRequest(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		RespondWithValidationErrors(w, err)
+		return
+	}
+
+	// Reject breached passwords when the breach check is enabled
+	if err := getPasswordPolicy().Check(r.Context(), req.Password); err != nil {
+		RespondWithValidationError(w, &ValidationError{Field: "password", Message: passwordPolicyMessage(err)})
 		return
 	}
 
@@ -127,19 +173,19 @@ func RegisterUser(w http.ResponseWriter, r *http.Request) {
 	err = config.DB.QueryRow(checkQuery, req.Email).Scan(&existingID)
 	if err != sql.ErrNoRows {
 		if err == nil {
-			http.Error(w, "Email address already registered", http.StatusConflict)
+			respondError(w, http.StatusConflict, model.ErrCodeEmailTaken, "Email address already registered")
 			return
 		}
-		log.Printf("Database error checking existing email: %v", err)
-		http.Error(w, "Database error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error checking existing email", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Database error")
 		return
 	}
 
 	// Hash the password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		log.Printf("Password hashing error: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Password hashing error", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -153,7 +199,7 @@ func RegisterUser(w http.ResponseWriter, r *http.Request) {
 		) RETURNING id, uuid, created_at`
 
 	var response RegisterResponse
-	now := time.Now()
+	now := appFrom(r.Context()).Clock.Now()
 
 	// Set default values
 	isActive := true
@@ -189,26 +235,33 @@ func RegisterUser(w http.ResponseWriter, r *http.Request) {
 			switch pqErr.Code {
 			case "23505": // unique_violation
 				if strings.Contains(pqErr.Detail, "email") {
-					http.Error(w, "Email address already registered", http.StatusConflict)
+					respondError(w, http.StatusConflict, model.ErrCodeEmailTaken, "Email address already registered")
 					return
 				}
 			case "23514": // check_violation
-				http.Error(w, "Invalid role specified", http.StatusBadRequest)
+				RespondWithError(w, http.StatusBadRequest, "Invalid role specified")
 				return
 			}
 		}
-		log.Printf("Database error inserting user: %v", err)
-		http.Error(w, "Failed to create user", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error inserting user", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to create user")
 		return
 	}
 
 	// Create default notification preferences for the new user
 	err = createDefaultNotificationPreferences(response.ID)
 	if err != nil {
-		log.Printf("Warning: Failed to create notification preferences for user %d: %v", response.ID, err)
+		slog.WarnContext(r.Context(), "Failed to create notification preferences for user", "user_id", response.ID, "error", err)
 		// Don't fail the registration for this
 	}
 
+	// Email a verification link; the user can request another if this fails
+	if !emailVerified {
+		if err := issueVerificationEmail(r.Context(), response.ID, req.Email, req.Name); err != nil {
+			slog.WarnContext(r.Context(), "Failed to issue verification email for user", "user_id", response.ID, "error", err)
+		}
+	}
+
 	// Build response
 	response.Name = req.Name
 	response.Email = req.Email
@@ -219,17 +272,18 @@ func RegisterUser(w http.ResponseWriter, r *http.Request) {
 	response.EmailVerified = emailVerified
 	response.PhoneVerified = phoneVerified
 
-	// Generate JWT token
-	token, err := auth.GenerateJWT(response.ID, response.UUID, response.Email, response.Role)
+	// Start a session; the user can log in if this fails
+	token, refreshToken, err := issueSessionTokens(r, response.ID, response.UUID, response.Email, response.Role)
 	if err != nil {
-		log.Printf("Failed to generate JWT token: %v", err)
+		slog.ErrorContext(r.Context(), "Failed to start session", "error", err)
 		// Don't fail registration for token generation error
 	} else {
 		response.Token = token
+		response.RefreshToken = refreshToken
 	}
 
 	// Log successful registration
-	log.Printf("New user registered: ID=%d, Email=%s, Role=%s", response.ID, response.Email, response.Role)
+	slog.InfoContext(r.Context(), "New user registered", "user_id", response.ID, "email", response.Email, "role", response.Role)
 
 	// Send response
 	w.Header().Set("Content-Type", "application/json")
@@ -239,181 +293,83 @@ func RegisterUser(w http.ResponseWriter, r *http.Request) {
 
 // validateRegistrationRequest validates the registration request
 func validateRegistrationRequest(req *RegisterRequest) error {
-	// Required fields
-	if req.Name == "" {
-		return fmt.Errorf("name is required")
-	}
-	if req.Email == "" {
-		return fmt.Errorf("email is required")
-	}
-	if req.Password == "" {
-		return fmt.Errorf("password is required")
-	}
-	if req.Address == "" {
-		return fmt.Errorf("address is required")
-	}
-	if req.Role == "" {
-		return fmt.Errorf("role is required")
-	}
-
-	// Validate password strength
-	if err := validatePasswordStrength(req.Password); err != nil {
-		return err
-	}
-
-	// Validate email format
-	if !emailRegex.MatchString(req.Email) {
-		return fmt.Errorf("invalid email format")
-	}
-
-	// Validate role
-	validRoles := []string{"consumer", "gig_worker", "admin"}
-	roleValid := false
-	for _, validRole := range validRoles {
-		if req.Role == validRole {
-			roleValid = true
-			break
+	var v validate.Validator
+	v.Required("name", req.Name)
+	v.Length("name", req.Name, 2, 255)
+	v.Required("email", req.Email)
+	v.Email("email", req.Email)
+	v.Length("email", req.Email, 0, 255)
+	v.Required("password", req.Password)
+	if v.Valid("password") {
+		if err := validatePasswordStrength(req.Password); err != nil {
+			v.Add("password", passwordPolicyMessage(err))
 		}
 	}
-	if !roleValid {
-		return fmt.Errorf("role must be one of: consumer, gig_worker, admin")
-	}
-
-	// Validate phone if provided
-	if req.Phone != "" && !phoneRegex.MatchString(req.Phone) {
-		return fmt.Errorf("invalid phone number format")
-	}
-
-	// Validate name length
-	if len(req.Name) < 2 || len(req.Name) > 255 {
-		return fmt.Errorf("name must be between 2 and 255 characters")
-	}
-
-	// Validate email length
-	if len(req.Email) > 255 {
-		return fmt.Errorf("email must be less than 255 characters")
-	}
+	v.Required("address", req.Address)
+	v.Required("role", req.Role)
+	v.OneOf("role", req.Role, "consumer", "gig_worker", "admin")
+	v.Phone("phone", req.Phone)
 
 	// Role-specific validations - block admin registration in production
 	if req.Role == "admin" {
 		env := os.Getenv("APP_ENV")
 		if env == "production" {
-			log.Printf("BLOCKED: Admin user registration attempted in production for email: %s", req.Email)
-			return fmt.Errorf("admin registration is not allowed via public API")
+			slog.Warn("Blocked admin user registration in production", "email", req.Email)
+			v.Add("role", "admin registration is not allowed via public API")
+		} else {
+			slog.Warn("Admin user registration attempted", "email", req.Email)
 		}
-		log.Printf("Warning: Admin user registration attempted for email: %s", req.Email)
 	}
 
-	return nil
+	return v.Err()
 }
 
-// validatePasswordStrength validates password meets security requirements
-func validatePasswordStrength(password string) error {
-	// Minimum length of 10 characters (NIST recommends 8+, we use 10 for better security)
-	if len(password) < 10 {
-		return fmt.Errorf("password must be at least 10 characters long")
-	}
-
-	// Maximum length to prevent DoS via bcrypt
-	if len(password) > 72 {
-		return fmt.Errorf("password must be less than 72 characters")
-	}
-
-	var (
-		hasUpper   bool
-		hasLower   bool
-		hasNumber  bool
-		hasSpecial bool
-	)
-
-	for _, char := range password {
-		switch {
-		case char >= 'A' && char <= 'Z':
-			hasUpper = true
-		case char >= 'a' && char <= 'z':
-			hasLower = true
-		case char >= '0' && char <= '9':
-			hasNumber = true
-		case char == '!' || char == '@' || char == '#' || char == '$' ||
-			char == '%' || char == '^' || char == '&' || char == '*' ||
-			char == '(' || char == ')' || char == '-' || char == '_' ||
-			char == '+' || char == '=' || char == '[' || char == ']' ||
-			char == '{' || char == '}' || char == '|' || char == '\\' ||
-			char == ':' || char == ';' || char == '"' || char == '\'' ||
-			char == '<' || char == '>' || char == ',' || char == '.' ||
-			char == '?' || char == '/' || char == '`' || char == '~':
-			hasSpecial = true
-		}
-	}
-
-	// Require at least 3 of 4 character types
-	typesPresent := 0
-	if hasUpper {
-		typesPresent++
-	}
-	if hasLower {
-		typesPresent++
-	}
-	if hasNumber {
-		typesPresent++
-	}
-	if hasSpecial {
-		typesPresent++
-	}
-
-	if typesPresent < 3 {
-		return fmt.Errorf("password must contain at least 3 of: uppercase, lowercase, numbers, special characters")
-	}
+// passwordPolicyMessage turns a password policy error ("password must ...") into a
+// message for the password field
+func passwordPolicyMessage(err error) string {
+	return strings.TrimPrefix(err.Error(), "password ")
+}
 
-	// Check for common weak passwords
-	commonPasswords := []string{
-		"password123", "123456789", "qwerty123", "abc123456",
-		"password1", "iloveyou1", "letmein123", "welcome123",
-		"admin12345", "monkey1234", "dragon1234", "master1234",
-	}
+var (
+	passwordPolicy     auth.PasswordPolicy
+	passwordPolicyOnce sync.Once
+)
 
-	lowerPassword := strings.ToLower(password)
-	for _, common := range commonPasswords {
-		if lowerPassword == common {
-			return fmt.Errorf("password is too common, please choose a stronger password")
+// getPasswordPolicy returns the policy new passwords must meet, configured by the
+// PASSWORD_* environment variables on first use
+func getPasswordPolicy() auth.PasswordPolicy {
+	passwordPolicyOnce.Do(func() {
+		passwordPolicy = auth.PasswordPolicyFromEnv()
+		if passwordPolicy.Breaches == nil {
+			slog.Info("Password breach check disabled; set PASSWORD_BREACH_CHECK=true to enable it")
 		}
-	}
+	})
+	return passwordPolicy
+}
 
-	return nil
+// validatePasswordStrength validates password meets the password policy's length,
+// character and common password rules
+func validatePasswordStrength(password string) error {
+	return getPasswordPolicy().Validate(password)
 }
 
 // createDefaultNotificationPreferences creates default notification preferences for a new user
 func createDefaultNotificationPreferences(userID int) error {
-	// Get all notification types
-	notificationTypes := []string{
-		"job_posted", "job_accepted", "job_completed",
-		"payment_received", "system_message",
-	}
-
-	for _, notificationType := range notificationTypes {
+	for _, notificationType := range model.NotificationTypes {
 		insertQuery := `
 			INSERT INTO notification_preferences (
 				user_id, type, email_enabled, push_enabled, sms_enabled
 			) VALUES ($1, $2, $3, $4, $5)
 			ON CONFLICT (user_id, type) DO NOTHING`
 
-		// Default preferences
-		emailEnabled := true
-		pushEnabled := true
-		smsEnabled := false
-
-		// Customize defaults by notification type
-		if notificationType == "system_message" {
-			pushEnabled = false // System messages via email only by default
-		}
-
+		pref := model.DefaultNotificationPreference(notificationType)
 		_, err := config.DB.Exec(
 			insertQuery,
 			userID,
 			notificationType,
-			emailEnabled,
-			pushEnabled,
-			smsEnabled,
+			pref.EmailEnabled,
+			pref.PushEnabled,
+			pref.SMSEnabled,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to create notification preference for %s: %v", notificationType, err)
@@ -470,13 +426,13 @@ func cleanPhoneNumber(phone string) string {
 // @Produce json
 // @Param credentials body LoginRequest true "Login credentials"
 // @Success 200 {object} LoginResponse
-// @Failure 400 {object} map[string]string "Invalid request data"
-// @Failure 401 {object} map[string]string "Invalid credentials"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} model.ErrorResponse "BAD_REQUEST or INVALID_JSON"
+// @Failure 401 {object} model.ErrorResponse "INVALID_CREDENTIALS or ACCOUNT_DEACTIVATED"
+// @Failure 500 {object} model.ErrorResponse "INTERNAL_ERROR"
 // @Router /auth/login [post]
 func LoginUser(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -484,13 +440,13 @@ func LoginUser(w http.ResponseWriter, r *http.Request) {
 
 	err := json.NewDecoder(r.Body).Decode(&loginReq)
 	if err != nil {
-		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
 		return
 	}
 
 	// Validate required fields
 	if loginReq.Email == "" || loginReq.Password == "" {
-		http.Error(w, "Email and password are required", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Email and password are required")
 		return
 	}
 
@@ -509,32 +465,32 @@ func LoginUser(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			http.Error(w, "Invalid email or password", http.StatusUnauthorized)
+			respondError(w, http.StatusUnauthorized, model.ErrCodeInvalidCredentials, "Invalid email or password")
 			return
 		}
-		log.Printf("Database error during login: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error during login", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
 	// Verify password
 	if !passwordHash.Valid || passwordHash.String == "" {
 		// No password set, reject login
-		http.Error(w, "Invalid email or password", http.StatusUnauthorized)
+		respondError(w, http.StatusUnauthorized, model.ErrCodeInvalidCredentials, "Invalid email or password")
 		return
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(passwordHash.String), []byte(loginReq.Password))
 	if err != nil {
-		http.Error(w, "Invalid email or password", http.StatusUnauthorized)
+		respondError(w, http.StatusUnauthorized, model.ErrCodeInvalidCredentials, "Invalid email or password")
 		return
 	}
 
-	// Generate JWT token
-	token, err := auth.GenerateJWT(user.ID, user.Uuid, user.Email, user.Role)
+	// Start a session with an access token and a refresh token
+	token, refreshToken, err := issueSessionTokens(r, user.ID, user.Uuid, user.Email, user.Role)
 	if err != nil {
-		log.Printf("Failed to generate JWT token: %v", err)
-		http.Error(w, "Failed to generate authentication token", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Failed to start session", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to generate authentication token")
 		return
 	}
 
@@ -549,6 +505,7 @@ func LoginUser(w http.ResponseWriter, r *http.Request) {
 		PhoneVerified: user.PhoneVerified,
 		CreatedAt:     user.CreatedAt,
 		Token:         token,
+		RefreshToken:  refreshToken,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -556,15 +513,29 @@ func LoginUser(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-// LogoutUser handles user logout
+// LogoutUser ends the session of the refresh token in the body. Access tokens already
+// issued stay valid until they expire, so clients should discard them too.
 func LogoutUser(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	// NOTE: JWT token blacklisting would require a cache/database implementation
-	// For stateless JWT, logout is handled client-side by removing the token
+	var logoutReq LogoutRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&logoutReq); err != nil {
+			respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+			return
+		}
+	}
+
+	if logoutReq.RefreshToken != "" {
+		if err := revokeSessionByRefreshToken(logoutReq.RefreshToken, appFrom(r.Context()).Clock.Now()); err != nil {
+			slog.ErrorContext(r.Context(), "Database error revoking session on logout", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -574,77 +545,103 @@ func LogoutUser(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// RefreshToken refreshes the JWT token
+// RefreshToken exchanges a refresh token for a new access token and a new refresh token.
+// Each refresh token works once; presenting a used one revokes its session.
 func RefreshToken(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	var refreshReq struct {
-		Token string `json:"token"`
-	}
+	var refreshReq RefreshTokenRequest
 
 	err := json.NewDecoder(r.Body).Decode(&refreshReq)
 	if err != nil {
-		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+
+	if refreshReq.RefreshToken == "" {
+		RespondWithError(w, http.StatusBadRequest, "Refresh token is required")
+		return
+	}
+
+	userID, sessionID, refreshToken, err := rotateRefreshToken(r, refreshReq.RefreshToken)
+	if err == errInvalidRefreshToken || err == errRefreshTokenReused {
+		respondError(w, http.StatusUnauthorized, model.ErrCodeInvalidToken, "Invalid or expired refresh token")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to refresh token", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	if refreshReq.Token == "" {
-		http.Error(w, "Token is required", http.StatusBadRequest)
+	// Deactivated accounts (including those in the deletion hold) cannot extend sessions
+	var user model.User
+	err = config.DB.QueryRow("SELECT uuid, email, role, is_active FROM people WHERE id = $1", userID).Scan(
+		&user.Uuid, &user.Email, &user.Role, &user.IsActive,
+	)
+	if err != nil && err != sql.ErrNoRows {
+		slog.ErrorContext(r.Context(), "Database error refreshing token", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if err == sql.ErrNoRows || !user.IsActive {
+		if _, err := revokeUserSessions(userID, "", appFrom(r.Context()).Clock.Now()); err != nil {
+			slog.ErrorContext(r.Context(), "Failed to revoke sessions of deactivated user", "user_id", userID, "error", err)
+		}
+		respondError(w, http.StatusUnauthorized, model.ErrCodeAccountDeactivated, "Account is deactivated")
 		return
 	}
 
-	// Validate and refresh JWT token
-	newToken, err := auth.RefreshJWT(refreshReq.Token)
+	token, err := appFrom(r.Context()).Tokens().GenerateSessionJWT(userID, user.Uuid, user.Email, user.Role, sessionID)
 	if err != nil {
-		log.Printf("Failed to refresh token: %v", err)
-		http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+		slog.ErrorContext(r.Context(), "Failed to generate JWT token", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to generate authentication token")
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
-		"token":   newToken,
+		"success":       true,
+		"token":         token,
+		"refresh_token": refreshToken,
 	})
 }
 
 // VerifyEmail verifies a user's email address
 func VerifyEmail(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	var verifyReq struct {
-		Token string `json:"token"`
-		Email string `json:"email"`
-	}
+	var verifyReq VerifyEmailRequest
 
 	err := json.NewDecoder(r.Body).Decode(&verifyReq)
 	if err != nil {
-		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
 		return
 	}
 
-	if verifyReq.Token == "" || verifyReq.Email == "" {
-		http.Error(w, "Token and email are required", http.StatusBadRequest)
+	if verifyReq.Token == "" {
+		RespondWithError(w, http.StatusBadRequest, "Token is required")
 		return
 	}
 
-	// TODO: Validate verification token
-	// For now, just update the email_verified status
-
-	query := "UPDATE people SET email_verified = true, updated_at = NOW() WHERE email = $1"
-	_, err = config.DB.Exec(query, strings.ToLower(strings.TrimSpace(verifyReq.Email)))
+	userID, err := redeemVerificationToken(verifyReq.Token, strings.ToLower(strings.TrimSpace(verifyReq.Email)), appFrom(r.Context()).Clock.Now())
+	if err == errInvalidVerificationToken {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidToken, "Invalid or expired verification token")
+		return
+	}
 	if err != nil {
-		log.Printf("Database error verifying email: %v", err)
-		http.Error(w, "Failed to verify email", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error verifying email", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to verify email")
 		return
 	}
+	slog.InfoContext(r.Context(), "Email verified for user", "user_id", userID)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -654,32 +651,71 @@ func VerifyEmail(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// ResendVerification emails a new verification link, superseding earlier ones. Like
+// ForgotPassword it always succeeds, so it does not reveal which emails have accounts.
+func ResendVerification(w http.ResponseWriter, r *http.Request) {
+	var resendReq ResendVerificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&resendReq); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	if resendReq.Email == "" {
+		RespondWithError(w, http.StatusBadRequest, "Email is required")
+		return
+	}
+
+	var userID int
+	var userEmail, userName string
+	var verified bool
+	err := config.DB.QueryRow(
+		"SELECT id, email, name, COALESCE(email_verified, false) FROM people WHERE email = $1 AND is_active = true",
+		strings.ToLower(strings.TrimSpace(resendReq.Email)),
+	).Scan(&userID, &userEmail, &userName, &verified)
+	if err != nil && err != sql.ErrNoRows {
+		slog.ErrorContext(r.Context(), "Database error resending verification", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	if err == nil && !verified {
+		if err := issueVerificationEmail(r.Context(), userID, userEmail, userName); err != nil {
+			slog.ErrorContext(r.Context(), "Failed to issue verification email for user", "user_id", userID, "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "If the email belongs to an unverified account, a verification link has been sent",
+	})
+}
+
 // ForgotPassword initiates password reset process
 func ForgotPassword(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	var forgotReq struct {
-		Email string `json:"email"`
-	}
+	var forgotReq ForgotPasswordRequest
 
 	err := json.NewDecoder(r.Body).Decode(&forgotReq)
 	if err != nil {
-		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
 		return
 	}
 
 	if forgotReq.Email == "" {
-		http.Error(w, "Email is required", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Email is required")
 		return
 	}
 
 	// Check if user exists
 	var userID int
-	query := "SELECT id FROM people WHERE email = $1 AND is_active = true"
-	err = config.DB.QueryRow(query, strings.ToLower(strings.TrimSpace(forgotReq.Email))).Scan(&userID)
+	var userEmail, userName string
+	query := "SELECT id, email, name FROM people WHERE email = $1 AND is_active = true"
+	err = config.DB.QueryRow(query, strings.ToLower(strings.TrimSpace(forgotReq.Email))).Scan(&userID, &userEmail, &userName)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			// Don't reveal if email exists, return success anyway
@@ -691,14 +727,37 @@ func ForgotPassword(w http.ResponseWriter, r *http.Request) {
 			})
 			return
 		}
-		log.Printf("Database error during password reset: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error during password reset", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	token, err := auth.GenerateResetToken()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to generate password reset token", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	// TODO: Generate password reset token and send email
-	// For now, just log and return success
-	log.Printf("Password reset requested for user ID: %d", userID)
+	ipAddress := middleware.ClientIP(r)
+	tokenID, err := storePasswordResetToken(r.Context(), userID, token, ipAddress)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error storing password reset token for user", "user_id", userID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// The email carries a single-use deep link naming the reset token, not the token itself
+	link, err := appFrom(r.Context()).Links().Create(r.Context(), links.ActionResetPassword, userID, tokenID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to create password reset link for user", "user_id", userID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Sent in the background so the response time does not reveal whether the email exists
+	go sendPasswordResetEmail(r.Context(), userEmail, userName, link.URL, ipAddress)
+	slog.InfoContext(r.Context(), "Password reset requested for user", "user_id", userID)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -711,35 +770,71 @@ func ForgotPassword(w http.ResponseWriter, r *http.Request) {
 // ResetPassword resets user password with token
 func ResetPassword(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	var resetReq struct {
-		Token       string `json:"token"`
-		NewPassword string `json:"new_password"`
-	}
+	var resetReq ResetPasswordRequest
 
 	err := json.NewDecoder(r.Body).Decode(&resetReq)
 	if err != nil {
-		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
 		return
 	}
 
-	if resetReq.Token == "" || resetReq.NewPassword == "" {
-		http.Error(w, "Token and new password are required", http.StatusBadRequest)
+	var v validate.Validator
+	v.Required("token", resetReq.Token)
+	v.Required("new_password", resetReq.NewPassword)
+	if err := v.Err(); err != nil {
+		RespondWithValidationErrors(w, err)
 		return
 	}
 
 	// Validate password strength
-	if len(resetReq.NewPassword) < 8 {
-		http.Error(w, "Password must be at least 8 characters long", http.StatusBadRequest)
+	if err := getPasswordPolicy().Check(r.Context(), resetReq.NewPassword); err != nil {
+		RespondWithValidationError(w, &ValidationError{Field: "new_password", Message: passwordPolicyMessage(err)})
+		return
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(resetReq.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Password hashing error", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Reset links carry a link token naming the reset token; tokens from emails sent
+	// before reset links are still accepted as they are
+	var userID int
+	if action, tokenID, linkErr := links.Parse(resetReq.Token); linkErr == nil && action == links.ActionResetPassword {
+		claims, useErr := appFrom(r.Context()).Links().Use(r.Context(), resetReq.Token, action, tokenID)
+		switch {
+		case errors.Is(useErr, links.ErrInvalidLink), errors.Is(useErr, links.ErrLinkUsed):
+			err = errInvalidResetToken
+		case useErr != nil:
+			err = useErr
+		default:
+			userID, err = redeemPasswordResetTokenID(tokenID, claims.UserID, string(hashedPassword), appFrom(r.Context()).Clock.Now())
+		}
+	} else {
+		userID, err = redeemPasswordResetToken(resetReq.Token, string(hashedPassword), appFrom(r.Context()).Clock.Now())
+	}
+	if err == errInvalidResetToken {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidToken, "Invalid or expired reset token")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error resetting password", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to reset password")
 		return
 	}
 
-	// TODO: Validate reset token and update password hash
-	// For now, just return success
-	log.Printf("Password reset completed for token: %s", resetReq.Token)
+	slog.InfoContext(r.Context(), "Password reset completed for user", "user_id", userID)
+
+	// Whoever knew the old password may hold a session; end them all
+	if _, err := revokeUserSessions(userID, "", appFrom(r.Context()).Clock.Now()); err != nil {
+		slog.ErrorContext(r.Context(), "Failed to revoke sessions after password reset for user", "user_id", userID, "error", err)
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -748,3 +843,274 @@ func ResetPassword(w http.ResponseWriter, r *http.Request) {
 		"message": "Password reset successfully",
 	})
 }
+
+// ChangePassword sets a new password for the authenticated user after checking the
+// current one. The user's other sessions are revoked; the current one stays signed in,
+// and the user is emailed in case someone else made the change.
+func ChangePassword(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	var v validate.Validator
+	v.Required("current_password", req.CurrentPassword)
+	v.Required("new_password", req.NewPassword)
+	if err := v.Err(); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+
+	var userEmail, userName string
+	var passwordHash sql.NullString
+	err := config.DB.QueryRow(
+		`SELECT email, name, password_hash FROM people WHERE id = $1 AND is_active = true`, userID,
+	).Scan(&userEmail, &userName, &passwordHash)
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error changing password", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if !passwordHash.Valid || !auth.VerifyPassword(req.CurrentPassword, passwordHash.String) {
+		respondError(w, http.StatusUnauthorized, model.ErrCodeInvalidCredentials, "Current password is incorrect")
+		return
+	}
+	if req.NewPassword == req.CurrentPassword {
+		RespondWithValidationError(w, &ValidationError{Field: "new_password", Message: "must be different from the current password"})
+		return
+	}
+	if err := getPasswordPolicy().Check(r.Context(), req.NewPassword); err != nil {
+		RespondWithValidationError(w, &ValidationError{Field: "new_password", Message: passwordPolicyMessage(err)})
+		return
+	}
+
+	hashedPassword, err := auth.HashPassword(req.NewPassword)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Password hashing error", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if _, err := config.DB.Exec(`UPDATE people SET password_hash = $1, updated_at = NOW() WHERE id = $2`, hashedPassword, userID); err != nil {
+		slog.ErrorContext(r.Context(), "Database error changing password", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to change password")
+		return
+	}
+
+	if _, err := revokeUserSessions(userID, GetSessionIDFromContext(r), appFrom(r.Context()).Clock.Now()); err != nil {
+		slog.ErrorContext(r.Context(), "Failed to revoke sessions after password change for user", "user_id", userID, "error", err)
+	}
+	slog.InfoContext(r.Context(), "Password changed for user", "user_id", userID)
+
+	go sendPasswordChangedEmail(r.Context(), userEmail, userName, middleware.ClientIP(r))
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Password changed successfully",
+	})
+}
+
+// passwordResetTTL is how long a reset link works; SendPasswordResetEmail tells users 30 minutes
+const passwordResetTTL = 30 * time.Minute
+
+// errInvalidResetToken is returned for reset tokens that are unknown, used or expired
+var errInvalidResetToken = errors.New("invalid or expired reset token")
+
+// storePasswordResetToken records the hash of a new reset token, superseding any
+// earlier unused token so only the latest email works
+func storePasswordResetToken(ctx context.Context, userID int, token, ipAddress string) (int, error) {
+	tx, err := config.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	now := appFrom(ctx).Clock.Now()
+	_, err = tx.Exec(`
+		UPDATE password_reset_tokens SET used_at = $2
+		WHERE user_id = $1 AND used_at IS NULL
+	`, userID, now)
+	if err != nil {
+		return 0, err
+	}
+
+	var tokenID int
+	err = tx.QueryRow(`
+		INSERT INTO password_reset_tokens (user_id, token_hash, requested_ip, expires_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4)
+		RETURNING id
+	`, userID, auth.HashToken(token), ipAddress, now.Add(passwordResetTTL)).Scan(&tokenID)
+	if err != nil {
+		return 0, err
+	}
+	return tokenID, tx.Commit()
+}
+
+// redeemPasswordResetToken sets a new password hash for the token's user and marks the
+// token used, so it works only once. Tokens expired as of now are refused.
+func redeemPasswordResetToken(token, passwordHash string, now time.Time) (int, error) {
+	return redeemPasswordReset(`t.token_hash = $2`, now, passwordHash, auth.HashToken(token))
+}
+
+// redeemPasswordResetTokenID redeems the reset token a reset link names, which must
+// belong to the link's user
+func redeemPasswordResetTokenID(tokenID, userID int, passwordHash string, now time.Time) (int, error) {
+	return redeemPasswordReset(`t.id = $2 AND t.user_id = $3`, now, passwordHash, tokenID, userID)
+}
+
+// redeemPasswordReset redeems the reset token matching where that is unused and
+// unexpired at now, which where's arguments follow as $1
+func redeemPasswordReset(where string, now time.Time, passwordHash string, args ...any) (int, error) {
+	tx, err := config.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var tokenID, userID int
+	err = tx.QueryRow(`
+		SELECT t.id, t.user_id
+		FROM password_reset_tokens t
+		JOIN people p ON p.id = t.user_id
+		WHERE `+where+` AND t.used_at IS NULL AND t.expires_at > $1 AND p.is_active = true
+		FOR UPDATE OF t
+	`, append([]any{now}, args...)...).Scan(&tokenID, &userID)
+	if err == sql.ErrNoRows {
+		return 0, errInvalidResetToken
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Exec(`UPDATE people SET password_hash = $1, updated_at = $3 WHERE id = $2`, passwordHash, userID, now); err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(`UPDATE password_reset_tokens SET used_at = $2 WHERE id = $1`, tokenID, now); err != nil {
+		return 0, err
+	}
+	return userID, tx.Commit()
+}
+
+// sendPasswordResetEmail emails a reset link to the account owner
+func sendPasswordResetEmail(ctx context.Context, to, name, resetLink, ipAddress string) {
+	emailService, err := email.NewServiceFromEnv()
+	if err != nil {
+		slog.ErrorContext(ctx, "Email not configured, password reset not sent", "to", to, "error", err)
+		return
+	}
+	if err := emailService.SendPasswordResetEmail(to, name, resetLink, ipAddress); err != nil {
+		slog.ErrorContext(ctx, "Failed to send password reset email", "to", to, "error", err)
+	}
+}
+
+// sendPasswordChangedEmail notifies the account owner that their password changed
+func sendPasswordChangedEmail(ctx context.Context, to, name, ipAddress string) {
+	emailService, err := email.NewServiceFromEnv()
+	if err != nil {
+		slog.ErrorContext(ctx, "Email not configured, password change notice not sent", "to", to, "error", err)
+		return
+	}
+	if err := emailService.SendPasswordChangedEmail(to, name, ipAddress, appFrom(ctx).Clock.Now()); err != nil {
+		slog.ErrorContext(ctx, "Failed to send password change notice", "to", to, "error", err)
+	}
+}
+
+// verificationTTL is how long a verification link works; SendVerificationEmail tells users 24 hours
+const verificationTTL = 24 * time.Hour
+
+// errInvalidVerificationToken is returned for verification tokens that are unknown, used,
+// expired or for a different email
+var errInvalidVerificationToken = errors.New("invalid or expired verification token")
+
+// issueVerificationEmail stores a new verification token for the user, superseding any
+// earlier one, and emails it in the background
+func issueVerificationEmail(ctx context.Context, userID int, to, name string) error {
+	token, err := auth.GenerateVerificationToken()
+	if err != nil {
+		return err
+	}
+
+	tx, err := config.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := appFrom(ctx).Clock.Now()
+	_, err = tx.Exec(`
+		UPDATE email_verification_tokens SET used_at = $2
+		WHERE user_id = $1 AND used_at IS NULL
+	`, userID, now)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO email_verification_tokens (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+	`, userID, auth.HashToken(token), now.Add(verificationTTL))
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	go sendVerificationEmail(ctx, to, name, token)
+	return nil
+}
+
+// redeemVerificationToken marks the token's user verified and the token used, refusing
+// tokens expired as of now. If emailAddress is not empty it must be the account's email.
+func redeemVerificationToken(token, emailAddress string, now time.Time) (int, error) {
+	tx, err := config.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var tokenID, userID int
+	err = tx.QueryRow(`
+		SELECT t.id, t.user_id
+		FROM email_verification_tokens t
+		JOIN people p ON p.id = t.user_id
+		WHERE t.token_hash = $1 AND t.used_at IS NULL AND t.expires_at > $3
+		  AND ($2 = '' OR p.email = $2)
+		FOR UPDATE OF t
+	`, auth.HashToken(token), emailAddress, now).Scan(&tokenID, &userID)
+	if err == sql.ErrNoRows {
+		return 0, errInvalidVerificationToken
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Exec(`UPDATE people SET email_verified = true, updated_at = $2 WHERE id = $1`, userID, now); err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(`UPDATE email_verification_tokens SET used_at = $2 WHERE id = $1`, tokenID, now); err != nil {
+		return 0, err
+	}
+	return userID, tx.Commit()
+}
+
+// sendVerificationEmail emails a verification link to a new account
+func sendVerificationEmail(ctx context.Context, to, name, token string) {
+	emailService, err := email.NewServiceFromEnv()
+	if err != nil {
+		slog.ErrorContext(ctx, "Email not configured, verification email not sent", "to", to, "error", err)
+		return
+	}
+	if err := emailService.SendVerificationEmail(to, name, token); err != nil {
+		slog.ErrorContext(ctx, "Failed to send verification email", "to", to, "error", err)
+	}
+}
@@ -142,7 +142,7 @@ func TestValidateRegistrationRequest(t *testing.T) {
 				Role:     "consumer",
 			},
 			wantErr: true,
-			errMsg:  "name is required",
+			errMsg:  "name: is required",
 		},
 		{
 			name: "missing email",
@@ -154,7 +154,7 @@ func TestValidateRegistrationRequest(t *testing.T) {
 				Role:     "consumer",
 			},
 			wantErr: true,
-			errMsg:  "email is required",
+			errMsg:  "email: is required",
 		},
 		{
 			name: "invalid email format",
@@ -166,7 +166,7 @@ func TestValidateRegistrationRequest(t *testing.T) {
 				Role:     "consumer",
 			},
 			wantErr: true,
-			errMsg:  "invalid email format",
+			errMsg:  "email: must be a valid email address",
 		},
 		{
 			name: "missing password",
@@ -178,7 +178,7 @@ func TestValidateRegistrationRequest(t *testing.T) {
 				Role:     "consumer",
 			},
 			wantErr: true,
-			errMsg:  "password is required",
+			errMsg:  "password: is required",
 		},
 		{
 			name: "weak password",
@@ -202,7 +202,7 @@ func TestValidateRegistrationRequest(t *testing.T) {
 				Role:     "consumer",
 			},
 			wantErr: true,
-			errMsg:  "address is required",
+			errMsg:  "address: is required",
 		},
 		{
 			name: "missing role",
@@ -214,7 +214,7 @@ func TestValidateRegistrationRequest(t *testing.T) {
 				Role:     "",
 			},
 			wantErr: true,
-			errMsg:  "role is required",
+			errMsg:  "role: is required",
 		},
 		{
 			name: "invalid role",
@@ -226,7 +226,7 @@ func TestValidateRegistrationRequest(t *testing.T) {
 				Role:     "invalid_role",
 			},
 			wantErr: true,
-			errMsg:  "role must be one of",
+			errMsg:  "role: must be one of",
 		},
 		{
 			name: "name too short",
@@ -263,7 +263,7 @@ func TestValidateRegistrationRequest(t *testing.T) {
 				Phone:    "invalid",
 			},
 			wantErr: true,
-			errMsg:  "invalid phone number",
+			errMsg:  "phone: must be a valid phone number",
 		},
 	}
 
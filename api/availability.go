@@ -0,0 +1,376 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/availability"
+	"app/internal/model"
+	"app/internal/recurrence"
+	"app/internal/routing"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ==============================================
+// WORKER AVAILABILITY
+// ==============================================
+
+// maxAvailabilityWindow is the longest range GetGigWorkerAvailability covers
+const maxAvailabilityWindow = 93 * 24 * time.Hour
+
+// GetGigWorkerAvailability merges a worker's booked schedules, active jobs and blackout
+// dates into free/busy windows (default: the next 7 days). Busy window titles are only
+// shown to the worker and admins.
+func GetGigWorkerAvailability(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	workerID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid gig worker ID format")
+		return
+	}
+
+	fromParam, err := ParseDateParam(r, "from")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	toParam, err := ParseDateParam(r, "to")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	from := appFrom(r.Context()).Clock.Now().UTC().Truncate(time.Minute)
+	if fromParam != nil {
+		from = *fromParam
+	}
+	to := from.AddDate(0, 0, 7)
+	if toParam != nil {
+		to = *toParam
+	}
+	if !to.After(from) {
+		RespondWithValidationError(w, &ValidationError{Field: "to", Message: "must be after from"})
+		return
+	}
+	if to.Sub(from) > maxAvailabilityWindow {
+		RespondWithValidationError(w, &ValidationError{Field: "to", Message: "must be within 93 days of from"})
+		return
+	}
+
+	if !requireGigWorkerExists(w, r, workerID) {
+		return
+	}
+
+	avail, err := availability.ForWorker(r.Context(), config.DB, availability.Query{WorkerID: workerID, From: from, To: to})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error loading availability for worker", "worker_id", workerID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	if GetUserRoleFromContext(r) != "admin" && GetUserIDFromContext(r) != workerID {
+		for i := range avail.Busy {
+			avail.Busy[i].Title = nil
+		}
+	}
+
+	RespondWithJSON(w, http.StatusOK, avail)
+}
+
+// GetMyScheduleConflicts lists what stops the calling worker taking a slot: booked
+// schedules, jobs and blackout dates overlapping it, and, when job_id names the job
+// being considered, neighbouring jobs too far away to drive between in time. The slot
+// defaults to the job's scheduled time. Accepting a job offer runs the same check.
+func GetMyScheduleConflicts(w http.ResponseWriter, r *http.Request) {
+	workerID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	start, err := ParseDateParam(r, "start")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	end, err := ParseDateParam(r, "end")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	var proposal availability.Proposal
+	if jobParam := r.URL.Query().Get("job_id"); jobParam != "" {
+		jobID, err := strconv.Atoi(jobParam)
+		if err != nil || jobID < 1 {
+			RespondWithValidationError(w, &ValidationError{Field: "job_id", Message: "must be a job ID", Value: jobParam})
+			return
+		}
+		job, err := jobConflictProposal(r.Context(), workerID, jobID)
+		if err == sql.ErrNoRows {
+			respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
+			return
+		}
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Database error loading job for conflict check", "job_id", jobID, "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		proposal = *job
+	}
+	proposal.WorkerID = workerID
+	if start != nil {
+		proposal.Slot.Start = *start
+	}
+	if end != nil {
+		proposal.Slot.End = *end
+	}
+
+	if proposal.Slot.Start.IsZero() || proposal.Slot.End.IsZero() {
+		RespondWithValidationError(w, &ValidationError{Field: "start", Message: "start and end are required unless job_id names a scheduled job"})
+		return
+	}
+	if !proposal.Slot.End.After(proposal.Slot.Start) {
+		RespondWithValidationError(w, &ValidationError{Field: "end", Message: "must be after start"})
+		return
+	}
+	if proposal.Slot.End.Sub(proposal.Slot.Start) > maxAvailabilityWindow {
+		RespondWithValidationError(w, &ValidationError{Field: "end", Message: "must be within 93 days of start"})
+		return
+	}
+
+	conflicts, err := availability.FindConflicts(r.Context(), config.DB, proposal)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error checking schedule conflicts for worker", "worker_id", workerID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"start_time": proposal.Slot.Start,
+		"end_time":   proposal.Slot.End,
+		"job_id":     proposal.ExcludeJobID,
+		"conflicts":  conflicts,
+	})
+}
+
+// jobConflictProposal is the worker taking the job at its scheduled time and location,
+// leaving out the job's own bookings. The slot is zero when the job is not scheduled.
+func jobConflictProposal(ctx context.Context, workerID, jobID int) (*availability.Proposal, error) {
+	var start, end sql.NullTime
+	var lat, lng sql.NullFloat64
+	err := config.DB.QueryRowContext(ctx, `
+		SELECT scheduled_start, scheduled_end, location_latitude, location_longitude
+		FROM jobs WHERE id = $1
+	`, jobID).Scan(&start, &end, &lat, &lng)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &availability.Proposal{WorkerID: workerID, ExcludeJobID: &jobID}
+	if start.Valid && end.Valid {
+		p.Slot = recurrence.Slot{Start: start.Time, End: end.Time}
+	}
+	if lat.Valid && lng.Valid {
+		p.Location = &routing.Point{Lat: lat.Float64, Lng: lng.Float64}
+	}
+	return p, nil
+}
+
+// ==============================================
+// BLACKOUT DATES
+// ==============================================
+
+// GetBlackoutDates lists a worker's current and upcoming blackout dates. Only the
+// worker or an admin may see them.
+func GetBlackoutDates(w http.ResponseWriter, r *http.Request) {
+	workerID, ok := blackoutOwner(w, r)
+	if !ok {
+		return
+	}
+
+	rows, err := config.DB.Query(`
+		SELECT id, worker_id, start_date, end_date, reason, created_at
+		FROM worker_blackout_dates
+		WHERE worker_id = $1 AND end_date >= (NOW() AT TIME ZONE 'UTC')::date
+		ORDER BY start_date, id
+	`, workerID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying blackout dates", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	blackouts := []model.BlackoutDate{}
+	for rows.Next() {
+		b, err := scanBlackoutDate(rows)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning blackout date row", "error", err)
+			continue
+		}
+		blackouts = append(blackouts, *b)
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"blackout_dates": blackouts,
+	})
+}
+
+// CreateBlackoutDate marks days the worker is not taking work. Matching and job
+// scheduling skip them.
+func CreateBlackoutDate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	workerID, ok := blackoutOwner(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.BlackoutDateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		RespondWithValidationError(w, &ValidationError{Field: "start_date", Message: "must be a date (YYYY-MM-DD)", Value: req.StartDate})
+		return
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		RespondWithValidationError(w, &ValidationError{Field: "end_date", Message: "must be a date (YYYY-MM-DD)", Value: req.EndDate})
+		return
+	}
+	if endDate.Before(startDate) {
+		RespondWithValidationError(w, &ValidationError{Field: "end_date", Message: "must not be before start_date"})
+		return
+	}
+	if endDate.Before(appFrom(r.Context()).Clock.Now().UTC().Truncate(24 * time.Hour)) {
+		RespondWithValidationError(w, &ValidationError{Field: "end_date", Message: "must not be in the past"})
+		return
+	}
+	if endDate.Sub(startDate) > 365*24*time.Hour {
+		RespondWithValidationError(w, &ValidationError{Field: "end_date", Message: "must be within a year of start_date"})
+		return
+	}
+	if req.Reason != nil {
+		reason := strings.TrimSpace(*req.Reason)
+		if len(reason) > 500 {
+			RespondWithValidationError(w, &ValidationError{Field: "reason", Message: "must be at most 500 characters"})
+			return
+		}
+		req.Reason = &reason
+		if reason == "" {
+			req.Reason = nil
+		}
+	}
+
+	b, err := scanBlackoutDate(config.DB.QueryRow(`
+		INSERT INTO worker_blackout_dates (worker_id, start_date, end_date, reason)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, worker_id, start_date, end_date, reason, created_at
+	`, workerID, req.StartDate, req.EndDate, req.Reason))
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error creating blackout date", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to create blackout date")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, b)
+}
+
+// DeleteBlackoutDate removes one of the worker's blackout dates
+func DeleteBlackoutDate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	workerID, ok := blackoutOwner(w, r)
+	if !ok {
+		return
+	}
+	blackoutID, err := strconv.Atoi(chi.URLParam(r, "blackoutId"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid blackout date ID format")
+		return
+	}
+
+	result, err := config.DB.Exec(`DELETE FROM worker_blackout_dates WHERE id = $1 AND worker_id = $2`, blackoutID, workerID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error deleting blackout date", "blackout_id", blackoutID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to delete blackout date")
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		RespondWithError(w, http.StatusNotFound, "Blackout date not found")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Blackout date deleted successfully",
+	})
+}
+
+// blackoutOwner returns the worker id in the path when the caller is that worker or an
+// admin, responding with an error otherwise
+func blackoutOwner(w http.ResponseWriter, r *http.Request) (int, bool) {
+	workerID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid gig worker ID format")
+		return 0, false
+	}
+	if GetUserRoleFromContext(r) != "admin" && GetUserIDFromContext(r) != workerID {
+		RespondWithError(w, http.StatusForbidden, "You can only manage your own blackout dates")
+		return 0, false
+	}
+	if !requireGigWorkerExists(w, r, workerID) {
+		return 0, false
+	}
+	return workerID, true
+}
+
+// requireGigWorkerExists responds 404 and returns false when workerID is not a gig
+// worker account
+func requireGigWorkerExists(w http.ResponseWriter, r *http.Request, workerID int) bool {
+	var exists bool
+	err := config.DB.QueryRow(`SELECT EXISTS(SELECT 1 FROM people WHERE id = $1 AND role = 'gig_worker')`, workerID).Scan(&exists)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error loading gig worker", "worker_id", workerID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return false
+	}
+	if !exists {
+		respondError(w, http.StatusNotFound, model.ErrCodeGigWorkerNotFound, "Gig worker not found")
+		return false
+	}
+	return true
+}
+
+func scanBlackoutDate(row rowScanner) (*model.BlackoutDate, error) {
+	var b model.BlackoutDate
+	var startDate, endDate time.Time
+	var reason sql.NullString
+	if err := row.Scan(&b.ID, &b.WorkerID, &startDate, &endDate, &reason, &b.CreatedAt); err != nil {
+		return nil, err
+	}
+	b.StartDate = startDate.Format("2006-01-02")
+	b.EndDate = endDate.Format("2006-01-02")
+	b.Reason = stringPtrFromNull(reason)
+	return &b, nil
+}
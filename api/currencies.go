@@ -0,0 +1,17 @@
+package api
+
+import (
+	"net/http"
+
+	"app/internal/currency"
+	"app/internal/model"
+)
+
+// GetCurrencies lists the currencies jobs can be priced in and the default for jobs
+// posted without one
+func GetCurrencies(w http.ResponseWriter, r *http.Request) {
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"currencies": currency.Supported(),
+		"default":    model.DefaultCurrency,
+	})
+}
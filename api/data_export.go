@@ -0,0 +1,150 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"app/config"
+	"app/internal/dataexport"
+	"app/internal/model"
+	"app/internal/temporal"
+	"app/internal/temporal/workflows"
+	"app/internal/validate"
+)
+
+// GetMyDataExport returns the caller's export of their data in the requested format,
+// starting one when they have none pending or ready. A background workflow builds the
+// archive and emails the caller a link; until then the export is pending and the
+// response is 202. A ready export comes with its download link.
+func GetMyDataExport(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = string(dataexport.FormatJSON)
+	}
+	var v validate.Validator
+	v.OneOf("format", format, dataexport.Formats...)
+	if err := v.Err(); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+
+	tx, err := config.DB.BeginTx(r.Context(), nil)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error starting transaction", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer tx.Rollback()
+
+	// Locking the user makes concurrent requests share one export
+	if _, err := tx.ExecContext(r.Context(), `SELECT 1 FROM people WHERE id = $1 FOR UPDATE`, userID); err != nil {
+		slog.ErrorContext(r.Context(), "Database error locking user for data export", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	var export model.DataExport
+	var key sql.NullString
+	err = tx.QueryRowContext(r.Context(), `
+		SELECT id, format, status, requested_at, completed_at, expires_at, storage_key
+		FROM data_exports
+		WHERE user_id = $1 AND format = $2 AND status IN ('pending', 'ready')
+		  AND (expires_at IS NULL OR expires_at > NOW())
+		ORDER BY requested_at DESC
+		LIMIT 1
+	`, userID, format).Scan(&export.ID, &export.Format, &export.Status, &export.RequestedAt,
+		&export.CompletedAt, &export.ExpiresAt, &key)
+	if err == nil {
+		tx.Rollback()
+		respondDataExport(w, r, export, key.String)
+		return
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		slog.ErrorContext(r.Context(), "Database error getting data export", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	err = tx.QueryRowContext(r.Context(), `
+		INSERT INTO data_exports (user_id, format) VALUES ($1, $2)
+		RETURNING id, format, status, requested_at
+	`, userID, format).Scan(&export.ID, &export.Format, &export.Status, &export.RequestedAt)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error creating data export", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to request data export")
+		return
+	}
+
+	// The export is only built once the workflow is running, so start it before committing
+	temporalClient, err := temporal.NewClient()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to create Temporal client", "error", err)
+		RespondWithError(w, http.StatusServiceUnavailable, "Data export is temporarily unavailable")
+		return
+	}
+	defer temporalClient.Close()
+
+	run, err := temporalClient.StartDataExportWorkflow(r.Context(), workflows.DataExportInput{
+		ExportID: export.ID,
+		UserID:   userID,
+		Format:   format,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to start data export workflow for user", "user_id", userID, "error", err)
+		RespondWithError(w, http.StatusServiceUnavailable, "Data export is temporarily unavailable")
+		return
+	}
+
+	if _, err := tx.ExecContext(r.Context(), `UPDATE data_exports SET temporal_workflow_id = $1 WHERE id = $2`, run.GetID(), export.ID); err != nil {
+		slog.ErrorContext(r.Context(), "Database error saving data export workflow", "error", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		// The workflow skips exports it cannot find, so an orphaned run is harmless
+		slog.ErrorContext(r.Context(), "Database error committing data export", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to request data export")
+		return
+	}
+
+	slog.InfoContext(r.Context(), "User requested a data export", "user_id", userID, "data_export_id", export.ID, "format", format)
+	respondDataExport(w, r, export, "")
+}
+
+// respondDataExport returns a pending export with 202, and a ready one with 200 and a
+// link valid until it expires
+func respondDataExport(w http.ResponseWriter, r *http.Request, export model.DataExport, key string) {
+	if export.Status != model.DataExportStatusReady {
+		RespondWithJSON(w, http.StatusAccepted, map[string]interface{}{
+			"message": "Your export is being prepared. We'll email you a download link when it's ready.",
+			"export":  export,
+		})
+		return
+	}
+
+	store, err := getAttachmentStore()
+	if err != nil {
+		RespondWithError(w, http.StatusServiceUnavailable, "Downloads are temporarily unavailable")
+		return
+	}
+	export.DownloadURL, err = store.SignedURL(r.Context(), key, time.Until(*export.ExpiresAt))
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to sign data export", "data_export_id", export.ID, "error", err)
+		RespondWithError(w, http.StatusServiceUnavailable, "Downloads are temporarily unavailable")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Your export is ready",
+		"export":  export,
+	})
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"app/config"
+	"app/internal/model"
+	"app/internal/notifications"
+	"app/internal/validate"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// maxDeviceTokenLength bounds FCM registration tokens, which are a few hundred characters
+const maxDeviceTokenLength = 4096
+
+// RegisterDevice registers the caller's app install for push notifications. Apps call
+// it after login and whenever FCM issues them a new token.
+func RegisterDevice(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	var req model.RegisterDeviceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	req.Token = strings.TrimSpace(req.Token)
+	req.Platform = strings.ToLower(strings.TrimSpace(req.Platform))
+	if err := validateRegisterDeviceRequest(&req); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+
+	device, err := notifications.NewDevices(config.DB).Register(r.Context(), userID, req)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error registering device", "user_id", userID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to register device")
+		return
+	}
+	slog.InfoContext(r.Context(), "Device registered for push notifications", "user_id", userID, "device_id", device.ID, "platform", device.Platform)
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Device registered",
+		"device":  device,
+	})
+}
+
+// validateRegisterDeviceRequest checks the token and platform of a device registration
+func validateRegisterDeviceRequest(req *model.RegisterDeviceRequest) error {
+	var v validate.Validator
+	v.Required("token", req.Token)
+	v.Check(len(req.Token) <= maxDeviceTokenLength, "token", "is too long")
+	v.Required("platform", req.Platform)
+	v.OneOf("platform", req.Platform, model.DevicePlatforms...)
+	v.MaxLength("app_version", req.AppVersion, 50)
+	v.MaxLength("device_model", req.DeviceModel, 100)
+	return v.Err()
+}
+
+// UnregisterDevice stops push notifications to one of the caller's devices, when the
+// app logs out or the user turns push off on it
+func UnregisterDevice(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+	deviceID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid device ID format")
+		return
+	}
+
+	err = notifications.NewDevices(config.DB).Unregister(r.Context(), userID, deviceID)
+	if errors.Is(err, notifications.ErrDeviceNotFound) {
+		respondError(w, http.StatusNotFound, model.ErrCodeNotFound, "Device not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error unregistering device", "user_id", userID, "device_id", deviceID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to unregister device")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Device unregistered",
+	})
+}
@@ -0,0 +1,470 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/model"
+	"app/internal/temporal"
+	"app/internal/temporal/workflows"
+	"app/internal/validate"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lib/pq"
+)
+
+const disputeColumns = `
+	id, uuid, job_id, opened_by, reason, description, status, temporal_workflow_id, assigned_to,
+	resolved_by, resolved_at, resolution_notes, refund_transaction_id, refund_amount, created_at, updated_at
+`
+
+// scanDispute scans a job_disputes row selected with disputeColumns, followed by any
+// extra columns into extra
+func scanDispute(row rowScanner, extra ...any) (*model.Dispute, error) {
+	var d model.Dispute
+	var workflowID, resolutionNotes sql.NullString
+	var assignedTo, resolvedBy, refundTransactionID sql.NullInt64
+	var resolvedAt sql.NullTime
+
+	err := row.Scan(append([]any{
+		&d.ID, &d.UUID, &d.JobID, &d.OpenedBy, &d.Reason, &d.Description, &d.Status, &workflowID,
+		&assignedTo, &resolvedBy, &resolvedAt, &resolutionNotes, &refundTransactionID, &d.RefundAmount,
+		&d.CreatedAt, &d.UpdatedAt,
+	}, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	d.WorkflowID = stringPtrFromNull(workflowID)
+	d.AssignedTo = intPtrFromNull(assignedTo)
+	d.ResolvedBy = intPtrFromNull(resolvedBy)
+	d.ResolvedAt = timePtrFromNull(resolvedAt)
+	d.ResolutionNotes = stringPtrFromNull(resolutionNotes)
+	d.RefundTransactionID = intPtrFromNull(refundTransactionID)
+	return &d, nil
+}
+
+// ==============================================
+// DISPUTE FILING (CONSUMERS)
+// ==============================================
+
+// CreateDispute lets a job's consumer dispute a completed job. The job's payment is
+// held, and payout settlement skips it, until support resolves the dispute.
+func CreateDispute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	var req model.DisputeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	req.Description = strings.TrimSpace(req.Description)
+	var v validate.Validator
+	if !model.ValidateDisputeReason(req.Reason) {
+		v.AddValue("reason", "must be one of work_incomplete, poor_quality, no_show, property_damage, overcharged, other", req.Reason)
+	}
+	v.Required("description", req.Description)
+	v.Length("description", req.Description, 0, 5000)
+	if err := v.Err(); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+
+	var consumerID int
+	var status string
+	var jobWorkflowID sql.NullString
+	err = config.DB.QueryRow(
+		`SELECT consumer_id, status, temporal_workflow_id FROM jobs WHERE id = $1`, jobID,
+	).Scan(&consumerID, &status, &jobWorkflowID)
+	if err == sql.ErrNoRows {
+		respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if consumerID != userID {
+		RespondWithError(w, http.StatusForbidden, "Only the job's consumer can dispute it")
+		return
+	}
+	switch status {
+	case "completed", "paid", "review_pending", "closed":
+	default:
+		RespondWithError(w, http.StatusConflict, "Only completed jobs can be disputed")
+		return
+	}
+
+	dispute, err := scanDispute(config.DB.QueryRow(`
+		INSERT INTO job_disputes (job_id, opened_by, reason, description)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+disputeColumns,
+		jobID, userID, req.Reason, req.Description,
+	))
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		RespondWithError(w, http.StatusConflict, "This job already has an open dispute")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error creating dispute", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to open dispute")
+		return
+	}
+
+	go startDisputeWorkflow(r.Context(), workflows.DisputeInput{
+		DisputeID:     dispute.ID,
+		JobID:         jobID,
+		JobWorkflowID: jobWorkflowID.String,
+	})
+	signalEscrowWorkflows(r.Context(), jobID)
+
+	RespondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"message": "Your dispute has been opened. Payment for this job is on hold while our support team reviews it.",
+		"dispute": dispute,
+	})
+}
+
+// startDisputeWorkflow starts the dispute hold and records its workflow ID. Without the
+// workflow the job workflow is not paused, but payout settlement still skips the job.
+func startDisputeWorkflow(ctx context.Context, input workflows.DisputeInput) {
+	temporalClient, err := temporal.NewClient()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create Temporal client", "error", err)
+		return
+	}
+	defer temporalClient.Close()
+
+	run, err := temporalClient.StartDisputeWorkflow(context.WithoutCancel(ctx), input)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to start dispute workflow for dispute", "dispute_id", input.DisputeID, "error", err)
+		return
+	}
+
+	if _, err := config.DB.Exec(`UPDATE job_disputes SET temporal_workflow_id = $1 WHERE id = $2`, run.GetID(), input.DisputeID); err != nil {
+		slog.ErrorContext(ctx, "Database error saving dispute workflow", "error", err)
+	}
+}
+
+// ==============================================
+// DISPUTE MANAGEMENT (SUPPORT TEAM)
+// ==============================================
+
+// GetDisputes lists disputes for the support team, unresolved first
+func GetDisputes(w http.ResponseWriter, r *http.Request) {
+	page, err := ParseIntParam(r, "page", 1, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	limit, err := ParseIntParam(r, "limit", DefaultPageSize, MinPageSize, MaxPageSize)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	var whereClauses []string
+	var args []any
+	argIndex := 1
+
+	if status := r.URL.Query().Get("status"); status != "" {
+		switch status {
+		case model.DisputeStatusOpen, model.DisputeStatusUnderReview, model.DisputeStatusResolved, model.DisputeStatusRefunded:
+		default:
+			RespondWithValidationError(w, &ValidationError{
+				Field:   "status",
+				Message: "must be open, under_review, resolved or refunded",
+				Value:   status,
+			})
+			return
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, status)
+		argIndex++
+	}
+	if jobID := r.URL.Query().Get("job_id"); jobID != "" {
+		id, err := ParseIntParam(r, "job_id", 0, 1, 0)
+		if err != nil {
+			RespondWithValidationError(w, err.(*ValidationError))
+			return
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("job_id = $%d", argIndex))
+		args = append(args, id)
+		argIndex++
+	}
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = " WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	var total int
+	if err := config.DB.QueryRow("SELECT COUNT(*) FROM job_disputes"+whereClause, args...).Scan(&total); err != nil {
+		slog.ErrorContext(r.Context(), "Database error counting disputes", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	query := "SELECT " + disputeColumns + " FROM job_disputes" + whereClause +
+		fmt.Sprintf(" ORDER BY (status IN ('resolved', 'refunded')), created_at DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, limit, (page-1)*limit)
+
+	rows, err := config.DB.Query(query, args...)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying disputes", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	disputes := []model.Dispute{}
+	for rows.Next() {
+		dispute, err := scanDispute(rows)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning dispute row", "error", err)
+			continue
+		}
+		disputes = append(disputes, *dispute)
+	}
+
+	pages := (total + limit - 1) / limit
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"disputes": disputes,
+		"pagination": model.Pagination{
+			Page:    page,
+			Limit:   limit,
+			Total:   total,
+			Pages:   pages,
+			HasNext: page < pages,
+			HasPrev: page > 1,
+		},
+	})
+}
+
+// GetDisputeByID retrieves a dispute
+func GetDisputeByID(w http.ResponseWriter, r *http.Request) {
+	disputeID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid dispute ID format")
+		return
+	}
+
+	dispute, err := getDispute(disputeID)
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Dispute not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting dispute", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, dispute)
+}
+
+// UpdateDispute moves a dispute to under_review, or resolves it. Resolving with
+// "refunded" refunds the job's captured payment, in full unless refund_amount is
+// given, which also cancels the job.
+func UpdateDispute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	adminID := GetUserIDFromContext(r)
+	disputeID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid dispute ID format")
+		return
+	}
+
+	var req model.DisputeUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	switch req.Status {
+	case model.DisputeStatusUnderReview, model.DisputeStatusResolved, model.DisputeStatusRefunded:
+	default:
+		RespondWithValidationError(w, &ValidationError{Field: "status", Message: "must be 'under_review', 'resolved' or 'refunded'", Value: req.Status})
+		return
+	}
+	if req.RefundAmount != nil && (req.Status != model.DisputeStatusRefunded || !req.RefundAmount.IsPositive()) {
+		RespondWithValidationError(w, &ValidationError{Field: "refund_amount", Message: "must be positive and is only allowed when refunding"})
+		return
+	}
+
+	current, err := getDispute(disputeID)
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Dispute not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting dispute", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if !model.CanTransitionDispute(current.Status, req.Status) {
+		RespondWithError(w, http.StatusConflict, fmt.Sprintf("Dispute cannot move from %s to %s", current.Status, req.Status))
+		return
+	}
+
+	var refundTransactionID *int
+	var refundAmount *model.Money
+	if req.Status == model.DisputeStatusRefunded {
+		resp, ok := refundDisputedPayment(w, r, current, req.RefundAmount)
+		if !ok {
+			return
+		}
+		refundTransactionID = &resp.RefundID
+		if resp.Transaction != nil {
+			refundAmount = &resp.Transaction.Amount
+		}
+	}
+
+	var query string
+	if req.Status == model.DisputeStatusUnderReview {
+		query = `
+			UPDATE job_disputes
+			SET status = $1, assigned_to = $2, resolution_notes = COALESCE($3, resolution_notes)
+			WHERE id = $4 AND status = $5
+			RETURNING ` + disputeColumns
+	} else {
+		query = `
+			UPDATE job_disputes
+			SET status = $1, resolved_by = $2, resolved_at = NOW(),
+			    assigned_to = COALESCE(assigned_to, $2), resolution_notes = COALESCE($3, resolution_notes),
+			    refund_transaction_id = $6, refund_amount = $7
+			WHERE id = $4 AND status = $5
+			RETURNING ` + disputeColumns
+	}
+	args := []any{req.Status, adminID, req.ResolutionNotes, disputeID, current.Status}
+	if req.Status != model.DisputeStatusUnderReview {
+		args = append(args, nullIntPtr(refundTransactionID), refundAmount)
+	}
+
+	dispute, err := scanDispute(config.DB.QueryRow(query, args...))
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusConflict, "Dispute was updated by someone else; reload it and try again")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error updating dispute", "dispute_id", disputeID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update dispute")
+		return
+	}
+
+	if !dispute.IsOpen() {
+		releaseDisputedJob(r.Context(), dispute)
+		signalEscrowWorkflows(r.Context(), dispute.JobID)
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Dispute updated successfully",
+		"dispute": dispute,
+	})
+}
+
+// refundDisputedPayment refunds the job's captured payment on the consumer's behalf
+func refundDisputedPayment(w http.ResponseWriter, r *http.Request, dispute *model.Dispute, amount *model.Money) (*model.PaymentRefundResponse, bool) {
+	var transactionID, consumerID int
+	var captured model.Money
+	err := config.DB.QueryRow(`
+		SELECT id, consumer_id, COALESCE(capture_amount, amount)
+		FROM transactions
+		WHERE job_id = $1 AND transaction_type = 'authorization'
+		  AND captured_at IS NOT NULL AND refunded_at IS NULL
+		ORDER BY captured_at DESC
+		LIMIT 1
+	`, dispute.JobID).Scan(&transactionID, &consumerID, &captured)
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusConflict, "Job has no captured payment to refund")
+		return nil, false
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error loading payment for dispute", "dispute_id", dispute.ID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return nil, false
+	}
+	if amount != nil && amount.Cents > captured.Cents {
+		RespondWithValidationError(w, &ValidationError{
+			Field:   "refund_amount",
+			Message: fmt.Sprintf("must not exceed the captured amount of %s", captured),
+			Value:   amount.String(),
+		})
+		return nil, false
+	}
+
+	if paymentService == nil {
+		InitPaymentService()
+	}
+	resp, err := paymentService.RefundJobPayment(r.Context(), consumerID, model.PaymentRefundRequest{
+		TransactionID:  transactionID,
+		Amount:         amount,
+		Reason:         "dispute " + dispute.UUID,
+		IdempotencyKey: "dispute-refund-" + dispute.UUID,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to refund payment for dispute", "dispute_id", dispute.ID, "error", err)
+		RespondWithError(w, http.StatusBadGateway, "Failed to refund payment")
+		return nil, false
+	}
+	return resp, true
+}
+
+// releaseDisputedJob ends the dispute's hold on the job. Disputes without a workflow
+// never paused the job workflow, so there is only a hold to release when one ran.
+func releaseDisputedJob(ctx context.Context, dispute *model.Dispute) {
+	if dispute.WorkflowID == nil {
+		return
+	}
+
+	resolution := workflows.DisputeResolution{
+		Status:   dispute.Status,
+		Refunded: dispute.Status == model.DisputeStatusRefunded,
+	}
+	workflowID := *dispute.WorkflowID
+	go func() {
+		temporalClient, err := temporal.NewClient()
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to create Temporal client", "error", err)
+			return
+		}
+		defer temporalClient.Close()
+
+		if err := temporalClient.SignalDisputeResolved(context.WithoutCancel(ctx), workflowID, resolution); err != nil {
+			slog.ErrorContext(ctx, "Failed to signal resolution of dispute", "dispute_id", dispute.ID, "error", err)
+		}
+	}()
+}
+
+func getDispute(disputeID int) (*model.Dispute, error) {
+	return scanDispute(config.DB.QueryRow(
+		"SELECT "+disputeColumns+" FROM job_disputes WHERE id = $1", disputeID,
+	))
+}
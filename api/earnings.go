@@ -0,0 +1,104 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"app/config"
+	"app/internal/currency"
+	"app/internal/earnings"
+	"app/internal/model"
+)
+
+// GetMyEarnings totals the worker's earnings for a tax year by month, with the
+// platform fees withheld and whether a Form 1099-NEC will be issued
+func GetMyEarnings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	thisYear := appFrom(r.Context()).Clock.Now().Year()
+	year, err := ParseIntParam(r, "year", thisYear, 2020, thisYear)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	code := model.DefaultCurrency
+	if v := r.URL.Query().Get("currency"); v != "" {
+		code, err = currency.Normalize(v)
+		if err != nil {
+			RespondWithValidationError(w, &ValidationError{
+				Field:   "currency",
+				Message: "must be one of " + strings.Join(currency.Codes(), ", "),
+				Value:   v,
+			})
+			return
+		}
+	}
+
+	summary, err := earnings.WorkerYear(r.Context(), config.DB, userID, year, code)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting worker earnings", "year", year, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, summary)
+}
+
+// AdminGet1099NEC lists the workers to issue a Form 1099-NEC for a tax year: those
+// paid at least the reporting threshold in USD. ?format=csv downloads it for filing.
+func AdminGet1099NEC(w http.ResponseWriter, r *http.Request) {
+	lastYear := appFrom(r.Context()).Clock.Now().Year() - 1
+	year, err := ParseIntParam(r, "year", lastYear, 2020, lastYear+1)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	threshold := earnings.ReportingThreshold(year)
+	if v := r.URL.Query().Get("threshold"); v != "" {
+		threshold, err = model.ParseMoney(v)
+		if err != nil || threshold.IsNegative() {
+			RespondWithValidationError(w, &ValidationError{Field: "threshold", Message: "must be a non-negative amount", Value: v})
+			return
+		}
+	}
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		RespondWithValidationError(w, &ValidationError{Field: "format", Message: "must be json or csv", Value: format})
+		return
+	}
+
+	recipients, err := earnings.Form1099NEC(r.Context(), config.DB, year, threshold)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting 1099-NEC recipients", "year", year, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	if format == "csv" {
+		records := make([][]string, 0, len(recipients))
+		for _, rcpt := range recipients {
+			records = append(records, []string{
+				strconv.Itoa(year), strconv.Itoa(rcpt.WorkerID), rcpt.WorkerUUID, rcpt.Name, rcpt.Email,
+				formatOptionalString(rcpt.Address), rcpt.NonemployeeCompensation.String(),
+				strconv.Itoa(rcpt.JobsPaid), rcpt.Tips.String(), rcpt.PlatformFees.String(),
+				rcpt.ProcessingFees.String(), rcpt.NetEarnings.String(), rcpt.Reimbursements.String(),
+			})
+		}
+		writeAdminCSV(w, r, "1099-nec-"+strconv.Itoa(year), []string{
+			"tax_year", "worker_id", "worker_uuid", "recipient_name", "email", "address",
+			"box1_nonemployee_compensation", "jobs_paid", "tips", "platform_fees_withheld",
+			"processing_fees_withheld", "net_earnings", "reimbursements_excluded",
+		}, records)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"year":       year,
+		"threshold":  threshold,
+		"recipients": recipients,
+	})
+}
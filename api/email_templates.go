@@ -0,0 +1,39 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"app/internal/email"
+	"app/internal/model"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetEmailTemplates lists the email templates that can be previewed
+func GetEmailTemplates(w http.ResponseWriter, r *http.Request) {
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"templates": email.Templates(),
+	})
+}
+
+// PreviewEmailTemplate renders an email template with sample data, as the HTML page a
+// recipient would see
+func PreviewEmailTemplate(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	html, err := email.Preview(name)
+	if errors.Is(err, email.ErrTemplateNotFound) {
+		respondError(w, http.StatusNotFound, model.ErrCodeNotFound, "Email template not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to render email template preview", "template", name, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to render email template")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(html))
+}
@@ -0,0 +1,284 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/model"
+	"app/internal/notifications"
+	"app/internal/vault"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// saveEmergencyContact encrypts and stores a gig worker's emergency contact
+func saveEmergencyContact(gigWorkerID int, contact model.EmergencyContact) error {
+	v, err := vault.NewVaultFromEnv()
+	if err != nil {
+		return err
+	}
+
+	sealed, err := v.SealJSON(contact, vault.RecordAAD("gigworker_emergency_contacts", gigWorkerID))
+	if err != nil {
+		return err
+	}
+
+	_, err = config.DB.Exec(`
+		INSERT INTO gigworker_emergency_contacts (gigworker_id, sealed_contact, key_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (gigworker_id) DO UPDATE SET
+			sealed_contact = EXCLUDED.sealed_contact,
+			key_id = EXCLUDED.key_id,
+			sealed_record_id = NULL
+	`, gigWorkerID, sealed, v.KeyID())
+	return err
+}
+
+// loadEmergencyContact decrypts a gig worker's stored emergency contact.
+// Returns sql.ErrNoRows if none is on file. Contacts sealed before gig workers were
+// unified into people stay bound to their legacy id until they are next saved.
+func loadEmergencyContact(gigWorkerID int) (*model.EmergencyContact, error) {
+	var sealed string
+	var sealedID int
+	err := config.DB.QueryRow(
+		`SELECT sealed_contact, COALESCE(sealed_record_id, gigworker_id) FROM gigworker_emergency_contacts WHERE gigworker_id = $1`, gigWorkerID,
+	).Scan(&sealed, &sealedID)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := vault.NewVaultFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	var contact model.EmergencyContact
+	if err := v.OpenJSON(sealed, vault.RecordAAD("gigworker_emergency_contacts", sealedID), &contact); err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+// updateEmergencyContact applies a partial update to the stored emergency contact
+func updateEmergencyContact(gigWorkerID int, name, phone, relationship *string) error {
+	contact, err := loadEmergencyContact(gigWorkerID)
+	if err == sql.ErrNoRows {
+		contact = &model.EmergencyContact{}
+	} else if err != nil {
+		return err
+	}
+
+	if name != nil {
+		contact.Name = strings.TrimSpace(*name)
+	}
+	if phone != nil {
+		contact.Phone = strings.TrimSpace(*phone)
+	}
+	if relationship != nil {
+		contact.Relationship = strings.TrimSpace(*relationship)
+	}
+
+	return saveEmergencyContact(gigWorkerID, *contact)
+}
+
+// ==============================================
+// BREAK-GLASS ACCESS (ADMIN, AUDITED)
+// ==============================================
+
+// BreakGlassEmergencyContact reveals a gig worker's emergency contact to an admin
+// handling an open safety incident. Every attempt is written to the access log
+// before decryption, and ops is notified.
+func BreakGlassEmergencyContact(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	adminID := GetUserIDFromContext(r)
+	if adminID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	gigWorkerID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid gig worker ID format")
+		return
+	}
+
+	var req model.BreakGlassRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.IncidentID <= 0 {
+		RespondWithValidationError(w, &ValidationError{Field: "incident_id", Message: "is required"})
+		return
+	}
+	if len(req.Reason) < 10 {
+		RespondWithValidationError(w, &ValidationError{Field: "reason", Message: "must describe why access is needed (at least 10 characters)"})
+		return
+	}
+
+	// The incident must be unresolved and involve this worker
+	var incidentOpen bool
+	err = config.DB.QueryRow(`
+		SELECT i.status <> 'resolved'
+		FROM safety_incidents i
+		JOIN jobs j ON i.job_id = j.id
+		WHERE i.id = $1 AND j.gig_worker_id = $2
+	`, req.IncidentID, gigWorkerID).Scan(&incidentOpen)
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusForbidden, "Incident does not involve this gig worker")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error verifying incident for break-glass", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if !incidentOpen {
+		RespondWithError(w, http.StatusForbidden, "Break-glass access requires an unresolved incident")
+		return
+	}
+
+	// Record the access before revealing anything
+	var accessID int
+	err = config.DB.QueryRow(`
+		INSERT INTO break_glass_access_log (admin_id, gigworker_id, incident_id, reason, ip_address, user_agent)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`, adminID, gigWorkerID, req.IncidentID, req.Reason, r.RemoteAddr, r.UserAgent()).Scan(&accessID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error writing break-glass audit log", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	contact, err := loadEmergencyContact(gigWorkerID)
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "No emergency contact on file")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to open emergency contact for gig worker", "gig_worker_id", gigWorkerID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Emergency contact unavailable")
+		return
+	}
+
+	go func() {
+		notifier, err := notifications.NewOpsNotifierFromEnv()
+		if err != nil {
+			return
+		}
+		err = notifier.Notify(notifications.OpsAlert{
+			Title:    "Emergency contact accessed (break-glass)",
+			Summary:  req.Reason,
+			Severity: notifications.SeverityWarning,
+			Source:   "break-glass",
+			Fields: map[string]string{
+				"Admin":      strconv.Itoa(adminID),
+				"Gig worker": strconv.Itoa(gigWorkerID),
+				"Incident":   strconv.Itoa(req.IncidentID),
+				"Access log": strconv.Itoa(accessID),
+			},
+		})
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Failed to notify ops of break-glass access", "access_id", accessID, "error", err)
+		}
+	}()
+
+	slog.InfoContext(r.Context(), "Break-glass access: admin viewed emergency contact of gig worker", "access_id", accessID, "admin_id", adminID, "gig_worker_id", gigWorkerID, "incident_id", req.IncidentID)
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"emergency_contact": contact,
+		"access_log_id":     accessID,
+	})
+}
+
+// GetBreakGlassAccessLog lists break-glass accesses for audit review
+func GetBreakGlassAccessLog(w http.ResponseWriter, r *http.Request) {
+	page, err := ParseIntParam(r, "page", 1, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	limit, err := ParseIntParam(r, "limit", DefaultPageSize, MinPageSize, MaxPageSize)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	whereClause := ""
+	var args []any
+	if gigWorkerParam := r.URL.Query().Get("gigworker_id"); gigWorkerParam != "" {
+		gigWorkerID, err := ParseIntParam(r, "gigworker_id", 0, 1, 0)
+		if err != nil {
+			RespondWithValidationError(w, err.(*ValidationError))
+			return
+		}
+		whereClause = " WHERE gigworker_id = $1"
+		args = append(args, gigWorkerID)
+	}
+
+	var total int
+	if err := config.DB.QueryRow("SELECT COUNT(*) FROM break_glass_access_log"+whereClause, args...).Scan(&total); err != nil {
+		slog.ErrorContext(r.Context(), "Database error counting break-glass log", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	query := `
+		SELECT id, admin_id, gigworker_id, incident_id, reason, ip_address, accessed_at
+		FROM break_glass_access_log` + whereClause +
+		fmt.Sprintf(" ORDER BY accessed_at DESC LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, limit, (page-1)*limit)
+
+	rows, err := config.DB.Query(query, args...)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying break-glass log", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	entries := []map[string]interface{}{}
+	for rows.Next() {
+		var id, adminID, gigWorkerID, incidentID int
+		var reason string
+		var ipAddress sql.NullString
+		var accessedAt sql.NullTime
+		if err := rows.Scan(&id, &adminID, &gigWorkerID, &incidentID, &reason, &ipAddress, &accessedAt); err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning break-glass log row", "error", err)
+			continue
+		}
+		entries = append(entries, map[string]interface{}{
+			"id":           id,
+			"admin_id":     adminID,
+			"gigworker_id": gigWorkerID,
+			"incident_id":  incidentID,
+			"reason":       reason,
+			"ip_address":   stringPtrFromNull(ipAddress),
+			"accessed_at":  timePtrFromNull(accessedAt),
+		})
+	}
+
+	pages := (total + limit - 1) / limit
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"access_log": entries,
+		"pagination": model.Pagination{
+			Page:    page,
+			Limit:   limit,
+			Total:   total,
+			Pages:   pages,
+			HasNext: page < pages,
+			HasPrev: page > 1,
+		},
+	})
+}
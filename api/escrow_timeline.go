@@ -0,0 +1,61 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/model"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetJobEscrowTimeline returns when the job's payment was authorized, held, charged and
+// refunded, so consumers can tell a hold on their card from a charge. The job's consumer,
+// its assigned worker and admins may view it.
+func GetJobEscrowTimeline(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	var consumerID int
+	var gigWorkerID sql.NullInt64
+	err = config.DB.QueryRow(`SELECT consumer_id, gig_worker_id FROM jobs WHERE id = $1`, jobID).Scan(&consumerID, &gigWorkerID)
+	if err == sql.ErrNoRows {
+		respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	isWorker := gigWorkerID.Valid && int(gigWorkerID.Int64) == userID
+	if userID != consumerID && !isWorker && GetUserRoleFromContext(r) != "admin" {
+		RespondWithError(w, http.StatusForbidden, "Only job participants can view its payments")
+		return
+	}
+
+	if paymentService == nil {
+		InitPaymentService()
+	}
+
+	timeline, err := paymentService.EscrowTimeline(jobID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to build escrow timeline for job", "job_id", jobID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to get escrow timeline")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, timeline)
+}
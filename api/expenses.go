@@ -0,0 +1,606 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/email"
+	"app/internal/model"
+	"app/internal/routing"
+	"app/internal/validate"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// defaultMileageRate is the IRS standard mileage rate used when MILEAGE_RATE_PER_MILE is unset
+const defaultMileageRate = 0.70
+
+// mileageRate returns the per-mile rate for mileage expenses
+func mileageRate() float64 {
+	if v := os.Getenv("MILEAGE_RATE_PER_MILE"); v != "" {
+		if rate, err := strconv.ParseFloat(v, 64); err == nil && rate > 0 {
+			return rate
+		}
+	}
+	return defaultMileageRate
+}
+
+const jobExpenseColumns = `
+	id, uuid, job_id, gig_worker_id, expense_type, description, amount, miles, rate_per_mile,
+	origin_latitude, origin_longitude, distance_method, receipt_url, incurred_at, reimbursable,
+	status, reviewed_by, reviewed_at, review_note, transaction_id, billed_at, created_at
+`
+
+// scanJobExpense scans a job_expenses row selected with jobExpenseColumns
+func scanJobExpense(row rowScanner) (*model.JobExpense, error) {
+	var e model.JobExpense
+	var miles, rate, originLat, originLng sql.NullFloat64
+	var distanceMethod, receiptURL, reviewNote sql.NullString
+	var reviewedBy, transactionID sql.NullInt64
+	var reviewedAt, billedAt sql.NullTime
+
+	err := row.Scan(
+		&e.ID, &e.UUID, &e.JobID, &e.GigWorkerID, &e.ExpenseType, &e.Description, &e.Amount,
+		&miles, &rate, &originLat, &originLng, &distanceMethod, &receiptURL, &e.IncurredAt,
+		&e.Reimbursable, &e.Status, &reviewedBy, &reviewedAt, &reviewNote, &transactionID,
+		&billedAt, &e.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	e.Miles = float64PtrFromNull(miles)
+	e.RatePerMile = float64PtrFromNull(rate)
+	e.OriginLatitude = float64PtrFromNull(originLat)
+	e.OriginLongitude = float64PtrFromNull(originLng)
+	e.DistanceMethod = stringPtrFromNull(distanceMethod)
+	e.ReceiptURL = stringPtrFromNull(receiptURL)
+	e.ReviewedBy = intPtrFromNull(reviewedBy)
+	e.ReviewedAt = timePtrFromNull(reviewedAt)
+	e.ReviewNote = stringPtrFromNull(reviewNote)
+	e.TransactionID = intPtrFromNull(transactionID)
+	e.BilledAt = timePtrFromNull(billedAt)
+	return &e, nil
+}
+
+// expenseJob holds the job fields needed for expense handling
+type expenseJob struct {
+	Title       string
+	Status      string
+	ConsumerID  int
+	GigWorkerID sql.NullInt64
+	Latitude    sql.NullFloat64
+	Longitude   sql.NullFloat64
+	StartedAt   sql.NullTime
+}
+
+// getExpenseJob loads a job for expense handling
+func getExpenseJob(w http.ResponseWriter, r *http.Request, jobID int) (*expenseJob, bool) {
+	var job expenseJob
+	err := config.DB.QueryRow(`
+		SELECT title, status, consumer_id, gig_worker_id, location_latitude, location_longitude,
+		       COALESCE(actual_start, scheduled_start)
+		FROM jobs WHERE id = $1
+	`, jobID).Scan(
+		&job.Title, &job.Status, &job.ConsumerID, &job.GigWorkerID,
+		&job.Latitude, &job.Longitude, &job.StartedAt,
+	)
+	if err == sql.ErrNoRows {
+		respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
+		return nil, false
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return nil, false
+	}
+	return &job, true
+}
+
+// isAssignedWorker reports whether userID is the job's assigned worker
+func (j *expenseJob) isAssignedWorker(userID int) bool {
+	return j.GigWorkerID.Valid && int(j.GigWorkerID.Int64) == userID
+}
+
+// acceptsExpenses reports whether expenses can still be added before payment
+func (j *expenseJob) acceptsExpenses() bool {
+	switch j.Status {
+	case "worker_assigned", "scheduled", "in_progress", "completed":
+		return true
+	}
+	return false
+}
+
+// expenseStatusFor returns the initial status for a new expense
+func expenseStatusFor(reimbursable bool) string {
+	if reimbursable {
+		return model.ExpenseStatusPending
+	}
+	return model.ExpenseStatusLogged
+}
+
+// ==============================================
+// JOB EXPENSES (WORKERS)
+// ==============================================
+
+// CreateJobExpense records a materials or other expense for a job
+func CreateJobExpense(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	var req model.JobExpenseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	req.Description = strings.TrimSpace(req.Description)
+	var v validate.Validator
+	v.Required("expense_type", req.ExpenseType)
+	v.OneOf("expense_type", req.ExpenseType, model.ExpenseTypeMaterials, model.ExpenseTypeOther)
+	v.Required("description", req.Description)
+	v.Length("description", req.Description, 0, 1000)
+	if req.Amount <= 0 || req.Amount > 10000 {
+		v.AddValue("amount", "must be greater than 0 and at most 10000", fmt.Sprintf("%.2f", req.Amount))
+	}
+	if req.Reimbursable && (req.ReceiptURL == nil || strings.TrimSpace(*req.ReceiptURL) == "") {
+		v.Add("receipt_url", "is required for reimbursable expenses")
+	}
+	if err := v.Err(); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+
+	job, ok := getExpenseJob(w, r, jobID)
+	if !ok {
+		return
+	}
+	if !job.isAssignedWorker(userID) {
+		RespondWithError(w, http.StatusForbidden, "Only the assigned worker can add expenses")
+		return
+	}
+	if req.Reimbursable && !job.acceptsExpenses() {
+		RespondWithError(w, http.StatusConflict, "Reimbursable expenses can only be added before payment")
+		return
+	}
+
+	incurredAt := appFrom(r.Context()).Clock.Now()
+	if req.IncurredAt != nil {
+		incurredAt = *req.IncurredAt
+	}
+
+	expense, err := scanJobExpense(config.DB.QueryRow(`
+		INSERT INTO job_expenses (job_id, gig_worker_id, expense_type, description, amount, receipt_url,
+		                          incurred_at, reimbursable, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING `+jobExpenseColumns,
+		jobID, userID, req.ExpenseType, req.Description, math.Round(req.Amount*100)/100, req.ReceiptURL,
+		incurredAt, req.Reimbursable, expenseStatusFor(req.Reimbursable),
+	))
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error creating job expense", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to add expense")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, expense)
+}
+
+// LogJobMileage records the drive to a job. Distance comes from the routing service,
+// starting at the worker's previous job that day or their home location.
+func LogJobMileage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	var req model.MileageRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+			return
+		}
+	}
+	if (req.OriginLatitude == nil) != (req.OriginLongitude == nil) {
+		RespondWithValidationError(w, &ValidationError{Field: "origin_latitude", Message: "origin_latitude and origin_longitude must be provided together"})
+		return
+	}
+
+	job, ok := getExpenseJob(w, r, jobID)
+	if !ok {
+		return
+	}
+	if !job.isAssignedWorker(userID) {
+		RespondWithError(w, http.StatusForbidden, "Only the assigned worker can log mileage")
+		return
+	}
+	if req.Reimbursable && !job.acceptsExpenses() {
+		RespondWithError(w, http.StatusConflict, "Reimbursable expenses can only be added before payment")
+		return
+	}
+	if !job.Latitude.Valid || !job.Longitude.Valid {
+		RespondWithError(w, http.StatusUnprocessableEntity, "Job has no location to compute mileage")
+		return
+	}
+
+	var existing int
+	err = config.DB.QueryRow(
+		`SELECT COUNT(*) FROM job_expenses WHERE job_id = $1 AND gig_worker_id = $2 AND expense_type = 'mileage'`,
+		jobID, userID,
+	).Scan(&existing)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error checking mileage", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if existing > 0 {
+		RespondWithError(w, http.StatusConflict, "Mileage has already been logged for this job")
+		return
+	}
+
+	origin, originDesc, err := mileageOrigin(jobID, userID, job, req)
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusUnprocessableEntity, "No starting point found; provide origin_latitude and origin_longitude")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error finding mileage origin", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	destination := routing.Point{Lat: job.Latitude.Float64, Lng: job.Longitude.Float64}
+	route := routing.NewClient().Distance(r.Context(), origin, destination)
+	rate := mileageRate()
+	amount := math.Round(route.Miles*rate*100) / 100
+
+	incurredAt := appFrom(r.Context()).Clock.Now()
+	if job.StartedAt.Valid {
+		incurredAt = job.StartedAt.Time
+	}
+
+	expense, err := scanJobExpense(config.DB.QueryRow(`
+		INSERT INTO job_expenses (job_id, gig_worker_id, expense_type, description, amount, miles, rate_per_mile,
+		                          origin_latitude, origin_longitude, distance_method, incurred_at, reimbursable, status)
+		VALUES ($1, $2, 'mileage', $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING `+jobExpenseColumns,
+		jobID, userID, fmt.Sprintf("Drive from %s", originDesc), amount, route.Miles, rate,
+		origin.Lat, origin.Lng, route.Method, incurredAt, req.Reimbursable, expenseStatusFor(req.Reimbursable),
+	))
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error logging mileage", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to log mileage")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, expense)
+}
+
+// mileageOrigin picks where the drive to a job started
+func mileageOrigin(jobID, userID int, job *expenseJob, req model.MileageRequest) (routing.Point, string, error) {
+	if req.OriginLatitude != nil {
+		return routing.Point{Lat: *req.OriginLatitude, Lng: *req.OriginLongitude}, "reported location", nil
+	}
+
+	// Previous job the same day
+	if job.StartedAt.Valid {
+		var lat, lng float64
+		var title string
+		err := config.DB.QueryRow(`
+			SELECT location_latitude, location_longitude, title
+			FROM jobs
+			WHERE gig_worker_id = $1 AND id <> $2
+			  AND location_latitude IS NOT NULL AND location_longitude IS NOT NULL
+			  AND COALESCE(actual_end, scheduled_end) <= $3
+			  AND COALESCE(actual_end, scheduled_end)::date = $3::date
+			ORDER BY COALESCE(actual_end, scheduled_end) DESC
+			LIMIT 1
+		`, userID, jobID, job.StartedAt.Time).Scan(&lat, &lng, &title)
+		if err == nil {
+			return routing.Point{Lat: lat, Lng: lng}, "previous job: " + title, nil
+		}
+		if err != sql.ErrNoRows {
+			return routing.Point{}, "", err
+		}
+	}
+
+	// Worker's home location
+	var lat, lng sql.NullFloat64
+	err := config.DB.QueryRow(`SELECT latitude, longitude FROM people WHERE id = $1`, userID).Scan(&lat, &lng)
+	if err != nil {
+		return routing.Point{}, "", err
+	}
+	if !lat.Valid || !lng.Valid {
+		return routing.Point{}, "", sql.ErrNoRows
+	}
+	return routing.Point{Lat: lat.Float64, Lng: lng.Float64}, "home", nil
+}
+
+// GetJobExpenses lists a job's expenses. Consumers only see reimbursement requests.
+func GetJobExpenses(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	job, ok := getExpenseJob(w, r, jobID)
+	if !ok {
+		return
+	}
+
+	query := `SELECT ` + jobExpenseColumns + ` FROM job_expenses WHERE job_id = $1`
+	switch {
+	case job.isAssignedWorker(userID), GetUserRoleFromContext(r) == "admin":
+	case userID == job.ConsumerID:
+		query += ` AND reimbursable = true`
+	default:
+		RespondWithError(w, http.StatusForbidden, "Only job participants can view expenses")
+		return
+	}
+
+	rows, err := config.DB.Query(query+` ORDER BY incurred_at`, jobID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying job expenses", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	expenses := []model.JobExpense{}
+	var pendingTotal, approvedTotal float64
+	for rows.Next() {
+		e, err := scanJobExpense(rows)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning job expense", "error", err)
+			continue
+		}
+		switch e.Status {
+		case model.ExpenseStatusPending:
+			pendingTotal += e.Amount
+		case model.ExpenseStatusApproved:
+			approvedTotal += e.Amount
+		}
+		expenses = append(expenses, *e)
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"expenses":               expenses,
+		"pending_reimbursement":  math.Round(pendingTotal*100) / 100,
+		"approved_reimbursement": math.Round(approvedTotal*100) / 100,
+	})
+}
+
+// ReviewJobExpense lets the consumer approve or reject a reimbursable expense.
+// Approved expenses are added to the job's payment capture.
+func ReviewJobExpense(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+	expenseID, err := strconv.Atoi(chi.URLParam(r, "expenseId"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid expense ID format")
+		return
+	}
+
+	var req model.ExpenseReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	req.Note = strings.TrimSpace(req.Note)
+	if len(req.Note) > 500 {
+		RespondWithValidationError(w, &ValidationError{Field: "note", Message: "must not exceed 500 characters"})
+		return
+	}
+
+	job, ok := getExpenseJob(w, r, jobID)
+	if !ok {
+		return
+	}
+	if userID != job.ConsumerID {
+		RespondWithError(w, http.StatusForbidden, "Only the consumer can review expenses")
+		return
+	}
+	if !job.acceptsExpenses() {
+		RespondWithError(w, http.StatusConflict, "Expenses can only be reviewed before payment")
+		return
+	}
+
+	status := model.ExpenseStatusRejected
+	if req.Approve {
+		status = model.ExpenseStatusApproved
+	}
+
+	expense, err := scanJobExpense(config.DB.QueryRow(`
+		UPDATE job_expenses
+		SET status = $1, reviewed_by = $2, reviewed_at = NOW(), review_note = $3
+		WHERE id = $4 AND job_id = $5 AND status = 'pending'
+		RETURNING `+jobExpenseColumns,
+		status, userID, nullString(req.Note), expenseID, jobID,
+	))
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "No pending expense found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error reviewing job expense", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to review expense")
+		return
+	}
+
+	go notifyExpenseReviewed(r.Context(), *expense, job.Title)
+
+	RespondWithJSON(w, http.StatusOK, expense)
+}
+
+// notifyExpenseReviewed emails the worker the consumer's decision on an expense
+func notifyExpenseReviewed(ctx context.Context, expense model.JobExpense, jobTitle string) {
+	emailService, err := email.NewServiceFromEnv()
+	if err != nil {
+		slog.ErrorContext(ctx, "Email not configured, expense review not sent", "expense_id", expense.ID, "error", err)
+		return
+	}
+
+	var toEmail, toName string
+	err = config.DB.QueryRow(`SELECT email, name FROM people WHERE id = $1`, expense.GigWorkerID).Scan(&toEmail, &toName)
+	if err != nil {
+		slog.ErrorContext(ctx, "Database error getting expense worker", "gig_worker_id", expense.GigWorkerID, "error", err)
+		return
+	}
+
+	baseURL := os.Getenv("APP_BASE_URL")
+	if baseURL == "" {
+		baseURL = "https://app.gigco.com"
+	}
+
+	message := fmt.Sprintf("Your $%.2f expense (%s) was approved and will be added to the job payment.", expense.Amount, expense.Description)
+	if expense.Status == model.ExpenseStatusRejected {
+		message = fmt.Sprintf("Your $%.2f expense (%s) was not approved for reimbursement.", expense.Amount, expense.Description)
+	}
+	if expense.ReviewNote != nil {
+		message += " Note: " + *expense.ReviewNote
+	}
+
+	err = emailService.SendJobNotification(toEmail, toName, email.JobNotificationData{
+		UserName:    toName,
+		JobTitle:    jobTitle,
+		JobID:       strconv.Itoa(expense.JobID),
+		Message:     message,
+		ActionLink:  fmt.Sprintf("%s/jobs/%d/expenses", baseURL, expense.JobID),
+		ActionLabel: "View expenses",
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to send expense review", "expense_id", expense.ID, "error", err)
+	}
+}
+
+// ==============================================
+// WORKER TAX SUMMARY
+// ==============================================
+
+// GetWorkerTaxSummary totals the worker's earnings and deductible expenses for a tax year
+func GetWorkerTaxSummary(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	thisYear := appFrom(r.Context()).Clock.Now().Year()
+	year, err := ParseIntParam(r, "year", thisYear, 2020, thisYear)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	from := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, 0)
+
+	summary := model.WorkerTaxSummary{Year: year, ExpensesByType: map[string]float64{}}
+
+	err = config.DB.QueryRow(`
+		SELECT COUNT(*),
+		       COALESCE(SUM(COALESCE(t.capture_amount, t.amount)), 0),
+		       COALESCE(SUM(COALESCE(t.platform_fee, 0)), 0),
+		       COALESCE(SUM(s.amount), 0)
+		FROM transactions t
+		LEFT JOIN (
+			SELECT transaction_id, SUM(amount) AS amount
+			FROM payment_splits
+			WHERE split_type IN ('expense_reimbursement', 'materials')
+			GROUP BY transaction_id
+		) s ON s.transaction_id = t.id
+		WHERE t.gig_worker_id = $1 AND t.captured_at >= $2 AND t.captured_at < $3 AND t.refunded_at IS NULL
+	`, userID, from, to).Scan(&summary.JobsPaid, &summary.GrossEarnings, &summary.PlatformFees, &summary.Reimbursements)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting worker earnings", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	// Reimbursed expenses and parts pass through to the worker and are not earnings
+	summary.GrossEarnings -= summary.Reimbursements
+
+	rows, err := config.DB.Query(`
+		SELECT expense_type, COALESCE(SUM(amount), 0), COALESCE(SUM(miles), 0)
+		FROM job_expenses
+		WHERE gig_worker_id = $1 AND incurred_at >= $2 AND incurred_at < $3 AND status <> 'rejected'
+		GROUP BY expense_type
+	`, userID, from, to)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting worker expenses", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var expenseType string
+		var amount, miles float64
+		if err := rows.Scan(&expenseType, &amount, &miles); err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning worker expense totals", "error", err)
+			continue
+		}
+		summary.ExpensesByType[expenseType] = math.Round(amount*100) / 100
+		if expenseType == model.ExpenseTypeMileage {
+			summary.TotalMiles = miles
+			summary.MileageDeduction = math.Round(amount*100) / 100
+		}
+		summary.TotalExpenses += amount
+	}
+	summary.TotalExpenses = math.Round(summary.TotalExpenses*100) / 100
+
+	RespondWithJSON(w, http.StatusOK, summary)
+}
@@ -0,0 +1,347 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"app/config"
+	"app/internal/model"
+	"app/internal/validate"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lib/pq"
+)
+
+// favoriteWorkerColumns selects a model.FavoriteWorker for scanFavoriteWorker
+const favoriteWorkerColumns = `
+	f.worker_id, p.name, f.consumer_auto_accept,
+	COALESCE(wp.auto_accept_enabled, false) AND f.worker_auto_accept,
+	wp.auto_accept_min_price, wp.last_seen_at, f.created_at
+	FROM favorite_workers f
+	JOIN people p ON p.id = f.worker_id
+	LEFT JOIN worker_profiles wp ON wp.worker_id = f.worker_id`
+
+func scanFavoriteWorker(row rowScanner, now time.Time) (*model.FavoriteWorker, error) {
+	var f model.FavoriteWorker
+	var minPrice sql.NullFloat64
+	var lastSeen sql.NullTime
+	if err := row.Scan(&f.WorkerID, &f.WorkerName, &f.AutoAccept, &f.WorkerAutoAccepts, &minPrice, &lastSeen, &f.CreatedAt); err != nil {
+		return nil, err
+	}
+	if minPrice.Valid {
+		f.MinPrice = &minPrice.Float64
+	}
+	f.Presence, f.OnlineNow = workerPresence(lastSeen, now)
+	return &f, nil
+}
+
+// GetFavoriteWorkers lists the workers the consumer favorited, newest first, with
+// whether each auto-accepts their rebookings
+func GetFavoriteWorkers(w http.ResponseWriter, r *http.Request) {
+	consumerID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	rows, err := config.DB.QueryContext(r.Context(), `
+		SELECT `+favoriteWorkerColumns+`
+		WHERE f.consumer_id = $1
+		ORDER BY f.created_at DESC
+	`, consumerID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing favorite workers", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	favorites := []model.FavoriteWorker{}
+	for rows.Next() {
+		f, err := scanFavoriteWorker(rows, appFrom(r.Context()).Clock.Now())
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning favorite worker", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		favorites = append(favorites, *f)
+	}
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing favorite workers", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{"favorites": favorites})
+}
+
+// AddFavoriteWorker favorites an active gig worker so the consumer can rebook them
+func AddFavoriteWorker(w http.ResponseWriter, r *http.Request) {
+	consumerID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	var req model.FavoriteWorkerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	if req.WorkerID <= 0 {
+		RespondWithValidationError(w, &ValidationError{Field: "worker_id", Message: "is required"})
+		return
+	}
+	autoAccept := true
+	if req.AutoAccept != nil {
+		autoAccept = *req.AutoAccept
+	}
+
+	var active bool
+	err := config.DB.QueryRowContext(r.Context(),
+		`SELECT is_active FROM people WHERE id = $1 AND role = 'gig_worker'`, req.WorkerID).Scan(&active)
+	if err == sql.ErrNoRows || (err == nil && !active) {
+		respondError(w, http.StatusNotFound, model.ErrCodeGigWorkerNotFound, "Gig worker not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error loading gig worker", "worker_id", req.WorkerID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	_, err = config.DB.ExecContext(r.Context(), `
+		INSERT INTO favorite_workers (consumer_id, worker_id, consumer_auto_accept)
+		VALUES ($1, $2, $3)
+	`, consumerID, req.WorkerID, autoAccept)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		RespondWithError(w, http.StatusConflict, "Worker is already a favorite")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error adding favorite worker", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to add favorite worker")
+		return
+	}
+
+	respondFavoriteWorker(w, r, consumerID, req.WorkerID, http.StatusCreated)
+}
+
+// UpdateFavoriteWorker turns auto-accept on or off for the consumer's rebookings with
+// one favorite worker
+func UpdateFavoriteWorker(w http.ResponseWriter, r *http.Request) {
+	consumerID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+	workerID, err := strconv.Atoi(chi.URLParam(r, "workerId"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid gig worker ID format")
+		return
+	}
+
+	var req model.AutoAcceptPairRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	if req.AutoAccept == nil {
+		RespondWithValidationError(w, &ValidationError{Field: "auto_accept", Message: "is required"})
+		return
+	}
+
+	result, err := config.DB.ExecContext(r.Context(), `
+		UPDATE favorite_workers SET consumer_auto_accept = $3
+		WHERE consumer_id = $1 AND worker_id = $2
+	`, consumerID, workerID, *req.AutoAccept)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error updating favorite worker", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update favorite worker")
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		RespondWithError(w, http.StatusNotFound, "Favorite worker not found")
+		return
+	}
+
+	respondFavoriteWorker(w, r, consumerID, workerID, http.StatusOK)
+}
+
+// RemoveFavoriteWorker unfavorites a worker. Jobs already rebooked with them keep
+// going through auto-accept checks, which then send the offer instead.
+func RemoveFavoriteWorker(w http.ResponseWriter, r *http.Request) {
+	consumerID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+	workerID, err := strconv.Atoi(chi.URLParam(r, "workerId"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid gig worker ID format")
+		return
+	}
+
+	result, err := config.DB.ExecContext(r.Context(),
+		`DELETE FROM favorite_workers WHERE consumer_id = $1 AND worker_id = $2`, consumerID, workerID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error removing favorite worker", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to remove favorite worker")
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		RespondWithError(w, http.StatusNotFound, "Favorite worker not found")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Favorite worker removed",
+	})
+}
+
+// respondFavoriteWorker writes one of the consumer's favorites
+func respondFavoriteWorker(w http.ResponseWriter, r *http.Request, consumerID, workerID, status int) {
+	f, err := scanFavoriteWorker(config.DB.QueryRowContext(r.Context(), `
+		SELECT `+favoriteWorkerColumns+`
+		WHERE f.consumer_id = $1 AND f.worker_id = $2
+	`, consumerID, workerID), appFrom(r.Context()).Clock.Now())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error loading favorite worker", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	RespondWithJSON(w, status, f)
+}
+
+// GetAutoAcceptSettings returns the worker's auto-accept opt-in and price floor and the
+// consumers who favorited them
+func GetAutoAcceptSettings(w http.ResponseWriter, r *http.Request) {
+	workerID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+	respondAutoAcceptSettings(w, r, workerID)
+}
+
+// UpdateAutoAcceptSettings opts the worker into or out of auto-accepting rebookings from
+// consumers who favorited them, with an optional price floor
+func UpdateAutoAcceptSettings(w http.ResponseWriter, r *http.Request) {
+	workerID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	var req model.AutoAcceptSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	var v validate.Validator
+	v.Check(req.Enabled != nil, "enabled", "is required")
+	v.Check(req.MinPrice == nil || *req.MinPrice > 0, "min_price", "must be greater than 0")
+	if err := v.Err(); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+
+	_, err := config.DB.ExecContext(r.Context(), `
+		INSERT INTO worker_profiles (worker_id, auto_accept_enabled, auto_accept_min_price) VALUES ($1, $2, $3)
+		ON CONFLICT (worker_id) DO UPDATE SET auto_accept_enabled = $2, auto_accept_min_price = $3
+	`, workerID, *req.Enabled, req.MinPrice)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error updating auto-accept settings", "worker_id", workerID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update auto-accept settings")
+		return
+	}
+
+	slog.InfoContext(r.Context(), "Worker updated auto-accept settings", "worker_id", workerID, "enabled", *req.Enabled)
+	respondAutoAcceptSettings(w, r, workerID)
+}
+
+// UpdateAutoAcceptConsumer opts the worker out of (or back into) auto-accepting one
+// consumer's rebookings
+func UpdateAutoAcceptConsumer(w http.ResponseWriter, r *http.Request) {
+	workerID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+	consumerID, err := strconv.Atoi(chi.URLParam(r, "consumerId"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid consumer ID format")
+		return
+	}
+
+	var req model.AutoAcceptPairRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	if req.AutoAccept == nil {
+		RespondWithValidationError(w, &ValidationError{Field: "auto_accept", Message: "is required"})
+		return
+	}
+
+	result, err := config.DB.ExecContext(r.Context(), `
+		UPDATE favorite_workers SET worker_auto_accept = $3
+		WHERE worker_id = $1 AND consumer_id = $2
+	`, workerID, consumerID, *req.AutoAccept)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error updating auto-accept pair", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update auto-accept settings")
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		RespondWithError(w, http.StatusNotFound, "Consumer has not favorited you")
+		return
+	}
+
+	respondAutoAcceptSettings(w, r, workerID)
+}
+
+// respondAutoAcceptSettings writes the worker's auto-accept settings
+func respondAutoAcceptSettings(w http.ResponseWriter, r *http.Request, workerID int) {
+	settings := model.AutoAcceptSettings{Consumers: []model.AutoAcceptConsumer{}}
+	var minPrice sql.NullFloat64
+	err := config.DB.QueryRowContext(r.Context(), `
+		SELECT auto_accept_enabled, auto_accept_min_price FROM worker_profiles WHERE worker_id = $1
+	`, workerID).Scan(&settings.Enabled, &minPrice)
+	if err != nil && err != sql.ErrNoRows {
+		slog.ErrorContext(r.Context(), "Database error loading auto-accept settings", "worker_id", workerID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if minPrice.Valid {
+		settings.MinPrice = &minPrice.Float64
+	}
+
+	rows, err := config.DB.QueryContext(r.Context(), `
+		SELECT f.consumer_id, p.name, f.worker_auto_accept, f.created_at
+		FROM favorite_workers f
+		JOIN people p ON p.id = f.consumer_id
+		WHERE f.worker_id = $1 AND p.is_active = true
+		ORDER BY f.created_at DESC
+	`, workerID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error loading auto-accept consumers", "worker_id", workerID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var c model.AutoAcceptConsumer
+		if err := rows.Scan(&c.ConsumerID, &c.ConsumerName, &c.AutoAccept, &c.FavoritedAt); err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning auto-accept consumer", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		settings.Consumers = append(settings.Consumers, c)
+	}
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(r.Context(), "Database error loading auto-accept consumers", "worker_id", workerID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, settings)
+}
@@ -0,0 +1,280 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"app/config"
+	"app/internal/model"
+	"app/internal/payment"
+	"app/internal/validate"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// maxFeeRuleFixed caps a fee rule's flat platform fee
+var maxFeeRuleFixed = model.USD(100000)
+
+// GetFeeRules lists the platform fee rules, active ones first in the order they are
+// matched. ?active=true|false filters by status.
+func GetFeeRules(w http.ResponseWriter, r *http.Request) {
+	active, filtered, err := ParseBoolParam(r, "active")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	query := `SELECT ` + payment.FeeRuleColumns + ` FROM fee_rules`
+	args := []interface{}{}
+	if filtered {
+		query += ` WHERE is_active = $1`
+		args = append(args, active)
+	}
+	query += ` ORDER BY is_active DESC, priority DESC, id DESC`
+
+	rows, err := config.DB.QueryContext(r.Context(), query, args...)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing fee rules", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	rules := []model.FeeRule{}
+	for rows.Next() {
+		rule, err := payment.ScanFeeRule(rows)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning fee rule", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		rules = append(rules, *rule)
+	}
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing fee rules", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{"rules": rules})
+}
+
+// CreateFeeRule adds a platform fee rule, which applies to jobs priced from then on
+func CreateFeeRule(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	req, ok := decodeFeeRuleRequest(w, r)
+	if !ok {
+		return
+	}
+
+	row := config.DB.QueryRowContext(r.Context(), `
+		INSERT INTO fee_rules (uuid, name, category, worker_tier, starts_at, ends_at,
+		                       platform_fee_percent, platform_fee_fixed, priority, is_active, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING `+payment.FeeRuleColumns,
+		appFrom(r.Context()).IDs.NewID(), req.Name, req.Category, req.WorkerTier, req.StartsAt, req.EndsAt,
+		req.PlatformFeePercent, req.PlatformFeeFixed, req.Priority, *req.IsActive, adminID)
+	rule, err := payment.ScanFeeRule(row)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error creating fee rule", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to create fee rule")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, rule)
+}
+
+// UpdateFeeRule replaces a fee rule. Payments already authorized keep the fee they
+// were quoted.
+func UpdateFeeRule(w http.ResponseWriter, r *http.Request) {
+	ruleID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid fee rule ID format")
+		return
+	}
+
+	req, ok := decodeFeeRuleRequest(w, r)
+	if !ok {
+		return
+	}
+
+	row := config.DB.QueryRowContext(r.Context(), `
+		UPDATE fee_rules
+		SET name = $2, category = $3, worker_tier = $4, starts_at = $5, ends_at = $6,
+		    platform_fee_percent = $7, platform_fee_fixed = $8, priority = $9, is_active = $10
+		WHERE id = $1
+		RETURNING `+payment.FeeRuleColumns,
+		ruleID, req.Name, req.Category, req.WorkerTier, req.StartsAt, req.EndsAt,
+		req.PlatformFeePercent, req.PlatformFeeFixed, req.Priority, *req.IsActive)
+	rule, err := payment.ScanFeeRule(row)
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Fee rule not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error updating fee rule", "fee_rule_id", ruleID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update fee rule")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, rule)
+}
+
+// DeactivateFeeRule stops a fee rule from applying. The rule is kept, since
+// authorized payments record the rule that priced them.
+func DeactivateFeeRule(w http.ResponseWriter, r *http.Request) {
+	ruleID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid fee rule ID format")
+		return
+	}
+
+	result, err := config.DB.ExecContext(r.Context(), `UPDATE fee_rules SET is_active = false WHERE id = $1`, ruleID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error deactivating fee rule", "fee_rule_id", ruleID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to deactivate fee rule")
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		RespondWithError(w, http.StatusNotFound, "Fee rule not found")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Fee rule deactivated",
+	})
+}
+
+// PreviewFees shows the effective fees for a job with ?category= and ?worker_tier= at
+// ?at= (default now), split over ?amount= (default 100.00)
+func PreviewFees(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	amount := model.USD(10000)
+	if s := query.Get("amount"); s != "" {
+		parsed, err := model.ParseMoney(s)
+		if err != nil || !parsed.IsPositive() {
+			RespondWithValidationError(w, &ValidationError{Field: "amount", Message: "must be a positive amount", Value: s})
+			return
+		}
+		amount = parsed
+	}
+	at, err := ParseDateParam(r, "at")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	if at == nil {
+		now := appFrom(r.Context()).Clock.Now()
+		at = &now
+	}
+
+	if paymentService == nil {
+		InitPaymentService()
+	}
+
+	preview, err := paymentService.PreviewFees(strings.TrimSpace(query.Get("category")),
+		strings.TrimSpace(query.Get("worker_tier")), amount, *at)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to preview fees", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to preview fees")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, preview)
+}
+
+// UpdateWorkerFeeTier sets the fee tier matched by fee rules' worker_tier when the
+// worker's jobs are priced; a null tier is the standard fee
+func UpdateWorkerFeeTier(w http.ResponseWriter, r *http.Request) {
+	workerID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid gig worker ID format")
+		return
+	}
+
+	var req model.WorkerFeeTierRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	req.Tier = trimOptional(req.Tier)
+	var v validate.Validator
+	v.MaxLength("tier", req.Tier, 50)
+	if err := v.Err(); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+
+	result, err := config.DB.ExecContext(r.Context(),
+		`UPDATE worker_profiles SET fee_tier = $2 WHERE worker_id = $1`, workerID, req.Tier)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error updating worker fee tier", "worker_id", workerID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update fee tier")
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		respondError(w, http.StatusNotFound, model.ErrCodeGigWorkerNotFound, "Gig worker not found")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"worker_id": workerID,
+		"fee_tier":  req.Tier,
+	})
+}
+
+// decodeFeeRuleRequest reads and validates a fee rule, defaulting is_active to true
+func decodeFeeRuleRequest(w http.ResponseWriter, r *http.Request) (*model.FeeRuleRequest, bool) {
+	var req model.FeeRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return nil, false
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	req.Category = trimOptional(req.Category)
+	req.WorkerTier = trimOptional(req.WorkerTier)
+	if req.IsActive == nil {
+		active := true
+		req.IsActive = &active
+	}
+
+	var v validate.Validator
+	v.Required("name", req.Name)
+	v.Length("name", req.Name, 0, 255)
+	v.MaxLength("category", req.Category, 100)
+	v.MaxLength("worker_tier", req.WorkerTier, 50)
+	if req.PlatformFeePercent < 0 || req.PlatformFeePercent > 100 {
+		v.AddValue("platform_fee_percent", "must be between 0 and 100", fmt.Sprintf("%.2f", req.PlatformFeePercent))
+	}
+	if req.PlatformFeeFixed.IsNegative() || req.PlatformFeeFixed.Cents > maxFeeRuleFixed.Cents {
+		v.AddValue("platform_fee_fixed", "must be between 0 and "+maxFeeRuleFixed.String(), req.PlatformFeeFixed.String())
+	}
+	v.Check(req.StartsAt == nil || req.EndsAt == nil || req.EndsAt.After(*req.StartsAt), "ends_at", "must be after starts_at")
+	if err := v.Err(); err != nil {
+		RespondWithValidationErrors(w, err)
+		return nil, false
+	}
+	return &req, true
+}
+
+// trimOptional trims an optional string, treating a blank one as absent
+func trimOptional(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/fraud"
+	"app/internal/model"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// fraudFlagColumns is the column list scanned by scanFraudFlag
+const fraudFlagColumns = `
+	id, uuid, user_id, job_id, rule, score, details, status, alerted_at,
+	reviewed_by, reviewed_at, review_notes, created_at, updated_at
+`
+
+// scanFraudFlag scans a fraud_flags row selected with fraudFlagColumns
+func scanFraudFlag(row rowScanner) (*model.FraudFlag, error) {
+	var f model.FraudFlag
+	var jobID, reviewedBy sql.NullInt64
+	var details, reviewNotes sql.NullString
+	var alertedAt, reviewedAt sql.NullTime
+
+	err := row.Scan(
+		&f.ID, &f.UUID, &f.UserID, &jobID, &f.Rule, &f.Score, &details, &f.Status, &alertedAt,
+		&reviewedBy, &reviewedAt, &reviewNotes, &f.CreatedAt, &f.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	f.JobID = intPtrFromNull(jobID)
+	f.Details = stringPtrFromNull(details)
+	f.AlertedAt = timePtrFromNull(alertedAt)
+	f.ReviewedBy = intPtrFromNull(reviewedBy)
+	f.ReviewedAt = timePtrFromNull(reviewedAt)
+	f.ReviewNotes = stringPtrFromNull(reviewNotes)
+	return &f, nil
+}
+
+// GetFraudFlags lists fraud flags for review, highest score first
+func GetFraudFlags(w http.ResponseWriter, r *http.Request) {
+	page, err := ParseIntParam(r, "page", 1, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	limit, err := ParseIntParam(r, "limit", DefaultPageSize, MinPageSize, MaxPageSize)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	var whereClauses []string
+	var args []any
+	argIndex := 1
+
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = fraud.StatusOpen
+	}
+	if status != "all" {
+		whereClauses = append(whereClauses, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, status)
+		argIndex++
+	}
+	if userID := r.URL.Query().Get("user_id"); userID != "" {
+		id, err := ParseIntParam(r, "user_id", 0, 1, 0)
+		if err != nil {
+			RespondWithValidationError(w, err.(*ValidationError))
+			return
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("user_id = $%d", argIndex))
+		args = append(args, id)
+		argIndex++
+	}
+	if minScore := r.URL.Query().Get("min_score"); minScore != "" {
+		score, err := strconv.ParseFloat(minScore, 64)
+		if err != nil || score < 0 || score > 1 {
+			RespondWithValidationError(w, &ValidationError{Field: "min_score", Message: "must be a number between 0 and 1", Value: minScore})
+			return
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("score >= $%d", argIndex))
+		args = append(args, score)
+		argIndex++
+	}
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = " WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	var total int
+	if err := config.DB.QueryRow("SELECT COUNT(*) FROM fraud_flags"+whereClause, args...).Scan(&total); err != nil {
+		slog.ErrorContext(r.Context(), "Database error counting fraud flags", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	query := "SELECT " + fraudFlagColumns + " FROM fraud_flags" + whereClause +
+		fmt.Sprintf(" ORDER BY score DESC, created_at DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, limit, (page-1)*limit)
+
+	rows, err := config.DB.Query(query, args...)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying fraud flags", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	flags := []model.FraudFlag{}
+	for rows.Next() {
+		flag, err := scanFraudFlag(rows)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning fraud flag row", "error", err)
+			continue
+		}
+		flags = append(flags, *flag)
+	}
+
+	pages := (total + limit - 1) / limit
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"flags":           flags,
+		"alert_threshold": fraud.AlertThreshold(),
+		"pagination": model.Pagination{
+			Page:    page,
+			Limit:   limit,
+			Total:   total,
+			Pages:   pages,
+			HasNext: page < pages,
+			HasPrev: page > 1,
+		},
+	})
+}
+
+// ReviewFraudFlag records an admin's decision to dismiss or confirm an open fraud flag
+func ReviewFraudFlag(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	adminID := GetUserIDFromContext(r)
+	flagID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid fraud flag ID format")
+		return
+	}
+
+	var req model.FraudFlagReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	if req.Status != fraud.StatusDismissed && req.Status != fraud.StatusConfirmed {
+		RespondWithValidationError(w, &ValidationError{Field: "status", Message: "must be 'dismissed' or 'confirmed'", Value: req.Status})
+		return
+	}
+
+	flag, err := scanFraudFlag(config.DB.QueryRow(`
+		UPDATE fraud_flags
+		SET status = $1, reviewed_by = $2, reviewed_at = NOW(), review_notes = $3
+		WHERE id = $4 AND status = 'open'
+		RETURNING `+fraudFlagColumns,
+		req.Status, adminID, req.ReviewNotes, flagID))
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusConflict, "Fraud flag not found or already reviewed")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error reviewing fraud flag", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to review fraud flag")
+		return
+	}
+
+	slog.InfoContext(r.Context(), "Fraud flag by admin", "flag_id", flagID, "status", req.Status, "admin_id", adminID)
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Fraud flag reviewed successfully",
+		"flag":    flag,
+	})
+}
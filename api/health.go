@@ -2,22 +2,30 @@ package api
 
 import (
 	"app/config"
+	"app/internal/migrate"
+	"app/internal/payment"
+	"app/internal/temporal"
+	"app/migrations"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"os"
 	"runtime"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // HealthStatus represents the overall health status
 type HealthStatus struct {
-	Status      string                   `json:"status"`
-	Version     string                   `json:"version,omitempty"`
-	Environment string                   `json:"environment,omitempty"`
-	Timestamp   time.Time                `json:"timestamp"`
-	Uptime      string                   `json:"uptime,omitempty"`
-	Checks      map[string]ComponentCheck `json:"checks,omitempty"`
+	Status        string                    `json:"status"`
+	Version       string                    `json:"version,omitempty"`
+	Environment   string                    `json:"environment,omitempty"`
+	SchemaVersion *int64                    `json:"schema_version,omitempty"` // Newest migration applied to the database
+	Timestamp     time.Time                 `json:"timestamp"`
+	Uptime        string                    `json:"uptime,omitempty"`
+	Checks        map[string]ComponentCheck `json:"checks,omitempty"`
 }
 
 // ComponentCheck represents a health check for a component
@@ -29,10 +37,20 @@ type ComponentCheck struct {
 
 var startTime = time.Now()
 
+// shuttingDown is set once the server starts draining, failing readiness so the load
+// balancer stops routing new requests here while in-flight ones finish
+var shuttingDown atomic.Bool
+
+// MarkShuttingDown makes the readiness probe fail for the rest of the process's life
+func MarkShuttingDown() {
+	shuttingDown.Store(true)
+}
+
 // Note: Basic HealthCheck is defined in api.go for backwards compatibility
 // Use ReadinessCheck and LivenessCheck for Kubernetes-style health checks
 
-// ReadinessCheck returns detailed health status with dependency checks
+// ReadinessCheck returns detailed health status with dependency checks. It fails while
+// the server is shutting down.
 // @Summary Readiness check endpoint
 // @Description Returns detailed health status including database and other dependencies
 // @Tags health
@@ -40,21 +58,39 @@ var startTime = time.Now()
 // @Success 200 {object} HealthStatus
 // @Failure 503 {object} HealthStatus
 // @Router /ready [get]
+// @Router /readyz [get]
 func ReadinessCheck(w http.ResponseWriter, r *http.Request) {
 	checks := make(map[string]ComponentCheck)
 	overallHealthy := true
 
+	if shuttingDown.Load() {
+		checks["server"] = ComponentCheck{
+			Status:  "unhealthy",
+			Message: "shutting down",
+		}
+		overallHealthy = false
+	}
+
 	// Check database
-	dbCheck := checkDatabase()
+	dbCheck := checkDatabase(r.Context())
 	checks["database"] = dbCheck
 	if dbCheck.Status != "healthy" {
 		overallHealthy = false
 	}
 
+	// Report the schema version; pending migrations do not fail readiness, since
+	// instances of a new release may start before cmd/migrate runs
+	var schemaVersion *int64
+	if dbCheck.Status == "healthy" {
+		var schemaCheck ComponentCheck
+		schemaCheck, schemaVersion = checkSchema(r.Context())
+		checks["schema"] = schemaCheck
+	}
+
 	// Check Temporal (if configured)
 	temporalHost := os.Getenv("TEMPORAL_HOST")
 	if temporalHost != "" {
-		temporalCheck := checkTemporal()
+		temporalCheck := checkTemporal(r.Context())
 		checks["temporal"] = temporalCheck
 		if temporalCheck.Status != "healthy" {
 			overallHealthy = false
@@ -69,12 +105,13 @@ func ReadinessCheck(w http.ResponseWriter, r *http.Request) {
 	}
 
 	healthStatus := HealthStatus{
-		Status:      status,
-		Version:     os.Getenv("APP_VERSION"),
-		Environment: os.Getenv("APP_ENV"),
-		Timestamp:   time.Now(),
-		Uptime:      time.Since(startTime).String(),
-		Checks:      checks,
+		Status:        status,
+		Version:       os.Getenv("APP_VERSION"),
+		Environment:   os.Getenv("APP_ENV"),
+		SchemaVersion: schemaVersion,
+		Timestamp:     appFrom(r.Context()).Clock.Now(),
+		Uptime:        time.Since(startTime).String(),
+		Checks:        checks,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -89,10 +126,11 @@ func ReadinessCheck(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Success 200 {object} HealthStatus
 // @Router /live [get]
+// @Router /healthz [get]
 func LivenessCheck(w http.ResponseWriter, r *http.Request) {
 	status := HealthStatus{
 		Status:    "alive",
-		Timestamp: time.Now(),
+		Timestamp: appFrom(r.Context()).Clock.Now(),
 		Uptime:    time.Since(startTime).String(),
 	}
 
@@ -112,7 +150,7 @@ func MetricsCheck(w http.ResponseWriter, r *http.Request) {
 	runtime.ReadMemStats(&memStats)
 
 	metrics := map[string]interface{}{
-		"timestamp": time.Now(),
+		"timestamp": appFrom(r.Context()).Clock.Now(),
 		"uptime":    time.Since(startTime).String(),
 		"runtime": map[string]interface{}{
 			"goroutines":    runtime.NumGoroutine(),
@@ -125,6 +163,7 @@ func MetricsCheck(w http.ResponseWriter, r *http.Request) {
 			"sys_mb":         memStats.Sys / 1024 / 1024,
 			"num_gc":         memStats.NumGC,
 		},
+		"payment_providers": payment.LimiterStats(),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -132,7 +171,7 @@ func MetricsCheck(w http.ResponseWriter, r *http.Request) {
 }
 
 // checkDatabase verifies database connectivity
-func checkDatabase() ComponentCheck {
+func checkDatabase(ctx context.Context) ComponentCheck {
 	if config.DB == nil {
 		return ComponentCheck{
 			Status:  "unhealthy",
@@ -140,7 +179,7 @@ func checkDatabase() ComponentCheck {
 		}
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	start := time.Now()
@@ -162,22 +201,58 @@ func checkDatabase() ComponentCheck {
 	}
 }
 
-// checkTemporal verifies Temporal connectivity
-func checkTemporal() ComponentCheck {
-	// For now, just check if the host is configured
-	// In a full implementation, you would ping the Temporal service
-	temporalHost := os.Getenv("TEMPORAL_HOST")
-	if temporalHost == "" {
+// checkTemporal verifies the Temporal frontend is reachable
+func checkTemporal(ctx context.Context) ComponentCheck {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	err := temporal.CheckHealth(ctx)
+	latency := time.Since(start)
+
+	if err != nil {
 		return ComponentCheck{
-			Status:  "unknown",
-			Message: "temporal host not configured",
+			Status:  "unhealthy",
+			Message: "temporal health check failed: " + err.Error(),
+			Latency: latency.String(),
 		}
 	}
 
-	// TODO: Add actual Temporal health check
-	// This would require importing the Temporal client and pinging the service
 	return ComponentCheck{
 		Status:  "healthy",
-		Message: "temporal configured at " + temporalHost,
+		Message: "temporal connection OK",
+		Latency: latency.String(),
 	}
 }
+
+// schemaMigrator is loaded once; the embedded migrations never change while running
+var schemaMigrator = sync.OnceValues(func() (*migrate.Migrator, error) {
+	return migrate.New(config.DB, migrations.FS)
+})
+
+// checkSchema reports the newest migration applied to the database against the newest
+// this binary embeds
+func checkSchema(ctx context.Context) (ComponentCheck, *int64) {
+	migrator, err := schemaMigrator()
+	if err != nil {
+		return ComponentCheck{Status: "unhealthy", Message: err.Error()}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	version, err := migrator.Version(ctx)
+	if err != nil {
+		return ComponentCheck{Status: "unhealthy", Message: "schema version check failed: " + err.Error()}, nil
+	}
+
+	if latest := migrator.Latest(); version < latest {
+		return ComponentCheck{
+			Status:  "pending",
+			Message: fmt.Sprintf("schema at version %d; this release expects %d", version, latest),
+		}, &version
+	}
+	return ComponentCheck{
+		Status:  "healthy",
+		Message: fmt.Sprintf("schema at version %d", version),
+	}, &version
+}
@@ -4,8 +4,18 @@
 package api
 
 import (
+	"app/config"
+	"app/internal/auth"
+	"app/internal/clock"
+	"app/internal/links"
+	"app/internal/logger"
 	"app/internal/model"
+	"app/internal/validate"
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"log/slog"
 	"net/http"
 	"strconv"
 	"time"
@@ -18,6 +28,55 @@ const (
 	MinPageSize     = 1
 )
 
+// App is what handlers share besides the database: the clock they record and compare
+// against and the source of the IDs they assign. Its middleware puts it on each
+// request's context, where handlers find it with appFrom.
+type App struct {
+	Clock clock.Clock
+	IDs   clock.IDGenerator
+}
+
+// NewApp creates an App on the wall clock that assigns random UUIDs
+func NewApp() *App {
+	return &App{Clock: clock.System, IDs: clock.UUIDs}
+}
+
+// WithClock makes handlers read the time from c and take new IDs from ids, for tests
+func (a *App) WithClock(c clock.Clock, ids clock.IDGenerator) *App {
+	a.Clock, a.IDs = c, ids
+	return a
+}
+
+// Tokens issues and validates tokens on the app's clock
+func (a *App) Tokens() *auth.Tokens {
+	return auth.NewTokens(a.Clock)
+}
+
+// Links creates, follows and uses deep links on the app's clock
+func (a *App) Links() *links.Service {
+	return links.NewService(config.DB).WithClock(a.Clock, a.IDs)
+}
+
+type appContextKey struct{}
+
+// Middleware puts the app on the context of each request it handles
+func (a *App) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), appContextKey{}, a)))
+	})
+}
+
+// systemApp serves requests that did not pass through an app's middleware
+var systemApp = NewApp()
+
+// appFrom returns the app handling the request with ctx
+func appFrom(ctx context.Context) *App {
+	if a, ok := ctx.Value(appContextKey{}).(*App); ok {
+		return a
+	}
+	return systemApp
+}
+
 // Query parameter validation helpers
 
 // ParseIntParam parses an integer query parameter with validation
@@ -108,37 +167,76 @@ func ParseDateParam(r *http.Request, paramName string) (*time.Time, error) {
 // Error response helpers
 
 // ValidationError represents a validation error
-type ValidationError struct {
-	Field   string
-	Message string
-	Value   string
-}
+type ValidationError = validate.FieldError
 
-func (e *ValidationError) Error() string {
-	return e.Field + ": " + e.Message
+// RespondWithError sends a JSON error response with the generic code for statusCode
+func RespondWithError(w http.ResponseWriter, statusCode int, errorMsg string) {
+	respondError(w, statusCode, model.ErrorCodeForStatus(statusCode), errorMsg)
 }
 
-// RespondWithError sends a JSON error response
-func RespondWithError(w http.ResponseWriter, statusCode int, errorMsg string) {
+// respondError sends a JSON error response with a machine-readable code from the
+// model.ErrCode catalogue
+func respondError(w http.ResponseWriter, statusCode int, code, errorMsg string) {
 	RespondWithJSON(w, statusCode, model.ErrorResponse{
 		Error: errorMsg,
+		Code:  code,
+	})
+}
+
+// scheduleConflictResponse is an error response listing the bookings a job conflicts with
+type scheduleConflictResponse struct {
+	model.ErrorResponse
+	Conflicts any `json:"conflicts"`
+}
+
+// respondScheduleConflict rejects a booking that does not fit a worker's calendar with
+// 409, SCHEDULE_CONFLICT and the conflicts found
+func respondScheduleConflict(w http.ResponseWriter, errorMsg string, conflicts any) {
+	RespondWithJSON(w, http.StatusConflict, scheduleConflictResponse{
+		ErrorResponse: model.ErrorResponse{
+			Error:     errorMsg,
+			Code:      model.ErrCodeScheduleConflict,
+			RequestID: w.Header().Get(logger.RequestIDHeader),
+		},
+		Conflicts: conflicts,
 	})
 }
 
 // RespondWithValidationError sends a validation error response
 func RespondWithValidationError(w http.ResponseWriter, err *ValidationError) {
-	RespondWithJSON(w, http.StatusBadRequest, model.ErrorResponse{
+	RespondWithValidationErrors(w, validate.Errors{err})
+}
+
+// RespondWithValidationErrors sends a validation error response with a message for
+// each invalid field in details. err is the result of validate.Validator.Err or a
+// single *ValidationError; any other error is reported without field details.
+func RespondWithValidationErrors(w http.ResponseWriter, err error) {
+	var errs validate.Errors
+	var fieldErr *ValidationError
+	switch {
+	case errors.As(err, &errs):
+	case errors.As(err, &fieldErr):
+		errs = validate.Errors{fieldErr}
+	}
+
+	response := model.ErrorResponse{
 		Error:   "Validation failed",
 		Message: err.Error(),
-		Code:    "VALIDATION_ERROR",
-		Details: map[string]string{
-			err.Field: err.Message,
-		},
-	})
+		Code:    model.ErrCodeValidation,
+	}
+	if len(errs) > 0 {
+		response.Details = errs.Fields()
+	}
+	RespondWithJSON(w, http.StatusBadRequest, response)
 }
 
-// RespondWithJSON sends a JSON response
+// RespondWithJSON sends a JSON response. Error responses carry the request ID set by
+// the RequestID middleware.
 func RespondWithJSON(w http.ResponseWriter, statusCode int, data any) {
+	if e, ok := data.(model.ErrorResponse); ok && e.RequestID == "" {
+		e.RequestID = w.Header().Get(logger.RequestIDHeader)
+		data = e
+	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 	if err := json.NewEncoder(w).Encode(data); err != nil {
@@ -186,3 +284,78 @@ func GetUserRoleFromContext(r *http.Request) string {
 	}
 	return role
 }
+
+// GetSessionIDFromContext extracts the login session UUID from request context
+func GetSessionIDFromContext(r *http.Request) string {
+	sessionID, ok := r.Context().Value("session_id").(string)
+	if !ok {
+		return ""
+	}
+	return sessionID
+}
+
+// RequireUserID returns the authenticated user ID from the JWT claims. A client-supplied
+// ID (claimedID, 0 when absent) that does not match the token is rejected as spoofed.
+// On failure the error response has already been written.
+func RequireUserID(w http.ResponseWriter, r *http.Request, claimedID int) (int, bool) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return 0, false
+	}
+	if claimedID != 0 && claimedID != userID {
+		slog.WarnContext(r.Context(), "Rejected spoofed user ID", "claimed_id", claimedID, "user_id", userID, "method", r.Method, "path", r.URL.Path)
+		RespondWithError(w, http.StatusForbidden, "User ID does not match the authenticated user")
+		return 0, false
+	}
+	return userID, true
+}
+
+// ClaimedUserIDParam reads a legacy user ID query parameter for RequireUserID.
+// Returns 0 when absent and -1 when it is not a valid ID, so it never matches.
+func ClaimedUserIDParam(r *http.Request, paramName string) int {
+	value := r.URL.Query().Get(paramName)
+	if value == "" {
+		return 0
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		return -1
+	}
+	return id
+}
+
+// Nullable scan helpers
+
+// stringPtrFromNull converts a scanned sql.NullString to *string
+func stringPtrFromNull(ns sql.NullString) *string {
+	if ns.Valid {
+		return &ns.String
+	}
+	return nil
+}
+
+// float64PtrFromNull converts a scanned sql.NullFloat64 to *float64
+func float64PtrFromNull(nf sql.NullFloat64) *float64 {
+	if nf.Valid {
+		return &nf.Float64
+	}
+	return nil
+}
+
+// timePtrFromNull converts a scanned sql.NullTime to *time.Time
+func timePtrFromNull(nt sql.NullTime) *time.Time {
+	if nt.Valid {
+		return &nt.Time
+	}
+	return nil
+}
+
+// intPtrFromNull converts a scanned sql.NullInt64 to *int
+func intPtrFromNull(ni sql.NullInt64) *int {
+	if ni.Valid {
+		v := int(ni.Int64)
+		return &v
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"app/internal/clock"
+	"app/internal/logger"
+	"app/internal/model"
+	"app/internal/payment"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequireUserID(t *testing.T) {
+	tests := []struct {
+		name       string
+		contextID  int
+		claimedID  int
+		wantID     int
+		wantOK     bool
+		wantStatus int
+	}{
+		{
+			name:      "authenticated without claimed ID",
+			contextID: 42,
+			wantID:    42,
+			wantOK:    true,
+		},
+		{
+			name:      "claimed ID matches token",
+			contextID: 42,
+			claimedID: 42,
+			wantID:    42,
+			wantOK:    true,
+		},
+		{
+			name:       "spoofed claimed ID",
+			contextID:  42,
+			claimedID:  7,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "invalid claimed ID",
+			contextID:  42,
+			claimedID:  -1,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "unauthenticated",
+			claimedID:  42,
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/users/profile", nil)
+			if tt.contextID != 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "user_id", tt.contextID))
+			}
+			w := httptest.NewRecorder()
+
+			gotID, gotOK := RequireUserID(w, r, tt.claimedID)
+			if gotOK != tt.wantOK || gotID != tt.wantID {
+				t.Errorf("RequireUserID() = (%d, %v), want (%d, %v)", gotID, gotOK, tt.wantID, tt.wantOK)
+			}
+			if !tt.wantOK && w.Code != tt.wantStatus {
+				t.Errorf("RequireUserID() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestClaimedUserIDParam(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  int
+	}{
+		{name: "absent", query: "", want: 0},
+		{name: "valid", query: "?user_id=12", want: 12},
+		{name: "not a number", query: "?user_id=abc", want: -1},
+		{name: "zero", query: "?user_id=0", want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/jobs/my-jobs"+tt.query, nil)
+			if got := ClaimedUserIDParam(r, "user_id"); got != tt.want {
+				t.Errorf("ClaimedUserIDParam() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRespondPaymentError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"declined", fmt.Errorf("%w by stripe: card_declined", payment.ErrPaymentDeclined), http.StatusPaymentRequired, model.ErrCodePaymentDeclined},
+		{"job not found", payment.ErrJobNotFound, http.StatusNotFound, model.ErrCodeJobNotFound},
+		{"not consumer", payment.ErrNotJobConsumer, http.StatusForbidden, model.ErrCodeForbidden},
+		{"price changed", fmt.Errorf("%w: requested 10.00, quoted 12.00", payment.ErrPriceChanged), http.StatusConflict, model.ErrCodePriceChanged},
+		{"idempotency key reused", payment.ErrIdempotencyKeyReused, http.StatusUnprocessableEntity, model.ErrCodeIdempotencyKeyReused},
+		{"currency mismatch", fmt.Errorf("%w: refund in EUR of a payment in USD", payment.ErrCurrencyMismatch), http.StatusUnprocessableEntity, model.ErrCodeCurrencyMismatch},
+		{"job on hold", payment.ErrJobOnHold, http.StatusConflict, model.ErrCodeJobOnHold},
+		{"payment busy", fmt.Errorf("failed to lock transaction: %w", payment.ErrPaymentBusy), http.StatusConflict, model.ErrCodeConflict},
+		{"provider rate limited", fmt.Errorf("failed to refund payment with clover: %w", payment.ErrProviderRateLimited), http.StatusServiceUnavailable, model.ErrCodeServiceUnavailable},
+		{"unexpected", errors.New("connection reset"), http.StatusInternalServerError, model.ErrCodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			respondPaymentError(w, tt.err)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			var body model.ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", body.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestRespondScheduleConflict(t *testing.T) {
+	w := httptest.NewRecorder()
+	w.Header().Set(logger.RequestIDHeader, "req-1")
+	respondScheduleConflict(w, "Job conflicts with the worker's booked slots", []map[string]int{{"schedule_id": 7}})
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusConflict)
+	}
+	var body struct {
+		model.ErrorResponse
+		Conflicts []map[string]int `json:"conflicts"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.Code != model.ErrCodeScheduleConflict || body.RequestID != "req-1" {
+		t.Errorf("code = %q, request ID = %q, want %q and req-1", body.Code, body.RequestID, model.ErrCodeScheduleConflict)
+	}
+	if len(body.Conflicts) != 1 || body.Conflicts[0]["schedule_id"] != 7 {
+		t.Errorf("conflicts = %v, want the schedule listed", body.Conflicts)
+	}
+}
+
+func TestAppMiddleware(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	app := NewApp().WithClock(clock.NewFake(now), &clock.Sequence{})
+
+	var got *App
+	handler := app.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = appFrom(r.Context())
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got != app {
+		t.Fatal("appFrom() inside the middleware is not its app")
+	}
+	if !got.Clock.Now().Equal(now) {
+		t.Errorf("Clock.Now() = %s, want %s", got.Clock.Now(), now)
+	}
+	if id := got.IDs.NewID(); id != "00000000-0000-4000-8000-000000000001" {
+		t.Errorf("IDs.NewID() = %q, want the first ID in the sequence", id)
+	}
+	if appFrom(context.Background()) != systemApp {
+		t.Error("appFrom() outside a request is not the system app")
+	}
+}
@@ -0,0 +1,461 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/email"
+	"app/internal/model"
+	"app/internal/notifications"
+	"app/internal/temporal/workflows"
+	"app/internal/validate"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const incidentColumns = `
+	id, uuid, job_id, reporter_id, other_party_id, incident_type, severity, description,
+	latitude, longitude, status, other_party_notified, workflow_paused, ops_notified_at,
+	acknowledged_by, acknowledged_at, resolved_by, resolved_at, resolution_notes,
+	created_at, updated_at
+`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanIncident scans a safety_incidents row selected with incidentColumns
+func scanIncident(row rowScanner) (*model.SafetyIncident, error) {
+	var inc model.SafetyIncident
+	var otherPartyID, acknowledgedBy, resolvedBy sql.NullInt64
+	var latitude, longitude sql.NullFloat64
+	var opsNotifiedAt, acknowledgedAt, resolvedAt sql.NullTime
+	var resolutionNotes sql.NullString
+
+	err := row.Scan(
+		&inc.ID, &inc.UUID, &inc.JobID, &inc.ReporterID, &otherPartyID, &inc.IncidentType,
+		&inc.Severity, &inc.Description, &latitude, &longitude, &inc.Status,
+		&inc.OtherPartyNotified, &inc.WorkflowPaused, &opsNotifiedAt,
+		&acknowledgedBy, &acknowledgedAt, &resolvedBy, &resolvedAt, &resolutionNotes,
+		&inc.CreatedAt, &inc.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	inc.OtherPartyID = intPtrFromNull(otherPartyID)
+	inc.AcknowledgedBy = intPtrFromNull(acknowledgedBy)
+	inc.ResolvedBy = intPtrFromNull(resolvedBy)
+	inc.Latitude = float64PtrFromNull(latitude)
+	inc.Longitude = float64PtrFromNull(longitude)
+	inc.OpsNotifiedAt = timePtrFromNull(opsNotifiedAt)
+	inc.AcknowledgedAt = timePtrFromNull(acknowledgedAt)
+	inc.ResolvedAt = timePtrFromNull(resolvedAt)
+	inc.ResolutionNotes = stringPtrFromNull(resolutionNotes)
+
+	return &inc, nil
+}
+
+// ==============================================
+// INCIDENT REPORTING (JOB PARTICIPANTS)
+// ==============================================
+
+// ReportIncident records an SOS/safety incident for a job, alerts ops, optionally
+// notifies the other party, and pauses the job workflow pending resolution.
+func ReportIncident(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	var req model.IncidentReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+
+	req.IncidentType = strings.TrimSpace(req.IncidentType)
+	req.Description = strings.TrimSpace(req.Description)
+	if req.IncidentType == "" {
+		req.IncidentType = model.IncidentTypeSOS
+	}
+	if req.Severity == "" {
+		req.Severity = "high"
+		if req.IncidentType == model.IncidentTypeSOS {
+			req.Severity = "emergency"
+		}
+	}
+
+	var v validate.Validator
+	if !model.ValidateIncidentType(req.IncidentType) {
+		v.AddValue("incident_type", "is not a supported incident type", req.IncidentType)
+	}
+	v.OneOf("severity", req.Severity, "low", "medium", "high", "emergency")
+	v.Required("description", req.Description)
+	v.Length("description", req.Description, 0, 5000)
+	if err := v.Err(); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+
+	// Reporter must be a participant in the job
+	var jobTitle string
+	var consumerID int
+	var gigWorkerID sql.NullInt64
+	err = config.DB.QueryRow(
+		`SELECT title, consumer_id, gig_worker_id FROM jobs WHERE id = $1`, jobID,
+	).Scan(&jobTitle, &consumerID, &gigWorkerID)
+	if err == sql.ErrNoRows {
+		respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	var otherPartyID *int
+	switch {
+	case userID == consumerID:
+		otherPartyID = intPtrFromNull(gigWorkerID)
+	case gigWorkerID.Valid && int(gigWorkerID.Int64) == userID:
+		otherPartyID = &consumerID
+	default:
+		RespondWithError(w, http.StatusForbidden, "Only job participants can report incidents")
+		return
+	}
+
+	// Never tip off the other party about harassment reports
+	notifyOtherParty := req.IncidentType != model.IncidentTypeHarassment
+	if req.NotifyOtherParty != nil && !*req.NotifyOtherParty {
+		notifyOtherParty = false
+	}
+	notifyOtherParty = notifyOtherParty && otherPartyID != nil
+
+	pauseJob := true
+	if req.PauseJob != nil {
+		pauseJob = *req.PauseJob
+	}
+
+	row := config.DB.QueryRow(`
+		INSERT INTO safety_incidents (job_id, reporter_id, other_party_id, incident_type, severity,
+		                              description, latitude, longitude, other_party_notified, workflow_paused)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING `+incidentColumns,
+		jobID, userID, nullIntPtr(otherPartyID), req.IncidentType, req.Severity,
+		req.Description, nullFloat64Ptr(req.Latitude), nullFloat64Ptr(req.Longitude), notifyOtherParty, pauseJob,
+	)
+	incident, err := scanIncident(row)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error creating incident", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to report incident")
+		return
+	}
+
+	if pauseJob {
+		pauseJobWorkflow(r.Context(), jobID, workflows.PauseRequest{
+			Reason:     "safety incident " + incident.UUID,
+			IncidentID: incident.ID,
+		})
+		signalEscrowWorkflows(r.Context(), jobID)
+	}
+
+	go alertOpsOfIncident(r.Context(), *incident, jobTitle)
+	if notifyOtherParty {
+		go notifyIncidentOtherParty(r.Context(), *incident, jobTitle)
+	}
+
+	RespondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"success":  true,
+		"message":  "Incident reported. Our safety team has been alerted.",
+		"incident": incident,
+	})
+}
+
+// alertOpsOfIncident pages the ops team about a new incident and records when it was sent
+func alertOpsOfIncident(ctx context.Context, incident model.SafetyIncident, jobTitle string) {
+	notifier, err := notifications.NewOpsNotifierFromEnv()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ops alerting not configured, incident not escalated", "uuid", incident.UUID, "error", err)
+		return
+	}
+
+	severity := notifications.SeverityError
+	if incident.Severity == "emergency" {
+		severity = notifications.SeverityCritical
+	} else if incident.Severity == "low" {
+		severity = notifications.SeverityWarning
+	}
+
+	fields := map[string]string{
+		"Incident": incident.UUID,
+		"Job":      fmt.Sprintf("%d (%s)", incident.JobID, jobTitle),
+		"Reporter": strconv.Itoa(incident.ReporterID),
+		"Type":     incident.IncidentType,
+	}
+	if incident.Latitude != nil && incident.Longitude != nil {
+		fields["Location"] = fmt.Sprintf("%.6f,%.6f", *incident.Latitude, *incident.Longitude)
+	}
+
+	alert := notifications.OpsAlert{
+		Title:    fmt.Sprintf("Safety incident (%s) on job %d", incident.Severity, incident.JobID),
+		Summary:  incident.Description,
+		Severity: severity,
+		Source:   "safety-incident",
+		DedupKey: "incident-" + incident.UUID,
+		Fields:   fields,
+	}
+	if baseURL := os.Getenv("ADMIN_BASE_URL"); baseURL != "" {
+		alert.Link = fmt.Sprintf("%s/incidents/%d", baseURL, incident.ID)
+	}
+
+	if err := notifier.Notify(alert); err != nil {
+		slog.ErrorContext(ctx, "Failed to alert ops of incident", "uuid", incident.UUID, "error", err)
+		return
+	}
+
+	if _, err := config.DB.Exec(`UPDATE safety_incidents SET ops_notified_at = NOW() WHERE id = $1`, incident.ID); err != nil {
+		slog.ErrorContext(ctx, "Database error marking incident ops notified", "incident_id", incident.ID, "error", err)
+	}
+}
+
+// notifyIncidentOtherParty tells the other job participant that the job is on hold
+func notifyIncidentOtherParty(ctx context.Context, incident model.SafetyIncident, jobTitle string) {
+	if incident.OtherPartyID == nil {
+		return
+	}
+
+	emailService, err := email.NewServiceFromEnv()
+	if err != nil {
+		slog.ErrorContext(ctx, "Email not configured, incident other party not notified", "uuid", incident.UUID, "error", err)
+		return
+	}
+
+	var toEmail, toName string
+	err = config.DB.QueryRow(`SELECT email, name FROM people WHERE id = $1`, *incident.OtherPartyID).Scan(&toEmail, &toName)
+	if err != nil {
+		slog.ErrorContext(ctx, "Database error loading other party for incident", "incident_id", incident.ID, "error", err)
+		return
+	}
+
+	err = emailService.SendJobNotification(toEmail, toName, email.JobNotificationData{
+		UserName: toName,
+		JobTitle: jobTitle,
+		JobID:    strconv.Itoa(incident.JobID),
+		Message:  "A safety concern was reported on this job. The job is on hold while our safety team reviews it, and they may contact you.",
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to notify other party of incident", "incident_id", incident.ID, "error", err)
+	}
+}
+
+// ==============================================
+// INCIDENT MANAGEMENT (TRUST & SAFETY)
+// ==============================================
+
+// GetIncidents lists safety incidents for trust & safety review
+func GetIncidents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	page, err := ParseIntParam(r, "page", 1, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	limit, err := ParseIntParam(r, "limit", DefaultPageSize, MinPageSize, MaxPageSize)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	var whereClauses []string
+	var args []any
+	argIndex := 1
+
+	if status := r.URL.Query().Get("status"); status != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, status)
+		argIndex++
+	}
+	if severity := r.URL.Query().Get("severity"); severity != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("severity = $%d", argIndex))
+		args = append(args, severity)
+		argIndex++
+	}
+	if jobID := r.URL.Query().Get("job_id"); jobID != "" {
+		id, err := ParseIntParam(r, "job_id", 0, 1, 0)
+		if err != nil {
+			RespondWithValidationError(w, err.(*ValidationError))
+			return
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("job_id = $%d", argIndex))
+		args = append(args, id)
+		argIndex++
+	}
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = " WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	var total int
+	if err := config.DB.QueryRow("SELECT COUNT(*) FROM safety_incidents"+whereClause, args...).Scan(&total); err != nil {
+		slog.ErrorContext(r.Context(), "Database error counting incidents", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	query := "SELECT " + incidentColumns + " FROM safety_incidents" + whereClause +
+		fmt.Sprintf(" ORDER BY (status = 'resolved'), created_at DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, limit, (page-1)*limit)
+
+	rows, err := config.DB.Query(query, args...)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying incidents", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	incidents := []model.SafetyIncident{}
+	for rows.Next() {
+		incident, err := scanIncident(rows)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning incident row", "error", err)
+			continue
+		}
+		incidents = append(incidents, *incident)
+	}
+
+	pages := (total + limit - 1) / limit
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"incidents": incidents,
+		"pagination": model.Pagination{
+			Page:    page,
+			Limit:   limit,
+			Total:   total,
+			Pages:   pages,
+			HasNext: page < pages,
+			HasPrev: page > 1,
+		},
+	})
+}
+
+// GetIncidentByID retrieves a single safety incident
+func GetIncidentByID(w http.ResponseWriter, r *http.Request) {
+	incidentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid incident ID format")
+		return
+	}
+
+	incident, err := scanIncident(config.DB.QueryRow(
+		"SELECT "+incidentColumns+" FROM safety_incidents WHERE id = $1", incidentID,
+	))
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Incident not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting incident", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, incident)
+}
+
+// UpdateIncident acknowledges or resolves an incident. Resolving the last open
+// hold on a paused job resumes its workflow.
+func UpdateIncident(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	adminID := GetUserIDFromContext(r)
+	incidentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid incident ID format")
+		return
+	}
+
+	var req model.IncidentUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+
+	var query string
+	switch req.Status {
+	case model.IncidentStatusAcknowledged:
+		query = `
+			UPDATE safety_incidents
+			SET status = 'acknowledged', acknowledged_by = $1, acknowledged_at = NOW(),
+			    resolution_notes = COALESCE($2, resolution_notes)
+			WHERE id = $3 AND status = 'open'
+			RETURNING ` + incidentColumns
+	case model.IncidentStatusResolved:
+		query = `
+			UPDATE safety_incidents
+			SET status = 'resolved', resolved_by = $1, resolved_at = NOW(),
+			    acknowledged_by = COALESCE(acknowledged_by, $1), acknowledged_at = COALESCE(acknowledged_at, NOW()),
+			    resolution_notes = COALESCE($2, resolution_notes)
+			WHERE id = $3 AND status <> 'resolved'
+			RETURNING ` + incidentColumns
+	default:
+		RespondWithValidationError(w, &ValidationError{Field: "status", Message: "must be 'acknowledged' or 'resolved'", Value: req.Status})
+		return
+	}
+
+	incident, err := scanIncident(config.DB.QueryRow(query, adminID, req.ResolutionNotes, incidentID))
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusConflict, "Incident not found or already in that state")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error updating incident", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update incident")
+		return
+	}
+
+	if incident.Status == model.IncidentStatusResolved && incident.WorkflowPaused {
+		held, err := jobHasOpenHolds(incident.JobID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Database error checking open holds for job", "job_id", incident.JobID, "error", err)
+		} else if !held {
+			resumeJobWorkflow(r.Context(), incident.JobID)
+			signalEscrowWorkflows(r.Context(), incident.JobID)
+		}
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success":  true,
+		"message":  "Incident updated successfully",
+		"incident": incident,
+	})
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"app/config"
+	"app/internal/email"
+	"app/internal/invoice"
+)
+
+// getInvoiceService returns a payment document service that keeps documents in the
+// attachment store, when one is configured
+func getInvoiceService() *invoice.Service {
+	store, err := getAttachmentStore()
+	if err != nil {
+		store = nil
+	}
+	return invoice.NewService(config.DB, store)
+}
+
+// respondTransactionDocument sends the PDF for a captured payment: the consumer's
+// receipt or the worker's earnings statement, whichever the caller is party to.
+// Admins choose with type=receipt or type=earnings_statement.
+func respondTransactionDocument(w http.ResponseWriter, r *http.Request, userID, transactionID int) {
+	kind := r.URL.Query().Get("type")
+	if kind != "" && kind != invoice.KindReceipt && kind != invoice.KindEarningsStatement {
+		RespondWithValidationError(w, &ValidationError{Field: "type", Message: "must be receipt or earnings_statement", Value: kind})
+		return
+	}
+
+	service := getInvoiceService()
+	p, err := service.GetPayment(r.Context(), transactionID)
+	if errors.Is(err, invoice.ErrNotFound) {
+		RespondWithError(w, http.StatusNotFound, "Receipt not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting payment for receipt", "transaction_id", transactionID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Each party may only download their own document
+	var allowed string
+	switch {
+	case GetUserRoleFromContext(r) == "admin":
+		allowed = kind
+		if allowed == "" {
+			allowed = invoice.KindReceipt
+		}
+	case userID == p.ConsumerID:
+		allowed = invoice.KindReceipt
+	case userID == p.WorkerID:
+		allowed = invoice.KindEarningsStatement
+	}
+	if allowed == "" || (kind != "" && kind != allowed) {
+		RespondWithError(w, http.StatusNotFound, "Receipt not found")
+		return
+	}
+
+	pdf, err := service.PDF(r.Context(), p, allowed)
+	if errors.Is(err, invoice.ErrNotFound) {
+		RespondWithError(w, http.StatusNotFound, "Receipt not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to render payment document", "transaction_id", transactionID, "kind", allowed, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", invoice.Filename(p, allowed)))
+	w.Header().Set("Content-Length", strconv.Itoa(len(pdf)))
+	w.Header().Set("Cache-Control", "private")
+	w.WriteHeader(http.StatusOK)
+	w.Write(pdf)
+}
+
+// emailPaymentDocuments emails the receipt and earnings statement for a captured
+// payment. Failures are logged; both can still be downloaded.
+func emailPaymentDocuments(ctx context.Context, transactionID int) {
+	emailService, err := email.NewServiceFromEnv()
+	if err != nil {
+		slog.ErrorContext(ctx, "Email not configured, payment receipt not sent", "transaction_id", transactionID, "error", err)
+		return
+	}
+	if err := getInvoiceService().EmailDocuments(ctx, transactionID, emailService); err != nil {
+		slog.ErrorContext(ctx, "Failed to email payment documents", "transaction_id", transactionID, "error", err)
+	}
+}
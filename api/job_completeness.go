@@ -0,0 +1,132 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/jobquality"
+	"app/internal/model"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ==============================================
+// JOB COMPLETENESS
+// ==============================================
+
+// CheckJobCompleteness scores a job before it is posted, taking the same body as
+// CreateJob, so clients can show what is missing while the consumer fills the form in
+func CheckJobCompleteness(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req model.JobCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+
+	report := jobquality.Check(createRequestPosting(&req))
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"completeness": report,
+		"can_post":     report.Complete(),
+	})
+}
+
+// GetJobCompleteness scores a posted job, counting its photos. Only the consumer who
+// posted it or an admin may see it.
+func GetJobCompleteness(w http.ResponseWriter, r *http.Request) {
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+	if !requireJobOwner(w, r, jobID) {
+		return
+	}
+
+	posting, err := loadJobPosting(config.DB, jobID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error loading job for completeness", "job_id", jobID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	report := jobquality.Check(*posting)
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"job_id":       jobID,
+		"completeness": report,
+		"can_post":     report.Complete(),
+	})
+}
+
+// createRequestPosting is the posting a create request describes, accepting the same
+// alternative field names CreateJob does. A job has no photos before it is posted.
+func createRequestPosting(req *model.JobCreateRequest) jobquality.Posting {
+	p := jobquality.Posting{
+		Category:           req.Category,
+		Description:        req.Description,
+		LocationAddress:    req.LocationAddress,
+		HasCoordinates:     req.LocationLatitude != nil && req.LocationLongitude != nil,
+		AccessInstructions: req.AccessInstructions,
+		ScheduledStart:     req.ScheduledStart,
+		ScheduledEnd:       req.ScheduledEnd,
+		DurationHours:      req.EstimatedDurationHours,
+	}
+	if p.LocationAddress == "" {
+		p.LocationAddress = req.Location
+	}
+	if p.DurationHours == nil {
+		p.DurationHours = req.EstimatedHours
+	}
+	return p
+}
+
+// loadJobPosting reads a job's posting fields and photo count
+func loadJobPosting(db queryRower, jobID int) (*jobquality.Posting, error) {
+	var p jobquality.Posting
+	var start, end sql.NullTime
+	var hours sql.NullFloat64
+	err := db.QueryRow(`
+		SELECT COALESCE(j.category, ''), j.description, COALESCE(j.location_address, ''),
+		       j.location_latitude IS NOT NULL AND j.location_longitude IS NOT NULL,
+		       COALESCE(j.access_instructions, ''), j.scheduled_start, j.scheduled_end,
+		       j.estimated_duration_hours,
+		       (SELECT COUNT(*) FROM attachments a
+		        WHERE a.owner_type = $2 AND a.owner_id = j.id AND a.kind = 'photo'
+		          AND a.scan_status IN ('clean', 'skipped'))
+		FROM jobs j
+		WHERE j.id = $1
+	`, jobID, model.AttachmentOwnerJob).Scan(
+		&p.Category, &p.Description, &p.LocationAddress, &p.HasCoordinates,
+		&p.AccessInstructions, &start, &end, &hours, &p.Photos,
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.ScheduledStart = timePtrFromNull(start)
+	p.ScheduledEnd = timePtrFromNull(end)
+	p.DurationHours = float64PtrFromNull(hours)
+	return &p, nil
+}
+
+// respondJobIncomplete rejects a posting that is missing required fields, listing
+// what to add
+func respondJobIncomplete(w http.ResponseWriter, report jobquality.Report) {
+	details := map[string]string{}
+	for _, m := range report.Required() {
+		details[m.Field] = m.Message
+	}
+	RespondWithJSON(w, http.StatusUnprocessableEntity, model.ErrorResponse{
+		Error:   "Job posting is incomplete",
+		Message: fmt.Sprintf("Add the required fields before posting (completeness score %d/100)", report.Score),
+		Code:    model.ErrCodeJobIncomplete,
+		Details: details,
+	})
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/audit"
+	"app/internal/jobevents"
+	"app/internal/model"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// recordJobEvent records a lifecycle transition made through the API on behalf of
+// the authenticated user
+func recordJobEvent(r *http.Request, t jobevents.Transition) (*jobevents.Event, error) {
+	tx, err := config.DB.BeginTx(r.Context(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	e, err := recordJobEventTx(r, tx, t)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit job event: %w", err)
+	}
+	return e, nil
+}
+
+// recordJobEventTx is recordJobEvent within tx, for handlers that update other job
+// columns alongside the transition. The status change is audited in the same tx.
+func recordJobEventTx(r *http.Request, tx *sql.Tx, t jobevents.Transition) (*jobevents.Event, error) {
+	var before jobevents.State
+	var workerID sql.NullInt64
+	err := tx.QueryRowContext(r.Context(),
+		`SELECT COALESCE(status::text, 'posted'), gig_worker_id FROM jobs WHERE id = $1 FOR UPDATE`,
+		t.JobID).Scan(&before.Status, &workerID)
+	if err == sql.ErrNoRows {
+		return nil, jobevents.ErrJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock job %d: %w", t.JobID, err)
+	}
+	before.GigWorkerID = intPtrFromNull(workerID)
+
+	e, err := jobevents.RecordTx(r.Context(), tx, apiTransition(r, t))
+	if err != nil {
+		return nil, err
+	}
+
+	// A repeated transition records nothing new
+	changes, err := audit.Diff(jobAuditState(before), jobAuditState(jobevents.Apply(before, *e)))
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return e, nil
+	}
+	entry := newAuditEntry(r, audit.ActionJobStatusChanged, audit.EntityJob, t.JobID)
+	entry.Changes = changes
+	entry.Metadata = map[string]interface{}{"event_type": e.Type, "sequence": e.Sequence}
+	if err := audit.Record(r.Context(), tx, entry); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// lockJobTx locks the job row until tx ends and returns its UUID and lifecycle state,
+// so a handler's checks still hold when it records its transition. A request racing
+// for the same job waits here and then sees the state the first one left.
+func lockJobTx(r *http.Request, tx *sql.Tx, jobID int) (string, jobevents.State, error) {
+	var uuid string
+	var s jobevents.State
+	var workerID sql.NullInt64
+	err := tx.QueryRowContext(r.Context(),
+		`SELECT uuid, COALESCE(status::text, 'posted'), gig_worker_id FROM jobs WHERE id = $1 FOR UPDATE`,
+		jobID).Scan(&uuid, &s.Status, &workerID)
+	if err == sql.ErrNoRows {
+		return "", s, jobevents.ErrJobNotFound
+	}
+	if err != nil {
+		return "", s, fmt.Errorf("failed to lock job %d: %w", jobID, err)
+	}
+	s.GigWorkerID = intPtrFromNull(workerID)
+	return uuid, s, nil
+}
+
+// jobAuditState is the part of a job's lifecycle state the audit log diffs
+func jobAuditState(s jobevents.State) map[string]interface{} {
+	return map[string]interface{}{"status": s.Status, "gig_worker_id": s.GigWorkerID}
+}
+
+func apiTransition(r *http.Request, t jobevents.Transition) jobevents.Transition {
+	t.Source = jobevents.SourceAPI
+	if userID := GetUserIDFromContext(r); userID > 0 {
+		t.ActorID = &userID
+	}
+	return t
+}
+
+// GetJobEvents returns a job's lifecycle history in order, for support to see how a
+// job reached its current state
+func GetJobEvents(w http.ResponseWriter, r *http.Request) {
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	var exists bool
+	if err := config.DB.QueryRow(`SELECT EXISTS(SELECT 1 FROM jobs WHERE id = $1)`, jobID).Scan(&exists); err != nil {
+		slog.ErrorContext(r.Context(), "Database error checking job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if !exists {
+		respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
+		return
+	}
+
+	events, err := jobevents.History(r.Context(), config.DB, jobID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting job events", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"job_id": jobID,
+		"events": events,
+		"state":  jobevents.Fold(events),
+	})
+}
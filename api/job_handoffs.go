@@ -0,0 +1,294 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"app/config"
+	"app/internal/email"
+	"app/internal/model"
+	"app/internal/temporal/workflows"
+	"app/internal/validate"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lib/pq"
+)
+
+const jobHandoffColumns = `
+	id, job_id, from_worker_id, to_worker_id, reason, completed_percent, status,
+	paid_amount, transaction_id, responded_at, completed_at, created_at
+`
+
+// scanJobHandoff scans a job_handoffs row selected with jobHandoffColumns
+func scanJobHandoff(row rowScanner) (*model.JobHandoff, error) {
+	var h model.JobHandoff
+	var toWorkerID, transactionID sql.NullInt64
+	var paidAmount sql.NullFloat64
+	var respondedAt, completedAt sql.NullTime
+
+	err := row.Scan(
+		&h.ID, &h.JobID, &h.FromWorkerID, &toWorkerID, &h.Reason, &h.CompletedPercent, &h.Status,
+		&paidAmount, &transactionID, &respondedAt, &completedAt, &h.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	h.ToWorkerID = intPtrFromNull(toWorkerID)
+	h.PaidAmount = float64PtrFromNull(paidAmount)
+	h.TransactionID = intPtrFromNull(transactionID)
+	h.RespondedAt = timePtrFromNull(respondedAt)
+	h.CompletedAt = timePtrFromNull(completedAt)
+	return &h, nil
+}
+
+// handoffJobStatuses are the statuses in which an assigned worker can hand a job off
+var handoffJobStatuses = []string{"scheduled", "in_progress"}
+
+// ==============================================
+// JOB HAND-OFFS
+// ==============================================
+
+// RequestJobHandoff lets the assigned worker ask to be released from a scheduled or
+// in-progress job they can't finish, saying how much of it they completed. The
+// consumer approves or declines the hand-off.
+func RequestJobHandoff(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	var req model.JobHandoffRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	var v validate.Validator
+	v.Required("reason", req.Reason)
+	v.Length("reason", req.Reason, 0, 1000)
+	v.Check(req.CompletedPercent >= 0 && req.CompletedPercent <= 99, "completed_percent", "must be between 0 and 99")
+	if err := v.Err(); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+
+	job, ok := getExpenseJob(w, r, jobID)
+	if !ok {
+		return
+	}
+	if !job.isAssignedWorker(userID) {
+		RespondWithError(w, http.StatusForbidden, "Only the assigned worker can hand off this job")
+		return
+	}
+	if !slices.Contains(handoffJobStatuses, job.Status) {
+		respondError(w, http.StatusConflict, model.ErrCodeInvalidJobStatus, fmt.Sprintf("Job cannot be handed off in current status: %s", job.Status))
+		return
+	}
+
+	handoff, err := scanJobHandoff(config.DB.QueryRowContext(r.Context(), `
+		INSERT INTO job_handoffs (job_id, from_worker_id, reason, completed_percent)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+jobHandoffColumns,
+		jobID, userID, req.Reason, req.CompletedPercent,
+	))
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		respondError(w, http.StatusConflict, model.ErrCodeConflict, "A hand-off of this job is already open")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error creating job hand-off", "job_id", jobID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to request hand-off")
+		return
+	}
+	slog.InfoContext(r.Context(), "Worker requested job hand-off", "job_id", jobID, "handoff_id", handoff.ID, "worker_id", userID)
+
+	go notifyJobHandoff(context.WithoutCancel(r.Context()), handoff, job.ConsumerID, "Your worker needs to hand off your job",
+		fmt.Sprintf("Your worker can't finish \"%s\" (%s) and has completed about %d%% of it. Approve the hand-off to find a replacement.",
+			job.Title, handoff.Reason, handoff.CompletedPercent))
+
+	RespondWithJSON(w, http.StatusCreated, handoff)
+}
+
+// GetJobHandoffs lists a job's hand-offs, newest first, for its consumer and the
+// workers involved
+func GetJobHandoffs(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	job, ok := getExpenseJob(w, r, jobID)
+	if !ok {
+		return
+	}
+
+	rows, err := config.DB.QueryContext(r.Context(),
+		`SELECT `+jobHandoffColumns+` FROM job_handoffs WHERE job_id = $1 ORDER BY created_at DESC`, jobID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing job hand-offs", "job_id", jobID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	handoffs := []model.JobHandoff{}
+	involved := userID == job.ConsumerID || job.isAssignedWorker(userID) || GetUserRoleFromContext(r) == "admin"
+	for rows.Next() {
+		h, err := scanJobHandoff(rows)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning job hand-off", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		involved = involved || h.FromWorkerID == userID
+		handoffs = append(handoffs, *h)
+	}
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(r.Context(), "Error iterating job hand-offs", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if !involved {
+		RespondWithError(w, http.StatusForbidden, "Only the job's participants can view its hand-offs")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"handoffs": handoffs,
+		"count":    len(handoffs),
+	})
+}
+
+// ApproveJobHandoff lets the consumer approve a requested hand-off, optionally
+// correcting the portion the worker completed. The job workflow then pays the worker
+// for that portion, releases them and offers the rest of the job to other workers.
+func ApproveJobHandoff(w http.ResponseWriter, r *http.Request) {
+	var req model.JobHandoffApproval
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+			return
+		}
+	}
+	if req.CompletedPercent != nil && (*req.CompletedPercent < 0 || *req.CompletedPercent > 99) {
+		RespondWithValidationError(w, &ValidationError{Field: "completed_percent", Message: "must be between 0 and 99", Value: strconv.Itoa(*req.CompletedPercent)})
+		return
+	}
+	respondToJobHandoff(w, r, true, req.CompletedPercent)
+}
+
+// DeclineJobHandoff lets the consumer keep the worker on the job
+func DeclineJobHandoff(w http.ResponseWriter, r *http.Request) {
+	respondToJobHandoff(w, r, false, nil)
+}
+
+// respondToJobHandoff records the consumer's answer to a requested hand-off and, when
+// approved, signals the job workflow to carry it out
+func respondToJobHandoff(w http.ResponseWriter, r *http.Request, approve bool, completedPercent *int) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+	handoffID, err := strconv.Atoi(chi.URLParam(r, "handoffId"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid hand-off ID format")
+		return
+	}
+
+	job, ok := getExpenseJob(w, r, jobID)
+	if !ok {
+		return
+	}
+	if userID != job.ConsumerID {
+		RespondWithError(w, http.StatusForbidden, "Only the consumer can respond to a hand-off")
+		return
+	}
+
+	status := model.JobHandoffDeclined
+	if approve {
+		// The workflow carries the hand-off out; without one it would never happen
+		var workflowID sql.NullString
+		err := config.DB.QueryRowContext(r.Context(), `SELECT temporal_workflow_id FROM jobs WHERE id = $1`, jobID).Scan(&workflowID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Database error loading workflow ID for job", "job_id", jobID, "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		if !workflowID.Valid || workflowID.String == "" {
+			respondError(w, http.StatusConflict, model.ErrCodeConflict, "This job can't be handed off automatically; contact support")
+			return
+		}
+		if !slices.Contains(handoffJobStatuses, job.Status) {
+			respondError(w, http.StatusConflict, model.ErrCodeInvalidJobStatus, fmt.Sprintf("Job cannot be handed off in current status: %s", job.Status))
+			return
+		}
+		status = model.JobHandoffApproved
+	}
+
+	// Only the assigned worker's hand-off can be approved
+	handoff, err := scanJobHandoff(config.DB.QueryRowContext(r.Context(), `
+		UPDATE job_handoffs h
+		SET status = $1, completed_percent = COALESCE($2, h.completed_percent), responded_at = NOW()
+		WHERE h.id = $3 AND h.job_id = $4 AND h.status = 'requested'
+		  AND (NOT $5 OR EXISTS (SELECT 1 FROM jobs j WHERE j.id = h.job_id AND j.gig_worker_id = h.from_worker_id))
+		RETURNING `+jobHandoffColumns,
+		status, completedPercent, handoffID, jobID, approve,
+	))
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "No open hand-off request found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error responding to job hand-off", "handoff_id", handoffID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to respond to hand-off")
+		return
+	}
+	slog.InfoContext(r.Context(), "Consumer responded to job hand-off", "job_id", jobID, "handoff_id", handoffID, "status", status)
+
+	message := fmt.Sprintf("Your hand-off of \"%s\" was declined; the job is still yours.", job.Title)
+	if approve {
+		signalJobHandoff(r.Context(), jobID, workflows.HandoffRequest{HandoffID: handoff.ID, WorkerID: handoff.FromWorkerID})
+		message = fmt.Sprintf("Your hand-off of \"%s\" was approved. You'll be paid for the %d%% you completed.", job.Title, handoff.CompletedPercent)
+	}
+	go notifyJobHandoff(context.WithoutCancel(r.Context()), handoff, handoff.FromWorkerID, "Job hand-off "+status, message)
+
+	RespondWithJSON(w, http.StatusOK, handoff)
+}
+
+// notifyJobHandoff notifies a party to a job hand-off
+func notifyJobHandoff(ctx context.Context, handoff *model.JobHandoff, recipientID int, title, message string) {
+	jobID := handoff.JobID
+	_, err := email.NewNotificationDispatcher(config.DB).Dispatch(ctx, model.Notification{
+		UserID:       recipientID,
+		Type:         model.NotificationSystemMessage,
+		Title:        title,
+		Message:      message,
+		RelatedJobID: &jobID,
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to notify of job hand-off", "handoff_id", handoff.ID, "user_id", recipientID, "error", err)
+	}
+}
@@ -0,0 +1,418 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"app/config"
+	"app/internal/analytics"
+	"app/internal/availability"
+	"app/internal/jobevents"
+	"app/internal/model"
+	"app/internal/temporal/workflows"
+	"app/internal/validate"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lib/pq"
+)
+
+// jobOfferStatus is an offer's status as the worker sees it: a pending or snoozed offer
+// whose window passed is expired even before the workflow closes the round
+const jobOfferStatus = `CASE WHEN o.status IN ('pending', 'snoozed') AND o.expires_at <= NOW() THEN 'expired' ELSE o.status END`
+
+// GetMyJobOffers lists the job offers sent to the calling worker, newest first.
+// ?status= narrows them to pending, snoozed, accepted, declined, cancelled or expired
+// offers.
+func GetMyJobOffers(w http.ResponseWriter, r *http.Request) {
+	workerID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	query := `
+		SELECT o.id, o.uuid, o.job_id, j.title, j.category, j.location_address, j.scheduled_start,
+			` + jobOfferStatus + `, o.amount, o.round, o.expires_at, o.snoozed_until, o.decline_reason,
+			o.responded_at, o.created_at
+		FROM job_offers o
+		JOIN jobs j ON j.id = o.job_id
+		WHERE o.worker_id = $1`
+	args := []any{workerID}
+	if status := r.URL.Query().Get("status"); status != "" {
+		switch status {
+		case model.JobOfferPending, model.JobOfferSnoozed, model.JobOfferAccepted, model.JobOfferDeclined, model.JobOfferCancelled, model.JobOfferExpired:
+		default:
+			RespondWithValidationError(w, &ValidationError{
+				Field:   "status",
+				Message: "must be pending, snoozed, accepted, declined, cancelled or expired",
+				Value:   status,
+			})
+			return
+		}
+		query += ` AND ` + jobOfferStatus + ` = $2`
+		args = append(args, status)
+	}
+	query += ` ORDER BY o.created_at DESC LIMIT 100`
+
+	rows, err := config.DB.QueryContext(r.Context(), query, args...)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing job offers", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	offers := []model.JobOffer{}
+	for rows.Next() {
+		var o model.JobOffer
+		var category, location, declineReason sql.NullString
+		var scheduledStart, snoozedUntil, respondedAt sql.NullTime
+		var amount sql.NullFloat64
+		err := rows.Scan(&o.ID, &o.UUID, &o.JobID, &o.JobTitle, &category, &location, &scheduledStart,
+			&o.Status, &amount, &o.Round, &o.ExpiresAt, &snoozedUntil, &declineReason, &respondedAt, &o.CreatedAt)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning job offer", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		o.Category = stringPtrFromNull(category)
+		o.Location = stringPtrFromNull(location)
+		o.ScheduledStart = timePtrFromNull(scheduledStart)
+		o.Amount = float64PtrFromNull(amount)
+		o.SnoozedUntil = timePtrFromNull(snoozedUntil)
+		o.DeclineReason = stringPtrFromNull(declineReason)
+		o.RespondedAt = timePtrFromNull(respondedAt)
+		offers = append(offers, o)
+	}
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing job offers", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{"offers": offers})
+}
+
+// AcceptWorkerJobOffer assigns the job to the calling worker if their offer is still
+// open. The offer is accepted, the job's other offers cancelled and the worker
+// assigned in one transaction with the job locked, so only the first worker to accept
+// gets the job. A scheduled job that overlaps the worker's bookings, or leaves too
+// little time to drive from or to a neighbouring job, is refused with the conflicts.
+func AcceptWorkerJobOffer(w http.ResponseWriter, r *http.Request) {
+	workerID, offerID, jobID, ok := loadWorkerJobOffer(w, r)
+	if !ok {
+		return
+	}
+
+	tx, err := config.DB.BeginTx(r.Context(), nil)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to begin transaction", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer tx.Rollback()
+
+	// Locks the job first, as closing an offer round does, and keeps it locked through
+	// the checks below so a second worker accepting waits and then finds it taken
+	_, state, err := lockJobTx(r, tx, jobID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error locking job for offer", "offer_id", offerID, "job_id", jobID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if state.Status != "accepted" {
+		respondError(w, http.StatusConflict, model.ErrCodeOfferUnavailable, "Job is no longer available")
+		return
+	}
+
+	// Scheduled jobs must fit the worker's calendar, including the drive from and to
+	// their neighbouring jobs
+	proposal, err := jobConflictProposal(r.Context(), workerID, jobID)
+	if err == nil && !proposal.Slot.Start.IsZero() {
+		var conflicts []availability.Conflict
+		conflicts, err = availability.FindConflicts(r.Context(), config.DB, *proposal)
+		if err == nil && len(conflicts) > 0 {
+			respondScheduleConflict(w, "Job conflicts with your schedule", conflicts)
+			return
+		}
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error checking schedule conflicts for job offer", "offer_id", offerID, "job_id", jobID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	_, err = recordJobEventTx(r, tx, jobevents.Transition{
+		JobID:    jobID,
+		Type:     jobevents.TypeWorkerAssigned,
+		WorkerID: &workerID,
+		Data:     map[string]interface{}{"job_offer_id": offerID},
+		Allowed:  jobevents.StatusIn("accepted"),
+	})
+	if errors.Is(err, jobevents.ErrNotAllowed) {
+		respondError(w, http.StatusConflict, model.ErrCodeOfferUnavailable, "Job is no longer available")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error assigning worker from offer", "offer_id", offerID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	result, err := tx.ExecContext(r.Context(), `
+		UPDATE job_offers SET status = 'accepted', responded_at = $2, snoozed_until = NULL
+		WHERE id = $1 AND status IN ('pending', 'snoozed') AND expires_at > $2
+	`, offerID, appFrom(r.Context()).Clock.Now())
+	var n int64
+	if err == nil {
+		n, err = result.RowsAffected()
+	}
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		respondError(w, http.StatusConflict, model.ErrCodeOfferUnavailable, "Job is no longer available")
+		return
+	}
+	if err == nil && n == 0 {
+		respondError(w, http.StatusConflict, model.ErrCodeOfferUnavailable, "Job offer is no longer open")
+		return
+	}
+	if err == nil {
+		_, err = tx.ExecContext(r.Context(), `
+			UPDATE job_offers SET status = 'cancelled', snoozed_until = NULL
+			WHERE job_id = $1 AND id <> $2 AND status IN ('pending', 'snoozed')
+		`, jobID, offerID)
+	}
+	if err == nil {
+		_, err = tx.ExecContext(r.Context(), `
+			INSERT INTO worker_profiles (worker_id, is_available) VALUES ($1, false)
+			ON CONFLICT (worker_id) DO UPDATE SET is_available = false
+		`, workerID)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error accepting job offer", "offer_id", offerID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	slog.InfoContext(r.Context(), "Worker accepted job offer", "offer_id", offerID, "job_id", jobID, "worker_id", workerID)
+	publishJobStatus(r, jobID, "worker_assigned")
+	signalWorkerOfferResponse(r.Context(), workflows.WorkerOfferResponse{WorkerID: workerID, Accepted: true}, jobID)
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Job offer accepted; the job is yours",
+		"job_id":  jobID,
+	})
+}
+
+// DeclineWorkerJobOffer declines the calling worker's open offer, so the workflow can
+// move on without waiting for the offer to expire. The optional reason feeds the offer
+// decline report and matching.
+func DeclineWorkerJobOffer(w http.ResponseWriter, r *http.Request) {
+	workerID, offerID, jobID, ok := loadWorkerJobOffer(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.DeclineJobOfferRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+			return
+		}
+	}
+	var v validate.Validator
+	if req.Reason != nil {
+		v.Required("reason", *req.Reason)
+		v.OneOf("reason", *req.Reason, model.OfferDeclineReasons...)
+	}
+	v.MaxLength("note", req.Note, 500)
+	if err := v.Err(); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+
+	result, err := config.DB.ExecContext(r.Context(), `
+		UPDATE job_offers
+		SET status = 'declined', responded_at = $4, snoozed_until = NULL, decline_reason = $2, decline_note = $3
+		WHERE id = $1 AND status IN ('pending', 'snoozed') AND expires_at > $4
+	`, offerID, req.Reason, req.Note, appFrom(r.Context()).Clock.Now())
+	var n int64
+	if err == nil {
+		n, err = result.RowsAffected()
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error declining job offer", "offer_id", offerID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if n == 0 {
+		respondError(w, http.StatusConflict, model.ErrCodeOfferUnavailable, "Job offer is no longer open")
+		return
+	}
+
+	slog.InfoContext(r.Context(), "Worker declined job offer", "offer_id", offerID, "job_id", jobID, "worker_id", workerID, "reason", req.Reason)
+	signalWorkerOfferResponse(r.Context(), workflows.WorkerOfferResponse{WorkerID: workerID}, jobID)
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Job offer declined",
+		"job_id":  jobID,
+	})
+}
+
+// SnoozeWorkerJobOffer puts the calling worker's open offer aside for a few minutes.
+// The offer stays open, and the workflow reminds the worker when the snooze ends; a
+// snooze must end before the offer expires.
+func SnoozeWorkerJobOffer(w http.ResponseWriter, r *http.Request) {
+	workerID, offerID, jobID, ok := loadWorkerJobOffer(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.SnoozeJobOfferRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+			return
+		}
+	}
+	if req.Minutes == 0 {
+		req.Minutes = model.OfferSnoozeDefaultMinutes
+	}
+	if req.Minutes < model.OfferSnoozeMinMinutes || req.Minutes > model.OfferSnoozeMaxMinutes {
+		RespondWithValidationError(w, &ValidationError{
+			Field:   "minutes",
+			Message: fmt.Sprintf("must be between %d and %d", model.OfferSnoozeMinMinutes, model.OfferSnoozeMaxMinutes),
+			Value:   strconv.Itoa(req.Minutes),
+		})
+		return
+	}
+
+	var status string
+	var expiresAt time.Time
+	var snoozes int
+	err := config.DB.QueryRowContext(r.Context(), `
+		SELECT `+jobOfferStatus+`, o.expires_at, o.snooze_count FROM job_offers o WHERE o.id = $1
+	`, offerID).Scan(&status, &expiresAt, &snoozes)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error loading job offer", "offer_id", offerID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if status != model.JobOfferPending && status != model.JobOfferSnoozed {
+		respondError(w, http.StatusConflict, model.ErrCodeOfferUnavailable, "Job offer is no longer open")
+		return
+	}
+	if snoozes >= model.MaxOfferSnoozes {
+		RespondWithError(w, http.StatusConflict, fmt.Sprintf("A job offer can be snoozed at most %d times", model.MaxOfferSnoozes))
+		return
+	}
+	snoozedUntil := appFrom(r.Context()).Clock.Now().Add(time.Duration(req.Minutes) * time.Minute).Truncate(time.Second)
+	if !snoozedUntil.Before(expiresAt) {
+		RespondWithError(w, http.StatusConflict, "Job offer expires before the snooze would end")
+		return
+	}
+
+	result, err := config.DB.ExecContext(r.Context(), `
+		UPDATE job_offers SET status = 'snoozed', snoozed_until = $2, snooze_count = snooze_count + 1
+		WHERE id = $1 AND status IN ('pending', 'snoozed') AND expires_at > $2 AND snooze_count < $3
+	`, offerID, snoozedUntil, model.MaxOfferSnoozes)
+	var n int64
+	if err == nil {
+		n, err = result.RowsAffected()
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error snoozing job offer", "offer_id", offerID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if n == 0 {
+		respondError(w, http.StatusConflict, model.ErrCodeOfferUnavailable, "Job offer is no longer open")
+		return
+	}
+
+	slog.InfoContext(r.Context(), "Worker snoozed job offer", "offer_id", offerID, "job_id", jobID, "worker_id", workerID, "until", snoozedUntil)
+	signalWorkerOfferResponse(r.Context(), workflows.WorkerOfferResponse{WorkerID: workerID, SnoozedUntil: &snoozedUntil}, jobID)
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success":       true,
+		"message":       "Job offer snoozed; we will remind you before it expires",
+		"job_id":        jobID,
+		"snoozed_until": snoozedUntil,
+		"expires_at":    expiresAt,
+	})
+}
+
+// GetOfferDeclineReport summarizes how workers answered the offers sent in the window,
+// overall and by job category: accept rate, decline reasons, snoozes and the amounts
+// offered on accepted and too-low-paid offers
+func GetOfferDeclineReport(w http.ResponseWriter, r *http.Request) {
+	from, err := ParseDateParam(r, "from")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	to, err := ParseDateParam(r, "to")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	end := appFrom(r.Context()).Clock.Now()
+	if to != nil {
+		end = *to
+	}
+	start := end.Add(-defaultFunnelReportWindow)
+	if from != nil {
+		start = *from
+	}
+	if !start.Before(end) {
+		RespondWithError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	report, err := analytics.OfferDeclineReport(r.Context(), config.DB, start, end)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error building offer decline report", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, report)
+}
+
+// loadWorkerJobOffer resolves the {id} offer, which must be the calling worker's,
+// writing the error response when it is not
+func loadWorkerJobOffer(w http.ResponseWriter, r *http.Request) (workerID, offerID, jobID int, ok bool) {
+	workerID, ok = RequireUserID(w, r, 0)
+	if !ok {
+		return 0, 0, 0, false
+	}
+	offerID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job offer ID format")
+		return 0, 0, 0, false
+	}
+
+	err = config.DB.QueryRowContext(r.Context(),
+		`SELECT job_id FROM job_offers WHERE id = $1 AND worker_id = $2`,
+		offerID, workerID).Scan(&jobID)
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Job offer not found")
+		return 0, 0, 0, false
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error loading job offer", "offer_id", offerID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return 0, 0, 0, false
+	}
+	return workerID, offerID, jobID, true
+}
@@ -0,0 +1,401 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"app/config"
+	"app/internal/availability"
+	"app/internal/model"
+	"app/internal/recurrence"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lib/pq"
+)
+
+// ==============================================
+// JOB TEMPLATES AND REBOOKING
+// ==============================================
+
+// jobTemplateColumns are the job_templates columns scanJobTemplate reads
+const jobTemplateColumns = `
+	id, uuid, consumer_id, name, title, description, COALESCE(category, ''),
+	COALESCE(location_address, ''), location_latitude, location_longitude,
+	estimated_duration_hours, pay_rate_per_hour, total_pay, COALESCE(notes, ''),
+	COALESCE(access_instructions, ''), preferred_worker_id, currency, source_job_id,
+	created_at, updated_at`
+
+func scanJobTemplate(row rowScanner) (*model.JobTemplate, error) {
+	var t model.JobTemplate
+	err := row.Scan(&t.ID, &t.UUID, &t.ConsumerID, &t.Name, &t.Title, &t.Description, &t.Category,
+		&t.LocationAddress, &t.LocationLatitude, &t.LocationLongitude,
+		&t.EstimatedDurationHours, &t.PayRatePerHour, &t.TotalPay, &t.Notes,
+		&t.AccessInstructions, &t.PreferredWorkerID, &t.Currency, &t.SourceJobID,
+		&t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// rebookableJobStatuses are the job statuses in which the work was done, so the job can
+// be booked again with its worker
+var rebookableJobStatuses = map[string]bool{
+	"completed":      true,
+	"paid":           true,
+	"review_pending": true,
+	"closed":         true,
+}
+
+// pastJob is one of the consumer's jobs as a posting to repeat, with who did it and when
+type pastJob struct {
+	posting      model.JobCreateRequest
+	status       string
+	workerID     *int
+	workerActive bool
+	scheduled    *recurrence.Slot
+}
+
+// loadPastJob reads one of the consumer's jobs; sql.ErrNoRows when it is not theirs
+func loadPastJob(ctx context.Context, jobID, consumerID int) (*pastJob, error) {
+	var j pastJob
+	var start, end sql.NullTime
+	err := config.DB.QueryRowContext(ctx, `
+		SELECT j.title, j.description, COALESCE(j.category, ''), COALESCE(j.location_address, ''),
+		       j.location_latitude, j.location_longitude, j.estimated_duration_hours,
+		       j.pay_rate_per_hour, j.total_pay, COALESCE(j.notes, ''),
+		       COALESCE(j.access_instructions, ''), j.currency, COALESCE(j.status, 'posted'),
+		       j.gig_worker_id, COALESCE(w.is_active, false), j.scheduled_start, j.scheduled_end
+		FROM jobs j
+		LEFT JOIN people w ON w.id = j.gig_worker_id
+		WHERE j.id = $1 AND j.consumer_id = $2
+	`, jobID, consumerID).Scan(
+		&j.posting.Title, &j.posting.Description, &j.posting.Category, &j.posting.LocationAddress,
+		&j.posting.LocationLatitude, &j.posting.LocationLongitude, &j.posting.EstimatedDurationHours,
+		&j.posting.PayRatePerHour, &j.posting.TotalPay, &j.posting.Notes,
+		&j.posting.AccessInstructions, &j.posting.Currency, &j.status,
+		&j.workerID, &j.workerActive, &start, &end,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if start.Valid && end.Valid && end.Time.After(start.Time) {
+		j.scheduled = &recurrence.Slot{Start: start.Time, End: end.Time}
+	}
+	return &j, nil
+}
+
+// templatePosting is the job a template posts, without a schedule
+func templatePosting(t *model.JobTemplate) model.JobCreateRequest {
+	return model.JobCreateRequest{
+		Title:                  t.Title,
+		Description:            t.Description,
+		Category:               t.Category,
+		LocationAddress:        t.LocationAddress,
+		LocationLatitude:       t.LocationLatitude,
+		LocationLongitude:      t.LocationLongitude,
+		EstimatedDurationHours: t.EstimatedDurationHours,
+		PayRatePerHour:         t.PayRatePerHour,
+		TotalPay:               t.TotalPay,
+		Notes:                  t.Notes,
+		AccessInstructions:     t.AccessInstructions,
+		PreferredWorkerID:      t.PreferredWorkerID,
+		Currency:               t.Currency,
+	}
+}
+
+// CreateJobTemplate saves a job template for the consumer, from one of their jobs when
+// job_id is given and from the posting fields otherwise
+func CreateJobTemplate(w http.ResponseWriter, r *http.Request) {
+	consumerID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	var req model.JobTemplateCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+
+	posting := model.JobCreateRequest{
+		Title:                  req.Title,
+		Description:            req.Description,
+		Category:               req.Category,
+		LocationAddress:        req.LocationAddress,
+		LocationLatitude:       req.LocationLatitude,
+		LocationLongitude:      req.LocationLongitude,
+		EstimatedDurationHours: req.EstimatedDurationHours,
+		PayRatePerHour:         req.PayRatePerHour,
+		TotalPay:               req.TotalPay,
+		Notes:                  req.Notes,
+		AccessInstructions:     req.AccessInstructions,
+		PreferredWorkerID:      req.PreferredWorkerID,
+		Currency:               req.Currency,
+	}
+	if req.JobID != nil {
+		job, err := loadPastJob(r.Context(), *req.JobID, consumerID)
+		if err == sql.ErrNoRows {
+			respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
+			return
+		}
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Database error loading job for template", "job_id", *req.JobID, "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Failed to save job template")
+			return
+		}
+		posting = job.posting
+		posting.PreferredWorkerID = req.PreferredWorkerID
+	}
+
+	if err := validateJobTemplateRequest(req.Name, &posting); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+	if posting.PreferredWorkerID != nil && !requireFavoriteWorker(w, r, consumerID, *posting.PreferredWorkerID) {
+		return
+	}
+
+	t, err := scanJobTemplate(config.DB.QueryRowContext(r.Context(), `
+		INSERT INTO job_templates (
+			consumer_id, name, title, description, category, location_address,
+			location_latitude, location_longitude, estimated_duration_hours,
+			pay_rate_per_hour, total_pay, notes, access_instructions, preferred_worker_id,
+			currency, source_job_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		RETURNING `+jobTemplateColumns,
+		consumerID, req.Name, posting.Title, posting.Description,
+		nullStringInterface(posting.Category), nullStringInterface(posting.LocationAddress),
+		nullFloat64Ptr(posting.LocationLatitude), nullFloat64Ptr(posting.LocationLongitude),
+		nullFloat64Ptr(posting.EstimatedDurationHours), nullFloat64Ptr(posting.PayRatePerHour),
+		nullFloat64Ptr(posting.TotalPay), nullStringInterface(posting.Notes),
+		nullStringInterface(posting.AccessInstructions), posting.PreferredWorkerID,
+		posting.Currency, req.JobID,
+	))
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		RespondWithError(w, http.StatusConflict, "You already have a template with this name")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error saving job template", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to save job template")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, t)
+}
+
+// GetJobTemplates lists the consumer's job templates by name
+func GetJobTemplates(w http.ResponseWriter, r *http.Request) {
+	consumerID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	rows, err := config.DB.QueryContext(r.Context(), `
+		SELECT `+jobTemplateColumns+`
+		FROM job_templates
+		WHERE consumer_id = $1
+		ORDER BY name
+	`, consumerID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing job templates", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	templates := []model.JobTemplate{}
+	for rows.Next() {
+		t, err := scanJobTemplate(rows)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning job template", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		templates = append(templates, *t)
+	}
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing job templates", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{"templates": templates})
+}
+
+// DeleteJobTemplate deletes one of the consumer's job templates. Jobs posted from it
+// are unaffected.
+func DeleteJobTemplate(w http.ResponseWriter, r *http.Request) {
+	consumerID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+	templateID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid template ID format")
+		return
+	}
+
+	result, err := config.DB.ExecContext(r.Context(),
+		`DELETE FROM job_templates WHERE id = $1 AND consumer_id = $2`, templateID, consumerID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error deleting job template", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to delete job template")
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		respondError(w, http.StatusNotFound, model.ErrCodeNotFound, "Job template not found")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Job template deleted",
+	})
+}
+
+// CreateJobFromTemplate posts a job from one of the consumer's templates, at the time
+// given and optionally with another favorite worker
+func CreateJobFromTemplate(w http.ResponseWriter, r *http.Request) {
+	consumerID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+	templateID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid template ID format")
+		return
+	}
+
+	var req model.JobFromTemplateRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+			return
+		}
+	}
+
+	t, err := scanJobTemplate(config.DB.QueryRowContext(r.Context(), `
+		SELECT `+jobTemplateColumns+`
+		FROM job_templates
+		WHERE id = $1 AND consumer_id = $2
+	`, templateID, consumerID))
+	if err == sql.ErrNoRows {
+		respondError(w, http.StatusNotFound, model.ErrCodeNotFound, "Job template not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error loading job template", "template_id", templateID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to create job")
+		return
+	}
+
+	posting := templatePosting(t)
+	posting.ScheduledStart = req.ScheduledStart
+	posting.ScheduledEnd = req.ScheduledEnd
+	if req.PreferredWorkerID != nil {
+		posting.PreferredWorkerID = req.PreferredWorkerID
+	}
+	if err := validateJobCreateRequest(&posting); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+	if posting.PreferredWorkerID != nil && !requireFavoriteWorker(w, r, consumerID, *posting.PreferredWorkerID) {
+		return
+	}
+	postJob(w, r, consumerID, &posting)
+}
+
+// RebookJob posts a finished job again with the worker who did it. Without a schedule
+// in the request the job is booked at the same weekday and time as before when the
+// worker is free then, otherwise in their first free working-hours slot; when neither
+// is free within the booking horizon it is posted unscheduled.
+func RebookJob(w http.ResponseWriter, r *http.Request) {
+	consumerID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	var req model.JobRebookRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+			return
+		}
+	}
+
+	job, err := loadPastJob(r.Context(), jobID, consumerID)
+	if err == sql.ErrNoRows {
+		respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error loading job to rebook", "job_id", jobID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to rebook job")
+		return
+	}
+	if !rebookableJobStatuses[job.status] || job.workerID == nil {
+		respondError(w, http.StatusConflict, model.ErrCodeConflict, "Only finished jobs can be rebooked with their worker")
+		return
+	}
+	if !job.workerActive {
+		respondError(w, http.StatusConflict, model.ErrCodeConflict, "The worker who did this job is no longer available")
+		return
+	}
+
+	posting := job.posting
+	posting.PreferredWorkerID = job.workerID
+	posting.ScheduledStart, posting.ScheduledEnd = req.ScheduledStart, req.ScheduledEnd
+	if posting.ScheduledStart == nil {
+		slot, found, err := rebookSlot(r.Context(), *job.workerID, job)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Failed to load worker availability for rebooking", "job_id", jobID, "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Failed to rebook job")
+			return
+		}
+		if found {
+			posting.ScheduledStart, posting.ScheduledEnd = &slot.Start, &slot.End
+		}
+	}
+	if err := validateJobCreateRequest(&posting); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+	postJob(w, r, consumerID, &posting)
+}
+
+// rebookSlot picks when to book a job again with the worker: at the same weekday and
+// time as the job was scheduled when they are free then, otherwise their first free
+// working-hours slot
+func rebookSlot(ctx context.Context, workerID int, job *pastJob) (recurrence.Slot, bool, error) {
+	from, to := availability.BookingRange(appFrom(ctx).Clock.Now())
+	avail, err := availability.ForWorker(ctx, config.DB, availability.Query{WorkerID: workerID, From: from, To: to})
+	if err != nil {
+		return recurrence.Slot{}, false, err
+	}
+	if job.scheduled != nil {
+		if slot, ok := avail.Repeat(*job.scheduled); ok {
+			return slot, true, nil
+		}
+	}
+
+	duration := availability.DefaultJobDuration
+	if job.scheduled != nil {
+		duration = job.scheduled.End.Sub(job.scheduled.Start)
+	} else if hours := job.posting.EstimatedDurationHours; hours != nil && *hours > 0 {
+		duration = time.Duration(*hours * float64(time.Hour))
+	}
+	slot, ok := avail.FirstBookable(duration)
+	return slot, ok, nil
+}
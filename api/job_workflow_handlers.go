@@ -2,11 +2,22 @@ package api
 
 import (
 	"app/config"
+	"app/internal/analytics"
+	"app/internal/auth"
+	"app/internal/jobevents"
+	"app/internal/links"
+	"app/internal/model"
+	"app/internal/realtime"
+	"app/internal/temporal"
+	"app/internal/temporal/workflows"
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"slices"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
@@ -15,14 +26,14 @@ import (
 // AcceptJobOffer allows a customer to accept a job offer
 func AcceptJobOffer(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	idParam := chi.URLParam(r, "id")
 	jobID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid job ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
 		return
 	}
 
@@ -36,41 +47,44 @@ func AcceptJobOffer(w http.ResponseWriter, r *http.Request) {
 	err = config.DB.QueryRow(query, jobID).Scan(&status)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			http.Error(w, "Job not found", http.StatusNotFound)
+			respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
 			return
 		}
-		log.Printf("Database error getting job: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error getting job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
 	// Check if job is in the right status for offer acceptance
 	if status != "offer_sent" {
 		if status == "posted" {
-			http.Error(w, "Job must be in offer_sent status to accept offer", http.StatusBadRequest)
+			respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJobStatus, "Job must be in offer_sent status to accept offer")
 			return
 		}
 		if status == "accepted" {
-			http.Error(w, "Job offer has already been accepted", http.StatusConflict)
+			respondError(w, http.StatusConflict, model.ErrCodeInvalidJobStatus, "Job offer has already been accepted")
 			return
 		}
-		http.Error(w, fmt.Sprintf("Job cannot be accepted in current status: %s", status), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJobStatus, fmt.Sprintf("Job cannot be accepted in current status: %s", status))
 		return
 	}
 
-	// Update job status directly (simplified for testing without Temporal)
-	updateQuery := `
-		UPDATE jobs 
-		SET status = 'accepted', updated_at = NOW()
-		WHERE id = $1
-	`
-	_, err = config.DB.Exec(updateQuery, jobID)
+	// Record the transition, then signal the job workflow so it stays in step
+	_, err = recordJobEvent(r, jobevents.Transition{JobID: jobID, Type: jobevents.TypeAccepted, Allowed: jobevents.StatusIn("offer_sent")})
 	if err != nil {
-		log.Printf("Database error updating job status: %v", err)
-		http.Error(w, "Failed to update job status", http.StatusInternalServerError)
+		if errors.Is(err, jobevents.ErrNotAllowed) {
+			respondError(w, http.StatusConflict, model.ErrCodeInvalidJobStatus, "Job offer is no longer pending")
+			return
+		}
+		slog.ErrorContext(r.Context(), "Database error updating job status", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update job status")
 		return
 	}
 
+	publishJobStatus(r, jobID, "accepted")
+	trackFunnel(r, jobID, analytics.StageAccepted, nil)
+	signalOfferResponse(r.Context(), jobID, true)
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]interface{}{
@@ -83,14 +97,14 @@ func AcceptJobOffer(w http.ResponseWriter, r *http.Request) {
 // RejectJobOffer allows a customer to reject a job offer
 func RejectJobOffer(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	idParam := chi.URLParam(r, "id")
 	jobID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid job ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
 		return
 	}
 
@@ -104,45 +118,52 @@ func RejectJobOffer(w http.ResponseWriter, r *http.Request) {
 	err = config.DB.QueryRow(query, jobID).Scan(&status)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			http.Error(w, "Job not found", http.StatusNotFound)
+			respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
 			return
 		}
-		log.Printf("Database error getting job: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error getting job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
 	// Check if job is in the right status for offer rejection
 	if status != "offer_sent" {
 		if status == "posted" {
-			http.Error(w, "Job must be in offer_sent status to reject offer", http.StatusBadRequest)
+			respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJobStatus, "Job must be in offer_sent status to reject offer")
 			return
 		}
 		if status == "accepted" {
-			http.Error(w, "Job offer has already been accepted", http.StatusConflict)
+			respondError(w, http.StatusConflict, model.ErrCodeInvalidJobStatus, "Job offer has already been accepted")
 			return
 		}
 		if status == "cancelled" {
-			http.Error(w, "Job has already been cancelled", http.StatusConflict)
+			respondError(w, http.StatusConflict, model.ErrCodeInvalidJobStatus, "Job has already been cancelled")
 			return
 		}
-		http.Error(w, fmt.Sprintf("Job cannot be rejected in current status: %s", status), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJobStatus, fmt.Sprintf("Job cannot be rejected in current status: %s", status))
 		return
 	}
 
-	// Update job status directly (simplified for testing without Temporal)
-	updateQuery := `
-		UPDATE jobs 
-		SET status = 'cancelled', updated_at = NOW()
-		WHERE id = $1
-	`
-	_, err = config.DB.Exec(updateQuery, jobID)
+	// Record the transition, then signal the job workflow so it stays in step
+	_, err = recordJobEvent(r, jobevents.Transition{
+		JobID:   jobID,
+		Type:    jobevents.TypeCancelled,
+		Data:    map[string]interface{}{"reason": "offer_rejected"},
+		Allowed: jobevents.StatusIn("offer_sent"),
+	})
 	if err != nil {
-		log.Printf("Database error updating job status: %v", err)
-		http.Error(w, "Failed to update job status", http.StatusInternalServerError)
+		if errors.Is(err, jobevents.ErrNotAllowed) {
+			respondError(w, http.StatusConflict, model.ErrCodeInvalidJobStatus, "Job offer is no longer pending")
+			return
+		}
+		slog.ErrorContext(r.Context(), "Database error updating job status", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update job status")
 		return
 	}
 
+	publishJobStatus(r, jobID, "cancelled")
+	signalOfferResponse(r.Context(), jobID, false)
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]interface{}{
@@ -155,66 +176,80 @@ func RejectJobOffer(w http.ResponseWriter, r *http.Request) {
 // StartJob allows a worker to mark a job as started
 func StartJob(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	idParam := chi.URLParam(r, "id")
 	jobID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid job ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
 		return
 	}
 
 	// Get job information
 	var status string
+	var gigWorkerID sql.NullInt32
 	query := `
-		SELECT COALESCE(status, 'posted') as status
+		SELECT COALESCE(status, 'posted') as status, gig_worker_id
 		FROM jobs 
 		WHERE id = $1
 	`
-	err = config.DB.QueryRow(query, jobID).Scan(&status)
+	err = config.DB.QueryRow(query, jobID).Scan(&status, &gigWorkerID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			http.Error(w, "Job not found", http.StatusNotFound)
+			respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
 			return
 		}
-		log.Printf("Database error getting job: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error getting job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	if !gigWorkerID.Valid || int(gigWorkerID.Int32) != userID {
+		RespondWithError(w, http.StatusForbidden, "Only the assigned worker can start this job")
 		return
 	}
 
 	// Check if job is in the right status to start
-	if status != "accepted" {
+	if !slices.Contains(startableJobStatuses, status) {
 		if status == "posted" {
-			http.Error(w, "Job must be accepted before starting", http.StatusBadRequest)
+			respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJobStatus, "Job must be accepted before starting")
 			return
 		}
 		if status == "in_progress" {
-			http.Error(w, "Job is already in progress", http.StatusConflict)
+			respondError(w, http.StatusConflict, model.ErrCodeInvalidJobStatus, "Job is already in progress")
 			return
 		}
 		if status == "completed" {
-			http.Error(w, "Job has already been completed", http.StatusConflict)
+			respondError(w, http.StatusConflict, model.ErrCodeInvalidJobStatus, "Job has already been completed")
 			return
 		}
-		http.Error(w, fmt.Sprintf("Job cannot be started in current status: %s", status), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJobStatus, fmt.Sprintf("Job cannot be started in current status: %s", status))
 		return
 	}
 
-	// Update job status directly (simplified for testing without Temporal)
-	updateQuery := `
-		UPDATE jobs 
-		SET status = 'in_progress', actual_start = NOW(), updated_at = NOW()
-		WHERE id = $1
-	`
-	_, err = config.DB.Exec(updateQuery, jobID)
+	// Record the transition, then signal the job workflow so it stays in step
+	_, err = recordJobEvent(r, jobevents.Transition{JobID: jobID, Type: jobevents.TypeStarted, Allowed: jobevents.StatusIn(startableJobStatuses...)})
 	if err != nil {
-		log.Printf("Database error updating job status: %v", err)
-		http.Error(w, "Failed to update job status", http.StatusInternalServerError)
+		if errors.Is(err, jobevents.ErrNotAllowed) {
+			RespondWithError(w, http.StatusConflict, "Job status changed, please retry")
+			return
+		}
+		slog.ErrorContext(r.Context(), "Database error updating job status", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update job status")
 		return
 	}
 
+	publishJobStatus(r, jobID, "in_progress")
+	trackFunnel(r, jobID, analytics.StageStarted, nil)
+	signalJobProgress(r.Context(), jobID, true, false)
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]interface{}{
@@ -228,21 +263,20 @@ func StartJob(w http.ResponseWriter, r *http.Request) {
 // Requires confirmation from both parties before marking as fully completed
 func CompleteJob(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	idParam := chi.URLParam(r, "id")
 	jobID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid job ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
 		return
 	}
 
 	// Get authenticated user from context (set by middleware)
-	userID, ok := r.Context().Value("user_id").(int)
+	userID, ok := RequireUserID(w, r, 0)
 	if !ok {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
 
@@ -259,22 +293,23 @@ func CompleteJob(w http.ResponseWriter, r *http.Request) {
 	err = config.DB.QueryRow(query, jobID).Scan(&status, &consumerID, &gigWorkerID, &workerCompletedAt, &consumerCompletedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			http.Error(w, "Job not found", http.StatusNotFound)
+			respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
 			return
 		}
-		log.Printf("Database error getting job: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error getting job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
 	// Check if job is in the right status to complete
-	// Allow completion from: accepted (auto-start), in_progress, or completed (for dual confirmation)
-	if status != "accepted" && status != "in_progress" && status != "completed" {
+	// Allow completion from: not yet started (auto-start), in_progress, or completed (for dual confirmation)
+	autoStart := slices.Contains(startableJobStatuses, status)
+	if !autoStart && status != "in_progress" && status != "completed" {
 		if status == "posted" {
-			http.Error(w, "Job must be accepted before completion", http.StatusBadRequest)
+			respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJobStatus, "Job must be accepted before completion")
 			return
 		}
-		http.Error(w, fmt.Sprintf("Job cannot be completed in current status: %s", status), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJobStatus, fmt.Sprintf("Job cannot be completed in current status: %s", status))
 		return
 	}
 
@@ -283,7 +318,7 @@ func CompleteJob(w http.ResponseWriter, r *http.Request) {
 	isWorker := gigWorkerID.Valid && int(gigWorkerID.Int32) == userID
 
 	if !isConsumer && !isWorker {
-		http.Error(w, "You are not a participant in this job", http.StatusForbidden)
+		RespondWithError(w, http.StatusForbidden, "You are not a participant in this job")
 		return
 	}
 
@@ -298,26 +333,14 @@ func CompleteJob(w http.ResponseWriter, r *http.Request) {
 		otherPartyConfirmed = consumerCompletedAt.Valid
 		confirmationType = "worker"
 
-		// If job is still in "accepted" status, auto-start it when completing
-		if status == "accepted" {
-			updateQuery = `
-				UPDATE jobs
-				SET worker_completed_at = NOW(),
-				    status = 'in_progress',
-				    actual_start = NOW(),
-				    actual_end = NOW(),
-				    updated_at = NOW()
-				WHERE id = $1
-			`
-		} else {
-			updateQuery = `
-				UPDATE jobs
-				SET worker_completed_at = NOW(),
-				    actual_end = CASE WHEN actual_end IS NULL THEN NOW() ELSE actual_end END,
-				    updated_at = NOW()
-				WHERE id = $1
-			`
-		}
+		// If the job was never started, it is auto-started below
+		updateQuery = `
+			UPDATE jobs
+			SET worker_completed_at = NOW(),
+			    actual_end = CASE WHEN actual_end IS NULL THEN NOW() ELSE actual_end END,
+			    updated_at = NOW()
+			WHERE id = $1
+		`
 	} else {
 		alreadyConfirmed = consumerCompletedAt.Valid
 		otherPartyConfirmed = workerCompletedAt.Valid
@@ -345,30 +368,71 @@ func CompleteJob(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Mark completion for this party
-	_, err = config.DB.Exec(updateQuery, jobID)
+	// Mark completion for this party, auto-starting the job if the worker never started it
+	tx, err := config.DB.Begin()
+	if err == nil {
+		defer tx.Rollback()
+		if isWorker && autoStart {
+			_, err = recordJobEventTx(r, tx, jobevents.Transition{
+				JobID:   jobID,
+				Type:    jobevents.TypeStarted,
+				Data:    map[string]interface{}{"auto_started": true},
+				Allowed: jobevents.StatusIn(startableJobStatuses...),
+			})
+		}
+	}
+	if err == nil {
+		_, err = tx.Exec(updateQuery, jobID)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
 	if err != nil {
-		log.Printf("Database error updating job completion: %v", err)
-		http.Error(w, "Failed to mark job as complete", http.StatusInternalServerError)
+		if errors.Is(err, jobevents.ErrNotAllowed) {
+			RespondWithError(w, http.StatusConflict, "Job status changed, please retry")
+			return
+		}
+		slog.ErrorContext(r.Context(), "Database error updating job completion", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to mark job as complete")
 		return
 	}
 
 	// If both parties have now confirmed, update status to completed
 	fullyCompleted := false
 	if otherPartyConfirmed {
-		statusUpdateQuery := `
-			UPDATE jobs
-			SET status = 'completed', updated_at = NOW()
-			WHERE id = $1 AND status = 'in_progress'
-		`
-		_, err = config.DB.Exec(statusUpdateQuery, jobID)
+		_, err = recordJobEvent(r, jobevents.Transition{
+			JobID:   jobID,
+			Type:    jobevents.TypeCompleted,
+			Data:    map[string]interface{}{"confirmed_last_by": confirmationType},
+			Allowed: jobevents.StatusIn("in_progress"),
+		})
 		if err != nil {
-			log.Printf("Warning: Failed to update job status to completed: %v", err)
+			slog.WarnContext(r.Context(), "Failed to update job status to completed", "error", err)
 		} else {
 			fullyCompleted = true
 		}
 	}
 
+	// Tell the other party, whether the job is now completed or awaits their confirmation
+	event := realtime.Event{Type: realtime.EventJobStatusChanged, JobID: jobID, Status: "in_progress",
+		Data: map[string]interface{}{"confirmed_by": confirmationType}}
+	if fullyCompleted {
+		event.Status = "completed"
+	}
+	if err := realtime.PublishJobEvent(r.Context(), config.DB, appFrom(r.Context()).Clock, event); err != nil {
+		slog.ErrorContext(r.Context(), "Failed to publish completion of job", "job_id", jobID, "error", err)
+	}
+	if autoStart && isWorker {
+		trackFunnel(r, jobID, analytics.StageStarted, map[string]interface{}{"auto_started": true})
+	}
+	if fullyCompleted {
+		trackFunnel(r, jobID, analytics.StageCompleted, map[string]interface{}{"confirmed_last_by": confirmationType})
+	}
+	signalJobProgress(r.Context(), jobID, autoStart && isWorker, fullyCompleted)
+	if fullyCompleted {
+		signalEscrowWorkflows(r.Context(), jobID)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]interface{}{
@@ -384,21 +448,24 @@ func CompleteJob(w http.ResponseWriter, r *http.Request) {
 // RejectJob allows a gig worker to reject a job offer or accepted job
 func RejectJob(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	idParam := chi.URLParam(r, "id")
 	jobID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid job ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
 		return
 	}
 
-	// Parse request body for optional rejection reason
-	var req struct {
-		RejectionReason string `json:"rejection_reason,omitempty"`
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
 	}
+
+	// Parse request body for optional rejection reason
+	var req model.JobRejectRequest
 	json.NewDecoder(r.Body).Decode(&req)
 
 	// Get job information
@@ -412,29 +479,33 @@ func RejectJob(w http.ResponseWriter, r *http.Request) {
 	err = config.DB.QueryRow(query, jobID).Scan(&status, &gigWorkerID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			http.Error(w, "Job not found", http.StatusNotFound)
+			respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
 			return
 		}
-		log.Printf("Database error getting job: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error getting job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	if !gigWorkerID.Valid || int(gigWorkerID.Int32) != userID {
+		RespondWithError(w, http.StatusForbidden, "Only the assigned worker can reject this job")
 		return
 	}
 
 	// Check if job can be rejected
 	if status != "accepted" && status != "offer_sent" {
-		http.Error(w, fmt.Sprintf("Job cannot be rejected in current status: %s", status), http.StatusConflict)
+		respondError(w, http.StatusConflict, model.ErrCodeInvalidJobStatus, fmt.Sprintf("Job cannot be rejected in current status: %s", status))
 		return
 	}
 
-	// Update job status back to posted and clear worker assignment
+	// Release the worker so the job is posted again, and note why
 	var updateQuery string
 	var args []interface{}
 
 	if req.RejectionReason != "" {
 		updateQuery = `
 			UPDATE jobs 
-			SET status = 'posted', gig_worker_id = NULL, 
-				notes = COALESCE(notes || E'\n\n', '') || 'Job rejected: ' || $2, 
+			SET notes = COALESCE(notes || E'\n\n', '') || 'Job rejected: ' || $2, 
 				updated_at = NOW()
 			WHERE id = $1
 		`
@@ -442,21 +513,49 @@ func RejectJob(w http.ResponseWriter, r *http.Request) {
 	} else {
 		updateQuery = `
 			UPDATE jobs 
-			SET status = 'posted', gig_worker_id = NULL, 
-				notes = COALESCE(notes || E'\n\n', '') || 'Job rejected by worker', 
+			SET notes = COALESCE(notes || E'\n\n', '') || 'Job rejected by worker', 
 				updated_at = NOW()
 			WHERE id = $1
 		`
 		args = []interface{}{jobID}
 	}
 
-	_, err = config.DB.Exec(updateQuery, args...)
+	var data map[string]interface{}
+	if req.RejectionReason != "" {
+		data = map[string]interface{}{"reason": req.RejectionReason}
+	}
+
+	tx, err := config.DB.Begin()
+	if err == nil {
+		defer tx.Rollback()
+		_, err = recordJobEventTx(r, tx, jobevents.Transition{
+			JobID: jobID,
+			Type:  jobevents.TypeWorkerReleased,
+			Data:  data,
+			Allowed: func(s jobevents.State) bool {
+				return s.GigWorkerID != nil && *s.GigWorkerID == userID &&
+					(s.Status == "accepted" || s.Status == "offer_sent")
+			},
+		})
+	}
+	if err == nil {
+		_, err = tx.Exec(updateQuery, args...)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
 	if err != nil {
-		log.Printf("Database error updating job status: %v", err)
-		http.Error(w, "Failed to reject job", http.StatusInternalServerError)
+		if errors.Is(err, jobevents.ErrNotAllowed) {
+			RespondWithError(w, http.StatusConflict, "Job status changed, please retry")
+			return
+		}
+		slog.ErrorContext(r.Context(), "Database error updating job status", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to reject job")
 		return
 	}
 
+	publishJobStatus(r, jobID, "posted")
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]interface{}{
@@ -470,36 +569,35 @@ func RejectJob(w http.ResponseWriter, r *http.Request) {
 // This function is kept for backward compatibility with existing Postman tests
 func SubmitReview(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	idParam := chi.URLParam(r, "id")
 	jobID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid job ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
 		return
 	}
 
 	// Parse request body - legacy format
-	var req struct {
-		ReviewerID int    `json:"reviewer_id"`
-		Rating     int    `json:"rating"`
-		Comment    string `json:"comment"`
-	}
+	var req model.JobReviewSubmission
 	err = json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
-		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
 		return
 	}
 
-	// Validate review data
-	if req.ReviewerID <= 0 {
-		http.Error(w, "Reviewer ID is required", http.StatusBadRequest)
+	// The reviewer is the authenticated user; a legacy reviewer_id must match
+	reviewerID, ok := RequireUserID(w, r, req.ReviewerID)
+	if !ok {
 		return
 	}
-	if req.Rating < 1 || req.Rating > 5 {
-		http.Error(w, "Rating must be between 1 and 5", http.StatusBadRequest)
+	req.ReviewerID = reviewerID
+
+	// Validate review data
+	if err := validateJobReviewSubmission(&req); err != nil {
+		RespondWithValidationErrors(w, err)
 		return
 	}
 
@@ -514,17 +612,17 @@ func SubmitReview(w http.ResponseWriter, r *http.Request) {
 	err = config.DB.QueryRow(query, jobID).Scan(&status, &consumerID, &gigWorkerID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			http.Error(w, "Job not found", http.StatusNotFound)
+			respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
 			return
 		}
-		log.Printf("Database error getting job: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error getting job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
 	// Check if job is in the right status for review submission
-	if status != "completed" {
-		http.Error(w, "Job must be completed before submitting a review", http.StatusBadRequest)
+	if !slices.Contains(reviewableJobStatuses, status) {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJobStatus, "Job must be completed before submitting a review")
 		return
 	}
 
@@ -535,14 +633,14 @@ func SubmitReview(w http.ResponseWriter, r *http.Request) {
 		if gigWorkerID.Valid {
 			revieweeID = int(gigWorkerID.Int32)
 		} else {
-			http.Error(w, "Cannot determine reviewee for this job", http.StatusBadRequest)
+			RespondWithError(w, http.StatusBadRequest, "Cannot determine reviewee for this job")
 			return
 		}
 	} else if gigWorkerID.Valid && int(gigWorkerID.Int32) == req.ReviewerID {
 		// Gig worker reviewing consumer
 		revieweeID = int(consumerID.Int32)
 	} else {
-		http.Error(w, "Reviewer must be a participant in this job", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Reviewer must be a participant in this job")
 		return
 	}
 
@@ -551,10 +649,10 @@ func SubmitReview(w http.ResponseWriter, r *http.Request) {
 	checkQuery := `SELECT id FROM job_reviews WHERE job_id = $1 AND reviewer_id = $2`
 	err = config.DB.QueryRow(checkQuery, jobID, req.ReviewerID).Scan(&existingID)
 	if err == nil {
-		http.Error(w, "Review already exists for this job", http.StatusConflict)
+		respondError(w, http.StatusConflict, model.ErrCodeReviewExists, "Review already exists for this job")
 		return
 	} else if err != sql.ErrNoRows {
-		log.Printf("Database error checking existing review: %v", err)
+		slog.ErrorContext(r.Context(), "Database error checking existing review", "error", err)
 	}
 
 	// Store review in job_reviews table
@@ -564,11 +662,14 @@ func SubmitReview(w http.ResponseWriter, r *http.Request) {
 	`
 	_, err = config.DB.Exec(insertQuery, jobID, req.ReviewerID, revieweeID, req.Rating, req.Comment)
 	if err != nil {
-		log.Printf("Database error storing review: %v", err)
-		http.Error(w, "Failed to store review", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error storing review", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to store review")
 		return
 	}
 
+	trackFunnel(r, jobID, analytics.StageReviewed, map[string]interface{}{"rating": req.Rating})
+	signalReviewSubmitted(r.Context(), workflows.ReviewSubmission{JobID: jobID, ReviewerID: req.ReviewerID, Rating: req.Rating, Comment: req.Comment})
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]interface{}{
@@ -577,3 +678,241 @@ func SubmitReview(w http.ResponseWriter, r *http.Request) {
 		"job_id":  jobID,
 	})
 }
+
+// SubmitReviewFromLink submits a review from the link in a review request email. The
+// link's token is scoped to the job and names the reviewer, so no session is needed.
+func SubmitReviewFromLink(w http.ResponseWriter, r *http.Request) {
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	// Review deep links are tried first; tokens from emails sent before them still work
+	token := r.URL.Query().Get("token")
+	claims, err := appFrom(r.Context()).Links().Use(r.Context(), token, links.ActionReviewJob, jobID)
+	if errors.Is(err, links.ErrInvalidLink) {
+		claims, err = appFrom(r.Context()).Tokens().ValidateScopedToken(token, auth.ScopeReviewSubmit(jobID))
+	} else if err != nil && !errors.Is(err, links.ErrLinkUsed) {
+		slog.ErrorContext(r.Context(), "Failed to use review link", "job_id", jobID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if err != nil || claims.UserID == 0 {
+		respondError(w, http.StatusForbidden, model.ErrCodeInvalidToken, "Link is invalid or has expired")
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "user_id", claims.UserID)
+	SubmitReview(w, r.WithContext(ctx))
+}
+
+// AcceptJobOfferFromLink accepts a job offer from the single-use link in the offer
+// notification. The link signs in as the job's consumer for this request only.
+func AcceptJobOfferFromLink(w http.ResponseWriter, r *http.Request) {
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	token := r.URL.Query().Get("token")
+	_, linkJobID, err := links.Parse(token)
+	if err != nil || linkJobID != jobID {
+		respondError(w, http.StatusForbidden, model.ErrCodeInvalidToken, "Link is invalid or has expired")
+		return
+	}
+
+	// The offer must still be pending before the link is used up
+	var consumerID int
+	var status string
+	err = config.DB.QueryRow(`SELECT consumer_id, COALESCE(status, 'posted') FROM jobs WHERE id = $1`, jobID).Scan(&consumerID, &status)
+	if err == sql.ErrNoRows {
+		respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if status != "offer_sent" {
+		respondError(w, http.StatusConflict, model.ErrCodeInvalidJobStatus, "Job offer is no longer pending")
+		return
+	}
+
+	claims, err := appFrom(r.Context()).Links().Use(r.Context(), token, links.ActionAcceptOffer, jobID)
+	switch {
+	case errors.Is(err, links.ErrInvalidLink):
+		respondError(w, http.StatusForbidden, model.ErrCodeInvalidToken, "Link is invalid or has expired")
+		return
+	case errors.Is(err, links.ErrLinkUsed):
+		respondError(w, http.StatusForbidden, model.ErrCodeInvalidToken, "Link has already been used")
+		return
+	case err != nil:
+		slog.ErrorContext(r.Context(), "Failed to use offer link", "job_id", jobID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if claims.UserID != consumerID {
+		RespondWithError(w, http.StatusForbidden, "Only the job's customer can accept its offer")
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "user_id", claims.UserID)
+	ctx = context.WithValue(ctx, "user_role", "consumer")
+	AcceptJobOffer(w, r.WithContext(ctx))
+}
+
+// JobWorkflowStatus is a job workflow's live state next to the job's stored status,
+// so support staff can see where the two disagree
+type JobWorkflowStatus struct {
+	workflows.JobWorkflowState
+	WorkflowID string `json:"workflow_id"`
+	JobStatus  string `json:"job_status"`
+}
+
+// GetJobWorkflowState queries the job's Temporal workflow for its current state
+func GetJobWorkflowState(w http.ResponseWriter, r *http.Request) {
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	var status string
+	var workflowID sql.NullString
+	err = config.DB.QueryRow(`SELECT status, temporal_workflow_id FROM jobs WHERE id = $1`, jobID).Scan(&status, &workflowID)
+	if err == sql.ErrNoRows {
+		respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting job workflow", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if !workflowID.Valid || workflowID.String == "" {
+		RespondWithError(w, http.StatusNotFound, "Job has no workflow")
+		return
+	}
+
+	temporalClient, err := temporal.NewClient()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to create Temporal client", "error", err)
+		RespondWithError(w, http.StatusServiceUnavailable, "Workflow status is temporarily unavailable")
+		return
+	}
+	defer temporalClient.Close()
+
+	state, err := temporalClient.QueryJobWorkflowState(r.Context(), workflowID.String)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to query workflow for job", "job_id", jobID, "error", err)
+		RespondWithError(w, http.StatusBadGateway, "Failed to query job workflow")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, JobWorkflowStatus{
+		JobWorkflowState: *state,
+		WorkflowID:       workflowID.String,
+		JobStatus:        status,
+	})
+}
+
+// Statuses a job can be started from. Jobs run by the workflow are assigned and
+// scheduled first; jobs a worker accepts directly start from accepted.
+var startableJobStatuses = []string{"accepted", "worker_assigned", "scheduled"}
+
+// Statuses a job can be reviewed in. Once both parties confirm completion the
+// workflow moves the job on to paid and review_pending while it waits for reviews.
+var reviewableJobStatuses = []string{"completed", "paid", "review_pending"}
+
+// signalOfferResponse passes the consumer's answer to the offer the job workflow
+// is waiting on
+func signalOfferResponse(ctx context.Context, jobID int, accepted bool) {
+	signalJobWorkflow(ctx, jobID, func(c *temporal.Client, workflowID string) error {
+		return c.SignalJobOfferResponse(context.WithoutCancel(ctx), workflowID, accepted)
+	})
+}
+
+// signalJobProgress tells the job workflow the job has started and/or completed.
+// Both are sent from one goroutine so the workflow sees them in order.
+func signalJobProgress(ctx context.Context, jobID int, started, completed bool) {
+	if !started && !completed {
+		return
+	}
+	signalJobWorkflow(ctx, jobID, func(c *temporal.Client, workflowID string) error {
+		if started {
+			if err := c.SignalJobStarted(context.WithoutCancel(ctx), workflowID); err != nil {
+				return err
+			}
+		}
+		if completed {
+			return c.SignalJobCompleted(context.WithoutCancel(ctx), workflowID)
+		}
+		return nil
+	})
+}
+
+// signalReviewSubmitted counts a review toward the two the job workflow waits for
+// before closing the job
+func signalReviewSubmitted(ctx context.Context, review workflows.ReviewSubmission) {
+	signalJobWorkflow(ctx, review.JobID, func(c *temporal.Client, workflowID string) error {
+		return c.SignalReviewSubmitted(context.WithoutCancel(ctx), workflowID, review)
+	})
+}
+
+// signalWorkerOfferResponse tells the job workflow a worker answered its offer of the job
+func signalWorkerOfferResponse(ctx context.Context, response workflows.WorkerOfferResponse, jobID int) {
+	signalJobWorkflow(ctx, jobID, func(c *temporal.Client, workflowID string) error {
+		return c.SignalWorkerOfferResponse(context.WithoutCancel(ctx), workflowID, response)
+	})
+}
+
+// signalJobHandoff tells the job workflow to hand the job from its worker to another
+func signalJobHandoff(ctx context.Context, jobID int, req workflows.HandoffRequest) {
+	signalJobWorkflow(ctx, jobID, func(c *temporal.Client, workflowID string) error {
+		return c.SignalJobHandoff(context.WithoutCancel(ctx), workflowID, req)
+	})
+}
+
+// pauseJobWorkflow signals the job's Temporal workflow to hold before its next step.
+// Jobs without a workflow (e.g. created while Temporal was unavailable) are skipped.
+func pauseJobWorkflow(ctx context.Context, jobID int, req workflows.PauseRequest) {
+	signalJobWorkflow(ctx, jobID, func(c *temporal.Client, workflowID string) error {
+		return c.SignalJobPaused(context.WithoutCancel(ctx), workflowID, req)
+	})
+}
+
+// resumeJobWorkflow releases a job workflow previously held by pauseJobWorkflow
+func resumeJobWorkflow(ctx context.Context, jobID int) {
+	signalJobWorkflow(ctx, jobID, func(c *temporal.Client, workflowID string) error {
+		return c.SignalJobResumed(context.WithoutCancel(ctx), workflowID)
+	})
+}
+
+// signalJobWorkflow looks up the job's workflow ID and sends a signal asynchronously
+func signalJobWorkflow(ctx context.Context, jobID int, send func(c *temporal.Client, workflowID string) error) {
+	var workflowID sql.NullString
+	err := config.DB.QueryRow(`SELECT temporal_workflow_id FROM jobs WHERE id = $1`, jobID).Scan(&workflowID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Database error loading workflow ID for job", "job_id", jobID, "error", err)
+		return
+	}
+	if !workflowID.Valid || workflowID.String == "" {
+		return
+	}
+
+	go func() {
+		temporalClient, err := temporal.NewClient()
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to create Temporal client", "error", err)
+			return
+		}
+		defer temporalClient.Close()
+
+		if err := send(temporalClient, workflowID.String); err != nil {
+			slog.ErrorContext(ctx, "Failed to signal workflow for job", "job_id", jobID, "error", err)
+		}
+	}()
+}
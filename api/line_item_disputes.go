@@ -0,0 +1,425 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/email"
+	"app/internal/model"
+	"app/internal/temporal/workflows"
+	"app/internal/validate"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lib/pq"
+)
+
+const lineItemDisputeColumns = `
+	id, uuid, transaction_id, job_id, consumer_id, gig_worker_id, line_type, line_id,
+	line_description, line_amount, amount, reason, status, worker_note, responded_at,
+	refund_transaction_id, dispute_id, created_at, updated_at
+`
+
+// scanLineItemDispute scans a line_item_disputes row selected with lineItemDisputeColumns
+func scanLineItemDispute(row rowScanner) (*model.LineItemDispute, error) {
+	var d model.LineItemDispute
+	var workerID, lineID, refundTransactionID, disputeID sql.NullInt64
+	var workerNote sql.NullString
+	var respondedAt sql.NullTime
+
+	err := row.Scan(
+		&d.ID, &d.UUID, &d.TransactionID, &d.JobID, &d.ConsumerID, &workerID, &d.LineType, &lineID,
+		&d.LineDescription, &d.LineAmount, &d.Amount, &d.Reason, &d.Status, &workerNote, &respondedAt,
+		&refundTransactionID, &disputeID, &d.CreatedAt, &d.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	d.GigWorkerID = intPtrFromNull(workerID)
+	d.LineID = intPtrFromNull(lineID)
+	d.WorkerNote = stringPtrFromNull(workerNote)
+	d.RespondedAt = timePtrFromNull(respondedAt)
+	d.RefundTransactionID = intPtrFromNull(refundTransactionID)
+	d.DisputeID = intPtrFromNull(disputeID)
+	return &d, nil
+}
+
+// ==============================================
+// LINE ITEM DISPUTES (CONSUMERS)
+// ==============================================
+
+// CreateLineItemDispute lets the consumer dispute one line of a payment's receipt. The
+// worker is notified and either accepts, refunding the disputed amount, or contests it.
+func CreateLineItemDispute(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	transactionID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid transaction ID format")
+		return
+	}
+
+	var req model.LineItemDisputeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.LineType == model.ReceiptLineService {
+		req.LineID = nil
+	}
+	var v validate.Validator
+	v.Required("line_type", req.LineType)
+	v.OneOf("line_type", req.LineType, model.ReceiptLineService, model.ReceiptLineMaterial, model.ReceiptLineExpense)
+	v.Check(req.LineType == model.ReceiptLineService || req.LineID != nil, "line_id", "is required for materials and expense lines")
+	v.Check(req.Amount == nil || req.Amount.IsPositive(), "amount", "must be greater than 0")
+	v.Required("reason", req.Reason)
+	v.Length("reason", req.Reason, 0, 2000)
+	if err := v.Err(); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+
+	_, receipt, err := scanSpendReceipt(config.DB.QueryRow(
+		`SELECT `+spendReceiptColumns+spendReceiptFrom+` AND t.id = $1 AND t.consumer_id = $2`,
+		transactionID, userID,
+	))
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Receipt not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting receipt", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if receipt.RefundedAt != nil {
+		RespondWithError(w, http.StatusConflict, "This payment has already been refunded")
+		return
+	}
+
+	items, err := receiptLineItems(transactionID, receipt)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting receipt line items", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	item, found := model.FindReceiptLine(items, req.LineType, req.LineID)
+	if !found {
+		RespondWithValidationError(w, &ValidationError{Field: "line_id", Message: "does not match a line on this receipt"})
+		return
+	}
+	lineAmount := model.MoneyFromDollars(item.Amount).In(receipt.Currency)
+	amount := lineAmount
+	if req.Amount != nil {
+		amount = req.Amount.In(receipt.Currency)
+	}
+	if amount.Cents > lineAmount.Cents {
+		RespondWithValidationError(w, &ValidationError{
+			Field:   "amount",
+			Message: fmt.Sprintf("must not exceed the line's amount of %s", lineAmount),
+			Value:   amount.String(),
+		})
+		return
+	}
+
+	dispute, err := scanLineItemDispute(config.DB.QueryRow(`
+		INSERT INTO line_item_disputes (transaction_id, job_id, consumer_id, gig_worker_id, line_type, line_id,
+			line_description, line_amount, amount, reason)
+		SELECT t.id, t.job_id, t.consumer_id, t.gig_worker_id, $2, $3, $4, $5, $6, $7
+		FROM transactions t WHERE t.id = $1
+		RETURNING `+lineItemDisputeColumns,
+		transactionID, req.LineType, nullIntPtr(req.LineID), item.Description, lineAmount, amount, req.Reason,
+	))
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		RespondWithError(w, http.StatusConflict, "A line item on this payment is already waiting for the worker's answer")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error creating line item dispute", "transaction_id", transactionID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to dispute line item")
+		return
+	}
+
+	if dispute.GigWorkerID != nil {
+		notifyLineItemDispute(r.Context(), dispute, *dispute.GigWorkerID, "Charge disputed",
+			fmt.Sprintf("Your customer disputed $%s of \"%s\" on %s: %s. Accept to refund it, or contest it for support to review.",
+				dispute.Amount, dispute.LineDescription, receipt.JobTitle, dispute.Reason))
+	}
+
+	RespondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"message": "The worker has been asked to accept or contest the charge",
+		"dispute": dispute,
+	})
+}
+
+// GetLineItemDisputes lists the line item disputes on a payment for its consumer,
+// its worker or an admin
+func GetLineItemDisputes(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	transactionID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid transaction ID format")
+		return
+	}
+
+	var consumerID int
+	var workerID sql.NullInt64
+	err = config.DB.QueryRow(`SELECT consumer_id, gig_worker_id FROM transactions WHERE id = $1`, transactionID).Scan(&consumerID, &workerID)
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Transaction not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting transaction", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if GetUserRoleFromContext(r) != "admin" && userID != consumerID && (!workerID.Valid || int(workerID.Int64) != userID) {
+		RespondWithError(w, http.StatusNotFound, "Transaction not found")
+		return
+	}
+
+	rows, err := config.DB.Query(`SELECT `+lineItemDisputeColumns+` FROM line_item_disputes WHERE transaction_id = $1 ORDER BY created_at DESC`, transactionID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying line item disputes", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	disputes := []model.LineItemDispute{}
+	for rows.Next() {
+		dispute, err := scanLineItemDispute(rows)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning line item dispute row", "error", err)
+			continue
+		}
+		disputes = append(disputes, *dispute)
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{"disputes": disputes})
+}
+
+// ==============================================
+// LINE ITEM DISPUTES (WORKERS)
+// ==============================================
+
+// RespondToLineItemDispute records the worker's answer to a disputed charge. Accepting
+// refunds the disputed amount from the payment; contesting opens a dispute case for
+// support, or adds to the job's open one.
+func RespondToLineItemDispute(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	disputeID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid dispute ID format")
+		return
+	}
+
+	var req model.LineItemDisputeAnswer
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	if req.Note != nil {
+		trimmed := strings.TrimSpace(*req.Note)
+		req.Note = &trimmed
+		if len(trimmed) > 2000 {
+			RespondWithValidationError(w, &ValidationError{Field: "note", Message: "must be at most 2000 characters"})
+			return
+		}
+	}
+
+	current, err := getLineItemDispute(disputeID)
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Dispute not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting line item dispute", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if current.GigWorkerID == nil || *current.GigWorkerID != userID {
+		RespondWithError(w, http.StatusForbidden, "Only the job's worker can answer this dispute")
+		return
+	}
+	if current.Status != model.LineItemDisputePending {
+		RespondWithError(w, http.StatusConflict, "This dispute has already been answered")
+		return
+	}
+
+	var dispute *model.LineItemDispute
+	if req.Accept {
+		dispute, ok = acceptLineItemDispute(w, r, current, req.Note)
+	} else {
+		dispute, ok = contestLineItemDispute(w, r, current, req.Note)
+	}
+	if !ok {
+		return
+	}
+
+	message := fmt.Sprintf("Your worker accepted your dispute of \"%s\". $%s will be refunded to your payment method.", dispute.LineDescription, dispute.Amount)
+	if dispute.Status == model.LineItemDisputeContested {
+		message = fmt.Sprintf("Your worker contested your dispute of \"%s\". Our support team will review it.", dispute.LineDescription)
+	}
+	if dispute.WorkerNote != nil && *dispute.WorkerNote != "" {
+		message += " Note: " + *dispute.WorkerNote
+	}
+	notifyLineItemDispute(r.Context(), dispute, dispute.ConsumerID, "Disputed charge answered", message)
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Dispute answered successfully",
+		"dispute": dispute,
+	})
+}
+
+// acceptLineItemDispute refunds the disputed amount on the consumer's behalf. The job
+// stays completed and its worker is paid the rest of the receipt.
+func acceptLineItemDispute(w http.ResponseWriter, r *http.Request, current *model.LineItemDispute, note *string) (*model.LineItemDispute, bool) {
+	if paymentService == nil {
+		InitPaymentService()
+	}
+	amount := current.Amount
+	resp, err := paymentService.RefundJobPayment(r.Context(), current.ConsumerID, model.PaymentRefundRequest{
+		TransactionID:  current.TransactionID,
+		Amount:         &amount,
+		Reason:         "line item dispute " + current.UUID,
+		IdempotencyKey: "line-item-refund-" + current.UUID,
+		KeepJobStatus:  true,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to refund disputed line item", "line_item_dispute_id", current.ID, "error", err)
+		RespondWithError(w, http.StatusBadGateway, "Failed to refund payment")
+		return nil, false
+	}
+
+	dispute, err := scanLineItemDispute(config.DB.QueryRow(`
+		UPDATE line_item_disputes
+		SET status = $2, worker_note = $3, responded_at = NOW(), refund_transaction_id = $4
+		WHERE id = $1
+		RETURNING `+lineItemDisputeColumns,
+		current.ID, model.LineItemDisputeAccepted, note, resp.RefundID,
+	))
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error recording accepted line item dispute", "line_item_dispute_id", current.ID, "refund_id", resp.RefundID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return nil, false
+	}
+	return dispute, true
+}
+
+// contestLineItemDispute opens a dispute case for the job, holding its payout until
+// support resolves it. A job can only have one open case, so a contested line joins it.
+func contestLineItemDispute(w http.ResponseWriter, r *http.Request, current *model.LineItemDispute, note *string) (*model.LineItemDispute, bool) {
+	description := fmt.Sprintf("Disputed line item: %s ($%s of $%s). Consumer: %s",
+		current.LineDescription, current.Amount, current.LineAmount, current.Reason)
+	if note != nil && *note != "" {
+		description += "\nWorker: " + *note
+	}
+
+	tx, err := config.DB.Begin()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to begin transaction", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return nil, false
+	}
+	defer tx.Rollback()
+
+	var caseID int
+	var opened bool
+	err = tx.QueryRow(`
+		INSERT INTO job_disputes (job_id, opened_by, reason, description)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (job_id) WHERE status IN ('open', 'under_review') DO NOTHING
+		RETURNING id
+	`, current.JobID, current.ConsumerID, model.DisputeReasonOvercharged, description).Scan(&caseID)
+	switch {
+	case err == nil:
+		opened = true
+	case err == sql.ErrNoRows:
+		err = tx.QueryRow(`SELECT id FROM job_disputes WHERE job_id = $1 AND status IN ('open', 'under_review')`, current.JobID).Scan(&caseID)
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error opening dispute for line item", "line_item_dispute_id", current.ID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return nil, false
+	}
+
+	dispute, err := scanLineItemDispute(tx.QueryRow(`
+		UPDATE line_item_disputes
+		SET status = $2, worker_note = $3, responded_at = NOW(), dispute_id = $4
+		WHERE id = $1 AND status = 'pending'
+		RETURNING `+lineItemDisputeColumns,
+		current.ID, model.LineItemDisputeContested, note, caseID,
+	))
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusConflict, "This dispute has already been answered")
+		return nil, false
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error recording contested line item dispute", "line_item_dispute_id", current.ID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return nil, false
+	}
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(r.Context(), "Failed to commit contested line item dispute", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return nil, false
+	}
+
+	if opened {
+		var jobWorkflowID sql.NullString
+		if err := config.DB.QueryRow(`SELECT temporal_workflow_id FROM jobs WHERE id = $1`, current.JobID).Scan(&jobWorkflowID); err != nil {
+			slog.ErrorContext(r.Context(), "Database error getting job workflow", "job_id", current.JobID, "error", err)
+		}
+		go startDisputeWorkflow(r.Context(), workflows.DisputeInput{
+			DisputeID:     caseID,
+			JobID:         current.JobID,
+			JobWorkflowID: jobWorkflowID.String,
+		})
+		signalEscrowWorkflows(r.Context(), current.JobID)
+	}
+	return dispute, true
+}
+
+// notifyLineItemDispute notifies a party to a line item dispute
+func notifyLineItemDispute(ctx context.Context, dispute *model.LineItemDispute, recipientID int, title, message string) {
+	jobID, transactionID := dispute.JobID, dispute.TransactionID
+	_, err := email.NewNotificationDispatcher(config.DB).Dispatch(ctx, model.Notification{
+		UserID:               recipientID,
+		Type:                 model.NotificationSystemMessage,
+		Title:                title,
+		Message:              message,
+		RelatedJobID:         &jobID,
+		RelatedTransactionID: &transactionID,
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to notify of line item dispute", "line_item_dispute_id", dispute.ID, "user_id", recipientID, "error", err)
+	}
+}
+
+func getLineItemDispute(disputeID int) (*model.LineItemDispute, error) {
+	return scanLineItemDispute(config.DB.QueryRow(
+		"SELECT "+lineItemDisputeColumns+" FROM line_item_disputes WHERE id = $1", disputeID,
+	))
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"app/internal/links"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// defaultLinkStatsWindow is the stats window when from is not given
+const defaultLinkStatsWindow = 30 * 24 * time.Hour
+
+// FollowLink counts a click on a deep link from an email or push notification and
+// redirects to the app page that performs its action. Links that are expired, forged
+// or already used redirect to the app's expired-link page instead.
+func FollowLink(w http.ResponseWriter, r *http.Request) {
+	service := appFrom(r.Context()).Links()
+	destination, err := service.Follow(r.Context(), chi.URLParam(r, "token"))
+	if errors.Is(err, links.ErrInvalidLink) || errors.Is(err, links.ErrLinkUsed) {
+		http.Redirect(w, r, service.ExpiredPage(), http.StatusFound)
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to follow deep link", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Link tokens must not leak to the next page through the Referer header
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, destination, http.StatusFound)
+}
+
+// GetDeepLinkStats reports, per action, how many deep links created in the window were
+// clicked, used, or left to expire
+func GetDeepLinkStats(w http.ResponseWriter, r *http.Request) {
+	from, err := ParseDateParam(r, "from")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	to, err := ParseDateParam(r, "to")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	end := appFrom(r.Context()).Clock.Now()
+	if to != nil {
+		end = *to
+	}
+	start := end.Add(-defaultLinkStatsWindow)
+	if from != nil {
+		start = *from
+	}
+	if !start.Before(end) {
+		RespondWithError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	stats, err := appFrom(r.Context()).Links().Stats(r.Context(), start, end)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error building deep link stats", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, stats)
+}
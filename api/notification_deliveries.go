@@ -0,0 +1,297 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/email"
+	"app/internal/model"
+	"app/internal/notifications"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// maxEventWebhookBytes caps a SendGrid event webhook batch or SNS message
+const maxEventWebhookBytes = 5 << 20
+
+// SendGridEventWebhook records SendGrid's delivery receipts (delivered, deferred,
+// bounce, dropped, ...) against the emails in the delivery ledger, and suppresses
+// addresses that bounce or report spam. Requests must be signed with the key in
+// SENDGRID_WEBHOOK_PUBLIC_KEY; unsigned requests are only accepted outside production
+// when no key is set.
+func SendGridEventWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxEventWebhookBytes))
+	if err != nil {
+		RespondWithError(w, http.StatusRequestEntityTooLarge, "Request body too large")
+		return
+	}
+
+	publicKey := os.Getenv("SENDGRID_WEBHOOK_PUBLIC_KEY")
+	switch {
+	case publicKey != "":
+		err := email.VerifyEventSignature(publicKey, r.Header.Get(email.EventSignatureHeader), r.Header.Get(email.EventTimestampHeader), body)
+		if errors.Is(err, email.ErrInvalidEventSignature) {
+			RespondWithError(w, http.StatusUnauthorized, "Invalid signature")
+			return
+		}
+		if err != nil {
+			slog.ErrorContext(r.Context(), "SendGrid webhook verification misconfigured", "error", err)
+			RespondWithError(w, http.StatusServiceUnavailable, "Webhook verification is not configured")
+			return
+		}
+	case os.Getenv("APP_ENV") == "production":
+		RespondWithError(w, http.StatusServiceUnavailable, "Webhook verification is not configured")
+		return
+	}
+
+	events, err := email.ParseEvents(body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid event payload")
+		return
+	}
+
+	ledger := notifications.NewLedger(config.DB)
+	suppressions := email.NewSuppressionList(config.DB)
+	recorded := 0
+	for _, e := range events {
+		if reason := e.Suppression(); reason != "" && e.Email != "" {
+			if err := suppressions.Add(r.Context(), e.Email, reason, email.ProviderSendGrid, e.Detail()); err != nil {
+				slog.ErrorContext(r.Context(), "Failed to suppress email address", "event", e.Event, "error", err)
+				RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+				return
+			}
+		}
+
+		messageID := e.ProviderMessageID()
+		if messageID == "" {
+			continue
+		}
+		found, err := ledger.ApplyReceipt(r.Context(), email.ProviderSendGrid, messageID, notifications.Receipt{
+			Event:      e.ReceiptEvent(),
+			Detail:     e.Detail(),
+			OccurredAt: e.OccurredAt(),
+		})
+		if err != nil {
+			// SendGrid retries the whole batch on an error response, so report it
+			slog.ErrorContext(r.Context(), "Failed to record SendGrid event", "message_id", messageID, "event", e.Event, "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		if found {
+			recorded++
+		}
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"received": len(events),
+		"recorded": recorded,
+	})
+}
+
+// SESEventWebhook receives the SES bounce, complaint and delivery notifications
+// published to an SNS topic with an HTTPS subscription to this endpoint. It confirms
+// the subscription, records receipts against the emails in the delivery ledger and
+// suppresses addresses that hard bounce or complain. Messages must be signed by SNS
+// and come from a topic in SES_SNS_TOPIC_ARN; any topic is accepted outside production
+// when it is unset.
+func SESEventWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxEventWebhookBytes))
+	if err != nil {
+		RespondWithError(w, http.StatusRequestEntityTooLarge, "Request body too large")
+		return
+	}
+
+	msg, err := email.ParseSNSMessage(body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid SNS message")
+		return
+	}
+
+	topics := os.Getenv("SES_SNS_TOPIC_ARN")
+	switch {
+	case topics != "":
+		if !slices.Contains(strings.Split(topics, ","), msg.TopicArn) {
+			RespondWithError(w, http.StatusForbidden, "Unknown SNS topic")
+			return
+		}
+	case os.Getenv("APP_ENV") == "production":
+		RespondWithError(w, http.StatusServiceUnavailable, "Webhook verification is not configured")
+		return
+	}
+
+	err = email.VerifySNSMessage(r.Context(), msg)
+	if errors.Is(err, email.ErrInvalidSNSSignature) {
+		RespondWithError(w, http.StatusUnauthorized, "Invalid signature")
+		return
+	}
+	if err != nil {
+		// SNS retries, by which time the signing certificate may be reachable
+		slog.ErrorContext(r.Context(), "Failed to verify SNS message", "topic", msg.TopicArn, "error", err)
+		RespondWithError(w, http.StatusServiceUnavailable, "Could not verify signature")
+		return
+	}
+
+	switch msg.Type {
+	case email.SNSSubscriptionConfirmation:
+		if err := email.ConfirmSNSSubscription(r.Context(), msg); err != nil {
+			slog.ErrorContext(r.Context(), "Failed to confirm SNS subscription", "topic", msg.TopicArn, "error", err)
+			RespondWithError(w, http.StatusBadGateway, "Failed to confirm subscription")
+			return
+		}
+		slog.InfoContext(r.Context(), "Confirmed SNS subscription for SES events", "topic", msg.TopicArn)
+		RespondWithJSON(w, http.StatusOK, map[string]interface{}{"confirmed": true})
+		return
+	case email.SNSNotification:
+	default:
+		RespondWithJSON(w, http.StatusOK, map[string]interface{}{"ignored": msg.Type})
+		return
+	}
+
+	event, err := email.ParseSESEvent(msg.Message)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid SES event")
+		return
+	}
+
+	addresses, reason := event.Suppressions()
+	suppressions := email.NewSuppressionList(config.DB)
+	for _, address := range addresses {
+		if err := suppressions.Add(r.Context(), address, reason, email.ProviderSES, event.Detail()); err != nil {
+			// SNS retries on an error response
+			slog.ErrorContext(r.Context(), "Failed to suppress email address", "event", event.Type(), "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+	}
+
+	found := false
+	if event.Mail.MessageID != "" {
+		found, err = notifications.NewLedger(config.DB).ApplyReceipt(r.Context(), email.ProviderSES, event.Mail.MessageID, notifications.Receipt{
+			Event:      event.ReceiptEvent(),
+			Detail:     event.Detail(),
+			OccurredAt: event.OccurredAt(),
+		})
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Failed to record SES event", "message_id", event.Mail.MessageID, "event", event.Type(), "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"recorded":   found,
+		"suppressed": len(addresses),
+	})
+}
+
+// AdminGetNotificationDeliveries lists the emails and push notifications sent to a user,
+// newest first, with each attempt and provider receipt, for support debugging
+func AdminGetNotificationDeliveries(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+
+	page, err := ParseIntParam(r, "page", 1, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	limit, err := ParseIntParam(r, "limit", DefaultPageSize, MinPageSize, MaxPageSize)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	channel := r.URL.Query().Get("channel")
+	switch channel {
+	case "", model.DeliveryChannelEmail, model.DeliveryChannelPush, model.DeliveryChannelSMS:
+	default:
+		RespondWithValidationError(w, &ValidationError{Field: "channel", Message: "must be email, push or sms", Value: channel})
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", model.DeliveryStatusPending, model.DeliveryStatusSent, model.DeliveryStatusRetrying,
+		model.DeliveryStatusDelivered, model.DeliveryStatusBounced, model.DeliveryStatusFailed:
+	default:
+		RespondWithValidationError(w, &ValidationError{
+			Field:   "status",
+			Message: "must be pending, sent, retrying, delivered, bounced or failed",
+			Value:   status,
+		})
+		return
+	}
+
+	deliveries, total, err := notifications.NewLedger(config.DB).History(r.Context(), userID, channel, status, limit, (page-1)*limit)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing notification deliveries", "user_id", userID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	listing := adminListing{page: page, limit: limit}
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"deliveries": deliveries,
+		"pagination": listing.pagination(total),
+	})
+}
+
+// AdminGetEmailSuppressions lists the addresses no longer emailed because they hard
+// bounced or reported spam, most recently added first. ?search= matches part of the
+// address.
+func AdminGetEmailSuppressions(w http.ResponseWriter, r *http.Request) {
+	page, err := ParseIntParam(r, "page", 1, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	limit, err := ParseIntParam(r, "limit", DefaultPageSize, MinPageSize, MaxPageSize)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	suppressions, total, err := email.NewSuppressionList(config.DB).List(r.Context(), r.URL.Query().Get("search"), limit, (page-1)*limit)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing email suppressions", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	listing := adminListing{page: page, limit: limit}
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"suppressions": suppressions,
+		"pagination":   listing.pagination(total),
+	})
+}
+
+// AdminDeleteEmailSuppression emails an address again, once its owner has fixed the
+// mailbox or asked to hear from GigCo again
+func AdminDeleteEmailSuppression(w http.ResponseWriter, r *http.Request) {
+	address := chi.URLParam(r, "email")
+
+	removed, err := email.NewSuppressionList(config.DB).Remove(r.Context(), address)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error removing email suppression", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to remove email suppression")
+		return
+	}
+	if !removed {
+		respondError(w, http.StatusNotFound, model.ErrCodeNotFound, "Email suppression not found")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Email suppression removed",
+	})
+}
@@ -0,0 +1,218 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/model"
+	"app/internal/notifications"
+	"app/internal/validate"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetNotifications lists the caller's in-app notifications, newest first
+func GetNotifications(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	page, err := ParseIntParam(r, "page", 1, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	limit, err := ParseIntParam(r, "limit", DefaultPageSize, MinPageSize, MaxPageSize)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", model.NotificationStatusUnread, model.NotificationStatusRead, model.NotificationStatusArchived:
+	default:
+		RespondWithValidationError(w, &ValidationError{
+			Field:   "status",
+			Message: "must be unread, read or archived",
+			Value:   status,
+		})
+		return
+	}
+
+	store := notifications.NewStore(config.DB)
+	list, total, err := store.List(r.Context(), userID, status, limit, (page-1)*limit)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing notifications", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	unread, err := store.UnreadCount(r.Context(), userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error counting unread notifications", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	pages := (total + limit - 1) / limit
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"notifications": list,
+		"unread_count":  unread,
+		"pagination": model.Pagination{
+			Page:    page,
+			Limit:   limit,
+			Total:   total,
+			Pages:   pages,
+			HasNext: page < pages,
+			HasPrev: page > 1,
+		},
+	})
+}
+
+// GetUnreadNotificationCount returns the caller's unread notification count, for
+// badge polling
+func GetUnreadNotificationCount(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	unread, err := notifications.NewStore(config.DB).UnreadCount(r.Context(), userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error counting unread notifications", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"unread_count": unread,
+	})
+}
+
+// MarkNotificationRead marks one of the caller's notifications as read
+func MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	notificationID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid notification ID format")
+		return
+	}
+
+	store := notifications.NewStore(config.DB)
+	notification, err := store.MarkRead(r.Context(), userID, notificationID)
+	if err == notifications.ErrNotificationNotFound {
+		RespondWithError(w, http.StatusNotFound, "Notification not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error marking notification read", "notification_id", notificationID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	unread, err := store.UnreadCount(r.Context(), userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error counting unread notifications", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success":      true,
+		"message":      "Notification marked as read",
+		"notification": notification,
+		"unread_count": unread,
+	})
+}
+
+// GetNotificationPreferences returns the caller's email, push and SMS settings for each
+// notification type, and the types that can be sent by SMS
+func GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	prefs, err := notifications.NewPreferences(config.DB).List(r.Context(), userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing notification preferences", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"preferences": prefs,
+		"sms_types":   model.SMSNotificationTypes,
+	})
+}
+
+// UpdateNotificationPreferences changes the caller's settings for the notification types
+// given and returns the settings for every type
+func UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	var req model.NotificationPreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	if err := validateNotificationPreferences(&req); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+
+	store := notifications.NewPreferences(config.DB)
+	if err := store.Update(r.Context(), userID, req.Preferences); err != nil {
+		slog.ErrorContext(r.Context(), "Database error updating notification preferences", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update notification preferences")
+		return
+	}
+	prefs, err := store.List(r.Context(), userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing notification preferences", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success":     true,
+		"message":     "Notification preferences updated",
+		"preferences": prefs,
+	})
+}
+
+// validateNotificationPreferences checks each preference names a known notification
+// type, once, and turns SMS on only for types sent by SMS
+func validateNotificationPreferences(req *model.NotificationPreferencesRequest) error {
+	var v validate.Validator
+	v.Check(len(req.Preferences) > 0, "preferences", "must include at least one notification type")
+	seen := make(map[string]bool, len(req.Preferences))
+	for i, pref := range req.Preferences {
+		field := "preferences[" + strconv.Itoa(i) + "].type"
+		v.Required(field, pref.Type)
+		v.OneOf(field, pref.Type, model.NotificationTypes...)
+		if seen[pref.Type] {
+			v.AddValue(field, "is listed more than once", pref.Type)
+		}
+		seen[pref.Type] = true
+		if pref.SMSEnabled && pref.Type != "" && !model.SupportsSMS(pref.Type) {
+			v.AddValue("preferences["+strconv.Itoa(i)+"].sms_enabled",
+				"SMS is only sent for "+strings.Join(model.SMSNotificationTypes, ", "), pref.Type)
+		}
+	}
+	return v.Err()
+}
@@ -0,0 +1,1322 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"app/internal/audit"
+	"app/internal/auth"
+	"app/internal/availability"
+	"app/internal/currency"
+	"app/internal/email"
+	"app/internal/jobevents"
+	"app/internal/jobquality"
+	"app/internal/middleware"
+	"app/internal/model"
+	"app/internal/openapi"
+	"app/internal/realtime"
+	"app/internal/reputation"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// apiChangelog records the API surface added in each version, newest last. The
+// latest entry is published as the document's info.version.
+var apiChangelog = []openapi.ChangelogEntry{
+	{Version: "1.0.0", Date: "2026-10-16", Changes: []string{
+		"Users, gig workers, jobs, reviews, schedules, transactions and escrow payments",
+		"JWT authentication with role-based access",
+	}},
+	{Version: "1.1.0", Date: "2026-10-16", Changes: []string{
+		"POST /api/v1/waitlist for unlaunched markets",
+		"Admin waitlist, market demand and market launch endpoints",
+	}},
+	{Version: "1.2.0", Date: "2026-10-16", Changes: []string{
+		"Consumer trust signals on job feed and job detail responses",
+	}},
+	{Version: "1.3.0", Date: "2026-10-16", Changes: []string{
+		"POST /api/v1/jobs/{id}/incidents for in-job safety incidents",
+		"Admin incident review endpoints",
+	}},
+	{Version: "1.4.0", Date: "2026-10-16", Changes: []string{
+		"Emergency contacts moved to an encrypted vault and removed from gig worker responses",
+		"Audited break-glass access to emergency contacts",
+	}},
+	{Version: "1.5.0", Date: "2026-10-16", Changes: []string{
+		"Weather advisories and reschedule proposals for outdoor jobs",
+	}},
+	{Version: "1.6.0", Date: "2026-10-16", Changes: []string{
+		"Self-serve account deletion with a reactivation hold",
+	}},
+	{Version: "1.7.0", Date: "2026-10-16", Changes: []string{
+		"Transaction receipts, CSV spend export and QuickBooks/Xero sync",
+	}},
+	{Version: "1.8.0", Date: "2026-10-16", Changes: []string{
+		"Per-job worker expenses, mileage logging and tax summary",
+	}},
+	{Version: "1.9.0", Date: "2026-10-16", Changes: []string{
+		"Mid-job parts requests with consumer approval",
+	}},
+	{Version: "1.10.0", Date: "2026-10-16", Changes: []string{
+		"Job message threads with escalation to support tickets",
+	}},
+	{Version: "1.11.0", Date: "2026-10-16", Changes: []string{
+		"Caller identity is taken from the access token; user_id parameters that name another user are rejected with 403",
+	}},
+	{Version: "1.12.0", Date: "2026-10-16", Changes: []string{
+		"Admin fraud flag review endpoints",
+	}},
+	{Version: "1.13.0", Date: "2026-10-16", Changes: []string{
+		"OpenAPI 3 document served at /openapi.json",
+		"Inline request bodies replaced by named models",
+	}},
+	{Version: "1.14.0", Date: "2026-10-16", Changes: []string{
+		"Stripe accepted as a payment provider alongside Clover; card_token may be a Stripe PaymentMethod ID",
+		"Transactions include payment_provider, provider_charge_id and provider_refund_id",
+	}},
+	{Version: "1.15.0", Date: "2026-10-16", Changes: []string{
+		"GET /api/v1/jobs/{id}/price-breakdown itemizes labor, fees, tax and account credit",
+		"payments/authorize charges the price breakdown total and returns 409 when amount differs",
+	}},
+	{Version: "1.16.0", Date: "2026-10-16", Changes: []string{
+		"In-app notifications with read state and unread counts",
+	}},
+	{Version: "1.17.0", Date: "2026-10-16", Changes: []string{
+		"Admin shadow evaluation report comparing candidate matching and pricing algorithms with production",
+	}},
+	{Version: "1.18.0", Date: "2026-10-16", Changes: []string{
+		"/ws WebSocket pushes job status, offer and payment events",
+	}},
+	{Version: "1.19.0", Date: "2026-10-16", Changes: []string{
+		"Admin job funnel report with per-stage conversion and time in stage",
+	}},
+	{Version: "1.20.0", Date: "2026-10-16", Changes: []string{
+		"Worker payouts settled in batches; GET /api/v1/gigworkers/{id}/payouts and admin settlement batch endpoints",
+	}},
+	{Version: "1.21.0", Date: "2026-10-16", Changes: []string{
+		"POST /api/v1/jobs/{id}/disputes holds a completed job's payment; admin dispute review and refund endpoints",
+	}},
+	{Version: "1.22.0", Date: "2026-10-16", Changes: []string{
+		"Job lifecycle transitions are recorded as events; admin GET /api/v1/jobs/{id}/events returns a job's history",
+		"Conflicting job transitions (accept, start, cancel, offers) return 409 instead of overwriting a concurrent change",
+	}},
+	{Version: "1.23.0", Date: "2026-10-16", Changes: []string{
+		"Admin GET /api/v1/jobs/{id}/workflow returns the job workflow's live state from Temporal",
+	}},
+	{Version: "1.24.0", Date: "2026-10-16", Changes: []string{
+		"POST /api/v1/jobs/{id}/expenses/{expenseId}/receipt uploads a receipt, scanned for malware before it is stored",
+		"Admin GET /api/v1/attachments/{id} and POST /api/v1/attachments/{id}/rescan surface and refresh an attachment's scan status",
+	}},
+	{Version: "1.25.0", Date: "2026-10-16", Changes: []string{
+		"payments/authorize, capture and refund accept an Idempotency-Key header and replay the original result for a repeated key",
+	}},
+	{Version: "1.26.0", Date: "2026-10-16", Changes: []string{
+		"Token-bucket rate limits per client IP, per user on authenticated routes and stricter per IP on login, register and forgot-password; 429 responses carry Retry-After",
+	}},
+	{Version: "1.27.0", Date: "2026-10-16", Changes: []string{
+		"GET /api/v1/jobs/{id}/escrow-timeline lists when a job's payment was authorized, held, charged and refunded",
+	}},
+	{Version: "1.28.0", Date: "2026-10-16", Changes: []string{
+		"auth/forgot-password emails a single-use reset token; auth/reset-password validates it and applies the registration password policy",
+	}},
+	{Version: "1.29.0", Date: "2026-10-16", Changes: []string{
+		"Registration emails a verification token that auth/verify-email validates; email is now optional there",
+		"POST /api/v1/auth/resend-verification, rate limited per client IP",
+	}},
+	{Version: "1.30.0", Date: "2026-10-16", Changes: []string{
+		"Access tokens carry a kid header; signing keys rotate on a schedule with an overlap window so sessions survive",
+		"Admin GET /api/v1/auth/signing-keys and POST /api/v1/auth/signing-keys/rotate",
+	}},
+	{Version: "2.0.0", Date: "2026-10-16", Changes: []string{
+		"Breaking: auth/refresh takes a refresh_token instead of an access token and returns a new refresh_token with each access token",
+		"Login, register and reactivation return a refresh_token; access tokens expire after 15 minutes",
+		"auth/logout revokes the session of the refresh_token given",
+		"GET /api/v1/auth/sessions, DELETE /api/v1/auth/sessions/{id} and POST /api/v1/auth/sessions/revoke-others",
+	}},
+	{Version: "2.1.0", Date: "2026-10-16", Changes: []string{
+		"Attachment download_url points at GET /api/v1/attachments/{id}/download with a token scoped to the attachment",
+		"POST /api/v1/jobs/{id}/review/link submits a review with the scoped token from a review request email",
+	}},
+	{Version: "2.2.0", Date: "2026-10-16", Changes: []string{
+		"POST /api/v1/auth/change-password checks the current password, applies the password policy and revokes other sessions",
+		"Registration, reset-password and change-password can reject passwords found in data breaches (PASSWORD_BREACH_CHECK)",
+	}},
+	{Version: "2.3.0", Date: "2026-10-16", Changes: []string{
+		"Change-password moves from POST /api/v1/auth/change-password to PUT /api/v1/users/me/password and emails the user a security notice",
+	}},
+	{Version: "2.4.0", Date: "2026-10-16", Changes: []string{
+		"POST /api/v1/users/merge to merge duplicate accounts, with a dry run",
+		"GET /api/v1/users/merges account merge audit log",
+	}},
+	{Version: "2.5.0", Date: "2026-10-16", Changes: []string{
+		"GET /api/v1/schedules/occurrences expands recurring schedules into slots",
+		"Invalid recurring patterns and booked slots that overlap the worker's other bookings are rejected; job acceptance and offers return 409 on conflicts",
+	}},
+	{Version: "2.6.0", Date: "2026-10-16", Changes: []string{
+		"Gig workers are gig_worker accounts with a worker profile; gig worker ids are now user ids",
+		"POST /api/v1/gigworkers/create requires a gig_worker token and creates the caller's own profile",
+		"Account status and verification fields on PUT /api/v1/gigworkers/{id} are admin-only",
+	}},
+	{Version: "2.7.0", Date: "2026-10-16", Changes: []string{
+		"Worker applications: POST /api/v1/worker-applications, GET /api/v1/worker-applications/mine, and admin screening under /api/v1/worker-applications",
+		"POST /api/v1/gigworkers/create is removed; accounts become gig workers when their application is approved",
+		"Only gig workers with a verified profile are matched to jobs",
+	}},
+	{Version: "2.8.0", Date: "2026-10-16", Changes: []string{
+		"GET /api/v1/gigworkers/{id}/availability returns free/busy windows from schedules, accepted jobs and blackout dates",
+		"Blackout dates under /api/v1/gigworkers/{id}/blackout-dates",
+		"Matching skips workers who are busy at the job's time, and unscheduled jobs are booked into the worker's first free slot",
+	}},
+	{Version: "2.9.0", Date: "2026-10-16", Changes: []string{
+		"Admin dashboard under /api/v1/admin: user, job and transaction search, verification and dispute queues, and platform metrics",
+		"Admin list endpoints export CSV with format=csv",
+	}},
+	{Version: "2.10.0", Date: "2026-10-16", Changes: []string{
+		"Job postings are checked for the fields their category needs (exact address, access instructions, schedule) and rejected with 422 JOB_INCOMPLETE when one is missing",
+		"Jobs carry access_instructions and a 0-100 completeness_score; POST /api/v1/jobs/completeness and GET /api/v1/jobs/{id}/completeness report what is missing",
+		"The available jobs feed lists low-completeness postings after the rest",
+	}},
+	{Version: "2.11.0", Date: "2026-10-16", Changes: []string{
+		"Job status changes, payment authorizations, captures and refunds, and profile edits are recorded to an append-only audit log with the fields that changed",
+		"State-changing admin requests are audited with their route and response status",
+		"GET /api/v1/admin/audit-events searches the audit log by actor, action, entity and date",
+	}},
+	{Version: "2.12.0", Date: "2026-10-16", Changes: []string{
+		"GET /api/v1/admin/overview returns GMV, take rate, active jobs by status, fill rate, new signups, payment failure rate and open disputes for a date range in one response",
+	}},
+	{Version: "2.13.0", Date: "2026-10-16", Changes: []string{
+		"Every response carries an X-Request-ID header; a valid X-Request-ID sent by the client is reused",
+		"Error responses include the request_id to quote when reporting a problem",
+	}},
+	{Version: "2.14.0", Date: "2026-10-16", Changes: []string{
+		"GET and POST /api/v1/jobs/{id}/survey return and answer the one-question CSAT or NPS survey sent when a job closes",
+		"GET /api/v1/analytics/surveys aggregates survey scores by job category and market",
+	}},
+	{Version: "2.15.0", Date: "2026-10-16", Changes: []string{
+		"GET /healthz and GET /readyz are liveness and readiness probes; readiness checks the database and Temporal and fails while the server drains for shutdown",
+	}},
+	{Version: "2.16.0", Date: "2026-10-16", Changes: []string{
+		"GET /public/stats returns cached marketplace aggregates (jobs completed this month, average worker rating, active markets) without authentication",
+	}},
+	{Version: "2.17.0", Date: "2026-10-16", Changes: []string{
+		"POST /api/v1/webhooks/sendgrid records SendGrid delivery receipts (delivered, bounced, dropped) against sent emails",
+		"GET /api/v1/admin/users/{id}/notification-deliveries lists a user's email and push deliveries with their attempts and receipts",
+	}},
+	{Version: "2.18.0", Date: "2026-10-16", Changes: []string{
+		"Create and update endpoints return every invalid field at once as a JSON VALIDATION_ERROR with per-field messages in details, instead of a plain-text message for the first problem",
+	}},
+	{Version: "2.19.0", Date: "2026-10-16", Changes: []string{
+		"Every error response, including authentication, role and rate limit rejections, is a JSON ErrorResponse with a machine-readable code; plain-text errors are gone",
+		"Domain error codes such as JOB_NOT_FOUND, INVALID_JOB_STATUS, INVALID_CREDENTIALS and PAYMENT_DECLINED identify specific failures",
+		"A card the payment provider refuses during authorization returns 402 PAYMENT_DECLINED instead of 500",
+	}},
+	{Version: "2.20.0", Date: "2026-10-16", Changes: []string{
+		"GET /api/v1/users/me/reputation-export returns the caller's ratings, review history, counts and tenure as an Ed25519-signed document",
+		"GET /.well-known/reputation-keys.json publishes the public keys reputation exports are verified with",
+	}},
+	{Version: "2.21.0", Date: "2026-10-16", Changes: []string{
+		"Emails and push notifications carry signed, expiring deep links through GET /l/{token}, which counts clicks and redirects to the app",
+		"POST /api/v1/jobs/{id}/accept-offer/link accepts a job offer with the single-use link from the offer notification",
+		"Password reset emails carry a single-use reset link; POST /api/v1/auth/reset-password accepts its token as well as older reset tokens",
+		"GET /api/v1/admin/links/stats reports deep link clicks, use and expiry by action",
+	}},
+	{Version: "2.22.0", Date: "2026-10-16", Changes: []string{
+		"Consumers favorite workers at /api/v1/users/me/favorite-workers and rebook one with preferred_worker_id when posting a job",
+		"Workers opt into auto-accepting rebookings with a price floor at PUT /api/v1/gigworkers/me/auto-accept; an auto-accepted job skips the offer and is scheduled at once",
+		"Either side can turn auto-accept off for one pair: PUT /api/v1/users/me/favorite-workers/{workerId} or PUT /api/v1/gigworkers/me/auto-accept/consumers/{consumerId}",
+	}},
+	{Version: "2.23.0", Date: "2026-10-16", Changes: []string{
+		"Accepted jobs are offered to the top matched workers at once instead of being assigned to one; the first to accept gets the job",
+		"Workers list their offers at GET /api/v1/gigworkers/me/offers and answer them at POST /api/v1/gigworkers/me/offers/{id}/accept or /decline",
+	}},
+	{Version: "2.24.0", Date: "2026-10-16", Changes: []string{
+		"Consumers can tip the worker of a completed job at POST /api/v1/jobs/{id}/tip",
+		"GET /api/v1/jobs/{id}/payment-summary returns total_tips, which worker_payment now includes",
+	}},
+	{Version: "2.25.0", Date: "2026-10-16", Changes: []string{
+		"Admins manage platform fee rules by job category, worker fee tier and promotional window at /api/v1/admin/fee-rules",
+		"GET /api/v1/admin/fee-rules/preview shows the effective fees for a job; PUT /api/v1/admin/gigworkers/{id}/fee-tier sets a worker's tier",
+		"Price quotes return fee_rule_id when a fee rule set the platform fee",
+	}},
+	{Version: "2.26.0", Date: "2026-10-16", Changes: []string{
+		"Jobs take a currency from GET /api/v1/currencies, defaulting to USD, and are priced, authorized and paid out in it",
+		"GET /api/v1/jobs/{id}/price-breakdown?display_currency= converts the quote for display at the configured exchange rate",
+		"Authorize, capture and refund accept an optional currency and return 422 CURRENCY_MISMATCH when it differs from the payment's",
+	}},
+	{Version: "2.27.0", Date: "2026-10-16", Changes: []string{
+		"Clover requests are paced and retried after a 429's Retry-After; payments still rate limited return 503 SERVICE_UNAVAILABLE",
+		"GET /metrics reports each payment provider's request queue under payment_providers",
+	}},
+	{Version: "2.28.0", Date: "2026-10-16", Changes: []string{
+		"Admins refund many captured payments at once at /api/v1/admin/refund-batches, by transaction IDs or a filter, with per-item results and a CSV report",
+	}},
+	{Version: "2.29.0", Date: "2026-10-16", Changes: []string{
+		"GET /api/v1/payments/{id}/receipt?format=pdf downloads a PDF receipt for the consumer or earnings statement for the worker",
+		"The receipt and earnings statement are emailed as PDF attachments when a payment is captured",
+	}},
+	{Version: "2.30.0", Date: "2026-10-16", Changes: []string{
+		"Workers see their earnings for a tax year by month, with fees withheld, at GET /api/v1/gigworkers/me/earnings",
+		"Admins list the workers to issue a Form 1099-NEC at GET /api/v1/admin/tax-reports/1099-nec, with ?format=csv for filing",
+	}},
+	{Version: "2.31.0", Date: "2026-10-16", Changes: []string{
+		"Gig workers and applicants upload IDs, licenses and insurance certificates to a signed URL from POST /api/v1/gigworkers/me/documents/uploads, then submit them at POST /api/v1/gigworkers/me/documents",
+		"Admins review verification documents at GET /api/v1/admin/worker-documents and POST /api/v1/admin/worker-documents/{id}/review",
+	}},
+	{Version: "2.32.0", Date: "2026-10-16", Changes: []string{
+		"Payments for jobs in markets routed to another merchant account are authorized, captured and refunded through that account",
+		"Transactions include merchant_id, the provider merchant account they were processed for",
+	}},
+	{Version: "2.33.0", Date: "2026-10-16", Changes: []string{
+		"Users set a profile photo at PUT /api/v1/users/me/avatar; job responses include avatar_url and avatar_thumbnail_url for the consumer and worker",
+		"POST /api/v1/jobs/{id}/photos adds posting photos from the consumer and before and after photos from the assigned worker; jobs include them as photos",
+		"Photos must be at least 128 (avatars) or 320 (job photos) pixels on their shorter side, and JPEG and PNG photos get a thumbnail at the download link with size=thumbnail",
+	}},
+	{Version: "2.34.0", Date: "2026-10-16", Changes: []string{
+		"Receipt line items include id for materials and expense lines",
+		"Consumers dispute one receipt line at POST /api/v1/payments/{id}/line-item-disputes; the worker accepts, refunding the disputed amount, or contests, opening a job dispute, at POST /api/v1/line-item-disputes/{id}/respond",
+	}},
+	{Version: "2.35.0", Date: "2026-10-16", Changes: []string{
+		"GET and PUT /api/v1/users/me/notification-preferences read and change whether each notification type is sent by email, push and SMS",
+		"Notifications are emailed, pushed to the FCM topic user_<id> and texted to verified phones as the user's preferences allow",
+		"Notification delivery history takes channel=sms",
+	}},
+	{Version: "2.36.0", Date: "2026-10-16", Changes: []string{
+		"GET /api/v1/gigworkers/me/conflicts lists the bookings overlapping a slot and, for a job, the neighbouring jobs too far away to reach in time",
+		"Accepting a job offer for a scheduled job that conflicts with the worker's calendar returns 409 SCHEDULE_CONFLICT with the conflicts",
+	}},
+	{Version: "2.37.0", Date: "2026-10-16", Changes: []string{
+		"Workers snooze a job offer for a few minutes at POST /api/v1/gigworkers/me/offers/{id}/snooze and are reminded before it expires; offers have a snoozed status",
+		"Declining an offer takes an optional reason (too_far, pay_too_low, schedule_conflict or other) and note",
+		"GET /api/v1/analytics/offer-declines reports offer accept rates and decline reasons by job category",
+	}},
+	{Version: "2.38.0", Date: "2026-10-16", Changes: []string{
+		"Users verify their phone by SMS code at POST /api/v1/users/me/phone/verification and /verify; changing the phone number clears phone_verified",
+		"SMS notifications are limited to job offers and payments, listed as sms_types in GET /api/v1/users/me/notification-preferences",
+	}},
+	{Version: "2.39.0", Date: "2026-10-16", Changes: []string{
+		"Apps register FCM tokens at POST /api/v1/users/me/devices and remove them at DELETE /api/v1/users/me/devices/{id}",
+		"Push notifications go to each of the user's registered devices, falling back to the user_<id> topic; tokens FCM rejects are disabled",
+	}},
+	{Version: "2.40.0", Date: "2026-10-16", Changes: []string{
+		"Assigned workers hand off scheduled or in-progress jobs at POST /api/v1/jobs/{id}/handoffs; consumers approve or decline at /handoffs/{handoffId}/approve and /decline",
+		"An approved hand-off pays the outgoing worker for their completed portion and re-offers the rest of the job urgently; the job's final payment is reduced accordingly",
+	}},
+	{Version: "2.41.0", Date: "2026-10-16", Changes: []string{
+		"GET /api/v1/offers/poll long-polls for the caller's realtime events where WebSockets are blocked, holding up to 25 seconds",
+		"Realtime events carry an id; long-poll clients pass the last one as after",
+	}},
+	{Version: "2.42.0", Date: "2026-10-16", Changes: []string{
+		"Emails are rendered from built-in templates, with their own for job offers, accepted and completed jobs, payment receipts and review requests",
+		"Admins list the email templates at GET /api/v1/admin/email-templates and preview one with sample data at /api/v1/admin/email-templates/{name}/preview",
+	}},
+	{Version: "2.43.0", Date: "2026-10-16", Changes: []string{
+		"Email can be sent through Amazon SES as well as SendGrid, chosen with EMAIL_PROVIDER, and fails over to EMAIL_FALLBACK_PROVIDER when the primary provider returns a server error",
+		"POST /api/v1/webhooks/ses receives SES bounce, complaint and delivery notifications through SNS",
+		"Addresses that hard bounce or report spam through either provider are no longer emailed; admins list them at GET /api/v1/admin/email-suppressions and remove one with DELETE /api/v1/admin/email-suppressions/{email}",
+	}},
+	{Version: "2.44.0", Date: "2026-10-16", Changes: []string{
+		"Admin list endpoints export newline-delimited JSON with format=ndjson; CSV and NDJSON exports are streamed as rows are read instead of built in memory",
+		"Job and gig worker lists are encoded one item at a time; an empty list is [] rather than null",
+	}},
+	{Version: "2.45.0", Date: "2026-10-16", Changes: []string{
+		"Database queries are timed; slow queries are logged with their parameters redacted, and GET /api/v1/admin/query-stats lists the queries taking the most time",
+		"With DB_EXPLAIN_SLOW_QUERIES=true, EXPLAIN ANALYZE plans of repeatedly slow queries are captured and listed at GET /api/v1/admin/query-diagnostics",
+	}},
+	{Version: "2.46.0", Date: "2026-10-16", Changes: []string{
+		"Schema migrations are embedded in the binaries and applied with cmd/migrate, or on startup with MIGRATE_ON_STARTUP=true",
+		"GET /health and the readiness probes report schema_version, the newest migration applied; readiness lists pending migrations under checks.schema without failing",
+	}},
+	{Version: "2.47.0", Date: "2026-10-16", Changes: []string{
+		"Migration 0002_add_list_indexes adds composite indexes for the job, transaction and user lists filtered by status, category, consumer, worker or role and sorted by created_at",
+		"GET /api/v1/admin/index-advisories reports which list queries no index serves; the API logs them on startup and DB_REQUIRE_INDEXES=true refuses to start",
+	}},
+	{Version: "2.48.0", Date: "2026-10-16", Changes: []string{
+		"POST /api/v1/jobs/{id}/accept, /send-offer and /api/v1/gigworkers/me/offers/{id}/accept check and update the job in one transaction with its row locked; concurrent requests get 409",
+		"POST /api/v1/jobs/{id}/accept only accepts posted jobs without a worker; other statuses return 409 with the current status",
+	}},
+	{Version: "2.49.0", Date: "2026-10-16", Changes: []string{
+		"DELETE /api/v1/users/me requests account deletion, like POST /api/v1/account/deletion",
+		"Deleting an account emails the user a zip export of their data before erasure; erasure also anonymizes review comments, job messages, support ticket descriptions and the legacy gig worker profile",
+		"Deletion requests, reactivations, data exports and erasures are recorded in the audit log as account.deletion_requested, account.reactivated, account.data_exported and account.erased",
+	}},
+	{Version: "2.50.0", Date: "2026-10-16", Changes: []string{
+		"POST /api/v1/gigworkers/me/heartbeat records that a gig worker has the app open; migration 0004_add_worker_presence stores the latest heartbeat",
+		"Gig workers and favorite workers include presence (online, recently_active or offline) and online_now",
+		"Job matching uses presence_v1: rating plus a boost for workers online now or active within the hour, who are also considered first",
+	}},
+	{Version: "2.51.0", Date: "2026-10-16", Changes: []string{
+		"GET /api/v1/users/me/export?format=json|csv exports the caller's profile, jobs, transactions, reviews and messages as a zip, built in the background and emailed as a link valid for 7 days; migration 0005_add_data_exports tracks exports",
+		"Erasing an account also deletes the data exports the user requested",
+	}},
+	{Version: "2.52.0", Date: "2026-10-16", Changes: []string{
+		"GET /api/v1/admin/transactions/{id}/events lists a transaction's payment events oldest first with error codes, the acting user and a provider response summary; card numbers are masked and security codes and expiry dates left out",
+	}},
+	{Version: "2.53.0", Date: "2026-10-16", Changes: []string{
+		"Payments captured automatically after the approval window are recorded in the audit log as payment.auto_captured, and the worker is notified as well as the consumer",
+	}},
+	{Version: "2.54.0", Date: "2026-10-16", Changes: []string{
+		"Consumers save job templates at POST /api/v1/job-templates, from the posting fields or from one of their jobs, list them at GET /api/v1/job-templates and delete them at DELETE /api/v1/job-templates/{id}",
+		"POST /api/v1/jobs/from-template/{id} posts a job from a template at the time given",
+		"POST /api/v1/jobs/{id}/rebook posts a finished job again with the same worker, scheduled at the same weekday and time or the worker's first free slot",
+		"Data exports include the caller's job templates, and erasing an account deletes them; migration 0006_add_job_templates creates the table",
+	}},
+}
+
+// documentExpiresOnExample is a verification document's expiry date
+var documentExpiresOnExample = "2027-06-30"
+
+// jobCompletenessExample is a completeness report listing one missing field
+var jobCompletenessExample = jobquality.Report{Missing: []jobquality.Missing{{}}}
+
+// successResponse is the envelope returned by handlers that only acknowledge an action
+var successResponse = openapi.Fields{"success": true, "message": ""}
+
+// withSuccess extends the success envelope with extra fields
+func withSuccess(fields openapi.Fields) openapi.Fields {
+	merged := openapi.Fields{}
+	for name, example := range successResponse {
+		merged[name] = example
+	}
+	for name, example := range fields {
+		merged[name] = example
+	}
+	return merged
+}
+
+var (
+	pageParams = []openapi.Param{
+		{Name: "page", Example: 0, Description: "Page number, starting at 1"},
+		{Name: "limit", Example: 0, Description: "Results per page"},
+	}
+	paginated = model.Pagination{}
+)
+
+// withPaging prepends the standard page and limit parameters
+func withPaging(params ...openapi.Param) []openapi.Param {
+	return append(append([]openapi.Param{}, pageParams...), params...)
+}
+
+// adminCSVNote describes the exports shared by the admin list endpoints
+const adminCSVNote = " With format=csv or format=ndjson the first 10000 matching rows are streamed as a CSV or newline-delimited JSON download instead of a page."
+
+// withAdminListing adds the paging and format parameters of the admin list endpoints
+func withAdminListing(params ...openapi.Param) []openapi.Param {
+	return withPaging(append([]openapi.Param{{Name: "format", Example: "json", Description: "json, csv or ndjson"}}, params...)...)
+}
+
+// OpenAPIRoutes documents the request and response models of every registered route.
+// Routes registered in the handler package without an entry here fail the coverage test.
+func OpenAPIRoutes() []openapi.Route {
+	return []openapi.Route{
+		// Health
+		{Method: http.MethodGet, Path: "/health", Tag: "Health", Summary: "Basic health check",
+			Response: openapi.Fields{"status": "", "database": "", "schema_version": int64(0), "timestamp": time.Time{}}},
+		{Method: http.MethodGet, Path: "/ready", Tag: "Health", Summary: "Readiness probe", Response: HealthStatus{}},
+		{Method: http.MethodGet, Path: "/live", Tag: "Health", Summary: "Liveness probe", Response: HealthStatus{}},
+		{Method: http.MethodGet, Path: "/healthz", Tag: "Health", OperationID: "Healthz", Summary: "Liveness probe", Response: HealthStatus{}},
+		{Method: http.MethodGet, Path: "/readyz", Tag: "Health", OperationID: "Readyz", Summary: "Readiness probe; fails while the server drains for shutdown", Response: HealthStatus{}},
+		{Method: http.MethodGet, Path: "/metrics", Tag: "Health", Summary: "Runtime metrics",
+			Description: "Runtime and memory statistics, and under payment_providers each payment provider's request queue: requests queued now, requests sent, 429 responses, average and longest queue wait, and any Retry-After pause.",
+			Response:    map[string]interface{}{}},
+		{Method: http.MethodGet, Path: "/openapi.json", Tag: "Health", Summary: "This OpenAPI document", Response: &openapi.Schema{Type: "object"}},
+		{Method: http.MethodGet, Path: "/", Hidden: true},
+		{Method: http.MethodGet, Path: "/email-submit", Hidden: true},
+		{Method: http.MethodGet, Path: "/swagger/*", Hidden: true},
+		{Method: http.MethodGet, Path: "/ws", Hidden: true},      // WebSocket upgrade, documented in API_REFERENCE.md
+		{Method: http.MethodGet, Path: "/files/*", Hidden: true}, // Local storage signed downloads, documented in API_REFERENCE.md
+		{Method: http.MethodPut, Path: "/files/*", Hidden: true}, // Local storage signed uploads, documented in API_REFERENCE.md
+
+		// Authentication
+		{Method: http.MethodPost, Path: "/api/v1/auth/register", Tag: "Auth", Summary: "Register a new user",
+			Request: RegisterRequest{}, Response: RegisterResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/api/v1/auth/login", Tag: "Auth", Summary: "Log in and receive an access token",
+			Request: LoginRequest{}, Response: LoginResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/auth/logout", Tag: "Auth", Summary: "Log out",
+			Description: "Revokes the session of the refresh token given. Access tokens already issued stay valid until they expire.",
+			Request:     LogoutRequest{}, Response: successResponse},
+		{Method: http.MethodPost, Path: "/api/v1/auth/refresh", Tag: "Auth", Summary: "Exchange a refresh token for new tokens",
+			Description: "Refresh tokens work once; each exchange returns a new one. Presenting a used refresh token revokes its session.",
+			Request:     RefreshTokenRequest{}, Response: openapi.Fields{"success": true, "token": "", "refresh_token": ""}},
+		{Method: http.MethodPost, Path: "/api/v1/auth/verify-email", Tag: "Auth", Summary: "Verify an email address",
+			Description: "Tokens are emailed at registration, work once and expire after 24 hours; an invalid, used or expired token returns 400.",
+			Request:     VerifyEmailRequest{}, Response: successResponse},
+		{Method: http.MethodPost, Path: "/api/v1/auth/resend-verification", Tag: "Auth", Summary: "Send a new verification email",
+			Description: "Always succeeds so it does not reveal which emails have accounts. A new link supersedes earlier ones.",
+			Request:     ResendVerificationRequest{}, Response: successResponse},
+		{Method: http.MethodPost, Path: "/api/v1/auth/forgot-password", Tag: "Auth", Summary: "Send a password reset email",
+			Description: "Always succeeds so it does not reveal which emails have accounts. A new request supersedes earlier links.",
+			Request:     ForgotPasswordRequest{}, Response: successResponse},
+		{Method: http.MethodPost, Path: "/api/v1/auth/reset-password", Tag: "Auth", Summary: "Reset a password with an emailed token",
+			Description: "Tokens work once and expire after 30 minutes; an invalid, used or expired token returns 400.",
+			Request:     ResetPasswordRequest{}, Response: successResponse},
+		{Method: http.MethodGet, Path: "/api/v1/auth/signing-keys", Tag: "Auth", Summary: "List JWT signing keys",
+			Response: openapi.Fields{"keys": []auth.SigningKey{}}},
+		{Method: http.MethodPost, Path: "/api/v1/auth/signing-keys/rotate", Tag: "Auth", Summary: "Rotate the JWT signing key",
+			Description: "The new key signs after a propagation delay; replaced keys keep validating for JWT_KEY_OVERLAP_HOURS. Returns 503 without VAULT_ENCRYPTION_KEY.",
+			Response:    auth.SigningKey{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/api/v1/auth/sessions", Tag: "Auth", Summary: "List the caller's login sessions",
+			Response: openapi.Fields{"sessions": []model.UserSession{}}},
+		{Method: http.MethodDelete, Path: "/api/v1/auth/sessions/{id}", Tag: "Auth", Summary: "Revoke one of the caller's sessions",
+			Description: "The session's refresh token stops working at once; its access tokens expire within 15 minutes.",
+			Response:    successResponse},
+		{Method: http.MethodPost, Path: "/api/v1/auth/sessions/revoke-others", Tag: "Auth", Summary: "Revoke the caller's other sessions",
+			Response: openapi.Fields{"success": true, "revoked": 0}},
+
+		// Account
+		{Method: http.MethodPost, Path: "/api/v1/account/reactivate", Tag: "Account", Summary: "Reactivate an account during the deletion hold",
+			Request: model.AccountReactivationRequest{}, Response: withSuccess(openapi.Fields{"token": ""})},
+		{Method: http.MethodPost, Path: "/api/v1/account/deletion", Tag: "Account", Summary: "Request account deletion",
+			Request: model.AccountDeletionBody{}, Response: withSuccess(openapi.Fields{"purge_after": time.Time{}}), Status: http.StatusAccepted},
+		{Method: http.MethodDelete, Path: "/api/v1/users/me", Tag: "Account", OperationID: "DeleteMyAccount", Summary: "Delete the caller's account",
+			Description: "Same as POST /api/v1/account/deletion. The caller confirms with their password and the account is deactivated at once. Before the hold ends they are emailed a link to a zip of their profile, jobs, transactions, reviews and messages; when it ends their personal data is erased and anonymized. Reactivating during the hold cancels both.",
+			Request:     model.AccountDeletionBody{}, Response: withSuccess(openapi.Fields{"purge_after": time.Time{}}), Status: http.StatusAccepted},
+
+		// Users
+		{Method: http.MethodGet, Path: "/api/v1/customers/{id}", Tag: "Users", Summary: "Get a customer", Response: model.User{}},
+		{Method: http.MethodGet, Path: "/api/v1/users/profile", Tag: "Users", Summary: "Get the caller's profile",
+			Query: []openapi.Param{{Name: "user_id", Example: 0, Description: "Must match the caller when given"}}, Response: model.User{}},
+		{Method: http.MethodPut, Path: "/api/v1/users/profile", Tag: "Users", Summary: "Update the caller's profile",
+			Query:   []openapi.Param{{Name: "user_id", Example: 0, Description: "Must match the caller when given"}},
+			Request: model.UserProfileUpdateRequest{}, Response: successResponse},
+		{Method: http.MethodGet, Path: "/api/v1/users/me/export", Tag: "Users", Summary: "Export the caller's data",
+			Description: "Returns the caller's latest export in the format (json or csv, default json) that is pending or ready, otherwise starts one. A background workflow builds a zip of their profile, jobs, job templates, transactions, reviews and messages, one file per section, and emails a download link. Pending exports return 202; ready ones return 200 with download_url, valid until expires_at, 7 days after the export was built, when the archive is deleted.",
+			Query:       []openapi.Param{{Name: "format", Example: "", Description: "json or csv"}},
+			Response:    openapi.Fields{"message": "", "export": model.DataExport{}}},
+		{Method: http.MethodGet, Path: "/api/v1/users/me/reputation-export", Tag: "Users", Summary: "Export the caller's signed reputation",
+			Description: "Returns the caller's public ratings, review history (most recent 500, without reviewer names), activity counts and tenure, with signature: an EdDSA JWT whose reputation claim is the same document. Third parties verify it with the key key_id from the key set at public_key_url.",
+			Response:    model.ReputationExport{Reputation: model.ReputationDocument{Reviews: []model.ReputationReview{{}}}}},
+		{Method: http.MethodGet, Path: "/api/v1/users/me/favorite-workers", Tag: "Users", Summary: "List the caller's favorite workers",
+			Description: "Newest first. worker_auto_accepts reports whether the worker auto-accepts the caller's rebookings at or above min_price.",
+			Response:    openapi.Fields{"favorites": []model.FavoriteWorker{{}}}},
+		{Method: http.MethodPost, Path: "/api/v1/users/me/favorite-workers", Tag: "Users", Summary: "Favorite a worker",
+			Description: "Returns 409 when the worker is already a favorite. auto_accept defaults to true.",
+			Request:     model.FavoriteWorkerRequest{}, Response: model.FavoriteWorker{}, Status: http.StatusCreated},
+		{Method: http.MethodPut, Path: "/api/v1/users/me/favorite-workers/{workerId}", Tag: "Users", Summary: "Turn auto-accept on or off for a favorite worker",
+			Request: model.AutoAcceptPairRequest{}, Response: model.FavoriteWorker{}},
+		{Method: http.MethodDelete, Path: "/api/v1/users/me/favorite-workers/{workerId}", Tag: "Users", Summary: "Unfavorite a worker", Response: successResponse},
+		{Method: http.MethodGet, Path: "/api/v1/job-templates", Tag: "Jobs", Summary: "List the caller's job templates",
+			Description: "Ordered by name.",
+			Response:    openapi.Fields{"templates": []model.JobTemplate{{}}}},
+		{Method: http.MethodPost, Path: "/api/v1/job-templates", Tag: "Jobs", Summary: "Save a job template",
+			Description: "Saves the posting fields given, or with job_id copies them from one of the caller's jobs. Templates have no schedule. Names are unique per consumer; a repeated name returns 409. preferred_worker_id must be a favorite worker.",
+			Request:     model.JobTemplateCreateRequest{}, Response: model.JobTemplate{}, Status: http.StatusCreated},
+		{Method: http.MethodDelete, Path: "/api/v1/job-templates/{id}", Tag: "Jobs", Summary: "Delete a job template", Response: successResponse},
+		{Method: http.MethodPut, Path: "/api/v1/users/me/password", Tag: "Users", Summary: "Change the caller's password",
+			Description: "Returns 401 when current_password is wrong and 400 when the new password fails the password policy. The caller's other sessions are revoked and they are emailed a security notice.",
+			Request:     ChangePasswordRequest{}, Response: successResponse},
+		{Method: http.MethodGet, Path: "/api/v1/users/{id}", Tag: "Users", Summary: "Get a user", Response: model.User{}},
+		{Method: http.MethodPost, Path: "/api/v1/users/create", Tag: "Users", Summary: "Create a user",
+			Request: model.User{}, Response: model.User{}, Status: http.StatusCreated},
+		{Method: http.MethodPut, Path: "/api/v1/users/{id}", Tag: "Users", Summary: "Update a user",
+			Request: model.UserUpdateRequest{}, Response: successResponse},
+		{Method: http.MethodDelete, Path: "/api/v1/users/{id}", Tag: "Users", Summary: "Deactivate a user", Response: successResponse},
+		{Method: http.MethodPost, Path: "/api/v1/users/merge", Tag: "Users", Summary: "Merge a duplicate account",
+			Description: "Moves the source account's jobs, reviews, transactions, payment methods, notifications and account credit to the target account, then deactivates the source and ends its sessions. With dry_run the changes are rolled back and only reported. Merges and dry runs are recorded in the merge audit log.",
+			Request:     model.AccountMergeRequest{},
+			Response:    openapi.Fields{"success": true, "message": "", "merge": model.AccountMerge{}}},
+		{Method: http.MethodGet, Path: "/api/v1/users/merges", Tag: "Users", Summary: "Account merge audit log",
+			Query:    withPaging(openapi.Param{Name: "user_id", Example: 0, Description: "Merges where the user was the source or target"}),
+			Response: openapi.Fields{"merges": []model.AccountMerge{}, "pagination": paginated}},
+
+		// Admin dashboard
+		{Method: http.MethodGet, Path: "/api/v1/admin/users", Tag: "Admin", Summary: "Search users",
+			Description: "Updates and deactivation use PUT and DELETE /api/v1/users/{id}." + adminCSVNote,
+			Query: withAdminListing(
+				openapi.Param{Name: "role", Example: "consumer"},
+				openapi.Param{Name: "is_active", Example: true},
+				openapi.Param{Name: "q", Example: "", Description: "Name or email contains"},
+				openapi.Param{Name: "from", Example: "2026-01-01", Description: "Signed up on or after"},
+				openapi.Param{Name: "to", Example: "2026-01-31", Description: "Signed up on or before"},
+			),
+			Response: openapi.Fields{"users": []model.AdminUser{}, "pagination": paginated}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/jobs", Tag: "Admin", Summary: "Job oversight",
+			Description: "All jobs, newest first." + adminCSVNote,
+			Query: withAdminListing(
+				openapi.Param{Name: "status", Example: "posted"},
+				openapi.Param{Name: "category", Example: ""},
+				openapi.Param{Name: "consumer_id", Example: 0},
+				openapi.Param{Name: "worker_id", Example: 0},
+				openapi.Param{Name: "q", Example: "", Description: "Title contains"},
+				openapi.Param{Name: "from", Example: "2026-01-01", Description: "Created on or after"},
+				openapi.Param{Name: "to", Example: "2026-01-31", Description: "Created on or before"},
+			),
+			Response: openapi.Fields{"jobs": []model.AdminJob{}, "pagination": paginated}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/transactions", Tag: "Admin", Summary: "Search transactions",
+			Description: "Newest first." + adminCSVNote,
+			Query: withAdminListing(
+				openapi.Param{Name: "status", Example: "completed"},
+				openapi.Param{Name: "provider", Example: "stripe"},
+				openapi.Param{Name: "user_id", Example: 0, Description: "Consumer or worker"},
+				openapi.Param{Name: "job_id", Example: 0},
+				openapi.Param{Name: "min_amount", Example: 0.0},
+				openapi.Param{Name: "max_amount", Example: 0.0},
+				openapi.Param{Name: "q", Example: "", Description: "Transaction reference or provider charge id"},
+				openapi.Param{Name: "from", Example: "2026-01-01", Description: "Created on or after"},
+				openapi.Param{Name: "to", Example: "2026-01-31", Description: "Created on or before"},
+			),
+			Response: openapi.Fields{"transactions": []model.AdminTransaction{}, "pagination": paginated}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/transactions/{id}/events", Tag: "Admin", Summary: "A transaction's payment events",
+			Description: "Every provider call recorded on the transaction, oldest first: event type and status, error code and message, who made the request, and a summary of the provider's response (status, amounts, codes and the card's brand and last four). Card numbers in messages are masked to their last four digits; security codes and expiry dates are never returned.",
+			Response: openapi.Fields{
+				"transaction": openapi.Fields{"id": 0, "uuid": "", "job_id": 0, "amount": 0.0, "currency": "USD", "status": "", "payment_provider": "", "created_at": time.Time{}},
+				"events":      []model.AdminPaymentEvent{{Actor: &model.PaymentEventActor{}}},
+			}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/verification-queue", Tag: "Admin", Summary: "Worker applications awaiting screening",
+			Description: "Oldest first; decide with POST /api/v1/worker-applications/{id}/status." + adminCSVNote,
+			Query:       withAdminListing(openapi.Param{Name: "status", Example: "background_check"}),
+			Response:    openapi.Fields{"applications": []model.WorkerApplication{}, "pagination": paginated}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/dispute-queue", Tag: "Admin", Summary: "Unresolved disputes",
+			Description: "Open and under-review disputes, oldest first; act on them with PUT /api/v1/disputes/{id}." + adminCSVNote,
+			Query:       withAdminListing(openapi.Param{Name: "unassigned", Example: true}),
+			Response:    openapi.Fields{"disputes": []model.AdminDispute{}, "pagination": paginated}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/metrics", Tag: "Admin", Summary: "Platform metrics",
+			Description: "Jobs by status, GMV, platform fees and take rate; the range defaults to the last 30 days and excludes to. format=csv returns metric,value rows.",
+			Query: []openapi.Param{
+				{Name: "from", Example: "2026-01-01"},
+				{Name: "to", Example: "2026-02-01"},
+				{Name: "format", Example: "json", Description: "json or csv"},
+			},
+			Response: model.PlatformMetrics{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/overview", Tag: "Admin", Summary: "Ops dashboard overview",
+			Description: "GMV, take rate, fill rate, signups and payment failure rate for a range of UTC days from hourly rollups, with current active jobs and open disputes. The range defaults to the last 30 days including today and excludes to.",
+			Query: []openapi.Param{
+				{Name: "from", Example: "2026-01-01"},
+				{Name: "to", Example: "2026-02-01"},
+			},
+			Response: model.AdminOverview{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/audit-events", Tag: "Admin", Summary: "Search the audit log",
+			Description: "Newest first. An action ending in \".\" (e.g. payment.) matches every action with that prefix; entity_id needs entity_type." + adminCSVNote,
+			Query: withAdminListing(
+				openapi.Param{Name: "actor_id", Example: 0},
+				openapi.Param{Name: "action", Example: "job.status_changed"},
+				openapi.Param{Name: "entity_type", Example: "job", Description: "job, transaction, user or route"},
+				openapi.Param{Name: "entity_id", Example: ""},
+				openapi.Param{Name: "from", Example: "2026-01-01", Description: "Recorded on or after"},
+				openapi.Param{Name: "to", Example: "2026-01-31", Description: "Recorded on or before"},
+			),
+			Response: openapi.Fields{"events": []audit.Event{{}}, "pagination": paginated}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/users/{id}/notification-deliveries", Tag: "Admin", Summary: "A user's email, push and SMS delivery history",
+			Description: "Newest first, with each send attempt and provider receipt. Payloads are not returned.",
+			Query: withPaging(
+				openapi.Param{Name: "channel", Example: "email", Description: "email, push or sms"},
+				openapi.Param{Name: "status", Example: "", Description: "pending, sent, retrying, delivered, bounced or failed"},
+			),
+			Response: openapi.Fields{"deliveries": []model.NotificationDelivery{{Events: []model.NotificationDeliveryEvent{{}}}}, "pagination": paginated}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/email-suppressions", Tag: "Admin", Summary: "List suppressed email addresses",
+			Description: "Addresses that hard bounced or reported an email as spam, most recently added first. No email is sent to them until they are removed.",
+			Query:       withPaging(openapi.Param{Name: "search", Example: "", Description: "Part of the address"}),
+			Response:    openapi.Fields{"suppressions": []model.EmailSuppression{{Reason: model.SuppressionReasonBounce, Provider: "ses"}}, "pagination": paginated}},
+		{Method: http.MethodDelete, Path: "/api/v1/admin/email-suppressions/{email}", Tag: "Admin", Summary: "Email a suppressed address again",
+			Response: successResponse},
+		{Method: http.MethodGet, Path: "/api/v1/admin/query-stats", Tag: "Admin", Summary: "Database queries by total time",
+			Description: "The queries this API instance has spent the most time in since it started. Literals are replaced by ?; the fingerprint matches slow-query log lines and captured plans.",
+			Query:       []openapi.Param{{Name: "limit", Example: 0}},
+			Response:    openapi.Fields{"queries": []model.QueryStats{{}}, "slow_threshold_ms": 0, "explain_enabled": false}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/query-diagnostics", Tag: "Admin", Summary: "Captured query plans",
+			Description: "EXPLAIN ANALYZE plans captured for queries that were slow repeatedly, newest first. Plans are only captured with DB_EXPLAIN_SLOW_QUERIES=true.",
+			Query:       withPaging(openapi.Param{Name: "fingerprint", Example: "", Description: "Only this query's plans"}),
+			Response:    openapi.Fields{"diagnostics": []model.QueryDiagnostic{{}}, "pagination": paginated}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/index-advisories", Tag: "Admin", Summary: "Indexes for list queries",
+			Description: "Each filtered, newest-first list query, the columns an index needs for it and the index serving it. Queries no index serves carry the statement that creates the missing index.",
+			Response:    openapi.Fields{"indexes": []model.IndexAdvisory{{Columns: []string{""}}}, "missing": 0}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/links/stats", Tag: "Admin", Summary: "Deep link clicks and use by action",
+			Description: "Links created in the range, how many were clicked, used, or expired unused. The range defaults to the last 30 days.",
+			Query: []openapi.Param{
+				{Name: "from", Example: "2026-01-01"},
+				{Name: "to", Example: "2026-02-01"},
+			},
+			Response: []model.DeepLinkStats{{}}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/tax-reports/1099-nec", Tag: "Admin", Summary: "Workers to issue a Form 1099-NEC",
+			Description: "Workers whose USD nonemployee compensation (gross earnings plus tips) for the year reached the threshold, by name. ?format=csv downloads it for filing; taxpayer IDs are matched from W-9s by worker_uuid.",
+			Query: []openapi.Param{
+				{Name: "year", Example: 0, Description: "Defaults to last year"},
+				{Name: "threshold", Example: "", Description: "Defaults to the IRS threshold for the year: 600.00 through 2025, 2000.00 from 2026"},
+				{Name: "format", Example: "", Description: "json or csv"},
+			},
+			Response: openapi.Fields{"year": 0, "threshold": 0.0, "recipients": []model.Form1099NECRecipient{{}}}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/fee-rules", Tag: "Admin", Summary: "List platform fee rules",
+			Description: "Active rules first, in the order they are matched.",
+			Query:       []openapi.Param{{Name: "active", Example: "true"}},
+			Response:    openapi.Fields{"rules": []model.FeeRule{{}}}},
+		{Method: http.MethodPost, Path: "/api/v1/admin/fee-rules", Tag: "Admin", Summary: "Create a platform fee rule",
+			Description: "Sets the platform fee for jobs in a category, for workers in a fee tier, within a promotional window, or any combination; unset conditions match any job. The highest priority matching rule applies, then the one with more conditions, then the newest. A rule with a zero fee is a fee-free promotion; card processing fees still apply.",
+			Request:     model.FeeRuleRequest{}, Response: model.FeeRule{}, Status: http.StatusCreated},
+		{Method: http.MethodPut, Path: "/api/v1/admin/fee-rules/{id}", Tag: "Admin", Summary: "Replace a platform fee rule",
+			Description: "Payments already authorized keep the fees they were quoted.",
+			Request:     model.FeeRuleRequest{}, Response: model.FeeRule{}},
+		{Method: http.MethodDelete, Path: "/api/v1/admin/fee-rules/{id}", Tag: "Admin", Summary: "Deactivate a platform fee rule", Response: successResponse},
+		{Method: http.MethodGet, Path: "/api/v1/admin/email-templates", Tag: "Admin", Summary: "List email templates",
+			Description: "The templates emails are rendered with, each of which can be previewed.",
+			Response:    openapi.Fields{"templates": []email.Template{{Name: "offer_sent", Description: "A job was offered to the worker (job_offer notifications)"}}}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/email-templates/{name}/preview", Tag: "Admin", Summary: "Preview an email template",
+			Description: "The template rendered as HTML with sample data, as a recipient would see it. 404 for an unknown template.",
+			Response:    &openapi.Schema{Type: "string"}, ContentType: "text/html"},
+		{Method: http.MethodGet, Path: "/api/v1/admin/fee-rules/preview", Tag: "Admin", Summary: "Preview the effective fees for a job",
+			Description: "The rule that would price a job with the category and worker tier at the given time (default now), and how its fees split the amount (default 100.00). rule is omitted when the default platform fee applies.",
+			Query: []openapi.Param{
+				{Name: "category", Example: "cleaning"},
+				{Name: "worker_tier", Example: "pro"},
+				{Name: "amount", Example: "100.00"},
+				{Name: "at", Example: "2026-11-27T12:00:00Z"},
+			},
+			Response: model.FeePreview{}},
+		{Method: http.MethodPut, Path: "/api/v1/admin/gigworkers/{id}/fee-tier", Tag: "Admin", Summary: "Set a worker's fee tier",
+			Description: "Fee rules with a worker_tier match the tier of the job's assigned worker. A null tier is the standard fee.",
+			Request:     model.WorkerFeeTierRequest{}, Response: openapi.Fields{"worker_id": 1, "fee_tier": "pro"}},
+		{Method: http.MethodPost, Path: "/api/v1/admin/refund-batches", Tag: "Admin", Summary: "Refund many payments at once",
+			Description: "Selects captured payments by transaction_ids or by filter (a capture window, optionally narrowed by provider, currency, consumer, or to duplicate captures of a job) and refunds each in full in the background, returning 202 with the batch. Payments already refunded or never captured are recorded as skipped. A batch holds at most 1000 payments in one currency. An Idempotency-Key header is required; repeating it returns the batch with 200. With dry_run the batch is returned without being created.",
+			Request:     model.RefundBatchRequest{Filter: &model.RefundBatchFilter{}}, Response: model.RefundBatch{}, Status: http.StatusAccepted},
+		{Method: http.MethodGet, Path: "/api/v1/admin/refund-batches", Tag: "Admin", Summary: "List refund batches",
+			Query:    withPaging(openapi.Param{Name: "status", Example: "", Description: "pending, running, completed or completed_with_errors"}),
+			Response: openapi.Fields{"batches": []model.RefundBatch{}, "pagination": paginated}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/refund-batches/{id}", Tag: "Admin", Summary: "Get a refund batch with each refund's result",
+			Description: "The summary counts items by status and totals the amounts refunded and failed. With format=csv the items are returned as a CSV report.",
+			Query:       []openapi.Param{{Name: "format", Example: "json", Description: "json or csv"}},
+			Response:    model.RefundBatch{Items: []model.RefundBatchItem{{}}}},
+		{Method: http.MethodPost, Path: "/api/v1/admin/refund-batches/{id}/retry", Tag: "Admin", Summary: "Retry a refund batch's failed refunds",
+			Description: "Returns 409 unless the batch completed with errors or has not started.",
+			Response:    model.RefundBatch{}, Status: http.StatusAccepted},
+
+		// Gig workers
+		{Method: http.MethodGet, Path: "/api/v1/gigworkers", Tag: "Gig Workers", Summary: "List gig workers",
+			Query: withPaging(
+				openapi.Param{Name: "verification_status", Example: ""},
+				openapi.Param{Name: "is_active", Example: false},
+			),
+			Description: "Each worker's presence is online, recently_active (within the hour) or offline, from the worker app's heartbeats; online_now is true while online, for an \"online now\" badge.",
+			Response:    openapi.Fields{"gigworkers": []model.GigWorker{}, "pagination": paginated}},
+		{Method: http.MethodGet, Path: "/api/v1/gigworkers/{id}", Tag: "Gig Workers", Summary: "Get a gig worker", Response: model.GigWorker{}},
+		{Method: http.MethodGet, Path: "/api/v1/gigworkers/me/earnings", Tag: "Gig Workers", Summary: "Earnings for a tax year by month",
+			Description: "Captured, unrefunded payments in one currency: gross earnings, tips, the platform and processing fees withheld and net earnings. Reimbursed expenses and parts are listed but are not earnings. form_1099_nec is true when USD earnings reach the reporting threshold.",
+			Query: []openapi.Param{
+				{Name: "year", Example: 0, Description: "Defaults to the current year"},
+				{Name: "currency", Example: "USD", Description: "Defaults to USD"},
+			},
+			Response: model.WorkerEarnings{Months: []model.MonthlyEarnings{{}}}},
+		{Method: http.MethodGet, Path: "/api/v1/gigworkers/me/offers", Tag: "Gig Workers", Summary: "List job offers sent to the caller",
+			Description: "Newest first, at most 100. A pending or snoozed offer past expires_at is reported as expired.",
+			Query:       []openapi.Param{{Name: "status", Example: "pending", Description: "pending, snoozed, accepted, declined, cancelled or expired"}},
+			Response:    openapi.Fields{"offers": []model.JobOffer{{}}}},
+		{Method: http.MethodGet, Path: "/api/v1/offers/poll", Tag: "Notifications", Summary: "Long-poll for the caller's realtime events",
+			Description: "Fallback for networks that block the /ws WebSocket, returning the same offer, job status and payment events. Returns the events after the one whose id is in after, waiting up to timeout seconds (default and maximum 25) for one; without after it waits for the next event. Poll again with the returned cursor. reset is true when after is older than the server keeps (about 2 minutes or 32 events): refetch jobs and offers, then continue from cursor.",
+			Query: []openapi.Param{{Name: "after", Example: "", Description: "id of the last event received, or the previous cursor"},
+				{Name: "timeout", Example: 25, Description: "Seconds to wait for an event, 0-25"}},
+			Response: openapi.Fields{"events": []realtime.Event{{}}, "cursor": "", "reset": false}},
+		{Method: http.MethodPost, Path: "/api/v1/gigworkers/me/offers/{id}/accept", Tag: "Gig Workers", Summary: "Accept a job offer",
+			Description: "The first worker to accept is assigned the job and its other offers are cancelled. Returns 409 OFFER_UNAVAILABLE when the offer expired or another worker accepted first, and 409 SCHEDULE_CONFLICT with the conflicts when a scheduled job does not fit the caller's calendar (see GET /api/v1/gigworkers/me/conflicts).",
+			Response:    openapi.Fields{"success": true, "message": "", "job_id": 0}},
+		{Method: http.MethodPost, Path: "/api/v1/gigworkers/me/offers/{id}/decline", Tag: "Gig Workers", Summary: "Decline a job offer",
+			Description: "The optional reason (too_far, pay_too_low, schedule_conflict or other) and note feed the offer decline report and matching. Snoozed offers can be declined too. Returns 409 OFFER_UNAVAILABLE when the offer is no longer open.",
+			Request:     model.DeclineJobOfferRequest{},
+			Response:    openapi.Fields{"success": true, "message": "", "job_id": 0}},
+		{Method: http.MethodPost, Path: "/api/v1/gigworkers/me/offers/{id}/snooze", Tag: "Gig Workers", Summary: "Snooze a job offer",
+			Description: "Puts an open offer aside for 1 to 25 minutes (default 10); it stays open and the worker is reminded when the snooze ends. An offer can be snoozed twice, and the snooze must end before the offer expires, otherwise 409.",
+			Request:     model.SnoozeJobOfferRequest{},
+			Response:    openapi.Fields{"success": true, "message": "", "job_id": 0, "snoozed_until": time.Time{}, "expires_at": time.Time{}}},
+		{Method: http.MethodPost, Path: "/api/v1/gigworkers/me/heartbeat", Tag: "Gig Workers", Summary: "Send a presence heartbeat",
+			Description: "The worker app sends one every next_heartbeat_seconds while it is open. The worker shows as online for 5 minutes after each, then recently_active for an hour; online and recently active workers are matched to jobs first.",
+			Response:    model.PresenceHeartbeat{}},
+		{Method: http.MethodPut, Path: "/api/v1/gigworkers/{id}", Tag: "Gig Workers", Summary: "Update a gig worker profile",
+			Description: "Allowed for the worker or an admin; account status and verification fields are admin-only.",
+			Request:     model.GigWorkerUpdateRequest{}, Response: successResponse},
+		{Method: http.MethodDelete, Path: "/api/v1/gigworkers/{id}", Tag: "Gig Workers", Summary: "Deactivate a gig worker", Response: successResponse},
+		{Method: http.MethodPost, Path: "/api/v1/gigworkers/{id}/emergency-contact/break-glass", Tag: "Gig Workers",
+			Summary: "Reveal a gig worker's emergency contact", Description: "Every access is written to the break-glass audit log.",
+			Request:  model.BreakGlassRequest{},
+			Response: openapi.Fields{"emergency_contact": model.EmergencyContact{}, "access_log_id": 0}},
+		{Method: http.MethodGet, Path: "/api/v1/break-glass/log", Tag: "Gig Workers", Summary: "Emergency contact access audit log",
+			Query: withPaging(openapi.Param{Name: "gigworker_id", Example: 0}),
+			Response: openapi.Fields{
+				"access_log": []openapi.Fields{{
+					"id": 0, "admin_id": 0, "gigworker_id": 0, "incident_id": 0,
+					"reason": "", "ip_address": (*string)(nil), "accessed_at": (*time.Time)(nil),
+				}},
+				"pagination": paginated,
+			}},
+
+		// Worker applications
+		{Method: http.MethodPost, Path: "/api/v1/worker-applications", Tag: "Worker Applications", Summary: "Apply to become a gig worker",
+			Description: "Any non-admin account; one application may be in screening at a time.",
+			Request:     model.WorkerApplicationRequest{},
+			Response:    openapi.Fields{"success": true, "message": "", "application": model.WorkerApplication{}}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/api/v1/worker-applications/mine", Tag: "Worker Applications", Summary: "List your worker applications",
+			Response: openapi.Fields{"applications": []model.WorkerApplication{}}},
+		{Method: http.MethodGet, Path: "/api/v1/worker-applications", Tag: "Worker Applications", Summary: "List worker applications for screening",
+			Query:    withPaging(openapi.Param{Name: "status", Example: "open"}),
+			Response: openapi.Fields{"applications": []model.WorkerApplication{}, "pagination": paginated}},
+		{Method: http.MethodGet, Path: "/api/v1/worker-applications/{id}", Tag: "Worker Applications", Summary: "Get a worker application",
+			Response: model.WorkerApplication{}},
+		{Method: http.MethodPost, Path: "/api/v1/worker-applications/{id}/status", Tag: "Worker Applications", Summary: "Move a worker application through screening",
+			Description: "submitted -> docs_pending -> background_check -> approved/denied. Approval makes the applicant a verified gig worker; denial requires a note.",
+			Request:     model.WorkerApplicationStatusRequest{},
+			Response:    openapi.Fields{"success": true, "message": "", "application": model.WorkerApplication{}}},
+
+		// Worker documents
+		{Method: http.MethodPost, Path: "/api/v1/gigworkers/me/documents/uploads", Tag: "Worker Documents", Summary: "Get a signed URL to upload a verification document to",
+			Description: "Gig workers and applicants. PUT the file to upload_url with the given headers within 15 minutes, then submit object_key with POST /api/v1/gigworkers/me/documents. PDF, JPEG or PNG up to 20 MB.",
+			Request:     model.WorkerDocumentUploadRequest{DocumentType: model.DocumentTypeLicense, ContentType: "application/pdf", SizeBytes: 250000},
+			Response:    model.WorkerDocumentUpload{Headers: map[string]string{"Content-Type": "application/pdf"}}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/api/v1/gigworkers/me/documents", Tag: "Worker Documents", Summary: "Submit an uploaded verification document for review",
+			Description: "The file is checked against the document types allowed and scanned for malware; infected files are quarantined and rejected with 422. Licenses and insurance require expires_on.",
+			Request:     model.WorkerDocumentRequest{DocumentType: model.DocumentTypeLicense, ObjectKey: "incoming/workers/1/documents/0f3c.pdf", ExpiresOn: &documentExpiresOnExample},
+			Response:    model.WorkerDocument{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/api/v1/gigworkers/me/documents", Tag: "Worker Documents", Summary: "List your verification documents",
+			Response: openapi.Fields{"documents": []model.WorkerDocument{}}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/worker-documents", Tag: "Worker Documents", Summary: "List verification documents for review",
+			Description: "Oldest first; download a document's file with GET /api/v1/attachments/{attachment_id}.",
+			Query: withPaging(
+				openapi.Param{Name: "status", Example: "pending", Description: "pending, approved, rejected or expired"},
+				openapi.Param{Name: "worker_id", Example: 0},
+			),
+			Response: openapi.Fields{"documents": []model.WorkerDocument{}, "pagination": paginated}},
+		{Method: http.MethodPost, Path: "/api/v1/admin/worker-documents/{id}/review", Tag: "Worker Documents", Summary: "Approve or reject a verification document",
+			Description: "Pending documents only; approval requires a clean or skipped malware scan and rejection requires a note. The worker is notified.",
+			Request:     model.WorkerDocumentReviewRequest{Status: model.DocumentStatusApproved},
+			Response:    openapi.Fields{"success": true, "message": "", "document": model.WorkerDocument{}}},
+
+		// Jobs
+		{Method: http.MethodGet, Path: "/api/v1/jobs", Tag: "Jobs", Summary: "List jobs",
+			Query: withPaging(
+				openapi.Param{Name: "status", Example: ""},
+				openapi.Param{Name: "category", Example: ""},
+				openapi.Param{Name: "consumer_id", Example: 0},
+				openapi.Param{Name: "gig_worker_id", Example: 0},
+			),
+			Response: model.JobsListResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/{id}", Tag: "Jobs", Summary: "Get a job", Response: model.JobResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/my-jobs", Tag: "Jobs", Summary: "List the caller's jobs",
+			Query: withPaging(
+				openapi.Param{Name: "user_id", Example: 0, Description: "Must match the caller when given"},
+				openapi.Param{Name: "role", Example: ""},
+				openapi.Param{Name: "status", Example: ""},
+			),
+			Response: model.JobsListResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/available", Tag: "Jobs", Summary: "List jobs open to gig workers",
+			Query: withPaging(
+				openapi.Param{Name: "category", Example: ""},
+				openapi.Param{Name: "max_distance", Example: 0.0},
+				openapi.Param{Name: "min_pay_rate", Example: 0.0},
+			),
+			Response: model.JobsListResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/create", Tag: "Jobs", Summary: "Post a job",
+			Description: "Postings missing a field required for their category are rejected with 422 and code JOB_INCOMPLETE; details maps each missing field to what to add. preferred_worker_id rebooks one of the consumer's favorite workers, who takes the job without the offer wait when they auto-accept it. currency is one of GET /api/v1/currencies and defaults to USD.",
+			Request:     model.JobCreateRequest{}, Response: model.Job{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/completeness", Tag: "Jobs", Summary: "Check a job posting before posting it",
+			Request: model.JobCreateRequest{}, Response: openapi.Fields{"completeness": jobCompletenessExample, "can_post": true}},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/from-template/{id}", Tag: "Jobs", Summary: "Post a job from a template",
+			Description: "Posts the template's job at the time given, checked like any posting. preferred_worker_id replaces the template's and must be a favorite worker. 404 for a template that is not the caller's.",
+			Request:     model.JobFromTemplateRequest{}, Response: model.Job{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/rebook", Tag: "Jobs", Summary: "Rebook a job with the same worker",
+			Description: "Posts a finished job (completed, paid, review_pending or closed) again with preferred_worker_id set to the worker who did it; 409 for other jobs or a worker no longer active. Without scheduled_start the job is booked at the same weekday and time as before when the worker is free then, otherwise in their first free working-hours slot in the next 14 days, or left unscheduled. A favorite worker who auto-accepts takes it at once; otherwise offer it to them with POST /api/v1/jobs/{id}/send-offer.",
+			Request:     model.JobRebookRequest{}, Response: model.Job{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/{id}/completeness", Tag: "Jobs", Summary: "Get a posted job's completeness",
+			Response: openapi.Fields{"job_id": 0, "completeness": jobCompletenessExample, "can_post": true}},
+		{Method: http.MethodPut, Path: "/api/v1/jobs/{id}", Tag: "Jobs", Summary: "Update a job",
+			Description: "The job is rescored; edits that remove a required field from a posted job are rejected with 422.",
+			Request:     model.JobUpdateRequest{}, Response: withSuccess(openapi.Fields{"completeness": jobCompletenessExample})},
+		{Method: http.MethodDelete, Path: "/api/v1/jobs/{id}", Tag: "Jobs", Summary: "Delete a job", Response: successResponse},
+		{Method: http.MethodDelete, Path: "/api/v1/jobs/{id}/cancel", Tag: "Jobs", Summary: "Cancel a job", Response: successResponse},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/accept", Tag: "Jobs", Summary: "Accept a job",
+			Description: "Only a posted job without a worker can be accepted. Workers accepting at once are serialized on the job; all but the first receive 409.",
+			Request:     model.JobAcceptRequest{},
+			Response:    withSuccess(openapi.Fields{"job_id": 0, "job_uuid": "", "updated_at": time.Time{}})},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/send-offer", Tag: "Jobs", Summary: "Offer a job to a gig worker",
+			Request: model.JobOfferRequest{}, Response: successResponse},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/start", Tag: "Jobs", Summary: "Start work on a job",
+			Response: withSuccess(openapi.Fields{"job_id": 0})},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/complete", Tag: "Jobs", Summary: "Confirm a job is complete",
+			Description: "Both the consumer and the gig worker must confirm before the job is fully completed.",
+			Response: withSuccess(openapi.Fields{
+				"job_id": 0, "awaiting_confirmation": false, "fully_completed": false, "your_confirmation": "",
+			})},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/reject", Tag: "Jobs", Summary: "Decline an offered job",
+			Request: model.JobRejectRequest{}, Response: withSuccess(openapi.Fields{"job_id": 0})},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/review", Tag: "Jobs", Summary: "Submit the job's completion review",
+			Request: model.JobReviewSubmission{}, Response: withSuccess(openapi.Fields{"job_id": 0})},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/{id}/handoffs", Tag: "Jobs", Summary: "List a job's hand-offs",
+			Description: "For the consumer, the assigned worker and workers who handed the job off.",
+			Response:    openapi.Fields{"handoffs": []model.JobHandoff{}, "count": 0}},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/handoffs", Tag: "Jobs", Summary: "Ask to hand a job off to another worker",
+			Description: "The assigned worker of a scheduled or in-progress job asks to be released, e.g. when sick, with the percentage of the job they completed (0-99). One hand-off can be open per job.",
+			Request:     model.JobHandoffRequest{}, Response: model.JobHandoff{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/handoffs/{handoffId}/approve", Tag: "Jobs", Summary: "Approve a worker's hand-off",
+			Description: "The body is optional; completed_percent corrects the worker's estimate. The job workflow pays the worker for their portion, releases them and offers the rest of the job to other workers, twice as many per round with a 10-minute window. The job's final payment is reduced by the portion paid. If nobody takes over, the job ends as no_worker_available.",
+			Request:     model.JobHandoffApproval{}, Response: model.JobHandoff{}},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/handoffs/{handoffId}/decline", Tag: "Jobs", Summary: "Keep the worker on the job",
+			Response: model.JobHandoff{}},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/{id}/survey", Tag: "Jobs", Summary: "Get the job's satisfaction survey",
+			Description: "The one-question CSAT (1-5) or NPS (0-10) survey sent to the consumer when the job closed; 404 when none was sent.",
+			Response:    model.JobSurvey{}},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/survey", Tag: "Jobs", Summary: "Answer the job's satisfaction survey",
+			Description: "Answers once, within 30 days of the survey being sent; a second answer returns 409 and a late one 410.",
+			Request:     model.JobSurveyResponse{}, Response: withSuccess(openapi.Fields{"job_id": 0})},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/review/link", Tag: "Jobs", Summary: "Submit a review from an emailed link",
+			Description: "The token from the review request email is scoped to the job and reviewer and expires after 7 days; an invalid or expired token returns 403.",
+			Query:       []openapi.Param{{Name: "token", Example: "", Required: true}},
+			Request:     model.JobReviewSubmission{}, Response: withSuccess(openapi.Fields{"job_id": 0})},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/accept-offer/link", Tag: "Jobs", Summary: "Accept a job offer from its notification link",
+			Description: "The token from the offer link works once, for the job's consumer, within 72 hours; an invalid, expired or used token returns 403 INVALID_TOKEN and an offer that is no longer pending 409.",
+			Query:       []openapi.Param{{Name: "token", Example: "", Required: true}},
+			Response:    withSuccess(openapi.Fields{"job_id": 0})},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/{id}/weather", Tag: "Jobs", Summary: "Forecast advisory for an outdoor job",
+			Response: model.WeatherAdvisory{}},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/{id}/reschedule-proposals", Tag: "Jobs", Summary: "List reschedule proposals",
+			Response: openapi.Fields{"proposals": []model.RescheduleProposal{}}},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/reschedule-proposals", Tag: "Jobs", Summary: "Propose a new time",
+			Request: model.RescheduleProposalRequest{}, Response: model.RescheduleProposal{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/reschedule-proposals/{proposalId}/respond", Tag: "Jobs",
+			Summary: "Accept or decline a reschedule proposal",
+			Request: model.RescheduleResponseRequest{}, Response: model.RescheduleProposal{}},
+
+		// Safety incidents
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/incidents", Tag: "Incidents", Summary: "Report a safety incident",
+			Request: model.IncidentReportRequest{}, Response: withSuccess(openapi.Fields{"incident": model.SafetyIncident{}}),
+			Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/api/v1/incidents", Tag: "Incidents", Summary: "List safety incidents",
+			Query: withPaging(
+				openapi.Param{Name: "status", Example: ""},
+				openapi.Param{Name: "severity", Example: ""},
+				openapi.Param{Name: "job_id", Example: 0},
+			),
+			Response: openapi.Fields{"incidents": []model.SafetyIncident{}, "pagination": paginated}},
+		{Method: http.MethodGet, Path: "/api/v1/incidents/{id}", Tag: "Incidents", Summary: "Get a safety incident", Response: model.SafetyIncident{}},
+		{Method: http.MethodPut, Path: "/api/v1/incidents/{id}", Tag: "Incidents", Summary: "Update a safety incident",
+			Request: model.IncidentUpdateRequest{}, Response: withSuccess(openapi.Fields{"incident": model.SafetyIncident{}})},
+
+		// Expenses and parts
+		{Method: http.MethodGet, Path: "/api/v1/jobs/{id}/expenses", Tag: "Expenses", Summary: "List a job's expenses",
+			Response: openapi.Fields{"expenses": []model.JobExpense{}, "pending_reimbursement": 0.0, "approved_reimbursement": 0.0}},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/expenses", Tag: "Expenses", Summary: "Log an expense",
+			Request: model.JobExpenseRequest{}, Response: model.JobExpense{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/expenses/mileage", Tag: "Expenses", Summary: "Log mileage to the job",
+			Request: model.MileageRequest{}, Response: model.JobExpense{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/expenses/{expenseId}/review", Tag: "Expenses", Summary: "Approve or reject reimbursement",
+			Request: model.ExpenseReviewRequest{}, Response: model.JobExpense{}},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/expenses/{expenseId}/receipt", Tag: "Attachments", Summary: "Attach a receipt to an expense",
+			Description: "PDF or photo up to 10 MB. The file is scanned for malware before it is stored; infected files are quarantined and rejected with 422.",
+			Upload:      "file", Response: model.Attachment{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/api/v1/attachments/{id}", Tag: "Attachments", Summary: "Get an attachment and its scan status",
+			Description: "download_url is only returned for attachments that passed the malware scan (or were uploaded with scanning turned off).",
+			Response:    openapi.Fields{"attachment": model.Attachment{}, "download_url": "", "expires_at": time.Time{}}},
+		{Method: http.MethodGet, Path: "/api/v1/attachments/{id}/download", Tag: "Attachments", Summary: "Download an attachment",
+			Description: "Redirects to the stored file. The token from download_url is scoped to the attachment and expires after 15 minutes; the scan status is checked again on each download.",
+			Query: []openapi.Param{{Name: "token", Example: "", Required: true},
+				{Name: "size", Example: "thumbnail", Description: "thumbnail redirects to a photo's thumbnail, when it has one"}},
+			Status: http.StatusFound},
+		{Method: http.MethodPost, Path: "/api/v1/attachments/{id}/rescan", Tag: "Attachments", Summary: "Scan an attachment again",
+			Description: "For attachments whose scan failed because the scanner was unavailable. Infected files are moved to the quarantine.",
+			Response:    model.Attachment{}},
+		{Method: http.MethodPut, Path: "/api/v1/users/me/avatar", Tag: "Attachments", Summary: "Set the caller's profile photo",
+			Description: "JPEG, PNG, WebP or HEIC up to 10 MB and at least 128 pixels on its shorter side. Replaces and deletes the previous avatar. JPEG and PNG photos get a thumbnail; others use the full image as thumbnail_url.",
+			Upload:      "file", Response: model.Avatar{}},
+		{Method: http.MethodDelete, Path: "/api/v1/users/me/avatar", Tag: "Attachments", Summary: "Remove the caller's profile photo", Response: successResponse},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/photos", Tag: "Attachments", Summary: "Add a photo to a job",
+			Description: "Posting photos come from the consumer while the job is open; before and after photos from the assigned worker around the work. Photos must be at least 320 pixels on their shorter side, and a job holds at most 30. Before and after photos are only shown to the job's parties.",
+			Query:       []openapi.Param{{Name: "stage", Example: "after", Required: true, Description: "posting, before or after"}},
+			Upload:      "file", Response: model.JobPhoto{}, Status: http.StatusCreated},
+		{Method: http.MethodDelete, Path: "/api/v1/jobs/{id}/photos/{photoId}", Tag: "Attachments", Summary: "Remove a job photo",
+			Description: "Only whoever uploaded the photo, or an admin, can remove it.", Response: successResponse},
+		{Method: http.MethodGet, Path: "/api/v1/gigworkers/me/auto-accept", Tag: "Gig Workers", Summary: "The caller's auto-accept settings",
+			Description: "The opt-in and price floor, and the consumers who favorited the caller with the caller's setting for each.",
+			Response:    model.AutoAcceptSettings{Consumers: []model.AutoAcceptConsumer{{}}}},
+		{Method: http.MethodPut, Path: "/api/v1/gigworkers/me/auto-accept", Tag: "Gig Workers", Summary: "Opt into auto-accepting rebookings",
+			Description: "Rebookings from consumers who favorited the caller are accepted and scheduled without the offer when the caller is free and the price is at least min_price (null accepts any price).",
+			Request:     model.AutoAcceptSettingsRequest{}, Response: model.AutoAcceptSettings{Consumers: []model.AutoAcceptConsumer{{}}}},
+		{Method: http.MethodPut, Path: "/api/v1/gigworkers/me/auto-accept/consumers/{consumerId}", Tag: "Gig Workers", Summary: "Turn auto-accept on or off for one consumer",
+			Request: model.AutoAcceptPairRequest{}, Response: model.AutoAcceptSettings{Consumers: []model.AutoAcceptConsumer{{}}}},
+		{Method: http.MethodGet, Path: "/api/v1/gigworkers/me/tax-summary", Tag: "Expenses", Summary: "Annual expense and mileage summary",
+			Query: []openapi.Param{{Name: "year", Example: 0, Description: "Defaults to the current year"}}, Response: model.WorkerTaxSummary{}},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/{id}/parts-requests", Tag: "Expenses", Summary: "List a job's parts requests",
+			Response: openapi.Fields{"parts_requests": []model.PartsRequest{}, "approved_total": 0.0}},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/parts-requests", Tag: "Expenses", Summary: "Request approval for a parts purchase",
+			Request: model.PartsRequestBody{}, Response: model.PartsRequest{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/parts-requests/{requestId}/cancel", Tag: "Expenses", Summary: "Withdraw a parts request",
+			Response: model.PartsRequest{}},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/parts-requests/{requestId}/review", Tag: "Expenses", Summary: "Approve or reject a parts request",
+			Request: model.PartsReviewRequest{}, Response: model.PartsRequest{}},
+
+		// Messages and support
+		{Method: http.MethodGet, Path: "/api/v1/jobs/{id}/messages", Tag: "Support", Summary: "Read a job's message thread",
+			Query: []openapi.Param{{Name: "limit", Example: 0}}, Response: openapi.Fields{"messages": []model.JobMessage{}}},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/messages", Tag: "Support", Summary: "Send a message on a job's thread",
+			Request: model.JobMessageRequest{}, Response: model.JobMessage{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/messages/escalate", Tag: "Support", Summary: "Escalate a job's thread to support",
+			Request: model.EscalationRequest{}, Response: withSuccess(openapi.Fields{"ticket": model.SupportTicket{}}), Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/api/v1/support/tickets", Tag: "Support", Summary: "List support tickets",
+			Query: withPaging(
+				openapi.Param{Name: "status", Example: ""},
+				openapi.Param{Name: "job_id", Example: 0},
+			),
+			Response: openapi.Fields{"tickets": []model.SupportTicket{}, "pagination": paginated}},
+		{Method: http.MethodGet, Path: "/api/v1/support/tickets/{id}", Tag: "Support", Summary: "Get a support ticket", Response: model.SupportTicket{}},
+		{Method: http.MethodPut, Path: "/api/v1/support/tickets/{id}", Tag: "Support", Summary: "Update a support ticket",
+			Request: model.SupportTicketUpdateRequest{}, Response: withSuccess(openapi.Fields{"ticket": model.SupportTicket{}})},
+
+		// Notifications
+		{Method: http.MethodGet, Path: "/api/v1/notifications", Tag: "Notifications", Summary: "List the caller's notifications",
+			Description: "Archived notifications are only listed when status=archived.",
+			Query: withPaging(
+				openapi.Param{Name: "status", Example: "", Description: "unread, read or archived"},
+			),
+			Response: openapi.Fields{"notifications": []model.Notification{}, "unread_count": 0, "pagination": paginated}},
+		{Method: http.MethodGet, Path: "/api/v1/notifications/unread-count", Tag: "Notifications", Summary: "Count the caller's unread notifications",
+			Response: openapi.Fields{"unread_count": 0}},
+		{Method: http.MethodPost, Path: "/api/v1/notifications/{id}/read", Tag: "Notifications", Summary: "Mark a notification as read",
+			Response: withSuccess(openapi.Fields{"notification": model.Notification{}, "unread_count": 0})},
+		{Method: http.MethodGet, Path: "/api/v1/users/me/notification-preferences", Tag: "Notifications", Summary: "The caller's notification preferences",
+			Description: "Whether each notification type is sent by email, push and SMS. Types never changed use the defaults: email and push, system messages by email only, no SMS. In-app notifications are always kept.",
+			Response:    openapi.Fields{"preferences": []model.NotificationPreference{{}}, "sms_types": []string{model.NotificationJobOffer}}},
+		{Method: http.MethodPut, Path: "/api/v1/users/me/notification-preferences", Tag: "Notifications", Summary: "Change the caller's notification preferences",
+			Description: "Types left out keep their settings. Push goes to the caller's registered devices (see POST /api/v1/users/me/devices), or the FCM topic user_<id> without any. SMS can only be turned on for the sms_types (job offers and payments) and is only sent to a verified phone.",
+			Request:     model.NotificationPreferencesRequest{Preferences: []model.NotificationPreference{{}}},
+			Response:    withSuccess(openapi.Fields{"preferences": []model.NotificationPreference{{}}})},
+		{Method: http.MethodPost, Path: "/api/v1/users/me/phone/verification", Tag: "Notifications", Summary: "Text a verification code to the caller's phone",
+			Description: "Texts a 6-digit code, valid for 10 minutes, to the phone number on the caller's profile and supersedes earlier codes. Returns 409 when the phone is already verified, 429 with Retry-After within a minute of the last code, and 503 when SMS is not configured.",
+			Response:    withSuccess(openapi.Fields{"expires_at": time.Time{}})},
+		{Method: http.MethodPost, Path: "/api/v1/users/me/phone/verify", Tag: "Notifications", Summary: "Verify the caller's phone",
+			Description: "Marks the phone verified when the code matches, so SMS notifications can be sent to it. A code allows 5 attempts; wrong, used up or expired codes return 400 INVALID_TOKEN. Changing the phone number clears phone_verified.",
+			Request:     VerifyPhoneRequest{}, Response: successResponse},
+		{Method: http.MethodPost, Path: "/api/v1/users/me/devices", Tag: "Notifications", Summary: "Register a device for push notifications",
+			Description: "Apps call this after login and whenever FCM issues a new registration token. Push notifications go to every registered device, or to the FCM topic user_<id> when there are none. Registering a token again updates it and moves it to the caller; tokens FCM reports as unregistered or invalid stop receiving pushes until registered again.",
+			Request:     model.RegisterDeviceRequest{}, Response: withSuccess(openapi.Fields{"device": model.Device{}})},
+		{Method: http.MethodDelete, Path: "/api/v1/users/me/devices/{id}", Tag: "Notifications", Summary: "Unregister a device",
+			Description: "Stops push notifications to the device, e.g. on logout. 404 for another user's device.",
+			Response:    successResponse},
+		{Method: http.MethodPost, Path: "/api/v1/webhooks/sendgrid", Tag: "Notifications", Summary: "SendGrid event webhook",
+			Description: "Called by SendGrid with batches of delivery events. Signed with the key in SENDGRID_WEBHOOK_PUBLIC_KEY; a bad signature is rejected with 401.",
+			Request:     []email.Event{{}}, Response: openapi.Fields{"received": 0, "recorded": 0}},
+		{Method: http.MethodPost, Path: "/api/v1/webhooks/ses", Tag: "Notifications", Summary: "SES event webhook",
+			Description: "An SNS HTTPS subscription to the topics SES publishes bounce, complaint and delivery notifications to. Subscription confirmations are confirmed. Messages must be signed by SNS and come from a topic in SES_SNS_TOPIC_ARN; hard bounces and complaints suppress the address.",
+			Request:     email.SNSMessage{Type: email.SNSNotification}, Response: openapi.Fields{"recorded": false, "suppressed": 0}},
+
+		// Reviews
+		{Method: http.MethodGet, Path: "/api/v1/reviews", Tag: "Reviews", Summary: "Search public reviews",
+			Query: withPaging(
+				openapi.Param{Name: "user_id", Example: 0},
+				openapi.Param{Name: "job_id", Example: 0},
+				openapi.Param{Name: "reviewer_id", Example: 0},
+				openapi.Param{Name: "reviewee_id", Example: 0},
+				openapi.Param{Name: "min_rating", Example: 0},
+				openapi.Param{Name: "max_rating", Example: 0},
+				openapi.Param{Name: "is_public", Example: false},
+				openapi.Param{Name: "category", Example: ""},
+				openapi.Param{Name: "date_from", Example: "", Description: "Earliest review date, YYYY-MM-DD"},
+				openapi.Param{Name: "date_to", Example: "", Description: "Latest review date, YYYY-MM-DD"},
+				openapi.Param{Name: "sort_by", Example: ""},
+				openapi.Param{Name: "sort_order", Example: ""},
+			),
+			Response: model.PaginatedReviews{}},
+		{Method: http.MethodGet, Path: "/api/v1/reviews/{id}", Tag: "Reviews", Summary: "Get a review", Response: model.ReviewWithDetails{}},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/{id}/reviews", Tag: "Reviews", Summary: "List a job's reviews",
+			Response: openapi.Fields{"job_id": 0, "reviews": []model.ReviewWithDetails{}}},
+		{Method: http.MethodGet, Path: "/api/v1/users/{id}/reviews", Tag: "Reviews", Summary: "Rating statistics for a user", Response: model.ReviewStats{}},
+		{Method: http.MethodGet, Path: "/api/v1/reviews/stats", Tag: "Reviews", Summary: "Platform-wide rating statistics", Response: model.PlatformReviewStats{}},
+		{Method: http.MethodGet, Path: "/api/v1/reviews/top-rated", Tag: "Reviews", Summary: "Top rated users",
+			Query:    []openapi.Param{{Name: "limit", Example: 0}, {Name: "role", Example: ""}},
+			Response: openapi.Fields{"top_rated_users": []model.ReviewStats{}, "limit": 0, "role_filter": ""}},
+		{Method: http.MethodPost, Path: "/api/v1/reviews", Tag: "Reviews", Summary: "Review the other party on a completed job",
+			Request: model.ReviewRequest{}, Response: withSuccess(openapi.Fields{"review": model.Review{}}), Status: http.StatusCreated},
+		{Method: http.MethodPut, Path: "/api/v1/reviews/{id}", Tag: "Reviews", Summary: "Edit a review",
+			Request: model.ReviewUpdateRequest{}, Response: successResponse},
+		{Method: http.MethodDelete, Path: "/api/v1/reviews/{id}", Tag: "Reviews", Summary: "Delete a review", Response: successResponse},
+
+		// Payments
+		{Method: http.MethodPost, Path: "/api/v1/payments/authorize", Tag: "Payments", Summary: "Authorize a job payment into escrow",
+			Description: "Send an Idempotency-Key header to retry safely; a repeated key returns the original authorization.",
+			Request:     model.PaymentAuthorizeRequest{}, Response: model.PaymentAuthorizeResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/payments/capture", Tag: "Payments", Summary: "Capture an authorized payment",
+			Description: "Send an Idempotency-Key header to retry safely; a repeated key returns the original capture. A currency other than the payment's returns 422 with code CURRENCY_MISMATCH, and a job held by an open dispute, incident or ticket returns 409 with code JOB_ON_HOLD.",
+			Request:     model.PaymentCaptureRequest{}, Response: model.PaymentCaptureResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/payments/refund", Tag: "Payments", Summary: "Refund a captured payment",
+			Description: "Send an Idempotency-Key header to retry safely; a repeated key returns the original refund. A currency other than the payment's returns 422 with code CURRENCY_MISMATCH.",
+			Request:     model.PaymentRefundRequest{}, Response: model.PaymentRefundResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/tip", Tag: "Payments", Summary: "Tip the worker of a completed job",
+			Description: "Charges the tip separately, to the card that paid for the job unless payment_method_id is given, and pays all of it to the worker with their next payout. A job takes one tip of at most $500; tipping a job that is not completed or already tipped returns 409. Send an Idempotency-Key header to retry safely.",
+			Request:     model.JobTipRequest{}, Response: model.JobTipResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/{id}/payments", Tag: "Payments", Summary: "List a job's transactions",
+			Response: openapi.Fields{"job_id": 0, "transactions": []model.EnhancedTransaction{}}},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/{id}/payment-summary", Tag: "Payments", Summary: "Payment summary for a job",
+			Response: model.JobPaymentSummary{}},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/{id}/price-breakdown", Tag: "Payments", Summary: "Itemized price, fees and tax before payment",
+			Description: "The total is the amount payments/authorize charges, in the job's currency; authorizing a different amount returns 409. display_currency adds display, the amounts converted at the configured exchange rate; 422 when there is no rate.",
+			Query:       []openapi.Param{{Name: "display_currency", Example: "EUR"}},
+			Response:    model.PriceBreakdown{Display: &model.CurrencyConversion{}}},
+		{Method: http.MethodGet, Path: "/api/v1/currencies", Tag: "Payments", Summary: "Currencies jobs can be priced in",
+			Response: openapi.Fields{"currencies": []currency.Currency{{}}, "default": model.DefaultCurrency}},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/{id}/escrow-timeline", Tag: "Payments", Summary: "When the job's payment was authorized, held, charged and refunded",
+			Description: "Milestones are oldest first. The job's consumer, assigned worker and admins may view it.",
+			Response:    model.EscrowTimeline{}},
+		{Method: http.MethodGet, Path: "/api/v1/payments/export", Tag: "Payments", Summary: "Export spend as CSV",
+			Query: []openapi.Param{
+				{Name: "from", Example: "", Description: "Start date, YYYY-MM-DD"},
+				{Name: "to", Example: "", Description: "End date, YYYY-MM-DD"},
+			},
+			Response: &openapi.Schema{Type: "string"}, ContentType: "text/csv"},
+		{Method: http.MethodGet, Path: "/api/v1/payments/{id}/receipt", Tag: "Payments", Summary: "Itemized receipt for a transaction",
+			Description: "Consumers get their itemized receipt. format=pdf downloads a PDF instead: the consumer receives their receipt and the worker their earnings statement; admins choose with type.",
+			Query: []openapi.Param{
+				{Name: "format", Example: "", Description: "json or pdf"},
+				{Name: "type", Example: "", Description: "With format=pdf, receipt or earnings_statement; defaults to the caller's document"},
+			},
+			Response: model.SpendReceipt{}},
+		{Method: http.MethodPost, Path: "/api/v1/transactions/create", Tag: "Payments", Summary: "Record a transaction",
+			Request: model.Transaction{}, Response: model.Transaction{}, Status: http.StatusCreated},
+
+		// Payouts
+		{Method: http.MethodGet, Path: "/api/v1/gigworkers/{id}/payouts", Tag: "Payouts", Summary: "List a gig worker's payouts",
+			Description: "Allowed for the profile owner or an admin.",
+			Query:       withPaging(),
+			Response:    openapi.Fields{"payouts": []model.WorkerPayout{}, "pagination": paginated}},
+		{Method: http.MethodGet, Path: "/api/v1/payouts/batches", Tag: "Payouts", Summary: "List settlement batches",
+			Query:    withPaging(openapi.Param{Name: "status", Example: "", Description: "pending, processed, failed or reconciled"}),
+			Response: openapi.Fields{"batches": []model.SettlementBatch{}, "pagination": paginated}},
+		{Method: http.MethodGet, Path: "/api/v1/payouts/batches/{id}", Tag: "Payouts", Summary: "Get a settlement batch with its payouts",
+			Response: model.SettlementBatch{}},
+		{Method: http.MethodPost, Path: "/api/v1/payouts/batches", Tag: "Payouts", Summary: "Settle captured payments now",
+			Description: "Batches captures older than PAYOUT_HOLD_HOURS and pays each worker out. Returns 200 with a message when nothing is waiting.",
+			Request:     model.SettlementBatchRequest{}, Response: model.SettlementBatch{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/api/v1/payouts/batches/{id}/process", Tag: "Payouts", Summary: "Retry a batch's failed payouts",
+			Response: model.SettlementBatch{}},
+		{Method: http.MethodPost, Path: "/api/v1/payouts/batches/{id}/reconcile", Tag: "Payouts", Summary: "Reconcile a settlement batch",
+			Description: "Marks the batch reconciled when its payouts match the settled transactions and every payout was paid.",
+			Response:    model.SettlementReconciliation{}},
+
+		// Disputes
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/disputes", Tag: "Disputes", Summary: "Dispute a completed job",
+			Description: "Holds the job's payment capture and worker payout until support resolves the dispute.",
+			Request:     model.DisputeRequest{}, Response: withSuccess(openapi.Fields{"dispute": model.Dispute{}}), Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/api/v1/disputes", Tag: "Disputes", Summary: "List disputes",
+			Query: withPaging(
+				openapi.Param{Name: "status", Example: "", Description: "open, under_review, resolved or refunded"},
+				openapi.Param{Name: "job_id", Example: 0},
+			),
+			Response: openapi.Fields{"disputes": []model.Dispute{}, "pagination": paginated}},
+		{Method: http.MethodGet, Path: "/api/v1/disputes/{id}", Tag: "Disputes", Summary: "Get a dispute", Response: model.Dispute{}},
+		{Method: http.MethodPut, Path: "/api/v1/disputes/{id}", Tag: "Disputes", Summary: "Review, resolve or refund a dispute",
+			Description: "Disputes move open → under_review → resolved or refunded. Refunding refunds the captured payment, in full unless refund_amount is set, and cancels the job.",
+			Request:     model.DisputeUpdateRequest{}, Response: withSuccess(openapi.Fields{"dispute": model.Dispute{}})},
+		{Method: http.MethodPost, Path: "/api/v1/payments/{id}/line-item-disputes", Tag: "Disputes", Summary: "Dispute one line of a receipt",
+			Description: "line_id is the id of a materials or expense line from the receipt and is omitted for the service line. amount defaults to the whole line. The worker is notified; a payment has one line waiting for an answer at a time.",
+			Request:     model.LineItemDisputeRequest{}, Response: withSuccess(openapi.Fields{"dispute": model.LineItemDispute{}}), Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/api/v1/payments/{id}/line-item-disputes", Tag: "Disputes", Summary: "Line item disputes on a payment",
+			Description: "For the payment's consumer and worker, and admins. Newest first.",
+			Response:    openapi.Fields{"disputes": []model.LineItemDispute{}}},
+		{Method: http.MethodPost, Path: "/api/v1/line-item-disputes/{id}/respond", Tag: "Disputes", Summary: "Accept or contest a disputed charge",
+			Description: "Accepting refunds the disputed amount from the payment. Contesting opens a job dispute for support, or adds to the job's open one.",
+			Request:     model.LineItemDisputeAnswer{}, Response: withSuccess(openapi.Fields{"dispute": model.LineItemDispute{}})},
+
+		// Accounting
+		{Method: http.MethodGet, Path: "/api/v1/accounting/connections", Tag: "Accounting", Summary: "List accounting connections",
+			Response: openapi.Fields{"connections": []model.AccountingConnection{}}},
+		{Method: http.MethodPost, Path: "/api/v1/accounting/{provider}/connect", Tag: "Accounting", Summary: "Start connecting QuickBooks or Xero",
+			Response: openapi.Fields{"authorization_url": ""}},
+		{Method: http.MethodGet, Path: "/api/v1/accounting/{provider}/callback", Tag: "Accounting", Summary: "OAuth redirect target",
+			Description: "Redirects back to the app's accounting settings page with the connection status.",
+			Query: []openapi.Param{
+				{Name: "code", Example: ""},
+				{Name: "state", Example: ""},
+				{Name: "error", Example: "", Description: "Set by the provider when the user declines"},
+			},
+			Status: http.StatusFound},
+		{Method: http.MethodDelete, Path: "/api/v1/accounting/connections/{id}", Tag: "Accounting", Summary: "Disconnect an accounting provider",
+			Response: successResponse},
+		{Method: http.MethodGet, Path: "/api/v1/accounting/connections/{id}/mappings", Tag: "Accounting", Summary: "Get category to account mappings",
+			Response: openapi.Fields{"mappings": []model.AccountingCategoryMapping{}}},
+		{Method: http.MethodPut, Path: "/api/v1/accounting/connections/{id}/mappings", Tag: "Accounting", Summary: "Replace category to account mappings",
+			Request: model.AccountingMappingsRequest{}, Response: openapi.Fields{"mappings": []model.AccountingCategoryMapping{}}},
+		{Method: http.MethodPost, Path: "/api/v1/accounting/connections/{id}/sync", Tag: "Accounting", Summary: "Push unsynced spend to the provider",
+			Response: model.AccountingSyncResult{}},
+
+		// Schedules
+		{Method: http.MethodGet, Path: "/api/v1/schedules", Tag: "Schedules", Summary: "List schedules",
+			Query:    []openapi.Param{{Name: "limit", Example: 0}, {Name: "worker_id", Example: 0}},
+			Response: model.SchedulesListResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/schedules/occurrences", Tag: "Schedules", Summary: "Expand a worker's schedules into slots",
+			Description: "Recurring schedules (daily, weekly, monthly or an RRULE subset) are expanded on read; the range defaults to the next 30 days and may span up to a year",
+			Query: []openapi.Param{
+				{Name: "worker_id", Example: 0},
+				{Name: "start_date", Example: "2026-01-01"},
+				{Name: "end_date", Example: "2026-01-31"},
+			},
+			Response: openapi.Fields{"worker_id": 0, "start_date": time.Time{}, "end_date": time.Time{}, "occurrences": []model.ScheduleOccurrence{}}},
+		{Method: http.MethodPost, Path: "/api/v1/schedules/create", Tag: "Schedules", Summary: "Create a schedule",
+			Description: "recurring_pattern accepts daily, weekly, monthly or FREQ=DAILY|WEEKLY|MONTHLY;INTERVAL=n;BYDAY=MO,...; booked slots that overlap the worker's other booked slots are rejected with 409",
+			Request:     model.Schedule{}, Response: model.Schedule{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/api/v1/gigworkers/{id}/availability", Tag: "Schedules", Summary: "A worker's free/busy windows",
+			Description: "Merges booked schedules, accepted jobs and blackout dates; the range defaults to the next 7 days and may span up to 93. Busy titles are shown to the worker and admins only.",
+			Query: []openapi.Param{
+				{Name: "from", Example: "2026-01-01T00:00:00Z"},
+				{Name: "to", Example: "2026-01-08T00:00:00Z"},
+			},
+			Response: availability.Availability{}},
+		{Method: http.MethodGet, Path: "/api/v1/gigworkers/me/conflicts", Tag: "Schedules", Summary: "What stops the caller taking a slot",
+			Description: "Booked schedules, jobs and blackout dates overlapping the slot (kind overlap) and, with job_id, the jobs just before and after it that are too far to drive between in time (kind travel). The slot defaults to the job's scheduled time. Accepting a job offer runs the same check.",
+			Query: []openapi.Param{
+				{Name: "start", Example: "2026-01-05T10:00:00Z", Description: "required unless job_id names a scheduled job"},
+				{Name: "end", Example: "2026-01-05T12:00:00Z"},
+				{Name: "job_id", Example: "", Description: "the job being considered, for its location"},
+			},
+			Response: openapi.Fields{"start_time": time.Time{}, "end_time": time.Time{}, "job_id": 0, "conflicts": []availability.Conflict{{}}}},
+		{Method: http.MethodGet, Path: "/api/v1/gigworkers/{id}/blackout-dates", Tag: "Schedules", Summary: "List a worker's upcoming blackout dates",
+			Response: openapi.Fields{"blackout_dates": []model.BlackoutDate{}}},
+		{Method: http.MethodPost, Path: "/api/v1/gigworkers/{id}/blackout-dates", Tag: "Schedules", Summary: "Add blackout dates",
+			Description: "Whole days in UTC, end date included; matching and job scheduling skip them.",
+			Request:     model.BlackoutDateRequest{}, Response: model.BlackoutDate{}, Status: http.StatusCreated},
+		{Method: http.MethodDelete, Path: "/api/v1/gigworkers/{id}/blackout-dates/{blackoutId}", Tag: "Schedules", Summary: "Delete blackout dates",
+			Response: successResponse},
+
+		// Waitlist and markets
+		{Method: http.MethodPost, Path: "/api/v1/waitlist", Tag: "Markets", Summary: "Join the waitlist for an unlaunched market",
+			Request: model.WaitlistSignupRequest{}, Response: successResponse, Status: http.StatusAccepted},
+		{Method: http.MethodGet, Path: "/api/v1/waitlist", Tag: "Markets", Summary: "List waitlist signups",
+			Query: withPaging(
+				openapi.Param{Name: "market", Example: ""},
+				openapi.Param{Name: "role", Example: ""},
+				openapi.Param{Name: "city", Example: ""},
+				openapi.Param{Name: "state", Example: ""},
+				openapi.Param{Name: "unassigned", Example: false},
+			),
+			Response: openapi.Fields{"signups": []model.WaitlistSignup{}, "pagination": paginated}},
+		{Method: http.MethodGet, Path: "/api/v1/markets", Tag: "Markets", Summary: "Markets with waitlist demand",
+			Response: openapi.Fields{"markets": []model.MarketDemand{}}},
+		{Method: http.MethodPost, Path: "/api/v1/markets/{id}/launch", Tag: "Markets", Summary: "Launch a market and invite its waitlist",
+			Response: withSuccess(openapi.Fields{"launch": model.MarketLaunchResponse{}})},
+		{Method: http.MethodGet, Path: "/public/stats", Tag: "Markets", Summary: "Marketplace aggregates for public counters; cached for PUBLIC_STATS_CACHE_TTL", Response: model.PublicStats{}},
+		{Method: http.MethodGet, Path: "/.well-known/reputation-keys.json", Tag: "Users", Summary: "Public keys for verifying reputation exports, as a JSON Web Key Set",
+			Response: openapi.Fields{"keys": []reputation.JWK{{}}}},
+		{Method: http.MethodGet, Path: "/l/{token}", Tag: "Users", Summary: "Follow a deep link from an email or push notification",
+			Description: "Counts the click and redirects to the app page for the link's action, which receives the token. Expired, forged and used single-use links redirect to the app's /link-expired page.",
+			Status:      http.StatusFound},
+
+		// Fraud
+		{Method: http.MethodGet, Path: "/api/v1/fraud/flags", Tag: "Fraud", Summary: "List fraud flags",
+			Query: withPaging(
+				openapi.Param{Name: "status", Example: "", Description: "open (default), dismissed, confirmed or all"},
+				openapi.Param{Name: "user_id", Example: 0},
+				openapi.Param{Name: "min_score", Example: 0.0},
+			),
+			Response: openapi.Fields{"flags": []model.FraudFlag{}, "alert_threshold": 0.0, "pagination": paginated}},
+		{Method: http.MethodPut, Path: "/api/v1/fraud/flags/{id}", Tag: "Fraud", Summary: "Dismiss or confirm a fraud flag",
+			Request: model.FraudFlagReviewRequest{}, Response: withSuccess(openapi.Fields{"flag": model.FraudFlag{}})},
+
+		// Shadow evaluation
+		{Method: http.MethodGet, Path: "/api/v1/shadow/report", Tag: "Shadow", Summary: "Compare shadow candidates with production",
+			Description: "Candidates are configured with SHADOW_PRICING_RULES and SHADOW_MATCHING_ENGINES; their outputs never affect jobs.",
+			Query: []openapi.Param{
+				{Name: "kind", Example: "", Description: "pricing or matching", Required: true},
+				{Name: "from", Example: "", Description: "Start date, YYYY-MM-DD; defaults to 7 days before to"},
+				{Name: "to", Example: "", Description: "End date, YYYY-MM-DD; defaults to now"},
+			},
+			Response: model.ShadowReport{}},
+
+		// Analytics
+		{Method: http.MethodGet, Path: "/api/v1/analytics/funnel", Tag: "Analytics", Summary: "Job funnel with time in stage",
+			Description: "Counts jobs posted in the window that reached each lifecycle stage, with conversion from posted and median/p90 seconds since the previous stage.",
+			Query: []openapi.Param{
+				{Name: "from", Example: "", Description: "Start date, YYYY-MM-DD; defaults to 30 days before to"},
+				{Name: "to", Example: "", Description: "End date, YYYY-MM-DD; defaults to now"},
+			},
+			Response: model.FunnelReport{}},
+		{Method: http.MethodGet, Path: "/api/v1/analytics/surveys", Tag: "Analytics", Summary: "Satisfaction survey scores",
+			Description: "CSAT (share of 4-5 answers) and NPS (promoters minus detractors) for surveys sent in the window, overall and by job category and market, with the response rate.",
+			Query: []openapi.Param{
+				{Name: "from", Example: "", Description: "Start date, YYYY-MM-DD; defaults to 30 days before to"},
+				{Name: "to", Example: "", Description: "End date, YYYY-MM-DD; defaults to now"},
+			},
+			Response: model.SurveyReport{}},
+		{Method: http.MethodGet, Path: "/api/v1/analytics/offer-declines", Tag: "Analytics", Summary: "Job offer outcomes and decline reasons",
+			Description: "How workers answered the offers sent in the window, overall and by job category: accept rate, declines by reason, expiries and snoozes, and the average amount of accepted offers against those declined as paying too little.",
+			Query: []openapi.Param{
+				{Name: "from", Example: "", Description: "Start date, YYYY-MM-DD; defaults to 30 days before to"},
+				{Name: "to", Example: "", Description: "End date, YYYY-MM-DD; defaults to now"},
+			},
+			Response: model.OfferDeclineReport{ByCategory: []model.OfferOutcomeSegment{{}}}},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/{id}/events", Tag: "Analytics", Summary: "Job lifecycle history",
+			Description: "Every lifecycle transition recorded for the job, in order, with the state they replay to.",
+			Response:    openapi.Fields{"job_id": 0, "events": []jobevents.Event{}, "state": jobevents.State{}}},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/{id}/workflow", Tag: "Jobs", Summary: "Job workflow state",
+			Description: "Queries the job's Temporal workflow for its live state, next to the job's stored status, to debug stuck jobs.",
+			Response:    JobWorkflowStatus{}},
+	}
+}
+
+// openAPIConfig describes the API and how routes authenticate
+func openAPIConfig() openapi.Config {
+	return openapi.Config{
+		Info: openapi.Info{
+			Title:       "GigCo API",
+			Description: "GigCo platform API for gig workers and job management",
+			Version:     apiChangelog[len(apiChangelog)-1].Version,
+			Contact:     &openapi.Contact{Name: "API Support"},
+			License:     &openapi.License{Name: "MIT", URL: "https://opensource.org/licenses/MIT"},
+		},
+		Servers: []openapi.Server{{URL: "/", Description: "Current host"}},
+		Tags: []openapi.Tag{
+			{Name: "Auth", Description: "Registration, login and password recovery"},
+			{Name: "Account", Description: "Account deletion and reactivation"},
+			{Name: "Users"},
+			{Name: "Admin", Description: "Admin dashboard: search, queues, metrics and CSV export"},
+			{Name: "Gig Workers"},
+			{Name: "Worker Applications", Description: "Applying to become a gig worker and admin screening"},
+			{Name: "Worker Documents", Description: "ID, license and insurance uploads and admin review"},
+			{Name: "Jobs", Description: "Job posting, offers and the job lifecycle"},
+			{Name: "Incidents", Description: "In-job safety incidents"},
+			{Name: "Expenses", Description: "Worker expenses, mileage and parts purchases"},
+			{Name: "Attachments", Description: "Uploaded receipts, documents and photos, scanned for malware"},
+			{Name: "Support", Description: "Job message threads and support tickets"},
+			{Name: "Reviews"},
+			{Name: "Payments", Description: "Escrow payments, receipts and spend export"},
+			{Name: "Payouts", Description: "Worker payouts and settlement batches"},
+			{Name: "Disputes", Description: "Consumer payment disputes and their resolution"},
+			{Name: "Accounting", Description: "QuickBooks and Xero sync"},
+			{Name: "Schedules"},
+			{Name: "Markets", Description: "Waitlist signups, market launches and public marketplace stats"},
+			{Name: "Fraud", Description: "Fraud flag review"},
+			{Name: "Health"},
+		},
+		Changelog:      apiChangelog,
+		ErrorModel:     model.ErrorResponse{},
+		Authenticators: []func(http.Handler) http.Handler{middleware.JWTAuth},
+		RateLimiters:   []func(http.Handler) http.Handler{middleware.RateLimitAuth, middleware.RateLimitUser},
+		Roles:          []string{"admin", "consumer", "gig_worker"},
+		RoleContextKey: "user_role",
+		Overrides: map[reflect.Type]*openapi.Schema{
+			reflect.TypeOf(model.NullString{}): {Type: "string", Nullable: true},
+			reflect.TypeOf(model.Money{}):      {Type: "number", Format: "double"},
+		},
+	}
+}
+
+// GenerateOpenAPI builds the OpenAPI document for a router
+func GenerateOpenAPI(routes chi.Routes) (*openapi.Document, error) {
+	return openapi.Generate(routes, openAPIConfig(), OpenAPIRoutes())
+}
+
+var (
+	openAPIOnce sync.Once
+	openAPIDoc  *openapi.Document
+	openAPIErr  error
+)
+
+// GetOpenAPISpec serves the OpenAPI 3 document for the router handling the request
+func GetOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	openAPIOnce.Do(func() {
+		rctx := chi.RouteContext(r.Context())
+		if rctx == nil || rctx.Routes == nil {
+			openAPIErr = errors.New("no router in request context")
+			return
+		}
+		openAPIDoc, openAPIErr = GenerateOpenAPI(rctx.Routes)
+	})
+	if openAPIErr != nil {
+		slog.ErrorContext(r.Context(), "Error generating OpenAPI document", "error", openAPIErr)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to generate API specification")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, openAPIDoc)
+}
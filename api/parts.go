@@ -0,0 +1,362 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/email"
+	"app/internal/model"
+	"app/internal/validate"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const partsRequestColumns = `
+	id, uuid, job_id, gig_worker_id, item_name, quantity, unit_price, total_amount, photo_url, note,
+	status, reviewed_at, review_note, transaction_id, billed_at, created_at
+`
+
+// scanPartsRequest scans a job_parts_requests row selected with partsRequestColumns
+func scanPartsRequest(row rowScanner) (*model.PartsRequest, error) {
+	var p model.PartsRequest
+	var note, reviewNote sql.NullString
+	var reviewedAt, billedAt sql.NullTime
+	var transactionID sql.NullInt64
+
+	err := row.Scan(
+		&p.ID, &p.UUID, &p.JobID, &p.GigWorkerID, &p.ItemName, &p.Quantity, &p.UnitPrice, &p.TotalAmount,
+		&p.PhotoURL, &note, &p.Status, &reviewedAt, &reviewNote, &transactionID, &billedAt, &p.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Note = stringPtrFromNull(note)
+	p.ReviewedAt = timePtrFromNull(reviewedAt)
+	p.ReviewNote = stringPtrFromNull(reviewNote)
+	p.TransactionID = intPtrFromNull(transactionID)
+	p.BilledAt = timePtrFromNull(billedAt)
+	return &p, nil
+}
+
+// ==============================================
+// PARTS REQUESTS
+// ==============================================
+
+// CreatePartsRequest asks the consumer to approve buying parts for an active job
+func CreatePartsRequest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	var req model.PartsRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	req.ItemName = strings.TrimSpace(req.ItemName)
+	req.PhotoURL = strings.TrimSpace(req.PhotoURL)
+	req.Note = strings.TrimSpace(req.Note)
+	if req.Quantity == 0 {
+		req.Quantity = 1
+	}
+	var v validate.Validator
+	v.Required("item_name", req.ItemName)
+	v.Length("item_name", req.ItemName, 0, 255)
+	if req.Quantity < 1 || req.Quantity > 1000 {
+		v.AddValue("quantity", "must be between 1 and 1000", strconv.Itoa(req.Quantity))
+	}
+	if req.UnitPrice <= 0 {
+		v.AddValue("unit_price", "must be greater than 0", fmt.Sprintf("%.2f", req.UnitPrice))
+	}
+	v.Required("photo_url", req.PhotoURL)
+	v.Length("note", req.Note, 0, 1000)
+	if err := v.Err(); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+
+	unitPrice := math.Round(req.UnitPrice*100) / 100
+	total := math.Round(unitPrice*float64(req.Quantity)*100) / 100
+	if total > 10000 {
+		RespondWithValidationError(w, &ValidationError{Field: "unit_price", Message: "request total must not exceed 10000", Value: fmt.Sprintf("%.2f", total)})
+		return
+	}
+
+	job, ok := getExpenseJob(w, r, jobID)
+	if !ok {
+		return
+	}
+	if !job.isAssignedWorker(userID) {
+		RespondWithError(w, http.StatusForbidden, "Only the assigned worker can request parts")
+		return
+	}
+	switch job.Status {
+	case "worker_assigned", "scheduled", "in_progress":
+	default:
+		RespondWithError(w, http.StatusConflict, "Parts can only be requested before the job is completed")
+		return
+	}
+
+	parts, err := scanPartsRequest(config.DB.QueryRow(`
+		INSERT INTO job_parts_requests (job_id, gig_worker_id, item_name, quantity, unit_price, total_amount, photo_url, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING `+partsRequestColumns,
+		jobID, userID, req.ItemName, req.Quantity, unitPrice, total, req.PhotoURL, nullString(req.Note),
+	))
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error creating parts request", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to create parts request")
+		return
+	}
+
+	go notifyPartsRequest(r.Context(), *parts, job.Title, job.ConsumerID)
+
+	RespondWithJSON(w, http.StatusCreated, parts)
+}
+
+// GetPartsRequests lists a job's parts requests for its participants
+func GetPartsRequests(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	job, ok := getExpenseJob(w, r, jobID)
+	if !ok {
+		return
+	}
+	if !job.isAssignedWorker(userID) && userID != job.ConsumerID && GetUserRoleFromContext(r) != "admin" {
+		RespondWithError(w, http.StatusForbidden, "Only job participants can view parts requests")
+		return
+	}
+
+	rows, err := config.DB.Query(
+		`SELECT `+partsRequestColumns+` FROM job_parts_requests WHERE job_id = $1 ORDER BY created_at`, jobID,
+	)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying parts requests", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	requests := []model.PartsRequest{}
+	var approvedTotal float64
+	for rows.Next() {
+		p, err := scanPartsRequest(rows)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning parts request", "error", err)
+			continue
+		}
+		if p.Status == model.PartsRequestStatusApproved {
+			approvedTotal += p.TotalAmount
+		}
+		requests = append(requests, *p)
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"parts_requests": requests,
+		"approved_total": math.Round(approvedTotal*100) / 100,
+	})
+}
+
+// ReviewPartsRequest lets the consumer approve or decline a pending parts request.
+// Approved parts are added to the job's payment capture.
+func ReviewPartsRequest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestId"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid parts request ID format")
+		return
+	}
+
+	var req model.PartsReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	req.Note = strings.TrimSpace(req.Note)
+	if len(req.Note) > 500 {
+		RespondWithValidationError(w, &ValidationError{Field: "note", Message: "must not exceed 500 characters"})
+		return
+	}
+
+	job, ok := getExpenseJob(w, r, jobID)
+	if !ok {
+		return
+	}
+	if userID != job.ConsumerID {
+		RespondWithError(w, http.StatusForbidden, "Only the consumer can review parts requests")
+		return
+	}
+	if !job.acceptsExpenses() {
+		RespondWithError(w, http.StatusConflict, "Parts can only be approved before payment")
+		return
+	}
+
+	status := model.PartsRequestStatusDeclined
+	if req.Approve {
+		status = model.PartsRequestStatusApproved
+	}
+
+	parts, err := scanPartsRequest(config.DB.QueryRow(`
+		UPDATE job_parts_requests
+		SET status = $1, reviewed_at = NOW(), review_note = $2
+		WHERE id = $3 AND job_id = $4 AND status = 'pending'
+		RETURNING `+partsRequestColumns,
+		status, nullString(req.Note), requestID, jobID,
+	))
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "No pending parts request found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error reviewing parts request", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to review parts request")
+		return
+	}
+
+	go notifyPartsReviewed(r.Context(), *parts, job.Title)
+
+	RespondWithJSON(w, http.StatusOK, parts)
+}
+
+// CancelPartsRequest withdraws a worker's pending parts request
+func CancelPartsRequest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestId"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid parts request ID format")
+		return
+	}
+
+	parts, err := scanPartsRequest(config.DB.QueryRow(`
+		UPDATE job_parts_requests SET status = 'cancelled'
+		WHERE id = $1 AND job_id = $2 AND gig_worker_id = $3 AND status = 'pending'
+		RETURNING `+partsRequestColumns,
+		requestID, jobID, userID,
+	))
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "No pending parts request found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error cancelling parts request", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to cancel parts request")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, parts)
+}
+
+// notifyPartsRequest emails the consumer a parts request awaiting approval
+func notifyPartsRequest(ctx context.Context, parts model.PartsRequest, jobTitle string, consumerID int) {
+	message := fmt.Sprintf("Your worker needs to buy %d x %s ($%.2f total) to continue. Please approve or decline.",
+		parts.Quantity, parts.ItemName, parts.TotalAmount)
+	sendPartsNotification(ctx, parts, jobTitle, consumerID, message, "Review parts request")
+}
+
+// notifyPartsReviewed emails the worker the consumer's decision on a parts request
+func notifyPartsReviewed(ctx context.Context, parts model.PartsRequest, jobTitle string) {
+	message := fmt.Sprintf("Your request for %s ($%.2f) was approved and will be added to the job payment.",
+		parts.ItemName, parts.TotalAmount)
+	if parts.Status == model.PartsRequestStatusDeclined {
+		message = fmt.Sprintf("Your request for %s ($%.2f) was declined.", parts.ItemName, parts.TotalAmount)
+	}
+	if parts.ReviewNote != nil {
+		message += " Note: " + *parts.ReviewNote
+	}
+	sendPartsNotification(ctx, parts, jobTitle, parts.GigWorkerID, message, "View parts requests")
+}
+
+// sendPartsNotification emails a job participant about a parts request
+func sendPartsNotification(ctx context.Context, parts model.PartsRequest, jobTitle string, recipientID int, message, actionLabel string) {
+	emailService, err := email.NewServiceFromEnv()
+	if err != nil {
+		slog.ErrorContext(ctx, "Email not configured, parts request notification not sent", "parts_id", parts.ID, "error", err)
+		return
+	}
+
+	var toEmail, toName string
+	err = config.DB.QueryRow(`SELECT email, name FROM people WHERE id = $1`, recipientID).Scan(&toEmail, &toName)
+	if err != nil {
+		slog.ErrorContext(ctx, "Database error getting parts request recipient", "recipient_id", recipientID, "error", err)
+		return
+	}
+
+	baseURL := os.Getenv("APP_BASE_URL")
+	if baseURL == "" {
+		baseURL = "https://app.gigco.com"
+	}
+
+	err = emailService.SendJobNotification(toEmail, toName, email.JobNotificationData{
+		UserName:    toName,
+		JobTitle:    jobTitle,
+		JobID:       strconv.Itoa(parts.JobID),
+		Message:     message,
+		ActionLink:  fmt.Sprintf("%s/jobs/%d/parts/%d", baseURL, parts.JobID, parts.ID),
+		ActionLabel: actionLabel,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to send parts request notification", "parts_id", parts.ID, "error", err)
+	}
+}
@@ -2,25 +2,75 @@ package api
 
 import (
 	"app/config"
+	"app/internal/audit"
+	"app/internal/currency"
 	"app/internal/model"
 	"app/internal/payment"
+	"app/internal/realtime"
+	"app/internal/temporal"
+	"app/internal/temporal/workflows"
+	"app/internal/validate"
+	"context"
 	"encoding/json"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 )
 
 var paymentService *payment.PaymentService
 
+// fxRates converts quoted prices for display. It is set from FX_RATES by
+// InitPaymentService; assign another currency.RateProvider to use a rates service.
+var fxRates currency.RateProvider = currency.StaticRates{}
+
 // InitPaymentService initializes the payment service
 func InitPaymentService() {
 	if config.Payment == nil {
 		config.InitPaymentConfig()
 	}
-	paymentService = payment.NewPaymentService(config.DB, &config.Payment.Clover)
-	log.Println("Payment service initialized")
+	provider, err := payment.NewProvider(config.Payment)
+	if err != nil {
+		slog.Error("Invalid payment provider, falling back", "fallback", payment.ProviderClover, "error", err)
+		provider = payment.NewCloverProvider(&config.Payment.Clover)
+	}
+	merchants, err := payment.NewMarketMerchants(config.Payment)
+	if err != nil {
+		slog.Error("Invalid market merchant accounts, every market pays the default merchant", "error", err)
+	}
+	paymentService = payment.NewPaymentService(config.DB, provider, config.Payment.SalesTaxPercent).
+		WithMerchants(config.Payment.MerchantID(), merchants)
+	if rates, err := currency.ParseRates(config.Payment.FXRates); err != nil {
+		slog.Error("Invalid FX_RATES, amounts will not be converted for display", "error", err)
+	} else {
+		fxRates = rates
+	}
+	payoutService = payment.NewPayoutService(config.DB, provider, time.Duration(config.Payment.PayoutHoldHours*float64(time.Hour)))
+	slog.Info("Payment service initialized", "name", provider.Name(), "market_merchants", len(merchants))
+}
+
+const (
+	// idempotencyKeyHeader lets clients retry authorize, capture and refund without
+	// repeating the charge
+	idempotencyKeyHeader = "Idempotency-Key"
+	// idempotentReplayedHeader marks a response that repeats an earlier request's result
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// readIdempotencyKey returns the request's Idempotency-Key header, or "" when it has
+// none. Returns false after writing an error response for keys that are too long.
+func readIdempotencyKey(w http.ResponseWriter, r *http.Request) (string, bool) {
+	key := strings.TrimSpace(r.Header.Get(idempotencyKeyHeader))
+	if len(key) > payment.MaxIdempotencyKeyLength {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("%s must not exceed %d characters", idempotencyKeyHeader, payment.MaxIdempotencyKeyLength))
+		return "", false
+	}
+	return key, true
 }
 
 // ==============================================
@@ -30,43 +80,246 @@ func InitPaymentService() {
 // AuthorizeJobPayment creates a pre-authorization for a job payment
 func AuthorizeJobPayment(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	// Get user ID from auth context
 	userID := GetUserIDFromContext(r)
 	if userID == 0 {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req model.PaymentAuthorizeRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid request body")
+		return
+	}
+	if err := validatePaymentAuthorizeRequest(&req); err != nil {
+		RespondWithValidationErrors(w, err)
 		return
 	}
+	key, ok := readIdempotencyKey(w, r)
+	if !ok {
+		return
+	}
+	req.IdempotencyKey = key
 
 	if paymentService == nil {
 		InitPaymentService()
 	}
 
-	resp, err := paymentService.AuthorizeJobPayment(userID, req)
+	resp, err := paymentService.AuthorizeJobPayment(r.Context(), userID, req)
 	if err != nil {
-		log.Printf("Failed to authorize payment: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(model.ErrorResponse{
-			Error: err.Error(),
-		})
+		slog.ErrorContext(r.Context(), "Failed to authorize payment", "error", err)
+		respondPaymentError(w, err)
 		return
 	}
 
+	if resp.Replayed {
+		w.Header().Set(idempotentReplayedHeader, "true")
+	} else {
+		auditPayment(r, audit.ActionPaymentAuthorized, resp.TransactionID, nil, map[string]interface{}{"job_id": req.JobID})
+		publishPaymentEvent(r, resp.Transaction)
+		go startEscrowWorkflow(r.Context(), workflows.EscrowInput{TransactionID: resp.TransactionID, JobID: req.JobID})
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(resp)
 }
 
+// startEscrowWorkflow starts the workflow that renews the authorization before it
+// expires and captures it after the job completes. Without it the hold lapses unless
+// the consumer captures in time, which payment reconciliation reports.
+func startEscrowWorkflow(ctx context.Context, input workflows.EscrowInput) {
+	temporalClient, err := temporal.NewClient()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create Temporal client", "error", err)
+		return
+	}
+	defer temporalClient.Close()
+
+	if _, err := temporalClient.StartEscrowWorkflow(context.WithoutCancel(ctx), input); err != nil {
+		slog.ErrorContext(ctx, "Failed to start escrow workflow for transaction", "transaction_id", input.TransactionID, "error", err)
+	}
+}
+
+// signalEscrowWorkflows asks the escrow workflows of a job's unsettled authorizations
+// to re-check them, e.g. to schedule auto-capture once the job completes, or to hold
+// it while a dispute, incident or ticket is open
+func signalEscrowWorkflows(ctx context.Context, jobID int) {
+	rows, err := config.DB.Query(`
+		SELECT id FROM transactions
+		WHERE job_id = $1 AND transaction_type = 'authorization'
+		  AND captured_at IS NULL AND refunded_at IS NULL AND status NOT IN ('failed', 'refunded')
+	`, jobID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Database error loading authorizations for job", "job_id", jobID, "error", err)
+		return
+	}
+	defer rows.Close()
+
+	var workflowIDs []string
+	for rows.Next() {
+		var transactionID int
+		if err := rows.Scan(&transactionID); err != nil {
+			slog.ErrorContext(ctx, "Database error scanning authorization", "error", err)
+			return
+		}
+		workflowIDs = append(workflowIDs, workflows.EscrowWorkflowID(transactionID))
+	}
+	if len(workflowIDs) == 0 {
+		return
+	}
+
+	go func() {
+		temporalClient, err := temporal.NewClient()
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to create Temporal client", "error", err)
+			return
+		}
+		defer temporalClient.Close()
+
+		for _, workflowID := range workflowIDs {
+			if err := temporalClient.SignalEscrowUpdated(context.WithoutCancel(ctx), workflowID); err != nil {
+				slog.ErrorContext(ctx, "Failed to signal escrow workflow for job", "job_id", jobID, "error", err)
+			}
+		}
+	}()
+}
+
+// ==============================================
+// PRICE BREAKDOWN
+// ==============================================
+
+// GetJobPriceBreakdown itemizes what the consumer will be charged for a job before
+// they authorize payment. ?display_currency= adds the amounts converted to another
+// currency; the charge stays in the job's currency.
+func GetJobPriceBreakdown(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	displayCurrency := r.URL.Query().Get("display_currency")
+	if displayCurrency != "" {
+		var v validate.Validator
+		validateCurrency(&v, "display_currency", &displayCurrency)
+		if err := v.Err(); err != nil {
+			RespondWithValidationErrors(w, err)
+			return
+		}
+	}
+
+	if paymentService == nil {
+		InitPaymentService()
+	}
+
+	breakdown, err := paymentService.QuoteJob(jobID, userID)
+	if err != nil {
+		status := paymentErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			slog.ErrorContext(r.Context(), "Failed to price job", "job_id", jobID, "error", err)
+			RespondWithError(w, status, "Failed to calculate price")
+			return
+		}
+		respondPaymentError(w, err)
+		return
+	}
+
+	if displayCurrency != "" && displayCurrency != breakdown.Currency {
+		amounts, rate, err := currency.Convert(r.Context(), fxRates, displayCurrency,
+			breakdown.Subtotal, breakdown.Credits, breakdown.Tax, breakdown.Total)
+		if errors.Is(err, currency.ErrNoRate) {
+			respondError(w, http.StatusUnprocessableEntity, model.ErrCodeValidation,
+				fmt.Sprintf("No exchange rate from %s to %s", breakdown.Currency, displayCurrency))
+			return
+		}
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Failed to convert price for display", "job_id", jobID, "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Failed to calculate price")
+			return
+		}
+		breakdown.Display = &model.CurrencyConversion{
+			Currency: displayCurrency,
+			Rate:     rate,
+			Subtotal: amounts[0],
+			Credits:  amounts[1],
+			Tax:      amounts[2],
+			Total:    amounts[3],
+		}
+	}
+
+	RespondWithJSON(w, http.StatusOK, breakdown)
+}
+
+// paymentErrorStatus maps pricing and payment errors to HTTP status codes
+func paymentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, payment.ErrIdempotencyKeyReused):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, payment.ErrJobNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, payment.ErrNotJobConsumer):
+		return http.StatusForbidden
+	case errors.Is(err, payment.ErrJobNotPriced):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, payment.ErrPriceChanged):
+		return http.StatusConflict
+	case errors.Is(err, payment.ErrPaymentDeclined):
+		return http.StatusPaymentRequired
+	case errors.Is(err, payment.ErrJobNotTippable), errors.Is(err, payment.ErrAlreadyTipped):
+		return http.StatusConflict
+	case errors.Is(err, payment.ErrJobOnHold), errors.Is(err, payment.ErrPaymentBusy):
+		return http.StatusConflict
+	case errors.Is(err, payment.ErrInvalidTip):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, payment.ErrCurrencyMismatch):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, payment.ErrProviderRateLimited):
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// paymentErrorCode maps pricing and payment errors to error codes
+func paymentErrorCode(err error) string {
+	switch {
+	case errors.Is(err, payment.ErrIdempotencyKeyReused):
+		return model.ErrCodeIdempotencyKeyReused
+	case errors.Is(err, payment.ErrJobNotFound):
+		return model.ErrCodeJobNotFound
+	case errors.Is(err, payment.ErrPriceChanged):
+		return model.ErrCodePriceChanged
+	case errors.Is(err, payment.ErrPaymentDeclined):
+		return model.ErrCodePaymentDeclined
+	case errors.Is(err, payment.ErrJobNotTippable):
+		return model.ErrCodeJobIncomplete
+	case errors.Is(err, payment.ErrInvalidTip):
+		return model.ErrCodeValidation
+	case errors.Is(err, payment.ErrCurrencyMismatch):
+		return model.ErrCodeCurrencyMismatch
+	case errors.Is(err, payment.ErrJobOnHold):
+		return model.ErrCodeJobOnHold
+	}
+	return model.ErrorCodeForStatus(paymentErrorStatus(err))
+}
+
+// respondPaymentError sends a payment service error with its status and code
+func respondPaymentError(w http.ResponseWriter, err error) {
+	status := paymentErrorStatus(err)
+	respondError(w, status, paymentErrorCode(err), err.Error())
+}
+
 // ==============================================
 // PAYMENT CAPTURE (RELEASE FROM ESCROW)
 // ==============================================
@@ -74,37 +327,47 @@ func AuthorizeJobPayment(w http.ResponseWriter, r *http.Request) {
 // CaptureJobPayment captures a previously authorized payment
 func CaptureJobPayment(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	userID := GetUserIDFromContext(r)
 	if userID == 0 {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req model.PaymentCaptureRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid request body")
 		return
 	}
+	key, ok := readIdempotencyKey(w, r)
+	if !ok {
+		return
+	}
+	req.IdempotencyKey = key
 
 	if paymentService == nil {
 		InitPaymentService()
 	}
 
-	resp, err := paymentService.CaptureJobPayment(userID, req)
+	before := paymentSnapshot(r, req.TransactionID)
+	resp, err := paymentService.CaptureJobPayment(r.Context(), userID, req)
 	if err != nil {
-		log.Printf("Failed to capture payment: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(model.ErrorResponse{
-			Error: err.Error(),
-		})
+		slog.ErrorContext(r.Context(), "Failed to capture payment", "error", err)
+		respondPaymentError(w, err)
 		return
 	}
 
+	if resp.Replayed {
+		w.Header().Set(idempotentReplayedHeader, "true")
+	} else {
+		auditPayment(r, audit.ActionPaymentCaptured, req.TransactionID, before, nil)
+		publishPaymentEvent(r, resp.Transaction)
+		go emailPaymentDocuments(context.WithoutCancel(r.Context()), resp.TransactionID)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(resp)
@@ -117,42 +380,127 @@ func CaptureJobPayment(w http.ResponseWriter, r *http.Request) {
 // RefundJobPayment refunds a payment
 func RefundJobPayment(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	userID := GetUserIDFromContext(r)
 	if userID == 0 {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req model.PaymentRefundRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid request body")
 		return
 	}
+	key, ok := readIdempotencyKey(w, r)
+	if !ok {
+		return
+	}
+	req.IdempotencyKey = key
 
 	if paymentService == nil {
 		InitPaymentService()
 	}
 
-	resp, err := paymentService.RefundJobPayment(userID, req)
+	before := paymentSnapshot(r, req.TransactionID)
+	resp, err := paymentService.RefundJobPayment(r.Context(), userID, req)
 	if err != nil {
-		log.Printf("Failed to refund payment: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(model.ErrorResponse{
-			Error: err.Error(),
-		})
+		slog.ErrorContext(r.Context(), "Failed to refund payment", "error", err)
+		respondPaymentError(w, err)
 		return
 	}
 
+	if resp.Replayed {
+		w.Header().Set(idempotentReplayedHeader, "true")
+	} else {
+		auditPayment(r, audit.ActionPaymentRefunded, req.TransactionID, before, map[string]interface{}{"refund_transaction_id": resp.RefundID})
+		publishPaymentEvent(r, resp.Transaction)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(resp)
 }
 
+// ==============================================
+// TIPS
+// ==============================================
+
+// TipJob charges the consumer a tip for the worker of a completed job
+func TipJob(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	var req model.JobTipRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid request body")
+		return
+	}
+	if !req.Amount.IsPositive() || req.Amount.Cents > payment.MaxTip.Cents {
+		RespondWithValidationError(w, &ValidationError{
+			Field:   "amount",
+			Message: fmt.Sprintf("must be more than 0 and at most $%s", payment.MaxTip),
+			Value:   req.Amount.String(),
+		})
+		return
+	}
+	key, ok := readIdempotencyKey(w, r)
+	if !ok {
+		return
+	}
+	req.IdempotencyKey = key
+
+	if paymentService == nil {
+		InitPaymentService()
+	}
+
+	resp, err := paymentService.TipJob(r.Context(), userID, jobID, req)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to tip job", "job_id", jobID, "error", err)
+		respondPaymentError(w, err)
+		return
+	}
+
+	if resp.Replayed {
+		w.Header().Set(idempotentReplayedHeader, "true")
+	} else {
+		auditPayment(r, audit.ActionPaymentTipped, resp.TransactionID, nil, map[string]interface{}{"job_id": jobID})
+		publishPaymentEvent(r, resp.Transaction)
+	}
+
+	RespondWithJSON(w, http.StatusOK, resp)
+}
+
+// publishPaymentEvent tells the job's participants a payment changed state
+func publishPaymentEvent(r *http.Request, txn *model.EnhancedTransaction) {
+	if txn == nil {
+		return
+	}
+	amount := txn.Amount.Dollars()
+	err := realtime.PublishJobEvent(r.Context(), config.DB, appFrom(r.Context()).Clock, realtime.Event{
+		Type:   realtime.EventPayment,
+		JobID:  txn.JobID,
+		Status: string(txn.Status),
+		Amount: &amount,
+		Data:   map[string]interface{}{"transaction_id": txn.ID},
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to publish payment event for transaction", "txn_id", txn.ID, "error", err)
+	}
+}
+
 // ==============================================
 // PAYMENT SUMMARY FOR JOB
 // ==============================================
@@ -160,20 +508,20 @@ func RefundJobPayment(w http.ResponseWriter, r *http.Request) {
 // GetJobPaymentSummary returns payment summary for a job
 func GetJobPaymentSummary(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	idParam := chi.URLParam(r, "id")
 	jobID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid job ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
 		return
 	}
 
 	userID := GetUserIDFromContext(r)
 	if userID == 0 {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
@@ -187,11 +535,12 @@ func GetJobPaymentSummary(w http.ResponseWriter, r *http.Request) {
 		&summary.PlatformFees,
 		&summary.WorkerPayment,
 		&summary.EscrowStatus,
+		&summary.TotalTips,
 	)
 
 	if err != nil {
-		log.Printf("Failed to get payment summary: %v", err)
-		http.Error(w, "Failed to get payment summary", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Failed to get payment summary", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to get payment summary")
 		return
 	}
 
@@ -209,20 +558,20 @@ func GetJobPaymentSummary(w http.ResponseWriter, r *http.Request) {
 // GetJobTransactions returns all transactions for a job
 func GetJobTransactions(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	idParam := chi.URLParam(r, "id")
 	jobID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid job ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
 		return
 	}
 
 	userID := GetUserIDFromContext(r)
 	if userID == 0 {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
@@ -233,6 +582,7 @@ func GetJobTransactions(w http.ResponseWriter, r *http.Request) {
 			status, transaction_type,
 			COALESCE(clover_charge_id, '') as clover_charge_id,
 			COALESCE(clover_payment_id, '') as clover_payment_id,
+			payment_provider, provider_charge_id, provider_refund_id,
 			authorized_at, captured_at, capture_amount,
 			processing_fee, platform_fee, net_amount,
 			escrow_held_at, escrow_released_at,
@@ -245,8 +595,8 @@ func GetJobTransactions(w http.ResponseWriter, r *http.Request) {
 
 	rows, err := config.DB.Query(query, jobID)
 	if err != nil {
-		log.Printf("Failed to query transactions: %v", err)
-		http.Error(w, "Failed to get transactions", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Failed to query transactions", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to get transactions")
 		return
 	}
 	defer rows.Close()
@@ -260,6 +610,7 @@ func GetJobTransactions(w http.ResponseWriter, r *http.Request) {
 			&t.ID, &t.UUID, &t.JobID, &t.ConsumerID, &t.GigWorkerID,
 			&t.Amount, &t.Currency, &t.Status, &t.TransactionType,
 			&cloverChargeID, &cloverPaymentID,
+			&t.PaymentProvider, &t.ProviderChargeID, &t.ProviderRefundID,
 			&t.AuthorizedAt, &t.CapturedAt, &t.CaptureAmount,
 			&t.ProcessingFee, &t.PlatformFee, &t.NetAmount,
 			&t.EscrowHeldAt, &t.EscrowReleasedAt,
@@ -267,7 +618,7 @@ func GetJobTransactions(w http.ResponseWriter, r *http.Request) {
 			&t.CreatedAt, &t.UpdatedAt,
 		)
 		if err != nil {
-			log.Printf("Failed to scan transaction: %v", err)
+			slog.ErrorContext(r.Context(), "Failed to scan transaction", "error", err)
 			continue
 		}
 
@@ -0,0 +1,234 @@
+package api
+
+import (
+	"app/internal/model"
+	"app/internal/payment"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+var payoutService *payment.PayoutService
+
+// GetGigWorkerPayouts lists a gig worker's payouts, newest first. Only the worker or
+// an admin may see them.
+func GetGigWorkerPayouts(w http.ResponseWriter, r *http.Request) {
+	gigWorkerID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid gig worker ID format")
+		return
+	}
+
+	page, err := ParseIntParam(r, "page", 1, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	limit, err := ParseIntParam(r, "limit", DefaultPageSize, MinPageSize, MaxPageSize)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	if GetUserRoleFromContext(r) != "admin" && GetUserIDFromContext(r) != gigWorkerID {
+		RespondWithError(w, http.StatusForbidden, "You can only view your own payouts")
+		return
+	}
+
+	if !requireGigWorkerExists(w, r, gigWorkerID) {
+		return
+	}
+
+	payouts, total, err := getPayoutService().ListWorkerPayouts(r.Context(), gigWorkerID, limit, (page-1)*limit)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing payouts for gig worker", "gig_worker_id", gigWorkerID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	pages := (total + limit - 1) / limit
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"payouts": payouts,
+		"pagination": model.Pagination{
+			Page:    page,
+			Limit:   limit,
+			Total:   total,
+			Pages:   pages,
+			HasNext: page < pages,
+			HasPrev: page > 1,
+		},
+	})
+}
+
+// GetSettlementBatches lists settlement batches, newest first
+func GetSettlementBatches(w http.ResponseWriter, r *http.Request) {
+	page, err := ParseIntParam(r, "page", 1, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	limit, err := ParseIntParam(r, "limit", DefaultPageSize, MinPageSize, MaxPageSize)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", model.SettlementBatchPending, model.SettlementBatchProcessed, model.SettlementBatchFailed, model.SettlementBatchReconciled:
+	default:
+		RespondWithValidationError(w, &ValidationError{
+			Field:   "status",
+			Message: "must be pending, processed, failed or reconciled",
+			Value:   status,
+		})
+		return
+	}
+
+	batches, total, err := getPayoutService().ListBatches(r.Context(), status, limit, (page-1)*limit)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing settlement batches", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	pages := (total + limit - 1) / limit
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"batches": batches,
+		"pagination": model.Pagination{
+			Page:    page,
+			Limit:   limit,
+			Total:   total,
+			Pages:   pages,
+			HasNext: page < pages,
+			HasPrev: page > 1,
+		},
+	})
+}
+
+// GetSettlementBatch returns a settlement batch with its payouts
+func GetSettlementBatch(w http.ResponseWriter, r *http.Request) {
+	batchID, ok := settlementBatchID(w, r)
+	if !ok {
+		return
+	}
+
+	batch, err := getPayoutService().GetBatch(r.Context(), batchID)
+	if err != nil {
+		respondWithPayoutError(w, r, batchID, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, batch)
+}
+
+// CreateSettlementBatch settles captures now instead of waiting for the schedule and
+// pays the batch out
+func CreateSettlementBatch(w http.ResponseWriter, r *http.Request) {
+	var req model.SettlementBatchRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+			return
+		}
+	}
+
+	cutoff := appFrom(r.Context()).Clock.Now()
+	if req.Cutoff != nil {
+		if req.Cutoff.After(cutoff) {
+			RespondWithValidationError(w, &ValidationError{
+				Field:   "cutoff",
+				Message: "must not be in the future",
+				Value:   req.Cutoff.Format(time.RFC3339),
+			})
+			return
+		}
+		cutoff = *req.Cutoff
+	}
+
+	service := getPayoutService()
+	batch, err := service.CreateBatch(r.Context(), cutoff)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to create settlement batch", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to create settlement batch")
+		return
+	}
+	if batch == nil {
+		RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+			"success": true,
+			"message": "No captured payments are waiting to be settled",
+		})
+		return
+	}
+
+	processed, err := service.ProcessBatch(r.Context(), batch.ID)
+	if err != nil {
+		respondWithPayoutError(w, r, batch.ID, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusCreated, processed)
+}
+
+// ProcessSettlementBatch retries a batch's pending and failed payouts
+func ProcessSettlementBatch(w http.ResponseWriter, r *http.Request) {
+	batchID, ok := settlementBatchID(w, r)
+	if !ok {
+		return
+	}
+
+	batch, err := getPayoutService().ProcessBatch(r.Context(), batchID)
+	if err != nil {
+		respondWithPayoutError(w, r, batchID, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, batch)
+}
+
+// ReconcileSettlementBatch checks a batch's payouts against the transactions it
+// settled, marking it reconciled when everything adds up
+func ReconcileSettlementBatch(w http.ResponseWriter, r *http.Request) {
+	batchID, ok := settlementBatchID(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := getPayoutService().ReconcileBatch(r.Context(), batchID)
+	if err != nil {
+		respondWithPayoutError(w, r, batchID, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, result)
+}
+
+// getPayoutService returns the payout service, initializing payments on first use
+func getPayoutService() *payment.PayoutService {
+	if payoutService == nil {
+		InitPaymentService()
+	}
+	return payoutService
+}
+
+func settlementBatchID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid settlement batch ID format")
+		return 0, false
+	}
+	return id, true
+}
+
+func respondWithPayoutError(w http.ResponseWriter, r *http.Request, batchID int, err error) {
+	switch {
+	case errors.Is(err, payment.ErrSettlementBatchNotFound):
+		RespondWithError(w, http.StatusNotFound, "Settlement batch not found")
+	case errors.Is(err, payment.ErrSettlementBatchClosed):
+		RespondWithError(w, http.StatusConflict, "Settlement batch is already reconciled")
+	default:
+		slog.ErrorContext(r.Context(), "Failed to handle settlement batch", "batch_id", batchID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"app/config"
+	"app/internal/auth"
+	"app/internal/model"
+	"app/internal/notifications"
+)
+
+// Phone verification codes are phoneCodeDigits long and work for phoneCodeTTL, with at
+// most phoneCodeMaxAttempts wrong guesses. A new one can be sent every phoneCodeResendInterval.
+const (
+	phoneCodeDigits         = 6
+	phoneCodeTTL            = 10 * time.Minute
+	phoneCodeMaxAttempts    = 5
+	phoneCodeResendInterval = time.Minute
+)
+
+// deliveryKindPhoneVerification is the delivery ledger kind of verification texts
+const deliveryKindPhoneVerification = "phone_verification"
+
+// VerifyPhoneRequest is the code texted to the caller's phone
+type VerifyPhoneRequest struct {
+	Code string `json:"code"`
+}
+
+// SendPhoneVerificationCode texts a code to the phone number on the caller's profile,
+// superseding codes sent earlier
+func SendPhoneVerificationCode(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	var phone sql.NullString
+	var verified bool
+	var lastSent sql.NullTime
+	err := config.DB.QueryRowContext(r.Context(), `
+		SELECT p.phone, COALESCE(p.phone_verified, false),
+		       (SELECT MAX(c.created_at) FROM phone_verification_codes c WHERE c.user_id = p.id)
+		FROM people p WHERE p.id = $1
+	`, userID).Scan(&phone, &verified, &lastSent)
+	if err == sql.ErrNoRows {
+		respondError(w, http.StatusNotFound, model.ErrCodeUserNotFound, "User not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error loading phone number", "user_id", userID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if !phone.Valid || strings.TrimSpace(phone.String) == "" {
+		RespondWithValidationError(w, &ValidationError{Field: "phone", Message: "add a phone number to your profile first"})
+		return
+	}
+	if _, ok := notifications.E164(phone.String); !ok {
+		RespondWithValidationError(w, &ValidationError{Field: "phone", Message: "must be a North American number that can receive texts", Value: phone.String})
+		return
+	}
+	if verified {
+		respondError(w, http.StatusConflict, model.ErrCodeConflict, "Phone number is already verified")
+		return
+	}
+	now := appFrom(r.Context()).Clock.Now()
+	if lastSent.Valid {
+		if wait := lastSent.Time.Add(phoneCodeResendInterval).Sub(now); wait > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			respondError(w, http.StatusTooManyRequests, model.ErrCodeRateLimited, "A code was sent recently; wait a minute before asking for another")
+			return
+		}
+	}
+
+	sender, err := notifications.NewSMSServiceFromEnv()
+	if err != nil {
+		slog.WarnContext(r.Context(), "SMS provider not configured, cannot send phone verification code", "error", err)
+		respondError(w, http.StatusServiceUnavailable, model.ErrCodeServiceUnavailable, "Text messages are not available right now")
+		return
+	}
+
+	code, err := auth.GenerateOTP(phoneCodeDigits)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to generate phone verification code", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	expiresAt := now.Add(phoneCodeTTL)
+	if err := storePhoneVerificationCode(r, userID, phone.String, code, expiresAt); err != nil {
+		slog.ErrorContext(r.Context(), "Database error storing phone verification code", "user_id", userID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	body := fmt.Sprintf("Your GigCo verification code is %s. It expires in %d minutes.", code, int(phoneCodeTTL/time.Minute))
+	if err := sender.SendToUser(r.Context(), notifications.NewLedger(config.DB), userID, phone.String, deliveryKindPhoneVerification, body); err != nil {
+		slog.ErrorContext(r.Context(), "Failed to text phone verification code", "user_id", userID, "error", err)
+		respondError(w, http.StatusServiceUnavailable, model.ErrCodeServiceUnavailable, "Failed to send the verification code; try again")
+		return
+	}
+	slog.InfoContext(r.Context(), "Phone verification code sent", "user_id", userID)
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success":    true,
+		"message":    "Verification code sent",
+		"expires_at": expiresAt,
+	})
+}
+
+// storePhoneVerificationCode stores a new code for the user's phone, superseding any
+// earlier one
+func storePhoneVerificationCode(r *http.Request, userID int, phone, code string, expiresAt time.Time) error {
+	tx, err := config.DB.BeginTx(r.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(r.Context(), `
+		UPDATE phone_verification_codes SET used_at = NOW()
+		WHERE user_id = $1 AND used_at IS NULL
+	`, userID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(r.Context(), `
+		INSERT INTO phone_verification_codes (user_id, phone, code_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+	`, userID, phone, auth.HashToken(code), expiresAt)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// VerifyPhone checks the code texted to the caller and marks their phone verified. Each
+// wrong code uses up one of the code's attempts.
+func VerifyPhone(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	var req VerifyPhoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	req.Code = strings.TrimSpace(req.Code)
+	if req.Code == "" {
+		RespondWithValidationError(w, &ValidationError{Field: "code", Message: "is required"})
+		return
+	}
+
+	verified, err := redeemPhoneVerificationCode(r, userID, req.Code)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error verifying phone", "user_id", userID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to verify phone number")
+		return
+	}
+	if !verified {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidToken, "Invalid or expired verification code")
+		return
+	}
+	slog.InfoContext(r.Context(), "Phone verified for user", "user_id", userID)
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Phone number verified",
+	})
+}
+
+// redeemPhoneVerificationCode checks code against the user's current code. On a match
+// the code is used up and the phone it was sent to verified, if it is still the user's
+// number; otherwise the attempt is counted.
+func redeemPhoneVerificationCode(r *http.Request, userID int, code string) (bool, error) {
+	tx, err := config.DB.BeginTx(r.Context(), nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var codeID, attempts int
+	var phone, codeHash string
+	var expiresAt time.Time
+	err = tx.QueryRowContext(r.Context(), `
+		SELECT id, phone, code_hash, attempts, expires_at
+		FROM phone_verification_codes
+		WHERE user_id = $1 AND used_at IS NULL
+		ORDER BY created_at DESC
+		LIMIT 1
+		FOR UPDATE
+	`, userID).Scan(&codeID, &phone, &codeHash, &attempts, &expiresAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if attempts >= phoneCodeMaxAttempts || !appFrom(r.Context()).Clock.Now().Before(expiresAt) {
+		return false, nil
+	}
+
+	if subtle.ConstantTimeCompare([]byte(auth.HashToken(code)), []byte(codeHash)) != 1 {
+		if _, err := tx.ExecContext(r.Context(), `UPDATE phone_verification_codes SET attempts = attempts + 1 WHERE id = $1`, codeID); err != nil {
+			return false, err
+		}
+		return false, tx.Commit()
+	}
+
+	if _, err := tx.ExecContext(r.Context(), `UPDATE phone_verification_codes SET used_at = NOW() WHERE id = $1`, codeID); err != nil {
+		return false, err
+	}
+	result, err := tx.ExecContext(r.Context(), `
+		UPDATE people SET phone_verified = true, updated_at = $3
+		WHERE id = $1 AND phone = $2
+	`, userID, phone, appFrom(r.Context()).Clock.Now())
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if n == 0 {
+		// The number changed after the code was sent; the code is spent either way
+		return false, tx.Commit()
+	}
+	return true, tx.Commit()
+}
@@ -0,0 +1,422 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/model"
+	"app/internal/storage"
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lib/pq"
+)
+
+// Minimum length of a photo's shorter side, in pixels
+const (
+	avatarMinSide   = 128
+	jobPhotoMinSide = 320
+)
+
+const jobPhotoColumns = `
+	jp.id, jp.uuid, jp.job_id, jp.stage, jp.attachment_id, jp.uploaded_by, a.content_type,
+	a.width, a.height, a.scan_status, a.thumbnail_key, jp.created_at
+`
+
+// scanJobPhoto scans a job_photos row (jp) joined to its attachment (a) selected with
+// jobPhotoColumns, and signs its URLs
+func scanJobPhoto(row rowScanner) (*model.JobPhoto, error) {
+	var p model.JobPhoto
+	var uploadedBy sql.NullInt64
+	var thumbnailKey *string
+
+	err := row.Scan(
+		&p.ID, &p.UUID, &p.JobID, &p.Stage, &p.AttachmentID, &uploadedBy, &p.ContentType,
+		&p.Width, &p.Height, &p.ScanStatus, &thumbnailKey, &p.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.UploadedBy = intPtrFromNull(uploadedBy)
+
+	p.URL, p.ThumbnailURL, err = photoURLs(&model.Attachment{ID: p.AttachmentID, ScanStatus: p.ScanStatus, ThumbnailKey: thumbnailKey})
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// avatarFrom returns the avatar stored in an attachment, with signed URLs
+func avatarFrom(a *model.Attachment) (*model.Avatar, error) {
+	photoURL, thumbnailURL, err := photoURLs(a)
+	if err != nil {
+		return nil, err
+	}
+	return &model.Avatar{
+		AttachmentID: a.ID,
+		ContentType:  a.ContentType,
+		Width:        a.Width,
+		Height:       a.Height,
+		ScanStatus:   a.ScanStatus,
+		URL:          photoURL,
+		ThumbnailURL: thumbnailURL,
+	}, nil
+}
+
+// deletePhoto deletes a photo's attachment record, then its file and thumbnail.
+// Files left behind by a storage failure are only logged, since nothing links to them.
+func deletePhoto(ctx context.Context, attachmentID int) error {
+	var objectKey string
+	var thumbnailKey sql.NullString
+	err := config.DB.QueryRow(`DELETE FROM attachments WHERE id = $1 RETURNING object_key, thumbnail_key`, attachmentID).Scan(&objectKey, &thumbnailKey)
+	if err != nil {
+		return err
+	}
+
+	store, err := getAttachmentStore()
+	if err != nil {
+		slog.ErrorContext(ctx, "Photo files not deleted: attachment storage unavailable", "key", objectKey, "error", err)
+		return nil
+	}
+	keys := []string{objectKey}
+	if thumbnailKey.Valid {
+		keys = append(keys, thumbnailKey.String)
+	}
+	for _, key := range keys {
+		if err := store.Delete(ctx, key); err != nil {
+			slog.ErrorContext(ctx, "Failed to delete photo file", "key", key, "error", err)
+		}
+	}
+	return nil
+}
+
+// ==============================================
+// AVATARS
+// ==============================================
+
+// UploadAvatar sets the caller's profile photo from the multipart "file" field,
+// replacing and deleting any previous one
+func UploadAvatar(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	attachment, ok := savePhoto(w, r, model.AttachmentOwnerUser, userID, fmt.Sprintf("users/%d", userID), avatarMinSide)
+	if !ok {
+		return
+	}
+	if attachment.ScanStatus == storage.ScanInfected {
+		RespondWithError(w, http.StatusUnprocessableEntity, "The file failed a malware scan and was not accepted")
+		return
+	}
+
+	var previousID sql.NullInt64
+	err := config.DB.QueryRow(`
+		UPDATE people p SET avatar_attachment_id = $2, updated_at = NOW()
+		FROM (SELECT avatar_attachment_id FROM people WHERE id = $1 FOR UPDATE) previous
+		WHERE p.id = $1
+		RETURNING previous.avatar_attachment_id
+	`, userID, attachment.ID).Scan(&previousID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error setting avatar", "user_id", userID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if previousID.Valid {
+		if err := deletePhoto(r.Context(), int(previousID.Int64)); err != nil {
+			slog.ErrorContext(r.Context(), "Failed to delete previous avatar", "attachment_id", previousID.Int64, "error", err)
+		}
+	}
+
+	avatar, err := avatarFrom(attachment)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to sign avatar", "attachment_id", attachment.ID, "error", err)
+		RespondWithError(w, http.StatusServiceUnavailable, "Downloads are temporarily unavailable")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, avatar)
+}
+
+// DeleteAvatar removes the caller's profile photo
+func DeleteAvatar(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	var previousID sql.NullInt64
+	err := config.DB.QueryRow(`
+		UPDATE people p SET avatar_attachment_id = NULL, updated_at = NOW()
+		FROM (SELECT avatar_attachment_id FROM people WHERE id = $1 FOR UPDATE) previous
+		WHERE p.id = $1
+		RETURNING previous.avatar_attachment_id
+	`, userID).Scan(&previousID)
+	if err != nil && err != sql.ErrNoRows {
+		slog.ErrorContext(r.Context(), "Database error removing avatar", "user_id", userID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if !previousID.Valid {
+		RespondWithError(w, http.StatusNotFound, "No avatar to remove")
+		return
+	}
+	if err := deletePhoto(r.Context(), int(previousID.Int64)); err != nil {
+		slog.ErrorContext(r.Context(), "Failed to delete avatar", "attachment_id", previousID.Int64, "error", err)
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Avatar removed",
+	})
+}
+
+// attachAvatars fills the avatar URLs of the consumer and worker on each job response.
+// Failures are logged and leave the jobs without avatars rather than failing the request.
+func attachAvatars(ctx context.Context, jobs []model.JobResponse) {
+	var summaries []*model.UserSummary
+	var userIDs []int
+	for i := range jobs {
+		for _, summary := range []*model.UserSummary{jobs[i].Consumer, jobs[i].GigWorker} {
+			if summary != nil {
+				summaries = append(summaries, summary)
+				userIDs = append(userIDs, summary.ID)
+			}
+		}
+	}
+	if len(userIDs) == 0 {
+		return
+	}
+
+	// Avatar attachments are owned by their user
+	rows, err := config.DB.QueryContext(ctx, `
+		SELECT `+attachmentColumns+` FROM attachments
+		WHERE id IN (SELECT avatar_attachment_id FROM people WHERE id = ANY($1))
+	`, pq.Array(userIDs))
+	if err != nil {
+		slog.ErrorContext(ctx, "Database error loading avatars", "error", err)
+		return
+	}
+	defer rows.Close()
+
+	avatars := make(map[int]*model.Avatar)
+	for rows.Next() {
+		attachment, err := scanAttachmentRow(rows)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error scanning avatar", "error", err)
+			return
+		}
+		avatar, err := avatarFrom(attachment)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to sign avatar", "attachment_id", attachment.ID, "error", err)
+			return
+		}
+		avatars[attachment.OwnerID] = avatar
+	}
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Database error loading avatars", "error", err)
+		return
+	}
+
+	for _, summary := range summaries {
+		if avatar := avatars[summary.ID]; avatar != nil {
+			summary.AvatarURL = avatar.URL
+			summary.AvatarThumbnailURL = avatar.ThumbnailURL
+		}
+	}
+}
+
+// ==============================================
+// JOB PHOTOS
+// ==============================================
+
+// UploadJobPhoto adds a photo from the multipart "file" field to a job. The stage query
+// parameter says what it shows: the consumer adds posting photos of the work needed, and
+// the assigned worker adds before and after photos around doing it.
+func UploadJobPhoto(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+	stage := r.URL.Query().Get("stage")
+	if stage != model.JobPhotoStagePosting && stage != model.JobPhotoStageBefore && stage != model.JobPhotoStageAfter {
+		RespondWithValidationError(w, &ValidationError{Field: "stage", Message: "must be one of: posting, before, after"})
+		return
+	}
+
+	var consumerID int
+	var workerID sql.NullInt64
+	var status string
+	var photoCount int
+	err = config.DB.QueryRow(`
+		SELECT consumer_id, gig_worker_id, status, (SELECT COUNT(*) FROM job_photos WHERE job_id = jobs.id)
+		FROM jobs WHERE id = $1
+	`, jobID).Scan(&consumerID, &workerID, &status, &photoCount)
+	if err == sql.ErrNoRows {
+		respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting job for photo", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	if stage == model.JobPhotoStagePosting && userID != consumerID {
+		RespondWithError(w, http.StatusForbidden, "Only the job's consumer can add posting photos")
+		return
+	}
+	if stage != model.JobPhotoStagePosting && (!workerID.Valid || int(workerID.Int64) != userID) {
+		RespondWithError(w, http.StatusForbidden, "Only the assigned worker can add before and after photos")
+		return
+	}
+	if !model.JobPhotoAllowed(stage, status) {
+		RespondWithError(w, http.StatusConflict, fmt.Sprintf("%s photos cannot be added to a %s job", stage, status))
+		return
+	}
+	if photoCount >= model.MaxJobPhotos {
+		RespondWithError(w, http.StatusConflict, fmt.Sprintf("Jobs are limited to %d photos", model.MaxJobPhotos))
+		return
+	}
+
+	attachment, ok := savePhoto(w, r, model.AttachmentOwnerJob, jobID, fmt.Sprintf("jobs/%d/photos", jobID), jobPhotoMinSide)
+	if !ok {
+		return
+	}
+	if attachment.ScanStatus == storage.ScanInfected {
+		RespondWithError(w, http.StatusUnprocessableEntity, "The file failed a malware scan and was not accepted")
+		return
+	}
+
+	photo, err := scanJobPhoto(config.DB.QueryRow(`
+		WITH jp AS (
+			INSERT INTO job_photos (job_id, attachment_id, stage, uploaded_by)
+			VALUES ($1, $2, $3, $4)
+			RETURNING *
+		)
+		SELECT `+jobPhotoColumns+` FROM jp JOIN attachments a ON a.id = jp.attachment_id
+	`, jobID, attachment.ID, stage, userID))
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error recording job photo", "attachment_id", attachment.ID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, photo)
+}
+
+// DeleteJobPhoto removes a job photo. Only whoever uploaded it, or an admin, can.
+func DeleteJobPhoto(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+	photoID, err := strconv.Atoi(chi.URLParam(r, "photoId"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid photo ID format")
+		return
+	}
+
+	var attachmentID int
+	var uploadedBy sql.NullInt64
+	err = config.DB.QueryRow(`SELECT attachment_id, uploaded_by FROM job_photos WHERE id = $1 AND job_id = $2`, photoID, jobID).Scan(&attachmentID, &uploadedBy)
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Photo not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting job photo", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if GetUserRoleFromContext(r) != "admin" && (!uploadedBy.Valid || int(uploadedBy.Int64) != userID) {
+		RespondWithError(w, http.StatusForbidden, "Only whoever uploaded a photo can remove it")
+		return
+	}
+
+	// Deleting the attachment removes the job_photos row with it
+	if err := deletePhoto(r.Context(), attachmentID); err != nil {
+		slog.ErrorContext(r.Context(), "Database error deleting job photo", "photo_id", photoID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Photo removed",
+	})
+}
+
+// attachJobPhotos fills Photos on each job response with the photos that passed the
+// malware scan. Posting photos are public with the job; before and after photos are
+// only shown to the consumer, the assigned worker and admins. Failures are logged and
+// leave the jobs without photos rather than failing the request.
+func attachJobPhotos(ctx context.Context, jobs []model.JobResponse, viewerID int, viewerRole string) {
+	if len(jobs) == 0 {
+		return
+	}
+	jobIDs := make([]int, len(jobs))
+	for i, job := range jobs {
+		jobIDs[i] = job.ID
+	}
+
+	rows, err := config.DB.QueryContext(ctx, `
+		SELECT `+jobPhotoColumns+`
+		FROM job_photos jp
+		JOIN attachments a ON a.id = jp.attachment_id
+		WHERE jp.job_id = ANY($1) AND a.scan_status IN ('clean', 'skipped')
+		ORDER BY jp.job_id, array_position($2::text[], jp.stage), jp.created_at, jp.id
+	`, pq.Array(jobIDs), pq.Array(model.JobPhotoStages))
+	if err != nil {
+		slog.ErrorContext(ctx, "Database error loading job photos", "error", err)
+		return
+	}
+	defer rows.Close()
+
+	photos := make(map[int][]model.JobPhoto)
+	for rows.Next() {
+		photo, err := scanJobPhoto(rows)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error scanning job photo", "error", err)
+			return
+		}
+		photos[photo.JobID] = append(photos[photo.JobID], *photo)
+	}
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Database error loading job photos", "error", err)
+		return
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		party := viewerRole == "admin" || viewerID == job.ConsumerID ||
+			(job.GigWorkerID != nil && viewerID == *job.GigWorkerID)
+		for _, photo := range photos[job.ID] {
+			if party || photo.Stage == model.JobPhotoStagePosting {
+				job.Photos = append(job.Photos, photo)
+			}
+		}
+	}
+}
+
+// attachJobMedia fills the photos and avatars on job responses for the requesting user
+func attachJobMedia(r *http.Request, jobs []model.JobResponse) {
+	attachJobPhotos(r.Context(), jobs, GetUserIDFromContext(r), GetUserRoleFromContext(r))
+	attachAvatars(r.Context(), jobs)
+}
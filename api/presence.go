@@ -0,0 +1,46 @@
+package api
+
+import (
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"app/config"
+	"app/internal/model"
+	"app/internal/presence"
+)
+
+// WorkerHeartbeat marks the calling gig worker online. The worker app sends one every
+// next_heartbeat_seconds while it is in the foreground; the worker shows as online
+// until presence.OnlineTTL passes without one.
+func WorkerHeartbeat(w http.ResponseWriter, r *http.Request) {
+	workerID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	now := appFrom(r.Context()).Clock.Now()
+	if err := presence.Record(r.Context(), config.DB, workerID, now); err != nil {
+		slog.ErrorContext(r.Context(), "Database error recording heartbeat", "worker_id", workerID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to record heartbeat")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, model.PresenceHeartbeat{
+		Presence:             string(presence.Online),
+		LastSeenAt:           now,
+		NextHeartbeatSeconds: int(presence.HeartbeatInterval.Seconds()),
+	})
+}
+
+// workerPresence is a worker's presence given their last heartbeat, and whether it
+// earns the "online now" badge
+func workerPresence(lastSeen sql.NullTime, now time.Time) (string, bool) {
+	var seen *time.Time
+	if lastSeen.Valid {
+		seen = &lastSeen.Time
+	}
+	status := presence.StatusAt(seen, now)
+	return string(status), status == presence.Online
+}
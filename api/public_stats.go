@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"app/config"
+	"app/internal/analytics"
+	"app/internal/model"
+)
+
+// defaultPublicStatsTTL is how long public stats are served from memory and by caches
+const defaultPublicStatsTTL = 15 * time.Minute
+
+// statsCache holds the last computed stats so counter widgets on every page view don't
+// reach the database. A failed refresh keeps serving the previous stats.
+type statsCache struct {
+	mu      sync.Mutex
+	stats   *model.PublicStats
+	expires time.Time
+	ttl     time.Duration
+	now     func() time.Time
+	load    func(ctx context.Context, now time.Time) (*model.PublicStats, error)
+}
+
+var publicStats = &statsCache{
+	ttl: publicStatsTTL(),
+	now: time.Now,
+	load: func(ctx context.Context, now time.Time) (*model.PublicStats, error) {
+		return analytics.PublicStats(ctx, config.DB, now)
+	},
+}
+
+// publicStatsTTL reads PUBLIC_STATS_CACHE_TTL (a duration such as 15m)
+func publicStatsTTL() time.Duration {
+	if ttl, err := time.ParseDuration(os.Getenv("PUBLIC_STATS_CACHE_TTL")); err == nil && ttl > 0 {
+		return ttl
+	}
+	return defaultPublicStatsTTL
+}
+
+// get returns the cached stats, refreshing them once they have expired
+func (c *statsCache) get(ctx context.Context) (*model.PublicStats, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if c.stats != nil && now.Before(c.expires) {
+		return c.stats, nil
+	}
+
+	stats, err := c.load(ctx, now)
+	if err != nil {
+		if c.stats != nil {
+			slog.WarnContext(ctx, "Serving stale public stats", "error", err)
+			return c.stats, nil
+		}
+		return nil, err
+	}
+	c.stats = stats
+	c.expires = now.Add(c.ttl)
+	return stats, nil
+}
+
+// GetPublicStats returns non-sensitive marketplace aggregates for the marketing site's
+// live counters. No authentication; responses may be cached by browsers and CDNs.
+func GetPublicStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := publicStats.get(r.Context())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error computing public stats", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	maxAge := int(publicStats.ttl.Seconds())
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d, stale-while-revalidate=%d", maxAge, maxAge))
+	RespondWithJSON(w, http.StatusOK, stats)
+}
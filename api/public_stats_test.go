@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"app/internal/model"
+)
+
+func TestStatsCache(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	loads := 0
+	var loadErr error
+	cache := &statsCache{
+		ttl: 15 * time.Minute,
+		now: func() time.Time { return now },
+		load: func(ctx context.Context, at time.Time) (*model.PublicStats, error) {
+			if loadErr != nil {
+				return nil, loadErr
+			}
+			loads++
+			return &model.PublicStats{JobsCompletedThisMonth: loads, GeneratedAt: at}, nil
+		},
+	}
+
+	tests := []struct {
+		name    string
+		advance time.Duration
+		failing bool
+		want    int // JobsCompletedThisMonth of the stats served
+	}{
+		{name: "first request loads", want: 1},
+		{name: "cached", advance: 10 * time.Minute, want: 1},
+		{name: "expired", advance: 5 * time.Minute, want: 2},
+		{name: "failed refresh serves stale", advance: time.Hour, failing: true, want: 2},
+		{name: "recovered", want: 3},
+	}
+
+	for _, tt := range tests {
+		now = now.Add(tt.advance)
+		loadErr = nil
+		if tt.failing {
+			loadErr = errors.New("database down")
+		}
+		stats, err := cache.get(context.Background())
+		if err != nil {
+			t.Fatalf("%s: get() error = %v", tt.name, err)
+		}
+		if stats.JobsCompletedThisMonth != tt.want {
+			t.Errorf("%s: get() served load %d, want %d", tt.name, stats.JobsCompletedThisMonth, tt.want)
+		}
+	}
+
+	empty := &statsCache{now: time.Now, load: func(context.Context, time.Time) (*model.PublicStats, error) {
+		return nil, errors.New("database down")
+	}}
+	if _, err := empty.get(context.Background()); err == nil {
+		t.Error("get() with nothing cached and a failing load returned no error")
+	}
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"app/config"
+	"app/internal/indexcheck"
+	"app/internal/querylog"
+)
+
+// AdminGetQueryStats lists the queries this API instance has spent the most time in
+// since it started, with their call counts, mean and worst durations and how often
+// they were slow. Each instance keeps its own statistics.
+func AdminGetQueryStats(w http.ResponseWriter, r *http.Request) {
+	limit, err := ParseIntParam(r, "limit", DefaultPageSize, MinPageSize, MaxPageSize)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	cfg := querylog.LoadConfig()
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"queries":           querylog.TopQueries(limit),
+		"slow_threshold_ms": cfg.SlowThreshold.Milliseconds(),
+		"explain_enabled":   cfg.Explain,
+	})
+}
+
+// AdminGetQueryDiagnostics lists the EXPLAIN ANALYZE plans captured for repeatedly slow
+// queries, newest first. fingerprint, as logged with each slow query, narrows the list
+// to one query.
+func AdminGetQueryDiagnostics(w http.ResponseWriter, r *http.Request) {
+	page, err := ParseIntParam(r, "page", 1, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	limit, err := ParseIntParam(r, "limit", DefaultPageSize, MinPageSize, MaxPageSize)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	diagnostics, total, err := querylog.ListDiagnostics(r.Context(), config.DB, r.URL.Query().Get("fingerprint"), limit, (page-1)*limit)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing query diagnostics", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	listing := adminListing{page: page, limit: limit}
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"diagnostics": diagnostics,
+		"pagination":  listing.pagination(total),
+	})
+}
+
+// AdminGetIndexAdvisories reports, for each filtered list query, whether an index
+// serves it, and the statement creating the index for those none does
+func AdminGetIndexAdvisories(w http.ResponseWriter, r *http.Request) {
+	advisories, err := indexcheck.Check(r.Context(), config.DB)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error checking indexes", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"indexes": advisories,
+		"missing": len(indexcheck.Missing(advisories)),
+	})
+}
@@ -0,0 +1,211 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/auth"
+	"app/internal/middleware"
+	"app/internal/model"
+	"app/internal/realtime"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// realtimeHeartbeat is how often idle connections get a heartbeat event
+	realtimeHeartbeat = 30 * time.Second
+	// realtimeWriteTimeout bounds each write to a client
+	realtimeWriteTimeout = 10 * time.Second
+	// longPollHold is the longest a poll waits for an event
+	longPollHold = 25 * time.Second
+	// longPollShortHold is used instead when the response's write deadline can't be
+	// pushed past the server's write timeout
+	longPollShortHold = 10 * time.Second
+)
+
+// realtimeHub delivers events to this server's WebSocket clients
+var realtimeHub = realtime.NewHub()
+
+// StartRealtimeListener feeds events published by handlers and workflow activities
+// to connected WebSocket clients until ctx is cancelled
+func StartRealtimeListener(ctx context.Context) {
+	if err := realtimeHub.Listen(ctx, config.ConnString()); err != nil {
+		slog.ErrorContext(ctx, "Realtime listener stopped, WebSocket clients will not receive events", "error", err)
+	}
+}
+
+// publishJobStatus tells a job's participants its status changed. Failures are
+// logged; clients fall back to polling the job.
+func publishJobStatus(r *http.Request, jobID int, status string) {
+	if err := realtime.PublishJobStatus(r.Context(), config.DB, appFrom(r.Context()).Clock, jobID, status); err != nil {
+		slog.ErrorContext(r.Context(), "Failed to publish status for job", "status", status, "job_id", jobID, "error", err)
+	}
+}
+
+// JobEventsSocket upgrades to a WebSocket that streams the caller's job status,
+// offer and payment events. Browsers cannot set headers on WebSocket requests, so
+// the access token may also be passed as ?token=.
+func JobEventsSocket(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if header := r.Header.Get("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		token = strings.TrimPrefix(header, "Bearer ")
+	}
+	if token == "" {
+		RespondWithError(w, http.StatusUnauthorized, "Missing access token")
+		return
+	}
+
+	claims, err := auth.ValidateJWT(token)
+	if err == auth.ErrExpiredToken {
+		respondError(w, http.StatusUnauthorized, model.ErrCodeTokenExpired, "Token has expired")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, model.ErrCodeInvalidToken, "Invalid token")
+		return
+	}
+
+	server := websocket.Server{
+		Handshake: checkSocketOrigin,
+		Handler: func(ws *websocket.Conn) {
+			serveJobEvents(ws, claims.UserID)
+		},
+	}
+	server.ServeHTTP(w, r)
+}
+
+// checkSocketOrigin allows native clients, which send no Origin, and the origins
+// allowed by CORS_ALLOWED_ORIGINS
+func checkSocketOrigin(_ *websocket.Config, r *http.Request) error {
+	origin := r.Header.Get("Origin")
+	if origin == "" || middleware.DefaultCORSConfig().AllowsOrigin(origin) {
+		return nil
+	}
+	return fmt.Errorf("origin %s not allowed", origin)
+}
+
+func serveJobEvents(ws *websocket.Conn, userID int) {
+	defer ws.Close()
+
+	client := realtimeHub.Subscribe(userID)
+	defer realtimeHub.Unsubscribe(client)
+
+	// The server's read and write timeouts still apply to the hijacked connection;
+	// clear them and bound each write instead
+	ws.SetDeadline(time.Time{})
+
+	// Clients only listen; reading detects when they go away
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var discard string
+		for websocket.Message.Receive(ws, &discard) == nil {
+		}
+	}()
+
+	heartbeat := time.NewTicker(realtimeHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		var event realtime.Event
+		select {
+		case <-closed:
+			return
+		case e, ok := <-client.Events:
+			if !ok {
+				return
+			}
+			event = e
+		case <-heartbeat.C:
+			event = realtime.Event{Type: realtime.EventHeartbeat, Timestamp: appFrom(ws.Request().Context()).Clock.Now().UTC()}
+		}
+
+		ws.SetWriteDeadline(time.Now().Add(realtimeWriteTimeout))
+		if err := websocket.JSON.Send(ws, event); err != nil {
+			return
+		}
+	}
+}
+
+// PollJobEvents is the long-poll fallback to the WebSocket for networks that block
+// WebSockets. It returns the caller's events after the one in ?after=, waiting up to
+// 25 seconds (or ?timeout= seconds) for one when there are none yet. Without ?after=
+// it waits for the caller's next event. reset is true when ?after= has fallen out of
+// the server's backlog; the client should refetch its jobs and offers and continue
+// from the returned cursor.
+func PollJobEvents(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	hold := longPollHold
+	if v := r.URL.Query().Get("timeout"); v != "" {
+		seconds, err := strconv.Atoi(v)
+		if err != nil || seconds < 0 || seconds > int(longPollHold/time.Second) {
+			RespondWithValidationError(w, &ValidationError{Field: "timeout", Message: "must be between 0 and 25 seconds", Value: v})
+			return
+		}
+		hold = time.Duration(seconds) * time.Second
+	}
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(hold + realtimeWriteTimeout)); err != nil && hold > longPollShortHold {
+		hold = longPollShortHold
+	}
+
+	// Subscribe before reading the backlog so no event falls between the two
+	client := realtimeHub.Subscribe(userID)
+	defer realtimeHub.Unsubscribe(client)
+
+	after := r.URL.Query().Get("after")
+	if after == "" {
+		after = realtimeHub.LatestID(userID)
+	}
+	events, ok := realtimeHub.After(userID, after)
+
+	timer := time.NewTimer(hold)
+	defer timer.Stop()
+	for ok && len(events) == 0 {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-timer.C:
+			respondPolledEvents(w, nil, after, false)
+			return
+		case _, open := <-client.Events:
+			events, ok = realtimeHub.After(userID, after)
+			if !open && ok && len(events) == 0 {
+				// Dropped by the hub; the client polls again
+				respondPolledEvents(w, nil, after, false)
+				return
+			}
+		}
+	}
+	if !ok {
+		respondPolledEvents(w, nil, realtimeHub.LatestID(userID), true)
+		return
+	}
+	respondPolledEvents(w, events, after, false)
+}
+
+// respondPolledEvents writes a long-poll response. The cursor is the last event's ID,
+// or after when there are no events.
+func respondPolledEvents(w http.ResponseWriter, events []realtime.Event, after string, reset bool) {
+	cursor := after
+	if len(events) > 0 {
+		cursor = events[len(events)-1].ID
+	}
+	if events == nil {
+		events = []realtime.Event{}
+	}
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"events": events,
+		"cursor": cursor,
+		"reset":  reset,
+	})
+}
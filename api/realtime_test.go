@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"app/internal/auth"
+	"app/internal/realtime"
+
+	"golang.org/x/net/websocket"
+)
+
+func TestJobEventsSocket(t *testing.T) {
+	auth.InitJWT()
+	server := httptest.NewServer(http.HandlerFunc(JobEventsSocket))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("GET without token error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("GET without token status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+
+	token, err := auth.GenerateJWT(42, "00000000-0000-0000-0000-000000000042", "ws@example.com", "consumer")
+	if err != nil {
+		t.Fatalf("GenerateJWT() error = %v", err)
+	}
+
+	config, err := websocket.NewConfig("ws"+strings.TrimPrefix(server.URL, "http")+"/ws?token="+token, server.URL)
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+	if _, err := websocket.DialConfig(config); err == nil {
+		t.Error("DialConfig() from an origin outside CORS_ALLOWED_ORIGINS succeeded")
+	}
+
+	t.Setenv("CORS_ALLOWED_ORIGINS", server.URL)
+	ws, err := websocket.DialConfig(config)
+	if err != nil {
+		t.Fatalf("DialConfig() error = %v", err)
+	}
+	defer ws.Close()
+
+	// Wait for the handler to subscribe before delivering
+	deadline := time.Now().Add(2 * time.Second)
+	for realtimeHub.Connections() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	realtimeHub.Deliver([]int{7}, realtime.Event{Type: realtime.EventJobStatusChanged, JobID: 1, Status: "paid"})
+	realtimeHub.Deliver([]int{42}, realtime.Event{Type: realtime.EventJobStatusChanged, JobID: 2, Status: "scheduled"})
+
+	ws.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var event realtime.Event
+	if err := websocket.JSON.Receive(ws, &event); err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+	if event.JobID != 2 || event.Status != "scheduled" {
+		t.Errorf("received %+v, want job 2 scheduled", event)
+	}
+}
+
+func TestPollJobEvents(t *testing.T) {
+	defer func(hub *realtime.Hub) { realtimeHub = hub }(realtimeHub)
+	realtimeHub = realtime.NewHub()
+	realtimeHub.Deliver([]int{501}, realtime.Event{ID: "e1", Type: realtime.EventJobOffer, JobID: 1})
+	realtimeHub.Deliver([]int{501}, realtime.Event{ID: "e2", Type: realtime.EventJobStatusChanged, JobID: 1})
+
+	tests := []struct {
+		name       string
+		userID     int
+		query      string
+		deliver    *realtime.Event // Delivered to the user while the poll waits
+		wantStatus int
+		wantIDs    []string
+		wantCursor string
+		wantReset  bool
+	}{
+		{name: "events after cursor", userID: 501, query: "after=e1", wantStatus: http.StatusOK, wantIDs: []string{"e2"}, wantCursor: "e2"},
+		{name: "waits for next event", userID: 502, query: "timeout=5", deliver: &realtime.Event{ID: "e3", Type: realtime.EventJobOffer},
+			wantStatus: http.StatusOK, wantIDs: []string{"e3"}, wantCursor: "e3"},
+		{name: "times out", userID: 501, query: "after=e2&timeout=0", wantStatus: http.StatusOK, wantCursor: "e2"},
+		{name: "cursor out of backlog", userID: 501, query: "after=gone", wantStatus: http.StatusOK, wantCursor: "e2", wantReset: true},
+		{name: "timeout too long", userID: 501, query: "timeout=60", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/offers/poll?"+tt.query, nil)
+			r = r.WithContext(context.WithValue(r.Context(), "user_id", tt.userID))
+			w := httptest.NewRecorder()
+
+			if tt.deliver != nil {
+				connected := realtimeHub.Connections()
+				go func() {
+					deadline := time.Now().Add(2 * time.Second)
+					for realtimeHub.Connections() == connected && time.Now().Before(deadline) {
+						time.Sleep(10 * time.Millisecond)
+					}
+					realtimeHub.Deliver([]int{tt.userID}, *tt.deliver)
+				}()
+			}
+			PollJobEvents(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var resp struct {
+				Events []realtime.Event `json:"events"`
+				Cursor string           `json:"cursor"`
+				Reset  bool             `json:"reset"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			var gotIDs []string
+			for _, e := range resp.Events {
+				gotIDs = append(gotIDs, e.ID)
+			}
+			if !slices.Equal(gotIDs, tt.wantIDs) || resp.Cursor != tt.wantCursor || resp.Reset != tt.wantReset {
+				t.Errorf("poll = %v cursor %q reset %v, want %v cursor %q reset %v",
+					gotIDs, resp.Cursor, resp.Reset, tt.wantIDs, tt.wantCursor, tt.wantReset)
+			}
+		})
+	}
+}
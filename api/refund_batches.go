@@ -0,0 +1,305 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"app/internal/currency"
+	"app/internal/model"
+	"app/internal/payment"
+	"app/internal/temporal"
+	"app/internal/validate"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// CreateRefundBatch refunds many captured payments at once, given transaction IDs or
+// a filter. The refunds run in the background; the batch is returned with 202 and
+// its progress read from GetRefundBatch. An Idempotency-Key header is required:
+// repeating it returns the existing batch. With dry_run the payments that would be
+// refunded are returned and nothing is created.
+func CreateRefundBatch(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	var req model.RefundBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	key, ok := readIdempotencyKey(w, r)
+	if !ok {
+		return
+	}
+	req.IdempotencyKey = key
+	if !validateRefundBatchRequest(w, &req) {
+		return
+	}
+
+	if paymentService == nil {
+		InitPaymentService()
+	}
+
+	if req.DryRun {
+		preview, err := paymentService.PreviewRefundBatch(r.Context(), req)
+		if err != nil {
+			respondRefundBatchError(w, r, 0, err)
+			return
+		}
+		RespondWithJSON(w, http.StatusOK, map[string]interface{}{"dry_run": true, "batch": preview})
+		return
+	}
+
+	batch, replayed, err := paymentService.CreateRefundBatch(r.Context(), adminID, req)
+	if err != nil {
+		respondRefundBatchError(w, r, 0, err)
+		return
+	}
+	if replayed {
+		w.Header().Set(idempotentReplayedHeader, "true")
+	}
+
+	// A replayed batch is started again when its first start failed
+	if !replayed || (batch.Status == model.RefundBatchPending && batch.WorkflowID == nil) {
+		if !startRefundBatch(r.Context(), batch) {
+			respondError(w, http.StatusServiceUnavailable, model.ErrCodeServiceUnavailable,
+				"Refund batch was created but could not be started; repeat the request with the same Idempotency-Key")
+			return
+		}
+	}
+
+	status := http.StatusAccepted
+	if replayed {
+		status = http.StatusOK
+	}
+	RespondWithJSON(w, status, batch)
+}
+
+// validateRefundBatchRequest checks the batch selects payments one way, within
+// limits. Returns false after writing the validation error.
+func validateRefundBatchRequest(w http.ResponseWriter, req *model.RefundBatchRequest) bool {
+	req.Reason = strings.TrimSpace(req.Reason)
+
+	var v validate.Validator
+	v.Required("reason", req.Reason)
+	v.Length("reason", req.Reason, 0, 255)
+	v.Check(req.IdempotencyKey != "", "Idempotency-Key", "is required")
+	switch {
+	case len(req.TransactionIDs) > 0 && req.Filter != nil:
+		v.Add("transaction_ids", "give transaction_ids or filter, not both")
+	case len(req.TransactionIDs) == 0 && req.Filter == nil:
+		v.Add("transaction_ids", "give transaction_ids or filter")
+	case len(req.TransactionIDs) > model.MaxRefundBatchSize:
+		v.Add("transaction_ids", fmt.Sprintf("must not list more than %d transactions", model.MaxRefundBatchSize))
+	}
+	seen := make(map[int]bool, len(req.TransactionIDs))
+	for _, id := range req.TransactionIDs {
+		if id <= 0 {
+			v.AddValue("transaction_ids", "must be positive IDs", strconv.Itoa(id))
+			break
+		}
+		if seen[id] {
+			v.AddValue("transaction_ids", "must not repeat a transaction", strconv.Itoa(id))
+			break
+		}
+		seen[id] = true
+	}
+
+	if f := req.Filter; f != nil {
+		v.Check(!f.CapturedFrom.IsZero(), "filter.captured_from", "is required")
+		v.Check(!f.CapturedTo.IsZero(), "filter.captured_to", "is required")
+		v.Check(f.CapturedFrom.IsZero() || f.CapturedTo.IsZero() || f.CapturedTo.After(f.CapturedFrom),
+			"filter.captured_to", "must be after captured_from")
+		if f.PaymentProvider != "" {
+			v.OneOf("filter.payment_provider", f.PaymentProvider, payment.ProviderClover, payment.ProviderStripe)
+		}
+		if f.Currency != "" {
+			code, err := currency.Normalize(f.Currency)
+			if err != nil {
+				v.AddValue("filter.currency", "must be one of "+strings.Join(currency.Codes(), ", "), f.Currency)
+			}
+			f.Currency = code
+		}
+		v.Check(f.ConsumerID == nil || *f.ConsumerID > 0, "filter.consumer_id", "must be positive")
+	}
+
+	if err := v.Err(); err != nil {
+		RespondWithValidationErrors(w, err)
+		return false
+	}
+	return true
+}
+
+// GetRefundBatches lists refund batches with their summaries, newest first
+func GetRefundBatches(w http.ResponseWriter, r *http.Request) {
+	page, err := ParseIntParam(r, "page", 1, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	limit, err := ParseIntParam(r, "limit", DefaultPageSize, MinPageSize, MaxPageSize)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", model.RefundBatchPending, model.RefundBatchRunning, model.RefundBatchCompleted, model.RefundBatchCompletedWithErrors:
+	default:
+		RespondWithValidationError(w, &ValidationError{
+			Field:   "status",
+			Message: "must be pending, running, completed or completed_with_errors",
+			Value:   status,
+		})
+		return
+	}
+
+	if paymentService == nil {
+		InitPaymentService()
+	}
+	batches, total, err := paymentService.ListRefundBatches(r.Context(), status, limit, (page-1)*limit)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing refund batches", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	pages := (total + limit - 1) / limit
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"batches": batches,
+		"pagination": model.Pagination{
+			Page:    page,
+			Limit:   limit,
+			Total:   total,
+			Pages:   pages,
+			HasNext: page < pages,
+			HasPrev: page > 1,
+		},
+	})
+}
+
+// GetRefundBatch returns a refund batch with its summary and the result of each item.
+// With format=csv the items are returned as a CSV report.
+func GetRefundBatch(w http.ResponseWriter, r *http.Request) {
+	batchID, ok := refundBatchID(w, r)
+	if !ok {
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		RespondWithValidationError(w, &ValidationError{Field: "format", Message: "must be json or csv", Value: format})
+		return
+	}
+
+	if paymentService == nil {
+		InitPaymentService()
+	}
+	batch, err := paymentService.GetRefundBatch(r.Context(), batchID)
+	if err != nil {
+		respondRefundBatchError(w, r, batchID, err)
+		return
+	}
+
+	if format == "csv" {
+		records := make([][]string, 0, len(batch.Items))
+		for _, item := range batch.Items {
+			refundID := ""
+			if item.RefundTransactionID != nil {
+				refundID = strconv.Itoa(*item.RefundTransactionID)
+			}
+			records = append(records, []string{
+				strconv.Itoa(item.TransactionID), strconv.Itoa(item.JobID), strconv.Itoa(item.ConsumerID),
+				item.Amount.String(), batch.Currency, item.Status, refundID,
+				formatOptionalString(item.Error), strconv.Itoa(item.Attempts), formatOptionalTime(item.ProcessedAt),
+			})
+		}
+		writeAdminCSV(w, r, "refund-batch-"+strconv.Itoa(batch.ID), []string{
+			"transaction_id", "job_id", "consumer_id", "amount", "currency", "status",
+			"refund_transaction_id", "error", "attempts", "processed_at",
+		}, records)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, batch)
+}
+
+// RetryRefundBatch retries a finished batch's failed refunds, or starts a batch whose
+// first start failed
+func RetryRefundBatch(w http.ResponseWriter, r *http.Request) {
+	batchID, ok := refundBatchID(w, r)
+	if !ok {
+		return
+	}
+
+	if paymentService == nil {
+		InitPaymentService()
+	}
+	batch, err := paymentService.RetryRefundBatch(r.Context(), batchID)
+	if err != nil {
+		respondRefundBatchError(w, r, batchID, err)
+		return
+	}
+	if !startRefundBatch(r.Context(), batch) {
+		respondError(w, http.StatusServiceUnavailable, model.ErrCodeServiceUnavailable, "Refund batch could not be started; try again")
+		return
+	}
+	RespondWithJSON(w, http.StatusAccepted, batch)
+}
+
+// startRefundBatch starts the batch's workflow and records its ID on the batch
+func startRefundBatch(ctx context.Context, batch *model.RefundBatch) bool {
+	temporalClient, err := temporal.NewClient()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create Temporal client", "error", err)
+		return false
+	}
+	defer temporalClient.Close()
+
+	run, err := temporalClient.StartRefundBatchWorkflow(context.WithoutCancel(ctx), batch.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to start refund batch workflow", "batch_id", batch.ID, "error", err)
+		return false
+	}
+
+	workflowID := run.GetID()
+	if err := paymentService.SetRefundBatchWorkflow(ctx, batch.ID, workflowID); err != nil {
+		slog.ErrorContext(ctx, "Failed to record refund batch workflow", "batch_id", batch.ID, "error", err)
+	}
+	batch.WorkflowID = &workflowID
+	return true
+}
+
+func refundBatchID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid refund batch ID format")
+		return 0, false
+	}
+	return id, true
+}
+
+func respondRefundBatchError(w http.ResponseWriter, r *http.Request, batchID int, err error) {
+	switch {
+	case errors.Is(err, payment.ErrRefundBatchNotFound):
+		respondError(w, http.StatusNotFound, model.ErrCodeNotFound, "Refund batch not found")
+	case errors.Is(err, payment.ErrRefundBatchNotRetryable):
+		respondError(w, http.StatusConflict, model.ErrCodeConflict, err.Error())
+	case errors.Is(err, payment.ErrRefundBatchEmpty), errors.Is(err, payment.ErrRefundBatchTooLarge),
+		errors.Is(err, payment.ErrRefundBatchTransactions):
+		respondError(w, http.StatusUnprocessableEntity, model.ErrCodeUnprocessable, err.Error())
+	case errors.Is(err, payment.ErrIdempotencyKeyReused), errors.Is(err, payment.ErrCurrencyMismatch):
+		respondPaymentError(w, err)
+	default:
+		slog.ErrorContext(r.Context(), "Failed to handle refund batch", "batch_id", batchID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"app/config"
+	"app/internal/model"
+	"app/internal/reputation"
+)
+
+// reputationKeysPath publishes the public key set reputation exports are verified with
+const reputationKeysPath = "/.well-known/reputation-keys.json"
+
+// maxReputationReviews caps the reviews in an export at the most recent ones
+const maxReputationReviews = 500
+
+// reputationSigner loads the signing key once. Outside production a missing key is
+// replaced by a throwaway one, so exports work locally but do not verify after restart.
+var reputationSigner = sync.OnceValues(func() (*reputation.Signer, error) {
+	signer, err := reputation.NewSignerFromEnv()
+	if !errors.Is(err, reputation.ErrNotConfigured) || os.Getenv("APP_ENV") == "production" {
+		return signer, err
+	}
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	slog.Warn("REPUTATION_SIGNING_KEY is not set; signing reputation exports with a temporary key")
+	return reputation.NewSigner(key), nil
+})
+
+// loadReputationSigner returns the signer, writing the error response when it is unavailable
+func loadReputationSigner(w http.ResponseWriter, r *http.Request) (*reputation.Signer, bool) {
+	signer, err := reputationSigner()
+	if errors.Is(err, reputation.ErrNotConfigured) {
+		RespondWithError(w, http.StatusServiceUnavailable, "Reputation export is not configured")
+		return nil, false
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Reputation signing key misconfigured", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return nil, false
+	}
+	return signer, true
+}
+
+// GetReputationKeys publishes the public keys reputation exports are signed with, as a
+// JSON Web Key Set
+func GetReputationKeys(w http.ResponseWriter, r *http.Request) {
+	signer, ok := loadReputationSigner(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []reputation.JWK{signer.JWK()},
+	})
+}
+
+// ExportReputation returns the caller's ratings, review history, activity counts and
+// tenure as a signed document they can share with other platforms
+func ExportReputation(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+	signer, ok := loadReputationSigner(w, r)
+	if !ok {
+		return
+	}
+
+	doc, err := buildReputationDocument(r, userID, appFrom(r.Context()).Clock.Now().UTC())
+	if err == sql.ErrNoRows {
+		respondError(w, http.StatusNotFound, model.ErrCodeUserNotFound, "User not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to build reputation export", "user_id", userID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	signed, err := signer.Sign(*doc, doc.Subject.UUID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to sign reputation export", "user_id", userID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	slog.InfoContext(r.Context(), "User exported their reputation", "user_id", userID, "key_id", signer.KeyID())
+	RespondWithJSON(w, http.StatusOK, model.ReputationExport{
+		Reputation:   *doc,
+		Signature:    signed,
+		Algorithm:    reputation.Algorithm,
+		KeyID:        signer.KeyID(),
+		PublicKeyURL: apiBaseURL(r) + reputationKeysPath,
+	})
+}
+
+// buildReputationDocument gathers an active user's public ratings and activity
+func buildReputationDocument(r *http.Request, userID int, now time.Time) (*model.ReputationDocument, error) {
+	ctx := r.Context()
+	doc := &model.ReputationDocument{
+		Ratings:     model.ReputationRatings{Distribution: map[string]int{"1": 0, "2": 0, "3": 0, "4": 0, "5": 0}},
+		Reviews:     []model.ReputationReview{},
+		GeneratedAt: now,
+	}
+
+	err := config.DB.QueryRowContext(ctx, `
+		SELECT uuid, name, role, created_at,
+			(SELECT COUNT(*) FROM jobs WHERE (consumer_id = $1 OR gig_worker_id = $1) AND status = 'completed'),
+			(SELECT COUNT(*) FROM job_reviews WHERE reviewer_id = $1)
+		FROM people
+		WHERE id = $1 AND is_active = true
+	`, userID).Scan(
+		&doc.Subject.UUID, &doc.Subject.Name, &doc.Subject.Role, &doc.MemberSince,
+		&doc.Counts.JobsCompleted, &doc.Counts.ReviewsGiven,
+	)
+	if err != nil {
+		return nil, err
+	}
+	doc.TenureDays = int(now.Sub(doc.MemberSince).Hours() / 24)
+
+	rows, err := config.DB.QueryContext(ctx, `
+		SELECT rating, COUNT(*) FROM job_reviews
+		WHERE reviewee_id = $1 AND is_public = true
+		GROUP BY rating
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	sum := 0
+	for rows.Next() {
+		var rating, count int
+		if err := rows.Scan(&rating, &count); err != nil {
+			return nil, err
+		}
+		doc.Ratings.Distribution[strconv.Itoa(rating)] = count
+		doc.Ratings.Total += count
+		sum += rating * count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if doc.Ratings.Total > 0 {
+		doc.Ratings.Average = math.Round(float64(sum)/float64(doc.Ratings.Total)*100) / 100
+	}
+	doc.Counts.ReviewsReceived = doc.Ratings.Total
+
+	reviewRows, err := config.DB.QueryContext(ctx, `
+		SELECT r.rating, r.review_text, reviewer.role, j.category, r.created_at
+		FROM job_reviews r
+		JOIN people reviewer ON reviewer.id = r.reviewer_id
+		JOIN jobs j ON j.id = r.job_id
+		WHERE r.reviewee_id = $1 AND r.is_public = true
+		ORDER BY r.created_at DESC
+		LIMIT $2
+	`, userID, maxReputationReviews)
+	if err != nil {
+		return nil, err
+	}
+	defer reviewRows.Close()
+	for reviewRows.Next() {
+		var review model.ReputationReview
+		var text, category sql.NullString
+		if err := reviewRows.Scan(&review.Rating, &text, &review.ReviewerRole, &category, &review.CreatedAt); err != nil {
+			return nil, err
+		}
+		review.Text = stringPtrFromNull(text)
+		review.JobCategory = stringPtrFromNull(category)
+		doc.Reviews = append(doc.Reviews, review)
+	}
+	return doc, reviewRows.Err()
+}
+
+// apiBaseURL is the API's public origin: API_BASE_URL when set, otherwise the origin
+// the request was made to
+func apiBaseURL(r *http.Request) string {
+	if baseURL := os.Getenv("API_BASE_URL"); baseURL != "" {
+		return strings.TrimSuffix(baseURL, "/")
+	}
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
@@ -2,15 +2,17 @@ package api
 
 import (
 	"app/config"
+	"app/internal/analytics"
 	"app/internal/model"
+	"app/internal/temporal/workflows"
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -19,29 +21,19 @@ import (
 func CreateReview(w http.ResponseWriter, r *http.Request) {
 	var req model.ReviewRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
 		return
 	}
 
-	// Validate required fields
-	if req.JobID <= 0 {
-		http.Error(w, "Job ID is required", http.StatusBadRequest)
-		return
-	}
-	if req.ReviewerID <= 0 {
-		http.Error(w, "Reviewer ID is required", http.StatusBadRequest)
-		return
-	}
-	if req.RevieweeID <= 0 {
-		http.Error(w, "Reviewee ID is required", http.StatusBadRequest)
-		return
-	}
-	if !model.ValidateRating(req.Rating) {
-		http.Error(w, "Rating must be between 1 and 5", http.StatusBadRequest)
+	reviewerID, ok := RequireUserID(w, r, req.ReviewerID)
+	if !ok {
 		return
 	}
-	if req.ReviewerID == req.RevieweeID {
-		http.Error(w, "Cannot review yourself", http.StatusBadRequest)
+	req.ReviewerID = reviewerID
+
+	// Validate required fields
+	if err := validateReviewRequest(&req); err != nil {
+		RespondWithValidationErrors(w, err)
 		return
 	}
 
@@ -62,17 +54,17 @@ func CreateReview(w http.ResponseWriter, r *http.Request) {
 	err := config.DB.QueryRow(jobQuery, req.JobID).Scan(&jobStatus, &consumerID, &gigWorkerID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			http.Error(w, "Job not found", http.StatusNotFound)
+			respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
 			return
 		}
-		log.Printf("Database error getting job: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error getting job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
 	// Ensure job is completed
-	if jobStatus != "completed" {
-		http.Error(w, "Job must be completed before submitting a review", http.StatusBadRequest)
+	if !slices.Contains(reviewableJobStatuses, jobStatus) {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJobStatus, "Job must be completed before submitting a review")
 		return
 	}
 
@@ -83,7 +75,7 @@ func CreateReview(w http.ResponseWriter, r *http.Request) {
 					 (gigWorkerID.Valid && int(gigWorkerID.Int32) == req.RevieweeID)
 
 	if !validReviewer || !validReviewee {
-		http.Error(w, "Reviewer and reviewee must be participants in this job", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Reviewer and reviewee must be participants in this job")
 		return
 	}
 
@@ -92,11 +84,11 @@ func CreateReview(w http.ResponseWriter, r *http.Request) {
 	checkQuery := `SELECT id FROM job_reviews WHERE job_id = $1 AND reviewer_id = $2`
 	err = config.DB.QueryRow(checkQuery, req.JobID, req.ReviewerID).Scan(&existingID)
 	if err == nil {
-		http.Error(w, "Review already exists for this job", http.StatusConflict)
+		respondError(w, http.StatusConflict, model.ErrCodeReviewExists, "Review already exists for this job")
 		return
 	} else if err != sql.ErrNoRows {
-		log.Printf("Database error checking existing review: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error checking existing review", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -118,11 +110,18 @@ func CreateReview(w http.ResponseWriter, r *http.Request) {
 	err = config.DB.QueryRow(insertQuery, req.JobID, req.ReviewerID, req.RevieweeID, req.Rating, req.ReviewText, isPublic).
 		Scan(&review.ID, &review.UUID, &review.CreatedAt, &review.UpdatedAt)
 	if err != nil {
-		log.Printf("Database error creating review: %v", err)
-		http.Error(w, "Failed to create review", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error creating review", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to create review")
 		return
 	}
 
+	trackFunnel(r, req.JobID, analytics.StageReviewed, map[string]interface{}{"rating": req.Rating})
+	submission := workflows.ReviewSubmission{JobID: req.JobID, ReviewerID: req.ReviewerID, Rating: req.Rating}
+	if req.ReviewText != nil {
+		submission.Comment = *req.ReviewText
+	}
+	signalReviewSubmitted(r.Context(), submission)
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(map[string]interface{}{
@@ -307,8 +306,8 @@ func GetReviews(w http.ResponseWriter, r *http.Request) {
 	var totalCount int
 	err := config.DB.QueryRow(countQuery, args...).Scan(&totalCount)
 	if err != nil {
-		log.Printf("Database error getting review count: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error getting review count", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -320,8 +319,8 @@ func GetReviews(w http.ResponseWriter, r *http.Request) {
 	// Execute query
 	rows, err := config.DB.Query(baseQuery, args...)
 	if err != nil {
-		log.Printf("Database error getting reviews: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error getting reviews", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 	defer rows.Close()
@@ -335,15 +334,15 @@ func GetReviews(w http.ResponseWriter, r *http.Request) {
 			&review.ReviewerName, &review.RevieweeName, &review.JobTitle, &review.JobCategory,
 		)
 		if err != nil {
-			log.Printf("Error scanning review row: %v", err)
+			slog.ErrorContext(r.Context(), "Error scanning review row", "error", err)
 			continue
 		}
 		reviews = append(reviews, review)
 	}
 
 	if err = rows.Err(); err != nil {
-		log.Printf("Row iteration error: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Row iteration error", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -372,7 +371,7 @@ func GetReviewByID(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
 	reviewID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid review ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid review ID format")
 		return
 	}
 
@@ -399,11 +398,11 @@ func GetReviewByID(w http.ResponseWriter, r *http.Request) {
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			http.Error(w, "Review not found", http.StatusNotFound)
+			respondError(w, http.StatusNotFound, model.ErrCodeReviewNotFound, "Review not found")
 			return
 		}
-		log.Printf("Database error getting review: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error getting review", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -411,7 +410,7 @@ func GetReviewByID(w http.ResponseWriter, r *http.Request) {
 	if !review.IsPublic {
 		// Here you could add authorization logic to check if the current user
 		// is the reviewer, reviewee, or an admin
-		http.Error(w, "Review is private", http.StatusForbidden)
+		RespondWithError(w, http.StatusForbidden, "Review is private")
 		return
 	}
 
@@ -424,13 +423,17 @@ func UpdateReview(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
 	reviewID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid review ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid review ID format")
 		return
 	}
 
 	var req model.ReviewUpdateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	if err := validateReviewUpdateRequest(&req); err != nil {
+		RespondWithValidationErrors(w, err)
 		return
 	}
 
@@ -440,16 +443,23 @@ func UpdateReview(w http.ResponseWriter, r *http.Request) {
 	err = config.DB.QueryRow(checkQuery, reviewID).Scan(&existingReview.ID, &existingReview.ReviewerID, &existingReview.RevieweeID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			http.Error(w, "Review not found", http.StatusNotFound)
+			respondError(w, http.StatusNotFound, model.ErrCodeReviewNotFound, "Review not found")
 			return
 		}
-		log.Printf("Database error getting review: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error getting review", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	// Here you could add authorization logic to ensure the current user
-	// is the original reviewer
+	// Only the original reviewer can edit a review
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+	if existingReview.ReviewerID != userID {
+		RespondWithError(w, http.StatusForbidden, "Only the reviewer can update this review")
+		return
+	}
 
 	// Build update query dynamically
 	var updateParts []string
@@ -457,10 +467,6 @@ func UpdateReview(w http.ResponseWriter, r *http.Request) {
 	argIndex := 1
 
 	if req.Rating != nil {
-		if !model.ValidateRating(*req.Rating) {
-			http.Error(w, "Rating must be between 1 and 5", http.StatusBadRequest)
-			return
-		}
 		updateParts = append(updateParts, fmt.Sprintf("rating = $%d", argIndex))
 		args = append(args, *req.Rating)
 		argIndex++
@@ -477,13 +483,13 @@ func UpdateReview(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if len(updateParts) == 0 {
-		http.Error(w, "No fields to update", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "No fields to update")
 		return
 	}
 
 	// Add updated_at
 	updateParts = append(updateParts, fmt.Sprintf("updated_at = $%d", argIndex))
-	args = append(args, time.Now())
+	args = append(args, appFrom(r.Context()).Clock.Now())
 	argIndex++
 
 	// Add WHERE clause
@@ -492,8 +498,8 @@ func UpdateReview(w http.ResponseWriter, r *http.Request) {
 
 	_, err = config.DB.Exec(updateQuery, args...)
 	if err != nil {
-		log.Printf("Database error updating review: %v", err)
-		http.Error(w, "Failed to update review", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error updating review", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update review")
 		return
 	}
 
@@ -509,7 +515,7 @@ func DeleteReview(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
 	reviewID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid review ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid review ID format")
 		return
 	}
 
@@ -519,23 +525,30 @@ func DeleteReview(w http.ResponseWriter, r *http.Request) {
 	err = config.DB.QueryRow(checkQuery, reviewID).Scan(&reviewerID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			http.Error(w, "Review not found", http.StatusNotFound)
+			respondError(w, http.StatusNotFound, model.ErrCodeReviewNotFound, "Review not found")
 			return
 		}
-		log.Printf("Database error getting review: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error getting review", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	// Here you could add authorization logic to ensure the current user
-	// is the original reviewer or an admin
+	// Only the original reviewer or an admin can delete a review
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+	if reviewerID != userID && GetUserRoleFromContext(r) != "admin" {
+		RespondWithError(w, http.StatusForbidden, "Only the reviewer can delete this review")
+		return
+	}
 
 	// Delete review
 	deleteQuery := `DELETE FROM job_reviews WHERE id = $1`
 	_, err = config.DB.Exec(deleteQuery, reviewID)
 	if err != nil {
-		log.Printf("Database error deleting review: %v", err)
-		http.Error(w, "Failed to delete review", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error deleting review", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to delete review")
 		return
 	}
 
@@ -551,7 +564,7 @@ func GetUserReviewStats(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
 	userID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid user ID format")
 		return
 	}
 
@@ -583,11 +596,11 @@ func GetUserReviewStats(w http.ResponseWriter, r *http.Request) {
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			http.Error(w, "User not found", http.StatusNotFound)
+			respondError(w, http.StatusNotFound, model.ErrCodeUserNotFound, "User not found")
 			return
 		}
-		log.Printf("Database error getting user review stats: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error getting user review stats", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -603,7 +616,7 @@ func GetJobReviews(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
 	jobID, err := strconv.Atoi(idParam)
 	if err != nil {
-		http.Error(w, "Invalid job ID format", http.StatusBadRequest)
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
 		return
 	}
 
@@ -625,8 +638,8 @@ func GetJobReviews(w http.ResponseWriter, r *http.Request) {
 
 	rows, err := config.DB.Query(query, jobID)
 	if err != nil {
-		log.Printf("Database error getting job reviews: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error getting job reviews", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 	defer rows.Close()
@@ -640,15 +653,15 @@ func GetJobReviews(w http.ResponseWriter, r *http.Request) {
 			&review.ReviewerName, &review.RevieweeName, &review.JobTitle, &review.JobCategory,
 		)
 		if err != nil {
-			log.Printf("Error scanning review row: %v", err)
+			slog.ErrorContext(r.Context(), "Error scanning review row", "error", err)
 			continue
 		}
 		reviews = append(reviews, review)
 	}
 
 	if err = rows.Err(); err != nil {
-		log.Printf("Row iteration error: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Row iteration error", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -677,18 +690,7 @@ func GetPlatformReviewStats(w http.ResponseWriter, r *http.Request) {
 		WHERE is_public = true
 	`
 
-	var stats struct {
-		TotalReviews      int        `json:"total_reviews"`
-		AverageRating     float64    `json:"average_rating"`
-		Rating5Count      int        `json:"rating_5_count"`
-		Rating4Count      int        `json:"rating_4_count"`
-		Rating3Count      int        `json:"rating_3_count"`
-		Rating2Count      int        `json:"rating_2_count"`
-		Rating1Count      int        `json:"rating_1_count"`
-		ReviewedUsers     int        `json:"reviewed_users"`
-		LatestReviewDate  *time.Time `json:"latest_review_date"`
-		FirstReviewDate   *time.Time `json:"first_review_date"`
-	}
+	var stats model.PlatformReviewStats
 
 	err := config.DB.QueryRow(query).Scan(
 		&stats.TotalReviews, &stats.AverageRating,
@@ -697,8 +699,8 @@ func GetPlatformReviewStats(w http.ResponseWriter, r *http.Request) {
 		&stats.ReviewedUsers, &stats.LatestReviewDate, &stats.FirstReviewDate,
 	)
 	if err != nil {
-		log.Printf("Database error getting platform review stats: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error getting platform review stats", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -759,8 +761,8 @@ func GetTopRatedUsers(w http.ResponseWriter, r *http.Request) {
 
 	rows, err := config.DB.Query(baseQuery, args...)
 	if err != nil {
-		log.Printf("Database error getting top rated users: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Database error getting top rated users", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 	defer rows.Close()
@@ -775,7 +777,7 @@ func GetTopRatedUsers(w http.ResponseWriter, r *http.Request) {
 			&user.LastReviewDate,
 		)
 		if err != nil {
-			log.Printf("Error scanning top rated user row: %v", err)
+			slog.ErrorContext(r.Context(), "Error scanning top rated user row", "error", err)
 			continue
 		}
 
@@ -785,8 +787,8 @@ func GetTopRatedUsers(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err = rows.Err(); err != nil {
-		log.Printf("Row iteration error: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Row iteration error", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/model"
+	"app/internal/recurrence"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// ==============================================
+// RECURRING SCHEDULES
+// ==============================================
+
+// maxOccurrenceWindow is the longest date range GetScheduleOccurrences expands
+const maxOccurrenceWindow = 366 * 24 * time.Hour
+
+// GetScheduleOccurrences expands a worker's schedules, recurring ones included, into
+// the individual slots falling in a date range (default: the next 30 days)
+func GetScheduleOccurrences(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	workerID, err := ParseIntParam(r, "worker_id", 0, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	if workerID == 0 {
+		RespondWithValidationError(w, &ValidationError{Field: "worker_id", Message: "is required"})
+		return
+	}
+
+	startDate, err := ParseDateParam(r, "start_date")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	endDate, err := ParseDateParam(r, "end_date")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	from := appFrom(r.Context()).Clock.Now().UTC().Truncate(24 * time.Hour)
+	if startDate != nil {
+		from = *startDate
+	}
+	to := from.AddDate(0, 0, 30)
+	if endDate != nil {
+		to = *endDate
+	}
+	if !to.After(from) {
+		RespondWithValidationError(w, &ValidationError{Field: "end_date", Message: "must be after start_date"})
+		return
+	}
+	if to.Sub(from) > maxOccurrenceWindow {
+		RespondWithValidationError(w, &ValidationError{Field: "end_date", Message: "must be within a year of start_date"})
+		return
+	}
+
+	rows, err := config.DB.QueryContext(r.Context(), `
+		SELECT id, title, start_time, end_time, is_available, job_id, recurring_pattern, recurring_until
+		FROM schedules
+		WHERE gig_worker_id = $1
+		  AND start_time < $3
+		  AND (end_time > $2 OR (COALESCE(recurring_pattern, '') <> '' AND (recurring_until IS NULL OR recurring_until + (end_time - start_time) > $2)))
+		ORDER BY start_time, id
+	`, workerID, from, to)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying schedules for occurrences", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	occurrences := []model.ScheduleOccurrence{}
+	for rows.Next() {
+		var s model.Schedule
+		if err := rows.Scan(&s.ID, &s.Title, &s.StartTime, &s.EndTime, &s.IsAvailable, &s.JobID,
+			&s.RecurringPattern, &s.RecurringUntil); err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning schedule row", "error", err)
+			continue
+		}
+
+		slots := []recurrence.Slot{{Start: s.StartTime, End: s.EndTime}}
+		if s.RecurringPattern != nil && *s.RecurringPattern != "" {
+			rule, err := recurrence.ParseRule(*s.RecurringPattern)
+			if err != nil {
+				slog.ErrorContext(r.Context(), "Schedule has an invalid recurring pattern", "id", s.ID, "recurring_pattern", *s.RecurringPattern, "error", err)
+			} else {
+				slots = rule.Occurrences(slots[0], s.RecurringUntil, from, to)
+			}
+		}
+		for _, slot := range slots {
+			occurrences = append(occurrences, model.ScheduleOccurrence{
+				ScheduleID:  s.ID,
+				Title:       s.Title,
+				StartTime:   slot.Start,
+				EndTime:     slot.End,
+				IsAvailable: s.IsAvailable,
+				JobID:       s.JobID,
+				Recurring:   s.RecurringPattern != nil && *s.RecurringPattern != "",
+			})
+		}
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].StartTime.Before(occurrences[j].StartTime)
+	})
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"worker_id":   workerID,
+		"start_date":  from,
+		"end_date":    to,
+		"occurrences": occurrences,
+	})
+}
+
+// requireWorkerFree responds 409 and returns false when a job's scheduled time
+// overlaps one of the worker's booked slots. Jobs without a scheduled time pass.
+func requireWorkerFree(w http.ResponseWriter, r *http.Request, workerID, jobID int) bool {
+	var start, end sql.NullTime
+	err := config.DB.QueryRow(`SELECT scheduled_start, scheduled_end FROM jobs WHERE id = $1`, jobID).Scan(&start, &end)
+	if err == sql.ErrNoRows {
+		return true
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting job schedule", "job_id", jobID, "error", err)
+		respondError(w, http.StatusInternalServerError, model.ErrCodeInternal, "Failed to check worker schedule")
+		return false
+	}
+	if !start.Valid || !end.Valid {
+		return true
+	}
+
+	conflicts, err := recurrence.FindConflicts(r.Context(), config.DB, recurrence.Proposal{
+		WorkerID:     workerID,
+		Slot:         recurrence.Slot{Start: start.Time, End: end.Time},
+		ExcludeJobID: &jobID,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error checking schedule conflicts for worker", "worker_id", workerID, "error", err)
+		respondError(w, http.StatusInternalServerError, model.ErrCodeInternal, "Failed to check worker schedule")
+		return false
+	}
+	if len(conflicts) > 0 {
+		respondScheduleConflict(w, "Job conflicts with the worker's booked slots", conflicts)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,276 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/auth"
+	"app/internal/middleware"
+	"app/internal/model"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// errInvalidRefreshToken is returned for refresh tokens that are unknown, expired or
+// belong to a revoked session
+var errInvalidRefreshToken = errors.New("invalid or expired refresh token")
+
+// errRefreshTokenReused is returned when an already-exchanged refresh token is presented
+// again. Either the client or an attacker holds a stale copy, so the session is revoked.
+var errRefreshTokenReused = errors.New("refresh token reused")
+
+// usedTokenRetention is how long exchanged refresh tokens are kept to detect reuse
+const usedTokenRetention = 7 * 24 * time.Hour
+
+// startSession records a new login session for the client making r and returns the
+// session UUID and its first refresh token
+func startSession(r *http.Request, userID int) (string, string, error) {
+	refreshToken, err := auth.GenerateRefreshToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	tx, err := config.DB.Begin()
+	if err != nil {
+		return "", "", err
+	}
+	defer tx.Rollback()
+
+	// Sessions that ended a while ago are no longer useful to list or check for reuse
+	now := appFrom(r.Context()).Clock.Now()
+	_, err = tx.Exec(`
+		DELETE FROM user_sessions
+		WHERE user_id = $1 AND (expires_at < $2 OR revoked_at < $2)
+	`, userID, now.Add(-usedTokenRetention))
+	if err != nil {
+		return "", "", err
+	}
+
+	var sessionID int
+	sessionUUID := appFrom(r.Context()).IDs.NewID()
+	err = tx.QueryRow(`
+		INSERT INTO user_sessions (uuid, user_id, user_agent, ip_address, expires_at)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5)
+		RETURNING id
+	`, sessionUUID, userID, r.UserAgent(), middleware.ClientIP(r), now.Add(auth.RefreshTokenLifetime)).Scan(&sessionID)
+	if err != nil {
+		return "", "", err
+	}
+
+	_, err = tx.Exec(`INSERT INTO refresh_tokens (session_id, token_hash) VALUES ($1, $2)`, sessionID, auth.HashToken(refreshToken))
+	if err != nil {
+		return "", "", err
+	}
+	return sessionUUID, refreshToken, tx.Commit()
+}
+
+// issueSessionTokens starts a session and returns an access token bound to it along
+// with the session's refresh token
+func issueSessionTokens(r *http.Request, userID int, uuid, email, role string) (string, string, error) {
+	sessionUUID, refreshToken, err := startSession(r, userID)
+	if err != nil {
+		return "", "", err
+	}
+	accessToken, err := appFrom(r.Context()).Tokens().GenerateSessionJWT(userID, uuid, email, role, sessionUUID)
+	if err != nil {
+		return "", "", err
+	}
+	return accessToken, refreshToken, nil
+}
+
+// rotateRefreshToken exchanges a refresh token for a new one in the same session, marking
+// the old token used and extending the session. It returns the session's user and UUID.
+func rotateRefreshToken(r *http.Request, token string) (int, string, string, error) {
+	newToken, err := auth.GenerateRefreshToken()
+	if err != nil {
+		return 0, "", "", err
+	}
+
+	tx, err := config.DB.Begin()
+	if err != nil {
+		return 0, "", "", err
+	}
+	defer tx.Rollback()
+
+	var tokenID, sessionID, userID int
+	var sessionUUID string
+	var usedAt, revokedAt sql.NullTime
+	var expiresAt time.Time
+	err = tx.QueryRow(`
+		SELECT t.id, t.used_at, s.id, s.uuid, s.user_id, s.expires_at, s.revoked_at
+		FROM refresh_tokens t
+		JOIN user_sessions s ON s.id = t.session_id
+		WHERE t.token_hash = $1
+		FOR UPDATE OF t, s
+	`, auth.HashToken(token)).Scan(&tokenID, &usedAt, &sessionID, &sessionUUID, &userID, &expiresAt, &revokedAt)
+	if err == sql.ErrNoRows {
+		return 0, "", "", errInvalidRefreshToken
+	}
+	if err != nil {
+		return 0, "", "", err
+	}
+	now := appFrom(r.Context()).Clock.Now()
+	if revokedAt.Valid || !expiresAt.After(now) {
+		return 0, "", "", errInvalidRefreshToken
+	}
+
+	if usedAt.Valid {
+		if _, err := tx.Exec(`UPDATE user_sessions SET revoked_at = $2 WHERE id = $1`, sessionID, now); err != nil {
+			return 0, "", "", err
+		}
+		if err := tx.Commit(); err != nil {
+			return 0, "", "", err
+		}
+		slog.WarnContext(r.Context(), "Refresh token reused, session revoked", "user_id", userID, "session_uuid", sessionUUID)
+		return 0, "", "", errRefreshTokenReused
+	}
+
+	if _, err := tx.Exec(`UPDATE refresh_tokens SET used_at = $2 WHERE id = $1`, tokenID, now); err != nil {
+		return 0, "", "", err
+	}
+	if _, err := tx.Exec(`INSERT INTO refresh_tokens (session_id, token_hash) VALUES ($1, $2)`, sessionID, auth.HashToken(newToken)); err != nil {
+		return 0, "", "", err
+	}
+	_, err = tx.Exec(`
+		DELETE FROM refresh_tokens WHERE session_id = $1 AND used_at < $2
+	`, sessionID, now.Add(-usedTokenRetention))
+	if err != nil {
+		return 0, "", "", err
+	}
+	_, err = tx.Exec(`
+		UPDATE user_sessions
+		SET last_used_at = $5, expires_at = $2, user_agent = COALESCE(NULLIF($3, ''), user_agent), ip_address = COALESCE(NULLIF($4, ''), ip_address)
+		WHERE id = $1
+	`, sessionID, now.Add(auth.RefreshTokenLifetime), r.UserAgent(), middleware.ClientIP(r), now)
+	if err != nil {
+		return 0, "", "", err
+	}
+	return userID, sessionUUID, newToken, tx.Commit()
+}
+
+// revokeSessionByRefreshToken ends the session a refresh token belongs to as of now
+func revokeSessionByRefreshToken(token string, now time.Time) error {
+	_, err := config.DB.Exec(`
+		UPDATE user_sessions SET revoked_at = $2
+		WHERE revoked_at IS NULL AND id = (SELECT session_id FROM refresh_tokens WHERE token_hash = $1)
+	`, auth.HashToken(token), now)
+	return err
+}
+
+// revokeUserSessions ends every session of the user live at now except keepUUID (empty
+// to end them all) and returns how many were ended
+func revokeUserSessions(userID int, keepUUID string, now time.Time) (int64, error) {
+	result, err := config.DB.Exec(`
+		UPDATE user_sessions SET revoked_at = $3
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $3 AND uuid::text <> $2
+	`, userID, keepUUID, now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// ListSessions returns the authenticated user's live sessions, most recently used first,
+// marking the one making the request
+func ListSessions(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	rows, err := config.DB.Query(`
+		SELECT uuid, user_agent, ip_address, created_at, last_used_at, expires_at
+		FROM user_sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+		ORDER BY last_used_at DESC
+	`, userID, appFrom(r.Context()).Clock.Now())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing sessions", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to list sessions")
+		return
+	}
+	defer rows.Close()
+
+	currentID := GetSessionIDFromContext(r)
+	sessions := []model.UserSession{}
+	for rows.Next() {
+		var s model.UserSession
+		if err := rows.Scan(&s.ID, &s.UserAgent, &s.IPAddress, &s.CreatedAt, &s.LastUsedAt, &s.ExpiresAt); err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning session", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Failed to list sessions")
+			return
+		}
+		s.Current = s.ID == currentID
+		sessions = append(sessions, s)
+	}
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(r.Context(), "Database error listing sessions", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to list sessions")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"sessions": sessions,
+	})
+}
+
+// RevokeSession ends one of the authenticated user's sessions. Its refresh token stops
+// working at once; access tokens already issued expire within auth.TokenLifetime.
+func RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	result, err := config.DB.Exec(`
+		UPDATE user_sessions SET revoked_at = $3
+		WHERE uuid::text = $1 AND user_id = $2 AND revoked_at IS NULL
+	`, chi.URLParam(r, "id"), userID, appFrom(r.Context()).Clock.Now())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error revoking session", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to revoke session")
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		RespondWithError(w, http.StatusNotFound, "Session not found")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Session revoked",
+	})
+}
+
+// RevokeOtherSessions ends every session of the authenticated user except the one making
+// the request
+func RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	currentID := GetSessionIDFromContext(r)
+	if currentID == "" {
+		RespondWithError(w, http.StatusBadRequest, "Token is not bound to a session; log in again")
+		return
+	}
+
+	revoked, err := revokeUserSessions(userID, currentID, appFrom(r.Context()).Clock.Now())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error revoking sessions for user", "user_id", userID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to revoke sessions")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"revoked": revoked,
+	})
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/model"
+	"app/internal/shadow"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// defaultShadowReportWindow is the report window when from is not given
+const defaultShadowReportWindow = 7 * 24 * time.Hour
+
+// GetShadowReport compares shadow candidates with the production matching engine
+// or pricing rule
+func GetShadowReport(w http.ResponseWriter, r *http.Request) {
+	kind := r.URL.Query().Get("kind")
+	if kind != model.ShadowKindPricing && kind != model.ShadowKindMatching {
+		RespondWithValidationError(w, &ValidationError{
+			Field:   "kind",
+			Message: "must be pricing or matching",
+			Value:   kind,
+		})
+		return
+	}
+
+	from, err := ParseDateParam(r, "from")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	to, err := ParseDateParam(r, "to")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	end := appFrom(r.Context()).Clock.Now()
+	if to != nil {
+		end = *to
+	}
+	start := end.Add(-defaultShadowReportWindow)
+	if from != nil {
+		start = *from
+	}
+	if !start.Before(end) {
+		RespondWithError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	report, err := shadow.Report(r.Context(), config.DB, kind, start, end)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error building shadow report", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, report)
+}
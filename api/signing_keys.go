@@ -0,0 +1,43 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/auth"
+	"app/internal/vault"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// GetSigningKeys lists the JWT signing keys this instance has loaded, without secrets
+func GetSigningKeys(w http.ResponseWriter, r *http.Request) {
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": auth.SigningKeys(),
+	})
+}
+
+// RotateSigningKey adds a new JWT signing key. It starts signing after the propagation
+// delay, and the keys it replaces keep validating for the overlap window so sessions
+// are not ended.
+func RotateSigningKey(w http.ResponseWriter, r *http.Request) {
+	v, err := vault.NewVaultFromEnv()
+	if errors.Is(err, vault.ErrNotConfigured) {
+		RespondWithError(w, http.StatusServiceUnavailable, "Key rotation requires VAULT_ENCRYPTION_KEY")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Vault misconfigured", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to rotate signing key")
+		return
+	}
+
+	key, err := auth.RotateSigningKey(r.Context(), config.DB, v, auth.KeyOverlap())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to rotate JWT signing key", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to rotate signing key")
+		return
+	}
+
+	slog.InfoContext(r.Context(), "User rotated the JWT signing key", "user_id", GetUserIDFromContext(r), "key_id", key.ID)
+	RespondWithJSON(w, http.StatusCreated, key)
+}
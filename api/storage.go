@@ -0,0 +1,132 @@
+package api
+
+import (
+	"app/internal/storage"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+)
+
+var (
+	attachmentStore     storage.Store
+	attachmentStoreErr  error
+	attachmentStoreOnce sync.Once
+
+	attachmentScanner     storage.Scanner
+	attachmentScannerErr  error
+	attachmentScannerOnce sync.Once
+)
+
+// getAttachmentStore returns the configured attachment store, creating it on first use
+func getAttachmentStore() (storage.Store, error) {
+	attachmentStoreOnce.Do(func() {
+		attachmentStore, attachmentStoreErr = storage.NewStoreFromEnv()
+		if attachmentStoreErr != nil {
+			slog.Error("Attachment storage not available", "error", attachmentStoreErr)
+		} else {
+			slog.Info("Attachment storage initialized", "name", attachmentStore.Name())
+		}
+	})
+	return attachmentStore, attachmentStoreErr
+}
+
+// getAttachmentScanner returns the configured malware scanner, or nil when scanning is
+// turned off
+func getAttachmentScanner() (storage.Scanner, error) {
+	attachmentScannerOnce.Do(func() {
+		attachmentScanner, attachmentScannerErr = storage.NewScannerFromEnv()
+		if attachmentScannerErr != nil {
+			slog.Error("Attachment scanning not available", "error", attachmentScannerErr)
+		} else if attachmentScanner == nil {
+			slog.Warn("STORAGE_SCAN_BACKEND not set, attachments will not be scanned for malware")
+		} else {
+			slog.Info("Attachment scanning initialized", "name", attachmentScanner.Name())
+		}
+	})
+	return attachmentScanner, attachmentScannerErr
+}
+
+// ServeStoredFile serves a local-disk attachment from a signed URL. S3 and GCS signed
+// URLs point at the bucket instead, so this route only answers for the local backend.
+func ServeStoredFile(w http.ResponseWriter, r *http.Request) {
+	store, err := getAttachmentStore()
+	local, ok := store.(*storage.LocalStore)
+	if err != nil || !ok {
+		RespondWithError(w, http.StatusNotFound, "File not found")
+		return
+	}
+
+	key := chi.URLParam(r, "*")
+	if strings.HasPrefix(key, storage.QuarantinePrefix) || strings.HasPrefix(key, storage.IncomingPrefix) {
+		RespondWithError(w, http.StatusNotFound, "File not found")
+		return
+	}
+	query := r.URL.Query()
+	if err := local.Verify(key, query.Get("expires"), query.Get("signature")); err != nil {
+		RespondWithError(w, http.StatusForbidden, "Link is invalid or has expired")
+		return
+	}
+
+	body, obj, err := local.Get(r.Context(), key)
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+		RespondWithError(w, http.StatusNotFound, "File not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to read stored file", "key", key, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer body.Close()
+
+	w.Header().Set("Content-Type", obj.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(obj.Size, 10))
+	w.Header().Set("Cache-Control", "private")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, body); err != nil {
+		slog.ErrorContext(r.Context(), "Failed to send stored file", "key", key, "error", err)
+	}
+}
+
+// ReceiveStoredFile stores a client's upload to a local-disk signed upload URL. Uploads
+// land under storage.IncomingPrefix and are checked when the client submits them.
+func ReceiveStoredFile(w http.ResponseWriter, r *http.Request) {
+	store, err := getAttachmentStore()
+	local, ok := store.(*storage.LocalStore)
+	if err != nil || !ok {
+		RespondWithError(w, http.StatusNotFound, "File not found")
+		return
+	}
+
+	key := chi.URLParam(r, "*")
+	query := r.URL.Query()
+	if !strings.HasPrefix(key, storage.IncomingPrefix) || local.VerifyUpload(key, query.Get("expires"), query.Get("signature")) != nil {
+		RespondWithError(w, http.StatusForbidden, "Link is invalid or has expired")
+		return
+	}
+	if r.ContentLength > storage.MaxUploadBytes() {
+		RespondWithError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("Uploads are limited to %d MB", storage.MaxUploadBytes()>>20))
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, storage.MaxUploadBytes())
+	if err := local.Put(r.Context(), key, body, r.ContentLength, r.Header.Get("Content-Type")); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			RespondWithError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("Uploads are limited to %d MB", storage.MaxUploadBytes()>>20))
+			return
+		}
+		slog.ErrorContext(r.Context(), "Failed to store uploaded file", "key", key, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
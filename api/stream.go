@@ -0,0 +1,108 @@
+package api
+
+import (
+	"app/internal/model"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Formats of a streamed admin export
+const (
+	exportCSV    = "csv"
+	exportNDJSON = "ndjson"
+)
+
+// exportFlushRows is how many rows a streamed export writes between flushes
+const exportFlushRows = 500
+
+// writeJSONList sends {"<key>": [items], "pagination": {...}} encoding one item at a
+// time, so a page is never held as one encoded buffer and its first items reach the
+// client while the rest are encoded
+func writeJSONList[T any](w http.ResponseWriter, key string, items []T, pagination model.Pagination) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	name, _ := json.Marshal(key)
+	fmt.Fprintf(w, "{%s:[", name)
+	enc := json.NewEncoder(w)
+	for i := range items {
+		if i > 0 {
+			io.WriteString(w, ",")
+		}
+		if err := enc.Encode(items[i]); err != nil {
+			// Headers are sent; a truncated body is all that can signal the failure
+			return
+		}
+	}
+	io.WriteString(w, `],"pagination":`)
+	enc.Encode(pagination)
+	io.WriteString(w, "}\n")
+}
+
+// rowExport streams an admin export while its rows are scanned, so memory stays flat
+// however many rows match and the download starts with the first one: CSV with a
+// header row, or NDJSON with one JSON object per line
+type rowExport struct {
+	w    http.ResponseWriter
+	csv  *csv.Writer // nil for NDJSON
+	json *json.Encoder
+	rows int
+}
+
+// startExport starts the download of an export named name; header is the CSV header row
+func (l adminListing) startExport(w http.ResponseWriter, r *http.Request, name string, header []string) *rowExport {
+	e := &rowExport{w: w}
+	filename := fmt.Sprintf("gigco-%s-%s.%s", name, appFrom(r.Context()).Clock.Now().Format("2006-01-02"), l.export)
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+	if l.export == exportNDJSON {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		e.json = json.NewEncoder(w)
+		return e
+	}
+	w.Header().Set("Content-Type", "text/csv")
+	e.csv = csv.NewWriter(w)
+	e.csv.Write(header)
+	return e
+}
+
+// write sends one row: v as a JSON line, or record's fields as a CSV line. An error
+// means the client has gone and the export should stop.
+func (e *rowExport) write(v any, record func() []string) error {
+	var err error
+	if e.csv != nil {
+		err = e.csv.Write(record())
+	} else {
+		err = e.json.Encode(v)
+	}
+	if err != nil {
+		return err
+	}
+
+	e.rows++
+	if e.rows%exportFlushRows == 0 {
+		return e.flush()
+	}
+	return nil
+}
+
+// finish sends the rows not yet flushed
+func (e *rowExport) finish() {
+	e.flush()
+}
+
+func (e *rowExport) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if err := http.NewResponseController(e.w).Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"app/internal/clock"
+	"app/internal/model"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestWriteJSONList(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []model.UserSummary
+	}{
+		{name: "empty", items: nil},
+		{name: "one", items: []model.UserSummary{{ID: 1, Name: "Sam"}}},
+		{name: "several", items: []model.UserSummary{{ID: 1, Name: "Sam"}, {ID: 2, Name: "Jordan"}, {ID: 3, Name: `"Quoted"`}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			pagination := model.Pagination{Page: 1, Limit: 20, Total: len(tt.items), Pages: 1}
+			writeJSONList(w, "users", tt.items, pagination)
+
+			var got struct {
+				Users      []model.UserSummary `json:"users"`
+				Pagination model.Pagination    `json:"pagination"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("body is not JSON: %v\n%s", err, w.Body)
+			}
+			if len(got.Users) != len(tt.items) || (len(tt.items) > 0 && !reflect.DeepEqual(got.Users, tt.items)) {
+				t.Errorf("users = %+v, want %+v", got.Users, tt.items)
+			}
+			if got.Pagination != pagination {
+				t.Errorf("pagination = %+v, want %+v", got.Pagination, pagination)
+			}
+		})
+	}
+}
+
+func TestRowExport(t *testing.T) {
+	users := []model.UserSummary{{ID: 1, Name: "Sam"}, {ID: 2, Name: "Lee, Jordan"}}
+
+	tests := []struct {
+		format      string
+		contentType string
+		want        string
+	}{
+		{exportCSV, "text/csv", "id,name\n1,Sam\n2,\"Lee, Jordan\"\n"},
+		{exportNDJSON, "application/x-ndjson", `{"id":1,"uuid":"","name":"Sam"}` + "\n" + `{"id":2,"uuid":"","name":"Lee, Jordan"}` + "\n"},
+	}
+
+	app := NewApp().WithClock(clock.NewFake(time.Date(2026, 10, 16, 23, 30, 0, 0, time.UTC)), &clock.Sequence{})
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users?format="+tt.format, nil)
+			r = r.WithContext(context.WithValue(r.Context(), appContextKey{}, app))
+			export := adminListing{page: 1, limit: adminExportLimit, export: tt.format}.startExport(w, r, "users", []string{"id", "name"})
+			for _, u := range users {
+				if err := export.write(u, func() []string { return []string{strconv.Itoa(u.ID), u.Name} }); err != nil {
+					t.Fatalf("write() error = %v", err)
+				}
+			}
+			export.finish()
+
+			if got := w.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.contentType)
+			}
+			if got, want := w.Header().Get("Content-Disposition"), "attachment; filename=gigco-users-2026-10-16."+tt.format; got != want {
+				t.Errorf("Content-Disposition = %q, want %q", got, want)
+			}
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("body = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,575 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/email"
+	"app/internal/model"
+	"app/internal/notifications"
+	"app/internal/temporal/workflows"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lib/pq"
+)
+
+// threadSnapshotLimit caps how many recent messages are copied onto a ticket
+const threadSnapshotLimit = 200
+
+const supportTicketColumns = `
+	id, uuid, job_id, opened_by, subject, description, status, thread_snapshot, workflow_paused,
+	ops_notified_at, assigned_to, resolved_by, resolved_at, resolution_notes, created_at, updated_at
+`
+
+// scanSupportTicket scans a support_tickets row selected with supportTicketColumns
+func scanSupportTicket(row rowScanner) (*model.SupportTicket, error) {
+	var t model.SupportTicket
+	var snapshot []byte
+	var opsNotifiedAt, resolvedAt sql.NullTime
+	var assignedTo, resolvedBy sql.NullInt64
+	var resolutionNotes sql.NullString
+
+	err := row.Scan(
+		&t.ID, &t.UUID, &t.JobID, &t.OpenedBy, &t.Subject, &t.Description, &t.Status, &snapshot,
+		&t.WorkflowPaused, &opsNotifiedAt, &assignedTo, &resolvedBy, &resolvedAt, &resolutionNotes,
+		&t.CreatedAt, &t.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(snapshot, &t.ThreadSnapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode thread snapshot: %w", err)
+	}
+	t.OpsNotifiedAt = timePtrFromNull(opsNotifiedAt)
+	t.AssignedTo = intPtrFromNull(assignedTo)
+	t.ResolvedBy = intPtrFromNull(resolvedBy)
+	t.ResolvedAt = timePtrFromNull(resolvedAt)
+	t.ResolutionNotes = stringPtrFromNull(resolutionNotes)
+	return &t, nil
+}
+
+// threadJob holds the job fields needed for the message thread
+type threadJob struct {
+	Title       string
+	ConsumerID  int
+	GigWorkerID sql.NullInt64
+}
+
+// isParticipant reports whether userID is the job's consumer or assigned worker
+func (j *threadJob) isParticipant(userID int) bool {
+	return userID == j.ConsumerID || (j.GigWorkerID.Valid && int(j.GigWorkerID.Int64) == userID)
+}
+
+// otherParty returns the participant opposite userID, if any
+func (j *threadJob) otherParty(userID int) *int {
+	if userID == j.ConsumerID {
+		return intPtrFromNull(j.GigWorkerID)
+	}
+	return &j.ConsumerID
+}
+
+// getThreadJob loads a job for its message thread and checks the caller is a participant
+func getThreadJob(w http.ResponseWriter, r *http.Request, jobID int) (*threadJob, bool) {
+	var job threadJob
+	err := config.DB.QueryRow(
+		`SELECT title, consumer_id, gig_worker_id FROM jobs WHERE id = $1`, jobID,
+	).Scan(&job.Title, &job.ConsumerID, &job.GigWorkerID)
+	if err == sql.ErrNoRows {
+		respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
+		return nil, false
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return nil, false
+	}
+
+	if !job.isParticipant(GetUserIDFromContext(r)) && GetUserRoleFromContext(r) != "admin" {
+		RespondWithError(w, http.StatusForbidden, "Only job participants can access this thread")
+		return nil, false
+	}
+	return &job, true
+}
+
+// loadJobThread returns the most recent messages in a job thread, oldest first
+func loadJobThread(jobID, limit int) ([]model.JobMessage, error) {
+	rows, err := config.DB.Query(`
+		SELECT id, uuid, job_id, sender_id, sender_name, body, created_at FROM (
+			SELECT m.id, m.uuid, m.job_id, m.sender_id, COALESCE(p.name, ''), m.body, m.created_at
+			FROM job_messages m
+			LEFT JOIN people p ON p.id = m.sender_id
+			WHERE m.job_id = $1
+			ORDER BY m.created_at DESC, m.id DESC
+			LIMIT $2
+		) recent (id, uuid, job_id, sender_id, sender_name, body, created_at)
+		ORDER BY created_at, id
+	`, jobID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []model.JobMessage{}
+	for rows.Next() {
+		var m model.JobMessage
+		if err := rows.Scan(&m.ID, &m.UUID, &m.JobID, &m.SenderID, &m.SenderName, &m.Body, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+	return messages, rows.Err()
+}
+
+// jobHasOpenHolds reports whether an unresolved incident, ticket or dispute is still holding the job workflow
+func jobHasOpenHolds(jobID int) (bool, error) {
+	var holds int
+	err := config.DB.QueryRow(`
+		SELECT
+			(SELECT COUNT(*) FROM safety_incidents WHERE job_id = $1 AND workflow_paused = true AND status <> 'resolved') +
+			(SELECT COUNT(*) FROM support_tickets WHERE job_id = $1 AND workflow_paused = true AND status <> 'resolved') +
+			(SELECT COUNT(*) FROM job_disputes WHERE job_id = $1 AND status IN ('open', 'under_review'))
+	`, jobID).Scan(&holds)
+	return holds > 0, err
+}
+
+// ==============================================
+// JOB MESSAGE THREAD (JOB PARTICIPANTS)
+// ==============================================
+
+// GetJobMessages returns a job's message thread
+func GetJobMessages(w http.ResponseWriter, r *http.Request) {
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+	limit, err := ParseIntParam(r, "limit", 50, 1, threadSnapshotLimit)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	if _, ok := getThreadJob(w, r, jobID); !ok {
+		return
+	}
+
+	messages, err := loadJobThread(jobID, limit)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error loading job thread", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"messages": messages,
+	})
+}
+
+// SendJobMessage posts a message to a job's thread
+func SendJobMessage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	var req model.JobMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	req.Body = strings.TrimSpace(req.Body)
+	if req.Body == "" || len(req.Body) > 5000 {
+		RespondWithValidationError(w, &ValidationError{Field: "body", Message: "is required and must not exceed 5000 characters"})
+		return
+	}
+
+	job, ok := getThreadJob(w, r, jobID)
+	if !ok {
+		return
+	}
+	if !job.isParticipant(userID) {
+		RespondWithError(w, http.StatusForbidden, "Only job participants can send messages")
+		return
+	}
+
+	m := model.JobMessage{JobID: jobID, SenderID: userID, Body: req.Body}
+	err = config.DB.QueryRow(`
+		INSERT INTO job_messages (job_id, sender_id, body)
+		VALUES ($1, $2, $3)
+		RETURNING id, uuid, created_at, (SELECT name FROM people WHERE id = $2)
+	`, jobID, userID, req.Body).Scan(&m.ID, &m.UUID, &m.CreatedAt, &m.SenderName)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error sending job message", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to send message")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, m)
+}
+
+// EscalateJobThread opens a support ticket from a job's message thread. The thread is
+// snapshotted onto the ticket, ops is alerted, and the job workflow is optionally paused.
+func EscalateJobThread(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	var req model.EscalationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	req.Subject = strings.TrimSpace(req.Subject)
+	req.Description = strings.TrimSpace(req.Description)
+	if req.Subject == "" || len(req.Subject) > 255 {
+		RespondWithValidationError(w, &ValidationError{Field: "subject", Message: "is required and must not exceed 255 characters"})
+		return
+	}
+	if req.Description == "" || len(req.Description) > 5000 {
+		RespondWithValidationError(w, &ValidationError{Field: "description", Message: "is required and must not exceed 5000 characters"})
+		return
+	}
+
+	job, ok := getThreadJob(w, r, jobID)
+	if !ok {
+		return
+	}
+	if !job.isParticipant(userID) {
+		RespondWithError(w, http.StatusForbidden, "Only job participants can escalate to support")
+		return
+	}
+
+	thread, err := loadJobThread(jobID, threadSnapshotLimit)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error loading job thread", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	snapshot, err := json.Marshal(thread)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to encode thread snapshot", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	ticket, err := scanSupportTicket(config.DB.QueryRow(`
+		INSERT INTO support_tickets (job_id, opened_by, subject, description, thread_snapshot, workflow_paused)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+supportTicketColumns,
+		jobID, userID, req.Subject, req.Description, snapshot, req.PauseJob,
+	))
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		RespondWithError(w, http.StatusConflict, "This job already has an open support ticket")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error creating support ticket", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to escalate to support")
+		return
+	}
+
+	if req.PauseJob {
+		pauseJobWorkflow(r.Context(), jobID, workflows.PauseRequest{
+			Reason:   "support ticket " + ticket.UUID,
+			TicketID: ticket.ID,
+		})
+		signalEscrowWorkflows(r.Context(), jobID)
+	}
+
+	go alertOpsOfTicket(r.Context(), *ticket, job.Title, len(thread))
+	if other := job.otherParty(userID); other != nil {
+		go notifyTicketOtherParty(r.Context(), *ticket, job.Title, *other)
+	}
+
+	RespondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"message": "Your conversation has been escalated to support.",
+		"ticket":  ticket,
+	})
+}
+
+// alertOpsOfTicket posts a new escalation to the ops channel and records when it was sent
+func alertOpsOfTicket(ctx context.Context, ticket model.SupportTicket, jobTitle string, messageCount int) {
+	notifier, err := notifications.NewOpsNotifierFromEnv()
+	if err != nil {
+		slog.ErrorContext(ctx, "Ops alerting not configured, ticket not escalated", "uuid", ticket.UUID, "error", err)
+		return
+	}
+
+	fields := map[string]string{
+		"Ticket":    ticket.UUID,
+		"Job":       fmt.Sprintf("%d (%s)", ticket.JobID, jobTitle),
+		"Opened by": strconv.Itoa(ticket.OpenedBy),
+		"Messages":  strconv.Itoa(messageCount),
+		"Job held":  strconv.FormatBool(ticket.WorkflowPaused),
+	}
+	if n := len(ticket.ThreadSnapshot); n > 0 {
+		last := ticket.ThreadSnapshot[n-1]
+		fields["Last message"] = fmt.Sprintf("%s: %s", last.SenderName, last.Body)
+	}
+
+	alert := notifications.OpsAlert{
+		Title:    fmt.Sprintf("Support escalation on job %d: %s", ticket.JobID, ticket.Subject),
+		Summary:  ticket.Description,
+		Severity: notifications.SeverityWarning,
+		Source:   "support-ticket",
+		DedupKey: "ticket-" + ticket.UUID,
+		Fields:   fields,
+	}
+	if baseURL := os.Getenv("ADMIN_BASE_URL"); baseURL != "" {
+		alert.Link = fmt.Sprintf("%s/support/tickets/%d", baseURL, ticket.ID)
+	}
+
+	if err := notifier.Notify(alert); err != nil {
+		slog.ErrorContext(ctx, "Failed to alert ops of ticket", "uuid", ticket.UUID, "error", err)
+		return
+	}
+
+	if _, err := config.DB.Exec(`UPDATE support_tickets SET ops_notified_at = NOW() WHERE id = $1`, ticket.ID); err != nil {
+		slog.ErrorContext(ctx, "Database error marking ticket ops notified", "ticket_id", ticket.ID, "error", err)
+	}
+}
+
+// notifyTicketOtherParty tells the other job participant that support is involved
+func notifyTicketOtherParty(ctx context.Context, ticket model.SupportTicket, jobTitle string, recipientID int) {
+	emailService, err := email.NewServiceFromEnv()
+	if err != nil {
+		slog.ErrorContext(ctx, "Email not configured, ticket other party not notified", "uuid", ticket.UUID, "error", err)
+		return
+	}
+
+	var toEmail, toName string
+	err = config.DB.QueryRow(`SELECT email, name FROM people WHERE id = $1`, recipientID).Scan(&toEmail, &toName)
+	if err != nil {
+		slog.ErrorContext(ctx, "Database error loading other party for ticket", "ticket_id", ticket.ID, "error", err)
+		return
+	}
+
+	message := "Your conversation about this job has been shared with GigCo support, who may contact you."
+	if ticket.WorkflowPaused {
+		message += " The job is on hold until support resolves the issue."
+	}
+
+	err = emailService.SendJobNotification(toEmail, toName, email.JobNotificationData{
+		UserName: toName,
+		JobTitle: jobTitle,
+		JobID:    strconv.Itoa(ticket.JobID),
+		Message:  message,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to notify other party of ticket", "ticket_id", ticket.ID, "error", err)
+	}
+}
+
+// ==============================================
+// SUPPORT TICKET MANAGEMENT (SUPPORT TEAM)
+// ==============================================
+
+// GetSupportTickets lists escalated tickets for the support team
+func GetSupportTickets(w http.ResponseWriter, r *http.Request) {
+	page, err := ParseIntParam(r, "page", 1, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	limit, err := ParseIntParam(r, "limit", DefaultPageSize, MinPageSize, MaxPageSize)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	var whereClauses []string
+	var args []any
+	argIndex := 1
+
+	if status := r.URL.Query().Get("status"); status != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, status)
+		argIndex++
+	}
+	if jobID := r.URL.Query().Get("job_id"); jobID != "" {
+		id, err := ParseIntParam(r, "job_id", 0, 1, 0)
+		if err != nil {
+			RespondWithValidationError(w, err.(*ValidationError))
+			return
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("job_id = $%d", argIndex))
+		args = append(args, id)
+		argIndex++
+	}
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = " WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	var total int
+	if err := config.DB.QueryRow("SELECT COUNT(*) FROM support_tickets"+whereClause, args...).Scan(&total); err != nil {
+		slog.ErrorContext(r.Context(), "Database error counting support tickets", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	query := "SELECT " + supportTicketColumns + " FROM support_tickets" + whereClause +
+		fmt.Sprintf(" ORDER BY (status = 'resolved'), created_at DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, limit, (page-1)*limit)
+
+	rows, err := config.DB.Query(query, args...)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying support tickets", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	tickets := []model.SupportTicket{}
+	for rows.Next() {
+		ticket, err := scanSupportTicket(rows)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning support ticket row", "error", err)
+			continue
+		}
+		// The list view omits the thread; fetch a ticket for the full snapshot
+		ticket.ThreadSnapshot = nil
+		tickets = append(tickets, *ticket)
+	}
+
+	pages := (total + limit - 1) / limit
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"tickets": tickets,
+		"pagination": model.Pagination{
+			Page:    page,
+			Limit:   limit,
+			Total:   total,
+			Pages:   pages,
+			HasNext: page < pages,
+			HasPrev: page > 1,
+		},
+	})
+}
+
+// GetSupportTicketByID retrieves a ticket with its thread snapshot
+func GetSupportTicketByID(w http.ResponseWriter, r *http.Request) {
+	ticketID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid ticket ID format")
+		return
+	}
+
+	ticket, err := scanSupportTicket(config.DB.QueryRow(
+		"SELECT "+supportTicketColumns+" FROM support_tickets WHERE id = $1", ticketID,
+	))
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Support ticket not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting support ticket", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, ticket)
+}
+
+// UpdateSupportTicket picks up or resolves a ticket. Resolving the last open hold
+// on a paused job resumes its workflow.
+func UpdateSupportTicket(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	adminID := GetUserIDFromContext(r)
+	ticketID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid ticket ID format")
+		return
+	}
+
+	var req model.SupportTicketUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+
+	var query string
+	switch req.Status {
+	case model.TicketStatusInProgress:
+		query = `
+			UPDATE support_tickets
+			SET status = 'in_progress', assigned_to = $1, resolution_notes = COALESCE($2, resolution_notes)
+			WHERE id = $3 AND status = 'open'
+			RETURNING ` + supportTicketColumns
+	case model.TicketStatusResolved:
+		query = `
+			UPDATE support_tickets
+			SET status = 'resolved', resolved_by = $1, resolved_at = NOW(),
+			    assigned_to = COALESCE(assigned_to, $1), resolution_notes = COALESCE($2, resolution_notes)
+			WHERE id = $3 AND status <> 'resolved'
+			RETURNING ` + supportTicketColumns
+	default:
+		RespondWithValidationError(w, &ValidationError{Field: "status", Message: "must be 'in_progress' or 'resolved'", Value: req.Status})
+		return
+	}
+
+	ticket, err := scanSupportTicket(config.DB.QueryRow(query, adminID, req.ResolutionNotes, ticketID))
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusConflict, "Support ticket not found or already in that state")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error updating support ticket", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update support ticket")
+		return
+	}
+
+	if ticket.Status == model.TicketStatusResolved && ticket.WorkflowPaused {
+		held, err := jobHasOpenHolds(ticket.JobID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Database error checking open holds for job", "job_id", ticket.JobID, "error", err)
+		} else if !held {
+			resumeJobWorkflow(r.Context(), ticket.JobID)
+			signalEscrowWorkflows(r.Context(), ticket.JobID)
+		}
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Support ticket updated successfully",
+		"ticket":  ticket,
+	})
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/analytics"
+	"app/internal/model"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ==============================================
+// JOB SURVEYS
+// ==============================================
+
+// GetJobSurvey returns the survey sent to the consumer when their job closed
+func GetJobSurvey(w http.ResponseWriter, r *http.Request) {
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	survey, err := getJobSurvey(config.DB, jobID, GetUserIDFromContext(r))
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Survey not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting job survey", "job_id", jobID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, survey)
+}
+
+// SubmitJobSurvey records the consumer's answer to their job's survey. Each survey can
+// be answered once, within SurveyResponseWindow of being sent.
+func SubmitJobSurvey(w http.ResponseWriter, r *http.Request) {
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	var req model.JobSurveyResponse
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	req.Comment = strings.TrimSpace(req.Comment)
+	if len(req.Comment) > 1000 {
+		RespondWithValidationError(w, &ValidationError{Field: "comment", Message: "must not exceed 1000 characters"})
+		return
+	}
+
+	survey, err := getJobSurvey(config.DB, jobID, GetUserIDFromContext(r))
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Survey not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting job survey", "job_id", jobID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if req.Score == nil || *req.Score < survey.MinScore || *req.Score > survey.MaxScore {
+		RespondWithValidationError(w, &ValidationError{Field: "score", Message: fmt.Sprintf("must be between %d and %d", survey.MinScore, survey.MaxScore)})
+		return
+	}
+	if survey.RespondedAt != nil {
+		RespondWithError(w, http.StatusConflict, "Survey has already been answered")
+		return
+	}
+	if appFrom(r.Context()).Clock.Now().After(survey.ExpiresAt) {
+		RespondWithError(w, http.StatusGone, "Survey has expired")
+		return
+	}
+
+	result, err := config.DB.ExecContext(r.Context(), `
+		UPDATE job_surveys SET score = $1, comment = $2, responded_at = NOW()
+		WHERE id = $3 AND responded_at IS NULL
+	`, *req.Score, nullString(req.Comment), survey.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error saving survey response", "survey_id", survey.ID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to save survey response")
+		return
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		RespondWithError(w, http.StatusConflict, "Survey has already been answered")
+		return
+	}
+
+	slog.InfoContext(r.Context(), "Job survey answered", "job_id", jobID, "survey_type", survey.SurveyType, "score", *req.Score)
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Thanks for your feedback!",
+		"job_id":  jobID,
+	})
+}
+
+// GetSurveyReport aggregates CSAT and NPS for surveys sent in the window, overall and
+// by job category and market
+func GetSurveyReport(w http.ResponseWriter, r *http.Request) {
+	from, err := ParseDateParam(r, "from")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	to, err := ParseDateParam(r, "to")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	end := appFrom(r.Context()).Clock.Now()
+	if to != nil {
+		end = *to
+	}
+	start := end.Add(-defaultFunnelReportWindow)
+	if from != nil {
+		start = *from
+	}
+	if !start.Before(end) {
+		RespondWithError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	report, err := analytics.SurveyReport(r.Context(), config.DB, start, end)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error building survey report", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, report)
+}
+
+// getJobSurvey loads the consumer's survey for a job
+func getJobSurvey(db *sql.DB, jobID, consumerID int) (*model.JobSurvey, error) {
+	var s model.JobSurvey
+	var workerID, score sql.NullInt64
+	var comment sql.NullString
+	var respondedAt sql.NullTime
+	err := db.QueryRow(`
+		SELECT s.id, s.job_id, j.title, s.gig_worker_id, s.survey_type, s.score, s.comment, s.sent_at, s.responded_at
+		FROM job_surveys s
+		JOIN jobs j ON j.id = s.job_id
+		WHERE s.job_id = $1 AND s.consumer_id = $2
+	`, jobID, consumerID).Scan(&s.ID, &s.JobID, &s.JobTitle, &workerID, &s.SurveyType, &score, &comment, &s.SentAt, &respondedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Question, s.MinScore, s.MaxScore = analytics.SurveyQuestion(s.SurveyType)
+	s.ExpiresAt = s.SentAt.Add(analytics.SurveyResponseWindow)
+	s.WorkerID = intPtrFromNull(workerID)
+	s.Score = intPtrFromNull(score)
+	if comment.Valid {
+		s.Comment = &comment.String
+	}
+	s.RespondedAt = timePtrFromNull(respondedAt)
+	return &s, nil
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/model"
+	"context"
+	"database/sql"
+	"log/slog"
+	"math"
+
+	"github.com/lib/pq"
+)
+
+// MinWorkerReviewsForRating is the number of worker reviews a consumer needs before
+// their average rating is shown, so individual reviews can't be backed out of it.
+const MinWorkerReviewsForRating = 3
+
+// workerReviewCountBuckets are the lower bounds a consumer's review count is reported
+// as once their average is shown
+var workerReviewCountBuckets = []int{100, 50, 25, 10, MinWorkerReviewsForRating}
+
+// bucketWorkerReviewCount rounds a review count down to its bucket. Counts below
+// MinWorkerReviewsForRating come with no average and are reported as they are.
+func bucketWorkerReviewCount(count int) int {
+	for _, bucket := range workerReviewCountBuckets {
+		if count >= bucket {
+			return bucket
+		}
+	}
+	return count
+}
+
+// getConsumerTrustSignals loads trust signals for a set of consumers in one query.
+// Consumers missing from the result map have no people row.
+func getConsumerTrustSignals(ctx context.Context, consumerIDs []int) (map[int]*model.ConsumerTrustSignals, error) {
+	signals := make(map[int]*model.ConsumerTrustSignals)
+	if len(consumerIDs) == 0 {
+		return signals, nil
+	}
+
+	ids := make([]int64, len(consumerIDs))
+	for i, id := range consumerIDs {
+		ids[i] = int64(id)
+	}
+
+	query := `
+		SELECT p.id,
+		       p.place_id IS NOT NULL AS verified_address,
+		       EXISTS (
+		           SELECT 1 FROM user_payment_methods pm
+		           WHERE pm.user_id = p.id AND pm.is_active = true
+		             AND (pm.expires_at IS NULL OR pm.expires_at >= CURRENT_DATE)
+		       ) AS verified_payment_method,
+		       (
+		           SELECT COUNT(*) FROM jobs cj
+		           WHERE cj.consumer_id = p.id
+		             AND cj.status IN ('completed', 'paid', 'review_pending', 'closed')
+		       ) AS completed_jobs,
+		       wr.review_count,
+		       wr.average_rating,
+		       TO_CHAR(p.created_at, 'YYYY-MM') AS member_since
+		FROM people p
+		CROSS JOIN LATERAL (
+		    SELECT COUNT(*) AS review_count, AVG(r.rating) AS average_rating
+		    FROM job_reviews r
+		    JOIN jobs rj ON r.job_id = rj.id
+		    WHERE r.reviewee_id = p.id AND r.reviewer_id = rj.gig_worker_id
+		) wr
+		WHERE p.id = ANY($1)
+	`
+
+	rows, err := config.DB.Query(query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var consumerID int
+		var averageRating sql.NullFloat64
+		ts := &model.ConsumerTrustSignals{}
+
+		if err := rows.Scan(
+			&consumerID, &ts.VerifiedAddress, &ts.VerifiedPaymentMethod, &ts.CompletedJobs,
+			&ts.WorkerReviewCount, &averageRating, &ts.MemberSince,
+		); err != nil {
+			slog.ErrorContext(ctx, "Error scanning consumer trust row", "error", err)
+			continue
+		}
+
+		if averageRating.Valid && ts.WorkerReviewCount >= MinWorkerReviewsForRating {
+			rounded := math.Round(averageRating.Float64*10) / 10
+			ts.AverageWorkerRating = &rounded
+		}
+		ts.WorkerReviewCount = bucketWorkerReviewCount(ts.WorkerReviewCount)
+
+		signals[consumerID] = ts
+	}
+
+	return signals, rows.Err()
+}
+
+// attachConsumerTrustSignals fills ConsumerTrust on each job response.
+// Failures are logged and leave the jobs without trust data rather than failing the request.
+func attachConsumerTrustSignals(ctx context.Context, jobs []model.JobResponse) {
+	var consumerIDs []int
+	seen := make(map[int]bool)
+	for _, job := range jobs {
+		if !seen[job.ConsumerID] {
+			seen[job.ConsumerID] = true
+			consumerIDs = append(consumerIDs, job.ConsumerID)
+		}
+	}
+
+	signals, err := getConsumerTrustSignals(ctx, consumerIDs)
+	if err != nil {
+		slog.ErrorContext(ctx, "Database error loading consumer trust signals", "error", err)
+		return
+	}
+
+	for i := range jobs {
+		jobs[i].ConsumerTrust = signals[jobs[i].ConsumerID]
+	}
+}
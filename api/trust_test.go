@@ -0,0 +1,25 @@
+package api
+
+import "testing"
+
+func TestBucketWorkerReviewCount(t *testing.T) {
+	tests := []struct {
+		count, want int
+	}{
+		{count: 0, want: 0},
+		{count: 2, want: 2},
+		{count: 3, want: 3},
+		{count: 9, want: 3},
+		{count: 10, want: 10},
+		{count: 24, want: 10},
+		{count: 49, want: 25},
+		{count: 99, want: 50},
+		{count: 1200, want: 100},
+	}
+
+	for _, tt := range tests {
+		if got := bucketWorkerReviewCount(tt.count); got != tt.want {
+			t.Errorf("bucketWorkerReviewCount(%d) = %d; want %d", tt.count, got, tt.want)
+		}
+	}
+}
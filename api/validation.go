@@ -0,0 +1,227 @@
+package api
+
+import (
+	"app/internal/currency"
+	"app/internal/model"
+	"app/internal/recurrence"
+	"app/internal/validate"
+	"strings"
+)
+
+// Request body validation for the create and update endpoints. Each validator checks
+// the whole request and returns validate.Errors listing every invalid field, for
+// RespondWithValidationErrors.
+
+// validateJobCreateRequest validates the job creation request
+func validateJobCreateRequest(req *model.JobCreateRequest) error {
+	var v validate.Validator
+	validateJobPosting(&v, req)
+	return v.Err()
+}
+
+// validateJobTemplateRequest validates a job template given by its fields
+func validateJobTemplateRequest(name string, posting *model.JobCreateRequest) error {
+	var v validate.Validator
+	v.Required("name", name)
+	v.Length("name", name, 1, 100)
+	validateJobPosting(&v, posting)
+	return v.Err()
+}
+
+// validateJobPosting checks the fields of a job being posted or saved as a template
+func validateJobPosting(v *validate.Validator, req *model.JobCreateRequest) {
+	v.Required("title", req.Title)
+	v.Length("title", req.Title, 3, 255)
+	v.Required("description", req.Description)
+	v.Length("description", req.Description, 10, 0)
+	validateJobTerms(v, req.EstimatedDurationHours, req.PayRatePerHour, req.TotalPay)
+	validateCoordinates(v, "location_latitude", "location_longitude", req.LocationLatitude, req.LocationLongitude)
+	if req.ScheduledStart != nil && req.ScheduledEnd != nil && req.ScheduledEnd.Before(*req.ScheduledStart) {
+		v.Add("scheduled_end", "must be after scheduled_start")
+	}
+	validateCurrency(v, "currency", &req.Currency)
+}
+
+// validateCurrency checks a currency code against the registry and normalizes it in
+// place; a blank code becomes the default currency
+func validateCurrency(v *validate.Validator, field string, code *string) {
+	normalized, err := currency.Normalize(*code)
+	if err != nil {
+		v.AddValue(field, "must be one of "+strings.Join(currency.Codes(), ", "), *code)
+		return
+	}
+	*code = normalized
+}
+
+// validateJobUpdateRequest validates the fields present in a job update. The stored
+// schedule is not checked against a new start or end sent on its own.
+func validateJobUpdateRequest(req *model.JobUpdateRequest) error {
+	var v validate.Validator
+	if req.Title != nil {
+		v.Required("title", *req.Title)
+		v.Length("title", *req.Title, 3, 255)
+	}
+	if req.Description != nil {
+		v.Required("description", *req.Description)
+		v.Length("description", *req.Description, 10, 0)
+	}
+	validateJobTerms(&v, req.EstimatedDurationHours, req.PayRatePerHour, req.TotalPay)
+	validateCoordinates(&v, "location_latitude", "location_longitude", req.LocationLatitude, req.LocationLongitude)
+	if req.ScheduledStart != nil && req.ScheduledEnd != nil && req.ScheduledEnd.Before(*req.ScheduledStart) {
+		v.Add("scheduled_end", "must be after scheduled_start")
+	}
+	return v.Err()
+}
+
+// validateJobTerms checks a job's optional duration and pay
+func validateJobTerms(v *validate.Validator, durationHours, payRate, totalPay *float64) {
+	v.Check(durationHours == nil || *durationHours > 0, "estimated_duration_hours", "must be greater than 0")
+	v.Check(payRate == nil || *payRate > 0, "pay_rate_per_hour", "must be greater than 0")
+	v.Check(totalPay == nil || *totalPay > 0, "total_pay", "must be greater than 0")
+}
+
+// validateCoordinates checks an optional latitude and longitude
+func validateCoordinates(v *validate.Validator, latField, lngField string, latitude, longitude *float64) {
+	v.Check(latitude == nil || (*latitude >= -90 && *latitude <= 90), latField, "must be between -90 and 90")
+	v.Check(longitude == nil || (*longitude >= -180 && *longitude <= 180), lngField, "must be between -180 and 180")
+}
+
+// validateContactUpdate checks the account fields shared by profile, user and
+// gig worker updates; nil fields are left unchanged
+func validateContactUpdate(v *validate.Validator, name, phone, address *string, latitude, longitude *float64) {
+	if name != nil {
+		v.Required("name", *name)
+		v.Length("name", strings.TrimSpace(*name), 2, 255)
+	}
+	if phone != nil {
+		v.Phone("phone", *phone)
+	}
+	if address != nil {
+		v.Required("address", *address)
+	}
+	validateCoordinates(v, "latitude", "longitude", latitude, longitude)
+}
+
+// validateUserProfileUpdateRequest validates a user's update to their own profile
+func validateUserProfileUpdateRequest(req *model.UserProfileUpdateRequest) error {
+	var v validate.Validator
+	validateContactUpdate(&v, req.Name, req.Phone, req.Address, req.Latitude, req.Longitude)
+	return v.Err()
+}
+
+// validateUserUpdateRequest validates an admin's update to a user
+func validateUserUpdateRequest(req *model.UserUpdateRequest) error {
+	var v validate.Validator
+	validateContactUpdate(&v, req.Name, req.Phone, req.Address, req.Latitude, req.Longitude)
+	return v.Err()
+}
+
+// validateGigWorkerUpdateRequest validates a gig worker profile update against the
+// same rules as a worker application
+func validateGigWorkerUpdateRequest(req *model.GigWorkerUpdateRequest) error {
+	var v validate.Validator
+	validateContactUpdate(&v, req.Name, req.Phone, req.Address, req.Latitude, req.Longitude)
+	v.Check(req.HourlyRate == nil || *req.HourlyRate > 0, "hourly_rate", "must be greater than 0")
+	v.Check(req.ExperienceYears == nil || (*req.ExperienceYears >= 0 && *req.ExperienceYears <= 50), "experience_years", "must be between 0 and 50")
+	v.Check(req.ServiceRadiusMiles == nil || (*req.ServiceRadiusMiles >= 1 && *req.ServiceRadiusMiles <= 100), "service_radius_miles", "must be between 1 and 100 miles")
+	v.MaxLength("bio", req.Bio, 5000)
+	v.MaxLength("availability_notes", req.AvailabilityNotes, 1000)
+	if req.VerificationStatus != nil {
+		v.OneOf("verification_status", *req.VerificationStatus, "pending", "verified", "rejected", "suspended")
+	}
+	if req.EmergencyContactPhone != nil {
+		v.Phone("emergency_contact_phone", strings.TrimSpace(*req.EmergencyContactPhone))
+	}
+	return v.Err()
+}
+
+// validateCreateUserRequest validates an admin-created customer record
+func validateCreateUserRequest(user *model.User) error {
+	var v validate.Validator
+	v.Required("name", user.Name)
+	v.Length("name", user.Name, 0, 255)
+	v.Required("address", user.Address)
+	return v.Err()
+}
+
+// validateScheduleRequest validates a new schedule slot, parsing its recurring
+// pattern (nil when it has none)
+func validateScheduleRequest(schedule *model.Schedule) (*recurrence.Rule, error) {
+	var v validate.Validator
+	v.Check(schedule.GigWorkerID > 0, "gig_worker_id", "is required")
+	v.Check(!schedule.StartTime.IsZero(), "start_time", "is required")
+	v.Check(!schedule.EndTime.IsZero(), "end_time", "is required")
+	if !schedule.StartTime.IsZero() && !schedule.EndTime.IsZero() {
+		v.Check(!schedule.StartTime.After(schedule.EndTime), "end_time", "must be after start_time")
+	}
+	v.MaxLength("title", schedule.Title, 255)
+
+	// Recurring patterns are expanded on read, so they must parse now
+	var rule *recurrence.Rule
+	if schedule.RecurringPattern != nil && strings.TrimSpace(*schedule.RecurringPattern) == "" {
+		schedule.RecurringPattern = nil
+	}
+	if schedule.RecurringPattern != nil {
+		parsed, err := recurrence.ParseRule(*schedule.RecurringPattern)
+		if err != nil {
+			v.AddValue("recurring_pattern", err.Error(), *schedule.RecurringPattern)
+		} else {
+			rule = &parsed
+		}
+		if schedule.RecurringUntil != nil && schedule.RecurringUntil.Before(schedule.StartTime) {
+			v.Add("recurring_until", "must not be before start_time")
+		}
+	} else if schedule.RecurringUntil != nil {
+		v.Add("recurring_until", "requires a recurring_pattern")
+	}
+
+	return rule, v.Err()
+}
+
+// validateTransactionRequest validates a manually recorded transaction
+func validateTransactionRequest(transaction *model.Transaction) error {
+	var v validate.Validator
+	v.Check(transaction.JobID > 0, "job_id", "is required")
+	v.Check(transaction.ConsumerID > 0, "consumer_id", "is required")
+	v.Check(transaction.GigWorkerID > 0, "gig_worker_id", "is required")
+	v.Check(transaction.Amount > 0, "amount", "must be greater than 0")
+	v.Required("payment_method", transaction.PaymentMethod)
+	validateCurrency(&v, "currency", &transaction.Currency)
+	return v.Err()
+}
+
+// validatePaymentAuthorizeRequest validates a job payment authorization before the
+// job and its price are looked up
+func validatePaymentAuthorizeRequest(req *model.PaymentAuthorizeRequest) error {
+	var v validate.Validator
+	v.Check(req.JobID > 0, "job_id", "is required")
+	v.Check(req.Amount.IsPositive(), "amount", "must be greater than 0")
+	return v.Err()
+}
+
+// validateReviewRequest validates a review of another job participant
+func validateReviewRequest(req *model.ReviewRequest) error {
+	var v validate.Validator
+	v.Check(req.JobID > 0, "job_id", "is required")
+	v.Check(req.RevieweeID > 0, "reviewee_id", "is required")
+	v.Check(req.RevieweeID <= 0 || req.RevieweeID != req.ReviewerID, "reviewee_id", "cannot be yourself")
+	v.Check(model.ValidateRating(req.Rating), "rating", "must be between 1 and 5")
+	v.MaxLength("review_text", req.ReviewText, 1000)
+	return v.Err()
+}
+
+// validateReviewUpdateRequest validates the fields present in a review edit
+func validateReviewUpdateRequest(req *model.ReviewUpdateRequest) error {
+	var v validate.Validator
+	v.Check(req.Rating == nil || model.ValidateRating(*req.Rating), "rating", "must be between 1 and 5")
+	v.MaxLength("review_text", req.ReviewText, 1000)
+	return v.Err()
+}
+
+// validateJobReviewSubmission validates a review submitted through the job workflow
+func validateJobReviewSubmission(req *model.JobReviewSubmission) error {
+	var v validate.Validator
+	v.Check(model.ValidateRating(req.Rating), "rating", "must be between 1 and 5")
+	v.Length("comment", req.Comment, 0, 1000)
+	return v.Err()
+}
@@ -0,0 +1,439 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/email"
+	"app/internal/model"
+	"app/internal/validate"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ==============================================
+// WAITLIST SIGNUP (PUBLIC)
+// ==============================================
+
+// JoinWaitlist records interest from a user in a market that has not launched yet.
+// Signups are de-duplicated by email; a repeat submission updates the stored location.
+func JoinWaitlist(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req model.WaitlistSignupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+
+	if err := validateWaitlistSignupRequest(&req); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+
+	marketID, err := resolveWaitlistMarket(req)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error resolving waitlist market", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// The caller is not authenticated, so the response only acknowledges the signup. It
+	// neither echoes the stored location nor tells whether the email was already listed.
+	_, err = config.DB.Exec(`
+		INSERT INTO waitlist_signups (email, role, market_id, city, state, postal_code, latitude, longitude)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (LOWER(email)) DO UPDATE SET
+			role = EXCLUDED.role,
+			market_id = COALESCE(EXCLUDED.market_id, waitlist_signups.market_id),
+			city = COALESCE(EXCLUDED.city, waitlist_signups.city),
+			state = COALESCE(EXCLUDED.state, waitlist_signups.state),
+			postal_code = COALESCE(EXCLUDED.postal_code, waitlist_signups.postal_code),
+			latitude = COALESCE(EXCLUDED.latitude, waitlist_signups.latitude),
+			longitude = COALESCE(EXCLUDED.longitude, waitlist_signups.longitude)
+	`,
+		req.Email, req.Role, marketID,
+		nullString(req.City), nullString(req.State), nullString(req.PostalCode),
+		req.Latitude, req.Longitude,
+	)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error creating waitlist signup", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to join waitlist")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusAccepted, map[string]interface{}{
+		"success": true,
+		"message": "You're on the waitlist",
+	})
+}
+
+// validateWaitlistSignupRequest normalizes and validates a waitlist signup
+func validateWaitlistSignupRequest(req *model.WaitlistSignupRequest) error {
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	req.Role = strings.TrimSpace(req.Role)
+	req.Market = strings.ToLower(strings.TrimSpace(req.Market))
+	req.City = strings.TrimSpace(req.City)
+	req.State = strings.TrimSpace(req.State)
+	req.PostalCode = strings.TrimSpace(req.PostalCode)
+
+	var v validate.Validator
+	v.Required("email", req.Email)
+	v.Email("email", req.Email)
+
+	if req.Role == "" {
+		req.Role = "consumer"
+	}
+	v.OneOf("role", req.Role, "consumer", "gig_worker")
+
+	if req.Market == "" && req.City == "" && req.PostalCode == "" && (req.Latitude == nil || req.Longitude == nil) {
+		v.Add("location", "market, city, postal_code, or latitude/longitude is required")
+	}
+	v.Check(req.Latitude == nil || (*req.Latitude >= -90 && *req.Latitude <= 90), "latitude", "must be between -90 and 90")
+	v.Check(req.Longitude == nil || (*req.Longitude >= -180 && *req.Longitude <= 180), "longitude", "must be between -180 and 180")
+
+	return v.Err()
+}
+
+// resolveWaitlistMarket finds the market a signup belongs to, by slug or by city/state.
+// Returns nil when no matching market exists yet so ops can assign one later.
+func resolveWaitlistMarket(req model.WaitlistSignupRequest) (*int, error) {
+	var marketID int
+	var err error
+
+	if req.Market != "" {
+		err = config.DB.QueryRow(`SELECT id FROM markets WHERE slug = $1`, req.Market).Scan(&marketID)
+	} else if req.City != "" {
+		err = config.DB.QueryRow(`
+			SELECT id FROM markets
+			WHERE LOWER(city) = LOWER($1) AND ($2 = '' OR LOWER(state) = LOWER($2))
+			ORDER BY id LIMIT 1
+		`, req.City, req.State).Scan(&marketID)
+	} else {
+		return nil, nil
+	}
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &marketID, nil
+}
+
+// ==============================================
+// WAITLIST OPS (ADMIN)
+// ==============================================
+
+// GetWaitlistSignups lists waitlist signups for ops with filtering and pagination
+func GetWaitlistSignups(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	page, err := ParseIntParam(r, "page", 1, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	limit, err := ParseIntParam(r, "limit", DefaultPageSize, MinPageSize, MaxPageSize)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	invited, invitedProvided, err := ParseBoolParam(r, "invited")
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	var whereClauses []string
+	var args []any
+	argIndex := 1
+
+	if market := r.URL.Query().Get("market"); market != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("m.slug = $%d", argIndex))
+		args = append(args, strings.ToLower(market))
+		argIndex++
+	}
+	if role := r.URL.Query().Get("role"); role != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("w.role = $%d", argIndex))
+		args = append(args, role)
+		argIndex++
+	}
+	if city := r.URL.Query().Get("city"); city != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("LOWER(w.city) = LOWER($%d)", argIndex))
+		args = append(args, city)
+		argIndex++
+	}
+	if state := r.URL.Query().Get("state"); state != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("LOWER(w.state) = LOWER($%d)", argIndex))
+		args = append(args, state)
+		argIndex++
+	}
+	if r.URL.Query().Get("unassigned") == "true" {
+		whereClauses = append(whereClauses, "w.market_id IS NULL")
+	}
+	if invitedProvided {
+		if invited {
+			whereClauses = append(whereClauses, "w.invited_at IS NOT NULL")
+		} else {
+			whereClauses = append(whereClauses, "w.invited_at IS NULL")
+		}
+	}
+
+	fromClause := " FROM waitlist_signups w LEFT JOIN markets m ON w.market_id = m.id"
+	if len(whereClauses) > 0 {
+		fromClause += " WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	var total int
+	if err := config.DB.QueryRow("SELECT COUNT(*)"+fromClause, args...).Scan(&total); err != nil {
+		slog.ErrorContext(r.Context(), "Database error counting waitlist signups", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	query := `
+		SELECT w.id, w.uuid, w.email, w.role, w.market_id, m.name, w.city, w.state,
+		       w.postal_code, w.latitude, w.longitude, w.invited_at, w.created_at, w.updated_at
+	` + fromClause + fmt.Sprintf(" ORDER BY w.created_at DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, limit, (page-1)*limit)
+
+	rows, err := config.DB.Query(query, args...)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying waitlist signups", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	signups := []model.WaitlistSignup{}
+	for rows.Next() {
+		var s model.WaitlistSignup
+		var marketID sql.NullInt64
+		var marketName, city, state, postalCode sql.NullString
+		var latitude, longitude sql.NullFloat64
+		var invitedAt sql.NullTime
+
+		if err := rows.Scan(
+			&s.ID, &s.UUID, &s.Email, &s.Role, &marketID, &marketName, &city, &state,
+			&postalCode, &latitude, &longitude, &invitedAt, &s.CreatedAt, &s.UpdatedAt,
+		); err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning waitlist signup row", "error", err)
+			continue
+		}
+
+		s.MarketID = intPtrFromNull(marketID)
+		s.MarketName = stringPtrFromNull(marketName)
+		s.City = stringPtrFromNull(city)
+		s.State = stringPtrFromNull(state)
+		s.PostalCode = stringPtrFromNull(postalCode)
+		s.Latitude = float64PtrFromNull(latitude)
+		s.Longitude = float64PtrFromNull(longitude)
+		s.InvitedAt = timePtrFromNull(invitedAt)
+
+		signups = append(signups, s)
+	}
+
+	pages := (total + limit - 1) / limit
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"signups": signups,
+		"pagination": model.Pagination{
+			Page:    page,
+			Limit:   limit,
+			Total:   total,
+			Pages:   pages,
+			HasNext: page < pages,
+			HasPrev: page > 1,
+		},
+	})
+}
+
+// GetMarkets lists all markets with their waitlist demand
+func GetMarkets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := `
+		SELECT m.id, m.uuid, m.slug, m.name, m.city, m.state, m.is_live, m.launched_at,
+		       m.created_at, m.updated_at,
+		       COUNT(w.id) AS waitlist_count,
+		       COUNT(w.id) FILTER (WHERE w.invited_at IS NULL) AS pending_invites
+		FROM markets m
+		LEFT JOIN waitlist_signups w ON w.market_id = m.id
+		GROUP BY m.id
+		ORDER BY m.is_live, waitlist_count DESC, m.name
+	`
+
+	rows, err := config.DB.Query(query)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying markets", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	markets := []model.MarketDemand{}
+	for rows.Next() {
+		var m model.MarketDemand
+		var city, state sql.NullString
+		var launchedAt sql.NullTime
+
+		if err := rows.Scan(
+			&m.ID, &m.UUID, &m.Slug, &m.Name, &city, &state, &m.IsLive, &launchedAt,
+			&m.CreatedAt, &m.UpdatedAt, &m.WaitlistCount, &m.PendingInvites,
+		); err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning market row", "error", err)
+			continue
+		}
+		m.City = stringPtrFromNull(city)
+		m.State = stringPtrFromNull(state)
+		m.LaunchedAt = timePtrFromNull(launchedAt)
+
+		markets = append(markets, m)
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"markets": markets,
+	})
+}
+
+// LaunchMarket flips a market to live and emails invites to everyone on its waitlist
+func LaunchMarket(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	marketID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || marketID <= 0 {
+		RespondWithError(w, http.StatusBadRequest, "Invalid market ID")
+		return
+	}
+
+	var market model.Market
+	var city, state sql.NullString
+	var launchedAt sql.NullTime
+	err = config.DB.QueryRow(`
+		UPDATE markets
+		SET is_live = true, launched_at = COALESCE(launched_at, NOW())
+		WHERE id = $1
+		RETURNING id, uuid, slug, name, city, state, is_live, launched_at, created_at, updated_at
+	`, marketID).Scan(
+		&market.ID, &market.UUID, &market.Slug, &market.Name, &city, &state,
+		&market.IsLive, &launchedAt, &market.CreatedAt, &market.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Market not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error launching market", "market_id", marketID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	market.City = stringPtrFromNull(city)
+	market.State = stringPtrFromNull(state)
+	market.LaunchedAt = timePtrFromNull(launchedAt)
+
+	// Claim the uninvited signups before sending, so a concurrent launch of the same
+	// market finds them invited and does not email them again
+	invitees, err := claimWaitlistInvitees(market.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error claiming waitlist invitees for market", "market_id", marketID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Send invites in background so the admin request returns immediately; they outlive
+	// the request, so its cancellation must not reach them
+	go sendWaitlistInvites(context.WithoutCancel(r.Context()), market, invitees)
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Market is now live",
+		"launch": model.MarketLaunchResponse{
+			Market:         market,
+			PendingInvites: len(invitees),
+		},
+	})
+}
+
+// waitlistInvitee is a signup claimed for a launch invite
+type waitlistInvitee struct {
+	id    int
+	email string
+	role  string
+}
+
+// claimWaitlistInvitees marks every uninvited signup in a market invited and returns them
+func claimWaitlistInvitees(marketID int) ([]waitlistInvitee, error) {
+	rows, err := config.DB.Query(`
+		UPDATE waitlist_signups SET invited_at = NOW()
+		WHERE market_id = $1 AND invited_at IS NULL
+		RETURNING id, email, role
+	`, marketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitees []waitlistInvitee
+	for rows.Next() {
+		var inv waitlistInvitee
+		if err := rows.Scan(&inv.id, &inv.email, &inv.role); err != nil {
+			return nil, err
+		}
+		invitees = append(invitees, inv)
+	}
+	return invitees, rows.Err()
+}
+
+// sendWaitlistInvites emails the signups claimed for a market's launch. Signups that
+// fail to send have invited_at cleared so a later launch call retries them.
+func sendWaitlistInvites(ctx context.Context, market model.Market, invitees []waitlistInvitee) {
+	if len(invitees) == 0 {
+		return
+	}
+	emailService, err := email.NewServiceFromEnv()
+	if err != nil {
+		slog.ErrorContext(ctx, "Skipping waitlist invites for market", "slug", market.Slug, "error", err)
+		releaseWaitlistInvitees(ctx, invitees)
+		return
+	}
+
+	sent := 0
+	for _, inv := range invitees {
+		if err := emailService.SendWaitlistInvite(inv.email, market.Name, inv.role); err != nil {
+			slog.ErrorContext(ctx, "Failed to send waitlist invite to signup", "id", inv.id, "error", err)
+			releaseWaitlistInvitees(ctx, []waitlistInvitee{inv})
+			continue
+		}
+		sent++
+	}
+
+	slog.InfoContext(ctx, "Sent waitlist invites for market", "sent", sent, "invitees", len(invitees), "slug", market.Slug)
+}
+
+// releaseWaitlistInvitees clears the invite claim on signups that were not emailed
+func releaseWaitlistInvitees(ctx context.Context, invitees []waitlistInvitee) {
+	for _, inv := range invitees {
+		if _, err := config.DB.Exec(`UPDATE waitlist_signups SET invited_at = NULL WHERE id = $1`, inv.id); err != nil {
+			slog.ErrorContext(ctx, "Database error releasing waitlist invite claim", "id", inv.id, "error", err)
+		}
+	}
+}
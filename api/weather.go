@@ -0,0 +1,500 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/email"
+	"app/internal/model"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lib/pq"
+)
+
+// loadWeatherAdvisory returns the stored advisory for a job, or nil if none has been computed
+func loadWeatherAdvisory(jobID int) (*model.WeatherAdvisory, error) {
+	var advisory model.WeatherAdvisory
+	var suggestedStart, severeNotifiedAt sql.NullTime
+
+	err := config.DB.QueryRow(`
+		SELECT job_id, level, summary, COALESCE(reasons, '{}'), suggested_start, checked_at, severe_notified_at
+		FROM job_weather_advisories
+		WHERE job_id = $1
+	`, jobID).Scan(
+		&advisory.JobID, &advisory.Level, &advisory.Summary, pq.Array(&advisory.Reasons),
+		&suggestedStart, &advisory.CheckedAt, &severeNotifiedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	advisory.SuggestedStart = timePtrFromNull(suggestedStart)
+	advisory.SevereNotifiedAt = timePtrFromNull(severeNotifiedAt)
+	return &advisory, nil
+}
+
+// rescheduleJob holds the job fields needed to propose or accept a new time
+type rescheduleJob struct {
+	Title          string
+	Status         string
+	ConsumerID     int
+	GigWorkerID    sql.NullInt64
+	ScheduledStart sql.NullTime
+	ScheduledEnd   sql.NullTime
+	DurationHours  float64
+}
+
+// isParticipant reports whether userID is the job's consumer or assigned worker
+func (j *rescheduleJob) isParticipant(userID int) bool {
+	return userID == j.ConsumerID || (j.GigWorkerID.Valid && int(j.GigWorkerID.Int64) == userID)
+}
+
+// reschedulable reports whether a job in status can still move to another time:
+// it has a worker and has not started, finished or been cancelled
+func reschedulable(status string) bool {
+	switch status {
+	case "accepted", "worker_assigned", "scheduled":
+		return true
+	}
+	return false
+}
+
+// getRescheduleJob loads a job and checks the user is one of its participants
+func getRescheduleJob(w http.ResponseWriter, r *http.Request, jobID, userID int) (*rescheduleJob, bool) {
+	job, ok := loadRescheduleJob(w, r, jobID)
+	if !ok {
+		return nil, false
+	}
+	if !job.isParticipant(userID) {
+		RespondWithError(w, http.StatusForbidden, "Only job participants can reschedule this job")
+		return nil, false
+	}
+	return job, true
+}
+
+// loadRescheduleJob loads a job's schedule and participants
+func loadRescheduleJob(w http.ResponseWriter, r *http.Request, jobID int) (*rescheduleJob, bool) {
+	var job rescheduleJob
+	err := config.DB.QueryRow(`
+		SELECT title, status, consumer_id, gig_worker_id, scheduled_start, scheduled_end,
+		       COALESCE(estimated_duration_hours, 2)
+		FROM jobs WHERE id = $1
+	`, jobID).Scan(
+		&job.Title, &job.Status, &job.ConsumerID, &job.GigWorkerID,
+		&job.ScheduledStart, &job.ScheduledEnd, &job.DurationHours,
+	)
+	if err == sql.ErrNoRows {
+		respondError(w, http.StatusNotFound, model.ErrCodeJobNotFound, "Job not found")
+		return nil, false
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return nil, false
+	}
+	return &job, true
+}
+
+// otherParty returns the participant who is not userID, if assigned
+func (j *rescheduleJob) otherParty(userID int) *int {
+	if userID == j.ConsumerID {
+		return intPtrFromNull(j.GigWorkerID)
+	}
+	return &j.ConsumerID
+}
+
+// ==============================================
+// WEATHER ADVISORIES
+// ==============================================
+
+// GetJobWeather returns the forecast advisory for a scheduled outdoor job to its
+// participants and admins
+func GetJobWeather(w http.ResponseWriter, r *http.Request) {
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	job, ok := loadRescheduleJob(w, r, jobID)
+	if !ok {
+		return
+	}
+	if !job.isParticipant(GetUserIDFromContext(r)) && GetUserRoleFromContext(r) != "admin" {
+		RespondWithError(w, http.StatusForbidden, "Only job participants can view its weather advisory")
+		return
+	}
+
+	advisory, err := loadWeatherAdvisory(jobID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting weather advisory", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if advisory == nil {
+		RespondWithError(w, http.StatusNotFound, "No weather advisory for this job")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, advisory)
+}
+
+// ==============================================
+// RESCHEDULE PROPOSALS
+// ==============================================
+
+const rescheduleProposalColumns = `
+	id, uuid, job_id, proposed_by, reason, original_start, original_end,
+	proposed_start, proposed_end, status, responded_by, responded_at, created_at
+`
+
+// scanRescheduleProposal scans a job_reschedule_proposals row selected with rescheduleProposalColumns
+func scanRescheduleProposal(row rowScanner) (*model.RescheduleProposal, error) {
+	var p model.RescheduleProposal
+	var originalStart, originalEnd, respondedAt sql.NullTime
+	var respondedBy sql.NullInt64
+
+	err := row.Scan(
+		&p.ID, &p.UUID, &p.JobID, &p.ProposedBy, &p.Reason, &originalStart, &originalEnd,
+		&p.ProposedStart, &p.ProposedEnd, &p.Status, &respondedBy, &respondedAt, &p.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	p.OriginalStart = timePtrFromNull(originalStart)
+	p.OriginalEnd = timePtrFromNull(originalEnd)
+	p.RespondedBy = intPtrFromNull(respondedBy)
+	p.RespondedAt = timePtrFromNull(respondedAt)
+	return &p, nil
+}
+
+// ProposeReschedule lets either participant propose a new time for a scheduled job.
+// With an empty body the weather advisory's suggested start is proposed (one-tap).
+func ProposeReschedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	var req model.RescheduleProposalRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+			return
+		}
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" {
+		req.Reason = "weather"
+	}
+
+	job, ok := getRescheduleJob(w, r, jobID, userID)
+	if !ok {
+		return
+	}
+	if !reschedulable(job.Status) {
+		RespondWithError(w, http.StatusConflict, "Only upcoming jobs can be rescheduled")
+		return
+	}
+
+	proposedStart := req.ProposedStart
+	if proposedStart == nil {
+		advisory, err := loadWeatherAdvisory(jobID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Database error getting weather advisory", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		if advisory == nil || advisory.SuggestedStart == nil {
+			RespondWithValidationError(w, &ValidationError{Field: "proposed_start", Message: "is required when no weather suggestion is available"})
+			return
+		}
+		proposedStart = advisory.SuggestedStart
+	}
+	if !proposedStart.After(appFrom(r.Context()).Clock.Now()) {
+		RespondWithValidationError(w, &ValidationError{Field: "proposed_start", Message: "must be in the future"})
+		return
+	}
+
+	// Keep the original duration
+	duration := time.Duration(job.DurationHours * float64(time.Hour))
+	if job.ScheduledStart.Valid && job.ScheduledEnd.Valid {
+		duration = job.ScheduledEnd.Time.Sub(job.ScheduledStart.Time)
+	}
+	proposedEnd := proposedStart.Add(duration)
+
+	tx, err := config.DB.Begin()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error starting transaction", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer tx.Rollback()
+
+	// A new proposal replaces any pending one
+	_, err = tx.Exec(`
+		UPDATE job_reschedule_proposals SET status = 'superseded'
+		WHERE job_id = $1 AND status = 'pending'
+	`, jobID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error superseding reschedule proposals", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	row := tx.QueryRow(`
+		INSERT INTO job_reschedule_proposals (job_id, proposed_by, reason, original_start, original_end, proposed_start, proposed_end)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+rescheduleProposalColumns,
+		jobID, userID, req.Reason, job.ScheduledStart, job.ScheduledEnd, *proposedStart, proposedEnd,
+	)
+	proposal, err := scanRescheduleProposal(row)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error creating reschedule proposal", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to create reschedule proposal")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(r.Context(), "Database error committing reschedule proposal", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	if otherPartyID := job.otherParty(userID); otherPartyID != nil {
+		go notifyRescheduleProposal(r.Context(), *proposal, job.Title, *otherPartyID)
+	}
+
+	RespondWithJSON(w, http.StatusCreated, proposal)
+}
+
+// GetRescheduleProposals lists reschedule proposals for a job
+func GetRescheduleProposals(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+
+	if _, ok := getRescheduleJob(w, r, jobID, userID); !ok {
+		return
+	}
+
+	rows, err := config.DB.Query(`
+		SELECT `+rescheduleProposalColumns+`
+		FROM job_reschedule_proposals
+		WHERE job_id = $1
+		ORDER BY created_at DESC
+	`, jobID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying reschedule proposals", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	proposals := []model.RescheduleProposal{}
+	for rows.Next() {
+		p, err := scanRescheduleProposal(rows)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning reschedule proposal", "error", err)
+			continue
+		}
+		proposals = append(proposals, *p)
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"proposals": proposals,
+	})
+}
+
+// RespondToReschedule lets the other participant accept or decline a pending proposal.
+// Accepting moves the job's schedule and clears its weather advisory for re-checking.
+func RespondToReschedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := GetUserIDFromContext(r)
+	if userID == 0 {
+		RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	jobID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid job ID format")
+		return
+	}
+	proposalID, err := strconv.Atoi(chi.URLParam(r, "proposalId"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid proposal ID format")
+		return
+	}
+
+	var req model.RescheduleResponseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+
+	if _, ok := getRescheduleJob(w, r, jobID, userID); !ok {
+		return
+	}
+
+	tx, err := config.DB.Begin()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error starting transaction", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer tx.Rollback()
+
+	proposal, err := scanRescheduleProposal(tx.QueryRow(`
+		SELECT `+rescheduleProposalColumns+`
+		FROM job_reschedule_proposals
+		WHERE id = $1 AND job_id = $2
+		FOR UPDATE
+	`, proposalID, jobID))
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Reschedule proposal not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting reschedule proposal", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if proposal.Status != model.RescheduleStatusPending {
+		RespondWithError(w, http.StatusConflict, "Proposal is no longer pending")
+		return
+	}
+	if proposal.ProposedBy == userID {
+		RespondWithError(w, http.StatusForbidden, "The other participant must respond to this proposal")
+		return
+	}
+
+	// The job may have started, finished or been cancelled since the proposal was made
+	var jobStatus string
+	if err := tx.QueryRow(`SELECT status FROM jobs WHERE id = $1 FOR UPDATE`, jobID).Scan(&jobStatus); err != nil {
+		slog.ErrorContext(r.Context(), "Database error getting job", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if !reschedulable(jobStatus) {
+		RespondWithError(w, http.StatusConflict, "Only upcoming jobs can be rescheduled")
+		return
+	}
+
+	status := model.RescheduleStatusDeclined
+	if req.Accept {
+		status = model.RescheduleStatusAccepted
+
+		_, err = tx.Exec(`
+			UPDATE jobs SET scheduled_start = $1, scheduled_end = $2, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $3
+		`, proposal.ProposedStart, proposal.ProposedEnd, jobID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Database error rescheduling job", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Failed to reschedule job")
+			return
+		}
+
+		if _, err := tx.Exec(`DELETE FROM job_weather_advisories WHERE job_id = $1`, jobID); err != nil {
+			slog.ErrorContext(r.Context(), "Database error clearing weather advisory", "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+	}
+
+	proposal, err = scanRescheduleProposal(tx.QueryRow(`
+		UPDATE job_reschedule_proposals
+		SET status = $1, responded_by = $2, responded_at = NOW()
+		WHERE id = $3
+		RETURNING `+rescheduleProposalColumns,
+		status, userID, proposalID,
+	))
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error updating reschedule proposal", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(r.Context(), "Database error committing reschedule response", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, proposal)
+}
+
+// notifyRescheduleProposal emails the other participant about a new proposed time
+func notifyRescheduleProposal(ctx context.Context, proposal model.RescheduleProposal, jobTitle string, recipientID int) {
+	emailService, err := email.NewServiceFromEnv()
+	if err != nil {
+		slog.ErrorContext(ctx, "Email not configured, reschedule proposal not sent", "proposal_id", proposal.ID, "error", err)
+		return
+	}
+
+	var toEmail, toName string
+	err = config.DB.QueryRow(`SELECT email, name FROM people WHERE id = $1`, recipientID).Scan(&toEmail, &toName)
+	if err != nil {
+		slog.ErrorContext(ctx, "Database error getting reschedule recipient", "recipient_id", recipientID, "error", err)
+		return
+	}
+
+	baseURL := os.Getenv("APP_BASE_URL")
+	if baseURL == "" {
+		baseURL = "https://app.gigco.com"
+	}
+
+	message := fmt.Sprintf("A new time has been proposed for this job: %s.",
+		proposal.ProposedStart.Format("Monday, Jan 2 at 3:04 PM MST"))
+	if proposal.Reason == "weather" {
+		message = "Severe weather is forecast for the scheduled time. " + message
+	}
+
+	err = emailService.SendJobNotification(toEmail, toName, email.JobNotificationData{
+		UserName:    toName,
+		JobTitle:    jobTitle,
+		JobID:       strconv.Itoa(proposal.JobID),
+		Message:     message,
+		ActionLink:  fmt.Sprintf("%s/jobs/%d/reschedule/%d", baseURL, proposal.JobID, proposal.ID),
+		ActionLabel: "Accept or decline",
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to send reschedule proposal", "proposal_id", proposal.ID, "error", err)
+	}
+}
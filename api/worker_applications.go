@@ -0,0 +1,572 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/email"
+	"app/internal/model"
+	"app/internal/validate"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lib/pq"
+)
+
+const workerApplicationColumns = `
+	a.id, a.uuid, a.user_id, p.name, p.email, a.status, a.address, a.bio, a.skills, a.hourly_rate,
+	a.experience_years, a.service_radius_miles, a.availability_notes, a.reviewer_id,
+	a.decision_note, a.submitted_at, a.decided_at, a.updated_at
+`
+
+// scanWorkerApplication scans a worker_applications row (joined to people as p)
+// selected with workerApplicationColumns
+func scanWorkerApplication(row rowScanner) (*model.WorkerApplication, error) {
+	var app model.WorkerApplication
+	var bio, skills, availabilityNotes, decisionNote sql.NullString
+	var hourlyRate, serviceRadiusMiles sql.NullFloat64
+	var experienceYears, reviewerID sql.NullInt64
+	var decidedAt sql.NullTime
+
+	err := row.Scan(
+		&app.ID, &app.UUID, &app.UserID, &app.ApplicantName, &app.ApplicantEmail, &app.Status,
+		&app.Address, &bio, &skills, &hourlyRate, &experienceYears, &serviceRadiusMiles,
+		&availabilityNotes, &reviewerID, &decisionNote, &app.SubmittedAt, &decidedAt, &app.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	app.Bio = stringPtrFromNull(bio)
+	app.Skills = stringPtrFromNull(skills)
+	app.AvailabilityNotes = stringPtrFromNull(availabilityNotes)
+	app.DecisionNote = stringPtrFromNull(decisionNote)
+	app.HourlyRate = float64PtrFromNull(hourlyRate)
+	app.ServiceRadiusMiles = float64PtrFromNull(serviceRadiusMiles)
+	app.ExperienceYears = intPtrFromNull(experienceYears)
+	app.ReviewerID = intPtrFromNull(reviewerID)
+	app.DecidedAt = timePtrFromNull(decidedAt)
+	return &app, nil
+}
+
+// ==============================================
+// WORKER APPLICATIONS (APPLICANTS)
+// ==============================================
+
+// SubmitWorkerApplication lets a user apply to become a gig worker. The application
+// is screened by admins; only approved applicants become eligible for matching.
+func SubmitWorkerApplication(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+	if GetUserRoleFromContext(r) == "admin" {
+		RespondWithError(w, http.StatusForbidden, "Admin accounts cannot apply to become gig workers")
+		return
+	}
+
+	var req model.WorkerApplicationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	if err := validateWorkerApplication(&req); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+
+	var verified bool
+	err := config.DB.QueryRow(`
+		SELECT EXISTS(SELECT 1 FROM worker_profiles WHERE worker_id = $1 AND verification_status = 'verified')
+	`, userID).Scan(&verified)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error checking worker verification", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if verified {
+		RespondWithError(w, http.StatusConflict, "You are already an approved gig worker")
+		return
+	}
+
+	tx, err := config.DB.Begin()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error starting transaction", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to submit application")
+		return
+	}
+	defer tx.Rollback()
+
+	var applicationID int
+	err = tx.QueryRow(`
+		INSERT INTO worker_applications (
+			user_id, address, bio, skills, hourly_rate, experience_years,
+			service_radius_miles, availability_notes
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`, userID, req.Address, req.Bio, req.Skills, req.HourlyRate, req.ExperienceYears,
+		req.ServiceRadiusMiles, req.AvailabilityNotes).Scan(&applicationID)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		RespondWithError(w, http.StatusConflict, "You already have an application in screening")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error creating worker application", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to submit application")
+		return
+	}
+
+	if err := recordWorkerApplicationEvent(tx, applicationID, nil, model.ApplicationStatusSubmitted, userID, nil); err != nil {
+		slog.ErrorContext(r.Context(), "Database error recording worker application submission", "application_id", applicationID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to submit application")
+		return
+	}
+
+	// Emergency contacts go straight to the encrypted vault, keyed by the account. The
+	// application is only committed once its contact is stored.
+	if req.EmergencyContactName != "" || req.EmergencyContactPhone != "" {
+		contact := model.EmergencyContact{
+			Name:         req.EmergencyContactName,
+			Phone:        req.EmergencyContactPhone,
+			Relationship: req.EmergencyContactRelationship,
+		}
+		if err := saveEmergencyContact(userID, contact); err != nil {
+			slog.ErrorContext(r.Context(), "Failed to store emergency contact for applicant", "user_id", userID, "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Failed to store emergency contact")
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(r.Context(), "Database error committing worker application", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to submit application")
+		return
+	}
+
+	app, err := getWorkerApplication(applicationID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error loading worker application", "application_id", applicationID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"success":     true,
+		"message":     "Application submitted; we will let you know as it moves through screening",
+		"application": app,
+	})
+}
+
+// validateWorkerApplication checks an application against the worker profile rules
+func validateWorkerApplication(req *model.WorkerApplicationRequest) error {
+	req.Address = strings.TrimSpace(req.Address)
+
+	var v validate.Validator
+	v.Required("address", req.Address)
+	v.Check(req.HourlyRate == nil || *req.HourlyRate > 0, "hourly_rate", "must be greater than 0")
+	v.Check(req.ExperienceYears == nil || (*req.ExperienceYears >= 0 && *req.ExperienceYears <= 50), "experience_years", "must be between 0 and 50")
+	v.Check(req.ServiceRadiusMiles == nil || (*req.ServiceRadiusMiles >= 1 && *req.ServiceRadiusMiles <= 100), "service_radius_miles", "must be between 1 and 100 miles")
+	v.MaxLength("bio", req.Bio, 5000)
+	v.MaxLength("skills", req.Skills, 1000)
+	v.MaxLength("availability_notes", req.AvailabilityNotes, 1000)
+	return v.Err()
+}
+
+// GetMyWorkerApplications lists the caller's worker applications with their
+// screening history, newest first
+func GetMyWorkerApplications(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	rows, err := config.DB.Query(`
+		SELECT `+workerApplicationColumns+`
+		FROM worker_applications a
+		JOIN people p ON p.id = a.user_id
+		WHERE a.user_id = $1
+		ORDER BY a.submitted_at DESC, a.id DESC
+	`, userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying worker applications", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	applications := []model.WorkerApplication{}
+	for rows.Next() {
+		app, err := scanWorkerApplication(rows)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning worker application row", "error", err)
+			continue
+		}
+		applications = append(applications, *app)
+	}
+	rows.Close()
+
+	for i := range applications {
+		applications[i].History, err = getWorkerApplicationHistory(applications[i].ID)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Database error loading history of worker application", "id", applications[i].ID, "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"applications": applications,
+	})
+}
+
+// ==============================================
+// WORKER APPLICATION SCREENING (ADMIN)
+// ==============================================
+
+// GetWorkerApplications lists worker applications for screening, oldest first so the
+// queue is worked in order
+func GetWorkerApplications(w http.ResponseWriter, r *http.Request) {
+	page, err := ParseIntParam(r, "page", 1, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	limit, err := ParseIntParam(r, "limit", DefaultPageSize, MinPageSize, MaxPageSize)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	whereClause := ""
+	var args []any
+	switch status := r.URL.Query().Get("status"); status {
+	case "":
+	case "open":
+		whereClause = " WHERE a.status NOT IN ('approved', 'denied')"
+	case model.ApplicationStatusSubmitted, model.ApplicationStatusDocsPending, model.ApplicationStatusBackgroundCheck,
+		model.ApplicationStatusApproved, model.ApplicationStatusDenied:
+		whereClause = " WHERE a.status = $1"
+		args = append(args, status)
+	default:
+		RespondWithValidationError(w, &ValidationError{
+			Field:   "status",
+			Message: "must be open, submitted, docs_pending, background_check, approved or denied",
+			Value:   status,
+		})
+		return
+	}
+
+	var total int
+	if err := config.DB.QueryRow("SELECT COUNT(*) FROM worker_applications a"+whereClause, args...).Scan(&total); err != nil {
+		slog.ErrorContext(r.Context(), "Database error counting worker applications", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	query := `
+		SELECT ` + workerApplicationColumns + `
+		FROM worker_applications a
+		JOIN people p ON p.id = a.user_id` + whereClause +
+		fmt.Sprintf(" ORDER BY a.submitted_at ASC, a.id ASC LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, limit, (page-1)*limit)
+
+	rows, err := config.DB.Query(query, args...)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying worker applications", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	applications := []model.WorkerApplication{}
+	for rows.Next() {
+		app, err := scanWorkerApplication(rows)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning worker application row", "error", err)
+			continue
+		}
+		applications = append(applications, *app)
+	}
+
+	pages := (total + limit - 1) / limit
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"applications": applications,
+		"pagination": model.Pagination{
+			Page:    page,
+			Limit:   limit,
+			Total:   total,
+			Pages:   pages,
+			HasNext: page < pages,
+			HasPrev: page > 1,
+		},
+	})
+}
+
+// GetWorkerApplicationByID returns a worker application with its screening history
+func GetWorkerApplicationByID(w http.ResponseWriter, r *http.Request) {
+	applicationID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid application ID format")
+		return
+	}
+
+	app, err := getWorkerApplication(applicationID)
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Application not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error loading worker application", "application_id", applicationID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, app)
+}
+
+// UpdateWorkerApplicationStatus moves an application to its next screening step.
+// Approval makes the applicant a gig worker with a verified profile, which makes them
+// eligible for matching; the applicant's next token refresh carries the new role.
+func UpdateWorkerApplicationStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	adminID := GetUserIDFromContext(r)
+	applicationID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid application ID format")
+		return
+	}
+
+	var req model.WorkerApplicationStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	switch req.Status {
+	case model.ApplicationStatusDocsPending, model.ApplicationStatusBackgroundCheck,
+		model.ApplicationStatusApproved, model.ApplicationStatusDenied:
+	default:
+		RespondWithValidationError(w, &ValidationError{
+			Field:   "status",
+			Message: "must be docs_pending, background_check, approved or denied",
+			Value:   req.Status,
+		})
+		return
+	}
+	if req.Note != nil {
+		note := strings.TrimSpace(*req.Note)
+		req.Note = &note
+		if note == "" {
+			req.Note = nil
+		}
+	}
+	if req.Status == model.ApplicationStatusDenied && req.Note == nil {
+		RespondWithValidationError(w, &ValidationError{Field: "note", Message: "is required when denying an application"})
+		return
+	}
+
+	tx, err := config.DB.Begin()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error starting transaction", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update application")
+		return
+	}
+	defer tx.Rollback()
+
+	var current string
+	var userID int
+	err = tx.QueryRow(`SELECT status, user_id FROM worker_applications WHERE id = $1 FOR UPDATE`, applicationID).Scan(&current, &userID)
+	if err == sql.ErrNoRows {
+		RespondWithError(w, http.StatusNotFound, "Application not found")
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error locking worker application", "application_id", applicationID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update application")
+		return
+	}
+	if !model.CanTransitionWorkerApplication(current, req.Status) {
+		RespondWithError(w, http.StatusConflict, fmt.Sprintf("Application cannot move from %s to %s", current, req.Status))
+		return
+	}
+
+	final := req.Status == model.ApplicationStatusApproved || req.Status == model.ApplicationStatusDenied
+	_, err = tx.Exec(`
+		UPDATE worker_applications
+		SET status = $1, reviewer_id = $2, decision_note = COALESCE($3, decision_note),
+		    decided_at = CASE WHEN $4 THEN NOW() ELSE decided_at END
+		WHERE id = $5
+	`, req.Status, adminID, req.Note, final, applicationID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error updating worker application", "application_id", applicationID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update application")
+		return
+	}
+
+	if err := recordWorkerApplicationEvent(tx, applicationID, &current, req.Status, adminID, req.Note); err != nil {
+		slog.ErrorContext(r.Context(), "Database error recording worker application event", "application_id", applicationID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update application")
+		return
+	}
+
+	if req.Status == model.ApplicationStatusApproved {
+		if err := approveWorkerApplication(tx, applicationID, userID); err != nil {
+			slog.ErrorContext(r.Context(), "Database error approving worker application", "application_id", applicationID, "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Failed to approve application")
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(r.Context(), "Database error committing worker application", "application_id", applicationID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to update application")
+		return
+	}
+
+	slog.InfoContext(r.Context(), "Worker application status changed by admin", "application_id", applicationID, "from", current, "to", req.Status, "admin_id", adminID)
+	notifyApplicant(r.Context(), userID, req.Status, req.Note)
+
+	app, err := getWorkerApplication(applicationID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error loading worker application", "application_id", applicationID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success":     true,
+		"message":     "Application updated successfully",
+		"application": app,
+	})
+}
+
+// approveWorkerApplication makes the applicant a gig worker whose profile, built from
+// the application, is verified and so eligible for matching
+func approveWorkerApplication(tx *sql.Tx, applicationID, userID int) error {
+	_, err := tx.Exec(`
+		UPDATE people p SET role = 'gig_worker', address = a.address, updated_at = NOW()
+		FROM worker_applications a
+		WHERE a.id = $1 AND p.id = a.user_id
+	`, applicationID)
+	if err != nil {
+		return fmt.Errorf("failed to promote applicant %d: %w", userID, err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO worker_profiles (
+			worker_id, bio, skills, hourly_rate, experience_years, service_radius_miles,
+			availability_notes, verification_status, background_check_date
+		)
+		SELECT user_id, bio, skills, hourly_rate, experience_years, COALESCE(service_radius_miles, 25.0),
+		       availability_notes, 'verified', CURRENT_DATE
+		FROM worker_applications WHERE id = $1
+		ON CONFLICT (worker_id) DO UPDATE SET
+			bio = EXCLUDED.bio,
+			skills = EXCLUDED.skills,
+			hourly_rate = EXCLUDED.hourly_rate,
+			experience_years = EXCLUDED.experience_years,
+			service_radius_miles = EXCLUDED.service_radius_miles,
+			availability_notes = EXCLUDED.availability_notes,
+			verification_status = 'verified',
+			background_check_date = CURRENT_DATE
+	`, applicationID)
+	if err != nil {
+		return fmt.Errorf("failed to create worker profile for applicant %d: %w", userID, err)
+	}
+	return nil
+}
+
+// notifyApplicant tells the applicant their application moved on
+func notifyApplicant(ctx context.Context, userID int, status string, note *string) {
+	title, message := "Application update", ""
+	switch status {
+	case model.ApplicationStatusDocsPending:
+		message = "We need a few more documents to continue screening your gig worker application."
+	case model.ApplicationStatusBackgroundCheck:
+		message = "Your gig worker application has moved to the background check."
+	case model.ApplicationStatusApproved:
+		title = "Application approved"
+		message = "You're approved as a gig worker and can now be matched with jobs. Sign in again to see worker features."
+	case model.ApplicationStatusDenied:
+		title = "Application not approved"
+		message = "Your gig worker application was not approved."
+	}
+	if note != nil {
+		message += " " + *note
+	}
+
+	_, err := email.NewNotificationDispatcher(config.DB).Dispatch(ctx, model.Notification{
+		UserID:  userID,
+		Type:    model.NotificationSystemMessage,
+		Title:   title,
+		Message: message,
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to notify applicant of application status", "user_id", userID, "status", status, "error", err)
+	}
+}
+
+func recordWorkerApplicationEvent(tx *sql.Tx, applicationID int, from *string, to string, actorID int, note *string) error {
+	_, err := tx.Exec(`
+		INSERT INTO worker_application_events (application_id, from_status, to_status, actor_id, note)
+		VALUES ($1, $2, $3, $4, $5)
+	`, applicationID, from, to, actorID, note)
+	return err
+}
+
+// getWorkerApplication loads an application with its screening history
+func getWorkerApplication(applicationID int) (*model.WorkerApplication, error) {
+	app, err := scanWorkerApplication(config.DB.QueryRow(`
+		SELECT `+workerApplicationColumns+`
+		FROM worker_applications a
+		JOIN people p ON p.id = a.user_id
+		WHERE a.id = $1
+	`, applicationID))
+	if err != nil {
+		return nil, err
+	}
+	app.History, err = getWorkerApplicationHistory(applicationID)
+	if err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+func getWorkerApplicationHistory(applicationID int) ([]model.WorkerApplicationEvent, error) {
+	rows, err := config.DB.Query(`
+		SELECT from_status, to_status, actor_id, note, occurred_at
+		FROM worker_application_events
+		WHERE application_id = $1
+		ORDER BY occurred_at, id
+	`, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []model.WorkerApplicationEvent
+	for rows.Next() {
+		var e model.WorkerApplicationEvent
+		var from, note sql.NullString
+		if err := rows.Scan(&from, &e.ToStatus, &e.ActorID, &note, &e.OccurredAt); err != nil {
+			return nil, err
+		}
+		e.FromStatus = stringPtrFromNull(from)
+		e.Note = stringPtrFromNull(note)
+		history = append(history, e)
+	}
+	return history, rows.Err()
+}
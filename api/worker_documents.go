@@ -0,0 +1,480 @@
+package api
+
+import (
+	"app/config"
+	"app/internal/email"
+	"app/internal/model"
+	"app/internal/storage"
+	"app/internal/validate"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// documentUploadURLTTL is how long a signed document upload URL stays valid
+const documentUploadURLTTL = 15 * time.Minute
+
+const workerDocumentColumns = `
+	d.id, d.uuid, d.worker_id, p.name, d.document_type, d.attachment_id, a.content_type,
+	a.size_bytes, a.scan_status, d.expires_on, d.status, d.reviewer_id, d.review_note,
+	d.reviewed_at, d.created_at
+`
+
+// workerDocumentFrom joins worker_documents (d) to the worker (p) and the file (a)
+const workerDocumentFrom = `
+	FROM worker_documents d
+	JOIN people p ON p.id = d.worker_id
+	JOIN attachments a ON a.id = d.attachment_id
+`
+
+// scanWorkerDocument scans a row selected with workerDocumentColumns
+func scanWorkerDocument(row rowScanner) (*model.WorkerDocument, error) {
+	var d model.WorkerDocument
+	var expiresOn, reviewedAt sql.NullTime
+	var reviewerID sql.NullInt64
+	var reviewNote sql.NullString
+
+	err := row.Scan(
+		&d.ID, &d.UUID, &d.WorkerID, &d.WorkerName, &d.DocumentType, &d.AttachmentID, &d.ContentType,
+		&d.SizeBytes, &d.ScanStatus, &expiresOn, &d.Status, &reviewerID, &reviewNote,
+		&reviewedAt, &d.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if expiresOn.Valid {
+		date := expiresOn.Time.Format("2006-01-02")
+		d.ExpiresOn = &date
+	}
+	d.ReviewerID = intPtrFromNull(reviewerID)
+	d.ReviewNote = stringPtrFromNull(reviewNote)
+	d.ReviewedAt = timePtrFromNull(reviewedAt)
+	return &d, nil
+}
+
+// workerDocumentPrefix groups a worker's document files in the attachment store
+func workerDocumentPrefix(workerID int) string {
+	return fmt.Sprintf("workers/%d", workerID)
+}
+
+// ==============================================
+// WORKER DOCUMENTS (WORKERS AND APPLICANTS)
+// ==============================================
+
+// CreateWorkerDocumentUpload returns a signed URL the caller uploads a verification
+// document to directly, so large scans do not pass through the API. The file is
+// checked when it is submitted with SubmitWorkerDocument.
+func CreateWorkerDocumentUpload(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	var req model.WorkerDocumentUploadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	var v validate.Validator
+	v.Required("document_type", req.DocumentType)
+	v.OneOf("document_type", req.DocumentType, model.DocumentTypeGovernmentID, model.DocumentTypeLicense, model.DocumentTypeInsurance)
+	v.Required("content_type", req.ContentType)
+	v.Check(req.SizeBytes > 0, "size_bytes", "must be greater than 0")
+	if v.Valid("content_type") && v.Valid("size_bytes") {
+		if err := storage.Validate(storage.KindDocument, req.ContentType, req.SizeBytes); err != nil {
+			if errors.Is(err, storage.ErrTooLarge) {
+				v.Add("size_bytes", err.Error())
+			} else {
+				v.AddValue("content_type", err.Error(), req.ContentType)
+			}
+		}
+	}
+	if err := v.Err(); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+
+	store, err := getAttachmentStore()
+	if err != nil {
+		RespondWithError(w, http.StatusServiceUnavailable, "Uploads are temporarily unavailable")
+		return
+	}
+	key, err := storage.NewUploadKey(workerDocumentPrefix(userID), storage.KindDocument, req.ContentType)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to create document upload key", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	uploadURL, err := store.SignedUploadURL(r.Context(), key, documentUploadURLTTL)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to sign document upload", "key", key, "error", err)
+		RespondWithError(w, http.StatusServiceUnavailable, "Uploads are temporarily unavailable")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, model.WorkerDocumentUpload{
+		UploadURL: uploadURL,
+		Method:    http.MethodPut,
+		Headers:   map[string]string{"Content-Type": req.ContentType},
+		ObjectKey: key,
+		ExpiresAt: appFrom(r.Context()).Clock.Now().Add(documentUploadURLTTL),
+	})
+}
+
+// validateWorkerDocument checks a document submission. Licenses and insurance
+// certificates must say when they expire, and nothing already expired is accepted.
+func validateWorkerDocument(req *model.WorkerDocumentRequest, workerID int, today time.Time) error {
+	var v validate.Validator
+	v.Required("document_type", req.DocumentType)
+	v.OneOf("document_type", req.DocumentType, model.DocumentTypeGovernmentID, model.DocumentTypeLicense, model.DocumentTypeInsurance)
+	v.Required("object_key", req.ObjectKey)
+	v.Check(req.ObjectKey == "" || strings.HasPrefix(req.ObjectKey, storage.IncomingPrefix+workerDocumentPrefix(workerID)+"/"),
+		"object_key", "must be a key from your own document upload")
+
+	if req.ExpiresOn == nil {
+		v.Check(req.DocumentType == model.DocumentTypeGovernmentID, "expires_on", "is required for licenses and insurance")
+	} else if expiresOn, err := time.Parse("2006-01-02", *req.ExpiresOn); err != nil {
+		v.AddValue("expires_on", "must be a date (YYYY-MM-DD)", *req.ExpiresOn)
+	} else {
+		v.Check(!expiresOn.Before(today), "expires_on", "must not be in the past")
+	}
+	return v.Err()
+}
+
+// SubmitWorkerDocument checks and scans a file uploaded to a signed upload URL and
+// queues it for admin review
+func SubmitWorkerDocument(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	var req model.WorkerDocumentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	today := appFrom(r.Context()).Clock.Now().UTC().Truncate(24 * time.Hour)
+	if err := validateWorkerDocument(&req, userID, today); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+
+	store, err := getAttachmentStore()
+	if err != nil {
+		RespondWithError(w, http.StatusServiceUnavailable, "Uploads are temporarily unavailable")
+		return
+	}
+	scanner, err := getAttachmentScanner()
+	if err != nil {
+		RespondWithError(w, http.StatusServiceUnavailable, "Uploads are temporarily unavailable")
+		return
+	}
+
+	obj, err := storage.Accept(r.Context(), store, scanner, storage.KindDocument, req.ObjectKey)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		RespondWithError(w, http.StatusNotFound, "Upload not found; upload the file before submitting it")
+		return
+	case errors.Is(err, storage.ErrTooLarge):
+		RespondWithError(w, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	case errors.Is(err, storage.ErrUnsupportedType), errors.Is(err, storage.ErrTypeMismatch):
+		RespondWithError(w, http.StatusUnsupportedMediaType, err.Error())
+		return
+	case err != nil:
+		slog.ErrorContext(r.Context(), "Failed to accept document upload", "key", req.ObjectKey, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	tx, err := config.DB.Begin()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error starting transaction", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to submit document")
+		return
+	}
+	defer tx.Rollback()
+
+	attachment, err := recordAttachment(tx, obj, storage.KindDocument, model.AttachmentOwnerWorker, userID, userID, scanner, appFrom(r.Context()).Clock.Now())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error recording attachment", "key", obj.Key, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to submit document")
+		return
+	}
+	if attachment.ScanStatus == storage.ScanInfected {
+		// Keep the record of the quarantined file, but no document for review
+		if err := tx.Commit(); err != nil {
+			slog.ErrorContext(r.Context(), "Database error committing attachment", "key", obj.Key, "error", err)
+		}
+		slog.WarnContext(r.Context(), "Verification document quarantined", "attachment_id", attachment.ID, "user_id", userID, "threat", obj.Threat)
+		RespondWithError(w, http.StatusUnprocessableEntity, "The file failed a malware scan and was not accepted")
+		return
+	}
+
+	var documentID int
+	err = tx.QueryRow(`
+		INSERT INTO worker_documents (worker_id, document_type, attachment_id, expires_on)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`, userID, req.DocumentType, attachment.ID, req.ExpiresOn).Scan(&documentID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error creating worker document", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to submit document")
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(r.Context(), "Database error committing worker document", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to submit document")
+		return
+	}
+
+	slog.InfoContext(r.Context(), "Verification document submitted", "document_id", documentID, "user_id", userID, "type", req.DocumentType)
+	document, err := getWorkerDocument(documentID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error loading worker document", "document_id", documentID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	RespondWithJSON(w, http.StatusCreated, document)
+}
+
+// GetMyWorkerDocuments lists the caller's verification documents, newest first
+func GetMyWorkerDocuments(w http.ResponseWriter, r *http.Request) {
+	userID, ok := RequireUserID(w, r, 0)
+	if !ok {
+		return
+	}
+
+	rows, err := config.DB.Query(`
+		SELECT `+workerDocumentColumns+workerDocumentFrom+`
+		WHERE d.worker_id = $1
+		ORDER BY d.created_at DESC, d.id DESC
+	`, userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying worker documents", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	documents := []model.WorkerDocument{}
+	for rows.Next() {
+		d, err := scanWorkerDocument(rows)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning worker document row", "error", err)
+			continue
+		}
+		documents = append(documents, *d)
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"documents": documents,
+	})
+}
+
+// ==============================================
+// WORKER DOCUMENT REVIEW (ADMIN)
+// ==============================================
+
+// GetWorkerDocuments lists verification documents for review, oldest first so the
+// queue is worked in order. Filter by status and worker_id.
+func GetWorkerDocuments(w http.ResponseWriter, r *http.Request) {
+	page, err := ParseIntParam(r, "page", 1, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	limit, err := ParseIntParam(r, "limit", DefaultPageSize, MinPageSize, MaxPageSize)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+	workerID, err := ParseIntParam(r, "worker_id", 0, 1, 0)
+	if err != nil {
+		RespondWithValidationError(w, err.(*ValidationError))
+		return
+	}
+
+	var conditions []string
+	var args []any
+	switch status := r.URL.Query().Get("status"); status {
+	case "":
+	case model.DocumentStatusPending, model.DocumentStatusApproved, model.DocumentStatusRejected, model.DocumentStatusExpired:
+		args = append(args, status)
+		conditions = append(conditions, fmt.Sprintf("d.status = $%d", len(args)))
+	default:
+		RespondWithValidationError(w, &ValidationError{
+			Field:   "status",
+			Message: "must be pending, approved, rejected or expired",
+			Value:   status,
+		})
+		return
+	}
+	if workerID != 0 {
+		args = append(args, workerID)
+		conditions = append(conditions, fmt.Sprintf("d.worker_id = $%d", len(args)))
+	}
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := config.DB.QueryRow("SELECT COUNT(*) FROM worker_documents d"+whereClause, args...).Scan(&total); err != nil {
+		slog.ErrorContext(r.Context(), "Database error counting worker documents", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	query := `SELECT ` + workerDocumentColumns + workerDocumentFrom + whereClause +
+		fmt.Sprintf(" ORDER BY d.created_at ASC, d.id ASC LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, limit, (page-1)*limit)
+
+	rows, err := config.DB.Query(query, args...)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error querying worker documents", "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	defer rows.Close()
+
+	documents := []model.WorkerDocument{}
+	for rows.Next() {
+		d, err := scanWorkerDocument(rows)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error scanning worker document row", "error", err)
+			continue
+		}
+		documents = append(documents, *d)
+	}
+
+	pages := (total + limit - 1) / limit
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"documents": documents,
+		"pagination": model.Pagination{
+			Page:    page,
+			Limit:   limit,
+			Total:   total,
+			Pages:   pages,
+			HasNext: page < pages,
+			HasPrev: page > 1,
+		},
+	})
+}
+
+// ReviewWorkerDocument approves or rejects a pending verification document. The
+// worker is notified either way; a rejection note tells them what to fix.
+func ReviewWorkerDocument(w http.ResponseWriter, r *http.Request) {
+	adminID := GetUserIDFromContext(r)
+	documentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid document ID format")
+		return
+	}
+
+	var req model.WorkerDocumentReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON data")
+		return
+	}
+	if req.Note != nil {
+		note := strings.TrimSpace(*req.Note)
+		req.Note = &note
+		if note == "" {
+			req.Note = nil
+		}
+	}
+	var v validate.Validator
+	v.Required("status", req.Status)
+	v.OneOf("status", req.Status, model.DocumentStatusApproved, model.DocumentStatusRejected)
+	v.Check(req.Status != model.DocumentStatusRejected || req.Note != nil, "note", "is required when rejecting a document")
+	v.MaxLength("note", req.Note, 1000)
+	if err := v.Err(); err != nil {
+		RespondWithValidationErrors(w, err)
+		return
+	}
+
+	var workerID int
+	var documentType, scanStatus string
+	err = config.DB.QueryRow(`
+		UPDATE worker_documents d
+		SET status = $1, reviewer_id = $2, review_note = $3, reviewed_at = NOW()
+		FROM attachments a
+		WHERE d.id = $4 AND d.status = 'pending' AND a.id = d.attachment_id
+		  AND ($1 = 'rejected' OR a.scan_status IN ('clean', 'skipped'))
+		RETURNING d.worker_id, d.document_type, a.scan_status
+	`, req.Status, adminID, req.Note, documentID).Scan(&workerID, &documentType, &scanStatus)
+	if err == sql.ErrNoRows {
+		document, err := getWorkerDocument(documentID)
+		switch {
+		case err == sql.ErrNoRows:
+			RespondWithError(w, http.StatusNotFound, "Document not found")
+		case err != nil:
+			slog.ErrorContext(r.Context(), "Database error loading worker document", "document_id", documentID, "error", err)
+			RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		case document.Status != model.DocumentStatusPending:
+			RespondWithError(w, http.StatusConflict, fmt.Sprintf("Document is already %s", document.Status))
+		default:
+			RespondWithError(w, http.StatusConflict, "Document cannot be approved until its malware scan passes; rescan the attachment first")
+		}
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error reviewing worker document", "document_id", documentID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Failed to review document")
+		return
+	}
+
+	slog.InfoContext(r.Context(), "Verification document reviewed by admin", "document_id", documentID, "status", req.Status, "admin_id", adminID)
+	notifyDocumentReviewed(r.Context(), workerID, documentType, req.Status, req.Note)
+
+	document, err := getWorkerDocument(documentID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Database error loading worker document", "document_id", documentID, "error", err)
+		RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success":  true,
+		"message":  "Document reviewed successfully",
+		"document": document,
+	})
+}
+
+// notifyDocumentReviewed tells the worker their document was approved or rejected
+func notifyDocumentReviewed(ctx context.Context, workerID int, documentType, status string, note *string) {
+	title := "Document approved"
+	message := fmt.Sprintf("Your %s was approved.", model.DocumentTypeNames[documentType])
+	if status == model.DocumentStatusRejected {
+		title = "Document not accepted"
+		message = fmt.Sprintf("Your %s was not accepted. Please upload a new one.", model.DocumentTypeNames[documentType])
+	}
+	if note != nil {
+		message += " " + *note
+	}
+
+	_, err := email.NewNotificationDispatcher(config.DB).Dispatch(ctx, model.Notification{
+		UserID:  workerID,
+		Type:    model.NotificationSystemMessage,
+		Title:   title,
+		Message: message,
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to notify worker of document review", "user_id", workerID, "status", status, "error", err)
+	}
+}
+
+func getWorkerDocument(documentID int) (*model.WorkerDocument, error) {
+	return scanWorkerDocument(config.DB.QueryRow(`SELECT `+workerDocumentColumns+workerDocumentFrom+` WHERE d.id = $1`, documentID))
+}
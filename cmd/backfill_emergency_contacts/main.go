@@ -0,0 +1,87 @@
+// Command backfill_emergency_contacts encrypts the legacy plaintext emergency
+// contact columns on worker_profiles into gigworker_emergency_contacts and clears
+// the plaintext. It is safe to re-run.
+package main
+
+import (
+	"log"
+
+	"app/config"
+	"app/internal/model"
+	"app/internal/vault"
+)
+
+func main() {
+	v, err := vault.NewVaultFromEnv()
+	if err != nil {
+		log.Fatal("Vault not configured:", err)
+	}
+
+	config.ConnectDB()
+	defer config.DB.Close()
+
+	rows, err := config.DB.Query(`
+		SELECT worker_id, COALESCE(emergency_contact_name, ''), COALESCE(emergency_contact_phone, ''),
+		       COALESCE(emergency_contact_relationship, '')
+		FROM worker_profiles
+		WHERE emergency_contact_name IS NOT NULL OR emergency_contact_phone IS NOT NULL
+		   OR emergency_contact_relationship IS NOT NULL
+	`)
+	if err != nil {
+		log.Fatal("Failed to load gig workers:", err)
+	}
+
+	type legacyContact struct {
+		gigWorkerID int
+		contact     model.EmergencyContact
+	}
+	var pending []legacyContact
+	for rows.Next() {
+		var lc legacyContact
+		if err := rows.Scan(&lc.gigWorkerID, &lc.contact.Name, &lc.contact.Phone, &lc.contact.Relationship); err != nil {
+			log.Fatal("Failed to scan gig worker:", err)
+		}
+		pending = append(pending, lc)
+	}
+	rows.Close()
+
+	migrated := 0
+	for _, lc := range pending {
+		sealed, err := v.SealJSON(lc.contact, vault.RecordAAD("gigworker_emergency_contacts", lc.gigWorkerID))
+		if err != nil {
+			log.Printf("Failed to seal contact for gig worker %d: %v", lc.gigWorkerID, err)
+			continue
+		}
+
+		tx, err := config.DB.Begin()
+		if err != nil {
+			log.Fatal("Failed to begin transaction:", err)
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO gigworker_emergency_contacts (gigworker_id, sealed_contact, key_id)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (gigworker_id) DO NOTHING
+		`, lc.gigWorkerID, sealed, v.KeyID())
+		if err == nil {
+			_, err = tx.Exec(`
+				UPDATE worker_profiles
+				SET emergency_contact_name = NULL, emergency_contact_phone = NULL,
+				    emergency_contact_relationship = NULL
+				WHERE worker_id = $1
+			`, lc.gigWorkerID)
+		}
+		if err != nil {
+			tx.Rollback()
+			log.Printf("Failed to migrate contact for gig worker %d: %v", lc.gigWorkerID, err)
+			continue
+		}
+		if err := tx.Commit(); err != nil {
+			log.Printf("Failed to commit contact for gig worker %d: %v", lc.gigWorkerID, err)
+			continue
+		}
+		migrated++
+	}
+
+	log.Printf("Encrypted %d/%d emergency contacts", migrated, len(pending))
+}
@@ -1,14 +1,24 @@
 package main
 
 import (
+	"app/api"
 	"app/config"
 	_ "app/docs"
 	"app/handler"
 	"app/internal/auth"
+	"app/internal/email"
+	"app/internal/faults"
+	"app/internal/indexcheck"
+	"app/internal/logger"
 	"app/internal/middleware"
+	"app/internal/migrate"
+	"app/internal/notifications"
+	"app/internal/tracing"
+	"app/internal/vault"
+	"app/migrations"
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -43,8 +53,12 @@ import (
 
 func main() {
 	// Load .env file if it exists (optional for Docker environments)
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found, using environment variables")
+	envErr := godotenv.Load()
+
+	// JSON logs; LOG_LEVEL sets the minimum level
+	logger.InitFromEnv("gigco-api")
+	if envErr != nil {
+		slog.Info("No .env file found, using environment variables")
 	}
 
 	// Validate required configuration in production
@@ -53,45 +67,75 @@ func main() {
 		validateProductionConfig()
 	}
 
+	// Export traces when an OTLP endpoint is configured
+	shutdownTracing, err := tracing.Init(context.Background(), "gigco-api")
+	if err != nil {
+		logger.Fatal("Failed to initialize tracing", "error", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	// Initialize database
 	config.ConnectDB()
 
+	// Apply pending schema migrations when asked to; instances starting together wait
+	// on the migration lock and find nothing left to do
+	if os.Getenv("MIGRATE_ON_STARTUP") == "true" {
+		migrateSchema()
+	}
+
+	// Report list queries no index serves; DB_REQUIRE_INDEXES=true refuses to start
+	checkIndexes(os.Getenv("DB_REQUIRE_INDEXES") == "true")
+
+	// Record every email sent in the delivery ledger, skipping suppressed addresses
+	email.UseLedger(notifications.NewLedger(config.DB))
+	email.UseSuppressionList(email.NewSuppressionList(config.DB))
+
 	// Initialize JWT
 	auth.InitJWT()
 
+	// Load rotated signing keys and pick up rotations made by other instances; the vault
+	// is nil when VAULT_ENCRYPTION_KEY is unset, leaving JWT_SECRET as the only key
+	keyVault, _ := vault.NewVaultFromEnv()
+	if err := auth.LoadSigningKeys(config.DB, keyVault); err != nil {
+		slog.Warn("Signing with JWT_SECRET only", "error", err)
+	}
+	keysCtx, stopKeyRefresh := context.WithCancel(context.Background())
+	defer stopKeyRefresh()
+	go auth.RefreshSigningKeys(keysCtx, config.DB, keyVault)
+
 	// Initialize payment configuration (optional - warnings only if not configured)
 	config.InitPaymentConfig()
 
+	if faults.Enabled() {
+		slog.Warn("Fault injection is enabled", "defaults", faults.Defaults().String())
+	}
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
 	}
 	serverAddress := fmt.Sprintf(":%s", port)
 
-	// Initialize rate limiters
-	standardLimiter := middleware.StandardRateLimit()
-
 	// Create router
 	router := chi.NewRouter()
 
 	// Apply global middleware (order matters!)
-	router.Use(middleware.SecurityHeaders)                           // Security headers first
+	router.Use(middleware.RequestID)                                 // Request ID for logs and error responses
+	router.Use(middleware.Tracing)                                   // Trace spans per route
+	router.Use(middleware.SecurityHeaders)                           // Security headers
 	router.Use(middleware.CORS(middleware.DefaultCORSConfig()))      // CORS handling
-	router.Use(middleware.RateLimit(standardLimiter))                // Rate limiting
+	router.Use(middleware.RateLimitIP)                               // Per-IP rate limiting
 	router.Use(middleware.Logger)                                    // Request logging
+	router.Use(middleware.FaultInjection)                            // Opt-in fault injection outside production
+	router.Use(middleware.Compress)                                  // gzip for JSON and CSV responses of 1KB or more
 
-	// Public routes (no JWT required)
-	handler.GetPublicHandlers(router)
-	handler.PostPublicHandlers(router)
+	// Public and JWT-protected routes
+	handler.RegisterRoutes(router, api.NewApp())
 
-	// Protected routes (JWT required)
-	router.Group(func(r chi.Router) {
-		r.Use(middleware.JWTAuth)
-		handler.GetHandlers(r)
-		handler.PostHandlers(r)
-		handler.PutHandlers(r)
-		handler.DeleteHandlers(r)
-	})
+	// Feed WebSocket clients from events published by handlers and the Temporal worker
+	realtimeCtx, stopRealtime := context.WithCancel(context.Background())
+	defer stopRealtime()
+	go api.StartRealtimeListener(realtimeCtx)
 
 	// Configure HTTP server with timeouts
 	server := &http.Server{
@@ -100,6 +144,16 @@ func main() {
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
+		Protocols:    serverProtocols(),
+		HTTP2: &http.HTTP2Config{
+			// Apps load job lists, their consumers' summaries and photos in parallel over
+			// one connection
+			MaxConcurrentStreams: 250,
+			// Detect connections a mobile network dropped silently instead of holding
+			// their streams until IdleTimeout
+			SendPingTimeout: 30 * time.Second,
+			PingTimeout:     10 * time.Second,
+		},
 	}
 
 	// Channel to listen for shutdown signals
@@ -111,15 +165,21 @@ func main() {
 	// Graceful shutdown goroutine
 	go func() {
 		<-quit
-		log.Println("Server is shutting down...")
+		slog.Info("Server is shutting down...")
+
+		// Fail readiness first so the load balancer stops sending new requests before the
+		// listener closes, then give in-flight requests such as payments time to finish
+		api.MarkShuttingDown()
+		time.Sleep(shutdownDrainDelay())
 
 		// Create context with timeout for shutdown
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
 		server.SetKeepAlivesEnabled(false)
+		stopRealtime()
 		if err := server.Shutdown(ctx); err != nil {
-			log.Fatalf("Could not gracefully shutdown the server: %v\n", err)
+			logger.Fatal("Could not gracefully shutdown the server", "error", err)
 		}
 		close(done)
 	}()
@@ -129,23 +189,44 @@ func main() {
 	tlsKey := os.Getenv("TLS_KEY")
 
 	if tlsCert != "" && tlsKey != "" {
-		log.Printf("Starting HTTPS server on %s", serverAddress)
-		log.Printf("Using TLS certificate: %s", tlsCert)
+		slog.Info("Starting HTTPS server", "server_address", serverAddress)
+		slog.Info("Using TLS certificate", "tls_cert", tlsCert)
 		if err := server.ListenAndServeTLS(tlsCert, tlsKey); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Could not start server: %v\n", err)
+			logger.Fatal("Could not start server", "error", err)
 		}
 	} else {
-		log.Printf("Starting HTTP server on %s", serverAddress)
+		slog.Info("Starting HTTP server", "server_address", serverAddress)
 		if appEnv == "production" {
-			log.Println("WARNING: Running without TLS in production - this is not recommended!")
+			slog.Warn("Running without TLS in production - this is not recommended!")
 		}
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Could not start server: %v\n", err)
+			logger.Fatal("Could not start server", "error", err)
 		}
 	}
 
 	<-done
-	log.Println("Server stopped gracefully")
+	config.DB.Close()
+	slog.Info("Server stopped gracefully")
+}
+
+// shutdownDrainDelay is how long the server keeps serving with readiness failing before
+// it stops accepting connections, set by SHUTDOWN_DRAIN_DELAY (default 5s)
+func shutdownDrainDelay() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv("SHUTDOWN_DRAIN_DELAY")); err == nil && d >= 0 {
+		return d
+	}
+	return 5 * time.Second
+}
+
+// serverProtocols serves HTTP/1.1 and, over TLS, HTTP/2. HTTP2_CLEARTEXT=true also
+// accepts HTTP/2 without TLS (h2c with prior knowledge), for load balancers that end TLS
+// and speak HTTP/2 to the backend.
+func serverProtocols() *http.Protocols {
+	var protocols http.Protocols
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(os.Getenv("HTTP2_CLEARTEXT") == "true")
+	return &protocols
 }
 
 // validateProductionConfig ensures required configuration is set for production
@@ -166,11 +247,51 @@ func validateProductionConfig() {
 	}
 
 	if len(missing) > 0 {
-		log.Fatalf("FATAL: Missing required environment variables for production: %v", missing)
+		logger.Fatal("Missing required environment variables for production", "missing", missing)
 	}
 
 	// Warn about security-sensitive settings
 	if os.Getenv("DB_SSLMODE") == "disable" {
-		log.Println("WARNING: DB_SSLMODE is disabled - database connections are not encrypted")
+		slog.Warn("DB_SSLMODE is disabled - database connections are not encrypted")
+	}
+}
+
+// migrateSchema applies the pending embedded migrations, exiting when one fails so the
+// release never serves requests against a schema it does not expect
+func migrateSchema() {
+	migrator, err := migrate.New(config.DB, migrations.FS)
+	if err != nil {
+		logger.Fatal("Failed to load migrations", "error", err)
+	}
+	applied, err := migrator.Up(context.Background())
+	for _, m := range applied {
+		slog.Info("Applied migration", "version", m.Version, "name", m.Name)
+	}
+	if err != nil {
+		logger.Fatal("Failed to migrate the schema", "error", err)
+	}
+	slog.Info("Schema is up to date", "version", migrator.Latest())
+}
+
+// checkIndexes logs each list query pattern no index serves, with the statement that
+// creates its index. A failed check is only logged unless required is set.
+func checkIndexes(required bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	advisories, err := indexcheck.Check(ctx, config.DB)
+	if err != nil {
+		if required {
+			logger.Fatal("Failed to check indexes", "error", err)
+		}
+		slog.Warn("Failed to check indexes", "error", err)
+		return
+	}
+	missing := indexcheck.Missing(advisories)
+	for _, a := range missing {
+		slog.Warn("Missing index for list query", "query", a.Query, "index", a.Index, "create", a.CreateSQL)
+	}
+	if len(missing) > 0 && required {
+		logger.Fatal("Required indexes are missing; run the migrations", "missing", len(missing))
 	}
 }
@@ -0,0 +1,113 @@
+// Command migrate applies the schema migrations embedded from the migrations
+// directory, using the DB_* environment variables:
+//
+//	migrate up          apply every pending migration (the default)
+//	migrate down        revert the newest applied migration
+//	migrate status      list migrations and when each was applied
+//	migrate version     print the newest applied version
+//	migrate baseline N  record migrations up to N as applied without running them,
+//	                    for a database already changed by hand
+//
+// Migrations run under an advisory lock, so it is safe alongside API instances
+// started with MIGRATE_ON_STARTUP=true.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"app/config"
+	"app/internal/migrate"
+	"app/migrations"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: migrate [up | down | status | version | baseline N]")
+	}
+	flag.Parse()
+
+	command := flag.Arg(0)
+	if command == "" {
+		command = "up"
+	}
+
+	config.ConnectDB()
+	defer config.DB.Close()
+
+	migrator, err := migrate.New(config.DB, migrations.FS)
+	if err != nil {
+		log.Fatal("Failed to load migrations: ", err)
+	}
+
+	ctx := context.Background()
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			log.Printf("Applied %d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(applied) == 0 {
+			log.Printf("Schema is up to date at version %d", migrator.Latest())
+		}
+
+	case "down":
+		reverted, err := migrator.Down(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if reverted == nil {
+			log.Print("No migration is applied")
+			return
+		}
+		log.Printf("Reverted %d_%s", reverted.Version, reverted.Name)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.AppliedAt != nil {
+				state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			if s.Modified {
+				state += " (file changed since)"
+			}
+			fmt.Printf("%6d  %-40s  %s\n", s.Version, s.Name, state)
+		}
+
+	case "version":
+		version, err := migrator.Version(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(version)
+
+	case "baseline":
+		version, err := strconv.ParseInt(flag.Arg(1), 10, 64)
+		if err != nil || version <= 0 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		recorded, err := migrator.Baseline(ctx, version)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, m := range recorded {
+			log.Printf("Recorded %d_%s as applied", m.Version, m.Name)
+		}
+
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,62 @@
+// Command replay_job_events rebuilds the lifecycle columns on jobs (status,
+// gig_worker_id, actual_start, workflow_completed_at) from job_events. Run it with
+// -job to replay a single job and -dry-run to only report jobs whose projection has
+// drifted from their history. It is safe to re-run.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"app/config"
+	"app/internal/jobevents"
+)
+
+func main() {
+	jobID := flag.Int("job", 0, "replay only this job")
+	dryRun := flag.Bool("dry-run", false, "report drift without rewriting jobs")
+	flag.Parse()
+
+	config.ConnectDB()
+	defer config.DB.Close()
+
+	var jobIDs []int
+	if *jobID > 0 {
+		jobIDs = []int{*jobID}
+	} else {
+		rows, err := config.DB.Query(`SELECT DISTINCT job_id FROM job_events ORDER BY job_id`)
+		if err != nil {
+			log.Fatal("Failed to load jobs:", err)
+		}
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				log.Fatal("Failed to scan job:", err)
+			}
+			jobIDs = append(jobIDs, id)
+		}
+		rows.Close()
+	}
+
+	ctx := context.Background()
+	drifted := 0
+	for _, id := range jobIDs {
+		result, err := jobevents.Replay(ctx, config.DB, id, *dryRun)
+		if err != nil {
+			log.Printf("Failed to replay job %d: %v", id, err)
+			continue
+		}
+		if !result.Changed {
+			continue
+		}
+		drifted++
+		log.Printf("Job %d: status %s -> %s (sequence %d)", id, result.Before.Status, result.After.Status, result.After.Sequence)
+	}
+
+	if *dryRun {
+		log.Printf("%d/%d jobs have drifted from their events", drifted, len(jobIDs))
+		return
+	}
+	log.Printf("Rebuilt %d/%d job projections", drifted, len(jobIDs))
+}
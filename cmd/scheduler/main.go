@@ -0,0 +1,63 @@
+// Command scheduler registers the recurring workflows declared in internal/scheduler as
+// Temporal schedules, applying each job's <ENV>_CRON, _JITTER, _OVERLAP and _ENABLED
+// settings, and exits. The worker does the same on startup; run this to apply changed
+// settings without restarting workers. -dry-run prints the settings without contacting
+// Temporal.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"go.temporal.io/sdk/client"
+
+	"app/internal/logger"
+	"app/internal/scheduler"
+	"app/internal/temporal"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "print each job's settings without registering schedules")
+	taskQueue := flag.String("task-queue", "gigco-jobs", "task queue the scheduled workflows run on")
+	flag.Parse()
+	logger.InitFromEnv("gigco-scheduler")
+
+	if *dryRun {
+		failed := false
+		for _, job := range scheduler.Jobs {
+			s, err := job.Settings(os.Getenv)
+			if err != nil {
+				fmt.Printf("%-22s invalid: %v\n", job.ID, err)
+				failed = true
+				continue
+			}
+			status := "enabled"
+			if !s.Enabled {
+				status = "disabled (" + s.DisabledBy + ")"
+			}
+			fmt.Printf("%-22s %-14s jitter %-6s %-10s %s\n", job.ID, s.Cron, s.Jitter, s.Overlap, status)
+		}
+		if failed {
+			os.Exit(1)
+		}
+		return
+	}
+
+	temporalHost := os.Getenv("TEMPORAL_HOST")
+	if temporalHost == "" {
+		temporalHost = "localhost:7233"
+	}
+	c, err := client.Dial(temporal.ClientOptions(temporalHost))
+	if err != nil {
+		logger.Fatal("Unable to create Temporal client", "error", err)
+	}
+	defer c.Close()
+
+	if err := scheduler.Sync(context.Background(), c, *taskQueue, scheduler.Jobs, os.Getenv); err != nil {
+		logger.Fatal("Not every scheduled job was registered", "error", err)
+	}
+	slog.Info("Scheduled jobs registered", "jobs", len(scheduler.Jobs))
+}
@@ -0,0 +1,69 @@
+// Command sdkgen generates the Go and TypeScript API clients from the OpenAPI
+// document served at /openapi.json. Run it through go generate ./sdk/...
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"app/api"
+	"app/handler"
+	"app/internal/sdkgen"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func main() {
+	goOut := flag.String("go-out", "", "path of the generated Go client file")
+	goPackage := flag.String("go-package", "gigco", "package name of the generated Go client")
+	tsOut := flag.String("ts-out", "", "directory of the generated TypeScript client package")
+	specOut := flag.String("spec-out", "", "path to write the OpenAPI document to, if set")
+	flag.Parse()
+
+	router := chi.NewRouter()
+	handler.RegisterRoutes(router, api.NewApp())
+
+	doc, err := api.GenerateOpenAPI(router)
+	if err != nil {
+		log.Fatalf("Failed to generate OpenAPI document: %v", err)
+	}
+
+	if *specOut != "" {
+		spec, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			log.Fatalf("Failed to encode OpenAPI document: %v", err)
+		}
+		writeFile(*specOut, append(spec, '\n'))
+	}
+
+	if *goOut != "" {
+		src, err := sdkgen.GenerateGo(doc, *goPackage)
+		if err != nil {
+			log.Fatalf("Failed to generate Go client: %v", err)
+		}
+		writeFile(*goOut, src)
+	}
+
+	if *tsOut != "" {
+		ts, err := sdkgen.GenerateTypeScript(doc)
+		if err != nil {
+			log.Fatalf("Failed to generate TypeScript client: %v", err)
+		}
+		writeFile(filepath.Join(*tsOut, "client.js"), ts.JS)
+		writeFile(filepath.Join(*tsOut, "client.d.ts"), ts.Declaration)
+		writeFile(filepath.Join(*tsOut, "package.json"), ts.Package)
+	}
+}
+
+func writeFile(path string, data []byte) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		log.Fatalf("Failed to create directory for %s: %v", path, err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		log.Fatalf("Failed to write %s: %v", path, err)
+	}
+	log.Printf("Wrote %s", path)
+}
@@ -1,61 +1,130 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
+	"time"
 
 	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/worker"
 
+	"app/config"
+	"app/internal/auth"
+	"app/internal/email"
+	"app/internal/faults"
+	"app/internal/logger"
+	"app/internal/notifications"
+	"app/internal/payment"
+	"app/internal/scheduler"
+	"app/internal/temporal"
 	"app/internal/temporal/activities"
 	"app/internal/temporal/workflows"
+	"app/internal/tracing"
+	"app/internal/vault"
 
 	_ "github.com/lib/pq"
 )
 
 func main() {
-	log.Println("Starting Temporal worker...")
+	logger.InitFromEnv("gigco-worker")
+	slog.Info("Starting Temporal worker...")
+
+	// Export traces when an OTLP endpoint is configured
+	shutdownTracing, err := tracing.Init(context.Background(), "gigco-worker")
+	if err != nil {
+		logger.Fatal("Failed to initialize tracing", "error", err)
+	}
+	defer shutdownTracing(context.Background())
 
 	// Get database connection
 	db, err := connectDB()
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		logger.Fatal("Failed to connect to database", "error", err)
 	}
 	defer db.Close()
 
 	// Test database connection
 	if err := db.Ping(); err != nil {
-		log.Fatal("Failed to ping database:", err)
+		logger.Fatal("Failed to ping database", "error", err)
+	}
+	slog.Info("Successfully connected to database")
+
+	// Record every email sent in the delivery ledger, skipping suppressed addresses
+	email.UseLedger(notifications.NewLedger(db))
+	email.UseSuppressionList(email.NewSuppressionList(db))
+
+	// Review request links are signed with the API's JWT signing keys
+	auth.InitJWT()
+	keyVault, _ := vault.NewVaultFromEnv()
+	if err := auth.LoadSigningKeys(db, keyVault); err != nil {
+		slog.Warn("Signing with JWT_SECRET only", "error", err)
 	}
-	log.Println("Successfully connected to database")
+	keysCtx, stopKeyRefresh := context.WithCancel(context.Background())
+	defer stopKeyRefresh()
+	go auth.RefreshSigningKeys(keysCtx, db, keyVault)
 
 	// Create Temporal client
 	temporalHost := getEnv("TEMPORAL_HOST", "localhost:7233")
-	c, err := client.Dial(client.Options{
-		HostPort: temporalHost,
-	})
+	c, err := client.Dial(temporal.ClientOptions(temporalHost))
 	if err != nil {
-		log.Fatal("Unable to create Temporal client:", err)
+		logger.Fatal("Unable to create Temporal client", "error", err)
 	}
 	defer c.Close()
 
-	log.Printf("Connected to Temporal server at %s", temporalHost)
+	slog.Info("Connected to Temporal server", "temporal_host", temporalHost)
 
-	// Create worker
+	// Create worker; on shutdown, running activities such as payment captures get time to
+	// finish before they are cancelled
 	taskQueue := "gigco-jobs"
-	w := worker.New(c, taskQueue, worker.Options{})
+	w := worker.New(c, taskQueue, worker.Options{
+		WorkerStopTimeout: 30 * time.Second,
+	})
 
 	// Register workflows
 	w.RegisterWorkflow(workflows.JobLifecycleWorkflow)
 	w.RegisterWorkflow(workflows.PaymentRetryWorkflow)
+	w.RegisterWorkflow(workflows.WeatherAdvisoryWorkflow)
+	w.RegisterWorkflow(workflows.AccountDeletionWorkflow)
+	w.RegisterWorkflow(workflows.DataExportWorkflow)
+	w.RegisterWorkflow(workflows.OpsMonitorWorkflow)
+	w.RegisterWorkflow(workflows.PayoutSettlementWorkflow)
+	w.RegisterWorkflow(workflows.DisputeWorkflow)
+	w.RegisterWorkflow(workflows.SigningKeyRotationWorkflow)
+	w.RegisterWorkflow(workflows.EscrowWorkflow)
+	w.RegisterWorkflow(workflows.NotificationRetryWorkflow)
+	w.RegisterWorkflow(workflows.RefundBatchWorkflow)
+	w.RegisterWorkflow(workflows.DocumentExpiryWorkflow)
+
+	config.InitPaymentConfig()
+	if faults.Enabled() {
+		// Activities have no request headers, so they always get the defaults
+		slog.Warn("Fault injection is enabled", "defaults", faults.Defaults().String())
+	}
+	provider, err := payment.NewProvider(config.Payment)
+	if err != nil {
+		slog.Error("Invalid payment provider, falling back", "fallback", payment.ProviderClover, "error", err)
+		provider = payment.NewCloverProvider(&config.Payment.Clover)
+	}
+	merchants, err := payment.NewMarketMerchants(config.Payment)
+	if err != nil {
+		slog.Error("Invalid market merchant accounts, every market pays the default merchant", "error", err)
+	}
+	paymentService := payment.NewPaymentService(db, provider, config.Payment.SalesTaxPercent).
+		WithMerchants(config.Payment.MerchantID(), merchants)
 
 	// Register activities
-	jobActivities := activities.NewJobActivities(db)
+	jobActivities := activities.NewJobActivities(db, paymentService)
 	w.RegisterActivity(jobActivities.PriceJob)
 	w.RegisterActivity(jobActivities.SendJobOffer)
+	w.RegisterActivity(jobActivities.AutoAcceptJob)
 	w.RegisterActivity(jobActivities.FindMatchingWorker)
+	w.RegisterActivity(jobActivities.RankWorkersForOffer)
+	w.RegisterActivity(jobActivities.SendWorkerOffer)
+	w.RegisterActivity(jobActivities.CloseWorkerOffers)
+	w.RegisterActivity(jobActivities.RemindSnoozedOffer)
 	w.RegisterActivity(jobActivities.ScheduleJob)
 	w.RegisterActivity(jobActivities.ProcessJobPayment)
 	w.RegisterActivity(jobActivities.RequestReviews)
@@ -64,19 +133,73 @@ func main() {
 	w.RegisterActivity(jobActivities.HandleNoWorkerAvailable)
 	w.RegisterActivity(jobActivities.HandlePaymentFailure)
 	w.RegisterActivity(jobActivities.UpdateJobPaymentStatus)
-
-	log.Printf("Worker registered for task queue: %s", taskQueue)
-	log.Println("Registered workflows: JobLifecycleWorkflow, PaymentRetryWorkflow")
-	log.Println("Registered activities: PriceJob, SendJobOffer, FindMatchingWorker, ScheduleJob, ProcessJobPayment, RequestReviews, CloseJob, HandleJobRejection, HandleNoWorkerAvailable, HandlePaymentFailure, UpdateJobPaymentStatus")
+	w.RegisterActivity(jobActivities.PayHandoffPortion)
+	w.RegisterActivity(jobActivities.ReleaseHandoffWorker)
+	w.RegisterActivity(jobActivities.ReverseHandoff)
+	w.RegisterActivity(jobActivities.FinishHandoff)
+	w.RegisterActivity(jobActivities.CheckOutdoorJobWeather)
+
+	accountActivities := activities.NewAccountActivities(db)
+	w.RegisterActivity(accountActivities.ExportAccountData)
+	w.RegisterActivity(accountActivities.SendAccountWinBack)
+	w.RegisterActivity(accountActivities.PurgeAccount)
+	w.RegisterActivity(accountActivities.BuildDataExport)
+	w.RegisterActivity(accountActivities.SendDataExportReady)
+	w.RegisterActivity(accountActivities.FailDataExport)
+	w.RegisterActivity(accountActivities.ExpireDataExport)
+
+	opsActivities := activities.NewOpsActivities(db)
+	w.RegisterActivity(opsActivities.ReconcilePayments)
+	w.RegisterActivity(opsActivities.CheckMarketFillRates)
+	w.RegisterActivity(opsActivities.CheckKPIAnomalies)
+	w.RegisterActivity(opsActivities.ReportWorkflowDeadLetter)
+	w.RegisterActivity(opsActivities.RefreshAdminOverview)
+
+	payoutService := payment.NewPayoutService(db, provider, time.Duration(config.Payment.PayoutHoldHours*float64(time.Hour)))
+	payoutActivities := activities.NewPayoutActivities(db, payoutService)
+	w.RegisterActivity(payoutActivities.CreateSettlementBatch)
+	w.RegisterActivity(payoutActivities.ProcessSettlementBatch)
+
+	escrowActivities := activities.NewEscrowActivities(db, paymentService)
+	w.RegisterActivity(escrowActivities.CheckEscrow)
+	w.RegisterActivity(escrowActivities.RenewEscrowAuthorization)
+	w.RegisterActivity(escrowActivities.AutoCaptureEscrow)
+
+	refundActivities := activities.NewRefundActivities(db, paymentService)
+	w.RegisterActivity(refundActivities.ProcessRefundBatch)
+
+	disputeActivities := activities.NewDisputeActivities(db)
+	w.RegisterActivity(disputeActivities.AlertDisputeOpened)
+	w.RegisterActivity(disputeActivities.EscalateDispute)
+	w.RegisterActivity(disputeActivities.JobHasOpenHolds)
+
+	authActivities := activities.NewAuthActivities(db)
+	w.RegisterActivity(authActivities.RotateSigningKey)
+
+	notificationActivities := activities.NewNotificationActivities(db)
+	w.RegisterActivity(notificationActivities.RetryNotificationDeliveries)
+
+	documentActivities := activities.NewDocumentActivities(db)
+	w.RegisterActivity(documentActivities.CheckDocumentExpiry)
+
+	slog.Info("Worker registered for task queue", "task_queue", taskQueue)
+	slog.Info("Registered workflows: JobLifecycleWorkflow, PaymentRetryWorkflow, WeatherAdvisoryWorkflow, AccountDeletionWorkflow, DataExportWorkflow, OpsMonitorWorkflow, PayoutSettlementWorkflow, DisputeWorkflow, SigningKeyRotationWorkflow, EscrowWorkflow, NotificationRetryWorkflow, RefundBatchWorkflow, DocumentExpiryWorkflow")
+	slog.Info("Registered activities: PriceJob, SendJobOffer, FindMatchingWorker, RankWorkersForOffer, SendWorkerOffer, CloseWorkerOffers, ScheduleJob, ProcessJobPayment, RequestReviews, CloseJob, HandleJobRejection, HandleNoWorkerAvailable, HandlePaymentFailure, UpdateJobPaymentStatus, CheckOutdoorJobWeather, ExportAccountData, SendAccountWinBack, PurgeAccount, BuildDataExport, SendDataExportReady, FailDataExport, ExpireDataExport, ReconcilePayments, CheckMarketFillRates, CheckKPIAnomalies, ReportWorkflowDeadLetter, RefreshAdminOverview, CreateSettlementBatch, ProcessSettlementBatch, CheckEscrow, RenewEscrowAuthorization, AutoCaptureEscrow, ProcessRefundBatch, AlertDisputeOpened, EscalateDispute, JobHasOpenHolds, RotateSigningKey, RetryNotificationDeliveries, CheckDocumentExpiry")
+
+	// Register the recurring workflows as Temporal schedules; cmd/scheduler does the same
+	// without starting a worker
+	if err := scheduler.Sync(context.Background(), c, taskQueue, scheduler.Jobs, os.Getenv); err != nil {
+		slog.Error("Not every scheduled job was registered", "error", err)
+	}
 
 	// Start worker
-	log.Println("Starting worker...")
+	slog.Info("Starting worker...")
 	err = w.Run(worker.InterruptCh())
 	if err != nil {
-		log.Fatal("Unable to start worker:", err)
+		logger.Fatal("Unable to start worker", "error", err)
 	}
 
-	log.Println("Worker stopped")
+	slog.Info("Worker stopped")
 }
 
 // connectDB creates a database connection using environment variables
@@ -91,10 +214,9 @@ func connectDB() (*sql.DB, error) {
 	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		dbHost, dbPort, dbUser, dbPassword, dbName, dbSSLMode)
 
-	log.Printf("Connecting to database: host=%s port=%s dbname=%s user=%s sslmode=%s",
-		dbHost, dbPort, dbName, dbUser, dbSSLMode)
+	slog.Info("Connecting to database", "host", dbHost, "port", dbPort, "dbname", dbName, "user", dbUser, "sslmode", dbSSLMode)
 
-	db, err := sql.Open("postgres", dsn)
+	db, err := config.OpenDB(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
@@ -1,27 +1,25 @@
 package config
 
 import (
+	"app/internal/logger"
+	"app/internal/querylog"
+	"app/internal/tracing"
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"time"
 
 	"github.com/joho/godotenv"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 var DB *sql.DB
 
-func ConnectDB() {
-	var err error
-
-	// Load .env file if it exists (optional for Docker environments)
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found in database config, using environment variables")
-	}
-
-	connStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+// ConnString returns the PostgreSQL connection string built from the DB_* environment
+// variables, for connections outside the pool such as LISTEN/NOTIFY listeners
+func ConnString() string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		os.Getenv("DB_HOST"),
 		os.Getenv("DB_PORT"),
 		os.Getenv("DB_USER"),
@@ -29,16 +27,36 @@ func ConnectDB() {
 		os.Getenv("DB_NAME"),
 		os.Getenv("DB_SSLMODE"),
 	)
+}
+
+// OpenDB opens a PostgreSQL connection pool whose queries are traced and timed, with
+// slow queries logged as configured by the DB_SLOW_QUERY_* and DB_EXPLAIN_* variables
+func OpenDB(dsn string) (*sql.DB, error) {
+	connector, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return tracing.OpenDB(querylog.NewConnector(connector, querylog.LoadConfig())), nil
+}
+
+func ConnectDB() {
+	var err error
+
+	// Load .env file if it exists (optional for Docker environments)
+	if err := godotenv.Load(); err != nil {
+		slog.Info("No .env file found in database config, using environment variables")
+	}
+
+	connStr := ConnString()
 
-	log.Printf("Attempting to connect to database: host=%s port=%s dbname=%s",
-		os.Getenv("DB_HOST"), os.Getenv("DB_PORT"), os.Getenv("DB_NAME"))
+	slog.Info("Attempting to connect to database", "host", os.Getenv("DB_HOST"), "port", os.Getenv("DB_PORT"), "dbname", os.Getenv("DB_NAME"))
 
 	// Retry connection with exponential backoff
 	maxRetries := 10
 	for i := 0; i < maxRetries; i++ {
-		DB, err = sql.Open("postgres", connStr)
+		DB, err = OpenDB(connStr)
 		if err != nil {
-			log.Printf("Failed to open database connection (attempt %d/%d): %v", i+1, maxRetries, err)
+			slog.Error("Failed to open database connection", "attempt", i+1, "max_retries", maxRetries, "error", err)
 			time.Sleep(time.Duration(i+1) * time.Second)
 			continue
 		}
@@ -56,9 +74,9 @@ func ConnectDB() {
 			return
 		}
 
-		log.Printf("Failed to ping database (attempt %d/%d): %v", i+1, maxRetries, err)
+		slog.Error("Failed to ping database", "attempt", i+1, "max_retries", maxRetries, "error", err)
 		time.Sleep(time.Duration(i+1) * time.Second)
 	}
 
-	log.Fatal("Failed to connect to database after all retries:", err)
+	logger.Fatal("Failed to connect to database after all retries", "error", err)
 }
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+// FaultConfig configures fault injection for resilience testing. It is never enabled
+// in production, whatever FAULT_INJECTION_ENABLED says.
+type FaultConfig struct {
+	Enabled        bool
+	Latency        time.Duration // Added to every API request
+	ErrorRate      float64       // Share of API requests failed with a 500
+	CloverDropRate float64       // Share of Clover calls whose response is lost after Clover handles them
+}
+
+// LoadFaultConfig reads the default faults from environment variables. Requests may
+// override them with X-Fault-* headers while fault injection is enabled.
+func LoadFaultConfig() *FaultConfig {
+	latency, _ := time.ParseDuration(os.Getenv("FAULT_LATENCY"))
+	return &FaultConfig{
+		Enabled:        os.Getenv("FAULT_INJECTION_ENABLED") == "true" && os.Getenv("APP_ENV") != "production",
+		Latency:        latency,
+		ErrorRate:      parseFloatEnv("FAULT_ERROR_RATE", 0),
+		CloverDropRate: parseFloatEnv("FAULT_CLOVER_DROP_RATE", 0),
+	}
+}
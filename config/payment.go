@@ -2,7 +2,7 @@ package config
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"strings"
 
@@ -11,8 +11,13 @@ import (
 
 // PaymentConfig holds payment provider configurations
 type PaymentConfig struct {
-	Provider string
-	Clover   CloverConfig
+	Provider        string  // clover or stripe
+	SalesTaxPercent float64 // Sales tax added to job prices (e.g., 8.25 for 8.25%)
+	PayoutHoldHours float64 // Captures younger than this wait for the next payout batch
+	FXRates         string  // Display exchange rates against USD, e.g. "EUR=0.92,GBP=0.79"
+	Clover          CloverConfig
+	Stripe          StripeConfig
+	Merchants       []MerchantConfig // Merchant accounts taking payments for jobs in particular markets
 }
 
 // CloverConfig holds Clover-specific configuration
@@ -25,7 +30,47 @@ type CloverConfig struct {
 	APIEndpoint          string
 	PAKMSEndpoint        string
 	WebhookSecret        string
-	PlatformFeePercent   float64 // Platform fee percentage (e.g., 10.0 for 10%)
+	PlatformFeePercent   float64   // Platform fee percentage (e.g., 10.0 for 10%)
+	RateLimit            RateLimit // Pace of requests to Clover; a 429 also pauses them for its Retry-After
+	MaxRetries           int       // Retries of a request Clover answers with 429
+}
+
+// StripeConfig holds Stripe-specific configuration
+type StripeConfig struct {
+	AccountID          string // Stripe account ID, stored on transactions to tell merchant accounts apart
+	SecretKey          string
+	PublishableKey     string // Returned to clients for Stripe.js tokenization
+	WebhookSecret      string
+	APIEndpoint        string
+	PlatformFeePercent float64
+}
+
+// MerchantConfig is a merchant account that jobs in some markets are paid into instead
+// of the default one, such as a Canadian merchant account for Canadian markets. It
+// inherits the default provider settings and overrides the credentials.
+type MerchantConfig struct {
+	Name     string   // Entry in PAYMENT_MERCHANTS
+	Provider string   // clover or stripe; defaults to PAYMENT_PROVIDER
+	Markets  []string // Slugs of the markets routed to this merchant
+	Clover   CloverConfig
+	Stripe   StripeConfig
+}
+
+// MerchantID identifies the default merchant account with the configured provider
+func (c *PaymentConfig) MerchantID() string {
+	return merchantID(c.Provider, c.Clover, c.Stripe)
+}
+
+// MerchantID identifies the merchant account with its provider
+func (m *MerchantConfig) MerchantID() string {
+	return merchantID(m.Provider, m.Clover, m.Stripe)
+}
+
+func merchantID(provider string, clover CloverConfig, stripe StripeConfig) string {
+	if provider == "stripe" {
+		return stripe.AccountID
+	}
+	return clover.MerchantID
 }
 
 var Payment *PaymentConfig
@@ -34,13 +79,16 @@ var Payment *PaymentConfig
 func InitPaymentConfig() {
 	// Load .env file if it exists
 	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found for payment config, using environment variables")
+		slog.Info("No .env file found for payment config, using environment variables")
 	}
 
 	environment := getEnvOrDefault("CLOVER_ENVIRONMENT", "sandbox")
 
 	Payment = &PaymentConfig{
-		Provider: getEnvOrDefault("PAYMENT_PROVIDER", "clover"),
+		Provider:        getEnvOrDefault("PAYMENT_PROVIDER", "clover"),
+		SalesTaxPercent: parseFloatEnv("SALES_TAX_PERCENT", 0),
+		PayoutHoldHours: parseFloatEnv("PAYOUT_HOLD_HOURS", 24),
+		FXRates:         os.Getenv("FX_RATES"),
 		Clover: CloverConfig{
 			Environment:          environment,
 			MerchantID:           os.Getenv("CLOVER_MERCHANT_ID"),
@@ -51,24 +99,81 @@ func InitPaymentConfig() {
 			TokenizationEndpoint: getCloverEndpoint(environment, "tokenization"),
 			APIEndpoint:          getCloverEndpoint(environment, "api"),
 			PAKMSEndpoint:        getCloverEndpoint(environment, "pakms"),
+			RateLimit: RateLimit{
+				PerMinute: parseFloatEnv("CLOVER_RATE_LIMIT_PER_MINUTE", 600),
+				Burst:     parseIntEnv("CLOVER_RATE_LIMIT_BURST", 10),
+			},
+			MaxRetries: parseIntEnv("CLOVER_MAX_RETRIES", 3),
+		},
+		Stripe: StripeConfig{
+			AccountID:          os.Getenv("STRIPE_ACCOUNT_ID"),
+			SecretKey:          os.Getenv("STRIPE_SECRET_KEY"),
+			PublishableKey:     os.Getenv("STRIPE_PUBLISHABLE_KEY"),
+			WebhookSecret:      os.Getenv("STRIPE_WEBHOOK_SECRET"),
+			APIEndpoint:        getEnvOrDefault("STRIPE_API_ENDPOINT", "https://api.stripe.com"),
+			PlatformFeePercent: parseFloatEnv("PLATFORM_FEE_PERCENT", 10.0),
 		},
 	}
 
+	Payment.Merchants = loadMerchantConfigs(Payment)
+
 	// Validate required Clover configuration
 	if Payment.Provider == "clover" {
 		if Payment.Clover.MerchantID == "" {
-			log.Println("WARNING: CLOVER_MERCHANT_ID is not set")
+			slog.Warn("CLOVER_MERCHANT_ID is not set")
 		}
 		if Payment.Clover.AccessToken == "" {
-			log.Println("WARNING: CLOVER_ACCESS_TOKEN is not set")
+			slog.Warn("CLOVER_ACCESS_TOKEN is not set")
 		}
 		if Payment.Clover.APIAccessKey == "" {
-			log.Println("WARNING: CLOVER_API_ACCESS_KEY is not set - required for card tokenization")
+			slog.Warn("CLOVER_API_ACCESS_KEY is not set - required for card tokenization")
+		}
+	}
+
+	// Validate required Stripe configuration
+	if Payment.Provider == "stripe" {
+		if Payment.Stripe.SecretKey == "" {
+			slog.Warn("STRIPE_SECRET_KEY is not set")
+		}
+		if Payment.Stripe.PublishableKey == "" {
+			slog.Warn("STRIPE_PUBLISHABLE_KEY is not set - required for client-side card tokenization")
 		}
 	}
 
-	log.Printf("Payment config initialized: Provider=%s, Environment=%s",
-		Payment.Provider, Payment.Clover.Environment)
+	slog.Info("Payment config initialized", "provider", Payment.Provider, "environment", Payment.Clover.Environment, "market_merchants", len(Payment.Merchants))
+}
+
+// loadMerchantConfigs reads the merchant accounts named in PAYMENT_MERCHANTS, each
+// configured with PAYMENT_MERCHANT_<NAME>_* variables
+func loadMerchantConfigs(base *PaymentConfig) []MerchantConfig {
+	var merchants []MerchantConfig
+	for _, name := range strings.Split(os.Getenv("PAYMENT_MERCHANTS"), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		prefix := "PAYMENT_MERCHANT_" + strings.ToUpper(name) + "_"
+
+		m := MerchantConfig{
+			Name:     name,
+			Provider: getEnvOrDefault(prefix+"PROVIDER", base.Provider),
+			Clover:   base.Clover,
+			Stripe:   base.Stripe,
+		}
+		for _, market := range strings.Split(os.Getenv(prefix+"MARKETS"), ",") {
+			if market = strings.TrimSpace(market); market != "" {
+				m.Markets = append(m.Markets, market)
+			}
+		}
+		m.Clover.MerchantID = os.Getenv(prefix + "CLOVER_MERCHANT_ID")
+		m.Clover.AccessToken = os.Getenv(prefix + "CLOVER_ACCESS_TOKEN")
+		m.Clover.APIAccessKey = os.Getenv(prefix + "CLOVER_API_ACCESS_KEY")
+		m.Stripe.AccountID = os.Getenv(prefix + "STRIPE_ACCOUNT_ID")
+		m.Stripe.SecretKey = os.Getenv(prefix + "STRIPE_SECRET_KEY")
+		m.Stripe.PublishableKey = os.Getenv(prefix + "STRIPE_PUBLISHABLE_KEY")
+		merchants = append(merchants, m)
+	}
+	return merchants
 }
 
 // getCloverEndpoint returns the appropriate Clover endpoint based on environment
@@ -114,23 +219,3 @@ func parseFloatEnv(key string, defaultValue float64) float64 {
 	}
 	return defaultValue
 }
-
-// CalculatePlatformFee calculates the platform fee based on amount
-func (c *CloverConfig) CalculatePlatformFee(amount float64) float64 {
-	return amount * (c.PlatformFeePercent / 100.0)
-}
-
-// CalculateProcessingFee calculates Clover's processing fee (typically 2.6% + $0.10)
-func (c *CloverConfig) CalculateProcessingFee(amount float64) float64 {
-	percentage := 2.6 // Clover's typical percentage
-	fixedFee := 0.10   // Fixed fee per transaction
-	return (amount * (percentage / 100.0)) + fixedFee
-}
-
-// CalculateNetAmount calculates the net amount after fees
-func (c *CloverConfig) CalculateNetAmount(amount float64) (netAmount, platformFee, processingFee float64) {
-	platformFee = c.CalculatePlatformFee(amount)
-	processingFee = c.CalculateProcessingFee(amount)
-	netAmount = amount - platformFee - processingFee
-	return
-}
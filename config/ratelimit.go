@@ -0,0 +1,57 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+// RateLimit is a token bucket: Burst requests may be made at once, and the bucket
+// refills at PerMinute. A PerMinute of zero turns the limit off.
+type RateLimit struct {
+	PerMinute float64
+	Burst     int
+}
+
+// RateLimitConfig configures request rate limits and where their buckets are kept
+type RateLimitConfig struct {
+	Store string // memory, or redis to share buckets between instances
+
+	// Redis
+	RedisAddress  string
+	RedisPassword string
+	RedisDB       int
+
+	IP   RateLimit // Every request, keyed on client IP
+	Auth RateLimit // Login, registration and password resets, keyed on client IP
+	User RateLimit // Authenticated requests, keyed on user ID
+}
+
+// LoadRateLimitConfig reads rate limit configuration from environment variables
+func LoadRateLimitConfig() *RateLimitConfig {
+	return &RateLimitConfig{
+		Store:         getEnvOrDefault("RATE_LIMIT_STORE", "memory"),
+		RedisAddress:  getEnvOrDefault("REDIS_ADDRESS", "localhost:6379"),
+		RedisPassword: os.Getenv("REDIS_PASSWORD"),
+		RedisDB:       parseIntEnv("REDIS_DB", 0),
+		IP: RateLimit{
+			PerMinute: parseFloatEnv("RATE_LIMIT_IP_PER_MINUTE", 100),
+			Burst:     parseIntEnv("RATE_LIMIT_IP_BURST", 100),
+		},
+		Auth: RateLimit{
+			PerMinute: parseFloatEnv("RATE_LIMIT_AUTH_PER_MINUTE", 5),
+			Burst:     parseIntEnv("RATE_LIMIT_AUTH_BURST", 5),
+		},
+		User: RateLimit{
+			PerMinute: parseFloatEnv("RATE_LIMIT_USER_PER_MINUTE", 120),
+			Burst:     parseIntEnv("RATE_LIMIT_USER_BURST", 60),
+		},
+	}
+}
+
+// parseIntEnv parses an integer environment variable or returns default
+func parseIntEnv(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"os"
+)
+
+// StorageConfig selects and configures the object store used for attachments
+type StorageConfig struct {
+	Backend string // local, s3 or gcs
+
+	// S3 and GCS. GCS is reached through its S3-compatible XML API with HMAC keys.
+	Bucket          string
+	Region          string
+	Endpoint        string // Overrides the provider endpoint, e.g. for MinIO
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// Local disk
+	LocalDir   string
+	PublicURL  string // Base URL the API is reachable at; local signed URLs point here
+	SigningKey string // Signs local download URLs
+
+	// Malware scanning
+	ScanBackend   string // clamav or none
+	ClamAVAddress string // clamd TCP address
+}
+
+// LoadStorageConfig reads storage configuration from environment variables
+func LoadStorageConfig() *StorageConfig {
+	return &StorageConfig{
+		Backend:         getEnvOrDefault("STORAGE_BACKEND", "local"),
+		Bucket:          os.Getenv("STORAGE_BUCKET"),
+		Region:          getEnvOrDefault("STORAGE_REGION", "us-east-1"),
+		Endpoint:        os.Getenv("STORAGE_ENDPOINT"),
+		AccessKeyID:     os.Getenv("STORAGE_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("STORAGE_SECRET_ACCESS_KEY"),
+		LocalDir:        getEnvOrDefault("STORAGE_LOCAL_DIR", "./uploads"),
+		PublicURL:       getEnvOrDefault("STORAGE_PUBLIC_URL", "http://localhost:8080"),
+		SigningKey:      os.Getenv("STORAGE_SIGNING_KEY"),
+		ScanBackend:     getEnvOrDefault("STORAGE_SCAN_BACKEND", "none"),
+		ClamAVAddress:   getEnvOrDefault("CLAMAV_ADDRESS", "localhost:3310"),
+	}
+}
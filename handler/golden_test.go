@@ -0,0 +1,340 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"app/api"
+	"app/config"
+	"app/internal/auth"
+	"app/internal/logger"
+	"app/internal/openapi"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// goldenEndpoint records the responses of one route. Golden files hold the endpoints
+// of one OpenAPI tag, in catalog order.
+type goldenEndpoint struct {
+	Route       string           `json:"route"`
+	OperationID string           `json:"operation_id"`
+	Responses   []goldenResponse `json:"responses"`
+}
+
+type goldenResponse struct {
+	Case        string          `json:"case"`
+	Status      int             `json:"status"`
+	ContentType string          `json:"content_type,omitempty"`
+	Body        json.RawMessage `json:"body,omitempty"`
+}
+
+// unavailableDriver fails every connection, so handlers take their database error paths
+type unavailableDriver struct{}
+
+func (unavailableDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("database unavailable")
+}
+
+// unavailableDB is shared by every run, since services keep the database they are
+// first built with
+var unavailableDB = sql.OpenDB(unavailableConnector{})
+
+type unavailableConnector struct{}
+
+func (unavailableConnector) Connect(context.Context) (driver.Conn, error) {
+	return unavailableDriver{}.Open("")
+}
+
+func (unavailableConnector) Driver() driver.Driver { return unavailableDriver{} }
+
+var (
+	pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+	tagSlug   = regexp.MustCompile(`[^a-z0-9]+`)
+
+	// Values that change from run to run
+	volatile = []struct {
+		pattern     *regexp.Regexp
+		placeholder string
+	}{
+		{regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$`), "<timestamp>"},
+		{regexp.MustCompile(`^(\d+(\.\d+)?(ns|µs|ms|s|m|h))+$`), "<duration>"},
+		{regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`), "<uuid>"},
+	}
+	// Routes whose bodies are checked elsewhere: the OpenAPI document is generated
+	// into sdk/openapi.json
+	bodiesElsewhere = []string{"GET /openapi.json"}
+
+	// Fields that depend on the process: runtime metrics, payment providers started by
+	// earlier requests, the JWT signing key, and random object keys and their signed URLs
+	volatileFields = []string{"alloc_mb", "sys_mb", "total_alloc_mb", "num_gc", "cpus", "go_version", "goroutines", "payment_providers", "kid", "x", "object_key", "upload_url"}
+)
+
+// TestResponseGolden compares each route's documented success response and the error
+// responses it actually returns against testdata/golden. Run with -update to accept
+// an intended change, and review the diff with the code.
+func TestResponseGolden(t *testing.T) {
+	t.Setenv("JWT_SECRET", "golden-test-secret-golden-test-secret")
+	t.Setenv("APP_ENV", "test")
+	t.Setenv("RATE_LIMIT_AUTH_BURST", "100000")
+	t.Setenv("RATE_LIMIT_USER_BURST", "100000")
+	t.Setenv("VAULT_ENCRYPTION_KEY", "")
+	t.Setenv("STORAGE_LOCAL_DIR", t.TempDir())
+	auth.InitJWT()
+
+	saved := config.DB
+	config.DB = unavailableDB
+	t.Cleanup(func() { config.DB = saved })
+
+	router := newTestRouter()
+	doc, err := api.GenerateOpenAPI(router)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI() error = %v", err)
+	}
+
+	byTag := make(map[string][]goldenEndpoint)
+	var tags []string
+	for i, route := range api.OpenAPIRoutes() {
+		if route.Hidden {
+			continue
+		}
+		op := operation(doc, route.Method, route.Path)
+		if op == nil {
+			t.Fatalf("%s %s not documented", route.Method, route.Path)
+		}
+		endpoint := goldenEndpoint{
+			Route:       route.Method + " " + route.Path,
+			OperationID: op.OperationID,
+			Responses:   endpointResponses(t, router, route, op, i+1),
+		}
+		if _, ok := byTag[route.Tag]; !ok {
+			tags = append(tags, route.Tag)
+		}
+		byTag[route.Tag] = append(byTag[route.Tag], endpoint)
+	}
+
+	for _, tag := range tags {
+		name := strings.Trim(tagSlug.ReplaceAllString(strings.ToLower(tag), "-"), "-") + ".json"
+		t.Run(name, func(t *testing.T) {
+			got, err := marshalGolden(byTag[tag])
+			if err != nil {
+				t.Fatalf("marshalGolden() error = %v", err)
+			}
+
+			path := filepath.Join("testdata", "golden", name)
+			if *update {
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("%v (run go test ./handler -run TestResponseGolden -update)", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s responses changed; review them and run go test ./handler -run TestResponseGolden -update\n%s",
+					tag, firstDifference(want, got))
+			}
+		})
+	}
+}
+
+// endpointResponses records the documented success response, then sends the requests
+// each error case needs. userID keeps every route in its own rate limit bucket.
+func endpointResponses(t *testing.T, router http.Handler, route openapi.Route, op *openapi.Operation, userID int) []goldenResponse {
+	t.Helper()
+
+	success := goldenResponse{Case: "success", Status: route.Status}
+	if success.Status == 0 {
+		success.Status = http.StatusOK
+	}
+	if route.Response != nil {
+		success.ContentType = route.ContentType
+		if success.ContentType == "" {
+			success.ContentType = "application/json"
+			body, err := marshalGolden(route.Response)
+			if err != nil {
+				t.Fatalf("%s %s: example response: %v", route.Method, route.Path, err)
+			}
+			success.Body = body
+		}
+	}
+	responses := []goldenResponse{success}
+
+	if len(op.Security) > 0 {
+		responses = append(responses, serve(t, router, "unauthenticated", route, "", "1", nil))
+	}
+	role := "consumer"
+	if len(op.Roles) > 0 {
+		role = op.Roles[0]
+		for _, other := range []string{"consumer", "gig_worker", "admin"} {
+			if !slices.Contains(op.Roles, other) {
+				responses = append(responses, serve(t, router, "forbidden", route, token(t, userID, other), "1", nil))
+				break
+			}
+		}
+	}
+	bearer := ""
+	if len(op.Security) > 0 {
+		bearer = token(t, userID, role)
+	}
+
+	// Malformed input: unparseable path parameters and request body
+	var malformed []byte
+	if route.Request != nil || route.Upload != "" {
+		malformed = []byte("{")
+	}
+	if pathParam.MatchString(route.Path) || malformed != nil {
+		responses = append(responses, serve(t, router, "malformed request", route, bearer, "not-a-number", malformed))
+	}
+
+	// The documented example request, with the database down
+	var example []byte
+	if route.Request != nil {
+		var err error
+		if example, err = json.Marshal(route.Request); err != nil {
+			t.Fatalf("%s %s: example request: %v", route.Method, route.Path, err)
+		}
+	}
+	return append(responses, serve(t, router, "database unavailable", route, bearer, "1", example))
+}
+
+// serve sends one request for route, filling its path parameters with param and its
+// required query parameters with their examples
+func serve(t *testing.T, router http.Handler, name string, route openapi.Route, bearer, param string, body []byte) goldenResponse {
+	t.Helper()
+
+	target := pathParam.ReplaceAllString(route.Path, param)
+	var query []string
+	for _, q := range route.Query {
+		if q.Required {
+			query = append(query, q.Name+"="+fmt.Sprint(q.Example))
+		}
+	}
+	if len(query) > 0 {
+		target += "?" + strings.Join(query, "&")
+	}
+
+	req := httptest.NewRequest(route.Method, target, bytes.NewReader(body))
+	req.Header.Set(logger.RequestIDHeader, "golden")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	resp := goldenResponse{Case: name, Status: rec.Code, ContentType: rec.Header().Get("Content-Type")}
+	if strings.HasPrefix(resp.ContentType, "application/json") && !slices.Contains(bodiesElsewhere, route.Method+" "+route.Path) {
+		var v interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &v); err != nil {
+			t.Fatalf("%s %s (%s): response is not JSON: %q", route.Method, route.Path, name, rec.Body.String())
+		}
+		resp.Body, _ = marshalGolden(normalize(v))
+	}
+	return resp
+}
+
+// normalize replaces the volatile values in a decoded JSON response with placeholders
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if slices.Contains(volatileFields, key) {
+				v[key] = "<" + key + ">"
+				continue
+			}
+			v[key] = normalize(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = normalize(value)
+		}
+	case string:
+		for _, vol := range volatile {
+			if vol.pattern.MatchString(v) {
+				return vol.placeholder
+			}
+		}
+	}
+	return v
+}
+
+// marshalGolden indents v without escaping HTML, so placeholders stay readable
+func marshalGolden(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func token(t *testing.T, userID int, role string) string {
+	t.Helper()
+	tok, err := auth.GenerateJWT(userID, "00000000-0000-4000-8000-000000000000", "golden@example.com", role)
+	if err != nil {
+		t.Fatalf("GenerateJWT() error = %v", err)
+	}
+	return tok
+}
+
+func operation(doc *openapi.Document, method, path string) *openapi.Operation {
+	item, ok := doc.Paths[path]
+	if !ok {
+		return nil
+	}
+	switch method {
+	case http.MethodGet:
+		return item.Get
+	case http.MethodPost:
+		return item.Post
+	case http.MethodPut:
+		return item.Put
+	case http.MethodPatch:
+		return item.Patch
+	case http.MethodDelete:
+		return item.Delete
+	}
+	return nil
+}
+
+// firstDifference shows the lines around the first difference between two golden files
+func firstDifference(want, got []byte) string {
+	wantLines := strings.Split(string(want), "\n")
+	gotLines := strings.Split(string(got), "\n")
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			return fmt.Sprintf("line %d:\n  want: %s\n  got:  %s", i+1, w, g)
+		}
+	}
+	return ""
+}
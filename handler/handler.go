@@ -14,11 +14,38 @@ func GetPublicHandlers(r chi.Router) {
 	r.Get("/health", api.HealthCheck)      // Basic health check (backwards compatible)
 	r.Get("/ready", api.ReadinessCheck)    // Kubernetes readiness probe
 	r.Get("/live", api.LivenessCheck)      // Kubernetes liveness probe
+	r.Get("/healthz", api.LivenessCheck)   // Liveness probe (conventional path)
+	r.Get("/readyz", api.ReadinessCheck)   // Readiness probe (conventional path)
 	r.Get("/metrics", api.MetricsCheck)    // Runtime metrics
 
 	r.Get("/", middleware.ServeEmailForm)
 	r.Get("/email-submit", middleware.HandleEmailSubmission)
 
+	// Accounting OAuth redirect target (state ties the callback to the user)
+	r.Get("/api/v1/accounting/{provider}/callback", api.AccountingOAuthCallback)
+
+	// Job status, offer and payment events over WebSocket (authenticates the token itself)
+	r.Get("/ws", api.JobEventsSocket)
+
+	// Attachment downloads from local-disk storage (signed URLs carry their own authorization)
+	r.Get("/files/*", api.ServeStoredFile)
+	r.Put("/files/*", api.ReceiveStoredFile) // Uploads to signed upload URLs (the one public PUT route)
+
+	// Attachment download links (the token is scoped to the attachment)
+	r.Get("/api/v1/attachments/{id}/download", api.DownloadAttachment)
+
+	// Marketplace aggregates for the marketing site's live counters (cached)
+	r.Get("/public/stats", api.GetPublicStats)
+
+	// Public keys third parties verify reputation exports with
+	r.Get("/.well-known/reputation-keys.json", api.GetReputationKeys)
+
+	// Deep links from emails and push notifications (counts the click, then redirects)
+	r.Get("/l/{token}", api.FollowLink)
+
+	// OpenAPI 3 document generated from the registered routes
+	r.Get("/openapi.json", api.GetOpenAPISpec)
+
 	// Swagger documentation
 	r.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL("/swagger/doc.json"),
@@ -30,17 +57,52 @@ func GetHandlers(r chi.Router) {
 	// User Management - Protected endpoints
 	r.With(middleware.RequireRoles("admin", "consumer")).Get("/api/v1/customers/{id}", api.GetCustomerByID)
 	r.Get("/api/v1/users/profile", api.GetUserProfile) // Any authenticated user
+	r.Get("/api/v1/users/me/reputation-export", api.ExportReputation) // Caller's signed ratings and review history
+	r.Get("/api/v1/users/me/export", api.GetMyDataExport)             // ?format=json|csv; starts an export when none is pending or ready
 	r.With(middleware.RequireRole("admin")).Get("/api/v1/users/{id}", api.GetUserByID)
+	r.Get("/api/v1/auth/sessions", api.ListSessions) // Caller's own login sessions
 
 	// GigWorker Management
 	r.With(middleware.RequireRoles("admin", "consumer")).Get("/api/v1/gigworkers", api.GetGigWorkers)
 	r.Get("/api/v1/gigworkers/{id}", api.GetGigWorkerByID) // Any authenticated user
+	r.With(middleware.RequireRoles("admin", "gig_worker")).Get("/api/v1/gigworkers/{id}/payouts", api.GetGigWorkerPayouts) // Profile owner or admin (checked in handler)
+	r.Get("/api/v1/gigworkers/{id}/availability", api.GetGigWorkerAvailability) // Any authenticated user; busy titles for owner or admin
+	r.With(middleware.RequireRoles("admin", "gig_worker")).Get("/api/v1/gigworkers/{id}/blackout-dates", api.GetBlackoutDates) // Profile owner or admin (checked in handler)
+	r.With(middleware.RequireRole("gig_worker")).Get("/api/v1/gigworkers/me/offers", api.GetMyJobOffers) // Offers of jobs sent to the caller
+	r.Get("/api/v1/offers/poll", api.PollJobEvents) // Long-poll fallback to /ws: the caller's offer, job status and payment events
+	r.With(middleware.RequireRole("gig_worker")).Get("/api/v1/gigworkers/me/conflicts", api.GetMyScheduleConflicts) // ?start=&end=&job_id=, checked again on accept
+	r.With(middleware.RequireRole("gig_worker")).Get("/api/v1/gigworkers/me/earnings", api.GetMyEarnings) // ?year=&currency=, monthly with fees withheld
+
+	// Worker applications
+	r.Get("/api/v1/worker-applications/mine", api.GetMyWorkerApplications) // Caller's own applications
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/worker-applications", api.GetWorkerApplications)
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/worker-applications/{id}", api.GetWorkerApplicationByID)
+
+	// Worker verification documents - gig workers and applicants upload, admins review
+	r.With(middleware.RequireRoles("consumer", "gig_worker")).Get("/api/v1/gigworkers/me/documents", api.GetMyWorkerDocuments)
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/worker-documents", api.GetWorkerDocuments) // ?status=&worker_id=
 
 	// Job Management
 	r.Get("/api/v1/jobs", api.GetJobs)           // Any authenticated user
 	r.Get("/api/v1/jobs/{id}", api.GetJobByID)   // Any authenticated user
 	r.Get("/api/v1/jobs/my-jobs", api.GetMyJobs) // Any authenticated user
 	r.With(middleware.RequireRole("gig_worker")).Get("/api/v1/jobs/available", api.GetAvailableJobs)
+	r.Get("/api/v1/jobs/{id}/weather", api.GetJobWeather) // Forecast advisory for outdoor jobs
+	r.With(middleware.RequireRoles("admin", "consumer")).Get("/api/v1/jobs/{id}/completeness", api.GetJobCompleteness) // Job owner or admin
+	r.With(middleware.RequireRole("consumer")).Get("/api/v1/jobs/{id}/survey", api.GetJobSurvey)                       // Sent when the job closes
+	r.With(middleware.RequireRoles("gig_worker", "consumer")).Get("/api/v1/jobs/{id}/reschedule-proposals", api.GetRescheduleProposals)
+	r.Get("/api/v1/jobs/{id}/expenses", api.GetJobExpenses)         // Job participants
+	r.Get("/api/v1/jobs/{id}/parts-requests", api.GetPartsRequests) // Job participants
+	r.Get("/api/v1/jobs/{id}/messages", api.GetJobMessages)         // Job participants
+	r.Get("/api/v1/jobs/{id}/handoffs", api.GetJobHandoffs)         // Job participants
+	r.With(middleware.RequireRole("gig_worker")).Get("/api/v1/gigworkers/me/tax-summary", api.GetWorkerTaxSummary)
+
+	// Favorite workers and auto-accept of rebookings
+	r.With(middleware.RequireRole("consumer")).Get("/api/v1/users/me/favorite-workers", api.GetFavoriteWorkers)
+	r.With(middleware.RequireRole("gig_worker")).Get("/api/v1/gigworkers/me/auto-accept", api.GetAutoAcceptSettings)
+
+	// Job templates
+	r.With(middleware.RequireRole("consumer")).Get("/api/v1/job-templates", api.GetJobTemplates)
 
 	// Review Management
 	r.Get("/api/v1/reviews", api.GetReviews)                    // Any authenticated user (public reviews only)
@@ -53,33 +115,175 @@ func GetHandlers(r chi.Router) {
 	// Payment Management
 	r.Get("/api/v1/jobs/{id}/payments", api.GetJobTransactions)          // Get all transactions for a job
 	r.Get("/api/v1/jobs/{id}/payment-summary", api.GetJobPaymentSummary) // Get payment summary for a job
+	r.With(middleware.RequireRole("consumer")).Get("/api/v1/jobs/{id}/price-breakdown", api.GetJobPriceBreakdown) // Fees and total before payment
+	r.Get("/api/v1/jobs/{id}/escrow-timeline", api.GetJobEscrowTimeline) // Job participants or admin (checked in handler)
+	r.Get("/api/v1/currencies", api.GetCurrencies)                        // Currencies jobs can be priced in
+	r.With(middleware.RequireRole("consumer")).Get("/api/v1/payments/export", api.ExportSpend)           // CSV spend export
+	r.Get("/api/v1/payments/{id}/receipt", api.GetTransactionReceipt) // Consumer's itemized receipt; format=pdf for the consumer's receipt or worker's earnings statement (checked in handler)
+	r.Get("/api/v1/payments/{id}/line-item-disputes", api.GetLineItemDisputes) // Consumer, worker or admin (checked in handler)
+
+	// Accounting export (QuickBooks / Xero)
+	r.With(middleware.RequireRole("consumer")).Get("/api/v1/accounting/connections", api.GetAccountingConnections)
+	r.With(middleware.RequireRole("consumer")).Get("/api/v1/accounting/connections/{id}/mappings", api.GetAccountingMappings)
 
 	// Schedule Endpoints
 	r.Get("/api/v1/schedules", api.GetSchedules) // Get all schedules
+	r.Get("/api/v1/schedules/occurrences", api.GetScheduleOccurrences) // Recurring schedules expanded into slots
+
+	// In-app notifications (caller's own inbox)
+	r.Get("/api/v1/notifications", api.GetNotifications)                        // ?status=unread|read|archived
+	r.Get("/api/v1/notifications/unread-count", api.GetUnreadNotificationCount) // Badge count
+
+	// Notification preferences (caller's own)
+	r.Get("/api/v1/users/me/notification-preferences", api.GetNotificationPreferences) // Email, push and SMS per notification type
+
+	// Waitlist & Market Management - Admin only
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/waitlist", api.GetWaitlistSignups)
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/markets", api.GetMarkets)
+
+	// Safety Incidents - Admin only (trust & safety)
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/incidents", api.GetIncidents)
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/incidents/{id}", api.GetIncidentByID)
+
+	// Support Tickets - Admin only (escalated job threads)
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/support/tickets", api.GetSupportTickets)
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/support/tickets/{id}", api.GetSupportTicketByID)
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/break-glass/log", api.GetBreakGlassAccessLog) // Audit trail of emergency contact access
+
+	// Attachments - Admin only (download links only for files that passed the malware scan)
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/attachments/{id}", api.GetAttachment)
+
+	// Fraud Flags - Admin only (risk review)
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/fraud/flags", api.GetFraudFlags) // ?status=open|dismissed|confirmed|all&min_score=
+
+	// Shadow evaluation - Admin only (candidate matching/pricing vs production)
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/shadow/report", api.GetShadowReport) // ?kind=pricing|matching&from=&to=
+
+	// Job funnel analytics - Admin only (jobs reaching each lifecycle stage, time in stage)
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/analytics/funnel", api.GetFunnelReport) // ?from=&to=
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/analytics/surveys", api.GetSurveyReport) // CSAT/NPS by category and market, ?from=&to=
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/analytics/offer-declines", api.GetOfferDeclineReport) // Offer outcomes and decline reasons by category, ?from=&to=
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/jobs/{id}/events", api.GetJobEvents)    // Lifecycle history from the event store
+
+	// Worker payouts - Admin only (settlement batches)
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/payouts/batches", api.GetSettlementBatches) // ?status=pending|processed|failed|reconciled
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/payouts/batches/{id}", api.GetSettlementBatch)
+
+	// Job workflow debugging - Admin only (support staff)
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/jobs/{id}/workflow", api.GetJobWorkflowState) // Live state queried from Temporal
+
+	// Payment disputes - Admin only (support review)
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/disputes", api.GetDisputes) // ?status=open|under_review|resolved|refunded&job_id=
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/disputes/{id}", api.GetDisputeByID)
+
+	// JWT signing keys - Admin only
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/auth/signing-keys", api.GetSigningKeys)
+
+	// Account merges - Admin only (audit trail of duplicate account merges)
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/users/merges", api.GetAccountMerges) // ?user_id=
+
+	// Admin dashboard - lists take ?format=csv for a CSV export
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/users", api.AdminGetUsers)                           // ?role=&is_active=&q=&from=&to=
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/jobs", api.AdminGetJobs)                             // ?status=&category=&consumer_id=&worker_id=&q=&from=&to=
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/transactions", api.AdminGetTransactions)             // ?status=&provider=&user_id=&job_id=&min_amount=&max_amount=&q=&from=&to=
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/transactions/{id}/events", api.AdminGetTransactionEvents) // Provider calls with card data redacted
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/verification-queue", api.AdminGetVerificationQueue) // ?status=
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/dispute-queue", api.AdminGetDisputeQueue)           // ?unassigned=
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/metrics", api.AdminGetMetrics)                       // ?from=&to=
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/overview", api.AdminGetOverview)                     // ?from=&to=
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/audit-events", api.GetAuditEvents)                   // ?actor_id=&action=&entity_type=&entity_id=&from=&to=
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/users/{id}/notification-deliveries", api.AdminGetNotificationDeliveries) // ?channel=&status=
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/email-suppressions", api.AdminGetEmailSuppressions) // ?search=&page=&limit=
+	r.With(middleware.RequireRole("admin")).Delete("/api/v1/admin/email-suppressions/{email}", api.AdminDeleteEmailSuppression)
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/query-stats", api.AdminGetQueryStats)             // ?limit=, this instance's queries by total time
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/query-diagnostics", api.AdminGetQueryDiagnostics) // ?fingerprint=&page=&limit=, captured EXPLAIN ANALYZE plans
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/index-advisories", api.AdminGetIndexAdvisories)   // List queries and whether an index serves each
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/links/stats", api.GetDeepLinkStats) // Deep link clicks and use by action, ?from=&to=
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/tax-reports/1099-nec", api.AdminGet1099NEC) // ?year=&threshold=, workers to issue a 1099-NEC
+
+	// Platform fee rules - Admin only
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/fee-rules", api.GetFeeRules)            // ?active=
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/fee-rules/preview", api.PreviewFees)    // ?category=&worker_tier=&amount=&at=
+
+	// Bulk refunds - Admin only
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/refund-batches", api.GetRefundBatches)     // ?status=pending|running|completed|completed_with_errors
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/refund-batches/{id}", api.GetRefundBatch) // Per-item results, ?format=csv
+
+	// Email templates - Admin only
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/email-templates", api.GetEmailTemplates)
+	r.With(middleware.RequireRole("admin")).Get("/api/v1/admin/email-templates/{name}/preview", api.PreviewEmailTemplate) // Rendered HTML with sample data
 }
 
 // PostPublicHandlers handles public POST routes (no authentication required)
 func PostPublicHandlers(r chi.Router) {
 	// Authentication endpoints (public)
-	r.Post("/api/v1/auth/register", api.RegisterUser)
-	r.Post("/api/v1/auth/login", api.LoginUser)
+	r.With(middleware.RateLimitAuth).Post("/api/v1/auth/register", api.RegisterUser) // Rate limited per client IP
+	r.With(middleware.RateLimitAuth).Post("/api/v1/auth/login", api.LoginUser)
 	r.Post("/api/v1/auth/logout", api.LogoutUser)
 	r.Post("/api/v1/auth/refresh", api.RefreshToken)
 	r.Post("/api/v1/auth/verify-email", api.VerifyEmail)
-	r.Post("/api/v1/auth/forgot-password", api.ForgotPassword)
+	r.With(middleware.RateLimitAuth).Post("/api/v1/auth/resend-verification", api.ResendVerification)
+	r.With(middleware.RateLimitAuth).Post("/api/v1/auth/forgot-password", api.ForgotPassword)
 	r.Post("/api/v1/auth/reset-password", api.ResetPassword)
+	r.Post("/api/v1/account/reactivate", api.ReactivateAccount) // During the deletion hold
+
+	// Review request email links (the token is scoped to the job and reviewer)
+	r.Post("/api/v1/jobs/{id}/review/link", api.SubmitReviewFromLink)
+	r.Post("/api/v1/jobs/{id}/accept-offer/link", api.AcceptJobOfferFromLink) // Single-use offer link
+
+	// Waitlist for unlaunched markets (public)
+	r.Post("/api/v1/waitlist", api.JoinWaitlist)
+
+	// Email provider delivery receipts (signature checked in handler)
+	r.Post("/api/v1/webhooks/sendgrid", api.SendGridEventWebhook)
+	r.Post("/api/v1/webhooks/ses", api.SESEventWebhook) // SES notifications via an SNS HTTPS subscription
 }
 
 func PostHandlers(r chi.Router) {
 
+	// Self-serve account deletion (starts the deactivation hold)
+	r.Post("/api/v1/account/deletion", api.RequestAccountDeletion)
+
+	// Login sessions - keeps the caller's current session
+	r.Post("/api/v1/auth/sessions/revoke-others", api.RevokeOtherSessions)
+
 	// User Management - Protected endpoints
 	r.With(middleware.RequireRole("admin")).Post("/api/v1/users/create", api.CreateUser)
 
-	// GigWorker Management
-	r.Post("/api/v1/gigworkers/create", api.CreateGigWorker) // Any authenticated user can register as gig worker
+	// Phone verification (caller's own) - verified phones can be sent SMS notifications
+	r.Post("/api/v1/users/me/phone/verification", api.SendPhoneVerificationCode) // Texts a code to the profile's phone
+	r.Post("/api/v1/users/me/phone/verify", api.VerifyPhone)                     // Sets phone_verified
+
+	// Push notification devices (caller's own)
+	r.Post("/api/v1/users/me/devices", api.RegisterDevice) // FCM token and platform; registering again updates it
+
+	// Worker blackout dates - profile owner or admin (checked in handler)
+	r.With(middleware.RequireRoles("admin", "gig_worker")).Post("/api/v1/gigworkers/{id}/blackout-dates", api.CreateBlackoutDate)
+
+	// Favorite workers - consumers rebook them with preferred_worker_id on job creation
+	r.With(middleware.RequireRole("consumer")).Post("/api/v1/users/me/favorite-workers", api.AddFavoriteWorker)
+
+	// Job templates and repeat bookings
+	r.With(middleware.RequireRole("consumer")).Post("/api/v1/job-templates", api.CreateJobTemplate)               // From the fields given or from job_id
+	r.With(middleware.RequireRole("consumer")).Post("/api/v1/jobs/from-template/{id}", api.CreateJobFromTemplate) // Posts a job from a saved template
+	r.With(middleware.RequireRole("consumer")).Post("/api/v1/jobs/{id}/rebook", api.RebookJob)                    // Same worker, schedule from their availability
+
+	// Job offers - the first worker to accept an offer gets the job
+	r.With(middleware.RequireRole("gig_worker")).Post("/api/v1/gigworkers/me/offers/{id}/accept", api.AcceptWorkerJobOffer)
+	r.With(middleware.RequireRole("gig_worker")).Post("/api/v1/gigworkers/me/offers/{id}/decline", api.DeclineWorkerJobOffer)
+	r.With(middleware.RequireRole("gig_worker")).Post("/api/v1/gigworkers/me/offers/{id}/snooze", api.SnoozeWorkerJobOffer)
+	r.With(middleware.RequireRole("gig_worker")).Post("/api/v1/gigworkers/me/heartbeat", api.WorkerHeartbeat) // App pings while open; online for presence.OnlineTTL
+
+	// Worker applications - any non-admin account may apply; admins screen them
+	r.Post("/api/v1/worker-applications", api.SubmitWorkerApplication)
+	r.With(middleware.RequireRole("admin")).Post("/api/v1/worker-applications/{id}/status", api.UpdateWorkerApplicationStatus)
+	r.With(middleware.RequireRoles("consumer", "gig_worker")).Post("/api/v1/gigworkers/me/documents/uploads", api.CreateWorkerDocumentUpload) // Signed URL to PUT the file to
+	r.With(middleware.RequireRoles("consumer", "gig_worker")).Post("/api/v1/gigworkers/me/documents", api.SubmitWorkerDocument)              // Checks and scans the uploaded file
+	r.With(middleware.RequireRole("admin")).Post("/api/v1/admin/worker-documents/{id}/review", api.ReviewWorkerDocument)
 
 	// Job Management
 	r.With(middleware.RequireRoles("admin", "consumer")).Post("/api/v1/jobs/create", api.CreateJob)
+	r.With(middleware.RequireRoles("admin", "consumer")).Post("/api/v1/jobs/completeness", api.CheckJobCompleteness)
 	r.With(middleware.RequireRole("gig_worker")).Post("/api/v1/jobs/{id}/accept", api.AcceptJob)
 	r.With(middleware.RequireRoles("admin", "consumer")).Post("/api/v1/jobs/{id}/send-offer", api.SendJobOffer)
 
@@ -87,7 +291,27 @@ func PostHandlers(r chi.Router) {
 	r.With(middleware.RequireRole("gig_worker")).Post("/api/v1/jobs/{id}/start", api.StartJob)
 	r.With(middleware.RequireRoles("gig_worker", "consumer")).Post("/api/v1/jobs/{id}/complete", api.CompleteJob)
 	r.With(middleware.RequireRole("gig_worker")).Post("/api/v1/jobs/{id}/reject", api.RejectJob)
+	r.With(middleware.RequireRole("gig_worker")).Post("/api/v1/jobs/{id}/handoffs", api.RequestJobHandoff) // Assigned worker can't finish the job
+	r.With(middleware.RequireRole("consumer")).Post("/api/v1/jobs/{id}/handoffs/{handoffId}/approve", api.ApproveJobHandoff)
+	r.With(middleware.RequireRole("consumer")).Post("/api/v1/jobs/{id}/handoffs/{handoffId}/decline", api.DeclineJobHandoff)
 	r.With(middleware.RequireRoles("admin", "consumer")).Post("/api/v1/jobs/{id}/review", api.SubmitReview)
+	r.With(middleware.RequireRole("consumer")).Post("/api/v1/jobs/{id}/survey", api.SubmitJobSurvey) // One-question CSAT/NPS answer
+	r.With(middleware.RequireRoles("gig_worker", "consumer")).Post("/api/v1/jobs/{id}/incidents", api.ReportIncident) // SOS / safety incident
+	r.With(middleware.RequireRoles("gig_worker", "consumer")).Post("/api/v1/jobs/{id}/messages", api.SendJobMessage)
+	r.With(middleware.RequireRoles("gig_worker", "consumer")).Post("/api/v1/jobs/{id}/messages/escalate", api.EscalateJobThread) // Open a support ticket from the thread
+	r.With(middleware.RequireRole("consumer")).Post("/api/v1/jobs/{id}/disputes", api.CreateDispute)                             // Holds payment until resolved
+	r.With(middleware.RequireRole("consumer")).Post("/api/v1/payments/{id}/line-item-disputes", api.CreateLineItemDispute)       // One receipt line; the worker accepts or contests
+	r.With(middleware.RequireRole("gig_worker")).Post("/api/v1/line-item-disputes/{id}/respond", api.RespondToLineItemDispute) // Accept refunds the amount; contest opens a dispute
+	r.With(middleware.RequireRoles("gig_worker", "consumer")).Post("/api/v1/jobs/{id}/reschedule-proposals", api.ProposeReschedule)
+	r.With(middleware.RequireRoles("gig_worker", "consumer")).Post("/api/v1/jobs/{id}/reschedule-proposals/{proposalId}/respond", api.RespondToReschedule)
+	r.With(middleware.RequireRole("gig_worker")).Post("/api/v1/jobs/{id}/expenses", api.CreateJobExpense)
+	r.With(middleware.RequireRole("gig_worker")).Post("/api/v1/jobs/{id}/expenses/mileage", api.LogJobMileage) // Distance from previous job or home
+	r.With(middleware.RequireRole("consumer")).Post("/api/v1/jobs/{id}/expenses/{expenseId}/review", api.ReviewJobExpense)
+	r.With(middleware.RequireRole("gig_worker")).Post("/api/v1/jobs/{id}/expenses/{expenseId}/receipt", api.UploadExpenseReceipt) // Multipart "file", scanned before storing
+	r.With(middleware.RequireRoles("consumer", "gig_worker")).Post("/api/v1/jobs/{id}/photos", api.UploadJobPhoto) // Multipart "file", ?stage=posting (consumer) or before/after (assigned worker)
+	r.With(middleware.RequireRole("gig_worker")).Post("/api/v1/jobs/{id}/parts-requests", api.CreatePartsRequest) // Mid-job parts purchase
+	r.With(middleware.RequireRole("gig_worker")).Post("/api/v1/jobs/{id}/parts-requests/{requestId}/cancel", api.CancelPartsRequest)
+	r.With(middleware.RequireRole("consumer")).Post("/api/v1/jobs/{id}/parts-requests/{requestId}/review", api.ReviewPartsRequest)
 
 	// Review Management
 	r.With(middleware.RequireRoles("admin", "consumer", "gig_worker")).Post("/api/v1/reviews", api.CreateReview)
@@ -95,41 +319,153 @@ func PostHandlers(r chi.Router) {
 	// Schedule Management
 	r.Post("/api/v1/schedules/create", api.CreateSchedule) // Any authenticated user
 
+	// In-app notifications
+	r.Post("/api/v1/notifications/{id}/read", api.MarkNotificationRead)
+
 	// Transaction Management
 	r.With(middleware.RequireRole("admin")).Post("/api/v1/transactions/create", api.CreateTransaction)
+	r.With(middleware.RequireRole("admin")).Post("/api/v1/auth/signing-keys/rotate", api.RotateSigningKey) // New key signs after a propagation delay
 
 	// Payment Processing
 	r.With(middleware.RequireRole("consumer")).Post("/api/v1/payments/authorize", api.AuthorizeJobPayment)            // Pre-authorize payment (escrow)
 	r.With(middleware.RequireRoles("consumer", "gig_worker")).Post("/api/v1/payments/capture", api.CaptureJobPayment) // Capture payment (release from escrow)
 	r.With(middleware.RequireRole("consumer")).Post("/api/v1/payments/refund", api.RefundJobPayment)                  // Refund payment
+	r.With(middleware.RequireRole("consumer")).Post("/api/v1/jobs/{id}/tip", api.TipJob)                              // Tip the worker after completion
+
+	// Accounting export (QuickBooks / Xero)
+	r.With(middleware.RequireRole("consumer")).Post("/api/v1/accounting/{provider}/connect", api.ConnectAccounting)
+	r.With(middleware.RequireRole("consumer")).Post("/api/v1/accounting/connections/{id}/sync", api.SyncAccounting)
+
+	// Attachments - Admin only
+	r.With(middleware.RequireRole("admin")).Post("/api/v1/attachments/{id}/rescan", api.RescanAttachment) // After the scanner was unavailable
+
+	// Emergency contact break-glass - Admin only, audited
+	r.With(middleware.RequireRole("admin")).Post("/api/v1/gigworkers/{id}/emergency-contact/break-glass", api.BreakGlassEmergencyContact)
+
+	// Duplicate account merge - Admin only, audited
+	r.With(middleware.RequireRole("admin")).Post("/api/v1/users/merge", api.MergeAccounts) // dry_run previews without changes
+
+	// Market Management - Admin only
+	r.With(middleware.RequireRole("admin")).Post("/api/v1/markets/{id}/launch", api.LaunchMarket) // Go live and invite waitlist
+
+	// Worker payouts - Admin only
+	r.With(middleware.RequireRole("admin")).Post("/api/v1/payouts/batches", api.CreateSettlementBatch)                   // Settle now and pay out
+	r.With(middleware.RequireRole("admin")).Post("/api/v1/payouts/batches/{id}/process", api.ProcessSettlementBatch)     // Retry failed payouts
+	r.With(middleware.RequireRole("admin")).Post("/api/v1/payouts/batches/{id}/reconcile", api.ReconcileSettlementBatch) // Check totals and close
+
+	// Platform fee rules - Admin only
+	r.With(middleware.RequireRole("admin")).Post("/api/v1/admin/fee-rules", api.CreateFeeRule)
+
+	// Bulk refunds - Admin only
+	r.With(middleware.RequireRole("admin")).Post("/api/v1/admin/refund-batches", api.CreateRefundBatch)           // Requires Idempotency-Key
+	r.With(middleware.RequireRole("admin")).Post("/api/v1/admin/refund-batches/{id}/retry", api.RetryRefundBatch) // Retry failed refunds
 }
 
 func PutHandlers(r chi.Router) {
 	// User Management - Protected endpoints
-	r.Put("/api/v1/users/profile", api.UpdateUserProfile) // Any authenticated user can update their own profile
+	r.Put("/api/v1/users/profile", api.UpdateUserProfile)  // Any authenticated user can update their own profile
+	r.Put("/api/v1/users/me/password", api.ChangePassword) // Any authenticated user; revokes their other sessions
+	r.Put("/api/v1/users/me/avatar", api.UploadAvatar)     // Any authenticated user; multipart "file", replaces the previous one
 	r.With(middleware.RequireRole("admin")).Put("/api/v1/users/{id}", api.UpdateUser)
 
+	// Notification preferences (caller's own)
+	r.Put("/api/v1/users/me/notification-preferences", api.UpdateNotificationPreferences) // Types left out keep their settings
+
 	// GigWorker Management
-	r.Put("/api/v1/gigworkers/{id}", api.UpdateGigWorker) // Any authenticated user (should validate ownership in handler)
+	r.Put("/api/v1/gigworkers/{id}", api.UpdateGigWorker) // Profile owner or admin (checked in handler)
+
+	// Auto-accept of rebookings - either side can turn it off for one pair
+	r.With(middleware.RequireRole("consumer")).Put("/api/v1/users/me/favorite-workers/{workerId}", api.UpdateFavoriteWorker)
+	r.With(middleware.RequireRole("gig_worker")).Put("/api/v1/gigworkers/me/auto-accept", api.UpdateAutoAcceptSettings) // Opt-in and price floor
+	r.With(middleware.RequireRole("gig_worker")).Put("/api/v1/gigworkers/me/auto-accept/consumers/{consumerId}", api.UpdateAutoAcceptConsumer)
 
 	// Job Management
 	r.With(middleware.RequireRoles("admin", "consumer")).Put("/api/v1/jobs/{id}", api.UpdateJob)
 
 	// Review Management
 	r.With(middleware.RequireRoles("admin", "consumer", "gig_worker")).Put("/api/v1/reviews/{id}", api.UpdateReview)
+
+	// Safety Incidents - Admin only
+	r.With(middleware.RequireRole("admin")).Put("/api/v1/incidents/{id}", api.UpdateIncident)
+
+	// Support Tickets - Admin only
+	r.With(middleware.RequireRole("admin")).Put("/api/v1/support/tickets/{id}", api.UpdateSupportTicket)
+
+	// Payment disputes - Admin only
+	r.With(middleware.RequireRole("admin")).Put("/api/v1/disputes/{id}", api.UpdateDispute) // Review, resolve or refund
+
+	// Fraud Flags - Admin only
+	r.With(middleware.RequireRole("admin")).Put("/api/v1/fraud/flags/{id}", api.ReviewFraudFlag) // Dismiss or confirm
+
+	// Platform fee rules - Admin only
+	r.With(middleware.RequireRole("admin")).Put("/api/v1/admin/fee-rules/{id}", api.UpdateFeeRule)
+	r.With(middleware.RequireRole("admin")).Put("/api/v1/admin/gigworkers/{id}/fee-tier", api.UpdateWorkerFeeTier) // Matched by fee rules' worker_tier
+
+	// Accounting export category mappings
+	r.With(middleware.RequireRole("consumer")).Put("/api/v1/accounting/connections/{id}/mappings", api.UpdateAccountingMappings)
 }
 
 func DeleteHandlers(r chi.Router) {
+	// Login sessions - any authenticated user, own sessions only
+	r.Delete("/api/v1/auth/sessions/{id}", api.RevokeSession)
+
 	// User Management - Admin only
 	r.With(middleware.RequireRole("admin")).Delete("/api/v1/users/{id}", api.DeactivateUser)
 
 	// GigWorker Management - Admin only
 	r.With(middleware.RequireRole("admin")).Delete("/api/v1/gigworkers/{id}", api.DeactivateGigWorker)
+	r.With(middleware.RequireRoles("admin", "gig_worker")).Delete("/api/v1/gigworkers/{id}/blackout-dates/{blackoutId}", api.DeleteBlackoutDate) // Profile owner or admin (checked in handler)
+
+	// Avatars - any authenticated user, own avatar only
+	r.Delete("/api/v1/users/me/avatar", api.DeleteAvatar)
+
+	// Account deletion - any authenticated user, own account only; same as POST /api/v1/account/deletion
+	r.Delete("/api/v1/users/me", api.RequestAccountDeletion) // Requires the password; data is exported, then erased after the hold
+
+	// Push notification devices - any authenticated user, own devices only
+	r.Delete("/api/v1/users/me/devices/{id}", api.UnregisterDevice)
+
+	// Favorite workers
+	r.With(middleware.RequireRole("consumer")).Delete("/api/v1/users/me/favorite-workers/{workerId}", api.RemoveFavoriteWorker)
+
+	// Job templates
+	r.With(middleware.RequireRole("consumer")).Delete("/api/v1/job-templates/{id}", api.DeleteJobTemplate)
 
 	// Job Management
 	r.With(middleware.RequireRoles("admin", "consumer")).Delete("/api/v1/jobs/{id}/cancel", api.CancelJob)
 	r.With(middleware.RequireRoles("admin", "consumer")).Delete("/api/v1/jobs/{id}", api.DeleteJob)
+	r.Delete("/api/v1/jobs/{id}/photos/{photoId}", api.DeleteJobPhoto) // Uploader or admin (checked in handler)
 
 	// Review Management
 	r.With(middleware.RequireRoles("admin", "consumer", "gig_worker")).Delete("/api/v1/reviews/{id}", api.DeleteReview)
+
+	// Accounting export
+	r.With(middleware.RequireRole("consumer")).Delete("/api/v1/accounting/connections/{id}", api.DisconnectAccounting)
+
+	// Platform fee rules - Admin only (deactivates; kept for the payments they priced)
+	r.With(middleware.RequireRole("admin")).Delete("/api/v1/admin/fee-rules/{id}", api.DeactivateFeeRule)
+}
+
+// RegisterRoutes registers the public routes and the JWT-protected routes on router,
+// handled with app's clock and IDs. Authenticated requests are rate limited per user;
+// global middleware (CORS, per-IP rate limiting, logging) is left to the caller.
+func RegisterRoutes(router chi.Router, app *api.App) {
+	router.Group(func(router chi.Router) {
+		router.Use(app.Middleware)
+
+		// Public routes (no JWT required)
+		GetPublicHandlers(router)
+		PostPublicHandlers(router)
+
+		// Protected routes (JWT required)
+		router.Group(func(r chi.Router) {
+			r.Use(middleware.JWTAuth)
+			r.Use(middleware.RateLimitUser)
+			r.Use(middleware.AuditAdminActions)
+			GetHandlers(r)
+			PostHandlers(r)
+			PutHandlers(r)
+			DeleteHandlers(r)
+		})
+	})
 }
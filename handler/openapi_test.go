@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"app/api"
+	"app/internal/middleware"
+	"app/internal/openapi"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// newTestRouter builds the router the same way cmd/main.go does, without CORS and rate limiting
+func newTestRouter() chi.Router {
+	router := chi.NewRouter()
+	router.Use(middleware.SecurityHeaders)
+	router.Use(middleware.Logger)
+
+	RegisterRoutes(router, api.NewApp())
+	return router
+}
+
+func TestOpenAPICoversAllRoutes(t *testing.T) {
+	missing, err := openapi.Undocumented(newTestRouter(), api.OpenAPIRoutes())
+	if err != nil {
+		t.Fatalf("Undocumented() error = %v", err)
+	}
+	if len(missing) > 0 {
+		t.Errorf("routes missing from api.OpenAPIRoutes(): %v", missing)
+	}
+}
+
+func TestGenerateOpenAPI(t *testing.T) {
+	doc, err := api.GenerateOpenAPI(newTestRouter())
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI() error = %v", err)
+	}
+
+	seen := make(map[string]string)
+	for path, item := range doc.Paths {
+		for _, op := range []*openapi.Operation{item.Get, item.Post, item.Put, item.Patch, item.Delete} {
+			if op == nil {
+				continue
+			}
+			if other, ok := seen[op.OperationID]; ok {
+				t.Errorf("operationId %q used by both %s and %s", op.OperationID, other, path)
+			}
+			seen[op.OperationID] = path
+		}
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		method    string
+		wantAuth  bool
+		wantRoles []string
+		wantLimit bool
+	}{
+		{name: "public route", path: "/api/v1/auth/login", method: http.MethodPost, wantLimit: true},
+		{name: "public unlimited route", path: "/api/v1/auth/refresh", method: http.MethodPost},
+		{name: "any authenticated user", path: "/api/v1/jobs/{id}", method: http.MethodGet, wantAuth: true, wantLimit: true},
+		{name: "single role", path: "/api/v1/fraud/flags", method: http.MethodGet, wantAuth: true, wantRoles: []string{"admin"}, wantLimit: true},
+		{name: "several roles", path: "/api/v1/jobs/{id}/complete", method: http.MethodPost, wantAuth: true, wantRoles: []string{"consumer", "gig_worker"}, wantLimit: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, ok := doc.Paths[tt.path]
+			if !ok {
+				t.Fatalf("path %s not documented", tt.path)
+			}
+			op := item.Get
+			if tt.method == http.MethodPost {
+				op = item.Post
+			}
+			if op == nil {
+				t.Fatalf("%s %s not documented", tt.method, tt.path)
+			}
+			if got := len(op.Security) > 0; got != tt.wantAuth {
+				t.Errorf("authenticated = %v, want %v", got, tt.wantAuth)
+			}
+			if !reflect.DeepEqual(op.Roles, tt.wantRoles) {
+				t.Errorf("roles = %v, want %v", op.Roles, tt.wantRoles)
+			}
+			if _, got := op.Responses["429"]; got != tt.wantLimit {
+				t.Errorf("rate limited = %v, want %v", got, tt.wantLimit)
+			}
+		})
+	}
+}
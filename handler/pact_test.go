@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"app/config"
+	"app/internal/auth"
+	"app/internal/pact"
+)
+
+// pactProvider is the API's name in consumer contracts
+const pactProvider = "GigCoAPI"
+
+// pactStates sets up the provider states consumer contracts name. Logged-in states
+// replace the consumer's placeholder token with one for the userId param.
+func pactStates() map[string]pact.StateHandler {
+	loggedIn := func(role string) pact.StateHandler {
+		return func(state pact.ProviderState) (pact.RequestFilter, error) {
+			userID := 1
+			if id, ok := state.Params["userId"].(float64); ok {
+				userID = int(id)
+			}
+			token, err := auth.GenerateJWT(userID, "00000000-0000-4000-8000-000000000001", "pact@example.com", role)
+			if err != nil {
+				return nil, err
+			}
+			return func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+token) }, nil
+		}
+	}
+	return map[string]pact.StateHandler{
+		"a consumer is logged in":   loggedIn("consumer"),
+		"a gig worker is logged in": loggedIn("gig_worker"),
+		"an admin is logged in":     loggedIn("admin"),
+	}
+}
+
+// TestPactProvider verifies the API against its consumers' contracts: those the Pact
+// Broker selects when PACT_BROKER_URL is set, and the copies in testdata/pacts
+// otherwise. Failures of pending contracts are logged rather than failing the build.
+func TestPactProvider(t *testing.T) {
+	t.Setenv("JWT_SECRET", "pact-test-secret-pact-test-secret-pact")
+	t.Setenv("RATE_LIMIT_AUTH_BURST", "100000")
+	auth.InitJWT()
+	saved := config.DB
+	config.DB = unavailableDB
+	t.Cleanup(func() { config.DB = saved })
+
+	broker, cfg, err := pact.NewBrokerFromEnv(pactProvider)
+	var contracts []pact.Contract
+	switch {
+	case errors.Is(err, pact.ErrNotConfigured):
+		paths, err := filepath.Glob(filepath.Join("testdata", "pacts", "*.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, path := range paths {
+			f, err := pact.Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			contracts = append(contracts, pact.Contract{File: f, URL: path})
+		}
+	case err != nil:
+		t.Fatalf("NewBrokerFromEnv() error = %v", err)
+	default:
+		contracts, err = broker.ContractsForVerification(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("ContractsForVerification() error = %v", err)
+		}
+	}
+	if len(contracts) == 0 {
+		t.Skip("no consumer contracts to verify")
+	}
+
+	verifier := &pact.Verifier{Handler: newTestRouter(), States: pactStates()}
+	for _, c := range contracts {
+		t.Run(c.File.Consumer.Name, func(t *testing.T) {
+			if c.File.Provider.Name != pactProvider {
+				t.Fatalf("%s is a contract with %s, not %s", c.URL, c.File.Provider.Name, pactProvider)
+			}
+			results := verifier.Verify(c.File)
+			for _, r := range results {
+				if r.OK() {
+					continue
+				}
+				report := t.Errorf
+				if c.Pending {
+					report = t.Logf
+				}
+				report("%s: %s", r.Interaction, strings.Join(r.Mismatches, "; "))
+			}
+
+			if broker != nil && cfg.Publish {
+				if err := broker.PublishResults(context.Background(), cfg, c, results); err != nil {
+					t.Errorf("PublishResults() error = %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+// Package accounting exports consumer spend to QuickBooks Online and Xero.
+package accounting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"app/internal/clock"
+)
+
+// Supported providers
+const (
+	ProviderQuickBooks = "quickbooks"
+	ProviderXero       = "xero"
+)
+
+// ErrNotConfigured is returned when a provider's OAuth credentials are missing
+var ErrNotConfigured = errors.New("accounting provider not configured")
+
+// ErrInvalidGrant is returned when the provider rejects a refresh token as revoked or
+// expired, so the organisation has to be connected again
+var ErrInvalidGrant = errors.New("accounting authorization revoked or expired")
+
+// Token holds OAuth credentials for a connected accounting organisation
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	TenantID     string    `json:"tenant_id"` // QuickBooks realm ID or Xero tenant ID
+}
+
+// Expired reports whether the access token needs refreshing at now
+func (t *Token) Expired(now time.Time) bool {
+	return now.Add(time.Minute).After(t.ExpiresAt)
+}
+
+// Expense is a captured GigCo payment to be recorded as a purchase
+type Expense struct {
+	Reference   string // GigCo transaction UUID, used for de-duplication
+	Date        time.Time
+	Amount      float64
+	Currency    string
+	Description string
+	Vendor      string // Shown as the payee
+	AccountRef  string // Expense account in the connected ledger
+}
+
+// Provider is an accounting system spend can be exported to
+type Provider interface {
+	Name() string
+	AuthURL(state string) string
+	ExchangeCode(ctx context.Context, code, tenantID string) (*Token, error)
+	Refresh(ctx context.Context, token *Token) (*Token, error)
+	PushExpense(ctx context.Context, token *Token, expense Expense) (string, error)
+}
+
+// NewProvider returns the named provider configured from environment variables. Tokens
+// it obtains expire relative to c.
+func NewProvider(name string, c clock.Clock) (Provider, error) {
+	switch name {
+	case ProviderQuickBooks:
+		return NewQuickBooksFromEnv(c)
+	case ProviderXero:
+		return NewXeroFromEnv(c)
+	default:
+		return nil, fmt.Errorf("unsupported accounting provider: %s", name)
+	}
+}
+
+// DefaultExpenseCategory maps a GigCo job category to a default expense category
+func DefaultExpenseCategory(jobCategory string) string {
+	switch jobCategory {
+	case "cleaning", "maintenance", "lawn_care", "exterior_painting":
+		return "Repairs and Maintenance"
+	case "delivery", "transportation":
+		return "Freight and Delivery"
+	case "tech_support":
+		return "Computer and Internet"
+	case "tutoring":
+		return "Training and Education"
+	default:
+		return "Contract Labor"
+	}
+}
+
+// xeroDefaultAccountCodes maps default expense categories to Xero's standard chart of accounts
+var xeroDefaultAccountCodes = map[string]string{
+	"Repairs and Maintenance": "473",
+	"Freight and Delivery":    "425",
+	"Computer and Internet":   "485",
+	"Training and Education":  "429",
+	"Contract Labor":          "429",
+}
+
+// DefaultAccountRef returns the account reference a provider uses for a job category
+// when the consumer has not mapped it. QuickBooks resolves account names; Xero uses codes.
+func DefaultAccountRef(provider, jobCategory string) string {
+	category := DefaultExpenseCategory(jobCategory)
+	if provider == ProviderXero {
+		return xeroDefaultAccountCodes[category]
+	}
+	return category
+}
+
+// oauthClient holds the OAuth2 settings shared by both providers
+type oauthClient struct {
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	authURL      string
+	tokenURL     string
+	scope        string
+	httpClient   *http.Client
+	clock        clock.Clock // Token expiry is counted from its time
+}
+
+// authCodeURL builds the authorization redirect
+func (o *oauthClient) authCodeURL(state string) string {
+	params := url.Values{}
+	params.Set("client_id", o.clientID)
+	params.Set("redirect_uri", o.redirectURL)
+	params.Set("response_type", "code")
+	params.Set("scope", o.scope)
+	params.Set("state", state)
+	return o.authURL + "?" + params.Encode()
+}
+
+// tokenRequest performs an authorization_code or refresh_token grant
+func (o *oauthClient) tokenRequest(ctx context.Context, form url.Values) (*Token, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", o.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.SetBasicAuth(o.clientID, o.clientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		var oauthErr struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(body, &oauthErr) == nil && oauthErr.Error == "invalid_grant" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidGrant, body)
+		}
+		return nil, fmt.Errorf("token endpoint returned status %d: %s", resp.StatusCode, body)
+	}
+
+	var data struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	return &Token{
+		AccessToken:  data.AccessToken,
+		RefreshToken: data.RefreshToken,
+		ExpiresAt:    o.clock.Now().Add(time.Duration(data.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// exchange trades an authorization code for tokens
+func (o *oauthClient) exchange(ctx context.Context, code string) (*Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", o.redirectURL)
+	return o.tokenRequest(ctx, form)
+}
+
+// refresh obtains a new access token, keeping the tenant
+func (o *oauthClient) refresh(ctx context.Context, token *Token) (*Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", token.RefreshToken)
+
+	refreshed, err := o.tokenRequest(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+	refreshed.TenantID = token.TenantID
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = token.RefreshToken
+	}
+	return refreshed, nil
+}
+
+// doJSON sends an authenticated JSON request and decodes the response into dest
+func doJSON(ctx context.Context, httpClient *http.Client, method, url string, headers map[string]string, payload, dest interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = strings.NewReader(string(jsonData))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, respBody)
+	}
+
+	if dest == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package accounting
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"app/internal/clock"
+)
+
+func TestTokenExpiry(t *testing.T) {
+	issuedAt := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"access","refresh_token":"refresh","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	fake := clock.NewFake(issuedAt)
+	o := &oauthClient{tokenURL: server.URL, httpClient: server.Client(), clock: fake}
+	token, err := o.exchange(t.Context(), "code")
+	if err != nil {
+		t.Fatalf("exchange() error = %v", err)
+	}
+	if want := issuedAt.Add(time.Hour); !token.ExpiresAt.Equal(want) {
+		t.Fatalf("ExpiresAt = %s, want %s", token.ExpiresAt, want)
+	}
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		want    bool
+	}{
+		{name: "fresh", elapsed: 0, want: false},
+		{name: "over a minute left", elapsed: 58 * time.Minute, want: false},
+		{name: "refreshed a minute early", elapsed: 59*time.Minute + time.Second, want: true},
+		{name: "expired", elapsed: 2 * time.Hour, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := token.Expired(issuedAt.Add(tt.elapsed)); got != tt.want {
+				t.Errorf("Expired() after %s = %v, want %v", tt.elapsed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshInvalidGrant(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantRevoked bool
+	}{
+		{name: "refresh token revoked", status: http.StatusBadRequest, body: `{"error":"invalid_grant"}`, wantRevoked: true},
+		{name: "client misconfigured", status: http.StatusUnauthorized, body: `{"error":"invalid_client"}`},
+		{name: "provider outage", status: http.StatusServiceUnavailable, body: `upstream unavailable`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			o := &oauthClient{tokenURL: server.URL, httpClient: server.Client(), clock: clock.System}
+			_, err := o.refresh(t.Context(), &Token{RefreshToken: "refresh"})
+			if err == nil {
+				t.Fatal("refresh() error = nil")
+			}
+			if revoked := errors.Is(err, ErrInvalidGrant); revoked != tt.wantRevoked {
+				t.Errorf("refresh() error = %v, revoked = %v, want %v", err, revoked, tt.wantRevoked)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package accounting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"app/internal/clock"
+)
+
+// QuickBooks exports expenses to QuickBooks Online as Purchase records
+type QuickBooks struct {
+	oauth      oauthClient
+	apiBaseURL string
+}
+
+// NewQuickBooksFromEnv creates a QuickBooks provider from environment variables, whose
+// tokens expire relative to c
+func NewQuickBooksFromEnv(c clock.Clock) (*QuickBooks, error) {
+	clientID := os.Getenv("QUICKBOOKS_CLIENT_ID")
+	clientSecret := os.Getenv("QUICKBOOKS_CLIENT_SECRET")
+	redirectURL := os.Getenv("QUICKBOOKS_REDIRECT_URL")
+	if clientID == "" || clientSecret == "" || redirectURL == "" {
+		return nil, ErrNotConfigured
+	}
+
+	apiBaseURL := "https://quickbooks.api.intuit.com"
+	if os.Getenv("QUICKBOOKS_ENVIRONMENT") != "production" {
+		apiBaseURL = "https://sandbox-quickbooks.api.intuit.com"
+	}
+
+	return &QuickBooks{
+		oauth: oauthClient{
+			clientID:     clientID,
+			clientSecret: clientSecret,
+			redirectURL:  redirectURL,
+			authURL:      "https://appcenter.intuit.com/connect/oauth2",
+			tokenURL:     "https://oauth.platform.intuit.com/oauth2/v1/tokens/bearer",
+			scope:        "com.intuit.quickbooks.accounting",
+			httpClient:   &http.Client{Timeout: 30 * time.Second},
+			clock:        c,
+		},
+		apiBaseURL: apiBaseURL,
+	}, nil
+}
+
+// Name returns the provider name
+func (q *QuickBooks) Name() string { return ProviderQuickBooks }
+
+// AuthURL returns the Intuit consent URL
+func (q *QuickBooks) AuthURL(state string) string { return q.oauth.authCodeURL(state) }
+
+// ExchangeCode completes the OAuth flow. QuickBooks passes the company (realm) ID on the callback.
+func (q *QuickBooks) ExchangeCode(ctx context.Context, code, realmID string) (*Token, error) {
+	if realmID == "" {
+		return nil, fmt.Errorf("realmId is required")
+	}
+
+	token, err := q.oauth.exchange(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	token.TenantID = realmID
+	return token, nil
+}
+
+// Refresh renews the access token
+func (q *QuickBooks) Refresh(ctx context.Context, token *Token) (*Token, error) {
+	return q.oauth.refresh(ctx, token)
+}
+
+// PushExpense records the expense as a cash Purchase and returns its QuickBooks ID
+func (q *QuickBooks) PushExpense(ctx context.Context, token *Token, expense Expense) (string, error) {
+	accountID, err := q.findAccount(ctx, token, expense.AccountRef)
+	if err != nil {
+		return "", err
+	}
+
+	paymentAccount := os.Getenv("QUICKBOOKS_PAYMENT_ACCOUNT")
+	if paymentAccount == "" {
+		paymentAccount = "GigCo Payments"
+	}
+	paymentAccountID, err := q.findAccount(ctx, token, paymentAccount)
+	if err != nil {
+		return "", err
+	}
+
+	purchase := map[string]interface{}{
+		"PaymentType": "CreditCard",
+		"TxnDate":     expense.Date.Format("2006-01-02"),
+		"DocNumber":   truncate(expense.Reference, 21),
+		"PrivateNote": expense.Description,
+		"CurrencyRef": map[string]string{"value": expense.Currency},
+		"Line": []map[string]interface{}{{
+			"Amount":      expense.Amount,
+			"DetailType":  "AccountBasedExpenseLineDetail",
+			"Description": expense.Description,
+			"AccountBasedExpenseLineDetail": map[string]interface{}{
+				"AccountRef": map[string]string{"value": accountID},
+			},
+		}},
+		"AccountRef": map[string]string{"value": paymentAccountID},
+	}
+
+	var resp struct {
+		Purchase struct {
+			ID string `json:"Id"`
+		} `json:"Purchase"`
+	}
+	endpoint := fmt.Sprintf("%s/v3/company/%s/purchase?minorversion=65", q.apiBaseURL, token.TenantID)
+	if err := doJSON(ctx, q.oauth.httpClient, "POST", endpoint, q.headers(token), purchase, &resp); err != nil {
+		return "", fmt.Errorf("failed to create QuickBooks purchase: %w", err)
+	}
+
+	return resp.Purchase.ID, nil
+}
+
+// findAccount resolves an account name (or ID) to a QuickBooks account ID
+func (q *QuickBooks) findAccount(ctx context.Context, token *Token, ref string) (string, error) {
+	query := fmt.Sprintf("select Id from Account where Name = '%s' or Id = '%s'", escapeQBO(ref), escapeQBO(ref))
+	endpoint := fmt.Sprintf("%s/v3/company/%s/query?query=%s", q.apiBaseURL, token.TenantID, url.QueryEscape(query))
+
+	var resp struct {
+		QueryResponse struct {
+			Account []struct {
+				ID string `json:"Id"`
+			} `json:"Account"`
+		} `json:"QueryResponse"`
+	}
+	if err := doJSON(ctx, q.oauth.httpClient, "GET", endpoint, q.headers(token), nil, &resp); err != nil {
+		return "", fmt.Errorf("failed to look up QuickBooks account: %w", err)
+	}
+	if len(resp.QueryResponse.Account) == 0 {
+		return "", fmt.Errorf("QuickBooks account %q not found", ref)
+	}
+	return resp.QueryResponse.Account[0].ID, nil
+}
+
+// headers returns the authorization headers for API calls
+func (q *QuickBooks) headers(token *Token) map[string]string {
+	return map[string]string{"Authorization": "Bearer " + token.AccessToken}
+}
+
+// escapeQBO escapes single quotes in a QuickBooks query literal
+func escapeQBO(s string) string {
+	out := make([]rune, 0, len(s))
+	for _, r := range s {
+		if r == '\'' {
+			out = append(out, '\\')
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
+
+// truncate shortens s to at most n bytes
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
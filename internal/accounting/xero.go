@@ -0,0 +1,123 @@
+package accounting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"app/internal/clock"
+)
+
+// Xero exports expenses to Xero as spend-money bank transactions
+type Xero struct {
+	oauth      oauthClient
+	apiBaseURL string
+}
+
+// NewXeroFromEnv creates a Xero provider from environment variables, whose tokens
+// expire relative to c
+func NewXeroFromEnv(c clock.Clock) (*Xero, error) {
+	clientID := os.Getenv("XERO_CLIENT_ID")
+	clientSecret := os.Getenv("XERO_CLIENT_SECRET")
+	redirectURL := os.Getenv("XERO_REDIRECT_URL")
+	if clientID == "" || clientSecret == "" || redirectURL == "" {
+		return nil, ErrNotConfigured
+	}
+
+	return &Xero{
+		oauth: oauthClient{
+			clientID:     clientID,
+			clientSecret: clientSecret,
+			redirectURL:  redirectURL,
+			authURL:      "https://login.xero.com/identity/connect/authorize",
+			tokenURL:     "https://identity.xero.com/connect/token",
+			scope:        "offline_access accounting.transactions accounting.settings.read",
+			httpClient:   &http.Client{Timeout: 30 * time.Second},
+			clock:        c,
+		},
+		apiBaseURL: "https://api.xero.com",
+	}, nil
+}
+
+// Name returns the provider name
+func (x *Xero) Name() string { return ProviderXero }
+
+// AuthURL returns the Xero consent URL
+func (x *Xero) AuthURL(state string) string { return x.oauth.authCodeURL(state) }
+
+// ExchangeCode completes the OAuth flow and resolves the connected organisation.
+// Xero does not pass the tenant on the callback, so the first connection is used.
+func (x *Xero) ExchangeCode(ctx context.Context, code, _ string) (*Token, error) {
+	token, err := x.oauth.exchange(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	var connections []struct {
+		TenantID   string `json:"tenantId"`
+		TenantType string `json:"tenantType"`
+	}
+	headers := map[string]string{"Authorization": "Bearer " + token.AccessToken}
+	if err := doJSON(ctx, x.oauth.httpClient, "GET", x.apiBaseURL+"/connections", headers, nil, &connections); err != nil {
+		return nil, fmt.Errorf("failed to list Xero connections: %w", err)
+	}
+	for _, c := range connections {
+		if c.TenantType == "ORGANISATION" {
+			token.TenantID = c.TenantID
+			return token, nil
+		}
+	}
+	return nil, fmt.Errorf("no Xero organisation was authorized")
+}
+
+// Refresh renews the access token
+func (x *Xero) Refresh(ctx context.Context, token *Token) (*Token, error) {
+	return x.oauth.refresh(ctx, token)
+}
+
+// PushExpense records the expense as a SPEND bank transaction and returns its Xero ID.
+// AccountRef is the Xero account code for the expense line.
+func (x *Xero) PushExpense(ctx context.Context, token *Token, expense Expense) (string, error) {
+	bankAccount := os.Getenv("XERO_BANK_ACCOUNT_CODE")
+	if bankAccount == "" {
+		bankAccount = "090"
+	}
+
+	payload := map[string]interface{}{
+		"BankTransactions": []map[string]interface{}{{
+			"Type":            "SPEND",
+			"Contact":         map[string]string{"Name": expense.Vendor},
+			"Date":            expense.Date.Format("2006-01-02"),
+			"Reference":       expense.Reference,
+			"CurrencyCode":    expense.Currency,
+			"LineAmountTypes": "Inclusive",
+			"BankAccount":     map[string]string{"Code": bankAccount},
+			"LineItems": []map[string]interface{}{{
+				"Description": expense.Description,
+				"Quantity":    1,
+				"UnitAmount":  expense.Amount,
+				"AccountCode": expense.AccountRef,
+			}},
+		}},
+	}
+
+	var resp struct {
+		BankTransactions []struct {
+			BankTransactionID string `json:"BankTransactionID"`
+		} `json:"BankTransactions"`
+	}
+	headers := map[string]string{
+		"Authorization":  "Bearer " + token.AccessToken,
+		"Xero-tenant-id": token.TenantID,
+	}
+	if err := doJSON(ctx, x.oauth.httpClient, "PUT", x.apiBaseURL+"/api.xro/2.0/BankTransactions", headers, payload, &resp); err != nil {
+		return "", fmt.Errorf("failed to create Xero bank transaction: %w", err)
+	}
+	if len(resp.BankTransactions) == 0 {
+		return "", fmt.Errorf("Xero returned no bank transaction")
+	}
+
+	return resp.BankTransactions[0].BankTransactionID, nil
+}
@@ -0,0 +1,101 @@
+// Package analytics records job funnel events. Each job emits one event per lifecycle
+// stage into job_funnel_events with the time spent in the previous stage, which the
+// funnel report and time-in-stage dashboards read.
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// Funnel stages, in lifecycle order
+const (
+	StagePosted    = "posted"
+	StagePriced    = "priced"
+	StageOfferSent = "offer_sent"
+	StageAccepted  = "accepted"
+	StageScheduled = "scheduled"
+	StageStarted   = "started"
+	StageCompleted = "completed"
+	StagePaid      = "paid"
+	StageReviewed  = "reviewed"
+	StageClosed    = "closed"
+)
+
+// Event sources
+const (
+	SourceAPI      = "api"
+	SourceWorkflow = "workflow"
+)
+
+// Stages lists the funnel stages in lifecycle order
+func Stages() []string {
+	return []string{
+		StagePosted, StagePriced, StageOfferSent, StageAccepted, StageScheduled,
+		StageStarted, StageCompleted, StagePaid, StageReviewed, StageClosed,
+	}
+}
+
+// stageOrder returns a stage's position in the funnel, or -1 for unknown stages
+func stageOrder(stage string) int {
+	for i, s := range Stages() {
+		if s == stage {
+			return i
+		}
+	}
+	return -1
+}
+
+// FunnelEvent is a job reaching a funnel stage
+type FunnelEvent struct {
+	JobID      int
+	Stage      string
+	Source     string
+	ActorID    *int // User whose action caused the event, if any
+	Properties map[string]interface{}
+}
+
+// Track records a funnel event. Only the first event per job and stage is kept, so
+// activity retries and repeated requests do not skew durations. Durations are
+// measured from the job's most recent event and from when it was posted.
+func Track(ctx context.Context, db *sql.DB, e FunnelEvent) error {
+	order := stageOrder(e.Stage)
+	if order < 0 {
+		return fmt.Errorf("unknown funnel stage %q", e.Stage)
+	}
+
+	var properties []byte
+	if len(e.Properties) > 0 {
+		var err error
+		if properties, err = json.Marshal(e.Properties); err != nil {
+			return fmt.Errorf("failed to marshal funnel event properties: %w", err)
+		}
+	}
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO job_funnel_events (
+			job_id, stage, stage_order, source, actor_id, properties,
+			previous_stage, seconds_in_previous_stage, seconds_since_posted
+		)
+		SELECT j.id, $2, $3, $4, $5, $6,
+		       prev.stage,
+		       EXTRACT(EPOCH FROM NOW() - prev.occurred_at),
+		       EXTRACT(EPOCH FROM NOW() - COALESCE(posted.occurred_at, j.created_at))
+		FROM jobs j
+		LEFT JOIN LATERAL (
+			SELECT stage, occurred_at FROM job_funnel_events
+			WHERE job_id = j.id
+			ORDER BY occurred_at DESC, id DESC
+			LIMIT 1
+		) prev ON true
+		LEFT JOIN job_funnel_events posted ON posted.job_id = j.id AND posted.stage = 'posted'
+		WHERE j.id = $1
+		ON CONFLICT (job_id, stage) DO NOTHING
+	`, e.JobID, e.Stage, order, e.Source, e.ActorID, properties)
+	if err != nil {
+		return fmt.Errorf("failed to record %s funnel event for job %d: %w", e.Stage, e.JobID, err)
+	}
+	return nil
+}
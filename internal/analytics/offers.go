@@ -0,0 +1,118 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"app/internal/model"
+)
+
+// unspecifiedReason counts declines the worker gave no reason for
+const unspecifiedReason = "unspecified"
+
+// offerAnswer is how one job offer ended and where it belongs
+type offerAnswer struct {
+	status   string
+	reason   string
+	snoozed  bool
+	amount   sql.NullFloat64
+	category string
+}
+
+// OfferDeclineReport aggregates the job offers sent in [from, to) by how workers
+// answered them. Offers still open count towards offers only.
+func OfferDeclineReport(ctx context.Context, db *sql.DB, from, to time.Time) (*model.OfferDeclineReport, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT o.status, COALESCE(o.decline_reason, ''), o.snooze_count > 0, o.amount, j.category
+		FROM job_offers o
+		JOIN jobs j ON j.id = o.job_id
+		WHERE o.created_at >= $1 AND o.created_at < $2
+	`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query job offers: %w", err)
+	}
+	defer rows.Close()
+
+	var answers []offerAnswer
+	for rows.Next() {
+		a := offerAnswer{category: unknownCategory}
+		var category sql.NullString
+		if err := rows.Scan(&a.status, &a.reason, &a.snoozed, &a.amount, &category); err != nil {
+			return nil, fmt.Errorf("failed to scan job offer: %w", err)
+		}
+		if category.Valid && category.String != "" {
+			a.category = category.String
+		}
+		answers = append(answers, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read job offers: %w", err)
+	}
+
+	report := &model.OfferDeclineReport{From: from, To: to, Overall: summarizeOffers(answers)}
+	groups := map[string][]offerAnswer{}
+	for _, a := range answers {
+		groups[a.category] = append(groups[a.category], a)
+	}
+	report.ByCategory = make([]model.OfferOutcomeSegment, 0, len(groups))
+	for segment, group := range groups {
+		report.ByCategory = append(report.ByCategory, model.OfferOutcomeSegment{Segment: segment, OfferOutcomes: summarizeOffers(group)})
+	}
+	sort.Slice(report.ByCategory, func(i, j int) bool {
+		if report.ByCategory[i].Offers != report.ByCategory[j].Offers {
+			return report.ByCategory[i].Offers > report.ByCategory[j].Offers
+		}
+		return report.ByCategory[i].Segment < report.ByCategory[j].Segment
+	})
+	return report, nil
+}
+
+// summarizeOffers counts a set of offers by outcome and averages their amounts
+func summarizeOffers(answers []offerAnswer) model.OfferOutcomes {
+	o := model.OfferOutcomes{DeclineReasons: map[string]int{}}
+	var acceptedTotal, payTooLowTotal float64
+	var acceptedAmounts, payTooLowAmounts int
+	for _, a := range answers {
+		o.Offers++
+		if a.snoozed {
+			o.Snoozed++
+		}
+		switch a.status {
+		case model.JobOfferAccepted:
+			o.Accepted++
+			if a.amount.Valid {
+				acceptedTotal += a.amount.Float64
+				acceptedAmounts++
+			}
+		case model.JobOfferDeclined:
+			o.Declined++
+			reason := a.reason
+			if reason == "" {
+				reason = unspecifiedReason
+			}
+			o.DeclineReasons[reason]++
+			if reason == model.OfferDeclinePayTooLow && a.amount.Valid {
+				payTooLowTotal += a.amount.Float64
+				payTooLowAmounts++
+			}
+		case model.JobOfferExpired:
+			o.Expired++
+		}
+	}
+
+	if answered := o.Accepted + o.Declined + o.Expired; answered > 0 {
+		o.AcceptRate = roundTo(float64(o.Accepted)/float64(answered), 4)
+	}
+	if acceptedAmounts > 0 {
+		avg := roundTo(acceptedTotal/float64(acceptedAmounts), 2)
+		o.AvgAcceptedAmount = &avg
+	}
+	if payTooLowAmounts > 0 {
+		avg := roundTo(payTooLowTotal/float64(payTooLowAmounts), 2)
+		o.AvgPayTooLowAmount = &avg
+	}
+	return o
+}
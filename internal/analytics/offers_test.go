@@ -0,0 +1,63 @@
+package analytics
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"app/internal/model"
+)
+
+func TestSummarizeOffers(t *testing.T) {
+	amount := func(v float64) sql.NullFloat64 { return sql.NullFloat64{Float64: v, Valid: true} }
+	avg := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name    string
+		answers []offerAnswer
+		want    model.OfferOutcomes
+	}{
+		{
+			name: "no offers",
+			want: model.OfferOutcomes{DeclineReasons: map[string]int{}},
+		},
+		{
+			name: "declines counted by reason",
+			answers: []offerAnswer{
+				{status: model.JobOfferDeclined, reason: model.OfferDeclineTooFar},
+				{status: model.JobOfferDeclined, reason: model.OfferDeclineTooFar, snoozed: true},
+				{status: model.JobOfferDeclined},
+				{status: model.JobOfferExpired},
+				{status: model.JobOfferPending},
+			},
+			want: model.OfferOutcomes{
+				Offers: 5, Declined: 3, Expired: 1, Snoozed: 1,
+				DeclineReasons: map[string]int{model.OfferDeclineTooFar: 2, unspecifiedReason: 1},
+			},
+		},
+		{
+			name: "pay too low compared with accepted pay",
+			answers: []offerAnswer{
+				{status: model.JobOfferAccepted, amount: amount(120)},
+				{status: model.JobOfferAccepted, amount: amount(100)},
+				{status: model.JobOfferDeclined, reason: model.OfferDeclinePayTooLow, amount: amount(60)},
+				{status: model.JobOfferDeclined, reason: model.OfferDeclinePayTooLow, amount: amount(70)},
+				{status: model.JobOfferCancelled, amount: amount(90)},
+			},
+			want: model.OfferOutcomes{
+				Offers: 5, Accepted: 2, Declined: 2, AcceptRate: 0.5,
+				DeclineReasons:     map[string]int{model.OfferDeclinePayTooLow: 2},
+				AvgAcceptedAmount:  avg(110),
+				AvgPayTooLowAmount: avg(65),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summarizeOffers(tt.answers); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("summarizeOffers() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
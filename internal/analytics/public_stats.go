@@ -0,0 +1,59 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"app/internal/model"
+)
+
+// MinPublicRatings is how many public worker ratings there must be before an average
+// is published
+const MinPublicRatings = 10
+
+// PublicStats computes the marketplace aggregates for the calendar month (UTC) that
+// contains now
+func PublicStats(ctx context.Context, db *sql.DB, now time.Time) (*model.PublicStats, error) {
+	now = now.UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	stats := &model.PublicStats{
+		Month:       monthStart.Format("2006-01"),
+		GeneratedAt: now,
+	}
+
+	// A job counts in the month its work ended
+	err := db.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM jobs
+		WHERE status IN ('completed', 'paid', 'review_pending', 'closed')
+		  AND COALESCE(actual_end, worker_completed_at, updated_at) >= $1
+		  AND COALESCE(actual_end, worker_completed_at, updated_at) < $2
+	`, monthStart, monthStart.AddDate(0, 1, 0)).Scan(&stats.JobsCompletedThisMonth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count completed jobs: %w", err)
+	}
+
+	var average sql.NullFloat64
+	err = db.QueryRowContext(ctx, `
+		SELECT COUNT(*), AVG(r.rating::numeric)
+		FROM job_reviews r
+		JOIN jobs j ON j.id = r.job_id
+		WHERE r.is_public = true AND r.reviewee_id = j.gig_worker_id
+	`).Scan(&stats.RatingCount, &average)
+	if err != nil {
+		return nil, fmt.Errorf("failed to average ratings: %w", err)
+	}
+	if average.Valid && stats.RatingCount >= MinPublicRatings {
+		rating := roundTo(average.Float64, 1)
+		stats.AverageRating = &rating
+	}
+
+	err = db.QueryRowContext(ctx, `SELECT COUNT(*) FROM markets WHERE is_live = true`).Scan(&stats.ActiveMarkets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count markets: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,87 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"app/internal/model"
+)
+
+// stageStats is one stage's aggregate row
+type stageStats struct {
+	jobs              int
+	medianInStage     *float64
+	p90InStage        *float64
+	medianSincePosted *float64
+}
+
+// Report builds the funnel for jobs posted in [from, to)
+func Report(ctx context.Context, db *sql.DB, from, to time.Time) (*model.FunnelReport, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT e.stage, COUNT(*),
+		       percentile_cont(0.5) WITHIN GROUP (ORDER BY e.seconds_in_previous_stage),
+		       percentile_cont(0.9) WITHIN GROUP (ORDER BY e.seconds_in_previous_stage),
+		       percentile_cont(0.5) WITHIN GROUP (ORDER BY e.seconds_since_posted)
+		FROM job_funnel_events e
+		JOIN jobs j ON j.id = e.job_id
+		WHERE j.created_at >= $1 AND j.created_at < $2
+		GROUP BY e.stage
+	`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query funnel events: %w", err)
+	}
+	defer rows.Close()
+
+	stats := map[string]stageStats{}
+	for rows.Next() {
+		var stage string
+		var s stageStats
+		var medianInStage, p90InStage, medianSincePosted sql.NullFloat64
+		if err := rows.Scan(&stage, &s.jobs, &medianInStage, &p90InStage, &medianSincePosted); err != nil {
+			return nil, fmt.Errorf("failed to scan funnel stage: %w", err)
+		}
+		s.medianInStage = roundedPtr(medianInStage)
+		s.p90InStage = roundedPtr(p90InStage)
+		s.medianSincePosted = roundedPtr(medianSincePosted)
+		stats[stage] = s
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read funnel stages: %w", err)
+	}
+
+	return &model.FunnelReport{From: from, To: to, Stages: buildFunnel(stats)}, nil
+}
+
+// buildFunnel orders stage stats by lifecycle, including stages no job reached, and
+// computes conversion against the posted stage
+func buildFunnel(stats map[string]stageStats) []model.FunnelStage {
+	posted := stats[StagePosted].jobs
+
+	stages := make([]model.FunnelStage, 0, len(Stages()))
+	for _, stage := range Stages() {
+		s := stats[stage]
+		fs := model.FunnelStage{
+			Stage:                  stage,
+			Jobs:                   s.jobs,
+			MedianSecondsInStage:   s.medianInStage,
+			P90SecondsInStage:      s.p90InStage,
+			MedianSecondsSincePost: s.medianSincePosted,
+		}
+		if posted > 0 {
+			fs.ConversionRate = math.Round(float64(s.jobs)/float64(posted)*10000) / 10000
+		}
+		stages = append(stages, fs)
+	}
+	return stages
+}
+
+func roundedPtr(v sql.NullFloat64) *float64 {
+	if !v.Valid {
+		return nil
+	}
+	rounded := math.Round(v.Float64)
+	return &rounded
+}
@@ -0,0 +1,67 @@
+package analytics
+
+import (
+	"reflect"
+	"testing"
+
+	"app/internal/model"
+)
+
+func TestBuildFunnel(t *testing.T) {
+	seconds := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name  string
+		stats map[string]stageStats
+		want  map[string]model.FunnelStage
+	}{
+		{
+			name:  "no events",
+			stats: map[string]stageStats{},
+			want: map[string]model.FunnelStage{
+				StagePosted: {Stage: StagePosted},
+				StageClosed: {Stage: StageClosed},
+			},
+		},
+		{
+			name: "conversion from posted",
+			stats: map[string]stageStats{
+				StagePosted:   {jobs: 3, medianSincePosted: seconds(0)},
+				StageAccepted: {jobs: 2, medianInStage: seconds(60), p90InStage: seconds(300), medianSincePosted: seconds(90)},
+				StageClosed:   {jobs: 1},
+			},
+			want: map[string]model.FunnelStage{
+				StagePosted:   {Stage: StagePosted, Jobs: 3, ConversionRate: 1, MedianSecondsSincePost: seconds(0)},
+				StagePriced:   {Stage: StagePriced},
+				StageAccepted: {Stage: StageAccepted, Jobs: 2, ConversionRate: 0.6667, MedianSecondsInStage: seconds(60), P90SecondsInStage: seconds(300), MedianSecondsSincePost: seconds(90)},
+				StageClosed:   {Stage: StageClosed, Jobs: 1, ConversionRate: 0.3333},
+			},
+		},
+		{
+			name: "later stages without posted",
+			stats: map[string]stageStats{
+				StagePaid: {jobs: 2},
+			},
+			want: map[string]model.FunnelStage{
+				StagePaid: {Stage: StagePaid, Jobs: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildFunnel(tt.stats)
+			if len(got) != len(Stages()) {
+				t.Fatalf("got %d stages, want %d", len(got), len(Stages()))
+			}
+			for i, stage := range got {
+				if stage.Stage != Stages()[i] {
+					t.Errorf("stage %d = %s, want %s", i, stage.Stage, Stages()[i])
+				}
+				if want, ok := tt.want[stage.Stage]; ok && !reflect.DeepEqual(stage, want) {
+					t.Errorf("%s = %+v, want %+v", stage.Stage, stage, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"app/internal/model"
+)
+
+// Survey types
+const (
+	SurveyCSAT = "csat"
+	SurveyNPS  = "nps"
+)
+
+// SurveyResponseWindow is how long after a job closes its survey can be answered
+const SurveyResponseWindow = 30 * 24 * time.Hour
+
+// Segments for answers that cannot be attributed
+const (
+	unknownCategory = "uncategorized"
+	unknownMarket   = "unassigned"
+)
+
+// SurveyTypeFromEnv returns the survey sent when a job closes, set by JOB_SURVEY (csat,
+// nps or off). It defaults to csat; off sends none.
+func SurveyTypeFromEnv() string {
+	switch strings.ToLower(os.Getenv("JOB_SURVEY")) {
+	case SurveyNPS:
+		return SurveyNPS
+	case "off":
+		return ""
+	default:
+		return SurveyCSAT
+	}
+}
+
+// SurveyQuestion returns the question a survey type asks and its score range
+func SurveyQuestion(surveyType string) (question string, minScore, maxScore int) {
+	if surveyType == SurveyNPS {
+		return "How likely are you to recommend GigCo to a friend?", 0, 10
+	}
+	return "How satisfied were you with this job?", 1, 5
+}
+
+// surveyAnswer is one answered survey and where it belongs
+type surveyAnswer struct {
+	surveyType string
+	score      int
+	category   string
+	market     string
+}
+
+// SurveyReport aggregates the surveys sent in [from, to). Jobs are matched to a market
+// by the city in their address.
+func SurveyReport(ctx context.Context, db *sql.DB, from, to time.Time) (*model.SurveyReport, error) {
+	report := &model.SurveyReport{From: from, To: to}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT s.survey_type, s.score, j.category, m.name
+		FROM job_surveys s
+		JOIN jobs j ON j.id = s.job_id
+		LEFT JOIN LATERAL (
+			SELECT name FROM markets
+			WHERE city IS NOT NULL AND j.location_address ILIKE '%' || city || '%'
+			ORDER BY is_live DESC, id
+			LIMIT 1
+		) m ON true
+		WHERE s.sent_at >= $1 AND s.sent_at < $2
+	`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query surveys: %w", err)
+	}
+	defer rows.Close()
+
+	var answers []surveyAnswer
+	for rows.Next() {
+		var surveyType string
+		var score sql.NullInt64
+		var category, market sql.NullString
+		if err := rows.Scan(&surveyType, &score, &category, &market); err != nil {
+			return nil, fmt.Errorf("failed to scan survey: %w", err)
+		}
+		report.Sent++
+		if !score.Valid {
+			continue
+		}
+		a := surveyAnswer{surveyType: surveyType, score: int(score.Int64), category: unknownCategory, market: unknownMarket}
+		if category.Valid && category.String != "" {
+			a.category = category.String
+		}
+		if market.Valid {
+			a.market = market.String
+		}
+		answers = append(answers, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read surveys: %w", err)
+	}
+
+	report.Overall = scoreSurveys(answers)
+	if report.Sent > 0 {
+		report.ResponseRate = math.Round(float64(len(answers))/float64(report.Sent)*10000) / 10000
+	}
+	report.ByCategory = segmentSurveys(answers, func(a surveyAnswer) string { return a.category })
+	report.ByMarket = segmentSurveys(answers, func(a surveyAnswer) string { return a.market })
+	return report, nil
+}
+
+// scoreSurveys computes CSAT and NPS over a set of answers
+func scoreSurveys(answers []surveyAnswer) model.SurveyScores {
+	var s model.SurveyScores
+	var satisfied, csatTotal int
+	for _, a := range answers {
+		s.Responses++
+		switch a.surveyType {
+		case SurveyCSAT:
+			s.CSATResponses++
+			csatTotal += a.score
+			if a.score >= 4 {
+				satisfied++
+			}
+		case SurveyNPS:
+			s.NPSResponses++
+			if a.score >= 9 {
+				s.Promoters++
+			} else if a.score <= 6 {
+				s.Detractors++
+			}
+		}
+	}
+
+	if s.CSATResponses > 0 {
+		csat := roundTo(float64(satisfied)/float64(s.CSATResponses)*100, 1)
+		average := roundTo(float64(csatTotal)/float64(s.CSATResponses), 2)
+		s.CSAT, s.AverageCSAT = &csat, &average
+	}
+	if s.NPSResponses > 0 {
+		nps := roundTo(float64(s.Promoters-s.Detractors)/float64(s.NPSResponses)*100, 1)
+		s.NPS = &nps
+	}
+	return s
+}
+
+// segmentSurveys scores answers grouped by segment, most answered first
+func segmentSurveys(answers []surveyAnswer, segmentOf func(surveyAnswer) string) []model.SurveySegment {
+	groups := map[string][]surveyAnswer{}
+	for _, a := range answers {
+		groups[segmentOf(a)] = append(groups[segmentOf(a)], a)
+	}
+
+	segments := make([]model.SurveySegment, 0, len(groups))
+	for segment, group := range groups {
+		segments = append(segments, model.SurveySegment{Segment: segment, SurveyScores: scoreSurveys(group)})
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		if segments[i].Responses != segments[j].Responses {
+			return segments[i].Responses > segments[j].Responses
+		}
+		return segments[i].Segment < segments[j].Segment
+	})
+	return segments
+}
+
+func roundTo(v float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(v*scale) / scale
+}
@@ -0,0 +1,83 @@
+package analytics
+
+import (
+	"reflect"
+	"testing"
+
+	"app/internal/model"
+)
+
+func TestScoreSurveys(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name    string
+		answers []surveyAnswer
+		want    model.SurveyScores
+	}{
+		{
+			name: "no answers",
+			want: model.SurveyScores{},
+		},
+		{
+			name: "csat counts 4 and 5 as satisfied",
+			answers: []surveyAnswer{
+				{surveyType: SurveyCSAT, score: 5},
+				{surveyType: SurveyCSAT, score: 4},
+				{surveyType: SurveyCSAT, score: 3},
+				{surveyType: SurveyCSAT, score: 1},
+			},
+			want: model.SurveyScores{Responses: 4, CSATResponses: 4, CSAT: score(50), AverageCSAT: score(3.25)},
+		},
+		{
+			name: "nps is promoters minus detractors",
+			answers: []surveyAnswer{
+				{surveyType: SurveyNPS, score: 10},
+				{surveyType: SurveyNPS, score: 9},
+				{surveyType: SurveyNPS, score: 8},
+				{surveyType: SurveyNPS, score: 6},
+				{surveyType: SurveyNPS, score: 0},
+				{surveyType: SurveyNPS, score: 9},
+			},
+			want: model.SurveyScores{Responses: 6, NPSResponses: 6, NPS: score(16.7), Promoters: 3, Detractors: 2},
+		},
+		{
+			name: "mixed survey types are scored separately",
+			answers: []surveyAnswer{
+				{surveyType: SurveyCSAT, score: 5},
+				{surveyType: SurveyNPS, score: 3},
+			},
+			want: model.SurveyScores{Responses: 2, CSATResponses: 1, CSAT: score(100), AverageCSAT: score(5), NPSResponses: 1, NPS: score(-100), Detractors: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scoreSurveys(tt.answers); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("scoreSurveys() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSegmentSurveys(t *testing.T) {
+	answers := []surveyAnswer{
+		{surveyType: SurveyCSAT, score: 5, category: "cleaning"},
+		{surveyType: SurveyCSAT, score: 2, category: "delivery"},
+		{surveyType: SurveyCSAT, score: 4, category: "delivery"},
+		{surveyType: SurveyCSAT, score: 5, category: "tutoring"},
+	}
+
+	segments := segmentSurveys(answers, func(a surveyAnswer) string { return a.category })
+
+	var order []string
+	for _, s := range segments {
+		order = append(order, s.Segment)
+	}
+	if want := []string{"delivery", "cleaning", "tutoring"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("segment order = %v, want %v", order, want)
+	}
+	if got := *segments[0].CSAT; got != 50 {
+		t.Errorf("delivery CSAT = %v, want 50", got)
+	}
+}
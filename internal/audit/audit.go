@@ -0,0 +1,410 @@
+// Package audit is the append-only record of who changed what. Handlers record an
+// event for job status changes, payments, refunds and profile edits with the fields
+// that changed; admin requests that don't record their own event are logged by
+// middleware with the route and response status.
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Actions
+const (
+	ActionJobStatusChanged         = "job.status_changed"
+	ActionPaymentAuthorized        = "payment.authorized"
+	ActionPaymentCaptured          = "payment.captured"
+	ActionPaymentAutoCaptured      = "payment.auto_captured" // The consumer neither released nor disputed the payment in time
+	ActionPaymentRefunded          = "payment.refunded"
+	ActionPaymentTipped            = "payment.tipped"
+	ActionProfileUpdated           = "profile.updated"
+	ActionAccountDeletionRequested = "account.deletion_requested" // The user confirmed erasure with their password
+	ActionAccountReactivated       = "account.reactivated"
+	ActionAccountDataExported      = "account.data_exported" // Archive of the user's data stored and emailed to them
+	ActionAccountErased            = "account.erased"
+	ActionAdminRequest             = "admin.request" // Admin request without a more specific event
+)
+
+// Entity types
+const (
+	EntityJob         = "job"
+	EntityTransaction = "transaction"
+	EntityUser        = "user"
+	EntityRoute       = "route" // Admin requests; the entity id is the route's {id}, if any
+)
+
+// ignoredFields change on every write and are left out of diffs
+var ignoredFields = map[string]bool{"updated_at": true}
+
+// Change is a field's value before and after an operation
+type Change struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// Entry is an operation to record
+type Entry struct {
+	ActorID    *int
+	ActorRole  string
+	Action     string
+	EntityType string
+	EntityID   string
+	Method     string
+	Route      string // Route pattern, e.g. /api/v1/jobs/{id}
+	StatusCode int    // Response status, for events recorded after the response
+	IP         string
+	Changes    map[string]Change
+	Metadata   map[string]interface{}
+}
+
+// Event is a recorded entry
+type Event struct {
+	ID         int64                  `json:"id"`
+	ActorID    *int                   `json:"actor_id"`
+	ActorRole  *string                `json:"actor_role"`
+	Action     string                 `json:"action"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   *string                `json:"entity_id"`
+	Method     *string                `json:"method"`
+	Route      *string                `json:"route"`
+	StatusCode *int                   `json:"status_code"`
+	IP         *string                `json:"ip_address"`
+	Changes    map[string]Change      `json:"changes"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// Execer is a *sql.DB or *sql.Tx. Recording within a transaction keeps the event only
+// if the change it describes commits.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Record appends an entry to the audit log and marks the request tracked by ctx, if
+// any, as recorded
+func Record(ctx context.Context, db Execer, e Entry) error {
+	changes := e.Changes
+	if changes == nil {
+		changes = map[string]Change{}
+	}
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit changes: %w", err)
+	}
+	var metadata interface{}
+	if len(e.Metadata) > 0 {
+		encoded, err := json.Marshal(e.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to encode audit metadata: %w", err)
+		}
+		metadata = string(encoded)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO audit_events (actor_id, actor_role, action, entity_type, entity_id,
+		                          method, route, status_code, ip_address, changes, metadata)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`, e.ActorID, nullString(e.ActorRole), e.Action, e.EntityType, nullString(e.EntityID),
+		nullString(e.Method), nullString(e.Route), nullInt(e.StatusCode), nullString(e.IP),
+		string(changesJSON), metadata)
+	if err != nil {
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+
+	if recorded, ok := ctx.Value(trackerKey{}).(*bool); ok {
+		*recorded = true
+	}
+	return nil
+}
+
+type trackerKey struct{}
+
+// Track returns a context that notes whether Record was called with it, and a func
+// reporting whether it was. Middleware uses it to skip its generic event when the
+// handler recorded a specific one.
+func Track(ctx context.Context) (context.Context, func() bool) {
+	recorded := new(bool)
+	return context.WithValue(ctx, trackerKey{}, recorded), func() bool { return *recorded }
+}
+
+// Diff compares two values by their JSON fields and returns the fields that differ.
+// Fields hidden from JSON (passwords, tokens) never appear. A nil before records every
+// field of after as new.
+func Diff(before, after interface{}) (map[string]Change, error) {
+	from, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+	to, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]bool{}
+	for k := range from {
+		keys[k] = true
+	}
+	for k := range to {
+		keys[k] = true
+	}
+	return compare(from, to, keys), nil
+}
+
+// Patch compares the fields set in patch, typically an update request whose unset
+// fields are omitted from its JSON, with their values in before
+func Patch(before, patch interface{}) (map[string]Change, error) {
+	from, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+	to, err := fields(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]bool{}
+	for k := range to {
+		keys[k] = true
+	}
+	return compare(from, to, keys), nil
+}
+
+// Redacted stands in for the values of sensitive fields
+const Redacted = "[redacted]"
+
+// Redact hides the values of the given fields in changes, keeping the record that
+// they changed
+func Redact(changes map[string]Change, names ...string) {
+	for _, name := range names {
+		if _, ok := changes[name]; ok {
+			changes[name] = Change{From: Redacted, To: Redacted}
+		}
+	}
+}
+
+func compare(from, to map[string]interface{}, keys map[string]bool) map[string]Change {
+	changes := map[string]Change{}
+	for k := range keys {
+		if ignoredFields[k] || reflect.DeepEqual(from[k], to[k]) {
+			continue
+		}
+		changes[k] = Change{From: from[k], To: to[k]}
+	}
+	return changes
+}
+
+// fields flattens v to its top-level JSON fields, so values read from the database
+// and decoded from requests compare alike
+func fields(v interface{}) (map[string]interface{}, error) {
+	if v == nil {
+		return map[string]interface{}{}, nil
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit value: %w", err)
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(encoded, &m); err != nil {
+		return nil, fmt.Errorf("audit value is not a JSON object: %w", err)
+	}
+	return m, nil
+}
+
+// Snapshot reads one row as a map of column name to value, for diffing against the
+// row after an update. It returns sql.ErrNoRows when the query matches nothing.
+func Snapshot(ctx context.Context, db *sql.DB, query string, args ...interface{}) (map[string]interface{}, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, sql.ErrNoRows
+	}
+
+	values := make([]interface{}, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	snapshot := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		if b, ok := values[i].([]byte); ok {
+			snapshot[column] = string(b)
+		} else {
+			snapshot[column] = values[i]
+		}
+	}
+	return snapshot, nil
+}
+
+// Filter selects audit events; zero fields match everything
+type Filter struct {
+	ActorID    int
+	Action     string // An exact action, or a prefix ending in "." such as "payment."
+	EntityType string
+	EntityID   string
+	From       *time.Time
+	To         *time.Time
+}
+
+// where builds the filter's WHERE clause and arguments
+func (f Filter) where() (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	add := func(cond string, value interface{}) {
+		args = append(args, value)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.ActorID != 0 {
+		add("actor_id = $%d", f.ActorID)
+	}
+	if strings.HasSuffix(f.Action, ".") {
+		add("action LIKE $%d", f.Action+"%")
+	} else if f.Action != "" {
+		add("action = $%d", f.Action)
+	}
+	if f.EntityType != "" {
+		add("entity_type = $%d", f.EntityType)
+	}
+	if f.EntityID != "" {
+		add("entity_id = $%d", f.EntityID)
+	}
+	if f.From != nil {
+		add("created_at >= $%d", *f.From)
+	}
+	if f.To != nil {
+		add("created_at <= $%d", *f.To)
+	}
+
+	if len(conds) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+// List returns the events matching f, newest first, and how many match in total
+func List(ctx context.Context, db *sql.DB, f Filter, limit, offset int) ([]Event, int, error) {
+	where, args := f.where()
+
+	var total int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM audit_events"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+
+	events := []Event{}
+	err := Each(ctx, db, f, limit, offset, func(e Event) error {
+		events = append(events, e)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// Each calls fn with the events matching f, newest first, as they are read, so an
+// export never holds them all. An error from fn stops the read and is returned.
+func Each(ctx context.Context, db *sql.DB, f Filter, limit, offset int, fn func(Event) error) error {
+	where, args := f.where()
+	query := fmt.Sprintf(`
+		SELECT id, actor_id, actor_role, action, entity_type, entity_id, method, route,
+		       status_code, ip_address, changes, metadata, created_at
+		FROM audit_events%s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+	rows, err := db.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return fmt.Errorf("failed to query audit events: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e Event
+		var actorID, statusCode sql.NullInt64
+		var actorRole, entityID, method, route, ip sql.NullString
+		var changes []byte
+		var metadata []byte
+		err := rows.Scan(&e.ID, &actorID, &actorRole, &e.Action, &e.EntityType, &entityID,
+			&method, &route, &statusCode, &ip, &changes, &metadata, &e.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		if actorID.Valid {
+			id := int(actorID.Int64)
+			e.ActorID = &id
+		}
+		if statusCode.Valid {
+			code := int(statusCode.Int64)
+			e.StatusCode = &code
+		}
+		e.ActorRole = stringPtr(actorRole)
+		e.EntityID = stringPtr(entityID)
+		e.Method = stringPtr(method)
+		e.Route = stringPtr(route)
+		e.IP = stringPtr(ip)
+		if err := json.Unmarshal(changes, &e.Changes); err != nil {
+			return fmt.Errorf("failed to decode audit changes: %w", err)
+		}
+		if len(metadata) > 0 {
+			if err := json.Unmarshal(metadata, &e.Metadata); err != nil {
+				return fmt.Errorf("failed to decode audit metadata: %w", err)
+			}
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// ChangedFields lists the fields in changes, sorted
+func ChangedFields(changes map[string]Change) []string {
+	names := make([]string, 0, len(changes))
+	for name := range changes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func nullInt(i int) interface{} {
+	if i == 0 {
+		return nil
+	}
+	return i
+}
+
+func stringPtr(ns sql.NullString) *string {
+	if !ns.Valid {
+		return nil
+	}
+	return &ns.String
+}
@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type profile struct {
+	Name     string  `json:"name"`
+	Phone    *string `json:"phone,omitempty"`
+	Password string  `json:"-"`
+	Updated  string  `json:"updated_at"`
+}
+
+func TestDiff(t *testing.T) {
+	phone := "555-0100"
+	before := profile{Name: "Ada", Password: "old", Updated: "monday"}
+	after := profile{Name: "Ada Lovelace", Phone: &phone, Password: "new", Updated: "tuesday"}
+
+	got, err := Diff(before, after)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	want := map[string]Change{
+		"name":  {From: "Ada", To: "Ada Lovelace"},
+		"phone": {From: nil, To: "555-0100"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %v, want %v", got, want)
+	}
+
+	created, err := Diff(nil, map[string]interface{}{"status": "authorized"})
+	if err != nil {
+		t.Fatalf("Diff(nil) error = %v", err)
+	}
+	if want := map[string]Change{"status": {From: nil, To: "authorized"}}; !reflect.DeepEqual(created, want) {
+		t.Errorf("Diff(nil) = %v, want %v", created, want)
+	}
+}
+
+func TestPatch(t *testing.T) {
+	type update struct {
+		Name     *string  `json:"name,omitempty"`
+		Latitude *float64 `json:"latitude,omitempty"`
+		IsActive *bool    `json:"is_active,omitempty"`
+	}
+	name := "Ada"
+	active := false
+	before := map[string]interface{}{"name": "Ada", "latitude": int64(40), "is_active": true, "phone": "555-0100"}
+
+	got, err := Patch(before, update{Name: &name, IsActive: &active})
+	if err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+	// Unchanged and unset fields are left out
+	if want := map[string]Change{"is_active": {From: true, To: false}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Patch() = %v, want %v", got, want)
+	}
+
+	Redact(got, "is_active", "not_changed")
+	if want := map[string]Change{"is_active": {From: Redacted, To: Redacted}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Redact() = %v, want %v", got, want)
+	}
+}
+
+func TestFilterWhere(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		filter    Filter
+		wantWhere string
+		wantArgs  []interface{}
+	}{
+		{"everything", Filter{}, "", nil},
+		{"exact action", Filter{Action: ActionPaymentRefunded}, " WHERE action = $1", []interface{}{"payment.refunded"}},
+		{"action prefix", Filter{Action: "payment."}, " WHERE action LIKE $1", []interface{}{"payment.%"}},
+		{
+			"entity and actor since",
+			Filter{ActorID: 3, EntityType: EntityJob, EntityID: "12", From: &from},
+			" WHERE actor_id = $1 AND entity_type = $2 AND entity_id = $3 AND created_at >= $4",
+			[]interface{}{3, "job", "12", from},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args := tt.filter.where()
+			if where != tt.wantWhere || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("where() = %q %v, want %q %v", where, args, tt.wantWhere, tt.wantArgs)
+			}
+		})
+	}
+}
+
+type execRecorder struct {
+	args []interface{}
+}
+
+func (e *execRecorder) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	e.args = args
+	return nil, nil
+}
+
+func TestRecordMarksTrackedRequest(t *testing.T) {
+	ctx, recorded := Track(context.Background())
+	if recorded() {
+		t.Fatal("recorded() = true before Record")
+	}
+
+	db := &execRecorder{}
+	actorID := 7
+	err := Record(ctx, db, Entry{ActorID: &actorID, Action: ActionAdminRequest, EntityType: EntityRoute, Method: "POST"})
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if !recorded() {
+		t.Error("recorded() = false after Record")
+	}
+	// Empty optional fields are stored as NULL and changes default to an empty object
+	if db.args[4] != nil || db.args[9] != "{}" || db.args[10] != nil {
+		t.Errorf("Record() args = %v", db.args)
+	}
+}
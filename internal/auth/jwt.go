@@ -2,18 +2,44 @@ package auth
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"math/big"
 	"os"
 	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
+
+	"app/internal/clock"
+	"app/internal/logger"
 )
 
+// TokenLifetime is how long an access token is valid; clients renew it with their
+// refresh token
+const TokenLifetime = 15 * time.Minute
+
+// RefreshTokenLifetime is how long a session lasts without being refreshed
+const RefreshTokenLifetime = 30 * 24 * time.Hour
+
+// Tokens issues and validates access and scoped tokens. Its clock is the time they are
+// issued at and checked against.
+type Tokens struct {
+	clock clock.Clock
+}
+
+// NewTokens creates Tokens that read the time from c
+func NewTokens(c clock.Clock) *Tokens {
+	return &Tokens{clock: c}
+}
+
+// systemTokens backs the package-level token functions with the wall clock
+var systemTokens = NewTokens(clock.System)
+
 var (
 	jwtSecret       []byte
 	ErrInvalidToken = errors.New("invalid token")
@@ -22,10 +48,11 @@ var (
 
 // JWTClaims represents the claims structure for JWT tokens
 type JWTClaims struct {
-	UserID int    `json:"user_id"`
-	UUID   string `json:"uuid"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+	UserID    int    `json:"user_id"`
+	UUID      string `json:"uuid"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	SessionID string `json:"sid,omitempty"` // UUID of the login session the token belongs to
 	jwt.RegisteredClaims
 }
 
@@ -36,13 +63,13 @@ func InitJWT() {
 
 	if secret == "" {
 		if env == "production" {
-			log.Fatal("FATAL: JWT_SECRET must be set in production environment")
+			logger.Fatal("JWT_SECRET must be set in production environment")
 		}
 		// Generate a random secret in development only
-		log.Println("Warning: JWT_SECRET not set, generating random secret for development")
+		slog.Warn("JWT_SECRET not set, generating random secret for development")
 		randomBytes := make([]byte, 32)
 		if _, err := rand.Read(randomBytes); err != nil {
-			log.Fatal("Failed to generate JWT secret:", err)
+			logger.Fatal("Failed to generate JWT secret", "error", err)
 		}
 		secret = hex.EncodeToString(randomBytes)
 		// Note: Secret is NOT logged for security reasons
@@ -50,36 +77,67 @@ func InitJWT() {
 
 	// Validate secret strength in production
 	if env == "production" && len(secret) < 32 {
-		log.Fatal("FATAL: JWT_SECRET must be at least 32 characters in production")
+		logger.Fatal("JWT_SECRET must be at least 32 characters in production")
 	}
 
 	jwtSecret = []byte(secret)
+
+	setEnvKey(jwtSecret)
 }
 
 // GenerateJWT creates a new JWT token for a user
 func GenerateJWT(userID int, uuid, email, role string) (string, error) {
+	return systemTokens.GenerateJWT(userID, uuid, email, role)
+}
+
+// GenerateSessionJWT creates a new JWT token for a user's login session
+func GenerateSessionJWT(userID int, uuid, email, role, sessionID string) (string, error) {
+	return systemTokens.GenerateSessionJWT(userID, uuid, email, role, sessionID)
+}
+
+// ValidateJWT validates a JWT token against the key named by its kid header and returns
+// the claims
+func ValidateJWT(tokenString string) (*JWTClaims, error) {
+	return systemTokens.ValidateJWT(tokenString)
+}
+
+// GenerateJWT creates a new JWT token for a user
+func (t *Tokens) GenerateJWT(userID int, uuid, email, role string) (string, error) {
+	return t.GenerateSessionJWT(userID, uuid, email, role, "")
+}
+
+// GenerateSessionJWT creates a new JWT token for a user's login session
+func (t *Tokens) GenerateSessionJWT(userID int, uuid, email, role, sessionID string) (string, error) {
 	if len(jwtSecret) == 0 {
 		InitJWT()
 	}
 
-	expirationTime := time.Now().Add(24 * time.Hour) // 24 hours
+	now := t.clock.Now()
+	key, err := currentSigningKey(now)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	expirationTime := now.Add(TokenLifetime)
 
 	claims := &JWTClaims{
-		UserID: userID,
-		UUID:   uuid,
-		Email:  email,
-		Role:   role,
+		UserID:    userID,
+		UUID:      uuid,
+		Email:     email,
+		Role:      role,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    "gigco-api",
 			Subject:   strconv.Itoa(userID),
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(jwtSecret)
+	token.Header["kid"] = key.ID
+	tokenString, err := token.SignedString(key.secret)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -87,8 +145,9 @@ func GenerateJWT(userID int, uuid, email, role string) (string, error) {
 	return tokenString, nil
 }
 
-// ValidateJWT validates a JWT token and returns the claims
-func ValidateJWT(tokenString string) (*JWTClaims, error) {
+// ValidateJWT validates a JWT token against the key named by its kid header and returns
+// the claims
+func (t *Tokens) ValidateJWT(tokenString string) (*JWTClaims, error) {
 	if len(jwtSecret) == 0 {
 		InitJWT()
 	}
@@ -97,8 +156,9 @@ func ValidateJWT(tokenString string) (*JWTClaims, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return jwtSecret, nil
-	})
+		kid, _ := token.Header["kid"].(string)
+		return verificationKey(kid, t.clock.Now())
+	}, jwt.WithTimeFunc(t.clock.Now))
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -115,22 +175,6 @@ func ValidateJWT(tokenString string) (*JWTClaims, error) {
 	return claims, nil
 }
 
-// RefreshJWT creates a new token from an existing valid token
-func RefreshJWT(tokenString string) (string, error) {
-	claims, err := ValidateJWT(tokenString)
-	if err != nil {
-		return "", err
-	}
-
-	// Check if token is close to expiry (refresh if less than 1 hour remaining)
-	if time.Until(claims.ExpiresAt.Time) > time.Hour {
-		return tokenString, nil // Token is still fresh, return original
-	}
-
-	// Generate new token with same claims but updated expiry
-	return GenerateJWT(claims.UserID, claims.UUID, claims.Email, claims.Role)
-}
-
 // HashPassword hashes a password using bcrypt
 func HashPassword(password string) (string, error) {
 	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -155,6 +199,22 @@ func GenerateResetToken() (string, error) {
 	return hex.EncodeToString(bytes), nil
 }
 
+// GenerateRefreshToken generates a secure random refresh token
+func GenerateRefreshToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// HashToken returns the hex SHA-256 of an emailed or refresh token. Only the hash is stored, so
+// tokens cannot be recovered from the database.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 // GenerateVerificationToken generates a secure random token for email verification
 func GenerateVerificationToken() (string, error) {
 	bytes := make([]byte, 32)
@@ -163,3 +223,23 @@ func GenerateVerificationToken() (string, error) {
 	}
 	return hex.EncodeToString(bytes), nil
 }
+
+// GenerateOTP generates a random numeric code of the given number of digits, for codes
+// users type in such as phone verification
+func GenerateOTP(digits int) (string, error) {
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate code: %w", err)
+	}
+	return fmt.Sprintf("%0*d", digits, n), nil
+}
+
+// GenerateOAuthState generates a random state value for third-party OAuth flows
+func GenerateOAuthState() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate OAuth state: %w", err)
+	}
+	return hex.EncodeToString(bytes), nil
+}
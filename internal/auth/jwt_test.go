@@ -2,8 +2,11 @@ package auth
 
 import (
 	"os"
+	"strings"
 	"testing"
 	"time"
+
+	"app/internal/clock"
 )
 
 func TestInitJWT(t *testing.T) {
@@ -257,6 +260,20 @@ func TestGenerateResetToken(t *testing.T) {
 	}
 }
 
+func TestHashToken(t *testing.T) {
+	token, _ := GenerateResetToken()
+	hash := HashToken(token)
+	if len(hash) != 64 || hash == token {
+		t.Errorf("HashToken() = %q, want a 64 character hash distinct from the token", hash)
+	}
+	if HashToken(token) != hash {
+		t.Error("HashToken() is not deterministic")
+	}
+	if other, _ := GenerateResetToken(); HashToken(other) == hash {
+		t.Error("HashToken() collided for different tokens")
+	}
+}
+
 func TestGenerateVerificationToken(t *testing.T) {
 	token1, err := GenerateVerificationToken()
 	if err != nil {
@@ -275,37 +292,50 @@ func TestGenerateVerificationToken(t *testing.T) {
 	}
 }
 
-func TestRefreshJWT(t *testing.T) {
+func TestGenerateOTP(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		code, err := GenerateOTP(6)
+		if err != nil {
+			t.Fatalf("GenerateOTP() error = %v", err)
+		}
+		if len(code) != 6 || strings.Trim(code, "0123456789") != "" {
+			t.Fatalf("GenerateOTP(6) = %q, want 6 digits", code)
+		}
+	}
+}
+
+func TestGenerateSessionJWT(t *testing.T) {
 	os.Setenv("JWT_SECRET", "test-secret-key-for-testing-purposes-only")
 	os.Setenv("APP_ENV", "test")
 	jwtSecret = nil
 	InitJWT()
 
-	// Generate a fresh token
-	token, err := GenerateJWT(1, "test-uuid", "test@example.com", "consumer")
-	if err != nil {
-		t.Fatalf("GenerateJWT() error = %v", err)
-	}
-
-	// Refresh should return same token since it's still fresh
-	refreshed, err := RefreshJWT(token)
-	if err != nil {
-		t.Errorf("RefreshJWT() error = %v", err)
-		return
-	}
-
-	if refreshed != token {
-		t.Log("Note: Token was refreshed even though still fresh")
+	tests := []struct {
+		name      string
+		sessionID string
+	}{
+		{name: "session token", sessionID: "6f1d0c6e-3b7a-4c38-9a51-2f7d8e4b1c90"},
+		{name: "token without a session", sessionID: ""},
 	}
 
-	// Validate the refreshed token
-	claims, err := ValidateJWT(refreshed)
-	if err != nil {
-		t.Errorf("ValidateJWT() on refreshed token error = %v", err)
-	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := GenerateSessionJWT(1, "test-uuid", "test@example.com", "consumer", tt.sessionID)
+			if err != nil {
+				t.Fatalf("GenerateSessionJWT() error = %v", err)
+			}
 
-	if claims.Email != "test@example.com" {
-		t.Errorf("Refreshed token email = %v, want %v", claims.Email, "test@example.com")
+			claims, err := ValidateJWT(token)
+			if err != nil {
+				t.Fatalf("ValidateJWT() error = %v", err)
+			}
+			if claims.SessionID != tt.sessionID {
+				t.Errorf("claims.SessionID = %q, want %q", claims.SessionID, tt.sessionID)
+			}
+			if lifetime := claims.ExpiresAt.Sub(claims.IssuedAt.Time); lifetime != TokenLifetime {
+				t.Errorf("token lifetime = %v, want %v", lifetime, TokenLifetime)
+			}
+		})
 	}
 }
 
@@ -344,8 +374,38 @@ func BenchmarkHashPassword(b *testing.B) {
 	}
 }
 
-// Helper to simulate time passing (for testing token expiration)
-func init() {
-	// Ensure tests run with consistent time
-	_ = time.Now()
+func TestJWTExpiry(t *testing.T) {
+	os.Setenv("JWT_SECRET", "test-secret-key-for-testing-purposes-only")
+	os.Setenv("APP_ENV", "test")
+	jwtSecret = nil
+	InitJWT()
+
+	fake := clock.NewFake(time.Now())
+	tokens := NewTokens(fake)
+
+	token, err := tokens.GenerateJWT(1, "test-uuid", "test@example.com", "consumer")
+	if err != nil {
+		t.Fatalf("GenerateJWT() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		wantErr error
+	}{
+		{name: "fresh", elapsed: 0},
+		{name: "just before expiry", elapsed: TokenLifetime - time.Second},
+		{name: "expired", elapsed: TokenLifetime + time.Second, wantErr: ErrExpiredToken},
+	}
+
+	issuedAt := fake.Now()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.Set(issuedAt.Add(tt.elapsed))
+			_, err := tokens.ValidateJWT(token)
+			if err != tt.wantErr {
+				t.Errorf("ValidateJWT() after %s error = %v, want %v", tt.elapsed, err, tt.wantErr)
+			}
+		})
+	}
 }
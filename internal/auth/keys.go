@@ -0,0 +1,273 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"app/internal/vault"
+)
+
+// EnvKeyID identifies the signing key taken from JWT_SECRET. Tokens issued before
+// rotation carry no kid header and are checked against it.
+const EnvKeyID = "env"
+
+// KeyPropagationDelay is how long a new key is only used to validate before it starts
+// signing, so every instance has loaded it by the time tokens signed with it arrive
+const KeyPropagationDelay = 5 * time.Minute
+
+// KeyRefreshInterval is how often instances reload keys rotated elsewhere
+const KeyRefreshInterval = time.Minute
+
+// ErrUnknownKey is returned for tokens signed with a key that is unknown or retired
+var ErrUnknownKey = errors.New("token signed with an unknown or retired key")
+
+// SigningKey is an HMAC key for access tokens. Keys validate tokens from creation until
+// they retire, and the newest key past SigningFrom signs new tokens.
+type SigningKey struct {
+	ID          string     `json:"kid"`
+	CreatedAt   time.Time  `json:"created_at"`
+	SigningFrom time.Time  `json:"signing_from"`
+	RetiresAt   *time.Time `json:"retires_at,omitempty"`
+	secret      []byte
+}
+
+func (k SigningKey) active(now time.Time) bool {
+	return k.RetiresAt == nil || now.Before(*k.RetiresAt)
+}
+
+var (
+	keysMu      sync.RWMutex
+	signingKeys []SigningKey // Newest first
+)
+
+// setSigningKeys replaces the key ring
+func setSigningKeys(keys []SigningKey) {
+	keysMu.Lock()
+	defer keysMu.Unlock()
+	signingKeys = keys
+}
+
+// setEnvKey sets the JWT_SECRET key's secret, adding the key if it is not in the ring.
+// Until LoadSigningKeys reads rotated keys, it is the only key.
+func setEnvKey(secret []byte) {
+	keysMu.Lock()
+	defer keysMu.Unlock()
+	for i := range signingKeys {
+		if signingKeys[i].ID == EnvKeyID {
+			signingKeys[i].secret = secret
+			return
+		}
+	}
+	signingKeys = append(signingKeys, SigningKey{ID: EnvKeyID, secret: secret})
+}
+
+// SigningKeys returns the loaded keys, newest first, without their secrets
+func SigningKeys() []SigningKey {
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+	keys := make([]SigningKey, len(signingKeys))
+	for i, k := range signingKeys {
+		k.secret = nil
+		keys[i] = k
+	}
+	return keys
+}
+
+// currentSigningKey returns the key new tokens are signed with
+func currentSigningKey(now time.Time) (SigningKey, error) {
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+	for _, k := range signingKeys {
+		if !k.SigningFrom.After(now) && k.active(now) {
+			return k, nil
+		}
+	}
+	return SigningKey{}, errors.New("no active signing key")
+}
+
+// verificationKey returns the secret of the active key with ID kid; tokens without a kid
+// were signed with the JWT_SECRET key
+func verificationKey(kid string, now time.Time) ([]byte, error) {
+	if kid == "" {
+		kid = EnvKeyID
+	}
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+	for _, k := range signingKeys {
+		if k.ID == kid && k.active(now) {
+			return k.secret, nil
+		}
+	}
+	return nil, ErrUnknownKey
+}
+
+// KeyOverlap is how long keys keep validating after their replacement starts signing
+// (JWT_KEY_OVERLAP_HOURS, default 48). It is never shorter than a token's lifetime, so
+// rotation does not end sessions.
+func KeyOverlap() time.Duration {
+	overlap := 48 * time.Hour
+	if hours, err := strconv.Atoi(os.Getenv("JWT_KEY_OVERLAP_HOURS")); err == nil && hours > 0 {
+		overlap = time.Duration(hours) * time.Hour
+	}
+	if overlap < TokenLifetime {
+		overlap = TokenLifetime
+	}
+	return overlap
+}
+
+// keyAAD binds a sealed secret to its key ID
+func keyAAD(kid string) []byte {
+	return []byte("jwt_signing_keys:" + kid)
+}
+
+// LoadSigningKeys replaces the key ring with the keys in jwt_signing_keys, opening their
+// secrets with v. The JWT_SECRET key stays in use until a rotation retires it.
+func LoadSigningKeys(db *sql.DB, v *vault.Vault) error {
+	if len(jwtSecret) == 0 {
+		InitJWT()
+	}
+
+	rows, err := db.Query(`
+		SELECT kid, sealed_secret, created_at, signing_from, retires_at
+		FROM jwt_signing_keys
+		ORDER BY signing_from DESC, created_at DESC
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to query signing keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []SigningKey
+	envStored := false
+	for rows.Next() {
+		var k SigningKey
+		var sealed sql.NullString
+		if err := rows.Scan(&k.ID, &sealed, &k.CreatedAt, &k.SigningFrom, &k.RetiresAt); err != nil {
+			return fmt.Errorf("failed to scan signing key: %w", err)
+		}
+
+		switch {
+		case k.ID == EnvKeyID:
+			k.secret = jwtSecret
+			envStored = true
+		case v == nil:
+			return fmt.Errorf("signing key %s is sealed but the vault is not configured", k.ID)
+		default:
+			if k.secret, err = v.Open(sealed.String, keyAAD(k.ID)); err != nil {
+				return fmt.Errorf("failed to open signing key %s: %w", k.ID, err)
+			}
+		}
+		keys = append(keys, k)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if !envStored {
+		keys = append(keys, SigningKey{ID: EnvKeyID, secret: jwtSecret})
+	}
+	setSigningKeys(keys)
+	return nil
+}
+
+// RefreshSigningKeys reloads keys every KeyRefreshInterval until ctx is done, picking up
+// rotations made by other instances and the scheduled rotation
+func RefreshSigningKeys(ctx context.Context, db *sql.DB, v *vault.Vault) {
+	ticker := time.NewTicker(KeyRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := LoadSigningKeys(db, v); err != nil {
+				slog.ErrorContext(ctx, "Failed to reload JWT signing keys", "error", err)
+			}
+		}
+	}
+}
+
+// RotateSigningKey adds a key that starts signing after KeyPropagationDelay and retires
+// the keys it replaces overlap later. The new secret is sealed with v.
+func RotateSigningKey(ctx context.Context, db *sql.DB, v *vault.Vault, overlap time.Duration) (*SigningKey, error) {
+	if v == nil {
+		return nil, vault.ErrNotConfigured
+	}
+
+	idBytes := make([]byte, 8)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(idBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate key ID: %w", err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	key := SigningKey{ID: hex.EncodeToString(idBytes), secret: secret}
+
+	sealed, err := v.Seal(secret, keyAAD(key.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal signing key: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// One rotation at a time, so two rotations cannot retire each other's keys
+	if _, err := tx.ExecContext(ctx, `LOCK TABLE jwt_signing_keys IN EXCLUSIVE MODE`); err != nil {
+		return nil, fmt.Errorf("failed to lock signing keys: %w", err)
+	}
+
+	// Record the JWT_SECRET key so its retirement survives restarts
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO jwt_signing_keys (kid, signing_from) VALUES ($1, 'epoch')
+		ON CONFLICT (kid) DO NOTHING
+	`, EnvKeyID); err != nil {
+		return nil, fmt.Errorf("failed to record environment key: %w", err)
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO jwt_signing_keys (kid, sealed_secret, signing_from)
+		VALUES ($1, $2, NOW() + $3 * INTERVAL '1 second')
+		RETURNING created_at, signing_from
+	`, key.ID, sealed, KeyPropagationDelay.Seconds()).Scan(&key.CreatedAt, &key.SigningFrom)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store signing key: %w", err)
+	}
+
+	retiresAt := key.SigningFrom.Add(overlap)
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE jwt_signing_keys SET retires_at = $1
+		WHERE kid <> $2 AND retires_at IS NULL
+	`, retiresAt, key.ID); err != nil {
+		return nil, fmt.Errorf("failed to retire previous keys: %w", err)
+	}
+
+	// Retired keys are no longer needed, except the environment key's row, which keeps it retired
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM jwt_signing_keys WHERE retires_at < NOW() AND kid <> $1
+	`, EnvKeyID); err != nil {
+		return nil, fmt.Errorf("failed to prune retired keys: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit key rotation: %w", err)
+	}
+
+	if err := LoadSigningKeys(db, v); err != nil {
+		slog.ErrorContext(ctx, "Key rotated but keys could not be reloaded", "key_id", key.ID, "error", err)
+	}
+	key.secret = nil
+	return &key, nil
+}
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSigningKeyRotation(t *testing.T) {
+	jwtSecret = []byte("environment-secret-for-key-rotation-tests")
+	defer func() {
+		jwtSecret = nil
+		setSigningKeys(nil)
+	}()
+
+	now := time.Now()
+	env := SigningKey{ID: EnvKeyID, secret: jwtSecret}
+	setSigningKeys([]SigningKey{env})
+	oldToken, err := GenerateJWT(1, "uuid-1", "user@example.com", "consumer")
+	if err != nil {
+		t.Fatalf("GenerateJWT() error = %v", err)
+	}
+
+	// A new key that has not propagated yet validates but does not sign
+	next := SigningKey{ID: "next", secret: []byte("next-secret-next-secret-next-secret"), SigningFrom: now.Add(KeyPropagationDelay)}
+	retires := next.SigningFrom.Add(KeyOverlap())
+	env.RetiresAt = &retires
+	setSigningKeys([]SigningKey{next, env})
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		wantKid string
+	}{
+		{name: "before propagation", now: now, wantKid: EnvKeyID},
+		{name: "after propagation", now: next.SigningFrom, wantKid: "next"},
+		{name: "after overlap", now: retires.Add(time.Second), wantKid: "next"},
+	}
+	for _, tt := range tests {
+		key, err := currentSigningKey(tt.now)
+		if err != nil || key.ID != tt.wantKid {
+			t.Errorf("%s: currentSigningKey() = %q, %v, want %q", tt.name, key.ID, err, tt.wantKid)
+		}
+	}
+
+	if _, err := ValidateJWT(oldToken); err != nil {
+		t.Errorf("token signed before rotation rejected during overlap: %v", err)
+	}
+	if _, err := verificationKey(EnvKeyID, retires.Add(time.Second)); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("retired key still validates: %v", err)
+	}
+	if _, err := verificationKey("", now); err != nil {
+		t.Errorf("tokens without a kid are not checked against the environment key: %v", err)
+	}
+	if _, err := verificationKey("forged", now); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("unknown kid accepted: %v", err)
+	}
+
+	for _, k := range SigningKeys() {
+		if k.secret != nil {
+			t.Errorf("SigningKeys() exposes the secret of %s", k.ID)
+		}
+	}
+}
+
+func TestKeyOverlap(t *testing.T) {
+	tests := []struct {
+		hours string
+		want  time.Duration
+	}{
+		{hours: "", want: 48 * time.Hour},
+		{hours: "72", want: 72 * time.Hour},
+		{hours: "1", want: time.Hour},
+		{hours: "soon", want: 48 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Setenv("JWT_KEY_OVERLAP_HOURS", tt.hours)
+		if got := KeyOverlap(); got != tt.want {
+			t.Errorf("KeyOverlap() with %q = %v, want %v", tt.hours, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// bcryptMaxBytes is the longest password bcrypt hashes; later bytes are ignored
+const bcryptMaxBytes = 72
+
+// ErrPasswordBreached is returned for passwords found in known data breaches
+var ErrPasswordBreached = errors.New("password has appeared in a data breach, please choose a different one")
+
+// commonPasswords are rejected even when they satisfy the length and character rules
+var commonPasswords = []string{
+	"password123", "123456789", "qwerty123", "abc123456",
+	"password1", "iloveyou1", "letmein123", "welcome123",
+	"admin12345", "monkey1234", "dragon1234", "master1234",
+}
+
+// PasswordPolicy is the set of rules new passwords must meet
+type PasswordPolicy struct {
+	MinLength  int            // In characters
+	MinClasses int            // Of uppercase, lowercase, numbers and special characters
+	Breaches   *BreachChecker // nil skips the breach check
+}
+
+// DefaultPasswordPolicy requires 10 characters from at least 3 character classes
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{MinLength: 10, MinClasses: 3}
+}
+
+// PasswordPolicyFromEnv returns the default policy adjusted by PASSWORD_MIN_LENGTH,
+// PASSWORD_MIN_CLASSES and PASSWORD_BREACH_CHECK
+func PasswordPolicyFromEnv() PasswordPolicy {
+	policy := DefaultPasswordPolicy()
+	if n, err := strconv.Atoi(os.Getenv("PASSWORD_MIN_LENGTH")); err == nil && n >= 8 && n <= bcryptMaxBytes {
+		policy.MinLength = n
+	}
+	if n, err := strconv.Atoi(os.Getenv("PASSWORD_MIN_CLASSES")); err == nil && n >= 1 && n <= 4 {
+		policy.MinClasses = n
+	}
+	if enabled, _ := strconv.ParseBool(os.Getenv("PASSWORD_BREACH_CHECK")); enabled {
+		policy.Breaches = NewBreachChecker(os.Getenv("PASSWORD_BREACH_API_URL"))
+	}
+	return policy
+}
+
+// Validate checks a password against the length, character class and common password
+// rules
+func (p PasswordPolicy) Validate(password string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return fmt.Errorf("password must be at least %d characters long", p.MinLength)
+	}
+
+	// Maximum length to prevent DoS via bcrypt
+	if len(password) > bcryptMaxBytes {
+		return fmt.Errorf("password must be less than %d characters", bcryptMaxBytes)
+	}
+
+	if classes := passwordClasses(password); classes < p.MinClasses {
+		if p.MinClasses == 4 {
+			return fmt.Errorf("password must contain uppercase, lowercase, numbers and special characters")
+		}
+		return fmt.Errorf("password must contain at least %d of: uppercase, lowercase, numbers, special characters", p.MinClasses)
+	}
+
+	lowerPassword := strings.ToLower(password)
+	for _, common := range commonPasswords {
+		if lowerPassword == common {
+			return fmt.Errorf("password is too common, please choose a stronger password")
+		}
+	}
+	return nil
+}
+
+// Check validates a password and, when configured, checks it has not been breached. An
+// unavailable breach service does not block the password.
+func (p PasswordPolicy) Check(ctx context.Context, password string) error {
+	if err := p.Validate(password); err != nil {
+		return err
+	}
+	if p.Breaches == nil {
+		return nil
+	}
+
+	breached, err := p.Breaches.Breached(ctx, password)
+	if err != nil {
+		slog.WarnContext(ctx, "Password breach check unavailable", "error", err)
+		return nil
+	}
+	if breached {
+		return ErrPasswordBreached
+	}
+	return nil
+}
+
+// passwordClasses counts the character classes in a password. Letters outside ASCII
+// count by their case; other symbols and punctuation are special characters.
+func passwordClasses(password string) int {
+	var hasUpper, hasLower, hasNumber, hasSpecial bool
+	for _, char := range password {
+		switch {
+		case unicode.IsUpper(char):
+			hasUpper = true
+		case unicode.IsLower(char):
+			hasLower = true
+		case unicode.IsDigit(char):
+			hasNumber = true
+		case unicode.IsPunct(char) || unicode.IsSymbol(char):
+			hasSpecial = true
+		}
+	}
+
+	classes := 0
+	for _, has := range []bool{hasUpper, hasLower, hasNumber, hasSpecial} {
+		if has {
+			classes++
+		}
+	}
+	return classes
+}
+
+// BreachChecker looks passwords up in the Have I Been Pwned Pwned Passwords range API.
+// Only the first 5 hex characters of the password's SHA-1 leave the server (k-anonymity).
+type BreachChecker struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewBreachChecker creates a checker for the range API at baseURL, defaulting to
+// https://api.pwnedpasswords.com
+func NewBreachChecker(baseURL string) *BreachChecker {
+	if baseURL == "" {
+		baseURL = "https://api.pwnedpasswords.com"
+	}
+	return &BreachChecker{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 3 * time.Second},
+	}
+}
+
+// Breached reports whether the password appears in a known breach
+func (c *BreachChecker) Breached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/range/"+prefix, nil)
+	if err != nil {
+		return false, err
+	}
+	// Padding hides how many suffixes share the prefix from anyone watching the response size
+	req.Header.Set("Add-Padding", "true")
+	req.Header.Set("User-Agent", "gigco-api")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("breach check request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("breach check returned status %d", resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		candidate, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok || !strings.EqualFold(candidate, suffix) {
+			continue
+		}
+		// Padding entries have a count of 0
+		n, _ := strconv.Atoi(count)
+		return n > 0, nil
+	}
+	return false, scanner.Err()
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	strict := PasswordPolicy{MinLength: 12, MinClasses: 4}
+
+	tests := []struct {
+		name     string
+		policy   PasswordPolicy
+		password string
+		errMsg   string // Empty when the password is accepted
+	}{
+		{name: "default policy", policy: DefaultPasswordPolicy(), password: "MyStr0ng!Pass"},
+		{name: "default two classes", policy: DefaultPasswordPolicy(), password: "onlylowercase123", errMsg: "at least 3 of"},
+		{name: "length counts characters", policy: DefaultPasswordPolicy(), password: "Pässwörd1!"},
+		{name: "common password", policy: PasswordPolicy{MinLength: 8, MinClasses: 2}, password: "Password123", errMsg: "too common"},
+		{name: "strict exactly 12", policy: strict, password: "MyStr0ng!Pas"},
+		{name: "strict one short", policy: strict, password: "MyStr0ng!Pa", errMsg: "at least 12 characters"},
+		{name: "strict missing class", policy: strict, password: "MyStrongPass1", errMsg: "uppercase, lowercase, numbers and special"},
+		{name: "over bcrypt limit", policy: DefaultPasswordPolicy(), password: "Abcd1234!" + strings.Repeat("a", 64), errMsg: "less than 72"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate(tt.password)
+			if tt.errMsg == "" && err != nil {
+				t.Errorf("Validate() error = %v, want nil", err)
+			}
+			if tt.errMsg != "" && (err == nil || !strings.Contains(err.Error(), tt.errMsg)) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicyBreachCheck(t *testing.T) {
+	hashOf := func(password string) string {
+		sum := sha1.Sum([]byte(password))
+		return strings.ToUpper(hex.EncodeToString(sum[:]))
+	}
+	breached := hashOf("Tr0ub4dor&3xyz")
+	padded := hashOf("C0rrect-Horse-Battery")
+
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		if r.URL.Path == "/range/"+hashOf("Unavailable#2024")[:5] {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintf(w, "%s:12\r\n%s:0\r\n", breached[5:], padded[5:])
+	}))
+	defer server.Close()
+
+	policy := DefaultPasswordPolicy()
+	policy.Breaches = NewBreachChecker(server.URL)
+
+	tests := []struct {
+		password string
+		wantErr  error
+	}{
+		{password: "Tr0ub4dor&3xyz", wantErr: ErrPasswordBreached},
+		{password: "C0rrect-Horse-Battery"}, // Padding entry
+		{password: "Unbr3ached!Pass"},
+		{password: "Unavailable#2024"}, // Service errors do not block the password
+	}
+	for _, tt := range tests {
+		if err := policy.Check(context.Background(), tt.password); err != tt.wantErr {
+			t.Errorf("Check(%q) error = %v, want %v", tt.password, err, tt.wantErr)
+		}
+	}
+
+	// Only the hash prefix is sent
+	for _, path := range requested {
+		if len(strings.TrimPrefix(path, "/range/")) != 5 {
+			t.Errorf("breach check requested %s, want a 5 character prefix", path)
+		}
+	}
+}
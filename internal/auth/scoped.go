@@ -0,0 +1,171 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// MaxScopedTokenLifetime is the longest a scoped token may stay valid
+const MaxScopedTokenLifetime = 7 * 24 * time.Hour
+
+// ScopeDownloadAttachment is the scope of a link that downloads one attachment
+func ScopeDownloadAttachment(attachmentID int) string {
+	return fmt.Sprintf("download:attachment:%d", attachmentID)
+}
+
+// ScopeReviewSubmit is the scope of a link that submits the holder's review of one job
+func ScopeReviewSubmit(jobID int) string {
+	return fmt.Sprintf("review-submit:%d", jobID)
+}
+
+// ScopeLink is the scope of a deep link that performs action on one resource
+func ScopeLink(action string, resourceID int) string {
+	return fmt.Sprintf("%s%s:%d", linkScopePrefix, action, resourceID)
+}
+
+// linkScopePrefix starts the scope of every deep link
+const linkScopePrefix = "link:"
+
+// ScopedClaims are the claims of a token that grants one action on one resource. The
+// scope is the token's audience.
+type ScopedClaims struct {
+	UserID int `json:"user_id,omitempty"` // The user the action is performed as, if any
+	jwt.RegisteredClaims
+}
+
+// scopedKey derives the key scoped tokens are signed with from a signing key, so a
+// scoped token is never accepted as an access token or the other way round
+func scopedKey(secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("gigco-scoped-token"))
+	return mac.Sum(nil)
+}
+
+// IssueScopedToken creates a token valid only for scope, acting as userID (0 for none),
+// that expires after ttl
+func IssueScopedToken(scope string, userID int, ttl time.Duration) (string, error) {
+	return systemTokens.IssueScopedToken(scope, userID, ttl)
+}
+
+// IssueLinkToken creates the token of the deep link linkID, scoped with ScopeLink
+func IssueLinkToken(scope string, userID int, linkID string, ttl time.Duration) (string, error) {
+	return systemTokens.IssueLinkToken(scope, userID, linkID, ttl)
+}
+
+// ValidateScopedToken validates a scoped token and checks it was issued for scope
+func ValidateScopedToken(tokenString, scope string) (*ScopedClaims, error) {
+	return systemTokens.ValidateScopedToken(tokenString, scope)
+}
+
+// ValidateLinkToken validates a deep link token without knowing its scope in advance
+func ValidateLinkToken(tokenString string) (*ScopedClaims, error) {
+	return systemTokens.ValidateLinkToken(tokenString)
+}
+
+// IssueScopedToken creates a token valid only for scope, acting as userID (0 for none),
+// that expires after ttl
+func (t *Tokens) IssueScopedToken(scope string, userID int, ttl time.Duration) (string, error) {
+	return t.issueScopedToken(scope, userID, "", ttl)
+}
+
+// IssueLinkToken creates the token of the deep link linkID, scoped with ScopeLink. The
+// link ID is the token's jti, so the link's record can be checked when it is used.
+func (t *Tokens) IssueLinkToken(scope string, userID int, linkID string, ttl time.Duration) (string, error) {
+	if linkID == "" {
+		return "", errors.New("link token requires a link ID")
+	}
+	return t.issueScopedToken(scope, userID, linkID, ttl)
+}
+
+func (t *Tokens) issueScopedToken(scope string, userID int, id string, ttl time.Duration) (string, error) {
+	if ttl <= 0 || ttl > MaxScopedTokenLifetime {
+		return "", fmt.Errorf("scoped token ttl must be between 0 and %s", MaxScopedTokenLifetime)
+	}
+	if len(jwtSecret) == 0 {
+		InitJWT()
+	}
+
+	now := t.clock.Now()
+	key, err := currentSigningKey(now)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign scoped token: %w", err)
+	}
+
+	claims := &ScopedClaims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{scope},
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    "gigco-api",
+			ID:        id,
+		},
+	}
+	if userID != 0 {
+		claims.Subject = strconv.Itoa(userID)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = key.ID
+	tokenString, err := token.SignedString(scopedKey(key.secret))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign scoped token: %w", err)
+	}
+	return tokenString, nil
+}
+
+// ValidateScopedToken validates a scoped token and checks it was issued for scope
+func (t *Tokens) ValidateScopedToken(tokenString, scope string) (*ScopedClaims, error) {
+	return t.parseScopedToken(tokenString, jwt.WithAudience(scope))
+}
+
+// ValidateLinkToken validates a deep link token without knowing its scope in advance.
+// The claims' Audience holds the ScopeLink scope and ID the link ID.
+func (t *Tokens) ValidateLinkToken(tokenString string) (*ScopedClaims, error) {
+	claims, err := t.parseScopedToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.ID == "" || len(claims.Audience) != 1 || !strings.HasPrefix(claims.Audience[0], linkScopePrefix) {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+func (t *Tokens) parseScopedToken(tokenString string, opts ...jwt.ParserOption) (*ScopedClaims, error) {
+	if len(jwtSecret) == 0 {
+		InitJWT()
+	}
+
+	token, err := jwt.ParseWithClaims(tokenString, &ScopedClaims{}, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+		secret, err := verificationKey(kid, t.clock.Now())
+		if err != nil {
+			return nil, err
+		}
+		return scopedKey(secret), nil
+	}, append(opts, jwt.WithIssuer("gigco-api"), jwt.WithExpirationRequired(), jwt.WithTimeFunc(t.clock.Now))...)
+
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*ScopedClaims)
+	if !ok || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
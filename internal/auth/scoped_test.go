@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"app/internal/clock"
+)
+
+func TestScopedToken(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret-key-for-testing-purposes-only")
+	t.Setenv("APP_ENV", "test")
+	jwtSecret = nil
+	InitJWT()
+
+	download, err := IssueScopedToken(ScopeDownloadAttachment(7), 0, time.Minute)
+	if err != nil {
+		t.Fatalf("IssueScopedToken() error = %v", err)
+	}
+	review, err := IssueScopedToken(ScopeReviewSubmit(3), 42, time.Hour)
+	if err != nil {
+		t.Fatalf("IssueScopedToken() error = %v", err)
+	}
+	session, err := GenerateJWT(42, "uuid-42", "user@example.com", "consumer")
+	if err != nil {
+		t.Fatalf("GenerateJWT() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		token    string
+		scope    string
+		wantUser int
+		wantErr  bool
+	}{
+		{name: "download link", token: download, scope: "download:attachment:7"},
+		{name: "review link acts as its user", token: review, scope: "review-submit:3", wantUser: 42},
+		{name: "another attachment", token: download, scope: "download:attachment:8", wantErr: true},
+		{name: "another purpose", token: review, scope: "download:attachment:3", wantErr: true},
+		{name: "session token", token: session, scope: "review-submit:3", wantErr: true},
+		{name: "garbage", token: "not-a-token", scope: "download:attachment:7", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := ValidateScopedToken(tt.token, tt.scope)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateScopedToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && claims.UserID != tt.wantUser {
+				t.Errorf("claims.UserID = %d, want %d", claims.UserID, tt.wantUser)
+			}
+		})
+	}
+
+	// Scoped tokens never work as access tokens
+	if _, err := ValidateJWT(download); err == nil {
+		t.Error("ValidateJWT() accepted a scoped token")
+	}
+	if _, err := IssueScopedToken(ScopeDownloadAttachment(7), 0, MaxScopedTokenLifetime+time.Second); err == nil {
+		t.Error("IssueScopedToken() accepted a ttl over MaxScopedTokenLifetime")
+	}
+}
+
+func TestValidateLinkToken(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret-key-for-testing-purposes-only")
+	t.Setenv("APP_ENV", "test")
+	jwtSecret = nil
+	InitJWT()
+
+	link, err := IssueLinkToken(ScopeLink("accept_offer", 9), 42, "link-1", time.Hour)
+	if err != nil {
+		t.Fatalf("IssueLinkToken() error = %v", err)
+	}
+	review, err := IssueScopedToken(ScopeReviewSubmit(9), 42, time.Hour)
+	if err != nil {
+		t.Fatalf("IssueScopedToken() error = %v", err)
+	}
+
+	claims, err := ValidateLinkToken(link)
+	if err != nil {
+		t.Fatalf("ValidateLinkToken() error = %v", err)
+	}
+	if claims.ID != "link-1" || claims.UserID != 42 || claims.Audience[0] != "link:accept_offer:9" {
+		t.Errorf("claims = %+v, want link-1 for user 42 scoped to link:accept_offer:9", claims)
+	}
+	if _, err := ValidateScopedToken(link, ScopeLink("accept_offer", 9)); err != nil {
+		t.Errorf("ValidateScopedToken() error = %v for the link's scope", err)
+	}
+	if _, err := ValidateLinkToken(review); err == nil {
+		t.Error("ValidateLinkToken() accepted a scoped token that is not a link")
+	}
+	if _, err := IssueLinkToken(ScopeLink("accept_offer", 9), 42, "", time.Hour); err == nil {
+		t.Error("IssueLinkToken() accepted an empty link ID")
+	}
+}
+
+func TestReviewTokenWindow(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret-key-for-testing-purposes-only")
+	t.Setenv("APP_ENV", "test")
+	jwtSecret = nil
+	InitJWT()
+
+	issuedAt := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(issuedAt)
+	tokens := NewTokens(fake)
+
+	// Review request emails link a token valid for the whole review window
+	review, err := tokens.IssueScopedToken(ScopeReviewSubmit(3), 42, MaxScopedTokenLifetime)
+	if err != nil {
+		t.Fatalf("IssueScopedToken() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		wantErr error
+	}{
+		{name: "when sent", elapsed: 0},
+		{name: "last day of the window", elapsed: MaxScopedTokenLifetime - time.Minute},
+		{name: "after the window", elapsed: MaxScopedTokenLifetime + time.Second, wantErr: ErrExpiredToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.Set(issuedAt.Add(tt.elapsed))
+			_, err := tokens.ValidateScopedToken(review, ScopeReviewSubmit(3))
+			if err != tt.wantErr {
+				t.Errorf("ValidateScopedToken() after %s error = %v, want %v", tt.elapsed, err, tt.wantErr)
+			}
+		})
+	}
+}
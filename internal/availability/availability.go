@@ -0,0 +1,309 @@
+// Package availability merges a worker's booked schedules, active jobs and blackout
+// dates into busy and free windows. Matching and job scheduling use it to place jobs
+// only where the worker is free.
+package availability
+
+import (
+	"app/internal/recurrence"
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Sources of busy windows
+const (
+	SourceSchedule = "schedule"
+	SourceJob      = "job"
+	SourceBlackout = "blackout"
+)
+
+// Busy is a window in which the worker cannot take work
+type Busy struct {
+	Source string  `json:"source"`
+	ID     int     `json:"id"` // Schedule, job or blackout id, depending on Source
+	Title  *string `json:"title,omitempty"`
+	recurrence.Slot
+}
+
+// Availability is a worker's free/busy picture over [From, To)
+type Availability struct {
+	WorkerID int               `json:"worker_id"`
+	From     time.Time         `json:"from"`
+	To       time.Time         `json:"to"`
+	Busy     []Busy            `json:"busy"`
+	Free     []recurrence.Slot `json:"free"`
+}
+
+// Query selects the worker and range to compute availability for
+type Query struct {
+	WorkerID int
+	From     time.Time
+	To       time.Time
+	// ExcludeJobID leaves out the busy windows of this job, e.g. when rescheduling it
+	ExcludeJobID *int
+}
+
+// Jobs without a time of their own are booked into the worker's first free slot in
+// working hours within BookingHorizon days, starting tomorrow
+const (
+	WorkdayStart       = 9 * time.Hour
+	WorkdayEnd         = 18 * time.Hour
+	BookingHorizon     = 14
+	DefaultJobDuration = 2 * time.Hour
+)
+
+// week is how far apart repeat bookings fall
+const week = 7 * 24 * time.Hour
+
+// BookingRange is the range to load availability for when booking a job at now: from
+// tomorrow to the end of the horizon, plus a day for jobs running past the workday
+func BookingRange(now time.Time) (from, to time.Time) {
+	from = now.AddDate(0, 0, 1).Truncate(24 * time.Hour)
+	return from, from.AddDate(0, 0, BookingHorizon+1)
+}
+
+// dateLayout formats the UTC calendar dates blackouts are stored as
+const dateLayout = "2006-01-02"
+
+// activeJobStatuses are the job statuses that hold the worker's time
+var activeJobStatuses = []string{"accepted", "worker_assigned", "scheduled", "in_progress"}
+
+// ForWorker loads the worker's busy windows in the query range and derives the free
+// windows between them
+func ForWorker(ctx context.Context, db *sql.DB, q Query) (*Availability, error) {
+	var busy []Busy
+
+	scheduled, err := bookedSchedules(ctx, db, q)
+	if err != nil {
+		return nil, err
+	}
+	busy = append(busy, scheduled...)
+
+	jobs, err := activeJobs(ctx, db, q)
+	if err != nil {
+		return nil, err
+	}
+	busy = append(busy, jobs...)
+
+	blackouts, err := blackoutDates(ctx, db, q)
+	if err != nil {
+		return nil, err
+	}
+	busy = append(busy, blackouts...)
+
+	sort.SliceStable(busy, func(i, j int) bool { return busy[i].Start.Before(busy[j].Start) })
+	if busy == nil {
+		busy = []Busy{}
+	}
+
+	return &Availability{
+		WorkerID: q.WorkerID,
+		From:     q.From,
+		To:       q.To,
+		Busy:     busy,
+		Free:     freeWindows(q.From, q.To, busy),
+	}, nil
+}
+
+// IsFree reports whether slot lies entirely within one free window
+func (a *Availability) IsFree(slot recurrence.Slot) bool {
+	for _, free := range a.Free {
+		if !slot.Start.Before(free.Start) && !slot.End.After(free.End) {
+			return true
+		}
+	}
+	return false
+}
+
+// FirstFree returns the earliest slot of length d inside within that is free
+func (a *Availability) FirstFree(within recurrence.Slot, d time.Duration) (recurrence.Slot, bool) {
+	for _, free := range a.Free {
+		start := free.Start
+		if start.Before(within.Start) {
+			start = within.Start
+		}
+		end := start.Add(d)
+		if !end.After(free.End) && !end.After(within.End) {
+			return recurrence.Slot{Start: start, End: end}, true
+		}
+	}
+	return recurrence.Slot{}, false
+}
+
+// FirstBookable returns the earliest working-hours slot of length d in the first
+// BookingHorizon days of the availability's range, which starts at midnight
+func (a *Availability) FirstBookable(d time.Duration) (recurrence.Slot, bool) {
+	// Jobs longer than a working day may run past its end
+	dayLength := WorkdayEnd - WorkdayStart
+	if d > dayLength {
+		dayLength = d
+	}
+	for day := 0; day < BookingHorizon; day++ {
+		start := a.From.AddDate(0, 0, day).Add(WorkdayStart)
+		if slot, ok := a.FirstFree(recurrence.Slot{Start: start, End: start.Add(dayLength)}, d); ok {
+			return slot, true
+		}
+	}
+	return recurrence.Slot{}, false
+}
+
+// Repeat returns the first free slot in the availability's range on the same weekday,
+// at the same time and for as long as previous, for booking a job again
+func (a *Availability) Repeat(previous recurrence.Slot) (recurrence.Slot, bool) {
+	d := previous.End.Sub(previous.Start)
+	start := previous.Start
+	if start.Before(a.From) {
+		weeks := (a.From.Sub(start) + week - 1) / week
+		start = start.Add(weeks * week)
+	}
+	for ; !start.Add(d).After(a.To); start = start.Add(week) {
+		if slot := (recurrence.Slot{Start: start, End: start.Add(d)}); a.IsFree(slot) {
+			return slot, true
+		}
+	}
+	return recurrence.Slot{}, false
+}
+
+// freeWindows returns the gaps in [from, to) not covered by any busy window. busy must
+// be ordered by start.
+func freeWindows(from, to time.Time, busy []Busy) []recurrence.Slot {
+	free := []recurrence.Slot{}
+	cursor := from
+	for _, b := range busy {
+		if !cursor.Before(to) {
+			break
+		}
+		if b.Start.After(cursor) {
+			end := b.Start
+			if end.After(to) {
+				end = to
+			}
+			free = append(free, recurrence.Slot{Start: cursor, End: end})
+		}
+		if b.End.After(cursor) {
+			cursor = b.End
+		}
+	}
+	if cursor.Before(to) {
+		free = append(free, recurrence.Slot{Start: cursor, End: to})
+	}
+	return free
+}
+
+// bookedSchedules expands the worker's booked schedules (unavailable or tied to a job)
+// into the occurrences overlapping the range
+func bookedSchedules(ctx context.Context, db *sql.DB, q Query) ([]Busy, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, title, start_time, end_time, COALESCE(recurring_pattern, ''), recurring_until
+		FROM schedules
+		WHERE gig_worker_id = $1
+		  AND (is_available = false OR job_id IS NOT NULL)
+		  AND ($4::int IS NULL OR job_id IS DISTINCT FROM $4)
+		  AND start_time < $3
+		  AND (end_time > $2
+		       OR (COALESCE(recurring_pattern, '') <> ''
+		           AND (recurring_until IS NULL OR recurring_until + (end_time - start_time) > $2)))
+		ORDER BY start_time, id
+	`, q.WorkerID, q.From, q.To, q.ExcludeJobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query booked schedules: %w", err)
+	}
+	defer rows.Close()
+
+	var busy []Busy
+	for rows.Next() {
+		var id int
+		var title *string
+		var first recurrence.Slot
+		var pattern string
+		var until *time.Time
+		if err := rows.Scan(&id, &title, &first.Start, &first.End, &pattern, &until); err != nil {
+			return nil, fmt.Errorf("failed to scan booked schedule: %w", err)
+		}
+
+		slots := []recurrence.Slot{first}
+		if pattern != "" {
+			if rule, err := recurrence.ParseRule(pattern); err == nil {
+				slots = rule.Occurrences(first, until, q.From, q.To)
+			}
+		}
+		for _, slot := range slots {
+			if slot.Overlaps(recurrence.Slot{Start: q.From, End: q.To}) {
+				busy = append(busy, Busy{Source: SourceSchedule, ID: id, Title: title, Slot: slot})
+			}
+		}
+	}
+	return busy, rows.Err()
+}
+
+// activeJobs returns the worker's accepted and ongoing jobs with a scheduled time that
+// has no booked schedule yet (booked ones are covered by bookedSchedules)
+func activeJobs(ctx context.Context, db *sql.DB, q Query) ([]Busy, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT j.id, j.title, j.scheduled_start, j.scheduled_end
+		FROM jobs j
+		WHERE j.gig_worker_id = $1
+		  AND j.status::text = ANY($4)
+		  AND j.scheduled_start IS NOT NULL AND j.scheduled_end IS NOT NULL
+		  AND j.scheduled_start < $3 AND j.scheduled_end > $2
+		  AND ($5::int IS NULL OR j.id <> $5)
+		  AND NOT EXISTS (SELECT 1 FROM schedules s WHERE s.job_id = j.id)
+		ORDER BY j.scheduled_start, j.id
+	`, q.WorkerID, q.From, q.To, pq.Array(activeJobStatuses), q.ExcludeJobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query active jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var busy []Busy
+	for rows.Next() {
+		b := Busy{Source: SourceJob}
+		var title string
+		if err := rows.Scan(&b.ID, &title, &b.Start, &b.End); err != nil {
+			return nil, fmt.Errorf("failed to scan active job: %w", err)
+		}
+		b.Title = &title
+		busy = append(busy, b)
+	}
+	return busy, rows.Err()
+}
+
+// blackoutDates returns the worker's blackout dates overlapping the range. A blackout
+// covers whole days in UTC, end date included.
+func blackoutDates(ctx context.Context, db *sql.DB, q Query) ([]Busy, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, start_date, end_date
+		FROM worker_blackout_dates
+		WHERE worker_id = $1 AND start_date <= $3::date AND end_date >= $2::date
+		ORDER BY start_date, id
+	`, q.WorkerID, q.From.UTC().Format(dateLayout), q.To.UTC().Format(dateLayout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query blackout dates: %w", err)
+	}
+	defer rows.Close()
+
+	var busy []Busy
+	for rows.Next() {
+		b := Busy{Source: SourceBlackout}
+		var startDate, endDate time.Time
+		if err := rows.Scan(&b.ID, &startDate, &endDate); err != nil {
+			return nil, fmt.Errorf("failed to scan blackout date: %w", err)
+		}
+		b.Slot = BlackoutSlot(startDate, endDate)
+		if b.Overlaps(recurrence.Slot{Start: q.From, End: q.To}) {
+			busy = append(busy, b)
+		}
+	}
+	return busy, rows.Err()
+}
+
+// BlackoutSlot is the time a blackout from startDate to endDate (inclusive) covers
+func BlackoutSlot(startDate, endDate time.Time) recurrence.Slot {
+	start := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	return recurrence.Slot{Start: start, End: end}
+}
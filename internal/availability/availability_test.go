@@ -0,0 +1,142 @@
+package availability
+
+import (
+	"app/internal/recurrence"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func at(hour int) time.Time {
+	return time.Date(2026, 3, 2, hour, 0, 0, 0, time.UTC)
+}
+
+func slot(start, end int) recurrence.Slot {
+	return recurrence.Slot{Start: at(start), End: at(end)}
+}
+
+func busyAt(start, end int) Busy {
+	return Busy{Source: SourceSchedule, Slot: slot(start, end)}
+}
+
+func TestFreeWindows(t *testing.T) {
+	tests := []struct {
+		name string
+		busy []Busy
+		want []recurrence.Slot
+	}{
+		{"nothing booked", nil, []recurrence.Slot{slot(8, 18)}},
+		{"one booking", []Busy{busyAt(10, 12)}, []recurrence.Slot{slot(8, 10), slot(12, 18)}},
+		{"overlapping bookings merge", []Busy{busyAt(9, 12), busyAt(11, 13)}, []recurrence.Slot{slot(8, 9), slot(13, 18)}},
+		{"nested booking", []Busy{busyAt(9, 15), busyAt(10, 11)}, []recurrence.Slot{slot(8, 9), slot(15, 18)}},
+		{"back to back", []Busy{busyAt(9, 10), busyAt(10, 11)}, []recurrence.Slot{slot(8, 9), slot(11, 18)}},
+		{"spills past the range", []Busy{busyAt(6, 9), busyAt(17, 20)}, []recurrence.Slot{slot(9, 17)}},
+		{"fully booked", []Busy{busyAt(0, 23)}, []recurrence.Slot{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := freeWindows(at(8), at(18), tt.busy); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("freeWindows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirstFreeAndIsFree(t *testing.T) {
+	a := &Availability{Free: []recurrence.Slot{slot(8, 9), slot(11, 14), slot(16, 18)}}
+
+	tests := []struct {
+		name   string
+		within recurrence.Slot
+		hours  int
+		want   recurrence.Slot
+		wantOK bool
+	}{
+		{"first gap fits", slot(8, 18), 1, slot(8, 9), true},
+		{"skips gaps too short", slot(8, 18), 2, slot(11, 13), true},
+		{"starts inside a gap", slot(12, 18), 2, slot(12, 14), true},
+		{"must end inside within", slot(8, 13), 3, recurrence.Slot{}, false},
+		{"no gap long enough", slot(8, 18), 4, recurrence.Slot{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := a.FirstFree(tt.within, time.Duration(tt.hours)*time.Hour)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("FirstFree() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+			if ok && !a.IsFree(got) {
+				t.Errorf("IsFree(%v) = false for a slot FirstFree returned", got)
+			}
+		})
+	}
+
+	if a.IsFree(slot(13, 15)) {
+		t.Error("IsFree() = true for a slot crossing a busy window")
+	}
+}
+
+func TestFirstBookableAndRepeat(t *testing.T) {
+	day := 24 * time.Hour
+	midnight := at(0)
+	// Busy until 15:00 on the first day, and all of the second week's first day
+	a := &Availability{
+		From: midnight,
+		To:   midnight.Add(15 * day),
+		Free: []recurrence.Slot{
+			{Start: at(15), End: midnight.Add(7 * day)},
+			{Start: midnight.Add(8 * day), End: midnight.Add(15 * day)},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		hours  int
+		want   recurrence.Slot
+		wantOK bool
+	}{
+		{"rest of the first workday", 2, slot(15, 17), true},
+		{"next day when today is too short", 4, slot(24+9, 24+13), true},
+		{"longer than a workday", 10, slot(24+9, 24+19), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := a.FirstBookable(time.Duration(tt.hours) * time.Hour)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("FirstBookable() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	repeats := []struct {
+		name     string
+		previous recurrence.Slot
+		want     recurrence.Slot
+		wantOK   bool
+	}{
+		{"same time this week", slot(16, 18), slot(16, 18), true},
+		{"weeks ago moves forward", slot(16-21*24, 18-21*24), slot(16, 18), true},
+		{"busy weeks are skipped", slot(10, 12), slot(14*24+10, 14*24+12), true},
+		{"past the end of the range", slot(14*24+23, 15*24+1), recurrence.Slot{}, false},
+	}
+	for _, tt := range repeats {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := a.Repeat(tt.previous)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("Repeat() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestBlackoutSlot(t *testing.T) {
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 7, 3, 0, 0, 0, 0, time.UTC)
+
+	got := BlackoutSlot(start, end)
+	want := recurrence.Slot{Start: start, End: time.Date(2026, 7, 4, 0, 0, 0, 0, time.UTC)}
+	if got != want {
+		t.Errorf("BlackoutSlot() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,194 @@
+package availability
+
+import (
+	"app/internal/recurrence"
+	"app/internal/routing"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Kinds of conflict
+const (
+	ConflictOverlap = "overlap" // A busy window overlaps the proposed slot
+	ConflictTravel  = "travel"  // Too little time to drive between the proposed job and a neighbouring one
+)
+
+// travelSpeedMPH estimates driving time from the straight-line distance between jobs,
+// roughly city driving once detours are counted
+const travelSpeedMPH = 25
+
+// travelLookaround is how far either side of a slot neighbouring jobs are checked for
+// travel time; jobs further away are never too close to reach
+const travelLookaround = 4 * time.Hour
+
+// Conflict is a busy window that stops the worker taking a proposed slot
+type Conflict struct {
+	Kind string `json:"kind"`
+	Busy
+	// Travel conflicts only: the drive between the jobs and the time there is for it
+	TravelMiles   *float64 `json:"travel_miles,omitempty"`
+	TravelMinutes *int     `json:"travel_minutes,omitempty"`
+	GapMinutes    *int     `json:"gap_minutes,omitempty"`
+}
+
+// Proposal is a slot a worker is considering, at Location when it is known
+type Proposal struct {
+	WorkerID int
+	Slot     recurrence.Slot
+	Location *routing.Point
+	// ExcludeJobID leaves out the proposed job's own busy windows
+	ExcludeJobID *int
+}
+
+// locatedBusy is a busy window with the location of its job, when it has one
+type locatedBusy struct {
+	Busy
+	location *routing.Point
+}
+
+// FindConflicts returns the busy windows overlapping the proposed slot and, when the
+// proposal has a location, the jobs just before and after it that are too far away to
+// drive between in the time left. Job offers and the conflicts API both use it.
+func FindConflicts(ctx context.Context, db *sql.DB, p Proposal) ([]Conflict, error) {
+	avail, err := ForWorker(ctx, db, Query{
+		WorkerID:     p.WorkerID,
+		From:         p.Slot.Start.Add(-travelLookaround),
+		To:           p.Slot.End.Add(travelLookaround),
+		ExcludeJobID: p.ExcludeJobID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	busy := make([]locatedBusy, len(avail.Busy))
+	for i, b := range avail.Busy {
+		busy[i] = locatedBusy{Busy: b}
+	}
+	if p.Location != nil {
+		if err := locateBusy(ctx, db, busy); err != nil {
+			return nil, err
+		}
+	}
+	return conflictsWith(p, busy), nil
+}
+
+// conflictsWith checks the proposal against busy windows ordered by start
+func conflictsWith(p Proposal, busy []locatedBusy) []Conflict {
+	conflicts := []Conflict{}
+	var before, after *locatedBusy
+	for i := range busy {
+		b := &busy[i]
+		if b.Overlaps(p.Slot) {
+			conflicts = append(conflicts, Conflict{Kind: ConflictOverlap, Busy: b.Busy})
+			continue
+		}
+		if b.location == nil {
+			continue
+		}
+		if !b.End.After(p.Slot.Start) && (before == nil || b.End.After(before.End)) {
+			before = b
+		}
+		if !b.Start.Before(p.Slot.End) && (after == nil || b.Start.Before(after.Start)) {
+			after = b
+		}
+	}
+	if p.Location == nil {
+		return conflicts
+	}
+
+	if before != nil {
+		if c, ok := travelConflict(before, *p.Location, p.Slot.Start.Sub(before.End)); ok {
+			conflicts = append(conflicts, c)
+		}
+	}
+	if after != nil {
+		if c, ok := travelConflict(after, *p.Location, after.Start.Sub(p.Slot.End)); ok {
+			conflicts = append(conflicts, c)
+		}
+	}
+	return conflicts
+}
+
+// travelConflict reports whether gap is too short to drive between b's job and at
+func travelConflict(b *locatedBusy, at routing.Point, gap time.Duration) (Conflict, bool) {
+	miles := routing.StraightLineMiles(*b.location, at)
+	travel := TravelTime(miles)
+	if gap >= travel {
+		return Conflict{}, false
+	}
+	miles = float64(int(miles*100+0.5)) / 100
+	travelMinutes := int((travel + time.Minute - 1) / time.Minute)
+	gapMinutes := int(gap / time.Minute)
+	return Conflict{
+		Kind:          ConflictTravel,
+		Busy:          b.Busy,
+		TravelMiles:   &miles,
+		TravelMinutes: &travelMinutes,
+		GapMinutes:    &gapMinutes,
+	}, true
+}
+
+// TravelTime estimates the drive between two jobs the given straight-line miles apart
+func TravelTime(miles float64) time.Duration {
+	return time.Duration(miles / travelSpeedMPH * float64(time.Hour))
+}
+
+// locateBusy fills in the location of the jobs behind busy windows: a job's own, or the
+// job a booked schedule is for. Blackouts and schedules without a job have none.
+func locateBusy(ctx context.Context, db *sql.DB, busy []locatedBusy) error {
+	var jobIDs, scheduleIDs []int64
+	for _, b := range busy {
+		switch b.Source {
+		case SourceJob:
+			jobIDs = append(jobIDs, int64(b.ID))
+		case SourceSchedule:
+			scheduleIDs = append(scheduleIDs, int64(b.ID))
+		}
+	}
+	if len(jobIDs) == 0 && len(scheduleIDs) == 0 {
+		return nil
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT 'job', j.id, j.location_latitude, j.location_longitude
+		FROM jobs j
+		WHERE j.id = ANY($1) AND j.location_latitude IS NOT NULL AND j.location_longitude IS NOT NULL
+		UNION ALL
+		SELECT 'schedule', s.id, j.location_latitude, j.location_longitude
+		FROM schedules s
+		JOIN jobs j ON j.id = s.job_id
+		WHERE s.id = ANY($2) AND j.location_latitude IS NOT NULL AND j.location_longitude IS NOT NULL
+	`, pq.Array(jobIDs), pq.Array(scheduleIDs))
+	if err != nil {
+		return fmt.Errorf("failed to query job locations: %w", err)
+	}
+	defer rows.Close()
+
+	type key struct {
+		source string
+		id     int
+	}
+	locations := make(map[key]routing.Point)
+	for rows.Next() {
+		var k key
+		var point routing.Point
+		if err := rows.Scan(&k.source, &k.id, &point.Lat, &point.Lng); err != nil {
+			return fmt.Errorf("failed to scan job location: %w", err)
+		}
+		locations[k] = point
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range busy {
+		if point, ok := locations[key{busy[i].Source, busy[i].ID}]; ok {
+			busy[i].location = &point
+		}
+	}
+	return nil
+}
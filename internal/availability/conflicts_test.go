@@ -0,0 +1,49 @@
+package availability
+
+import (
+	"app/internal/routing"
+	"testing"
+)
+
+func TestConflictsWith(t *testing.T) {
+	here := &routing.Point{Lat: 40.0, Lng: -75.0}
+	across := &routing.Point{Lat: 40.1, Lng: -75.0} // ~7 miles, ~17 minutes away
+	at := func(start, end int, location *routing.Point) locatedBusy {
+		return locatedBusy{Busy: busyAt(start, end), location: location}
+	}
+
+	tests := []struct {
+		name     string
+		location *routing.Point
+		busy     []locatedBusy
+		want     []string
+	}{
+		{"free", here, nil, []string{}},
+		{"overlapping booking", here, []locatedBusy{at(9, 11, here)}, []string{ConflictOverlap}},
+		{"back to back at the same place", here, []locatedBusy{at(9, 10, here), at(12, 13, here)}, []string{}},
+		{"no time to drive from the job before", here, []locatedBusy{at(9, 10, across)}, []string{ConflictTravel}},
+		{"no time to drive to the job after", here, []locatedBusy{at(12, 13, across)}, []string{ConflictTravel}},
+		{"an hour is enough", here, []locatedBusy{at(8, 9, across), at(13, 14, across)}, []string{}},
+		{"only the nearest job before is checked", here, []locatedBusy{at(8, 9, here), at(9, 10, across)}, []string{ConflictTravel}},
+		{"busy time without a job", here, []locatedBusy{at(9, 10, nil)}, []string{}},
+		{"proposal without a location", nil, []locatedBusy{at(9, 10, across)}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := conflictsWith(Proposal{Slot: slot(10, 12), Location: tt.location}, tt.busy)
+			kinds := []string{}
+			for _, c := range got {
+				kinds = append(kinds, c.Kind)
+			}
+			if len(kinds) != len(tt.want) {
+				t.Fatalf("conflictsWith() kinds = %v, want %v", kinds, tt.want)
+			}
+			for i := range kinds {
+				if kinds[i] != tt.want[i] {
+					t.Errorf("conflictsWith() kinds = %v, want %v", kinds, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package clock
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+var uuidV4 = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestIDGenerators(t *testing.T) {
+	seq := &Sequence{}
+	tests := []struct {
+		name string
+		gen  IDGenerator
+		want []string // Exact IDs; nil checks only that they are distinct UUIDs
+	}{
+		{name: "random", gen: UUIDs},
+		{name: "sequence", gen: seq, want: []string{
+			"00000000-0000-4000-8000-000000000001",
+			"00000000-0000-4000-8000-000000000002",
+			"00000000-0000-4000-8000-000000000003",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := map[string]bool{}
+			for i := 0; i < 3; i++ {
+				id := tt.gen.NewID()
+				if !uuidV4.MatchString(id) {
+					t.Errorf("NewID() = %q, not a version 4 UUID", id)
+				}
+				if seen[id] {
+					t.Errorf("NewID() repeated %q", id)
+				}
+				seen[id] = true
+				if tt.want != nil && id != tt.want[i] {
+					t.Errorf("NewID() #%d = %q, want %q", i+1, id, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFake(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := NewFake(start)
+	if got := c.Now(); !got.Equal(start) {
+		t.Fatalf("Now() = %v, want %v", got, start)
+	}
+	c.Advance(90 * time.Minute)
+	if got, want := c.Now(), start.Add(90*time.Minute); !got.Equal(want) {
+		t.Errorf("Now() after Advance = %v, want %v", got, want)
+	}
+	c.Set(start)
+	if got := c.Now(); !got.Equal(start) {
+		t.Errorf("Now() after Set = %v, want %v", got, start)
+	}
+}
@@ -0,0 +1,72 @@
+// Package currency is the registry of currencies jobs can be priced and paid in, and
+// the exchange rates used to show amounts in another currency. Payments are always
+// taken in the job's own currency; converted amounts are for display only.
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"app/internal/model"
+)
+
+// ErrUnsupported is returned for currency codes that are not in the registry
+var ErrUnsupported = errors.New("unsupported currency")
+
+// Currency is an ISO 4217 currency the platform accepts
+type Currency struct {
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	Symbol string `json:"symbol"`
+}
+
+// registry holds the accepted currencies. model.Money counts hundredths, so only
+// currencies with two minor digits can be added.
+var registry = map[string]Currency{
+	"USD": {Code: "USD", Name: "US Dollar", Symbol: "$"},
+	"CAD": {Code: "CAD", Name: "Canadian Dollar", Symbol: "CA$"},
+	"EUR": {Code: "EUR", Name: "Euro", Symbol: "€"},
+	"GBP": {Code: "GBP", Name: "British Pound", Symbol: "£"},
+	"AUD": {Code: "AUD", Name: "Australian Dollar", Symbol: "A$"},
+	"MXN": {Code: "MXN", Name: "Mexican Peso", Symbol: "MX$"},
+}
+
+// Lookup returns the registered currency for a code, in any case
+func Lookup(code string) (Currency, bool) {
+	c, ok := registry[strings.ToUpper(strings.TrimSpace(code))]
+	return c, ok
+}
+
+// Normalize returns the registered code for code, or model.DefaultCurrency when code
+// is blank
+func Normalize(code string) (string, error) {
+	if strings.TrimSpace(code) == "" {
+		return model.DefaultCurrency, nil
+	}
+	c, ok := Lookup(code)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnsupported, code)
+	}
+	return c.Code, nil
+}
+
+// Supported lists the registered currencies by code
+func Supported() []Currency {
+	list := make([]Currency, 0, len(registry))
+	for _, c := range registry {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+	return list
+}
+
+// Codes lists the registered currency codes in order, for validation messages
+func Codes() []string {
+	codes := make([]string, 0, len(registry))
+	for _, c := range Supported() {
+		codes = append(codes, c.Code)
+	}
+	return codes
+}
@@ -0,0 +1,119 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"app/internal/model"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		code    string
+		want    string
+		wantErr error
+	}{
+		{code: "", want: "USD"},
+		{code: "eur", want: "EUR"},
+		{code: " GBP ", want: "GBP"},
+		{code: "JPY", wantErr: ErrUnsupported},
+		{code: "dollars", wantErr: ErrUnsupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			got, err := Normalize(tt.code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Normalize(%q) error = %v, want %v", tt.code, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRates(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    StaticRates
+		wantErr bool
+	}{
+		{name: "empty", in: "", want: StaticRates{}},
+		{name: "rates", in: "eur=0.92, GBP=0.79", want: StaticRates{"EUR": 0.92, "GBP": 0.79}},
+		{name: "missing rate", in: "EUR", wantErr: true},
+		{name: "unregistered currency", in: "JPY=150", wantErr: true},
+		{name: "zero rate", in: "EUR=0", wantErr: true},
+		{name: "not a number", in: "EUR=abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRates(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRates(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseRates(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+			for code, rate := range tt.want {
+				if got[code] != rate {
+					t.Errorf("ParseRates(%q)[%s] = %v, want %v", tt.in, code, got[code], rate)
+				}
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	rates := StaticRates{"EUR": 0.8, "GBP": 0.5}
+	eur := func(cents int64) model.Money { return model.NewMoney(cents, "EUR") }
+
+	tests := []struct {
+		name     string
+		to       string
+		amounts  []model.Money
+		want     []model.Money
+		wantRate float64
+		wantErr  error
+	}{
+		{name: "from the default currency", to: "EUR", amounts: []model.Money{model.USD(10000), model.USD(1999)},
+			want: []model.Money{eur(8000), eur(1599)}, wantRate: 0.8},
+		{name: "to the default currency", to: "USD", amounts: []model.Money{eur(8000)},
+			want: []model.Money{model.USD(10000)}, wantRate: 1.25},
+		{name: "cross rate", to: "GBP", amounts: []model.Money{eur(1000)},
+			want: []model.Money{model.NewMoney(625, "GBP")}, wantRate: 0.625},
+		{name: "same currency", to: "EUR", amounts: []model.Money{eur(1234)},
+			want: []model.Money{eur(1234)}, wantRate: 1},
+		{name: "no rate", to: "CAD", amounts: []model.Money{model.USD(100)}, wantErr: ErrNoRate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rate, err := Convert(context.Background(), rates, tt.to, tt.amounts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Convert() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if rate != tt.wantRate {
+				t.Errorf("Convert() rate = %v, want %v", rate, tt.wantRate)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("Convert()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	if _, _, err := Convert(context.Background(), rates, "GBP", model.USD(100), eur(100)); err == nil {
+		t.Error("Convert() of mixed currencies should fail")
+	}
+}
@@ -0,0 +1,100 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"app/internal/model"
+)
+
+// ErrNoRate is returned when a rate provider has no rate between two currencies
+var ErrNoRate = errors.New("no exchange rate")
+
+// RateProvider supplies exchange rates. Rate returns how many units of to one unit of
+// from buys. Implementations backed by a rates service should cache, since every
+// converted response asks for a rate.
+type RateProvider interface {
+	Rate(ctx context.Context, from, to string) (float64, error)
+}
+
+// StaticRates is a RateProvider with fixed rates, each the units of the currency that
+// one unit of model.DefaultCurrency buys. Cross rates go through the default currency.
+type StaticRates map[string]float64
+
+// ParseRates reads rates such as "EUR=0.92,GBP=0.79" against the default currency,
+// as set in FX_RATES. Every currency must be registered and every rate positive.
+func ParseRates(s string) (StaticRates, error) {
+	rates := StaticRates{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		code, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid exchange rate %q: want CODE=rate", pair)
+		}
+		normalized, err := Normalize(code)
+		if err != nil || strings.TrimSpace(code) == "" {
+			return nil, fmt.Errorf("invalid exchange rate %q: %w", pair, ErrUnsupported)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || rate <= 0 || math.IsInf(rate, 0) {
+			return nil, fmt.Errorf("invalid exchange rate %q: rate must be a positive number", pair)
+		}
+		rates[normalized] = rate
+	}
+	return rates, nil
+}
+
+// Rate returns the rate from one currency to another through the default currency
+func (r StaticRates) Rate(ctx context.Context, from, to string) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+	fromRate, ok := r.perDefault(from)
+	if !ok {
+		return 0, fmt.Errorf("%w for %s", ErrNoRate, from)
+	}
+	toRate, ok := r.perDefault(to)
+	if !ok {
+		return 0, fmt.Errorf("%w for %s", ErrNoRate, to)
+	}
+	return toRate / fromRate, nil
+}
+
+func (r StaticRates) perDefault(code string) (float64, bool) {
+	if code == model.DefaultCurrency {
+		return 1, true
+	}
+	rate, ok := r[code]
+	return rate, ok
+}
+
+// Convert converts amounts to another currency at the provider's rate, rounding each
+// to the cent. It returns the converted amounts, in order, and the rate used.
+func Convert(ctx context.Context, rates RateProvider, to string, amounts ...model.Money) ([]model.Money, float64, error) {
+	converted := make([]model.Money, len(amounts))
+	if len(amounts) == 0 {
+		return converted, 1, nil
+	}
+	from := amounts[0].Currency
+	if from == "" {
+		from = model.DefaultCurrency
+	}
+	rate, err := rates.Rate(ctx, from, to)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i, amount := range amounts {
+		if amount.Currency != "" && amount.Currency != from {
+			return nil, 0, fmt.Errorf("cannot convert %s and %s amounts together", from, amount.Currency)
+		}
+		converted[i] = model.NewMoney(int64(math.Round(float64(amount.Cents)*rate)), to)
+	}
+	return converted, rate, nil
+}
@@ -0,0 +1,221 @@
+// Package dataexport assembles the archive of a user's personal data: their profile,
+// jobs, job templates, transactions, reviews and messages, one JSON or CSV file per
+// section in a zip. Users download it on request, and receive it before their account
+// is erased.
+package dataexport
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"app/internal/clock"
+)
+
+// ContentType is the archive's content type
+const ContentType = "application/zip"
+
+// Format is the format of an archive's section files
+type Format string
+
+const (
+	FormatJSON Format = "json" // A JSON array of objects per section
+	FormatCSV  Format = "csv"  // A CSV file with a header row per section
+)
+
+// Formats lists the formats an archive can be written in
+var Formats = []string{string(FormatJSON), string(FormatCSV)}
+
+// Section is one file of the archive: the rows its query returns for the user
+type Section struct {
+	Name  string // File name without its extension
+	Query string // Selects the user's rows, oldest first; $1 is the user's id
+}
+
+// Sections are the archive's files, in order
+var Sections = []Section{
+	{Name: "profile", Query: `
+		SELECT id, uuid, name, email, phone, address, role, is_active, email_verified, phone_verified,
+		       created_at, updated_at
+		FROM people WHERE id = $1`},
+	{Name: "worker_profile", Query: `
+		SELECT bio, hourly_rate, experience_years, verification_status, service_radius_miles,
+		       availability_notes, created_at, updated_at
+		FROM worker_profiles WHERE worker_id = $1`},
+	{Name: "jobs", Query: `
+		SELECT id, uuid, CASE WHEN consumer_id = $1 THEN 'consumer' ELSE 'gig_worker' END AS role,
+		       title, description, category, location_address, status, pay_rate_per_hour, total_pay,
+		       scheduled_start, scheduled_end, actual_start, actual_end, created_at
+		FROM jobs WHERE consumer_id = $1 OR gig_worker_id = $1
+		ORDER BY created_at, id`},
+	{Name: "job_templates", Query: `
+		SELECT id, name, title, description, category, location_address, estimated_duration_hours,
+		       pay_rate_per_hour, total_pay, notes, access_instructions, currency, created_at
+		FROM job_templates WHERE consumer_id = $1
+		ORDER BY created_at, id`},
+	{Name: "transactions", Query: `
+		SELECT id, uuid, job_id, CASE WHEN consumer_id = $1 THEN 'paid' ELSE 'received' END AS direction,
+		       amount, currency, status, transaction_type, payment_provider, captured_at, refund_amount,
+		       refunded_at, created_at
+		FROM transactions WHERE consumer_id = $1 OR gig_worker_id = $1
+		ORDER BY created_at, id`},
+	{Name: "reviews", Query: `
+		SELECT id, job_id, CASE WHEN reviewer_id = $1 THEN 'given' ELSE 'received' END AS direction,
+		       rating, review_text, is_public, created_at
+		FROM job_reviews WHERE reviewer_id = $1 OR reviewee_id = $1
+		ORDER BY created_at, id`},
+	{Name: "messages", Query: `
+		SELECT id, job_id, body, created_at
+		FROM job_messages WHERE sender_id = $1
+		ORDER BY created_at, id`},
+}
+
+// manifest describes the archive in manifest.json
+type manifest struct {
+	UserID      int       `json:"user_id"`
+	Format      Format    `json:"format"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Files       []string  `json:"files"`
+}
+
+// table is a section's rows, with its columns in order
+type table struct {
+	columns []string
+	rows    [][]interface{}
+}
+
+// Write writes the archive of userID's data to w, with a file per section in format.
+// The manifest is dated from clk.
+func Write(ctx context.Context, db *sql.DB, clk clock.Clock, userID int, format Format, w io.Writer) error {
+	if !slices.Contains(Formats, string(format)) {
+		return fmt.Errorf("unknown data export format %q", format)
+	}
+	archive := zip.NewWriter(w)
+	m := manifest{UserID: userID, Format: format, GeneratedAt: clk.Now().UTC()}
+
+	for _, section := range Sections {
+		t, err := query(ctx, db, section, userID)
+		if err != nil {
+			return err
+		}
+		name := section.Name + "." + string(format)
+		if format == FormatCSV {
+			err = writeCSV(archive, name, t)
+		} else {
+			records := make([]map[string]interface{}, len(t.rows))
+			for i, values := range t.rows {
+				records[i] = record(t.columns, values)
+			}
+			err = writeJSON(archive, name, records)
+		}
+		if err != nil {
+			return err
+		}
+		m.Files = append(m.Files, name)
+	}
+
+	if err := writeJSON(archive, "manifest.json", m); err != nil {
+		return err
+	}
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to finish data export: %w", err)
+	}
+	return nil
+}
+
+// query returns a section's rows
+func query(ctx context.Context, db *sql.DB, section Section, userID int) (table, error) {
+	rows, err := db.QueryContext(ctx, section.Query, userID)
+	if err != nil {
+		return table{}, fmt.Errorf("failed to export %s: %w", section.Name, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return table{}, fmt.Errorf("failed to export %s: %w", section.Name, err)
+	}
+	t := table{columns: columns}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return table{}, fmt.Errorf("failed to scan %s: %w", section.Name, err)
+		}
+		t.rows = append(t.rows, values)
+	}
+	if err := rows.Err(); err != nil {
+		return table{}, fmt.Errorf("failed to export %s: %w", section.Name, err)
+	}
+	return t, nil
+}
+
+// record pairs a row's values with its columns. The driver returns text, numeric and
+// UUID columns as bytes, which are kept as strings so amounts keep their precision.
+func record(columns []string, values []interface{}) map[string]interface{} {
+	r := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		if b, ok := values[i].([]byte); ok {
+			r[column] = string(b)
+			continue
+		}
+		r[column] = values[i]
+	}
+	return r
+}
+
+// csvValue formats a value for a CSV cell: empty for NULL, RFC 3339 for times
+func csvValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprint(v)
+}
+
+func writeCSV(archive *zip.Writer, name string, t table) error {
+	f, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to data export: %w", name, err)
+	}
+	cw := csv.NewWriter(f)
+	cw.Write(t.columns)
+	cells := make([]string, len(t.columns))
+	for _, values := range t.rows {
+		for i, v := range values {
+			cells[i] = csvValue(v)
+		}
+		cw.Write(cells)
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write %s to data export: %w", name, err)
+	}
+	return nil
+}
+
+func writeJSON(archive *zip.Writer, name string, v interface{}) error {
+	f, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to data export: %w", name, err)
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to write %s to data export: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package dataexport
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRecord(t *testing.T) {
+	at := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		columns []string
+		values  []interface{}
+		want    map[string]interface{}
+	}{
+		{
+			name:    "bytes become strings",
+			columns: []string{"uuid", "amount"},
+			values:  []interface{}{[]byte("3f1c9a2e-8d5b-4c61-9b0a-2e7d4f6a1c3b"), []byte("125.50")},
+			want:    map[string]interface{}{"uuid": "3f1c9a2e-8d5b-4c61-9b0a-2e7d4f6a1c3b", "amount": "125.50"},
+		},
+		{
+			name:    "other values kept",
+			columns: []string{"id", "is_public", "created_at", "review_text"},
+			values:  []interface{}{int64(7), true, at, nil},
+			want:    map[string]interface{}{"id": int64(7), "is_public": true, "created_at": at, "review_text": nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := record(tt.columns, tt.values); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("record() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{name: "null", value: nil, want: ""},
+		{name: "numeric", value: []byte("125.50"), want: "125.50"},
+		{name: "time in UTC", value: time.Date(2026, 10, 16, 9, 30, 0, 0, time.FixedZone("CDT", -5*3600)), want: "2026-10-16T14:30:00Z"},
+		{name: "integer", value: int64(7), want: "7"},
+		{name: "boolean", value: true, want: "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := csvValue(tt.value); got != tt.want {
+				t.Errorf("csvValue() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,295 @@
+// Package dispatch holds the algorithms that price a job and pick its worker. The
+// production algorithms run in the job workflow; candidates registered here can be
+// evaluated against them in shadow mode (see internal/shadow) before switching.
+package dispatch
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"app/internal/presence"
+)
+
+// Job is the part of a job the pricing and matching algorithms see. Completeness is
+// the posting's 0-100 completeness score (see internal/jobquality), 100 for jobs
+// posted before scoring; engines can use it to deprioritize low-quality postings.
+type Job struct {
+	ID            int    `json:"id"`
+	Category      string `json:"category"`
+	Location      string `json:"location"`
+	DurationHours int    `json:"duration_hours"`
+	Urgency       string `json:"urgency"`
+	Completeness  int    `json:"completeness"`
+}
+
+// Candidate is an available worker a matching engine can choose
+type Candidate struct {
+	WorkerID int     `json:"worker_id"`
+	Skills   string  `json:"skills"`
+	Location string  `json:"location"`
+	Rating   float64 `json:"rating"`
+	// Offers the worker declined recently as too far away or paying too little
+	DeclinedTooFar    int `json:"declined_too_far,omitempty"`
+	DeclinedPayTooLow int `json:"declined_pay_too_low,omitempty"`
+	// Whether the worker has the app open, from its heartbeats (see internal/presence)
+	Presence presence.Status `json:"presence,omitempty"`
+}
+
+// PricingRule prices a job
+type PricingRule interface {
+	Name() string
+	Price(job Job) float64
+}
+
+// MatchingEngine picks a worker for a job from the available candidates. ok is false
+// when none of them is suitable.
+type MatchingEngine interface {
+	Name() string
+	Match(job Job, candidates []Candidate) (workerID int, ok bool)
+}
+
+// Production algorithms used by the job workflow. Switching algorithms means
+// pointing these at a candidate once its shadow reports look right.
+var (
+	ProductionPricing  PricingRule    = HourlyPricing{}
+	ProductionMatching MatchingEngine = PresenceMatcher{}
+)
+
+// pricingRules and matchingEngines are the algorithms selectable by name, including
+// the production ones so a shadow run can compare against a previous version
+var (
+	pricingRules = map[string]PricingRule{
+		HourlyPricing{}.Name():   HourlyPricing{},
+		CategoryPricing{}.Name(): CategoryPricing{},
+	}
+	matchingEngines = map[string]MatchingEngine{
+		RatingMatcher{}.Name():       RatingMatcher{},
+		SkillMatcher{}.Name():        SkillMatcher{},
+		DeclineAwareMatcher{}.Name(): DeclineAwareMatcher{},
+		PresenceMatcher{}.Name():     PresenceMatcher{},
+	}
+)
+
+// LookupPricingRule returns the pricing rule registered under name
+func LookupPricingRule(name string) (PricingRule, bool) {
+	rule, ok := pricingRules[name]
+	return rule, ok
+}
+
+// LookupMatchingEngine returns the matching engine registered under name
+func LookupMatchingEngine(name string) (MatchingEngine, bool) {
+	engine, ok := matchingEngines[name]
+	return engine, ok
+}
+
+// PricingRuleNames lists the registered pricing rules
+func PricingRuleNames() []string {
+	names := make([]string, 0, len(pricingRules))
+	for name := range pricingRules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MatchingEngineNames lists the registered matching engines
+func MatchingEngineNames() []string {
+	names := make([]string, 0, len(matchingEngines))
+	for name := range matchingEngines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// urgencyMultiplier scales the price of urgent jobs
+func urgencyMultiplier(urgency string) float64 {
+	switch urgency {
+	case "urgent":
+		return 1.5
+	case "high":
+		return 1.3
+	case "medium":
+		return 1.1
+	}
+	return 1.0
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// Rank returns up to n workers in the order engine picks them: its match, then its
+// match among the rest, and so on
+func Rank(engine MatchingEngine, job Job, candidates []Candidate, n int) []int {
+	remaining := append([]Candidate(nil), candidates...)
+	var ranked []int
+	for len(ranked) < n {
+		workerID, ok := engine.Match(job, remaining)
+		if !ok {
+			break
+		}
+		ranked = append(ranked, workerID)
+		remaining = slices.DeleteFunc(remaining, func(c Candidate) bool { return c.WorkerID == workerID })
+	}
+	return ranked
+}
+
+// HourlyPricing charges a flat $25/hour, scaled by urgency
+type HourlyPricing struct{}
+
+// Name implements PricingRule
+func (HourlyPricing) Name() string { return "hourly_v1" }
+
+// Price implements PricingRule
+func (HourlyPricing) Price(job Job) float64 {
+	return roundCents(25.0 * float64(job.DurationHours) * urgencyMultiplier(job.Urgency))
+}
+
+// categoryHourlyRates are CategoryPricing's hourly rates; other categories use
+// defaultHourlyRate
+var categoryHourlyRates = map[string]float64{
+	"cleaning":    25.0,
+	"moving":      30.0,
+	"landscaping": 30.0,
+	"handyman":    35.0,
+	"plumbing":    45.0,
+	"electrical":  50.0,
+}
+
+const defaultHourlyRate = 25.0
+
+// CategoryPricing charges a per-category hourly rate with a one-hour minimum,
+// scaled by urgency
+type CategoryPricing struct{}
+
+// Name implements PricingRule
+func (CategoryPricing) Name() string { return "category_v2" }
+
+// Price implements PricingRule
+func (CategoryPricing) Price(job Job) float64 {
+	rate, ok := categoryHourlyRates[strings.ToLower(strings.TrimSpace(job.Category))]
+	if !ok {
+		rate = defaultHourlyRate
+	}
+	hours := job.DurationHours
+	if hours < 1 {
+		hours = 1
+	}
+	return roundCents(rate * float64(hours) * urgencyMultiplier(job.Urgency))
+}
+
+// RatingMatcher picks the highest rated candidate, the earliest on ties. Unrated
+// candidates are never picked.
+type RatingMatcher struct{}
+
+// Name implements MatchingEngine
+func (RatingMatcher) Name() string { return "rating_v1" }
+
+// Match implements MatchingEngine
+func (RatingMatcher) Match(job Job, candidates []Candidate) (int, bool) {
+	return bestScore(candidates, func(c Candidate) float64 { return c.Rating })
+}
+
+// SkillMatcher scores candidates by rating plus a point for each word of the job's
+// category found in their skills and half a point for working in the job's city
+type SkillMatcher struct{}
+
+// Name implements MatchingEngine
+func (SkillMatcher) Name() string { return "skill_v2" }
+
+// Match implements MatchingEngine
+func (SkillMatcher) Match(job Job, candidates []Candidate) (int, bool) {
+	categoryWords := strings.Fields(strings.ToLower(job.Category))
+	jobCity := city(job.Location)
+
+	return bestScore(candidates, func(c Candidate) float64 {
+		score := c.Rating
+		skills := strings.ToLower(c.Skills)
+		for _, word := range categoryWords {
+			if strings.Contains(skills, word) {
+				score++
+			}
+		}
+		if jobCity != "" && city(c.Location) == jobCity {
+			score += 0.5
+		}
+		return score
+	})
+}
+
+// declinePenalty is taken off a candidate's score for each recent offer they declined
+// as too far or paying too little, up to maxDeclinePenalty
+const (
+	declinePenalty    = 0.25
+	maxDeclinePenalty = 1.0
+)
+
+// DeclineAwareMatcher scores candidates by rating less a penalty for offers they
+// recently declined as too far away or paying too little, so workers who keep turning
+// similar jobs down are offered them later
+type DeclineAwareMatcher struct{}
+
+// Name implements MatchingEngine
+func (DeclineAwareMatcher) Name() string { return "decline_aware_v1" }
+
+// Match implements MatchingEngine
+func (DeclineAwareMatcher) Match(job Job, candidates []Candidate) (int, bool) {
+	return bestScore(candidates, func(c Candidate) float64 {
+		penalty := declinePenalty * float64(c.DeclinedTooFar+c.DeclinedPayTooLow)
+		return c.Rating - min(penalty, maxDeclinePenalty)
+	})
+}
+
+// Presence boosts added to a candidate's score: a worker with the app open is likelier
+// to answer an offer before it expires
+const (
+	onlineBoost         = 0.5
+	recentlyActiveBoost = 0.25
+)
+
+// PresenceMatcher scores candidates by rating plus a boost for being online now or
+// recently active, so offers go first to workers likely to see them
+type PresenceMatcher struct{}
+
+// Name implements MatchingEngine
+func (PresenceMatcher) Name() string { return "presence_v1" }
+
+// Match implements MatchingEngine
+func (PresenceMatcher) Match(job Job, candidates []Candidate) (int, bool) {
+	return bestScore(candidates, func(c Candidate) float64 {
+		if c.Rating <= 0 {
+			return 0
+		}
+		switch c.Presence {
+		case presence.Online:
+			return c.Rating + onlineBoost
+		case presence.RecentlyActive:
+			return c.Rating + recentlyActiveBoost
+		}
+		return c.Rating
+	})
+}
+
+// city returns the city of a "street, city, state zip" address, lowercased
+func city(address string) string {
+	parts := strings.Split(address, ",")
+	if len(parts) < 3 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(parts[len(parts)-2]))
+}
+
+// bestScore returns the candidate with the highest positive score, the earliest on ties
+func bestScore(candidates []Candidate, score func(Candidate) float64) (int, bool) {
+	bestID := 0
+	var best float64
+	for _, c := range candidates {
+		if s := score(c); s > best {
+			bestID, best = c.WorkerID, s
+		}
+	}
+	return bestID, bestID != 0
+}
@@ -0,0 +1,112 @@
+package dispatch
+
+import (
+	"slices"
+	"testing"
+
+	"app/internal/presence"
+)
+
+func TestPricingRules(t *testing.T) {
+	tests := []struct {
+		name string
+		rule PricingRule
+		job  Job
+		want float64
+	}{
+		{"hourly medium urgency", HourlyPricing{}, Job{DurationHours: 3, Urgency: "medium"}, 82.5},
+		{"hourly urgent", HourlyPricing{}, Job{DurationHours: 2, Urgency: "urgent"}, 75},
+		{"hourly ignores category", HourlyPricing{}, Job{Category: "electrical", DurationHours: 1}, 25},
+		{"category rate", CategoryPricing{}, Job{Category: "Plumbing", DurationHours: 2, Urgency: "high"}, 117},
+		{"category default rate", CategoryPricing{}, Job{Category: "pet sitting", DurationHours: 2}, 50},
+		{"category one hour minimum", CategoryPricing{}, Job{Category: "electrical"}, 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Price(tt.job); got != tt.want {
+				t.Errorf("%s.Price() = %v, want %v", tt.rule.Name(), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchingEngines(t *testing.T) {
+	candidates := []Candidate{
+		{WorkerID: 1, Skills: "House cleaning", Location: "1 Main St, Austin, TX 78701", Rating: 5},
+		{WorkerID: 2, Skills: "Licensed plumbing and repairs", Location: "9 Oak Ave, Dallas, TX 75201", Rating: 5},
+		{WorkerID: 3, Skills: "Plumbing", Location: "4 Elm St, Austin, TX 78702", Rating: 5},
+	}
+	job := Job{Category: "plumbing", Location: "7 Pine Rd, Austin, TX 78703"}
+
+	tests := []struct {
+		name       string
+		engine     MatchingEngine
+		candidates []Candidate
+		wantID     int
+		wantOK     bool
+	}{
+		{"rating takes earliest on ties", RatingMatcher{}, candidates, 1, true},
+		{"rating skips unrated", RatingMatcher{}, []Candidate{{WorkerID: 4}}, 0, false},
+		{"skill prefers skill and city", SkillMatcher{}, candidates, 3, true},
+		{"no candidates", SkillMatcher{}, nil, 0, false},
+		{"decline aware passes over decliners", DeclineAwareMatcher{}, []Candidate{
+			{WorkerID: 1, Rating: 5, DeclinedTooFar: 2},
+			{WorkerID: 2, Rating: 4.8, DeclinedPayTooLow: 1},
+			{WorkerID: 3, Rating: 4.7},
+		}, 3, true},
+		{"decline penalty is capped", DeclineAwareMatcher{}, []Candidate{
+			{WorkerID: 1, Rating: 5, DeclinedTooFar: 10},
+			{WorkerID: 2, Rating: 3.9},
+		}, 1, true},
+		{"presence prefers online workers", PresenceMatcher{}, []Candidate{
+			{WorkerID: 1, Rating: 5},
+			{WorkerID: 2, Rating: 4.8, Presence: presence.RecentlyActive},
+			{WorkerID: 3, Rating: 4.6, Presence: presence.Online},
+		}, 3, true},
+		{"presence boost does not outweigh ratings", PresenceMatcher{}, []Candidate{
+			{WorkerID: 1, Rating: 5},
+			{WorkerID: 2, Rating: 4.4, Presence: presence.Online},
+		}, 1, true},
+		{"presence skips unrated", PresenceMatcher{}, []Candidate{{WorkerID: 4, Presence: presence.Online}}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotID, gotOK := tt.engine.Match(job, tt.candidates)
+			if gotID != tt.wantID || gotOK != tt.wantOK {
+				t.Errorf("%s.Match() = (%d, %v), want (%d, %v)", tt.engine.Name(), gotID, gotOK, tt.wantID, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	candidates := []Candidate{
+		{WorkerID: 1, Skills: "House cleaning", Location: "1 Main St, Austin, TX 78701", Rating: 4},
+		{WorkerID: 2, Skills: "Licensed plumbing and repairs", Location: "9 Oak Ave, Dallas, TX 75201", Rating: 5},
+		{WorkerID: 3, Skills: "Plumbing", Location: "4 Elm St, Austin, TX 78702", Rating: 5},
+		{WorkerID: 4, Skills: "Plumbing"},
+	}
+	job := Job{Category: "plumbing", Location: "7 Pine Rd, Austin, TX 78703"}
+
+	tests := []struct {
+		name   string
+		engine MatchingEngine
+		n      int
+		want   []int
+	}{
+		{"by rating", RatingMatcher{}, 3, []int{2, 3, 1}},
+		{"stops at unrated", RatingMatcher{}, 10, []int{2, 3, 1}},
+		{"by skill and city", SkillMatcher{}, 2, []int{3, 2}},
+		{"none wanted", SkillMatcher{}, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Rank(tt.engine, job, candidates, tt.n); !slices.Equal(got, tt.want) {
+				t.Errorf("Rank(%s, %d) = %v, want %v", tt.engine.Name(), tt.n, got, tt.want)
+			}
+		})
+	}
+}
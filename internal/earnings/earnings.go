@@ -0,0 +1,192 @@
+// Package earnings totals what gig workers earned for tax reporting. A job payment
+// earns its price: the amount captured less tax and reimbursed expenses and parts,
+// plus any account credit GigCo funded. Platform and processing fees are withheld
+// from it, and tips are earned in full. Refunded payments earn nothing.
+package earnings
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"app/internal/model"
+)
+
+// Payment is what a worker earned from one captured payment
+type Payment struct {
+	CapturedAt     time.Time
+	Gross          model.Money // Zero for tips
+	Tips           model.Money
+	PlatformFees   model.Money
+	ProcessingFees model.Money
+	Reimbursements model.Money
+}
+
+// ReportingThreshold is the least nonemployee compensation a Form 1099-NEC is issued
+// for: $600 through 2025 and $2,000 from 2026
+func ReportingThreshold(year int) model.Money {
+	if year <= 2025 {
+		return model.USD(60000)
+	}
+	return model.USD(200000)
+}
+
+// paymentsSQL selects each captured, unrefunded payment to a worker in [$1, $2) in
+// currency $3, with what it earned
+const paymentsSQL = `
+	SELECT t.gig_worker_id AS worker_id, t.captured_at,
+	       CASE WHEN s.tips IS NOT NULL THEN 0
+	            ELSE COALESCE(t.capture_amount, t.amount)
+	                 - COALESCE((t.metadata->>'tax')::numeric, 0)
+	                 + COALESCE((t.metadata->>'credits')::numeric, 0)
+	                 - COALESCE(s.reimbursed, 0)
+	       END AS gross,
+	       COALESCE(s.tips, 0) AS tips,
+	       COALESCE(t.platform_fee, 0) AS platform_fees,
+	       COALESCE(t.processing_fee, 0) AS processing_fees,
+	       COALESCE(s.reimbursed, 0) AS reimbursed
+	FROM transactions t
+	LEFT JOIN (
+		SELECT transaction_id,
+		       SUM(amount) FILTER (WHERE split_type IN ('expense_reimbursement', 'materials')) AS reimbursed,
+		       SUM(amount) FILTER (WHERE split_type = 'tip') AS tips
+		FROM payment_splits
+		GROUP BY transaction_id
+	) s ON s.transaction_id = t.id
+	WHERE t.gig_worker_id IS NOT NULL
+	  AND t.captured_at >= $1 AND t.captured_at < $2
+	  AND t.refunded_at IS NULL
+	  AND t.transaction_type IN ('authorization', 'capture', 'charge', 'adjustment')
+	  AND COALESCE(t.currency, 'USD') = $3
+`
+
+// yearRange is the tax year in UTC
+func yearRange(year int) (time.Time, time.Time) {
+	from := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	return from, from.AddDate(1, 0, 0)
+}
+
+// WorkerYear totals a worker's earnings in currency for a tax year
+func WorkerYear(ctx context.Context, db *sql.DB, workerID, year int, currency string) (*model.WorkerEarnings, error) {
+	from, to := yearRange(year)
+	rows, err := db.QueryContext(ctx, `
+		SELECT captured_at, gross, tips, platform_fees, processing_fees, reimbursed
+		FROM (`+paymentsSQL+`) p
+		WHERE worker_id = $4
+		ORDER BY captured_at
+	`, from, to, currency, workerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worker payments: %w", err)
+	}
+	defer rows.Close()
+
+	var payments []Payment
+	for rows.Next() {
+		var p Payment
+		if err := rows.Scan(&p.CapturedAt, &p.Gross, &p.Tips, &p.PlatformFees, &p.ProcessingFees, &p.Reimbursements); err != nil {
+			return nil, fmt.Errorf("failed to scan worker payment: %w", err)
+		}
+		payments = append(payments, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return Summarize(workerID, year, currency, payments), nil
+}
+
+// Summarize totals payments into a year of monthly earnings
+func Summarize(workerID, year int, currency string, payments []Payment) *model.WorkerEarnings {
+	zero := model.NewMoney(0, currency)
+	e := &model.WorkerEarnings{
+		WorkerID:           workerID,
+		Year:               year,
+		Currency:           currency,
+		GrossEarnings:      zero,
+		Tips:               zero,
+		PlatformFees:       zero,
+		ProcessingFees:     zero,
+		NetEarnings:        zero,
+		Reimbursements:     zero,
+		ReportingThreshold: ReportingThreshold(year),
+		Months:             make([]model.MonthlyEarnings, 12),
+	}
+	for i := range e.Months {
+		e.Months[i] = model.MonthlyEarnings{
+			Month:          fmt.Sprintf("%d-%02d", year, i+1),
+			GrossEarnings:  zero,
+			Tips:           zero,
+			PlatformFees:   zero,
+			ProcessingFees: zero,
+			NetEarnings:    zero,
+			Reimbursements: zero,
+		}
+	}
+
+	for _, p := range payments {
+		// Amounts scan in the default currency
+		gross, tips := p.Gross.In(currency), p.Tips.In(currency)
+		platformFees, processingFees := p.PlatformFees.In(currency), p.ProcessingFees.In(currency)
+		reimbursements := p.Reimbursements.In(currency)
+		net := gross.Add(tips).Sub(platformFees).Sub(processingFees)
+
+		m := &e.Months[p.CapturedAt.UTC().Month()-1]
+		if !gross.IsZero() {
+			m.JobsPaid++
+			e.JobsPaid++
+		}
+		m.GrossEarnings = m.GrossEarnings.Add(gross)
+		m.Tips = m.Tips.Add(tips)
+		m.PlatformFees = m.PlatformFees.Add(platformFees)
+		m.ProcessingFees = m.ProcessingFees.Add(processingFees)
+		m.NetEarnings = m.NetEarnings.Add(net)
+		m.Reimbursements = m.Reimbursements.Add(reimbursements)
+
+		e.GrossEarnings = e.GrossEarnings.Add(gross)
+		e.Tips = e.Tips.Add(tips)
+		e.PlatformFees = e.PlatformFees.Add(platformFees)
+		e.ProcessingFees = e.ProcessingFees.Add(processingFees)
+		e.NetEarnings = e.NetEarnings.Add(net)
+		e.Reimbursements = e.Reimbursements.Add(reimbursements)
+	}
+
+	e.ReportableAmount = e.GrossEarnings.Add(e.Tips)
+	e.Form1099NEC = currency == model.DefaultCurrency && e.ReportableAmount.Cents >= e.ReportingThreshold.Cents
+	return e
+}
+
+// Form1099NEC lists the workers paid at least threshold in USD nonemployee
+// compensation in a tax year, by name
+func Form1099NEC(ctx context.Context, db *sql.DB, year int, threshold model.Money) ([]model.Form1099NECRecipient, error) {
+	from, to := yearRange(year)
+	rows, err := db.QueryContext(ctx, `
+		SELECT w.id, w.uuid, w.name, w.email, w.address,
+		       COUNT(*) FILTER (WHERE p.gross <> 0),
+		       SUM(p.gross) + SUM(p.tips), SUM(p.tips),
+		       SUM(p.platform_fees), SUM(p.processing_fees),
+		       SUM(p.gross) + SUM(p.tips) - SUM(p.platform_fees) - SUM(p.processing_fees),
+		       SUM(p.reimbursed)
+		FROM (`+paymentsSQL+`) p
+		JOIN people w ON w.id = p.worker_id
+		GROUP BY w.id
+		HAVING SUM(p.gross) + SUM(p.tips) >= $4
+		ORDER BY w.name, w.id
+	`, from, to, model.DefaultCurrency, threshold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get 1099-NEC recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var recipients []model.Form1099NECRecipient
+	for rows.Next() {
+		var r model.Form1099NECRecipient
+		err := rows.Scan(&r.WorkerID, &r.WorkerUUID, &r.Name, &r.Email, &r.Address,
+			&r.JobsPaid, &r.NonemployeeCompensation, &r.Tips,
+			&r.PlatformFees, &r.ProcessingFees, &r.NetEarnings, &r.Reimbursements)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan 1099-NEC recipient: %w", err)
+		}
+		recipients = append(recipients, r)
+	}
+	return recipients, rows.Err()
+}
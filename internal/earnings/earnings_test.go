@@ -0,0 +1,104 @@
+package earnings
+
+import (
+	"testing"
+	"time"
+
+	"app/internal/model"
+)
+
+func TestSummarize(t *testing.T) {
+	at := func(month time.Month, day int) time.Time { return time.Date(2026, month, day, 12, 0, 0, 0, time.UTC) }
+	payments := []Payment{
+		{CapturedAt: at(1, 5), Gross: model.USD(100000), PlatformFees: model.USD(15000), ProcessingFees: model.USD(3000), Reimbursements: model.USD(2000)},
+		{CapturedAt: at(1, 20), Tips: model.USD(2500)},
+		{CapturedAt: at(3, 1), Gross: model.USD(150000), PlatformFees: model.USD(22500), ProcessingFees: model.USD(4500)},
+	}
+
+	tests := []struct {
+		name         string
+		currency     string
+		payments     []Payment
+		wantGross    model.Money
+		wantNet      model.Money
+		wantJobs     int
+		wantReported bool
+	}{
+		{
+			name:         "USD earnings above the threshold",
+			currency:     "USD",
+			payments:     payments,
+			wantGross:    model.USD(250000),
+			wantNet:      model.USD(207500),
+			wantJobs:     2,
+			wantReported: true,
+		},
+		{
+			name:      "USD earnings below the threshold",
+			currency:  "USD",
+			payments:  payments[:2],
+			wantGross: model.USD(100000),
+			wantNet:   model.USD(84500),
+			wantJobs:  1,
+		},
+		{
+			name:      "other currencies are never reported",
+			currency:  "EUR",
+			payments:  payments,
+			wantGross: model.NewMoney(250000, "EUR"),
+			wantNet:   model.NewMoney(207500, "EUR"),
+			wantJobs:  2,
+		},
+		{
+			name:      "no payments",
+			currency:  "USD",
+			wantGross: model.USD(0),
+			wantNet:   model.USD(0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Summarize(7, 2026, tt.currency, tt.payments)
+			if got.GrossEarnings != tt.wantGross || got.NetEarnings != tt.wantNet || got.JobsPaid != tt.wantJobs {
+				t.Errorf("Summarize() gross = %v, net = %v, jobs = %d; want %v, %v, %d",
+					got.GrossEarnings, got.NetEarnings, got.JobsPaid, tt.wantGross, tt.wantNet, tt.wantJobs)
+			}
+			if got.Form1099NEC != tt.wantReported {
+				t.Errorf("Summarize() form_1099_nec = %v, want %v", got.Form1099NEC, tt.wantReported)
+			}
+			if len(got.Months) != 12 || got.Months[11].Month != "2026-12" {
+				t.Fatalf("Summarize() months = %d, want all 12", len(got.Months))
+			}
+
+			var monthlyNet int64
+			for _, m := range got.Months {
+				monthlyNet += m.NetEarnings.Cents
+			}
+			if monthlyNet != got.NetEarnings.Cents {
+				t.Errorf("monthly net earnings sum to %d, year is %d", monthlyNet, got.NetEarnings.Cents)
+			}
+		})
+	}
+
+	january := Summarize(7, 2026, "USD", payments).Months[0]
+	if january.JobsPaid != 1 || january.Tips != model.USD(2500) || january.Reimbursements != model.USD(2000) {
+		t.Errorf("January = %+v, want one job, $25.00 tips and $20.00 reimbursed", january)
+	}
+}
+
+func TestReportingThreshold(t *testing.T) {
+	tests := []struct {
+		year int
+		want model.Money
+	}{
+		{2024, model.USD(60000)},
+		{2025, model.USD(60000)},
+		{2026, model.USD(200000)},
+	}
+	for _, tt := range tests {
+		if got := ReportingThreshold(tt.year); got != tt.want {
+			t.Errorf("ReportingThreshold(%d) = %v, want %v", tt.year, got, tt.want)
+		}
+	}
+}
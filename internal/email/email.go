@@ -1,37 +1,65 @@
 package email
 
 import (
-	"bytes"
+	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"html/template"
-	"net/http"
+	"log/slog"
 	"os"
+	"strings"
 	"time"
+
+	"app/internal/model"
+	"app/internal/notifications"
+)
+
+// Kinds of email, recorded in the delivery ledger
+const (
+	KindMessage           = "message"
+	KindVerification      = "email_verification"
+	KindPasswordReset     = "password_reset"
+	KindPasswordChanged   = "password_changed"
+	KindJobNotification   = "job_notification"
+	KindWaitlistInvite    = "waitlist_invite"
+	KindAccountWinBack    = "account_win_back"
+	KindAccountDataExport = "account_data_export"
+	KindDataExport        = "data_export"
+	KindPaymentDocument   = "payment_document"
+	KindDocumentExpiry    = "document_expiry"
+	KindNotification      = "notification"
+	KindReviewRequest     = "review_request"
 )
 
+// ledger records every email sent and retries transient failures; nil sends directly
+var ledger *notifications.Ledger
+
+// UseLedger routes every email sent through l from now on
+func UseLedger(l *notifications.Ledger) {
+	ledger = l
+}
+
 // Service handles email sending operations
 type Service struct {
-	apiKey     string
-	fromEmail  string
-	fromName   string
-	baseURL    string
-	httpClient *http.Client
+	fromEmail string
+	fromName  string
+	primary   Sender
+	fallback  Sender // nil without failover
 }
 
 // Config holds email service configuration
 type Config struct {
-	APIKey    string
-	FromEmail string
-	FromName  string
-	Provider  string // "sendgrid" or "ses" (future)
+	APIKey           string // SendGrid API key
+	FromEmail        string
+	FromName         string
+	Provider         string // "sendgrid" (default) or "ses"
+	FallbackProvider string // Sent through when Provider fails with a 5xx; empty for none
+	SES              SESConfig
 }
 
 // NewService creates a new email service
 func NewService(cfg Config) (*Service, error) {
-	if cfg.APIKey == "" {
-		return nil, fmt.Errorf("email API key is required")
-	}
 	if cfg.FromEmail == "" {
 		return nil, fmt.Errorf("from email is required")
 	}
@@ -41,110 +69,118 @@ func NewService(cfg Config) (*Service, error) {
 		fromName = "GigCo"
 	}
 
-	return &Service{
-		apiKey:    cfg.APIKey,
+	primary, err := newSender(cfg.Provider, cfg)
+	if err != nil {
+		return nil, err
+	}
+	s := &Service{
 		fromEmail: cfg.FromEmail,
 		fromName:  fromName,
-		baseURL:   "https://api.sendgrid.com/v3",
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-	}, nil
+		primary:   primary,
+	}
+	if cfg.FallbackProvider != "" && cfg.FallbackProvider != primary.Provider() {
+		if s.fallback, err = newSender(cfg.FallbackProvider, cfg); err != nil {
+			return nil, fmt.Errorf("fallback email provider: %w", err)
+		}
+	}
+	return s, nil
 }
 
 // NewServiceFromEnv creates email service from environment variables
 func NewServiceFromEnv() (*Service, error) {
 	return NewService(Config{
-		APIKey:    os.Getenv("SENDGRID_API_KEY"),
-		FromEmail: os.Getenv("EMAIL_FROM"),
-		FromName:  os.Getenv("EMAIL_FROM_NAME"),
-		Provider:  "sendgrid",
+		APIKey:           os.Getenv("SENDGRID_API_KEY"),
+		FromEmail:        os.Getenv("EMAIL_FROM"),
+		FromName:         os.Getenv("EMAIL_FROM_NAME"),
+		Provider:         os.Getenv("EMAIL_PROVIDER"),
+		FallbackProvider: os.Getenv("EMAIL_FALLBACK_PROVIDER"),
+		SES: SESConfig{
+			Region:           os.Getenv("AWS_REGION"),
+			AccessKeyID:      os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey:  os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:     os.Getenv("AWS_SESSION_TOKEN"),
+			ConfigurationSet: os.Getenv("SES_CONFIGURATION_SET"),
+		},
 	})
 }
 
-// SendGridRequest represents a SendGrid API request
-type SendGridRequest struct {
-	Personalizations []Personalization `json:"personalizations"`
-	From             EmailAddress      `json:"from"`
-	Subject          string            `json:"subject"`
-	Content          []Content         `json:"content"`
-}
-
-// Personalization represents email recipients
-type Personalization struct {
-	To []EmailAddress `json:"to"`
-}
-
-// EmailAddress represents an email address
-type EmailAddress struct {
-	Email string `json:"email"`
-	Name  string `json:"name,omitempty"`
-}
-
-// Content represents email content
-type Content struct {
-	Type  string `json:"type"`
-	Value string `json:"value"`
-}
-
 // Send sends an email
 func (s *Service) Send(to, toName, subject, htmlContent, textContent string) error {
-	request := SendGridRequest{
-		Personalizations: []Personalization{
-			{
-				To: []EmailAddress{
-					{Email: to, Name: toName},
-				},
-			},
-		},
-		From: EmailAddress{
-			Email: s.fromEmail,
-			Name:  s.fromName,
-		},
-		Subject: subject,
-		Content: []Content{},
-	}
+	return s.send(KindMessage, to, toName, subject, htmlContent, textContent)
+}
 
-	// Add text content if provided
-	if textContent != "" {
-		request.Content = append(request.Content, Content{
-			Type:  "text/plain",
-			Value: textContent,
-		})
+// send skips suppressed addresses, then sends the message through the delivery ledger,
+// when one is in use, so it is recorded and retried if the providers are briefly
+// unavailable
+func (s *Service) send(kind, to, toName, subject, htmlContent, textContent string, attachments ...Attachment) error {
+	ctx := context.Background()
+	if suppressions != nil {
+		suppressed, err := suppressions.IsSuppressed(ctx, to)
+		if err != nil {
+			// The list protects sender reputation; a lookup failure shouldn't lose the email
+			slog.ErrorContext(ctx, "Failed to check email suppression list", "kind", kind, "error", err)
+		} else if suppressed {
+			return fmt.Errorf("%w: %s", ErrRecipientSuppressed, to)
+		}
 	}
 
-	// Add HTML content
-	if htmlContent != "" {
-		request.Content = append(request.Content, Content{
-			Type:  "text/html",
-			Value: htmlContent,
-		})
+	msg := Message{
+		From:        EmailAddress{Email: s.fromEmail, Name: s.fromName},
+		To:          EmailAddress{Email: to, Name: toName},
+		Subject:     subject,
+		Text:        textContent,
+		HTML:        htmlContent,
+		Attachments: attachments,
 	}
-
-	jsonData, err := json.Marshal(request)
+	jsonData, err := json.Marshal(msg)
 	if err != nil {
-		return fmt.Errorf("failed to marshal email request: %w", err)
+		return fmt.Errorf("failed to marshal email: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", s.baseURL+"/mail/send", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+	if ledger == nil {
+		_, err := s.Deliver(ctx, jsonData)
+		return err
 	}
+	return ledger.Send(ctx, notifications.Message{
+		Channel:   model.DeliveryChannelEmail,
+		Kind:      kind,
+		Recipient: to,
+		Subject:   subject,
+		Payload:   jsonData,
+	}, s)
+}
 
-	req.Header.Set("Authorization", "Bearer "+s.apiKey)
-	req.Header.Set("Content-Type", "application/json")
+// Provider returns the primary provider key, recorded in the delivery ledger until a
+// send succeeds through the fallback
+func (s *Service) Provider() string {
+	return s.primary.Provider()
+}
 
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send email: %w", err)
+// Providers returns the keys of every provider the service sends through, so retries
+// of deliveries recorded against any of them are sent by it
+func (s *Service) Providers() []string {
+	if s.fallback == nil {
+		return []string{s.primary.Provider()}
 	}
-	defer resp.Body.Close()
+	return []string{s.primary.Provider(), s.fallback.Provider()}
+}
 
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("email API returned status %d", resp.StatusCode)
-	}
+// Deliver sends a marshaled Message and returns the provider's message ID. Network
+// errors, rate limiting and server errors are transient.
+func (s *Service) Deliver(ctx context.Context, payload []byte) (string, error) {
+	_, messageID, err := s.DeliverVia(ctx, payload)
+	return messageID, err
+}
 
-	return nil
+// DeliverVia sends a marshaled Message, failing over to the fallback provider when the
+// primary is unavailable, and returns the provider that accepted it with its message
+// ID. It implements notifications.FailoverSender.
+func (s *Service) DeliverVia(ctx context.Context, payload []byte) (string, string, error) {
+	msg, err := decodeMessage(payload)
+	if err != nil {
+		return s.primary.Provider(), "", err
+	}
+	return s.deliver(ctx, msg)
 }
 
 // VerificationEmailData holds data for verification email template
@@ -169,14 +205,7 @@ func (s *Service) SendVerificationEmail(to, userName, token string) error {
 
 	htmlContent, err := renderTemplate("verification", data)
 	if err != nil {
-		// Fallback to simple HTML
-		htmlContent = fmt.Sprintf(`
-			<h1>Welcome to GigCo, %s!</h1>
-			<p>Please verify your email address by clicking the link below:</p>
-			<p><a href="%s">Verify Email Address</a></p>
-			<p>This link will expire in %d hours.</p>
-			<p>If you didn't create an account with GigCo, please ignore this email.</p>
-		`, data.UserName, data.VerificationLink, data.ExpirationHours)
+		return err
 	}
 
 	textContent := fmt.Sprintf(
@@ -184,7 +213,7 @@ func (s *Service) SendVerificationEmail(to, userName, token string) error {
 		data.UserName, data.VerificationLink, data.ExpirationHours,
 	)
 
-	return s.Send(to, userName, "Verify your GigCo email address", htmlContent, textContent)
+	return s.send(KindVerification, to, userName, "Verify your GigCo email address", htmlContent, textContent)
 }
 
 // PasswordResetData holds data for password reset email template
@@ -195,32 +224,18 @@ type PasswordResetData struct {
 	IPAddress       string
 }
 
-// SendPasswordResetEmail sends a password reset email
-func (s *Service) SendPasswordResetEmail(to, userName, token, ipAddress string) error {
-	baseURL := os.Getenv("APP_BASE_URL")
-	if baseURL == "" {
-		baseURL = "https://app.gigco.com"
-	}
-
+// SendPasswordResetEmail sends a password reset email with the reset deep link
+func (s *Service) SendPasswordResetEmail(to, userName, resetLink, ipAddress string) error {
 	data := PasswordResetData{
 		UserName:       userName,
-		ResetLink:      fmt.Sprintf("%s/reset-password?token=%s", baseURL, token),
+		ResetLink:      resetLink,
 		ExpirationMins: 30,
 		IPAddress:      ipAddress,
 	}
 
 	htmlContent, err := renderTemplate("password_reset", data)
 	if err != nil {
-		// Fallback to simple HTML
-		htmlContent = fmt.Sprintf(`
-			<h1>Password Reset Request</h1>
-			<p>Hi %s,</p>
-			<p>We received a request to reset your password. Click the link below to set a new password:</p>
-			<p><a href="%s">Reset Password</a></p>
-			<p>This link will expire in %d minutes.</p>
-			<p>If you didn't request a password reset, please ignore this email or contact support if you're concerned.</p>
-			<p><small>Request originated from IP: %s</small></p>
-		`, data.UserName, data.ResetLink, data.ExpirationMins, data.IPAddress)
+		return err
 	}
 
 	textContent := fmt.Sprintf(
@@ -228,7 +243,33 @@ func (s *Service) SendPasswordResetEmail(to, userName, token, ipAddress string)
 		data.UserName, data.ResetLink, data.ExpirationMins, data.IPAddress,
 	)
 
-	return s.Send(to, userName, "Reset your GigCo password", htmlContent, textContent)
+	return s.send(KindPasswordReset, to, userName, "Reset your GigCo password", htmlContent, textContent)
+}
+
+// SendPasswordChangedEmail tells the account owner their password was changed, so they
+// can act if they did not change it
+func (s *Service) SendPasswordChangedEmail(to, userName, ipAddress string, changedAt time.Time) error {
+	baseURL := os.Getenv("APP_BASE_URL")
+	if baseURL == "" {
+		baseURL = "https://app.gigco.com"
+	}
+	resetLink := baseURL + "/forgot-password"
+	when := changedAt.UTC().Format("January 2, 2006 at 15:04 UTC")
+
+	htmlContent := fmt.Sprintf(`
+		<h1>Your password was changed</h1>
+		<p>Hi %s,</p>
+		<p>The password for your GigCo account was changed on %s. Your other devices have been signed out.</p>
+		<p>If this wasn't you, <a href="%s">reset your password</a> right away and contact support.</p>
+		<p><small>Change made from IP: %s</small></p>
+	`, userName, when, resetLink, ipAddress)
+
+	textContent := fmt.Sprintf(
+		"Hi %s,\n\nThe password for your GigCo account was changed on %s. Your other devices have been signed out.\n\nIf this wasn't you, reset your password right away: %s\n\nChange made from IP: %s",
+		userName, when, resetLink, ipAddress,
+	)
+
+	return s.send(KindPasswordChanged, to, userName, "Your GigCo password was changed", htmlContent, textContent)
 }
 
 // JobNotificationData holds data for job notification emails
@@ -256,23 +297,191 @@ func (s *Service) SendJobNotification(to, userName string, data JobNotificationD
 		data.UserName, data.Message, data.JobTitle, data.ActionLink,
 	)
 
-	return s.Send(to, userName, fmt.Sprintf("GigCo: %s", data.JobTitle), htmlContent, textContent)
+	return s.send(KindJobNotification, to, userName, fmt.Sprintf("GigCo: %s", data.JobTitle), htmlContent, textContent)
 }
 
-// renderTemplate renders an email template
-func renderTemplate(name string, data interface{}) (string, error) {
-	templatePath := fmt.Sprintf("templates/email/%s.html", name)
-	tmpl, err := template.ParseFiles(templatePath)
+// SendPaymentDocument emails a receipt or earnings statement as an attachment
+func (s *Service) SendPaymentDocument(to, userName, subject, message string, document Attachment) error {
+	htmlContent := fmt.Sprintf(`
+		<h1>%s</h1>
+		<p>Hi %s,</p>
+		<p>%s</p>
+		<p>You can also download it from the payment in the GigCo app.</p>
+	`, template.HTMLEscapeString(subject), template.HTMLEscapeString(userName), template.HTMLEscapeString(message))
+
+	textContent := fmt.Sprintf(
+		"Hi %s,\n\n%s\n\nYou can also download it from the payment in the GigCo app.",
+		userName, message,
+	)
+
+	return s.send(KindPaymentDocument, to, userName, subject, htmlContent, textContent, document)
+}
+
+// SendWaitlistInvite invites a waitlist signup to join once their market is live
+func (s *Service) SendWaitlistInvite(to, marketName, role string) error {
+	baseURL := os.Getenv("APP_BASE_URL")
+	if baseURL == "" {
+		baseURL = "https://app.gigco.com"
+	}
+	signupLink := fmt.Sprintf("%s/register?role=%s", baseURL, role)
+
+	htmlContent := fmt.Sprintf(`
+		<h1>GigCo is now live in %s!</h1>
+		<p>Thanks for joining the waitlist. You can now create your account and get started.</p>
+		<p><a href="%s">Create your account</a></p>
+	`, marketName, signupLink)
+
+	textContent := fmt.Sprintf(
+		"GigCo is now live in %s!\n\nThanks for joining the waitlist. Create your account here: %s",
+		marketName, signupLink,
+	)
+
+	return s.send(KindWaitlistInvite, to, "", fmt.Sprintf("GigCo is now live in %s", marketName), htmlContent, textContent)
+}
+
+// SendAccountWinBack reminds a user their deactivated account will soon be erased
+func (s *Service) SendAccountWinBack(to, userName string, purgeAfter time.Time, reactivateLink string) error {
+	purgeDate := purgeAfter.Format("January 2, 2006")
+
+	htmlContent := fmt.Sprintf(`
+		<h1>We'd love to have you back, %s</h1>
+		<p>Your GigCo account is deactivated and will be permanently deleted on %s.</p>
+		<p>Changed your mind? Your jobs, reviews and settings are still here.</p>
+		<p><a href="%s">Reactivate my account</a></p>
+		<p>If you'd still like to leave, no action is needed.</p>
+	`, userName, purgeDate, reactivateLink)
+
+	textContent := fmt.Sprintf(
+		"Hi %s,\n\nYour GigCo account is deactivated and will be permanently deleted on %s.\n\nChanged your mind? Reactivate here: %s\n\nIf you'd still like to leave, no action is needed.",
+		userName, purgeDate, reactivateLink,
+	)
+
+	return s.send(KindAccountWinBack, to, userName, "Your GigCo account will be deleted soon", htmlContent, textContent)
+}
+
+// SendAccountDataExport sends a user the link to download the archive of their data,
+// exported before their account is erased
+func (s *Service) SendAccountDataExport(to, userName, downloadLink string, linkExpires, purgeAfter time.Time) error {
+	expiryDate := linkExpires.Format("January 2, 2006")
+	purgeDate := purgeAfter.Format("January 2, 2006")
+
+	htmlContent := fmt.Sprintf(`
+		<h1>Your GigCo data is ready, %s</h1>
+		<p>Before your account is deleted on %s, here is a copy of your profile, jobs, payments, reviews and messages.</p>
+		<p><a href="%s">Download my data</a></p>
+		<p>The link works until %s. The copy is deleted with your account.</p>
+	`, template.HTMLEscapeString(userName), purgeDate, downloadLink, expiryDate)
+
+	textContent := fmt.Sprintf(
+		"Hi %s,\n\nBefore your account is deleted on %s, here is a copy of your profile, jobs, payments, reviews and messages: %s\n\nThe link works until %s. The copy is deleted with your account.",
+		userName, purgeDate, downloadLink, expiryDate,
+	)
+
+	return s.send(KindAccountDataExport, to, userName, "Your GigCo data export", htmlContent, textContent)
+}
+
+// SendDataExportReady sends a user the link to download the archive of their data
+// they requested
+func (s *Service) SendDataExportReady(to, userName, downloadLink string, linkExpires time.Time) error {
+	expiryDate := linkExpires.Format("January 2, 2006")
+
+	htmlContent := fmt.Sprintf(`
+		<h1>Your GigCo data is ready, %s</h1>
+		<p>Here is the copy you asked for of your profile, jobs, payments, reviews and messages.</p>
+		<p><a href="%s">Download my data</a></p>
+		<p>The link works until %s, when the copy is deleted. You can request a new one from your account settings.</p>
+	`, template.HTMLEscapeString(userName), downloadLink, expiryDate)
+
+	textContent := fmt.Sprintf(
+		"Hi %s,\n\nHere is the copy you asked for of your profile, jobs, payments, reviews and messages: %s\n\nThe link works until %s, when the copy is deleted. You can request a new one from your account settings.",
+		userName, downloadLink, expiryDate,
+	)
+
+	return s.send(KindDataExport, to, userName, "Your GigCo data export is ready", htmlContent, textContent)
+}
+
+// SendDocumentExpiryReminder reminds a worker a verification document expires soon, or
+// has expired when expired is true
+func (s *Service) SendDocumentExpiryReminder(to, userName, documentName string, expiresOn time.Time, expired bool, uploadLink string) error {
+	expiryDate := expiresOn.Format("January 2, 2006")
+	subject := fmt.Sprintf("Your %s expires on %s", documentName, expiryDate)
+	status := fmt.Sprintf("Your %s on file with GigCo expires on %s.", documentName, expiryDate)
+	if expired {
+		subject = fmt.Sprintf("Your %s has expired", documentName)
+		status = fmt.Sprintf("Your %s on file with GigCo expired on %s.", documentName, expiryDate)
+	}
+
+	htmlContent := fmt.Sprintf(`
+		<h1>%s</h1>
+		<p>Hi %s,</p>
+		<p>%s Upload a current one to keep your verification up to date.</p>
+		<p><a href="%s">Upload a new document</a></p>
+	`, template.HTMLEscapeString(subject), template.HTMLEscapeString(userName), template.HTMLEscapeString(status), uploadLink)
+
+	textContent := fmt.Sprintf(
+		"Hi %s,\n\n%s Upload a current one to keep your verification up to date: %s",
+		userName, status, uploadLink,
+	)
+
+	return s.send(KindDocumentExpiry, to, userName, subject, htmlContent, textContent)
+}
+
+// SendReviewRequest emails a participant of a finished job the link that submits their
+// review of it
+func (s *Service) SendReviewRequest(to, userName, jobTitle, reviewLink string) error {
+	data := ReviewRequestData{UserName: userName, JobTitle: jobTitle, ReviewLink: reviewLink}
+	htmlContent, err := renderTemplate("review_request", data)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		return "", err
+	textContent := fmt.Sprintf(
+		"Hi %s,\n\nThe job is complete. How did it go? Your review helps the GigCo community.\n\nLeave a review: %s",
+		userName, reviewLink,
+	)
+
+	return s.send(KindReviewRequest, to, userName, fmt.Sprintf("How did \"%s\" go?", jobTitle), htmlContent, textContent)
+}
+
+// SendNotificationEmail emails an in-app notification with its type's template, linking
+// to its action when it has one. It implements notifications.EmailSender.
+func (s *Service) SendNotificationEmail(ctx context.Context, to, userName string, n model.Notification) error {
+	var link string
+	if n.ActionURL != nil && *n.ActionURL != "" {
+		link = *n.ActionURL
+		if strings.HasPrefix(link, "/") {
+			baseURL := os.Getenv("APP_BASE_URL")
+			if baseURL == "" {
+				baseURL = "https://app.gigco.com"
+			}
+			link = strings.TrimRight(baseURL, "/") + link
+		}
 	}
 
-	return buf.String(), nil
+	name, ok := notificationTemplates[n.Type]
+	if !ok {
+		name = "notification"
+	}
+	htmlContent, err := renderTemplate(name, EventEmailData{UserName: userName, Title: n.Title, Message: n.Message, ActionLink: link})
+	if err != nil {
+		return err
+	}
+	textContent := fmt.Sprintf("Hi %s,\n\n%s", userName, n.Message)
+	if link != "" {
+		textContent += "\n\nView in GigCo: " + link
+	}
+
+	return s.send(KindNotification, to, userName, fmt.Sprintf("GigCo: %s", n.Title), htmlContent, textContent)
+}
+
+// NewNotificationDispatcher creates a notification dispatcher that emails through this
+// package when an email provider is configured
+func NewNotificationDispatcher(db *sql.DB) *notifications.Dispatcher {
+	var sender notifications.EmailSender
+	if emailService, err := NewServiceFromEnv(); err == nil {
+		sender = emailService
+	}
+	return notifications.NewDispatcherFromEnv(db, sender)
 }
 
 // MockService is a mock email service for testing
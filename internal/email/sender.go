@@ -0,0 +1,101 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"app/internal/notifications"
+)
+
+// Sender is an email provider. SendEmail hands msg to the provider and returns the
+// message ID its delivery events report against. Failures worth retrying, such as
+// rate limiting and server errors, are wrapped in notifications.TransientError.
+type Sender interface {
+	Provider() string
+	SendEmail(ctx context.Context, msg *Message) (string, error)
+}
+
+// Message is an email as stored in the delivery ledger, independent of the provider
+// that sends it so a retry can go out through another one
+type Message struct {
+	From        EmailAddress `json:"from"`
+	To          EmailAddress `json:"to"`
+	Subject     string       `json:"subject"`
+	Text        string       `json:"text,omitempty"`
+	HTML        string       `json:"html,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// decodeMessage reads a ledger payload. Payloads recorded before the provider-neutral
+// format are SendGrid requests.
+func decodeMessage(payload []byte) (*Message, error) {
+	var probe struct {
+		Personalizations json.RawMessage `json:"personalizations"`
+	}
+	if err := json.Unmarshal(payload, &probe); err != nil {
+		return nil, fmt.Errorf("failed to decode email payload: %w", err)
+	}
+	if probe.Personalizations == nil {
+		var msg Message
+		if err := json.Unmarshal(payload, &msg); err != nil {
+			return nil, fmt.Errorf("failed to decode email payload: %w", err)
+		}
+		return &msg, nil
+	}
+
+	var legacy SendGridRequest
+	if err := json.Unmarshal(payload, &legacy); err != nil {
+		return nil, fmt.Errorf("failed to decode email payload: %w", err)
+	}
+	if len(legacy.Personalizations) == 0 || len(legacy.Personalizations[0].To) == 0 {
+		return nil, errors.New("email payload has no recipient")
+	}
+	msg := &Message{
+		From:        legacy.From,
+		To:          legacy.Personalizations[0].To[0],
+		Subject:     legacy.Subject,
+		Attachments: legacy.Attachments,
+	}
+	for _, c := range legacy.Content {
+		switch c.Type {
+		case "text/plain":
+			msg.Text = c.Value
+		case "text/html":
+			msg.HTML = c.Value
+		}
+	}
+	return msg, nil
+}
+
+// newSender creates the sender for a provider key
+func newSender(provider string, cfg Config) (Sender, error) {
+	switch provider {
+	case "", ProviderSendGrid:
+		return NewSendGridSender(cfg.APIKey)
+	case ProviderSES:
+		return NewSESSender(cfg.SES)
+	default:
+		return nil, fmt.Errorf("unknown email provider %q", provider)
+	}
+}
+
+// deliver sends msg through the primary provider, and through the fallback when the
+// primary fails with a transient error such as a 5xx. It returns the provider that
+// accepted the message.
+func (s *Service) deliver(ctx context.Context, msg *Message) (string, string, error) {
+	messageID, err := s.primary.SendEmail(ctx, msg)
+	if err == nil || s.fallback == nil || !notifications.IsTransient(err) {
+		return s.primary.Provider(), messageID, err
+	}
+
+	slog.WarnContext(ctx, "Email provider unavailable, failing over",
+		"provider", s.primary.Provider(), "fallback", s.fallback.Provider(), "error", err)
+	messageID, fallbackErr := s.fallback.SendEmail(ctx, msg)
+	if fallbackErr != nil {
+		return s.primary.Provider(), "", fmt.Errorf("%w; %s fallback: %w", err, s.fallback.Provider(), fallbackErr)
+	}
+	return s.fallback.Provider(), messageID, nil
+}
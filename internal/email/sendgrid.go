@@ -0,0 +1,125 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"app/internal/notifications"
+)
+
+// ProviderSendGrid is the provider key recorded in the delivery ledger
+const ProviderSendGrid = "sendgrid"
+
+// SendGridSender sends email through SendGrid's v3 Mail Send API
+type SendGridSender struct {
+	apiKey     string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewSendGridSender creates a SendGrid sender
+func NewSendGridSender(apiKey string) (*SendGridSender, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("SendGrid API key is required")
+	}
+	return &SendGridSender{
+		apiKey:  apiKey,
+		baseURL: "https://api.sendgrid.com/v3",
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// SendGridRequest represents a SendGrid API request
+type SendGridRequest struct {
+	Personalizations []Personalization `json:"personalizations"`
+	From             EmailAddress      `json:"from"`
+	Subject          string            `json:"subject"`
+	Content          []Content         `json:"content"`
+	Attachments      []Attachment      `json:"attachments,omitempty"`
+}
+
+// Personalization represents email recipients
+type Personalization struct {
+	To []EmailAddress `json:"to"`
+}
+
+// EmailAddress represents an email address
+type EmailAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+// Content represents email content
+type Content struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Attachment is a file attached to an email. Content is sent base64 encoded.
+type Attachment struct {
+	Content  []byte `json:"content"`
+	Type     string `json:"type"`
+	Filename string `json:"filename"`
+}
+
+// Provider returns the provider key recorded in the delivery ledger
+func (s *SendGridSender) Provider() string {
+	return ProviderSendGrid
+}
+
+// SendEmail posts msg to /mail/send and returns SendGrid's message ID, which its event
+// webhook reports against. Network errors, rate limiting and server errors are
+// transient.
+func (s *SendGridSender) SendEmail(ctx context.Context, msg *Message) (string, error) {
+	request := SendGridRequest{
+		Personalizations: []Personalization{{To: []EmailAddress{msg.To}}},
+		From:             msg.From,
+		Subject:          msg.Subject,
+		Content:          []Content{},
+		Attachments:      msg.Attachments,
+	}
+	// SendGrid requires text/plain before text/html
+	if msg.Text != "" {
+		request.Content = append(request.Content, Content{Type: "text/plain", Value: msg.Text})
+	}
+	if msg.HTML != "" {
+		request.Content = append(request.Content, Content{Type: "text/html", Value: msg.HTML})
+	}
+
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal email request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.baseURL+"/mail/send", bytes.NewReader(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", &notifications.TransientError{Err: fmt.Errorf("failed to send email: %w", err)}
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 400 {
+		err := fmt.Errorf("SendGrid returned status %d", resp.StatusCode)
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return "", &notifications.TransientError{Err: err}
+		}
+		return "", err
+	}
+
+	return resp.Header.Get("X-Message-Id"), nil
+}
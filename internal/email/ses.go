@@ -0,0 +1,304 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/http"
+	"net/mail"
+	"net/textproto"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"app/internal/notifications"
+)
+
+// ProviderSES is the provider key recorded in the delivery ledger
+const ProviderSES = "ses"
+
+const (
+	sesSendPath       = "/v2/email/outbound-emails"
+	sigV4Algorithm    = "AWS4-HMAC-SHA256"
+	amzDateFormat     = "20060102T150405Z"
+	sesSigningService = "ses"
+)
+
+// SESConfig holds Amazon SES configuration
+type SESConfig struct {
+	Region           string
+	AccessKeyID      string
+	SecretAccessKey  string
+	SessionToken     string // Set with temporary credentials
+	ConfigurationSet string // Publishes bounce and complaint events to SNS; optional when the identity does
+	Endpoint         string // Defaults to https://email.<region>.amazonaws.com
+}
+
+// SESSender sends email through the Amazon SES v2 SendEmail API. Requests are signed
+// with AWS Signature Version 4.
+type SESSender struct {
+	cfg        SESConfig
+	endpoint   *url.URL
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewSESSender creates an SES sender
+func NewSESSender(cfg SESConfig) (*SESSender, error) {
+	if cfg.Region == "" || cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("SES requires AWS_REGION, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = "https://email." + cfg.Region + ".amazonaws.com"
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid SES endpoint %q", endpoint)
+	}
+	return &SESSender{
+		cfg:      cfg,
+		endpoint: u,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		now: time.Now,
+	}, nil
+}
+
+// Provider returns the provider key recorded in the delivery ledger
+func (s *SESSender) Provider() string {
+	return ProviderSES
+}
+
+// sesSendRequest is the body of an SES v2 SendEmail request
+type sesSendRequest struct {
+	FromEmailAddress     string         `json:"FromEmailAddress"`
+	Destination          sesDestination `json:"Destination"`
+	Content              sesContent     `json:"Content"`
+	ConfigurationSetName string         `json:"ConfigurationSetName,omitempty"`
+}
+
+type sesDestination struct {
+	ToAddresses []string `json:"ToAddresses"`
+}
+
+// sesContent is a simple message, or a raw MIME message when there are attachments
+type sesContent struct {
+	Simple *sesSimple `json:"Simple,omitempty"`
+	Raw    *sesRaw    `json:"Raw,omitempty"`
+}
+
+type sesSimple struct {
+	Subject sesText `json:"Subject"`
+	Body    sesBody `json:"Body"`
+}
+
+type sesBody struct {
+	Text *sesText `json:"Text,omitempty"`
+	HTML *sesText `json:"Html,omitempty"`
+}
+
+type sesText struct {
+	Data    string `json:"Data"`
+	Charset string `json:"Charset"`
+}
+
+type sesRaw struct {
+	Data []byte `json:"Data"` // base64 encoded by encoding/json
+}
+
+// SendEmail sends msg and returns the SES message ID, which SES bounce, complaint and
+// delivery notifications report against. Network errors, throttling and server errors
+// are transient.
+func (s *SESSender) SendEmail(ctx context.Context, msg *Message) (string, error) {
+	from := formatAddress(msg.From)
+	to := formatAddress(msg.To)
+	request := sesSendRequest{
+		FromEmailAddress:     from,
+		Destination:          sesDestination{ToAddresses: []string{to}},
+		ConfigurationSetName: s.cfg.ConfigurationSet,
+	}
+	if len(msg.Attachments) > 0 {
+		raw, err := rawMessage(from, to, msg)
+		if err != nil {
+			return "", err
+		}
+		request.Content.Raw = &sesRaw{Data: raw}
+	} else {
+		simple := &sesSimple{Subject: sesText{Data: msg.Subject, Charset: "UTF-8"}}
+		if msg.Text != "" {
+			simple.Body.Text = &sesText{Data: msg.Text, Charset: "UTF-8"}
+		}
+		if msg.HTML != "" {
+			simple.Body.HTML = &sesText{Data: msg.HTML, Charset: "UTF-8"}
+		}
+		request.Content.Simple = simple
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal SES request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint.Scheme+"://"+s.endpoint.Host+sesSendPath, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	s.signRequest(req, body)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", &notifications.TransientError{Err: fmt.Errorf("failed to send email: %w", err)}
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 400 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		json.Unmarshal(respBody, &apiErr)
+		err := fmt.Errorf("SES returned status %d: %s", resp.StatusCode, apiErr.Message)
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return "", &notifications.TransientError{Err: err}
+		}
+		return "", err
+	}
+
+	var result struct {
+		MessageID string `json:"MessageId"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", fmt.Errorf("failed to decode SES response: %w", err)
+	}
+	return result.MessageID, nil
+}
+
+// formatAddress formats an address for a header, encoding a non-ASCII display name
+func formatAddress(a EmailAddress) string {
+	return (&mail.Address{Name: a.Name, Address: a.Email}).String()
+}
+
+// rawMessage builds the MIME message for an email with attachments: a multipart/mixed
+// message holding the text and HTML bodies as multipart/alternative, then each file
+func rawMessage(from, to string, msg *Message) ([]byte, error) {
+	var buf bytes.Buffer
+	mixed := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: multipart/mixed; boundary=%s\r\n\r\n",
+		from, to, mime.QEncoding.Encode("utf-8", msg.Subject), mixed.Boundary())
+
+	var alternatives bytes.Buffer
+	alt := multipart.NewWriter(&alternatives)
+	for _, body := range []struct{ contentType, value string }{
+		{"text/plain", msg.Text},
+		{"text/html", msg.HTML},
+	} {
+		if body.value == "" {
+			continue
+		}
+		part, err := alt.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {body.contentType + "; charset=UTF-8"},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(part)
+		qp.Write([]byte(body.value))
+		qp.Close()
+	}
+	alt.Close()
+
+	part, err := mixed.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {"multipart/alternative; boundary=" + alt.Boundary()},
+	})
+	if err != nil {
+		return nil, err
+	}
+	part.Write(alternatives.Bytes())
+
+	for _, a := range msg.Attachments {
+		filename := mime.QEncoding.Encode("utf-8", a.Filename)
+		part, err := mixed.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(a.Type, map[string]string{"name": filename})},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": filename})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoded := base64.StdEncoding.EncodeToString(a.Content)
+		for len(encoded) > 76 {
+			io.WriteString(part, encoded[:76]+"\r\n")
+			encoded = encoded[76:]
+		}
+		io.WriteString(part, encoded+"\r\n")
+	}
+	if err := mixed.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// signRequest adds a Signature Version 4 Authorization header covering the body
+func (s *SESSender) signRequest(req *http.Request, body []byte) {
+	now := s.now().UTC()
+	req.Header.Set("X-Amz-Date", now.Format(amzDateFormat))
+	headers := map[string]string{
+		"content-type": req.Header.Get("Content-Type"),
+		"host":         req.URL.Host,
+		"x-amz-date":   now.Format(amzDateFormat),
+	}
+	if s.cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.cfg.SessionToken)
+		headers["x-amz-security-token"] = s.cfg.SessionToken
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method, req.URL.EscapedPath(), req.URL.RawQuery, canonicalHeaders.String(), signedHeaders, hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	scope := now.Format("20060102") + "/" + s.cfg.Region + "/" + sesSigningService + "/aws4_request"
+	stringToSign := strings.Join([]string{
+		sigV4Algorithm, now.Format(amzDateFormat), scope, hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretAccessKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, sesSigningService)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, s.cfg.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
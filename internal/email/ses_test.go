@@ -0,0 +1,175 @@
+package email
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"app/internal/model"
+	"app/internal/notifications"
+)
+
+func TestServiceDeliverFailover(t *testing.T) {
+	tests := []struct {
+		name          string
+		sesStatus     int
+		payload       string
+		wantProvider  string
+		wantMessageID string
+		wantSendGrid  bool
+		wantErr       func(error) bool
+	}{
+		{
+			name:          "sent through SES",
+			sesStatus:     http.StatusOK,
+			payload:       `{"from":{"email":"noreply@gigco.com","name":"GigCo"},"to":{"email":"sam@example.com"},"subject":"Hi","text":"Hello"}`,
+			wantProvider:  ProviderSES,
+			wantMessageID: "ses-1",
+		},
+		{
+			name:          "SES unavailable fails over to SendGrid",
+			sesStatus:     http.StatusServiceUnavailable,
+			payload:       `{"from":{"email":"noreply@gigco.com"},"to":{"email":"sam@example.com"},"subject":"Hi","html":"<p>Hello</p>"}`,
+			wantProvider:  ProviderSendGrid,
+			wantMessageID: "sg-1",
+			wantSendGrid:  true,
+		},
+		{
+			name:          "legacy SendGrid payload",
+			sesStatus:     http.StatusOK,
+			payload:       `{"personalizations":[{"to":[{"email":"sam@example.com"}]}],"from":{"email":"noreply@gigco.com"},"subject":"Hi","content":[{"type":"text/plain","value":"Hello"}]}`,
+			wantProvider:  ProviderSES,
+			wantMessageID: "ses-1",
+		},
+		{
+			name:         "rejected message not failed over",
+			sesStatus:    http.StatusBadRequest,
+			payload:      `{"from":{"email":"noreply@gigco.com"},"to":{"email":"not-an-address"},"subject":"Hi","text":"Hello"}`,
+			wantProvider: ProviderSES,
+			wantErr:      func(err error) bool { return err != nil && !notifications.IsTransient(err) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent sesSendRequest
+			sendGridCalled := false
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST "+sesSendPath, func(w http.ResponseWriter, r *http.Request) {
+				auth := r.Header.Get("Authorization")
+				if !strings.HasPrefix(auth, sigV4Algorithm+" Credential=AKID/") || !strings.Contains(auth, "/us-east-1/ses/aws4_request") {
+					t.Errorf("Authorization = %q; want SigV4 for ses in us-east-1", auth)
+				}
+				json.NewDecoder(r.Body).Decode(&sent)
+				w.WriteHeader(tt.sesStatus)
+				if tt.sesStatus == http.StatusOK {
+					json.NewEncoder(w).Encode(map[string]string{"MessageId": "ses-1"})
+					return
+				}
+				json.NewEncoder(w).Encode(map[string]string{"message": "failed"})
+			})
+			mux.HandleFunc("POST /mail/send", func(w http.ResponseWriter, r *http.Request) {
+				sendGridCalled = true
+				w.Header().Set("X-Message-Id", "sg-1")
+				w.WriteHeader(http.StatusAccepted)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			ses, err := NewSESSender(SESConfig{Region: "us-east-1", AccessKeyID: "AKID", SecretAccessKey: "secret", Endpoint: server.URL})
+			if err != nil {
+				t.Fatal(err)
+			}
+			sendGrid, _ := NewSendGridSender("key")
+			sendGrid.baseURL = server.URL
+			s := &Service{fromEmail: "noreply@gigco.com", fromName: "GigCo", primary: ses, fallback: sendGrid}
+
+			provider, messageID, err := s.DeliverVia(t.Context(), []byte(tt.payload))
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("DeliverVia() error = %v", err)
+			}
+			if tt.wantErr != nil && !tt.wantErr(err) {
+				t.Fatalf("DeliverVia() error = %v", err)
+			}
+			if provider != tt.wantProvider || messageID != tt.wantMessageID {
+				t.Errorf("DeliverVia() = (%q, %q); want (%q, %q)", provider, messageID, tt.wantProvider, tt.wantMessageID)
+			}
+			if sendGridCalled != tt.wantSendGrid {
+				t.Errorf("SendGrid called = %v; want %v", sendGridCalled, tt.wantSendGrid)
+			}
+			if to := sent.Destination.ToAddresses; len(to) != 1 {
+				t.Errorf("SES ToAddresses = %v; want the one recipient", to)
+			}
+		})
+	}
+}
+
+func TestSNSMessage(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "sns.amazonaws.com"}, NotAfter: time.Now().Add(time.Hour)}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	event := `{"notificationType":"Bounce","mail":{"messageId":"ses-1"},"bounce":{"bounceType":"Permanent","bouncedRecipients":[{"emailAddress":"Sam@Example.com","diagnosticCode":"550 5.1.1 user unknown"}]}}`
+	msg := &SNSMessage{
+		Type:             SNSNotification,
+		MessageID:        "m-1",
+		TopicArn:         "arn:aws:sns:us-east-1:123456789012:ses-events",
+		Message:          event,
+		Timestamp:        "2026-10-16T12:00:00.000Z",
+		SignatureVersion: "2",
+	}
+	digest := sha256.Sum256([]byte(msg.stringToSign()))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg.Signature = base64.StdEncoding.EncodeToString(sig)
+
+	if err := msg.verify(cert); err != nil {
+		t.Errorf("verify() error = %v", err)
+	}
+	tampered := *msg
+	tampered.Message = strings.Replace(event, "Permanent", "Transient", 1)
+	if err := tampered.verify(cert); !errors.Is(err, ErrInvalidSNSSignature) {
+		t.Errorf("verify() of tampered message error = %v; want ErrInvalidSNSSignature", err)
+	}
+	forged := *msg
+	forged.SigningCertURL = "https://example.com/cert.pem"
+	if err := VerifySNSMessage(t.Context(), &forged); !errors.Is(err, ErrInvalidSNSSignature) {
+		t.Errorf("VerifySNSMessage() with a foreign certificate URL error = %v; want ErrInvalidSNSSignature", err)
+	}
+
+	parsed, err := ParseSESEvent(msg.Message)
+	if err != nil {
+		t.Fatalf("ParseSESEvent() error = %v", err)
+	}
+	addresses, reason := parsed.Suppressions()
+	if len(addresses) != 1 || addresses[0] != "Sam@Example.com" || reason != model.SuppressionReasonBounce {
+		t.Errorf("Suppressions() = (%v, %q); want the bounced address", addresses, reason)
+	}
+	if parsed.ReceiptEvent() != "bounce" || parsed.Detail() != "550 5.1.1 user unknown" {
+		t.Errorf("receipt = (%q, %q); want a bounce with the diagnostic", parsed.ReceiptEvent(), parsed.Detail())
+	}
+}
@@ -0,0 +1,318 @@
+package email
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"app/internal/model"
+)
+
+// SNS message types
+const (
+	SNSSubscriptionConfirmation = "SubscriptionConfirmation"
+	SNSNotification             = "Notification"
+)
+
+// ErrInvalidSNSSignature is returned for SNS messages not signed by Amazon SNS
+var ErrInvalidSNSSignature = errors.New("invalid SNS message signature")
+
+// snsHost matches the SNS endpoints that serve signing certificates and subscription
+// confirmations; any other URL in a message is forged
+var snsHost = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// snsClient fetches signing certificates and confirms subscriptions
+var snsClient = &http.Client{Timeout: 10 * time.Second}
+
+// snsCertificates caches signing certificates by URL; SNS rotates them rarely
+var snsCertificates sync.Map
+
+// SNSMessage is a message Amazon SNS posts to an HTTPS subscription. SES publishes its
+// bounce, complaint and delivery notifications through SNS.
+type SNSMessage struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	Token            string `json:"Token"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject"`
+	Message          string `json:"Message"`
+	SubscribeURL     string `json:"SubscribeURL"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+}
+
+// ParseSNSMessage decodes an SNS HTTPS delivery
+func ParseSNSMessage(body []byte) (*SNSMessage, error) {
+	var m SNSMessage
+	if err := json.Unmarshal(body, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse SNS message: %w", err)
+	}
+	if m.Type == "" {
+		return nil, errors.New("failed to parse SNS message: no Type")
+	}
+	return &m, nil
+}
+
+// VerifySNSMessage checks m was signed by Amazon SNS with the certificate at its
+// SigningCertURL, which must be served by SNS
+func VerifySNSMessage(ctx context.Context, m *SNSMessage) error {
+	if !isSNSURL(m.SigningCertURL) {
+		return ErrInvalidSNSSignature
+	}
+	cert, err := snsCertificate(ctx, m.SigningCertURL)
+	if err != nil {
+		return err
+	}
+	return m.verify(cert)
+}
+
+// ConfirmSNSSubscription confirms the subscription m asks for by visiting its
+// SubscribeURL
+func ConfirmSNSSubscription(ctx context.Context, m *SNSMessage) error {
+	if m.Type != SNSSubscriptionConfirmation || !isSNSURL(m.SubscribeURL) {
+		return fmt.Errorf("not an SNS subscription confirmation")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.SubscribeURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := snsClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to confirm SNS subscription: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("SNS subscription confirmation returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// verify checks the signature over the message's fields with cert. Version 1 signs
+// with SHA1, version 2 with SHA256.
+func (m *SNSMessage) verify(cert *x509.Certificate) error {
+	key, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return ErrInvalidSNSSignature
+	}
+	sig, err := base64.StdEncoding.DecodeString(m.Signature)
+	if err != nil {
+		return ErrInvalidSNSSignature
+	}
+
+	var hash crypto.Hash
+	var digest []byte
+	switch m.SignatureVersion {
+	case "1":
+		sum := sha1.Sum([]byte(m.stringToSign()))
+		hash, digest = crypto.SHA1, sum[:]
+	case "2":
+		sum := sha256.Sum256([]byte(m.stringToSign()))
+		hash, digest = crypto.SHA256, sum[:]
+	default:
+		return ErrInvalidSNSSignature
+	}
+	if rsa.VerifyPKCS1v15(key, hash, digest, sig) != nil {
+		return ErrInvalidSNSSignature
+	}
+	return nil
+}
+
+// stringToSign lists the signed fields of the message's type as name and value lines
+func (m *SNSMessage) stringToSign() string {
+	fields := []string{"Message", m.Message, "MessageId", m.MessageID}
+	if m.Type == SNSNotification {
+		if m.Subject != "" {
+			fields = append(fields, "Subject", m.Subject)
+		}
+		fields = append(fields, "Timestamp", m.Timestamp, "TopicArn", m.TopicArn, "Type", m.Type)
+	} else {
+		fields = append(fields, "SubscribeURL", m.SubscribeURL, "Timestamp", m.Timestamp,
+			"Token", m.Token, "TopicArn", m.TopicArn, "Type", m.Type)
+	}
+	return strings.Join(fields, "\n") + "\n"
+}
+
+func isSNSURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && u.Scheme == "https" && snsHost.MatchString(u.Host)
+}
+
+func snsCertificate(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	if cert, ok := snsCertificates.Load(certURL); ok {
+		return cert.(*x509.Certificate), nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, certURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := snsClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch SNS signing certificate: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("SNS signing certificate returned status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SNS signing certificate: %w", err)
+	}
+	block, _ := pem.Decode(body)
+	if block == nil {
+		return nil, errors.New("invalid SNS signing certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SNS signing certificate: %w", err)
+	}
+	snsCertificates.Store(certURL, cert)
+	return cert, nil
+}
+
+// SESEvent is an SES notification carried in an SNS message: an identity notification
+// (notificationType) or a configuration set event (eventType)
+type SESEvent struct {
+	NotificationType string `json:"notificationType"`
+	EventType        string `json:"eventType"`
+	Mail             struct {
+		MessageID string    `json:"messageId"`
+		Timestamp time.Time `json:"timestamp"`
+	} `json:"mail"`
+	Bounce *struct {
+		BounceType        string    `json:"bounceType"` // Permanent, Transient or Undetermined
+		BounceSubType     string    `json:"bounceSubType"`
+		Timestamp         time.Time `json:"timestamp"`
+		BouncedRecipients []struct {
+			EmailAddress   string `json:"emailAddress"`
+			DiagnosticCode string `json:"diagnosticCode"`
+		} `json:"bouncedRecipients"`
+	} `json:"bounce"`
+	Complaint *struct {
+		ComplaintFeedbackType string    `json:"complaintFeedbackType"`
+		Timestamp             time.Time `json:"timestamp"`
+		ComplainedRecipients  []struct {
+			EmailAddress string `json:"emailAddress"`
+		} `json:"complainedRecipients"`
+	} `json:"complaint"`
+	Delivery *struct {
+		Timestamp    time.Time `json:"timestamp"`
+		SMTPResponse string    `json:"smtpResponse"`
+	} `json:"delivery"`
+	Reject *struct {
+		Reason string `json:"reason"`
+	} `json:"reject"`
+}
+
+// ParseSESEvent decodes the Message of an SNS notification from SES
+func ParseSESEvent(message string) (*SESEvent, error) {
+	var e SESEvent
+	if err := json.Unmarshal([]byte(message), &e); err != nil {
+		return nil, fmt.Errorf("failed to parse SES event: %w", err)
+	}
+	return &e, nil
+}
+
+// Type is the notification or event type, e.g. Bounce, Complaint or Delivery
+func (e *SESEvent) Type() string {
+	if e.EventType != "" {
+		return e.EventType
+	}
+	return e.NotificationType
+}
+
+// ReceiptEvent is the event name recorded in the delivery ledger, using SendGrid's
+// names so receipts read the same whichever provider sent the email. Transient bounces
+// are retried by the receiving server, like SendGrid's deferrals.
+func (e *SESEvent) ReceiptEvent() string {
+	switch e.Type() {
+	case "Bounce":
+		if e.Bounce != nil && e.Bounce.BounceType == "Transient" {
+			return "deferred"
+		}
+		return "bounce"
+	case "Complaint":
+		return "spamreport"
+	case "Delivery":
+		return "delivered"
+	case "DeliveryDelay":
+		return "deferred"
+	case "Reject":
+		return "dropped"
+	case "Send":
+		return "processed"
+	default:
+		return strings.ToLower(e.Type())
+	}
+}
+
+// Detail is the bounce diagnostic, complaint feedback type, SMTP response or reject
+// reason, if any
+func (e *SESEvent) Detail() string {
+	switch {
+	case e.Bounce != nil:
+		for _, r := range e.Bounce.BouncedRecipients {
+			if r.DiagnosticCode != "" {
+				return r.DiagnosticCode
+			}
+		}
+		return strings.TrimSpace(e.Bounce.BounceType + " " + e.Bounce.BounceSubType)
+	case e.Complaint != nil:
+		return e.Complaint.ComplaintFeedbackType
+	case e.Delivery != nil:
+		return e.Delivery.SMTPResponse
+	case e.Reject != nil:
+		return e.Reject.Reason
+	}
+	return ""
+}
+
+// OccurredAt is when SES recorded the event
+func (e *SESEvent) OccurredAt() time.Time {
+	switch {
+	case e.Bounce != nil && !e.Bounce.Timestamp.IsZero():
+		return e.Bounce.Timestamp
+	case e.Complaint != nil && !e.Complaint.Timestamp.IsZero():
+		return e.Complaint.Timestamp
+	case e.Delivery != nil && !e.Delivery.Timestamp.IsZero():
+		return e.Delivery.Timestamp
+	}
+	return e.Mail.Timestamp
+}
+
+// Suppressions returns the addresses to stop emailing and why: permanent bounces and
+// complaints
+func (e *SESEvent) Suppressions() ([]string, string) {
+	var addresses []string
+	switch {
+	case e.Bounce != nil && e.Bounce.BounceType == "Permanent":
+		for _, r := range e.Bounce.BouncedRecipients {
+			addresses = append(addresses, r.EmailAddress)
+		}
+		return addresses, model.SuppressionReasonBounce
+	case e.Complaint != nil:
+		for _, r := range e.Complaint.ComplainedRecipients {
+			addresses = append(addresses, r.EmailAddress)
+		}
+		return addresses, model.SuppressionReasonComplaint
+	}
+	return nil, ""
+}
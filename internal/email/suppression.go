@@ -0,0 +1,108 @@
+package email
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"app/internal/model"
+)
+
+// ErrRecipientSuppressed is returned for an email to an address on the suppression list
+var ErrRecipientSuppressed = errors.New("recipient is on the email suppression list")
+
+// suppressions is checked before every email sent; nil sends to every address
+var suppressions *SuppressionList
+
+// UseSuppressionList skips every address on l from now on
+func UseSuppressionList(l *SuppressionList) {
+	suppressions = l
+}
+
+// SuppressionList is the addresses that hard bounced or reported an email as spam.
+// Sending to them again hurts the sender reputation with every provider.
+type SuppressionList struct {
+	db *sql.DB
+}
+
+// NewSuppressionList creates a suppression list
+func NewSuppressionList(db *sql.DB) *SuppressionList {
+	return &SuppressionList{db: db}
+}
+
+// IsSuppressed reports whether address is on the list
+func (l *SuppressionList) IsSuppressed(ctx context.Context, address string) (bool, error) {
+	var exists bool
+	err := l.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM email_suppressions WHERE email = $1)
+	`, strings.ToLower(address)).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check email suppression: %w", err)
+	}
+	return exists, nil
+}
+
+// Add puts address on the list, or updates why it is there
+func (l *SuppressionList) Add(ctx context.Context, address, reason, provider, detail string) error {
+	_, err := l.db.ExecContext(ctx, `
+		INSERT INTO email_suppressions (email, reason, provider, detail)
+		VALUES ($1, $2, $3, NULLIF($4, ''))
+		ON CONFLICT (email) DO UPDATE SET
+			reason = EXCLUDED.reason,
+			provider = EXCLUDED.provider,
+			detail = EXCLUDED.detail
+	`, strings.ToLower(address), reason, provider, detail)
+	if err != nil {
+		return fmt.Errorf("failed to add email suppression: %w", err)
+	}
+	return nil
+}
+
+// Remove takes address off the list. Returns false when it was not on it.
+func (l *SuppressionList) Remove(ctx context.Context, address string) (bool, error) {
+	result, err := l.db.ExecContext(ctx, `DELETE FROM email_suppressions WHERE email = $1`, strings.ToLower(address))
+	if err != nil {
+		return false, fmt.Errorf("failed to remove email suppression: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, nil
+}
+
+// List returns a page of the list, most recently updated first, and its total size.
+// A non-empty search matches part of the address.
+func (l *SuppressionList) List(ctx context.Context, search string, limit, offset int) ([]model.EmailSuppression, int, error) {
+	pattern := "%" + strings.ToLower(search) + "%"
+
+	var total int
+	if err := l.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM email_suppressions WHERE email LIKE $1`, pattern).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count email suppressions: %w", err)
+	}
+
+	rows, err := l.db.QueryContext(ctx, `
+		SELECT email, reason, provider, detail, created_at, updated_at
+		FROM email_suppressions
+		WHERE email LIKE $1
+		ORDER BY updated_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`, pattern, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query email suppressions: %w", err)
+	}
+	defer rows.Close()
+
+	list := []model.EmailSuppression{}
+	for rows.Next() {
+		var s model.EmailSuppression
+		var detail sql.NullString
+		if err := rows.Scan(&s.Email, &s.Reason, &s.Provider, &detail, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan email suppression: %w", err)
+		}
+		if detail.Valid {
+			s.Detail = &detail.String
+		}
+		list = append(list, s)
+	}
+	return list, total, rows.Err()
+}
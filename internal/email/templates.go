@@ -0,0 +1,160 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"app/internal/model"
+)
+
+// templateFiles holds the email templates: layout.html wraps each page's "title" and
+// "content", and partials/ defines the blocks pages share
+//
+//go:embed templates
+var templateFiles embed.FS
+
+// ErrTemplateNotFound is returned for an email template that does not exist
+var ErrTemplateNotFound = errors.New("email template not found")
+
+// emailTemplates are the pages by name, each parsed with the layout and partials
+var emailTemplates = mustParseTemplates()
+
+// buttonLink is the data of the button partial
+type buttonLink struct {
+	URL   string
+	Label string
+}
+
+var templateFuncs = template.FuncMap{
+	"link": func(url, label string) buttonLink { return buttonLink{URL: url, Label: label} },
+}
+
+// mustParseTemplates parses every page in templates/. The templates are compiled in,
+// so an error is a bug and panics at startup.
+func mustParseTemplates() map[string]*template.Template {
+	base := template.Must(template.New("layout").Funcs(templateFuncs).
+		ParseFS(templateFiles, "templates/layout.html", "templates/partials/*.html"))
+
+	pages, err := fs.Glob(templateFiles, "templates/*.html")
+	if err != nil {
+		panic(err)
+	}
+	templates := map[string]*template.Template{}
+	for _, page := range pages {
+		name := strings.TrimSuffix(path.Base(page), ".html")
+		if name == "layout" {
+			continue
+		}
+		templates[name] = template.Must(template.Must(base.Clone()).ParseFS(templateFiles, page))
+	}
+	return templates
+}
+
+// renderTemplate renders an email template inside the layout
+func renderTemplate(name string, data interface{}) (string, error) {
+	tmpl, ok := emailTemplates[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "layout", data); err != nil {
+		return "", fmt.Errorf("failed to render email template %s: %w", name, err)
+	}
+
+	return buf.String(), nil
+}
+
+// Template describes an email template for the admin preview
+type Template struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// templatePreview is what a template is for and the sample data it is previewed with
+type templatePreview struct {
+	description string
+	sample      interface{}
+}
+
+var templatePreviews = map[string]templatePreview{
+	"verification": {"Sent after signup to verify the email address", VerificationEmailData{
+		UserName: "Jordan Smith", VerificationLink: "https://app.gigco.com/verify-email?token=sample", ExpirationHours: 24,
+	}},
+	"password_reset": {"Password reset link", PasswordResetData{
+		UserName: "Jordan Smith", ResetLink: "https://app.gigco.com/reset-password?token=sample", ExpirationMins: 30, IPAddress: "203.0.113.7",
+	}},
+	"offer_sent": {"A job was offered to the worker (job_offer notifications)", EventEmailData{
+		UserName: "Sam Lee", Title: "New job offer", Message: "You have been offered \"Lawn mowing\" for $120.00 on Saturday.",
+		ActionLink: "https://app.gigco.com/jobs/42/offer",
+	}},
+	"job_accepted": {"A worker accepted a job (job_accepted notifications)", EventEmailData{
+		UserName: "Jordan Smith", Title: "Job accepted", Message: "Sam Lee accepted \"Lawn mowing\".",
+		ActionLink: "https://app.gigco.com/jobs/42",
+	}},
+	"job_completed": {"A job was marked complete (job_completed notifications)", EventEmailData{
+		UserName: "Jordan Smith", Title: "Job completed", Message: "Sam Lee marked \"Lawn mowing\" as complete.",
+		ActionLink: "https://app.gigco.com/jobs/42",
+	}},
+	"payment_receipt": {"A payment was made or received (payment_received and payment_sent notifications)", EventEmailData{
+		UserName: "Sam Lee", Title: "Payment received", Message: "You were paid $120.00 for \"Lawn mowing\".",
+		ActionLink: "https://app.gigco.com/payments/9",
+	}},
+	"review_request": {"Asks both parties to review a finished job", ReviewRequestData{
+		UserName: "Jordan Smith", JobTitle: "Lawn mowing", ReviewLink: "https://app.gigco.com/l/sample",
+	}},
+	"notification": {"Any other notification", EventEmailData{
+		UserName: "Jordan Smith", Title: "Your document was approved", Message: "Your driver's license has been verified.",
+	}},
+}
+
+// Templates lists the email templates by name
+func Templates() []Template {
+	templates := make([]Template, 0, len(templatePreviews))
+	for name, preview := range templatePreviews {
+		templates = append(templates, Template{Name: name, Description: preview.description})
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates
+}
+
+// Preview renders a template with its sample data
+func Preview(name string) (string, error) {
+	preview, ok := templatePreviews[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+	}
+	return renderTemplate(name, preview.sample)
+}
+
+// EventEmailData holds data for the emails sent for notifications
+type EventEmailData struct {
+	UserName   string
+	Title      string
+	Message    string
+	ActionLink string
+}
+
+// ReviewRequestData holds data for the review request email
+type ReviewRequestData struct {
+	UserName   string
+	JobTitle   string
+	ReviewLink string
+}
+
+// notificationTemplates are the templates of notification types with their own; other
+// types use "notification"
+var notificationTemplates = map[string]string{
+	model.NotificationJobOffer:        "offer_sent",
+	model.NotificationJobAccepted:     "job_accepted",
+	model.NotificationJobCompleted:    "job_completed",
+	model.NotificationPaymentReceived: "payment_receipt",
+	model.NotificationPaymentSent:     "payment_receipt",
+}
@@ -0,0 +1,74 @@
+package email
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	tests := []struct {
+		name        string
+		template    string
+		data        interface{}
+		wantContain []string
+		wantMissing []string
+		wantErr     error
+	}{
+		{
+			name:        "event with link",
+			template:    "offer_sent",
+			data:        EventEmailData{UserName: "Sam", Title: "New job offer", Message: "Lawn mowing", ActionLink: "https://app.gigco.com/jobs/1/offer"},
+			wantContain: []string{"<title>New job offer</title>", "Hi Sam,", `href="https://app.gigco.com/jobs/1/offer"`, "View offer", "notification settings"},
+		},
+		{
+			name:        "no button without a link",
+			template:    "notification",
+			data:        EventEmailData{Title: "Hello", Message: "Welcome"},
+			wantContain: []string{"Hi there,", "Welcome"},
+			wantMissing: []string{"<a href"},
+		},
+		{
+			name:        "message escaped",
+			template:    "payment_receipt",
+			data:        EventEmailData{UserName: "Sam", Title: "Paid", Message: "<script>alert(1)</script>"},
+			wantContain: []string{"&lt;script&gt;"},
+			wantMissing: []string{"<script>"},
+		},
+		{
+			name:     "unknown template",
+			template: "missing",
+			wantErr:  ErrTemplateNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderTemplate(tt.template, tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("renderTemplate() error = %v; want %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantContain {
+				if !strings.Contains(got, want) {
+					t.Errorf("renderTemplate() missing %q", want)
+				}
+			}
+			for _, unwanted := range tt.wantMissing {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("renderTemplate() contains %q", unwanted)
+				}
+			}
+		})
+	}
+}
+
+func TestPreviewEveryTemplate(t *testing.T) {
+	if len(templatePreviews) != len(emailTemplates) {
+		t.Errorf("%d templates have previews; want all %d", len(templatePreviews), len(emailTemplates))
+	}
+	for name := range emailTemplates {
+		if _, err := Preview(name); err != nil {
+			t.Errorf("Preview(%q) error = %v", name, err)
+		}
+	}
+}
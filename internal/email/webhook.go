@@ -0,0 +1,115 @@
+package email
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"app/internal/model"
+)
+
+// Headers carrying SendGrid's signature on event webhook requests
+const (
+	EventSignatureHeader = "X-Twilio-Email-Event-Webhook-Signature"
+	EventTimestampHeader = "X-Twilio-Email-Event-Webhook-Timestamp"
+)
+
+// ErrInvalidEventSignature is returned for event webhook requests not signed by SendGrid
+var ErrInvalidEventSignature = errors.New("invalid event webhook signature")
+
+// Event is one entry of a SendGrid event webhook batch
+type Event struct {
+	Email     string `json:"email"`
+	Timestamp int64  `json:"timestamp"`
+	Event     string `json:"event"` // processed, deferred, delivered, bounce, dropped, open, click, ...
+	MessageID string `json:"sg_message_id"`
+	Reason    string `json:"reason"`
+	Response  string `json:"response"`
+	Type      string `json:"type"` // bounce or blocked, for bounce events
+}
+
+// ProviderMessageID is the X-Message-Id returned when the email was sent. SendGrid
+// suffixes it per recipient in sg_message_id.
+func (e Event) ProviderMessageID() string {
+	id, _, _ := strings.Cut(e.MessageID, ".")
+	return id
+}
+
+// ReceiptEvent is the event name recorded in the delivery ledger; blocked bounces
+// (rejected by the receiving server's policy rather than an unknown address) keep their
+// own name
+func (e Event) ReceiptEvent() string {
+	if e.Event == "bounce" && e.Type == "blocked" {
+		return "blocked"
+	}
+	return e.Event
+}
+
+// Detail is the reason or server response SendGrid gave, if any
+func (e Event) Detail() string {
+	if e.Reason != "" {
+		return e.Reason
+	}
+	return e.Response
+}
+
+// Suppression is why to stop emailing the address, or "" to keep sending: a bounce
+// that is not a policy block, or a spam report
+func (e Event) Suppression() string {
+	switch {
+	case e.Event == "bounce" && e.Type != "blocked":
+		return model.SuppressionReasonBounce
+	case e.Event == "spamreport":
+		return model.SuppressionReasonComplaint
+	}
+	return ""
+}
+
+// OccurredAt is when SendGrid recorded the event
+func (e Event) OccurredAt() time.Time {
+	return time.Unix(e.Timestamp, 0)
+}
+
+// ParseEvents decodes an event webhook body
+func ParseEvents(body []byte) ([]Event, error) {
+	var events []Event
+	if err := json.Unmarshal(body, &events); err != nil {
+		return nil, fmt.Errorf("failed to parse events: %w", err)
+	}
+	return events, nil
+}
+
+// VerifyEventSignature checks a signed event webhook request. publicKey is the base64
+// verification key from SendGrid's Mail Settings; the signature covers the timestamp
+// header followed by the raw body.
+func VerifyEventSignature(publicKey, signature, timestamp string, body []byte) error {
+	der, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		return fmt.Errorf("invalid event webhook public key: %w", err)
+	}
+	parsed, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return fmt.Errorf("invalid event webhook public key: %w", err)
+	}
+	key, ok := parsed.(*ecdsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("invalid event webhook public key: not an ECDSA key")
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || timestamp == "" {
+		return ErrInvalidEventSignature
+	}
+
+	digest := sha256.Sum256(append([]byte(timestamp), body...))
+	if !ecdsa.VerifyASN1(key, digest[:], sig) {
+		return ErrInvalidEventSignature
+	}
+	return nil
+}
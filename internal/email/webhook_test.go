@@ -0,0 +1,61 @@
+package email
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"testing"
+)
+
+func TestVerifyEventSignature(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey := base64.StdEncoding.EncodeToString(der)
+
+	body := []byte(`[{"email":"a@example.com","event":"delivered","sg_message_id":"abc.filter0001"}]`)
+	timestamp := "1792150000"
+	digest := sha256.Sum256(append([]byte(timestamp), body...))
+	raw, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature := base64.StdEncoding.EncodeToString(raw)
+
+	tests := []struct {
+		name      string
+		signature string
+		timestamp string
+		body      []byte
+		wantErr   bool
+	}{
+		{name: "valid", signature: signature, timestamp: timestamp, body: body},
+		{name: "tampered body", signature: signature, timestamp: timestamp, body: append([]byte(" "), body...), wantErr: true},
+		{name: "other timestamp", signature: signature, timestamp: "1792150001", body: body, wantErr: true},
+		{name: "missing signature", timestamp: timestamp, body: body, wantErr: true},
+		{name: "malformed signature", signature: "not base64!", timestamp: timestamp, body: body, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := VerifyEventSignature(publicKey, tt.signature, tt.timestamp, tt.body)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: VerifyEventSignature() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+
+	events, err := ParseEvents(body)
+	if err != nil {
+		t.Fatalf("ParseEvents() error = %v", err)
+	}
+	if len(events) != 1 || events[0].ProviderMessageID() != "abc" {
+		t.Errorf("ParseEvents() = %+v, want one event for message abc", events)
+	}
+}
@@ -0,0 +1,171 @@
+// Package faults injects faults outside production so client retry logic and
+// workflow compensation can be exercised against realistic failures: extra latency
+// and random 500s on API requests, and Clover responses lost after Clover has handled
+// the request. Defaults come from FAULT_* environment variables; while fault
+// injection is enabled, a request may override them with X-Fault-* headers.
+package faults
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"app/config"
+)
+
+// Request headers overriding the default faults
+const (
+	HeaderLatency        = "X-Fault-Latency"          // Go duration, such as 1500ms
+	HeaderErrorRate      = "X-Fault-Error-Rate"       // 0 to 1
+	HeaderCloverDropRate = "X-Fault-Clover-Drop-Rate" // 0 to 1
+
+	// HeaderPlan echoes the faults planned for a request on its response
+	HeaderPlan = "X-Fault-Plan"
+)
+
+// Headers are the request headers that override the default faults
+var Headers = []string{HeaderLatency, HeaderErrorRate, HeaderCloverDropRate}
+
+// MaxLatency caps injected latency below the server's write timeout
+const MaxLatency = 10 * time.Second
+
+// ErrDroppedResponse is returned in place of a provider response that was dropped
+var ErrDroppedResponse = errors.New("injected fault: provider response dropped")
+
+// Plan is the faults to inject while handling one request
+type Plan struct {
+	Latency        time.Duration
+	ErrorRate      float64
+	CloverDropRate float64
+}
+
+var (
+	loadOnce sync.Once
+	enabled  bool
+	defaults Plan
+
+	// random returns a number in [0, 1); tests replace it
+	random = rand.Float64
+)
+
+func load() {
+	loadOnce.Do(func() { configure(config.LoadFaultConfig()) })
+}
+
+func configure(cfg *config.FaultConfig) {
+	enabled = cfg.Enabled
+	defaults = Plan{Latency: min(cfg.Latency, MaxLatency), ErrorRate: cfg.ErrorRate, CloverDropRate: cfg.CloverDropRate}
+}
+
+// Configure replaces the configuration read from the environment, for tests
+func Configure(cfg *config.FaultConfig) {
+	loadOnce.Do(func() {})
+	configure(cfg)
+}
+
+// Enabled reports whether fault injection is on: FAULT_INJECTION_ENABLED is true and
+// APP_ENV is not production
+func Enabled() bool {
+	load()
+	return enabled
+}
+
+// Defaults returns the faults injected when a request does not override them
+func Defaults() Plan {
+	load()
+	if !enabled {
+		return Plan{}
+	}
+	return defaults
+}
+
+// HeaderError is an invalid X-Fault-* header
+type HeaderError struct {
+	Header string
+	Value  string
+	Reason string
+}
+
+func (e *HeaderError) Error() string {
+	return fmt.Sprintf("%s %s", e.Header, e.Reason)
+}
+
+// FromHeaders returns the default faults overridden by the request's X-Fault-* headers
+func FromHeaders(h http.Header) (Plan, error) {
+	plan := Defaults()
+	if v := h.Get(HeaderLatency); v != "" {
+		latency, err := time.ParseDuration(v)
+		if err != nil || latency < 0 || latency > MaxLatency {
+			return Plan{}, &HeaderError{Header: HeaderLatency, Value: v, Reason: "must be a duration between 0s and " + MaxLatency.String()}
+		}
+		plan.Latency = latency
+	}
+	for _, rate := range []struct {
+		header string
+		field  *float64
+	}{
+		{HeaderErrorRate, &plan.ErrorRate},
+		{HeaderCloverDropRate, &plan.CloverDropRate},
+	} {
+		if v := h.Get(rate.header); v != "" {
+			value, err := strconv.ParseFloat(v, 64)
+			if err != nil || value < 0 || value > 1 {
+				return Plan{}, &HeaderError{Header: rate.header, Value: v, Reason: "must be a number between 0 and 1"}
+			}
+			*rate.field = value
+		}
+	}
+	return plan, nil
+}
+
+// Active reports whether the plan injects any fault
+func (p Plan) Active() bool {
+	return p.Latency > 0 || p.ErrorRate > 0 || p.CloverDropRate > 0
+}
+
+// FailRequest decides whether to fail a request with a 500
+func (p Plan) FailRequest() bool { return happens(p.ErrorRate) }
+
+// DropCloverResponse decides whether to drop a Clover response
+func (p Plan) DropCloverResponse() bool { return happens(p.CloverDropRate) }
+
+func happens(rate float64) bool {
+	return rate > 0 && (rate >= 1 || random() < rate)
+}
+
+// String lists the plan's faults for logs and the X-Fault-Plan header
+func (p Plan) String() string {
+	var parts []string
+	if p.Latency > 0 {
+		parts = append(parts, "latency="+p.Latency.String())
+	}
+	if p.ErrorRate > 0 {
+		parts = append(parts, "error_rate="+strconv.FormatFloat(p.ErrorRate, 'f', -1, 64))
+	}
+	if p.CloverDropRate > 0 {
+		parts = append(parts, "clover_drop_rate="+strconv.FormatFloat(p.CloverDropRate, 'f', -1, 64))
+	}
+	return strings.Join(parts, ",")
+}
+
+type contextKey struct{}
+
+// WithPlan returns a context carrying the request's plan to the services it calls
+func WithPlan(ctx context.Context, p Plan) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext returns the plan for the request being handled, or the defaults outside
+// a request, such as in Temporal activities
+func FromContext(ctx context.Context) Plan {
+	if p, ok := ctx.Value(contextKey{}).(Plan); ok {
+		return p
+	}
+	return Defaults()
+}
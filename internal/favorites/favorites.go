@@ -0,0 +1,56 @@
+// Package favorites decides whether a favorited worker auto-accepts a consumer's
+// rebooking. The worker opts in with a price floor, and either side can turn
+// auto-accept off for the pair; the job workflow also checks the worker is free.
+package favorites
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrNotFavorite is returned when the worker is not an active, verified worker the
+// consumer favorited
+var ErrNotFavorite = errors.New("worker is not one of the consumer's favorites")
+
+// AutoAccept is a favorited pair's auto-accept settings
+type AutoAccept struct {
+	WorkerEnabled  bool     // The worker opted into auto-accept
+	MinPrice       *float64 // The worker's price floor; nil accepts any price
+	ConsumerAllows bool     // The consumer's setting for the pair
+	WorkerAllows   bool     // The worker's setting for the pair
+}
+
+// Allows reports whether a job priced at price is auto-accepted
+func (a AutoAccept) Allows(price float64) bool {
+	if !a.WorkerEnabled || !a.ConsumerAllows || !a.WorkerAllows {
+		return false
+	}
+	return a.MinPrice == nil || price >= *a.MinPrice
+}
+
+// Load returns the auto-accept settings between a consumer and a worker they favorited
+func Load(ctx context.Context, db *sql.DB, consumerID, workerID int) (*AutoAccept, error) {
+	var a AutoAccept
+	var minPrice sql.NullFloat64
+	err := db.QueryRowContext(ctx, `
+		SELECT wp.auto_accept_enabled, wp.auto_accept_min_price,
+			f.consumer_auto_accept, f.worker_auto_accept
+		FROM favorite_workers f
+		JOIN people p ON p.id = f.worker_id
+		JOIN worker_profiles wp ON wp.worker_id = f.worker_id
+		WHERE f.consumer_id = $1 AND f.worker_id = $2
+		  AND p.is_active = true AND wp.verification_status = 'verified'
+	`, consumerID, workerID).Scan(&a.WorkerEnabled, &minPrice, &a.ConsumerAllows, &a.WorkerAllows)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFavorite
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load auto-accept settings: %w", err)
+	}
+	if minPrice.Valid {
+		a.MinPrice = &minPrice.Float64
+	}
+	return &a, nil
+}
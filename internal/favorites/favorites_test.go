@@ -0,0 +1,32 @@
+package favorites
+
+import "testing"
+
+func TestAutoAcceptAllows(t *testing.T) {
+	floor := 100.0
+	on := AutoAccept{WorkerEnabled: true, ConsumerAllows: true, WorkerAllows: true}
+	withFloor := on
+	withFloor.MinPrice = &floor
+
+	tests := []struct {
+		name     string
+		settings AutoAccept
+		price    float64
+		want     bool
+	}{
+		{"opted in without floor", on, 20, true},
+		{"at the floor", withFloor, 100, true},
+		{"below the floor", withFloor, 99.99, false},
+		{"worker not opted in", AutoAccept{ConsumerAllows: true, WorkerAllows: true}, 150, false},
+		{"consumer opted out of pair", AutoAccept{WorkerEnabled: true, WorkerAllows: true}, 150, false},
+		{"worker opted out of pair", AutoAccept{WorkerEnabled: true, ConsumerAllows: true}, 150, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.settings.Allows(tt.price); got != tt.want {
+				t.Errorf("Allows(%v) = %v, want %v", tt.price, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package fraud
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	"app/internal/notifications"
+)
+
+// Fraud flag statuses
+const (
+	StatusOpen      = "open"
+	StatusDismissed = "dismissed"
+	StatusConfirmed = "confirmed"
+)
+
+// Rules that raise fraud flags
+const (
+	RulePaymentFailureVelocity = "payment_failure_velocity"
+)
+
+// Payment failure velocity: repeated failed payments from one consumer in a day
+const (
+	paymentFailureWindow     = 24 * time.Hour
+	paymentFailureMinimum    = 2
+	paymentFailureSaturation = 5
+)
+
+// Signal is a risk observation about a user
+type Signal struct {
+	UserID  int
+	JobID   *int
+	Rule    string
+	Score   float64 // 0 to 1
+	Details string
+}
+
+// AlertThreshold returns the score at or above which flags are routed to ops
+func AlertThreshold() float64 {
+	if v, err := strconv.ParseFloat(os.Getenv("FRAUD_ALERT_THRESHOLD"), 64); err == nil && v > 0 && v <= 1 {
+		return v
+	}
+	return 0.8
+}
+
+// Raise records a fraud signal. Repeat signals for the same user and rule update the
+// open flag with the higher score. Flags that reach the alert threshold are routed to
+// ops once. Returns the flag ID.
+func Raise(ctx context.Context, db *sql.DB, s Signal) (int, error) {
+	score := math.Min(math.Max(s.Score, 0), 1)
+
+	var flagID int
+	var alerted bool
+	err := db.QueryRowContext(ctx, `
+		INSERT INTO fraud_flags (user_id, job_id, rule, score, details)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, rule) WHERE status = 'open' DO UPDATE SET
+			score = GREATEST(fraud_flags.score, EXCLUDED.score),
+			job_id = COALESCE(EXCLUDED.job_id, fraud_flags.job_id),
+			details = EXCLUDED.details
+		RETURNING id, alerted_at IS NOT NULL
+	`, s.UserID, s.JobID, s.Rule, score, s.Details).Scan(&flagID, &alerted)
+	if err != nil {
+		return 0, fmt.Errorf("failed to record fraud flag: %w", err)
+	}
+
+	if alerted || score < AlertThreshold() {
+		return flagID, nil
+	}
+
+	fields := map[string]string{
+		"Flag":  strconv.Itoa(flagID),
+		"User":  strconv.Itoa(s.UserID),
+		"Rule":  s.Rule,
+		"Score": fmt.Sprintf("%.2f", score),
+	}
+	if s.JobID != nil {
+		fields["Job"] = strconv.Itoa(*s.JobID)
+	}
+	link := ""
+	if adminURL := os.Getenv("ADMIN_BASE_URL"); adminURL != "" {
+		link = fmt.Sprintf("%s/fraud/flags/%d", adminURL, flagID)
+	}
+
+	_, err = notifications.PublishOnce(ctx, db, notifications.EventFraudFlag, notifications.OpsAlert{
+		Title:    "Fraud flag above threshold",
+		Summary:  s.Details,
+		Severity: notifications.SeverityError,
+		Source:   "fraud",
+		DedupKey: fmt.Sprintf("fraud-flag-%d", flagID),
+		Fields:   fields,
+		Link:     link,
+	}, 24*time.Hour)
+	if err != nil {
+		return flagID, err
+	}
+
+	if _, err := db.ExecContext(ctx, `UPDATE fraud_flags SET alerted_at = NOW() WHERE id = $1`, flagID); err != nil {
+		return flagID, fmt.Errorf("failed to mark fraud flag alerted: %w", err)
+	}
+	return flagID, nil
+}
+
+// CheckPaymentFailureVelocity flags a consumer whose payments have failed repeatedly
+// in the last day. Returns 0 when no flag was raised.
+func CheckPaymentFailureVelocity(ctx context.Context, db *sql.DB, consumerID, jobID int) (int, error) {
+	var failures int
+	err := db.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM jobs
+			 WHERE consumer_id = $1 AND status = 'payment_failed' AND updated_at > NOW() - make_interval(secs => $2))
+			+
+			(SELECT COUNT(*) FROM transactions
+			 WHERE consumer_id = $1 AND status = 'failed' AND created_at > NOW() - make_interval(secs => $2))
+	`, consumerID, paymentFailureWindow.Seconds()).Scan(&failures)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count payment failures: %w", err)
+	}
+	if failures < paymentFailureMinimum {
+		return 0, nil
+	}
+
+	return Raise(ctx, db, Signal{
+		UserID:  consumerID,
+		JobID:   &jobID,
+		Rule:    RulePaymentFailureVelocity,
+		Score:   float64(failures) / paymentFailureSaturation,
+		Details: fmt.Sprintf("%d failed payments in the last 24 hours", failures),
+	})
+}
@@ -0,0 +1,133 @@
+// Package indexcheck verifies that the indexes the list endpoints' queries rely on
+// exist. Each registered query pattern names the columns it filters on, in the order
+// an index must have them; an index whose leading key columns are those columns
+// serves the pattern. Indexes are created by the migrations, so a missing one usually
+// means a database that has not been migrated or an index dropped by hand.
+package indexcheck
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"app/internal/model"
+)
+
+// Pattern is a query shape and the index that serves it
+type Pattern struct {
+	Query   string   // What the query does, and the endpoints that make it
+	Table   string   // Table the query filters
+	Columns []string // Leading key columns an index needs, in order
+	Index   string   // Name of the index the migrations create for it
+}
+
+// Patterns are the filtered, newest-first list queries. A pattern added here needs a
+// migration creating its index.
+var Patterns = []Pattern{
+	{Query: "Jobs newest first (GET /api/v1/admin/jobs)", Table: "jobs", Columns: []string{"created_at"}, Index: "idx_jobs_created_at"},
+	{Query: "Jobs by status, newest first (GET /api/v1/jobs, /api/v1/jobs/available, /api/v1/admin/jobs)", Table: "jobs", Columns: []string{"status", "created_at"}, Index: "idx_jobs_status_created"},
+	{Query: "Jobs by consumer, newest first (GET /api/v1/jobs, /api/v1/jobs/my-jobs)", Table: "jobs", Columns: []string{"consumer_id", "created_at"}, Index: "idx_jobs_consumer_created"},
+	{Query: "Jobs by worker, newest first (GET /api/v1/jobs, /api/v1/jobs/my-jobs)", Table: "jobs", Columns: []string{"gig_worker_id", "created_at"}, Index: "idx_jobs_gig_worker_created"},
+	{Query: "Jobs by category, newest first (GET /api/v1/jobs, /api/v1/admin/jobs)", Table: "jobs", Columns: []string{"category", "created_at"}, Index: "idx_jobs_category_created"},
+	{Query: "Transactions for a job (GET /api/v1/jobs/{id}/payments)", Table: "transactions", Columns: []string{"job_id"}, Index: "idx_transactions_job_id"},
+	{Query: "Transactions newest first (GET /api/v1/admin/transactions)", Table: "transactions", Columns: []string{"created_at"}, Index: "idx_transactions_created_at"},
+	{Query: "Transactions by status, newest first (GET /api/v1/admin/transactions)", Table: "transactions", Columns: []string{"status", "created_at"}, Index: "idx_transactions_status_created"},
+	{Query: "Transactions by consumer, newest first (GET /api/v1/admin/transactions)", Table: "transactions", Columns: []string{"consumer_id", "created_at"}, Index: "idx_transactions_consumer_created"},
+	{Query: "Transactions by worker, newest first (GET /api/v1/admin/transactions)", Table: "transactions", Columns: []string{"gig_worker_id", "created_at"}, Index: "idx_transactions_gig_worker_created"},
+	{Query: "Users newest first (GET /api/v1/admin/users)", Table: "people", Columns: []string{"created_at"}, Index: "idx_people_created_at"},
+	{Query: "Users by role, newest first (GET /api/v1/admin/users)", Table: "people", Columns: []string{"role", "created_at"}, Index: "idx_people_role_created"},
+}
+
+// index is an existing index and its key columns, in order
+type index struct {
+	name    string
+	columns []string
+}
+
+// Check reports, for every pattern, whether an index serves it. Partial indexes count:
+// the patterns filter on their leading column with equality, which excludes the NULLs
+// a partial index on that column leaves out.
+func Check(ctx context.Context, db *sql.DB) ([]model.IndexAdvisory, error) {
+	existing, err := load(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	return advise(Patterns, existing), nil
+}
+
+// Missing returns the advisories whose pattern no index serves
+func Missing(advisories []model.IndexAdvisory) []model.IndexAdvisory {
+	var missing []model.IndexAdvisory
+	for _, a := range advisories {
+		if !a.Present {
+			missing = append(missing, a)
+		}
+	}
+	return missing
+}
+
+func advise(patterns []Pattern, existing map[string][]index) []model.IndexAdvisory {
+	advisories := make([]model.IndexAdvisory, len(patterns))
+	for i, p := range patterns {
+		advisories[i] = model.IndexAdvisory{Query: p.Query, Table: p.Table, Columns: p.Columns, Index: p.Index}
+		for _, idx := range existing[p.Table] {
+			if len(idx.columns) >= len(p.Columns) && slices.Equal(idx.columns[:len(p.Columns)], p.Columns) {
+				advisories[i].Present = true
+				advisories[i].ServedBy = idx.name
+				break
+			}
+		}
+		if !advisories[i].Present {
+			columns := make([]string, len(p.Columns))
+			for j, column := range p.Columns {
+				columns[j] = pq.QuoteIdentifier(column)
+			}
+			advisories[i].CreateSQL = fmt.Sprintf("CREATE INDEX CONCURRENTLY %s ON %s(%s)", p.Index, p.Table, strings.Join(columns, ", "))
+		}
+	}
+	return advisories
+}
+
+// load reads the valid indexes on the patterns' tables in the current schema, keyed by
+// table. Expression columns have no name and match no pattern column.
+func load(ctx context.Context, db *sql.DB) (map[string][]index, error) {
+	var tables []string
+	for _, p := range Patterns {
+		if !slices.Contains(tables, p.Table) {
+			tables = append(tables, p.Table)
+		}
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT t.relname, i.relname, array_agg(COALESCE(a.attname, '') ORDER BY k.n)
+		FROM pg_index x
+		JOIN pg_class t ON t.oid = x.indrelid
+		JOIN pg_class i ON i.oid = x.indexrelid
+		JOIN pg_namespace ns ON ns.oid = t.relnamespace
+		CROSS JOIN LATERAL unnest(x.indkey::int2[]) WITH ORDINALITY AS k(attnum, n)
+		LEFT JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum
+		WHERE ns.nspname = current_schema() AND t.relname = ANY($1) AND x.indisvalid
+		  AND k.n <= x.indnkeyatts
+		GROUP BY t.relname, i.relname
+		ORDER BY t.relname, i.relname
+	`, pq.Array(tables))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read indexes: %w", err)
+	}
+	defer rows.Close()
+
+	existing := map[string][]index{}
+	for rows.Next() {
+		var table string
+		var idx index
+		if err := rows.Scan(&table, &idx.name, pq.Array(&idx.columns)); err != nil {
+			return nil, fmt.Errorf("failed to scan index: %w", err)
+		}
+		existing[table] = append(existing[table], idx)
+	}
+	return existing, rows.Err()
+}
@@ -0,0 +1,57 @@
+package indexcheck
+
+import (
+	"testing"
+
+	"app/internal/model"
+)
+
+func TestAdvise(t *testing.T) {
+	pattern := Pattern{Query: "Jobs by consumer", Table: "jobs", Columns: []string{"consumer_id", "created_at"}, Index: "idx_jobs_consumer_created"}
+
+	tests := []struct {
+		name       string
+		existing   map[string][]index
+		wantServed string
+		wantSQL    string
+	}{
+		{
+			name:       "composite index",
+			existing:   map[string][]index{"jobs": {{name: "idx_jobs_consumer_created", columns: []string{"consumer_id", "created_at"}}}},
+			wantServed: "idx_jobs_consumer_created",
+		},
+		{
+			name:       "wider index with the same leading columns",
+			existing:   map[string][]index{"jobs": {{name: "idx_jobs_consumer_created_status", columns: []string{"consumer_id", "created_at", "status"}}}},
+			wantServed: "idx_jobs_consumer_created_status",
+		},
+		{
+			name:     "leading column only",
+			existing: map[string][]index{"jobs": {{name: "idx_jobs_consumer_id", columns: []string{"consumer_id"}}}},
+			wantSQL:  `CREATE INDEX CONCURRENTLY idx_jobs_consumer_created ON jobs("consumer_id", "created_at")`,
+		},
+		{
+			name:     "columns in another order",
+			existing: map[string][]index{"jobs": {{name: "idx_jobs_created_consumer", columns: []string{"created_at", "consumer_id"}}}},
+			wantSQL:  `CREATE INDEX CONCURRENTLY idx_jobs_consumer_created ON jobs("consumer_id", "created_at")`,
+		},
+		{
+			name:     "index on another table",
+			existing: map[string][]index{"transactions": {{name: "idx_transactions_consumer_created", columns: []string{"consumer_id", "created_at"}}}},
+			wantSQL:  `CREATE INDEX CONCURRENTLY idx_jobs_consumer_created ON jobs("consumer_id", "created_at")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := advise([]Pattern{pattern}, tt.existing)[0]
+			if got.Present != (tt.wantServed != "") || got.ServedBy != tt.wantServed || got.CreateSQL != tt.wantSQL {
+				t.Errorf("advise() = present %v, served by %q, create %q; want served by %q, create %q",
+					got.Present, got.ServedBy, got.CreateSQL, tt.wantServed, tt.wantSQL)
+			}
+			if missing := Missing([]model.IndexAdvisory{got}); (len(missing) == 0) != got.Present {
+				t.Errorf("Missing() = %d advisories for present %v", len(missing), got.Present)
+			}
+		})
+	}
+}
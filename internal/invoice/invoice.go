@@ -0,0 +1,462 @@
+// Package invoice renders the PDF documents issued for a captured payment: a receipt
+// for the consumer and an earnings statement for the worker. Documents are rendered
+// once, from the payment as captured, and kept in the attachment store; a refund
+// issues a new version showing it. They are emailed to both parties when the payment
+// is captured and can be downloaded from GET /api/v1/transactions/{id}/receipt.
+package invoice
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"app/internal/email"
+	"app/internal/model"
+	"app/internal/storage"
+)
+
+// Document kinds
+const (
+	KindReceipt           = "receipt"            // For the consumer
+	KindEarningsStatement = "earnings_statement" // For the worker
+)
+
+// layoutVersion is part of every stored document's key; bump it when the layout
+// changes so documents are rendered again
+const layoutVersion = "v1"
+
+// ErrNotFound is returned for transactions that do not exist or were not captured
+var ErrNotFound = errors.New("captured payment not found")
+
+// Document is a receipt or earnings statement, ready to render
+type Document struct {
+	Kind           string
+	Title          string
+	Number         string
+	Issued         time.Time
+	RecipientLabel string // e.g. "Billed to"
+	Recipient      string
+	Details        []Detail
+	Lines          []Line
+	Totals         []Line // The last is the total
+	Notes          []string
+}
+
+// Detail is a labelled fact about the payment, e.g. the job or payment method
+type Detail struct {
+	Label string
+	Value string
+}
+
+// Line is one charge or deduction. Quantity is zero for lines without a unit price.
+type Line struct {
+	Description string
+	Quantity    int
+	UnitPrice   model.Money
+	Amount      model.Money
+}
+
+// Payment is a captured payment with what its documents show. Amounts are in Currency.
+type Payment struct {
+	TransactionID   int
+	TransactionUUID string
+	JobID           int
+	JobTitle        string
+	Currency        string
+	ConsumerID      int
+	ConsumerName    string
+	ConsumerEmail   string
+	WorkerID        int    // Zero when the job had no worker
+	WorkerName      string // Empty when the job had no worker
+	WorkerEmail     string
+	Authorized      model.Money // Price less credits plus tax
+	Captured        model.Money // Authorized plus billed expenses and parts, unless adjusted
+	PlatformFee     model.Money
+	ProcessingFee   model.Money
+	NetAmount       *model.Money // Worker's share of the price; missing on older payments
+	Tax             model.Money
+	Credits         model.Money
+	PaymentMethod   string
+	LastFour        string
+	CapturedAt      time.Time
+	RefundedAt      *time.Time
+	RefundAmount    *model.Money
+	Extras          []Line // Billed materials and reimbursed expenses
+}
+
+// Price is the job price before credits and tax
+func (p *Payment) Price() model.Money {
+	return p.Authorized.Sub(p.Tax).Add(p.Credits)
+}
+
+// WorkerShare is what the worker earns from the job price after fees
+func (p *Payment) WorkerShare() model.Money {
+	if p.NetAmount != nil {
+		return *p.NetAmount
+	}
+	return p.Price().Sub(p.PlatformFee).Sub(p.ProcessingFee)
+}
+
+// BuildReceipt itemizes what the consumer paid
+func BuildReceipt(p *Payment) *Document {
+	doc := &Document{
+		Kind:           KindReceipt,
+		Title:          "Receipt",
+		Number:         documentNumber("R", p.TransactionUUID),
+		Issued:         p.CapturedAt,
+		RecipientLabel: "Billed to",
+		Recipient:      p.ConsumerName,
+		Details:        p.details(p.WorkerName, "Worker"),
+	}
+
+	// The price is itemized into labor and fees when they add up to it
+	price := p.Price()
+	labor := p.WorkerShare()
+	if p.NetAmount != nil && labor.Add(p.PlatformFee).Add(p.ProcessingFee) == price {
+		doc.Lines = append(doc.Lines, quantityLine(p.JobTitle, labor))
+		if p.PlatformFee.IsPositive() {
+			doc.Lines = append(doc.Lines, Line{Description: "Platform fee", Amount: p.PlatformFee})
+		}
+		if p.ProcessingFee.IsPositive() {
+			doc.Lines = append(doc.Lines, Line{Description: "Processing fee", Amount: p.ProcessingFee})
+		}
+	} else {
+		doc.Lines = append(doc.Lines, quantityLine(p.JobTitle, price))
+	}
+	doc.Lines = append(doc.Lines, p.Extras...)
+
+	subtotal := model.NewMoney(0, p.Currency)
+	for _, line := range doc.Lines {
+		subtotal = subtotal.Add(line.Amount)
+	}
+	doc.Totals = append(doc.Totals, Line{Description: "Subtotal", Amount: subtotal})
+	charged := subtotal
+	if p.Credits.IsPositive() {
+		doc.Totals = append(doc.Totals, Line{Description: "Account credit", Amount: p.Credits.Neg()})
+		charged = charged.Sub(p.Credits)
+	}
+	if p.Tax.IsPositive() {
+		doc.Totals = append(doc.Totals, Line{Description: "Tax", Amount: p.Tax})
+		charged = charged.Add(p.Tax)
+	}
+	if adjustment := p.Captured.Sub(charged); !adjustment.IsZero() {
+		doc.Totals = append(doc.Totals, Line{Description: "Adjustment", Amount: adjustment})
+	}
+	doc.Totals = append(doc.Totals, Line{Description: "Total paid", Amount: p.Captured})
+
+	if p.RefundedAt != nil && p.RefundAmount != nil {
+		doc.Totals = append(doc.Totals,
+			Line{Description: "Refunded " + p.RefundedAt.UTC().Format("Jan 2, 2006"), Amount: p.RefundAmount.Neg()},
+			Line{Description: "Net paid", Amount: p.Captured.Sub(*p.RefundAmount)},
+		)
+	}
+
+	doc.Notes = []string{
+		fmt.Sprintf("All amounts in %s. Payment reference %s.", p.Currency, p.TransactionUUID),
+		"Questions about this receipt? Contact support from the job page in the GigCo app.",
+	}
+	return doc
+}
+
+// BuildEarningsStatement itemizes what the worker earned from the payment
+func BuildEarningsStatement(p *Payment) *Document {
+	doc := &Document{
+		Kind:           KindEarningsStatement,
+		Title:          "Earnings Statement",
+		Number:         documentNumber("E", p.TransactionUUID),
+		Issued:         p.CapturedAt,
+		RecipientLabel: "Paid to",
+		Recipient:      p.WorkerName,
+		Details:        p.details(p.ConsumerName, "Customer"),
+	}
+
+	doc.Lines = append(doc.Lines, quantityLine(p.JobTitle, p.Price()))
+	if p.PlatformFee.IsPositive() {
+		doc.Lines = append(doc.Lines, Line{Description: "GigCo platform fee", Amount: p.PlatformFee.Neg()})
+	}
+	if p.ProcessingFee.IsPositive() {
+		doc.Lines = append(doc.Lines, Line{Description: "Card processing fee", Amount: p.ProcessingFee.Neg()})
+	}
+	earnings := p.WorkerShare()
+	for _, extra := range p.Extras {
+		doc.Lines = append(doc.Lines, extra)
+		earnings = earnings.Add(extra.Amount)
+	}
+	doc.Totals = []Line{{Description: "Total earnings", Amount: earnings}}
+
+	doc.Notes = []string{fmt.Sprintf("All amounts in %s. Payment reference %s.", p.Currency, p.TransactionUUID)}
+	if p.Tax.IsPositive() {
+		doc.Notes = append(doc.Notes, fmt.Sprintf("Tax of %s collected from the customer is not part of your earnings.", FormatMoney(p.Tax)))
+	}
+	if p.RefundedAt != nil {
+		doc.Notes = append(doc.Notes, fmt.Sprintf("This payment was refunded to the customer on %s.", p.RefundedAt.UTC().Format("January 2, 2006")))
+	}
+	return doc
+}
+
+// details lists the job, the other party and how the payment was made
+func (p *Payment) details(otherParty, otherPartyLabel string) []Detail {
+	details := []Detail{{Label: "Job", Value: fmt.Sprintf("#%d %s", p.JobID, truncate(p.JobTitle, maxDescriptionRunes))}}
+	if otherParty != "" {
+		details = append(details, Detail{Label: otherPartyLabel, Value: otherParty})
+	}
+	details = append(details, Detail{Label: "Payment date", Value: p.CapturedAt.UTC().Format("January 2, 2006")})
+	if p.PaymentMethod != "" || p.LastFour != "" {
+		method := strings.TrimSpace(strings.ReplaceAll(p.PaymentMethod, "_", " "))
+		if method == "" {
+			method = "card"
+		}
+		if p.LastFour != "" {
+			method += " ending in " + p.LastFour
+		}
+		details = append(details, Detail{Label: "Paid with", Value: method})
+	}
+	return details
+}
+
+func quantityLine(description string, amount model.Money) Line {
+	return Line{Description: description, Quantity: 1, UnitPrice: amount, Amount: amount}
+}
+
+// documentNumber derives a document's number from its payment, so it is the same
+// every time the document is rendered
+func documentNumber(prefix, transactionUUID string) string {
+	return prefix + "-" + strings.ToUpper(shortID(transactionUUID))
+}
+
+// shortID is the first 12 hex digits of a UUID
+func shortID(uuid string) string {
+	id := strings.ReplaceAll(uuid, "-", "")
+	if len(id) > 12 {
+		id = id[:12]
+	}
+	return id
+}
+
+// Service renders and stores payment documents
+type Service struct {
+	db    *sql.DB
+	store storage.Store // nil renders documents without keeping them
+}
+
+// NewService creates a Service that keeps documents in store
+func NewService(db *sql.DB, store storage.Store) *Service {
+	return &Service{db: db, store: store}
+}
+
+// NewServiceFromEnv creates a Service using the store configured by STORAGE_* environment
+// variables. Documents are still rendered when the store is unavailable.
+func NewServiceFromEnv(db *sql.DB) *Service {
+	store, err := storage.NewStoreFromEnv()
+	if err != nil {
+		slog.Warn("Attachment storage not available, payment documents will not be stored", "error", err)
+		store = nil
+	}
+	return NewService(db, store)
+}
+
+// GetPayment loads a captured payment. It returns ErrNotFound for other transactions.
+func (s *Service) GetPayment(ctx context.Context, transactionID int) (*Payment, error) {
+	var p Payment
+	var workerID sql.NullInt64
+	var workerName, workerEmail, paymentMethod, lastFour sql.NullString
+	var netAmount, refundAmount *model.Money
+	var refundedAt sql.NullTime
+
+	err := s.db.QueryRowContext(ctx, `
+		SELECT t.id, t.uuid, j.id, j.title, COALESCE(t.currency, 'USD'),
+		       c.id, c.name, c.email, w.id, w.name, w.email,
+		       t.amount, COALESCE(t.capture_amount, t.amount),
+		       COALESCE(t.platform_fee, 0), COALESCE(t.processing_fee, 0), t.net_amount,
+		       COALESCE(t.metadata->>'tax', '0'), COALESCE(t.metadata->>'credits', '0'),
+		       t.payment_method, t.last_four, t.captured_at, t.refunded_at, t.refund_amount
+		FROM transactions t
+		JOIN jobs j ON j.id = t.job_id
+		JOIN people c ON c.id = t.consumer_id
+		LEFT JOIN people w ON w.id = t.gig_worker_id
+		WHERE t.id = $1 AND t.captured_at IS NOT NULL
+	`, transactionID).Scan(
+		&p.TransactionID, &p.TransactionUUID, &p.JobID, &p.JobTitle, &p.Currency,
+		&p.ConsumerID, &p.ConsumerName, &p.ConsumerEmail, &workerID, &workerName, &workerEmail,
+		&p.Authorized, &p.Captured,
+		&p.PlatformFee, &p.ProcessingFee, &netAmount,
+		&p.Tax, &p.Credits,
+		&paymentMethod, &lastFour, &p.CapturedAt, &refundedAt, &refundAmount,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	// Amounts scan in the default currency
+	for _, m := range []*model.Money{&p.Authorized, &p.Captured, &p.PlatformFee, &p.ProcessingFee, &p.Tax, &p.Credits, netAmount, refundAmount} {
+		if m != nil {
+			*m = m.In(p.Currency)
+		}
+	}
+	p.NetAmount = netAmount
+	p.RefundAmount = refundAmount
+	p.WorkerID = int(workerID.Int64)
+	p.WorkerName = workerName.String
+	p.WorkerEmail = workerEmail.String
+	p.PaymentMethod = paymentMethod.String
+	p.LastFour = lastFour.String
+	if refundedAt.Valid {
+		p.RefundedAt = &refundedAt.Time
+	}
+
+	p.Extras, err = s.getExtras(ctx, transactionID, p.Currency)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// getExtras lists the parts and reimbursed expenses billed with a capture
+func (s *Service) getExtras(ctx context.Context, transactionID int, currency string) ([]Line, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT 'Materials: ' || item_name, quantity, unit_price, total_amount, created_at
+		FROM job_parts_requests WHERE transaction_id = $1
+		UNION ALL
+		SELECT 'Expense: ' || description, 1, amount, amount, incurred_at
+		FROM job_expenses WHERE transaction_id = $1
+		ORDER BY 5
+	`, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get billed parts and expenses: %w", err)
+	}
+	defer rows.Close()
+
+	var extras []Line
+	for rows.Next() {
+		var line Line
+		var createdAt time.Time
+		if err := rows.Scan(&line.Description, &line.Quantity, &line.UnitPrice, &line.Amount, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan billed item: %w", err)
+		}
+		line.UnitPrice = line.UnitPrice.In(currency)
+		line.Amount = line.Amount.In(currency)
+		extras = append(extras, line)
+	}
+	return extras, rows.Err()
+}
+
+// Build builds the document of kind for a payment
+func Build(p *Payment, kind string) (*Document, error) {
+	switch kind {
+	case KindReceipt:
+		return BuildReceipt(p), nil
+	case KindEarningsStatement:
+		if p.WorkerID == 0 {
+			return nil, ErrNotFound
+		}
+		return BuildEarningsStatement(p), nil
+	default:
+		return nil, fmt.Errorf("unknown document kind %q", kind)
+	}
+}
+
+// Filename is the name a document is downloaded and attached as
+func Filename(p *Payment, kind string) string {
+	doc := "receipt"
+	if kind == KindEarningsStatement {
+		doc = "earnings-statement"
+	}
+	return fmt.Sprintf("gigco-%s-%s.pdf", doc, shortID(p.TransactionUUID))
+}
+
+// PDF returns the payment's document of kind, rendering and storing it the first time
+func (s *Service) PDF(ctx context.Context, p *Payment, kind string) ([]byte, error) {
+	doc, err := Build(p, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	key := storageKey(p, kind)
+	if s.store != nil {
+		body, _, err := s.store.Get(ctx, key)
+		if err == nil {
+			defer body.Close()
+			return io.ReadAll(body)
+		}
+		if !errors.Is(err, storage.ErrNotFound) {
+			slog.WarnContext(ctx, "Failed to read stored payment document, rendering it again", "key", key, "error", err)
+		}
+	}
+
+	pdf, err := Render(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", kind, err)
+	}
+	if s.store != nil {
+		if err := s.store.Put(ctx, key, bytes.NewReader(pdf), int64(len(pdf)), "application/pdf"); err != nil {
+			slog.WarnContext(ctx, "Failed to store payment document", "key", key, "error", err)
+		}
+	}
+	return pdf, nil
+}
+
+// storageKey names a document by payment, kind and whether it shows a refund, so a
+// refunded payment's documents are rendered again
+func storageKey(p *Payment, kind string) string {
+	version := layoutVersion
+	if p.RefundedAt != nil {
+		version += fmt.Sprintf("-refunded-%d", p.RefundedAt.Unix())
+	}
+	return fmt.Sprintf("transactions/%s/%s-%s.pdf", p.TransactionUUID, kind, version)
+}
+
+// EmailDocuments emails the consumer their receipt and the worker their earnings
+// statement for a captured payment
+func (s *Service) EmailDocuments(ctx context.Context, transactionID int, sender *email.Service) error {
+	p, err := s.GetPayment(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+
+	type delivery struct {
+		kind, to, name, subject, message string
+	}
+	deliveries := []delivery{{
+		kind:    KindReceipt,
+		to:      p.ConsumerEmail,
+		name:    p.ConsumerName,
+		subject: fmt.Sprintf("Your GigCo receipt for %s", p.JobTitle),
+		message: fmt.Sprintf("Thanks for your payment of %s for %s. Your receipt is attached.", FormatMoney(p.Captured), p.JobTitle),
+	}}
+	if p.WorkerEmail != "" {
+		deliveries = append(deliveries, delivery{
+			kind:    KindEarningsStatement,
+			to:      p.WorkerEmail,
+			name:    p.WorkerName,
+			subject: fmt.Sprintf("Your GigCo earnings statement for %s", p.JobTitle),
+			message: fmt.Sprintf("You've been paid for %s. Your earnings statement is attached.", p.JobTitle),
+		})
+	}
+
+	var errs []error
+	for _, d := range deliveries {
+		pdf, err := s.PDF(ctx, p, d.kind)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		err = sender.SendPaymentDocument(d.to, d.name, d.subject, d.message, email.Attachment{
+			Content:  pdf,
+			Type:     "application/pdf",
+			Filename: Filename(p, d.kind),
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to email %s: %w", d.kind, err))
+		}
+	}
+	return errors.Join(errs...)
+}
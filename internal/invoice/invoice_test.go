@@ -0,0 +1,185 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"app/internal/model"
+)
+
+func testPayment() *Payment {
+	net := model.NewMoney(8000, "EUR")
+	return &Payment{
+		TransactionID:   7,
+		TransactionUUID: "3f2a9c1e-4b7d-4e8a-9c2f-1a2b3c4d5e6f",
+		JobID:           42,
+		JobTitle:        "Fix the (leaky) kitchen tap",
+		Currency:        "EUR",
+		ConsumerID:      1,
+		ConsumerName:    "Ana Consumer",
+		WorkerID:        2,
+		WorkerName:      "Sam Worker",
+		Authorized:      model.NewMoney(9900, "EUR"), // 100.00 - 5.00 credit + 4.00 tax
+		Captured:        model.NewMoney(11400, "EUR"),
+		PlatformFee:     model.NewMoney(1500, "EUR"),
+		ProcessingFee:   model.NewMoney(500, "EUR"),
+		NetAmount:       &net,
+		Tax:             model.NewMoney(400, "EUR"),
+		Credits:         model.NewMoney(500, "EUR"),
+		LastFour:        "4242",
+		CapturedAt:      time.Date(2026, 10, 1, 15, 0, 0, 0, time.UTC),
+		Extras: []Line{
+			{Description: "Materials: Washer", Quantity: 3, UnitPrice: model.NewMoney(500, "EUR"), Amount: model.NewMoney(1500, "EUR")},
+		},
+	}
+}
+
+func TestBuildDocuments(t *testing.T) {
+	eur := func(cents int64) model.Money { return model.NewMoney(cents, "EUR") }
+	refundedAt := time.Date(2026, 10, 3, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		payment    func(p *Payment)
+		build      func(p *Payment) *Document
+		wantLines  int
+		wantTotals []Line
+	}{
+		{
+			name:      "receipt itemizes fees, credit and tax",
+			build:     BuildReceipt,
+			wantLines: 4,
+			wantTotals: []Line{
+				{Description: "Subtotal", Amount: eur(11500)},
+				{Description: "Account credit", Amount: eur(-500)},
+				{Description: "Tax", Amount: eur(400)},
+				{Description: "Total paid", Amount: eur(11400)},
+			},
+		},
+		{
+			name: "receipt shows a manual capture as an adjustment",
+			payment: func(p *Payment) {
+				p.Captured = eur(9000)
+				p.Extras = nil
+			},
+			build:     BuildReceipt,
+			wantLines: 3,
+			wantTotals: []Line{
+				{Description: "Subtotal", Amount: eur(10000)},
+				{Description: "Account credit", Amount: eur(-500)},
+				{Description: "Tax", Amount: eur(400)},
+				{Description: "Adjustment", Amount: eur(-900)},
+				{Description: "Total paid", Amount: eur(9000)},
+			},
+		},
+		{
+			name: "receipt without a worker share prices the job as one line",
+			payment: func(p *Payment) {
+				p.NetAmount = nil
+				p.Credits = eur(0)
+				p.Tax = eur(0)
+				p.Authorized = eur(10000)
+				p.Captured = eur(10000)
+				p.Extras = nil
+			},
+			build:      BuildReceipt,
+			wantLines:  1,
+			wantTotals: []Line{{Description: "Subtotal", Amount: eur(10000)}, {Description: "Total paid", Amount: eur(10000)}},
+		},
+		{
+			name: "refunded receipt shows the net paid",
+			payment: func(p *Payment) {
+				p.RefundedAt = &refundedAt
+				refund := eur(11400)
+				p.RefundAmount = &refund
+			},
+			build:     BuildReceipt,
+			wantLines: 4,
+			wantTotals: []Line{
+				{Description: "Subtotal", Amount: eur(11500)},
+				{Description: "Account credit", Amount: eur(-500)},
+				{Description: "Tax", Amount: eur(400)},
+				{Description: "Total paid", Amount: eur(11400)},
+				{Description: "Refunded Oct 3, 2026", Amount: eur(-11400)},
+				{Description: "Net paid", Amount: eur(0)},
+			},
+		},
+		{
+			name:       "earnings statement deducts fees and adds reimbursements",
+			build:      BuildEarningsStatement,
+			wantLines:  4,
+			wantTotals: []Line{{Description: "Total earnings", Amount: eur(9500)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testPayment()
+			if tt.payment != nil {
+				tt.payment(p)
+			}
+			doc := tt.build(p)
+			if len(doc.Lines) != tt.wantLines {
+				t.Errorf("got %d lines, want %d: %+v", len(doc.Lines), tt.wantLines, doc.Lines)
+			}
+			if fmt.Sprint(doc.Totals) != fmt.Sprint(tt.wantTotals) {
+				t.Errorf("totals = %+v, want %+v", doc.Totals, tt.wantTotals)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	p := testPayment()
+	for i := 0; i < 60; i++ {
+		p.Extras = append(p.Extras, Line{Description: "Expense: Parking", Quantity: 1, UnitPrice: model.NewMoney(250, "EUR"), Amount: model.NewMoney(250, "EUR")})
+	}
+
+	pdf, err := Render(BuildReceipt(p))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4")) || !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Fatal("Render() is not a PDF")
+	}
+	if !bytes.Contains(pdf, []byte(`(Fix the \(leaky\) kitchen tap)`)) {
+		t.Error("Render() should escape parentheses in text")
+	}
+	if bytes.Contains(pdf, []byte(`/Count 1 `)) {
+		t.Error("Render() should continue long receipts on more pages")
+	}
+
+	// Every cross-reference entry must point at its object
+	startxref := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(pdf)
+	if startxref == nil {
+		t.Fatal("Render() has no startxref")
+	}
+	xref, _ := strconv.Atoi(string(startxref[1]))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllSubmatch(pdf[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if want := fmt.Sprintf("%d 0 obj", i+1); !bytes.HasPrefix(pdf[offset:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q, want %q", i+1, pdf[offset:offset+10], want)
+		}
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	tests := []struct {
+		amount model.Money
+		want   string
+	}{
+		{model.USD(1999), "$19.99"},
+		{model.NewMoney(-500, "EUR"), "-€5.00"},
+		{model.NewMoney(120000, "MXN"), "MX$1200.00"},
+	}
+	for _, tt := range tests {
+		if got := FormatMoney(tt.amount); got != tt.want {
+			t.Errorf("FormatMoney(%v) = %q, want %q", tt.amount, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"app/internal/currency"
+	"app/internal/model"
+)
+
+// US Letter, in points
+const (
+	pageWidth  = 612.0
+	pageHeight = 792.0
+	margin     = 54.0
+)
+
+// Table columns: x of the description, and the right edges of the numeric columns
+const (
+	colDescription = margin
+	colQuantity    = 390.0
+	colUnitPrice   = 480.0
+	colAmount      = pageWidth - margin
+	colTotalLabel  = 360.0
+)
+
+// maxDescriptionRunes truncates line descriptions so they stay clear of the numeric columns
+const maxDescriptionRunes = 52
+
+// Fonts: the standard Helvetica faces every PDF reader has, so nothing is embedded
+const (
+	fontRegular = "F1"
+	fontBold    = "F2"
+)
+
+// Render lays the document out as a PDF
+func Render(doc *Document) ([]byte, error) {
+	p := &pdfPages{}
+	p.newPage()
+
+	p.text(fontBold, 11, margin, p.y, "GigCo")
+	p.y -= 30
+	p.text(fontBold, 20, margin, p.y, doc.Title)
+	p.y -= 20
+	p.text(fontRegular, 10, margin, p.y, doc.Number+"  |  "+doc.Issued.UTC().Format("January 2, 2006"))
+	p.y -= 28
+
+	if doc.Recipient != "" {
+		p.text(fontBold, 10, margin, p.y, doc.RecipientLabel)
+		p.y -= 14
+		p.text(fontRegular, 10, margin, p.y, doc.Recipient)
+		p.y -= 24
+	}
+	for _, d := range doc.Details {
+		p.text(fontBold, 9, margin, p.y, d.Label)
+		p.text(fontRegular, 9, margin+110, p.y, d.Value)
+		p.y -= 14
+	}
+	p.y -= 14
+
+	header := func() {
+		p.text(fontBold, 9, colDescription, p.y, "Description")
+		p.textRight(fontBold, 9, colQuantity, p.y, "Qty")
+		p.textRight(fontBold, 9, colUnitPrice, p.y, "Unit price")
+		p.textRight(fontBold, 9, colAmount, p.y, "Amount")
+		p.y -= 6
+		p.rule(margin, colAmount, p.y)
+		p.y -= 14
+	}
+	header()
+	for _, line := range doc.Lines {
+		if p.need(16) {
+			header()
+		}
+		p.text(fontRegular, 9, colDescription, p.y, truncate(line.Description, maxDescriptionRunes))
+		if line.Quantity > 0 {
+			p.textRight(fontRegular, 9, colQuantity, p.y, fmt.Sprint(line.Quantity))
+			p.textRight(fontRegular, 9, colUnitPrice, p.y, FormatMoney(line.UnitPrice))
+		}
+		p.textRight(fontRegular, 9, colAmount, p.y, FormatMoney(line.Amount))
+		p.y -= 16
+	}
+
+	p.need(20 + 16*float64(len(doc.Totals)))
+	p.y += 8
+	p.rule(colTotalLabel, colAmount, p.y)
+	p.y -= 16
+	for i, total := range doc.Totals {
+		font := fontRegular
+		if i == len(doc.Totals)-1 {
+			font = fontBold
+		}
+		p.text(font, 10, colTotalLabel, p.y, total.Description)
+		p.textRight(font, 10, colAmount, p.y, FormatMoney(total.Amount))
+		p.y -= 16
+	}
+
+	p.y -= 16
+	for _, note := range doc.Notes {
+		p.need(14)
+		p.text(fontRegular, 8, margin, p.y, note)
+		p.y -= 12
+	}
+
+	return p.bytes(doc.Title + " " + doc.Number), nil
+}
+
+// FormatMoney formats an amount with its currency's symbol, e.g. "-€12.50"
+func FormatMoney(m model.Money) string {
+	symbol := m.Currency
+	if c, ok := currency.Lookup(m.Currency); ok {
+		symbol = c.Symbol
+	}
+	if m.IsNegative() {
+		return "-" + symbol + m.Neg().String()
+	}
+	return symbol + m.String()
+}
+
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n-3]) + "..."
+}
+
+// pdfPages collects the content stream of each page while the document is laid out
+type pdfPages struct {
+	pages []*bytes.Buffer
+	y     float64 // Baseline of the next line on the current page
+}
+
+func (p *pdfPages) newPage() {
+	p.pages = append(p.pages, &bytes.Buffer{})
+	p.y = pageHeight - margin
+}
+
+// need starts a new page unless height fits above the bottom margin, and reports
+// whether it did
+func (p *pdfPages) need(height float64) bool {
+	if p.y-height >= margin {
+		return false
+	}
+	p.newPage()
+	return true
+}
+
+func (p *pdfPages) text(font string, size, x, y float64, s string) {
+	fmt.Fprintf(p.pages[len(p.pages)-1], "BT /%s %g Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfString(s))
+}
+
+// textRight draws s ending at x. Only the characters amounts are written in are
+// measured exactly; anything else is estimated.
+func (p *pdfPages) textRight(font string, size, x, y float64, s string) {
+	p.text(font, size, x-textWidth(s, size), y, s)
+}
+
+func (p *pdfPages) rule(x1, x2, y float64) {
+	fmt.Fprintf(p.pages[len(p.pages)-1], "0.5 w %.2f %.2f m %.2f %.2f l S\n", x1, y, x2, y)
+}
+
+// bytes assembles the catalog, fonts, pages and cross-reference table
+func (p *pdfPages) bytes(title string) []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1 catalog, 2 page tree, 3-4 fonts, 5 info, then a page and its content per page
+	const firstPage = 6
+	kids := make([]string, len(p.pages))
+	for i := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) /Producer (GigCo) >>", pdfString(title)))
+	for i, content := range p.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %g %g] /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, fontRegular, fontBold, firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// pdfString encodes s for a literal string in WinAnsiEncoding, escaping delimiters.
+// Characters the encoding lacks are replaced with "?".
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r == '€':
+			b.WriteString(`\200`)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, `\%03o`, r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// helveticaWidths are glyph widths, in thousandths of the font size, of the characters
+// amounts and column headings are written in
+var helveticaWidths = map[rune]float64{
+	'0': 556, '1': 556, '2': 556, '3': 556, '4': 556, '5': 556, '6': 556, '7': 556, '8': 556, '9': 556,
+	'.': 278, ',': 278, '-': 333, ' ': 278, '$': 556, '€': 556, '£': 556,
+	'A': 667, 'C': 722, 'M': 833, 'X': 667, 'Q': 778,
+	'a': 556, 'c': 500, 'e': 556, 'i': 222, 'n': 556, 'o': 556, 'p': 556, 'r': 333, 't': 278, 'u': 556, 'y': 500,
+}
+
+func textWidth(s string, size float64) float64 {
+	var width float64
+	for _, r := range s {
+		w, ok := helveticaWidths[r]
+		if !ok {
+			w = 556
+		}
+		width += w
+	}
+	return width * size / 1000
+}
@@ -0,0 +1,420 @@
+// Package jobevents is the append-only history of job lifecycle transitions. Every
+// transition appends an event to job_events and projects the result onto the jobs row
+// in the same database transaction, so the lifecycle columns on jobs (status,
+// gig_worker_id, actual_start, workflow_completed_at) are a projection of the event
+// log that Replay can rebuild.
+package jobevents
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Event types
+const (
+	TypeImported          = "imported" // Snapshot of a job that predates the event store
+	TypePosted            = "posted"
+	TypeOfferSent         = "offer_sent"
+	TypeAccepted          = "accepted"
+	TypeWorkerAssigned    = "worker_assigned"
+	TypeScheduled         = "scheduled"
+	TypeStarted           = "started"
+	TypeCompleted         = "completed"
+	TypePaid              = "paid"
+	TypePaymentFailed     = "payment_failed"
+	TypeReviewRequested   = "review_requested"
+	TypeClosed            = "closed"
+	TypeRejected          = "rejected" // Consumer let the workflow's offer lapse or declined it
+	TypeNoWorkerAvailable = "no_worker_available"
+	TypeCancelled         = "cancelled"
+	TypeWorkerReleased    = "worker_released" // Assigned worker backed out; the job is posted again
+	TypeHandedOff         = "handed_off"      // Assigned worker was released mid-job; the rest is offered to other workers
+)
+
+// Event sources
+const (
+	SourceAPI       = "api"
+	SourceWorkflow  = "workflow"
+	SourceMigration = "migration"
+)
+
+var (
+	// ErrJobNotFound is returned when recording against a job that does not exist
+	ErrJobNotFound = errors.New("job not found")
+	// ErrNotAllowed is returned when the job's current state rejects the transition
+	ErrNotAllowed = errors.New("transition not allowed in the job's current state")
+)
+
+// statusAfter maps each event type to the job status it leaves behind. Imported
+// events carry their status instead.
+var statusAfter = map[string]string{
+	TypePosted:            "posted",
+	TypeOfferSent:         "offer_sent",
+	TypeAccepted:          "accepted",
+	TypeWorkerAssigned:    "worker_assigned",
+	TypeScheduled:         "scheduled",
+	TypeStarted:           "in_progress",
+	TypeCompleted:         "completed",
+	TypePaid:              "paid",
+	TypePaymentFailed:     "payment_failed",
+	TypeReviewRequested:   "review_pending",
+	TypeClosed:            "closed",
+	TypeRejected:          "rejected",
+	TypeNoWorkerAvailable: "no_worker_available",
+	TypeCancelled:         "cancelled",
+	TypeWorkerReleased:    "posted",
+	TypeHandedOff:         "accepted",
+}
+
+// Event is one recorded lifecycle transition
+type Event struct {
+	ID         int64                  `json:"id"`
+	JobID      int                    `json:"job_id"`
+	Sequence   int                    `json:"sequence"`
+	Type       string                 `json:"type"`
+	Status     string                 `json:"status"`                  // Job status after the event
+	WorkerID   *int                   `json:"gig_worker_id,omitempty"` // Worker the event assigns, if any
+	Source     string                 `json:"source"`
+	ActorID    *int                   `json:"actor_id,omitempty"` // User whose action caused the event, if any
+	Data       map[string]interface{} `json:"data,omitempty"`
+	OccurredAt time.Time              `json:"occurred_at"`
+}
+
+// State is the projection of a job's events onto its lifecycle columns
+type State struct {
+	Status              string     `json:"status"`
+	GigWorkerID         *int       `json:"gig_worker_id"`
+	ActualStart         *time.Time `json:"actual_start"`
+	WorkflowCompletedAt *time.Time `json:"workflow_completed_at"`
+	Sequence            int        `json:"sequence"` // Last event applied
+}
+
+// Transition is a lifecycle change to record against a job
+type Transition struct {
+	JobID    int
+	Type     string
+	WorkerID *int
+	Source   string
+	ActorID  *int
+	Data     map[string]interface{}
+	// Allowed reports whether the job's current state permits the transition. It is
+	// checked with the job row locked, so it doubles as a concurrency guard. Nil
+	// allows any state.
+	Allowed func(State) bool
+}
+
+// StatusIn allows a transition only from the given statuses
+func StatusIn(statuses ...string) func(State) bool {
+	return func(s State) bool {
+		for _, status := range statuses {
+			if s.Status == status {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Apply returns the state after an event. It is the only place the projection rules
+// live, so recording and replay always agree.
+func Apply(s State, e Event) State {
+	s.Status = e.Status
+	s.Sequence = e.Sequence
+
+	switch e.Type {
+	case TypeImported:
+		s.GigWorkerID = e.WorkerID
+		s.ActualStart = dataTime(e.Data, "actual_start")
+		s.WorkflowCompletedAt = dataTime(e.Data, "workflow_completed_at")
+		return s
+	case TypeWorkerReleased:
+		s.GigWorkerID = nil
+		s.ActualStart = nil
+	case TypeHandedOff:
+		// The job did start; only the worker changes
+		s.GigWorkerID = nil
+	case TypeStarted:
+		if s.ActualStart == nil {
+			at := e.OccurredAt
+			s.ActualStart = &at
+		}
+	case TypeClosed:
+		at := e.OccurredAt
+		s.WorkflowCompletedAt = &at
+	}
+	if e.WorkerID != nil {
+		s.GigWorkerID = e.WorkerID
+	}
+	return s
+}
+
+// Fold replays events in sequence order from an empty job
+func Fold(events []Event) State {
+	var s State
+	for _, e := range events {
+		s = Apply(s, e)
+	}
+	return s
+}
+
+// Record appends a transition and updates the job's projection in its own transaction
+func Record(ctx context.Context, db *sql.DB, t Transition) (*Event, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	e, err := RecordTx(ctx, tx, t)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit job event: %w", err)
+	}
+	return e, nil
+}
+
+// RecordTx appends a transition and updates the job's projection within tx. The job
+// row stays locked until tx ends, so concurrent transitions on one job serialize.
+// Repeating the job's latest transition (e.g. a retried activity) records nothing and
+// returns the existing event.
+func RecordTx(ctx context.Context, tx *sql.Tx, t Transition) (*Event, error) {
+	status, ok := statusAfter[t.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown job event type %q", t.Type)
+	}
+
+	state, err := lockState(ctx, tx, t.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Jobs created outside the API (seed data, manual inserts) start with a snapshot
+	// so replay reproduces the state the transition was applied to
+	if state.Sequence == 0 {
+		if err := importState(ctx, tx, t.JobID, &state); err != nil {
+			return nil, err
+		}
+	}
+
+	last, err := latestEvent(ctx, tx, t.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if last != nil && isRepeat(*last, t) {
+		return last, nil
+	}
+
+	if t.Allowed != nil && !t.Allowed(state) {
+		return nil, fmt.Errorf("%w: job %d is %s", ErrNotAllowed, t.JobID, state.Status)
+	}
+
+	e := Event{
+		JobID:    t.JobID,
+		Sequence: state.Sequence + 1,
+		Type:     t.Type,
+		Status:   status,
+		WorkerID: t.WorkerID,
+		Source:   t.Source,
+		ActorID:  t.ActorID,
+		Data:     t.Data,
+	}
+	if err := insertEvent(ctx, tx, &e); err != nil {
+		return nil, err
+	}
+	if err := project(ctx, tx, t.JobID, Apply(state, e)); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// isRepeat reports whether a transition would only repeat the job's latest event
+func isRepeat(last Event, t Transition) bool {
+	if last.Type != t.Type {
+		return false
+	}
+	if t.WorkerID == nil {
+		return true
+	}
+	return last.WorkerID != nil && *last.WorkerID == *t.WorkerID
+}
+
+// History returns a job's events in sequence order
+func History(ctx context.Context, db *sql.DB, jobID int) ([]Event, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+eventColumns+`
+		FROM job_events
+		WHERE job_id = $1
+		ORDER BY sequence
+	`, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load events for job %d: %w", jobID, err)
+	}
+	defer rows.Close()
+
+	events := []Event{}
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *e)
+	}
+	return events, rows.Err()
+}
+
+const eventColumns = `id, job_id, sequence, event_type, status, gig_worker_id, source, actor_id, data, occurred_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanEvent(row rowScanner) (*Event, error) {
+	var e Event
+	var workerID, actorID sql.NullInt64
+	var data []byte
+	err := row.Scan(&e.ID, &e.JobID, &e.Sequence, &e.Type, &e.Status, &workerID, &e.Source, &actorID, &data, &e.OccurredAt)
+	if err != nil {
+		return nil, err
+	}
+	e.WorkerID = intPtr(workerID)
+	e.ActorID = intPtr(actorID)
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &e.Data); err != nil {
+			return nil, fmt.Errorf("failed to decode data of job event %d: %w", e.ID, err)
+		}
+	}
+	return &e, nil
+}
+
+// lockState locks the job row and reads its current projection
+func lockState(ctx context.Context, tx *sql.Tx, jobID int) (State, error) {
+	var s State
+	var workerID sql.NullInt64
+	var actualStart, workflowCompletedAt sql.NullTime
+	err := tx.QueryRowContext(ctx, `
+		SELECT COALESCE(j.status::text, 'posted'), j.gig_worker_id, j.actual_start, j.workflow_completed_at,
+		       (SELECT COALESCE(MAX(e.sequence), 0) FROM job_events e WHERE e.job_id = j.id)
+		FROM jobs j
+		WHERE j.id = $1
+		FOR UPDATE OF j
+	`, jobID).Scan(&s.Status, &workerID, &actualStart, &workflowCompletedAt, &s.Sequence)
+	if err == sql.ErrNoRows {
+		return State{}, ErrJobNotFound
+	}
+	if err != nil {
+		return State{}, fmt.Errorf("failed to lock job %d: %w", jobID, err)
+	}
+	s.GigWorkerID = intPtr(workerID)
+	s.ActualStart = timePtr(actualStart)
+	s.WorkflowCompletedAt = timePtr(workflowCompletedAt)
+	return s, nil
+}
+
+// importState records a snapshot of a job that has no events yet
+func importState(ctx context.Context, tx *sql.Tx, jobID int, s *State) error {
+	e := Event{
+		JobID:    jobID,
+		Sequence: 1,
+		Type:     TypeImported,
+		Status:   s.Status,
+		WorkerID: s.GigWorkerID,
+		Source:   SourceMigration,
+		Data:     map[string]interface{}{},
+	}
+	if s.ActualStart != nil {
+		e.Data["actual_start"] = s.ActualStart.Format(time.RFC3339Nano)
+	}
+	if s.WorkflowCompletedAt != nil {
+		e.Data["workflow_completed_at"] = s.WorkflowCompletedAt.Format(time.RFC3339Nano)
+	}
+	if err := insertEvent(ctx, tx, &e); err != nil {
+		return err
+	}
+	s.Sequence = 1
+	return nil
+}
+
+func latestEvent(ctx context.Context, tx *sql.Tx, jobID int) (*Event, error) {
+	e, err := scanEvent(tx.QueryRowContext(ctx, `
+		SELECT `+eventColumns+`
+		FROM job_events
+		WHERE job_id = $1
+		ORDER BY sequence DESC
+		LIMIT 1
+	`, jobID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load latest event for job %d: %w", jobID, err)
+	}
+	return e, nil
+}
+
+func insertEvent(ctx context.Context, tx *sql.Tx, e *Event) error {
+	var data []byte
+	if len(e.Data) > 0 {
+		var err error
+		if data, err = json.Marshal(e.Data); err != nil {
+			return fmt.Errorf("failed to marshal job event data: %w", err)
+		}
+	}
+
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO job_events (job_id, sequence, event_type, status, gig_worker_id, source, actor_id, data)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, occurred_at
+	`, e.JobID, e.Sequence, e.Type, e.Status, e.WorkerID, e.Source, e.ActorID, data).Scan(&e.ID, &e.OccurredAt)
+	if err != nil {
+		return fmt.Errorf("failed to record %s event for job %d: %w", e.Type, e.JobID, err)
+	}
+	return nil
+}
+
+// project writes a state onto the job's lifecycle columns
+func project(ctx context.Context, tx *sql.Tx, jobID int, s State) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE jobs
+		SET status = $2, gig_worker_id = $3, actual_start = $4, workflow_completed_at = $5, updated_at = NOW()
+		WHERE id = $1
+	`, jobID, s.Status, s.GigWorkerID, s.ActualStart, s.WorkflowCompletedAt)
+	if err != nil {
+		return fmt.Errorf("failed to project job %d: %w", jobID, err)
+	}
+	return nil
+}
+
+// dataTime reads a timestamp stored in event data
+func dataTime(data map[string]interface{}, key string) *time.Time {
+	v, ok := data[key].(string)
+	if !ok {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+func intPtr(n sql.NullInt64) *int {
+	if !n.Valid {
+		return nil
+	}
+	v := int(n.Int64)
+	return &v
+}
+
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
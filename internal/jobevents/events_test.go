@@ -0,0 +1,123 @@
+package jobevents
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFold(t *testing.T) {
+	worker, other := 7, 9
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+	event := func(seq int, typ string, workerID *int, minutes int) Event {
+		return Event{Sequence: seq, Type: typ, Status: statusAfter[typ], WorkerID: workerID, OccurredAt: at(minutes)}
+	}
+
+	tests := []struct {
+		name        string
+		events      []Event
+		wantStatus  string
+		wantWorker  *int
+		wantStarted *time.Time
+		wantClosed  *time.Time
+	}{
+		{
+			name: "full lifecycle",
+			events: []Event{
+				event(1, TypePosted, nil, 0),
+				event(2, TypeAccepted, &worker, 1),
+				event(3, TypeStarted, nil, 2),
+				event(4, TypeCompleted, nil, 3),
+				event(5, TypePaid, nil, 4),
+				event(6, TypeReviewRequested, nil, 5),
+				event(7, TypeClosed, nil, 6),
+			},
+			wantStatus: "closed", wantWorker: &worker, wantStarted: ptr(at(2)), wantClosed: ptr(at(6)),
+		},
+		{
+			name: "released worker clears assignment",
+			events: []Event{
+				event(1, TypePosted, nil, 0),
+				event(2, TypeOfferSent, &worker, 1),
+				event(3, TypeWorkerReleased, nil, 2),
+				event(4, TypeAccepted, &other, 3),
+			},
+			wantStatus: "accepted", wantWorker: &other,
+		},
+		{
+			name: "hand-off swaps worker and keeps start time",
+			events: []Event{
+				event(1, TypeWorkerAssigned, &worker, 0),
+				event(2, TypeStarted, nil, 1),
+				event(3, TypeHandedOff, nil, 2),
+				event(4, TypeWorkerAssigned, &other, 3),
+				event(5, TypeStarted, nil, 4),
+			},
+			wantStatus: "in_progress", wantWorker: &other, wantStarted: ptr(at(1)),
+		},
+		{
+			name: "repeated start keeps first start time",
+			events: []Event{
+				event(1, TypeAccepted, &worker, 0),
+				event(2, TypeStarted, nil, 1),
+				event(3, TypeStarted, nil, 5),
+			},
+			wantStatus: "in_progress", wantWorker: &worker, wantStarted: ptr(at(1)),
+		},
+		{
+			name: "imported snapshot then transition",
+			events: []Event{
+				{Sequence: 1, Type: TypeImported, Status: "in_progress", WorkerID: &worker,
+					Data: map[string]interface{}{"actual_start": at(30).Format(time.RFC3339Nano)}},
+				event(2, TypeCompleted, nil, 40),
+			},
+			wantStatus: "completed", wantWorker: &worker, wantStarted: ptr(at(30)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Fold(tt.events)
+			if got.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", got.Status, tt.wantStatus)
+			}
+			if got.Sequence != len(tt.events) {
+				t.Errorf("Sequence = %d, want %d", got.Sequence, len(tt.events))
+			}
+			if !sameInt(got.GigWorkerID, tt.wantWorker) {
+				t.Errorf("GigWorkerID = %v, want %v", got.GigWorkerID, tt.wantWorker)
+			}
+			if !sameTime(got.ActualStart, tt.wantStarted) {
+				t.Errorf("ActualStart = %v, want %v", got.ActualStart, tt.wantStarted)
+			}
+			if !sameTime(got.WorkflowCompletedAt, tt.wantClosed) {
+				t.Errorf("WorkflowCompletedAt = %v, want %v", got.WorkflowCompletedAt, tt.wantClosed)
+			}
+		})
+	}
+}
+
+func TestIsRepeat(t *testing.T) {
+	worker, other := 7, 9
+	tests := []struct {
+		name string
+		last Event
+		next Transition
+		want bool
+	}{
+		{name: "retried activity", last: Event{Type: TypeScheduled}, next: Transition{Type: TypeScheduled}, want: true},
+		{name: "different type", last: Event{Type: TypeScheduled}, next: Transition{Type: TypePaid}},
+		{name: "same worker", last: Event{Type: TypeOfferSent, WorkerID: &worker}, next: Transition{Type: TypeOfferSent, WorkerID: &worker}, want: true},
+		{name: "offer to another worker", last: Event{Type: TypeOfferSent, WorkerID: &worker}, next: Transition{Type: TypeOfferSent, WorkerID: &other}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRepeat(tt.last, tt.next); got != tt.want {
+				t.Errorf("isRepeat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func ptr(t time.Time) *time.Time { return &t }
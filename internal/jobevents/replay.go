@@ -0,0 +1,94 @@
+package jobevents
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ReplayResult describes one job's projection before and after a replay
+type ReplayResult struct {
+	JobID   int
+	Before  State
+	After   State
+	Changed bool
+}
+
+// Replay rebuilds a job's lifecycle columns from its events. With dryRun it only
+// reports drift. Jobs without events are left alone.
+func Replay(ctx context.Context, db *sql.DB, jobID int, dryRun bool) (*ReplayResult, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	before, err := lockState(ctx, tx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT `+eventColumns+`
+		FROM job_events
+		WHERE job_id = $1
+		ORDER BY sequence
+	`, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load events for job %d: %w", jobID, err)
+	}
+	var events []Event
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		events = append(events, *e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := &ReplayResult{JobID: jobID, Before: before, After: before}
+	if len(events) == 0 {
+		return result, nil
+	}
+	result.After = Fold(events)
+	result.Changed = !sameProjection(before, result.After)
+	if !result.Changed || dryRun {
+		return result, nil
+	}
+
+	if err := project(ctx, tx, jobID, result.After); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit replay of job %d: %w", jobID, err)
+	}
+	return result, nil
+}
+
+// sameProjection compares the columns a replay would write
+func sameProjection(a, b State) bool {
+	return a.Status == b.Status &&
+		sameInt(a.GigWorkerID, b.GigWorkerID) &&
+		sameTime(a.ActualStart, b.ActualStart) &&
+		sameTime(a.WorkflowCompletedAt, b.WorkflowCompletedAt)
+}
+
+func sameInt(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
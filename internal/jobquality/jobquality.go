@@ -0,0 +1,197 @@
+// Package jobquality checks a job posting has what a worker needs to take it on. Some
+// fields are required before a job can be posted, depending on its category; the rest
+// only count toward a 0-100 completeness score that the worker feed and matching use
+// to rank well-described postings ahead of sparse ones.
+package jobquality
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// LowScore is the completeness score below which a posting counts as low quality
+const LowScore = 60
+
+// MinDescriptionLength is how long a description must be to count as detailed
+const MinDescriptionLength = 50
+
+// Posting is the part of a job the completeness check looks at. Photos is the number
+// of photos attached to the job, zero before it is posted.
+type Posting struct {
+	Category           string
+	Description        string
+	LocationAddress    string
+	HasCoordinates     bool
+	AccessInstructions string
+	ScheduledStart     *time.Time
+	ScheduledEnd       *time.Time
+	DurationHours      *float64
+	Photos             int
+}
+
+// Missing is a field the posting lacks and what to do about it. Required fields block
+// posting; the others only lower the score.
+type Missing struct {
+	Field    string `json:"field"`
+	Message  string `json:"message"`
+	Required bool   `json:"required"`
+}
+
+// Report is the result of checking a posting
+type Report struct {
+	Score   int       `json:"score"`
+	Missing []Missing `json:"missing"`
+}
+
+// Complete reports whether no required field is missing
+func (r Report) Complete() bool {
+	return len(r.Required()) == 0
+}
+
+// Required returns the missing fields that block posting
+func (r Report) Required() []Missing {
+	var required []Missing
+	for _, m := range r.Missing {
+		if m.Required {
+			required = append(required, m)
+		}
+	}
+	return required
+}
+
+// requirement is how much a check matters for a category
+type requirement int
+
+const (
+	notApplicable requirement = iota
+	recommended
+	required
+)
+
+// check is one completeness rule. weight is its share of the score when it applies.
+type check struct {
+	field   string
+	message string
+	weight  int
+	applies func(category string) requirement
+	ok      func(p Posting) bool
+}
+
+// Categories worked inside the customer's home need access instructions; categories
+// tied to a time slot need a schedule; remote-capable ones don't need an exact address
+var (
+	inHomeCategories   = categorySet("cleaning", "maintenance", "pet_care", "personal_care")
+	timedCategories    = categorySet("personal_care", "pet_care", "tutoring", "transportation")
+	remoteCategories   = categorySet("tutoring", "tech_support")
+	photoCategories    = categorySet("cleaning", "maintenance")
+	houseNumberAddress = regexp.MustCompile(`^\d+[A-Za-z]?\s+\S`)
+)
+
+var checks = []check{
+	{
+		field:   "location_address",
+		message: "Give the exact street address, including the house number, or the location's coordinates",
+		weight:  25,
+		applies: func(category string) requirement {
+			if remoteCategories[category] {
+				return recommended
+			}
+			return required
+		},
+		ok: func(p Posting) bool {
+			return p.HasCoordinates || houseNumberAddress.MatchString(strings.TrimSpace(p.LocationAddress))
+		},
+	},
+	{
+		field:   "access_instructions",
+		message: "Explain how the worker gets in: gate or door codes, parking, where the key is",
+		weight:  20,
+		applies: func(category string) requirement {
+			if inHomeCategories[category] {
+				return required
+			}
+			return recommended
+		},
+		ok: func(p Posting) bool { return strings.TrimSpace(p.AccessInstructions) != "" },
+	},
+	{
+		field:   "scheduled_start",
+		message: "Set scheduled_start and scheduled_end for when the work should happen",
+		weight:  20,
+		applies: func(category string) requirement {
+			if timedCategories[category] {
+				return required
+			}
+			return recommended
+		},
+		ok: func(p Posting) bool { return p.ScheduledStart != nil && p.ScheduledEnd != nil },
+	},
+	{
+		field:   "photos",
+		message: "Add photos of the work area so workers can see the size of the job",
+		weight:  15,
+		applies: func(category string) requirement {
+			if photoCategories[category] {
+				return recommended
+			}
+			return notApplicable
+		},
+		ok: func(p Posting) bool { return p.Photos > 0 },
+	},
+	{
+		field:   "description",
+		message: "Describe the work in more detail (at least 50 characters)",
+		weight:  10,
+		applies: func(string) requirement { return recommended },
+		ok:      func(p Posting) bool { return len(strings.TrimSpace(p.Description)) >= MinDescriptionLength },
+	},
+	{
+		field:   "estimated_duration_hours",
+		message: "Estimate how many hours the work will take",
+		weight:  10,
+		applies: func(string) requirement { return recommended },
+		ok:      func(p Posting) bool { return p.DurationHours != nil && *p.DurationHours > 0 },
+	},
+}
+
+// Check scores a posting and lists what it is missing, required fields first
+func Check(p Posting) Report {
+	category := strings.ToLower(strings.TrimSpace(p.Category))
+
+	report := Report{Missing: []Missing{}}
+	var total, earned int
+	var optional []Missing
+	for _, c := range checks {
+		req := c.applies(category)
+		if req == notApplicable {
+			continue
+		}
+		total += c.weight
+		if c.ok(p) {
+			earned += c.weight
+			continue
+		}
+		m := Missing{Field: c.field, Message: c.message, Required: req == required}
+		if m.Required {
+			report.Missing = append(report.Missing, m)
+		} else {
+			optional = append(optional, m)
+		}
+	}
+	report.Missing = append(report.Missing, optional...)
+
+	report.Score = 100
+	if total > 0 {
+		report.Score = earned * 100 / total
+	}
+	return report
+}
+
+func categorySet(categories ...string) map[string]bool {
+	set := make(map[string]bool, len(categories))
+	for _, c := range categories {
+		set[c] = true
+	}
+	return set
+}
@@ -0,0 +1,100 @@
+package jobquality
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func fields(missing []Missing) []string {
+	names := []string{}
+	for _, m := range missing {
+		names = append(names, m.Field)
+	}
+	return names
+}
+
+func TestCheck(t *testing.T) {
+	start := time.Date(2026, 11, 2, 9, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Hour)
+	hours := 3.0
+	detailed := strings.Repeat("Deep clean of a two bedroom flat. ", 2)
+
+	complete := Posting{
+		Category:           "cleaning",
+		Description:        detailed,
+		LocationAddress:    "12 Elm Street, Springfield, IL 62701",
+		AccessInstructions: "Key is under the mat",
+		ScheduledStart:     &start,
+		ScheduledEnd:       &end,
+		DurationHours:      &hours,
+		Photos:             2,
+	}
+	noPhotos := complete
+	noPhotos.Photos = 0
+
+	tests := []struct {
+		name         string
+		posting      Posting
+		wantScore    int
+		wantRequired []string
+		wantMissing  []string
+	}{
+		{"complete", complete, 100, []string{}, []string{}},
+		{"photos only count toward the score", noPhotos, 85, []string{}, []string{"photos"}},
+		{
+			"empty in-home posting",
+			Posting{Category: "Cleaning"},
+			0,
+			[]string{"location_address", "access_instructions"},
+			[]string{"location_address", "access_instructions", "scheduled_start", "photos", "description", "estimated_duration_hours"},
+		},
+		{
+			"timed category needs a schedule",
+			Posting{Category: "pet_care", LocationAddress: "4B Baker Street", AccessInstructions: "Side gate"},
+			52,
+			[]string{"scheduled_start"},
+			[]string{"scheduled_start", "description", "estimated_duration_hours"},
+		},
+		{
+			"street without a house number is not exact",
+			Posting{Category: "delivery", LocationAddress: "Main Street, Springfield", ScheduledStart: &start, ScheduledEnd: &end},
+			23,
+			[]string{"location_address"},
+			[]string{"location_address", "access_instructions", "description", "estimated_duration_hours"},
+		},
+		{
+			"coordinates are exact",
+			Posting{Category: "delivery", HasCoordinates: true},
+			29,
+			[]string{},
+			[]string{"access_instructions", "scheduled_start", "description", "estimated_duration_hours"},
+		},
+		{
+			"remote category only recommends an address",
+			Posting{Category: "tutoring", Description: detailed, ScheduledStart: &start, ScheduledEnd: &end, DurationHours: &hours},
+			47,
+			[]string{},
+			[]string{"location_address", "access_instructions"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Check(tt.posting)
+			if got.Score != tt.wantScore {
+				t.Errorf("Score = %d, want %d", got.Score, tt.wantScore)
+			}
+			if required := fields(got.Required()); !reflect.DeepEqual(required, tt.wantRequired) {
+				t.Errorf("Required() = %v, want %v", required, tt.wantRequired)
+			}
+			if missing := fields(got.Missing); !reflect.DeepEqual(missing, tt.wantMissing) {
+				t.Errorf("Missing = %v, want %v", missing, tt.wantMissing)
+			}
+			if got.Complete() != (len(tt.wantRequired) == 0) {
+				t.Errorf("Complete() = %v with required %v", got.Complete(), tt.wantRequired)
+			}
+		})
+	}
+}
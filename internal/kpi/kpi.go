@@ -0,0 +1,165 @@
+// Package kpi watches platform KPIs for anomalies. Each metric is measured over the
+// last 24 hours and compared with the same measure over each of the trailing days; it
+// is anomalous when it has moved far enough from that baseline, both in standard
+// deviations and relative to the baseline mean, in the direction that matters for it.
+package kpi
+
+import (
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Monitored metrics
+const (
+	MetricJobCreation     = "job_creation"
+	MetricPaymentFailure  = "payment_failure_rate"
+	MetricOfferAcceptance = "offer_acceptance_rate"
+)
+
+// Directions a metric can move in to be anomalous
+const (
+	DirectionBoth = "both"
+	DirectionUp   = "up"
+	DirectionDown = "down"
+)
+
+// MinBaselinePeriods is how many usable trailing days a metric needs before it is judged
+const MinBaselinePeriods = 7
+
+// Metric is a monitored KPI and how sensitive its alert is
+type Metric struct {
+	Name      string
+	Label     string
+	Rate      bool    // Value is a share of Volume rather than a count
+	Direction string  // Which moves are anomalous
+	Threshold float64 // Standard deviations from the baseline mean
+	MinChange float64 // Change relative to the baseline mean, e.g. 0.3 for 30%
+	// Rate metrics ignore days with fewer events than this; count metrics are only
+	// judged once their baseline averages at least this many
+	MinVolume int
+}
+
+// Period is one day's measure of a metric
+type Period struct {
+	Value  float64
+	Volume int
+}
+
+// Anomaly is a metric that has moved away from its baseline
+type Anomaly struct {
+	Metric    Metric
+	Current   float64
+	Volume    int
+	Baseline  float64 // Mean of the trailing days
+	StdDev    float64
+	Deviation float64 // Standard deviations from the baseline; infinite when the baseline never varied
+	Change    float64 // Relative to the baseline; infinite when the baseline is zero
+}
+
+// Metrics returns the monitored KPIs. KPI_<METRIC>_THRESHOLD, KPI_<METRIC>_MIN_CHANGE and
+// KPI_<METRIC>_MIN_VOLUME override each metric's sensitivity, e.g.
+// KPI_PAYMENT_FAILURE_RATE_THRESHOLD=2.5.
+func Metrics() []Metric {
+	metrics := []Metric{
+		{Name: MetricJobCreation, Label: "Jobs posted", Direction: DirectionBoth, Threshold: 3, MinChange: 0.3, MinVolume: 5},
+		{Name: MetricPaymentFailure, Label: "Payment failure rate", Rate: true, Direction: DirectionUp, Threshold: 3, MinChange: 0.5, MinVolume: 20},
+		{Name: MetricOfferAcceptance, Label: "Offer acceptance rate", Rate: true, Direction: DirectionDown, Threshold: 3, MinChange: 0.2, MinVolume: 20},
+	}
+	for i := range metrics {
+		prefix := "KPI_" + strings.ToUpper(metrics[i].Name) + "_"
+		metrics[i].Threshold = envFloat(prefix+"THRESHOLD", metrics[i].Threshold)
+		metrics[i].MinChange = envFloat(prefix+"MIN_CHANGE", metrics[i].MinChange)
+		metrics[i].MinVolume = int(envFloat(prefix+"MIN_VOLUME", float64(metrics[i].MinVolume)))
+	}
+	return metrics
+}
+
+// Detect compares the current period of a metric with its trailing baseline
+func Detect(m Metric, current Period, baseline []Period) (Anomaly, bool) {
+	if m.Rate && current.Volume < m.MinVolume {
+		return Anomaly{}, false
+	}
+
+	var values []float64
+	for _, p := range baseline {
+		if m.Rate && p.Volume < m.MinVolume {
+			continue
+		}
+		values = append(values, p.Value)
+	}
+	if len(values) < MinBaselinePeriods {
+		return Anomaly{}, false
+	}
+
+	mean, stdDev := meanStdDev(values)
+	if !m.Rate && mean < float64(m.MinVolume) {
+		return Anomaly{}, false
+	}
+
+	a := Anomaly{
+		Metric:    m,
+		Current:   current.Value,
+		Volume:    current.Volume,
+		Baseline:  mean,
+		StdDev:    stdDev,
+		Deviation: ratio(current.Value-mean, stdDev),
+		Change:    ratio(current.Value-mean, mean),
+	}
+
+	switch m.Direction {
+	case DirectionUp:
+		if a.Deviation < m.Threshold || a.Change < m.MinChange {
+			return Anomaly{}, false
+		}
+	case DirectionDown:
+		if -a.Deviation < m.Threshold || -a.Change < m.MinChange {
+			return Anomaly{}, false
+		}
+	default:
+		if math.Abs(a.Deviation) < m.Threshold || math.Abs(a.Change) < m.MinChange {
+			return Anomaly{}, false
+		}
+	}
+	return a, true
+}
+
+// Up reports whether the metric rose above its baseline
+func (a Anomaly) Up() bool {
+	return a.Current > a.Baseline
+}
+
+// meanStdDev returns the mean and sample standard deviation of values
+func meanStdDev(values []float64) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(values)-1))
+}
+
+// ratio divides, returning a signed infinity for a non-zero numerator over zero
+func ratio(numerator, denominator float64) float64 {
+	if denominator == 0 {
+		if numerator == 0 {
+			return 0
+		}
+		return math.Inf(int(math.Copysign(1, numerator)))
+	}
+	return numerator / denominator
+}
+
+// envFloat reads a positive float setting, falling back when unset or invalid
+func envFloat(key string, fallback float64) float64 {
+	if v, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil && v > 0 {
+		return v
+	}
+	return fallback
+}
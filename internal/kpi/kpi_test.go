@@ -0,0 +1,121 @@
+package kpi
+
+import (
+	"testing"
+)
+
+// days repeats a period n times
+func days(n int, values ...float64) []Period {
+	periods := make([]Period, 0, n)
+	for i := 0; i < n; i++ {
+		periods = append(periods, Period{Value: values[i%len(values)], Volume: 100})
+	}
+	return periods
+}
+
+func TestDetect(t *testing.T) {
+	jobs := Metric{Name: MetricJobCreation, Direction: DirectionBoth, Threshold: 3, MinChange: 0.3, MinVolume: 5}
+	failures := Metric{Name: MetricPaymentFailure, Rate: true, Direction: DirectionUp, Threshold: 3, MinChange: 0.5, MinVolume: 20}
+
+	tests := []struct {
+		name     string
+		metric   Metric
+		current  Period
+		baseline []Period
+		want     bool
+	}{
+		{
+			name:     "within normal variation",
+			metric:   jobs,
+			current:  Period{Value: 44, Volume: 44},
+			baseline: days(14, 38, 42, 40, 45, 35),
+		},
+		{
+			name:     "sharp drop in job creation",
+			metric:   jobs,
+			current:  Period{Value: 12, Volume: 12},
+			baseline: days(14, 38, 42, 40, 45, 35),
+			want:     true,
+		},
+		{
+			name:     "spike in job creation",
+			metric:   jobs,
+			current:  Period{Value: 90, Volume: 90},
+			baseline: days(14, 38, 42, 40, 45, 35),
+			want:     true,
+		},
+		{
+			name:     "too few baseline days",
+			metric:   jobs,
+			current:  Period{Value: 12, Volume: 12},
+			baseline: days(5, 38, 42, 40, 45, 35),
+		},
+		{
+			name:     "baseline below minimum volume",
+			metric:   jobs,
+			current:  Period{Value: 0},
+			baseline: days(14, 2, 3, 1),
+		},
+		{
+			name:     "failure rate spike",
+			metric:   failures,
+			current:  Period{Value: 0.2, Volume: 150},
+			baseline: days(14, 0.04, 0.05, 0.06),
+			want:     true,
+		},
+		{
+			name:     "failure rate drop is not an anomaly",
+			metric:   failures,
+			current:  Period{Value: 0.0, Volume: 150},
+			baseline: days(14, 0.04, 0.05, 0.06),
+		},
+		{
+			name:     "current volume too low to judge a rate",
+			metric:   failures,
+			current:  Period{Value: 0.5, Volume: 4},
+			baseline: days(14, 0.04, 0.05, 0.06),
+		},
+		{
+			name:     "change from a constant baseline",
+			metric:   failures,
+			current:  Period{Value: 0.1, Volume: 150},
+			baseline: days(14, 0.02),
+			want:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, got := Detect(tt.metric, tt.current, tt.baseline)
+			if got != tt.want {
+				t.Fatalf("Detect() = %v (%+v), want %v", got, a, tt.want)
+			}
+			if got && a.Up() != (tt.current.Value > a.Baseline) {
+				t.Errorf("Up() = %v for current %v against baseline %v", a.Up(), tt.current.Value, a.Baseline)
+			}
+		})
+	}
+}
+
+func TestMetricsSensitivityOverrides(t *testing.T) {
+	t.Setenv("KPI_PAYMENT_FAILURE_RATE_THRESHOLD", "2.5")
+	t.Setenv("KPI_OFFER_ACCEPTANCE_RATE_MIN_VOLUME", "50")
+	t.Setenv("KPI_JOB_CREATION_MIN_CHANGE", "not-a-number")
+
+	for _, m := range Metrics() {
+		switch m.Name {
+		case MetricPaymentFailure:
+			if m.Threshold != 2.5 {
+				t.Errorf("%s threshold = %v, want 2.5", m.Name, m.Threshold)
+			}
+		case MetricOfferAcceptance:
+			if m.MinVolume != 50 {
+				t.Errorf("%s min volume = %v, want 50", m.Name, m.MinVolume)
+			}
+		case MetricJobCreation:
+			if m.MinChange != 0.3 {
+				t.Errorf("%s min change = %v, want the 0.3 default", m.Name, m.MinChange)
+			}
+		}
+	}
+}
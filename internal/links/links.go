@@ -0,0 +1,262 @@
+// Package links issues the signed, expiring deep links embedded in emails and push
+// notifications. A link is a scoped token (auth.IssueLinkToken) naming a deep_links row,
+// which counts the link's clicks and records when a single-use link was used.
+package links
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"app/internal/auth"
+	"app/internal/clock"
+	"app/internal/model"
+)
+
+// Link actions
+const (
+	ActionAcceptOffer   = "accept_offer"
+	ActionReviewJob     = "review_job"
+	ActionResetPassword = "reset_password"
+)
+
+var (
+	// ErrInvalidLink is returned for links that are forged, expired or for another action
+	ErrInvalidLink = errors.New("link is invalid or has expired")
+	// ErrLinkUsed is returned when a single-use link is used again
+	ErrLinkUsed = errors.New("link has already been used")
+)
+
+// actionSpec is how links for an action behave
+type actionSpec struct {
+	path      func(resourceID int) string // The app page the link opens
+	ttl       time.Duration
+	singleUse bool
+}
+
+var actions = map[string]actionSpec{
+	ActionAcceptOffer: {
+		path:      func(jobID int) string { return fmt.Sprintf("/jobs/%d/offer", jobID) },
+		ttl:       72 * time.Hour,
+		singleUse: true,
+	},
+	ActionReviewJob: {
+		path: func(jobID int) string { return fmt.Sprintf("/jobs/%d/review", jobID) },
+		ttl:  auth.MaxScopedTokenLifetime,
+	},
+	// Matches the reset token the link names, which expires with it
+	ActionResetPassword: {
+		path:      func(int) string { return "/reset-password" },
+		ttl:       30 * time.Minute,
+		singleUse: true,
+	},
+}
+
+// Link is an issued deep link. URL goes in the message; Token is its signed part.
+type Link struct {
+	ID         string
+	Action     string
+	UserID     int
+	ResourceID int
+	Token      string
+	URL        string
+	ExpiresAt  time.Time
+}
+
+// Service creates, follows and uses deep links
+type Service struct {
+	db         *sql.DB
+	apiBaseURL string // Where links are followed (GET /l/{token})
+	appBaseURL string // Where followed links land
+	clock      clock.Clock
+	ids        clock.IDGenerator // Link IDs, which tokens carry
+	tokens     *auth.Tokens      // On clock, so tokens expire with their links
+}
+
+// NewService creates a link service. Links point at API_BASE_URL and open pages on
+// APP_BASE_URL.
+func NewService(db *sql.DB) *Service {
+	return &Service{
+		db:         db,
+		apiBaseURL: baseURL("API_BASE_URL", "https://api.gigco.com"),
+		appBaseURL: baseURL("APP_BASE_URL", "https://app.gigco.com"),
+		clock:      clock.System,
+		ids:        clock.UUIDs,
+		tokens:     auth.NewTokens(clock.System),
+	}
+}
+
+// WithClock replaces the clock links expire by and the generator of their IDs
+func (s *Service) WithClock(c clock.Clock, ids clock.IDGenerator) *Service {
+	s.clock, s.ids, s.tokens = c, ids, auth.NewTokens(c)
+	return s
+}
+
+func baseURL(env, fallback string) string {
+	if value := os.Getenv(env); value != "" {
+		return strings.TrimSuffix(value, "/")
+	}
+	return fallback
+}
+
+// Create issues a link letting userID perform action on resourceID
+func (s *Service) Create(ctx context.Context, action string, userID, resourceID int) (*Link, error) {
+	spec, ok := actions[action]
+	if !ok {
+		return nil, fmt.Errorf("unknown link action %q", action)
+	}
+
+	link := &Link{
+		ID:         s.ids.NewID(),
+		Action:     action,
+		UserID:     userID,
+		ResourceID: resourceID,
+		ExpiresAt:  s.clock.Now().Add(spec.ttl),
+	}
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO deep_links (uuid, action, user_id, resource_id, single_use, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, link.ID, action, userID, resourceID, spec.singleUse, link.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store link: %w", err)
+	}
+
+	link.Token, err = s.tokens.IssueLinkToken(auth.ScopeLink(action, resourceID), userID, link.ID, spec.ttl)
+	if err != nil {
+		return nil, err
+	}
+	link.URL = s.apiBaseURL + "/l/" + link.Token
+	return link, nil
+}
+
+// Follow records a click on a link and returns the app page it opens, which receives
+// the token to perform the action with. Following never uses a link, since mail
+// scanners fetch links before the recipient does.
+func (s *Service) Follow(ctx context.Context, token string) (string, error) {
+	claims, err := s.tokens.ValidateLinkToken(token)
+	if err != nil {
+		return "", ErrInvalidLink
+	}
+
+	var action string
+	var resourceID int
+	var usedAt sql.NullTime
+	var singleUse bool
+	err = s.db.QueryRowContext(ctx, `
+		UPDATE deep_links
+		SET click_count = click_count + 1,
+			first_clicked_at = COALESCE(first_clicked_at, NOW()),
+			last_clicked_at = NOW()
+		WHERE uuid = $1
+		RETURNING action, resource_id, single_use, used_at
+	`, claims.ID).Scan(&action, &resourceID, &singleUse, &usedAt)
+	if err == sql.ErrNoRows {
+		return "", ErrInvalidLink
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to record link click: %w", err)
+	}
+
+	spec, ok := actions[action]
+	if !ok || claims.Audience[0] != auth.ScopeLink(action, resourceID) {
+		return "", ErrInvalidLink
+	}
+	if singleUse && usedAt.Valid {
+		return "", ErrLinkUsed
+	}
+	return s.destination(spec, resourceID, token), nil
+}
+
+// ExpiredPage is the app page followed links land on when they cannot be used
+func (s *Service) ExpiredPage() string {
+	return s.appBaseURL + "/link-expired"
+}
+
+// Parse checks a link token's signature and expiry and returns the action and resource
+// it was issued for, for handlers that learn the resource from the link
+func Parse(token string) (action string, resourceID int, err error) {
+	claims, err := auth.ValidateLinkToken(token)
+	if err != nil {
+		return "", 0, ErrInvalidLink
+	}
+	scope := strings.TrimPrefix(claims.Audience[0], "link:")
+	i := strings.LastIndex(scope, ":")
+	if i < 0 {
+		return "", 0, ErrInvalidLink
+	}
+	if resourceID, err = strconv.Atoi(scope[i+1:]); err != nil {
+		return "", 0, ErrInvalidLink
+	}
+	return scope[:i], resourceID, nil
+}
+
+// destination is the app page a link opens, carrying its token
+func (s *Service) destination(spec actionSpec, resourceID int, token string) string {
+	return s.appBaseURL + spec.path(resourceID) + "?token=" + url.QueryEscape(token)
+}
+
+// Use checks that token is a link for action on resourceID and returns its claims,
+// whose UserID is the user the action is performed as. A single-use link is marked
+// used, so a second call fails with ErrLinkUsed; call Use once the request is
+// otherwise valid.
+func (s *Service) Use(ctx context.Context, token, action string, resourceID int) (*auth.ScopedClaims, error) {
+	claims, err := s.tokens.ValidateScopedToken(token, auth.ScopeLink(action, resourceID))
+	if err != nil || claims.ID == "" {
+		return nil, ErrInvalidLink
+	}
+
+	// Reusable links record their first use for the stats
+	query := `UPDATE deep_links SET used_at = COALESCE(used_at, NOW()) WHERE uuid = $1`
+	if actions[action].singleUse {
+		query = `UPDATE deep_links SET used_at = NOW() WHERE uuid = $1 AND used_at IS NULL`
+	}
+	result, err := s.db.ExecContext(ctx, query, claims.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to use link: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, ErrLinkUsed
+	}
+	return claims, nil
+}
+
+// Stats summarizes the links created between from and to by action
+func (s *Service) Stats(ctx context.Context, from, to time.Time) ([]model.DeepLinkStats, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT action,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE click_count > 0),
+			COALESCE(SUM(click_count), 0),
+			COUNT(*) FILTER (WHERE used_at IS NOT NULL),
+			COUNT(*) FILTER (WHERE used_at IS NULL AND expires_at <= NOW())
+		FROM deep_links
+		WHERE created_at >= $1 AND created_at < $2
+		GROUP BY action
+		ORDER BY action
+	`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query link stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := []model.DeepLinkStats{}
+	for rows.Next() {
+		var st model.DeepLinkStats
+		if err := rows.Scan(&st.Action, &st.Created, &st.Clicked, &st.Clicks, &st.Used, &st.ExpiredUnused); err != nil {
+			return nil, fmt.Errorf("failed to scan link stats: %w", err)
+		}
+		if st.Created > 0 {
+			st.ClickRate = float64(st.Clicked) / float64(st.Created)
+		}
+		stats = append(stats, st)
+	}
+	return stats, rows.Err()
+}
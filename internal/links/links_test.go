@@ -0,0 +1,72 @@
+package links
+
+import (
+	"testing"
+	"time"
+
+	"app/internal/auth"
+)
+
+func TestParse(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret-key-for-testing-purposes-only")
+	t.Setenv("APP_ENV", "test")
+	auth.InitJWT()
+
+	reset, err := auth.IssueLinkToken(auth.ScopeLink(ActionResetPassword, 31), 42, "link-1", time.Minute)
+	if err != nil {
+		t.Fatalf("IssueLinkToken() error = %v", err)
+	}
+	review, err := auth.IssueScopedToken(auth.ScopeReviewSubmit(9), 42, time.Minute)
+	if err != nil {
+		t.Fatalf("IssueScopedToken() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		token        string
+		wantAction   string
+		wantResource int
+		wantErr      bool
+	}{
+		{name: "reset link", token: reset, wantAction: ActionResetPassword, wantResource: 31},
+		{name: "scoped token that is not a link", token: review, wantErr: true},
+		{name: "tampered", token: reset + "x", wantErr: true},
+		{name: "garbage", token: "not-a-token", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, resourceID, err := Parse(tt.token)
+			if tt.wantErr {
+				if err != ErrInvalidLink {
+					t.Fatalf("Parse() error = %v, want ErrInvalidLink", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if action != tt.wantAction || resourceID != tt.wantResource {
+				t.Errorf("Parse() = %q, %d, want %q, %d", action, resourceID, tt.wantAction, tt.wantResource)
+			}
+		})
+	}
+}
+
+func TestDestination(t *testing.T) {
+	s := &Service{appBaseURL: "https://app.example.com"}
+
+	tests := []struct {
+		action string
+		want   string
+	}{
+		{ActionAcceptOffer, "https://app.example.com/jobs/7/offer?token=a.b%2Bc"},
+		{ActionReviewJob, "https://app.example.com/jobs/7/review?token=a.b%2Bc"},
+		{ActionResetPassword, "https://app.example.com/reset-password?token=a.b%2Bc"},
+	}
+	for _, tt := range tests {
+		if got := s.destination(actions[tt.action], 7, "a.b+c"); got != tt.want {
+			t.Errorf("destination(%s) = %q, want %q", tt.action, got, tt.want)
+		}
+	}
+}
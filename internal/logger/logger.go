@@ -1,120 +1,113 @@
+// Package logger writes structured JSON logs with log/slog and carries the request ID
+// that ties a request's log lines, its error response and the background work it
+// starts together.
 package logger
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"io"
+	"log/slog"
 	"os"
-	"time"
+	"strings"
 
-	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 )
 
-var (
-	// Log is the global logger instance
-	Log zerolog.Logger
-)
+// RequestIDHeader carries the request ID on requests and responses
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength caps request IDs accepted from clients
+const maxRequestIDLength = 64
 
-// Config holds logger configuration
-type Config struct {
-	Level      string // debug, info, warn, error
-	Pretty     bool   // Use console writer for pretty output
-	TimeFormat string // Time format string
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying the request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
 }
 
-// DefaultConfig returns default logger configuration
-func DefaultConfig() Config {
-	return Config{
-		Level:      "info",
-		Pretty:     false,
-		TimeFormat: time.RFC3339,
-	}
+// RequestID returns the context's request ID, or "" if it has none
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
 }
 
-// Init initializes the global logger
-func Init(cfg Config) {
-	// Set time format
-	zerolog.TimeFieldFormat = cfg.TimeFormat
+// NewRequestID generates a random request ID
+func NewRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
 
-	// Parse log level
-	level, err := zerolog.ParseLevel(cfg.Level)
-	if err != nil {
-		level = zerolog.InfoLevel
+// ValidRequestID reports whether a client-supplied request ID is safe to log and echo:
+// at most 64 letters, digits, dashes, underscores and dots
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
 	}
-
-	// Choose output writer
-	var writer io.Writer = os.Stdout
-	if cfg.Pretty {
-		writer = zerolog.ConsoleWriter{
-			Out:        os.Stdout,
-			TimeFormat: time.RFC3339,
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+			return false
 		}
 	}
-
-	// Create logger with common fields
-	Log = zerolog.New(writer).
-		Level(level).
-		With().
-		Timestamp().
-		Caller().
-		Str("service", "gigco-api").
-		Logger()
+	return true
 }
 
-// InitFromEnv initializes logger from environment variables
-func InitFromEnv() {
-	level := os.Getenv("LOG_LEVEL")
-	if level == "" {
-		level = "info"
-	}
-
-	env := os.Getenv("APP_ENV")
-	pretty := env != "production"
-
-	Init(Config{
-		Level:      level,
-		Pretty:     pretty,
-		TimeFormat: time.RFC3339,
-	})
-}
-
-// WithRequestID returns a logger with request ID
-func WithRequestID(requestID string) zerolog.Logger {
-	return Log.With().Str("request_id", requestID).Logger()
+// InitFromEnv makes a JSON logger tagged with service the default for slog and the
+// log package. LOG_LEVEL (debug, info, warn or error) sets the minimum level; the
+// default is info.
+func InitFromEnv(service string) {
+	logger := New(os.Stdout, ParseLevel(os.Getenv("LOG_LEVEL"))).With("service", service)
+	slog.SetDefault(logger)
 }
 
-// WithUserID returns a logger with user ID
-func WithUserID(userID int) zerolog.Logger {
-	return Log.With().Int("user_id", userID).Logger()
+// New returns a JSON logger that adds the request ID from each record's context
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(contextHandler{slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level})})
 }
 
-// WithJob returns a logger with job context
-func WithJob(jobID int, jobUUID string) zerolog.Logger {
-	return Log.With().
-		Int("job_id", jobID).
-		Str("job_uuid", jobUUID).
-		Logger()
+// ParseLevel reads a level name, defaulting to info
+func ParseLevel(name string) slog.Level {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
 }
 
-// Debug logs a debug message
-func Debug() *zerolog.Event {
-	return Log.Debug()
+// Fatal logs msg at error level and exits
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
 }
 
-// Info logs an info message
-func Info() *zerolog.Event {
-	return Log.Info()
+// contextHandler adds the request ID and trace ID to records logged with a context that
+// has them
+type contextHandler struct {
+	slog.Handler
 }
 
-// Warn logs a warning message
-func Warn() *zerolog.Event {
-	return Log.Warn()
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
 }
 
-// Error logs an error message
-func Error() *zerolog.Event {
-	return Log.Error()
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
 }
 
-// Fatal logs a fatal message and exits
-func Fatal() *zerolog.Event {
-	return Log.Fatal()
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
 }
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"app/config"
+	"app/internal/audit"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// AuditAdminActions records every state-changing request an admin makes to the audit
+// log with its route, response status and the route's {id}. Handlers that record a
+// more specific event (with the fields changed) replace this one.
+func AuditAdminActions(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role, _ := r.Context().Value("user_role").(string)
+		if role != "admin" || r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, recorded := audit.Track(r.Context())
+		ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		// Rejected requests changed nothing
+		if recorded() || ww.Status() >= http.StatusBadRequest {
+			return
+		}
+
+		e := audit.Entry{
+			ActorRole:  role,
+			Action:     audit.ActionAdminRequest,
+			EntityType: audit.EntityRoute,
+			Method:     r.Method,
+			StatusCode: ww.Status(),
+			IP:         ClientIP(r),
+		}
+		if userID, ok := r.Context().Value("user_id").(int); ok && userID != 0 {
+			e.ActorID = &userID
+		}
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			e.Route = rctx.RoutePattern()
+			e.EntityID = rctx.URLParam("id")
+		}
+		if err := audit.Record(r.Context(), config.DB, e); err != nil {
+			slog.ErrorContext(r.Context(), "Failed to audit admin request", "method", r.Method, "path", r.URL.Path, "error", err)
+		}
+	})
+}
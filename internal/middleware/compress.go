@@ -0,0 +1,239 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// compressMinSize is the smallest body worth compressing, in bytes. Below it the gzip
+// header and CPU cost outweigh the saving.
+const compressMinSize = 1024
+
+// compressLevel trades a little size for speed; JSON compresses well at any level
+const compressLevel = 5
+
+// compressibleTypes are the content types compressed, by prefix. Images, PDFs and
+// other binary downloads are already compressed.
+var compressibleTypes = []string{
+	"application/json",
+	"application/problem+json",
+	"application/x-ndjson",
+	"application/javascript",
+	"application/xml",
+	"image/svg+xml",
+	"text/",
+}
+
+// encoder is a content coding the server can produce
+type encoder struct {
+	name string
+	pool *sync.Pool
+}
+
+// encoders are the supported codings in order of preference. Brotli compresses JSON
+// further but needs a third-party encoder; every client that sends Accept-Encoding
+// accepts gzip.
+var encoders = []encoder{
+	{name: "gzip", pool: &sync.Pool{New: func() interface{} {
+		w, _ := gzip.NewWriterLevel(io.Discard, compressLevel)
+		return w
+	}}},
+}
+
+// resettableWriter is a pooled compressor
+type resettableWriter interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// Compress compresses responses of compressibleTypes of at least compressMinSize bytes
+// with the best coding the client accepts. Bodies are compressed as they are written,
+// so streamed exports are never buffered whole; a handler that flushes before writing
+// compressMinSize bytes is compressed from the flush on. WebSocket upgrades and HEAD
+// requests pass through.
+func Compress(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		enc, ok := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if !ok || r.Method == http.MethodHead || r.Header.Get("Upgrade") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, enc: enc}
+		defer cw.close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// negotiateEncoding picks the preferred encoder the Accept-Encoding header allows
+func negotiateEncoding(header string) (encoder, bool) {
+	accepted := map[string]bool{}
+	wildcard := false
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		if name == "*" {
+			wildcard = q > 0
+			continue
+		}
+		accepted[name] = q > 0
+	}
+
+	for _, enc := range encoders {
+		if allowed, listed := accepted[enc.name]; allowed || (!listed && wildcard) {
+			return enc, true
+		}
+	}
+	return encoder{}, false
+}
+
+// compressWriter holds back the start of the body until it knows whether to compress:
+// once compressMinSize bytes are written, the handler flushes, or it returns
+type compressWriter struct {
+	http.ResponseWriter
+	enc encoder
+
+	status  int
+	buf     []byte
+	decided bool
+	zw      resettableWriter // nil when passing the body through
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.decided || cw.status != 0 {
+		return
+	}
+	if status < 200 {
+		cw.ResponseWriter.WriteHeader(status) // 1xx informational responses go straight out
+		return
+	}
+	cw.status = status
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if cw.decided {
+		if cw.zw != nil {
+			return cw.zw.Write(p)
+		}
+		return cw.ResponseWriter.Write(p)
+	}
+
+	cw.buf = append(cw.buf, p...)
+	if len(cw.buf) >= compressMinSize || !cw.compressible() {
+		if err := cw.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// compressible reports whether the response so far can be compressed
+func (cw *compressWriter) compressible() bool {
+	h := cw.Header()
+	if h.Get("Content-Encoding") != "" || h.Get("Content-Range") != "" {
+		return false
+	}
+	switch cw.status {
+	case http.StatusNoContent, http.StatusNotModified, http.StatusPartialContent:
+		return false
+	}
+	if length, err := strconv.Atoi(h.Get("Content-Length")); err == nil && length < compressMinSize {
+		return false
+	}
+
+	contentType := h.Get("Content-Type")
+	if contentType == "" && len(cw.buf) > 0 {
+		contentType = http.DetectContentType(cw.buf)
+		h.Set("Content-Type", contentType)
+	}
+	for _, prefix := range compressibleTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// decide starts the response and writes out what was held back, compressed when
+// compress is set and the response is compressible and has a body
+func (cw *compressWriter) decide(compress bool) error {
+	cw.decided = true
+	h := cw.Header()
+	compressible := cw.compressible()
+	if compressible {
+		h.Add("Vary", "Accept-Encoding") // Larger responses from the same URL are compressed
+	}
+	if compress && compressible && len(cw.buf) > 0 {
+		h.Set("Content-Encoding", cw.enc.name)
+		h.Del("Content-Length")
+		cw.zw = cw.enc.pool.Get().(resettableWriter)
+		cw.zw.Reset(cw.ResponseWriter)
+	}
+
+	if cw.status != 0 {
+		cw.ResponseWriter.WriteHeader(cw.status)
+	}
+	if len(cw.buf) == 0 {
+		return nil
+	}
+	buf := cw.buf
+	cw.buf = nil
+	_, err := cw.Write(buf)
+	return err
+}
+
+// Flush sends what has been written so far, compressing the rest of the body if it is
+// compressible, so streamed responses reach the client as they are written
+func (cw *compressWriter) Flush() {
+	if !cw.decided && len(cw.buf) > 0 {
+		cw.decide(true)
+	}
+	if cw.zw != nil {
+		cw.zw.Flush()
+	}
+	http.NewResponseController(cw.ResponseWriter).Flush()
+}
+
+// Hijack hands the connection to the handler; only possible before anything is written
+func (cw *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if cw.decided || len(cw.buf) > 0 {
+		return nil, nil, errors.New("compress: response already started")
+	}
+	return http.NewResponseController(cw.ResponseWriter).Hijack()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to extend the
+// write deadline
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// close finishes the response once the handler returns
+func (cw *compressWriter) close() {
+	if !cw.decided {
+		if cw.status == 0 && len(cw.buf) == 0 {
+			return // Nothing written: leave the default response to net/http
+		}
+		cw.decide(false) // Smaller than compressMinSize
+	}
+	if cw.zw != nil {
+		cw.zw.Close()
+		cw.zw.Reset(io.Discard)
+		cw.enc.pool.Put(cw.zw)
+		cw.zw = nil
+	}
+}
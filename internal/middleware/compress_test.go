@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCompress(t *testing.T) {
+	large := `{"jobs":[` + strings.Repeat(`{"title":"Lawn mowing","status":"posted"},`, 100) + `{}]}`
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		contentType    string
+		status         int
+		chunks         []string // Written in turn, flushing after each when flush is set
+		flush          bool
+		wantEncoding   string
+		wantVary       bool
+	}{
+		{name: "large JSON", acceptEncoding: "gzip, deflate, br", contentType: "application/json", chunks: []string{large}, wantEncoding: "gzip", wantVary: true},
+		{name: "small JSON", acceptEncoding: "gzip", contentType: "application/json", chunks: []string{`{"ok":true}`}, wantVary: true},
+		{name: "not accepted", contentType: "application/json", chunks: []string{large}},
+		{name: "refused with q=0", acceptEncoding: "gzip;q=0, *", contentType: "application/json", chunks: []string{large}},
+		{name: "wildcard", acceptEncoding: "*", contentType: "application/json", chunks: []string{large}, wantEncoding: "gzip", wantVary: true},
+		{name: "PDF passes through", acceptEncoding: "gzip", contentType: "application/pdf", chunks: []string{large}},
+		{name: "streamed CSV", acceptEncoding: "gzip", contentType: "text/csv", chunks: []string{"id,title\n", "1,Lawn mowing\n", "2,Gutters\n"}, flush: true, wantEncoding: "gzip", wantVary: true},
+		{name: "error status kept", acceptEncoding: "gzip", contentType: "application/json", status: http.StatusNotFound, chunks: []string{large}, wantEncoding: "gzip", wantVary: true},
+		{name: "no content", acceptEncoding: "gzip", status: http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := Compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.contentType != "" {
+					w.Header().Set("Content-Type", tt.contentType)
+				}
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+				}
+				for _, chunk := range tt.chunks {
+					io.WriteString(w, chunk)
+					if tt.flush {
+						http.NewResponseController(w).Flush()
+					}
+				}
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/jobs", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			wantStatus := tt.status
+			if wantStatus == 0 {
+				wantStatus = http.StatusOK
+			}
+			if rec.Code != wantStatus {
+				t.Errorf("status = %d; want %d", rec.Code, wantStatus)
+			}
+			if got := rec.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Errorf("Content-Encoding = %q; want %q", got, tt.wantEncoding)
+			}
+			if got := rec.Header().Get("Vary") == "Accept-Encoding"; got != tt.wantVary {
+				t.Errorf("Vary = %q; want Accept-Encoding %v", rec.Header().Get("Vary"), tt.wantVary)
+			}
+
+			body := io.Reader(rec.Body)
+			if tt.wantEncoding == "gzip" {
+				zr, err := gzip.NewReader(rec.Body)
+				if err != nil {
+					t.Fatalf("gzip.NewReader() error = %v", err)
+				}
+				body = zr
+			}
+			got, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatalf("reading body: %v", err)
+			}
+			if want := strings.Join(tt.chunks, ""); string(got) != want {
+				t.Errorf("body = %q; want %q", got, want)
+			}
+		})
+	}
+}
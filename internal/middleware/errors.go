@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"app/internal/logger"
+	"app/internal/model"
+	"encoding/json"
+	"net/http"
+)
+
+// writeError sends the same JSON error envelope as the API handlers, so clients see
+// one error format whether a request is rejected here or by a handler
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(model.ErrorResponse{
+		Error:     message,
+		Code:      code,
+		RequestID: w.Header().Get(logger.RequestIDHeader),
+	})
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"app/internal/faults"
+	"app/internal/model"
+)
+
+// faultExemptPaths are never faulted, so orchestrators keep seeing the real health
+var faultExemptPaths = map[string]bool{
+	"/health":  true,
+	"/ready":   true,
+	"/live":    true,
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// FaultInjection injects the faults planned for each request while fault injection is
+// enabled outside production: it adds the planned latency, fails a share of requests
+// with the usual 500 before they reach a handler, and passes the plan on in the
+// request context for services to drop provider responses. It goes after Logger so
+// injected faults are logged like real ones.
+func FaultInjection(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !faults.Enabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if faultExemptPaths[r.URL.Path] {
+			next.ServeHTTP(w, r.WithContext(faults.WithPlan(r.Context(), faults.Plan{})))
+			return
+		}
+
+		plan, err := faults.FromHeaders(r.Header)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, model.ErrCodeBadRequest, err.Error())
+			return
+		}
+		if !plan.Active() {
+			next.ServeHTTP(w, r.WithContext(faults.WithPlan(r.Context(), plan)))
+			return
+		}
+		w.Header().Set(faults.HeaderPlan, plan.String())
+
+		if plan.Latency > 0 {
+			timer := time.NewTimer(plan.Latency)
+			select {
+			case <-timer.C:
+			case <-r.Context().Done():
+				timer.Stop()
+				return
+			}
+		}
+		if plan.FailRequest() {
+			slog.WarnContext(r.Context(), "Injected fault: failing request", "path", r.URL.Path, "plan", plan.String())
+			writeError(w, http.StatusInternalServerError, model.ErrCodeInternal, "Internal server error")
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(faults.WithPlan(r.Context(), plan)))
+	})
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"app/config"
+	"app/internal/faults"
+)
+
+func TestFaultInjection(t *testing.T) {
+	t.Cleanup(func() { faults.Configure(&config.FaultConfig{}) })
+
+	var plan faults.Plan
+	handler := FaultInjection(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		plan = faults.FromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name       string
+		cfg        config.FaultConfig
+		path       string
+		headers    map[string]string
+		wantStatus int
+		wantPlan   faults.Plan
+		wantHeader string
+	}{
+		{
+			name:       "disabled ignores headers",
+			path:       "/api/v1/jobs",
+			headers:    map[string]string{faults.HeaderErrorRate: "1"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "every request fails",
+			cfg:        config.FaultConfig{Enabled: true, ErrorRate: 1},
+			path:       "/api/v1/jobs",
+			wantStatus: http.StatusInternalServerError,
+			wantHeader: "error_rate=1",
+		},
+		{
+			name:       "health probes are never faulted",
+			cfg:        config.FaultConfig{Enabled: true, ErrorRate: 1},
+			path:       "/readyz",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "headers override the defaults",
+			cfg:        config.FaultConfig{Enabled: true, ErrorRate: 1},
+			path:       "/api/v1/payments/authorize",
+			headers:    map[string]string{faults.HeaderErrorRate: "0", faults.HeaderCloverDropRate: "0.5", faults.HeaderLatency: "1ms"},
+			wantStatus: http.StatusOK,
+			wantPlan:   faults.Plan{Latency: time.Millisecond, CloverDropRate: 0.5},
+			wantHeader: "latency=1ms,clover_drop_rate=0.5",
+		},
+		{
+			name:       "invalid header",
+			cfg:        config.FaultConfig{Enabled: true},
+			path:       "/api/v1/jobs",
+			headers:    map[string]string{faults.HeaderLatency: "1h"},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			faults.Configure(&tt.cfg)
+			plan = faults.Plan{}
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if plan != tt.wantPlan {
+				t.Errorf("plan = %+v, want %+v", plan, tt.wantPlan)
+			}
+			if got := rec.Header().Get(faults.HeaderPlan); got != tt.wantHeader {
+				t.Errorf("%s = %q, want %q", faults.HeaderPlan, got, tt.wantHeader)
+			}
+		})
+	}
+}
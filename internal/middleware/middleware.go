@@ -2,29 +2,41 @@ package middleware
 
 import (
 	"app/internal/auth"
+	"app/internal/model"
 	"context"
 	"encoding/json"
-	"log"
+	"log/slog"
 	"net/http"
 	"regexp"
 	"strings"
 	"text/template"
 	"time"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
 )
 
 var validEmails = make(map[string]time.Time)
 
 var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 
-// Logger Function
+// Logger logs each request once it completes, with its status and duration
 func Logger(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
-		log.Printf("Started %s %s", r.Method, r.URL.Path)
+		ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
 
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(ww, r)
 
-		log.Printf("Completed %s in %v", r.URL.Path, time.Since(start))
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		slog.InfoContext(r.Context(), "Request completed",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", status,
+			"bytes", ww.BytesWritten(),
+			"duration_ms", time.Since(start).Milliseconds())
 	})
 }
 
@@ -60,7 +72,7 @@ func ServeEmailForm(w http.ResponseWriter, r *http.Request) {
 	tmpl, err := template.ParseFiles("templates/email.html")
 	if err != nil {
 		// Fallback to simple HTML if template not found
-		log.Printf("Template error, using fallback HTML: %v", err)
+		slog.ErrorContext(r.Context(), "Template error, using fallback HTML", "error", err)
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`<!DOCTYPE html>
 <html lang="en">
@@ -91,7 +103,7 @@ func ServeEmailForm(w http.ResponseWriter, r *http.Request) {
 	// Template found, execute it
 	err = tmpl.Execute(w, nil)
 	if err != nil {
-		log.Printf("Template execution error, using fallback: %v", err)
+		slog.ErrorContext(r.Context(), "Template execution error, using fallback", "error", err)
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`<!DOCTYPE html><html><head><title>Email Form</title></head><body><h1>Email Form</h1><p>Template execution failed.</p></body></html>`))
 	}
@@ -99,7 +111,7 @@ func ServeEmailForm(w http.ResponseWriter, r *http.Request) {
 
 func HandleEmailSubmission(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, model.ErrCodeMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -108,7 +120,7 @@ func HandleEmailSubmission(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
-		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, model.ErrCodeInvalidJSON, "Invalid JSON")
 		return
 	}
 
@@ -133,7 +145,7 @@ func HandleEmailSubmission(w http.ResponseWriter, r *http.Request) {
 
 	validEmails[email] = time.Now()
 
-	log.Printf("Valid email registered: %s", email)
+	slog.InfoContext(r.Context(), "Valid email registered", "email", email)
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]interface{}{
@@ -154,14 +166,14 @@ func JWTAuth(next http.Handler) http.Handler {
 		// Get token from Authorization header
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
-			http.Error(w, "Missing authorization header", http.StatusUnauthorized)
+			writeError(w, http.StatusUnauthorized, model.ErrCodeUnauthorized, "Missing authorization header")
 			return
 		}
 
 		// Check for Bearer token format
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			http.Error(w, "Invalid authorization header format", http.StatusUnauthorized)
+			writeError(w, http.StatusUnauthorized, model.ErrCodeUnauthorized, "Invalid authorization header format")
 			return
 		}
 
@@ -171,10 +183,10 @@ func JWTAuth(next http.Handler) http.Handler {
 		claims, err := auth.ValidateJWT(tokenString)
 		if err != nil {
 			if err == auth.ErrExpiredToken {
-				http.Error(w, "Token has expired", http.StatusUnauthorized)
+				writeError(w, http.StatusUnauthorized, model.ErrCodeTokenExpired, "Token has expired")
 				return
 			}
-			http.Error(w, "Invalid token", http.StatusUnauthorized)
+			writeError(w, http.StatusUnauthorized, model.ErrCodeInvalidToken, "Invalid token")
 			return
 		}
 
@@ -183,6 +195,7 @@ func JWTAuth(next http.Handler) http.Handler {
 		ctx = context.WithValue(ctx, "user_uuid", claims.UUID)
 		ctx = context.WithValue(ctx, "user_email", claims.Email)
 		ctx = context.WithValue(ctx, "user_role", claims.Role)
+		ctx = context.WithValue(ctx, "session_id", claims.SessionID)
 
 		// Call next handler with updated context
 		next.ServeHTTP(w, r.WithContext(ctx))
@@ -195,12 +208,12 @@ func RequireRole(role string) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			userRole, ok := r.Context().Value("user_role").(string)
 			if !ok {
-				http.Error(w, "User role not found in context", http.StatusInternalServerError)
+				writeError(w, http.StatusInternalServerError, model.ErrCodeInternal, "User role not found in context")
 				return
 			}
 
 			if userRole != role {
-				http.Error(w, "Insufficient permissions", http.StatusForbidden)
+				writeError(w, http.StatusForbidden, model.ErrCodeForbidden, "Insufficient permissions")
 				return
 			}
 
@@ -215,7 +228,7 @@ func RequireRoles(roles ...string) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			userRole, ok := r.Context().Value("user_role").(string)
 			if !ok {
-				http.Error(w, "User role not found in context", http.StatusInternalServerError)
+				writeError(w, http.StatusInternalServerError, model.ErrCodeInternal, "User role not found in context")
 				return
 			}
 
@@ -228,7 +241,7 @@ func RequireRoles(roles ...string) func(http.Handler) http.Handler {
 			}
 
 			if !hasRole {
-				http.Error(w, "Insufficient permissions", http.StatusForbidden)
+				writeError(w, http.StatusForbidden, model.ErrCodeForbidden, "Insufficient permissions")
 				return
 			}
 
@@ -0,0 +1,221 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"app/config"
+	"app/internal/model"
+)
+
+// Supported bucket stores, selected with RATE_LIMIT_STORE
+const (
+	RateLimitStoreMemory = "memory"
+	RateLimitStoreRedis  = "redis"
+)
+
+// BucketStore keeps token buckets
+type BucketStore interface {
+	// Take removes a token from key's bucket after refilling it for the time since it
+	// was last used. When the bucket is empty it returns false and how long until the
+	// next token.
+	Take(ctx context.Context, key string, limit config.RateLimit) (bool, time.Duration, error)
+}
+
+// NewBucketStore creates the store selected by the configuration
+func NewBucketStore(cfg *config.RateLimitConfig) (BucketStore, error) {
+	switch cfg.Store {
+	case RateLimitStoreMemory, "":
+		return NewMemoryBucketStore(), nil
+	case RateLimitStoreRedis:
+		return NewRedisBucketStore(cfg.RedisAddress, cfg.RedisPassword, cfg.RedisDB), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q", cfg.Store)
+	}
+}
+
+// RateLimiter limits requests with a token bucket per key, e.g. per client IP
+type RateLimiter struct {
+	name  string
+	store BucketStore
+	limit config.RateLimit
+	key   func(r *http.Request) string
+}
+
+// NewRateLimiter creates a limiter. name scopes its buckets, so limiters sharing a
+// store do not share buckets; key picks the bucket for a request.
+func NewRateLimiter(name string, store BucketStore, limit config.RateLimit, key func(r *http.Request) string) *RateLimiter {
+	return &RateLimiter{name: name, store: store, limit: limit, key: key}
+}
+
+// Handler rejects requests over the limit with 429 and a Retry-After header. If the
+// store cannot be reached, requests are let through rather than failing the API.
+func (rl *RateLimiter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rl.limit.PerMinute <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		allowed, retryAfter, err := rl.store.Take(r.Context(), rl.name+":"+rl.key(r), rl.limit)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Rate limit unavailable, allowing request", "name", rl.name, "error", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			writeError(w, http.StatusTooManyRequests, model.ErrCodeRateLimited, "Too many requests")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ClientIP returns the client address, preferring the first X-Forwarded-For entry
+// and X-Real-IP set by the load balancer
+func ClientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
+		return realIP
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// UserKey keys authenticated requests on the user ID set by JWTAuth, and anything
+// else on the client IP
+func UserKey(r *http.Request) string {
+	if userID, ok := r.Context().Value("user_id").(int); ok && userID != 0 {
+		return "user:" + strconv.Itoa(userID)
+	}
+	return "ip:" + ClientIP(r)
+}
+
+var (
+	rateLimitOnce sync.Once
+	ipLimiter     *RateLimiter
+	authLimiter   *RateLimiter
+	userLimiter   *RateLimiter
+)
+
+// rateLimiters builds the configured limiters on first use, sharing one store
+func rateLimiters() (ip, auth, user *RateLimiter) {
+	rateLimitOnce.Do(func() {
+		cfg := config.LoadRateLimitConfig()
+		store, err := NewBucketStore(cfg)
+		if err != nil {
+			slog.Warn("Keeping rate limits in memory", "error", err)
+			store = NewMemoryBucketStore()
+		}
+		ipLimiter = NewRateLimiter("ip", store, cfg.IP, ClientIP)
+		authLimiter = NewRateLimiter("auth", store, cfg.Auth, ClientIP)
+		userLimiter = NewRateLimiter("user", store, cfg.User, UserKey)
+	})
+	return ipLimiter, authLimiter, userLimiter
+}
+
+// RateLimitIP limits every request per client IP (RATE_LIMIT_IP_*)
+func RateLimitIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, _, _ := rateLimiters()
+		ip.Handler(next).ServeHTTP(w, r)
+	})
+}
+
+// RateLimitAuth limits login, registration, password reset and verification email
+// requests per client IP (RATE_LIMIT_AUTH_*)
+func RateLimitAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, auth, _ := rateLimiters()
+		auth.Handler(next).ServeHTTP(w, r)
+	})
+}
+
+// RateLimitUser limits authenticated requests per user (RATE_LIMIT_USER_*). It goes
+// after JWTAuth.
+func RateLimitUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _, user := rateLimiters()
+		user.Handler(next).ServeHTTP(w, r)
+	})
+}
+
+// MemoryBucketStore keeps buckets in process memory. Each instance has its own
+// buckets, so limits are per instance; use Redis when running more than one.
+type MemoryBucketStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	idle    time.Duration // How long until the bucket is full again and can be dropped
+}
+
+// NewMemoryBucketStore creates an in-memory store that drops refilled buckets
+// every minute
+func NewMemoryBucketStore() *MemoryBucketStore {
+	s := &MemoryBucketStore{buckets: make(map[string]*bucket), now: time.Now}
+	go s.cleanup(time.Minute)
+	return s
+}
+
+// Take implements BucketStore
+func (s *MemoryBucketStore) Take(ctx context.Context, key string, limit config.RateLimit) (bool, time.Duration, error) {
+	perSecond := limit.PerMinute / 60
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, updated: now}
+		s.buckets[key] = b
+	}
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.updated).Seconds()*perSecond)
+	b.updated = now
+	b.idle = time.Duration(burst / perSecond * float64(time.Second))
+
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / perSecond * float64(time.Second)), nil
+	}
+	b.tokens--
+	return true, 0, nil
+}
+
+// cleanup drops buckets that have refilled, since a new bucket starts full
+func (s *MemoryBucketStore) cleanup(interval time.Duration) {
+	for {
+		time.Sleep(interval)
+
+		s.mu.Lock()
+		now := s.now()
+		for key, b := range s.buckets {
+			if now.Sub(b.updated) > b.idle {
+				delete(s.buckets, key)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"app/config"
+)
+
+func TestMemoryBucketStore(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	store := &MemoryBucketStore{buckets: make(map[string]*bucket), now: func() time.Time { return now }}
+	limit := config.RateLimit{PerMinute: 6, Burst: 2} // A token every 10s
+
+	tests := []struct {
+		name    string
+		advance time.Duration
+		key     string
+		allowed bool
+		wait    time.Duration
+	}{
+		{name: "full bucket", key: "a", allowed: true},
+		{name: "burst", key: "a", allowed: true},
+		{name: "empty", key: "a", wait: 10 * time.Second},
+		{name: "other key has its own bucket", key: "b", allowed: true},
+		{name: "partly refilled", advance: 4 * time.Second, key: "a", wait: 6 * time.Second},
+		{name: "refilled one token", advance: 6 * time.Second, key: "a", allowed: true},
+		{name: "refill is capped at burst", advance: time.Hour, key: "a", allowed: true},
+		{name: "second burst token", key: "a", allowed: true},
+		{name: "empty again", key: "a", wait: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		now = now.Add(tt.advance)
+		allowed, wait, err := store.Take(context.Background(), tt.key, limit)
+		if err != nil {
+			t.Fatalf("%s: Take() error = %v", tt.name, err)
+		}
+		if allowed != tt.allowed || wait.Round(time.Millisecond) != tt.wait {
+			t.Errorf("%s: Take() = %v, %v, want %v, %v", tt.name, allowed, wait, tt.allowed, tt.wait)
+		}
+	}
+}
+
+func TestRateLimiterHandler(t *testing.T) {
+	store := NewMemoryBucketStore()
+	limiter := NewRateLimiter("test", store, config.RateLimit{PerMinute: 1, Burst: 1}, UserKey)
+	handler := limiter.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	request := func(userID int, ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Forwarded-For", ip+", 10.0.0.1")
+		if userID != 0 {
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", userID))
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := request(1, "203.0.113.1"); w.Code != http.StatusOK {
+		t.Fatalf("first request status = %d, want 200", w.Code)
+	}
+	w := request(1, "203.0.113.2")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("second request for user status = %d, want 429", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "60" {
+		t.Errorf("Retry-After = %q, want 60", got)
+	}
+	if w := request(2, "203.0.113.1"); w.Code != http.StatusOK {
+		t.Errorf("other user status = %d, want 200", w.Code)
+	}
+	if w := request(0, "203.0.113.1"); w.Code != http.StatusOK {
+		t.Errorf("anonymous request status = %d, want 200 (keyed on IP, not user 1)", w.Code)
+	}
+	if w := request(0, "203.0.113.1"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("repeated anonymous request status = %d, want 429", w.Code)
+	}
+}
+
+func TestRedisBucketStore(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	defer listener.Close()
+
+	// Fake server: checks AUTH and SELECT on connect, then denies the EVAL for 1.5s
+	commands := make(chan string, 3)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for _, reply := range []string{"+OK\r\n", "+OK\r\n", "*2\r\n:0\r\n:1500\r\n"} {
+			args, err := readRedisReply(reader)
+			if err != nil {
+				return
+			}
+			parts := args.([]interface{})
+			commands <- parts[0].(string)
+			conn.Write([]byte(reply))
+		}
+	}()
+
+	store := NewRedisBucketStore(listener.Addr().String(), "secret", 2)
+	allowed, wait, err := store.Take(context.Background(), "auth:203.0.113.1", config.RateLimit{PerMinute: 5, Burst: 5})
+	if err != nil {
+		t.Fatalf("Take() error = %v", err)
+	}
+	if allowed || wait != 1500*time.Millisecond {
+		t.Errorf("Take() = %v, %v, want false, 1.5s", allowed, wait)
+	}
+
+	close(commands)
+	var got []string
+	for command := range commands {
+		got = append(got, command)
+	}
+	if strings.Join(got, " ") != "AUTH SELECT EVAL" {
+		t.Errorf("commands = %v, want AUTH SELECT EVAL", got)
+	}
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"app/internal/logger"
+	"net/http"
+)
+
+// RequestID tags each request with an ID, reusing the caller's X-Request-ID when it is
+// valid. The ID is echoed in the X-Request-ID response header and error bodies and is
+// added to every log line written with the request's context.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(logger.RequestIDHeader)
+		if !logger.ValidRequestID(id) {
+			id = logger.NewRequestID()
+		}
+		w.Header().Set(logger.RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(logger.WithRequestID(r.Context(), id)))
+	})
+}
@@ -4,8 +4,8 @@ import (
 	"net/http"
 	"os"
 	"strings"
-	"sync"
-	"time"
+
+	"app/internal/faults"
 )
 
 // SecurityHeaders adds security headers to all responses
@@ -43,6 +43,7 @@ type CORSConfig struct {
 	AllowedOrigins   []string
 	AllowedMethods   []string
 	AllowedHeaders   []string
+	ExposedHeaders   []string // Response headers browser clients may read
 	AllowCredentials bool
 	MaxAge           int
 }
@@ -64,13 +65,30 @@ func DefaultCORSConfig() CORSConfig {
 		}
 	}
 
-	return CORSConfig{
+	cfg := CORSConfig{
 		AllowedOrigins:   origins,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Request-ID"},
+		ExposedHeaders:   []string{"X-Request-ID"},
 		AllowCredentials: true,
 		MaxAge:           86400, // 24 hours
 	}
+	// Let browser clients on staging ask for faults
+	if faults.Enabled() {
+		cfg.AllowedHeaders = append(cfg.AllowedHeaders, faults.Headers...)
+		cfg.ExposedHeaders = append(cfg.ExposedHeaders, faults.HeaderPlan)
+	}
+	return cfg
+}
+
+// AllowsOrigin reports whether origin is one of the allowed origins
+func (c CORSConfig) AllowsOrigin(origin string) bool {
+	for _, allowedOrigin := range c.AllowedOrigins {
+		if allowedOrigin == "*" || allowedOrigin == origin {
+			return true
+		}
+	}
+	return false
 }
 
 // CORS middleware handles Cross-Origin Resource Sharing
@@ -79,16 +97,7 @@ func CORS(config CORSConfig) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			origin := r.Header.Get("Origin")
 
-			// Check if origin is allowed
-			allowed := false
-			for _, allowedOrigin := range config.AllowedOrigins {
-				if allowedOrigin == "*" || allowedOrigin == origin {
-					allowed = true
-					break
-				}
-			}
-
-			if allowed && origin != "" {
+			if config.AllowsOrigin(origin) && origin != "" {
 				w.Header().Set("Access-Control-Allow-Origin", origin)
 
 				if config.AllowCredentials {
@@ -97,6 +106,9 @@ func CORS(config CORSConfig) func(http.Handler) http.Handler {
 
 				w.Header().Set("Access-Control-Allow-Methods", strings.Join(config.AllowedMethods, ", "))
 				w.Header().Set("Access-Control-Allow-Headers", strings.Join(config.AllowedHeaders, ", "))
+				if len(config.ExposedHeaders) > 0 {
+					w.Header().Set("Access-Control-Expose-Headers", strings.Join(config.ExposedHeaders, ", "))
+				}
 				w.Header().Set("Access-Control-Max-Age", string(rune(config.MaxAge)))
 			}
 
@@ -110,121 +122,3 @@ func CORS(config CORSConfig) func(http.Handler) http.Handler {
 		})
 	}
 }
-
-// RateLimiter provides IP-based rate limiting
-type RateLimiter struct {
-	visitors map[string]*visitor
-	mu       sync.RWMutex
-	rate     int           // requests per window
-	window   time.Duration // time window
-}
-
-type visitor struct {
-	count    int
-	lastSeen time.Time
-}
-
-// NewRateLimiter creates a new rate limiter
-// rate: number of requests allowed per window
-// window: time duration of the window
-func NewRateLimiter(rate int, window time.Duration) *RateLimiter {
-	rl := &RateLimiter{
-		visitors: make(map[string]*visitor),
-		rate:     rate,
-		window:   window,
-	}
-
-	// Start cleanup goroutine
-	go rl.cleanup()
-
-	return rl
-}
-
-// cleanup removes old entries periodically
-func (rl *RateLimiter) cleanup() {
-	for {
-		time.Sleep(rl.window)
-
-		rl.mu.Lock()
-		for ip, v := range rl.visitors {
-			if time.Since(v.lastSeen) > rl.window {
-				delete(rl.visitors, ip)
-			}
-		}
-		rl.mu.Unlock()
-	}
-}
-
-// getVisitor returns the visitor for an IP
-func (rl *RateLimiter) getVisitor(ip string) *visitor {
-	rl.mu.Lock()
-	defer rl.mu.Unlock()
-
-	v, exists := rl.visitors[ip]
-	if !exists {
-		v = &visitor{count: 0, lastSeen: time.Now()}
-		rl.visitors[ip] = v
-	}
-
-	// Reset if window has passed
-	if time.Since(v.lastSeen) > rl.window {
-		v.count = 0
-		v.lastSeen = time.Now()
-	}
-
-	return v
-}
-
-// Allow checks if a request should be allowed
-func (rl *RateLimiter) Allow(ip string) bool {
-	v := rl.getVisitor(ip)
-
-	rl.mu.Lock()
-	defer rl.mu.Unlock()
-
-	v.count++
-	v.lastSeen = time.Now()
-
-	return v.count <= rl.rate
-}
-
-// RateLimit middleware applies rate limiting
-func RateLimit(rl *RateLimiter) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Get client IP
-			ip := r.Header.Get("X-Forwarded-For")
-			if ip == "" {
-				ip = r.Header.Get("X-Real-IP")
-			}
-			if ip == "" {
-				ip = strings.Split(r.RemoteAddr, ":")[0]
-			}
-
-			// Handle comma-separated X-Forwarded-For
-			if strings.Contains(ip, ",") {
-				ip = strings.TrimSpace(strings.Split(ip, ",")[0])
-			}
-
-			if !rl.Allow(ip) {
-				w.Header().Set("Retry-After", "60")
-				http.Error(w, "Too many requests", http.StatusTooManyRequests)
-				return
-			}
-
-			next.ServeHTTP(w, r)
-		})
-	}
-}
-
-// StrictRateLimit creates a rate limiter for sensitive endpoints (like login)
-// More restrictive: 5 requests per minute
-func StrictRateLimit() *RateLimiter {
-	return NewRateLimiter(5, time.Minute)
-}
-
-// StandardRateLimit creates a rate limiter for general API endpoints
-// Standard: 100 requests per minute
-func StandardRateLimit() *RateLimiter {
-	return NewRateLimiter(100, time.Minute)
-}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingNamesSpansByRoute(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	router := chi.NewRouter()
+	router.Use(Tracing)
+	router.Get("/api/v1/jobs/{id}", func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/api/v1/jobs/42", want: "GET /api/v1/jobs/{id}"},
+		{path: "/missing", want: "GET"},
+	}
+
+	for _, tt := range tests {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		spans := recorder.Ended()
+		if got := spans[len(spans)-1].Name(); got != tt.want {
+			t.Errorf("%s: span name = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"app/migrations"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name         string
+		files        fstest.MapFS
+		wantVersions []int64
+		wantDown     []bool
+		wantErr      bool
+	}{
+		{
+			name: "sorted by version with optional down files",
+			files: fstest.MapFS{
+				"0010_add_b.up.sql":   {Data: []byte("CREATE TABLE b ();")},
+				"0002_add_a.up.sql":   {Data: []byte("CREATE TABLE a ();")},
+				"0002_add_a.down.sql": {Data: []byte("DROP TABLE a;")},
+			},
+			wantVersions: []int64{2, 10},
+			wantDown:     []bool{true, false},
+		},
+		{
+			name:    "down without up",
+			files:   fstest.MapFS{"0001_add_a.down.sql": {Data: []byte("DROP TABLE a;")}},
+			wantErr: true,
+		},
+		{
+			name: "version reused",
+			files: fstest.MapFS{
+				"0001_add_a.up.sql": {Data: []byte("CREATE TABLE a ();")},
+				"0001_add_b.up.sql": {Data: []byte("CREATE TABLE b ();")},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "misnamed file",
+			files:   fstest.MapFS{"add_a.sql": {Data: []byte("CREATE TABLE a ();")}},
+			wantErr: true,
+		},
+		{
+			name:    "version zero",
+			files:   fstest.MapFS{"0000_add_a.up.sql": {Data: []byte("CREATE TABLE a ();")}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Load(tt.files)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v; wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.wantVersions) {
+				t.Fatalf("Load() = %d migrations; want %d", len(got), len(tt.wantVersions))
+			}
+			for i, m := range got {
+				if m.Version != tt.wantVersions[i] || (m.Down != "") != tt.wantDown[i] {
+					t.Errorf("migration %d = version %d, down %v; want %d, %v", i, m.Version, m.Down != "", tt.wantVersions[i], tt.wantDown[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	got, err := Load(migrations.FS)
+	if err != nil {
+		t.Fatalf("Load(migrations.FS) error = %v", err)
+	}
+	for i, m := range got {
+		if m.Version != int64(i+1) {
+			t.Errorf("migration %s has version %d; want %d, versions are consecutive", m.Name, m.Version, i+1)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"time"
+)
+
+// Account deletion request statuses
+const (
+	DeletionStatusPending     = "pending"
+	DeletionStatusReactivated = "reactivated"
+	DeletionStatusPurged      = "purged"
+)
+
+// AccountDeletionRequest tracks a user's self-serve deletion through its hold period
+type AccountDeletionRequest struct {
+	ID                 int        `json:"id" db:"id"`
+	UUID               string     `json:"uuid" db:"uuid"`
+	UserID             int        `json:"user_id" db:"user_id"`
+	Status             string     `json:"status" db:"status"`
+	Reason             *string    `json:"reason,omitempty" db:"reason"`
+	RequestedAt        time.Time  `json:"requested_at" db:"requested_at"`
+	PurgeAfter         time.Time  `json:"purge_after" db:"purge_after"`
+	WinBackSentAt      *time.Time `json:"winback_sent_at,omitempty" db:"winback_sent_at"`
+	ReactivatedAt      *time.Time `json:"reactivated_at,omitempty" db:"reactivated_at"`
+	PurgedAt           *time.Time `json:"purged_at,omitempty" db:"purged_at"`
+	TemporalWorkflowID *string    `json:"-" db:"temporal_workflow_id"`
+}
+
+// AccountDeletionBody represents the payload for requesting account deletion
+type AccountDeletionBody struct {
+	Password string `json:"password" validate:"required"`
+	Reason   string `json:"reason" validate:"max=1000"`
+}
+
+// AccountReactivationRequest represents the payload for reactivating a held account.
+// Login is blocked during the hold, so the user re-authenticates with credentials.
+type AccountReactivationRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+// Data export statuses
+const (
+	DataExportStatusPending = "pending"
+	DataExportStatusReady   = "ready"
+	DataExportStatusFailed  = "failed"
+	DataExportStatusExpired = "expired"
+)
+
+// DataExport is an archive of a user's data they requested. DownloadURL is set while
+// it is ready.
+type DataExport struct {
+	ID          int        `json:"id" db:"id"`
+	Format      string     `json:"format" db:"format"`
+	Status      string     `json:"status" db:"status"`
+	RequestedAt time.Time  `json:"requested_at" db:"requested_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	DownloadURL string     `json:"download_url,omitempty"`
+}
+
+// AccountMergeRequest represents the payload for merging a duplicate account into
+// the account the user keeps
+type AccountMergeRequest struct {
+	SourceUserID int    `json:"source_user_id" validate:"required"` // Duplicate account, deactivated by the merge
+	TargetUserID int    `json:"target_user_id" validate:"required"` // Surviving account
+	Reason       string `json:"reason" validate:"required,min=10"`
+	DryRun       bool   `json:"dry_run"` // Report what would move without changing anything
+}
+
+// AccountMerge is an audited account merge or dry run
+type AccountMerge struct {
+	ID           int              `json:"id" db:"id"`
+	UUID         string           `json:"uuid" db:"uuid"`
+	AdminID      int              `json:"admin_id" db:"admin_id"`
+	SourceUserID int              `json:"source_user_id" db:"source_user_id"`
+	TargetUserID int              `json:"target_user_id" db:"target_user_id"`
+	SourceEmail  string           `json:"source_email" db:"source_email"`
+	TargetEmail  string           `json:"target_email" db:"target_email"`
+	Reason       string           `json:"reason" db:"reason"`
+	DryRun       bool             `json:"dry_run" db:"dry_run"`
+	Moved        map[string]int64 `json:"moved" db:"moved"` // Rows moved per table.column
+	MergedAt     time.Time        `json:"merged_at" db:"merged_at"`
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"time"
+)
+
+// AccountingConnection is a consumer's linked QuickBooks or Xero organisation
+type AccountingConnection struct {
+	ID            int        `json:"id" db:"id"`
+	UUID          string     `json:"uuid" db:"uuid"`
+	Provider      string     `json:"provider" db:"provider"`
+	TenantID      string     `json:"tenant_id" db:"tenant_id"`
+	IsActive      bool       `json:"is_active" db:"is_active"`
+	ConnectedAt   time.Time  `json:"connected_at" db:"connected_at"`
+	LastSyncedAt  *time.Time `json:"last_synced_at" db:"last_synced_at"`
+	LastSyncError *string    `json:"last_sync_error,omitempty" db:"last_sync_error"`
+}
+
+// AccountingCategoryMapping maps a job category to an account in the connected ledger
+type AccountingCategoryMapping struct {
+	JobCategory string `json:"job_category" db:"job_category"`
+	AccountRef  string `json:"account_ref" db:"account_ref"`
+	IsDefault   bool   `json:"is_default"`
+}
+
+// AccountingMappingsRequest replaces a connection's category mappings
+type AccountingMappingsRequest struct {
+	Mappings []AccountingCategoryMapping `json:"mappings" validate:"required,dive"`
+}
+
+// AccountingSyncResult summarizes one sync run
+type AccountingSyncResult struct {
+	Synced int      `json:"synced"`
+	Failed int      `json:"failed"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+// SpendReceipt is a consumer-facing receipt for a captured payment
+type SpendReceipt struct {
+	TransactionUUID string     `json:"transaction_uuid"`
+	JobID           int        `json:"job_id"`
+	JobTitle        string     `json:"job_title"`
+	JobCategory     string     `json:"job_category"`
+	WorkerName      *string    `json:"worker_name,omitempty"`
+	Amount          float64    `json:"amount"`
+	PlatformFee     float64    `json:"platform_fee"`
+	Currency        string     `json:"currency"`
+	PaymentMethod   *string    `json:"payment_method,omitempty"`
+	LastFour        *string    `json:"last_four,omitempty"`
+	CapturedAt      time.Time  `json:"captured_at"`
+	RefundedAt      *time.Time `json:"refunded_at,omitempty"`
+	RefundAmount    *float64   `json:"refund_amount,omitempty"`
+
+	LineItems []ReceiptLineItem `json:"line_items,omitempty"`
+}
+
+// Receipt line item types
+const (
+	ReceiptLineService  = "service"
+	ReceiptLineMaterial = "materials"
+	ReceiptLineExpense  = "expense"
+)
+
+// ReceiptLineItem is one itemized charge on a receipt. ID identifies materials and
+// expense lines, e.g. to dispute them; the service line has none.
+type ReceiptLineItem struct {
+	ID          *int    `json:"id,omitempty"`
+	Type        string  `json:"type"`
+	Description string  `json:"description"`
+	Quantity    int     `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	Amount      float64 `json:"amount"`
+}
@@ -0,0 +1,160 @@
+package model
+
+import (
+	"time"
+)
+
+// AdminUser is a user as listed in the admin dashboard
+type AdminUser struct {
+	ID            int        `json:"id"`
+	UUID          string     `json:"uuid"`
+	Name          string     `json:"name"`
+	Email         string     `json:"email"`
+	Role          string     `json:"role"`
+	IsActive      bool       `json:"is_active"`
+	EmailVerified bool       `json:"email_verified"`
+	JobsPosted    int        `json:"jobs_posted"`
+	JobsWorked    int        `json:"jobs_worked"`
+	LastActiveAt  *time.Time `json:"last_active_at"` // Last use of any login session
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// AdminJob is a job as listed in the admin dashboard
+type AdminJob struct {
+	ID             int        `json:"id"`
+	UUID           string     `json:"uuid"`
+	Title          string     `json:"title"`
+	Category       *string    `json:"category"`
+	Status         string     `json:"status"`
+	ConsumerID     int        `json:"consumer_id"`
+	ConsumerName   string     `json:"consumer_name"`
+	WorkerID       *int       `json:"worker_id"`
+	WorkerName     *string    `json:"worker_name"`
+	TotalPay       *float64   `json:"total_pay"`
+	ScheduledStart *time.Time `json:"scheduled_start"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// AdminTransaction is a payment as listed in the admin dashboard
+type AdminTransaction struct {
+	ID              int        `json:"id"`
+	UUID            string     `json:"uuid"`
+	JobID           int        `json:"job_id"`
+	JobTitle        string     `json:"job_title"`
+	ConsumerID      int        `json:"consumer_id"`
+	ConsumerName    string     `json:"consumer_name"`
+	WorkerID        int        `json:"worker_id"`
+	WorkerName      string     `json:"worker_name"`
+	Amount          float64    `json:"amount"`
+	PlatformFee     float64    `json:"platform_fee"`
+	RefundAmount    *float64   `json:"refund_amount"`
+	Currency        string     `json:"currency"`
+	Status          string     `json:"status"`
+	PaymentProvider string     `json:"payment_provider"`
+	CapturedAt      *time.Time `json:"captured_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// AdminPaymentEvent is a provider call recorded on a transaction, for support. Card
+// numbers, security codes and expiry dates are redacted from the provider's response.
+type AdminPaymentEvent struct {
+	ID               int                    `json:"id"`
+	EventType        string                 `json:"event_type"`   // authorize, capture, refund, ...
+	EventStatus      string                 `json:"event_status"` // success, failed or pending
+	ErrorCode        *string                `json:"error_code,omitempty"`
+	ErrorMessage     *string                `json:"error_message,omitempty"`
+	ProviderResponse map[string]interface{} `json:"provider_response,omitempty"` // Status, amounts, codes and card brand and last four
+	IdempotencyKey   *string                `json:"idempotency_key,omitempty"`
+	Actor            *PaymentEventActor     `json:"actor,omitempty"` // Who made the request; absent for the system
+	CreatedAt        time.Time              `json:"created_at"`
+}
+
+// PaymentEventActor is the user whose request made a payment event
+type PaymentEventActor struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// AdminDispute is a dispute in the admin dispute queue
+type AdminDispute struct {
+	Dispute
+	JobTitle     string `json:"job_title"`
+	OpenedByName string `json:"opened_by_name"`
+}
+
+// PlatformMetrics summarizes platform activity over a date range. GMV is the value of
+// payments captured in the range; the take rate is platform fees as a share of GMV.
+type PlatformMetrics struct {
+	From                 time.Time      `json:"from"`
+	To                   time.Time      `json:"to"`
+	JobsByStatus         map[string]int `json:"jobs_by_status"` // Jobs created in the range, by current status
+	JobsCreated          int            `json:"jobs_created"`
+	CapturedTransactions int            `json:"captured_transactions"`
+	GMV                  float64        `json:"gmv"`
+	PlatformFees         float64        `json:"platform_fees"`
+	Refunds              float64        `json:"refunds"`
+	TakeRate             float64        `json:"take_rate"`
+	NewUsers             int            `json:"new_users"`
+}
+
+// AdminOverview is the ops dashboard summary for a date range. Range figures come from
+// daily rollups refreshed hourly, as of RefreshedAt; active jobs and open disputes are
+// current counts.
+type AdminOverview struct {
+	From               time.Time      `json:"from"`
+	To                 time.Time      `json:"to"`
+	RefreshedAt        *time.Time     `json:"refreshed_at"`
+	GMV                float64        `json:"gmv"`
+	PlatformFees       float64        `json:"platform_fees"`
+	TakeRate           float64        `json:"take_rate"`
+	ActiveJobsByStatus map[string]int `json:"active_jobs_by_status"`
+	JobsPosted         int            `json:"jobs_posted"`
+	JobsFilled         int            `json:"jobs_filled"`
+	FillRate           float64        `json:"fill_rate"` // Share of jobs posted in the range that found a worker
+	NewSignups         int            `json:"new_signups"`
+	NewSignupsByRole   map[string]int `json:"new_signups_by_role"`
+	PaymentAttempts    int            `json:"payment_attempts"`
+	PaymentsFailed     int            `json:"payments_failed"`
+	PaymentFailureRate float64        `json:"payment_failure_rate"`
+	OpenDisputes       int            `json:"open_disputes"`
+}
+
+// QueryStats is the running statistics of one query in the serving process, with its
+// literals replaced by ?
+type QueryStats struct {
+	Fingerprint string     `json:"fingerprint"`
+	Query       string     `json:"query"`
+	Calls       int64      `json:"calls"`
+	Errors      int64      `json:"errors"`
+	SlowCalls   int64      `json:"slow_calls"`
+	TotalMS     float64    `json:"total_ms"`
+	MeanMS      float64    `json:"mean_ms"`
+	MaxMS       float64    `json:"max_ms"`
+	LastSlowAt  *time.Time `json:"last_slow_at"`
+}
+
+// QueryDiagnostic is the EXPLAIN ANALYZE plan captured for a query that was slow
+// repeatedly, with the duration of the execution that triggered the capture
+type QueryDiagnostic struct {
+	ID          int       `json:"id"`
+	Fingerprint string    `json:"fingerprint"`
+	Query       string    `json:"query"`
+	DurationMS  float64   `json:"duration_ms"`
+	SlowCount   int       `json:"slow_count"`
+	Plan        string    `json:"plan"`
+	CapturedAt  time.Time `json:"captured_at"`
+}
+
+// IndexAdvisory is whether an index serves one of the list queries, with the statement
+// that creates the index when none does
+type IndexAdvisory struct {
+	Query     string   `json:"query"`
+	Table     string   `json:"table"`
+	Columns   []string `json:"columns"`
+	Index     string   `json:"index"`
+	Present   bool     `json:"present"`
+	ServedBy  string   `json:"served_by,omitempty"`
+	CreateSQL string   `json:"create_sql,omitempty"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+)
+
+// Attachment owner types
+const (
+	AttachmentOwnerExpense = "job_expense"
+	AttachmentOwnerJob     = "job"
+	AttachmentOwnerWorker  = "worker" // Verification documents; owner_id is the worker's user ID
+	AttachmentOwnerUser    = "user"   // Avatars
+)
+
+// Attachment is an uploaded file and the result of scanning it for malware
+type Attachment struct {
+	ID          int        `json:"id" db:"id"`
+	UUID        string     `json:"uuid" db:"uuid"`
+	OwnerType   string     `json:"owner_type" db:"owner_type"`
+	OwnerID     int        `json:"owner_id" db:"owner_id"`
+	Kind        string     `json:"kind" db:"kind"`
+	ObjectKey   string     `json:"-" db:"object_key"`
+	ContentType string     `json:"content_type" db:"content_type"`
+	SizeBytes   int64      `json:"size_bytes" db:"size_bytes"`
+	UploadedBy  *int       `json:"uploaded_by" db:"uploaded_by"`
+	ScanStatus  string     `json:"scan_status" db:"scan_status"`
+	Scanner     *string    `json:"scanner,omitempty" db:"scanner"`
+	ScanThreat  *string    `json:"scan_threat,omitempty" db:"scan_threat"`
+	ScannedAt   *time.Time `json:"scanned_at,omitempty" db:"scanned_at"`
+	Width       *int       `json:"width,omitempty" db:"width"`
+	Height      *int       `json:"height,omitempty" db:"height"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+
+	// ThumbnailKey is the stored thumbnail of a photo, if one could be made
+	ThumbnailKey *string `json:"-" db:"thumbnail_key"`
+}
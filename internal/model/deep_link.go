@@ -0,0 +1,14 @@
+package model
+
+// DeepLinkStats summarizes the deep links of one action created in a period. Clicked
+// counts links followed at least once and Clicks every follow; ClickRate is Clicked
+// over Created.
+type DeepLinkStats struct {
+	Action        string  `json:"action"`
+	Created       int     `json:"created"`
+	Clicked       int     `json:"clicked"`
+	Clicks        int     `json:"clicks"`
+	ClickRate     float64 `json:"click_rate"`
+	Used          int     `json:"used"`
+	ExpiredUnused int     `json:"expired_unused"`
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+)
+
+// Device platforms
+const (
+	DevicePlatformIOS     = "ios"
+	DevicePlatformAndroid = "android"
+	DevicePlatformWeb     = "web"
+)
+
+// DevicePlatforms lists the platforms a device can register from
+var DevicePlatforms = []string{DevicePlatformIOS, DevicePlatformAndroid, DevicePlatformWeb}
+
+// Device is an app install registered for push notifications with its FCM token
+type Device struct {
+	ID          int       `json:"id" db:"id"`
+	Platform    string    `json:"platform" db:"platform"`
+	AppVersion  *string   `json:"app_version,omitempty" db:"app_version"`
+	DeviceModel *string   `json:"device_model,omitempty" db:"device_model"`
+	LastSeenAt  time.Time `json:"last_seen_at" db:"last_seen_at"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// RegisterDeviceRequest registers the calling app install for push notifications.
+// Registering a token again updates its details.
+type RegisterDeviceRequest struct {
+	Token       string  `json:"token" validate:"required"`
+	Platform    string  `json:"platform" validate:"required"`
+	AppVersion  *string `json:"app_version,omitempty"`
+	DeviceModel *string `json:"device_model,omitempty"`
+}
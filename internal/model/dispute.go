@@ -0,0 +1,84 @@
+package model
+
+import (
+	"time"
+)
+
+// Dispute reasons
+const (
+	DisputeReasonIncomplete  = "work_incomplete"
+	DisputeReasonPoorQuality = "poor_quality"
+	DisputeReasonNoShow      = "no_show"
+	DisputeReasonDamage      = "property_damage"
+	DisputeReasonOvercharged = "overcharged"
+	DisputeReasonOther       = "other"
+)
+
+// Dispute statuses
+const (
+	DisputeStatusOpen        = "open"
+	DisputeStatusUnderReview = "under_review"
+	DisputeStatusResolved    = "resolved"
+	DisputeStatusRefunded    = "refunded"
+)
+
+// Dispute is a consumer's challenge to a completed job's payment
+type Dispute struct {
+	ID                  int        `json:"id" db:"id"`
+	UUID                string     `json:"uuid" db:"uuid"`
+	JobID               int        `json:"job_id" db:"job_id"`
+	OpenedBy            int        `json:"opened_by" db:"opened_by"`
+	Reason              string     `json:"reason" db:"reason"`
+	Description         string     `json:"description" db:"description"`
+	Status              string     `json:"status" db:"status"`
+	WorkflowID          *string    `json:"workflow_id" db:"temporal_workflow_id"`
+	AssignedTo          *int       `json:"assigned_to" db:"assigned_to"`
+	ResolvedBy          *int       `json:"resolved_by" db:"resolved_by"`
+	ResolvedAt          *time.Time `json:"resolved_at" db:"resolved_at"`
+	ResolutionNotes     *string    `json:"resolution_notes" db:"resolution_notes"`
+	RefundTransactionID *int       `json:"refund_transaction_id" db:"refund_transaction_id"`
+	RefundAmount        *Money     `json:"refund_amount" db:"refund_amount"`
+	CreatedAt           time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// DisputeRequest represents a consumer opening a dispute
+type DisputeRequest struct {
+	Reason      string `json:"reason" validate:"required"`
+	Description string `json:"description" validate:"required,max=5000"`
+}
+
+// DisputeUpdateRequest represents support moving a dispute along. RefundAmount only
+// applies when refunding; omit it to refund the payment in full.
+type DisputeUpdateRequest struct {
+	Status          string  `json:"status" validate:"required,oneof=under_review resolved refunded"`
+	ResolutionNotes *string `json:"resolution_notes" validate:"omitempty,max=5000"`
+	RefundAmount    *Money  `json:"refund_amount" validate:"omitempty,gt=0"`
+}
+
+// ValidateDisputeReason checks if a dispute reason is supported
+func ValidateDisputeReason(reason string) bool {
+	switch reason {
+	case DisputeReasonIncomplete, DisputeReasonPoorQuality, DisputeReasonNoShow,
+		DisputeReasonDamage, DisputeReasonOvercharged, DisputeReasonOther:
+		return true
+	}
+	return false
+}
+
+// CanTransitionDispute reports whether a dispute may move from one status to another.
+// Disputes go open → under_review → resolved or refunded.
+func CanTransitionDispute(from, to string) bool {
+	switch from {
+	case DisputeStatusOpen:
+		return to == DisputeStatusUnderReview
+	case DisputeStatusUnderReview:
+		return to == DisputeStatusResolved || to == DisputeStatusRefunded
+	}
+	return false
+}
+
+// IsOpen reports whether the dispute is still holding the job's payment
+func (d *Dispute) IsOpen() bool {
+	return d.Status == DisputeStatusOpen || d.Status == DisputeStatusUnderReview
+}
@@ -0,0 +1,49 @@
+package model
+
+// WorkerEarnings totals what a gig worker earned in a tax year, by month. Amounts
+// are in Currency; payments in other currencies are reported separately.
+type WorkerEarnings struct {
+	WorkerID           int               `json:"worker_id"`
+	Year               int               `json:"year"`
+	Currency           string            `json:"currency"`
+	JobsPaid           int               `json:"jobs_paid"`
+	GrossEarnings      Money             `json:"gross_earnings"` // Job prices, before fees
+	Tips               Money             `json:"tips"`
+	PlatformFees       Money             `json:"platform_fees"` // Withheld by GigCo
+	ProcessingFees     Money             `json:"processing_fees"`
+	NetEarnings        Money             `json:"net_earnings"`      // Gross plus tips, less fees
+	Reimbursements     Money             `json:"reimbursements"`    // Expenses and parts passed through; not earnings
+	ReportableAmount   Money             `json:"reportable_amount"` // Form 1099-NEC box 1: gross earnings plus tips
+	ReportingThreshold Money             `json:"reporting_threshold"`
+	Form1099NEC        bool              `json:"form_1099_nec"` // A 1099-NEC will be issued: USD earnings at or above the threshold
+	Months             []MonthlyEarnings `json:"months"`
+}
+
+// MonthlyEarnings is one month of a WorkerEarnings
+type MonthlyEarnings struct {
+	Month          string `json:"month"` // YYYY-MM
+	JobsPaid       int    `json:"jobs_paid"`
+	GrossEarnings  Money  `json:"gross_earnings"`
+	Tips           Money  `json:"tips"`
+	PlatformFees   Money  `json:"platform_fees"`
+	ProcessingFees Money  `json:"processing_fees"`
+	NetEarnings    Money  `json:"net_earnings"`
+	Reimbursements Money  `json:"reimbursements"`
+}
+
+// Form1099NECRecipient is a worker who is issued a Form 1099-NEC for a tax year.
+// Taxpayer IDs are collected on Form W-9 outside GigCo and matched by WorkerUUID.
+type Form1099NECRecipient struct {
+	WorkerID                int     `json:"worker_id"`
+	WorkerUUID              string  `json:"worker_uuid"`
+	Name                    string  `json:"name"`
+	Email                   string  `json:"email"`
+	Address                 *string `json:"address"`
+	JobsPaid                int     `json:"jobs_paid"`
+	NonemployeeCompensation Money   `json:"nonemployee_compensation"` // Box 1: gross earnings plus tips
+	Tips                    Money   `json:"tips"`
+	PlatformFees            Money   `json:"platform_fees"`
+	ProcessingFees          Money   `json:"processing_fees"`
+	NetEarnings             Money   `json:"net_earnings"`
+	Reimbursements          Money   `json:"reimbursements"`
+}
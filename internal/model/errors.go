@@ -0,0 +1,72 @@
+package model
+
+import "net/http"
+
+// Error codes returned in ErrorResponse.Code. Clients branch on the code rather than
+// the human-readable error text, which may change. Generic codes follow the HTTP
+// status; domain codes name the specific problem.
+const (
+	ErrCodeBadRequest         = "BAD_REQUEST"
+	ErrCodeInvalidJSON        = "INVALID_JSON"
+	ErrCodeValidation         = "VALIDATION_ERROR"
+	ErrCodeUnauthorized       = "UNAUTHORIZED"
+	ErrCodeForbidden          = "FORBIDDEN"
+	ErrCodeNotFound           = "NOT_FOUND"
+	ErrCodeMethodNotAllowed   = "METHOD_NOT_ALLOWED"
+	ErrCodeConflict           = "CONFLICT"
+	ErrCodePayloadTooLarge    = "PAYLOAD_TOO_LARGE"
+	ErrCodeUnprocessable      = "UNPROCESSABLE_ENTITY"
+	ErrCodeRateLimited        = "RATE_LIMITED"
+	ErrCodeInternal           = "INTERNAL_ERROR"
+	ErrCodeServiceUnavailable = "SERVICE_UNAVAILABLE"
+
+	ErrCodeInvalidCredentials   = "INVALID_CREDENTIALS"
+	ErrCodeInvalidToken         = "INVALID_TOKEN"
+	ErrCodeTokenExpired         = "TOKEN_EXPIRED"
+	ErrCodeAccountDeactivated   = "ACCOUNT_DEACTIVATED"
+	ErrCodeEmailTaken           = "EMAIL_TAKEN"
+	ErrCodeUserNotFound         = "USER_NOT_FOUND"
+	ErrCodeGigWorkerNotFound    = "GIG_WORKER_NOT_FOUND"
+	ErrCodeJobNotFound          = "JOB_NOT_FOUND"
+	ErrCodeInvalidJobStatus     = "INVALID_JOB_STATUS"
+	ErrCodeJobIncomplete        = "JOB_INCOMPLETE"
+	ErrCodeOfferUnavailable     = "OFFER_UNAVAILABLE"
+	ErrCodeReviewNotFound       = "REVIEW_NOT_FOUND"
+	ErrCodeReviewExists         = "REVIEW_EXISTS"
+	ErrCodePaymentDeclined      = "PAYMENT_DECLINED"
+	ErrCodePriceChanged         = "PRICE_CHANGED"
+	ErrCodeIdempotencyKeyReused = "IDEMPOTENCY_KEY_REUSED"
+	ErrCodeCurrencyMismatch     = "CURRENCY_MISMATCH"
+	ErrCodeScheduleConflict     = "SCHEDULE_CONFLICT"
+	ErrCodeJobOnHold            = "JOB_ON_HOLD"
+)
+
+// ErrorCodeForStatus returns the generic error code for an HTTP status
+func ErrorCodeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrCodeBadRequest
+	case http.StatusUnauthorized:
+		return ErrCodeUnauthorized
+	case http.StatusForbidden:
+		return ErrCodeForbidden
+	case http.StatusNotFound:
+		return ErrCodeNotFound
+	case http.StatusMethodNotAllowed:
+		return ErrCodeMethodNotAllowed
+	case http.StatusConflict:
+		return ErrCodeConflict
+	case http.StatusRequestEntityTooLarge:
+		return ErrCodePayloadTooLarge
+	case http.StatusUnprocessableEntity:
+		return ErrCodeUnprocessable
+	case http.StatusTooManyRequests:
+		return ErrCodeRateLimited
+	case http.StatusServiceUnavailable:
+		return ErrCodeServiceUnavailable
+	}
+	if status >= 500 {
+		return ErrCodeInternal
+	}
+	return ErrCodeBadRequest
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+)
+
+// Escrow milestone types, in the order they happen to a payment
+const (
+	EscrowMilestoneAuthorized    = "authorized"     // The card was authorized; nothing is charged yet
+	EscrowMilestoneHeld          = "held"           // The authorized amount is held in escrow
+	EscrowMilestoneCaptureFailed = "capture_failed" // Charging the held amount failed; the hold remains
+	EscrowMilestoneReleased      = "released"       // The card was charged and the payment released to the worker
+	EscrowMilestoneRefundFailed  = "refund_failed"  // A refund attempt failed
+	EscrowMilestoneRefunded      = "refunded"       // All or part of the payment was returned to the card
+)
+
+// Escrow timeline statuses, summarizing where the job's money is now
+const (
+	EscrowStatusNone              = "none"               // No payment has been authorized
+	EscrowStatusHeld              = "held"               // Authorized and held, not yet charged
+	EscrowStatusReleased          = "released"           // Charged and released to the worker
+	EscrowStatusPartiallyRefunded = "partially_refunded" // Charged, with part returned
+	EscrowStatusRefunded          = "refunded"           // Everything charged or held was returned
+)
+
+// EscrowMilestone is one step in a job's payment
+type EscrowMilestone struct {
+	Type          string    `json:"type"`
+	OccurredAt    time.Time `json:"occurred_at"`
+	Amount        *Money    `json:"amount,omitempty"` // Omitted for failed attempts
+	Currency      string    `json:"currency"`
+	TransactionID int       `json:"transaction_id"`
+	Description   string    `json:"description"`
+}
+
+// EscrowTimeline shows a consumer when their card was authorized, held, charged and
+// refunded for a job
+type EscrowTimeline struct {
+	JobID         int               `json:"job_id"`
+	Status        string            `json:"status"`
+	Held          Money             `json:"held"`    // Authorized but not yet charged
+	Charged       Money             `json:"charged"` // Captured, less refunds
+	Refunded      Money             `json:"refunded"`
+	HoldExpiresAt *time.Time        `json:"hold_expires_at,omitempty"` // When an uncaptured hold lapses
+	Milestones    []EscrowMilestone `json:"milestones"`
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"time"
+)
+
+// Expense types
+const (
+	ExpenseTypeMileage   = "mileage"
+	ExpenseTypeMaterials = "materials"
+	ExpenseTypeOther     = "other"
+)
+
+// Expense statuses
+const (
+	ExpenseStatusLogged   = "logged" // Tax record only, not billed to the consumer
+	ExpenseStatusPending  = "pending"
+	ExpenseStatusApproved = "approved"
+	ExpenseStatusRejected = "rejected"
+)
+
+// JobExpense is a cost a gig worker incurred for a job
+type JobExpense struct {
+	ID              int        `json:"id" db:"id"`
+	UUID            string     `json:"uuid" db:"uuid"`
+	JobID           int        `json:"job_id" db:"job_id"`
+	GigWorkerID     int        `json:"gig_worker_id" db:"gig_worker_id"`
+	ExpenseType     string     `json:"expense_type" db:"expense_type"`
+	Description     string     `json:"description" db:"description"`
+	Amount          float64    `json:"amount" db:"amount"`
+	Miles           *float64   `json:"miles,omitempty" db:"miles"`
+	RatePerMile     *float64   `json:"rate_per_mile,omitempty" db:"rate_per_mile"`
+	OriginLatitude  *float64   `json:"origin_latitude,omitempty" db:"origin_latitude"`
+	OriginLongitude *float64   `json:"origin_longitude,omitempty" db:"origin_longitude"`
+	DistanceMethod  *string    `json:"distance_method,omitempty" db:"distance_method"`
+	ReceiptURL      *string    `json:"receipt_url,omitempty" db:"receipt_url"`
+	IncurredAt      time.Time  `json:"incurred_at" db:"incurred_at"`
+	Reimbursable    bool       `json:"reimbursable" db:"reimbursable"`
+	Status          string     `json:"status" db:"status"`
+	ReviewedBy      *int       `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	ReviewNote      *string    `json:"review_note,omitempty" db:"review_note"`
+	TransactionID   *int       `json:"transaction_id,omitempty" db:"transaction_id"`
+	BilledAt        *time.Time `json:"billed_at,omitempty" db:"billed_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+}
+
+// JobExpenseRequest represents a materials or other expense submitted by a worker
+type JobExpenseRequest struct {
+	ExpenseType  string     `json:"expense_type" validate:"required,oneof=materials other"`
+	Description  string     `json:"description" validate:"required,max=1000"`
+	Amount       float64    `json:"amount" validate:"required,gt=0"`
+	ReceiptURL   *string    `json:"receipt_url"`
+	IncurredAt   *time.Time `json:"incurred_at"`
+	Reimbursable bool       `json:"reimbursable"`
+}
+
+// MileageRequest logs the drive to a job. The origin defaults to the worker's
+// previous job that day, or their home location.
+type MileageRequest struct {
+	OriginLatitude  *float64 `json:"origin_latitude"`
+	OriginLongitude *float64 `json:"origin_longitude"`
+	Reimbursable    bool     `json:"reimbursable"`
+}
+
+// ExpenseReviewRequest is the consumer's decision on a reimbursable expense
+type ExpenseReviewRequest struct {
+	Approve bool   `json:"approve"`
+	Note    string `json:"note" validate:"max=500"`
+}
+
+// WorkerTaxSummary totals a worker's earnings and deductible expenses for a year
+type WorkerTaxSummary struct {
+	Year             int                `json:"year"`
+	GrossEarnings    float64            `json:"gross_earnings"`
+	PlatformFees     float64            `json:"platform_fees"`
+	Reimbursements   float64            `json:"reimbursements"`
+	JobsPaid         int                `json:"jobs_paid"`
+	TotalMiles       float64            `json:"total_miles"`
+	MileageDeduction float64            `json:"mileage_deduction"`
+	ExpensesByType   map[string]float64 `json:"expenses_by_type"`
+	TotalExpenses    float64            `json:"total_expenses"`
+}
@@ -0,0 +1,52 @@
+package model
+
+import "time"
+
+// FavoriteWorker is a worker a consumer favorited. AutoAccept is the consumer's setting
+// for the pair; WorkerAutoAccepts reports whether the worker currently auto-accepts the
+// consumer's rebookings (their floor still applies).
+type FavoriteWorker struct {
+	WorkerID          int       `json:"worker_id"`
+	WorkerName        string    `json:"worker_name"`
+	AutoAccept        bool      `json:"auto_accept"`
+	WorkerAutoAccepts bool      `json:"worker_auto_accepts"`
+	MinPrice          *float64  `json:"min_price,omitempty"` // Worker's auto-accept floor
+	Presence          string    `json:"presence"`
+	OnlineNow         bool      `json:"online_now"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// FavoriteWorkerRequest favorites a worker. AutoAccept defaults to true.
+type FavoriteWorkerRequest struct {
+	WorkerID   int   `json:"worker_id"`
+	AutoAccept *bool `json:"auto_accept,omitempty"`
+}
+
+// AutoAcceptPairRequest turns auto-accept on or off for one consumer-worker pair
+type AutoAcceptPairRequest struct {
+	AutoAccept *bool `json:"auto_accept"`
+}
+
+// AutoAcceptSettings is a worker's auto-accept opt-in, price floor and the consumers
+// who favorited them
+type AutoAcceptSettings struct {
+	Enabled   bool                 `json:"enabled"`
+	MinPrice  *float64             `json:"min_price"`
+	Consumers []AutoAcceptConsumer `json:"consumers"`
+}
+
+// AutoAcceptConsumer is a consumer who favorited the worker, with the worker's setting
+// for the pair
+type AutoAcceptConsumer struct {
+	ConsumerID   int       `json:"consumer_id"`
+	ConsumerName string    `json:"consumer_name"`
+	AutoAccept   bool      `json:"auto_accept"`
+	FavoritedAt  time.Time `json:"favorited_at"`
+}
+
+// AutoAcceptSettingsRequest replaces a worker's auto-accept opt-in and price floor; a
+// null min_price accepts any price
+type AutoAcceptSettingsRequest struct {
+	Enabled  *bool    `json:"enabled"`
+	MinPrice *float64 `json:"min_price"`
+}
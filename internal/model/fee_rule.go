@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+// FeeRule overrides the platform fee for the jobs matching its conditions. A nil
+// condition matches any job; a rule with StartsAt or EndsAt is a promotion that only
+// applies within that window.
+type FeeRule struct {
+	ID                 int        `json:"id"`
+	UUID               string     `json:"uuid"`
+	Name               string     `json:"name"`
+	Category           *string    `json:"category,omitempty"`
+	WorkerTier         *string    `json:"worker_tier,omitempty"`
+	StartsAt           *time.Time `json:"starts_at,omitempty"`
+	EndsAt             *time.Time `json:"ends_at,omitempty"`
+	PlatformFeePercent float64    `json:"platform_fee_percent"`
+	PlatformFeeFixed   Money      `json:"platform_fee_fixed"`
+	Priority           int        `json:"priority"`
+	IsActive           bool       `json:"is_active"`
+	CreatedBy          *int       `json:"created_by,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// FeeRuleRequest creates or replaces a fee rule
+type FeeRuleRequest struct {
+	Name               string     `json:"name"`
+	Category           *string    `json:"category,omitempty"`
+	WorkerTier         *string    `json:"worker_tier,omitempty"`
+	StartsAt           *time.Time `json:"starts_at,omitempty"`
+	EndsAt             *time.Time `json:"ends_at,omitempty"`
+	PlatformFeePercent float64    `json:"platform_fee_percent"`
+	PlatformFeeFixed   Money      `json:"platform_fee_fixed"`
+	Priority           int        `json:"priority"`
+	IsActive           *bool      `json:"is_active,omitempty"` // Defaults to true
+}
+
+// WorkerFeeTierRequest sets a worker's fee tier; a nil tier is the standard fee
+type WorkerFeeTierRequest struct {
+	Tier *string `json:"tier"`
+}
+
+// FeePreview is the fee schedule that applies to a job with the given category and
+// worker tier at a point in time, and how it splits an amount
+type FeePreview struct {
+	Category           string    `json:"category,omitempty"`
+	WorkerTier         string    `json:"worker_tier,omitempty"`
+	At                 time.Time `json:"at"`
+	Rule               *FeeRule  `json:"rule,omitempty"` // Nil when the default platform fee applies
+	PlatformFeePercent float64   `json:"platform_fee_percent"`
+	PlatformFeeFixed   Money     `json:"platform_fee_fixed"`
+	ProcessingPercent  float64   `json:"processing_percent"`
+	ProcessingFixed    Money     `json:"processing_fixed"`
+	Amount             Money     `json:"amount"`
+	WorkerNet          Money     `json:"worker_net"`
+	PlatformFee        Money     `json:"platform_fee"`
+	ProcessingFee      Money     `json:"processing_fee"`
+	Provider           string    `json:"provider"`
+}
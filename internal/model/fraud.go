@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+)
+
+// FraudFlag is a risk signal about a user raised by a fraud heuristic
+type FraudFlag struct {
+	ID          int        `json:"id" db:"id"`
+	UUID        string     `json:"uuid" db:"uuid"`
+	UserID      int        `json:"user_id" db:"user_id"`
+	JobID       *int       `json:"job_id" db:"job_id"`
+	Rule        string     `json:"rule" db:"rule"`
+	Score       float64    `json:"score" db:"score"`
+	Details     *string    `json:"details" db:"details"`
+	Status      string     `json:"status" db:"status"`
+	AlertedAt   *time.Time `json:"alerted_at" db:"alerted_at"`
+	ReviewedBy  *int       `json:"reviewed_by" db:"reviewed_by"`
+	ReviewedAt  *time.Time `json:"reviewed_at" db:"reviewed_at"`
+	ReviewNotes *string    `json:"review_notes" db:"review_notes"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// FraudFlagReviewRequest represents an admin's decision on a fraud flag
+type FraudFlagReviewRequest struct {
+	Status      string  `json:"status" validate:"required,oneof=dismissed confirmed"`
+	ReviewNotes *string `json:"review_notes" validate:"omitempty,max=5000"`
+}
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+)
+
+// FunnelReport is the job funnel for jobs posted in a time window
+type FunnelReport struct {
+	From   time.Time     `json:"from"`
+	To     time.Time     `json:"to"`
+	Stages []FunnelStage `json:"stages"`
+}
+
+// FunnelStage is how many jobs reached a stage and how long they took to get there.
+// Durations are in seconds and null when no job reached the stage.
+type FunnelStage struct {
+	Stage                  string   `json:"stage"`
+	Jobs                   int      `json:"jobs"`
+	ConversionRate         float64  `json:"conversion_rate"` // Share of posted jobs that reached the stage
+	MedianSecondsInStage   *float64 `json:"median_seconds_in_previous_stage"`
+	P90SecondsInStage      *float64 `json:"p90_seconds_in_previous_stage"`
+	MedianSecondsSincePost *float64 `json:"median_seconds_since_posted"`
+}
@@ -0,0 +1,50 @@
+package model
+
+import "time"
+
+// Job hand-off statuses
+const (
+	JobHandoffRequested = "requested" // Waiting for the consumer
+	JobHandoffApproved  = "approved"  // The job workflow is paying the outgoing worker and finding a replacement
+	JobHandoffDeclined  = "declined"  // The consumer kept the worker on the job
+	JobHandoffCompleted = "completed" // A replacement worker took the job over
+	JobHandoffUnfilled  = "unfilled"  // Nobody took the job over; the rest of it was not done
+	JobHandoffFailed    = "failed"    // The worker could not be released; they stay on the job and the portion payment was reversed
+)
+
+// JobHandoff is an assigned worker handing the rest of a job to another worker, e.g.
+// when they fall sick part way through. The outgoing worker is paid for the portion
+// they completed and the replacement for the rest.
+type JobHandoff struct {
+	ID               int        `json:"id"`
+	JobID            int        `json:"job_id"`
+	FromWorkerID     int        `json:"from_worker_id"`
+	ToWorkerID       *int       `json:"to_worker_id,omitempty"`
+	Reason           string     `json:"reason"`
+	CompletedPercent int        `json:"completed_percent"`
+	Status           string     `json:"status"`
+	PaidAmount       *float64   `json:"paid_amount,omitempty"`
+	TransactionID    *int       `json:"transaction_id,omitempty"`
+	RespondedAt      *time.Time `json:"responded_at,omitempty"`
+	CompletedAt      *time.Time `json:"completed_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// JobHandoffRequest is the assigned worker asking to be released from a job, with how
+// much of it they finished
+type JobHandoffRequest struct {
+	Reason           string `json:"reason"`
+	CompletedPercent int    `json:"completed_percent"`
+}
+
+// JobHandoffApproval is the consumer approving a hand-off. CompletedPercent, when set,
+// corrects the portion the outgoing worker is paid for.
+type JobHandoffApproval struct {
+	CompletedPercent *int `json:"completed_percent,omitempty"`
+}
+
+// HandoffPortionAmount is what the outgoing worker is paid for completing percent of a
+// job paying total, rounded down to the cent
+func HandoffPortionAmount(total Money, percent int) Money {
+	return NewMoney(total.Cents*int64(percent)/100, total.Currency)
+}
@@ -0,0 +1,26 @@
+package model
+
+import "testing"
+
+func TestHandoffPortionAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   Money
+		percent int
+		want    Money
+	}{
+		{name: "even split", total: USD(8250), percent: 40, want: USD(3300)},
+		{name: "rounds down to the cent", total: USD(1999), percent: 50, want: USD(999)},
+		{name: "whole job", total: USD(29), percent: 100, want: USD(29)},
+		{name: "nothing done", total: USD(12000), percent: 0, want: USD(0)},
+		{name: "keeps the currency", total: NewMoney(5000, "EUR"), percent: 30, want: NewMoney(1500, "EUR")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HandoffPortionAmount(tt.total, tt.percent); got != tt.want {
+				t.Errorf("HandoffPortionAmount(%v, %d) = %v, want %v", tt.total, tt.percent, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"time"
+)
+
+// Incident types
+const (
+	IncidentTypeSOS              = "sos"
+	IncidentTypeHarassment       = "harassment"
+	IncidentTypeInjury           = "injury"
+	IncidentTypePropertyDamage   = "property_damage"
+	IncidentTypeUnsafeConditions = "unsafe_conditions"
+	IncidentTypeOther            = "other"
+)
+
+// Incident statuses
+const (
+	IncidentStatusOpen         = "open"
+	IncidentStatusAcknowledged = "acknowledged"
+	IncidentStatusResolved     = "resolved"
+)
+
+// SafetyIncident represents a safety incident reported during a job
+type SafetyIncident struct {
+	ID                 int        `json:"id" db:"id"`
+	UUID               string     `json:"uuid" db:"uuid"`
+	JobID              int        `json:"job_id" db:"job_id"`
+	ReporterID         int        `json:"reporter_id" db:"reporter_id"`
+	OtherPartyID       *int       `json:"other_party_id" db:"other_party_id"`
+	IncidentType       string     `json:"incident_type" db:"incident_type"`
+	Severity           string     `json:"severity" db:"severity"`
+	Description        string     `json:"description" db:"description"`
+	Latitude           *float64   `json:"latitude" db:"latitude"`
+	Longitude          *float64   `json:"longitude" db:"longitude"`
+	Status             string     `json:"status" db:"status"`
+	OtherPartyNotified bool       `json:"other_party_notified" db:"other_party_notified"`
+	WorkflowPaused     bool       `json:"workflow_paused" db:"workflow_paused"`
+	OpsNotifiedAt      *time.Time `json:"ops_notified_at" db:"ops_notified_at"`
+	AcknowledgedBy     *int       `json:"acknowledged_by" db:"acknowledged_by"`
+	AcknowledgedAt     *time.Time `json:"acknowledged_at" db:"acknowledged_at"`
+	ResolvedBy         *int       `json:"resolved_by" db:"resolved_by"`
+	ResolvedAt         *time.Time `json:"resolved_at" db:"resolved_at"`
+	ResolutionNotes    *string    `json:"resolution_notes" db:"resolution_notes"`
+	CreatedAt          time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// IncidentReportRequest represents the request payload for reporting an incident
+type IncidentReportRequest struct {
+	IncidentType     string   `json:"incident_type" validate:"required"`
+	Severity         string   `json:"severity" validate:"omitempty,oneof=low medium high emergency"`
+	Description      string   `json:"description" validate:"required,max=5000"`
+	Latitude         *float64 `json:"latitude"`
+	Longitude        *float64 `json:"longitude"`
+	NotifyOtherParty *bool    `json:"notify_other_party"`
+	PauseJob         *bool    `json:"pause_job"`
+}
+
+// IncidentUpdateRequest represents an ops update to an incident
+type IncidentUpdateRequest struct {
+	Status          string  `json:"status" validate:"required,oneof=acknowledged resolved"`
+	ResolutionNotes *string `json:"resolution_notes" validate:"omitempty,max=5000"`
+}
+
+// ValidateIncidentType checks if an incident type is supported
+func ValidateIncidentType(incidentType string) bool {
+	switch incidentType {
+	case IncidentTypeSOS, IncidentTypeHarassment, IncidentTypeInjury,
+		IncidentTypePropertyDamage, IncidentTypeUnsafeConditions, IncidentTypeOther:
+		return true
+	}
+	return false
+}
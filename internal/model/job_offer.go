@@ -0,0 +1,94 @@
+package model
+
+import "time"
+
+// Job offer statuses
+const (
+	JobOfferPending   = "pending"
+	JobOfferAccepted  = "accepted"
+	JobOfferDeclined  = "declined"
+	JobOfferCancelled = "cancelled" // Another worker accepted first, or the job moved on
+	JobOfferExpired   = "expired"
+	JobOfferSnoozed   = "snoozed" // Still open; the worker is reminded when the snooze ends
+)
+
+// Reasons a worker declines a job offer, fed to matching and pricing analytics
+const (
+	OfferDeclineTooFar           = "too_far"
+	OfferDeclinePayTooLow        = "pay_too_low"
+	OfferDeclineScheduleConflict = "schedule_conflict"
+	OfferDeclineOther            = "other"
+)
+
+// OfferDeclineReasons lists the decline reasons a worker can give
+var OfferDeclineReasons = []string{OfferDeclineTooFar, OfferDeclinePayTooLow, OfferDeclineScheduleConflict, OfferDeclineOther}
+
+// Snoozing an offer: for OfferSnoozeMinMinutes to OfferSnoozeMaxMinutes (default
+// OfferSnoozeDefaultMinutes), at most MaxOfferSnoozes times, ending before the offer expires
+const (
+	OfferSnoozeMinMinutes     = 1
+	OfferSnoozeMaxMinutes     = 25
+	OfferSnoozeDefaultMinutes = 10
+	MaxOfferSnoozes           = 2
+)
+
+// JobOffer is an offer of a job to a worker. The job workflow offers a job to several
+// workers at once; the first to accept is assigned.
+type JobOffer struct {
+	ID             int        `json:"id"`
+	UUID           string     `json:"uuid"`
+	JobID          int        `json:"job_id"`
+	JobTitle       string     `json:"job_title"`
+	Category       *string    `json:"category,omitempty"`
+	Location       *string    `json:"location,omitempty"`
+	ScheduledStart *time.Time `json:"scheduled_start,omitempty"`
+	Status         string     `json:"status"`
+	Amount         *float64   `json:"amount,omitempty"`
+	Round          int        `json:"round"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	SnoozedUntil   *time.Time `json:"snoozed_until,omitempty"`
+	DeclineReason  *string    `json:"decline_reason,omitempty"`
+	RespondedAt    *time.Time `json:"responded_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// DeclineJobOfferRequest is why a worker turned an offer down. Both fields are optional.
+type DeclineJobOfferRequest struct {
+	Reason *string `json:"reason,omitempty"` // too_far, pay_too_low, schedule_conflict or other
+	Note   *string `json:"note,omitempty"`
+}
+
+// SnoozeJobOfferRequest is how long to put an offer aside
+type SnoozeJobOfferRequest struct {
+	Minutes int `json:"minutes,omitempty"`
+}
+
+// OfferDeclineReport is how workers answered the job offers sent in a time window, for
+// tuning matching (too_far) and pricing (pay_too_low)
+type OfferDeclineReport struct {
+	From       time.Time             `json:"from"`
+	To         time.Time             `json:"to"`
+	Overall    OfferOutcomes         `json:"overall"`
+	ByCategory []OfferOutcomeSegment `json:"by_category"`
+}
+
+// OfferOutcomes counts offers by how they ended. Declines are counted by reason, with
+// "unspecified" for declines without one. The average amounts compare what accepted
+// offers paid with what offers declined as pay_too_low paid.
+type OfferOutcomes struct {
+	Offers             int            `json:"offers"`
+	Accepted           int            `json:"accepted"`
+	Declined           int            `json:"declined"`
+	Expired            int            `json:"expired"`
+	Snoozed            int            `json:"snoozed"` // Offers the worker snoozed at least once
+	DeclineReasons     map[string]int `json:"decline_reasons"`
+	AcceptRate         float64        `json:"accept_rate"`
+	AvgAcceptedAmount  *float64       `json:"avg_accepted_amount"`
+	AvgPayTooLowAmount *float64       `json:"avg_pay_too_low_amount"`
+}
+
+// OfferOutcomeSegment is the offer outcomes for one job category
+type OfferOutcomeSegment struct {
+	Segment string `json:"segment"`
+	OfferOutcomes
+}
@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+// JobTemplate is a job a consumer saved to post again. It has the job's posting
+// fields but no schedule, which is given each time a job is posted from it.
+type JobTemplate struct {
+	ID                     int       `json:"id"`
+	UUID                   string    `json:"uuid"`
+	ConsumerID             int       `json:"consumer_id"`
+	Name                   string    `json:"name"`
+	Title                  string    `json:"title"`
+	Description            string    `json:"description"`
+	Category               string    `json:"category,omitempty"`
+	LocationAddress        string    `json:"location_address,omitempty"`
+	LocationLatitude       *float64  `json:"location_latitude,omitempty"`
+	LocationLongitude      *float64  `json:"location_longitude,omitempty"`
+	EstimatedDurationHours *float64  `json:"estimated_duration_hours,omitempty"`
+	PayRatePerHour         *float64  `json:"pay_rate_per_hour,omitempty"`
+	TotalPay               *float64  `json:"total_pay,omitempty"`
+	Notes                  string    `json:"notes,omitempty"`
+	AccessInstructions     string    `json:"access_instructions,omitempty"`
+	PreferredWorkerID      *int      `json:"preferred_worker_id,omitempty"` // Favorited worker jobs from the template are rebooked with
+	Currency               string    `json:"currency"`
+	SourceJobID            *int      `json:"source_job_id,omitempty"` // Job the template was saved from
+	CreatedAt              time.Time `json:"created_at"`
+	UpdatedAt              time.Time `json:"updated_at"`
+}
+
+// JobTemplateCreateRequest saves a template. With job_id the posting fields are copied
+// from that job; only name and preferred_worker_id are taken from the request.
+type JobTemplateCreateRequest struct {
+	Name                   string   `json:"name"`
+	JobID                  *int     `json:"job_id,omitempty"`
+	Title                  string   `json:"title,omitempty"`
+	Description            string   `json:"description,omitempty"`
+	Category               string   `json:"category,omitempty"`
+	LocationAddress        string   `json:"location_address,omitempty"`
+	LocationLatitude       *float64 `json:"location_latitude,omitempty"`
+	LocationLongitude      *float64 `json:"location_longitude,omitempty"`
+	EstimatedDurationHours *float64 `json:"estimated_duration_hours,omitempty"`
+	PayRatePerHour         *float64 `json:"pay_rate_per_hour,omitempty"`
+	TotalPay               *float64 `json:"total_pay,omitempty"`
+	Notes                  string   `json:"notes,omitempty"`
+	AccessInstructions     string   `json:"access_instructions,omitempty"`
+	PreferredWorkerID      *int     `json:"preferred_worker_id,omitempty"`
+	Currency               string   `json:"currency,omitempty"`
+}
+
+// JobFromTemplateRequest posts a job from a template
+type JobFromTemplateRequest struct {
+	ScheduledStart    *time.Time `json:"scheduled_start,omitempty"`
+	ScheduledEnd      *time.Time `json:"scheduled_end,omitempty"`
+	PreferredWorkerID *int       `json:"preferred_worker_id,omitempty"` // Replaces the template's
+}
+
+// JobRebookRequest books a job again with the worker who did it. Without a schedule
+// one is suggested from the worker's availability.
+type JobRebookRequest struct {
+	ScheduledStart *time.Time `json:"scheduled_start,omitempty"`
+	ScheduledEnd   *time.Time `json:"scheduled_end,omitempty"`
+}
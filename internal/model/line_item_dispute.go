@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+)
+
+// Line item dispute statuses
+const (
+	LineItemDisputePending   = "pending"   // Waiting for the worker
+	LineItemDisputeAccepted  = "accepted"  // The worker agreed and the amount was refunded
+	LineItemDisputeContested = "contested" // The worker disagreed and a dispute case was opened
+)
+
+// LineItemDispute is a consumer's challenge to one charge on a receipt, such as an
+// extra hour or a parts charge, rather than to the whole payment
+type LineItemDispute struct {
+	ID                  int        `json:"id" db:"id"`
+	UUID                string     `json:"uuid" db:"uuid"`
+	TransactionID       int        `json:"transaction_id" db:"transaction_id"`
+	JobID               int        `json:"job_id" db:"job_id"`
+	ConsumerID          int        `json:"consumer_id" db:"consumer_id"`
+	GigWorkerID         *int       `json:"gig_worker_id" db:"gig_worker_id"`
+	LineType            string     `json:"line_type" db:"line_type"`
+	LineID              *int       `json:"line_id,omitempty" db:"line_id"`
+	LineDescription     string     `json:"line_description" db:"line_description"`
+	LineAmount          Money      `json:"line_amount" db:"line_amount"`
+	Amount              Money      `json:"amount" db:"amount"`
+	Reason              string     `json:"reason" db:"reason"`
+	Status              string     `json:"status" db:"status"`
+	WorkerNote          *string    `json:"worker_note,omitempty" db:"worker_note"`
+	RespondedAt         *time.Time `json:"responded_at,omitempty" db:"responded_at"`
+	RefundTransactionID *int       `json:"refund_transaction_id,omitempty" db:"refund_transaction_id"`
+	DisputeID           *int       `json:"dispute_id,omitempty" db:"dispute_id"`
+	CreatedAt           time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// LineItemDisputeRequest flags a receipt line item. LineID is the id of a materials or
+// expense line and is omitted for the service charge. Amount defaults to the whole line.
+type LineItemDisputeRequest struct {
+	LineType string `json:"line_type" validate:"required,oneof=service materials expense"`
+	LineID   *int   `json:"line_id"`
+	Amount   *Money `json:"amount" validate:"omitempty,gt=0"`
+	Reason   string `json:"reason" validate:"required,max=2000"`
+}
+
+// LineItemDisputeAnswer is the worker accepting a line item dispute, which refunds
+// the disputed amount, or contesting it, which opens a dispute case for support
+type LineItemDisputeAnswer struct {
+	Accept bool    `json:"accept"`
+	Note   *string `json:"note" validate:"omitempty,max=2000"`
+}
+
+// FindReceiptLine returns the receipt line of lineType with lineID; the service line
+// has no ID
+func FindReceiptLine(items []ReceiptLineItem, lineType string, lineID *int) (*ReceiptLineItem, bool) {
+	for i, item := range items {
+		if item.Type != lineType {
+			continue
+		}
+		if lineType == ReceiptLineService || (lineID != nil && item.ID != nil && *item.ID == *lineID) {
+			return &items[i], true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,39 @@
+package model
+
+import "testing"
+
+func TestFindReceiptLine(t *testing.T) {
+	partID, expenseID, otherID := 4, 9, 5
+	items := []ReceiptLineItem{
+		{Type: ReceiptLineService, Description: "Fix a tap", Amount: 120},
+		{ID: &partID, Type: ReceiptLineMaterial, Description: "Washer", Amount: 3.5},
+		{ID: &expenseID, Type: ReceiptLineExpense, Description: "Parking", Amount: 8},
+	}
+
+	tests := []struct {
+		name     string
+		lineType string
+		lineID   *int
+		want     string
+	}{
+		{name: "service line needs no id", lineType: ReceiptLineService, want: "Fix a tap"},
+		{name: "materials by id", lineType: ReceiptLineMaterial, lineID: &partID, want: "Washer"},
+		{name: "expense by id", lineType: ReceiptLineExpense, lineID: &expenseID, want: "Parking"},
+		{name: "id of another type", lineType: ReceiptLineMaterial, lineID: &expenseID},
+		{name: "unknown id", lineType: ReceiptLineExpense, lineID: &otherID},
+		{name: "materials without id", lineType: ReceiptLineMaterial},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, ok := FindReceiptLine(items, tt.lineType, tt.lineID)
+			got := ""
+			if ok {
+				got = item.Description
+			}
+			if got != tt.want {
+				t.Errorf("FindReceiptLine(%s) = %q, want %q", tt.lineType, got, tt.want)
+			}
+		})
+	}
+}
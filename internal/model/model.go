@@ -64,31 +64,89 @@ type User struct {
 }
 
 type GigWorker struct {
-	ID                           int        `json:"id"`
-	Uuid                         string     `json:"uuid"`
-	Name                         string     `json:"name"`
-	Email                        string     `json:"email"`
-	Phone                        string     `json:"phone"`
-	Address                      string     `json:"address"`
-	Latitude                     float64    `json:"latitude"`
-	Longitude                    float64    `json:"longitude"`
-	PlaceID                      string     `json:"place_id"`
-	Role                         string     `json:"role"`
-	IsActive                     bool       `json:"is_active"`
-	EmailVerified                bool       `json:"email_verified"`
-	PhoneVerified                bool       `json:"phone_verified"`
-	Bio                          string     `json:"bio,omitempty"`
+	ID                  int        `json:"id"`
+	Uuid                string     `json:"uuid"`
+	Name                string     `json:"name"`
+	Email               string     `json:"email"`
+	Phone               string     `json:"phone"`
+	Address             string     `json:"address"`
+	Latitude            float64    `json:"latitude"`
+	Longitude           float64    `json:"longitude"`
+	PlaceID             string     `json:"place_id"`
+	Role                string     `json:"role"`
+	IsActive            bool       `json:"is_active"`
+	EmailVerified       bool       `json:"email_verified"`
+	PhoneVerified       bool       `json:"phone_verified"`
+	Bio                 string     `json:"bio,omitempty"`
+	HourlyRate          *float64   `json:"hourly_rate,omitempty"`
+	ExperienceYears     *int       `json:"experience_years,omitempty"`
+	VerificationStatus  string     `json:"verification_status,omitempty"`
+	BackgroundCheckDate *time.Time `json:"background_check_date,omitempty"`
+	ServiceRadiusMiles  *float64   `json:"service_radius_miles,omitempty"`
+	AvailabilityNotes   string     `json:"availability_notes,omitempty"`
+	Presence            string     `json:"presence"`   // online, recently_active or offline, from the worker app's heartbeats
+	OnlineNow           bool       `json:"online_now"` // The "online now" badge: presence is online
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// UserProfileUpdateRequest represents a partial update to the caller's own profile
+type UserProfileUpdateRequest struct {
+	Name      *string  `json:"name,omitempty"`
+	Phone     *string  `json:"phone,omitempty"`
+	Address   *string  `json:"address,omitempty"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+	PlaceID   *string  `json:"place_id,omitempty"`
+}
+
+// UserUpdateRequest represents an admin's partial update to a user
+type UserUpdateRequest struct {
+	Name          *string  `json:"name,omitempty"`
+	Phone         *string  `json:"phone,omitempty"`
+	Address       *string  `json:"address,omitempty"`
+	Latitude      *float64 `json:"latitude,omitempty"`
+	Longitude     *float64 `json:"longitude,omitempty"`
+	PlaceID       *string  `json:"place_id,omitempty"`
+	IsActive      *bool    `json:"is_active,omitempty"`
+	EmailVerified *bool    `json:"email_verified,omitempty"`
+	PhoneVerified *bool    `json:"phone_verified,omitempty"`
+}
+
+// GigWorkerUpdateRequest represents a partial update to a gig worker profile
+type GigWorkerUpdateRequest struct {
+	Name                         *string    `json:"name,omitempty"`
+	Phone                        *string    `json:"phone,omitempty"`
+	Address                      *string    `json:"address,omitempty"`
+	Latitude                     *float64   `json:"latitude,omitempty"`
+	Longitude                    *float64   `json:"longitude,omitempty"`
+	PlaceID                      *string    `json:"place_id,omitempty"`
+	IsActive                     *bool      `json:"is_active,omitempty"`
+	EmailVerified                *bool      `json:"email_verified,omitempty"`
+	PhoneVerified                *bool      `json:"phone_verified,omitempty"`
+	Bio                          *string    `json:"bio,omitempty"`
 	HourlyRate                   *float64   `json:"hourly_rate,omitempty"`
 	ExperienceYears              *int       `json:"experience_years,omitempty"`
-	VerificationStatus           string     `json:"verification_status,omitempty"`
+	VerificationStatus           *string    `json:"verification_status,omitempty"`
 	BackgroundCheckDate          *time.Time `json:"background_check_date,omitempty"`
 	ServiceRadiusMiles           *float64   `json:"service_radius_miles,omitempty"`
-	AvailabilityNotes            string     `json:"availability_notes,omitempty"`
-	EmergencyContactName         string     `json:"emergency_contact_name,omitempty"`
-	EmergencyContactPhone        string     `json:"emergency_contact_phone,omitempty"`
-	EmergencyContactRelationship string     `json:"emergency_contact_relationship,omitempty"`
-	CreatedAt                    time.Time  `json:"created_at"`
-	UpdatedAt                    time.Time  `json:"updated_at"`
+	AvailabilityNotes            *string    `json:"availability_notes,omitempty"`
+	EmergencyContactName         *string    `json:"emergency_contact_name,omitempty"`
+	EmergencyContactPhone        *string    `json:"emergency_contact_phone,omitempty"`
+	EmergencyContactRelationship *string    `json:"emergency_contact_relationship,omitempty"`
+}
+
+// EmergencyContact is a worker's emergency contact, held in the encrypted vault
+type EmergencyContact struct {
+	Name         string `json:"name"`
+	Phone        string `json:"phone"`
+	Relationship string `json:"relationship,omitempty"`
+}
+
+// BreakGlassRequest is the justification required to reveal an emergency contact
+type BreakGlassRequest struct {
+	IncidentID int    `json:"incident_id" validate:"required,min=1"`
+	Reason     string `json:"reason" validate:"required,min=10"`
 }
 
 type Schedule struct {
@@ -107,6 +165,34 @@ type Schedule struct {
 	UpdatedAt        time.Time  `json:"updated_at"`
 }
 
+// ScheduleOccurrence is one slot of a schedule, expanded from its recurring pattern
+type ScheduleOccurrence struct {
+	ScheduleID  int       `json:"schedule_id"`
+	Title       *string   `json:"title"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	IsAvailable bool      `json:"is_available"`
+	JobID       *int      `json:"job_id"`
+	Recurring   bool      `json:"recurring"`
+}
+
+// BlackoutDate is a run of days a gig worker is not taking work
+type BlackoutDate struct {
+	ID        int       `json:"id"`
+	WorkerID  int       `json:"worker_id"`
+	StartDate string    `json:"start_date"` // YYYY-MM-DD, UTC
+	EndDate   string    `json:"end_date"`   // Inclusive
+	Reason    *string   `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BlackoutDateRequest represents the payload for adding a blackout
+type BlackoutDateRequest struct {
+	StartDate string  `json:"start_date" validate:"required"`
+	EndDate   string  `json:"end_date" validate:"required"`
+	Reason    *string `json:"reason" validate:"omitempty,max=500"`
+}
+
 type Transaction struct {
 	ID                int        `json:"id"`
 	Uuid              string     `json:"uuid"`
@@ -149,6 +235,10 @@ type Job struct {
 	WorkerCompletedAt      *time.Time `json:"worker_completed_at,omitempty"`
 	ConsumerCompletedAt    *time.Time `json:"consumer_completed_at,omitempty"`
 	Notes                  NullString `json:"notes,omitempty"`
+	AccessInstructions     *string    `json:"access_instructions,omitempty"` // Consumer, assigned worker and admins only
+	CompletenessScore      *int       `json:"completeness_score,omitempty"`
+	PreferredWorkerID      *int       `json:"preferred_worker_id,omitempty"` // Favorited worker, or the worker of a rebooked job
+	Currency               string     `json:"currency,omitempty"`            // ISO 4217 code the job is priced and paid in
 	CreatedAt              time.Time  `json:"created_at"`
 	UpdatedAt              time.Time  `json:"updated_at"`
 }
@@ -169,7 +259,10 @@ type JobCreateRequest struct {
 	ScheduledStart         *time.Time `json:"scheduled_start,omitempty"`
 	ScheduledEnd           *time.Time `json:"scheduled_end,omitempty"`
 	Notes                  string     `json:"notes,omitempty"`
-	ConsumerID             int        `json:"consumer_id,omitempty"` // For tests
+	AccessInstructions     string     `json:"access_instructions,omitempty"`
+	PreferredWorkerID      *int       `json:"preferred_worker_id,omitempty"` // Rebooks a favorited worker
+	Currency               string     `json:"currency,omitempty"`            // ISO 4217 code; defaults to USD
+	ConsumerID             int        `json:"consumer_id,omitempty"`         // For tests
 }
 
 type JobUpdateRequest struct {
@@ -185,21 +278,63 @@ type JobUpdateRequest struct {
 	ScheduledStart         *time.Time `json:"scheduled_start,omitempty"`
 	ScheduledEnd           *time.Time `json:"scheduled_end,omitempty"`
 	Notes                  *string    `json:"notes,omitempty"`
+	AccessInstructions     *string    `json:"access_instructions,omitempty"`
+}
+
+// JobAcceptRequest represents a gig worker accepting a job
+type JobAcceptRequest struct {
+	GigWorkerID int `json:"gig_worker_id"`
+}
+
+// JobOfferRequest represents a job offer sent to a gig worker
+type JobOfferRequest struct {
+	GigWorkerID int    `json:"gig_worker_id"`
+	Message     string `json:"message,omitempty"`
+}
+
+// JobRejectRequest represents a gig worker rejecting an assigned job
+type JobRejectRequest struct {
+	RejectionReason string `json:"rejection_reason,omitempty"`
+}
+
+// JobReviewSubmission represents a review submitted through the job workflow
+type JobReviewSubmission struct {
+	ReviewerID int    `json:"reviewer_id"`
+	Rating     int    `json:"rating"`
+	Comment    string `json:"comment"`
 }
 
 type JobResponse struct {
 	Job
-	Consumer  *UserSummary `json:"consumer,omitempty"`
-	GigWorker *UserSummary `json:"gig_worker,omitempty"`
-	Distance  *float64     `json:"distance_km,omitempty"`
+	Consumer      *UserSummary          `json:"consumer,omitempty"`
+	ConsumerTrust *ConsumerTrustSignals `json:"consumer_trust,omitempty"`
+	GigWorker     *UserSummary          `json:"gig_worker,omitempty"`
+	Distance      *float64              `json:"distance_km,omitempty"`
+	Weather       *WeatherAdvisory      `json:"weather_advisory,omitempty"`
+	Photos        []JobPhoto            `json:"photos,omitempty"`
+}
+
+// ConsumerTrustSignals gives workers aggregate, privacy-safe information about a consumer.
+// Ratings are only exposed once enough workers have reviewed the consumer that no single
+// review can be inferred from the average. The review count is then only a lower bound
+// (3, 10, 25, 50 or 100), so a new review can't be backed out of a change in the average.
+type ConsumerTrustSignals struct {
+	VerifiedPaymentMethod bool     `json:"verified_payment_method"`
+	VerifiedAddress       bool     `json:"verified_address"`
+	CompletedJobs         int      `json:"completed_jobs"`
+	AverageWorkerRating   *float64 `json:"average_worker_rating,omitempty"`
+	WorkerReviewCount     int      `json:"worker_review_count"`
+	MemberSince           string   `json:"member_since"` // YYYY-MM, month granularity only
 }
 
 type UserSummary struct {
-	ID            int      `json:"id"`
-	UUID          string   `json:"uuid"`
-	Name          string   `json:"name"`
-	AverageRating *float64 `json:"average_rating,omitempty"`
-	TotalJobs     int      `json:"total_jobs,omitempty"`
+	ID                 int      `json:"id"`
+	UUID               string   `json:"uuid"`
+	Name               string   `json:"name"`
+	AverageRating      *float64 `json:"average_rating,omitempty"`
+	TotalJobs          int      `json:"total_jobs,omitempty"`
+	AvatarURL          *string  `json:"avatar_url,omitempty"`
+	AvatarThumbnailURL *string  `json:"avatar_thumbnail_url,omitempty"`
 }
 
 type JobsListResponse struct {
@@ -221,6 +356,8 @@ type ErrorResponse struct {
 	Message string            `json:"message,omitempty"`
 	Code    string            `json:"code,omitempty"`
 	Details map[string]string `json:"details,omitempty"`
+	// RequestID identifies the request in the server logs, for support
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // ValidationError represents a validation error response
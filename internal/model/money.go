@@ -0,0 +1,228 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// DefaultCurrency is the currency payments are taken in
+const DefaultCurrency = "USD"
+
+// ErrInvalidAmount is returned when parsing an amount that is not a decimal number
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// Money is an amount in a currency's minor units (cents), so sums and fee splits are
+// exact where float64 dollars drift. It is read and written in the decimal form used
+// by the API and the NUMERIC(10,2) columns, e.g. 19.99; amounts with more than two
+// decimal places are rounded half away from zero, as Postgres rounds them.
+type Money struct {
+	Cents    int64
+	Currency string
+}
+
+// NewMoney returns an amount of cents in currency
+func NewMoney(cents int64, currency string) Money {
+	return Money{Cents: cents, Currency: currency}
+}
+
+// USD returns an amount of US cents
+func USD(cents int64) Money {
+	return Money{Cents: cents, Currency: DefaultCurrency}
+}
+
+// MoneyFromDollars converts a float64 dollar amount, rounding to the nearest cent
+// (19.99 * 100 is 1998.9999... in floating point). It is a shim for amounts that are
+// still float64, such as job pay rates.
+func MoneyFromDollars(dollars float64) Money {
+	return USD(int64(math.Round(dollars * 100)))
+}
+
+// ParseMoney parses a decimal amount such as "19.99", "-5" or "1e2" in the default
+// currency
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.Contains(s, "/") {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	r.Mul(r, big.NewRat(100, 1))
+
+	// Round half away from zero
+	num := new(big.Int).Abs(r.Num())
+	cents, rem := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	if rem.Lsh(rem, 1).Cmp(r.Denom()) >= 0 {
+		cents.Add(cents, big.NewInt(1))
+	}
+	if !cents.IsInt64() {
+		return Money{}, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, s)
+	}
+	if r.Sign() < 0 {
+		cents.Neg(cents)
+	}
+	return USD(cents.Int64()), nil
+}
+
+// Dollars converts the amount to float64 for code that has not moved to Money
+func (m Money) Dollars() float64 {
+	return float64(m.Cents) / 100.0
+}
+
+// String formats the amount as a decimal with two places, e.g. "-3.05"
+func (m Money) String() string {
+	sign := ""
+	cents := m.Cents
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.Cents == 0
+}
+
+// IsPositive reports whether the amount is greater than zero
+func (m Money) IsPositive() bool {
+	return m.Cents > 0
+}
+
+// IsNegative reports whether the amount is less than zero
+func (m Money) IsNegative() bool {
+	return m.Cents < 0
+}
+
+// Add returns m + o. Amounts in different currencies cannot be combined; an amount
+// without a currency takes the other's.
+func (m Money) Add(o Money) Money {
+	return Money{Cents: m.Cents + o.Cents, Currency: m.currencyWith(o)}
+}
+
+// Sub returns m - o
+func (m Money) Sub(o Money) Money {
+	return Money{Cents: m.Cents - o.Cents, Currency: m.currencyWith(o)}
+}
+
+// In returns the same number of minor units in another currency, for amounts set per
+// transaction such as fixed fees. It does not convert.
+func (m Money) In(currency string) Money {
+	return Money{Cents: m.Cents, Currency: currency}
+}
+
+// Neg returns -m
+func (m Money) Neg() Money {
+	return Money{Cents: -m.Cents, Currency: m.Currency}
+}
+
+// Min returns the smaller of m and o
+func (m Money) Min(o Money) Money {
+	if o.Cents < m.Cents {
+		return Money{Cents: o.Cents, Currency: m.currencyWith(o)}
+	}
+	return Money{Cents: m.Cents, Currency: m.currencyWith(o)}
+}
+
+// Percent returns percent% of the amount, rounded half away from zero to the cent.
+// The rate is taken to a millionth (2.9 is 2.8999... in floating point) and applied
+// in integer arithmetic, so the same amount always yields the same fee.
+func (m Money) Percent(percent float64) Money {
+	rate := int64(math.Round(percent * 10000))
+	return Money{Cents: divRound(m.Cents*rate, 1000000), Currency: m.Currency}
+}
+
+func (m Money) currencyWith(o Money) string {
+	switch {
+	case m.Currency == "":
+		return o.Currency
+	case o.Currency == "" || o.Currency == m.Currency:
+		return m.Currency
+	default:
+		panic(fmt.Sprintf("model: cannot combine %s and %s amounts", m.Currency, o.Currency))
+	}
+}
+
+// divRound divides n by a positive d, rounding half away from zero
+func divRound(n, d int64) int64 {
+	q, r := n/d, n%d
+	if r < 0 {
+		r = -r
+	}
+	if 2*r >= d {
+		if n < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return q
+}
+
+// MarshalJSON writes the amount as a JSON number with two decimal places
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON reads a JSON number or numeric string. The currency is kept if
+// already set, otherwise it is the default currency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	if m.Currency != "" {
+		parsed.Currency = m.Currency
+	}
+	*m = parsed
+	return nil
+}
+
+// Scan implements the sql.Scanner interface for NUMERIC columns. Scanned amounts are
+// in the default currency; scan nullable columns into a *Money.
+func (m *Money) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	case int64:
+		*m = USD(v * 100)
+		return nil
+	case float64:
+		*m = MoneyFromDollars(v)
+		return nil
+	case nil:
+		return errors.New("cannot scan NULL into Money")
+	default:
+		return fmt.Errorf("cannot scan %T into Money", value)
+	}
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value implements the driver.Valuer interface, writing the decimal amount
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
@@ -0,0 +1,156 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "19.99", want: 1999},
+		{in: "19.9", want: 1990},
+		{in: "20", want: 2000},
+		{in: "-3.05", want: -305},
+		{in: "0.005", want: 1},
+		{in: "-0.005", want: -1},
+		{in: "0.0049", want: 0},
+		{in: "1e2", want: 10000},
+		{in: " 7.10 ", want: 710},
+		{in: "", wantErr: true},
+		{in: "abc", wantErr: true},
+		{in: "1/3", wantErr: true},
+		{in: "1e30", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseMoney(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidAmount) {
+					t.Fatalf("ParseMoney(%q) error = %v, want ErrInvalidAmount", tt.in, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMoney(%q) error = %v", tt.in, err)
+			}
+			if got != USD(tt.want) {
+				t.Errorf("ParseMoney(%q) = %+v, want %d cents", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Money
+		want Money
+	}{
+		{name: "from dollars rounds float error", got: MoneyFromDollars(19.99), want: USD(1999)},
+		{name: "from dollars sums without drift", got: MoneyFromDollars(0.1 + 0.2), want: USD(30)},
+		{name: "add", got: USD(1999).Add(USD(1)), want: USD(2000)},
+		{name: "add takes currency", got: Money{Cents: 5}.Add(NewMoney(5, "EUR")), want: NewMoney(10, "EUR")},
+		{name: "sub", got: USD(500).Sub(USD(750)), want: USD(-250)},
+		{name: "min", got: USD(500).Min(USD(300)), want: USD(300)},
+		{name: "percent", got: USD(10000).Percent(8.25), want: USD(825)},
+		{name: "percent rounds half away from zero", got: USD(1500).Percent(2.9), want: USD(44)},
+		{name: "negative percent rounds half away from zero", got: USD(-1500).Percent(2.9), want: USD(-44)},
+		{name: "percent rounds down below half", got: USD(1999).Percent(7), want: USD(140)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %+v, want %+v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyCurrencyMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("adding USD and EUR did not panic")
+		}
+	}()
+	USD(1).Add(NewMoney(1, "EUR"))
+}
+
+func TestMoneyJSON(t *testing.T) {
+	type body struct {
+		Amount Money  `json:"amount"`
+		Refund *Money `json:"refund,omitempty"`
+	}
+
+	data, err := json.Marshal(body{Amount: USD(-305)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"amount":-3.05}` {
+		t.Errorf("Marshal = %s", data)
+	}
+
+	tests := []struct {
+		in   string
+		want body
+	}{
+		{in: `{"amount":19.99}`, want: body{Amount: USD(1999)}},
+		{in: `{"amount":"19.99"}`, want: body{Amount: USD(1999)}},
+		{in: `{"amount":12.345,"refund":1}`, want: body{Amount: USD(1235), Refund: &Money{Cents: 100, Currency: DefaultCurrency}}},
+		{in: `{"amount":null}`, want: body{}},
+	}
+	for _, tt := range tests {
+		var got body
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", tt.in, err)
+		}
+		if got.Amount != tt.want.Amount || (got.Refund == nil) != (tt.want.Refund == nil) ||
+			(got.Refund != nil && *got.Refund != *tt.want.Refund) {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+
+	var bad body
+	if err := json.Unmarshal([]byte(`{"amount":"ten"}`), &bad); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("Unmarshal of a non-number error = %v, want ErrInvalidAmount", err)
+	}
+}
+
+func TestMoneySQL(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  Money
+	}{
+		{name: "numeric bytes", value: []byte("19.99"), want: USD(1999)},
+		{name: "numeric string", value: "-0.50", want: USD(-50)},
+		{name: "integer", value: int64(3), want: USD(300)},
+		{name: "float", value: 19.99, want: USD(1999)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Money
+			if err := got.Scan(tt.value); err != nil {
+				t.Fatalf("Scan(%v) error = %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("Scan(%v) = %+v, want %+v", tt.value, got, tt.want)
+			}
+			if v, _ := got.Value(); v != got.String() {
+				t.Errorf("Value() = %v, want %s", v, got.String())
+			}
+		})
+	}
+
+	var m Money
+	if err := m.Scan(nil); err == nil {
+		t.Error("Scan(nil) succeeded, want an error")
+	}
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"time"
+)
+
+// In-app notification types (the notification_type enum)
+const (
+	NotificationJobPosted       = "job_posted"
+	NotificationJobAccepted     = "job_accepted"
+	NotificationJobCompleted    = "job_completed"
+	NotificationJobOffer        = "job_offer"
+	NotificationPaymentReceived = "payment_received"
+	NotificationPaymentSent     = "payment_sent"
+	NotificationSystemMessage   = "system_message"
+	NotificationSurveyRequest   = "survey_request"
+)
+
+// In-app notification statuses (the notification_status enum)
+const (
+	NotificationStatusUnread   = "unread"
+	NotificationStatusRead     = "read"
+	NotificationStatusArchived = "archived"
+)
+
+// Notification is an in-app notification shown in a user's inbox
+type Notification struct {
+	ID                   int        `json:"id" db:"id"`
+	UUID                 string     `json:"uuid" db:"uuid"`
+	UserID               int        `json:"user_id" db:"user_id"`
+	Type                 string     `json:"type" db:"type"`
+	Title                string     `json:"title" db:"title"`
+	Message              string     `json:"message" db:"message"`
+	Status               string     `json:"status" db:"status"`
+	RelatedJobID         *int       `json:"related_job_id" db:"related_job_id"`
+	RelatedTransactionID *int       `json:"related_transaction_id" db:"related_transaction_id"`
+	ActionURL            *string    `json:"action_url" db:"action_url"`
+	Metadata             JSONB      `json:"metadata,omitempty" db:"metadata"`
+	ReadAt               *time.Time `json:"read_at" db:"read_at"`
+	CreatedAt            time.Time  `json:"created_at" db:"created_at"`
+}
+
+// NotificationTypes lists every notification type, in the order preferences are shown
+var NotificationTypes = []string{
+	NotificationJobPosted, NotificationJobOffer, NotificationJobAccepted, NotificationJobCompleted,
+	NotificationPaymentReceived, NotificationPaymentSent, NotificationSurveyRequest, NotificationSystemMessage,
+}
+
+// SMSNotificationTypes are the notification types that can be sent by SMS: those a user
+// may need to act on away from the app
+var SMSNotificationTypes = []string{NotificationJobOffer, NotificationPaymentReceived, NotificationPaymentSent}
+
+// SupportsSMS reports whether a notification type can be sent by SMS
+func SupportsSMS(notificationType string) bool {
+	for _, t := range SMSNotificationTypes {
+		if t == notificationType {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationPreference is whether a user is sent one type of notification by email,
+// push and SMS. In-app notifications are always kept in the inbox.
+type NotificationPreference struct {
+	Type         string `json:"type" db:"type"`
+	EmailEnabled bool   `json:"email_enabled" db:"email_enabled"`
+	PushEnabled  bool   `json:"push_enabled" db:"push_enabled"`
+	SMSEnabled   bool   `json:"sms_enabled" db:"sms_enabled"`
+}
+
+// NotificationPreferencesRequest changes some of a user's notification preferences;
+// types left out keep their current settings
+type NotificationPreferencesRequest struct {
+	Preferences []NotificationPreference `json:"preferences" validate:"required,dive"`
+}
+
+// DefaultNotificationPreference is the preference for users who never changed it:
+// email and push, except system messages by email only, and no SMS
+func DefaultNotificationPreference(notificationType string) NotificationPreference {
+	return NotificationPreference{
+		Type:         notificationType,
+		EmailEnabled: true,
+		PushEnabled:  notificationType != NotificationSystemMessage,
+	}
+}
+
+// Enabled reports whether the preference allows a delivery channel. SMS is only ever
+// enabled for SMSNotificationTypes.
+func (p NotificationPreference) Enabled(channel string) bool {
+	switch channel {
+	case DeliveryChannelEmail:
+		return p.EmailEnabled
+	case DeliveryChannelPush:
+		return p.PushEnabled
+	case DeliveryChannelSMS:
+		return p.SMSEnabled && SupportsSMS(p.Type)
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"time"
+)
+
+// Notification delivery channels
+const (
+	DeliveryChannelEmail = "email"
+	DeliveryChannelPush  = "push"
+	DeliveryChannelSMS   = "sms"
+)
+
+// Notification delivery statuses
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSent      = "sent"
+	DeliveryStatusRetrying  = "retrying"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusBounced   = "bounced"
+	DeliveryStatusFailed    = "failed"
+)
+
+// NotificationDelivery is one email, push or SMS notification handed to a provider, with
+// its delivery history
+type NotificationDelivery struct {
+	ID                int                         `json:"id"`
+	UUID              string                      `json:"uuid"`
+	UserID            *int                        `json:"user_id"`
+	Channel           string                      `json:"channel"`
+	Provider          string                      `json:"provider"`
+	Kind              string                      `json:"kind"`
+	Recipient         string                      `json:"recipient"`
+	Subject           *string                     `json:"subject"`
+	Status            string                      `json:"status"`
+	ProviderMessageID *string                     `json:"provider_message_id"`
+	Attempts          int                         `json:"attempts"`
+	LastError         *string                     `json:"last_error"`
+	NextAttemptAt     *time.Time                  `json:"next_attempt_at"`
+	SentAt            *time.Time                  `json:"sent_at"`
+	DeliveredAt       *time.Time                  `json:"delivered_at"`
+	CreatedAt         time.Time                   `json:"created_at"`
+	UpdatedAt         time.Time                   `json:"updated_at"`
+	Events            []NotificationDeliveryEvent `json:"events"`
+}
+
+// NotificationDeliveryEvent is a send attempt or provider receipt for a delivery
+type NotificationDeliveryEvent struct {
+	Event      string    `json:"event"`
+	Detail     *string   `json:"detail"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// Email suppression reasons
+const (
+	SuppressionReasonBounce    = "bounce"
+	SuppressionReasonComplaint = "complaint"
+)
+
+// EmailSuppression is an address no longer emailed because it hard bounced or its owner
+// marked an email as spam
+type EmailSuppression struct {
+	Email     string    `json:"email"`
+	Reason    string    `json:"reason"`
+	Provider  string    `json:"provider"`
+	Detail    *string   `json:"detail"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
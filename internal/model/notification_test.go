@@ -0,0 +1,33 @@
+package model
+
+import "testing"
+
+func TestDefaultNotificationPreferenceEnabled(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     string
+		sms     bool
+		channel string
+		want    bool
+	}{
+		{name: "job email", typ: NotificationJobAccepted, channel: DeliveryChannelEmail, want: true},
+		{name: "job push", typ: NotificationJobAccepted, channel: DeliveryChannelPush, want: true},
+		{name: "job sms is opt-in", typ: NotificationJobAccepted, channel: DeliveryChannelSMS},
+		{name: "job offer sms when turned on", typ: NotificationJobOffer, sms: true, channel: DeliveryChannelSMS, want: true},
+		{name: "payment sms when turned on", typ: NotificationPaymentReceived, sms: true, channel: DeliveryChannelSMS, want: true},
+		{name: "sms only for offers and payments", typ: NotificationJobAccepted, sms: true, channel: DeliveryChannelSMS},
+		{name: "system message email", typ: NotificationSystemMessage, channel: DeliveryChannelEmail, want: true},
+		{name: "system message push off", typ: NotificationSystemMessage, channel: DeliveryChannelPush},
+		{name: "unknown channel", typ: NotificationJobPosted, channel: "fax"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pref := DefaultNotificationPreference(tt.typ)
+			pref.SMSEnabled = tt.sms
+			if got := pref.Enabled(tt.channel); got != tt.want {
+				t.Errorf("preference for %q Enabled(%q) = %v; want %v", tt.typ, tt.channel, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"time"
+)
+
+// Parts request statuses
+const (
+	PartsRequestStatusPending   = "pending"
+	PartsRequestStatusApproved  = "approved"
+	PartsRequestStatusDeclined  = "declined"
+	PartsRequestStatusCancelled = "cancelled"
+)
+
+// PartsRequest is a worker's request to buy materials for a job, billed to the consumer once approved
+type PartsRequest struct {
+	ID            int        `json:"id" db:"id"`
+	UUID          string     `json:"uuid" db:"uuid"`
+	JobID         int        `json:"job_id" db:"job_id"`
+	GigWorkerID   int        `json:"gig_worker_id" db:"gig_worker_id"`
+	ItemName      string     `json:"item_name" db:"item_name"`
+	Quantity      int        `json:"quantity" db:"quantity"`
+	UnitPrice     float64    `json:"unit_price" db:"unit_price"`
+	TotalAmount   float64    `json:"total_amount" db:"total_amount"`
+	PhotoURL      string     `json:"photo_url" db:"photo_url"`
+	Note          *string    `json:"note,omitempty" db:"note"`
+	Status        string     `json:"status" db:"status"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	ReviewNote    *string    `json:"review_note,omitempty" db:"review_note"`
+	TransactionID *int       `json:"transaction_id,omitempty" db:"transaction_id"`
+	BilledAt      *time.Time `json:"billed_at,omitempty" db:"billed_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// PartsRequestBody represents a worker's parts request
+type PartsRequestBody struct {
+	ItemName  string  `json:"item_name" validate:"required,max=255"`
+	Quantity  int     `json:"quantity" validate:"omitempty,min=1"`
+	UnitPrice float64 `json:"unit_price" validate:"required,gt=0"`
+	PhotoURL  string  `json:"photo_url" validate:"required"`
+	Note      string  `json:"note" validate:"max=1000"`
+}
+
+// PartsReviewRequest is the consumer's decision on a parts request
+type PartsReviewRequest struct {
+	Approve bool   `json:"approve"`
+	Note    string `json:"note" validate:"max=500"`
+}
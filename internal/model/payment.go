@@ -38,20 +38,23 @@ const (
 	PaymentSplitTypeTax           PaymentSplitType = "tax"
 	PaymentSplitTypeTip           PaymentSplitType = "tip"
 	PaymentSplitTypeOther         PaymentSplitType = "other"
+
+	PaymentSplitTypeExpenseReimbursement PaymentSplitType = "expense_reimbursement"
+	PaymentSplitTypeMaterials            PaymentSplitType = "materials"
 )
 
 // ==============================================
 // PAYMENT MODELS
 // ==============================================
 
-// EnhancedTransaction extends the basic Transaction with Clover fields
+// EnhancedTransaction extends the basic Transaction with payment provider fields
 type EnhancedTransaction struct {
 	ID                       int                `json:"id"`
 	UUID                     string             `json:"uuid"`
 	JobID                    int                `json:"job_id"`
 	ConsumerID               int                `json:"consumer_id"`
 	GigWorkerID              *int               `json:"gig_worker_id,omitempty"`
-	Amount                   float64            `json:"amount"`
+	Amount                   Money              `json:"amount"`
 	Currency                 string             `json:"currency"`
 	Status                   TransactionStatus  `json:"status"`
 	TransactionType          TransactionType    `json:"transaction_type"`
@@ -60,20 +63,24 @@ type EnhancedTransaction struct {
 	CloverSourceToken        *string            `json:"clover_source_token,omitempty"`
 	CloverRefundID           *string            `json:"clover_refund_id,omitempty"`
 	CloverOrderID            *string            `json:"clover_order_id,omitempty"`
+	PaymentProvider          string             `json:"payment_provider"`
+	MerchantID               *string            `json:"merchant_id,omitempty"`
+	ProviderChargeID         *string            `json:"provider_charge_id,omitempty"`
+	ProviderRefundID         *string            `json:"provider_refund_id,omitempty"`
 	AuthorizedAt             *time.Time         `json:"authorized_at,omitempty"`
 	AuthorizationExpiresAt   *time.Time         `json:"authorization_expires_at,omitempty"`
 	CapturedAt               *time.Time         `json:"captured_at,omitempty"`
-	CaptureAmount            *float64           `json:"capture_amount,omitempty"`
+	CaptureAmount            *Money             `json:"capture_amount,omitempty"`
 	PaymentMethodID          *int               `json:"payment_method_id,omitempty"`
 	PaymentMethod            *string            `json:"payment_method,omitempty"`
 	LastFour                 *string            `json:"last_four,omitempty"`
-	ProcessingFee            float64            `json:"processing_fee"`
-	PlatformFee              float64            `json:"platform_fee"`
-	NetAmount                *float64           `json:"net_amount,omitempty"`
+	ProcessingFee            Money              `json:"processing_fee"`
+	PlatformFee              Money              `json:"platform_fee"`
+	NetAmount                *Money             `json:"net_amount,omitempty"`
 	EscrowHeldAt             *time.Time         `json:"escrow_held_at,omitempty"`
 	EscrowReleasedAt         *time.Time         `json:"escrow_released_at,omitempty"`
 	RefundedAt               *time.Time         `json:"refunded_at,omitempty"`
-	RefundAmount             *float64           `json:"refund_amount,omitempty"`
+	RefundAmount             *Money             `json:"refund_amount,omitempty"`
 	RefundReason             *string            `json:"refund_reason,omitempty"`
 	SettlementBatchID        *int               `json:"settlement_batch_id,omitempty"`
 	ReconciledAt             *time.Time         `json:"reconciled_at,omitempty"`
@@ -91,7 +98,7 @@ type PaymentSplit struct {
 	UUID          string           `json:"uuid"`
 	TransactionID int              `json:"transaction_id"`
 	SplitType     PaymentSplitType `json:"split_type"`
-	Amount        float64          `json:"amount"`
+	Amount        Money            `json:"amount"`
 	Percentage    *float64         `json:"percentage,omitempty"`
 	RecipientID   *int             `json:"recipient_id,omitempty"`
 	Description   *string          `json:"description,omitempty"`
@@ -270,9 +277,11 @@ type PaymentAuthorizeRequest struct {
 	PaymentMethodID   *int                `json:"payment_method_id,omitempty"`
 	CardToken         *string             `json:"card_token,omitempty"`
 	CardDetails       *CardDetails        `json:"card_details,omitempty"`
-	Amount            float64             `json:"amount" binding:"required,gt=0"`
+	Amount            Money               `json:"amount" binding:"required,gt=0"` // Must match the price breakdown total
+	Currency          string              `json:"currency,omitempty"`              // Must match the job's currency when given
 	SaveCard          bool                `json:"save_card"`
 	Metadata          map[string]interface{} `json:"metadata,omitempty"`
+	IdempotencyKey    string              `json:"-"` // From the Idempotency-Key header
 }
 
 type CardDetails struct {
@@ -292,12 +301,15 @@ type PaymentAuthorizeResponse struct {
 	TransactionID int                 `json:"transaction_id"`
 	Transaction   *EnhancedTransaction `json:"transaction,omitempty"`
 	Message       string              `json:"message,omitempty"`
+	Replayed      bool                `json:"-"` // Returned for a repeated idempotency key
 }
 
 // Payment capture request
 type PaymentCaptureRequest struct {
-	TransactionID int      `json:"transaction_id" binding:"required"`
-	Amount        *float64 `json:"amount,omitempty"` // Omit for full capture
+	TransactionID  int      `json:"transaction_id" binding:"required"`
+	Amount         *Money   `json:"amount,omitempty"` // Omit for full capture
+	Currency       string   `json:"currency,omitempty"` // Must match the payment's currency when given
+	IdempotencyKey string   `json:"-"`                // From the Idempotency-Key header
 }
 
 type PaymentCaptureResponse struct {
@@ -305,13 +317,17 @@ type PaymentCaptureResponse struct {
 	TransactionID int                 `json:"transaction_id"`
 	Transaction   *EnhancedTransaction `json:"transaction,omitempty"`
 	Message       string              `json:"message,omitempty"`
+	Replayed      bool                `json:"-"` // Returned for a repeated idempotency key
 }
 
 // Payment refund request
 type PaymentRefundRequest struct {
-	TransactionID int      `json:"transaction_id" binding:"required"`
-	Amount        *float64 `json:"amount,omitempty"` // Omit for full refund
-	Reason        string   `json:"reason,omitempty"`
+	TransactionID  int      `json:"transaction_id" binding:"required"`
+	Amount         *Money   `json:"amount,omitempty"` // Omit for full refund
+	Currency       string   `json:"currency,omitempty"` // Must match the payment's currency when given
+	Reason         string   `json:"reason,omitempty"`
+	IdempotencyKey string   `json:"-"` // From the Idempotency-Key header
+	KeepJobStatus  bool     `json:"-"` // Refunds part of a job, such as a hand-off portion, without cancelling it
 }
 
 type PaymentRefundResponse struct {
@@ -319,6 +335,22 @@ type PaymentRefundResponse struct {
 	RefundID      int                 `json:"refund_id"`
 	Transaction   *EnhancedTransaction `json:"transaction,omitempty"`
 	Message       string              `json:"message,omitempty"`
+	Replayed      bool                `json:"-"` // Returned for a repeated idempotency key
+}
+
+// Tip request; the worker receives the whole tip
+type JobTipRequest struct {
+	Amount          Money  `json:"amount" binding:"required"`
+	PaymentMethodID *int   `json:"payment_method_id,omitempty"` // Omit to charge the card that paid for the job
+	IdempotencyKey  string `json:"-"`                           // From the Idempotency-Key header
+}
+
+type JobTipResponse struct {
+	Success       bool                 `json:"success"`
+	TransactionID int                  `json:"transaction_id"`
+	Transaction   *EnhancedTransaction `json:"transaction,omitempty"`
+	Message       string               `json:"message,omitempty"`
+	Replayed      bool                 `json:"-"` // Returned for a repeated idempotency key
 }
 
 // Payment method save request
@@ -336,14 +368,45 @@ type SavePaymentMethodResponse struct {
 // Job payment summary
 type JobPaymentSummary struct {
 	JobID            int     `json:"job_id"`
-	TotalAuthorized  float64 `json:"total_authorized"`
-	TotalCaptured    float64 `json:"total_captured"`
-	TotalRefunded    float64 `json:"total_refunded"`
-	PlatformFees     float64 `json:"platform_fees"`
-	WorkerPayment    float64 `json:"worker_payment"`
+	TotalAuthorized  Money   `json:"total_authorized"`
+	TotalCaptured    Money   `json:"total_captured"`
+	TotalRefunded    Money   `json:"total_refunded"`
+	PlatformFees     Money   `json:"platform_fees"`
+	WorkerPayment    Money   `json:"worker_payment"` // Includes tips
+	TotalTips        Money   `json:"total_tips"`
 	EscrowStatus     string  `json:"escrow_status"` // held, released, none
 }
 
+// PriceBreakdown itemizes what a consumer pays for a job before they authorize
+// payment. Labor, PlatformFee and ProcessingFee add up to the job price; Total is
+// the price less Credits plus Tax, and is exactly the amount authorization charges.
+type PriceBreakdown struct {
+	JobID          int                 `json:"job_id"`
+	Currency       string              `json:"currency"`
+	Labor          Money               `json:"labor"` // Paid to the worker
+	PlatformFee    Money               `json:"platform_fee"`
+	ProcessingFee  Money               `json:"processing_fee"`
+	Subtotal       Money               `json:"subtotal"` // The job price
+	Credits        Money               `json:"credits"`  // Account credit applied, capped at the subtotal
+	TaxRatePercent float64             `json:"tax_rate_percent"`
+	Tax            Money               `json:"tax"`
+	Total          Money               `json:"total"`
+	Provider       string              `json:"provider"`
+	FeeRuleID      *int                `json:"fee_rule_id,omitempty"` // Fee rule that set the platform fee, if any
+	Display        *CurrencyConversion `json:"display,omitempty"`     // With ?display_currency=; the charge is still in Currency
+}
+
+// CurrencyConversion shows a price in another currency for display. Payments are
+// always taken in the job's currency.
+type CurrencyConversion struct {
+	Currency string  `json:"currency"`
+	Rate     float64 `json:"rate"` // Units of Currency per unit of the job's currency
+	Subtotal Money   `json:"subtotal"`
+	Credits  Money   `json:"credits"`
+	Tax      Money   `json:"tax"`
+	Total    Money   `json:"total"`
+}
+
 // ==============================================
 // JSONB TYPE FOR POSTGRES
 // ==============================================
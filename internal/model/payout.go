@@ -0,0 +1,77 @@
+package model
+
+import (
+	"time"
+)
+
+// Settlement batch statuses
+const (
+	SettlementBatchPending    = "pending"    // Created; payouts not yet sent
+	SettlementBatchProcessed  = "processed"  // Every payout was sent or handed off
+	SettlementBatchFailed     = "failed"     // At least one payout failed and can be retried
+	SettlementBatchReconciled = "reconciled" // Totals checked against the batch's transactions
+)
+
+// Worker payout statuses
+const (
+	PayoutStatusPending = "pending"
+	PayoutStatusPaid    = "paid"
+	PayoutStatusFailed  = "failed"
+	PayoutStatusManual  = "manual" // The provider cannot pay workers; settled outside the platform
+)
+
+// SettlementBatch groups captured job payments into one payout run
+type SettlementBatch struct {
+	ID               int            `json:"id" db:"id"`
+	UUID             string         `json:"uuid" db:"uuid"`
+	BatchDate        time.Time      `json:"batch_date" db:"batch_date"`
+	BatchReference   *string        `json:"batch_reference" db:"batch_reference"`
+	PaymentProvider  string         `json:"payment_provider" db:"payment_provider"`
+	CutoffAt         *time.Time     `json:"cutoff_at" db:"cutoff_at"`
+	TotalAmount      float64        `json:"total_amount" db:"total_amount"`
+	TransactionCount int            `json:"transaction_count" db:"transaction_count"`
+	PayoutCount      int            `json:"payout_count" db:"payout_count"`
+	Status           string         `json:"status" db:"status"`
+	ProcessedAt      *time.Time     `json:"processed_at" db:"processed_at"`
+	ReconciledAt     *time.Time     `json:"reconciled_at" db:"reconciled_at"`
+	Notes            *string        `json:"notes" db:"notes"`
+	CreatedAt        time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at" db:"updated_at"`
+	Payouts          []WorkerPayout `json:"payouts,omitempty"`
+}
+
+// WorkerPayout is one worker's earnings in a settlement batch
+type WorkerPayout struct {
+	ID                int        `json:"id" db:"id"`
+	UUID              string     `json:"uuid" db:"uuid"`
+	SettlementBatchID int        `json:"settlement_batch_id" db:"settlement_batch_id"`
+	GigWorkerID       int        `json:"gig_worker_id" db:"gig_worker_id"`
+	Amount            float64    `json:"amount" db:"amount"`
+	PaidAmount        *float64   `json:"paid_amount" db:"paid_amount"`
+	Currency          string     `json:"currency" db:"currency"`
+	TransactionCount  int        `json:"transaction_count" db:"transaction_count"`
+	Status            string     `json:"status" db:"status"`
+	PaymentProvider   string     `json:"payment_provider" db:"payment_provider"`
+	ProviderPayoutID  *string    `json:"provider_payout_id" db:"provider_payout_id"`
+	FailureReason     *string    `json:"failure_reason" db:"failure_reason"`
+	Attempts          int        `json:"attempts" db:"attempts"`
+	PaidAt            *time.Time `json:"paid_at" db:"paid_at"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// SettlementBatchRequest triggers a settlement batch outside the schedule
+type SettlementBatchRequest struct {
+	Cutoff *time.Time `json:"cutoff,omitempty"` // Settle captures before this time; defaults to now
+}
+
+// SettlementReconciliation compares a batch's payouts with the transactions it settled
+type SettlementReconciliation struct {
+	SettlementBatchID int      `json:"settlement_batch_id"`
+	Reconciled        bool     `json:"reconciled"`
+	EarningsTotal     float64  `json:"earnings_total"` // Worker earnings of the batch's transactions
+	PayoutTotal       float64  `json:"payout_total"`
+	SettledTotal      float64  `json:"settled_total"` // Paid by the provider or handed off for manual payment
+	UnsettledPayouts  int      `json:"unsettled_payouts"`
+	Discrepancies     []string `json:"discrepancies"`
+}
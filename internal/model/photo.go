@@ -0,0 +1,65 @@
+package model
+
+import (
+	"time"
+)
+
+// Job photo stages
+const (
+	JobPhotoStagePosting = "posting" // The consumer shows the work needed
+	JobPhotoStageBefore  = "before"  // The worker records the state before starting
+	JobPhotoStageAfter   = "after"   // The worker shows the finished work
+)
+
+// MaxJobPhotos caps the photos on one job across all stages
+const MaxJobPhotos = 30
+
+// jobPhotoStatuses are the job statuses each photo stage can be uploaded in
+var jobPhotoStatuses = map[string][]string{
+	JobPhotoStagePosting: {"posted", "offer_sent", "accepted", "worker_assigned", "scheduled"},
+	JobPhotoStageBefore:  {"accepted", "worker_assigned", "scheduled", "in_progress"},
+	JobPhotoStageAfter:   {"in_progress", "completed", "paid", "review_pending"},
+}
+
+// JobPhotoStages lists the photo stages in the order they are taken
+var JobPhotoStages = []string{JobPhotoStagePosting, JobPhotoStageBefore, JobPhotoStageAfter}
+
+// JobPhotoAllowed reports whether a photo of stage may be added to a job in status.
+// Posting photos come from the consumer and before and after shots from the worker.
+func JobPhotoAllowed(stage, status string) bool {
+	for _, s := range jobPhotoStatuses[stage] {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// JobPhoto is a photo of a job. URLs are short-lived download links; they are only
+// set for photos that passed the malware scan.
+type JobPhoto struct {
+	ID           int       `json:"id" db:"id"`
+	UUID         string    `json:"uuid" db:"uuid"`
+	JobID        int       `json:"job_id" db:"job_id"`
+	Stage        string    `json:"stage" db:"stage"`
+	AttachmentID int       `json:"attachment_id" db:"attachment_id"`
+	UploadedBy   *int      `json:"uploaded_by" db:"uploaded_by"`
+	ContentType  string    `json:"content_type" db:"content_type"`
+	Width        *int      `json:"width,omitempty" db:"width"`
+	Height       *int      `json:"height,omitempty" db:"height"`
+	ScanStatus   string    `json:"scan_status" db:"scan_status"`
+	URL          *string   `json:"url,omitempty"`
+	ThumbnailURL *string   `json:"thumbnail_url,omitempty"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// Avatar is a user's profile photo
+type Avatar struct {
+	AttachmentID int     `json:"attachment_id"`
+	ContentType  string  `json:"content_type"`
+	Width        *int    `json:"width,omitempty"`
+	Height       *int    `json:"height,omitempty"`
+	ScanStatus   string  `json:"scan_status"`
+	URL          *string `json:"url,omitempty"`
+	ThumbnailURL *string `json:"thumbnail_url,omitempty"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import "testing"
+
+func TestJobPhotoAllowed(t *testing.T) {
+	tests := []struct {
+		stage  string
+		status string
+		want   bool
+	}{
+		{JobPhotoStagePosting, "posted", true},
+		{JobPhotoStagePosting, "in_progress", false},
+		{JobPhotoStageBefore, "scheduled", true},
+		{JobPhotoStageBefore, "completed", false},
+		{JobPhotoStageAfter, "completed", true},
+		{JobPhotoStageAfter, "posted", false},
+		{JobPhotoStageAfter, "cancelled", false},
+		{"during", "in_progress", false},
+	}
+
+	for _, tt := range tests {
+		if got := JobPhotoAllowed(tt.stage, tt.status); got != tt.want {
+			t.Errorf("JobPhotoAllowed(%q, %q) = %v, want %v", tt.stage, tt.status, got, tt.want)
+		}
+	}
+}
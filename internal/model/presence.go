@@ -0,0 +1,13 @@
+package model
+
+import (
+	"time"
+)
+
+// PresenceHeartbeat acknowledges a heartbeat from the worker app, with when to send the
+// next one
+type PresenceHeartbeat struct {
+	Presence             string    `json:"presence"`
+	LastSeenAt           time.Time `json:"last_seen_at"`
+	NextHeartbeatSeconds int       `json:"next_heartbeat_seconds"`
+}
@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+)
+
+// PublicStats are the marketplace-wide aggregates shown on the marketing site. They
+// contain no per-user, per-job or revenue figures.
+type PublicStats struct {
+	Month                  string    `json:"month"` // YYYY-MM, UTC
+	JobsCompletedThisMonth int       `json:"jobs_completed_this_month"`
+	AverageRating          *float64  `json:"average_rating"` // Public ratings of workers, null until there are enough
+	RatingCount            int       `json:"rating_count"`
+	ActiveMarkets          int       `json:"active_markets"`
+	GeneratedAt            time.Time `json:"generated_at"`
+}
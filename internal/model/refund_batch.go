@@ -0,0 +1,92 @@
+package model
+
+import (
+	"time"
+)
+
+// Refund batch statuses
+const (
+	RefundBatchPending             = "pending"               // Created; refunds not yet started
+	RefundBatchRunning             = "running"               // Refunds are being made
+	RefundBatchCompleted           = "completed"             // Every item was refunded or skipped
+	RefundBatchCompletedWithErrors = "completed_with_errors" // At least one refund failed and can be retried
+)
+
+// Refund batch item statuses
+const (
+	RefundItemPending  = "pending"
+	RefundItemRefunded = "refunded"
+	RefundItemFailed   = "failed"
+	RefundItemSkipped  = "skipped" // Not captured, or already refunded, when the batch was created
+)
+
+// MaxRefundBatchSize is the most payments one refund batch may refund
+const MaxRefundBatchSize = 1000
+
+// RefundBatch refunds many captured payments at once for incident response
+type RefundBatch struct {
+	ID             int                `json:"id" db:"id"`
+	UUID           string             `json:"uuid" db:"uuid"`
+	IdempotencyKey string             `json:"idempotency_key" db:"idempotency_key"`
+	Reason         string             `json:"reason" db:"reason"`
+	Currency       string             `json:"currency" db:"currency"`
+	Filter         *RefundBatchFilter `json:"filter,omitempty" db:"filter"` // Absent when given transaction IDs
+	Status         string             `json:"status" db:"status"`
+	WorkflowID     *string            `json:"workflow_id,omitempty" db:"workflow_id"`
+	CreatedBy      int                `json:"created_by" db:"created_by"`
+	Summary        RefundBatchSummary `json:"summary"`
+	StartedAt      *time.Time         `json:"started_at" db:"started_at"`
+	CompletedAt    *time.Time         `json:"completed_at" db:"completed_at"`
+	CreatedAt      time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at" db:"updated_at"`
+	Items          []RefundBatchItem  `json:"items,omitempty"`
+}
+
+// RefundBatchSummary counts a batch's items by status and totals their amounts
+type RefundBatchSummary struct {
+	Items          int   `json:"items"`
+	Pending        int   `json:"pending"`
+	Refunded       int   `json:"refunded"`
+	Failed         int   `json:"failed"`
+	Skipped        int   `json:"skipped"`
+	TotalAmount    Money `json:"total_amount"` // Of the items not skipped
+	RefundedAmount Money `json:"refunded_amount"`
+	FailedAmount   Money `json:"failed_amount"`
+}
+
+// RefundBatchItem is one payment in a refund batch and the result of refunding it
+type RefundBatchItem struct {
+	ID                  int        `json:"id" db:"id"`
+	BatchID             int        `json:"batch_id" db:"batch_id"`
+	TransactionID       int        `json:"transaction_id" db:"transaction_id"`
+	JobID               int        `json:"job_id" db:"job_id"`
+	ConsumerID          int        `json:"consumer_id" db:"consumer_id"`
+	Amount              Money      `json:"amount" db:"amount"`
+	Status              string     `json:"status" db:"status"`
+	RefundTransactionID *int       `json:"refund_transaction_id" db:"refund_transaction_id"`
+	Error               *string    `json:"error" db:"error"`
+	Attempts            int        `json:"attempts" db:"attempts"`
+	ProcessedAt         *time.Time `json:"processed_at" db:"processed_at"`
+	CreatedAt           time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// RefundBatchRequest creates a refund batch from either transaction IDs or a filter
+type RefundBatchRequest struct {
+	TransactionIDs []int              `json:"transaction_ids,omitempty"`
+	Filter         *RefundBatchFilter `json:"filter,omitempty"`
+	Reason         string             `json:"reason"`
+	IdempotencyKey string             `json:"-"`                 // From the Idempotency-Key header
+	DryRun         bool               `json:"dry_run,omitempty"` // List the payments that would be refunded without refunding them
+}
+
+// RefundBatchFilter selects captured, unrefunded job payments to refund. The capture
+// window is required so a filter can never select every payment.
+type RefundBatchFilter struct {
+	CapturedFrom    time.Time `json:"captured_from"`
+	CapturedTo      time.Time `json:"captured_to"`
+	PaymentProvider string    `json:"payment_provider,omitempty"`
+	Currency        string    `json:"currency,omitempty"`
+	ConsumerID      *int      `json:"consumer_id,omitempty"`
+	DuplicatesOnly  bool      `json:"duplicates_only,omitempty"` // Only payments for jobs with an earlier capture, e.g. double charges
+}
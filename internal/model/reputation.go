@@ -0,0 +1,57 @@
+package model
+
+import "time"
+
+// ReputationDocument is a user's rating history, exported so they can show it on
+// other platforms. Only public reviews are included, without reviewer names.
+type ReputationDocument struct {
+	Subject     ReputationSubject  `json:"subject"`
+	MemberSince time.Time          `json:"member_since"`
+	TenureDays  int                `json:"tenure_days"`
+	Ratings     ReputationRatings  `json:"ratings"`
+	Counts      ReputationCounts   `json:"counts"`
+	Reviews     []ReputationReview `json:"reviews"`
+	GeneratedAt time.Time          `json:"generated_at"`
+}
+
+// ReputationSubject identifies whose reputation a document describes
+type ReputationSubject struct {
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// ReputationRatings summarizes the public ratings a user has received. Distribution
+// counts ratings by star value, "1" to "5".
+type ReputationRatings struct {
+	Average      float64        `json:"average"`
+	Total        int            `json:"total"`
+	Distribution map[string]int `json:"distribution"`
+}
+
+// ReputationCounts are a user's activity totals
+type ReputationCounts struct {
+	JobsCompleted   int `json:"jobs_completed"`
+	ReviewsReceived int `json:"reviews_received"`
+	ReviewsGiven    int `json:"reviews_given"`
+}
+
+// ReputationReview is one public review a user received
+type ReputationReview struct {
+	Rating       int       `json:"rating"`
+	Text         *string   `json:"text,omitempty"`
+	ReviewerRole string    `json:"reviewer_role"`
+	JobCategory  *string   `json:"job_category,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ReputationExport is the response of GET /api/v1/users/me/reputation-export. Signature
+// is an EdDSA-signed JWT whose reputation claim is the same document; verify it with
+// the key KeyID from the public key set at PublicKeyURL and trust only the signed copy.
+type ReputationExport struct {
+	Reputation   ReputationDocument `json:"reputation"`
+	Signature    string             `json:"signature"`
+	Algorithm    string             `json:"algorithm"`
+	KeyID        string             `json:"key_id"`
+	PublicKeyURL string             `json:"public_key_url"`
+}
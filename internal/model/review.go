@@ -59,6 +59,20 @@ type ReviewStats struct {
 	LastReviewDate *time.Time `json:"last_review_date" db:"last_review_date"`
 }
 
+// PlatformReviewStats represents review statistics across the whole platform
+type PlatformReviewStats struct {
+	TotalReviews     int        `json:"total_reviews"`
+	AverageRating    float64    `json:"average_rating"`
+	Rating5Count     int        `json:"rating_5_count"`
+	Rating4Count     int        `json:"rating_4_count"`
+	Rating3Count     int        `json:"rating_3_count"`
+	Rating2Count     int        `json:"rating_2_count"`
+	Rating1Count     int        `json:"rating_1_count"`
+	ReviewedUsers    int        `json:"reviewed_users"`
+	LatestReviewDate *time.Time `json:"latest_review_date"`
+	FirstReviewDate  *time.Time `json:"first_review_date"`
+}
+
 // ReviewFilters represents filtering options for review queries
 type ReviewFilters struct {
 	UserID        *int    `json:"user_id"`
@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+)
+
+// UserSession is a login on one device. Its refresh token is rotated on every use.
+type UserSession struct {
+	ID         string    `json:"id" db:"uuid"`
+	UserAgent  *string   `json:"user_agent,omitempty" db:"user_agent"`
+	IPAddress  *string   `json:"ip_address,omitempty" db:"ip_address"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at" db:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at" db:"expires_at"`
+	Current    bool      `json:"current"` // The session making the request
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+)
+
+// Shadow evaluation kinds
+const (
+	ShadowKindPricing  = "pricing"
+	ShadowKindMatching = "matching"
+)
+
+// ShadowReport compares candidate algorithms with production over a time window
+type ShadowReport struct {
+	Kind       string                  `json:"kind"`
+	From       time.Time               `json:"from"`
+	To         time.Time               `json:"to"`
+	Candidates []ShadowCandidateReport `json:"candidates"`
+}
+
+// ShadowCandidateReport summarizes one candidate's shadow evaluations. The price
+// fields are only set for pricing and the no-match counts only for matching.
+type ShadowCandidateReport struct {
+	Candidate     string  `json:"candidate"`
+	Production    string  `json:"production"`
+	Evaluations   int     `json:"evaluations"`
+	Errors        int     `json:"errors"`
+	Agreements    int     `json:"agreements"`
+	AgreementRate float64 `json:"agreement_rate"`
+
+	// Pricing: candidate minus production price
+	MeanPriceDelta      float64 `json:"mean_price_delta,omitempty"`
+	MeanAbsPercentDelta float64 `json:"mean_abs_percent_delta,omitempty"`
+	P95AbsPercentDelta  float64 `json:"p95_abs_percent_delta,omitempty"`
+	PricedHigher        int     `json:"priced_higher,omitempty"`
+	PricedLower         int     `json:"priced_lower,omitempty"`
+
+	// Matching: jobs where one side found no suitable worker
+	CandidateNoMatch  int `json:"candidate_no_match,omitempty"`
+	ProductionNoMatch int `json:"production_no_match,omitempty"`
+
+	MeanLatencyMicros int64 `json:"mean_latency_micros"`
+}
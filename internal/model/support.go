@@ -0,0 +1,61 @@
+package model
+
+import (
+	"time"
+)
+
+// Support ticket statuses
+const (
+	TicketStatusOpen       = "open"
+	TicketStatusInProgress = "in_progress"
+	TicketStatusResolved   = "resolved"
+)
+
+// JobMessage is a message in a job's in-app thread
+type JobMessage struct {
+	ID         int       `json:"id" db:"id"`
+	UUID       string    `json:"uuid" db:"uuid"`
+	JobID      int       `json:"job_id" db:"job_id"`
+	SenderID   int       `json:"sender_id" db:"sender_id"`
+	SenderName string    `json:"sender_name"`
+	Body       string    `json:"body" db:"body"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// JobMessageRequest represents a new message in a job thread
+type JobMessageRequest struct {
+	Body string `json:"body" validate:"required,max=5000"`
+}
+
+// SupportTicket is a job thread escalated to the support team
+type SupportTicket struct {
+	ID              int          `json:"id" db:"id"`
+	UUID            string       `json:"uuid" db:"uuid"`
+	JobID           int          `json:"job_id" db:"job_id"`
+	OpenedBy        int          `json:"opened_by" db:"opened_by"`
+	Subject         string       `json:"subject" db:"subject"`
+	Description     string       `json:"description" db:"description"`
+	Status          string       `json:"status" db:"status"`
+	ThreadSnapshot  []JobMessage `json:"thread_snapshot,omitempty" db:"thread_snapshot"`
+	WorkflowPaused  bool         `json:"workflow_paused" db:"workflow_paused"`
+	OpsNotifiedAt   *time.Time   `json:"ops_notified_at" db:"ops_notified_at"`
+	AssignedTo      *int         `json:"assigned_to" db:"assigned_to"`
+	ResolvedBy      *int         `json:"resolved_by" db:"resolved_by"`
+	ResolvedAt      *time.Time   `json:"resolved_at" db:"resolved_at"`
+	ResolutionNotes *string      `json:"resolution_notes" db:"resolution_notes"`
+	CreatedAt       time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// EscalationRequest represents a participant escalating a job thread to support
+type EscalationRequest struct {
+	Subject     string `json:"subject" validate:"required,max=255"`
+	Description string `json:"description" validate:"required,max=5000"`
+	PauseJob    bool   `json:"pause_job"`
+}
+
+// SupportTicketUpdateRequest represents a support agent's update to a ticket
+type SupportTicketUpdateRequest struct {
+	Status          string  `json:"status" validate:"required,oneof=in_progress resolved"`
+	ResolutionNotes *string `json:"resolution_notes" validate:"omitempty,max=5000"`
+}
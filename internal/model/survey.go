@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+)
+
+// JobSurvey is the one-question satisfaction survey sent to a consumer when their job
+// closes
+type JobSurvey struct {
+	ID          int        `json:"id"`
+	JobID       int        `json:"job_id"`
+	JobTitle    string     `json:"job_title"`
+	WorkerID    *int       `json:"gig_worker_id,omitempty"`
+	SurveyType  string     `json:"survey_type"` // csat or nps
+	Question    string     `json:"question"`
+	MinScore    int        `json:"min_score"`
+	MaxScore    int        `json:"max_score"`
+	Score       *int       `json:"score"`
+	Comment     *string    `json:"comment,omitempty"`
+	SentAt      time.Time  `json:"sent_at"`
+	RespondedAt *time.Time `json:"responded_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+}
+
+// JobSurveyResponse is a consumer's answer to a job survey
+type JobSurveyResponse struct {
+	Score   *int   `json:"score"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// SurveyScores aggregates survey answers. CSAT is the percentage of CSAT answers scoring
+// 4 or 5; NPS is the percentage of promoters (9-10) minus detractors (0-6). Scores are
+// null without answers of their type.
+type SurveyScores struct {
+	Responses     int      `json:"responses"`
+	CSATResponses int      `json:"csat_responses"`
+	CSAT          *float64 `json:"csat"`
+	AverageCSAT   *float64 `json:"average_csat"`
+	NPSResponses  int      `json:"nps_responses"`
+	NPS           *float64 `json:"nps"`
+	Promoters     int      `json:"promoters"`
+	Detractors    int      `json:"detractors"`
+}
+
+// SurveySegment is the survey scores for one category or market
+type SurveySegment struct {
+	Segment string `json:"segment"`
+	SurveyScores
+}
+
+// SurveyReport is the survey scores for surveys sent in a time window
+type SurveyReport struct {
+	From         time.Time       `json:"from"`
+	To           time.Time       `json:"to"`
+	Sent         int             `json:"sent"`
+	ResponseRate float64         `json:"response_rate"` // Share of sent surveys answered
+	Overall      SurveyScores    `json:"overall"`
+	ByCategory   []SurveySegment `json:"by_category"`
+	ByMarket     []SurveySegment `json:"by_market"`
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"time"
+)
+
+// Market represents a geographic market that can be launched
+type Market struct {
+	ID         int        `json:"id" db:"id"`
+	UUID       string     `json:"uuid" db:"uuid"`
+	Slug       string     `json:"slug" db:"slug"`
+	Name       string     `json:"name" db:"name"`
+	City       *string    `json:"city" db:"city"`
+	State      *string    `json:"state" db:"state"`
+	IsLive     bool       `json:"is_live" db:"is_live"`
+	LaunchedAt *time.Time `json:"launched_at" db:"launched_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// WaitlistSignup represents an interested user in a market that is not live yet
+type WaitlistSignup struct {
+	ID         int        `json:"id" db:"id"`
+	UUID       string     `json:"uuid" db:"uuid"`
+	Email      string     `json:"email" db:"email"`
+	Role       string     `json:"role" db:"role"`
+	MarketID   *int       `json:"market_id" db:"market_id"`
+	MarketName *string    `json:"market_name,omitempty" db:"market_name"`
+	City       *string    `json:"city" db:"city"`
+	State      *string    `json:"state" db:"state"`
+	PostalCode *string    `json:"postal_code" db:"postal_code"`
+	Latitude   *float64   `json:"latitude" db:"latitude"`
+	Longitude  *float64   `json:"longitude" db:"longitude"`
+	InvitedAt  *time.Time `json:"invited_at" db:"invited_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// WaitlistSignupRequest represents the request payload for joining the waitlist
+type WaitlistSignupRequest struct {
+	Email      string   `json:"email" validate:"required,email"`
+	Role       string   `json:"role" validate:"omitempty,oneof=consumer gig_worker"`
+	Market     string   `json:"market,omitempty"`
+	City       string   `json:"city,omitempty"`
+	State      string   `json:"state,omitempty"`
+	PostalCode string   `json:"postal_code,omitempty"`
+	Latitude   *float64 `json:"latitude,omitempty"`
+	Longitude  *float64 `json:"longitude,omitempty"`
+}
+
+// MarketDemand is a market with its waitlist demand, as listed for ops
+type MarketDemand struct {
+	Market
+	WaitlistCount  int `json:"waitlist_count"`
+	PendingInvites int `json:"pending_invites"`
+}
+
+// MarketLaunchResponse summarizes the result of flipping a market to live
+type MarketLaunchResponse struct {
+	Market         Market `json:"market"`
+	PendingInvites int    `json:"pending_invites"`
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"time"
+)
+
+// Reschedule proposal statuses
+const (
+	RescheduleStatusPending    = "pending"
+	RescheduleStatusAccepted   = "accepted"
+	RescheduleStatusDeclined   = "declined"
+	RescheduleStatusSuperseded = "superseded"
+)
+
+// WeatherAdvisory is the latest forecast assessment for a scheduled outdoor job
+type WeatherAdvisory struct {
+	JobID            int        `json:"job_id" db:"job_id"`
+	Level            string     `json:"level" db:"level"` // clear, advisory, severe
+	Summary          string     `json:"summary" db:"summary"`
+	Reasons          []string   `json:"reasons,omitempty" db:"reasons"`
+	SuggestedStart   *time.Time `json:"suggested_start,omitempty" db:"suggested_start"`
+	CheckedAt        time.Time  `json:"checked_at" db:"checked_at"`
+	SevereNotifiedAt *time.Time `json:"severe_notified_at,omitempty" db:"severe_notified_at"`
+}
+
+// RescheduleProposal is a request by one party to move a scheduled job
+type RescheduleProposal struct {
+	ID            int        `json:"id" db:"id"`
+	UUID          string     `json:"uuid" db:"uuid"`
+	JobID         int        `json:"job_id" db:"job_id"`
+	ProposedBy    int        `json:"proposed_by" db:"proposed_by"`
+	Reason        string     `json:"reason" db:"reason"`
+	OriginalStart *time.Time `json:"original_start" db:"original_start"`
+	OriginalEnd   *time.Time `json:"original_end" db:"original_end"`
+	ProposedStart time.Time  `json:"proposed_start" db:"proposed_start"`
+	ProposedEnd   time.Time  `json:"proposed_end" db:"proposed_end"`
+	Status        string     `json:"status" db:"status"`
+	RespondedBy   *int       `json:"responded_by" db:"responded_by"`
+	RespondedAt   *time.Time `json:"responded_at" db:"responded_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// RescheduleProposalRequest represents the payload for proposing a new time.
+// When ProposedStart is omitted the weather advisory's suggested start is used.
+type RescheduleProposalRequest struct {
+	ProposedStart *time.Time `json:"proposed_start"`
+	Reason        string     `json:"reason"`
+}
+
+// RescheduleResponseRequest represents the other party's answer to a proposal
+type RescheduleResponseRequest struct {
+	Accept bool `json:"accept"`
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"time"
+)
+
+// Worker application statuses
+const (
+	ApplicationStatusSubmitted       = "submitted"
+	ApplicationStatusDocsPending     = "docs_pending"
+	ApplicationStatusBackgroundCheck = "background_check"
+	ApplicationStatusApproved        = "approved"
+	ApplicationStatusDenied          = "denied"
+)
+
+// workerApplicationTransitions lists the screening steps each status may move to.
+// Approved and denied are final; a denied applicant may submit a new application.
+var workerApplicationTransitions = map[string][]string{
+	ApplicationStatusSubmitted:       {ApplicationStatusDocsPending, ApplicationStatusBackgroundCheck, ApplicationStatusDenied},
+	ApplicationStatusDocsPending:     {ApplicationStatusBackgroundCheck, ApplicationStatusDenied},
+	ApplicationStatusBackgroundCheck: {ApplicationStatusApproved, ApplicationStatusDenied},
+}
+
+// CanTransitionWorkerApplication reports whether screening may move an application
+// from one status to another
+func CanTransitionWorkerApplication(from, to string) bool {
+	for _, next := range workerApplicationTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkerApplication is a user's application to become a gig worker
+type WorkerApplication struct {
+	ID                 int                      `json:"id" db:"id"`
+	UUID               string                   `json:"uuid" db:"uuid"`
+	UserID             int                      `json:"user_id" db:"user_id"`
+	ApplicantName      string                   `json:"applicant_name" db:"applicant_name"`
+	ApplicantEmail     string                   `json:"applicant_email" db:"applicant_email"`
+	Status             string                   `json:"status" db:"status"`
+	Address            string                   `json:"address" db:"address"`
+	Bio                *string                  `json:"bio" db:"bio"`
+	Skills             *string                  `json:"skills" db:"skills"`
+	HourlyRate         *float64                 `json:"hourly_rate" db:"hourly_rate"`
+	ExperienceYears    *int                     `json:"experience_years" db:"experience_years"`
+	ServiceRadiusMiles *float64                 `json:"service_radius_miles" db:"service_radius_miles"`
+	AvailabilityNotes  *string                  `json:"availability_notes" db:"availability_notes"`
+	ReviewerID         *int                     `json:"reviewer_id" db:"reviewer_id"`
+	DecisionNote       *string                  `json:"decision_note" db:"decision_note"`
+	SubmittedAt        time.Time                `json:"submitted_at" db:"submitted_at"`
+	DecidedAt          *time.Time               `json:"decided_at" db:"decided_at"`
+	UpdatedAt          time.Time                `json:"updated_at" db:"updated_at"`
+	History            []WorkerApplicationEvent `json:"history,omitempty"`
+}
+
+// WorkerApplicationEvent is one screening step of a worker application
+type WorkerApplicationEvent struct {
+	FromStatus *string   `json:"from_status" db:"from_status"`
+	ToStatus   string    `json:"to_status" db:"to_status"`
+	ActorID    int       `json:"actor_id" db:"actor_id"`
+	Note       *string   `json:"note" db:"note"`
+	OccurredAt time.Time `json:"occurred_at" db:"occurred_at"`
+}
+
+// WorkerApplicationRequest represents the payload for applying to become a gig worker.
+// Emergency contact details are stored encrypted and never returned.
+type WorkerApplicationRequest struct {
+	Address                      string   `json:"address" validate:"required"`
+	Bio                          *string  `json:"bio" validate:"omitempty,max=5000"`
+	Skills                       *string  `json:"skills" validate:"omitempty,max=1000"` // Comma-separated, e.g. "cleaning,pet-care"
+	HourlyRate                   *float64 `json:"hourly_rate" validate:"omitempty,gt=0"`
+	ExperienceYears              *int     `json:"experience_years" validate:"omitempty,min=0,max=50"`
+	ServiceRadiusMiles           *float64 `json:"service_radius_miles" validate:"omitempty,min=1,max=100"`
+	AvailabilityNotes            *string  `json:"availability_notes" validate:"omitempty,max=1000"`
+	EmergencyContactName         string   `json:"emergency_contact_name,omitempty"`
+	EmergencyContactPhone        string   `json:"emergency_contact_phone,omitempty"`
+	EmergencyContactRelationship string   `json:"emergency_contact_relationship,omitempty"`
+}
+
+// WorkerApplicationStatusRequest represents a screening decision on an application
+type WorkerApplicationStatusRequest struct {
+	Status string  `json:"status" validate:"required,oneof=docs_pending background_check approved denied"`
+	Note   *string `json:"note" validate:"omitempty,max=2000"` // Required when denying
+}
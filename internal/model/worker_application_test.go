@@ -0,0 +1,27 @@
+package model
+
+import "testing"
+
+func TestCanTransitionWorkerApplication(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{ApplicationStatusSubmitted, ApplicationStatusDocsPending, true},
+		{ApplicationStatusSubmitted, ApplicationStatusBackgroundCheck, true},
+		{ApplicationStatusSubmitted, ApplicationStatusApproved, false},
+		{ApplicationStatusDocsPending, ApplicationStatusBackgroundCheck, true},
+		{ApplicationStatusDocsPending, ApplicationStatusSubmitted, false},
+		{ApplicationStatusBackgroundCheck, ApplicationStatusApproved, true},
+		{ApplicationStatusBackgroundCheck, ApplicationStatusDenied, true},
+		{ApplicationStatusApproved, ApplicationStatusDenied, false},
+		{ApplicationStatusDenied, ApplicationStatusSubmitted, false},
+		{"unknown", ApplicationStatusApproved, false},
+	}
+
+	for _, tt := range tests {
+		if got := CanTransitionWorkerApplication(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransitionWorkerApplication(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package model
+
+import (
+	"time"
+)
+
+// Worker verification document types
+const (
+	DocumentTypeGovernmentID = "government_id"
+	DocumentTypeLicense      = "license"
+	DocumentTypeInsurance    = "insurance"
+)
+
+// DocumentTypeNames are how document types read in notifications
+var DocumentTypeNames = map[string]string{
+	DocumentTypeGovernmentID: "government ID",
+	DocumentTypeLicense:      "license",
+	DocumentTypeInsurance:    "insurance certificate",
+}
+
+// Worker verification document statuses. Approved documents become expired the day
+// after their expiry date.
+const (
+	DocumentStatusPending  = "pending"
+	DocumentStatusApproved = "approved"
+	DocumentStatusRejected = "rejected"
+	DocumentStatusExpired  = "expired"
+)
+
+// DocumentExpiryReminderDays are how many days before an approved document expires
+// its worker is reminded, earliest first
+var DocumentExpiryReminderDays = []int{30, 7}
+
+// DocumentExpiryReminderDue reports which reminder a document expiring in daysLeft
+// days is due, given the lead time of the last reminder sent (nil before the first).
+// Only the nearest reminder is sent, so a document approved a week before it expires
+// gets the 7-day reminder alone.
+func DocumentExpiryReminderDue(daysLeft int, lastReminderDays *int) (int, bool) {
+	if daysLeft < 0 {
+		return 0, false
+	}
+	due := 0
+	for _, days := range DocumentExpiryReminderDays {
+		if daysLeft <= days {
+			due = days
+		}
+	}
+	if due == 0 || (lastReminderDays != nil && *lastReminderDays <= due) {
+		return 0, false
+	}
+	return due, true
+}
+
+// WorkerDocument is an ID, license or insurance certificate a gig worker or applicant
+// uploaded for verification. The file is an attachment, downloaded by admins through
+// GET /api/v1/attachments/{id}.
+type WorkerDocument struct {
+	ID           int        `json:"id" db:"id"`
+	UUID         string     `json:"uuid" db:"uuid"`
+	WorkerID     int        `json:"worker_id" db:"worker_id"`
+	WorkerName   string     `json:"worker_name" db:"worker_name"`
+	DocumentType string     `json:"document_type" db:"document_type"`
+	AttachmentID int        `json:"attachment_id" db:"attachment_id"`
+	ContentType  string     `json:"content_type" db:"content_type"`
+	SizeBytes    int64      `json:"size_bytes" db:"size_bytes"`
+	ScanStatus   string     `json:"scan_status" db:"scan_status"`
+	ExpiresOn    *string    `json:"expires_on" db:"expires_on"` // YYYY-MM-DD
+	Status       string     `json:"status" db:"status"`
+	ReviewerID   *int       `json:"reviewer_id" db:"reviewer_id"`
+	ReviewNote   *string    `json:"review_note" db:"review_note"`
+	ReviewedAt   *time.Time `json:"reviewed_at" db:"reviewed_at"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+}
+
+// WorkerDocumentUploadRequest asks for a signed URL to upload a document file to
+type WorkerDocumentUploadRequest struct {
+	DocumentType string `json:"document_type" validate:"required"`
+	ContentType  string `json:"content_type" validate:"required"` // application/pdf, image/jpeg or image/png
+	SizeBytes    int64  `json:"size_bytes" validate:"required,gt=0"`
+}
+
+// WorkerDocumentUpload is where to PUT a document file before submitting it
+type WorkerDocumentUpload struct {
+	UploadURL string            `json:"upload_url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"`
+	ObjectKey string            `json:"object_key"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// WorkerDocumentRequest submits an uploaded file for review
+type WorkerDocumentRequest struct {
+	DocumentType string  `json:"document_type" validate:"required"`
+	ObjectKey    string  `json:"object_key" validate:"required"`
+	ExpiresOn    *string `json:"expires_on,omitempty"` // YYYY-MM-DD; required for licenses and insurance
+}
+
+// WorkerDocumentReviewRequest approves or rejects a document
+type WorkerDocumentReviewRequest struct {
+	Status string  `json:"status" validate:"required,oneof=approved rejected"`
+	Note   *string `json:"note,omitempty"` // Required when rejecting; shown to the worker
+}
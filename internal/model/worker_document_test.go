@@ -0,0 +1,31 @@
+package model
+
+import "testing"
+
+func TestDocumentExpiryReminderDue(t *testing.T) {
+	thirty, seven := 30, 7
+	tests := []struct {
+		name     string
+		daysLeft int
+		last     *int
+		want     int
+		wantDue  bool
+	}{
+		{name: "not yet", daysLeft: 31},
+		{name: "first reminder", daysLeft: 30, want: 30, wantDue: true},
+		{name: "already reminded", daysLeft: 20, last: &thirty},
+		{name: "final reminder", daysLeft: 7, last: &thirty, want: 7, wantDue: true},
+		{name: "approved late skips to the final reminder", daysLeft: 5, want: 7, wantDue: true},
+		{name: "final reminder sent", daysLeft: 1, last: &seven},
+		{name: "expired", daysLeft: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, due := DocumentExpiryReminderDue(tt.daysLeft, tt.last)
+			if got != tt.want || due != tt.wantDue {
+				t.Errorf("DocumentExpiryReminderDue(%d) = %d, %v; want %d, %v", tt.daysLeft, got, due, tt.want, tt.wantDue)
+			}
+		})
+	}
+}
@@ -0,0 +1,384 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"app/internal/clock"
+	"app/internal/model"
+)
+
+// MaxDeliveryAttempts is how many times a notification is sent before it is marked failed
+const MaxDeliveryAttempts = 5
+
+// deliveryBackoff is the wait before each retry of a transient failure
+var deliveryBackoff = []time.Duration{time.Minute, 5 * time.Minute, 30 * time.Minute, 2 * time.Hour}
+
+// deliveryClaim is how long a retry pass holds a claimed delivery before another pass
+// may take it, in case the worker dies mid-send
+const deliveryClaim = 10 * time.Minute
+
+// Sender hands a stored provider request to the provider, returning the provider's
+// message ID. Failures worth retrying are wrapped in TransientError.
+type Sender interface {
+	Provider() string
+	Deliver(ctx context.Context, payload []byte) (string, error)
+}
+
+// FailoverSender is a Sender that may hand a message to a secondary provider when its
+// primary is unavailable. DeliverVia also returns the provider that accepted it, which
+// is recorded so the provider's receipts match the delivery.
+type FailoverSender interface {
+	Sender
+	DeliverVia(ctx context.Context, payload []byte) (provider, messageID string, err error)
+}
+
+// TransientError marks a send that failed for a reason that may clear up, such as a
+// timeout, rate limit or provider outage
+type TransientError struct {
+	Err error
+}
+
+func (e *TransientError) Error() string { return e.Err.Error() }
+func (e *TransientError) Unwrap() error { return e.Err }
+
+// IsTransient reports whether err is worth retrying
+func IsTransient(err error) bool {
+	var transient *TransientError
+	return errors.As(err, &transient)
+}
+
+// Message is one notification to send. UserID may be nil for email, in which case the
+// account with the recipient address is used, if any.
+type Message struct {
+	UserID    *int
+	Channel   string
+	Kind      string
+	Recipient string
+	Subject   string
+	Payload   []byte
+}
+
+// Receipt is a provider's report on a message it accepted
+type Receipt struct {
+	Event      string // Provider event name, e.g. delivered, deferred, bounce, dropped
+	Detail     string
+	OccurredAt time.Time
+}
+
+// Ledger records every notification sent and each attempt to deliver it, and retries
+// transient failures
+type Ledger struct {
+	db    *sql.DB
+	clock clock.Clock // Schedules retries and dates provider events without a timestamp
+}
+
+// NewLedger creates a delivery ledger
+func NewLedger(db *sql.DB) *Ledger {
+	return &Ledger{db: db, clock: clock.System}
+}
+
+// Send records m and makes the first attempt to deliver it. A transient failure is
+// left for RetryDue and reported as success; other failures are returned.
+func (l *Ledger) Send(ctx context.Context, m Message, sender Sender) error {
+	var id int
+	err := l.db.QueryRowContext(ctx, `
+		INSERT INTO notification_deliveries (user_id, channel, provider, kind, recipient, subject, payload)
+		VALUES (
+			COALESCE($1, CASE WHEN $2 = 'email' THEN (SELECT id FROM people WHERE LOWER(email) = LOWER($5) LIMIT 1) END),
+			$2, $3, $4, $5, NULLIF($6, ''), $7
+		)
+		RETURNING id
+	`, m.UserID, m.Channel, sender.Provider(), m.Kind, m.Recipient, m.Subject, m.Payload).Scan(&id)
+	if err != nil {
+		// The ledger is bookkeeping; never hold up the message itself
+		slog.ErrorContext(ctx, "Failed to record notification delivery", "channel", m.Channel, "kind", m.Kind, "error", err)
+		_, sendErr := sender.Deliver(ctx, m.Payload)
+		return sendErr
+	}
+
+	err = l.attempt(ctx, id, 0, m.Payload, sender)
+	if IsTransient(err) {
+		slog.WarnContext(ctx, "Notification delivery failed, will retry", "delivery_id", id, "kind", m.Kind, "error", err)
+		return nil
+	}
+	return err
+}
+
+// attempt sends the payload of delivery id and records the outcome
+func (l *Ledger) attempt(ctx context.Context, id, attempts int, payload []byte, sender Sender) error {
+	var provider, messageID string
+	var sendErr error
+	if failover, ok := sender.(FailoverSender); ok {
+		provider, messageID, sendErr = failover.DeliverVia(ctx, payload)
+	} else {
+		messageID, sendErr = sender.Deliver(ctx, payload)
+	}
+	attempts++
+	status, next := nextDeliveryState(sendErr, attempts, l.clock.Now())
+
+	event := "attempt"
+	var lastError sql.NullString
+	if sendErr != nil {
+		event = "attempt_failed"
+		lastError = sql.NullString{String: sendErr.Error(), Valid: true}
+	}
+
+	// Keep the payload only while a retry is pending
+	_, err := l.db.ExecContext(ctx, `
+		UPDATE notification_deliveries SET
+			status = $2,
+			attempts = $3,
+			provider = CASE WHEN $2 = 'sent' AND $7 <> '' THEN $7 ELSE provider END,
+			provider_message_id = COALESCE(NULLIF($4, ''), provider_message_id),
+			last_error = $5,
+			next_attempt_at = $6,
+			sent_at = CASE WHEN $2 = 'sent' THEN NOW() ELSE sent_at END,
+			payload = CASE WHEN $2 = 'retrying' THEN payload END
+		WHERE id = $1
+	`, id, status, attempts, messageID, lastError, next, provider)
+	if err == nil {
+		_, err = l.db.ExecContext(ctx, `
+			INSERT INTO notification_delivery_events (delivery_id, event, detail)
+			VALUES ($1, $2, $3)
+		`, id, event, lastError)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to record notification delivery attempt", "delivery_id", id, "error", err)
+	}
+	return sendErr
+}
+
+// nextDeliveryState is the status after the given attempt, and when to retry if the
+// failure was transient and attempts remain
+func nextDeliveryState(sendErr error, attempts int, now time.Time) (string, *time.Time) {
+	switch {
+	case sendErr == nil:
+		return model.DeliveryStatusSent, nil
+	case !IsTransient(sendErr) || attempts >= MaxDeliveryAttempts:
+		return model.DeliveryStatusFailed, nil
+	}
+	wait := deliveryBackoff[len(deliveryBackoff)-1]
+	if attempts-1 < len(deliveryBackoff) {
+		wait = deliveryBackoff[attempts-1]
+	}
+	next := now.Add(wait)
+	return model.DeliveryStatusRetrying, &next
+}
+
+// RetryDue resends up to limit deliveries whose retry is due, using the sender for
+// each delivery's provider. It returns how many were retried and how many of those
+// were sent.
+func (l *Ledger) RetryDue(ctx context.Context, senders map[string]Sender, limit int) (int, int, error) {
+	// Claim the due rows so overlapping passes don't send the same message twice
+	rows, err := l.db.QueryContext(ctx, `
+		UPDATE notification_deliveries SET next_attempt_at = NOW() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id FROM notification_deliveries
+			WHERE status = 'retrying' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, provider, attempts, payload
+	`, limit, deliveryClaim.Seconds())
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to claim due deliveries: %w", err)
+	}
+
+	type due struct {
+		id       int
+		provider string
+		attempts int
+		payload  []byte
+	}
+	var claimed []due
+	for rows.Next() {
+		var d due
+		if err := rows.Scan(&d.id, &d.provider, &d.attempts, &d.payload); err != nil {
+			rows.Close()
+			return 0, 0, fmt.Errorf("failed to scan due delivery: %w", err)
+		}
+		claimed = append(claimed, d)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, 0, fmt.Errorf("failed to read due deliveries: %w", err)
+	}
+
+	retried, sent := 0, 0
+	for _, d := range claimed {
+		sender, ok := senders[d.provider]
+		if !ok {
+			// Not configured on this worker; the claim lapses and another pass retries it
+			slog.WarnContext(ctx, "No sender for notification provider", "delivery_id", d.id, "provider", d.provider)
+			continue
+		}
+		retried++
+		if err := l.attempt(ctx, d.id, d.attempts, d.payload, sender); err == nil {
+			sent++
+		}
+	}
+	return retried, sent, nil
+}
+
+// ApplyReceipt records a provider receipt against the delivery with the provider's
+// message ID. Returns false when no delivery has that ID.
+func (l *Ledger) ApplyReceipt(ctx context.Context, provider, messageID string, r Receipt) (bool, error) {
+	var id int
+	err := l.db.QueryRowContext(ctx, `
+		SELECT id FROM notification_deliveries
+		WHERE provider = $1 AND provider_message_id = $2
+		ORDER BY id DESC LIMIT 1
+	`, provider, messageID).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find delivery: %w", err)
+	}
+
+	occurredAt := r.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = l.clock.Now()
+	}
+	_, err = l.db.ExecContext(ctx, `
+		INSERT INTO notification_delivery_events (delivery_id, event, detail, occurred_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4)
+	`, id, r.Event, r.Detail, occurredAt)
+	if err != nil {
+		return true, fmt.Errorf("failed to record delivery receipt: %w", err)
+	}
+
+	status := receiptStatus(r.Event)
+	if status == "" {
+		return true, nil
+	}
+	// A bounce can follow a delivered receipt, but nothing moves a message back to delivered
+	_, err = l.db.ExecContext(ctx, `
+		UPDATE notification_deliveries SET
+			status = $2,
+			delivered_at = CASE WHEN $2 = 'delivered' THEN $3 ELSE delivered_at END,
+			last_error = CASE WHEN $2 = 'delivered' THEN last_error ELSE NULLIF($4, '') END
+		WHERE id = $1 AND (status = 'sent' OR (status = 'delivered' AND $2 <> 'delivered'))
+	`, id, status, occurredAt, r.Detail)
+	if err != nil {
+		return true, fmt.Errorf("failed to update delivery status: %w", err)
+	}
+	return true, nil
+}
+
+// receiptStatus maps a provider receipt event to the delivery status it sets, or ""
+// for events that don't change it (processed, deferred, open, click)
+func receiptStatus(event string) string {
+	switch event {
+	case "delivered":
+		return model.DeliveryStatusDelivered
+	case "bounce", "blocked":
+		return model.DeliveryStatusBounced
+	case "dropped":
+		return model.DeliveryStatusFailed
+	default:
+		return ""
+	}
+}
+
+// History returns a page of the user's deliveries, newest first, with their events,
+// and the total matching the filters. Empty channel and status match all.
+func (l *Ledger) History(ctx context.Context, userID int, channel, status string, limit, offset int) ([]model.NotificationDelivery, int, error) {
+	where := ` WHERE user_id = $1 AND ($2 = '' OR channel = $2) AND ($3 = '' OR status = $3)`
+	args := []any{userID, channel, status}
+
+	var total int
+	if err := l.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM notification_deliveries`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count deliveries: %w", err)
+	}
+
+	rows, err := l.db.QueryContext(ctx, `
+		SELECT id, uuid, user_id, channel, provider, kind, recipient, subject, status, provider_message_id,
+		       attempts, last_error, next_attempt_at, sent_at, delivered_at, created_at, updated_at
+		FROM notification_deliveries`+where+`
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4 OFFSET $5
+	`, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []model.NotificationDelivery{}
+	index := map[int]int{}
+	for rows.Next() {
+		var d model.NotificationDelivery
+		var user sql.NullInt64
+		var subject, messageID, lastError sql.NullString
+		var nextAttempt, sentAt, deliveredAt sql.NullTime
+		if err := rows.Scan(&d.ID, &d.UUID, &user, &d.Channel, &d.Provider, &d.Kind, &d.Recipient, &subject, &d.Status,
+			&messageID, &d.Attempts, &lastError, &nextAttempt, &sentAt, &deliveredAt, &d.CreatedAt, &d.UpdatedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan delivery: %w", err)
+		}
+		if user.Valid {
+			u := int(user.Int64)
+			d.UserID = &u
+		}
+		d.Subject = nullStringPtr(subject)
+		d.ProviderMessageID = nullStringPtr(messageID)
+		d.LastError = nullStringPtr(lastError)
+		d.NextAttemptAt = nullTimePtr(nextAttempt)
+		d.SentAt = nullTimePtr(sentAt)
+		d.DeliveredAt = nullTimePtr(deliveredAt)
+		d.Events = []model.NotificationDeliveryEvent{}
+
+		index[d.ID] = len(deliveries)
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to read deliveries: %w", err)
+	}
+	if len(deliveries) == 0 {
+		return deliveries, total, nil
+	}
+
+	events, err := l.db.QueryContext(ctx, `
+		SELECT delivery_id, event, detail, occurred_at
+		FROM notification_delivery_events
+		WHERE delivery_id IN (SELECT id FROM notification_deliveries`+where+` ORDER BY created_at DESC, id DESC LIMIT $4 OFFSET $5)
+		ORDER BY occurred_at, id
+	`, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query delivery events: %w", err)
+	}
+	defer events.Close()
+
+	for events.Next() {
+		var deliveryID int
+		var e model.NotificationDeliveryEvent
+		var detail sql.NullString
+		if err := events.Scan(&deliveryID, &e.Event, &detail, &e.OccurredAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan delivery event: %w", err)
+		}
+		e.Detail = nullStringPtr(detail)
+		if i, ok := index[deliveryID]; ok {
+			deliveries[i].Events = append(deliveries[i].Events, e)
+		}
+	}
+	return deliveries, total, events.Err()
+}
+
+func nullStringPtr(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -0,0 +1,60 @@
+package notifications
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"app/internal/model"
+)
+
+func TestNextDeliveryState(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	transient := &TransientError{Err: errors.New("email API returned status 503")}
+
+	tests := []struct {
+		name       string
+		err        error
+		attempts   int
+		wantStatus string
+		wantWait   time.Duration // 0 when no retry is scheduled
+	}{
+		{name: "sent", attempts: 1, wantStatus: model.DeliveryStatusSent},
+		{name: "permanent failure", err: errors.New("email API returned status 400"), attempts: 1, wantStatus: model.DeliveryStatusFailed},
+		{name: "first transient failure", err: transient, attempts: 1, wantStatus: model.DeliveryStatusRetrying, wantWait: time.Minute},
+		{name: "wrapped transient failure", err: fmt.Errorf("send: %w", transient), attempts: 2, wantStatus: model.DeliveryStatusRetrying, wantWait: 5 * time.Minute},
+		{name: "last retry", err: transient, attempts: MaxDeliveryAttempts - 1, wantStatus: model.DeliveryStatusRetrying, wantWait: 2 * time.Hour},
+		{name: "out of attempts", err: transient, attempts: MaxDeliveryAttempts, wantStatus: model.DeliveryStatusFailed},
+	}
+
+	for _, tt := range tests {
+		status, next := nextDeliveryState(tt.err, tt.attempts, now)
+		if status != tt.wantStatus {
+			t.Errorf("%s: status = %q, want %q", tt.name, status, tt.wantStatus)
+		}
+		switch {
+		case tt.wantWait == 0 && next != nil:
+			t.Errorf("%s: next attempt = %v, want none", tt.name, next)
+		case tt.wantWait != 0 && (next == nil || next.Sub(now) != tt.wantWait):
+			t.Errorf("%s: next attempt = %v, want in %v", tt.name, next, tt.wantWait)
+		}
+	}
+}
+
+func TestReceiptStatus(t *testing.T) {
+	tests := map[string]string{
+		"delivered": model.DeliveryStatusDelivered,
+		"bounce":    model.DeliveryStatusBounced,
+		"blocked":   model.DeliveryStatusBounced,
+		"dropped":   model.DeliveryStatusFailed,
+		"deferred":  "",
+		"processed": "",
+		"open":      "",
+	}
+	for event, want := range tests {
+		if got := receiptStatus(event); got != want {
+			t.Errorf("receiptStatus(%q) = %q, want %q", event, got, want)
+		}
+	}
+}
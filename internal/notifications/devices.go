@@ -0,0 +1,151 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"app/internal/model"
+)
+
+// ErrDeviceNotFound is returned when a device does not exist or belongs to another user
+var ErrDeviceNotFound = errors.New("device not found")
+
+// Devices persists the FCM tokens of users' app installs in the user_devices table
+type Devices struct {
+	db *sql.DB
+}
+
+// NewDevices creates a device store
+func NewDevices(db *sql.DB) *Devices {
+	return &Devices{db: db}
+}
+
+// Register saves the device for the user. A token already registered, by this or
+// another user, is updated, moved to the user and enabled again.
+func (d *Devices) Register(ctx context.Context, userID int, req model.RegisterDeviceRequest) (*model.Device, error) {
+	var device model.Device
+	err := d.db.QueryRowContext(ctx, `
+		INSERT INTO user_devices (user_id, token, platform, app_version, device_model)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (token) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			platform = EXCLUDED.platform,
+			app_version = EXCLUDED.app_version,
+			device_model = EXCLUDED.device_model,
+			last_seen_at = NOW(),
+			disabled_at = NULL,
+			disabled_reason = NULL
+		RETURNING id, platform, app_version, device_model, last_seen_at, created_at
+	`, userID, req.Token, req.Platform, req.AppVersion, req.DeviceModel).Scan(
+		&device.ID, &device.Platform, &device.AppVersion, &device.DeviceModel, &device.LastSeenAt, &device.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register device: %w", err)
+	}
+	return &device, nil
+}
+
+// Unregister removes one of the user's devices, when the app logs out or turns push off
+func (d *Devices) Unregister(ctx context.Context, userID, deviceID int) error {
+	result, err := d.db.ExecContext(ctx, `DELETE FROM user_devices WHERE id = $1 AND user_id = $2`, deviceID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to unregister device: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrDeviceNotFound
+	}
+	return nil
+}
+
+// ActiveTokens returns the tokens of the user's enabled devices, most recently seen first
+func (d *Devices) ActiveTokens(ctx context.Context, userID int) ([]string, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT token FROM user_devices
+		WHERE user_id = $1 AND disabled_at IS NULL
+		ORDER BY last_seen_at DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query devices: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []string
+	for rows.Next() {
+		var token string
+		if err := rows.Scan(&token); err != nil {
+			return nil, fmt.Errorf("failed to scan device: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+// Disable stops sending to a token FCM rejected for good
+func (d *Devices) Disable(ctx context.Context, token, reason string) error {
+	_, err := d.db.ExecContext(ctx, `
+		UPDATE user_devices SET disabled_at = NOW(), disabled_reason = $2
+		WHERE token = $1 AND disabled_at IS NULL
+	`, token, reason)
+	if err != nil {
+		return fmt.Errorf("failed to disable device: %w", err)
+	}
+	return nil
+}
+
+// Replace swaps a token for the canonical one FCM reported for the same install. If the
+// canonical token is already registered, the old row is dropped instead.
+func (d *Devices) Replace(ctx context.Context, token, canonical string) error {
+	_, err := d.db.ExecContext(ctx, `
+		UPDATE user_devices SET token = $2
+		WHERE token = $1 AND NOT EXISTS (SELECT 1 FROM user_devices WHERE token = $2)
+	`, token, canonical)
+	if err != nil {
+		return fmt.Errorf("failed to replace device token: %w", err)
+	}
+	_, err = d.db.ExecContext(ctx, `DELETE FROM user_devices WHERE token = $1`, token)
+	if err != nil {
+		return fmt.Errorf("failed to replace device token: %w", err)
+	}
+	return nil
+}
+
+// Prune applies FCM's per-token results for a multicast to tokens: tokens it rejected
+// for good are disabled and tokens with a canonical replacement are swapped for it
+func (d *Devices) Prune(ctx context.Context, tokens []string, results []FCMResult) error {
+	invalid, replaced := pruneResults(tokens, results)
+	for token, reason := range invalid {
+		if err := d.Disable(ctx, token, reason); err != nil {
+			return err
+		}
+	}
+	for token, canonical := range replaced {
+		if err := d.Replace(ctx, token, canonical); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneResults pairs FCM's results with the tokens they are for, returning the tokens
+// to disable with FCM's reason and the tokens to replace with their canonical token
+func pruneResults(tokens []string, results []FCMResult) (invalid, replaced map[string]string) {
+	invalid = map[string]string{}
+	replaced = map[string]string{}
+	for i, result := range results {
+		if i >= len(tokens) {
+			break
+		}
+		switch {
+		case IsInvalidTokenError(result.Error):
+			invalid[tokens[i]] = result.Error
+		case result.Error == "" && result.RegistrationID != "" && result.RegistrationID != tokens[i]:
+			replaced[tokens[i]] = result.RegistrationID
+		}
+	}
+	return invalid, replaced
+}
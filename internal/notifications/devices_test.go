@@ -0,0 +1,59 @@
+package notifications
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPruneResults(t *testing.T) {
+	tests := []struct {
+		name         string
+		tokens       []string
+		results      []FCMResult
+		wantInvalid  map[string]string
+		wantReplaced map[string]string
+	}{
+		{
+			name:         "all delivered",
+			tokens:       []string{"a", "b"},
+			results:      []FCMResult{{MessageID: "1"}, {MessageID: "2"}},
+			wantInvalid:  map[string]string{},
+			wantReplaced: map[string]string{},
+		},
+		{
+			name:   "invalid tokens disabled, transient errors kept",
+			tokens: []string{"a", "b", "c", "d"},
+			results: []FCMResult{
+				{Error: "NotRegistered"}, {Error: "Unavailable"}, {Error: "InvalidRegistration"}, {MessageID: "4"},
+			},
+			wantInvalid:  map[string]string{"a": "NotRegistered", "c": "InvalidRegistration"},
+			wantReplaced: map[string]string{},
+		},
+		{
+			name:         "canonical token replaces the old one",
+			tokens:       []string{"a", "b"},
+			results:      []FCMResult{{MessageID: "1", RegistrationID: "a2"}, {MessageID: "2", RegistrationID: "b"}},
+			wantInvalid:  map[string]string{},
+			wantReplaced: map[string]string{"a": "a2"},
+		},
+		{
+			name:         "extra results ignored",
+			tokens:       []string{"a"},
+			results:      []FCMResult{{MessageID: "1"}, {Error: "NotRegistered"}},
+			wantInvalid:  map[string]string{},
+			wantReplaced: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invalid, replaced := pruneResults(tt.tokens, tt.results)
+			if !reflect.DeepEqual(invalid, tt.wantInvalid) {
+				t.Errorf("pruneResults() invalid = %v; want %v", invalid, tt.wantInvalid)
+			}
+			if !reflect.DeepEqual(replaced, tt.wantReplaced) {
+				t.Errorf("pruneResults() replaced = %v; want %v", replaced, tt.wantReplaced)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"app/internal/model"
+)
+
+// EmailSender emails a notification to a user; email.Service implements it
+type EmailSender interface {
+	SendNotificationEmail(ctx context.Context, to, userName string, n model.Notification) error
+}
+
+// Dispatcher delivers notifications: each one is kept in the user's in-app inbox, then
+// sent by email, push and SMS as the user's preferences for its type allow. Channels
+// without a configured provider are skipped.
+type Dispatcher struct {
+	db      *sql.DB
+	store   *Store
+	prefs   *Preferences
+	ledger  *Ledger
+	devices *Devices
+	email   EmailSender
+	push    *PushService
+	sms     SMSSender
+}
+
+// NewDispatcher creates a dispatcher. Any of emailSender, push and sms may be nil.
+func NewDispatcher(db *sql.DB, emailSender EmailSender, push *PushService, sms SMSSender) *Dispatcher {
+	return &Dispatcher{
+		db:      db,
+		store:   NewStore(db),
+		prefs:   NewPreferences(db),
+		ledger:  NewLedger(db),
+		devices: NewDevices(db),
+		email:   emailSender,
+		push:    push,
+		sms:     sms,
+	}
+}
+
+// NewDispatcherFromEnv creates a dispatcher sending push and SMS through the providers
+// configured in the environment
+func NewDispatcherFromEnv(db *sql.DB, emailSender EmailSender) *Dispatcher {
+	push, err := NewPushServiceFromEnv()
+	if err != nil {
+		push = nil
+	}
+	var sms SMSSender
+	if smsService, err := NewSMSServiceFromEnv(); err == nil {
+		sms = smsService
+	}
+	return NewDispatcher(db, emailSender, push, sms)
+}
+
+// UserTopic is the FCM topic push notifications go to for users without registered
+// devices; apps subscribe to it after login
+func UserTopic(userID int) string {
+	return "user_" + strconv.Itoa(userID)
+}
+
+// Dispatch records n in the user's inbox and sends it over the channels the user allows.
+// Only a failure to record it is returned; failed sends are logged, and the delivery
+// ledger retries transient ones.
+func (d *Dispatcher) Dispatch(ctx context.Context, n model.Notification) (*model.Notification, error) {
+	created, err := d.store.Create(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	if d.email == nil && d.push == nil && d.sms == nil {
+		return created, nil
+	}
+
+	pref, err := d.prefs.Get(ctx, n.UserID, n.Type)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get notification preference, sending in-app only", "user_id", n.UserID, "type", n.Type, "error", err)
+		return created, nil
+	}
+	if !pref.EmailEnabled && !pref.PushEnabled && !pref.SMSEnabled {
+		return created, nil
+	}
+
+	var emailAddr, name string
+	var phone sql.NullString
+	var phoneVerified bool
+	err = d.db.QueryRowContext(ctx, `
+		SELECT email, name, phone, COALESCE(phone_verified, false)
+		FROM people WHERE id = $1 AND is_active = true
+	`, n.UserID).Scan(&emailAddr, &name, &phone, &phoneVerified)
+	if err == sql.ErrNoRows {
+		return created, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get notification recipient, sending in-app only", "user_id", n.UserID, "error", err)
+		return created, nil
+	}
+
+	if pref.Enabled(model.DeliveryChannelEmail) && d.email != nil {
+		if err := d.email.SendNotificationEmail(ctx, emailAddr, name, *created); err != nil {
+			slog.ErrorContext(ctx, "Failed to email notification", "user_id", n.UserID, "type", n.Type, "error", err)
+		}
+	}
+	if pref.Enabled(model.DeliveryChannelPush) && d.push != nil {
+		d.pushToDevices(ctx, created)
+	}
+	if pref.Enabled(model.DeliveryChannelSMS) && d.sms != nil && phone.Valid && phone.String != "" && phoneVerified {
+		body := fmt.Sprintf("GigCo: %s - %s", created.Title, created.Message)
+		if err := d.sms.SendToUser(ctx, d.ledger, n.UserID, phone.String, n.Type, body); err != nil {
+			slog.ErrorContext(ctx, "Failed to text notification", "user_id", n.UserID, "type", n.Type, "error", err)
+		}
+	}
+	return created, nil
+}
+
+// pushToDevices pushes n to each of the user's registered devices, or to their FCM topic
+// when they have none. Tokens FCM rejects for good are disabled.
+func (d *Dispatcher) pushToDevices(ctx context.Context, n *model.Notification) {
+	tokens, err := d.devices.ActiveTokens(ctx, n.UserID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get devices, pushing to the user topic", "user_id", n.UserID, "error", err)
+	}
+	if len(tokens) == 0 {
+		tokens = []string{"/topics/" + UserTopic(n.UserID)}
+	}
+
+	notification := &FCMNotification{
+		Title: n.Title,
+		Body:  n.Message,
+	}
+	for _, token := range tokens {
+		err := d.push.SendToUser(ctx, d.ledger, n.UserID, token, n.Type, notification, pushData(n))
+		var invalid *InvalidTokenError
+		switch {
+		case errors.As(err, &invalid):
+			if err := d.devices.Disable(ctx, token, invalid.Reason); err != nil {
+				slog.ErrorContext(ctx, "Failed to disable device", "user_id", n.UserID, "error", err)
+			}
+		case err != nil:
+			slog.ErrorContext(ctx, "Failed to push notification", "user_id", n.UserID, "type", n.Type, "error", err)
+		}
+	}
+}
+
+// pushData is the data payload apps use to open the notification's job or payment
+func pushData(n *model.Notification) map[string]string {
+	data := map[string]string{
+		"type":            n.Type,
+		"notification_id": strconv.Itoa(n.ID),
+	}
+	if n.RelatedJobID != nil {
+		data["job_id"] = strconv.Itoa(*n.RelatedJobID)
+	}
+	if n.RelatedTransactionID != nil {
+		data["transaction_id"] = strconv.Itoa(*n.RelatedTransactionID)
+	}
+	if n.ActionURL != nil {
+		data["action_url"] = *n.ActionURL
+	}
+	return data
+}
@@ -0,0 +1,59 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// PublishOnce records an event in ops_event_log and routes it, unless an event with
+// the same dedup key was already recorded within the suppression window.
+// Returns whether the event was published.
+func PublishOnce(ctx context.Context, db *sql.DB, event string, alert OpsAlert, window time.Duration) (bool, error) {
+	if alert.DedupKey == "" {
+		return false, fmt.Errorf("dedup key is required")
+	}
+
+	var seen bool
+	err := db.QueryRowContext(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM ops_event_log
+			WHERE dedup_key = $1 AND created_at > NOW() - make_interval(secs => $2)
+		)
+	`, alert.DedupKey, window.Seconds()).Scan(&seen)
+	if err != nil {
+		return false, fmt.Errorf("failed to check ops event log: %w", err)
+	}
+	if seen {
+		return false, nil
+	}
+
+	fields, err := json.Marshal(alert.Fields)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal event fields: %w", err)
+	}
+
+	var deliveryError sql.NullString
+	router, err := NewEventRouterFromEnv()
+	if err == nil {
+		err = router.Publish(event, alert)
+	}
+	if err != nil {
+		deliveryError = sql.NullString{String: err.Error(), Valid: true}
+	}
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO ops_event_log (event_type, dedup_key, severity, title, summary, fields, delivered, delivery_error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, event, alert.DedupKey, alert.Severity, alert.Title, alert.Summary, fields, !deliveryError.Valid, deliveryError)
+	if err != nil {
+		return true, fmt.Errorf("failed to record ops event: %w", err)
+	}
+
+	if deliveryError.Valid {
+		return true, fmt.Errorf("%s", deliveryError.String)
+	}
+	return true, nil
+}
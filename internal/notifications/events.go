@@ -0,0 +1,176 @@
+package notifications
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Critical business event types that can be routed to their own channel
+const (
+	EventPaymentReconciliation = "payment_reconciliation"
+	EventWorkflowDeadLetter    = "workflow_dead_letter"
+	EventFraudFlag             = "fraud_flag"
+	EventFillRateDrop          = "fill_rate_drop"
+	EventPaymentInvariant      = "payment_invariant"
+	EventKPIAnomaly            = "kpi_anomaly"
+)
+
+// EventTypes lists every routable event type
+func EventTypes() []string {
+	return []string{EventPaymentReconciliation, EventWorkflowDeadLetter, EventFraudFlag, EventFillRateDrop, EventPaymentInvariant, EventKPIAnomaly}
+}
+
+// Webhook payload formats
+const (
+	webhookFormatSlack = "slack"
+	webhookFormatTeams = "teams"
+)
+
+// EventRouter posts critical business events to Slack or Microsoft Teams webhooks,
+// routing each event type to its own channels
+type EventRouter struct {
+	routes          map[string][]string
+	defaultWebhooks []string
+	httpClient      *http.Client
+}
+
+// EventRouterConfig holds event routing configuration
+type EventRouterConfig struct {
+	Routes          map[string][]string // Event type -> webhook URLs
+	DefaultWebhooks []string            // Used for event types without their own route
+}
+
+// NewEventRouter creates a new event router
+func NewEventRouter(cfg EventRouterConfig) (*EventRouter, error) {
+	routes := make(map[string][]string)
+	for event, webhooks := range cfg.Routes {
+		if len(webhooks) > 0 {
+			routes[event] = webhooks
+		}
+	}
+	if len(routes) == 0 && len(cfg.DefaultWebhooks) == 0 {
+		return nil, fmt.Errorf("at least one event webhook URL is required")
+	}
+
+	return &EventRouter{
+		routes:          routes,
+		defaultWebhooks: cfg.DefaultWebhooks,
+		httpClient:      &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// NewEventRouterFromEnv creates an event router from environment variables.
+// OPS_WEBHOOK_<EVENT_TYPE> holds a comma-separated list of webhooks for one event
+// type; anything unrouted goes to OPS_SLACK_WEBHOOK_URL and OPS_TEAMS_WEBHOOK_URL.
+func NewEventRouterFromEnv() (*EventRouter, error) {
+	routes := make(map[string][]string)
+	for _, event := range EventTypes() {
+		routes[event] = splitWebhooks(os.Getenv("OPS_WEBHOOK_" + strings.ToUpper(event)))
+	}
+
+	defaults := splitWebhooks(os.Getenv("OPS_SLACK_WEBHOOK_URL"))
+	defaults = append(defaults, splitWebhooks(os.Getenv("OPS_TEAMS_WEBHOOK_URL"))...)
+
+	return NewEventRouter(EventRouterConfig{Routes: routes, DefaultWebhooks: defaults})
+}
+
+// WebhooksFor returns the webhooks an event type is delivered to
+func (r *EventRouter) WebhooksFor(event string) []string {
+	if webhooks, ok := r.routes[event]; ok {
+		return webhooks
+	}
+	return r.defaultWebhooks
+}
+
+// Publish delivers an event to every webhook routed for its type
+func (r *EventRouter) Publish(event string, alert OpsAlert) error {
+	if alert.Source == "" {
+		alert.Source = event
+	}
+
+	var errs []error
+	for _, webhook := range r.WebhooksFor(event) {
+		var payload interface{}
+		if webhookFormat(webhook) == webhookFormatTeams {
+			payload = teamsPayload(alert)
+		} else {
+			payload = slackPayload(alert)
+		}
+		if err := postJSON(r.httpClient, webhook, payload); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s event delivery failed: %v", event, errs)
+	}
+	return nil
+}
+
+// splitWebhooks parses a comma-separated list of webhook URLs
+func splitWebhooks(value string) []string {
+	var webhooks []string
+	for _, webhook := range strings.Split(value, ",") {
+		if webhook = strings.TrimSpace(webhook); webhook != "" {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks
+}
+
+// webhookFormat detects whether a webhook URL belongs to Teams or Slack
+func webhookFormat(webhook string) string {
+	u, err := url.Parse(webhook)
+	if err != nil {
+		return webhookFormatSlack
+	}
+	host := strings.ToLower(u.Hostname())
+	if strings.HasSuffix(host, ".office.com") || strings.HasSuffix(host, ".logic.azure.com") {
+		return webhookFormatTeams
+	}
+	return webhookFormatSlack
+}
+
+// teamsPayload formats an alert as a Teams connector message card
+func teamsPayload(alert OpsAlert) map[string]interface{} {
+	facts := make([]map[string]string, 0, len(alert.Fields))
+	for _, key := range sortedKeys(alert.Fields) {
+		facts = append(facts, map[string]string{"name": key, "value": alert.Fields[key]})
+	}
+
+	card := map[string]interface{}{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"summary":    alert.Title,
+		"themeColor": severityColor(alert.Severity),
+		"title":      fmt.Sprintf("[%s] %s", alert.Severity, alert.Title),
+		"text":       alert.Summary,
+		"sections":   []map[string]interface{}{{"facts": facts}},
+	}
+	if alert.Link != "" {
+		card["potentialAction"] = []map[string]interface{}{{
+			"@type":   "OpenUri",
+			"name":    "View details",
+			"targets": []map[string]string{{"os": "default", "uri": alert.Link}},
+		}}
+	}
+	return card
+}
+
+// severityColor maps an alert severity to a card accent color
+func severityColor(severity string) string {
+	switch severity {
+	case SeverityCritical:
+		return "8B0000"
+	case SeverityError:
+		return "D13438"
+	case SeverityWarning:
+		return "FFB900"
+	default:
+		return "0078D7"
+	}
+}
@@ -0,0 +1,108 @@
+package notifications
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNewEventRouterFromEnv(t *testing.T) {
+	t.Setenv("OPS_SLACK_WEBHOOK_URL", "https://hooks.slack.com/services/ops")
+	t.Setenv("OPS_TEAMS_WEBHOOK_URL", "https://example.webhook.office.com/webhookb2/ops")
+	t.Setenv("OPS_WEBHOOK_FRAUD_FLAG", "https://hooks.slack.com/services/trust, https://hooks.slack.com/services/finance")
+
+	router, err := NewEventRouterFromEnv()
+	if err != nil {
+		t.Fatalf("NewEventRouterFromEnv() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		event string
+		want  []string
+	}{
+		{
+			name:  "routed event",
+			event: EventFraudFlag,
+			want:  []string{"https://hooks.slack.com/services/trust", "https://hooks.slack.com/services/finance"},
+		},
+		{
+			name:  "unrouted event falls back to defaults",
+			event: EventFillRateDrop,
+			want:  []string{"https://hooks.slack.com/services/ops", "https://example.webhook.office.com/webhookb2/ops"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := router.WebhooksFor(tt.event); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WebhooksFor(%q) = %v, want %v", tt.event, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewEventRouterRequiresWebhook(t *testing.T) {
+	if _, err := NewEventRouter(EventRouterConfig{Routes: map[string][]string{EventFraudFlag: nil}}); err == nil {
+		t.Error("NewEventRouter() with no webhooks should fail")
+	}
+}
+
+func TestWebhookFormat(t *testing.T) {
+	tests := []struct {
+		webhook string
+		want    string
+	}{
+		{"https://hooks.slack.com/services/T000/B000/XXXX", webhookFormatSlack},
+		{"https://contoso.webhook.office.com/webhookb2/abc", webhookFormatTeams},
+		{"https://prod-12.westus.logic.azure.com/workflows/abc", webhookFormatTeams},
+		{"https://office.com.example.net/hook", webhookFormatSlack},
+		{"::not a url", webhookFormatSlack},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.webhook, func(t *testing.T) {
+			if got := webhookFormat(tt.webhook); got != tt.want {
+				t.Errorf("webhookFormat(%q) = %q, want %q", tt.webhook, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublish(t *testing.T) {
+	var got map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	router, err := NewEventRouter(EventRouterConfig{
+		Routes: map[string][]string{EventWorkflowDeadLetter: {server.URL}},
+	})
+	if err != nil {
+		t.Fatalf("NewEventRouter() error = %v", err)
+	}
+
+	err = router.Publish(EventWorkflowDeadLetter, OpsAlert{
+		Title:    "JobLifecycleWorkflow dead-lettered",
+		Summary:  "activity error",
+		Severity: SeverityError,
+		Fields:   map[string]string{"Job": "42", "ID": "job-42"},
+	})
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	want := "*[error] JobLifecycleWorkflow dead-lettered*\nactivity error\n• ID: job-42\n• Job: 42"
+	if got["text"] != want {
+		t.Errorf("Publish() text = %q, want %q", got["text"], want)
+	}
+
+	if err := router.Publish(EventFraudFlag, OpsAlert{Title: "unrouted"}); err != nil {
+		t.Errorf("Publish() for an event with no webhooks error = %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package notifications
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// fcmScope is the OAuth scope access tokens for sending through FCM are issued with
+const fcmScope = "https://www.googleapis.com/auth/firebase.messaging"
+
+// googleTokenURL is where service accounts exchange a signed assertion for an access
+// token, unless their key names another
+const googleTokenURL = "https://oauth2.googleapis.com/token"
+
+// tokenRefreshMargin is how long before it expires an access token is replaced, so a
+// send never starts with a token about to lapse
+const tokenRefreshMargin = time.Minute
+
+// serviceAccount is the part of a Google service account key FCM auth uses
+type serviceAccount struct {
+	ProjectID    string `json:"project_id"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// assertionClaims are the claims of the JWT a service account signs to get an access token
+type assertionClaims struct {
+	Scope string `json:"scope"`
+	jwt.RegisteredClaims
+}
+
+// serviceAccountAuth issues OAuth access tokens for a service account, caching each
+// until shortly before it expires
+type serviceAccountAuth struct {
+	account    serviceAccount
+	key        *rsa.PrivateKey
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// newServiceAccountAuth reads a service account key as downloaded from the Firebase
+// console
+func newServiceAccountAuth(keyJSON []byte, httpClient *http.Client) (*serviceAccountAuth, error) {
+	var account serviceAccount
+	if err := json.Unmarshal(keyJSON, &account); err != nil {
+		return nil, fmt.Errorf("failed to parse service account key: %w", err)
+	}
+	if account.ClientEmail == "" || account.PrivateKey == "" {
+		return nil, fmt.Errorf("service account key needs client_email and private_key")
+	}
+	if account.TokenURI == "" {
+		account.TokenURI = googleTokenURL
+	}
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(account.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse service account private key: %w", err)
+	}
+	return &serviceAccountAuth{account: account, key: key, httpClient: httpClient, now: time.Now}, nil
+}
+
+// accessToken returns a token for FCM's HTTP v1 API, fetching a new one when the
+// cached token is missing or about to expire
+func (a *serviceAccountAuth) accessToken(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != "" && a.now().Before(a.expiresAt.Add(-tokenRefreshMargin)) {
+		return a.token, nil
+	}
+	token, expiresIn, err := a.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	a.token, a.expiresAt = token, a.now().Add(expiresIn)
+	return a.token, nil
+}
+
+// invalidate drops the cached token after FCM rejected it, e.g. when the key was revoked
+func (a *serviceAccountAuth) invalidate() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.token = ""
+}
+
+// fetch exchanges a freshly signed assertion for an access token. Network errors, rate
+// limits and 5xx responses are transient.
+func (a *serviceAccountAuth) fetch(ctx context.Context) (string, time.Duration, error) {
+	now := a.now()
+	assertion := jwt.NewWithClaims(jwt.SigningMethodRS256, assertionClaims{
+		Scope: fcmScope,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    a.account.ClientEmail,
+			Audience:  jwt.ClaimStrings{a.account.TokenURI},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+	})
+	if a.account.PrivateKeyID != "" {
+		assertion.Header["kid"] = a.account.PrivateKeyID
+	}
+	signed, err := assertion.SignedString(a.key)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to sign service account assertion: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {signed},
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", a.account.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return "", 0, &TransientError{Err: fmt.Errorf("failed to request access token: %w", err)}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		err := fmt.Errorf("access token request returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return "", 0, &TransientError{Err: err}
+		}
+		return "", 0, err
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", 0, fmt.Errorf("failed to decode access token: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return "", 0, fmt.Errorf("token endpoint returned no access token")
+	}
+	return tokenResp.AccessToken, time.Duration(tokenResp.ExpiresIn) * time.Second, nil
+}
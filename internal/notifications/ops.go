@@ -0,0 +1,164 @@
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+)
+
+// Alert severities, ordered from least to most urgent
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityError    = "error"
+	SeverityCritical = "critical"
+)
+
+// OpsNotifier alerts the operations team via Slack and PagerDuty webhooks
+type OpsNotifier struct {
+	slackWebhookURL     string
+	pagerDutyRoutingKey string
+	pagerDutyURL        string
+	httpClient          *http.Client
+}
+
+// OpsConfig holds ops alerting configuration
+type OpsConfig struct {
+	SlackWebhookURL     string // Incoming webhook for the ops channel
+	PagerDutyRoutingKey string // Events API v2 integration key
+}
+
+// NewOpsNotifier creates a new ops notifier
+func NewOpsNotifier(cfg OpsConfig) (*OpsNotifier, error) {
+	if cfg.SlackWebhookURL == "" && cfg.PagerDutyRoutingKey == "" {
+		return nil, fmt.Errorf("at least one of Slack webhook URL or PagerDuty routing key is required")
+	}
+
+	return &OpsNotifier{
+		slackWebhookURL:     cfg.SlackWebhookURL,
+		pagerDutyRoutingKey: cfg.PagerDutyRoutingKey,
+		pagerDutyURL:        "https://events.pagerduty.com/v2/enqueue",
+		httpClient:          &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// NewOpsNotifierFromEnv creates ops notifier from environment variables
+func NewOpsNotifierFromEnv() (*OpsNotifier, error) {
+	return NewOpsNotifier(OpsConfig{
+		SlackWebhookURL:     os.Getenv("OPS_SLACK_WEBHOOK_URL"),
+		PagerDutyRoutingKey: os.Getenv("PAGERDUTY_ROUTING_KEY"),
+	})
+}
+
+// OpsAlert describes an event the operations team needs to see
+type OpsAlert struct {
+	Title    string
+	Summary  string
+	Severity string            // info, warning, error, critical
+	Source   string            // e.g. "safety-incident", "payments"
+	DedupKey string            // PagerDuty de-duplication key
+	Fields   map[string]string // Extra context shown in the alert
+	Link     string
+}
+
+// Notify posts the alert to Slack and, for error and critical alerts, pages via PagerDuty
+func (n *OpsNotifier) Notify(alert OpsAlert) error {
+	var errs []error
+
+	if n.slackWebhookURL != "" {
+		if err := n.sendSlack(alert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if n.pagerDutyRoutingKey != "" && (alert.Severity == SeverityError || alert.Severity == SeverityCritical) {
+		if err := n.sendPagerDuty(alert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("ops alert delivery failed: %v", errs)
+	}
+	return nil
+}
+
+// sendSlack posts the alert to the Slack incoming webhook
+func (n *OpsNotifier) sendSlack(alert OpsAlert) error {
+	return postJSON(n.httpClient, n.slackWebhookURL, slackPayload(alert))
+}
+
+// sendPagerDuty triggers a PagerDuty incident via the Events API v2
+func (n *OpsNotifier) sendPagerDuty(alert OpsAlert) error {
+	payload := map[string]interface{}{
+		"routing_key":  n.pagerDutyRoutingKey,
+		"event_action": "trigger",
+		"payload": map[string]interface{}{
+			"summary":        alert.Title + ": " + alert.Summary,
+			"source":         alert.Source,
+			"severity":       alert.Severity,
+			"custom_details": alert.Fields,
+		},
+	}
+	if alert.DedupKey != "" {
+		payload["dedup_key"] = alert.DedupKey
+	}
+	if alert.Link != "" {
+		payload["links"] = []map[string]string{{"href": alert.Link, "text": "View details"}}
+	}
+
+	return postJSON(n.httpClient, n.pagerDutyURL, payload)
+}
+
+// slackPayload formats an alert as a Slack incoming webhook message
+func slackPayload(alert OpsAlert) map[string]interface{} {
+	text := fmt.Sprintf("*[%s] %s*\n%s", alert.Severity, alert.Title, alert.Summary)
+	for _, key := range sortedKeys(alert.Fields) {
+		text += fmt.Sprintf("\n• %s: %s", key, alert.Fields[key])
+	}
+	if alert.Link != "" {
+		text += fmt.Sprintf("\n<%s|View details>", alert.Link)
+	}
+
+	return map[string]interface{}{"text": text}
+}
+
+// sortedKeys returns alert field names in a stable order
+func sortedKeys(fields map[string]string) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// postJSON sends a JSON payload to a webhook URL
+func postJSON(client *http.Client, url string, payload interface{}) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("alert webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
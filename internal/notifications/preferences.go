@@ -0,0 +1,92 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"app/internal/model"
+)
+
+// Preferences reads and updates the notification_preferences table. Types without a
+// row use model.DefaultNotificationPreference.
+type Preferences struct {
+	db *sql.DB
+}
+
+// NewPreferences creates a notification preference store
+func NewPreferences(db *sql.DB) *Preferences {
+	return &Preferences{db: db}
+}
+
+// List returns the user's preference for every notification type
+func (p *Preferences) List(ctx context.Context, userID int) ([]model.NotificationPreference, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT type, COALESCE(email_enabled, true), COALESCE(push_enabled, true), COALESCE(sms_enabled, false)
+		FROM notification_preferences WHERE user_id = $1
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notification preferences: %w", err)
+	}
+	defer rows.Close()
+
+	saved := make(map[string]model.NotificationPreference)
+	for rows.Next() {
+		var pref model.NotificationPreference
+		if err := rows.Scan(&pref.Type, &pref.EmailEnabled, &pref.PushEnabled, &pref.SMSEnabled); err != nil {
+			return nil, fmt.Errorf("failed to scan notification preference: %w", err)
+		}
+		saved[pref.Type] = pref
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	prefs := make([]model.NotificationPreference, 0, len(model.NotificationTypes))
+	for _, t := range model.NotificationTypes {
+		pref, ok := saved[t]
+		if !ok {
+			pref = model.DefaultNotificationPreference(t)
+		}
+		prefs = append(prefs, pref)
+	}
+	return prefs, nil
+}
+
+// Get returns the user's preference for one notification type
+func (p *Preferences) Get(ctx context.Context, userID int, notificationType string) (model.NotificationPreference, error) {
+	pref := model.NotificationPreference{Type: notificationType}
+	err := p.db.QueryRowContext(ctx, `
+		SELECT COALESCE(email_enabled, true), COALESCE(push_enabled, true), COALESCE(sms_enabled, false)
+		FROM notification_preferences WHERE user_id = $1 AND type = $2
+	`, userID, notificationType).Scan(&pref.EmailEnabled, &pref.PushEnabled, &pref.SMSEnabled)
+	if err == sql.ErrNoRows {
+		return model.DefaultNotificationPreference(notificationType), nil
+	}
+	if err != nil {
+		return pref, fmt.Errorf("failed to get notification preference: %w", err)
+	}
+	return pref, nil
+}
+
+// Update saves the given preferences in one transaction
+func (p *Preferences) Update(ctx context.Context, userID int, prefs []model.NotificationPreference) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, pref := range prefs {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO notification_preferences (user_id, type, email_enabled, push_enabled, sms_enabled)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (user_id, type) DO UPDATE
+			SET email_enabled = EXCLUDED.email_enabled, push_enabled = EXCLUDED.push_enabled, sms_enabled = EXCLUDED.sms_enabled
+		`, userID, pref.Type, pref.EmailEnabled, pref.PushEnabled, pref.SMSEnabled)
+		if err != nil {
+			return fmt.Errorf("failed to save notification preference for %s: %w", pref.Type, err)
+		}
+	}
+	return tx.Commit()
+}
@@ -2,84 +2,170 @@ package notifications
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"time"
+
+	"app/internal/model"
 )
 
-// PushService handles push notifications via Firebase Cloud Messaging
+// ProviderFCM is the provider key recorded in the delivery ledger
+const ProviderFCM = "fcm"
+
+// Retries of a send that FCM rate limited or failed with a 5xx, before it is left to the
+// delivery ledger
+const (
+	maxPushAttempts   = 3
+	pushBackoff       = 500 * time.Millisecond // Doubled for each retry without a Retry-After
+	maxPushRetryAfter = 10 * time.Second       // Longer waits are left to the ledger
+)
+
+// PushService handles push notifications via the Firebase Cloud Messaging HTTP v1 API,
+// authenticating as a service account
 type PushService struct {
-	serverKey  string
 	projectID  string
+	auth       *serviceAccountAuth
 	httpClient *http.Client
 	fcmURL     string
+	backoff    time.Duration
 }
 
 // PushConfig holds push notification configuration
 type PushConfig struct {
-	ServerKey string // FCM Server Key (legacy) or Service Account Key
-	ProjectID string // Firebase Project ID
+	ServiceAccountKey []byte // Service account key JSON from the Firebase console
+	ProjectID         string // Firebase Project ID; defaults to the service account's project
 }
 
 // NewPushService creates a new push notification service
 func NewPushService(cfg PushConfig) (*PushService, error) {
-	if cfg.ServerKey == "" {
-		return nil, fmt.Errorf("FCM server key is required")
+	if len(cfg.ServiceAccountKey) == 0 {
+		return nil, fmt.Errorf("FCM service account key is required")
+	}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	auth, err := newServiceAccountAuth(cfg.ServiceAccountKey, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	projectID := cfg.ProjectID
+	if projectID == "" {
+		projectID = auth.account.ProjectID
+	}
+	if projectID == "" {
+		return nil, fmt.Errorf("Firebase project ID is required")
 	}
 
 	return &PushService{
-		serverKey:  cfg.ServerKey,
-		projectID:  cfg.ProjectID,
-		httpClient: &http.Client{Timeout: 30 * time.Second},
-		fcmURL:     "https://fcm.googleapis.com/fcm/send",
+		projectID:  projectID,
+		auth:       auth,
+		httpClient: httpClient,
+		fcmURL:     "https://fcm.googleapis.com/v1/projects/" + url.PathEscape(projectID) + "/messages:send",
+		backoff:    pushBackoff,
 	}, nil
 }
 
-// NewPushServiceFromEnv creates push service from environment variables
+// NewPushServiceFromEnv creates push service from environment variables. The service
+// account key is read from FCM_SERVICE_ACCOUNT_JSON, or from the file named by
+// GOOGLE_APPLICATION_CREDENTIALS.
 func NewPushServiceFromEnv() (*PushService, error) {
+	key := []byte(os.Getenv("FCM_SERVICE_ACCOUNT_JSON"))
+	if path := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"); len(key) == 0 && path != "" {
+		var err error
+		if key, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("failed to read service account key: %w", err)
+		}
+	}
 	return NewPushService(PushConfig{
-		ServerKey: os.Getenv("FCM_SERVER_KEY"),
-		ProjectID: os.Getenv("FIREBASE_PROJECT_ID"),
+		ServiceAccountKey: key,
+		ProjectID:         os.Getenv("FIREBASE_PROJECT_ID"),
 	})
 }
 
-// FCMMessage represents a Firebase Cloud Messaging message
+// FCMMessage is an FCM HTTP v1 message to one device token or topic. The Android and
+// APNs blocks override the notification on those platforms.
 type FCMMessage struct {
-	To               string            `json:"to,omitempty"`
-	RegistrationIDs  []string          `json:"registration_ids,omitempty"`
-	Condition        string            `json:"condition,omitempty"`
-	Topic            string            `json:"topic,omitempty"`
-	Notification     *FCMNotification  `json:"notification,omitempty"`
-	Data             map[string]string `json:"data,omitempty"`
-	Priority         string            `json:"priority,omitempty"`
-	TimeToLive       int               `json:"time_to_live,omitempty"`
-	ContentAvailable bool              `json:"content_available,omitempty"`
-	MutableContent   bool              `json:"mutable_content,omitempty"`
-}
-
-// FCMNotification represents the notification payload
+	Token        string            `json:"token,omitempty"`
+	Topic        string            `json:"topic,omitempty"`
+	Notification *FCMNotification  `json:"notification,omitempty"`
+	Data         map[string]string `json:"data,omitempty"`
+	Android      *AndroidConfig    `json:"android,omitempty"`
+	APNS         *APNSConfig       `json:"apns,omitempty"`
+}
+
+// FCMNotification represents the notification payload shown on every platform
 type FCMNotification struct {
-	Title        string `json:"title,omitempty"`
-	Body         string `json:"body,omitempty"`
-	Icon         string `json:"icon,omitempty"`
-	Sound        string `json:"sound,omitempty"`
-	Badge        string `json:"badge,omitempty"`
-	ClickAction  string `json:"click_action,omitempty"`
-	BodyLocKey   string `json:"body_loc_key,omitempty"`
-	BodyLocArgs  string `json:"body_loc_args,omitempty"`
-	TitleLocKey  string `json:"title_loc_key,omitempty"`
-	TitleLocArgs string `json:"title_loc_args,omitempty"`
-}
-
-// FCMResponse represents the response from FCM
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+	Image string `json:"image,omitempty"`
+}
+
+// AndroidConfig is the Android-specific part of a message
+type AndroidConfig struct {
+	Priority     string               `json:"priority,omitempty"` // "high" wakes the device
+	Notification *AndroidNotification `json:"notification,omitempty"`
+}
+
+// AndroidNotification overrides the notification on Android
+type AndroidNotification struct {
+	Sound string `json:"sound,omitempty"`
+}
+
+// APNSConfig is the iOS-specific part of a message, sent on to APNs
+type APNSConfig struct {
+	Headers map[string]string `json:"headers,omitempty"`
+	Payload *APNSPayload      `json:"payload,omitempty"`
+}
+
+// APNSPayload is the APNs payload of a message
+type APNSPayload struct {
+	Aps APS `json:"aps"`
+}
+
+// APS is the aps dictionary of an APNs payload
+type APS struct {
+	Sound          string `json:"sound,omitempty"`
+	MutableContent int    `json:"mutable-content,omitempty"`
+}
+
+// fcmSendRequest is the body of a messages:send request
+type fcmSendRequest struct {
+	Message FCMMessage `json:"message"`
+}
+
+// fcmErrorResponse is the body of a failed messages:send request
+type fcmErrorResponse struct {
+	Error struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Details []struct {
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// code is FCM's error code, e.g. UNREGISTERED, or the generic status without one
+func (r fcmErrorResponse) code() string {
+	for _, detail := range r.Error.Details {
+		if detail.ErrorCode != "" {
+			return detail.ErrorCode
+		}
+	}
+	return r.Error.Status
+}
+
+// FCMResponse sums up a send to several devices
 type FCMResponse struct {
-	MulticastID  int64        `json:"multicast_id"`
-	Success      int          `json:"success"`
-	Failure      int          `json:"failure"`
-	CanonicalIDs int          `json:"canonical_ids"`
-	Results      []FCMResult  `json:"results"`
+	Success int         `json:"success"`
+	Failure int         `json:"failure"`
+	Results []FCMResult `json:"results"`
 }
 
 // FCMResult represents individual result for each recipient
@@ -89,77 +175,169 @@ type FCMResult struct {
 	Error          string `json:"error,omitempty"`
 }
 
-// SendToDevice sends a push notification to a specific device
-func (s *PushService) SendToDevice(deviceToken string, notification *FCMNotification, data map[string]string) (*FCMResponse, error) {
+// InvalidTokenError is a send FCM rejected because the device token will never work
+// again, such as an uninstalled app
+type InvalidTokenError struct {
+	Reason string // FCM's error code, e.g. UNREGISTERED
+}
+
+func (e *InvalidTokenError) Error() string { return "FCM error: " + e.Reason }
+
+// IsInvalidTokenError reports whether an FCM error code means the token should no
+// longer be used. The legacy API's names for the same errors are recognised too.
+func IsInvalidTokenError(fcmError string) bool {
+	switch fcmError {
+	case "UNREGISTERED", "SENDER_ID_MISMATCH",
+		"NotRegistered", "InvalidRegistration", "MismatchSenderId":
+		return true
+	}
+	return false
+}
+
+// newMessage addresses a message to a device token, or to a topic given as
+// /topics/<name>, with the notification set up for Android and iOS
+func newMessage(target string, notification *FCMNotification, data map[string]string) FCMMessage {
 	message := FCMMessage{
-		To:           deviceToken,
 		Notification: notification,
 		Data:         data,
-		Priority:     "high",
+		Android: &AndroidConfig{
+			Priority:     "high",
+			Notification: &AndroidNotification{Sound: "default"},
+		},
+		APNS: &APNSConfig{
+			Headers: map[string]string{"apns-priority": "10"},
+			Payload: &APNSPayload{Aps: APS{Sound: "default", MutableContent: 1}},
+		},
 	}
-
-	return s.send(message)
+	if topic, ok := strings.CutPrefix(target, "/topics/"); ok {
+		message.Topic = topic
+	} else {
+		message.Token = target
+	}
+	return message
 }
 
-// SendToDevices sends a push notification to multiple devices
-func (s *PushService) SendToDevices(deviceTokens []string, notification *FCMNotification, data map[string]string) (*FCMResponse, error) {
-	if len(deviceTokens) > 1000 {
-		return nil, fmt.Errorf("cannot send to more than 1000 devices at once")
-	}
+// SendToDevice sends a push notification to a specific device and returns FCM's
+// message name
+func (s *PushService) SendToDevice(ctx context.Context, deviceToken string, notification *FCMNotification, data map[string]string) (string, error) {
+	return s.send(ctx, newMessage(deviceToken, notification, data))
+}
 
-	message := FCMMessage{
-		RegistrationIDs: deviceTokens,
-		Notification:    notification,
-		Data:            data,
-		Priority:        "high",
+// SendToDevices sends a push notification to each of the devices. The v1 API takes one
+// device per request, so FCM's errors are collected per token rather than returned.
+func (s *PushService) SendToDevices(ctx context.Context, deviceTokens []string, notification *FCMNotification, data map[string]string) *FCMResponse {
+	resp := &FCMResponse{Results: make([]FCMResult, len(deviceTokens))}
+	for i, token := range deviceTokens {
+		name, err := s.SendToDevice(ctx, token, notification, data)
+		var invalid *InvalidTokenError
+		switch {
+		case errors.As(err, &invalid):
+			resp.Results[i].Error = invalid.Reason
+		case err != nil:
+			resp.Results[i].Error = err.Error()
+		default:
+			resp.Results[i].MessageID = name
+			resp.Success++
+			continue
+		}
+		resp.Failure++
 	}
-
-	return s.send(message)
+	return resp
 }
 
 // SendToTopic sends a push notification to a topic
-func (s *PushService) SendToTopic(topic string, notification *FCMNotification, data map[string]string) (*FCMResponse, error) {
-	message := FCMMessage{
-		To:           "/topics/" + topic,
-		Notification: notification,
-		Data:         data,
-		Priority:     "high",
+func (s *PushService) SendToTopic(ctx context.Context, topic string, notification *FCMNotification, data map[string]string) (string, error) {
+	return s.send(ctx, newMessage("/topics/"+topic, notification, data))
+}
+
+// send posts the message, retrying rate limits and 5xx responses with backoff. The last
+// error is returned once the attempts run out or FCM asks for a longer wait.
+func (s *PushService) send(ctx context.Context, message FCMMessage) (string, error) {
+	body, err := json.Marshal(fcmSendRequest{Message: message})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal message: %w", err)
 	}
 
-	return s.send(message)
+	for attempt := 1; ; attempt++ {
+		name, retryAfter, err := s.post(ctx, body)
+		if err == nil || !IsTransient(err) || attempt >= maxPushAttempts {
+			return name, err
+		}
+
+		delay := s.backoff << (attempt - 1)
+		if retryAfter > 0 {
+			delay = retryAfter
+		}
+		if delay > maxPushRetryAfter {
+			return "", err
+		}
+		slog.WarnContext(ctx, "Retrying FCM send", "attempt", attempt, "delay", delay.String(), "error", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", err
+		case <-timer.C:
+		}
+	}
 }
 
-// send sends the FCM message
-func (s *PushService) send(message FCMMessage) (*FCMResponse, error) {
-	jsonData, err := json.Marshal(message)
+// post makes one messages:send request and returns the message name, with how long FCM
+// asked to wait before retrying. Network errors, 429s and 5xx responses are transient,
+// and a rejected access token is dropped so the retry fetches a new one.
+func (s *PushService) post(ctx context.Context, body []byte) (string, time.Duration, error) {
+	accessToken, err := s.auth.accessToken(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal message: %w", err)
+		return "", 0, err
 	}
 
-	req, err := http.NewRequest("POST", s.fcmURL, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", s.fcmURL, bytes.NewReader(body))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return "", 0, fmt.Errorf("failed to create request: %w", err)
 	}
-
-	req.Header.Set("Authorization", "key="+s.serverKey)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return "", 0, &TransientError{Err: fmt.Errorf("failed to send request: %w", err)}
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("FCM returned status %d", resp.StatusCode)
+	if resp.StatusCode == http.StatusOK {
+		var sent struct {
+			Name string `json:"name"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&sent); err != nil {
+			return "", 0, fmt.Errorf("failed to decode response: %w", err)
+		}
+		return sent.Name, 0, nil
 	}
 
-	var fcmResp FCMResponse
-	if err := json.NewDecoder(resp.Body).Decode(&fcmResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	var fcmErr fcmErrorResponse
+	_ = json.NewDecoder(resp.Body).Decode(&fcmErr)
+	code := fcmErr.code()
+	err = fmt.Errorf("FCM returned status %d: %s", resp.StatusCode, strings.TrimSpace(code+" "+fcmErr.Error.Message))
+	switch {
+	case IsInvalidTokenError(code):
+		return "", 0, &InvalidTokenError{Reason: code}
+	case resp.StatusCode == http.StatusUnauthorized:
+		s.auth.invalidate()
+		return "", 0, &TransientError{Err: err}
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return "", retryAfter(resp.Header.Get("Retry-After")), &TransientError{Err: err}
 	}
+	return "", 0, err
+}
 
-	return &fcmResp, nil
+// retryAfter reads a Retry-After header given in seconds, returning 0 without one
+func retryAfter(header string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(header))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
 }
 
 // JobNotification creates a notification for job-related events
@@ -170,12 +348,12 @@ type JobNotification struct {
 	ActionType  string // "view", "accept", "complete", etc.
 }
 
-// SendJobNotification sends a job-related push notification
-func (s *PushService) SendJobNotification(deviceToken string, jn JobNotification) (*FCMResponse, error) {
+// SendJobNotification sends a job-related push notification to every active device of
+// the user, disabling tokens FCM rejects. A user without devices is sent nothing.
+func (s *PushService) SendJobNotification(ctx context.Context, devices *Devices, userID int, jn JobNotification) (*FCMResponse, error) {
 	notification := &FCMNotification{
 		Title: "GigCo: " + jn.JobTitle,
 		Body:  jn.Message,
-		Sound: "default",
 	}
 
 	data := map[string]string{
@@ -185,7 +363,7 @@ func (s *PushService) SendJobNotification(deviceToken string, jn JobNotification
 		"type":        "job_notification",
 	}
 
-	return s.SendToDevice(deviceToken, notification, data)
+	return s.sendToUserDevices(ctx, devices, userID, notification, data)
 }
 
 // PaymentNotification creates a notification for payment events
@@ -195,12 +373,12 @@ type PaymentNotification struct {
 	Message       string
 }
 
-// SendPaymentNotification sends a payment-related push notification
-func (s *PushService) SendPaymentNotification(deviceToken string, pn PaymentNotification) (*FCMResponse, error) {
+// SendPaymentNotification sends a payment-related push notification to every active
+// device of the user, disabling tokens FCM rejects
+func (s *PushService) SendPaymentNotification(ctx context.Context, devices *Devices, userID int, pn PaymentNotification) (*FCMResponse, error) {
 	notification := &FCMNotification{
 		Title: "GigCo Payment",
 		Body:  pn.Message,
-		Sound: "default",
 	}
 
 	data := map[string]string{
@@ -209,7 +387,73 @@ func (s *PushService) SendPaymentNotification(deviceToken string, pn PaymentNoti
 		"type":           "payment_notification",
 	}
 
-	return s.SendToDevice(deviceToken, notification, data)
+	return s.sendToUserDevices(ctx, devices, userID, notification, data)
+}
+
+// sendToUserDevices sends to each of the user's active devices and prunes the tokens
+// according to FCM's results
+func (s *PushService) sendToUserDevices(ctx context.Context, devices *Devices, userID int, notification *FCMNotification, data map[string]string) (*FCMResponse, error) {
+	tokens, err := devices.ActiveTokens(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return &FCMResponse{}, nil
+	}
+
+	resp := s.SendToDevices(ctx, tokens, notification, data)
+	if err := devices.Prune(ctx, tokens, resp.Results); err != nil {
+		slog.ErrorContext(ctx, "Failed to prune device tokens", "user_id", userID, "error", err)
+	}
+	return resp, nil
+}
+
+// SendToUser sends a push notification to one of a user's devices through the delivery
+// ledger, which records it and retries transient FCM failures. kind names the
+// notification in the user's delivery history.
+func (s *PushService) SendToUser(ctx context.Context, ledger *Ledger, userID int, deviceToken, kind string, notification *FCMNotification, data map[string]string) error {
+	payload, err := json.Marshal(newMessage(deviceToken, notification, data))
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	var subject string
+	if notification != nil {
+		subject = notification.Title
+	}
+	return ledger.Send(ctx, Message{
+		UserID:    &userID,
+		Channel:   model.DeliveryChannelPush,
+		Kind:      kind,
+		Recipient: deviceToken,
+		Subject:   subject,
+		Payload:   payload,
+	}, s)
+}
+
+// Provider returns the provider key recorded in the delivery ledger
+func (s *PushService) Provider() string {
+	return ProviderFCM
+}
+
+// Deliver sends a marshaled FCMMessage and returns FCM's message name. Rate limits and
+// 5xx responses are retried a few times with backoff before the send fails with a
+// TransientError for the ledger to retry later. Tokens that will never work again fail
+// with InvalidTokenError.
+func (s *PushService) Deliver(ctx context.Context, payload []byte) (string, error) {
+	// Deliveries queued for the legacy API name their device token or topic in to
+	var queued struct {
+		FCMMessage
+		To string `json:"to"`
+	}
+	if err := json.Unmarshal(payload, &queued); err != nil {
+		return "", fmt.Errorf("failed to decode message: %w", err)
+	}
+	message := queued.FCMMessage
+	if queued.To != "" {
+		message = newMessage(queued.To, message.Notification, message.Data)
+	}
+	return s.send(ctx, message)
 }
 
 // MockPushService is a mock push service for testing
@@ -218,11 +462,7 @@ type MockPushService struct {
 }
 
 // SendToDevice mocks sending a notification
-func (m *MockPushService) SendToDevice(deviceToken string, notification *FCMNotification, data map[string]string) (*FCMResponse, error) {
-	m.SentNotifications = append(m.SentNotifications, FCMMessage{
-		To:           deviceToken,
-		Notification: notification,
-		Data:         data,
-	})
-	return &FCMResponse{Success: 1}, nil
+func (m *MockPushService) SendToDevice(ctx context.Context, deviceToken string, notification *FCMNotification, data map[string]string) (string, error) {
+	m.SentNotifications = append(m.SentNotifications, newMessage(deviceToken, notification, data))
+	return "projects/mock/messages/" + strconv.Itoa(len(m.SentNotifications)), nil
 }
@@ -0,0 +1,148 @@
+package notifications
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPushDeliver(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	tests := []struct {
+		name         string
+		payload      string
+		responses    []int // Status of each send; the last one repeats
+		errorCode    string
+		wantToken    string
+		wantAPNS     bool
+		wantAttempts int
+		wantErr      func(error) bool
+	}{
+		{
+			name:         "sent",
+			payload:      `{"token":"a","notification":{"title":"Hi"}}`,
+			responses:    []int{http.StatusOK},
+			wantToken:    "a",
+			wantAttempts: 1,
+		},
+		{
+			name:         "legacy payload from the ledger",
+			payload:      `{"to":"b","notification":{"title":"Hi","sound":"default"},"priority":"high"}`,
+			responses:    []int{http.StatusOK},
+			wantToken:    "b",
+			wantAPNS:     true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "unavailable retried",
+			payload:      `{"token":"a"}`,
+			responses:    []int{http.StatusServiceUnavailable, http.StatusOK},
+			errorCode:    "UNAVAILABLE",
+			wantToken:    "a",
+			wantAttempts: 2,
+		},
+		{
+			name:         "rate limited until the attempts run out",
+			payload:      `{"token":"a"}`,
+			responses:    []int{http.StatusTooManyRequests},
+			errorCode:    "QUOTA_EXCEEDED",
+			wantToken:    "a",
+			wantAttempts: maxPushAttempts,
+			wantErr:      IsTransient,
+		},
+		{
+			name:         "unregistered token",
+			payload:      `{"token":"a"}`,
+			responses:    []int{http.StatusNotFound},
+			errorCode:    "UNREGISTERED",
+			wantToken:    "a",
+			wantAttempts: 1,
+			wantErr: func(err error) bool {
+				var invalid *InvalidTokenError
+				return errors.As(err, &invalid) && invalid.Reason == "UNREGISTERED"
+			},
+		},
+		{
+			name:         "bad request not retried",
+			payload:      `{"token":"a"}`,
+			responses:    []int{http.StatusBadRequest},
+			errorCode:    "INVALID_ARGUMENT",
+			wantToken:    "a",
+			wantAttempts: 1,
+			wantErr:      func(err error) bool { return err != nil && !IsTransient(err) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tokenRequests, attempts int
+			var sent fcmSendRequest
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+				tokenRequests++
+				json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "at", "expires_in": 3600})
+			})
+			mux.HandleFunc("POST /send", func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer at" {
+					t.Errorf("Authorization = %q; want Bearer at", r.Header.Get("Authorization"))
+				}
+				json.NewDecoder(r.Body).Decode(&sent)
+				status := tt.responses[min(attempts, len(tt.responses)-1)]
+				attempts++
+				w.WriteHeader(status)
+				if status == http.StatusOK {
+					json.NewEncoder(w).Encode(map[string]string{"name": "projects/p/messages/1"})
+					return
+				}
+				json.NewEncoder(w).Encode(map[string]interface{}{"error": map[string]interface{}{
+					"status":  "ERROR",
+					"details": []map[string]string{{"errorCode": tt.errorCode}},
+				}})
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			account, _ := json.Marshal(serviceAccount{
+				ProjectID:   "p",
+				PrivateKey:  string(keyPEM),
+				ClientEmail: "push@p.iam.gserviceaccount.com",
+				TokenURI:    server.URL + "/token",
+			})
+			s, err := NewPushService(PushConfig{ServiceAccountKey: account})
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.fcmURL = server.URL + "/send"
+			s.backoff = time.Millisecond
+
+			name, err := s.Deliver(t.Context(), []byte(tt.payload))
+			if tt.wantErr == nil && (err != nil || name != "projects/p/messages/1") {
+				t.Fatalf("Deliver() = (%q, %v); want the message name", name, err)
+			}
+			if tt.wantErr != nil && !tt.wantErr(err) {
+				t.Fatalf("Deliver() error = %v", err)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d; want %d", attempts, tt.wantAttempts)
+			}
+			if tokenRequests != 1 {
+				t.Errorf("token requests = %d; want 1", tokenRequests)
+			}
+			if sent.Message.Token != tt.wantToken || (sent.Message.APNS != nil) != tt.wantAPNS {
+				t.Errorf("sent message = %+v; want token %q", sent.Message, tt.wantToken)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"app/internal/model"
+)
+
+// ProviderTwilio is the provider key recorded in the delivery ledger
+const ProviderTwilio = "twilio"
+
+// maxSMSLength keeps texts to a few segments, in characters
+const maxSMSLength = 320
+
+// SMSSender texts a user through the delivery ledger; SMSService implements it
+type SMSSender interface {
+	SendToUser(ctx context.Context, ledger *Ledger, userID int, phone, kind, body string) error
+}
+
+// SMSService sends text messages through Twilio
+type SMSService struct {
+	accountSID string
+	authToken  string
+	fromNumber string
+	httpClient *http.Client
+	apiURL     string
+}
+
+// SMSConfig holds SMS configuration
+type SMSConfig struct {
+	AccountSID string
+	AuthToken  string
+	FromNumber string // E.164 number or messaging service SID the texts come from
+}
+
+// NewSMSService creates a new SMS service
+func NewSMSService(cfg SMSConfig) (*SMSService, error) {
+	if cfg.AccountSID == "" || cfg.AuthToken == "" || cfg.FromNumber == "" {
+		return nil, fmt.Errorf("Twilio account SID, auth token and from number are required")
+	}
+
+	return &SMSService{
+		accountSID: cfg.AccountSID,
+		authToken:  cfg.AuthToken,
+		fromNumber: cfg.FromNumber,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		apiURL:     "https://api.twilio.com/2010-04-01/Accounts/" + url.PathEscape(cfg.AccountSID) + "/Messages.json",
+	}, nil
+}
+
+// NewSMSServiceFromEnv creates SMS service from environment variables
+func NewSMSServiceFromEnv() (*SMSService, error) {
+	return NewSMSService(SMSConfig{
+		AccountSID: os.Getenv("TWILIO_ACCOUNT_SID"),
+		AuthToken:  os.Getenv("TWILIO_AUTH_TOKEN"),
+		FromNumber: os.Getenv("TWILIO_FROM_NUMBER"),
+	})
+}
+
+// SMSMessage is the payload stored in the delivery ledger for a text
+type SMSMessage struct {
+	To   string `json:"to"`
+	Body string `json:"body"`
+}
+
+// E164 formats a North American phone number, as users enter them, the way Twilio
+// expects it. ok is false for anything else.
+func E164(phone string) (string, bool) {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+	switch {
+	case len(digits) == 10:
+		return "+1" + digits, true
+	case len(digits) == 11 && digits[0] == '1':
+		return "+" + digits, true
+	}
+	return "", false
+}
+
+// SendToUser texts a user's phone through the delivery ledger, which records it and
+// retries transient Twilio failures
+func (s *SMSService) SendToUser(ctx context.Context, ledger *Ledger, userID int, phone, kind, body string) error {
+	to, ok := E164(phone)
+	if !ok {
+		return fmt.Errorf("cannot text phone number %q", phone)
+	}
+	if runes := []rune(body); len(runes) > maxSMSLength {
+		body = strings.TrimSpace(string(runes[:maxSMSLength-3])) + "..."
+	}
+	payload, err := json.Marshal(SMSMessage{To: to, Body: body})
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	return ledger.Send(ctx, Message{
+		UserID:    &userID,
+		Channel:   model.DeliveryChannelSMS,
+		Kind:      kind,
+		Recipient: to,
+		Payload:   payload,
+	}, s)
+}
+
+// Provider returns the provider key recorded in the delivery ledger
+func (s *SMSService) Provider() string {
+	return ProviderTwilio
+}
+
+// Deliver sends a marshaled SMSMessage and returns Twilio's message SID. Rate limits,
+// network and 5xx errors are transient.
+func (s *SMSService) Deliver(ctx context.Context, payload []byte) (string, error) {
+	var msg SMSMessage
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		return "", fmt.Errorf("failed to decode message: %w", err)
+	}
+
+	form := url.Values{"To": {msg.To}, "Body": {msg.Body}}
+	if strings.HasPrefix(s.fromNumber, "MG") {
+		form.Set("MessagingServiceSid", s.fromNumber)
+	} else {
+		form.Set("From", s.fromNumber)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.apiURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(s.accountSID, s.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", &TransientError{Err: fmt.Errorf("failed to send request: %w", err)}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("Twilio returned status %d", resp.StatusCode)
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return "", &TransientError{Err: err}
+		}
+		return "", err
+	}
+
+	var result struct {
+		SID string `json:"sid"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.SID, nil
+}
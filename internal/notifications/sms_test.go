@@ -0,0 +1,27 @@
+package notifications
+
+import "testing"
+
+func TestE164(t *testing.T) {
+	tests := []struct {
+		phone  string
+		want   string
+		wantOK bool
+	}{
+		{"+1-555-0101", "", false},
+		{"(512) 555-0101", "+15125550101", true},
+		{"512.555.0101", "+15125550101", true},
+		{"+1 512 555 0101", "+15125550101", true},
+		{"25125550101", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phone, func(t *testing.T) {
+			got, ok := E164(tt.phone)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("E164(%q) = (%q, %v); want (%q, %v)", tt.phone, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"app/internal/model"
+)
+
+// ErrNotificationNotFound is returned when a notification does not exist or belongs
+// to another user
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// notificationColumns is the column list scanned by scanNotification
+const notificationColumns = `
+	id, uuid, user_id, type, title, message, status, related_job_id,
+	related_transaction_id, action_url, metadata, read_at, created_at
+`
+
+// Store persists in-app notifications in the notifications table. Notifications
+// scheduled for the future are hidden from the inbox and unread count until due.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore creates a notification store
+func NewStore(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+// Create records a notification for n.UserID and returns it with its ID and timestamps
+func (s *Store) Create(ctx context.Context, n model.Notification) (*model.Notification, error) {
+	row := s.db.QueryRowContext(ctx, `
+		INSERT INTO notifications (user_id, type, title, message, related_job_id, related_transaction_id, action_url, metadata, sent_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		RETURNING `+notificationColumns,
+		n.UserID, n.Type, n.Title, n.Message, n.RelatedJobID, n.RelatedTransactionID, n.ActionURL, n.Metadata)
+	created, err := scanNotification(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification: %w", err)
+	}
+	return created, nil
+}
+
+// List returns a page of the user's notifications, newest first, and the total
+// matching the filter. An empty status lists unread and read notifications.
+func (s *Store) List(ctx context.Context, userID int, status string, limit, offset int) ([]model.Notification, int, error) {
+	where := ` WHERE user_id = $1 AND scheduled_for <= NOW() AND status <> 'archived'`
+	args := []any{userID}
+	if status != "" {
+		where = ` WHERE user_id = $1 AND scheduled_for <= NOW() AND status = $2`
+		args = append(args, status)
+	}
+
+	var total int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM notifications`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	query := `SELECT ` + notificationColumns + ` FROM notifications` + where +
+		fmt.Sprintf(` ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+	rows, err := s.db.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query notifications: %w", err)
+	}
+	defer rows.Close()
+
+	list := []model.Notification{}
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		list = append(list, *n)
+	}
+	return list, total, rows.Err()
+}
+
+// MarkRead marks one of the user's notifications as read. Marking a notification
+// that is already read keeps its original read_at.
+func (s *Store) MarkRead(ctx context.Context, userID, id int) (*model.Notification, error) {
+	row := s.db.QueryRowContext(ctx, `
+		UPDATE notifications
+		SET status = 'read', read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND user_id = $2 AND scheduled_for <= NOW()
+		RETURNING `+notificationColumns,
+		id, userID)
+	n, err := scanNotification(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotificationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	return n, nil
+}
+
+// UnreadCount returns how many of the user's notifications are unread
+func (s *Store) UnreadCount(ctx context.Context, userID int) (int, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM notifications
+		WHERE user_id = $1 AND status = 'unread' AND scheduled_for <= NOW()
+	`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanNotification scans a notifications row selected with notificationColumns
+func scanNotification(row rowScanner) (*model.Notification, error) {
+	var n model.Notification
+	var relatedJobID, relatedTransactionID sql.NullInt64
+	var actionURL sql.NullString
+	var readAt sql.NullTime
+
+	err := row.Scan(&n.ID, &n.UUID, &n.UserID, &n.Type, &n.Title, &n.Message, &n.Status,
+		&relatedJobID, &relatedTransactionID, &actionURL, &n.Metadata, &readAt, &n.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if relatedJobID.Valid {
+		id := int(relatedJobID.Int64)
+		n.RelatedJobID = &id
+	}
+	if relatedTransactionID.Valid {
+		id := int(relatedTransactionID.Int64)
+		n.RelatedTransactionID = &id
+	}
+	if actionURL.Valid {
+		n.ActionURL = &actionURL.String
+	}
+	if readAt.Valid {
+		n.ReadAt = &readAt.Time
+	}
+	return &n, nil
+}
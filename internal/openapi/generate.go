@@ -0,0 +1,373 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Route documents the request and response models of one registered route
+type Route struct {
+	Method      string
+	Path        string
+	OperationID string // Defaults to the handler function name
+	Summary     string
+	Description string
+	Tag         string
+	Query       []Param
+	Request     interface{} // Example request body; nil for none
+	Upload      string      // Multipart form field carrying an uploaded file; empty for none
+	Response    interface{} // Example response body; nil for none
+	Status      int         // Success status, defaults to 200
+	ContentType string      // Success response content type, defaults to application/json
+	Hidden      bool        // Registered but left out of the document
+}
+
+// Param documents a query parameter
+type Param struct {
+	Name        string
+	Example     interface{}
+	Description string
+	Required    bool
+}
+
+// Config controls document generation
+type Config struct {
+	Info      Info
+	Servers   []Server
+	Tags      []Tag
+	Changelog []ChangelogEntry
+
+	// ErrorModel is the error envelope every handler responds with on failure
+	ErrorModel interface{}
+
+	// Authenticators are the middlewares that mark a route as requiring a bearer token
+	Authenticators []func(http.Handler) http.Handler
+
+	// RateLimiters are the middlewares that may reject a route with 429. They are not
+	// probed for role checks, since probing would spend their tokens.
+	RateLimiters []func(http.Handler) http.Handler
+
+	// Roles and RoleContextKey let the generator probe role-check middlewares to
+	// discover which roles each route accepts
+	Roles          []string
+	RoleContextKey interface{}
+
+	// Overrides replaces the generated schema for types with custom JSON encoding
+	Overrides map[reflect.Type]*Schema
+}
+
+const securitySchemeName = "BearerAuth"
+
+var pathParamPattern = regexp.MustCompile(`\{([^}:]+)(:[^}]+)?\}`)
+
+// Generate walks the router and documents every registered route, using the catalog
+// for request and response models. It fails if a catalog entry matches no route.
+func Generate(routes chi.Routes, cfg Config, catalog []Route) (*Document, error) {
+	registry := newSchemaRegistry()
+	for t, schema := range cfg.Overrides {
+		registry.overrides[t] = schema
+	}
+
+	doc := &Document{
+		OpenAPI:   Version,
+		Info:      cfg.Info,
+		Servers:   cfg.Servers,
+		Tags:      cfg.Tags,
+		Paths:     make(map[string]*PathItem),
+		Changelog: cfg.Changelog,
+		Components: Components{
+			Schemas:   registry.schemas,
+			Responses: errorResponses(registry.schemaFor(cfg.ErrorModel)),
+			SecuritySchemes: map[string]*SecurityScheme{
+				securitySchemeName: {
+					Type:         "http",
+					Scheme:       "bearer",
+					BearerFormat: "JWT",
+					Description:  "Access token from /api/v1/auth/login, sent as \"Authorization: Bearer <token>\"",
+				},
+			},
+		},
+	}
+
+	byKey := make(map[string]Route, len(catalog))
+	for _, route := range catalog {
+		byKey[routeKey(route.Method, route.Path)] = route
+	}
+	matched := make(map[string]bool, len(catalog))
+
+	rootMiddlewares := len(routes.Middlewares())
+	err := chi.Walk(routes, func(method, path string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		key := routeKey(method, path)
+		route, ok := byKey[key]
+		matched[key] = ok
+		if route.Hidden {
+			return nil
+		}
+		if !ok {
+			route = Route{Method: method, Path: path}
+		}
+
+		// Router-wide middlewares (logging, rate limits) apply to everything; only
+		// the route's own middlewares decide authentication and roles
+		var routeMiddlewares []func(http.Handler) http.Handler
+		if len(middlewares) > rootMiddlewares {
+			routeMiddlewares = middlewares[rootMiddlewares:]
+		}
+
+		op := buildOperation(registry, cfg, route, handler, routeMiddlewares)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+		switch method {
+		case http.MethodGet:
+			item.Get = op
+		case http.MethodPost:
+			item.Post = op
+		case http.MethodPut:
+			item.Put = op
+		case http.MethodPatch:
+			item.Patch = op
+		case http.MethodDelete:
+			item.Delete = op
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []string
+	for key := range byKey {
+		if !matched[key] {
+			stale = append(stale, key)
+		}
+	}
+	if len(stale) > 0 {
+		sort.Strings(stale)
+		return nil, fmt.Errorf("documented routes are not registered: %s", strings.Join(stale, ", "))
+	}
+
+	return doc, nil
+}
+
+// Undocumented returns the registered routes that have no catalog entry
+func Undocumented(routes chi.Routes, catalog []Route) ([]string, error) {
+	documented := make(map[string]bool, len(catalog))
+	for _, route := range catalog {
+		documented[routeKey(route.Method, route.Path)] = true
+	}
+
+	var missing []string
+	err := chi.Walk(routes, func(method, path string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		if key := routeKey(method, path); !documented[key] {
+			missing = append(missing, key)
+		}
+		return nil
+	})
+	sort.Strings(missing)
+	return missing, err
+}
+
+func routeKey(method, path string) string {
+	return method + " " + path
+}
+
+// buildOperation documents one route
+func buildOperation(registry *schemaRegistry, cfg Config, route Route, handler http.Handler, middlewares []func(http.Handler) http.Handler) *Operation {
+	op := &Operation{
+		OperationID: route.OperationID,
+		Summary:     route.Summary,
+		Description: route.Description,
+		Responses:   make(map[string]*Response),
+	}
+	if op.OperationID == "" {
+		op.OperationID = handlerName(handler, route.Method, route.Path)
+	}
+	if route.Tag != "" {
+		op.Tags = []string{route.Tag}
+	}
+
+	// Path parameters come from the route pattern
+	for _, match := range pathParamPattern.FindAllStringSubmatch(route.Path, -1) {
+		name := match[1]
+		schema := &Schema{Type: "string"}
+		if name == "id" || strings.HasSuffix(name, "Id") || strings.HasSuffix(name, "ID") {
+			schema = &Schema{Type: "integer", Format: "int32"}
+		}
+		op.Parameters = append(op.Parameters, Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	for _, param := range route.Query {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:        param.Name,
+			In:          "query",
+			Description: param.Description,
+			Required:    param.Required,
+			Schema:      registry.schemaFor(param.Example),
+		})
+	}
+
+	if route.Request != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{"application/json": {Schema: registry.schemaFor(route.Request)}},
+		}
+		op.Responses["400"] = &Response{Ref: "#/components/responses/BadRequest"}
+	}
+	if route.Upload != "" {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]*MediaType{"multipart/form-data": {Schema: &Schema{
+				Type:       "object",
+				Properties: map[string]*Schema{route.Upload: {Type: "string", Format: "binary"}},
+				Required:   []string{route.Upload},
+			}}},
+		}
+		op.Responses["400"] = &Response{Ref: "#/components/responses/BadRequest"}
+	}
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := &Response{Description: http.StatusText(status)}
+	if route.Response != nil {
+		contentType := route.ContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		success.Content = map[string]*MediaType{contentType: {Schema: registry.schemaFor(route.Response)}}
+	}
+	op.Responses[fmt.Sprint(status)] = success
+
+	authenticated, roles := routeAccess(cfg, middlewares)
+	if authenticated {
+		op.Security = []map[string][]string{{securitySchemeName: {}}}
+		op.Responses["401"] = &Response{Ref: "#/components/responses/Unauthorized"}
+	}
+	if len(roles) > 0 {
+		op.Roles = roles
+		op.Responses["403"] = &Response{Ref: "#/components/responses/Forbidden"}
+	}
+	if rateLimited(cfg, middlewares) {
+		op.Responses["429"] = &Response{Ref: "#/components/responses/TooManyRequests"}
+	}
+	if strings.Contains(route.Path, "{") {
+		op.Responses["404"] = &Response{Ref: "#/components/responses/NotFound"}
+	}
+	op.Responses["500"] = &Response{Ref: "#/components/responses/InternalError"}
+
+	return op
+}
+
+// routeAccess reports whether a route requires a token and which roles it accepts.
+// Role checks are discovered by running each middleware against a request carrying
+// each candidate role; a nil role list means any authenticated user.
+func routeAccess(cfg Config, middlewares []func(http.Handler) http.Handler) (bool, []string) {
+	authenticated := false
+	allowed := append([]string(nil), cfg.Roles...)
+	restricted := false
+
+	for _, mw := range middlewares {
+		if containsMiddleware(cfg.Authenticators, mw) {
+			authenticated = true
+			continue
+		}
+		if containsMiddleware(cfg.RateLimiters, mw) {
+			continue
+		}
+		if cfg.RoleContextKey == nil {
+			continue
+		}
+
+		var passed []string
+		for _, role := range allowed {
+			reached := false
+			probe := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { reached = true }))
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req = req.WithContext(context.WithValue(req.Context(), cfg.RoleContextKey, role))
+			probe.ServeHTTP(httptest.NewRecorder(), req)
+			if reached {
+				passed = append(passed, role)
+			}
+		}
+		if len(passed) < len(allowed) {
+			restricted = true
+		}
+		allowed = passed
+	}
+
+	if !restricted {
+		return authenticated, nil
+	}
+	return authenticated, allowed
+}
+
+// rateLimited reports whether any of a route's middlewares is a rate limiter
+func rateLimited(cfg Config, middlewares []func(http.Handler) http.Handler) bool {
+	for _, mw := range middlewares {
+		if containsMiddleware(cfg.RateLimiters, mw) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsMiddleware reports whether mw is one of middlewares, compared by function
+func containsMiddleware(middlewares []func(http.Handler) http.Handler, mw func(http.Handler) http.Handler) bool {
+	ptr := reflect.ValueOf(mw).Pointer()
+	for _, candidate := range middlewares {
+		if reflect.ValueOf(candidate).Pointer() == ptr {
+			return true
+		}
+	}
+	return false
+}
+
+// handlerName derives an operation ID from the handler's function name
+func handlerName(handler http.Handler, method, path string) string {
+	if fn := runtime.FuncForPC(reflect.ValueOf(handler).Pointer()); fn != nil && reflect.TypeOf(handler).Kind() == reflect.Func {
+		name := fn.Name()
+		if idx := strings.LastIndex(name, "."); idx != -1 {
+			name = name[idx+1:]
+		}
+		if !strings.HasPrefix(name, "func") {
+			return name
+		}
+	}
+
+	id := strings.ToLower(method)
+	for _, part := range strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '{' || r == '}' || r == '-' }) {
+		id += strings.ToUpper(part[:1]) + part[1:]
+	}
+	return id
+}
+
+// errorResponses are the shared error responses, all using the error envelope
+func errorResponses(errorSchema *Schema) map[string]*Response {
+	response := func(description string) *Response {
+		return &Response{
+			Description: description,
+			Content:     map[string]*MediaType{"application/json": {Schema: errorSchema}},
+		}
+	}
+	return map[string]*Response{
+		"BadRequest":    response("The request was malformed (BAD_REQUEST, INVALID_JSON) or failed validation (VALIDATION_ERROR, with details per field)"),
+		"Unauthorized":  response("Missing, invalid or expired access token (UNAUTHORIZED, INVALID_TOKEN, TOKEN_EXPIRED)"),
+		"Forbidden":     response("The caller's role or identity does not permit this action (FORBIDDEN)"),
+		"NotFound":      response("The resource does not exist (NOT_FOUND or a specific code such as JOB_NOT_FOUND)"),
+		"InternalError": response("Unexpected server error (INTERNAL_ERROR)"),
+		// Retry-After gives the seconds to wait
+		"TooManyRequests": response("Rate limit exceeded (RATE_LIMITED); retry after the number of seconds in the Retry-After header"),
+	}
+}
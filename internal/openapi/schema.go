@@ -0,0 +1,248 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fields describes an ad-hoc JSON object, such as a response envelope built from a
+// map. Each value is an example of the field's Go type, or a *Schema.
+type Fields map[string]interface{}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	rawJSONType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaRegistry converts Go types to schemas, collecting named structs as components
+type schemaRegistry struct {
+	schemas   map[string]*Schema
+	types     map[string]reflect.Type
+	overrides map[reflect.Type]*Schema
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas:   make(map[string]*Schema),
+		types:     make(map[string]reflect.Type),
+		overrides: make(map[reflect.Type]*Schema),
+	}
+}
+
+// schemaFor returns the schema for an example value
+func (s *schemaRegistry) schemaFor(v interface{}) *Schema {
+	switch value := v.(type) {
+	case nil:
+		return &Schema{}
+	case *Schema:
+		return value
+	case Fields:
+		return s.fieldsSchema(value)
+	case []Fields:
+		items := &Schema{Type: "object"}
+		if len(value) > 0 {
+			items = s.fieldsSchema(value[0])
+		}
+		return &Schema{Type: "array", Items: items}
+	}
+	return s.schemaForType(reflect.TypeOf(v))
+}
+
+// fieldsSchema builds an inline object schema from Fields
+func (s *schemaRegistry) fieldsSchema(fields Fields) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for name, example := range fields {
+		schema.Properties[name] = s.schemaFor(example)
+		schema.Required = append(schema.Required, name)
+	}
+	sort.Strings(schema.Required)
+	return schema
+}
+
+func (s *schemaRegistry) schemaForType(t reflect.Type) *Schema {
+	if override, ok := s.overrides[t]; ok {
+		copied := *override
+		return &copied
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawJSONType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := s.schemaForType(t.Elem())
+		if schema.Ref != "" {
+			return schema
+		}
+		schema.Nullable = true
+		return schema
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.schemaForType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.schemaForType(t.Elem())}
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.structSchema(t)
+		}
+		name := s.componentName(t)
+		if _, ok := s.schemas[name]; !ok {
+			// Register before walking fields so self-referencing types terminate
+			s.schemas[name] = &Schema{}
+			s.types[name] = t
+			*s.schemas[name] = *s.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+	return &Schema{}
+}
+
+// componentName names a struct's component schema, qualifying it with the package
+// name when another package already registered a type with the same name
+func (s *schemaRegistry) componentName(t reflect.Type) string {
+	name := t.Name()
+	if existing, ok := s.types[name]; ok && existing != t {
+		pkg := t.PkgPath()
+		if idx := strings.LastIndex(pkg, "/"); idx != -1 {
+			pkg = pkg[idx+1:]
+		}
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+	return name
+}
+
+// structSchema builds an object schema from a struct's exported, JSON-tagged fields
+func (s *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts := parseJSONTag(field.Tag.Get("json"))
+		if name == "-" && opts == "" {
+			continue
+		}
+
+		// Embedded structs without a JSON name are flattened into the parent
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				inner := s.structSchema(embedded)
+				for propName, prop := range inner.Properties {
+					schema.Properties[propName] = prop
+				}
+				schema.Required = append(schema.Required, inner.Required...)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		prop := s.schemaForType(field.Type)
+		required := applyValidateTag(prop, field.Tag.Get("validate"))
+		schema.Properties[name] = prop
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	sort.Strings(schema.Required)
+	return schema
+}
+
+// parseJSONTag splits a json struct tag into its name and options
+func parseJSONTag(tag string) (string, string) {
+	if idx := strings.Index(tag, ","); idx != -1 {
+		return tag[:idx], tag[idx+1:]
+	}
+	return tag, ""
+}
+
+// applyValidateTag maps go-playground/validator rules onto the schema and reports
+// whether the field is required
+func applyValidateTag(schema *Schema, tag string) bool {
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		if key == "required" {
+			required = true
+			continue
+		}
+		// Referenced component schemas are shared and cannot carry field-level rules
+		if schema.Ref != "" {
+			continue
+		}
+		switch key {
+		case "email":
+			schema.Format = "email"
+		case "url":
+			schema.Format = "uri"
+		case "oneof":
+			schema.Enum = strings.Fields(value)
+		case "min", "gte":
+			setBound(schema, value, true)
+		case "max", "lte":
+			setBound(schema, value, false)
+		case "len":
+			setBound(schema, value, true)
+			setBound(schema, value, false)
+		}
+	}
+	return required
+}
+
+// setBound applies a min or max rule as a length limit for strings and a value limit for numbers
+func setBound(schema *Schema, value string, lower bool) {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return
+	}
+
+	switch schema.Type {
+	case "string":
+		length := int(n)
+		if lower {
+			schema.MinLength = &length
+		} else {
+			schema.MaxLength = &length
+		}
+	case "integer", "number":
+		if lower {
+			schema.Minimum = &n
+		} else {
+			schema.Maximum = &n
+		}
+	}
+}
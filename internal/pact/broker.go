@@ -0,0 +1,229 @@
+package pact
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrNotConfigured is returned when PACT_BROKER_URL is not set
+var ErrNotConfigured = errors.New("pact broker not configured")
+
+// Selector picks the consumer versions whose contracts are verified
+type Selector struct {
+	MainBranch         bool   `json:"mainBranch,omitempty"`
+	MatchingBranch     bool   `json:"matchingBranch,omitempty"`
+	DeployedOrReleased bool   `json:"deployedOrReleased,omitempty"`
+	Branch             string `json:"branch,omitempty"`
+	Consumer           string `json:"consumer,omitempty"`
+}
+
+// DefaultSelectors verify the consumers' main branches, the versions deployed or
+// released, and any consumer branch named like the provider's
+var DefaultSelectors = []Selector{{MainBranch: true}, {DeployedOrReleased: true}, {MatchingBranch: true}}
+
+// Broker fetches contracts from a Pact Broker and publishes verification results
+type Broker struct {
+	baseURL    string
+	token      string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// BrokerConfig identifies the provider version being verified
+type BrokerConfig struct {
+	Provider        string     // Provider name in the contracts
+	ProviderVersion string     // Usually the commit SHA
+	ProviderBranch  string     // Branch the version was built from
+	Selectors       []Selector // Defaults to DefaultSelectors
+	Publish         bool       // Publish verification results; only CI builds should
+}
+
+// NewBroker creates a broker client. Authenticate with a token, or a username and
+// password.
+func NewBroker(baseURL, token, username, password string) *Broker {
+	return &Broker{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		token:      token,
+		username:   username,
+		password:   password,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// NewBrokerFromEnv creates a broker client and configuration from PACT_BROKER_URL,
+// PACT_BROKER_TOKEN or PACT_BROKER_USERNAME and PACT_BROKER_PASSWORD,
+// PACT_PROVIDER_VERSION, PACT_PROVIDER_BRANCH, PACT_CONSUMER_VERSION_SELECTORS (a JSON
+// list) and PACT_PUBLISH_VERIFICATION_RESULTS
+func NewBrokerFromEnv(provider string) (*Broker, *BrokerConfig, error) {
+	baseURL := os.Getenv("PACT_BROKER_URL")
+	if baseURL == "" {
+		return nil, nil, ErrNotConfigured
+	}
+	cfg := &BrokerConfig{
+		Provider:        provider,
+		ProviderVersion: os.Getenv("PACT_PROVIDER_VERSION"),
+		ProviderBranch:  os.Getenv("PACT_PROVIDER_BRANCH"),
+		Publish:         os.Getenv("PACT_PUBLISH_VERIFICATION_RESULTS") == "true",
+	}
+	if v := os.Getenv("PACT_CONSUMER_VERSION_SELECTORS"); v != "" {
+		if err := json.Unmarshal([]byte(v), &cfg.Selectors); err != nil {
+			return nil, nil, fmt.Errorf("invalid PACT_CONSUMER_VERSION_SELECTORS: %w", err)
+		}
+	}
+	if cfg.Publish && cfg.ProviderVersion == "" {
+		return nil, nil, fmt.Errorf("PACT_PROVIDER_VERSION is required to publish verification results")
+	}
+	broker := NewBroker(baseURL, os.Getenv("PACT_BROKER_TOKEN"), os.Getenv("PACT_BROKER_USERNAME"), os.Getenv("PACT_BROKER_PASSWORD"))
+	return broker, cfg, nil
+}
+
+// Contract is a contract to verify, as selected by the broker
+type Contract struct {
+	File    *File
+	URL     string
+	Pending bool     // Failures should not fail the build until the provider first passes
+	Notices []string // Why the broker selected the contract
+}
+
+// ContractsForVerification fetches the contracts the selectors pick for the provider
+func (b *Broker) ContractsForVerification(ctx context.Context, cfg *BrokerConfig) ([]Contract, error) {
+	selectors := cfg.Selectors
+	if len(selectors) == 0 {
+		selectors = DefaultSelectors
+	}
+	request := map[string]interface{}{
+		"consumerVersionSelectors": selectors,
+		"includePendingStatus":     true,
+	}
+	if cfg.ProviderBranch != "" {
+		request["providerVersionBranch"] = cfg.ProviderBranch
+	}
+
+	var found struct {
+		Embedded struct {
+			Pacts []struct {
+				VerificationProperties struct {
+					Pending bool `json:"pending"`
+					Notices []struct {
+						Text string `json:"text"`
+					} `json:"notices"`
+				} `json:"verificationProperties"`
+				Links struct {
+					Self Link `json:"self"`
+				} `json:"_links"`
+			} `json:"pacts"`
+		} `json:"_embedded"`
+	}
+	path := "/pacts/provider/" + url.PathEscape(cfg.Provider) + "/for-verification"
+	if err := b.do(ctx, http.MethodPost, b.baseURL+path, request, &found); err != nil {
+		return nil, fmt.Errorf("failed to find pacts for verification: %w", err)
+	}
+
+	contracts := make([]Contract, 0, len(found.Embedded.Pacts))
+	for _, p := range found.Embedded.Pacts {
+		var raw json.RawMessage
+		if err := b.do(ctx, http.MethodGet, p.Links.Self.Href, nil, &raw); err != nil {
+			return nil, fmt.Errorf("failed to fetch pact %s: %w", p.Links.Self.Href, err)
+		}
+		f, err := Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p.Links.Self.Href, err)
+		}
+		c := Contract{File: f, URL: p.Links.Self.Href, Pending: p.VerificationProperties.Pending}
+		for _, notice := range p.VerificationProperties.Notices {
+			c.Notices = append(c.Notices, notice.Text)
+		}
+		contracts = append(contracts, c)
+	}
+	return contracts, nil
+}
+
+// PublishResults records the provider version's verification of a contract, first
+// recording which branch the version belongs to
+func (b *Broker) PublishResults(ctx context.Context, cfg *BrokerConfig, c Contract, results []Result) error {
+	link, ok := c.File.Links["pb:publish-verification-results"]
+	if !ok {
+		return fmt.Errorf("pact %s has no pb:publish-verification-results link", c.URL)
+	}
+
+	if cfg.ProviderBranch != "" {
+		path := fmt.Sprintf("/pacticipants/%s/branches/%s/versions/%s",
+			url.PathEscape(cfg.Provider), url.PathEscape(cfg.ProviderBranch), url.PathEscape(cfg.ProviderVersion))
+		if err := b.do(ctx, http.MethodPut, b.baseURL+path, map[string]interface{}{}, nil); err != nil {
+			return fmt.Errorf("failed to record provider branch: %w", err)
+		}
+	}
+
+	success := true
+	testResults := make([]map[string]interface{}, 0, len(results))
+	for _, r := range results {
+		success = success && r.OK()
+		testResults = append(testResults, map[string]interface{}{
+			"testDescription": r.Interaction,
+			"success":         r.OK(),
+			"mismatches":      r.Mismatches,
+		})
+	}
+	body := map[string]interface{}{
+		"success":                    success,
+		"providerApplicationVersion": cfg.ProviderVersion,
+		"testResults":                testResults,
+		"verifiedBy":                 map[string]string{"implementation": "app/internal/pact"},
+	}
+	if err := b.do(ctx, http.MethodPost, link.Href, body, nil); err != nil {
+		return fmt.Errorf("failed to publish verification results: %w", err)
+	}
+	return nil
+}
+
+// do sends a HAL JSON request and decodes the response into out, if given
+func (b *Broker) do(ctx context.Context, method, target string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/hal+json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if b.token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	} else if b.username != "" {
+		req.SetBasicAuth(b.username, b.password)
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned %d: %s", method, target, resp.StatusCode, truncate(string(data), 200))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,421 @@
+package pact
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"mime"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Matcher is one matching rule. Min and Max bound array lengths and imply type
+// matching of the items.
+type Matcher struct {
+	Match  string `json:"match"` // equality, type, regex, include, integer, decimal, number, boolean, null, date, time or timestamp
+	Regex  string `json:"regex,omitempty"`
+	Value  string `json:"value,omitempty"`  // For include
+	Format string `json:"format,omitempty"` // For date, time and timestamp
+	Min    *int   `json:"min,omitempty"`
+	Max    *int   `json:"max,omitempty"`
+}
+
+// Rule is the matchers for one path, of which all (AND) or any (OR) must pass
+type Rule struct {
+	Matchers []Matcher `json:"matchers"`
+	Combine  string    `json:"combine,omitempty"`
+}
+
+// MatchingRules are a response's matching rules: body rules keyed by a JSON path from
+// the body root ($) and header rules keyed by header name. A body rule also applies
+// below its path unless a more specific rule does, as in the Pact reference verifier.
+type MatchingRules struct {
+	Body   map[string]Rule
+	Header map[string]Rule
+
+	paths map[string][]string // Parsed body rule paths
+}
+
+// UnmarshalJSON reads version 2 rules, keyed by paths such as $.body.jobs and
+// $.headers.Content-Type, and version 3 rules, grouped by body and header
+func (m *MatchingRules) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("matchingRules must be an object: %w", err)
+	}
+	m.Body = make(map[string]Rule)
+	m.Header = make(map[string]Rule)
+
+	for key, value := range raw {
+		if strings.HasPrefix(key, "$") {
+			var matcher Matcher
+			if err := json.Unmarshal(value, &matcher); err != nil {
+				return fmt.Errorf("matching rule %s: %w", key, err)
+			}
+			rule := Rule{Matchers: []Matcher{matcher}}
+			switch {
+			case key == "$.body" || strings.HasPrefix(key, "$.body.") || strings.HasPrefix(key, "$.body["):
+				m.Body["$"+strings.TrimPrefix(key, "$.body")] = rule
+			case strings.HasPrefix(key, "$.headers."):
+				m.Header[strings.TrimPrefix(key, "$.headers.")] = rule
+			case strings.HasPrefix(key, "$.header."):
+				m.Header[strings.TrimPrefix(key, "$.header.")] = rule
+			}
+			continue
+		}
+
+		var rules map[string]Rule
+		switch key {
+		case "body":
+			rules = m.Body
+		case "header", "headers":
+			rules = m.Header
+		default:
+			continue // Status, path and query rules do not apply to responses
+		}
+		var group map[string]Rule
+		if err := json.Unmarshal(value, &group); err != nil {
+			return fmt.Errorf("matching rules for %s: %w", key, err)
+		}
+		for path, rule := range group {
+			rules[path] = rule
+		}
+	}
+
+	m.paths = make(map[string][]string, len(m.Body))
+	for expr, rule := range m.Body {
+		path, err := parsePath(expr)
+		if err != nil {
+			return fmt.Errorf("matching rule %s: %w", expr, err)
+		}
+		for i, matcher := range rule.Matchers {
+			if matcher.Match == "" && (matcher.Min != nil || matcher.Max != nil) {
+				rule.Matchers[i].Match = "type"
+			}
+		}
+		m.paths[expr] = path
+	}
+	return nil
+}
+
+// bodyRule returns the most specific rule for path or its nearest parent, and whether
+// it is defined for path itself
+func (m MatchingRules) bodyRule(path []string) (rule Rule, exact, ok bool) {
+	bestWeight, bestLen := -1, -1
+	for expr, rulePath := range m.paths {
+		if len(rulePath) > len(path) {
+			continue
+		}
+		weight := 0
+		for i, segment := range rulePath {
+			if segment == path[i] {
+				weight += 2
+			} else if segment == "*" {
+				weight++
+			} else {
+				weight = -1
+				break
+			}
+		}
+		if weight > bestWeight || (weight == bestWeight && len(rulePath) > bestLen) {
+			bestWeight, bestLen = weight, len(rulePath)
+			rule, exact, ok = m.Body[expr], len(rulePath) == len(path), true
+		}
+	}
+	return rule, exact, ok
+}
+
+// parsePath splits a JSON path such as $.jobs[*].title or $['first name'] into its
+// segments, with * for wildcards
+func parsePath(expr string) ([]string, error) {
+	if !strings.HasPrefix(expr, "$") {
+		return nil, fmt.Errorf("path must start with $")
+	}
+	path := []string{"$"}
+	rest := expr[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			name := rest[1 : end+1]
+			if name == "" {
+				return nil, fmt.Errorf("empty field name")
+			}
+			path = append(path, name)
+			rest = rest[end+1:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed [")
+			}
+			path = append(path, strings.Trim(rest[1:end], `'"`))
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected %q", rest[0])
+		}
+	}
+	return path, nil
+}
+
+// formatPath renders path segments as a JSON path
+func formatPath(path []string) string {
+	var b strings.Builder
+	for i, segment := range path {
+		switch {
+		case i == 0:
+			b.WriteString(segment)
+		case isIndex(segment):
+			b.WriteString("[" + segment + "]")
+		default:
+			b.WriteString("." + segment)
+		}
+	}
+	return b.String()
+}
+
+func isIndex(segment string) bool {
+	_, err := strconv.Atoi(segment)
+	return err == nil
+}
+
+// compareBody records where actual fails to satisfy expected: every expected object
+// field must be present, arrays must have the same length unless a type rule makes
+// the first expected item a template, and other values must be equal unless a rule
+// applies.
+func compareBody(expected, actual interface{}, path []string, rules MatchingRules, mismatches *[]string) {
+	rule, exact, ruled := rules.bodyRule(path)
+	fail := func(format string, args ...interface{}) {
+		*mismatches = append(*mismatches, formatPath(path)+": "+fmt.Sprintf(format, args...))
+	}
+
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			fail("expected an object, got %s", describe(actual))
+			return
+		}
+		for _, key := range sortedKeys(e) {
+			child := append(append([]string(nil), path...), key)
+			value, present := a[key]
+			if !present {
+				*mismatches = append(*mismatches, formatPath(child)+": missing")
+				continue
+			}
+			compareBody(e[key], value, child, rules, mismatches)
+		}
+
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			fail("expected an array, got %s", describe(actual))
+			return
+		}
+		if ruled && rule.typed() {
+			if exact {
+				for _, matcher := range rule.Matchers {
+					if matcher.Min != nil && len(a) < *matcher.Min {
+						fail("expected at least %d items, got %d", *matcher.Min, len(a))
+					}
+					if matcher.Max != nil && len(a) > *matcher.Max {
+						fail("expected at most %d items, got %d", *matcher.Max, len(a))
+					}
+				}
+			}
+			if len(e) > 0 {
+				for i, value := range a {
+					compareBody(e[0], value, append(append([]string(nil), path...), strconv.Itoa(i)), rules, mismatches)
+				}
+			}
+			return
+		}
+		if len(a) != len(e) {
+			fail("expected %d items, got %d", len(e), len(a))
+		}
+		for i := 0; i < len(e) && i < len(a); i++ {
+			compareBody(e[i], a[i], append(append([]string(nil), path...), strconv.Itoa(i)), rules, mismatches)
+		}
+
+	default:
+		if ruled {
+			if err := rule.check(expected, actual); err != nil {
+				fail("%v", err)
+			}
+			return
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			fail("expected %s, got %s", jsonText(expected), jsonText(actual))
+		}
+	}
+}
+
+// typed reports whether the rule matches arrays by item type
+func (r Rule) typed() bool {
+	for _, matcher := range r.Matchers {
+		if matcher.Match == "type" {
+			return true
+		}
+	}
+	return false
+}
+
+// check applies the rule to a value
+func (r Rule) check(expected, actual interface{}) error {
+	var errs []string
+	for _, matcher := range r.Matchers {
+		err := matcher.check(expected, actual)
+		if err == nil && strings.EqualFold(r.Combine, "OR") {
+			return nil
+		}
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(errs, "; "))
+}
+
+// check applies one matcher to a value. Dates, times and timestamps with a format are
+// only checked to be strings, since formats are Java date patterns.
+func (m Matcher) check(expected, actual interface{}) error {
+	switch m.Match {
+	case "equality":
+		if !reflect.DeepEqual(expected, actual) {
+			return fmt.Errorf("expected %s, got %s", jsonText(expected), jsonText(actual))
+		}
+	case "type":
+		if describe(expected) != describe(actual) {
+			return fmt.Errorf("expected %s, got %s", describe(expected), describe(actual))
+		}
+	case "regex":
+		re, err := regexp.Compile(`^(?:` + m.Regex + `)$`)
+		if err != nil {
+			return fmt.Errorf("invalid regex %q: %v", m.Regex, err)
+		}
+		s, ok := scalarText(actual)
+		if !ok || !re.MatchString(s) {
+			return fmt.Errorf("expected a value matching %q, got %s", m.Regex, jsonText(actual))
+		}
+	case "include":
+		s, ok := actual.(string)
+		if !ok || !strings.Contains(s, m.Value) {
+			return fmt.Errorf("expected a string including %q, got %s", m.Value, jsonText(actual))
+		}
+	case "integer":
+		n, ok := actual.(float64)
+		if !ok || n != math.Trunc(n) {
+			return fmt.Errorf("expected an integer, got %s", jsonText(actual))
+		}
+	case "decimal", "number":
+		if _, ok := actual.(float64); !ok {
+			return fmt.Errorf("expected a number, got %s", jsonText(actual))
+		}
+	case "boolean":
+		if _, ok := actual.(bool); !ok {
+			return fmt.Errorf("expected a boolean, got %s", jsonText(actual))
+		}
+	case "null":
+		if actual != nil {
+			return fmt.Errorf("expected null, got %s", jsonText(actual))
+		}
+	case "date", "time", "timestamp", "datetime":
+		s, ok := actual.(string)
+		if !ok {
+			return fmt.Errorf("expected a %s, got %s", m.Match, jsonText(actual))
+		}
+		if m.Format != "" {
+			return nil
+		}
+		layout := map[string]string{"date": "2006-01-02", "time": "15:04:05"}[m.Match]
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		if _, err := time.Parse(layout, s); err != nil {
+			return fmt.Errorf("expected a %s, got %s", m.Match, jsonText(actual))
+		}
+	default:
+		return fmt.Errorf("unsupported matcher %q", m.Match)
+	}
+	return nil
+}
+
+// compareHeader checks one expected header value. Content types compare by media
+// type, and any parameters the consumer lists must be present.
+func compareHeader(name, expected, actual string, rules MatchingRules) error {
+	for ruleName, rule := range rules.Header {
+		if strings.EqualFold(ruleName, name) {
+			return rule.check(expected, actual)
+		}
+	}
+	if strings.EqualFold(name, "Content-Type") {
+		wantType, wantParams, err1 := mime.ParseMediaType(expected)
+		gotType, gotParams, err2 := mime.ParseMediaType(actual)
+		if err1 == nil && err2 == nil && wantType == gotType {
+			for param, value := range wantParams {
+				if !strings.EqualFold(gotParams[param], value) {
+					return fmt.Errorf("expected %q, got %q", expected, actual)
+				}
+			}
+			return nil
+		}
+	}
+	if normalizeHeader(expected) != normalizeHeader(actual) {
+		return fmt.Errorf("expected %q, got %q", expected, actual)
+	}
+	return nil
+}
+
+func normalizeHeader(value string) string {
+	parts := strings.Split(value, ",")
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+	return strings.Join(parts, ",")
+}
+
+// describe names a decoded JSON value's type
+func describe(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func scalarText(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
+
+func jsonText(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -0,0 +1,192 @@
+// Package pact verifies the API against consumer-driven contracts in the Pact format
+// (specification versions 2 and 3). Consumers such as the mobile app publish the
+// requests they make and the parts of each response they rely on to a Pact Broker;
+// the Verifier replays those requests against the router and reports where the
+// responses no longer satisfy them.
+package pact
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+)
+
+// File is a contract between one consumer and one provider
+type File struct {
+	Consumer     Pacticipant     `json:"consumer"`
+	Provider     Pacticipant     `json:"provider"`
+	Interactions []Interaction   `json:"interactions"`
+	Metadata     Metadata        `json:"metadata"`
+	Links        map[string]Link `json:"_links,omitempty"` // Set on contracts fetched from a broker
+}
+
+// Pacticipant names a consumer or provider
+type Pacticipant struct {
+	Name string `json:"name"`
+}
+
+// Metadata records the specification the contract was written to
+type Metadata struct {
+	PactSpecification struct {
+		Version string `json:"version"`
+	} `json:"pactSpecification"`
+}
+
+// Link is a broker HAL link
+type Link struct {
+	Href  string `json:"href"`
+	Name  string `json:"name,omitempty"`
+	Title string `json:"title,omitempty"`
+}
+
+// Interaction is one request the consumer makes and the response it expects
+type Interaction struct {
+	Description    string          `json:"description"`
+	ProviderState  string          `json:"providerState,omitempty"` // Version 2
+	ProviderStates []ProviderState `json:"providerStates,omitempty"`
+	Request        Request         `json:"request"`
+	Response       Response        `json:"response"`
+}
+
+// States returns the provider states the interaction needs, in either version's form
+func (i Interaction) States() []ProviderState {
+	if i.ProviderState != "" {
+		return append([]ProviderState{{Name: i.ProviderState}}, i.ProviderStates...)
+	}
+	return i.ProviderStates
+}
+
+// ProviderState names data the provider must hold before an interaction is replayed
+type ProviderState struct {
+	Name   string                 `json:"name"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+// Request is the request the consumer sends
+type Request struct {
+	Method  string          `json:"method"`
+	Path    string          `json:"path"`
+	Query   Query           `json:"query,omitempty"`
+	Headers Headers         `json:"headers,omitempty"`
+	Body    json.RawMessage `json:"body,omitempty"`
+}
+
+// Response is what the consumer expects back. Only the headers and body fields it
+// lists are checked; matching rules loosen equality to type or pattern checks.
+type Response struct {
+	Status        int             `json:"status"`
+	Headers       Headers         `json:"headers,omitempty"`
+	Body          json.RawMessage `json:"body,omitempty"`
+	MatchingRules MatchingRules   `json:"matchingRules,omitempty"`
+}
+
+// Query is a request query string: a string in version 2 and a map of values in
+// version 3
+type Query url.Values
+
+// UnmarshalJSON implements json.Unmarshaler
+func (q *Query) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		values, err := url.ParseQuery(raw)
+		if err != nil {
+			return fmt.Errorf("invalid query %q: %w", raw, err)
+		}
+		*q = Query(values)
+		return nil
+	}
+
+	var values map[string]stringList
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("query must be a string or an object: %w", err)
+	}
+	*q = make(Query, len(values))
+	for name, list := range values {
+		(*q)[name] = list
+	}
+	return nil
+}
+
+// Encode returns the query in URL form, sorted by name
+func (q Query) Encode() string {
+	return url.Values(q).Encode()
+}
+
+// Headers are HTTP headers; version 3 allows each to be a list of values
+type Headers map[string]string
+
+// UnmarshalJSON implements json.Unmarshaler
+func (h *Headers) UnmarshalJSON(data []byte) error {
+	var values map[string]stringList
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("headers must be an object: %w", err)
+	}
+	*h = make(Headers, len(values))
+	for name, list := range values {
+		(*h)[name] = strings.Join(list, ", ")
+	}
+	return nil
+}
+
+// stringList is a string or a list of strings
+type stringList []string
+
+func (s *stringList) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*s = stringList{one}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("must be a string or a list of strings")
+	}
+	*s = list
+	return nil
+}
+
+// Parse reads a contract
+func Parse(data []byte) (*File, error) {
+	var f File
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("invalid pact: %w", err)
+	}
+	if f.Consumer.Name == "" || f.Provider.Name == "" {
+		return nil, fmt.Errorf("invalid pact: consumer and provider names are required")
+	}
+	if v := f.Metadata.PactSpecification.Version; v != "" && !strings.HasPrefix(v, "2.") && !strings.HasPrefix(v, "3.") {
+		return nil, fmt.Errorf("unsupported pact specification version %s", v)
+	}
+	for i, interaction := range f.Interactions {
+		if interaction.Description == "" || interaction.Request.Method == "" || interaction.Request.Path == "" || interaction.Response.Status == 0 {
+			return nil, fmt.Errorf("invalid pact: interaction %d needs a description, request method and path, and response status", i+1)
+		}
+	}
+	return &f, nil
+}
+
+// Load reads a contract from a file
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, nil
+}
+
+// sortedKeys returns a map's keys in order, for stable mismatch reports
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,163 @@
+package pact
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCompareResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string // Response JSON
+		body     string
+		header   string
+		want     []string
+	}{
+		{
+			name:     "extra fields are allowed",
+			expected: `{"status": 200, "body": {"id": 7}}`,
+			body:     `{"id": 7, "title": "Fix a tap"}`,
+		},
+		{
+			name:     "missing fields and changed values",
+			expected: `{"status": 200, "body": {"id": 7, "title": "Fix a tap"}}`,
+			body:     `{"id": 8}`,
+			want:     []string{"$.id: expected 7, got 8", "$.title: missing"},
+		},
+		{
+			name:     "version 3 type and regex rules",
+			expected: `{"status": 200, "body": {"id": 7, "status": "posted"}, "matchingRules": {"body": {"$.id": {"matchers": [{"match": "type"}]}, "$.status": {"matchers": [{"match": "regex", "regex": "posted|accepted"}]}}}}`,
+			body:     `{"id": 42, "status": "accepted"}`,
+		},
+		{
+			name:     "version 2 rules cascade into arrays",
+			expected: `{"status": 200, "body": {"jobs": [{"id": 1, "tags": ["plumbing"]}]}, "matchingRules": {"$.body.jobs": {"min": 2}}}`,
+			body:     `{"jobs": [{"id": 5, "tags": []}]}`,
+			want:     []string{"$.jobs: expected at least 2 items, got 1"},
+		},
+		{
+			name:     "array items are checked against the template",
+			expected: `{"status": 200, "body": {"jobs": [{"id": 1}]}, "matchingRules": {"body": {"$.jobs": {"matchers": [{"match": "type", "min": 1}]}}}}`,
+			body:     `{"jobs": [{"id": 5}, {"id": "6"}]}`,
+			want:     []string{"$.jobs[1].id: expected number, got string"},
+		},
+		{
+			name:     "arrays without rules must match in length",
+			expected: `{"status": 200, "body": [1, 2]}`,
+			body:     `[1, 2, 3]`,
+			want:     []string{"$: expected 2 items, got 3"},
+		},
+		{
+			name:     "status and content type",
+			expected: `{"status": 201, "headers": {"Content-Type": "application/json; charset=utf-8"}}`,
+			body:     `{}`,
+			header:   "application/json",
+			want:     []string{"status: expected 201, got 200", `header Content-Type: expected "application/json; charset=utf-8", got "application/json"`},
+		},
+		{
+			name:     "integer and timestamp rules",
+			expected: `{"status": 200, "body": {"n": 1, "at": "2026-01-01T00:00:00Z"}, "matchingRules": {"body": {"$.n": {"matchers": [{"match": "integer"}]}, "$.at": {"matchers": [{"match": "timestamp"}]}}}}`,
+			body:     `{"n": 1.5, "at": "yesterday"}`,
+			want:     []string{`$.at: expected a timestamp, got "yesterday"`, "$.n: expected an integer, got 1.5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var expected Response
+			if err := json.Unmarshal([]byte(tt.expected), &expected); err != nil {
+				t.Fatalf("invalid expected response: %v", err)
+			}
+			header := http.Header{}
+			if tt.header != "" {
+				header.Set("Content-Type", tt.header)
+			}
+			got := compareResponse(expected, http.StatusOK, header, []byte(tt.body))
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("compareResponse() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestVerifyWithBroker(t *testing.T) {
+	var published map[string]interface{}
+	var branchRecorded bool
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer broker-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/pacts/provider/GigCoAPI/for-verification":
+			w.Write([]byte(`{"_embedded": {"pacts": [{"verificationProperties": {"pending": true, "notices": [{"text": "pending"}]},
+				"_links": {"self": {"href": "` + server.URL + `/pacts/1"}}}]}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/pacts/1":
+			w.Write([]byte(`{"consumer": {"name": "GigCoMobile"}, "provider": {"name": "GigCoAPI"},
+				"interactions": [{"description": "a job", "providerState": "a job exists",
+					"request": {"method": "GET", "path": "/jobs/7", "query": "fields=id"},
+					"response": {"status": 200, "body": {"id": 7}}}],
+				"_links": {"pb:publish-verification-results": {"href": "` + server.URL + `/results"}}}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/pacticipants/GigCoAPI/branches/main/versions/abc123":
+			branchRecorded = true
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/results":
+			json.NewDecoder(r.Body).Decode(&published)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("PACT_BROKER_URL", server.URL)
+	t.Setenv("PACT_BROKER_TOKEN", "broker-token")
+	t.Setenv("PACT_PROVIDER_VERSION", "abc123")
+	t.Setenv("PACT_PROVIDER_BRANCH", "main")
+	t.Setenv("PACT_PUBLISH_VERIFICATION_RESULTS", "true")
+	broker, cfg, err := NewBrokerFromEnv("GigCoAPI")
+	if err != nil {
+		t.Fatalf("NewBrokerFromEnv() error = %v", err)
+	}
+
+	contracts, err := broker.ContractsForVerification(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("ContractsForVerification() error = %v", err)
+	}
+	if len(contracts) != 1 || !contracts[0].Pending {
+		t.Fatalf("ContractsForVerification() = %+v, want one pending contract", contracts)
+	}
+
+	var stateSet bool
+	verifier := &Verifier{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.RawQuery != "fields=id" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"id": 7, "title": "Fix a tap"}`))
+		}),
+		States: map[string]StateHandler{
+			"a job exists": func(ProviderState) (RequestFilter, error) {
+				stateSet = true
+				return nil, nil
+			},
+		},
+	}
+	results := verifier.Verify(contracts[0].File)
+	if len(results) != 1 || !results[0].OK() || !stateSet {
+		t.Fatalf("Verify() = %+v, state set = %v; want one passing interaction", results, stateSet)
+	}
+
+	if err := broker.PublishResults(context.Background(), cfg, contracts[0], results); err != nil {
+		t.Fatalf("PublishResults() error = %v", err)
+	}
+	if !branchRecorded || published["success"] != true || published["providerApplicationVersion"] != "abc123" {
+		t.Errorf("published %v, branch recorded = %v", published, branchRecorded)
+	}
+}
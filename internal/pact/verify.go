@@ -0,0 +1,158 @@
+package pact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+// StateHandler puts the provider in a state an interaction needs. It may return a
+// RequestFilter to apply to the interaction's request, such as one that replaces the
+// consumer's placeholder token with a valid one.
+type StateHandler func(state ProviderState) (RequestFilter, error)
+
+// RequestFilter changes a request before it is replayed
+type RequestFilter func(r *http.Request)
+
+// Verifier replays contracts against a handler
+type Verifier struct {
+	Handler http.Handler
+	States  map[string]StateHandler
+}
+
+// Result is the outcome of one interaction
+type Result struct {
+	Interaction string
+	States      []string
+	Mismatches  []string
+}
+
+// OK reports whether the response satisfied the contract
+func (r Result) OK() bool { return len(r.Mismatches) == 0 }
+
+// Verify replays every interaction in the contract, in order
+func (v *Verifier) Verify(f *File) []Result {
+	results := make([]Result, 0, len(f.Interactions))
+	for _, interaction := range f.Interactions {
+		results = append(results, v.verifyInteraction(interaction))
+	}
+	return results
+}
+
+func (v *Verifier) verifyInteraction(interaction Interaction) Result {
+	result := Result{Interaction: interaction.Description}
+
+	var filters []RequestFilter
+	for _, state := range interaction.States() {
+		result.States = append(result.States, state.Name)
+		handler, ok := v.States[state.Name]
+		if !ok {
+			result.Mismatches = append(result.Mismatches, fmt.Sprintf("no handler for provider state %q", state.Name))
+			return result
+		}
+		filter, err := handler(state)
+		if err != nil {
+			result.Mismatches = append(result.Mismatches, fmt.Sprintf("provider state %q: %v", state.Name, err))
+			return result
+		}
+		if filter != nil {
+			filters = append(filters, filter)
+		}
+	}
+
+	req := newRequest(interaction.Request)
+	for _, filter := range filters {
+		filter(req)
+	}
+	rec := httptest.NewRecorder()
+	v.Handler.ServeHTTP(rec, req)
+
+	result.Mismatches = compareResponse(interaction.Response, rec.Result().StatusCode, rec.Header(), rec.Body.Bytes())
+	return result
+}
+
+// newRequest builds the interaction's request
+func newRequest(r Request) *http.Request {
+	target := r.Path
+	if len(r.Query) > 0 {
+		target += "?" + r.Query.Encode()
+	}
+
+	var body []byte
+	if len(r.Body) > 0 && string(r.Body) != "null" {
+		body = r.Body
+		// A JSON string body is sent as text unless the request says it is JSON
+		var text string
+		if json.Unmarshal(r.Body, &text) == nil && !strings.Contains(headerValue(r.Headers, "Content-Type"), "json") {
+			body = []byte(text)
+		}
+	}
+
+	req := httptest.NewRequest(r.Method, target, bytes.NewReader(body))
+	for name, value := range r.Headers {
+		req.Header.Set(name, value)
+	}
+	if body != nil && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req
+}
+
+// compareResponse lists where a response fails to satisfy the expected one
+func compareResponse(expected Response, status int, header http.Header, body []byte) []string {
+	var mismatches []string
+	if status != expected.Status {
+		mismatches = append(mismatches, fmt.Sprintf("status: expected %d, got %d", expected.Status, status))
+	}
+
+	for _, name := range sortedKeys(expected.Headers) {
+		actual := header.Get(name)
+		if actual == "" {
+			mismatches = append(mismatches, fmt.Sprintf("header %s: missing", name))
+			continue
+		}
+		if err := compareHeader(name, expected.Headers[name], actual, expected.MatchingRules); err != nil {
+			mismatches = append(mismatches, fmt.Sprintf("header %s: %v", name, err))
+		}
+	}
+
+	if len(expected.Body) == 0 {
+		return mismatches
+	}
+	var want, got interface{}
+	if err := json.Unmarshal(expected.Body, &want); err != nil {
+		return append(mismatches, fmt.Sprintf("body: expected body is not JSON: %v", err))
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		// Text bodies compare as JSON strings
+		if text, ok := want.(string); ok && !strings.Contains(header.Get("Content-Type"), "json") {
+			got = string(body)
+			if text == got {
+				return mismatches
+			}
+		} else {
+			return append(mismatches, fmt.Sprintf("body: expected JSON, got %q", truncate(string(body), 200)))
+		}
+	}
+	compareBody(want, got, []string{"$"}, expected.MatchingRules, &mismatches)
+	return mismatches
+}
+
+func headerValue(headers Headers, name string) string {
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,123 @@
+package payment
+
+import (
+	"context"
+
+	"app/config"
+	"app/internal/model"
+)
+
+// CloverProvider adapts the Clover API to the Provider interface
+type CloverProvider struct {
+	service *CloverService
+	config  *config.CloverConfig
+}
+
+// NewCloverProvider creates a Clover payment provider
+func NewCloverProvider(cfg *config.CloverConfig) *CloverProvider {
+	return &CloverProvider{
+		service: NewCloverService(cfg),
+		config:  cfg,
+	}
+}
+
+// Name returns the provider key
+func (p *CloverProvider) Name() string {
+	return ProviderClover
+}
+
+// Tokenize tokenizes a card through Clover's PAKMS tokenization endpoint
+func (p *CloverProvider) Tokenize(ctx context.Context, card model.CardDetails) (*CardToken, error) {
+	resp, err := p.service.TokenizeCard(ctx, model.CloverCard{
+		Number:       card.Number,
+		ExpMonth:     card.ExpMonth,
+		ExpYear:      card.ExpYear,
+		CVV:          card.CVV,
+		Name:         card.Name,
+		AddressLine1: card.AddressLine1,
+		AddressCity:  card.AddressCity,
+		AddressState: card.AddressState,
+		AddressZip:   card.AddressZip,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CardToken{
+		Token:    resp.ID,
+		Brand:    resp.Card.Brand,
+		Last4:    resp.Card.Last4,
+		ExpMonth: resp.Card.ExpMonth,
+		ExpYear:  resp.Card.ExpYear,
+		Raw:      resp,
+	}, nil
+}
+
+// Authorize creates a Clover charge with capture disabled
+func (p *CloverProvider) Authorize(ctx context.Context, token string, amountCents int64, currency string, metadata map[string]interface{}) (*Charge, error) {
+	resp, err := p.service.AuthorizePayment(ctx, token, amountCents, currency, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Charge{
+		ID:          resp.ID,
+		AmountCents: resp.Amount,
+		Status:      resp.Status,
+		SourceToken: resp.Source.ID,
+		Brand:       resp.Source.Brand,
+		Last4:       resp.Source.Last4,
+		Raw:         resp,
+	}, nil
+}
+
+// Capture captures a held Clover charge
+func (p *CloverProvider) Capture(ctx context.Context, chargeID string, amountCents *int64) (*Capture, error) {
+	resp, err := p.service.CapturePayment(ctx, chargeID, amountCents)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Capture{
+		ID:          resp.ID,
+		AmountCents: resp.Amount,
+		Status:      resp.Status,
+		Raw:         resp,
+	}, nil
+}
+
+// Refund refunds a Clover charge
+func (p *CloverProvider) Refund(ctx context.Context, chargeID string, amountCents *int64, reason string) (*Refund, error) {
+	resp, err := p.service.RefundPayment(ctx, chargeID, amountCents, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Refund{
+		ID:          resp.ID,
+		AmountCents: resp.Amount,
+		Status:      resp.Status,
+		Raw:         resp,
+	}, nil
+}
+
+// Payout is not available on Clover; worker earnings settle to the merchant account
+// and are paid out outside the platform
+func (p *CloverProvider) Payout(ctx context.Context, req PayoutRequest) (*Payout, error) {
+	return nil, ErrPayoutUnsupported
+}
+
+// Fees is the configured platform fee and Clover's processing fee (typically
+// 2.6% + $0.10)
+func (p *CloverProvider) Fees() FeeSchedule {
+	return FeeSchedule{
+		PlatformPercent:   p.config.PlatformFeePercent,
+		ProcessingPercent: 2.6,
+		ProcessingFixed:   model.USD(10),
+	}
+}
+
+// CalculateNetAmount splits amount under the default fee schedule
+func (p *CloverProvider) CalculateNetAmount(amount model.Money) (netAmount, platformFee, processingFee model.Money) {
+	return p.Fees().Split(amount)
+}
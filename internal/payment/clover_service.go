@@ -2,13 +2,20 @@ package payment
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
+	"math"
 	"net/http"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"app/config"
+	"app/internal/faults"
 	"app/internal/model"
 )
 
@@ -16,6 +23,7 @@ import (
 type CloverService struct {
 	config     *config.CloverConfig
 	httpClient *http.Client
+	limiter    *ProviderLimiter
 }
 
 // NewCloverService creates a new Clover service instance
@@ -25,6 +33,7 @@ func NewCloverService(cfg *config.CloverConfig) *CloverService {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		limiter: limiterFor(ProviderClover, cfg.RateLimit),
 	}
 }
 
@@ -33,7 +42,7 @@ func NewCloverService(cfg *config.CloverConfig) *CloverService {
 // ==============================================
 
 // TokenizeCard tokenizes a credit card and returns a Clover token
-func (s *CloverService) TokenizeCard(card model.CloverCard) (*model.CloverTokenizeResponse, error) {
+func (s *CloverService) TokenizeCard(ctx context.Context, card model.CloverCard) (*model.CloverTokenizeResponse, error) {
 	reqBody := model.CloverTokenizeRequest{
 		Card: card,
 	}
@@ -43,7 +52,7 @@ func (s *CloverService) TokenizeCard(card model.CloverCard) (*model.CloverTokeni
 		return nil, fmt.Errorf("failed to marshal tokenize request: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", s.config.TokenizationEndpoint, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", s.config.TokenizationEndpoint, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create tokenize request: %w", err)
 	}
@@ -51,19 +60,13 @@ func (s *CloverService) TokenizeCard(card model.CloverCard) (*model.CloverTokeni
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("apikey", s.config.APIAccessKey)
 
-	resp, err := s.httpClient.Do(req)
+	status, responseBody, err := s.do(ctx, "tokenize", req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute tokenize request: %w", err)
 	}
-	defer resp.Body.Close()
-
-	responseBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read tokenize response: %w", err)
-	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("tokenization failed with status %d: %s", resp.StatusCode, string(responseBody))
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("tokenization failed with status %d: %s", status, string(responseBody))
 	}
 
 	var tokenResp model.CloverTokenizeResponse
@@ -79,16 +82,16 @@ func (s *CloverService) TokenizeCard(card model.CloverCard) (*model.CloverTokeni
 // ==============================================
 
 // AuthorizePayment creates a pre-authorization (hold) on a card
-func (s *CloverService) AuthorizePayment(token string, amountCents int64, metadata map[string]interface{}) (*model.CloverChargeResponse, error) {
+func (s *CloverService) AuthorizePayment(ctx context.Context, token string, amountCents int64, currency string, metadata map[string]interface{}) (*model.CloverChargeResponse, error) {
 	reqBody := model.CloverChargeRequest{
 		Amount:   amountCents,
-		Currency: "USD",
+		Currency: cloverCurrency(currency),
 		Source:   token,
 		Capture:  false, // false for pre-authorization
 		Metadata: metadata,
 	}
 
-	return s.createCharge(reqBody)
+	return s.createCharge(ctx, "authorize", reqBody)
 }
 
 // ==============================================
@@ -96,27 +99,36 @@ func (s *CloverService) AuthorizePayment(token string, amountCents int64, metada
 // ==============================================
 
 // ChargePayment creates a direct charge (authorization + capture)
-func (s *CloverService) ChargePayment(token string, amountCents int64, metadata map[string]interface{}) (*model.CloverChargeResponse, error) {
+func (s *CloverService) ChargePayment(ctx context.Context, token string, amountCents int64, currency string, metadata map[string]interface{}) (*model.CloverChargeResponse, error) {
 	reqBody := model.CloverChargeRequest{
 		Amount:   amountCents,
-		Currency: "USD",
+		Currency: cloverCurrency(currency),
 		Source:   token,
 		Capture:  true, // true for direct charge
 		Metadata: metadata,
 	}
 
-	return s.createCharge(reqBody)
+	return s.createCharge(ctx, "charge", reqBody)
+}
+
+// cloverCurrency returns the uppercase code Clover expects, defaulting to
+// model.DefaultCurrency
+func cloverCurrency(currency string) string {
+	if currency == "" {
+		return model.DefaultCurrency
+	}
+	return strings.ToUpper(currency)
 }
 
 // createCharge is a helper method to create a charge (used by both authorize and direct charge)
-func (s *CloverService) createCharge(reqBody model.CloverChargeRequest) (*model.CloverChargeResponse, error) {
+func (s *CloverService) createCharge(ctx context.Context, operation string, reqBody model.CloverChargeRequest) (*model.CloverChargeResponse, error) {
 	body, err := json.Marshal(reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal charge request: %w", err)
 	}
 
 	endpoint := fmt.Sprintf("%s/v1/charges", s.config.APIEndpoint)
-	req, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create charge request: %w", err)
 	}
@@ -124,19 +136,13 @@ func (s *CloverService) createCharge(reqBody model.CloverChargeRequest) (*model.
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.config.AccessToken))
 
-	resp, err := s.httpClient.Do(req)
+	status, responseBody, err := s.do(ctx, operation, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute charge request: %w", err)
 	}
-	defer resp.Body.Close()
 
-	responseBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read charge response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("charge failed with status %d: %s", resp.StatusCode, string(responseBody))
+	if status != http.StatusOK && status != http.StatusCreated {
+		return nil, fmt.Errorf("charge failed with status %d: %s", status, string(responseBody))
 	}
 
 	var chargeResp model.CloverChargeResponse
@@ -151,8 +157,8 @@ func (s *CloverService) createCharge(reqBody model.CloverChargeRequest) (*model.
 // CAPTURE
 // ==============================================
 
-// CapturePayment captures a previously authorized payment
-func (s *CloverService) CapturePayment(paymentID string, amountCents *int64) (*model.CloverCaptureResponse, error) {
+// CapturePayment captures a previously authorized charge
+func (s *CloverService) CapturePayment(ctx context.Context, chargeID string, amountCents *int64) (*model.CloverCaptureResponse, error) {
 	var reqBody model.CloverCaptureRequest
 	if amountCents != nil {
 		reqBody.Amount = *amountCents
@@ -163,8 +169,8 @@ func (s *CloverService) CapturePayment(paymentID string, amountCents *int64) (*m
 		return nil, fmt.Errorf("failed to marshal capture request: %w", err)
 	}
 
-	endpoint := fmt.Sprintf("%s/v1/payments/%s/capture", s.config.APIEndpoint, paymentID)
-	req, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(body))
+	endpoint := fmt.Sprintf("%s/v1/charges/%s/capture", s.config.APIEndpoint, chargeID)
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create capture request: %w", err)
 	}
@@ -172,19 +178,13 @@ func (s *CloverService) CapturePayment(paymentID string, amountCents *int64) (*m
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.config.AccessToken))
 
-	resp, err := s.httpClient.Do(req)
+	status, responseBody, err := s.do(ctx, "capture", req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute capture request: %w", err)
 	}
-	defer resp.Body.Close()
-
-	responseBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read capture response: %w", err)
-	}
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("capture failed with status %d: %s", resp.StatusCode, string(responseBody))
+	if status != http.StatusOK && status != http.StatusCreated {
+		return nil, fmt.Errorf("capture failed with status %d: %s", status, string(responseBody))
 	}
 
 	var captureResp model.CloverCaptureResponse
@@ -200,7 +200,7 @@ func (s *CloverService) CapturePayment(paymentID string, amountCents *int64) (*m
 // ==============================================
 
 // RefundPayment refunds a charge
-func (s *CloverService) RefundPayment(chargeID string, amountCents *int64, reason string) (*model.CloverRefundResponse, error) {
+func (s *CloverService) RefundPayment(ctx context.Context, chargeID string, amountCents *int64, reason string) (*model.CloverRefundResponse, error) {
 	reqBody := model.CloverRefundRequest{
 		ChargeID: chargeID,
 		Reason:   reason,
@@ -216,7 +216,7 @@ func (s *CloverService) RefundPayment(chargeID string, amountCents *int64, reaso
 	}
 
 	endpoint := fmt.Sprintf("%s/v1/refunds", s.config.APIEndpoint)
-	req, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create refund request: %w", err)
 	}
@@ -224,19 +224,13 @@ func (s *CloverService) RefundPayment(chargeID string, amountCents *int64, reaso
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.config.AccessToken))
 
-	resp, err := s.httpClient.Do(req)
+	status, responseBody, err := s.do(ctx, "refund", req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute refund request: %w", err)
 	}
-	defer resp.Body.Close()
 
-	responseBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read refund response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("refund failed with status %d: %s", resp.StatusCode, string(responseBody))
+	if status != http.StatusOK && status != http.StatusCreated {
+		return nil, fmt.Errorf("refund failed with status %d: %s", status, string(responseBody))
 	}
 
 	var refundResp model.CloverRefundResponse
@@ -251,12 +245,76 @@ func (s *CloverService) RefundPayment(chargeID string, amountCents *int64, reaso
 // HELPER FUNCTIONS
 // ==============================================
 
-// DollarsToCents converts dollars to cents for Clover API
+// do sends a request to Clover through the provider's limiter and returns the
+// response status and body. A 429 pauses every Clover request for its Retry-After and
+// the request is retried, up to MaxRetries times.
+func (s *CloverService) do(ctx context.Context, operation string, req *http.Request) (int, []byte, error) {
+	for attempt := 0; ; attempt++ {
+		queued, err := s.limiter.Wait(ctx)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		status, header, body, err := s.send(ctx, operation, req, queued)
+		if err != nil || status != http.StatusTooManyRequests {
+			return status, body, err
+		}
+
+		retryAfter, ok := ParseRetryAfter(header.Get("Retry-After"), time.Now())
+		if !ok {
+			retryAfter = defaultRetryAfter << attempt
+		}
+		s.limiter.Backoff(retryAfter)
+		slog.WarnContext(ctx, "Clover rate limited request", "operation", operation,
+			"attempt", attempt+1, "retry_after", retryAfter.String())
+		if attempt >= s.config.MaxRetries || retryAfter > maxRetryAfter || req.GetBody == nil {
+			return status, body, fmt.Errorf("%w: clover %s after %d attempts", ErrProviderRateLimited, operation, attempt+1)
+		}
+
+		retry := req.Clone(ctx)
+		if retry.Body, err = req.GetBody(); err != nil {
+			return 0, nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		req = retry
+	}
+}
+
+// send makes one attempt at a request inside a span for the operation, recording how
+// long it was queued
+func (s *CloverService) send(ctx context.Context, operation string, req *http.Request, queued time.Duration) (int, http.Header, []byte, error) {
+	ctx, span := startProviderSpan(ctx, ProviderClover, operation)
+	defer span.End()
+	span.SetAttributes(attribute.Int64("payment.queue_wait_ms", queued.Milliseconds()))
+
+	resp, err := s.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		endProviderSpan(span, 0, err)
+		return 0, nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err == nil && faults.FromContext(ctx).DropCloverResponse() {
+		// Clover handled the request, but the caller never hears back
+		slog.WarnContext(ctx, "Injected fault: dropping Clover response", "operation", operation, "status", resp.StatusCode)
+		err = faults.ErrDroppedResponse
+		endProviderSpan(span, 0, err)
+		return 0, nil, nil, fmt.Errorf("clover %s: %w", operation, err)
+	}
+	endProviderSpan(span, resp.StatusCode, err)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return resp.StatusCode, resp.Header, body, nil
+}
+
+// DollarsToCents converts dollars to cents for provider APIs, rounding to the nearest
+// cent (19.99 * 100 is 1998.9999... in floating point)
 func DollarsToCents(dollars float64) int64 {
-	return int64(dollars * 100)
+	return int64(math.Round(dollars * 100))
 }
 
-// CentsToDollars converts cents to dollars from Clover API
+// CentsToDollars converts cents to dollars from provider APIs
 func CentsToDollars(cents int64) float64 {
 	return float64(cents) / 100.0
 }
@@ -0,0 +1,21 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrCurrencyMismatch is returned when a payment request names a different currency
+// than the payment it applies to. Captures and refunds are always in the currency the
+// payment was authorized in.
+var ErrCurrencyMismatch = errors.New("currency does not match the payment")
+
+// checkRequestCurrency rejects a requested currency that differs from the payment's; an
+// empty request currency means the payment's
+func checkRequestCurrency(operation, requested, paymentCurrency string) error {
+	if requested == "" || strings.EqualFold(requested, paymentCurrency) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s in %s of a payment in %s", ErrCurrencyMismatch, operation, strings.ToUpper(requested), paymentCurrency)
+}
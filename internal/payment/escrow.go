@@ -0,0 +1,265 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"app/internal/model"
+)
+
+// AuthorizationLifetime is how long a card hold lasts before the card network releases it
+const AuthorizationLifetime = 7 * 24 * time.Hour
+
+// EscrowRenewalLead is how long before an authorization expires the escrow workflow
+// renews or voids it
+const EscrowRenewalLead = 24 * time.Hour
+
+// ErrEscrowSettled is returned when an authorization was already captured, refunded or voided
+var ErrEscrowSettled = errors.New("authorization is already settled")
+
+// ErrJobOnHold is returned when a payment is captured while an open dispute, or an
+// unresolved incident or support ticket pausing the job, holds it
+var ErrJobOnHold = errors.New("job has an open dispute or hold")
+
+// EscrowAutoCaptureDelay is how long after a job completes its payment is captured if
+// the consumer has not released it (ESCROW_AUTO_CAPTURE_DAYS, default 3)
+func EscrowAutoCaptureDelay() time.Duration {
+	days := 3
+	if v, err := strconv.Atoi(os.Getenv("ESCROW_AUTO_CAPTURE_DAYS")); err == nil && v > 0 {
+		days = v
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// EscrowHold is an authorization as the escrow workflow sees it
+type EscrowHold struct {
+	TransactionID int
+	JobID         int
+	ConsumerID    int
+	GigWorkerID   *int // The job's worker, if one is assigned
+	Amount        model.Money
+	JobStatus     string
+	ExpiresAt     time.Time
+	CompletedAt   *time.Time // When the job was completed, if it has been
+	Settled       bool       // Captured, refunded or voided
+}
+
+// GetEscrowHold loads an authorization and its job
+func (s *PaymentService) GetEscrowHold(transactionID int) (*EscrowHold, error) {
+	var h EscrowHold
+	var currency string
+	var expiresAt sql.NullTime
+	err := s.db.QueryRow(`
+		SELECT t.id, t.job_id, t.consumer_id, j.gig_worker_id, t.amount, t.currency, j.status,
+		       t.authorization_expires_at,
+		       (SELECT MAX(occurred_at) FROM job_events WHERE job_id = j.id AND event_type = 'completed'),
+		       t.captured_at IS NOT NULL OR t.refunded_at IS NOT NULL OR t.status IN ('failed', 'refunded')
+		FROM transactions t
+		JOIN jobs j ON j.id = t.job_id
+		WHERE t.id = $1 AND t.transaction_type = 'authorization'
+	`, transactionID).Scan(
+		&h.TransactionID, &h.JobID, &h.ConsumerID, &h.GigWorkerID, &h.Amount, &currency, &h.JobStatus,
+		&expiresAt, &h.CompletedAt, &h.Settled,
+	)
+	if err != nil {
+		return nil, err
+	}
+	h.Amount = model.NewMoney(h.Amount.Cents, currency)
+	h.ExpiresAt = expiresAt.Time
+	if !expiresAt.Valid {
+		h.ExpiresAt = s.clock.Now().Add(AuthorizationLifetime)
+	}
+	return &h, nil
+}
+
+// jobOnHold reports whether an open dispute, or an unresolved incident or support
+// ticket pausing the job, holds its payment
+func (s *PaymentService) jobOnHold(ctx context.Context, jobID int) (bool, error) {
+	var holds int
+	err := s.db.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM safety_incidents WHERE job_id = $1 AND workflow_paused = true AND status <> 'resolved') +
+			(SELECT COUNT(*) FROM support_tickets WHERE job_id = $1 AND workflow_paused = true AND status <> 'resolved') +
+			(SELECT COUNT(*) FROM job_disputes WHERE job_id = $1 AND status IN ('open', 'under_review'))
+	`, jobID).Scan(&holds)
+	return holds > 0, err
+}
+
+// WorkerEarnings is what a captured transaction owes its worker, as settlement pays it:
+// their share of the job price plus reimbursed expenses and materials
+func (s *PaymentService) WorkerEarnings(ctx context.Context, transactionID int) (model.Money, error) {
+	var earnings model.Money
+	var currency string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT `+workerEarnings+`, COALESCE(t.currency, 'USD') FROM transactions t WHERE t.id = $1`, transactionID,
+	).Scan(&earnings, &currency)
+	if err != nil {
+		return model.Money{}, err
+	}
+	return model.NewMoney(earnings.Cents, currency), nil
+}
+
+// ReauthorizePayment places a new hold for the same amount on the card an expiring
+// authorization used, then releases the old hold. The transaction keeps its ID and
+// now points at the new charge. It holds the transaction's lock throughout, so a
+// capture waits for the new charge rather than capturing the old one as it is released.
+func (s *PaymentService) ReauthorizePayment(ctx context.Context, transactionID int) (*model.EnhancedTransaction, error) {
+	unlock, err := s.lockTransaction(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	transaction, err := s.getTransaction(transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	if transaction.CapturedAt != nil || transaction.Status == model.TransactionStatusRefunded {
+		return nil, ErrEscrowSettled
+	}
+	merchant, err := s.transactionMerchant(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	var sourceToken sql.NullString
+	if err := s.db.QueryRow(`SELECT provider_source_token FROM transactions WHERE id = $1`, transactionID).Scan(&sourceToken); err != nil {
+		return nil, fmt.Errorf("failed to get payment source: %w", err)
+	}
+	if !sourceToken.Valid || sourceToken.String == "" {
+		return nil, fmt.Errorf("transaction does not have a reusable payment source")
+	}
+
+	previousChargeID := *transaction.ProviderChargeID
+	charge, err := merchant.Provider.Authorize(ctx, sourceToken.String, transaction.Amount.Cents, transaction.Currency, map[string]interface{}{
+		"job_id":         transaction.JobID,
+		"consumer_id":    transaction.ConsumerID,
+		"type":           "job_payment",
+		"reauthorizes":   previousChargeID,
+		"transaction_id": transactionID,
+	})
+	if err != nil {
+		s.createPaymentEventSimple(transactionID, "reauthorize", "failed", nil, err, transaction.ConsumerID, "")
+		return nil, fmt.Errorf("failed to reauthorize payment with %s: %w", merchant.Provider.Name(), err)
+	}
+	s.checkProviderAmount(ctx, merchant.Provider, transaction.JobID, transactionID, "reauthorize", &transaction.Amount.Cents, charge.AmountCents, transaction.Currency)
+
+	now := s.clock.Now()
+	tx, err := s.db.Begin()
+	if err != nil {
+		s.releaseReauthorization(ctx, merchant.Provider, transactionID, charge.ID)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE transactions
+		SET provider_charge_id = $1, authorized_at = $2, authorization_expires_at = $3, updated_at = $2
+		WHERE id = $4
+	`, charge.ID, now, now.Add(AuthorizationLifetime), transactionID)
+	if err == nil {
+		err = s.createPaymentEvent(tx, transactionID, "reauthorize", "success", charge.Raw, nil, transaction.ConsumerID, "")
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		// The transaction still points at the old hold, so the new one must not be left on the card
+		s.releaseReauthorization(ctx, merchant.Provider, transactionID, charge.ID)
+		return nil, fmt.Errorf("failed to record re-authorization: %w", err)
+	}
+
+	// The old hold lapses on its own if it cannot be released now
+	if release, err := merchant.Provider.Refund(ctx, previousChargeID, nil, "reauthorized"); err != nil {
+		s.createPaymentEventSimple(transactionID, "void", "failed", nil, err, transaction.ConsumerID, "")
+	} else {
+		s.createPaymentEventSimple(transactionID, "void", "success", release.Raw, nil, transaction.ConsumerID, "")
+	}
+
+	return s.getTransaction(transactionID)
+}
+
+// releaseReauthorization releases a new hold that could not be recorded
+func (s *PaymentService) releaseReauthorization(ctx context.Context, provider Provider, transactionID int, chargeID string) {
+	if _, err := provider.Refund(context.WithoutCancel(ctx), chargeID, nil, "re-authorization not recorded"); err != nil {
+		slog.ErrorContext(ctx, "Failed to release unrecorded re-authorization", "transaction_id", transactionID, "charge_id", chargeID, "error", err)
+	}
+}
+
+// VoidAuthorization releases an uncaptured hold and gives back any account credit it
+// used. The transaction is marked refunded, since none of it will be captured.
+func (s *PaymentService) VoidAuthorization(ctx context.Context, transactionID int, reason string) error {
+	unlock, err := s.lockTransaction(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	transaction, err := s.getTransaction(transactionID)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction: %w", err)
+	}
+	if transaction.CapturedAt != nil || transaction.Status == model.TransactionStatusRefunded {
+		return ErrEscrowSettled
+	}
+	merchant, err := s.transactionMerchant(transaction)
+	if err != nil {
+		return err
+	}
+
+	release, err := merchant.Provider.Refund(ctx, *transaction.ProviderChargeID, nil, reason)
+	if err != nil {
+		s.createPaymentEventSimple(transactionID, "void", "failed", nil, err, transaction.ConsumerID, "")
+		return fmt.Errorf("failed to void authorization with %s: %w", merchant.Provider.Name(), err)
+	}
+
+	now := s.clock.Now()
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE transactions
+		SET status = 'refunded', refunded_at = $1, refund_reason = $2, updated_at = $1
+		WHERE id = $3
+	`, now, reason, transactionID)
+	if err != nil {
+		return fmt.Errorf("failed to update transaction: %w", err)
+	}
+
+	if err := s.restoreCredits(tx, transactionID); err != nil {
+		return fmt.Errorf("failed to restore account credit: %w", err)
+	}
+
+	if err := s.createPaymentEvent(tx, transactionID, "void", "success", release.Raw, nil, transaction.ConsumerID, ""); err != nil {
+		return fmt.Errorf("failed to create payment event: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// AutoCaptureJobPayment captures a completed job's payment on the consumer's behalf
+// once they have let EscrowAutoCaptureDelay pass without releasing it. The idempotency
+// key makes retries of the same auto-capture return the first capture.
+func (s *PaymentService) AutoCaptureJobPayment(ctx context.Context, transactionID int) (*model.PaymentCaptureResponse, error) {
+	hold, err := s.GetEscrowHold(transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authorization: %w", err)
+	}
+
+	return s.CaptureJobPayment(ctx, hold.ConsumerID, model.PaymentCaptureRequest{
+		TransactionID:  transactionID,
+		IdempotencyKey: fmt.Sprintf("escrow-auto-capture-%d", transactionID),
+	})
+}
@@ -0,0 +1,142 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"app/internal/clock"
+	"app/internal/model"
+)
+
+// rowDB is a database whose queries all return one row of values
+type rowDB struct{ values []driver.Value }
+
+func (d rowDB) Connect(context.Context) (driver.Conn, error) { return rowConn(d), nil }
+func (d rowDB) Driver() driver.Driver                        { return nil }
+
+type rowConn rowDB
+
+func (c rowConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c rowConn) Close() error                        { return nil }
+func (c rowConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (c rowConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &oneRow{values: c.values}, nil
+}
+
+type oneRow struct {
+	values []driver.Value
+	read   bool
+}
+
+func (r *oneRow) Columns() []string { return make([]string, len(r.values)) }
+func (r *oneRow) Close() error      { return nil }
+
+func (r *oneRow) Next(dest []driver.Value) error {
+	if r.read {
+		return io.EOF
+	}
+	r.read = true
+	copy(dest, r.values)
+	return nil
+}
+
+func TestGetEscrowHoldExpiry(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	recorded := now.Add(48 * time.Hour)
+
+	tests := []struct {
+		name      string
+		expiresAt driver.Value
+		want      time.Time
+	}{
+		{name: "recorded expiry", expiresAt: recorded, want: recorded},
+		{name: "no expiry recorded lasts a full authorization", expiresAt: nil, want: now.Add(AuthorizationLifetime)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := sql.OpenDB(rowDB{values: []driver.Value{
+				int64(5), int64(42), int64(3), nil, "86.60", "USD", "in_progress", tt.expiresAt, nil, false,
+			}})
+			defer db.Close()
+
+			s := (&PaymentService{db: db}).WithClock(clock.NewFake(now), &clock.Sequence{})
+			hold, err := s.GetEscrowHold(5)
+			if err != nil {
+				t.Fatalf("GetEscrowHold() error = %v", err)
+			}
+			if !hold.ExpiresAt.Equal(tt.want) {
+				t.Errorf("ExpiresAt = %s, want %s", hold.ExpiresAt, tt.want)
+			}
+			if hold.Amount != model.USD(8660) {
+				t.Errorf("Amount = %v, want %v", hold.Amount, model.USD(8660))
+			}
+		})
+	}
+}
+
+func TestReauthorizePayment(t *testing.T) {
+	hold := transactionRow{
+		id: 5, jobID: 42, consumerID: 3, workerID: 7, amount: "100.00",
+		status: "completed", kind: "authorization", chargeID: "ch_old", platformFee: "10.00", net: "90.00",
+	}
+	captured := hold
+	captured.captured = true
+
+	errDatabaseDown := errors.New("database unavailable")
+
+	tests := []struct {
+		name        string
+		transaction transactionRow
+		failUpdate  error
+		wantErr     error
+		wantRefunds []string
+	}{
+		{name: "old hold released after the new one", transaction: hold, wantRefunds: []string{"ch_old"}},
+		{name: "captured while waiting for the lock", transaction: captured, wantErr: ErrEscrowSettled},
+		{
+			name: "new hold released when it cannot be recorded", transaction: hold,
+			failUpdate: errDatabaseDown, wantErr: errDatabaseDown, wantRefunds: []string{"ch_1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := (&scriptDB{}).
+				on("clover_charge_id", tt.transaction.values()).
+				on("SELECT provider_source_token", []driver.Value{"tok_card"})
+			if tt.failUpdate != nil {
+				db.failing("SET provider_charge_id", tt.failUpdate)
+			}
+			provider := &fakeProvider{}
+
+			_, err := newTestService(db, provider).ReauthorizePayment(context.Background(), 5)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReauthorizePayment() error = %v, want %v", err, tt.wantErr)
+			}
+			if errors.Is(tt.wantErr, ErrEscrowSettled) && len(provider.authorized) != 0 {
+				t.Errorf("provider authorizations = %v, want none", provider.authorized)
+			}
+			if fmt.Sprint(provider.refunded) != fmt.Sprint(tt.wantRefunds) {
+				t.Errorf("provider refunds = %v, want %v", provider.refunded, tt.wantRefunds)
+			}
+
+			// The transaction is read under its lock, and the lock held until the old
+			// hold is released
+			positions := db.order("pg_advisory_lock", "clover_charge_id", "pg_advisory_unlock")
+			if positions[0] < 0 || positions[0] > positions[1] || positions[2] < positions[1] {
+				t.Errorf("lock, read and unlock ran at %v, want them in that order", positions)
+			}
+			if locks := db.executed("pg_advisory_lock"); len(locks) != 1 || locks[0].args[0] != "transaction:5" {
+				t.Errorf("advisory locks = %v, want one on transaction:5", locks)
+			}
+		})
+	}
+}
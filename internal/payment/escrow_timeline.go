@@ -0,0 +1,241 @@
+package payment
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"app/internal/model"
+)
+
+// escrowAuthorization is an authorization transaction as the escrow timeline sees it
+type escrowAuthorization struct {
+	ID            int
+	Amount        model.Money
+	AuthorizedAt  time.Time
+	HeldAt        *time.Time
+	ReleasedAt    *time.Time
+	CaptureAmount *model.Money
+	ExpiresAt     *time.Time
+}
+
+// escrowRefund is a refund transaction against an authorization
+type escrowRefund struct {
+	ID         int
+	ParentID   int
+	Amount     model.Money
+	RefundedAt time.Time
+}
+
+// escrowFailure is a failed capture or refund attempt from payment_events
+type escrowFailure struct {
+	TransactionID int
+	EventType     string
+	At            time.Time
+}
+
+// milestoneOrder breaks ties between milestones recorded at the same instant, e.g. an
+// authorization and its hold
+var milestoneOrder = map[string]int{
+	model.EscrowMilestoneAuthorized:    0,
+	model.EscrowMilestoneHeld:          1,
+	model.EscrowMilestoneCaptureFailed: 2,
+	model.EscrowMilestoneReleased:      3,
+	model.EscrowMilestoneRefundFailed:  4,
+	model.EscrowMilestoneRefunded:      5,
+}
+
+// EscrowTimeline returns a job's payment milestones, oldest first, built from its
+// transactions and failed payment events
+func (s *PaymentService) EscrowTimeline(jobID int) (*model.EscrowTimeline, error) {
+	auths, err := s.getEscrowAuthorizations(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authorizations: %w", err)
+	}
+	refunds, err := s.getEscrowRefunds(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refunds: %w", err)
+	}
+	failures, err := s.getEscrowFailures(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment events: %w", err)
+	}
+	return buildEscrowTimeline(jobID, auths, refunds, failures), nil
+}
+
+// buildEscrowTimeline derives milestones and totals. A refund before capture cancels
+// the hold; after capture it reduces what was charged.
+func buildEscrowTimeline(jobID int, auths []escrowAuthorization, refunds []escrowRefund, failures []escrowFailure) *model.EscrowTimeline {
+	currency := model.DefaultCurrency
+	if len(auths) > 0 {
+		currency = auths[0].Amount.Currency
+	}
+	timeline := &model.EscrowTimeline{
+		JobID:      jobID,
+		Held:       model.NewMoney(0, currency),
+		Charged:    model.NewMoney(0, currency),
+		Refunded:   model.NewMoney(0, currency),
+		Milestones: []model.EscrowMilestone{},
+	}
+
+	add := func(kind string, at time.Time, amount *model.Money, transactionID int, description string) {
+		timeline.Milestones = append(timeline.Milestones, model.EscrowMilestone{
+			Type: kind, OccurredAt: at, Amount: amount, Currency: currency,
+			TransactionID: transactionID, Description: description,
+		})
+	}
+
+	refunded := make(map[int]model.Money)
+	for _, r := range refunds {
+		amount := r.Amount
+		refunded[r.ParentID] = refunded[r.ParentID].Add(amount)
+		add(model.EscrowMilestoneRefunded, r.RefundedAt, &amount, r.ID,
+			fmt.Sprintf("%s %s was returned to your card.", amount, currency))
+		timeline.Refunded = timeline.Refunded.Add(amount)
+	}
+
+	for i := range auths {
+		a := auths[i]
+		add(model.EscrowMilestoneAuthorized, a.AuthorizedAt, &a.Amount, a.ID,
+			fmt.Sprintf("Your card was authorized for %s %s. Nothing is charged until the job is complete.", a.Amount, currency))
+		if a.HeldAt != nil {
+			add(model.EscrowMilestoneHeld, *a.HeldAt, &a.Amount, a.ID,
+				fmt.Sprintf("%s %s is held in escrow and will be charged when the job is complete.", a.Amount, currency))
+		}
+
+		_, wasRefunded := refunded[a.ID]
+		switch {
+		case a.ReleasedAt != nil:
+			captured := a.Amount
+			if a.CaptureAmount != nil {
+				captured = *a.CaptureAmount
+			}
+			add(model.EscrowMilestoneReleased, *a.ReleasedAt, &captured, a.ID,
+				fmt.Sprintf("Your card was charged %s %s and the payment was released to your worker.", captured, currency))
+			charged := captured.Sub(refunded[a.ID])
+			if charged.IsPositive() {
+				timeline.Charged = timeline.Charged.Add(charged)
+			}
+		case !wasRefunded:
+			timeline.Held = timeline.Held.Add(a.Amount)
+			if a.ExpiresAt != nil && (timeline.HoldExpiresAt == nil || a.ExpiresAt.Before(*timeline.HoldExpiresAt)) {
+				timeline.HoldExpiresAt = a.ExpiresAt
+			}
+		}
+	}
+
+	for _, f := range failures {
+		switch f.EventType {
+		case "capture":
+			add(model.EscrowMilestoneCaptureFailed, f.At, nil, f.TransactionID,
+				"Charging your card failed. The hold remains in place and the charge will be retried.")
+		case "refund":
+			add(model.EscrowMilestoneRefundFailed, f.At, nil, f.TransactionID,
+				"A refund to your card failed. Support has been notified.")
+		}
+	}
+
+	sort.SliceStable(timeline.Milestones, func(i, j int) bool {
+		a, b := timeline.Milestones[i], timeline.Milestones[j]
+		if !a.OccurredAt.Equal(b.OccurredAt) {
+			return a.OccurredAt.Before(b.OccurredAt)
+		}
+		return milestoneOrder[a.Type] < milestoneOrder[b.Type]
+	})
+
+	switch {
+	case len(auths) == 0:
+		timeline.Status = model.EscrowStatusNone
+	case timeline.Held.IsPositive():
+		timeline.Status = model.EscrowStatusHeld
+	case timeline.Charged.IsPositive() && timeline.Refunded.IsPositive():
+		timeline.Status = model.EscrowStatusPartiallyRefunded
+	case timeline.Charged.IsPositive():
+		timeline.Status = model.EscrowStatusReleased
+	case timeline.Refunded.IsPositive():
+		timeline.Status = model.EscrowStatusRefunded
+	default:
+		timeline.Status = model.EscrowStatusReleased
+	}
+	return timeline
+}
+
+func (s *PaymentService) getEscrowAuthorizations(jobID int) ([]escrowAuthorization, error) {
+	rows, err := s.db.Query(`
+		SELECT id, amount, currency, COALESCE(authorized_at, created_at), escrow_held_at,
+		       escrow_released_at, capture_amount, authorization_expires_at
+		FROM transactions
+		WHERE job_id = $1 AND transaction_type = 'authorization'
+		ORDER BY created_at, id
+	`, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var auths []escrowAuthorization
+	for rows.Next() {
+		var a escrowAuthorization
+		var currency string
+		if err := rows.Scan(&a.ID, &a.Amount, &currency, &a.AuthorizedAt, &a.HeldAt,
+			&a.ReleasedAt, &a.CaptureAmount, &a.ExpiresAt); err != nil {
+			return nil, err
+		}
+		a.Amount = model.NewMoney(a.Amount.Cents, currency)
+		if a.CaptureAmount != nil {
+			captured := model.NewMoney(a.CaptureAmount.Cents, currency)
+			a.CaptureAmount = &captured
+		}
+		auths = append(auths, a)
+	}
+	return auths, rows.Err()
+}
+
+func (s *PaymentService) getEscrowRefunds(jobID int) ([]escrowRefund, error) {
+	rows, err := s.db.Query(`
+		SELECT id, parent_transaction_id, COALESCE(refund_amount, amount), currency, COALESCE(refunded_at, created_at)
+		FROM transactions
+		WHERE job_id = $1 AND transaction_type = 'refund' AND parent_transaction_id IS NOT NULL
+		ORDER BY created_at, id
+	`, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var refunds []escrowRefund
+	for rows.Next() {
+		var r escrowRefund
+		var currency string
+		if err := rows.Scan(&r.ID, &r.ParentID, &r.Amount, &currency, &r.RefundedAt); err != nil {
+			return nil, err
+		}
+		r.Amount = model.NewMoney(r.Amount.Cents, currency)
+		refunds = append(refunds, r)
+	}
+	return refunds, rows.Err()
+}
+
+func (s *PaymentService) getEscrowFailures(jobID int) ([]escrowFailure, error) {
+	rows, err := s.db.Query(`
+		SELECT e.transaction_id, e.event_type, e.created_at
+		FROM payment_events e
+		JOIN transactions t ON t.id = e.transaction_id
+		WHERE t.job_id = $1 AND e.event_status = 'failed' AND e.event_type IN ('capture', 'refund')
+		ORDER BY e.created_at, e.id
+	`, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var failures []escrowFailure
+	for rows.Next() {
+		var f escrowFailure
+		if err := rows.Scan(&f.TransactionID, &f.EventType, &f.At); err != nil {
+			return nil, err
+		}
+		failures = append(failures, f)
+	}
+	return failures, rows.Err()
+}
@@ -0,0 +1,89 @@
+package payment
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"app/internal/model"
+)
+
+func TestBuildEscrowTimeline(t *testing.T) {
+	usd := model.USD
+	at := func(minutes int) time.Time {
+		return time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC).Add(time.Duration(minutes) * time.Minute)
+	}
+	ptr := func(t time.Time) *time.Time { return &t }
+	money := func(m model.Money) *model.Money { return &m }
+
+	tests := []struct {
+		name      string
+		auths     []escrowAuthorization
+		refunds   []escrowRefund
+		failures  []escrowFailure
+		status    string
+		held      model.Money
+		charged   model.Money
+		refunded  model.Money
+		expiresAt *time.Time
+		types     []string
+	}{
+		{name: "no payment", status: model.EscrowStatusNone, held: usd(0), charged: usd(0), refunded: usd(0), types: []string{}},
+		{
+			name:   "held",
+			auths:  []escrowAuthorization{{ID: 1, Amount: usd(8660), AuthorizedAt: at(0), HeldAt: ptr(at(0)), ExpiresAt: ptr(at(7 * 24 * 60))}},
+			status: model.EscrowStatusHeld, held: usd(8660), charged: usd(0), refunded: usd(0), expiresAt: ptr(at(7 * 24 * 60)),
+			types: []string{"authorized", "held"},
+		},
+		{
+			name: "failed capture then released with expenses",
+			auths: []escrowAuthorization{{ID: 1, Amount: usd(8660), AuthorizedAt: at(0), HeldAt: ptr(at(0)),
+				ReleasedAt: ptr(at(120)), CaptureAmount: money(usd(9910)), ExpiresAt: ptr(at(7 * 24 * 60))}},
+			failures: []escrowFailure{{TransactionID: 1, EventType: "capture", At: at(90)}},
+			status:   model.EscrowStatusReleased, held: usd(0), charged: usd(9910), refunded: usd(0),
+			types: []string{"authorized", "held", "capture_failed", "released"},
+		},
+		{
+			name: "partial refund after capture",
+			auths: []escrowAuthorization{{ID: 1, Amount: usd(8660), AuthorizedAt: at(0), HeldAt: ptr(at(0)),
+				ReleasedAt: ptr(at(120))}},
+			refunds:  []escrowRefund{{ID: 2, ParentID: 1, Amount: usd(2000), RefundedAt: at(300)}},
+			failures: []escrowFailure{{TransactionID: 1, EventType: "refund", At: at(200)}},
+			status:   model.EscrowStatusPartiallyRefunded, held: usd(0), charged: usd(6660), refunded: usd(2000),
+			types: []string{"authorized", "held", "released", "refund_failed", "refunded"},
+		},
+		{
+			name:    "hold cancelled before capture",
+			auths:   []escrowAuthorization{{ID: 1, Amount: usd(8660), AuthorizedAt: at(0), HeldAt: ptr(at(0)), ExpiresAt: ptr(at(60))}},
+			refunds: []escrowRefund{{ID: 2, ParentID: 1, Amount: usd(8660), RefundedAt: at(30)}},
+			status:  model.EscrowStatusRefunded, held: usd(0), charged: usd(0), refunded: usd(8660),
+			types: []string{"authorized", "held", "refunded"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildEscrowTimeline(7, tt.auths, tt.refunds, tt.failures)
+			if got.JobID != 7 || got.Status != tt.status {
+				t.Errorf("job %d status %q, want job 7 status %q", got.JobID, got.Status, tt.status)
+			}
+			if got.Held != tt.held || got.Charged != tt.charged || got.Refunded != tt.refunded {
+				t.Errorf("held %s charged %s refunded %s, want %s, %s and %s",
+					got.Held, got.Charged, got.Refunded, tt.held, tt.charged, tt.refunded)
+			}
+			if !reflect.DeepEqual(got.HoldExpiresAt, tt.expiresAt) {
+				t.Errorf("hold expires %v, want %v", got.HoldExpiresAt, tt.expiresAt)
+			}
+			types := []string{}
+			for i, m := range got.Milestones {
+				types = append(types, m.Type)
+				if i > 0 && m.OccurredAt.Before(got.Milestones[i-1].OccurredAt) {
+					t.Errorf("milestone %d (%s) is out of order", i, m.Type)
+				}
+			}
+			if !reflect.DeepEqual(types, tt.types) {
+				t.Errorf("milestones %v, want %v", types, tt.types)
+			}
+		})
+	}
+}
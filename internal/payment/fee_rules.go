@@ -0,0 +1,136 @@
+package payment
+
+import (
+	"fmt"
+	"time"
+
+	"app/internal/model"
+)
+
+// FeeRuleColumns are the fee_rules columns ScanFeeRule reads, for SELECT and RETURNING
+const FeeRuleColumns = `
+	id, uuid, name, category, worker_tier, starts_at, ends_at,
+	platform_fee_percent, platform_fee_fixed, priority, is_active, created_by,
+	created_at, updated_at`
+
+// ScanFeeRule scans a row selected with FeeRuleColumns
+func ScanFeeRule(row interface{ Scan(...interface{}) error }) (*model.FeeRule, error) {
+	var rule model.FeeRule
+	err := row.Scan(
+		&rule.ID, &rule.UUID, &rule.Name, &rule.Category, &rule.WorkerTier, &rule.StartsAt, &rule.EndsAt,
+		&rule.PlatformFeePercent, &rule.PlatformFeeFixed, &rule.Priority, &rule.IsActive, &rule.CreatedBy,
+		&rule.CreatedAt, &rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// SelectFeeRule returns the rule that sets the platform fee for a job with the given
+// category and worker tier at a point in time, or nil for the default fee. A rule
+// applies when it is active, at is within its window, and each condition it sets
+// matches. The highest priority applies; ties go to the rule with more conditions,
+// then to the newest.
+func SelectFeeRule(rules []model.FeeRule, category, workerTier string, at time.Time) *model.FeeRule {
+	var best *model.FeeRule
+	for i := range rules {
+		rule := &rules[i]
+		if !feeRuleApplies(rule, category, workerTier, at) {
+			continue
+		}
+		if best == nil || feeRuleOutranks(rule, best) {
+			best = rule
+		}
+	}
+	return best
+}
+
+func feeRuleApplies(rule *model.FeeRule, category, workerTier string, at time.Time) bool {
+	switch {
+	case !rule.IsActive:
+		return false
+	case rule.Category != nil && *rule.Category != category:
+		return false
+	case rule.WorkerTier != nil && *rule.WorkerTier != workerTier:
+		return false
+	case rule.StartsAt != nil && at.Before(*rule.StartsAt):
+		return false
+	case rule.EndsAt != nil && !at.Before(*rule.EndsAt):
+		return false
+	}
+	return true
+}
+
+func feeRuleOutranks(a, b *model.FeeRule) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	if ca, cb := feeRuleConditions(a), feeRuleConditions(b); ca != cb {
+		return ca > cb
+	}
+	return a.ID > b.ID
+}
+
+func feeRuleConditions(rule *model.FeeRule) int {
+	n := 0
+	if rule.Category != nil {
+		n++
+	}
+	if rule.WorkerTier != nil {
+		n++
+	}
+	if rule.StartsAt != nil || rule.EndsAt != nil {
+		n++
+	}
+	return n
+}
+
+// feeRule loads the active rules and selects the one for a job
+func (s *PaymentService) feeRule(category, workerTier string, at time.Time) (*model.FeeRule, error) {
+	rows, err := s.db.Query(`SELECT ` + FeeRuleColumns + ` FROM fee_rules WHERE is_active`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []model.FeeRule
+	for rows.Next() {
+		rule, err := ScanFeeRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, *rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return SelectFeeRule(rules, category, workerTier, at), nil
+}
+
+// PreviewFees shows the fee schedule a job with the given category and worker tier
+// would be charged at a point in time, and how it splits amount
+func (s *PaymentService) PreviewFees(category, workerTier string, amount model.Money, at time.Time) (*model.FeePreview, error) {
+	rule, err := s.feeRule(category, workerTier, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fee rules: %w", err)
+	}
+
+	fees := s.provider.Fees().WithRule(rule)
+	net, platformFee, processingFee := fees.Split(amount)
+	return &model.FeePreview{
+		Category:           category,
+		WorkerTier:         workerTier,
+		At:                 at,
+		Rule:               rule,
+		PlatformFeePercent: fees.PlatformPercent,
+		PlatformFeeFixed:   fees.PlatformFixed,
+		ProcessingPercent:  fees.ProcessingPercent,
+		ProcessingFixed:    fees.ProcessingFixed,
+		Amount:             amount,
+		WorkerNet:          net,
+		PlatformFee:        platformFee,
+		ProcessingFee:      processingFee,
+		Provider:           s.provider.Name(),
+	}, nil
+}
@@ -0,0 +1,81 @@
+package payment
+
+import (
+	"testing"
+	"time"
+
+	"app/internal/model"
+)
+
+func TestSelectFeeRule(t *testing.T) {
+	str := func(s string) *string { return &s }
+	at := func(day int) *time.Time {
+		t := time.Date(2026, 11, day, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+
+	rules := []model.FeeRule{
+		{ID: 1, Category: str("cleaning"), PlatformFeePercent: 8, IsActive: true},
+		{ID: 2, WorkerTier: str("pro"), PlatformFeePercent: 7, IsActive: true},
+		{ID: 3, Category: str("cleaning"), WorkerTier: str("pro"), PlatformFeePercent: 6, IsActive: true},
+		{ID: 4, StartsAt: at(20), EndsAt: at(28), Priority: 10, IsActive: true}, // Zero-fee promotion
+		{ID: 5, Category: str("moving"), PlatformFeePercent: 12, IsActive: false},
+		{ID: 6, Category: str("plumbing"), PlatformFeePercent: 9, IsActive: true},
+		{ID: 7, Category: str("plumbing"), PlatformFeePercent: 11, IsActive: true},
+	}
+
+	tests := []struct {
+		name     string
+		category string
+		tier     string
+		at       *time.Time
+		want     int // Rule ID; 0 for the default fee
+	}{
+		{name: "no matching rule", category: "gardening", at: at(1)},
+		{name: "category", category: "cleaning", at: at(1), want: 1},
+		{name: "worker tier", category: "gardening", tier: "pro", at: at(1), want: 2},
+		{name: "more conditions win a tie", category: "cleaning", tier: "pro", at: at(1), want: 3},
+		{name: "promotion outranks", category: "cleaning", tier: "pro", at: at(20), want: 4},
+		{name: "promotion end is exclusive", category: "cleaning", at: at(28), want: 1},
+		{name: "inactive rule", category: "moving", at: at(1)},
+		{name: "newest wins an exact tie", category: "plumbing", at: at(1), want: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := 0
+			if rule := SelectFeeRule(rules, tt.category, tt.tier, *tt.at); rule != nil {
+				got = rule.ID
+			}
+			if got != tt.want {
+				t.Errorf("SelectFeeRule(%q, %q) = rule %d, want %d", tt.category, tt.tier, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeeScheduleWithRule(t *testing.T) {
+	stripe := FeeSchedule{PlatformPercent: 10, ProcessingPercent: 2.9, ProcessingFixed: model.USD(30)}
+
+	tests := []struct {
+		name                 string
+		rule                 *model.FeeRule
+		net, platform, procs int64
+	}{
+		{name: "default fee", net: 8680, platform: 1000, procs: 320},
+		{name: "lower percent", rule: &model.FeeRule{PlatformFeePercent: 5}, net: 9180, platform: 500, procs: 320},
+		{name: "flat fee", rule: &model.FeeRule{PlatformFeeFixed: model.USD(250)}, net: 9430, platform: 250, procs: 320},
+		{name: "percent plus flat", rule: &model.FeeRule{PlatformFeePercent: 5, PlatformFeeFixed: model.USD(100)}, net: 9080, platform: 600, procs: 320},
+		{name: "zero-fee promotion keeps processing", rule: &model.FeeRule{}, net: 9680, platform: 0, procs: 320},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			net, platform, processing := stripe.WithRule(tt.rule).Split(model.USD(10000))
+			if net != model.USD(tt.net) || platform != model.USD(tt.platform) || processing != model.USD(tt.procs) {
+				t.Errorf("Split() = %s, %s, %s; want %s, %s, %s", net, platform, processing,
+					model.USD(tt.net), model.USD(tt.platform), model.USD(tt.procs))
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package payment
+
+import "app/internal/model"
+
+// FeeSchedule is what the platform and the card processor take from a charge
+type FeeSchedule struct {
+	PlatformPercent   float64
+	PlatformFixed     model.Money // Per transaction, on top of PlatformPercent
+	ProcessingPercent float64
+	ProcessingFixed   model.Money // Per transaction
+}
+
+// Split divides amount into what the worker nets and the two fees. Each fee is
+// rounded to the cent on its own and the worker gets the remainder, so the three
+// parts always add up to amount exactly. Fixed fees are charged in amount's currency.
+func (f FeeSchedule) Split(amount model.Money) (netAmount, platformFee, processingFee model.Money) {
+	platformFee = amount.Percent(f.PlatformPercent).Add(f.PlatformFixed.In(amount.Currency))
+	processingFee = amount.Percent(f.ProcessingPercent).Add(f.ProcessingFixed.In(amount.Currency))
+	netAmount = amount.Sub(platformFee).Sub(processingFee)
+	return
+}
+
+// WithRule replaces the platform fee with a fee rule's; the processing fee is the
+// provider's and stays. A nil rule leaves the schedule unchanged.
+func (f FeeSchedule) WithRule(rule *model.FeeRule) FeeSchedule {
+	if rule != nil {
+		f.PlatformPercent = rule.PlatformFeePercent
+		f.PlatformFixed = rule.PlatformFeeFixed
+	}
+	return f
+}
@@ -0,0 +1,96 @@
+package payment
+
+import (
+	"testing"
+
+	"app/config"
+	"app/internal/model"
+)
+
+func TestFeeScheduleSplit(t *testing.T) {
+	stripe := FeeSchedule{PlatformPercent: 10, ProcessingPercent: 2.9, ProcessingFixed: model.USD(30)}
+	clover := FeeSchedule{PlatformPercent: 10, ProcessingPercent: 2.6, ProcessingFixed: model.USD(10)}
+
+	tests := []struct {
+		name                 string
+		fees                 FeeSchedule
+		amount               int64
+		net, platform, procs int64
+	}{
+		{name: "stripe round amount", fees: stripe, amount: 10000, net: 8680, platform: 1000, procs: 320},
+		{name: "stripe fractional cents", fees: stripe, amount: 1999, net: 1711, platform: 200, procs: 88},
+		{name: "stripe half cent rounds up", fees: stripe, amount: 1500, net: 1276, platform: 150, procs: 74}, // 2.9% is 43.5 cents
+		{name: "stripe one cent", fees: stripe, amount: 1, net: -29, platform: 0, procs: 30},
+		{name: "stripe zero", fees: stripe, amount: 0, net: -30, platform: 0, procs: 30},
+		{name: "clover round amount", fees: clover, amount: 10000, net: 8730, platform: 1000, procs: 270},
+		{name: "clover fractional cents", fees: clover, amount: 1999, net: 1737, platform: 200, procs: 62},
+		{name: "clover half cent rounds up", fees: clover, amount: 250, net: 208, platform: 25, procs: 17}, // 2.6% is 6.5 cents
+		{name: "platform half cent rounds up", fees: clover, amount: 5, net: -6, platform: 1, procs: 10},
+		{name: "no fees", fees: FeeSchedule{}, amount: 1234, net: 1234, platform: 0, procs: 0},
+		{name: "refund direction", fees: stripe, amount: -1999, net: -1771, platform: -200, procs: -28},
+		{name: "large amount", fees: stripe, amount: 99999999, net: 87099969, platform: 10000000, procs: 2900030},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			net, platform, processing := tt.fees.Split(model.USD(tt.amount))
+			if net != model.USD(tt.net) || platform != model.USD(tt.platform) || processing != model.USD(tt.procs) {
+				t.Errorf("Split(%d) = %s, %s, %s; want %s, %s, %s", tt.amount, net, platform, processing,
+					model.USD(tt.net), model.USD(tt.platform), model.USD(tt.procs))
+			}
+		})
+	}
+}
+
+func TestFeeScheduleSplitAddsUp(t *testing.T) {
+	schedules := []FeeSchedule{
+		{PlatformPercent: 10, ProcessingPercent: 2.9, ProcessingFixed: model.USD(30)},
+		{PlatformPercent: 10, ProcessingPercent: 2.6, ProcessingFixed: model.USD(10)},
+		{PlatformPercent: 12.5, ProcessingPercent: 3.49, ProcessingFixed: model.USD(49)},
+		{PlatformPercent: 0.1, ProcessingPercent: 0.05},
+	}
+
+	for _, fees := range schedules {
+		for cents := int64(0); cents <= 100000; cents++ {
+			amount := model.USD(cents)
+			net, platform, processing := fees.Split(amount)
+			if sum := net.Add(platform).Add(processing); sum != amount {
+				t.Fatalf("%+v: Split(%s) parts add up to %s", fees, amount, sum)
+			}
+			if again, _, _ := fees.Split(amount); again != net {
+				t.Fatalf("%+v: Split(%s) is not deterministic", fees, amount)
+			}
+		}
+	}
+}
+
+func TestProviderFees(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider Provider
+		amount   model.Money
+		want     [3]model.Money
+	}{
+		{
+			name:     "stripe",
+			provider: NewStripeProvider(&config.StripeConfig{PlatformFeePercent: 15}),
+			amount:   model.USD(4000),
+			want:     [3]model.Money{model.USD(3254), model.USD(600), model.USD(146)},
+		},
+		{
+			name:     "clover",
+			provider: NewCloverProvider(&config.CloverConfig{PlatformFeePercent: 15}),
+			amount:   model.USD(4000),
+			want:     [3]model.Money{model.USD(3286), model.USD(600), model.USD(114)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			net, platform, processing := tt.provider.CalculateNetAmount(tt.amount)
+			if got := [3]model.Money{net, platform, processing}; got != tt.want {
+				t.Errorf("CalculateNetAmount(%s) = %v, want %v", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"app/internal/model"
+)
+
+// ChargeHandoffPortion charges the consumer for the portion of a job a worker completed
+// before handing it off. The portion is a separate charge, captured at once, on the
+// card that authorized the job. It is recorded as an adjustment to the job's
+// authorization for the outgoing worker, less the job's fees, so the next payout
+// settles it. The authorization captures only the rest of the job. A call repeating
+// the idempotency key of one that succeeded returns that charge.
+func (s *PaymentService) ChargeHandoffPortion(ctx context.Context, jobID, workerID int, amount model.Money, idempotencyKey string) (*model.EnhancedTransaction, error) {
+	job, err := s.getJob(jobID)
+	if err == sql.ErrNoRows {
+		return nil, ErrJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	unlock, err := s.lockIdempotencyKey(ctx, job.ConsumerID, idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	previousID, err := s.findIdempotentTransaction(job.ConsumerID, idempotencyKey, "handoff")
+	if err != nil {
+		return nil, err
+	}
+	if previousID != 0 {
+		transaction, err := s.getTransaction(previousID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction: %w", err)
+		}
+		if transaction.JobID != jobID {
+			return nil, ErrIdempotencyKeyReused
+		}
+		return transaction, nil
+	}
+	if err := checkRequestCurrency("hand-off", amount.Currency, job.Currency); err != nil {
+		return nil, err
+	}
+
+	// 1. Find the job's authorization, whose card is charged
+	var parentID int
+	var parentProvider string
+	var sourceToken sql.NullString
+	parentMerchant := s.merchantID
+	err = s.db.QueryRow(`
+		SELECT id, payment_provider, provider_source_token, COALESCE(merchant_id, $2)
+		FROM transactions
+		WHERE job_id = $1 AND transaction_type = 'authorization' AND status <> 'failed'
+		ORDER BY id DESC
+		LIMIT 1
+	`, jobID, s.merchantID).Scan(&parentID, &parentProvider, &sourceToken, &parentMerchant)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("job %d has no payment authorization to charge", jobID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job authorization: %w", err)
+	}
+
+	merchant, err := s.jobMerchant(job)
+	if err != nil {
+		return nil, err
+	}
+	provider := merchant.Provider
+	if !sourceToken.Valid || sourceToken.String == "" || parentProvider != provider.Name() || parentMerchant != merchant.ID {
+		return nil, fmt.Errorf("no payment source provided")
+	}
+
+	// The outgoing worker's fee tier applies to the portion they are paid for
+	job.GigWorkerID = &workerID
+	rule, err := s.jobFeeRule(job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fee rules: %w", err)
+	}
+	net, platformFee, processingFee := provider.Fees().WithRule(rule).Split(amount)
+
+	// 2. Charge the portion with the provider
+	metadata := map[string]interface{}{
+		"job_id":      jobID,
+		"consumer_id": job.ConsumerID,
+		"worker_id":   workerID,
+		"type":        "handoff_portion",
+	}
+	charge, err := provider.Authorize(ctx, sourceToken.String, amount.Cents, amount.Currency, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("%w by %s: %w", ErrPaymentDeclined, provider.Name(), err)
+	}
+	capture, err := provider.Capture(ctx, charge.ID, nil)
+	if err != nil {
+		s.releaseHandoffCharge(ctx, provider, jobID, charge.ID)
+		return nil, fmt.Errorf("failed to capture hand-off portion with %s: %w", provider.Name(), err)
+	}
+	s.checkProviderAmount(ctx, provider, jobID, 0, "handoff", &amount.Cents, capture.AmountCents, amount.Currency)
+
+	// 3. Record the portion for the outgoing worker
+	now := s.clock.Now()
+	tx, err := s.db.Begin()
+	if err != nil {
+		s.releaseHandoffCharge(ctx, provider, jobID, charge.ID)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var transactionID int
+	err = tx.QueryRow(`
+		INSERT INTO transactions (
+			job_id, consumer_id, gig_worker_id, amount, currency,
+			status, transaction_type,
+			payment_provider, provider_charge_id, provider_source_token,
+			authorized_at, captured_at, capture_amount,
+			payment_method, last_four,
+			processing_fee, platform_fee, net_amount,
+			parent_transaction_id, metadata, uuid, merchant_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, NULLIF($22, ''))
+		RETURNING id
+	`,
+		jobID, job.ConsumerID, workerID, amount, amount.Currency,
+		"completed", "adjustment",
+		provider.Name(), charge.ID, charge.SourceToken,
+		now, now, model.NewMoney(capture.AmountCents, amount.Currency),
+		charge.Brand, charge.Last4,
+		processingFee, platformFee, net,
+		parentID, toJSON(metadata), s.ids.NewID(), merchant.ID,
+	).Scan(&transactionID)
+	if err == nil {
+		err = s.createPaymentEvent(tx, transactionID, "handoff", "success", capture.Raw, nil, job.ConsumerID, idempotencyKey)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		// The portion is not recorded, so the consumer must not be charged for it
+		s.releaseHandoffCharge(ctx, provider, jobID, charge.ID)
+		return nil, fmt.Errorf("failed to record hand-off portion: %w", err)
+	}
+
+	transaction, err := s.getTransaction(transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	return transaction, nil
+}
+
+// releaseHandoffCharge refunds a hand-off portion charge that could not be recorded
+func (s *PaymentService) releaseHandoffCharge(ctx context.Context, provider Provider, jobID int, chargeID string) {
+	if _, err := provider.Refund(context.WithoutCancel(ctx), chargeID, nil, "hand-off portion not recorded"); err != nil {
+		slog.ErrorContext(ctx, "Failed to release unrecorded hand-off charge", "job_id", jobID, "charge_id", chargeID, "error", err)
+	}
+}
+
+// handoffPortions is what an authorization's hand-off portions took of the job: their
+// charges and how each was split between the outgoing worker and fees
+type handoffPortions struct {
+	Amount        model.Money
+	NetAmount     model.Money
+	PlatformFee   model.Money
+	ProcessingFee model.Money
+}
+
+// getHandoffPortions sums the hand-off portions charged against an authorization and
+// not refunded. The consumer paid them separately, so the authorization captures and
+// settles only the rest of the job.
+func (s *PaymentService) getHandoffPortions(authorizationID int, currency string) (handoffPortions, error) {
+	var p handoffPortions
+	err := s.db.QueryRow(`
+		SELECT COALESCE(SUM(amount), 0), COALESCE(SUM(net_amount), 0),
+		       COALESCE(SUM(platform_fee), 0), COALESCE(SUM(processing_fee), 0)
+		FROM transactions
+		WHERE parent_transaction_id = $1 AND transaction_type = 'adjustment'
+		  AND metadata->>'type' = 'handoff_portion' AND status = 'completed'
+	`, authorizationID).Scan(&p.Amount, &p.NetAmount, &p.PlatformFee, &p.ProcessingFee)
+	if err != nil {
+		return handoffPortions{}, err
+	}
+	p.Amount, p.NetAmount = p.Amount.In(currency), p.NetAmount.In(currency)
+	p.PlatformFee, p.ProcessingFee = p.PlatformFee.In(currency), p.ProcessingFee.In(currency)
+	return p, nil
+}
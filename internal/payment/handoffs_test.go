@@ -0,0 +1,109 @@
+package payment
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"app/internal/model"
+)
+
+func TestChargeHandoffPortion(t *testing.T) {
+	portion := transactionRow{
+		id: 9, jobID: 42, consumerID: 3, workerID: 7, amount: "30.00",
+		status: "completed", kind: "adjustment", chargeID: "ch_1", captured: true, platformFee: "3.00", net: "27.00",
+	}
+	db := (&scriptDB{}).
+		on("FROM jobs WHERE id", jobRow(42, 3, 7, "in_progress")).
+		on("transaction_type = 'authorization'", []driver.Value{int64(5), "fake", "tok_card", ""}).
+		on("INSERT INTO transactions", []driver.Value{int64(9)}).
+		on("clover_charge_id", portion.values())
+	provider := &fakeProvider{}
+
+	transaction, err := newTestService(db, provider).ChargeHandoffPortion(context.Background(), 42, 7, model.USD(3000), "handoff-portion-1")
+	if err != nil {
+		t.Fatalf("ChargeHandoffPortion() error = %v", err)
+	}
+	if transaction.ID != 9 {
+		t.Errorf("transaction ID = %d, want 9", transaction.ID)
+	}
+	if len(provider.authorized) != 1 || provider.authorized[0] != 3000 {
+		t.Errorf("provider authorizations = %v, want [3000]", provider.authorized)
+	}
+	if len(provider.captured) != 1 {
+		t.Errorf("provider captures = %d, want the portion captured at once", len(provider.captured))
+	}
+	if len(provider.refunded) != 0 {
+		t.Errorf("provider refunds = %v, want none", provider.refunded)
+	}
+	if got := len(db.executed("INSERT INTO payment_events")); got != 1 {
+		t.Errorf("payment events = %d, want 1", got)
+	}
+}
+
+func TestCaptureJobPaymentDeductsHandoffPortions(t *testing.T) {
+	authorization := transactionRow{
+		id: 5, jobID: 42, consumerID: 3, workerID: 8, amount: "100.00",
+		status: "completed", kind: "authorization", chargeID: "ch_hold", platformFee: "10.00", net: "90.00",
+	}
+
+	tests := []struct {
+		name        string
+		portions    []driver.Value
+		wantCapture *int64
+		wantNet     model.Money // Deducted from the replacement's share
+	}{
+		{
+			name:        "portion paid to the outgoing worker",
+			portions:    []driver.Value{"30.00", "27.00", "3.00", "0"},
+			wantCapture: ptr(int64(7000)),
+			wantNet:     model.USD(2700),
+		},
+		{
+			name:     "portion refunded when the hand-off failed",
+			portions: []driver.Value{"0", "0", "0", "0"},
+			wantNet:  model.USD(0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := (&scriptDB{}).
+				on("clover_charge_id", authorization.values()).
+				on("handoff_portion", tt.portions).
+				onCapture(jobRow(42, 3, 8, "completed"))
+			provider := &fakeProvider{authorized: []int64{10000}}
+
+			_, err := newTestService(db, provider).CaptureJobPayment(context.Background(), 3, model.PaymentCaptureRequest{TransactionID: 5})
+			if err != nil {
+				t.Fatalf("CaptureJobPayment() error = %v", err)
+			}
+
+			if len(provider.captured) != 1 {
+				t.Fatalf("provider captures = %d, want 1", len(provider.captured))
+			}
+			got := provider.captured[0]
+			if (got == nil) != (tt.wantCapture == nil) || (got != nil && *got != *tt.wantCapture) {
+				t.Errorf("captured %v, want %v", deref(got), deref(tt.wantCapture))
+			}
+
+			updates := db.executed("SET captured_at")
+			if len(updates) != 1 {
+				t.Fatalf("transaction updated %d times, want 1", len(updates))
+			}
+			if net := updates[0].args[5]; net != tt.wantNet {
+				t.Errorf("net deducted = %v, want %v", net, tt.wantNet)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+// deref shows a capture amount, nil being the full authorization
+func deref(cents *int64) interface{} {
+	if cents == nil {
+		return "full"
+	}
+	return *cents
+}
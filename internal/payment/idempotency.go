@@ -0,0 +1,105 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+// MaxIdempotencyKeyLength is the longest key payment_events.idempotency_key holds
+const MaxIdempotencyKeyLength = 255
+
+// ErrIdempotencyKeyReused is returned when a key that already completed one payment
+// operation is sent with a different request
+var ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+
+// ErrPaymentBusy is returned when another request holds a payment's lock for longer
+// than advisoryLockWait. Retrying later is safe.
+var ErrPaymentBusy = errors.New("payment is being processed by another request")
+
+// advisoryLockWait is how long a request waits for another holding the same lock
+const advisoryLockWait = 30 * time.Second
+
+// lockIdempotencyKey holds a Postgres advisory lock on the user's key until the
+// returned unlock is called. A retry that arrives while the original request is still
+// waiting on the provider blocks here, then finds the original's event and replays it
+// instead of charging again. Requests without a key are not locked.
+func (s *PaymentService) lockIdempotencyKey(ctx context.Context, userID int, key string) (func(), error) {
+	if key == "" {
+		return func() {}, nil
+	}
+	unlock, err := s.advisoryLock(ctx, "payment:"+strconv.Itoa(userID)+":"+key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock idempotency key: %w", err)
+	}
+	return unlock, nil
+}
+
+// lockTransaction holds a Postgres advisory lock on a transaction until the returned
+// unlock is called. Operations that move its charge at the provider (capture, refund,
+// void and re-authorization) take it, so one cannot act on a charge another is
+// replacing. They read the transaction again once they hold it.
+func (s *PaymentService) lockTransaction(ctx context.Context, transactionID int) (func(), error) {
+	unlock, err := s.advisoryLock(ctx, "transaction:"+strconv.Itoa(transactionID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock transaction: %w", err)
+	}
+	return unlock, nil
+}
+
+// advisoryLock holds a session advisory lock on scope on a reserved connection until
+// unlock is called, even if the request is cancelled once it holds it. Waiting for the
+// lock gives up when the request is cancelled, or with ErrPaymentBusy after
+// advisoryLockWait. A cancelled wait discards the connection, and with it the lock
+// should it have been granted as the wait was cancelled.
+func (s *PaymentService) advisoryLock(ctx context.Context, scope string) (func(), error) {
+	lockCtx, cancel := context.WithTimeout(ctx, advisoryLockWait)
+	defer cancel()
+	conn, err := s.db.Conn(lockCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve connection: %w", err)
+	}
+	if _, err := conn.ExecContext(lockCtx, `SELECT pg_advisory_lock(hashtext($1))`, scope); err != nil {
+		conn.Close()
+		if ctx.Err() == nil && errors.Is(lockCtx.Err(), context.DeadlineExceeded) {
+			return nil, ErrPaymentBusy
+		}
+		return nil, err
+	}
+
+	unlockCtx := context.WithoutCancel(ctx)
+	return func() {
+		if _, err := conn.ExecContext(unlockCtx, `SELECT pg_advisory_unlock(hashtext($1))`, scope); err != nil {
+			slog.ErrorContext(unlockCtx, "Failed to release advisory lock", "error", err)
+		}
+		conn.Close()
+	}, nil
+}
+
+// findIdempotentTransaction returns the transaction recorded by the user's successful
+// eventType event with this key, or 0 when the key has not completed one. Failed
+// attempts are not replayed, so a declined card can be retried with the same key.
+func (s *PaymentService) findIdempotentTransaction(userID int, key, eventType string) (int, error) {
+	if key == "" {
+		return 0, nil
+	}
+
+	var transactionID int
+	err := s.db.QueryRow(`
+		SELECT transaction_id FROM payment_events
+		WHERE idempotency_key = $1 AND user_id = $2 AND event_type = $3 AND event_status = 'success'
+		ORDER BY id
+		LIMIT 1
+	`, key, userID, eventType).Scan(&transactionID)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up idempotency key: %w", err)
+	}
+	return transactionID, nil
+}
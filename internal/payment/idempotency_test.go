@@ -0,0 +1,173 @@
+package payment
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"app/internal/model"
+)
+
+var errProviderDown = errors.New("provider unavailable")
+
+// authorizationRow is the authorization the idempotency tests capture
+var authorizationRow = transactionRow{
+	id: 5, jobID: 42, consumerID: 3, workerID: 7, amount: "100.00",
+	status: "completed", kind: "authorization", chargeID: "ch_hold", platformFee: "10.00", net: "90.00",
+}
+
+// onSucceeded answers idempotency key lookups with a successful event on transactionID
+func (d *scriptDB) onSucceeded(transactionID int) *scriptDB {
+	return d.on("FROM payment_events", []driver.Value{int64(transactionID)})
+}
+
+func TestCaptureIdempotencyReplay(t *testing.T) {
+	captured := authorizationRow
+	captured.captured = true
+	db := (&scriptDB{}).
+		onSucceeded(5).
+		on("clover_charge_id", captured.values())
+	provider := &fakeProvider{}
+
+	resp, err := newTestService(db, provider).CaptureJobPayment(context.Background(), 3, model.PaymentCaptureRequest{
+		TransactionID:  5,
+		IdempotencyKey: "capture-1",
+	})
+	if err != nil {
+		t.Fatalf("CaptureJobPayment() error = %v", err)
+	}
+	if !resp.Replayed {
+		t.Error("Replayed = false for a repeated key")
+	}
+	if resp.TransactionID != 5 {
+		t.Errorf("transaction ID = %d, want 5", resp.TransactionID)
+	}
+	if len(provider.captured) != 0 {
+		t.Errorf("provider captures = %d, want the replay not to capture again", len(provider.captured))
+	}
+	if got := len(db.executed("INSERT INTO payment_events")); got != 0 {
+		t.Errorf("payment events = %d, want none for a replay", got)
+	}
+
+	locks := db.executed("pg_advisory_lock")
+	if len(locks) != 1 || locks[0].args[0] != "payment:3:capture-1" {
+		t.Errorf("locks = %v, want only the idempotency key's", locks)
+	}
+	if got := len(db.executed("pg_advisory_unlock")); got != 1 {
+		t.Errorf("unlocks = %d, want the key released", got)
+	}
+}
+
+func TestIdempotencyKeyReusedForDifferentRequest(t *testing.T) {
+	t.Run("capture of another transaction", func(t *testing.T) {
+		db := (&scriptDB{}).
+			onSucceeded(6).
+			on("clover_charge_id", authorizationRow.values())
+		provider := &fakeProvider{authorized: []int64{10000}}
+
+		_, err := newTestService(db, provider).CaptureJobPayment(context.Background(), 3, model.PaymentCaptureRequest{
+			TransactionID:  5,
+			IdempotencyKey: "capture-1",
+		})
+		if !errors.Is(err, ErrIdempotencyKeyReused) {
+			t.Fatalf("CaptureJobPayment() error = %v, want ErrIdempotencyKeyReused", err)
+		}
+		if len(provider.captured) != 0 {
+			t.Errorf("provider captures = %d, want none", len(provider.captured))
+		}
+	})
+
+	t.Run("authorization of another job", func(t *testing.T) {
+		db := (&scriptDB{}).
+			onSucceeded(5).
+			on("clover_charge_id", authorizationRow.values())
+		provider := &fakeProvider{}
+
+		_, err := newTestService(db, provider).AuthorizeJobPayment(context.Background(), 3, model.PaymentAuthorizeRequest{
+			JobID:          43,
+			Amount:         model.USD(10000),
+			IdempotencyKey: "authorize-1",
+		})
+		if !errors.Is(err, ErrIdempotencyKeyReused) {
+			t.Fatalf("AuthorizeJobPayment() error = %v, want ErrIdempotencyKeyReused", err)
+		}
+		if len(provider.authorized) != 0 {
+			t.Errorf("provider authorizations = %v, want none", provider.authorized)
+		}
+	})
+}
+
+func TestCaptureRetryAfterFailedAttempt(t *testing.T) {
+	db := (&scriptDB{}).
+		on("clover_charge_id", authorizationRow.values()).
+		on("handoff_portion", []driver.Value{"0", "0", "0", "0"}).
+		onCapture(jobRow(42, 3, 7, "completed"))
+	provider := &fakeProvider{authorized: []int64{10000}, failNext: errProviderDown}
+	service := newTestService(db, provider)
+	req := model.PaymentCaptureRequest{TransactionID: 5, IdempotencyKey: "capture-1"}
+
+	if _, err := service.CaptureJobPayment(context.Background(), 3, req); !errors.Is(err, errProviderDown) {
+		t.Fatalf("first CaptureJobPayment() error = %v, want the provider's", err)
+	}
+	events := db.executed("INSERT INTO payment_events")
+	if len(events) != 1 || events[0].args[2] != "failed" || events[0].args[6] != "capture-1" {
+		t.Fatalf("payment events = %v, want the failure recorded with its key", events)
+	}
+
+	// Only successful events are replayed, so the failure is not found under the key
+	lookups := 0
+	for _, statement := range db.statements {
+		if strings.Contains(statement, "FROM payment_events") {
+			lookups++
+			if !strings.Contains(statement, "event_status = 'success'") {
+				t.Errorf("key lookup %q would replay failed attempts", statement)
+			}
+		}
+	}
+	if lookups != 1 {
+		t.Errorf("key lookups = %d, want 1", lookups)
+	}
+
+	resp, err := service.CaptureJobPayment(context.Background(), 3, req)
+	if err != nil {
+		t.Fatalf("retried CaptureJobPayment() error = %v", err)
+	}
+	if resp.Replayed {
+		t.Error("Replayed = true for a retry of a failed attempt")
+	}
+	if len(provider.captured) != 1 {
+		t.Errorf("provider captures = %d, want the retry to capture", len(provider.captured))
+	}
+	if got := len(db.executed("SET captured_at")); got != 1 {
+		t.Errorf("transaction updated %d times, want 1", got)
+	}
+	events = db.executed("INSERT INTO payment_events")
+	if len(events) != 2 || events[1].args[2] != "success" || events[1].args[6] != "capture-1" {
+		t.Errorf("payment events = %v, want the retry's success recorded with its key", events)
+	}
+}
+
+func TestCaptureCancelledWhileWaitingForLock(t *testing.T) {
+	db := (&scriptDB{}).
+		on("clover_charge_id", authorizationRow.values()).
+		onCapture(jobRow(42, 3, 7, "completed"))
+	provider := &fakeProvider{authorized: []int64{10000}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := newTestService(db, provider).CaptureJobPayment(ctx, 3, model.PaymentCaptureRequest{
+		TransactionID:  5,
+		IdempotencyKey: "capture-1",
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("CaptureJobPayment() error = %v, want the request's cancellation", err)
+	}
+	if got := len(db.executed("pg_advisory_lock")); got != 0 {
+		t.Errorf("locks = %d, want a cancelled request not to wait for one", got)
+	}
+	if len(provider.captured) != 0 {
+		t.Errorf("provider captures = %d, want none", len(provider.captured))
+	}
+}
@@ -0,0 +1,180 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"app/internal/model"
+	"app/internal/notifications"
+)
+
+// ErrInvariantViolated is returned when the amounts about to be charged, captured or
+// refunded do not add up. Nothing is sent to the provider.
+var ErrInvariantViolated = errors.New("payment amounts do not add up")
+
+// invariantAlertWindow suppresses repeat alerts for the same check on the same job
+const invariantAlertWindow = time.Hour
+
+// InvariantViolation describes the check that failed and the amounts involved
+type InvariantViolation struct {
+	Check  string
+	Detail string
+}
+
+func (v *InvariantViolation) Error() string {
+	return fmt.Sprintf("%s: %s: %s", ErrInvariantViolated, v.Check, v.Detail)
+}
+
+func (v *InvariantViolation) Unwrap() error {
+	return ErrInvariantViolated
+}
+
+func violation(check, format string, args ...interface{}) *InvariantViolation {
+	return &InvariantViolation{Check: check, Detail: fmt.Sprintf(format, args...)}
+}
+
+// checkCurrency ensures every amount is in currency, so they can be added up
+func checkCurrency(currency string, amounts ...model.Money) error {
+	for _, amount := range amounts {
+		if amount.Currency != currency {
+			return violation("currency_mismatch", "%s %s amount mixed with %s amounts", amount, amount.Currency, currency)
+		}
+	}
+	return nil
+}
+
+// CheckFeeSplit verifies that what the worker nets plus the fees is exactly the gross
+// amount, and that no part is negative
+func CheckFeeSplit(gross, net, platformFee, processingFee model.Money) error {
+	if err := checkCurrency(gross.Currency, net, platformFee, processingFee); err != nil {
+		return err
+	}
+	if platformFee.IsNegative() || processingFee.IsNegative() {
+		return violation("negative_fee", "platform fee %s, processing fee %s", platformFee, processingFee)
+	}
+	if net.IsNegative() {
+		return violation("negative_net", "worker nets %s of %s after %s and %s in fees", net, gross, platformFee, processingFee)
+	}
+	if sum := net.Add(platformFee).Add(processingFee); sum != gross {
+		return violation("fee_split", "net %s + fees %s and %s = %s, gross is %s", net, platformFee, processingFee, sum, gross)
+	}
+	return nil
+}
+
+// CheckPriceBreakdown verifies a quote before it is charged: labor and fees add up to
+// the subtotal, credits are within it, and the total is the subtotal less credits
+// plus tax
+func CheckPriceBreakdown(b model.PriceBreakdown) error {
+	if err := checkCurrency(b.Currency, b.Subtotal, b.Credits, b.Tax, b.Total); err != nil {
+		return err
+	}
+	if err := CheckFeeSplit(b.Subtotal, b.Labor, b.PlatformFee, b.ProcessingFee); err != nil {
+		return err
+	}
+	if b.Credits.IsNegative() || b.Credits.Cents > b.Subtotal.Cents {
+		return violation("credits_range", "credits %s against a subtotal of %s", b.Credits, b.Subtotal)
+	}
+	if b.Tax.IsNegative() {
+		return violation("negative_tax", "tax %s", b.Tax)
+	}
+	if total := b.Subtotal.Sub(b.Credits).Add(b.Tax); total != b.Total {
+		return violation("total", "subtotal %s - credits %s + tax %s = %s, total is %s", b.Subtotal, b.Credits, b.Tax, total, b.Total)
+	}
+	return nil
+}
+
+// CheckSplits verifies that the parts an amount is split into sum exactly to it.
+// A part may be negative, e.g. the platform's share after it funds account credit.
+func CheckSplits(total model.Money, splits ...model.Money) error {
+	if err := checkCurrency(total.Currency, splits...); err != nil {
+		return err
+	}
+	sum := model.NewMoney(0, total.Currency)
+	for _, split := range splits {
+		sum = sum.Add(split)
+	}
+	if sum != total {
+		return violation("split_total", "splits add up to %s, amount is %s", sum, total)
+	}
+	return nil
+}
+
+// checkCaptureSplits verifies an authorization before its capture: the worker's net
+// plus fees is the job price it was charged for, and the worker's share (net plus
+// expenses and parts), fees and tax less platform-funded credit and the hand-off
+// portions paid separately sum exactly to the amount being captured
+func (s *PaymentService) checkCaptureSplits(transaction *model.EnhancedTransaction, portions handoffPortions, expenses, parts, capture model.Money) error {
+	if transaction.NetAmount == nil {
+		return violation("missing_net", "authorization %d has no net amount", transaction.ID)
+	}
+	tax, credits, err := s.getTaxAndCredits(transaction.ID, transaction.Currency)
+	if err != nil {
+		return fmt.Errorf("failed to get tax and credits: %w", err)
+	}
+
+	price := transaction.Amount.Sub(tax).Add(credits)
+	if err := CheckFeeSplit(price, *transaction.NetAmount, transaction.PlatformFee, transaction.ProcessingFee); err != nil {
+		return err
+	}
+	workerShare := transaction.NetAmount.Sub(portions.NetAmount).Add(expenses).Add(parts)
+	return CheckSplits(capture, workerShare, transaction.PlatformFee.Sub(portions.PlatformFee).Sub(credits),
+		transaction.ProcessingFee.Sub(portions.ProcessingFee), tax)
+}
+
+// getTaxAndCredits reads the tax and account credit recorded in an authorization's
+// metadata, which are in the authorization's currency
+func (s *PaymentService) getTaxAndCredits(transactionID int, currency string) (tax, credits model.Money, err error) {
+	err = s.db.QueryRow(`
+		SELECT COALESCE(metadata->>'tax', '0'), COALESCE(metadata->>'credits', '0')
+		FROM transactions WHERE id = $1
+	`, transactionID).Scan(&tax, &credits)
+	return tax.In(currency), credits.In(currency), err
+}
+
+// checkProviderAmount verifies the provider moved the amount that was asked for. The
+// money has already moved when this fails, so it is alerted on but not returned.
+func (s *PaymentService) checkProviderAmount(ctx context.Context, provider Provider, jobID, transactionID int, operation string, requestedCents *int64, movedCents int64, currency string) {
+	if requestedCents == nil || *requestedCents == movedCents {
+		return
+	}
+	s.reportViolation(ctx, jobID, transactionID, operation, violation("provider_amount", "%s %s requested, %s reported by %s",
+		operation, model.NewMoney(*requestedCents, currency), model.NewMoney(movedCents, currency), provider.Name()))
+}
+
+// reportViolation logs a failed check and routes it to the payment invariant channel.
+// It returns err so callers can fail with it.
+func (s *PaymentService) reportViolation(ctx context.Context, jobID, transactionID int, operation string, err error) error {
+	slog.ErrorContext(ctx, "Payment invariant violated", "operation", operation, "job_id", jobID, "error", err)
+
+	check := "unknown"
+	var v *InvariantViolation
+	if errors.As(err, &v) {
+		check = v.Check
+	}
+	fields := map[string]string{
+		"Job":       strconv.Itoa(jobID),
+		"Operation": operation,
+		"Check":     check,
+		"Provider":  s.provider.Name(),
+	}
+	if transactionID != 0 {
+		fields["Transaction"] = strconv.Itoa(transactionID)
+	}
+
+	_, alertErr := notifications.PublishOnce(context.WithoutCancel(ctx), s.db, notifications.EventPaymentInvariant, notifications.OpsAlert{
+		Title:    fmt.Sprintf("Payment invariant violated during %s", operation),
+		Summary:  err.Error(),
+		Severity: notifications.SeverityCritical,
+		Source:   "payments",
+		DedupKey: fmt.Sprintf("payment-invariant-%s-%s-job-%d", operation, check, jobID),
+		Fields:   fields,
+	}, invariantAlertWindow)
+	if alertErr != nil {
+		slog.ErrorContext(ctx, "Failed to route payment invariant alert", "job_id", jobID, "error", alertErr)
+	}
+	return err
+}
@@ -0,0 +1,147 @@
+package payment
+
+import (
+	"errors"
+	"testing"
+	"testing/quick"
+
+	"app/internal/model"
+)
+
+// feeProvider prices with a fixed fee schedule; pricing only needs Name and Fees
+type feeProvider struct {
+	Provider
+	fees FeeSchedule
+}
+
+func (p feeProvider) Name() string { return "test" }
+
+func (p feeProvider) Fees() FeeSchedule { return p.fees }
+
+func violationCheck(err error) string {
+	var v *InvariantViolation
+	if errors.As(err, &v) {
+		return v.Check
+	}
+	return ""
+}
+
+func TestPriceJobInvariantsHold(t *testing.T) {
+	// Random prices up to $100k, fee and tax rates to the hundredth of a percent, and
+	// credit balances that may be negative or exceed the price
+	property := func(priceCents uint32, platformBP, processingBP uint16, fixedCents uint8, taxBP uint16, creditCents int32) bool {
+		fees := FeeSchedule{
+			PlatformPercent:   float64(platformBP%3000) / 100,
+			ProcessingPercent: float64(processingBP%500) / 100,
+			ProcessingFixed:   model.USD(int64(fixedCents % 100)),
+		}
+		price := model.USD(int64(priceCents % 10000000))
+		taxPercent := float64(taxBP%1500) / 100
+
+		b := PriceJob(feeProvider{fees: fees}, price, taxPercent, model.USD(int64(creditCents)))
+		err := CheckPriceBreakdown(b)
+		if b.Labor.IsNegative() {
+			// Fixed processing fees can exceed tiny prices; those must never be charged
+			return violationCheck(err) == "negative_net" && errors.Is(err, ErrInvariantViolated)
+		}
+		return err == nil && b.Total.Cents == b.Subtotal.Cents-b.Credits.Cents+b.Tax.Cents
+	}
+
+	if err := quick.Check(property, &quick.Config{MaxCount: 20000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheckSplitsProperty(t *testing.T) {
+	property := func(parts []int32, off int8) bool {
+		splits := make([]model.Money, len(parts))
+		total := model.USD(0)
+		for i, p := range parts {
+			splits[i] = model.USD(int64(p))
+			total = total.Add(splits[i])
+		}
+		if CheckSplits(total, splits...) != nil {
+			return false
+		}
+		if off == 0 {
+			return true
+		}
+		// Any amount that is not exactly the sum is a violation
+		return violationCheck(CheckSplits(total.Add(model.USD(int64(off))), splits...)) == "split_total"
+	}
+
+	if err := quick.Check(property, &quick.Config{MaxCount: 5000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheckPriceBreakdown(t *testing.T) {
+	usd := model.USD
+	valid := model.PriceBreakdown{
+		Currency: "USD", Labor: usd(8680), PlatformFee: usd(1000), ProcessingFee: usd(320), Subtotal: usd(10000),
+		Credits: usd(2000), TaxRatePercent: 8.25, Tax: usd(660), Total: usd(8660),
+	}
+
+	tests := []struct {
+		name   string
+		modify func(b *model.PriceBreakdown)
+		check  string
+	}{
+		{name: "valid", modify: func(b *model.PriceBreakdown) {}},
+		{name: "fees off by a cent", modify: func(b *model.PriceBreakdown) { b.PlatformFee = usd(1001) }, check: "fee_split"},
+		{name: "negative labor", modify: func(b *model.PriceBreakdown) {
+			b.Labor, b.ProcessingFee = usd(-100), usd(9100)
+		}, check: "negative_net"},
+		{name: "negative fee", modify: func(b *model.PriceBreakdown) {
+			b.Labor, b.ProcessingFee = usd(9100), usd(-100)
+		}, check: "negative_fee"},
+		{name: "credits exceed subtotal", modify: func(b *model.PriceBreakdown) {
+			b.Credits, b.Tax, b.Total = usd(10001), usd(0), usd(-1)
+		}, check: "credits_range"},
+		{name: "negative tax", modify: func(b *model.PriceBreakdown) { b.Tax, b.Total = usd(-1), usd(7999) }, check: "negative_tax"},
+		{name: "total off by a cent", modify: func(b *model.PriceBreakdown) { b.Total = usd(8661) }, check: "total"},
+		{name: "currency mismatch", modify: func(b *model.PriceBreakdown) { b.Tax = model.NewMoney(660, "EUR") }, check: "currency_mismatch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := valid
+			tt.modify(&b)
+			err := CheckPriceBreakdown(b)
+			if got := violationCheck(err); got != tt.check {
+				t.Errorf("CheckPriceBreakdown() = %v, want check %q", err, tt.check)
+			}
+			if tt.check != "" && !errors.Is(err, ErrInvariantViolated) {
+				t.Errorf("CheckPriceBreakdown() = %v, want ErrInvariantViolated", err)
+			}
+		})
+	}
+}
+
+func TestCheckSplits(t *testing.T) {
+	usd := model.USD
+
+	tests := []struct {
+		name   string
+		total  model.Money
+		splits []model.Money
+		check  string
+	}{
+		// $86.60 charged for a $100 job with $20 credit and $6.60 tax, plus $12.50 expenses
+		{name: "capture with credit and expenses", total: usd(9910),
+			splits: []model.Money{usd(8680 + 1250), usd(1000 - 2000), usd(320), usd(660)}},
+		{name: "one cent short", total: usd(9910),
+			splits: []model.Money{usd(8680 + 1250), usd(1000 - 2000), usd(320), usd(659)}, check: "split_total"},
+		{name: "currency mismatch", total: usd(100), splits: []model.Money{model.NewMoney(100, "EUR")}, check: "currency_mismatch"},
+		{name: "no splits for nothing", total: usd(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSplits(tt.total, tt.splits...)
+			if got := violationCheck(err); got != tt.check {
+				t.Errorf("CheckSplits() = %v, want check %q", err, tt.check)
+			}
+		})
+	}
+}
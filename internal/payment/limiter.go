@@ -0,0 +1,209 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"app/config"
+)
+
+// ErrProviderRateLimited is returned when a provider still answers 429 after the
+// retries, or asks to back off for longer than a request may be held
+var ErrProviderRateLimited = errors.New("payment provider is rate limiting requests")
+
+// maxRetryAfter is the longest Retry-After a request waits out before failing with
+// ErrProviderRateLimited instead
+const maxRetryAfter = 30 * time.Second
+
+// defaultRetryAfter is the first back-off when a 429 has no usable Retry-After; it
+// doubles with each retry
+const defaultRetryAfter = time.Second
+
+// ProviderLimiter paces the requests to one payment provider with a token bucket, and
+// holds all of them while the provider has asked, with a 429 and Retry-After, to back
+// off. Requests over the limit wait their turn rather than fail.
+type ProviderLimiter struct {
+	provider string
+	limit    config.RateLimit
+
+	mu          sync.Mutex
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	queued      int
+	requests    int64
+	throttled   int64
+	totalWait   time.Duration
+	maxWait     time.Duration
+}
+
+// ProviderLimiterStats reports a provider's request queue, for /metrics
+type ProviderLimiterStats struct {
+	Provider    string     `json:"provider"`
+	Queued      int        `json:"queued"`
+	Requests    int64      `json:"requests"`
+	Throttled   int64      `json:"throttled"` // 429 responses
+	AvgWaitMs   float64    `json:"avg_wait_ms"`
+	MaxWaitMs   float64    `json:"max_wait_ms"`
+	PausedUntil *time.Time `json:"paused_until,omitempty"`
+}
+
+var (
+	limitersMu sync.Mutex
+	limiters   = map[string]*ProviderLimiter{}
+)
+
+// limiterFor returns the limiter for a provider, creating it with limit on first use,
+// so every client of the provider shares one queue
+func limiterFor(provider string, limit config.RateLimit) *ProviderLimiter {
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+	if l, ok := limiters[provider]; ok {
+		return l
+	}
+	l := NewProviderLimiter(provider, limit)
+	limiters[provider] = l
+	return l
+}
+
+// LimiterStats reports the request queue of each provider in use, by name
+func LimiterStats() []ProviderLimiterStats {
+	limitersMu.Lock()
+	list := make([]*ProviderLimiter, 0, len(limiters))
+	for _, l := range limiters {
+		list = append(list, l)
+	}
+	limitersMu.Unlock()
+
+	stats := make([]ProviderLimiterStats, 0, len(list))
+	for _, l := range list {
+		stats = append(stats, l.Stats())
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Provider < stats[j].Provider })
+	return stats
+}
+
+// NewProviderLimiter creates a limiter starting with a full bucket. A PerMinute of
+// zero turns pacing off; Retry-After is still honored.
+func NewProviderLimiter(provider string, limit config.RateLimit) *ProviderLimiter {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	return &ProviderLimiter{provider: provider, limit: limit, tokens: float64(limit.Burst)}
+}
+
+// Wait blocks until the request may be sent and returns how long it was queued
+func (l *ProviderLimiter) Wait(ctx context.Context) (time.Duration, error) {
+	l.mu.Lock()
+	wait := l.reserve(time.Now())
+	l.queued++
+	l.mu.Unlock()
+
+	var err error
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			err = ctx.Err()
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.queued--
+	if err != nil {
+		l.tokens++ // The request was not sent
+		return 0, err
+	}
+	l.requests++
+	l.totalWait += wait
+	if wait > l.maxWait {
+		l.maxWait = wait
+	}
+	return wait, nil
+}
+
+// reserve takes a token for a request made at now and returns how long it must wait,
+// for the bucket to refill and for any back-off to pass. Tokens may go negative, which
+// queues later requests behind earlier ones. The caller holds l.mu.
+func (l *ProviderLimiter) reserve(now time.Time) time.Duration {
+	var wait time.Duration
+	if l.limit.PerMinute > 0 {
+		perSecond := l.limit.PerMinute / 60
+		if !l.last.IsZero() {
+			l.tokens = math.Min(float64(l.limit.Burst), l.tokens+now.Sub(l.last).Seconds()*perSecond)
+		}
+		l.last = now
+		l.tokens--
+		if l.tokens < 0 {
+			wait = time.Duration(-l.tokens / perSecond * float64(time.Second))
+		}
+	}
+	if paused := l.pausedUntil.Sub(now); paused > wait {
+		wait = paused
+	}
+	return wait
+}
+
+// Backoff holds every request to the provider for d, after it answered 429
+func (l *ProviderLimiter) Backoff(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.throttled++
+	if until := time.Now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// Stats reports the limiter's queue and the time requests have waited in it
+func (l *ProviderLimiter) Stats() ProviderLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	stats := ProviderLimiterStats{
+		Provider:  l.provider,
+		Queued:    l.queued,
+		Requests:  l.requests,
+		Throttled: l.throttled,
+		MaxWaitMs: float64(l.maxWait) / float64(time.Millisecond),
+	}
+	if l.requests > 0 {
+		stats.AvgWaitMs = float64(l.totalWait) / float64(l.requests) / float64(time.Millisecond)
+	}
+	if l.pausedUntil.After(time.Now()) {
+		until := l.pausedUntil
+		stats.PausedUntil = &until
+	}
+	return stats
+}
+
+// ParseRetryAfter reads a Retry-After header, given either in seconds or as an HTTP
+// date. It returns false when the header is missing or unreadable.
+func ParseRetryAfter(header string, now time.Time) (time.Duration, bool) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	at, err := http.ParseTime(header)
+	if err != nil {
+		return 0, false
+	}
+	if d := at.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
@@ -0,0 +1,113 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"app/config"
+	"app/internal/faults"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		header string
+		want   time.Duration
+		wantOK bool
+	}{
+		{header: "", wantOK: false},
+		{header: "5", want: 5 * time.Second, wantOK: true},
+		{header: " 0 ", want: 0, wantOK: true},
+		{header: "-1", wantOK: false},
+		{header: "Fri, 16 Oct 2026 12:00:30 GMT", want: 30 * time.Second, wantOK: true},
+		{header: "Fri, 16 Oct 2026 11:59:00 GMT", want: 0, wantOK: true},
+		{header: "soon", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			got, ok := ParseRetryAfter(tt.header, now)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("ParseRetryAfter(%q) = %v, %v, want %v, %v", tt.header, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestProviderLimiterReserve(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	l := NewProviderLimiter(ProviderClover, config.RateLimit{PerMinute: 60, Burst: 2})
+
+	// The burst goes at once, then requests queue a second apart
+	for i, want := range []time.Duration{0, 0, time.Second, 2 * time.Second} {
+		if got := l.reserve(now); got != want {
+			t.Errorf("reserve() #%d = %v, want %v", i+1, got, want)
+		}
+	}
+
+	// Five seconds on, the queue has drained and one token has refilled
+	now = now.Add(5 * time.Second)
+	if got := l.reserve(now); got != 0 {
+		t.Errorf("reserve() after refill = %v, want 0", got)
+	}
+
+	// A back-off holds requests even with tokens to spare
+	l.pausedUntil = now.Add(10 * time.Second)
+	if got := l.reserve(now.Add(5 * time.Second)); got != 5*time.Second {
+		t.Errorf("reserve() while paused = %v, want 5s", got)
+	}
+}
+
+func TestCloverRetriesRateLimitedRequests(t *testing.T) {
+	tests := []struct {
+		name       string
+		limited    int // 429 responses before success
+		maxRetries int
+		dropRate   float64 // Injected share of dropped responses
+		wantErr    error
+		wantCalls  int
+	}{
+		{name: "succeeds first time", limited: 0, maxRetries: 2, wantCalls: 1},
+		{name: "injected dropped response", limited: 0, maxRetries: 2, dropRate: 1, wantErr: faults.ErrDroppedResponse, wantCalls: 1},
+		{name: "succeeds after retries", limited: 2, maxRetries: 2, wantCalls: 3},
+		{name: "retries exhausted", limited: 3, maxRetries: 2, wantErr: ErrProviderRateLimited, wantCalls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if calls <= tt.limited {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				w.Write([]byte(`{"id":"re_123","charge":"ch_123","amount":500,"status":"succeeded"}`))
+			}))
+			defer server.Close()
+
+			cfg := &config.CloverConfig{APIEndpoint: server.URL, MaxRetries: tt.maxRetries}
+			service := NewCloverService(cfg)
+			service.limiter = NewProviderLimiter(ProviderClover, config.RateLimit{})
+
+			amount := int64(500)
+			ctx := faults.WithPlan(context.Background(), faults.Plan{CloverDropRate: tt.dropRate})
+			_, err := service.RefundPayment(ctx, "ch_123", &amount, "requested_by_customer")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RefundPayment() error = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("Clover was called %d times, want %d", calls, tt.wantCalls)
+			}
+			if stats := service.limiter.Stats(); stats.Throttled != int64(tt.limited) {
+				t.Errorf("Stats().Throttled = %d, want %d", stats.Throttled, tt.limited)
+			}
+		})
+	}
+}
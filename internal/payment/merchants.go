@@ -0,0 +1,119 @@
+package payment
+
+import (
+	"database/sql"
+	"fmt"
+
+	"app/config"
+	"app/internal/model"
+)
+
+// Merchant is a merchant account payments are taken into. Jobs in a merchant's markets
+// are authorized, captured and refunded through its account.
+type Merchant struct {
+	ID       string // Provider's merchant or account ID, stored on every transaction
+	Provider Provider
+	Markets  []string // Slugs of the markets routed to the merchant
+}
+
+// NewMarketMerchants returns the merchant accounts configured for particular markets.
+// Each needs a merchant ID, so its transactions can be routed back to it, and a market
+// may only be routed to one merchant.
+func NewMarketMerchants(cfg *config.PaymentConfig) ([]Merchant, error) {
+	routed := map[string]string{}
+	var merchants []Merchant
+	for _, m := range cfg.Merchants {
+		if len(m.Markets) == 0 {
+			return nil, fmt.Errorf("merchant %s has no markets", m.Name)
+		}
+		id := m.MerchantID()
+		if id == "" {
+			return nil, fmt.Errorf("merchant %s has no merchant ID", m.Name)
+		}
+		provider, err := NewProvider(&config.PaymentConfig{Provider: m.Provider, Clover: m.Clover, Stripe: m.Stripe})
+		if err != nil {
+			return nil, fmt.Errorf("merchant %s: %w", m.Name, err)
+		}
+		for _, market := range m.Markets {
+			if other, ok := routed[market]; ok {
+				return nil, fmt.Errorf("market %s is routed to both merchant %s and %s", market, other, m.Name)
+			}
+			routed[market] = m.Name
+		}
+		merchants = append(merchants, Merchant{ID: id, Provider: provider, Markets: m.Markets})
+	}
+	return merchants, nil
+}
+
+// defaultMerchant is the account payments go to outside the routed markets
+func (s *PaymentService) defaultMerchant() Merchant {
+	return Merchant{ID: s.merchantID, Provider: s.provider}
+}
+
+// marketMerchant returns the merchant for jobs in a market
+func (s *PaymentService) marketMerchant(market string) Merchant {
+	for _, m := range s.merchants {
+		for _, slug := range m.Markets {
+			if slug == market {
+				return m
+			}
+		}
+	}
+	return s.defaultMerchant()
+}
+
+// jobMerchant returns the merchant new payments for a job go to. Jobs are matched to a
+// market by the city in their address, as elsewhere.
+func (s *PaymentService) jobMerchant(job *model.Job) (Merchant, error) {
+	if len(s.merchants) == 0 {
+		return s.defaultMerchant(), nil
+	}
+	var market string
+	err := s.db.QueryRow(`
+		SELECT m.slug
+		FROM jobs j
+		JOIN markets m ON m.city IS NOT NULL AND j.location_address ILIKE '%' || m.city || '%'
+		WHERE j.id = $1
+		ORDER BY m.is_live DESC, m.id
+		LIMIT 1
+	`, job.ID).Scan(&market)
+	if err == sql.ErrNoRows {
+		return s.defaultMerchant(), nil
+	}
+	if err != nil {
+		return Merchant{}, fmt.Errorf("failed to get job market: %w", err)
+	}
+	return s.marketMerchant(market), nil
+}
+
+// merchantFor returns the merchant a transaction was processed for. Transactions
+// without a merchant ID predate routing and went to the default merchant.
+func (s *PaymentService) merchantFor(provider string, merchantID *string) (Merchant, error) {
+	merchant := s.defaultMerchant()
+	if merchantID != nil && *merchantID != s.merchantID {
+		found := false
+		for _, m := range s.merchants {
+			if m.ID == *merchantID && m.Provider.Name() == provider {
+				merchant, found = m, true
+				break
+			}
+		}
+		if !found {
+			return Merchant{}, fmt.Errorf("transaction was processed for %s merchant %s, which is not configured", provider, *merchantID)
+		}
+	}
+	if provider != merchant.Provider.Name() {
+		return Merchant{}, fmt.Errorf("transaction was processed by %s but the configured payment provider is %s",
+			provider, merchant.Provider.Name())
+	}
+	return merchant, nil
+}
+
+// transactionMerchant returns the merchant that can capture or refund a transaction,
+// since charge IDs mean nothing to other providers or merchant accounts
+func (s *PaymentService) transactionMerchant(transaction *model.EnhancedTransaction) (Merchant, error) {
+	if transaction.ProviderChargeID == nil {
+		return Merchant{}, fmt.Errorf("transaction does not have a provider charge ID")
+	}
+	return s.merchantFor(transaction.PaymentProvider, transaction.MerchantID)
+}
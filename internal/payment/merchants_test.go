@@ -0,0 +1,99 @@
+package payment
+
+import (
+	"strings"
+	"testing"
+
+	"app/config"
+)
+
+func TestNewMarketMerchants(t *testing.T) {
+	canada := config.MerchantConfig{Name: "canada", Provider: ProviderClover, Markets: []string{"toronto"}, Clover: config.CloverConfig{MerchantID: "CA123"}}
+
+	tests := []struct {
+		name      string
+		merchants []config.MerchantConfig
+		wantErr   string
+	}{
+		{name: "none"},
+		{name: "routed markets", merchants: []config.MerchantConfig{canada}},
+		{
+			name:      "no markets",
+			merchants: []config.MerchantConfig{{Name: "canada", Provider: ProviderClover, Clover: canada.Clover}},
+			wantErr:   "merchant canada has no markets",
+		},
+		{
+			name:      "no merchant ID",
+			merchants: []config.MerchantConfig{{Name: "canada", Provider: ProviderStripe, Markets: canada.Markets}},
+			wantErr:   "merchant canada has no merchant ID",
+		},
+		{
+			name:      "market routed twice",
+			merchants: []config.MerchantConfig{canada, {Name: "ontario", Provider: ProviderClover, Markets: []string{"toronto"}, Clover: config.CloverConfig{MerchantID: "ON456"}}},
+			wantErr:   "market toronto is routed to both merchant canada and ontario",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merchants, err := NewMarketMerchants(&config.PaymentConfig{Merchants: tt.merchants})
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("NewMarketMerchants() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewMarketMerchants() error = %v", err)
+			}
+			if len(merchants) != len(tt.merchants) {
+				t.Errorf("NewMarketMerchants() returned %d merchants, want %d", len(merchants), len(tt.merchants))
+			}
+		})
+	}
+}
+
+func TestMerchantFor(t *testing.T) {
+	canada := Merchant{ID: "CA123", Provider: feeProvider{}, Markets: []string{"toronto", "vancouver"}}
+	s := (&PaymentService{provider: feeProvider{}}).WithMerchants("US999", []Merchant{canada})
+
+	if got := s.marketMerchant("vancouver"); got.ID != "CA123" {
+		t.Errorf("marketMerchant(vancouver) = %s, want CA123", got.ID)
+	}
+	if got := s.marketMerchant("austin"); got.ID != "US999" {
+		t.Errorf("marketMerchant(austin) = %s, want the default US999", got.ID)
+	}
+
+	id := func(s string) *string { return &s }
+	tests := []struct {
+		name       string
+		provider   string
+		merchantID *string
+		want       string
+		wantErr    string
+	}{
+		{name: "before routing", provider: "test", want: "US999"},
+		{name: "default merchant", provider: "test", merchantID: id("US999"), want: "US999"},
+		{name: "routed merchant", provider: "test", merchantID: id("CA123"), want: "CA123"},
+		{name: "unknown merchant", provider: "test", merchantID: id("GB777"), wantErr: "merchant GB777, which is not configured"},
+		{name: "other provider", provider: ProviderStripe, wantErr: "processed by stripe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.merchantFor(tt.provider, tt.merchantID)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("merchantFor() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("merchantFor() error = %v", err)
+			}
+			if got.ID != tt.want {
+				t.Errorf("merchantFor() = %s, want %s", got.ID, tt.want)
+			}
+		})
+	}
+}
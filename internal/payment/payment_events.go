@@ -0,0 +1,170 @@
+package payment
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"app/internal/model"
+)
+
+// cardNumberPattern matches runs of 13 to 19 digits, optionally split by spaces or
+// dashes, the lengths of card numbers
+var cardNumberPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+
+// MaskCardNumbers replaces every card number in s with asterisks and its last four digits
+func MaskCardNumbers(s string) string {
+	return cardNumberPattern.ReplaceAllStringFunc(s, func(number string) string {
+		digits := strings.NewReplacer(" ", "", "-", "").Replace(number)
+		return strings.Repeat("*", len(digits)-4) + digits[len(digits)-4:]
+	})
+}
+
+// responseSummaryFields are the provider response fields support sees: outcome,
+// amounts and codes. Nothing identifying the card beyond its brand and last four is
+// among them.
+var responseSummaryFields = []string{
+	"id", "status", "result", "amount", "amount_refunded", "currency", "captured", "refunded", "paid",
+	"code", "decline_code", "failure_code", "failure_message", "message", "type", "reason",
+}
+
+// responseErrorObjects are the nested objects providers report errors and outcomes in
+var responseErrorObjects = []string{"error", "last_payment_error", "outcome"}
+
+// SummarizeProviderResponse picks the summary fields from a provider response stored on
+// a payment event, with the card reduced to its brand and last four. Card numbers in
+// messages are masked.
+func SummarizeProviderResponse(response map[string]interface{}) map[string]interface{} {
+	if len(response) == 0 {
+		return nil
+	}
+	summary := map[string]interface{}{}
+	for _, field := range responseSummaryFields {
+		if v, ok := scalar(response[field]); ok {
+			summary[field] = v
+		}
+	}
+	for _, object := range responseErrorObjects {
+		nested, ok := response[object].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, field := range responseSummaryFields {
+			if v, ok := scalar(nested[field]); ok {
+				summary[object+"."+field] = v
+			}
+		}
+	}
+	if card := findCard(response); card != nil {
+		summary["card"] = card
+	}
+	return summary
+}
+
+// ProviderErrorCode is the provider's error code in a summarized response: the decline
+// code when there is one, as the more specific
+func ProviderErrorCode(summary map[string]interface{}) string {
+	for _, field := range []string{
+		"decline_code", "failure_code", "code",
+		"last_payment_error.decline_code", "last_payment_error.code", "error.decline_code", "error.code",
+	} {
+		if code, ok := summary[field].(string); ok && code != "" {
+			return code
+		}
+	}
+	return ""
+}
+
+// scalar returns v when it is a string, number or boolean, masking card numbers in
+// strings
+func scalar(v interface{}) (interface{}, bool) {
+	switch v := v.(type) {
+	case string:
+		return MaskCardNumbers(v), true
+	case float64, bool:
+		return v, true
+	}
+	return nil, false
+}
+
+// findCard returns the brand and last four of the first card found in v: an object
+// with a last4 field
+func findCard(v interface{}) map[string]interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if last4, ok := v["last4"].(string); ok {
+			card := map[string]interface{}{"last4": last4}
+			if brand, ok := v["brand"].(string); ok {
+				card["brand"] = brand
+			}
+			return card
+		}
+		for _, nested := range v {
+			if card := findCard(nested); card != nil {
+				return card
+			}
+		}
+	case []interface{}:
+		for _, nested := range v {
+			if card := findCard(nested); card != nil {
+				return card
+			}
+		}
+	}
+	return nil
+}
+
+// PaymentEvents returns a transaction's payment events, oldest first, with who made
+// each and a redacted summary of the provider's response
+func (s *PaymentService) PaymentEvents(transactionID int) ([]model.AdminPaymentEvent, error) {
+	rows, err := s.db.Query(`
+		SELECT e.id, e.event_type, e.event_status, e.error_code, e.error_message, e.clover_response,
+		       e.idempotency_key, p.id, p.name, p.role, e.created_at
+		FROM payment_events e
+		LEFT JOIN people p ON p.id = e.user_id
+		WHERE e.transaction_id = $1
+		ORDER BY e.created_at, e.id
+	`, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []model.AdminPaymentEvent{}
+	for rows.Next() {
+		var e model.AdminPaymentEvent
+		var errorCode, errorMessage, idempotencyKey, actorName, actorRole sql.NullString
+		var response []byte
+		var actorID sql.NullInt64
+		if err := rows.Scan(&e.ID, &e.EventType, &e.EventStatus, &errorCode, &errorMessage, &response,
+			&idempotencyKey, &actorID, &actorName, &actorRole, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan payment event: %w", err)
+		}
+
+		if len(response) > 0 {
+			var raw map[string]interface{}
+			if err := json.Unmarshal(response, &raw); err == nil {
+				e.ProviderResponse = SummarizeProviderResponse(raw)
+			}
+		}
+		if errorCode.Valid {
+			e.ErrorCode = &errorCode.String
+		} else if code := ProviderErrorCode(e.ProviderResponse); code != "" {
+			e.ErrorCode = &code
+		}
+		if errorMessage.Valid {
+			message := MaskCardNumbers(errorMessage.String)
+			e.ErrorMessage = &message
+		}
+		if idempotencyKey.Valid {
+			e.IdempotencyKey = &idempotencyKey.String
+		}
+		if actorID.Valid {
+			e.Actor = &model.PaymentEventActor{ID: int(actorID.Int64), Name: actorName.String, Role: actorRole.String}
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,82 @@
+package payment
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMaskCardNumbers(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "plain number", in: "card 4242424242424242 declined", want: "card ************4242 declined"},
+		{name: "grouped number", in: "4000-0000-0000-0002", want: "************0002"},
+		{name: "spaced amex", in: "3782 822463 10005", want: "***********0005"},
+		{name: "short numbers kept", in: "order 123456789012 for $25.00", want: "order 123456789012 for $25.00"},
+		{name: "no digits", in: "Your card was declined.", want: "Your card was declined."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MaskCardNumbers(tt.in); got != tt.want {
+				t.Errorf("MaskCardNumbers(%q) = %q; want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeProviderResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response map[string]interface{}
+		want     map[string]interface{}
+		wantCode string
+	}{
+		{
+			name: "clover charge",
+			response: map[string]interface{}{
+				"id": "ch_1", "amount": 5000.0, "currency": "usd", "status": "succeeded", "captured": true,
+				"source": map[string]interface{}{"brand": "VISA", "last4": "4242", "first6": "424242", "exp_month": "12", "exp_year": "2030"},
+			},
+			want: map[string]interface{}{
+				"id": "ch_1", "amount": 5000.0, "currency": "usd", "status": "succeeded", "captured": true,
+				"card": map[string]interface{}{"brand": "VISA", "last4": "4242"},
+			},
+		},
+		{
+			name: "stripe decline",
+			response: map[string]interface{}{
+				"id": "pi_1", "status": "requires_payment_method",
+				"last_payment_error": map[string]interface{}{"code": "card_declined", "decline_code": "insufficient_funds", "message": "Card 4000000000009995 was declined"},
+				"payment_method":     map[string]interface{}{"card": map[string]interface{}{"number": "4000000000009995", "cvc": "123"}},
+			},
+			want: map[string]interface{}{
+				"id": "pi_1", "status": "requires_payment_method",
+				"last_payment_error.code": "card_declined", "last_payment_error.decline_code": "insufficient_funds",
+				"last_payment_error.message": "Card ************9995 was declined",
+			},
+			wantCode: "insufficient_funds",
+		},
+		{
+			name:     "top-level failure code",
+			response: map[string]interface{}{"failure_code": "expired_card", "number": "4242424242424242"},
+			want:     map[string]interface{}{"failure_code": "expired_card"},
+			wantCode: "expired_card",
+		},
+		{name: "empty response"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SummarizeProviderResponse(tt.response)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SummarizeProviderResponse() = %v; want %v", got, tt.want)
+			}
+			if code := ProviderErrorCode(got); code != tt.wantCode {
+				t.Errorf("ProviderErrorCode() = %q; want %q", code, tt.wantCode)
+			}
+		})
+	}
+}
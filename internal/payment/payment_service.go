@@ -1,84 +1,150 @@
 package payment
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"time"
+	"log/slog"
 
-	"app/config"
+	"app/internal/clock"
+	"app/internal/jobevents"
 	"app/internal/model"
 )
 
 // PaymentService handles payment business logic and database operations
 type PaymentService struct {
-	db            *sql.DB
-	cloverService *CloverService
-	config        *config.CloverConfig
+	db              *sql.DB
+	provider        Provider
+	merchantID      string     // Default merchant account with provider
+	merchants       []Merchant // Merchant accounts for particular markets
+	salesTaxPercent float64
+	clock           clock.Clock
+	ids             clock.IDGenerator // UUIDs of new transactions
 }
 
-// NewPaymentService creates a new payment service instance
-func NewPaymentService(db *sql.DB, cloverConfig *config.CloverConfig) *PaymentService {
+// NewPaymentService creates a new payment service instance backed by the given provider
+func NewPaymentService(db *sql.DB, provider Provider, salesTaxPercent float64) *PaymentService {
 	return &PaymentService{
-		db:            db,
-		cloverService: NewCloverService(cloverConfig),
-		config:        cloverConfig,
+		db:              db,
+		provider:        provider,
+		salesTaxPercent: salesTaxPercent,
+		clock:           clock.System,
+		ids:             clock.UUIDs,
 	}
 }
 
+// WithClock makes the service read the time from c and take transaction UUIDs from
+// ids, for tests
+func (s *PaymentService) WithClock(c clock.Clock, ids clock.IDGenerator) *PaymentService {
+	s.clock, s.ids = c, ids
+	return s
+}
+
+// WithMerchants sets the ID of the provider's default merchant account and routes
+// payments for jobs in the merchants' markets to their accounts
+func (s *PaymentService) WithMerchants(defaultID string, merchants []Merchant) *PaymentService {
+	s.merchantID, s.merchants = defaultID, merchants
+	return s
+}
+
+// Provider returns the payment provider processing new payments outside the markets
+// routed to other merchants
+func (s *PaymentService) Provider() Provider {
+	return s.provider
+}
+
 // ==============================================
 // AUTHORIZATION (ESCROW)
 // ==============================================
 
-// AuthorizeJobPayment creates a pre-authorization for a job payment
-func (s *PaymentService) AuthorizeJobPayment(userID int, req model.PaymentAuthorizeRequest) (*model.PaymentAuthorizeResponse, error) {
+// AuthorizeJobPayment creates a pre-authorization for a job payment. A request
+// repeating the idempotency key of one that succeeded returns that authorization.
+func (s *PaymentService) AuthorizeJobPayment(ctx context.Context, userID int, req model.PaymentAuthorizeRequest) (*model.PaymentAuthorizeResponse, error) {
+	unlock, err := s.lockIdempotencyKey(ctx, userID, req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	previousID, err := s.findIdempotentTransaction(userID, req.IdempotencyKey, "authorize")
+	if err != nil {
+		return nil, err
+	}
+	if previousID != 0 {
+		transaction, err := s.getTransaction(previousID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction: %w", err)
+		}
+		if transaction.JobID != req.JobID {
+			return nil, ErrIdempotencyKeyReused
+		}
+		return authorizeResponse(transaction, true), nil
+	}
+
 	// 1. Get job details
 	job, err := s.getJob(req.JobID)
+	if err == sql.ErrNoRows {
+		return nil, ErrJobNotFound
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get job: %w", err)
 	}
 
 	// Verify user is the consumer
 	if job.ConsumerID != userID {
-		return nil, fmt.Errorf("unauthorized: user is not the consumer of this job")
+		return nil, ErrNotJobConsumer
+	}
+
+	// Charge the quoted total; a mismatch means the client showed a stale price
+	quote, err := s.quote(job)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckPriceBreakdown(*quote); err != nil {
+		return nil, s.reportViolation(ctx, job.ID, 0, "authorize", err)
+	}
+	if err := checkRequestCurrency("authorization", req.Currency, quote.Currency); err != nil {
+		return nil, err
+	}
+	if req.Amount.Cents != quote.Total.Cents {
+		return nil, fmt.Errorf("%w: requested %s, quoted %s", ErrPriceChanged, req.Amount, quote.Total)
 	}
 
-	// 2. Get or create card token
+	// 2. Get or create card token with the merchant for the job's market
+	merchant, err := s.jobMerchant(job)
+	if err != nil {
+		return nil, err
+	}
+	provider := merchant.Provider
+
 	var cardToken string
 	if req.CardToken != nil {
 		cardToken = *req.CardToken
 	} else if req.CardDetails != nil {
-		tokenResp, err := s.cloverService.TokenizeCard(model.CloverCard{
-			Number:   req.CardDetails.Number,
-			ExpMonth: req.CardDetails.ExpMonth,
-			ExpYear:  req.CardDetails.ExpYear,
-			CVV:      req.CardDetails.CVV,
-			Name:     req.CardDetails.Name,
-			AddressLine1: req.CardDetails.AddressLine1,
-			AddressCity:  req.CardDetails.AddressCity,
-			AddressState: req.CardDetails.AddressState,
-			AddressZip:   req.CardDetails.AddressZip,
-		})
+		tokenResp, err := provider.Tokenize(ctx, *req.CardDetails)
 		if err != nil {
-			return nil, fmt.Errorf("failed to tokenize card: %w", err)
+			return nil, fmt.Errorf("%w: failed to tokenize card: %w", ErrPaymentDeclined, err)
 		}
-		cardToken = tokenResp.ID
+		cardToken = tokenResp.Token
 
 		// Save card if requested
 		if req.SaveCard {
 			if err := s.savePaymentMethod(userID, tokenResp, req.PaymentMethodID); err != nil {
 				// Log error but don't fail the transaction
-				fmt.Printf("Warning: failed to save payment method: %v\n", err)
+				slog.WarnContext(ctx, "Failed to save payment method", "error", err)
 			}
 		}
 	} else if req.PaymentMethodID != nil {
 		// Load saved payment method
-		pm, err := s.getPaymentMethod(*req.PaymentMethodID, userID)
+		pm, err := s.getPaymentMethod(*req.PaymentMethodID, userID, provider.Name())
 		if err != nil {
 			return nil, fmt.Errorf("failed to get payment method: %w", err)
 		}
 		if pm.CloverToken != nil {
 			cardToken = *pm.CloverToken
+		} else if pm.ExternalID != "" {
+			cardToken = pm.ExternalID
 		} else {
 			return nil, fmt.Errorf("payment method does not have a valid token")
 		}
@@ -86,10 +152,7 @@ func (s *PaymentService) AuthorizeJobPayment(userID int, req model.PaymentAuthor
 		return nil, fmt.Errorf("no payment source provided")
 	}
 
-	// 3. Calculate fees
-	netAmount, platformFee, processingFee := s.config.CalculateNetAmount(req.Amount)
-
-	// 4. Create Clover authorization
+	// 3. Create provider authorization
 	metadata := map[string]interface{}{
 		"job_id":      req.JobID,
 		"consumer_id": userID,
@@ -98,19 +161,27 @@ func (s *PaymentService) AuthorizeJobPayment(userID int, req model.PaymentAuthor
 	for k, v := range req.Metadata {
 		metadata[k] = v
 	}
+	metadata["tax"] = quote.Tax
+	metadata["credits"] = quote.Credits
+	if quote.FeeRuleID != nil {
+		metadata["fee_rule_id"] = *quote.FeeRuleID
+	}
 
-	cloverResp, err := s.cloverService.AuthorizePayment(
+	charge, err := provider.Authorize(
+		ctx,
 		cardToken,
-		DollarsToCents(req.Amount),
+		quote.Total.Cents,
+		quote.Currency,
 		metadata,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to authorize payment with Clover: %w", err)
+		return nil, fmt.Errorf("%w by %s: %w", ErrPaymentDeclined, provider.Name(), err)
 	}
+	s.checkProviderAmount(ctx, provider, job.ID, 0, "authorize", &quote.Total.Cents, charge.AmountCents, quote.Currency)
 
-	// 5. Create transaction record
-	now := time.Now()
-	authExpiresAt := now.Add(7 * 24 * time.Hour) // Typical 7-day auth window
+	// 4. Create transaction record
+	now := s.clock.Now()
+	authExpiresAt := now.Add(AuthorizationLifetime)
 
 	tx, err := s.db.Begin()
 	if err != nil {
@@ -123,29 +194,35 @@ func (s *PaymentService) AuthorizeJobPayment(userID int, req model.PaymentAuthor
 		INSERT INTO transactions (
 			job_id, consumer_id, gig_worker_id, amount, currency,
 			status, transaction_type,
-			clover_charge_id, clover_source_token,
+			payment_provider, provider_charge_id, provider_source_token,
 			authorized_at, authorization_expires_at,
 			payment_method, last_four,
 			processing_fee, platform_fee, net_amount,
-			escrow_held_at, metadata
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+			escrow_held_at, metadata, uuid, merchant_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, NULLIF($21, ''))
 		RETURNING id
 	`,
-		req.JobID, job.ConsumerID, job.GigWorkerID, req.Amount, "USD",
+		req.JobID, job.ConsumerID, job.GigWorkerID, quote.Total, quote.Currency,
 		"completed", "authorization",
-		cloverResp.ID, cloverResp.Source.ID,
+		provider.Name(), charge.ID, charge.SourceToken,
 		now, authExpiresAt,
-		cloverResp.Source.Brand, cloverResp.Source.Last4,
-		processingFee, platformFee, netAmount,
-		now, toJSON(metadata),
+		charge.Brand, charge.Last4,
+		quote.ProcessingFee, quote.PlatformFee, quote.Labor,
+		now, toJSON(metadata), s.ids.NewID(), merchant.ID,
 	).Scan(&transactionID)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
 
-	// 6. Create payment event log
-	if err := s.createPaymentEvent(tx, transactionID, "authorize", "success", cloverResp, nil, userID); err != nil {
+	if quote.Credits.IsPositive() {
+		if err := s.redeemCredits(tx, userID, transactionID, quote.Credits); err != nil {
+			return nil, fmt.Errorf("failed to redeem account credit: %w", err)
+		}
+	}
+
+	// 5. Create payment event log
+	if err := s.createPaymentEvent(tx, transactionID, "authorize", "success", charge.Raw, nil, userID, req.IdempotencyKey); err != nil {
 		return nil, fmt.Errorf("failed to create payment event: %w", err)
 	}
 
@@ -153,27 +230,60 @@ func (s *PaymentService) AuthorizeJobPayment(userID int, req model.PaymentAuthor
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	// 7. Get full transaction details
+	// 6. Get full transaction details
 	transaction, err := s.getTransaction(transactionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transaction: %w", err)
 	}
 
+	return authorizeResponse(transaction, false), nil
+}
+
+func authorizeResponse(transaction *model.EnhancedTransaction, replayed bool) *model.PaymentAuthorizeResponse {
 	return &model.PaymentAuthorizeResponse{
 		Success:       true,
-		TransactionID: transactionID,
+		TransactionID: transaction.ID,
 		Transaction:   transaction,
 		Message:       "Payment authorized successfully. Funds are held in escrow.",
-	}, nil
+		Replayed:      replayed,
+	}
 }
 
 // ==============================================
 // CAPTURE (RELEASE FROM ESCROW)
 // ==============================================
 
-// CaptureJobPayment captures a previously authorized payment
-func (s *PaymentService) CaptureJobPayment(userID int, req model.PaymentCaptureRequest) (*model.PaymentCaptureResponse, error) {
-	// 1. Get transaction
+// CaptureJobPayment captures a previously authorized payment. A request repeating
+// the idempotency key of one that succeeded returns that capture.
+func (s *PaymentService) CaptureJobPayment(ctx context.Context, userID int, req model.PaymentCaptureRequest) (*model.PaymentCaptureResponse, error) {
+	unlock, err := s.lockIdempotencyKey(ctx, userID, req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	previousID, err := s.findIdempotentTransaction(userID, req.IdempotencyKey, "capture")
+	if err != nil {
+		return nil, err
+	}
+	if previousID != 0 {
+		if previousID != req.TransactionID {
+			return nil, ErrIdempotencyKeyReused
+		}
+		transaction, err := s.getTransaction(previousID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction: %w", err)
+		}
+		return captureResponse(transaction, true), nil
+	}
+
+	// 1. Get transaction, once no re-authorization is replacing its charge
+	unlockTransaction, err := s.lockTransaction(ctx, req.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlockTransaction()
+
 	transaction, err := s.getTransaction(req.TransactionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transaction: %w", err)
@@ -194,6 +304,9 @@ func (s *PaymentService) CaptureJobPayment(userID int, req model.PaymentCaptureR
 	if err != nil {
 		return nil, fmt.Errorf("failed to get job: %w", err)
 	}
+	if err := checkRequestCurrency("capture", req.Currency, transaction.Currency); err != nil {
+		return nil, err
+	}
 
 	// Either consumer or worker can trigger capture (when job is completed)
 	isConsumer := job.ConsumerID == userID
@@ -202,28 +315,75 @@ func (s *PaymentService) CaptureJobPayment(userID int, req model.PaymentCaptureR
 		return nil, fmt.Errorf("unauthorized: user cannot capture this payment")
 	}
 
+	// A dispute or hold must be resolved first, and auto-capture waits for it too
+	held, err := s.jobOnHold(ctx, job.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check job holds: %w", err)
+	}
+	if held {
+		return nil, ErrJobOnHold
+	}
+
 	// 3. Determine capture amount
+	// Hand-off portions the consumer already paid are deducted, and approved
+	// reimbursable expenses and parts are added, unless an explicit amount is given.
+	// An explicit amount is a manual adjustment and is not split.
+	portions, err := s.getHandoffPortions(transaction.ID, transaction.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hand-off portions: %w", err)
+	}
+	var expenseTotal, partsTotal model.Money
 	var captureAmountCents *int64
 	if req.Amount != nil {
-		cents := DollarsToCents(*req.Amount)
-		captureAmountCents = &cents
+		captureAmountCents = &req.Amount.Cents
+	} else {
+		expenseTotal, err = s.getUnbilledExpenseTotal(job.ID, job.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job expenses: %w", err)
+		}
+		partsTotal, err = s.getUnbilledPartsTotal(job.ID, job.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job parts: %w", err)
+		}
+		// Expenses and parts are billed in the job's currency
+		if job.Currency != transaction.Currency {
+			if expenseTotal.IsPositive() || partsTotal.IsPositive() {
+				return nil, fmt.Errorf("%w: job expenses and parts are in %s, the payment is in %s",
+					ErrCurrencyMismatch, job.Currency, transaction.Currency)
+			}
+			expenseTotal, partsTotal = model.NewMoney(0, transaction.Currency), model.NewMoney(0, transaction.Currency)
+		}
+		captureTotal := transaction.Amount.Sub(portions.Amount).Add(expenseTotal).Add(partsTotal)
+		if err := s.checkCaptureSplits(transaction, portions, expenseTotal, partsTotal, captureTotal); err != nil {
+			return nil, s.reportViolation(ctx, job.ID, transaction.ID, "capture", err)
+		}
+		if captureTotal != transaction.Amount {
+			captureAmountCents = &captureTotal.Cents
+		}
 	}
 
-	// 4. Capture with Clover
-	if transaction.CloverPaymentID == nil {
-		return nil, fmt.Errorf("transaction does not have a Clover payment ID")
+	// 4. Capture with the provider and merchant that authorized the payment
+	merchant, err := s.transactionMerchant(transaction)
+	if err != nil {
+		return nil, err
 	}
 
-	cloverResp, err := s.cloverService.CapturePayment(*transaction.CloverPaymentID, captureAmountCents)
+	capture, err := merchant.Provider.Capture(ctx, *transaction.ProviderChargeID, captureAmountCents)
 	if err != nil {
 		// Log the failure
-		s.createPaymentEventSimple(req.TransactionID, "capture", "failed", nil, err, userID)
-		return nil, fmt.Errorf("failed to capture payment with Clover: %w", err)
+		s.createPaymentEventSimple(req.TransactionID, "capture", "failed", nil, err, userID, req.IdempotencyKey)
+		return nil, fmt.Errorf("failed to capture payment with %s: %w", merchant.Provider.Name(), err)
+	}
+
+	expectedCents := captureAmountCents
+	if expectedCents == nil {
+		expectedCents = &transaction.Amount.Cents
 	}
+	s.checkProviderAmount(ctx, merchant.Provider, job.ID, transaction.ID, "capture", expectedCents, capture.AmountCents, transaction.Currency)
 
 	// 5. Update transaction
-	now := time.Now()
-	captureAmount := CentsToDollars(cloverResp.Amount)
+	now := s.clock.Now()
+	captureAmount := model.NewMoney(capture.AmountCents, transaction.Currency)
 
 	tx, err := s.db.Begin()
 	if err != nil {
@@ -231,23 +391,44 @@ func (s *PaymentService) CaptureJobPayment(userID int, req model.PaymentCaptureR
 	}
 	defer tx.Rollback()
 
+	// The outgoing workers of hand-offs were paid their portions separately, so the
+	// worker who finished the job is settled the rest
 	_, err = tx.Exec(`
 		UPDATE transactions
-		SET captured_at = $1, capture_amount = $2, escrow_released_at = $3, updated_at = $4
+		SET captured_at = $1, capture_amount = $2, escrow_released_at = $3, updated_at = $4,
+		    net_amount = net_amount - $6, platform_fee = platform_fee - $7, processing_fee = processing_fee - $8
 		WHERE id = $5
-	`, now, captureAmount, now, now, req.TransactionID)
+	`, now, captureAmount, now, now, req.TransactionID, portions.NetAmount, portions.PlatformFee, portions.ProcessingFee)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to update transaction: %w", err)
 	}
 
 	// 6. Create capture event log
-	if err := s.createPaymentEvent(tx, req.TransactionID, "capture", "success", cloverResp, nil, userID); err != nil {
+	if err := s.createPaymentEvent(tx, req.TransactionID, "capture", "success", capture.Raw, nil, userID, req.IdempotencyKey); err != nil {
 		return nil, fmt.Errorf("failed to create payment event: %w", err)
 	}
 
-	// 7. Update job status to paid
-	_, err = tx.Exec(`UPDATE jobs SET status = 'paid', updated_at = $1 WHERE id = $2`, now, job.ID)
+	// 7. Record reimbursed expenses and approved parts against this capture
+	if expenseTotal.IsPositive() {
+		if err := s.billExpenses(tx, req.TransactionID, job, expenseTotal); err != nil {
+			return nil, fmt.Errorf("failed to bill job expenses: %w", err)
+		}
+	}
+	if partsTotal.IsPositive() {
+		if err := s.billParts(tx, req.TransactionID, job, partsTotal); err != nil {
+			return nil, fmt.Errorf("failed to bill job parts: %w", err)
+		}
+	}
+
+	// 8. Update job status to paid
+	_, err = jobevents.RecordTx(context.WithoutCancel(ctx), tx, jobevents.Transition{
+		JobID:   job.ID,
+		Type:    jobevents.TypePaid,
+		Source:  jobevents.SourceAPI,
+		ActorID: &userID,
+		Data:    map[string]interface{}{"transaction_id": req.TransactionID},
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to update job status: %w", err)
 	}
@@ -256,27 +437,60 @@ func (s *PaymentService) CaptureJobPayment(userID int, req model.PaymentCaptureR
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	// 8. Get updated transaction
+	// 9. Get updated transaction
 	updatedTransaction, err := s.getTransaction(req.TransactionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get updated transaction: %w", err)
 	}
 
+	return captureResponse(updatedTransaction, false), nil
+}
+
+func captureResponse(transaction *model.EnhancedTransaction, replayed bool) *model.PaymentCaptureResponse {
 	return &model.PaymentCaptureResponse{
 		Success:       true,
-		TransactionID: req.TransactionID,
-		Transaction:   updatedTransaction,
+		TransactionID: transaction.ID,
+		Transaction:   transaction,
 		Message:       "Payment captured successfully. Funds released from escrow.",
-	}, nil
+		Replayed:      replayed,
+	}
 }
 
 // ==============================================
 // REFUNDS
 // ==============================================
 
-// RefundJobPayment refunds a payment
-func (s *PaymentService) RefundJobPayment(userID int, req model.PaymentRefundRequest) (*model.PaymentRefundResponse, error) {
-	// 1. Get transaction
+// RefundJobPayment refunds a payment. A request repeating the idempotency key of one
+// that succeeded returns that refund.
+func (s *PaymentService) RefundJobPayment(ctx context.Context, userID int, req model.PaymentRefundRequest) (*model.PaymentRefundResponse, error) {
+	unlock, err := s.lockIdempotencyKey(ctx, userID, req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	previousID, err := s.findIdempotentTransaction(userID, req.IdempotencyKey, "refund")
+	if err != nil {
+		return nil, err
+	}
+	if previousID != 0 {
+		refundTransaction, err := s.getTransaction(previousID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get refund transaction: %w", err)
+		}
+		if refundTransaction.ParentTransactionID == nil || *refundTransaction.ParentTransactionID != req.TransactionID {
+			return nil, ErrIdempotencyKeyReused
+		}
+		return refundResponse(refundTransaction, true), nil
+	}
+
+	// 1. Get transaction, once no re-authorization is replacing its charge
+	unlockTransaction, err := s.lockTransaction(ctx, req.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlockTransaction()
+
 	transaction, err := s.getTransaction(req.TransactionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transaction: %w", err)
@@ -291,33 +505,46 @@ func (s *PaymentService) RefundJobPayment(userID int, req model.PaymentRefundReq
 	if job.ConsumerID != userID {
 		return nil, fmt.Errorf("unauthorized: only the consumer can request a refund")
 	}
+	if err := checkRequestCurrency("refund", req.Currency, transaction.Currency); err != nil {
+		return nil, err
+	}
 
 	// 3. Verify can be refunded
 	if transaction.Status == model.TransactionStatusRefunded {
 		return nil, fmt.Errorf("transaction already refunded")
 	}
 
-	if transaction.CloverChargeID == nil {
-		return nil, fmt.Errorf("transaction does not have a Clover charge ID")
+	merchant, err := s.transactionMerchant(transaction)
+	if err != nil {
+		return nil, err
 	}
 
 	// 4. Determine refund amount
 	var refundAmountCents *int64
 	if req.Amount != nil {
-		cents := DollarsToCents(*req.Amount)
-		refundAmountCents = &cents
+		refundAmountCents = &req.Amount.Cents
 	}
 
-	// 5. Process refund with Clover
-	cloverResp, err := s.cloverService.RefundPayment(*transaction.CloverChargeID, refundAmountCents, req.Reason)
+	// 5. Process refund with the provider and merchant that took the payment
+	refund, err := merchant.Provider.Refund(ctx, *transaction.ProviderChargeID, refundAmountCents, req.Reason)
 	if err != nil {
-		s.createPaymentEventSimple(req.TransactionID, "refund", "failed", nil, err, userID)
-		return nil, fmt.Errorf("failed to refund payment with Clover: %w", err)
+		s.createPaymentEventSimple(req.TransactionID, "refund", "failed", nil, err, userID, req.IdempotencyKey)
+		return nil, fmt.Errorf("failed to refund payment with %s: %w", merchant.Provider.Name(), err)
+	}
+
+	expectedCents := refundAmountCents
+	if expectedCents == nil {
+		refundable := transaction.Amount
+		if transaction.CaptureAmount != nil {
+			refundable = *transaction.CaptureAmount
+		}
+		expectedCents = &refundable.Cents
 	}
+	s.checkProviderAmount(ctx, merchant.Provider, job.ID, transaction.ID, "refund", expectedCents, refund.AmountCents, transaction.Currency)
 
 	// 6. Create refund transaction
-	now := time.Now()
-	refundAmount := CentsToDollars(cloverResp.Amount)
+	now := s.clock.Now()
+	refundAmount := model.NewMoney(refund.AmountCents, transaction.Currency)
 
 	tx, err := s.db.Begin()
 	if err != nil {
@@ -330,43 +557,62 @@ func (s *PaymentService) RefundJobPayment(userID int, req model.PaymentRefundReq
 		INSERT INTO transactions (
 			job_id, consumer_id, gig_worker_id, amount, currency,
 			status, transaction_type,
-			clover_refund_id,
+			payment_provider, provider_refund_id,
 			refunded_at, refund_amount, refund_reason,
-			parent_transaction_id
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			parent_transaction_id, uuid, merchant_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NULLIF($15, ''))
 		RETURNING id
 	`,
-		job.ID, job.ConsumerID, job.GigWorkerID, refundAmount, "USD",
+		job.ID, job.ConsumerID, job.GigWorkerID, refundAmount, refundAmount.Currency,
 		"completed", "refund",
-		cloverResp.ID,
+		merchant.Provider.Name(), refund.ID,
 		now, refundAmount, req.Reason,
-		req.TransactionID,
+		req.TransactionID, s.ids.NewID(), merchant.ID,
 	).Scan(&refundID)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create refund transaction: %w", err)
 	}
 
-	// 7. Update original transaction status
+	// 7. Update original transaction. Partial refunds add up; the transaction is
+	// refunded, and no longer settled to its worker, once they cover all of it.
 	_, err = tx.Exec(`
 		UPDATE transactions
-		SET status = 'refunded', refunded_at = $1, refund_amount = $2, refund_reason = $3, updated_at = $4
+		SET refund_amount = COALESCE(refund_amount, 0) + $2,
+		    status = CASE WHEN $6 OR COALESCE(refund_amount, 0) + $2 >= COALESCE(capture_amount, amount) THEN 'refunded' ELSE status END,
+		    refunded_at = CASE WHEN $6 OR COALESCE(refund_amount, 0) + $2 >= COALESCE(capture_amount, amount) THEN $1 ELSE refunded_at END,
+		    refund_reason = $3, updated_at = $4
 		WHERE id = $5
-	`, now, refundAmount, req.Reason, now, req.TransactionID)
+	`, now, refundAmount, req.Reason, now, req.TransactionID, req.Amount == nil)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to update original transaction: %w", err)
 	}
 
+	// A full refund gives back any account credit the payment used
+	if req.Amount == nil {
+		if err := s.restoreCredits(tx, req.TransactionID); err != nil {
+			return nil, fmt.Errorf("failed to restore account credit: %w", err)
+		}
+	}
+
 	// 8. Create refund event log
-	if err := s.createPaymentEvent(tx, refundID, "refund", "success", cloverResp, nil, userID); err != nil {
+	if err := s.createPaymentEvent(tx, refundID, "refund", "success", refund.Raw, nil, userID, req.IdempotencyKey); err != nil {
 		return nil, fmt.Errorf("failed to create payment event: %w", err)
 	}
 
 	// 9. Update job status
-	_, err = tx.Exec(`UPDATE jobs SET status = 'cancelled', updated_at = $1 WHERE id = $2`, now, job.ID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to update job status: %w", err)
+	if !req.KeepJobStatus {
+		_, err = jobevents.RecordTx(context.WithoutCancel(ctx), tx, jobevents.Transition{
+			JobID:   job.ID,
+			Type:    jobevents.TypeCancelled,
+			Source:  jobevents.SourceAPI,
+			ActorID: &userID,
+			Data:    map[string]interface{}{"reason": "refunded", "refund_transaction_id": refundID},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to update job status: %w", err)
+		}
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -379,12 +625,17 @@ func (s *PaymentService) RefundJobPayment(userID int, req model.PaymentRefundReq
 		return nil, fmt.Errorf("failed to get refund transaction: %w", err)
 	}
 
+	return refundResponse(refundTransaction, false), nil
+}
+
+func refundResponse(refundTransaction *model.EnhancedTransaction, replayed bool) *model.PaymentRefundResponse {
 	return &model.PaymentRefundResponse{
 		Success:     true,
-		RefundID:    refundID,
+		RefundID:    refundTransaction.ID,
 		Transaction: refundTransaction,
 		Message:     "Payment refunded successfully.",
-	}, nil
+		Replayed:    replayed,
+	}
 }
 
 // ==============================================
@@ -394,11 +645,13 @@ func (s *PaymentService) RefundJobPayment(userID int, req model.PaymentRefundReq
 func (s *PaymentService) getJob(jobID int) (*model.Job, error) {
 	var job model.Job
 	err := s.db.QueryRow(`
-		SELECT id, uuid, consumer_id, gig_worker_id, title, description, status
+		SELECT id, uuid, consumer_id, gig_worker_id, title, description, status,
+		       total_pay, pay_rate_per_hour, estimated_duration_hours, COALESCE(category, ''), currency
 		FROM jobs WHERE id = $1
 	`, jobID).Scan(
 		&job.ID, &job.UUID, &job.ConsumerID, &job.GigWorkerID,
 		&job.Title, &job.Description, &job.Status,
+		&job.TotalPay, &job.PayRatePerHour, &job.EstimatedDurationHours, &job.Category, &job.Currency,
 	)
 	if err != nil {
 		return nil, err
@@ -411,6 +664,7 @@ func (s *PaymentService) getTransaction(id int) (*model.EnhancedTransaction, err
 	err := s.db.QueryRow(`
 		SELECT id, uuid, job_id, consumer_id, gig_worker_id, amount, currency,
 		       status, transaction_type, clover_charge_id, clover_payment_id,
+		       payment_provider, merchant_id, provider_charge_id, provider_refund_id,
 		       authorized_at, captured_at, capture_amount,
 		       processing_fee, platform_fee, net_amount,
 		       escrow_held_at, escrow_released_at,
@@ -419,34 +673,109 @@ func (s *PaymentService) getTransaction(id int) (*model.EnhancedTransaction, err
 	`, id).Scan(
 		&t.ID, &t.UUID, &t.JobID, &t.ConsumerID, &t.GigWorkerID, &t.Amount, &t.Currency,
 		&t.Status, &t.TransactionType, &t.CloverChargeID, &t.CloverPaymentID,
+		&t.PaymentProvider, &t.MerchantID, &t.ProviderChargeID, &t.ProviderRefundID,
 		&t.AuthorizedAt, &t.CapturedAt, &t.CaptureAmount,
 		&t.ProcessingFee, &t.PlatformFee, &t.NetAmount,
 		&t.EscrowHeldAt, &t.EscrowReleasedAt,
 		&t.CreatedAt, &t.UpdatedAt,
 	)
-	return &t, err
+	if err != nil {
+		return nil, err
+	}
+
+	// Amounts scan in the default currency; they are in the transaction's
+	t.Amount = t.Amount.In(t.Currency)
+	t.ProcessingFee, t.PlatformFee = t.ProcessingFee.In(t.Currency), t.PlatformFee.In(t.Currency)
+	if t.CaptureAmount != nil {
+		*t.CaptureAmount = t.CaptureAmount.In(t.Currency)
+	}
+	if t.NetAmount != nil {
+		*t.NetAmount = t.NetAmount.In(t.Currency)
+	}
+	return &t, nil
+}
+
+// getUnbilledExpenseTotal sums approved reimbursable expenses not yet added to a
+// capture, which are in the job's currency
+func (s *PaymentService) getUnbilledExpenseTotal(jobID int, currency string) (model.Money, error) {
+	var total model.Money
+	err := s.db.QueryRow(`
+		SELECT COALESCE(SUM(amount), 0)
+		FROM job_expenses
+		WHERE job_id = $1 AND reimbursable = true AND status = 'approved' AND billed_at IS NULL
+	`, jobID).Scan(&total)
+	return total.In(currency), err
+}
+
+// billExpenses links approved expenses to the capture and records the worker's reimbursement split
+func (s *PaymentService) billExpenses(tx *sql.Tx, transactionID int, job *model.Job, total model.Money) error {
+	_, err := tx.Exec(`
+		UPDATE job_expenses SET transaction_id = $1, billed_at = NOW()
+		WHERE job_id = $2 AND reimbursable = true AND status = 'approved' AND billed_at IS NULL
+	`, transactionID, job.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO payment_splits (transaction_id, split_type, amount, recipient_id, description)
+		VALUES ($1, $2, $3, $4, $5)
+	`, transactionID, model.PaymentSplitTypeExpenseReimbursement, total, job.GigWorkerID, "Reimbursed job expenses")
+	return err
+}
+
+// getUnbilledPartsTotal sums approved parts requests not yet added to a capture,
+// which are in the job's currency
+func (s *PaymentService) getUnbilledPartsTotal(jobID int, currency string) (model.Money, error) {
+	var total model.Money
+	err := s.db.QueryRow(`
+		SELECT COALESCE(SUM(total_amount), 0)
+		FROM job_parts_requests
+		WHERE job_id = $1 AND status = 'approved' AND billed_at IS NULL
+	`, jobID).Scan(&total)
+	return total.In(currency), err
+}
+
+// billParts links approved parts to the capture and records the materials split paid to the worker
+func (s *PaymentService) billParts(tx *sql.Tx, transactionID int, job *model.Job, total model.Money) error {
+	_, err := tx.Exec(`
+		UPDATE job_parts_requests SET transaction_id = $1, billed_at = NOW()
+		WHERE job_id = $2 AND status = 'approved' AND billed_at IS NULL
+	`, transactionID, job.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO payment_splits (transaction_id, split_type, amount, recipient_id, description)
+		VALUES ($1, $2, $3, $4, $5)
+	`, transactionID, model.PaymentSplitTypeMaterials, total, job.GigWorkerID, "Approved materials and parts")
+	return err
 }
 
-func (s *PaymentService) getPaymentMethod(id, userID int) (*model.UserPaymentMethod, error) {
+// getPaymentMethod loads a saved card; tokens only work with the provider that issued them
+func (s *PaymentService) getPaymentMethod(id, userID int, provider string) (*model.UserPaymentMethod, error) {
 	var pm model.UserPaymentMethod
 	err := s.db.QueryRow(`
-		SELECT id, uuid, user_id, clover_token, type, last_four, brand, is_default, is_active
-		FROM user_payment_methods
-		WHERE id = $1 AND user_id = $2 AND is_active = true
-	`, id, userID).Scan(
-		&pm.ID, &pm.UUID, &pm.UserID, &pm.CloverToken,
+		SELECT upm.id, upm.uuid, upm.user_id, upm.provider_id, upm.external_id, upm.clover_token,
+		       upm.type, upm.last_four, upm.brand, upm.is_default, upm.is_active
+		FROM user_payment_methods upm
+		JOIN payment_providers pp ON pp.id = upm.provider_id
+		WHERE upm.id = $1 AND upm.user_id = $2 AND upm.is_active = true AND pp.name = $3
+	`, id, userID, provider).Scan(
+		&pm.ID, &pm.UUID, &pm.UserID, &pm.ProviderID, &pm.ExternalID, &pm.CloverToken,
 		&pm.Type, &pm.LastFour, &pm.Brand, &pm.IsDefault, &pm.IsActive,
 	)
 	return &pm, err
 }
 
-func (s *PaymentService) savePaymentMethod(userID int, tokenResp *model.CloverTokenizeResponse, existingID *int) error {
+func (s *PaymentService) savePaymentMethod(userID int, token *CardToken, existingID *int) error {
 	// Implementation for saving payment method
 	// This would insert/update user_payment_methods table
 	return nil
 }
 
-func (s *PaymentService) createPaymentEvent(tx *sql.Tx, transactionID int, eventType, status string, response interface{}, err error, userID int) error {
+func (s *PaymentService) createPaymentEvent(tx *sql.Tx, transactionID int, eventType, status string, response interface{}, err error, userID int, idempotencyKey string) error {
 	var errorMsg *string
 	if err != nil {
 		msg := err.Error()
@@ -454,14 +783,14 @@ func (s *PaymentService) createPaymentEvent(tx *sql.Tx, transactionID int, event
 	}
 
 	_, execErr := tx.Exec(`
-		INSERT INTO payment_events (transaction_id, event_type, event_status, clover_response, error_message, user_id)
-		VALUES ($1, $2, $3, $4, $5, $6)
-	`, transactionID, eventType, status, toJSON(response), errorMsg, userID)
+		INSERT INTO payment_events (transaction_id, event_type, event_status, clover_response, error_message, user_id, idempotency_key)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''))
+	`, transactionID, eventType, status, toJSON(response), errorMsg, userID, idempotencyKey)
 
 	return execErr
 }
 
-func (s *PaymentService) createPaymentEventSimple(transactionID int, eventType, status string, response interface{}, err error, userID int) {
+func (s *PaymentService) createPaymentEventSimple(transactionID int, eventType, status string, response interface{}, err error, userID int, idempotencyKey string) {
 	var errorMsg *string
 	if err != nil {
 		msg := err.Error()
@@ -469,9 +798,9 @@ func (s *PaymentService) createPaymentEventSimple(transactionID int, eventType,
 	}
 
 	s.db.Exec(`
-		INSERT INTO payment_events (transaction_id, event_type, event_status, clover_response, error_message, user_id)
-		VALUES ($1, $2, $3, $4, $5, $6)
-	`, transactionID, eventType, status, toJSON(response), errorMsg, userID)
+		INSERT INTO payment_events (transaction_id, event_type, event_status, clover_response, error_message, user_id, idempotency_key)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''))
+	`, transactionID, eventType, status, toJSON(response), errorMsg, userID, idempotencyKey)
 }
 
 func toJSON(v interface{}) interface{} {
@@ -0,0 +1,457 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"app/internal/clock"
+	"app/internal/model"
+)
+
+// scriptDB is a database that answers each query with the rows of the first rule
+// whose fragment the query contains, and records the statements executed on it.
+// Queries no rule matches return no rows.
+type scriptDB struct {
+	mu         sync.Mutex
+	rules      []scriptRule
+	failures   []scriptFailure
+	execs      []recordedExec
+	statements []string // Queries and statements executed, in order
+}
+
+type scriptRule struct {
+	fragment string
+	rows     [][]driver.Value
+}
+
+type scriptFailure struct {
+	fragment string
+	err      error
+}
+
+type recordedExec struct {
+	query string
+	args  []driver.Value
+}
+
+// on answers queries containing fragment with rows
+func (d *scriptDB) on(fragment string, rows ...[]driver.Value) *scriptDB {
+	d.rules = append(d.rules, scriptRule{fragment: fragment, rows: rows})
+	return d
+}
+
+// failing makes statements containing fragment fail with err
+func (d *scriptDB) failing(fragment string, err error) *scriptDB {
+	d.failures = append(d.failures, scriptFailure{fragment: fragment, err: err})
+	return d
+}
+
+// executed returns the statements executed that contain fragment
+func (d *scriptDB) executed(fragment string) []recordedExec {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var found []recordedExec
+	for _, e := range d.execs {
+		if strings.Contains(e.query, fragment) {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// order returns the positions of the first statements containing each fragment, -1
+// for those never run
+func (d *scriptDB) order(fragments ...string) []int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	positions := make([]int, len(fragments))
+	for i, fragment := range fragments {
+		positions[i] = -1
+		for j, statement := range d.statements {
+			if strings.Contains(statement, fragment) {
+				positions[i] = j
+				break
+			}
+		}
+	}
+	return positions
+}
+
+func (d *scriptDB) Connect(context.Context) (driver.Conn, error) { return scriptConn{d}, nil }
+func (d *scriptDB) Driver() driver.Driver                        { return nil }
+
+type scriptConn struct{ d *scriptDB }
+
+func (c scriptConn) Prepare(string) (driver.Stmt, error)      { return nil, driver.ErrSkip }
+func (c scriptConn) Close() error                             { return nil }
+func (c scriptConn) Begin() (driver.Tx, error)                { return scriptTx{}, nil }
+func (c scriptConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+func (c scriptConn) ResetSession(context.Context) error       { return nil }
+func (c scriptConn) IsValid() bool                            { return true }
+func (c scriptConn) Ping(context.Context) error               { return nil }
+func (c scriptConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return scriptTx{}, nil
+}
+
+func (c scriptConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.execs = append(c.d.execs, recordedExec{query: query, args: namedValues(args)})
+	c.d.statements = append(c.d.statements, query)
+	for _, failure := range c.d.failures {
+		if strings.Contains(query, failure.fragment) {
+			return nil, failure.err
+		}
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c scriptConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.d.mu.Lock()
+	c.d.statements = append(c.d.statements, query)
+	c.d.mu.Unlock()
+	for _, rule := range c.d.rules {
+		if strings.Contains(query, rule.fragment) {
+			return &scriptRows{rows: rule.rows}, nil
+		}
+	}
+	return &scriptRows{}, nil
+}
+
+type scriptTx struct{}
+
+func (scriptTx) Commit() error   { return nil }
+func (scriptTx) Rollback() error { return nil }
+
+type scriptRows struct {
+	rows [][]driver.Value
+	next int
+}
+
+func (r *scriptRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return nil
+	}
+	return make([]string, len(r.rows[0]))
+}
+
+func (r *scriptRows) Close() error { return nil }
+
+func (r *scriptRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
+
+func namedValues(args []driver.NamedValue) []driver.Value {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	return values
+}
+
+// fakeProvider is a provider whose calls all succeed and are recorded
+type fakeProvider struct {
+	mu         sync.Mutex
+	authorized []int64
+	captured   []*int64 // Amounts asked for; nil captures in full
+	refunded   []string // Charge IDs
+	charges    int
+	failNext   error // Returned by the next call that moves money
+}
+
+func (p *fakeProvider) Name() string { return "fake" }
+
+func (p *fakeProvider) Tokenize(context.Context, model.CardDetails) (*CardToken, error) {
+	return &CardToken{Token: "tok_fake"}, nil
+}
+
+func (p *fakeProvider) Authorize(_ context.Context, token string, amountCents int64, _ string, _ map[string]interface{}) (*Charge, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.takeFailure(); err != nil {
+		return nil, err
+	}
+	p.charges++
+	p.authorized = append(p.authorized, amountCents)
+	return &Charge{ID: fmt.Sprintf("ch_%d", p.charges), AmountCents: amountCents, Status: "authorized", SourceToken: token}, nil
+}
+
+func (p *fakeProvider) Capture(_ context.Context, chargeID string, amountCents *int64) (*Capture, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.takeFailure(); err != nil {
+		return nil, err
+	}
+	p.captured = append(p.captured, amountCents)
+	captured := p.authorized[len(p.authorized)-1]
+	if amountCents != nil {
+		captured = *amountCents
+	}
+	return &Capture{ID: chargeID, AmountCents: captured, Status: "captured"}, nil
+}
+
+func (p *fakeProvider) Refund(_ context.Context, chargeID string, amountCents *int64, _ string) (*Refund, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.takeFailure(); err != nil {
+		return nil, err
+	}
+	p.refunded = append(p.refunded, chargeID)
+	var refunded int64
+	if amountCents != nil {
+		refunded = *amountCents
+	}
+	return &Refund{ID: "re_" + chargeID, AmountCents: refunded, Status: "refunded"}, nil
+}
+
+func (p *fakeProvider) Payout(context.Context, PayoutRequest) (*Payout, error) {
+	return nil, ErrPayoutUnsupported
+}
+
+func (p *fakeProvider) Fees() FeeSchedule {
+	return FeeSchedule{PlatformPercent: 10}
+}
+
+func (p *fakeProvider) CalculateNetAmount(amount model.Money) (model.Money, model.Money, model.Money) {
+	return p.Fees().Split(amount)
+}
+
+func (p *fakeProvider) takeFailure() error {
+	err := p.failNext
+	p.failNext = nil
+	return err
+}
+
+// testNow is the time services under test read from their clock
+var testNow = time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+// newTestService returns a payment service on db and provider with a fake clock
+func newTestService(db *scriptDB, provider *fakeProvider) *PaymentService {
+	return NewPaymentService(sql.OpenDB(db), provider, 0).WithClock(clock.NewFake(testNow), &clock.Sequence{})
+}
+
+// transactionRow is a transactions row as getTransaction selects it
+type transactionRow struct {
+	id, jobID, consumerID int
+	workerID              int
+	amount                string
+	status, kind          string
+	chargeID              string
+	captured              bool
+	platformFee, net      string
+	currency              string // USD when empty
+}
+
+func (t transactionRow) values() []driver.Value {
+	var capturedAt, captureAmount driver.Value
+	if t.captured {
+		capturedAt, captureAmount = testNow, t.amount
+	}
+	var workerID driver.Value
+	if t.workerID != 0 {
+		workerID = int64(t.workerID)
+	}
+	currency := t.currency
+	if currency == "" {
+		currency = "USD"
+	}
+	platformFee, net := t.platformFee, t.net
+	if platformFee == "" {
+		platformFee = "0"
+	}
+	var netAmount driver.Value
+	if net != "" {
+		netAmount = net
+	}
+	return []driver.Value{
+		int64(t.id), fmt.Sprintf("uuid-%d", t.id), int64(t.jobID), int64(t.consumerID), workerID, t.amount, currency,
+		t.status, t.kind, nil, nil,
+		"fake", nil, t.chargeID, nil,
+		testNow, capturedAt, captureAmount,
+		"0", platformFee, netAmount,
+		testNow, nil,
+		testNow, testNow,
+	}
+}
+
+// jobRow is a jobs row as getJob selects it
+func jobRow(id, consumerID, workerID int, status string) []driver.Value {
+	return jobRowIn(id, consumerID, workerID, status, "USD")
+}
+
+// jobRowIn is a jobs row priced in currency
+func jobRowIn(id, consumerID, workerID int, status, currency string) []driver.Value {
+	return []driver.Value{
+		int64(id), fmt.Sprintf("job-uuid-%d", id), int64(consumerID), int64(workerID), "Fence repair", "Repair the fence", status,
+		100.0, nil, nil, "", currency,
+	}
+}
+
+// onCapture answers the queries a capture of an authorization makes besides loading
+// it: its job, holds on it, tax and credits, unbilled expenses and parts, and the
+// job's state
+func (d *scriptDB) onCapture(job []driver.Value) *scriptDB {
+	return d.
+		on("FROM jobs WHERE id", job).
+		on("FROM job_disputes", []driver.Value{int64(0)}).
+		on("metadata->>'tax'", []driver.Value{"0", "0"}).
+		on("FROM job_expenses", []driver.Value{"0"}).
+		on("FROM job_parts_requests", []driver.Value{"0"}).
+		on("FOR UPDATE OF j", []driver.Value{"completed", int64(7), nil, nil, int64(4)}).
+		on("INSERT INTO job_events", []driver.Value{int64(1), testNow})
+}
+
+func TestCaptureJobPayment(t *testing.T) {
+	authorization := transactionRow{
+		id: 5, jobID: 42, consumerID: 3, workerID: 7, amount: "100.00",
+		status: "completed", kind: "authorization", chargeID: "ch_hold", platformFee: "10.00", net: "90.00",
+	}
+	db := (&scriptDB{}).
+		on("clover_charge_id", authorization.values()).
+		on("handoff_portion", []driver.Value{"0", "0", "0", "0"}).
+		onCapture(jobRow(42, 3, 7, "completed"))
+	provider := &fakeProvider{authorized: []int64{10000}}
+
+	resp, err := newTestService(db, provider).CaptureJobPayment(context.Background(), 3, model.PaymentCaptureRequest{TransactionID: 5})
+	if err != nil {
+		t.Fatalf("CaptureJobPayment() error = %v", err)
+	}
+	if resp.Replayed {
+		t.Error("Replayed = true for a first capture")
+	}
+	if len(provider.captured) != 1 || provider.captured[0] != nil {
+		t.Errorf("provider captures = %v, want the full authorization", provider.captured)
+	}
+	if got := len(db.executed("SET captured_at")); got != 1 {
+		t.Errorf("transaction updated %d times, want 1", got)
+	}
+}
+
+func TestCaptureJobPaymentOnHold(t *testing.T) {
+	authorization := transactionRow{
+		id: 5, jobID: 42, consumerID: 3, workerID: 7, amount: "100.00",
+		status: "completed", kind: "authorization", chargeID: "ch_hold", platformFee: "10.00", net: "90.00",
+	}
+	db := (&scriptDB{}).
+		on("clover_charge_id", authorization.values()).
+		on("FROM job_disputes", []driver.Value{int64(1)}).
+		on("handoff_portion", []driver.Value{"0", "0", "0", "0"}).
+		onCapture(jobRow(42, 3, 7, "completed"))
+	provider := &fakeProvider{authorized: []int64{10000}}
+
+	_, err := newTestService(db, provider).CaptureJobPayment(context.Background(), 3, model.PaymentCaptureRequest{TransactionID: 5})
+	if !errors.Is(err, ErrJobOnHold) {
+		t.Fatalf("CaptureJobPayment() error = %v, want ErrJobOnHold", err)
+	}
+	if len(provider.captured) != 0 {
+		t.Errorf("provider captures = %d, want none while the job is held", len(provider.captured))
+	}
+	if got := len(db.executed("SET captured_at")); got != 0 {
+		t.Errorf("transaction updated %d times, want none", got)
+	}
+}
+
+func TestCaptureJobPaymentInCAD(t *testing.T) {
+	authorization := transactionRow{
+		id: 5, jobID: 42, consumerID: 3, workerID: 7, amount: "100.00", currency: "CAD",
+		status: "completed", kind: "authorization", chargeID: "ch_hold", platformFee: "10.00", net: "90.00",
+	}
+
+	tests := []struct {
+		name        string
+		portions    []driver.Value
+		expenses    string
+		wantCapture *int64
+	}{
+		{name: "full authorization", portions: []driver.Value{"0", "0", "0", "0"}, expenses: "0"},
+		{name: "less a hand-off portion", portions: []driver.Value{"30.00", "27.00", "3.00", "0"}, expenses: "0", wantCapture: ptr(int64(7000))},
+		{name: "with reimbursed expenses", portions: []driver.Value{"0", "0", "0", "0"}, expenses: "12.50", wantCapture: ptr(int64(11250))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := (&scriptDB{}).
+				on("clover_charge_id", authorization.values()).
+				on("handoff_portion", tt.portions).
+				on("FROM job_expenses", []driver.Value{tt.expenses}).
+				onCapture(jobRowIn(42, 3, 7, "completed", "CAD"))
+			provider := &fakeProvider{authorized: []int64{10000}}
+
+			resp, err := newTestService(db, provider).CaptureJobPayment(context.Background(), 3, model.PaymentCaptureRequest{TransactionID: 5})
+			if err != nil {
+				t.Fatalf("CaptureJobPayment() error = %v", err)
+			}
+			if len(provider.captured) != 1 {
+				t.Fatalf("provider captures = %d, want 1", len(provider.captured))
+			}
+			if got := provider.captured[0]; (got == nil) != (tt.wantCapture == nil) || (got != nil && *got != *tt.wantCapture) {
+				t.Errorf("captured %v, want %v", deref(got), deref(tt.wantCapture))
+			}
+			if resp.Transaction.Amount.Currency != "CAD" {
+				t.Errorf("transaction amount in %s, want CAD", resp.Transaction.Amount.Currency)
+			}
+			if updates := db.executed("SET captured_at"); len(updates) != 1 || updates[0].args[1].(model.Money).Currency != "CAD" {
+				t.Errorf("capture recorded as %v, want one update in CAD", updates)
+			}
+		})
+	}
+}
+
+func TestRefundJobPayment(t *testing.T) {
+	capture := transactionRow{
+		id: 5, jobID: 42, consumerID: 3, workerID: 7, amount: "100.00",
+		status: "completed", kind: "authorization", chargeID: "ch_hold", captured: true, platformFee: "10.00", net: "90.00",
+	}
+
+	tests := []struct {
+		name          string
+		amount        *model.Money
+		keepJobStatus bool
+		wantFull      bool
+	}{
+		{name: "full refund cancels the job", wantFull: true},
+		{name: "line item refunded on a completed job", amount: ptr(model.USD(2500)), keepJobStatus: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := (&scriptDB{}).
+				on("clover_charge_id", capture.values()).
+				on("INSERT INTO transactions", []driver.Value{int64(5)}).
+				onCapture(jobRow(42, 3, 7, "completed"))
+
+			_, err := newTestService(db, &fakeProvider{}).RefundJobPayment(context.Background(), 3, model.PaymentRefundRequest{
+				TransactionID: 5,
+				Amount:        tt.amount,
+				KeepJobStatus: tt.keepJobStatus,
+			})
+			if err != nil {
+				t.Fatalf("RefundJobPayment() error = %v", err)
+			}
+
+			updates := db.executed("SET refund_amount = COALESCE(refund_amount, 0) + $2")
+			if len(updates) != 1 {
+				t.Fatalf("original transaction updated %d times, want 1", len(updates))
+			}
+			if full := updates[0].args[5]; full != tt.wantFull {
+				t.Errorf("refunded in full = %v, want %v", full, tt.wantFull)
+			}
+			if cancelled := len(db.executed("UPDATE jobs")) > 0; cancelled == tt.keepJobStatus {
+				t.Errorf("job cancelled = %v, want %v", cancelled, !tt.keepJobStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,457 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"app/internal/model"
+)
+
+var (
+	ErrSettlementBatchNotFound = errors.New("settlement batch not found")
+	ErrSettlementBatchClosed   = errors.New("settlement batch is already reconciled")
+)
+
+// workerEarnings is what a captured transaction owes its worker: their share of the
+// job price plus reimbursed expenses and materials, or the whole of a tip, less what
+// was refunded of it in part
+const workerEarnings = `
+	COALESCE(t.net_amount, 0) - COALESCE(t.refund_amount, 0) + COALESCE((
+		SELECT SUM(ps.amount) FROM payment_splits ps
+		WHERE ps.transaction_id = t.id AND ps.split_type IN ('expense_reimbursement', 'materials', 'tip')
+	), 0)`
+
+// PayoutService settles captured job payments to workers in batches
+type PayoutService struct {
+	db       *sql.DB
+	provider Provider
+	hold     time.Duration
+}
+
+// NewPayoutService creates a payout service. Captures younger than hold wait for a
+// later batch, leaving time for refunds before workers are paid.
+func NewPayoutService(db *sql.DB, provider Provider, hold time.Duration) *PayoutService {
+	return &PayoutService{db: db, provider: provider, hold: hold}
+}
+
+// CreateBatch claims unsettled captures made before cutoff minus the hold period and
+// totals them into one pending payout per worker. It returns nil when there is nothing
+// to settle. A batch already created for the same cutoff is returned instead, so
+// retries do not split captures across batches.
+func (s *PayoutService) CreateBatch(ctx context.Context, cutoff time.Time) (*model.SettlementBatch, error) {
+	var existingID int
+	err := s.db.QueryRowContext(ctx, `SELECT id FROM settlement_batches WHERE cutoff_at = $1`, cutoff).Scan(&existingID)
+	if err == nil {
+		return s.GetBatch(ctx, existingID)
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to check for existing batch: %w", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var batchID int
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO settlement_batches (
+			provider_id, payment_provider, batch_date, batch_reference, cutoff_at,
+			total_amount, transaction_count, status
+		)
+		SELECT id, name, $2, $3, $4, 0, 0, $5
+		FROM payment_providers WHERE name = $1
+		RETURNING id
+	`, s.provider.Name(), cutoff.UTC().Format("2006-01-02"), "payouts-"+cutoff.UTC().Format("20060102T150405Z"), cutoff, model.SettlementBatchPending).Scan(&batchID)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("payment provider %s is not registered in payment_providers", s.provider.Name())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create settlement batch: %w", err)
+	}
+
+	// Tips are captured adjustments. Refunded captures are left for support to settle
+	// by hand, and disputed ones wait until the dispute is resolved.
+	result, err := tx.ExecContext(ctx, `
+		UPDATE transactions t
+		SET settlement_batch_id = $1, updated_at = NOW()
+		WHERE t.transaction_type IN ('authorization', 'adjustment')
+		  AND t.captured_at IS NOT NULL AND t.captured_at < $2
+		  AND t.refunded_at IS NULL
+		  AND t.gig_worker_id IS NOT NULL
+		  AND t.settlement_batch_id IS NULL
+		  AND t.payment_provider = $3
+		  AND NOT EXISTS (
+			SELECT 1 FROM job_disputes d
+			WHERE d.job_id = t.job_id AND d.status IN ('open', 'under_review')
+		  )
+	`, batchID, cutoff.Add(-s.hold), s.provider.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim captured transactions: %w", err)
+	}
+	claimed, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count claimed transactions: %w", err)
+	}
+	if claimed == 0 {
+		return nil, nil
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO worker_payouts (settlement_batch_id, gig_worker_id, amount, currency, transaction_count, status, payment_provider)
+		SELECT $1, t.gig_worker_id, SUM(`+workerEarnings+`), COALESCE(t.currency, 'USD'), COUNT(*), $2, $3
+		FROM transactions t
+		WHERE t.settlement_batch_id = $1
+		GROUP BY t.gig_worker_id, COALESCE(t.currency, 'USD')
+		HAVING SUM(`+workerEarnings+`) > 0
+	`, batchID, model.PayoutStatusPending, s.provider.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create worker payouts: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE settlement_batches b
+		SET transaction_count = $2,
+		    total_amount = COALESCE(p.total, 0),
+		    payout_count = COALESCE(p.count, 0),
+		    updated_at = NOW()
+		FROM (SELECT SUM(amount) AS total, COUNT(*) AS count FROM worker_payouts WHERE settlement_batch_id = $1) p
+		WHERE b.id = $1
+	`, batchID, claimed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to total settlement batch: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit settlement batch: %w", err)
+	}
+	return s.GetBatch(ctx, batchID)
+}
+
+// ProcessBatch sends the batch's pending and failed payouts through the provider.
+// Payout failures are recorded on the payout rather than returned, so one worker's
+// missing account does not hold up the rest; call again to retry them.
+func (s *PayoutService) ProcessBatch(ctx context.Context, batchID int) (*model.SettlementBatch, error) {
+	batch, err := s.GetBatch(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if batch.Status == model.SettlementBatchReconciled {
+		return nil, ErrSettlementBatchClosed
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT wp.id, wp.uuid, wp.amount, wp.currency, wp.payment_provider, COALESCE(prof.payout_account_id, '')
+		FROM worker_payouts wp
+		LEFT JOIN worker_profiles prof ON prof.worker_id = wp.gig_worker_id
+		WHERE wp.settlement_batch_id = $1 AND wp.status IN ($2, $3)
+		ORDER BY wp.id
+	`, batchID, model.PayoutStatusPending, model.PayoutStatusFailed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load payouts: %w", err)
+	}
+
+	type pendingPayout struct {
+		id          int
+		uuid        string
+		amount      float64
+		currency    string
+		provider    string
+		destination string
+	}
+	var pending []pendingPayout
+	for rows.Next() {
+		var p pendingPayout
+		if err := rows.Scan(&p.id, &p.uuid, &p.amount, &p.currency, &p.provider, &p.destination); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan payout: %w", err)
+		}
+		pending = append(pending, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read payouts: %w", err)
+	}
+
+	for _, p := range pending {
+		var payout *Payout
+		var payoutErr error
+		if p.provider != s.provider.Name() {
+			payoutErr = fmt.Errorf("payout was batched for %s but %s is configured", p.provider, s.provider.Name())
+		} else {
+			payout, payoutErr = s.provider.Payout(ctx, PayoutRequest{
+				Destination:    p.destination,
+				AmountCents:    DollarsToCents(p.amount),
+				Currency:       p.currency,
+				Description:    fmt.Sprintf("GigCo earnings, %s", derefString(batch.BatchReference)),
+				Metadata:       map[string]string{"settlement_batch_id": strconv.Itoa(batchID), "worker_payout_id": strconv.Itoa(p.id)},
+				IdempotencyKey: "worker-payout-" + p.uuid,
+			})
+		}
+
+		if payoutErr != nil {
+			status := model.PayoutStatusFailed
+			if errors.Is(payoutErr, ErrPayoutUnsupported) {
+				status = model.PayoutStatusManual
+			}
+			_, err = s.db.ExecContext(ctx, `
+				UPDATE worker_payouts
+				SET status = $2, failure_reason = $3, attempts = attempts + 1, updated_at = NOW()
+				WHERE id = $1
+			`, p.id, status, payoutErr.Error())
+		} else {
+			_, err = s.db.ExecContext(ctx, `
+				UPDATE worker_payouts
+				SET status = $2, provider_payout_id = $3, paid_amount = $4, failure_reason = NULL,
+				    attempts = attempts + 1, paid_at = NOW(), updated_at = NOW()
+				WHERE id = $1
+			`, p.id, model.PayoutStatusPaid, payout.ID, CentsToDollars(payout.AmountCents))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to record payout %d: %w", p.id, err)
+		}
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		UPDATE settlement_batches
+		SET status = CASE WHEN EXISTS (
+		        SELECT 1 FROM worker_payouts WHERE settlement_batch_id = $1 AND status IN ($2, $3)
+		    ) THEN $4 ELSE $5 END,
+		    processed_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`, batchID, model.PayoutStatusPending, model.PayoutStatusFailed, model.SettlementBatchFailed, model.SettlementBatchProcessed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update settlement batch: %w", err)
+	}
+
+	return s.GetBatch(ctx, batchID)
+}
+
+// ReconcileBatch checks that the batch's payouts add up to the earnings of the
+// transactions it claimed and that every payout settled. A batch with no
+// discrepancies is marked reconciled.
+func (s *PayoutService) ReconcileBatch(ctx context.Context, batchID int) (*model.SettlementReconciliation, error) {
+	batch, err := s.GetBatch(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	var earnings float64
+	err = s.db.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(`+workerEarnings+`), 0)
+		FROM transactions t
+		WHERE t.settlement_batch_id = $1
+	`, batchID).Scan(&earnings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to total batch earnings: %w", err)
+	}
+
+	result := Reconcile(batch, earnings)
+	if result.Reconciled && batch.Status != model.SettlementBatchReconciled {
+		_, err = s.db.ExecContext(ctx, `
+			UPDATE settlement_batches SET status = $2, reconciled_at = NOW(), updated_at = NOW() WHERE id = $1
+		`, batchID, model.SettlementBatchReconciled)
+		if err != nil {
+			return nil, fmt.Errorf("failed to mark batch reconciled: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// Reconcile compares a batch and its payouts with the earnings of the transactions
+// it settled
+func Reconcile(batch *model.SettlementBatch, earnings float64) *model.SettlementReconciliation {
+	result := &model.SettlementReconciliation{
+		SettlementBatchID: batch.ID,
+		EarningsTotal:     roundCents(earnings),
+		Discrepancies:     []string{},
+	}
+
+	for _, p := range batch.Payouts {
+		result.PayoutTotal += p.Amount
+		switch p.Status {
+		case model.PayoutStatusPaid:
+			paid := p.Amount
+			if p.PaidAmount != nil {
+				paid = *p.PaidAmount
+			}
+			result.SettledTotal += paid
+			if DollarsToCents(paid) != DollarsToCents(p.Amount) {
+				result.Discrepancies = append(result.Discrepancies,
+					fmt.Sprintf("Payout %d paid %.2f of %.2f", p.ID, paid, p.Amount))
+			}
+		case model.PayoutStatusManual:
+			result.SettledTotal += p.Amount
+		default:
+			result.UnsettledPayouts++
+		}
+	}
+	result.PayoutTotal = roundCents(result.PayoutTotal)
+	result.SettledTotal = roundCents(result.SettledTotal)
+
+	if DollarsToCents(result.PayoutTotal) != DollarsToCents(result.EarningsTotal) {
+		result.Discrepancies = append(result.Discrepancies,
+			fmt.Sprintf("Payouts total %.2f but settled transactions earned %.2f", result.PayoutTotal, result.EarningsTotal))
+	}
+	if DollarsToCents(batch.TotalAmount) != DollarsToCents(result.PayoutTotal) {
+		result.Discrepancies = append(result.Discrepancies,
+			fmt.Sprintf("Batch total %.2f does not match payouts total %.2f", batch.TotalAmount, result.PayoutTotal))
+	}
+	if result.UnsettledPayouts > 0 {
+		result.Discrepancies = append(result.Discrepancies,
+			fmt.Sprintf("%d payouts are not settled", result.UnsettledPayouts))
+	}
+
+	result.Reconciled = len(result.Discrepancies) == 0
+	return result
+}
+
+// GetBatch loads a settlement batch with its payouts
+func (s *PayoutService) GetBatch(ctx context.Context, id int) (*model.SettlementBatch, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+settlementBatchColumns+` FROM settlement_batches WHERE id = $1`, id)
+	batch, err := scanSettlementBatch(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrSettlementBatchNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load settlement batch: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+workerPayoutColumns+` FROM worker_payouts WHERE settlement_batch_id = $1 ORDER BY id
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load payouts: %w", err)
+	}
+	defer rows.Close()
+
+	batch.Payouts = []model.WorkerPayout{}
+	for rows.Next() {
+		p, err := scanWorkerPayout(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payout: %w", err)
+		}
+		batch.Payouts = append(batch.Payouts, *p)
+	}
+	return batch, rows.Err()
+}
+
+// ListBatches returns settlement batches newest first, optionally filtered by status
+func (s *PayoutService) ListBatches(ctx context.Context, status string, limit, offset int) ([]model.SettlementBatch, int, error) {
+	var total int
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM settlement_batches WHERE ($1 = '' OR status = $1)
+	`, status).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count settlement batches: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+settlementBatchColumns+` FROM settlement_batches
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`, status, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list settlement batches: %w", err)
+	}
+	defer rows.Close()
+
+	batches := []model.SettlementBatch{}
+	for rows.Next() {
+		batch, err := scanSettlementBatch(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan settlement batch: %w", err)
+		}
+		batches = append(batches, *batch)
+	}
+	return batches, total, rows.Err()
+}
+
+// ListWorkerPayouts returns a worker's payouts newest first
+func (s *PayoutService) ListWorkerPayouts(ctx context.Context, workerID, limit, offset int) ([]model.WorkerPayout, int, error) {
+	var total int
+	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM worker_payouts WHERE gig_worker_id = $1`, workerID).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count payouts: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+workerPayoutColumns+` FROM worker_payouts
+		WHERE gig_worker_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`, workerID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list payouts: %w", err)
+	}
+	defer rows.Close()
+
+	payouts := []model.WorkerPayout{}
+	for rows.Next() {
+		p, err := scanWorkerPayout(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan payout: %w", err)
+		}
+		payouts = append(payouts, *p)
+	}
+	return payouts, total, rows.Err()
+}
+
+const settlementBatchColumns = `
+	id, uuid, batch_date, batch_reference, COALESCE(payment_provider, ''), cutoff_at,
+	total_amount, transaction_count, COALESCE(payout_count, 0), COALESCE(status, 'pending'),
+	processed_at, reconciled_at, notes, created_at, updated_at`
+
+const workerPayoutColumns = `
+	id, uuid, settlement_batch_id, gig_worker_id, amount, paid_amount, currency,
+	transaction_count, status, payment_provider, provider_payout_id, failure_reason,
+	attempts, paid_at, created_at, updated_at`
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSettlementBatch(row rowScanner) (*model.SettlementBatch, error) {
+	var b model.SettlementBatch
+	err := row.Scan(
+		&b.ID, &b.UUID, &b.BatchDate, &b.BatchReference, &b.PaymentProvider, &b.CutoffAt,
+		&b.TotalAmount, &b.TransactionCount, &b.PayoutCount, &b.Status,
+		&b.ProcessedAt, &b.ReconciledAt, &b.Notes, &b.CreatedAt, &b.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func scanWorkerPayout(row rowScanner) (*model.WorkerPayout, error) {
+	var p model.WorkerPayout
+	err := row.Scan(
+		&p.ID, &p.UUID, &p.SettlementBatchID, &p.GigWorkerID, &p.Amount, &p.PaidAmount, &p.Currency,
+		&p.TransactionCount, &p.Status, &p.PaymentProvider, &p.ProviderPayoutID, &p.FailureReason,
+		&p.Attempts, &p.PaidAt, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
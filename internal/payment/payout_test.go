@@ -0,0 +1,73 @@
+package payment
+
+import (
+	"reflect"
+	"testing"
+
+	"app/internal/model"
+)
+
+func TestReconcile(t *testing.T) {
+	amount := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name     string
+		batch    model.SettlementBatch
+		earnings float64
+		want     model.SettlementReconciliation
+	}{
+		{
+			name: "paid and manual payouts match earnings",
+			batch: model.SettlementBatch{ID: 1, TotalAmount: 150.10, Payouts: []model.WorkerPayout{
+				{ID: 1, Amount: 100.05, PaidAmount: amount(100.05), Status: model.PayoutStatusPaid},
+				{ID: 2, Amount: 50.05, Status: model.PayoutStatusManual},
+			}},
+			earnings: 150.10,
+			want: model.SettlementReconciliation{
+				SettlementBatchID: 1, Reconciled: true,
+				EarningsTotal: 150.10, PayoutTotal: 150.10, SettledTotal: 150.10,
+				Discrepancies: []string{},
+			},
+		},
+		{
+			name: "failed payout and short transfer",
+			batch: model.SettlementBatch{ID: 2, TotalAmount: 150, Payouts: []model.WorkerPayout{
+				{ID: 3, Amount: 100, PaidAmount: amount(90), Status: model.PayoutStatusPaid},
+				{ID: 4, Amount: 50, Status: model.PayoutStatusFailed},
+			}},
+			earnings: 150,
+			want: model.SettlementReconciliation{
+				SettlementBatchID: 2,
+				EarningsTotal:     150, PayoutTotal: 150, SettledTotal: 90, UnsettledPayouts: 1,
+				Discrepancies: []string{
+					"Payout 3 paid 90.00 of 100.00",
+					"1 payouts are not settled",
+				},
+			},
+		},
+		{
+			name: "payouts do not match earnings",
+			batch: model.SettlementBatch{ID: 3, TotalAmount: 80, Payouts: []model.WorkerPayout{
+				{ID: 5, Amount: 75, Status: model.PayoutStatusManual},
+			}},
+			earnings: 75.5,
+			want: model.SettlementReconciliation{
+				SettlementBatchID: 3,
+				EarningsTotal:     75.5, PayoutTotal: 75, SettledTotal: 75,
+				Discrepancies: []string{
+					"Payouts total 75.00 but settled transactions earned 75.50",
+					"Batch total 80.00 does not match payouts total 75.00",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Reconcile(&tt.batch, tt.earnings)
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("Reconcile() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package payment
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"app/internal/model"
+)
+
+var (
+	// ErrJobNotFound is returned when pricing a job that does not exist
+	ErrJobNotFound = errors.New("job not found")
+	// ErrNotJobConsumer is returned when the caller is not the job's consumer
+	ErrNotJobConsumer = errors.New("user is not the consumer of this job")
+	// ErrJobNotPriced is returned for jobs with neither a total pay nor an hourly rate and duration
+	ErrJobNotPriced = errors.New("job has no price")
+	// ErrPriceChanged is returned when the amount being authorized no longer matches the quote
+	ErrPriceChanged = errors.New("amount does not match the current price breakdown")
+)
+
+// JobPrice returns what the consumer agreed to pay for a job: its total pay, or the
+// hourly rate times the estimated duration, rounded to the cent, in the job's currency
+func JobPrice(job *model.Job) (model.Money, error) {
+	currency := job.Currency
+	if currency == "" {
+		currency = model.DefaultCurrency
+	}
+	switch {
+	case job.TotalPay != nil && *job.TotalPay > 0:
+		return model.MoneyFromDollars(*job.TotalPay).In(currency), nil
+	case job.PayRatePerHour != nil && job.EstimatedDurationHours != nil:
+		price := model.MoneyFromDollars(*job.PayRatePerHour * *job.EstimatedDurationHours).In(currency)
+		if price.IsPositive() {
+			return price, nil
+		}
+	}
+	return model.Money{}, ErrJobNotPriced
+}
+
+// PriceJob itemizes a job price under the provider's default fee schedule. The fees
+// are split off the price and the remainder is the worker's labor. Credits are
+// platform-funded, so they reduce the total without touching the worker's share; tax
+// applies to the price after credits. All amounts are whole cents, so the items always
+// add up to the total.
+func PriceJob(provider Provider, price model.Money, salesTaxPercent float64, creditBalance model.Money) model.PriceBreakdown {
+	return PriceJobWithFees(provider, provider.Fees(), price, salesTaxPercent, creditBalance)
+}
+
+// PriceJobWithFees itemizes a job price like PriceJob under the given fee schedule,
+// such as the provider's with a fee rule applied
+func PriceJobWithFees(provider Provider, fees FeeSchedule, price model.Money, salesTaxPercent float64, creditBalance model.Money) model.PriceBreakdown {
+	labor, platformFee, processingFee := fees.Split(price)
+
+	credits := creditBalance.Min(price)
+	if credits.IsNegative() {
+		credits = model.NewMoney(0, price.Currency)
+	}
+
+	taxable := price.Sub(credits)
+	tax := taxable.Percent(salesTaxPercent)
+
+	return model.PriceBreakdown{
+		Currency:       price.Currency,
+		Labor:          labor,
+		PlatformFee:    platformFee,
+		ProcessingFee:  processingFee,
+		Subtotal:       price,
+		Credits:        credits,
+		TaxRatePercent: salesTaxPercent,
+		Tax:            tax,
+		Total:          taxable.Add(tax),
+		Provider:       provider.Name(),
+	}
+}
+
+// QuoteJob returns the price breakdown for a job's consumer. AuthorizeJobPayment
+// charges the Total of the same quote.
+func (s *PaymentService) QuoteJob(jobID, userID int) (*model.PriceBreakdown, error) {
+	job, err := s.getJob(jobID)
+	if err == sql.ErrNoRows {
+		return nil, ErrJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	if job.ConsumerID != userID {
+		return nil, ErrNotJobConsumer
+	}
+	return s.quote(job)
+}
+
+func (s *PaymentService) quote(job *model.Job) (*model.PriceBreakdown, error) {
+	price, err := JobPrice(job)
+	if err != nil {
+		return nil, err
+	}
+
+	credits, err := s.getCreditBalance(job.ConsumerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account credit: %w", err)
+	}
+	if credits.Currency != price.Currency {
+		// Account credit is held in the default currency and only applies to its jobs
+		credits = model.NewMoney(0, price.Currency)
+	}
+
+	rule, err := s.jobFeeRule(job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fee rules: %w", err)
+	}
+
+	// Processing fees are those of the merchant the job will be paid to
+	merchant, err := s.jobMerchant(job)
+	if err != nil {
+		return nil, err
+	}
+
+	breakdown := PriceJobWithFees(merchant.Provider, merchant.Provider.Fees().WithRule(rule), price, s.salesTaxPercent, credits)
+	breakdown.JobID = job.ID
+	if rule != nil {
+		breakdown.FeeRuleID = &rule.ID
+	}
+	return &breakdown, nil
+}
+
+// jobFeeRule selects the fee rule for a job by its category and the fee tier of its
+// assigned worker, as of now
+func (s *PaymentService) jobFeeRule(job *model.Job) (*model.FeeRule, error) {
+	var tier string
+	if job.GigWorkerID != nil {
+		err := s.db.QueryRow(`SELECT COALESCE(fee_tier, '') FROM worker_profiles WHERE worker_id = $1`, *job.GigWorkerID).Scan(&tier)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+	}
+	return s.feeRule(job.Category, tier, s.clock.Now())
+}
+
+// getCreditBalance sums the consumer's account credit ledger
+func (s *PaymentService) getCreditBalance(userID int) (model.Money, error) {
+	var balance model.Money
+	err := s.db.QueryRow(`SELECT COALESCE(SUM(amount), 0) FROM account_credits WHERE user_id = $1`, userID).Scan(&balance)
+	return balance, err
+}
+
+// redeemCredits records the credit applied to an authorization as a negative ledger entry
+func (s *PaymentService) redeemCredits(tx *sql.Tx, userID, transactionID int, amount model.Money) error {
+	_, err := tx.Exec(`
+		INSERT INTO account_credits (user_id, amount, reason, transaction_id)
+		VALUES ($1, $2, 'redeemed', $3)
+	`, userID, amount.Neg(), transactionID)
+	return err
+}
+
+// restoreCredits reverses the credit redeemed by a transaction
+func (s *PaymentService) restoreCredits(tx *sql.Tx, transactionID int) error {
+	_, err := tx.Exec(`
+		INSERT INTO account_credits (user_id, amount, reason, transaction_id)
+		SELECT user_id, -amount, 'restored', transaction_id
+		FROM account_credits
+		WHERE transaction_id = $1 AND reason = 'redeemed'
+	`, transactionID)
+	return err
+}
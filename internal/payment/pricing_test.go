@@ -0,0 +1,105 @@
+package payment
+
+import (
+	"testing"
+
+	"app/config"
+	"app/internal/model"
+)
+
+func TestPriceJob(t *testing.T) {
+	// 10% platform fee; Stripe processing is 2.9% + $0.30
+	provider := NewStripeProvider(&config.StripeConfig{PlatformFeePercent: 10})
+
+	usd := model.USD
+
+	tests := []struct {
+		name    string
+		price   model.Money
+		taxRate float64
+		credits model.Money
+		want    model.PriceBreakdown
+	}{
+		{
+			name:  "no tax or credit",
+			price: usd(10000),
+			want: model.PriceBreakdown{Labor: usd(8680), PlatformFee: usd(1000), ProcessingFee: usd(320), Subtotal: usd(10000),
+				Credits: usd(0), Tax: usd(0), Total: usd(10000)},
+		},
+		{
+			name:    "tax after credit",
+			price:   usd(10000),
+			taxRate: 8.25,
+			credits: usd(2000),
+			want: model.PriceBreakdown{Labor: usd(8680), PlatformFee: usd(1000), ProcessingFee: usd(320), Subtotal: usd(10000),
+				Credits: usd(2000), TaxRatePercent: 8.25, Tax: usd(660), Total: usd(8660)},
+		},
+		{
+			name:    "credit capped at price",
+			price:   usd(5000),
+			credits: usd(8000),
+			want: model.PriceBreakdown{Labor: usd(4325), PlatformFee: usd(500), ProcessingFee: usd(175), Subtotal: usd(5000),
+				Credits: usd(5000), Tax: usd(0), Total: usd(0)},
+		},
+		{
+			name:    "negative credit balance ignored",
+			price:   usd(5000),
+			credits: usd(-1000),
+			want: model.PriceBreakdown{Labor: usd(4325), PlatformFee: usd(500), ProcessingFee: usd(175), Subtotal: usd(5000),
+				Credits: usd(0), Tax: usd(0), Total: usd(5000)},
+		},
+		{
+			name:    "fractional cents round",
+			price:   usd(1999),
+			taxRate: 7,
+			want: model.PriceBreakdown{Labor: usd(1711), PlatformFee: usd(200), ProcessingFee: usd(88), Subtotal: usd(1999),
+				Credits: usd(0), TaxRatePercent: 7, Tax: usd(140), Total: usd(2139)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PriceJob(provider, tt.price, tt.taxRate, tt.credits)
+			tt.want.Currency = "USD"
+			tt.want.Provider = ProviderStripe
+			if got != tt.want {
+				t.Errorf("PriceJob() = %+v, want %+v", got, tt.want)
+			}
+
+			items := got.Labor.Add(got.PlatformFee).Add(got.ProcessingFee)
+			if items != got.Subtotal {
+				t.Errorf("labor and fees add up to %s, subtotal is %s", items, got.Subtotal)
+			}
+			if total := got.Subtotal.Sub(got.Credits).Add(got.Tax); total != got.Total {
+				t.Errorf("subtotal less credits plus tax is %s, total is %s", total, got.Total)
+			}
+		})
+	}
+}
+
+func TestJobPrice(t *testing.T) {
+	total, rate, hours := 19.99, 22.5, 1.5
+
+	tests := []struct {
+		name    string
+		job     model.Job
+		want    model.Money
+		wantErr error
+	}{
+		{name: "total pay", job: model.Job{TotalPay: &total}, want: model.USD(1999)},
+		{name: "hourly", job: model.Job{PayRatePerHour: &rate, EstimatedDurationHours: &hours}, want: model.USD(3375)},
+		{name: "unpriced", job: model.Job{}, wantErr: ErrJobNotPriced},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := JobPrice(&tt.job)
+			if err != tt.wantErr {
+				t.Fatalf("JobPrice() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("JobPrice() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"app/config"
+	"app/internal/model"
+)
+
+// Supported payment providers, selected with PAYMENT_PROVIDER
+const (
+	ProviderClover = "clover"
+	ProviderStripe = "stripe"
+)
+
+// ErrPayoutUnsupported is returned by providers that cannot send funds to a worker
+var ErrPayoutUnsupported = errors.New("payouts are not supported by this payment provider")
+
+// ErrPaymentDeclined wraps a provider's refusal to tokenize or authorize a card
+var ErrPaymentDeclined = errors.New("payment declined")
+
+// Provider is a card processor able to hold, capture and refund job payments and pay
+// workers out. Amounts are in cents.
+type Provider interface {
+	// Name is the provider's key, stored on every transaction it processes
+	Name() string
+
+	// Tokenize exchanges raw card details for a reusable token
+	Tokenize(ctx context.Context, card model.CardDetails) (*CardToken, error)
+
+	// Authorize places a hold on the card without capturing funds. The currency is an
+	// ISO 4217 code; capture and refund amounts are in the authorization's currency.
+	Authorize(ctx context.Context, token string, amountCents int64, currency string, metadata map[string]interface{}) (*Charge, error)
+
+	// Capture collects a held charge; a nil amount captures the full authorization
+	Capture(ctx context.Context, chargeID string, amountCents *int64) (*Capture, error)
+
+	// Refund returns funds for a charge; a nil amount refunds it in full
+	Refund(ctx context.Context, chargeID string, amountCents *int64, reason string) (*Refund, error)
+
+	// Payout sends funds to a worker's account with the provider
+	Payout(ctx context.Context, req PayoutRequest) (*Payout, error)
+
+	// Fees is the default fee schedule: the configured platform fee and the provider's
+	// processing fee. Fee rules may replace the platform fee for a job.
+	Fees() FeeSchedule
+
+	// CalculateNetAmount splits an amount into what the worker nets and the fees taken
+	// under the default fee schedule
+	CalculateNetAmount(amount model.Money) (netAmount, platformFee, processingFee model.Money)
+}
+
+// CardToken is a tokenized card
+type CardToken struct {
+	Token    string
+	Brand    string
+	Last4    string
+	ExpMonth string
+	ExpYear  string
+	Raw      interface{} // Provider response, kept for the payment event log
+}
+
+// Charge is an authorized (held) or captured charge
+type Charge struct {
+	ID          string
+	AmountCents int64
+	Status      string
+	SourceToken string
+	Brand       string
+	Last4       string
+	Raw         interface{}
+}
+
+// Capture is the result of capturing a held charge
+type Capture struct {
+	ID          string
+	AmountCents int64
+	Status      string
+	Raw         interface{}
+}
+
+// Refund is the result of refunding a charge
+type Refund struct {
+	ID          string
+	AmountCents int64
+	Status      string
+	Raw         interface{}
+}
+
+// PayoutRequest describes funds to send to a worker
+type PayoutRequest struct {
+	Destination string // Worker's account with the provider
+	AmountCents int64
+	Currency    string
+	Description string
+	Metadata    map[string]string
+
+	// IdempotencyKey makes retries of the same payout safe where the provider supports it
+	IdempotencyKey string
+}
+
+// Payout is the result of a payout
+type Payout struct {
+	ID          string
+	AmountCents int64
+	Status      string
+	Raw         interface{}
+}
+
+// NewProvider returns the provider selected by the payment configuration
+func NewProvider(cfg *config.PaymentConfig) (Provider, error) {
+	switch cfg.Provider {
+	case ProviderClover, "":
+		return NewCloverProvider(&cfg.Clover), nil
+	case ProviderStripe:
+		return NewStripeProvider(&cfg.Stripe), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", cfg.Provider)
+	}
+}
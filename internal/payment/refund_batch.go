@@ -0,0 +1,557 @@
+package payment
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/lib/pq"
+
+	"app/internal/model"
+)
+
+var (
+	ErrRefundBatchNotFound     = errors.New("refund batch not found")
+	ErrRefundBatchEmpty        = errors.New("no refundable payments were selected")
+	ErrRefundBatchTooLarge     = fmt.Errorf("refund batch selects more than %d payments", model.MaxRefundBatchSize)
+	ErrRefundBatchTransactions = errors.New("transactions not found")
+	ErrRefundBatchNotRetryable = errors.New("refund batch has no failed refunds to retry")
+)
+
+// refundBatchColumns selects a batch with its summary; the query joins refund_batches
+// b to refundBatchSummary s
+const refundBatchColumns = `
+	b.id, b.uuid, b.idempotency_key, b.reason, b.currency, b.filter, b.status, b.workflow_id,
+	b.created_by, b.started_at, b.completed_at, b.created_at, b.updated_at,
+	s.items, s.pending, s.refunded, s.failed, s.skipped, s.total_amount, s.refunded_amount, s.failed_amount`
+
+const refundBatchSummary = `
+	LEFT JOIN LATERAL (
+		SELECT COUNT(*) AS items,
+		       COUNT(*) FILTER (WHERE status = 'pending') AS pending,
+		       COUNT(*) FILTER (WHERE status = 'refunded') AS refunded,
+		       COUNT(*) FILTER (WHERE status = 'failed') AS failed,
+		       COUNT(*) FILTER (WHERE status = 'skipped') AS skipped,
+		       COALESCE(SUM(amount) FILTER (WHERE status <> 'skipped'), 0) AS total_amount,
+		       COALESCE(SUM(amount) FILTER (WHERE status = 'refunded'), 0) AS refunded_amount,
+		       COALESCE(SUM(amount) FILTER (WHERE status = 'failed'), 0) AS failed_amount
+		FROM refund_batch_items WHERE batch_id = b.id
+	) s ON true`
+
+const refundBatchItemColumns = `
+	id, batch_id, transaction_id, job_id, consumer_id, amount, status,
+	refund_transaction_id, error, attempts, processed_at, created_at, updated_at`
+
+// refundCandidate is a payment selected for a refund batch
+type refundCandidate struct {
+	transactionID int
+	jobID         int
+	consumerID    int
+	amount        model.Money
+	currency      string
+	skipReason    string // Set when the payment cannot be refunded
+}
+
+// PreviewRefundBatch lists the payments a refund batch request would refund, without
+// creating the batch
+func (s *PaymentService) PreviewRefundBatch(ctx context.Context, req model.RefundBatchRequest) (*model.RefundBatch, error) {
+	candidates, currency, err := s.refundCandidates(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &model.RefundBatch{
+		Reason:   req.Reason,
+		Currency: currency,
+		Filter:   req.Filter,
+		Status:   model.RefundBatchPending,
+		Items:    make([]model.RefundBatchItem, 0, len(candidates)),
+	}
+	for _, c := range candidates {
+		item := model.RefundBatchItem{
+			TransactionID: c.transactionID,
+			JobID:         c.jobID,
+			ConsumerID:    c.consumerID,
+			Amount:        c.amount,
+			Status:        model.RefundItemPending,
+		}
+		if c.skipReason != "" {
+			item.Status = model.RefundItemSkipped
+			item.Error = &c.skipReason
+		}
+		batch.Items = append(batch.Items, item)
+	}
+	batch.Summary = SummarizeRefundItems(batch.Items, currency)
+	return batch, nil
+}
+
+// CreateRefundBatch records a refund batch and its items, to be refunded by
+// ProcessRefundBatch. Repeating the idempotency key with the same request returns the
+// existing batch and true; with a different request it fails with
+// ErrIdempotencyKeyReused.
+func (s *PaymentService) CreateRefundBatch(ctx context.Context, adminID int, req model.RefundBatchRequest) (*model.RefundBatch, bool, error) {
+	hash, err := refundBatchRequestHash(req)
+	if err != nil {
+		return nil, false, err
+	}
+	if batch, err := s.findRefundBatch(ctx, req.IdempotencyKey, hash); err != nil || batch != nil {
+		return batch, batch != nil, err
+	}
+
+	candidates, currency, err := s.refundCandidates(ctx, req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var filter []byte
+	if req.Filter != nil {
+		if filter, err = json.Marshal(req.Filter); err != nil {
+			return nil, false, fmt.Errorf("failed to encode filter: %w", err)
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var batchID int
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO refund_batches (uuid, idempotency_key, request_hash, reason, currency, filter, status, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (idempotency_key) DO NOTHING
+		RETURNING id
+	`, s.ids.NewID(), req.IdempotencyKey, hash, req.Reason, currency, filter, model.RefundBatchPending, adminID).Scan(&batchID)
+	if err == sql.ErrNoRows {
+		// A concurrent request with the same key created the batch first
+		tx.Rollback()
+		batch, err := s.findRefundBatch(ctx, req.IdempotencyKey, hash)
+		return batch, true, err
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create refund batch: %w", err)
+	}
+
+	for _, c := range candidates {
+		status, skipReason := model.RefundItemPending, sql.NullString{}
+		if c.skipReason != "" {
+			status, skipReason = model.RefundItemSkipped, sql.NullString{String: c.skipReason, Valid: true}
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO refund_batch_items (batch_id, transaction_id, job_id, consumer_id, amount, status, error)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, batchID, c.transactionID, c.jobID, c.consumerID, c.amount, status, skipReason)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to add transaction %d to refund batch: %w", c.transactionID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("failed to commit refund batch: %w", err)
+	}
+	batch, err := s.GetRefundBatch(ctx, batchID)
+	return batch, false, err
+}
+
+// findRefundBatch returns the batch created with the idempotency key, or nil when the
+// key is new
+func (s *PaymentService) findRefundBatch(ctx context.Context, key, hash string) (*model.RefundBatch, error) {
+	var id int
+	var existingHash string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT id, request_hash FROM refund_batches WHERE idempotency_key = $1
+	`, key).Scan(&id, &existingHash)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up idempotency key: %w", err)
+	}
+	if existingHash != hash {
+		return nil, ErrIdempotencyKeyReused
+	}
+	return s.GetRefundBatch(ctx, id)
+}
+
+// refundCandidates selects the payments for a batch, in transaction order, with the
+// currency they share. Given transaction IDs must all exist; ones that cannot be
+// refunded are returned with a skip reason.
+func (s *PaymentService) refundCandidates(ctx context.Context, req model.RefundBatchRequest) ([]refundCandidate, string, error) {
+	const columns = `
+		SELECT t.id, t.job_id, j.consumer_id, COALESCE(t.capture_amount, t.amount), COALESCE(t.currency, 'USD'),
+		       CASE
+		           WHEN t.refunded_at IS NOT NULL THEN 'already refunded'
+		           WHEN t.transaction_type <> 'authorization' OR t.captured_at IS NULL THEN 'not a captured payment'
+		           ELSE ''
+		       END
+		FROM transactions t
+		JOIN jobs j ON j.id = t.job_id`
+
+	var rows *sql.Rows
+	var err error
+	if req.Filter != nil {
+		f := req.Filter
+		rows, err = s.db.QueryContext(ctx, columns+`
+			WHERE t.transaction_type = 'authorization'
+			  AND t.captured_at IS NOT NULL AND t.refunded_at IS NULL
+			  AND t.captured_at >= $1 AND t.captured_at < $2
+			  AND ($3 = '' OR t.payment_provider = $3)
+			  AND ($4 = '' OR COALESCE(t.currency, 'USD') = $4)
+			  AND ($5::int IS NULL OR j.consumer_id = $5)
+			  AND (NOT $6::boolean OR EXISTS (
+				SELECT 1 FROM transactions e
+				WHERE e.job_id = t.job_id AND e.transaction_type = 'authorization'
+				  AND e.captured_at IS NOT NULL
+				  AND (e.captured_at, e.id) < (t.captured_at, t.id)
+			  ))
+			ORDER BY t.id
+			LIMIT $7
+		`, f.CapturedFrom, f.CapturedTo, f.PaymentProvider, f.Currency, f.ConsumerID, f.DuplicatesOnly, model.MaxRefundBatchSize+1)
+	} else {
+		rows, err = s.db.QueryContext(ctx, columns+` WHERE t.id = ANY($1) ORDER BY t.id`, pq.Array(req.TransactionIDs))
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to select payments: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []refundCandidate
+	for rows.Next() {
+		var c refundCandidate
+		if err := rows.Scan(&c.transactionID, &c.jobID, &c.consumerID, &c.amount, &c.currency, &c.skipReason); err != nil {
+			return nil, "", fmt.Errorf("failed to scan payment: %w", err)
+		}
+		c.amount = c.amount.In(c.currency)
+		candidates = append(candidates, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("failed to read payments: %w", err)
+	}
+
+	if len(candidates) > model.MaxRefundBatchSize {
+		return nil, "", ErrRefundBatchTooLarge
+	}
+	if req.Filter == nil && len(candidates) < len(req.TransactionIDs) {
+		return nil, "", fmt.Errorf("%w: %v", ErrRefundBatchTransactions, missingTransactionIDs(req.TransactionIDs, candidates))
+	}
+	return checkRefundCandidates(candidates)
+}
+
+// checkRefundCandidates returns the currency of the refundable candidates, which must
+// all share one so the batch totals add up
+func checkRefundCandidates(candidates []refundCandidate) ([]refundCandidate, string, error) {
+	currency := ""
+	for _, c := range candidates {
+		if c.skipReason != "" {
+			continue
+		}
+		if currency != "" && c.currency != currency {
+			return nil, "", fmt.Errorf("%w: refund batch mixes %s and %s payments; filter by currency", ErrCurrencyMismatch, currency, c.currency)
+		}
+		currency = c.currency
+	}
+	if currency == "" {
+		return nil, "", ErrRefundBatchEmpty
+	}
+	return candidates, currency, nil
+}
+
+func missingTransactionIDs(ids []int, found []refundCandidate) []int {
+	seen := make(map[int]bool, len(found))
+	for _, c := range found {
+		seen[c.transactionID] = true
+	}
+	var missing []int
+	for _, id := range ids {
+		if !seen[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+// refundBatchRequestHash fingerprints what a batch refunds, so a repeated idempotency
+// key can be told apart from a reused one. Transaction ID order does not matter.
+func refundBatchRequestHash(req model.RefundBatchRequest) (string, error) {
+	ids := append([]int(nil), req.TransactionIDs...)
+	sort.Ints(ids)
+	data, err := json.Marshal(struct {
+		TransactionIDs []int                    `json:"transaction_ids"`
+		Filter         *model.RefundBatchFilter `json:"filter"`
+		Reason         string                   `json:"reason"`
+	}{ids, req.Filter, req.Reason})
+	if err != nil {
+		return "", fmt.Errorf("failed to hash refund batch request: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// ProcessRefundBatch refunds the batch's pending items on their consumers' behalf,
+// calling progress with the count refunded so far. A failed refund is recorded on its
+// item rather than returned, so one bad payment does not hold up the rest. When the
+// provider is rate limiting or ctx ends, the items left stay pending and the error is
+// returned, for the caller to try again later.
+func (s *PaymentService) ProcessRefundBatch(ctx context.Context, batchID int, progress func(done int)) (*model.RefundBatch, error) {
+	batch, err := s.GetRefundBatch(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		UPDATE refund_batches SET status = $2, started_at = COALESCE(started_at, $3), completed_at = NULL
+		WHERE id = $1
+	`, batchID, model.RefundBatchRunning, s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to start refund batch: %w", err)
+	}
+
+	done := 0
+	for {
+		items, err := s.listRefundBatchItems(ctx, batchID, model.RefundItemPending, 100)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			break
+		}
+
+		for _, item := range items {
+			resp, refundErr := s.RefundJobPayment(ctx, item.ConsumerID, model.PaymentRefundRequest{
+				TransactionID:  item.TransactionID,
+				Reason:         batch.Reason,
+				Currency:       batch.Currency,
+				IdempotencyKey: "refund-batch-" + batch.UUID + "-" + strconv.Itoa(item.TransactionID),
+			})
+			if refundErr != nil && (errors.Is(refundErr, ErrProviderRateLimited) || ctx.Err() != nil) {
+				return nil, fmt.Errorf("refund batch %d stopped at transaction %d: %w", batchID, item.TransactionID, refundErr)
+			}
+
+			if refundErr != nil {
+				_, err = s.db.ExecContext(ctx, `
+					UPDATE refund_batch_items
+					SET status = $2, error = $3, attempts = attempts + 1, processed_at = $4
+					WHERE id = $1
+				`, item.ID, model.RefundItemFailed, refundErr.Error(), s.clock.Now())
+			} else {
+				_, err = s.db.ExecContext(ctx, `
+					UPDATE refund_batch_items
+					SET status = $2, refund_transaction_id = $3, error = NULL, attempts = attempts + 1, processed_at = $4
+					WHERE id = $1
+				`, item.ID, model.RefundItemRefunded, resp.RefundID, s.clock.Now())
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to record refund of transaction %d: %w", item.TransactionID, err)
+			}
+			done++
+			if progress != nil {
+				progress(done)
+			}
+		}
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		UPDATE refund_batches
+		SET status = CASE WHEN EXISTS (
+		        SELECT 1 FROM refund_batch_items WHERE batch_id = $1 AND status = $2
+		    ) THEN $3 ELSE $4 END,
+		    completed_at = $5
+		WHERE id = $1
+	`, batchID, model.RefundItemFailed, model.RefundBatchCompletedWithErrors, model.RefundBatchCompleted, s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete refund batch: %w", err)
+	}
+	return s.GetRefundBatch(ctx, batchID)
+}
+
+// RetryRefundBatch returns a batch's failed items to pending so ProcessRefundBatch
+// tries them again. A batch that never started can also be retried.
+func (s *PaymentService) RetryRefundBatch(ctx context.Context, batchID int) (*model.RefundBatch, error) {
+	batch, err := s.GetRefundBatch(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+	switch batch.Status {
+	case model.RefundBatchPending:
+		return batch, nil
+	case model.RefundBatchCompletedWithErrors:
+	default:
+		return nil, ErrRefundBatchNotRetryable
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE refund_batch_items SET status = $2 WHERE batch_id = $1 AND status = $3
+	`, batchID, model.RefundItemPending, model.RefundItemFailed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reset failed refunds: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE refund_batches SET status = $2, completed_at = NULL WHERE id = $1
+	`, batchID, model.RefundBatchPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reset refund batch: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit refund batch retry: %w", err)
+	}
+	return s.GetRefundBatch(ctx, batchID)
+}
+
+// SetRefundBatchWorkflow records the workflow refunding a batch
+func (s *PaymentService) SetRefundBatchWorkflow(ctx context.Context, batchID int, workflowID string) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE refund_batches SET workflow_id = $2 WHERE id = $1`, batchID, workflowID)
+	if err != nil {
+		return fmt.Errorf("failed to record refund batch workflow: %w", err)
+	}
+	return nil
+}
+
+// GetRefundBatch loads a refund batch with its items
+func (s *PaymentService) GetRefundBatch(ctx context.Context, id int) (*model.RefundBatch, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT `+refundBatchColumns+` FROM refund_batches b `+refundBatchSummary+` WHERE b.id = $1
+	`, id)
+	batch, err := scanRefundBatch(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrRefundBatchNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load refund batch: %w", err)
+	}
+
+	items, err := s.listRefundBatchItems(ctx, id, "", model.MaxRefundBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].Amount = items[i].Amount.In(batch.Currency)
+	}
+	batch.Items = items
+	return batch, nil
+}
+
+// ListRefundBatches returns refund batches newest first, optionally filtered by status
+func (s *PaymentService) ListRefundBatches(ctx context.Context, status string, limit, offset int) ([]model.RefundBatch, int, error) {
+	var total int
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM refund_batches WHERE ($1 = '' OR status = $1)
+	`, status).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count refund batches: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+refundBatchColumns+` FROM refund_batches b `+refundBatchSummary+`
+		WHERE ($1 = '' OR b.status = $1)
+		ORDER BY b.created_at DESC, b.id DESC
+		LIMIT $2 OFFSET $3
+	`, status, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list refund batches: %w", err)
+	}
+	defer rows.Close()
+
+	batches := []model.RefundBatch{}
+	for rows.Next() {
+		batch, err := scanRefundBatch(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan refund batch: %w", err)
+		}
+		batches = append(batches, *batch)
+	}
+	return batches, total, rows.Err()
+}
+
+// listRefundBatchItems returns up to limit of a batch's items in order, only those
+// with status when it is set
+func (s *PaymentService) listRefundBatchItems(ctx context.Context, batchID int, status string, limit int) ([]model.RefundBatchItem, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+refundBatchItemColumns+` FROM refund_batch_items
+		WHERE batch_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY id
+		LIMIT $3
+	`, batchID, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load refund batch items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []model.RefundBatchItem{}
+	for rows.Next() {
+		var item model.RefundBatchItem
+		err := rows.Scan(
+			&item.ID, &item.BatchID, &item.TransactionID, &item.JobID, &item.ConsumerID, &item.Amount, &item.Status,
+			&item.RefundTransactionID, &item.Error, &item.Attempts, &item.ProcessedAt, &item.CreatedAt, &item.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan refund batch item: %w", err)
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func scanRefundBatch(row rowScanner) (*model.RefundBatch, error) {
+	var b model.RefundBatch
+	var filter []byte
+	var sum model.RefundBatchSummary
+	err := row.Scan(
+		&b.ID, &b.UUID, &b.IdempotencyKey, &b.Reason, &b.Currency, &filter, &b.Status, &b.WorkflowID,
+		&b.CreatedBy, &b.StartedAt, &b.CompletedAt, &b.CreatedAt, &b.UpdatedAt,
+		&sum.Items, &sum.Pending, &sum.Refunded, &sum.Failed, &sum.Skipped, &sum.TotalAmount, &sum.RefundedAmount, &sum.FailedAmount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if filter != nil {
+		b.Filter = &model.RefundBatchFilter{}
+		if err := json.Unmarshal(filter, b.Filter); err != nil {
+			return nil, fmt.Errorf("failed to decode refund batch filter: %w", err)
+		}
+	}
+	sum.TotalAmount = sum.TotalAmount.In(b.Currency)
+	sum.RefundedAmount = sum.RefundedAmount.In(b.Currency)
+	sum.FailedAmount = sum.FailedAmount.In(b.Currency)
+	b.Summary = sum
+	return &b, nil
+}
+
+// SummarizeRefundItems counts items by status and totals their amounts in currency
+func SummarizeRefundItems(items []model.RefundBatchItem, currency string) model.RefundBatchSummary {
+	sum := model.RefundBatchSummary{
+		Items:          len(items),
+		TotalAmount:    model.NewMoney(0, currency),
+		RefundedAmount: model.NewMoney(0, currency),
+		FailedAmount:   model.NewMoney(0, currency),
+	}
+	for _, item := range items {
+		switch item.Status {
+		case model.RefundItemPending:
+			sum.Pending++
+		case model.RefundItemRefunded:
+			sum.Refunded++
+			sum.RefundedAmount = sum.RefundedAmount.Add(item.Amount)
+		case model.RefundItemFailed:
+			sum.Failed++
+			sum.FailedAmount = sum.FailedAmount.Add(item.Amount)
+		case model.RefundItemSkipped:
+			sum.Skipped++
+			continue
+		}
+		sum.TotalAmount = sum.TotalAmount.Add(item.Amount)
+	}
+	return sum
+}
@@ -0,0 +1,95 @@
+package payment
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"app/internal/model"
+)
+
+func TestCheckRefundCandidates(t *testing.T) {
+	usd := func(id int) refundCandidate {
+		return refundCandidate{transactionID: id, amount: model.USD(1000), currency: "USD"}
+	}
+	eur := refundCandidate{transactionID: 9, amount: model.NewMoney(1000, "EUR"), currency: "EUR"}
+	skippedEUR := eur
+	skippedEUR.skipReason = "already refunded"
+
+	tests := []struct {
+		name       string
+		candidates []refundCandidate
+		want       string
+		wantErr    error
+	}{
+		{name: "one currency", candidates: []refundCandidate{usd(1), usd(2)}, want: "USD"},
+		{name: "skipped payments in another currency", candidates: []refundCandidate{usd(1), skippedEUR}, want: "USD"},
+		{name: "mixed currencies", candidates: []refundCandidate{usd(1), eur}, wantErr: ErrCurrencyMismatch},
+		{name: "nothing refundable", candidates: []refundCandidate{skippedEUR}, wantErr: ErrRefundBatchEmpty},
+		{name: "nothing selected", wantErr: ErrRefundBatchEmpty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, got, err := checkRefundCandidates(tt.candidates)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("checkRefundCandidates() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("checkRefundCandidates() currency = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefundBatchRequestHash(t *testing.T) {
+	hash := func(req model.RefundBatchRequest) string {
+		t.Helper()
+		h, err := refundBatchRequestHash(req)
+		if err != nil {
+			t.Fatalf("refundBatchRequestHash() error = %v", err)
+		}
+		return h
+	}
+	from := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	filter := &model.RefundBatchFilter{CapturedFrom: from, CapturedTo: from.Add(2 * time.Hour), DuplicatesOnly: true}
+
+	base := hash(model.RefundBatchRequest{TransactionIDs: []int{3, 1, 2}, Reason: "outage", IdempotencyKey: "a"})
+	if got := hash(model.RefundBatchRequest{TransactionIDs: []int{1, 2, 3}, Reason: "outage", IdempotencyKey: "b", DryRun: true}); got != base {
+		t.Error("hash should not depend on transaction order, the key or dry_run")
+	}
+	if got := hash(model.RefundBatchRequest{TransactionIDs: []int{1, 2}, Reason: "outage"}); got == base {
+		t.Error("hash should change with the transactions")
+	}
+	if got := hash(model.RefundBatchRequest{TransactionIDs: []int{1, 2, 3}, Reason: "duplicate charge"}); got == base {
+		t.Error("hash should change with the reason")
+	}
+	if hash(model.RefundBatchRequest{Filter: filter, Reason: "outage"}) == hash(model.RefundBatchRequest{Filter: &model.RefundBatchFilter{CapturedFrom: from, CapturedTo: from.Add(2 * time.Hour)}, Reason: "outage"}) {
+		t.Error("hash should change with the filter")
+	}
+}
+
+func TestSummarizeRefundItems(t *testing.T) {
+	items := []model.RefundBatchItem{
+		{Amount: model.USD(1000), Status: model.RefundItemRefunded},
+		{Amount: model.USD(2500), Status: model.RefundItemRefunded},
+		{Amount: model.USD(700), Status: model.RefundItemFailed},
+		{Amount: model.USD(300), Status: model.RefundItemPending},
+		{Amount: model.USD(9900), Status: model.RefundItemSkipped},
+	}
+
+	got := SummarizeRefundItems(items, "USD")
+	want := model.RefundBatchSummary{
+		Items:          5,
+		Pending:        1,
+		Refunded:       2,
+		Failed:         1,
+		Skipped:        1,
+		TotalAmount:    model.USD(4500),
+		RefundedAmount: model.USD(3500),
+		FailedAmount:   model.USD(700),
+	}
+	if got != want {
+		t.Errorf("SummarizeRefundItems() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,310 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"app/config"
+	"app/internal/model"
+)
+
+// StripeProvider processes job payments through Stripe PaymentIntents and pays
+// workers out with Connect transfers
+type StripeProvider struct {
+	config     *config.StripeConfig
+	httpClient *http.Client
+}
+
+// NewStripeProvider creates a Stripe payment provider
+func NewStripeProvider(cfg *config.StripeConfig) *StripeProvider {
+	return &StripeProvider{
+		config: cfg,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// stripeCard is the card summary Stripe returns on payment methods
+type stripeCard struct {
+	Brand    string `json:"brand"`
+	Last4    string `json:"last4"`
+	ExpMonth int    `json:"exp_month"`
+	ExpYear  int    `json:"exp_year"`
+}
+
+type stripePaymentMethod struct {
+	ID   string     `json:"id"`
+	Card stripeCard `json:"card"`
+}
+
+type stripePaymentIntent struct {
+	ID             string               `json:"id"`
+	Amount         int64                `json:"amount"`
+	AmountReceived int64                `json:"amount_received"`
+	Currency       string               `json:"currency"`
+	Status         string               `json:"status"`
+	LatestCharge   string               `json:"latest_charge"`
+	PaymentMethod  *stripePaymentMethod `json:"payment_method"`
+}
+
+type stripeRefund struct {
+	ID     string `json:"id"`
+	Amount int64  `json:"amount"`
+	Status string `json:"status"`
+}
+
+type stripeTransfer struct {
+	ID          string `json:"id"`
+	Amount      int64  `json:"amount"`
+	Destination string `json:"destination"`
+	Reversed    bool   `json:"reversed"`
+}
+
+type stripeError struct {
+	Error struct {
+		Type    string `json:"type"`
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Name returns the provider key
+func (p *StripeProvider) Name() string {
+	return ProviderStripe
+}
+
+// Tokenize creates a card PaymentMethod. Sending raw card numbers to Stripe requires
+// raw card data access on the account; clients should normally tokenize with
+// Stripe.js and send the resulting pm_ ID as card_token.
+func (p *StripeProvider) Tokenize(ctx context.Context, card model.CardDetails) (*CardToken, error) {
+	form := url.Values{}
+	form.Set("type", "card")
+	form.Set("card[number]", card.Number)
+	form.Set("card[exp_month]", card.ExpMonth)
+	form.Set("card[exp_year]", card.ExpYear)
+	form.Set("card[cvc]", card.CVV)
+	setIfNotEmpty(form, "billing_details[name]", card.Name)
+	setIfNotEmpty(form, "billing_details[address][line1]", card.AddressLine1)
+	setIfNotEmpty(form, "billing_details[address][city]", card.AddressCity)
+	setIfNotEmpty(form, "billing_details[address][state]", card.AddressState)
+	setIfNotEmpty(form, "billing_details[address][postal_code]", card.AddressZip)
+
+	var pm stripePaymentMethod
+	if err := p.post(ctx, "tokenize", "/v1/payment_methods", form, &pm); err != nil {
+		return nil, fmt.Errorf("tokenization failed: %w", err)
+	}
+
+	return &CardToken{
+		Token:    pm.ID,
+		Brand:    pm.Card.Brand,
+		Last4:    pm.Card.Last4,
+		ExpMonth: strconv.Itoa(pm.Card.ExpMonth),
+		ExpYear:  strconv.Itoa(pm.Card.ExpYear),
+		Raw:      pm,
+	}, nil
+}
+
+// Authorize confirms a PaymentIntent with manual capture, which places a hold on the card
+func (p *StripeProvider) Authorize(ctx context.Context, token string, amountCents int64, currency string, metadata map[string]interface{}) (*Charge, error) {
+	form := url.Values{}
+	form.Set("amount", strconv.FormatInt(amountCents, 10))
+	form.Set("currency", stripeCurrency(currency))
+	form.Set("payment_method", token)
+	form.Set("payment_method_types[]", "card")
+	form.Set("capture_method", "manual")
+	form.Set("confirm", "true")
+	form.Set("expand[]", "payment_method")
+	setMetadata(form, metadata)
+
+	var intent stripePaymentIntent
+	if err := p.post(ctx, "authorize", "/v1/payment_intents", form, &intent); err != nil {
+		return nil, fmt.Errorf("authorization failed: %w", err)
+	}
+	if intent.Status != "requires_capture" {
+		return nil, fmt.Errorf("authorization failed: payment intent %s is %s", intent.ID, intent.Status)
+	}
+
+	charge := &Charge{
+		ID:          intent.ID,
+		AmountCents: intent.Amount,
+		Status:      intent.Status,
+		SourceToken: token,
+		Raw:         intent,
+	}
+	if intent.PaymentMethod != nil {
+		charge.Brand = intent.PaymentMethod.Card.Brand
+		charge.Last4 = intent.PaymentMethod.Card.Last4
+	}
+	return charge, nil
+}
+
+// Capture captures a PaymentIntent placed on hold by Authorize
+func (p *StripeProvider) Capture(ctx context.Context, chargeID string, amountCents *int64) (*Capture, error) {
+	form := url.Values{}
+	if amountCents != nil {
+		form.Set("amount_to_capture", strconv.FormatInt(*amountCents, 10))
+	}
+
+	var intent stripePaymentIntent
+	if err := p.post(ctx, "capture", "/v1/payment_intents/"+url.PathEscape(chargeID)+"/capture", form, &intent); err != nil {
+		return nil, fmt.Errorf("capture failed: %w", err)
+	}
+
+	return &Capture{
+		ID:          intent.ID,
+		AmountCents: intent.AmountReceived,
+		Status:      intent.Status,
+		Raw:         intent,
+	}, nil
+}
+
+// Refund refunds a captured PaymentIntent, or releases the hold on an uncaptured one
+func (p *StripeProvider) Refund(ctx context.Context, chargeID string, amountCents *int64, reason string) (*Refund, error) {
+	form := url.Values{}
+	form.Set("payment_intent", chargeID)
+	if amountCents != nil {
+		form.Set("amount", strconv.FormatInt(*amountCents, 10))
+	}
+	// Stripe only accepts its own reason codes, so the free-text reason goes in metadata
+	form.Set("reason", "requested_by_customer")
+	setIfNotEmpty(form, "metadata[reason]", reason)
+
+	var refund stripeRefund
+	if err := p.post(ctx, "refund", "/v1/refunds", form, &refund); err != nil {
+		return nil, fmt.Errorf("refund failed: %w", err)
+	}
+
+	return &Refund{
+		ID:          refund.ID,
+		AmountCents: refund.Amount,
+		Status:      refund.Status,
+		Raw:         refund,
+	}, nil
+}
+
+// Payout transfers funds from the platform balance to a worker's connected account
+func (p *StripeProvider) Payout(ctx context.Context, req PayoutRequest) (*Payout, error) {
+	if req.Destination == "" {
+		return nil, fmt.Errorf("payout failed: no destination account")
+	}
+
+	form := url.Values{}
+	form.Set("amount", strconv.FormatInt(req.AmountCents, 10))
+	form.Set("currency", stripeCurrency(req.Currency))
+	form.Set("destination", req.Destination)
+	setIfNotEmpty(form, "description", req.Description)
+	for key, value := range req.Metadata {
+		form.Set("metadata["+key+"]", value)
+	}
+
+	var transfer stripeTransfer
+	if err := p.postIdempotent(ctx, "payout", "/v1/transfers", form, req.IdempotencyKey, &transfer); err != nil {
+		return nil, fmt.Errorf("payout failed: %w", err)
+	}
+
+	status := "paid"
+	if transfer.Reversed {
+		status = "reversed"
+	}
+	return &Payout{
+		ID:          transfer.ID,
+		AmountCents: transfer.Amount,
+		Status:      status,
+		Raw:         transfer,
+	}, nil
+}
+
+// Fees is the configured platform fee and Stripe's standard card processing
+// fee (2.9% + $0.30)
+func (p *StripeProvider) Fees() FeeSchedule {
+	return FeeSchedule{
+		PlatformPercent:   p.config.PlatformFeePercent,
+		ProcessingPercent: 2.9,
+		ProcessingFixed:   model.USD(30),
+	}
+}
+
+// CalculateNetAmount splits amount under the default fee schedule
+func (p *StripeProvider) CalculateNetAmount(amount model.Money) (netAmount, platformFee, processingFee model.Money) {
+	return p.Fees().Split(amount)
+}
+
+// stripeCurrency returns the lowercase code Stripe expects, defaulting to
+// model.DefaultCurrency
+func stripeCurrency(currency string) string {
+	if currency == "" {
+		currency = model.DefaultCurrency
+	}
+	return strings.ToLower(currency)
+}
+
+// post sends a form-encoded request to the Stripe API and decodes the response into out
+func (p *StripeProvider) post(ctx context.Context, operation, path string, form url.Values, out interface{}) error {
+	return p.postIdempotent(ctx, operation, path, form, "", out)
+}
+
+// postIdempotent is post with an Idempotency-Key, so Stripe replays the original
+// response instead of repeating the operation when a request is retried. The request is
+// traced as a span for the operation.
+func (p *StripeProvider) postIdempotent(ctx context.Context, operation, path string, form url.Values, idempotencyKey string, out interface{}) error {
+	ctx, span := startProviderSpan(ctx, ProviderStripe, operation)
+	defer span.End()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.config.APIEndpoint+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", p.config.SecretKey))
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		endProviderSpan(span, 0, err)
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	endProviderSpan(span, resp.StatusCode, err)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var stripeErr stripeError
+		if json.Unmarshal(body, &stripeErr) == nil && stripeErr.Error.Message != "" {
+			return fmt.Errorf("status %d: %s", resp.StatusCode, stripeErr.Error.Message)
+		}
+		return fmt.Errorf("status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}
+
+func setIfNotEmpty(form url.Values, key, value string) {
+	if value != "" {
+		form.Set(key, value)
+	}
+}
+
+// setMetadata flattens metadata into Stripe's metadata[key] form fields
+func setMetadata(form url.Values, metadata map[string]interface{}) {
+	for key, value := range metadata {
+		form.Set("metadata["+key+"]", fmt.Sprint(value))
+	}
+}
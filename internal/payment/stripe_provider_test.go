@@ -0,0 +1,135 @@
+package payment
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"app/config"
+)
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		provider string
+		want     string
+		wantErr  bool
+	}{
+		{provider: "", want: ProviderClover},
+		{provider: "clover", want: ProviderClover},
+		{provider: "stripe", want: ProviderStripe},
+		{provider: "paypal", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			provider, err := NewProvider(&config.PaymentConfig{Provider: tt.provider})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewProvider(%q) error = %v, wantErr %v", tt.provider, err, tt.wantErr)
+			}
+			if err == nil && provider.Name() != tt.want {
+				t.Errorf("NewProvider(%q).Name() = %q, want %q", tt.provider, provider.Name(), tt.want)
+			}
+		})
+	}
+}
+
+// newStripeTestServer records the last request's path and form and replies with body
+func newStripeTestServer(t *testing.T, status int, body string, path *string, form *url.Values) *StripeProvider {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer sk_test_123" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse form: %v", err)
+		}
+		*path = r.URL.Path
+		*form = r.PostForm
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return NewStripeProvider(&config.StripeConfig{SecretKey: "sk_test_123", APIEndpoint: server.URL})
+}
+
+func TestStripeAuthorize(t *testing.T) {
+	var path string
+	var form url.Values
+	provider := newStripeTestServer(t, http.StatusOK, `{
+		"id": "pi_123", "amount": 5000, "status": "requires_capture",
+		"payment_method": {"id": "pm_123", "card": {"brand": "visa", "last4": "4242"}}
+	}`, &path, &form)
+
+	charge, err := provider.Authorize(context.Background(), "pm_123", 5000, "USD", map[string]interface{}{"job_id": 42})
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+
+	if path != "/v1/payment_intents" {
+		t.Errorf("path = %q, want /v1/payment_intents", path)
+	}
+	wantForm := map[string]string{
+		"amount":           "5000",
+		"currency":         "usd",
+		"payment_method":   "pm_123",
+		"capture_method":   "manual",
+		"confirm":          "true",
+		"metadata[job_id]": "42",
+	}
+	for key, want := range wantForm {
+		if got := form.Get(key); got != want {
+			t.Errorf("form[%s] = %q, want %q", key, got, want)
+		}
+	}
+
+	if charge.ID != "pi_123" || charge.AmountCents != 5000 || charge.Brand != "visa" || charge.Last4 != "4242" {
+		t.Errorf("Authorize() = %+v", charge)
+	}
+}
+
+func TestStripeAuthorizeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{name: "card declined", status: http.StatusPaymentRequired, body: `{"error": {"type": "card_error", "message": "Your card was declined."}}`},
+		{name: "not held", status: http.StatusOK, body: `{"id": "pi_123", "status": "requires_action"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path string
+			var form url.Values
+			provider := newStripeTestServer(t, tt.status, tt.body, &path, &form)
+			if _, err := provider.Authorize(context.Background(), "pm_123", 5000, "USD", nil); err == nil {
+				t.Error("Authorize() should fail")
+			}
+		})
+	}
+}
+
+func TestStripeCapture(t *testing.T) {
+	var path string
+	var form url.Values
+	provider := newStripeTestServer(t, http.StatusOK, `{"id": "pi_123", "amount": 5000, "amount_received": 4500, "status": "succeeded"}`, &path, &form)
+
+	amount := int64(4500)
+	capture, err := provider.Capture(context.Background(), "pi_123", &amount)
+	if err != nil {
+		t.Fatalf("Capture() error = %v", err)
+	}
+
+	if path != "/v1/payment_intents/pi_123/capture" {
+		t.Errorf("path = %q", path)
+	}
+	if got := form.Get("amount_to_capture"); got != "4500" {
+		t.Errorf("amount_to_capture = %q, want 4500", got)
+	}
+	if capture.AmountCents != 4500 {
+		t.Errorf("Capture().AmountCents = %d, want 4500", capture.AmountCents)
+	}
+}
@@ -0,0 +1,244 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"app/internal/model"
+
+	"github.com/lib/pq"
+)
+
+// MaxTip is the largest tip a consumer can leave on one job
+var MaxTip = model.USD(50000)
+
+var (
+	// ErrJobNotTippable is returned when tipping a job that has not been completed
+	ErrJobNotTippable = errors.New("job can only be tipped once it is completed")
+	// ErrAlreadyTipped is returned when the job's worker was already tipped
+	ErrAlreadyTipped = errors.New("job has already been tipped")
+	// ErrInvalidTip is returned for tips that are not positive or exceed MaxTip
+	ErrInvalidTip = errors.New("tip amount is out of range")
+)
+
+// tippableStatuses are the job statuses in which the worker can be tipped
+var tippableStatuses = map[string]bool{
+	"completed":      true,
+	"paid":           true,
+	"review_pending": true,
+	"closed":         true,
+}
+
+// checkTip validates a tip on a job in status
+func checkTip(status string, amount model.Money) error {
+	if !tippableStatuses[status] {
+		return ErrJobNotTippable
+	}
+	if !amount.IsPositive() || amount.Cents > MaxTip.Cents {
+		return fmt.Errorf("%w: must be more than 0 and at most $%s", ErrInvalidTip, MaxTip)
+	}
+	return nil
+}
+
+// TipJob charges the consumer a tip for a completed job's worker. The tip is a
+// separate charge, captured at once, on the card that paid for the job unless a saved
+// payment method is given. It is recorded as an adjustment to the job's authorization
+// with a tip split paid to the worker in full, so the next payout settles it. A job
+// takes one tip; a request repeating the idempotency key of one that succeeded returns
+// that tip.
+func (s *PaymentService) TipJob(ctx context.Context, userID, jobID int, req model.JobTipRequest) (*model.JobTipResponse, error) {
+	unlock, err := s.lockIdempotencyKey(ctx, userID, req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	previousID, err := s.findIdempotentTransaction(userID, req.IdempotencyKey, "tip")
+	if err != nil {
+		return nil, err
+	}
+	if previousID != 0 {
+		transaction, err := s.getTransaction(previousID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction: %w", err)
+		}
+		if transaction.JobID != jobID {
+			return nil, ErrIdempotencyKeyReused
+		}
+		return tipResponse(transaction, true), nil
+	}
+
+	// 1. Get job and verify it can be tipped
+	job, err := s.getJob(jobID)
+	if err == sql.ErrNoRows {
+		return nil, ErrJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	if job.ConsumerID != userID {
+		return nil, ErrNotJobConsumer
+	}
+	if job.GigWorkerID == nil {
+		return nil, ErrJobNotTippable
+	}
+	if err := checkTip(job.Status, req.Amount); err != nil {
+		return nil, err
+	}
+
+	var tipped bool
+	err = s.db.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM payment_splits ps
+			JOIN transactions t ON t.id = ps.transaction_id
+			WHERE t.job_id = $1 AND ps.split_type = 'tip' AND t.refunded_at IS NULL
+		)
+	`, jobID).Scan(&tipped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for an earlier tip: %w", err)
+	}
+	if tipped {
+		return nil, ErrAlreadyTipped
+	}
+
+	// 2. Find the job's authorization, whose card is charged by default
+	var parentID sql.NullInt64
+	var currency, parentProvider string
+	var sourceToken sql.NullString
+	parentMerchant := s.merchantID
+	err = s.db.QueryRow(`
+		SELECT id, COALESCE(currency, 'USD'), payment_provider, provider_source_token, COALESCE(merchant_id, $2)
+		FROM transactions
+		WHERE job_id = $1 AND transaction_type = 'authorization' AND status <> 'failed'
+		ORDER BY id DESC
+		LIMIT 1
+	`, jobID, s.merchantID).Scan(&parentID, &currency, &parentProvider, &sourceToken, &parentMerchant)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get job authorization: %w", err)
+	}
+	if currency == "" {
+		currency = model.DefaultCurrency
+	}
+	amount := model.NewMoney(req.Amount.Cents, currency)
+
+	merchant, err := s.jobMerchant(job)
+	if err != nil {
+		return nil, err
+	}
+	provider := merchant.Provider
+
+	var cardToken string
+	if req.PaymentMethodID != nil {
+		pm, err := s.getPaymentMethod(*req.PaymentMethodID, userID, provider.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get payment method: %w", err)
+		}
+		if pm.CloverToken != nil {
+			cardToken = *pm.CloverToken
+		} else if pm.ExternalID != "" {
+			cardToken = pm.ExternalID
+		} else {
+			return nil, fmt.Errorf("payment method does not have a valid token")
+		}
+	} else if sourceToken.Valid && sourceToken.String != "" && parentProvider == provider.Name() && parentMerchant == merchant.ID {
+		cardToken = sourceToken.String
+	} else {
+		return nil, fmt.Errorf("no payment source provided")
+	}
+
+	// 3. Charge the tip with the provider
+	metadata := map[string]interface{}{
+		"job_id":      jobID,
+		"consumer_id": userID,
+		"type":        "tip",
+	}
+	charge, err := provider.Authorize(ctx, cardToken, amount.Cents, currency, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("%w by %s: %w", ErrPaymentDeclined, provider.Name(), err)
+	}
+	capture, err := provider.Capture(ctx, charge.ID, nil)
+	if err != nil {
+		s.releaseTip(ctx, provider, jobID, charge.ID)
+		return nil, fmt.Errorf("failed to capture tip with %s: %w", provider.Name(), err)
+	}
+	s.checkProviderAmount(ctx, provider, jobID, 0, "tip", &amount.Cents, capture.AmountCents, currency)
+
+	// 4. Record the tip and the worker's split
+	now := s.clock.Now()
+	tx, err := s.db.Begin()
+	if err != nil {
+		s.releaseTip(ctx, provider, jobID, charge.ID)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var transactionID int
+	err = tx.QueryRow(`
+		INSERT INTO transactions (
+			job_id, consumer_id, gig_worker_id, amount, currency,
+			status, transaction_type,
+			payment_provider, provider_charge_id, provider_source_token,
+			authorized_at, captured_at, capture_amount,
+			payment_method, last_four,
+			processing_fee, platform_fee,
+			parent_transaction_id, metadata, uuid, merchant_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, NULLIF($21, ''))
+		RETURNING id
+	`,
+		jobID, job.ConsumerID, job.GigWorkerID, amount, currency,
+		"completed", "adjustment",
+		provider.Name(), charge.ID, charge.SourceToken,
+		now, now, model.NewMoney(capture.AmountCents, currency),
+		charge.Brand, charge.Last4,
+		model.NewMoney(0, currency), model.NewMoney(0, currency),
+		parentID, toJSON(metadata), s.ids.NewID(), merchant.ID,
+	).Scan(&transactionID)
+	if err == nil {
+		_, err = tx.Exec(`
+			INSERT INTO payment_splits (transaction_id, split_type, amount, recipient_id, description)
+			VALUES ($1, $2, $3, $4, $5)
+		`, transactionID, model.PaymentSplitTypeTip, amount, job.GigWorkerID, "Tip")
+	}
+	if err == nil {
+		err = s.createPaymentEvent(tx, transactionID, "tip", "success", capture.Raw, nil, userID, req.IdempotencyKey)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		// The tip is not recorded, so the consumer must not be charged for it
+		s.releaseTip(ctx, provider, jobID, charge.ID)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, ErrAlreadyTipped
+		}
+		return nil, fmt.Errorf("failed to record tip: %w", err)
+	}
+
+	// 5. Get full transaction details
+	transaction, err := s.getTransaction(transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	return tipResponse(transaction, false), nil
+}
+
+// releaseTip refunds a tip charge that could not be recorded
+func (s *PaymentService) releaseTip(ctx context.Context, provider Provider, jobID int, chargeID string) {
+	if _, err := provider.Refund(context.WithoutCancel(ctx), chargeID, nil, "tip not recorded"); err != nil {
+		slog.ErrorContext(ctx, "Failed to release unrecorded tip charge", "job_id", jobID, "charge_id", chargeID, "error", err)
+	}
+}
+
+func tipResponse(transaction *model.EnhancedTransaction, replayed bool) *model.JobTipResponse {
+	return &model.JobTipResponse{
+		Success:       true,
+		TransactionID: transaction.ID,
+		Transaction:   transaction,
+		Message:       "Tip sent. The worker receives all of it with their next payout.",
+		Replayed:      replayed,
+	}
+}
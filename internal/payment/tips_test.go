@@ -0,0 +1,35 @@
+package payment
+
+import (
+	"errors"
+	"testing"
+
+	"app/internal/model"
+)
+
+func TestCheckTip(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		amount  int64
+		wantErr error
+	}{
+		{name: "completed job", status: "completed", amount: 500},
+		{name: "paid job", status: "paid", amount: 1},
+		{name: "closed job at the cap", status: "closed", amount: MaxTip.Cents},
+		{name: "job in progress", status: "in_progress", amount: 500, wantErr: ErrJobNotTippable},
+		{name: "cancelled job", status: "cancelled", amount: 500, wantErr: ErrJobNotTippable},
+		{name: "zero tip", status: "completed", amount: 0, wantErr: ErrInvalidTip},
+		{name: "negative tip", status: "completed", amount: -100, wantErr: ErrInvalidTip},
+		{name: "over the cap", status: "review_pending", amount: MaxTip.Cents + 1, wantErr: ErrInvalidTip},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkTip(tt.status, model.USD(tt.amount))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkTip(%q, %d) = %v, want %v", tt.status, tt.amount, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package payment
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"app/internal/tracing"
+)
+
+var tracer = tracing.Tracer("app/internal/payment")
+
+// startProviderSpan starts a client span for one call to a payment provider's API,
+// named like "clover.capture"
+func startProviderSpan(ctx context.Context, provider, operation string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, provider+"."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("payment.provider", provider),
+			attribute.String("payment.operation", operation),
+		),
+	)
+}
+
+// endProviderSpan records the provider's response status, marking the span failed for
+// transport errors and error statuses. The caller still ends the span.
+func endProviderSpan(span trace.Span, status int, err error) {
+	if status > 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+	}
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case status >= 400:
+		span.SetStatus(codes.Error, "provider returned an error status")
+	}
+}
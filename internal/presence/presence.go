@@ -0,0 +1,59 @@
+// Package presence tracks which gig workers have the app open. While in the
+// foreground the worker app sends a heartbeat every HeartbeatInterval; each one stamps
+// the worker's last_seen_at, which expires after OnlineTTL. Presence is kept in the
+// database rather than in process memory so every API instance and the Temporal
+// worker, which matches jobs, see the same state.
+package presence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const (
+	// HeartbeatInterval is how often the app sends a heartbeat
+	HeartbeatInterval = 2 * time.Minute
+	// OnlineTTL is how long a heartbeat keeps a worker online, allowing for a missed one
+	OnlineTTL = 5 * time.Minute
+	// RecentTTL is how long after their last heartbeat a worker counts as recently active
+	RecentTTL = time.Hour
+)
+
+// Status is a worker's presence
+type Status string
+
+const (
+	Online         Status = "online"
+	RecentlyActive Status = "recently_active"
+	Offline        Status = "offline"
+)
+
+// StatusAt is the presence at now of a worker last seen at lastSeen, nil when never
+func StatusAt(lastSeen *time.Time, now time.Time) Status {
+	if lastSeen == nil {
+		return Offline
+	}
+	switch since := now.Sub(*lastSeen); {
+	case since < OnlineTTL:
+		return Online
+	case since < RecentTTL:
+		return RecentlyActive
+	}
+	return Offline
+}
+
+// Record stamps a heartbeat from workerID at the given time. Heartbeats arriving out
+// of order never move last_seen_at back.
+func Record(ctx context.Context, db *sql.DB, workerID int, at time.Time) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO worker_profiles (worker_id, last_seen_at) VALUES ($1, $2)
+		ON CONFLICT (worker_id) DO UPDATE
+		SET last_seen_at = GREATEST(worker_profiles.last_seen_at, EXCLUDED.last_seen_at)
+	`, workerID, at)
+	if err != nil {
+		return fmt.Errorf("failed to record heartbeat: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package presence
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatusAt(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) *time.Time {
+		at := now.Add(-d)
+		return &at
+	}
+
+	tests := []struct {
+		name     string
+		lastSeen *time.Time
+		want     Status
+	}{
+		{name: "never seen", want: Offline},
+		{name: "just now", lastSeen: ago(0), want: Online},
+		{name: "one missed heartbeat", lastSeen: ago(2*HeartbeatInterval + time.Second), want: Online},
+		{name: "heartbeat expired", lastSeen: ago(OnlineTTL), want: RecentlyActive},
+		{name: "within the hour", lastSeen: ago(59 * time.Minute), want: RecentlyActive},
+		{name: "over an hour ago", lastSeen: ago(RecentTTL), want: Offline},
+		{name: "clock ahead of the server", lastSeen: ago(-time.Minute), want: Online},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StatusAt(tt.lastSeen, now); got != tt.want {
+				t.Errorf("StatusAt() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
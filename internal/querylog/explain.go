@@ -0,0 +1,126 @@
+package querylog
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"app/internal/model"
+)
+
+// explainTimeout bounds a plan capture, which runs the slow query again
+const explainTimeout = 30 * time.Second
+
+// explainer captures plans one at a time over a connection outside the instrumented
+// pool, so neither the capture nor its insert is timed or explained in turn
+type explainer struct {
+	db   *sql.DB
+	busy chan struct{}
+}
+
+func newExplainer(db *sql.DB) *explainer {
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxIdleTime(5 * time.Minute)
+	return &explainer{db: db, busy: make(chan struct{}, 1)}
+}
+
+// capture runs EXPLAIN ANALYZE for query in the background and stores its plan. A
+// capture already running means this one is dropped; the query will be due again.
+func (e *explainer) capture(o observation, d time.Duration, query string, args []any) {
+	select {
+	case e.busy <- struct{}{}:
+	default:
+		return
+	}
+	go func() {
+		defer func() { <-e.busy }()
+		ctx, cancel := context.WithTimeout(context.Background(), explainTimeout)
+		defer cancel()
+
+		plan, err := e.explain(ctx, query, args)
+		if err != nil {
+			slog.Warn("Failed to capture query plan", "fingerprint", o.fingerprint, "error", err)
+			return
+		}
+		_, err = e.db.ExecContext(ctx, `
+			INSERT INTO query_diagnostics (fingerprint, query, duration_ms, slow_count, plan)
+			VALUES ($1, $2, $3, $4, $5)
+		`, o.fingerprint, o.normalized, milliseconds(d), o.slowCount, plan)
+		if err != nil {
+			slog.Warn("Failed to store query plan", "fingerprint", o.fingerprint, "error", err)
+			return
+		}
+		slog.Info("Captured query plan", "fingerprint", o.fingerprint, "slow_count", o.slowCount)
+	}()
+}
+
+// explain runs query under EXPLAIN ANALYZE with its original parameters, in a
+// read-only transaction that is rolled back. String literals in the plan, which may
+// be parameter values, are replaced by ?.
+func (e *explainer) explain(ctx context.Context, query string, args []any) (string, error) {
+	tx, err := e.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", explainTimeout.Milliseconds())); err != nil {
+		return "", err
+	}
+	rows, err := tx.QueryContext(ctx, "EXPLAIN (ANALYZE, BUFFERS) "+query, args...)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var lines []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return "", err
+		}
+		lines = append(lines, stringLiteral.ReplaceAllString(line, "?"))
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// ListDiagnostics returns a page of captured plans, newest first, and their total
+// count. A non-empty fingerprint returns only that query's plans.
+func ListDiagnostics(ctx context.Context, db *sql.DB, fingerprint string, limit, offset int) ([]model.QueryDiagnostic, int, error) {
+	var total int
+	err := db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM query_diagnostics WHERE ($1::text = '' OR fingerprint = $1::text)
+	`, fingerprint).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count query diagnostics: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, fingerprint, query, duration_ms, slow_count, plan, captured_at
+		FROM query_diagnostics
+		WHERE ($1::text = '' OR fingerprint = $1::text)
+		ORDER BY captured_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`, fingerprint, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query query diagnostics: %w", err)
+	}
+	defer rows.Close()
+
+	list := []model.QueryDiagnostic{}
+	for rows.Next() {
+		var q model.QueryDiagnostic
+		if err := rows.Scan(&q.ID, &q.Fingerprint, &q.Query, &q.DurationMS, &q.SlowCount, &q.Plan, &q.CapturedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan query diagnostic: %w", err)
+		}
+		list = append(list, q)
+	}
+	return list, total, rows.Err()
+}
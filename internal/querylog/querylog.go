@@ -0,0 +1,239 @@
+// Package querylog times every database query, logs the slow ones and, when enabled,
+// captures EXPLAIN ANALYZE plans for queries that are slow again and again into the
+// query_diagnostics table for the on-call engineer. Bind parameters are never logged
+// or stored: a slow query is logged with its text, literals replaced by ?, and the
+// types of its parameters.
+//
+// DB_SLOW_QUERY_THRESHOLD (a Go duration, default 500ms, 0 to turn off) is how long a
+// query may take before it is logged. DB_EXPLAIN_SLOW_QUERIES=true turns on plan
+// capture for a read-only query once it has been slow DB_EXPLAIN_AFTER times (default
+// 3), at most once every DB_EXPLAIN_INTERVAL (default 1h) per query.
+package querylog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"app/internal/model"
+)
+
+// maxTracked caps the distinct queries whose statistics are kept, so SQL built with
+// inlined values cannot grow the table without bound. Later queries are still logged.
+const maxTracked = 2000
+
+// Config configures query instrumentation
+type Config struct {
+	SlowThreshold   time.Duration // Queries taking longer are logged; 0 logs none
+	Explain         bool          // Capture plans of repeatedly slow queries
+	ExplainAfter    int           // Slow executions of a query before its plan is captured
+	ExplainInterval time.Duration // Minimum time between captures of one query's plan
+}
+
+// LoadConfig reads the configuration from environment variables
+func LoadConfig() Config {
+	cfg := Config{
+		SlowThreshold:   500 * time.Millisecond,
+		Explain:         os.Getenv("DB_EXPLAIN_SLOW_QUERIES") == "true",
+		ExplainAfter:    3,
+		ExplainInterval: time.Hour,
+	}
+	if d, err := time.ParseDuration(os.Getenv("DB_SLOW_QUERY_THRESHOLD")); err == nil && d >= 0 {
+		cfg.SlowThreshold = d
+	}
+	if n, err := strconv.Atoi(os.Getenv("DB_EXPLAIN_AFTER")); err == nil && n > 0 {
+		cfg.ExplainAfter = n
+	}
+	if d, err := time.ParseDuration(os.Getenv("DB_EXPLAIN_INTERVAL")); err == nil && d > 0 {
+		cfg.ExplainInterval = d
+	}
+	return cfg
+}
+
+var (
+	stringLiteral  = regexp.MustCompile(`'(?:[^']|'')*'`)
+	numericLiteral = regexp.MustCompile(`(^|[^\w$.])\d+(?:\.\d+)?\b`)
+	whitespace     = regexp.MustCompile(`\s+`)
+)
+
+// Normalize returns query with its whitespace collapsed and its string and numeric
+// literals replaced by ?, so queries differing only in inlined values read the same
+// and no value reaches a log
+func Normalize(query string) string {
+	query = stringLiteral.ReplaceAllString(query, "?")
+	query = numericLiteral.ReplaceAllString(query, "${1}?")
+	return strings.TrimSpace(whitespace.ReplaceAllString(query, " "))
+}
+
+// Fingerprint identifies a normalized query
+func Fingerprint(normalized string) string {
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:8])
+}
+
+// queryStats is the running statistics of one normalized query
+type queryStats struct {
+	query        string
+	calls        int64
+	errors       int64
+	slow         int64
+	total        time.Duration
+	max          time.Duration
+	lastExplain  time.Time
+	lastSlowTime time.Time
+}
+
+// recorder holds the statistics of every query this process has made
+type recorder struct {
+	mu      sync.Mutex
+	queries map[string]*queryStats
+	now     func() time.Time
+}
+
+// stats is shared by every instrumented pool in the process
+var stats = &recorder{queries: map[string]*queryStats{}, now: time.Now}
+
+// observation is what record learned about one execution
+type observation struct {
+	fingerprint string
+	normalized  string
+	slow        bool
+	slowCount   int64
+	explain     bool // the query is due for a plan capture
+}
+
+// record adds one execution of query taking d. A slow execution of a read-only query
+// is due for a plan capture once the query has been slow cfg.ExplainAfter times and
+// its plan was not captured in the last cfg.ExplainInterval.
+func (r *recorder) record(cfg Config, query string, d time.Duration, failed bool) observation {
+	normalized := Normalize(query)
+	o := observation{fingerprint: Fingerprint(normalized), normalized: normalized}
+	o.slow = cfg.SlowThreshold > 0 && d >= cfg.SlowThreshold
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.queries[o.fingerprint]
+	if !ok {
+		if len(r.queries) >= maxTracked {
+			return o
+		}
+		s = &queryStats{query: normalized}
+		r.queries[o.fingerprint] = s
+	}
+	s.calls++
+	s.total += d
+	s.max = max(s.max, d)
+	if failed {
+		s.errors++
+	}
+	if !o.slow {
+		return o
+	}
+
+	now := r.now()
+	s.slow++
+	s.lastSlowTime = now
+	o.slowCount = s.slow
+	if cfg.Explain && !failed && explainable(normalized) && s.slow >= int64(cfg.ExplainAfter) && now.Sub(s.lastExplain) >= cfg.ExplainInterval {
+		s.lastExplain = now
+		o.explain = true
+	}
+	return o
+}
+
+// top returns the statistics of up to limit queries, the most total time first
+func (r *recorder) top(limit int) []model.QueryStats {
+	r.mu.Lock()
+	list := make([]model.QueryStats, 0, len(r.queries))
+	for fingerprint, s := range r.queries {
+		q := model.QueryStats{
+			Fingerprint: fingerprint,
+			Query:       s.query,
+			Calls:       s.calls,
+			Errors:      s.errors,
+			SlowCalls:   s.slow,
+			TotalMS:     milliseconds(s.total),
+			MeanMS:      milliseconds(s.total / time.Duration(s.calls)),
+			MaxMS:       milliseconds(s.max),
+		}
+		if !s.lastSlowTime.IsZero() {
+			t := s.lastSlowTime
+			q.LastSlowAt = &t
+		}
+		list = append(list, q)
+	}
+	r.mu.Unlock()
+
+	slices.SortFunc(list, func(a, b model.QueryStats) int {
+		if a.TotalMS != b.TotalMS {
+			if a.TotalMS > b.TotalMS {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Fingerprint, b.Fingerprint)
+	})
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	return list
+}
+
+// TopQueries returns this process's statistics for up to limit queries, those that
+// have taken the most time in total first. Statistics start afresh with each process.
+func TopQueries(limit int) []model.QueryStats {
+	return stats.top(limit)
+}
+
+// explainable reports whether a normalized query only reads, so running it again under
+// EXPLAIN ANALYZE changes nothing. Plans are captured in a read-only transaction as
+// well, which rejects a data-modifying WITH.
+func explainable(normalized string) bool {
+	keyword, _, _ := strings.Cut(strings.TrimLeft(normalized, "( "), " ")
+	switch strings.ToUpper(keyword) {
+	case "SELECT", "WITH":
+		return !strings.Contains(strings.ToUpper(normalized), " FOR UPDATE")
+	}
+	return false
+}
+
+// paramTypes describes bind parameters by their Go types only
+func paramTypes(args []any) []string {
+	types := make([]string, len(args))
+	for i, arg := range args {
+		if arg == nil {
+			types[i] = "null"
+			continue
+		}
+		types[i] = fmt.Sprintf("%T", arg)
+	}
+	return types
+}
+
+// logSlow logs a slow execution without its parameter values
+func logSlow(ctx context.Context, o observation, d time.Duration, args []any, err error) {
+	attrs := []any{
+		"fingerprint", o.fingerprint,
+		"duration_ms", milliseconds(d),
+		"query", o.normalized,
+		"params", paramTypes(args),
+		"slow_count", o.slowCount,
+	}
+	if err != nil {
+		attrs = append(attrs, "error", err)
+	}
+	slog.WarnContext(ctx, "Slow database query", attrs...)
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,143 @@
+package querylog
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		want        string
+		explainable bool
+	}{
+		{
+			name:        "placeholders kept",
+			query:       "SELECT id FROM jobs\n\t\tWHERE consumer_id = $1 AND status = $2",
+			want:        "SELECT id FROM jobs WHERE consumer_id = $1 AND status = $2",
+			explainable: true,
+		},
+		{
+			name:        "literals redacted",
+			query:       "SELECT * FROM people WHERE email = 'sam@example.com' AND id > 42 AND rating >= 4.5 LIMIT 10",
+			want:        "SELECT * FROM people WHERE email = ? AND id > ? AND rating >= ? LIMIT ?",
+			explainable: true,
+		},
+		{
+			name:  "quotes inside a literal",
+			query: "UPDATE jobs SET notes = 'it''s done' WHERE id = $1",
+			want:  "UPDATE jobs SET notes = ? WHERE id = $1",
+		},
+		{
+			name:        "identifiers with digits kept",
+			query:       "WITH t1 AS (SELECT amount_v2 FROM ledger_2026) SELECT * FROM t1",
+			want:        "WITH t1 AS (SELECT amount_v2 FROM ledger_2026) SELECT * FROM t1",
+			explainable: true,
+		},
+		{
+			name:  "locking read not explained",
+			query: "SELECT id FROM payments WHERE id = $1 FOR UPDATE",
+			want:  "SELECT id FROM payments WHERE id = $1 FOR UPDATE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Normalize(tt.query)
+			if got != tt.want {
+				t.Errorf("Normalize() = %q; want %q", got, tt.want)
+			}
+			if explainable(got) != tt.explainable {
+				t.Errorf("explainable(%q) = %v; want %v", got, !tt.explainable, tt.explainable)
+			}
+		})
+	}
+}
+
+func TestRecorderExplain(t *testing.T) {
+	cfg := Config{SlowThreshold: 100 * time.Millisecond, Explain: true, ExplainAfter: 2, ExplainInterval: time.Hour}
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	query := "SELECT * FROM jobs WHERE status = $1"
+
+	tests := []struct {
+		name        string
+		at          time.Duration // after start
+		duration    time.Duration
+		wantSlow    bool
+		wantExplain bool
+	}{
+		{name: "fast", duration: 10 * time.Millisecond},
+		{name: "first slow", at: time.Minute, duration: 300 * time.Millisecond, wantSlow: true},
+		{name: "second slow captures", at: 2 * time.Minute, duration: 300 * time.Millisecond, wantSlow: true, wantExplain: true},
+		{name: "within the interval", at: 30 * time.Minute, duration: 300 * time.Millisecond, wantSlow: true},
+		{name: "after the interval", at: 63 * time.Minute, duration: 300 * time.Millisecond, wantSlow: true, wantExplain: true},
+	}
+
+	r := &recorder{queries: map[string]*queryStats{}}
+	for _, tt := range tests {
+		r.now = func() time.Time { return start.Add(tt.at) }
+		o := r.record(cfg, query, tt.duration, false)
+		if o.slow != tt.wantSlow || o.explain != tt.wantExplain {
+			t.Errorf("%s: record() = slow %v, explain %v; want %v, %v", tt.name, o.slow, o.explain, tt.wantSlow, tt.wantExplain)
+		}
+	}
+
+	top := r.top(10)
+	if len(top) != 1 || top[0].Calls != 5 || top[0].SlowCalls != 4 || top[0].MaxMS != 300 {
+		t.Errorf("top() = %+v; want one query with 5 calls, 4 slow, max 300ms", top)
+	}
+}
+
+// fakeConnector serves one row per query and sleeps before closing its rows
+type fakeConnector struct{ delay time.Duration }
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn(c), nil }
+func (c fakeConnector) Driver() driver.Driver                        { return nil }
+
+type fakeConn fakeConnector
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c fakeConn) Close() error                        { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+func (c fakeConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &fakeRows{delay: c.delay}, nil
+}
+
+type fakeRows struct {
+	delay time.Duration
+	read  bool
+}
+
+func (r *fakeRows) Columns() []string { return []string{"n"} }
+func (r *fakeRows) Close() error      { time.Sleep(r.delay); return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.read {
+		return io.EOF
+	}
+	r.read = true
+	dest[0] = int64(1)
+	return nil
+}
+
+func TestConnectorTimesRows(t *testing.T) {
+	stats = &recorder{queries: map[string]*queryStats{}, now: time.Now}
+	db := sql.OpenDB(NewConnector(fakeConnector{delay: 20 * time.Millisecond}, Config{SlowThreshold: 10 * time.Millisecond}))
+	defer db.Close()
+
+	var n int
+	if err := db.QueryRowContext(t.Context(), "SELECT n FROM numbers WHERE n = $1", 1).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	top := TopQueries(10)
+	if len(top) != 1 || top[0].Calls != 1 || top[0].SlowCalls != 1 {
+		t.Fatalf("TopQueries() = %+v; want one slow call, timed until its rows closed", top)
+	}
+	if top[0].Query != "SELECT n FROM numbers WHERE n = $1" {
+		t.Errorf("Query = %q", top[0].Query)
+	}
+}
@@ -0,0 +1,87 @@
+// Package realtime pushes job and payment events to connected clients. Events are
+// published with PostgreSQL NOTIFY, so the Temporal worker and every API replica can
+// publish, and each API replica's Hub delivers them to its own WebSocket clients.
+package realtime
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"app/internal/clock"
+)
+
+// Channel is the PostgreSQL NOTIFY channel events are published on
+const Channel = "gigco_realtime"
+
+// Event types
+const (
+	EventJobStatusChanged = "job.status_changed"
+	EventJobOffer         = "job.offer"
+	EventPayment          = "payment"
+	EventHeartbeat        = "heartbeat" // Sent by the server to keep idle connections open
+)
+
+// Event is a message pushed to clients. ID is unique per published event; long-poll
+// clients pass the last one they saw to get the events after it.
+type Event struct {
+	ID        string                 `json:"id,omitempty"`
+	Type      string                 `json:"type"`
+	JobID     int                    `json:"job_id,omitempty"`
+	Status    string                 `json:"status,omitempty"`
+	Amount    *float64               `json:"amount,omitempty"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+}
+
+// envelope is the NOTIFY payload: the event and the users it is delivered to
+type envelope struct {
+	UserIDs []int `json:"user_ids"`
+	Event   Event `json:"event"`
+}
+
+// Publish sends an event to the given users' connected clients. An event without a
+// timestamp is stamped from clk.
+func Publish(ctx context.Context, db *sql.DB, clk clock.Clock, userIDs []int, event Event) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = clk.Now().UTC()
+	}
+	if event.ID == "" {
+		event.ID = clock.UUIDs.NewID()
+	}
+
+	payload, err := json.Marshal(envelope{UserIDs: userIDs, Event: event})
+	if err != nil {
+		return fmt.Errorf("failed to marshal realtime event: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, Channel, string(payload)); err != nil {
+		return fmt.Errorf("failed to publish realtime event: %w", err)
+	}
+	return nil
+}
+
+// PublishJobEvent sends an event about a job to its consumer and assigned worker
+func PublishJobEvent(ctx context.Context, db *sql.DB, clk clock.Clock, event Event) error {
+	var consumerID int
+	var workerID sql.NullInt64
+	err := db.QueryRowContext(ctx, `SELECT consumer_id, gig_worker_id FROM jobs WHERE id = $1`, event.JobID).Scan(&consumerID, &workerID)
+	if err != nil {
+		return fmt.Errorf("failed to load job %d participants: %w", event.JobID, err)
+	}
+
+	userIDs := []int{consumerID}
+	if workerID.Valid {
+		userIDs = append(userIDs, int(workerID.Int64))
+	}
+	return Publish(ctx, db, clk, userIDs, event)
+}
+
+// PublishJobStatus sends a job's new status to its consumer and assigned worker
+func PublishJobStatus(ctx context.Context, db *sql.DB, clk clock.Clock, jobID int, status string) error {
+	return PublishJobEvent(ctx, db, clk, Event{Type: EventJobStatusChanged, JobID: jobID, Status: status})
+}
@@ -0,0 +1,236 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// clientBuffer is how many events a client may fall behind before it is dropped
+const clientBuffer = 32
+
+// Long-poll clients catch up from a backlog of each user's most recent events: at most
+// backlogSize of them, for backlogTTL. Every API replica receives the same events in
+// the same order, so an event ID from one replica's poll is found in another's backlog.
+const (
+	backlogSize = 32
+	backlogTTL  = 2 * time.Minute
+)
+
+// Client is a connected user's event stream. Events is closed when the hub drops
+// the client.
+type Client struct {
+	UserID int
+	Events <-chan Event
+	events chan Event
+}
+
+// Hub tracks connected clients and delivers events to them
+type Hub struct {
+	mu      sync.Mutex
+	clients map[int]map[*Client]struct{}
+	backlog map[int][]backlogEvent
+	now     func() time.Time
+}
+
+// backlogEvent is an event in a user's backlog and when the hub received it
+type backlogEvent struct {
+	event      Event
+	receivedAt time.Time
+}
+
+// NewHub creates an empty hub
+func NewHub() *Hub {
+	return &Hub{
+		clients: make(map[int]map[*Client]struct{}),
+		backlog: make(map[int][]backlogEvent),
+		now:     time.Now,
+	}
+}
+
+// Subscribe registers a client for the user's events
+func (h *Hub) Subscribe(userID int) *Client {
+	events := make(chan Event, clientBuffer)
+	c := &Client{UserID: userID, Events: events, events: events}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.clients[userID] == nil {
+		h.clients[userID] = make(map[*Client]struct{})
+	}
+	h.clients[userID][c] = struct{}{}
+	return c
+}
+
+// Unsubscribe removes a client. It is safe to call after the hub dropped it.
+func (h *Hub) Unsubscribe(c *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(c)
+}
+
+// remove deletes a client and closes its events; h.mu must be held
+func (h *Hub) remove(c *Client) {
+	clients, ok := h.clients[c.UserID]
+	if !ok {
+		return
+	}
+	if _, ok := clients[c]; !ok {
+		return
+	}
+	delete(clients, c)
+	close(c.events)
+	if len(clients) == 0 {
+		delete(h.clients, c.UserID)
+	}
+}
+
+// Deliver sends an event to every client of the given users and adds it to their
+// backlogs. A client whose buffer is full is dropped rather than blocking delivery to
+// the others; it reconnects and refetches the job.
+func (h *Hub) Deliver(userIDs []int, event Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	for _, userID := range userIDs {
+		backlog := append(h.liveBacklog(userID, now), backlogEvent{event: event, receivedAt: now})
+		if len(backlog) > backlogSize {
+			backlog = backlog[len(backlog)-backlogSize:]
+		}
+		h.backlog[userID] = backlog
+
+		for c := range h.clients[userID] {
+			select {
+			case c.events <- event:
+			default:
+				slog.Warn("Realtime client is too slow, dropping it", "user_id", userID)
+				h.remove(c)
+			}
+		}
+	}
+}
+
+// After returns the user's events after the one with ID afterID, oldest first, or their
+// whole backlog when afterID is empty. ok is false when afterID is no longer in the
+// backlog; the client missed events and should refetch its jobs.
+func (h *Hub) After(userID int, afterID string) (events []Event, ok bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	backlog := h.liveBacklog(userID, h.now())
+	start := 0
+	if afterID != "" {
+		start = -1
+		for i, b := range backlog {
+			if b.event.ID == afterID {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			return nil, false
+		}
+	}
+	for _, b := range backlog[start:] {
+		events = append(events, b.event)
+	}
+	return events, true
+}
+
+// LatestID returns the ID of the user's most recent event in the backlog, or "" when
+// there is none
+func (h *Hub) LatestID(userID int) string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	backlog := h.liveBacklog(userID, h.now())
+	if len(backlog) == 0 {
+		return ""
+	}
+	return backlog[len(backlog)-1].event.ID
+}
+
+// liveBacklog drops the user's expired events and returns the rest; h.mu must be held
+func (h *Hub) liveBacklog(userID int, now time.Time) []backlogEvent {
+	backlog := h.backlog[userID]
+	expired := 0
+	for expired < len(backlog) && now.Sub(backlog[expired].receivedAt) > backlogTTL {
+		expired++
+	}
+	if expired == len(backlog) {
+		delete(h.backlog, userID)
+		return nil
+	}
+	backlog = backlog[expired:]
+	h.backlog[userID] = backlog
+	return backlog
+}
+
+// pruneBacklogs drops expired events of users who have had no new ones
+func (h *Hub) pruneBacklogs() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	for userID := range h.backlog {
+		h.liveBacklog(userID, now)
+	}
+}
+
+// Connections returns how many clients are connected
+func (h *Hub) Connections() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	total := 0
+	for _, clients := range h.clients {
+		total += len(clients)
+	}
+	return total
+}
+
+// Listen delivers events published on Channel until ctx is cancelled. The listener
+// reconnects on its own; events published while it is disconnected are lost.
+func (h *Hub) Listen(ctx context.Context, connStr string) error {
+	listener := pq.NewListener(connStr, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			slog.ErrorContext(ctx, "Realtime listener error", "error", err)
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(Channel); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "Realtime listener subscribed", "channel", Channel)
+
+	prune := time.NewTicker(backlogTTL)
+	defer prune.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-listener.Notify:
+			if notification == nil {
+				// Reconnected; anything published in between was missed
+				continue
+			}
+			var env envelope
+			if err := json.Unmarshal([]byte(notification.Extra), &env); err != nil {
+				slog.ErrorContext(ctx, "Invalid realtime event payload", "error", err)
+				continue
+			}
+			h.Deliver(env.UserIDs, env.Event)
+		case <-prune.C:
+			h.pruneBacklogs()
+		case <-time.After(90 * time.Second):
+			go listener.Ping()
+		}
+	}
+}
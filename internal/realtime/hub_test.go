@@ -0,0 +1,105 @@
+package realtime
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestHubDeliver(t *testing.T) {
+	hub := NewHub()
+	consumer := hub.Subscribe(1)
+	worker := hub.Subscribe(2)
+	other := hub.Subscribe(3)
+
+	hub.Deliver([]int{1, 2}, Event{Type: EventJobStatusChanged, JobID: 7, Status: "scheduled"})
+
+	for _, c := range []*Client{consumer, worker} {
+		select {
+		case event := <-c.Events:
+			if event.JobID != 7 || event.Status != "scheduled" {
+				t.Errorf("user %d got %+v, want job 7 scheduled", c.UserID, event)
+			}
+		default:
+			t.Errorf("user %d got no event", c.UserID)
+		}
+	}
+	select {
+	case event := <-other.Events:
+		t.Errorf("user 3 got %+v, want nothing", event)
+	default:
+	}
+
+	hub.Unsubscribe(consumer)
+	hub.Unsubscribe(consumer) // already removed
+	if _, open := <-consumer.Events; open {
+		t.Error("Events still open after Unsubscribe")
+	}
+	if got := hub.Connections(); got != 2 {
+		t.Errorf("Connections() = %d, want 2", got)
+	}
+}
+
+func TestHubDropsSlowClient(t *testing.T) {
+	hub := NewHub()
+	slow := hub.Subscribe(1)
+
+	for i := 0; i <= clientBuffer; i++ {
+		hub.Deliver([]int{1}, Event{Type: EventJobStatusChanged, JobID: i})
+	}
+
+	received := 0
+	for range slow.Events {
+		received++
+	}
+	if received != clientBuffer {
+		t.Errorf("slow client received %d events before being dropped, want %d", received, clientBuffer)
+	}
+	if got := hub.Connections(); got != 0 {
+		t.Errorf("Connections() = %d, want 0", got)
+	}
+}
+
+func TestHubAfter(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	hub := NewHub()
+	hub.now = func() time.Time { return now }
+
+	hub.Deliver([]int{1}, Event{ID: "a", Type: EventJobOffer})
+	now = now.Add(time.Minute)
+	hub.Deliver([]int{1, 2}, Event{ID: "b", Type: EventJobStatusChanged})
+	hub.Deliver([]int{1}, Event{ID: "c", Type: EventPayment})
+
+	tests := []struct {
+		name    string
+		userID  int
+		afterID string
+		advance time.Duration
+		want    []string
+		wantOK  bool
+	}{
+		{name: "whole backlog", userID: 1, want: []string{"a", "b", "c"}, wantOK: true},
+		{name: "after an event", userID: 1, afterID: "a", want: []string{"b", "c"}, wantOK: true},
+		{name: "caught up", userID: 1, afterID: "c", wantOK: true},
+		{name: "other user's backlog", userID: 2, afterID: "a"},
+		{name: "unknown event", userID: 1, afterID: "z"},
+		{name: "expired event", userID: 1, afterID: "a", advance: backlogTTL + 30*time.Second},
+		{name: "everything expired", userID: 1, advance: backlogTTL + 2*time.Minute, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.advance)
+			defer func() { now = now.Add(-tt.advance) }()
+
+			events, ok := hub.After(tt.userID, tt.afterID)
+			var got []string
+			for _, e := range events {
+				got = append(got, e.ID)
+			}
+			if ok != tt.wantOK || !slices.Equal(got, tt.want) {
+				t.Errorf("After(%d, %q) = %v, %v, want %v, %v", tt.userID, tt.afterID, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package recurrence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// OpenEndedHorizon is how far ahead a recurrence without recurring_until is checked
+// for conflicts
+const OpenEndedHorizon = 365 * 24 * time.Hour
+
+// Conflict is the first occurrence of a booked schedule that overlaps a proposed slot
+type Conflict struct {
+	ScheduleID int     `json:"schedule_id"`
+	Title      *string `json:"title,omitempty"`
+	JobID      *int    `json:"job_id,omitempty"`
+	Slot
+}
+
+// Proposal is a slot being booked for a worker, optionally repeated by Rule until Until
+type Proposal struct {
+	WorkerID int
+	Slot     Slot
+	Rule     *Rule
+	Until    *time.Time
+	// ExcludeJobID skips schedules already booked for this job
+	ExcludeJobID *int
+}
+
+// occurrences expands the proposal; open-ended recurrences stop at OpenEndedHorizon
+func (p Proposal) occurrences() []Slot {
+	if p.Rule == nil {
+		return []Slot{p.Slot}
+	}
+	return p.Rule.Occurrences(p.Slot, p.Until, p.Slot.Start, p.Slot.Start.Add(OpenEndedHorizon))
+}
+
+// FindConflicts returns the worker's booked schedules (unavailable or tied to a job)
+// with an occurrence overlapping any occurrence of the proposal, one conflict per
+// schedule. Stored patterns that no longer parse are treated as one-off slots.
+func FindConflicts(ctx context.Context, db *sql.DB, p Proposal) ([]Conflict, error) {
+	proposed := p.occurrences()
+	if len(proposed) == 0 {
+		return nil, nil
+	}
+	from, to := proposed[0].Start, proposed[len(proposed)-1].End
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, title, start_time, end_time, job_id, COALESCE(recurring_pattern, ''), recurring_until
+		FROM schedules
+		WHERE gig_worker_id = $1
+		  AND (is_available = false OR job_id IS NOT NULL)
+		  AND ($4::int IS NULL OR job_id IS DISTINCT FROM $4)
+		  AND start_time < $3
+		  AND (end_time > $2
+		       OR (COALESCE(recurring_pattern, '') <> ''
+		           AND (recurring_until IS NULL OR recurring_until + (end_time - start_time) > $2)))
+		ORDER BY start_time, id
+	`, p.WorkerID, from, to, p.ExcludeJobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query booked schedules: %w", err)
+	}
+	defer rows.Close()
+
+	var conflicts []Conflict
+	for rows.Next() {
+		var c Conflict
+		var first Slot
+		var pattern string
+		var until *time.Time
+		if err := rows.Scan(&c.ScheduleID, &c.Title, &first.Start, &first.End, &c.JobID, &pattern, &until); err != nil {
+			return nil, fmt.Errorf("failed to scan booked schedule: %w", err)
+		}
+
+		booked := []Slot{first}
+		if pattern != "" {
+			if rule, err := ParseRule(pattern); err == nil {
+				booked = rule.Occurrences(first, until, from, to)
+			}
+		}
+		if slot, ok := firstOverlap(proposed, booked); ok {
+			c.Slot = slot
+			conflicts = append(conflicts, c)
+		}
+	}
+	return conflicts, rows.Err()
+}
+
+// firstOverlap returns the first slot of booked that overlaps a slot of proposed.
+// Both lists are ordered by start and, being occurrences of one slot, by end too.
+func firstOverlap(proposed, booked []Slot) (Slot, bool) {
+	i, j := 0, 0
+	for i < len(proposed) && j < len(booked) {
+		if proposed[i].Overlaps(booked[j]) {
+			return booked[j], true
+		}
+		if proposed[i].End.After(booked[j].End) {
+			j++
+		} else {
+			i++
+		}
+	}
+	return Slot{}, false
+}
@@ -0,0 +1,196 @@
+// Package recurrence expands recurring worker schedules into occurrences and finds
+// conflicts between booked slots. Occurrences are computed on read from a schedule's
+// first slot, its recurring_pattern and recurring_until; nothing is materialized.
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recurrence frequencies
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+)
+
+// MaxOccurrences caps how many occurrences one expansion returns
+const MaxOccurrences = 1000
+
+// ErrInvalidPattern is returned for recurring patterns that cannot be parsed
+var ErrInvalidPattern = errors.New("invalid recurring pattern")
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// Rule is a parsed recurring pattern
+type Rule struct {
+	Freq     string
+	Interval int            // Repeat every Interval days, weeks or months
+	ByDay    []time.Weekday // Weekly rules only; empty repeats on the first slot's weekday
+}
+
+// ParseRule parses a recurring pattern. It accepts "daily", "weekly" and "monthly", or
+// an RRULE subset with FREQ (DAILY, WEEKLY or MONTHLY), INTERVAL and, for weekly
+// rules, BYDAY, e.g. "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE". End dates come from the
+// schedule's recurring_until, so UNTIL and COUNT are not supported.
+func ParseRule(pattern string) (Rule, error) {
+	pattern = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(pattern)), "RRULE:")
+	switch pattern {
+	case FreqDaily, FreqWeekly, FreqMonthly:
+		return Rule{Freq: pattern, Interval: 1}, nil
+	case "":
+		return Rule{}, fmt.Errorf("%w: empty", ErrInvalidPattern)
+	}
+
+	rule := Rule{Interval: 1}
+	for _, part := range strings.Split(pattern, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return Rule{}, fmt.Errorf("%w: %q is not KEY=VALUE", ErrInvalidPattern, part)
+		}
+		switch key {
+		case "FREQ":
+			if value != FreqDaily && value != FreqWeekly && value != FreqMonthly {
+				return Rule{}, fmt.Errorf("%w: FREQ must be DAILY, WEEKLY or MONTHLY", ErrInvalidPattern)
+			}
+			rule.Freq = value
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Rule{}, fmt.Errorf("%w: INTERVAL must be a positive number", ErrInvalidPattern)
+			}
+			rule.Interval = n
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				wd, ok := weekdays[day]
+				if !ok {
+					return Rule{}, fmt.Errorf("%w: unknown BYDAY %q", ErrInvalidPattern, day)
+				}
+				rule.ByDay = append(rule.ByDay, wd)
+			}
+		default:
+			return Rule{}, fmt.Errorf("%w: %s is not supported", ErrInvalidPattern, key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return Rule{}, fmt.Errorf("%w: FREQ is required", ErrInvalidPattern)
+	}
+	if len(rule.ByDay) > 0 && rule.Freq != FreqWeekly {
+		return Rule{}, fmt.Errorf("%w: BYDAY is only supported for weekly rules", ErrInvalidPattern)
+	}
+	return rule, nil
+}
+
+// Slot is a time range, e.g. one occurrence of a schedule
+type Slot struct {
+	Start time.Time `json:"start_time"`
+	End   time.Time `json:"end_time"`
+}
+
+// Overlaps reports whether two slots share any time; slots that only touch do not
+func (s Slot) Overlaps(o Slot) bool {
+	return s.Start.Before(o.End) && o.Start.Before(s.End)
+}
+
+// Occurrences returns the occurrences of first repeated by rule that overlap
+// [from, to), oldest first. Occurrences starting after until are not included; a nil
+// until repeats forever. At most MaxOccurrences are returned.
+func (r Rule) Occurrences(first Slot, until *time.Time, from, to time.Time) []Slot {
+	duration := first.End.Sub(first.Start)
+	var slots []Slot
+	add := func(start time.Time) bool {
+		if until != nil && start.After(*until) {
+			return false
+		}
+		if !start.Before(to) {
+			return false
+		}
+		slot := Slot{Start: start, End: start.Add(duration)}
+		if slot.Overlaps(Slot{Start: from, End: to}) {
+			slots = append(slots, slot)
+		}
+		return len(slots) < MaxOccurrences
+	}
+
+	// Skip whole periods that end before the window; one period of slack covers
+	// DST shifts and slots longer than a period
+	skip := 0
+	if gap := from.Sub(first.End); gap > 0 {
+		switch r.Freq {
+		case FreqDaily:
+			skip = int(gap/(24*time.Hour))/r.Interval - 1
+		case FreqWeekly:
+			skip = int(gap/(7*24*time.Hour))/r.Interval - 1
+		case FreqMonthly:
+			skip = int(gap/(31*24*time.Hour))/r.Interval - 1
+		}
+		if skip < 0 {
+			skip = 0
+		}
+	}
+
+	for k := skip; ; k++ {
+		switch r.Freq {
+		case FreqDaily:
+			if !add(first.Start.AddDate(0, 0, k*r.Interval)) {
+				return slots
+			}
+		case FreqWeekly:
+			weekStart := first.Start.AddDate(0, 0, 7*k*r.Interval)
+			if len(r.ByDay) == 0 {
+				if !add(weekStart) {
+					return slots
+				}
+				continue
+			}
+			// Days are counted from the first slot's weekday; earlier days in the
+			// first week are skipped, as RRULE does
+			for _, day := range sortedFrom(r.ByDay, first.Start.Weekday()) {
+				offset := (int(day) - int(first.Start.Weekday()) + 7) % 7
+				if !add(weekStart.AddDate(0, 0, offset)) {
+					return slots
+				}
+			}
+		case FreqMonthly:
+			// Months without the first slot's day are skipped rather than rolled over
+			month := time.Date(first.Start.Year(), first.Start.Month()+time.Month(k*r.Interval), 1,
+				first.Start.Hour(), first.Start.Minute(), first.Start.Second(), first.Start.Nanosecond(), first.Start.Location())
+			start := month.AddDate(0, 0, first.Start.Day()-1)
+			if start.Month() != month.Month() {
+				if until != nil && month.After(*until) || !month.Before(to) {
+					return slots
+				}
+				continue
+			}
+			if !add(start) {
+				return slots
+			}
+		default:
+			return slots
+		}
+	}
+}
+
+// sortedFrom orders weekdays as they fall in a week starting on start
+func sortedFrom(days []time.Weekday, start time.Weekday) []time.Weekday {
+	seen := make(map[time.Weekday]bool, len(days))
+	var sorted []time.Weekday
+	for i := 0; i < 7; i++ {
+		day := time.Weekday((int(start) + i) % 7)
+		for _, d := range days {
+			if d == day && !seen[d] {
+				seen[d] = true
+				sorted = append(sorted, d)
+			}
+		}
+	}
+	return sorted
+}
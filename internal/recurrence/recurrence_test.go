@@ -0,0 +1,145 @@
+package recurrence
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    Rule
+		wantErr bool
+	}{
+		{pattern: "weekly", want: Rule{Freq: FreqWeekly, Interval: 1}},
+		{pattern: " Daily ", want: Rule{Freq: FreqDaily, Interval: 1}},
+		{pattern: "RRULE:FREQ=MONTHLY;INTERVAL=2", want: Rule{Freq: FreqMonthly, Interval: 2}},
+		{pattern: "FREQ=WEEKLY;BYDAY=MO,WE", want: Rule{Freq: FreqWeekly, Interval: 1, ByDay: []time.Weekday{time.Monday, time.Wednesday}}},
+		{pattern: "", wantErr: true},
+		{pattern: "yearly", wantErr: true},
+		{pattern: "FREQ=HOURLY", wantErr: true},
+		{pattern: "INTERVAL=2", wantErr: true},
+		{pattern: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{pattern: "FREQ=DAILY;BYDAY=MO", wantErr: true},
+		{pattern: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+		{pattern: "FREQ=DAILY;COUNT=5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			got, err := ParseRule(tt.pattern)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPattern) {
+					t.Fatalf("ParseRule(%q) error = %v, want ErrInvalidPattern", tt.pattern, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRule(%q) unexpected error: %v", tt.pattern, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRule(%q) = %+v, want %+v", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	at := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	// Monday 5 January 2026, 9-11 AM
+	first := Slot{Start: at(time.January, 5, 9), End: at(time.January, 5, 11)}
+	starts := func(slots []Slot) []time.Time {
+		var out []time.Time
+		for _, s := range slots {
+			out = append(out, s.Start)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name     string
+		rule     Rule
+		first    Slot
+		until    *time.Time
+		from, to time.Time
+		want     []time.Time
+	}{
+		{
+			name:  "daily stops at until",
+			rule:  Rule{Freq: FreqDaily, Interval: 1},
+			first: first,
+			until: ptr(at(time.January, 7, 9)),
+			from:  at(time.January, 1, 0), to: at(time.February, 1, 0),
+			want: []time.Time{at(time.January, 5, 9), at(time.January, 6, 9), at(time.January, 7, 9)},
+		},
+		{
+			name:  "every other week within window",
+			rule:  Rule{Freq: FreqWeekly, Interval: 2},
+			first: first,
+			from:  at(time.March, 1, 0), to: at(time.March, 31, 0),
+			want: []time.Time{at(time.March, 2, 9), at(time.March, 16, 9), at(time.March, 30, 9)},
+		},
+		{
+			name:  "weekly by day skips earlier days of the first week",
+			rule:  Rule{Freq: FreqWeekly, Interval: 1, ByDay: []time.Weekday{time.Friday, time.Monday}},
+			first: Slot{Start: at(time.January, 7, 9), End: at(time.January, 7, 10)}, // Wednesday
+			from:  at(time.January, 1, 0), to: at(time.January, 20, 0),
+			want: []time.Time{at(time.January, 9, 9), at(time.January, 12, 9), at(time.January, 16, 9), at(time.January, 19, 9)},
+		},
+		{
+			name:  "monthly skips months without the day",
+			rule:  Rule{Freq: FreqMonthly, Interval: 1},
+			first: Slot{Start: at(time.January, 31, 9), End: at(time.January, 31, 10)},
+			from:  at(time.January, 1, 0), to: at(time.June, 1, 0),
+			want: []time.Time{at(time.January, 31, 9), at(time.March, 31, 9), at(time.May, 31, 9)},
+		},
+		{
+			name:  "occurrence in progress at window start is included",
+			rule:  Rule{Freq: FreqDaily, Interval: 1},
+			first: first,
+			from:  at(time.January, 10, 10), to: at(time.January, 11, 10),
+			want: []time.Time{at(time.January, 10, 9), at(time.January, 11, 9)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := starts(tt.rule.Occurrences(tt.first, tt.until, tt.from, tt.to))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Occurrences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirstOverlap(t *testing.T) {
+	hour := func(h int) time.Time { return time.Date(2026, time.January, 5, h, 0, 0, 0, time.UTC) }
+	slot := func(start, end int) Slot { return Slot{Start: hour(start), End: hour(end)} }
+
+	tests := []struct {
+		name     string
+		proposed []Slot
+		booked   []Slot
+		want     Slot
+		wantOK   bool
+	}{
+		{name: "touching slots do not conflict", proposed: []Slot{slot(9, 11)}, booked: []Slot{slot(7, 9), slot(11, 12)}},
+		{name: "overlap found", proposed: []Slot{slot(8, 9), slot(13, 15)}, booked: []Slot{slot(10, 11), slot(14, 16)}, want: slot(14, 16), wantOK: true},
+		{name: "booked slot inside proposed", proposed: []Slot{slot(8, 18)}, booked: []Slot{slot(6, 7), slot(12, 13)}, want: slot(12, 13), wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := firstOverlap(tt.proposed, tt.booked)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("firstOverlap() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
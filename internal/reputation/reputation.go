@@ -0,0 +1,141 @@
+// Package reputation signs the reputation documents users export to take their ratings
+// to other platforms. A signed document is an EdDSA (Ed25519) JWT, so third parties can
+// verify it with any JOSE library and the public key set the API publishes.
+package reputation
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"app/internal/model"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Issuer is the iss claim of every signed document
+const Issuer = "gigco-api"
+
+// Algorithm is the JWS algorithm documents are signed with
+const Algorithm = "EdDSA"
+
+// ErrNotConfigured is returned when REPUTATION_SIGNING_KEY is not set
+var ErrNotConfigured = errors.New("reputation signing key is not configured")
+
+// ErrInvalidSignature is returned for documents that were altered, signed with another
+// key or not issued by this API
+var ErrInvalidSignature = errors.New("reputation document signature is invalid")
+
+// Claims are the claims of a signed document. The subject is the user's UUID.
+type Claims struct {
+	Reputation model.ReputationDocument `json:"reputation"`
+	jwt.RegisteredClaims
+}
+
+// JWK is a public key in JSON Web Key form (RFC 8037)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+}
+
+// Signer signs reputation documents with an Ed25519 key
+type Signer struct {
+	key   ed25519.PrivateKey
+	keyID string
+}
+
+// NewSigner creates a signer for key. The key ID is derived from the public key, so
+// it stays the same across restarts and instances.
+func NewSigner(key ed25519.PrivateKey) *Signer {
+	sum := sha256.Sum256(key.Public().(ed25519.PublicKey))
+	return &Signer{key: key, keyID: hex.EncodeToString(sum[:8])}
+}
+
+// NewSignerFromEnv creates a signer from REPUTATION_SIGNING_KEY, a base64-encoded
+// 32-byte Ed25519 seed (or 64-byte private key)
+func NewSignerFromEnv() (*Signer, error) {
+	encoded := strings.TrimSpace(os.Getenv("REPUTATION_SIGNING_KEY"))
+	if encoded == "" {
+		return nil, ErrNotConfigured
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("REPUTATION_SIGNING_KEY is not valid base64: %w", err)
+	}
+	switch len(raw) {
+	case ed25519.SeedSize:
+		return NewSigner(ed25519.NewKeyFromSeed(raw)), nil
+	case ed25519.PrivateKeySize:
+		return NewSigner(ed25519.PrivateKey(raw)), nil
+	}
+	return nil, fmt.Errorf("REPUTATION_SIGNING_KEY must be a %d-byte seed or %d-byte private key, got %d bytes", ed25519.SeedSize, ed25519.PrivateKeySize, len(raw))
+}
+
+// KeyID identifies the signer's key in the kid header and the public key set
+func (s *Signer) KeyID() string {
+	return s.keyID
+}
+
+// PublicKey returns the key third parties verify documents with
+func (s *Signer) PublicKey() ed25519.PublicKey {
+	return s.key.Public().(ed25519.PublicKey)
+}
+
+// JWK returns the public key as a JSON Web Key
+func (s *Signer) JWK() JWK {
+	return JWK{
+		KeyType:   "OKP",
+		Curve:     "Ed25519",
+		X:         base64.RawURLEncoding.EncodeToString(s.PublicKey()),
+		KeyID:     s.keyID,
+		Use:       "sig",
+		Algorithm: Algorithm,
+	}
+}
+
+// Sign returns doc as a signed JWT about the user subjectUUID. Documents do not expire;
+// verifiers judge freshness from the iat claim.
+func (s *Signer) Sign(doc model.ReputationDocument, subjectUUID string) (string, error) {
+	claims := &Claims{
+		Reputation: doc,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:   Issuer,
+			Subject:  subjectUUID,
+			IssuedAt: jwt.NewNumericDate(doc.GeneratedAt),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = s.keyID
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign reputation document: %w", err)
+	}
+	return signed, nil
+}
+
+// Verify checks a signed document against publicKey and returns its claims
+func Verify(signed string, publicKey ed25519.PublicKey) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(signed, &Claims{}, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodEd25519); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return publicKey, nil
+	}, jwt.WithIssuer(Issuer), jwt.WithIssuedAt())
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, ErrInvalidSignature
+	}
+	return claims, nil
+}
@@ -0,0 +1,105 @@
+package reputation
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"app/internal/model"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	seed[0] = 1
+	signer := NewSigner(ed25519.NewKeyFromSeed(seed))
+	other := NewSigner(ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)))
+
+	doc := model.ReputationDocument{
+		Subject:     model.ReputationSubject{UUID: "uuid-42", Name: "Jane", Role: "gig_worker"},
+		Ratings:     model.ReputationRatings{Average: 4.5, Total: 2, Distribution: map[string]int{"4": 1, "5": 1}},
+		GeneratedAt: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+	}
+	signed, err := signer.Sign(doc, "uuid-42")
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	// Swap the payload for one claiming a perfect rating, keeping the signature
+	parts := strings.Split(signed, ".")
+	forged := doc
+	forged.Ratings.Average = 5
+	forgedToken, err := other.Sign(forged, "uuid-42")
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	tampered := parts[0] + "." + strings.Split(forgedToken, ".")[1] + "." + parts[2]
+
+	tests := []struct {
+		name    string
+		token   string
+		key     ed25519.PublicKey
+		wantErr bool
+	}{
+		{name: "signed document", token: signed, key: signer.PublicKey()},
+		{name: "another key", token: signed, key: other.PublicKey(), wantErr: true},
+		{name: "tampered payload", token: tampered, key: signer.PublicKey(), wantErr: true},
+		{name: "garbage", token: "not-a-token", key: signer.PublicKey(), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := Verify(tt.token, tt.key)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Verify() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if claims.Subject != "uuid-42" || claims.Reputation.Ratings.Average != 4.5 {
+				t.Errorf("claims = %+v, want the signed document", claims)
+			}
+		})
+	}
+}
+
+func TestNewSignerFromEnv(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	seed[0] = 1
+	fromSeed := NewSigner(ed25519.NewKeyFromSeed(seed))
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "seed", value: base64.StdEncoding.EncodeToString(seed)},
+		{name: "private key", value: base64.StdEncoding.EncodeToString(ed25519.NewKeyFromSeed(seed))},
+		{name: "unset", value: "", wantErr: true},
+		{name: "not base64", value: "not base64!", wantErr: true},
+		{name: "wrong length", value: base64.StdEncoding.EncodeToString([]byte("short")), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("REPUTATION_SIGNING_KEY", tt.value)
+			signer, err := NewSignerFromEnv()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("NewSignerFromEnv() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewSignerFromEnv() error = %v", err)
+			}
+			if signer.KeyID() != fromSeed.KeyID() {
+				t.Errorf("KeyID() = %q, want %q", signer.KeyID(), fromSeed.KeyID())
+			}
+		})
+	}
+}
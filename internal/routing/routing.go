@@ -0,0 +1,117 @@
+// Package routing computes driving distances between job locations.
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Distance methods
+const (
+	MethodRoute        = "route"         // Driving distance from the routing service
+	MethodStraightLine = "straight_line" // Great-circle fallback when routing is unavailable
+)
+
+const (
+	metersPerMile    = 1609.344
+	earthRadiusMiles = 3958.8
+)
+
+// Point is a latitude/longitude pair
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// Route is the distance between two points
+type Route struct {
+	Miles  float64 `json:"miles"`
+	Method string  `json:"method"`
+}
+
+// Client queries an OSRM-compatible routing service
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a routing client using ROUTING_API_URL (OSRM by default)
+func NewClient() *Client {
+	baseURL := os.Getenv("ROUTING_API_URL")
+	if baseURL == "" {
+		baseURL = "https://router.project-osrm.org"
+	}
+
+	return &Client{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Distance returns the driving distance between two points, falling back to
+// the straight-line distance if the routing service cannot be reached
+func (c *Client) Distance(ctx context.Context, from, to Point) Route {
+	miles, err := c.drivingMiles(ctx, from, to)
+	if err != nil {
+		return Route{Miles: round2(StraightLineMiles(from, to)), Method: MethodStraightLine}
+	}
+	return Route{Miles: round2(miles), Method: MethodRoute}
+}
+
+// drivingMiles asks the routing service for the driving distance
+func (c *Client) drivingMiles(ctx context.Context, from, to Point) (float64, error) {
+	url := fmt.Sprintf("%s/route/v1/driving/%f,%f;%f,%f?overview=false",
+		c.baseURL, from.Lng, from.Lat, to.Lng, to.Lat)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch route: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("routing API returned status %d", resp.StatusCode)
+	}
+
+	var data struct {
+		Code   string `json:"code"`
+		Routes []struct {
+			Distance float64 `json:"distance"` // meters
+		} `json:"routes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return 0, fmt.Errorf("failed to decode route: %w", err)
+	}
+	if data.Code != "Ok" || len(data.Routes) == 0 {
+		return 0, fmt.Errorf("no route found")
+	}
+
+	return data.Routes[0].Distance / metersPerMile, nil
+}
+
+// StraightLineMiles returns the great-circle distance between two points in miles
+func StraightLineMiles(from, to Point) float64 {
+	lat1 := from.Lat * math.Pi / 180
+	lat2 := to.Lat * math.Pi / 180
+	dLat := (to.Lat - from.Lat) * math.Pi / 180
+	dLng := (to.Lng - from.Lng) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return earthRadiusMiles * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// round2 rounds to two decimal places
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
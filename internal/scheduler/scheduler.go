@@ -0,0 +1,220 @@
+// Package scheduler declares the worker's recurring workflows in one place and keeps a
+// Temporal Schedule registered for each. A job's cron expression, jitter, overlap policy
+// and whether it runs at all are read from the environment as <ENV>_CRON, <ENV>_JITTER,
+// <ENV>_OVERLAP and <ENV>_ENABLED, falling back to the defaults declared in Jobs.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+
+	"app/internal/temporal/workflows"
+)
+
+// Job is a recurring workflow
+type Job struct {
+	ID          string // Schedule ID; also the ID the workflow ran under as a cron workflow
+	Env         string // Prefix of the job's environment settings
+	Workflow    interface{}
+	Description string
+	Cron        string
+	Jitter      time.Duration // Random delay added to each run, so jobs on one cron spread out
+	Overlap     enumspb.ScheduleOverlapPolicy
+	Requires    string // Environment variable the job needs; unset disables it
+}
+
+// Jobs is every recurring workflow the worker runs. Add new scheduled tasks here rather
+// than starting cron workflows elsewhere.
+var Jobs = []Job{
+	{
+		ID:          workflows.WeatherAdvisoryWorkflowID,
+		Env:         "WEATHER_CHECK",
+		Workflow:    workflows.WeatherAdvisoryWorkflow,
+		Description: "Severe weather advisories for upcoming outdoor jobs",
+		Cron:        "0 */3 * * *",
+		Jitter:      5 * time.Minute,
+		Overlap:     enumspb.SCHEDULE_OVERLAP_POLICY_SKIP,
+	},
+	{
+		ID:          workflows.OpsMonitorWorkflowID,
+		Env:         "OPS_MONITOR",
+		Workflow:    workflows.OpsMonitorWorkflow,
+		Description: "Payment reconciliation, fill-rate and KPI checks, admin overview refresh",
+		Cron:        "0 * * * *",
+		Jitter:      2 * time.Minute,
+		Overlap:     enumspb.SCHEDULE_OVERLAP_POLICY_SKIP,
+	},
+	{
+		// A run delayed past the next one still settles, so no payout day is skipped
+		ID:          workflows.PayoutSettlementWorkflowID,
+		Env:         "PAYOUT_SETTLEMENT",
+		Workflow:    workflows.PayoutSettlementWorkflow,
+		Description: "Worker payout settlement",
+		Cron:        "0 6 * * *",
+		Overlap:     enumspb.SCHEDULE_OVERLAP_POLICY_BUFFER_ONE,
+	},
+	{
+		ID:          workflows.NotificationRetryWorkflowID,
+		Env:         "NOTIFICATION_RETRY",
+		Workflow:    workflows.NotificationRetryWorkflow,
+		Description: "Retry of notifications that failed transiently",
+		Cron:        "*/5 * * * *",
+		Jitter:      30 * time.Second,
+		Overlap:     enumspb.SCHEDULE_OVERLAP_POLICY_SKIP,
+	},
+	{
+		// Keys are sealed with the vault
+		ID:          workflows.SigningKeyRotationWorkflowID,
+		Env:         "JWT_KEY_ROTATION",
+		Workflow:    workflows.SigningKeyRotationWorkflow,
+		Description: "JWT signing key rotation",
+		Cron:        "0 4 1 * *",
+		Overlap:     enumspb.SCHEDULE_OVERLAP_POLICY_SKIP,
+		Requires:    "VAULT_ENCRYPTION_KEY",
+	},
+	{
+		ID:          workflows.DocumentExpiryWorkflowID,
+		Env:         "DOCUMENT_EXPIRY",
+		Workflow:    workflows.DocumentExpiryWorkflow,
+		Description: "Worker document expiry reminders",
+		Cron:        "0 9 * * *",
+		Jitter:      5 * time.Minute,
+		Overlap:     enumspb.SCHEDULE_OVERLAP_POLICY_SKIP,
+	},
+}
+
+// overlapPolicies are the <ENV>_OVERLAP values
+var overlapPolicies = map[string]enumspb.ScheduleOverlapPolicy{
+	"skip":            enumspb.SCHEDULE_OVERLAP_POLICY_SKIP,
+	"buffer_one":      enumspb.SCHEDULE_OVERLAP_POLICY_BUFFER_ONE,
+	"buffer_all":      enumspb.SCHEDULE_OVERLAP_POLICY_BUFFER_ALL,
+	"cancel_other":    enumspb.SCHEDULE_OVERLAP_POLICY_CANCEL_OTHER,
+	"terminate_other": enumspb.SCHEDULE_OVERLAP_POLICY_TERMINATE_OTHER,
+	"allow_all":       enumspb.SCHEDULE_OVERLAP_POLICY_ALLOW_ALL,
+}
+
+// Settings is a job's configuration after environment overrides
+type Settings struct {
+	Cron       string
+	Jitter     time.Duration
+	Overlap    enumspb.ScheduleOverlapPolicy
+	Enabled    bool
+	DisabledBy string // Why the job is disabled
+}
+
+// Settings applies the job's environment overrides to its defaults. Invalid values are
+// errors rather than ignored, so a typo does not quietly run the default.
+func (j Job) Settings(getenv func(string) string) (Settings, error) {
+	s := Settings{Cron: j.Cron, Jitter: j.Jitter, Overlap: j.Overlap, Enabled: true}
+	if cron := strings.TrimSpace(getenv(j.Env + "_CRON")); cron != "" {
+		if len(strings.Fields(cron)) != 5 {
+			return s, fmt.Errorf("%s_CRON %q must have five fields", j.Env, cron)
+		}
+		s.Cron = cron
+	}
+	if jitter := getenv(j.Env + "_JITTER"); jitter != "" {
+		d, err := time.ParseDuration(jitter)
+		if err != nil || d < 0 {
+			return s, fmt.Errorf("%s_JITTER %q must be a duration such as 5m", j.Env, jitter)
+		}
+		s.Jitter = d
+	}
+	if overlap := getenv(j.Env + "_OVERLAP"); overlap != "" {
+		policy, ok := overlapPolicies[strings.ToLower(overlap)]
+		if !ok {
+			return s, fmt.Errorf("%s_OVERLAP %q must be skip, buffer_one, buffer_all, cancel_other, terminate_other or allow_all", j.Env, overlap)
+		}
+		s.Overlap = policy
+	}
+	if enabled := getenv(j.Env + "_ENABLED"); enabled != "" {
+		on, err := strconv.ParseBool(enabled)
+		if err != nil {
+			return s, fmt.Errorf("%s_ENABLED %q must be true or false", j.Env, enabled)
+		}
+		if !on {
+			s.Enabled, s.DisabledBy = false, j.Env+"_ENABLED"
+		}
+	}
+	if s.Enabled && j.Requires != "" && getenv(j.Requires) == "" {
+		s.Enabled, s.DisabledBy = false, j.Requires+" not set"
+	}
+	return s, nil
+}
+
+// Sync creates or updates a schedule for each job, starting its workflow on taskQueue.
+// Disabled jobs keep their schedule, paused with a note saying why, so their history
+// survives. A cron workflow left running under the job's ID from before schedules is
+// terminated, as it would run the job twice. Every job is attempted; the errors are
+// returned together.
+func Sync(ctx context.Context, c client.Client, taskQueue string, jobs []Job, getenv func(string) string) error {
+	var errs []error
+	for _, job := range jobs {
+		settings, err := job.Settings(getenv)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := syncJob(ctx, c, taskQueue, job, settings); err != nil {
+			errs = append(errs, fmt.Errorf("schedule %s: %w", job.ID, err))
+			continue
+		}
+		slog.InfoContext(ctx, "Scheduled job registered", "schedule_id", job.ID, "cron", settings.Cron,
+			"jitter", settings.Jitter, "overlap", settings.Overlap.String(), "enabled", settings.Enabled, "disabled_by", settings.DisabledBy)
+	}
+	return errors.Join(errs...)
+}
+
+func syncJob(ctx context.Context, c client.Client, taskQueue string, job Job, s Settings) error {
+	spec := client.ScheduleSpec{CronExpressions: []string{s.Cron}, Jitter: s.Jitter}
+	action := &client.ScheduleWorkflowAction{ID: job.ID, Workflow: job.Workflow, TaskQueue: taskQueue}
+	note := job.Description
+	if !s.Enabled {
+		note = "Disabled: " + s.DisabledBy
+	}
+
+	_, err := c.ScheduleClient().Create(ctx, client.ScheduleOptions{
+		ID:      job.ID,
+		Spec:    spec,
+		Action:  action,
+		Overlap: s.Overlap,
+		Paused:  !s.Enabled,
+		Note:    note,
+	})
+	if errors.Is(err, temporal.ErrScheduleAlreadyRunning) {
+		err = c.ScheduleClient().GetHandle(ctx, job.ID).Update(ctx, client.ScheduleUpdateOptions{
+			DoUpdate: func(input client.ScheduleUpdateInput) (*client.ScheduleUpdate, error) {
+				schedule := input.Description.Schedule
+				schedule.Spec = &spec
+				schedule.Action = action
+				schedule.Policy = &client.SchedulePolicies{Overlap: s.Overlap}
+				schedule.State = &client.ScheduleState{Paused: !s.Enabled, Note: note}
+				return &client.ScheduleUpdate{Schedule: &schedule}, nil
+			},
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	// Scheduled runs get IDs of the form <id>-<time>, so the cron workflow is the only
+	// execution under the bare ID
+	err = c.TerminateWorkflow(ctx, job.ID, "", "Replaced by Temporal schedule "+job.ID)
+	var notFound *serviceerror.NotFound
+	if err != nil && !errors.As(err, &notFound) {
+		return fmt.Errorf("failed to stop cron workflow: %w", err)
+	}
+	if err == nil {
+		slog.InfoContext(ctx, "Stopped cron workflow replaced by schedule", "workflow_id", job.ID)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+func TestJobSettings(t *testing.T) {
+	job := Job{ID: "nightly", Env: "NIGHTLY", Cron: "0 3 * * *", Jitter: time.Minute, Overlap: enumspb.SCHEDULE_OVERLAP_POLICY_SKIP, Requires: "NIGHTLY_KEY"}
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    Settings
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			env:  map[string]string{"NIGHTLY_KEY": "k"},
+			want: Settings{Cron: "0 3 * * *", Jitter: time.Minute, Overlap: enumspb.SCHEDULE_OVERLAP_POLICY_SKIP, Enabled: true},
+		},
+		{
+			name: "overrides",
+			env:  map[string]string{"NIGHTLY_KEY": "k", "NIGHTLY_CRON": "30 1 * * 1", "NIGHTLY_JITTER": "10m", "NIGHTLY_OVERLAP": "buffer_one"},
+			want: Settings{Cron: "30 1 * * 1", Jitter: 10 * time.Minute, Overlap: enumspb.SCHEDULE_OVERLAP_POLICY_BUFFER_ONE, Enabled: true},
+		},
+		{
+			name: "disabled by flag",
+			env:  map[string]string{"NIGHTLY_KEY": "k", "NIGHTLY_ENABLED": "false"},
+			want: Settings{Cron: "0 3 * * *", Jitter: time.Minute, Overlap: enumspb.SCHEDULE_OVERLAP_POLICY_SKIP, DisabledBy: "NIGHTLY_ENABLED"},
+		},
+		{
+			name: "required setting missing",
+			env:  map[string]string{"NIGHTLY_ENABLED": "true"},
+			want: Settings{Cron: "0 3 * * *", Jitter: time.Minute, Overlap: enumspb.SCHEDULE_OVERLAP_POLICY_SKIP, DisabledBy: "NIGHTLY_KEY not set"},
+		},
+		{name: "bad cron", env: map[string]string{"NIGHTLY_CRON": "daily"}, wantErr: true},
+		{name: "bad jitter", env: map[string]string{"NIGHTLY_JITTER": "5"}, wantErr: true},
+		{name: "bad overlap", env: map[string]string{"NIGHTLY_OVERLAP": "queue"}, wantErr: true},
+		{name: "bad flag", env: map[string]string{"NIGHTLY_ENABLED": "maybe"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := job.Settings(func(key string) string { return tt.env[key] })
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Settings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Settings() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package sdkgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+
+	"app/internal/openapi"
+)
+
+// GenerateGo renders the typed Go client for the document. The transport (Client,
+// APIError and do) is hand-written in the target package; this emits the types and
+// one method per operation.
+func GenerateGo(doc *openapi.Document, pkg string) ([]byte, error) {
+	s, err := buildSpec(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "// APIVersion is the version of the API document the client was generated from\nconst APIVersion = %q\n\n", s.Version)
+	for _, t := range s.Types {
+		writeGoType(&body, t)
+	}
+	for _, op := range s.Operations {
+		if op.ParamsType != "" {
+			writeGoParams(&body, op)
+		}
+		writeGoMethod(&body, op)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by cmd/sdkgen from the %s %s OpenAPI document. DO NOT EDIT.\n\n", s.Title, s.Version)
+	fmt.Fprintf(&b, "package %s\n\nimport (\n", pkg)
+	for _, imp := range []struct{ path, use string }{
+		{"context", "context.Context"},
+		{"fmt", "fmt.Sprint("},
+		{"net/http", "http.Method"},
+		{"net/url", "url.Values"},
+		{"time", "time.Time"},
+	} {
+		if bytes.Contains(body.Bytes(), []byte(imp.use)) {
+			fmt.Fprintf(&b, "\t%q\n", imp.path)
+		}
+	}
+	b.WriteString(")\n\n")
+	b.Write(body.Bytes())
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated Go client: %w", err)
+	}
+	return src, nil
+}
+
+func writeGoType(b *bytes.Buffer, t namedType) {
+	if t.Schema.Description != "" {
+		writeGoComment(b, t.Name, "is "+lowerFirst(t.Schema.Description), "")
+	}
+	if t.Schema.Type != "object" || len(t.Schema.Properties) == 0 {
+		fmt.Fprintf(b, "type %s %s\n\n", t.Name, goType(t.Schema, true))
+		return
+	}
+
+	fmt.Fprintf(b, "type %s struct {\n", t.Name)
+	for _, prop := range sortedProperties(t.Schema) {
+		schema := t.Schema.Properties[prop]
+		required := isRequired(t.Schema, prop)
+		tag := prop
+		if !required {
+			tag += ",omitempty"
+		}
+		if schema.Description != "" {
+			fmt.Fprintf(b, "\t// %s\n", schema.Description)
+		}
+		if len(schema.Enum) > 0 {
+			fmt.Fprintf(b, "\t// One of: %s\n", joinEnum(schema.Enum))
+		}
+		fmt.Fprintf(b, "\t%s %s `json:%q`\n", goName(prop), goType(schema, required), tag)
+	}
+	b.WriteString("}\n\n")
+}
+
+func writeGoParams(b *bytes.Buffer, op operation) {
+	fmt.Fprintf(b, "// %s holds the query parameters of %s\n", op.ParamsType, goName(op.ID))
+	fmt.Fprintf(b, "type %s struct {\n", op.ParamsType)
+	for _, param := range op.QueryParams {
+		if param.Description != "" {
+			fmt.Fprintf(b, "\t// %s\n", param.Description)
+		}
+		fmt.Fprintf(b, "\t%s %s\n", goName(param.Name), goParamType(param))
+	}
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(b, "func (p *%s) values() url.Values {\n", op.ParamsType)
+	b.WriteString("\tquery := url.Values{}\n\tif p == nil {\n\t\treturn query\n\t}\n")
+	for _, param := range op.QueryParams {
+		field := "p." + goName(param.Name)
+		if param.Required {
+			fmt.Fprintf(b, "\tquery.Set(%q, fmt.Sprint(%s))\n", param.Name, field)
+			continue
+		}
+		fmt.Fprintf(b, "\tif %s != nil {\n\t\tquery.Set(%q, fmt.Sprint(*%s))\n\t}\n", field, param.Name, field)
+	}
+	b.WriteString("\treturn query\n}\n\n")
+}
+
+func writeGoMethod(b *bytes.Buffer, op operation) {
+	name := goName(op.ID)
+	writeGoComment(b, name, "calls "+op.Method+" "+op.Path, op.Summary)
+
+	args := []string{"ctx context.Context"}
+	for _, param := range op.PathParams {
+		args = append(args, goParamName(param.Name)+" "+goParamType(param))
+	}
+	if op.ParamsType != "" {
+		args = append(args, "params *"+op.ParamsType)
+	}
+	if op.Body != nil {
+		args = append(args, "body "+goType(op.Body, true))
+	}
+
+	result, out := goResult(op)
+	if result == "" {
+		fmt.Fprintf(b, "func (c *Client) %s(%s) error {\n", name, strings.Join(args, ", "))
+	} else {
+		fmt.Fprintf(b, "func (c *Client) %s(%s) (%s, error) {\n", name, strings.Join(args, ", "), result)
+	}
+
+	query, body := "nil", "nil"
+	if op.ParamsType != "" {
+		query = "params.values()"
+	}
+	if op.Body != nil {
+		body = "body"
+	}
+	call := fmt.Sprintf("c.do(ctx, %s, %s, %s, %s, %s)", goMethodConst(op.Method), goPathExpr(op), query, body, out)
+
+	switch {
+	case result == "":
+		fmt.Fprintf(b, "\treturn %s\n", call)
+	case strings.HasPrefix(result, "*"):
+		fmt.Fprintf(b, "\tout := new(%s)\n", strings.TrimPrefix(result, "*"))
+		fmt.Fprintf(b, "\tif err := %s; err != nil {\n\t\treturn nil, err\n\t}\n\treturn out, nil\n", call)
+	default:
+		fmt.Fprintf(b, "\tvar out %s\n", result)
+		fmt.Fprintf(b, "\terr := %s\n\treturn out, err\n", call)
+	}
+	b.WriteString("}\n\n")
+}
+
+// goResult returns the method's result type and the argument passed to do for decoding
+func goResult(op operation) (string, string) {
+	switch {
+	case op.Response == nil:
+		return "", "nil"
+	case op.ContentType != "application/json":
+		return "[]byte", "&out"
+	case op.Response.Ref != "":
+		return "*" + refName(op.Response.Ref), "out"
+	}
+	return goType(op.Response, true), "&out"
+}
+
+// goPathExpr builds the request path, escaping path parameters
+func goPathExpr(op operation) string {
+	var parts []string
+	rest := op.Path
+	for {
+		start := strings.Index(rest, "{")
+		if start == -1 {
+			break
+		}
+		end := strings.Index(rest[start:], "}") + start
+		if rest[:start] != "" {
+			parts = append(parts, fmt.Sprintf("%q", rest[:start]))
+		}
+		param := goParamName(rest[start+1 : end])
+		parts = append(parts, fmt.Sprintf("pathParam(%s)", param))
+		rest = rest[end+1:]
+	}
+	if rest != "" || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%q", rest))
+	}
+	return strings.Join(parts, " + ")
+}
+
+func goMethodConst(method string) string {
+	switch method {
+	case "GET":
+		return "http.MethodGet"
+	case "POST":
+		return "http.MethodPost"
+	case "PUT":
+		return "http.MethodPut"
+	case "PATCH":
+		return "http.MethodPatch"
+	case "DELETE":
+		return "http.MethodDelete"
+	}
+	return fmt.Sprintf("%q", method)
+}
+
+// goType maps a schema to a Go type. Nullable scalars and optional structs and times
+// are pointers so an absent value round-trips as absent.
+func goType(schema *openapi.Schema, required bool) string {
+	if schema == nil {
+		return "interface{}"
+	}
+
+	pointer := schema.Nullable
+	var base string
+	switch {
+	case schema.Ref != "":
+		base = refName(schema.Ref)
+		pointer = pointer || !required
+	case schema.Type == "string" && schema.Format == "date-time":
+		base = "time.Time"
+		pointer = pointer || !required
+	case schema.Type == "string" && schema.Format == "byte":
+		return "[]byte"
+	case schema.Type == "string":
+		base = "string"
+	case schema.Type == "integer" && schema.Format == "int64":
+		base = "int64"
+	case schema.Type == "integer":
+		base = "int"
+	case schema.Type == "number" && schema.Format == "float":
+		base = "float32"
+	case schema.Type == "number":
+		base = "float64"
+	case schema.Type == "boolean":
+		base = "bool"
+	case schema.Type == "array":
+		return "[]" + goType(schema.Items, true)
+	case schema.Type == "object" && schema.AdditionalProperties != nil:
+		return "map[string]" + goType(schema.AdditionalProperties, true)
+	case schema.Type == "object":
+		return "map[string]interface{}"
+	default:
+		return "interface{}"
+	}
+
+	if pointer {
+		return "*" + base
+	}
+	return base
+}
+
+// goParamType maps a parameter to a Go type; optional query parameters are pointers
+// so callers can tell the zero value from an absent one
+func goParamType(param openapi.Parameter) string {
+	schema := *param.Schema
+	schema.Nullable = false
+	typ := goType(&schema, true)
+	if param.In == "query" && !param.Required && !strings.HasPrefix(typ, "[]") {
+		return "*" + typ
+	}
+	return typ
+}
+
+// writeGoComment writes a doc comment of the form "Name text", followed by detail as
+// a separate paragraph
+func writeGoComment(b *bytes.Buffer, name, text, detail string) {
+	if text == "" && detail == "" {
+		return
+	}
+	fmt.Fprintf(b, "// %s %s\n", name, strings.TrimSuffix(text, "."))
+	if detail != "" {
+		fmt.Fprintf(b, "//\n// %s\n", detail)
+	}
+}
+
+func lowerFirst(s string) string {
+	if len(s) > 1 && strings.ToUpper(s[:2]) == s[:2] {
+		// Leave acronyms such as "JWT" alone
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func joinEnum(values []string) string {
+	return strings.Join(values, ", ")
+}
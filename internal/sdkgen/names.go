@@ -0,0 +1,108 @@
+package sdkgen
+
+import (
+	"strings"
+	"unicode"
+)
+
+// initialisms are kept upper-case in Go identifiers, following Go naming conventions
+var initialisms = map[string]string{
+	"api":  "API",
+	"cvv":  "CVV",
+	"id":   "ID",
+	"ids":  "IDs",
+	"ip":   "IP",
+	"json": "JSON",
+	"sms":  "SMS",
+	"url":  "URL",
+	"uuid": "UUID",
+}
+
+// splitWords breaks snake_case, kebab-case and camelCase names into lower-case words
+func splitWords(name string) []string {
+	var words []string
+	var current []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-' || r == ' ' || r == '.' || r == '/':
+			if len(current) > 0 {
+				words = append(words, string(current))
+				current = nil
+			}
+			continue
+		case unicode.IsUpper(r) && len(current) > 0:
+			// Split before an upper-case letter that starts a word: "jobId", "HTTPServer"
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (nextLower && unicode.IsUpper(runes[i-1])) {
+				words = append(words, string(current))
+				current = nil
+			}
+		}
+		current = append(current, unicode.ToLower(r))
+	}
+	if len(current) > 0 {
+		words = append(words, string(current))
+	}
+	return words
+}
+
+// goName converts a JSON or schema name to an exported Go identifier
+func goName(name string) string {
+	var b strings.Builder
+	for _, word := range splitWords(name) {
+		if upper, ok := initialisms[word]; ok {
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	id := b.String()
+	if id == "" || unicode.IsDigit(rune(id[0])) {
+		id = "X" + id
+	}
+	return id
+}
+
+// goParamName converts a parameter name to an unexported Go identifier
+func goParamName(name string) string {
+	id := lowerCamel(name)
+	if goKeywords[id] {
+		id += "Param"
+	}
+	return id
+}
+
+// tsMethodName converts an operation ID to a lowerCamelCase TypeScript method name
+func tsMethodName(operationID string) string {
+	return lowerCamel(operationID)
+}
+
+// lowerCamel is goName with the first word in lower case: "JobID" becomes "jobID"
+// and "ID" becomes "id"
+func lowerCamel(name string) string {
+	words := splitWords(name)
+	if len(words) == 0 {
+		return goName(name)
+	}
+	return words[0] + strings.TrimPrefix(goName(name), goName(words[0]))
+}
+
+// tsPropertyName quotes property names that are not valid identifiers
+func tsPropertyName(name string) string {
+	for i, r := range name {
+		if !(unicode.IsLetter(r) || r == '_' || r == '$' || (i > 0 && unicode.IsDigit(r))) {
+			return `"` + name + `"`
+		}
+	}
+	return name
+}
+
+var goKeywords = map[string]bool{
+	"break": true, "case": true, "chan": true, "const": true, "continue": true, "default": true,
+	"defer": true, "else": true, "fallthrough": true, "for": true, "func": true, "go": true,
+	"goto": true, "if": true, "import": true, "interface": true, "map": true, "package": true,
+	"range": true, "return": true, "select": true, "struct": true, "switch": true, "type": true,
+	"var": true,
+}
@@ -0,0 +1,34 @@
+package sdkgen
+
+import "testing"
+
+func TestNames(t *testing.T) {
+	tests := []struct {
+		in       string
+		goName   string
+		goParam  string
+		tsMethod string
+	}{
+		{in: "gig_worker_id", goName: "GigWorkerID", goParam: "gigWorkerID", tsMethod: "gigWorkerID"},
+		{in: "GetJobByID", goName: "GetJobByID", goParam: "getJobByID", tsMethod: "getJobByID"},
+		{in: "GetOpenAPISpec", goName: "GetOpenAPISpec", goParam: "getOpenAPISpec", tsMethod: "getOpenAPISpec"},
+		{in: "id", goName: "ID", goParam: "id", tsMethod: "id"},
+		{in: "uuid", goName: "UUID", goParam: "uuid", tsMethod: "uuid"},
+		{in: "type", goName: "Type", goParam: "typeParam", tsMethod: "type"},
+		{in: "address_line1", goName: "AddressLine1", goParam: "addressLine1", tsMethod: "addressLine1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := goName(tt.in); got != tt.goName {
+				t.Errorf("goName(%q) = %q, want %q", tt.in, got, tt.goName)
+			}
+			if got := goParamName(tt.in); got != tt.goParam {
+				t.Errorf("goParamName(%q) = %q, want %q", tt.in, got, tt.goParam)
+			}
+			if got := tsMethodName(tt.in); got != tt.tsMethod {
+				t.Errorf("tsMethodName(%q) = %q, want %q", tt.in, got, tt.tsMethod)
+			}
+		})
+	}
+}
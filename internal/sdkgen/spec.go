@@ -0,0 +1,229 @@
+// Package sdkgen generates typed API clients from the OpenAPI document
+package sdkgen
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"app/internal/openapi"
+)
+
+const schemaRefPrefix = "#/components/schemas/"
+
+// namedType is a schema emitted as a named client type
+type namedType struct {
+	Name   string
+	Schema *openapi.Schema
+}
+
+// operation is one client method
+type operation struct {
+	ID          string
+	Method      string
+	Path        string
+	Summary     string
+	PathParams  []openapi.Parameter
+	QueryParams []openapi.Parameter
+	ParamsType  string          // Name of the query parameter struct, if any
+	Body        *openapi.Schema // nil when the operation takes no body
+	Response    *openapi.Schema // nil when the response has no body
+	ContentType string          // Response content type
+}
+
+// spec is the document flattened for code generation: every inline object is hoisted
+// to a named type so both generators can refer to it by name
+type spec struct {
+	Title      string
+	Version    string
+	ErrorType  string
+	Types      []namedType
+	Operations []operation
+
+	names map[string]bool
+}
+
+// buildSpec flattens the document. Operations without a 2xx response (redirects) are
+// skipped since they are meant for browsers, not API clients.
+func buildSpec(doc *openapi.Document) (*spec, error) {
+	s := &spec{
+		Title:   doc.Info.Title,
+		Version: doc.Info.Version,
+		names:   make(map[string]bool),
+	}
+
+	componentNames := make([]string, 0, len(doc.Components.Schemas))
+	for name := range doc.Components.Schemas {
+		componentNames = append(componentNames, name)
+		s.names[name] = true
+	}
+	sort.Strings(componentNames)
+	for _, name := range componentNames {
+		s.Types = append(s.Types, namedType{Name: name, Schema: s.hoistProperties(doc.Components.Schemas[name], name)})
+	}
+
+	if errResp, ok := doc.Components.Responses["BadRequest"]; ok {
+		if media, ok := errResp.Content["application/json"]; ok && media.Schema.Ref != "" {
+			s.ErrorType = refName(media.Schema.Ref)
+		}
+	}
+	if s.ErrorType == "" {
+		return nil, fmt.Errorf("document has no error response schema")
+	}
+
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item := doc.Paths[path]
+		for _, entry := range []struct {
+			method string
+			op     *openapi.Operation
+		}{
+			{http.MethodGet, item.Get},
+			{http.MethodPost, item.Post},
+			{http.MethodPut, item.Put},
+			{http.MethodPatch, item.Patch},
+			{http.MethodDelete, item.Delete},
+		} {
+			if entry.op == nil {
+				continue
+			}
+			if op, ok := s.buildOperation(entry.method, path, entry.op); ok {
+				s.Operations = append(s.Operations, op)
+			}
+		}
+	}
+
+	return s, nil
+}
+
+func (s *spec) buildOperation(method, path string, op *openapi.Operation) (operation, bool) {
+	result := operation{
+		ID:      op.OperationID,
+		Method:  method,
+		Path:    path,
+		Summary: op.Summary,
+	}
+
+	response := successResponse(op)
+	if response == nil {
+		return result, false
+	}
+	if op.RequestBody != nil && op.RequestBody.Content["application/json"] == nil {
+		// The clients only send JSON; file uploads are left to callers
+		return result, false
+	}
+	for contentType, media := range response.Content {
+		result.ContentType = contentType
+		result.Response = s.hoist(media.Schema, goName(op.OperationID)+"Response")
+	}
+
+	for _, param := range op.Parameters {
+		switch param.In {
+		case "path":
+			result.PathParams = append(result.PathParams, param)
+		case "query":
+			result.QueryParams = append(result.QueryParams, param)
+		}
+	}
+	if len(result.QueryParams) > 0 {
+		result.ParamsType = s.uniqueName(goName(op.OperationID) + "Params")
+	}
+
+	if op.RequestBody != nil {
+		media := op.RequestBody.Content["application/json"]
+		result.Body = s.hoist(media.Schema, goName(op.OperationID)+"Request")
+	}
+
+	return result, true
+}
+
+// successResponse returns the lowest 2xx response of an operation
+func successResponse(op *openapi.Operation) *openapi.Response {
+	best := 0
+	for code := range op.Responses {
+		status, err := strconv.Atoi(code)
+		if err != nil || status < 200 || status > 299 {
+			continue
+		}
+		if best == 0 || status < best {
+			best = status
+		}
+	}
+	if best == 0 {
+		return nil
+	}
+	return op.Responses[strconv.Itoa(best)]
+}
+
+// hoist replaces inline object schemas with references to new named types
+func (s *spec) hoist(schema *openapi.Schema, name string) *openapi.Schema {
+	if schema == nil {
+		return nil
+	}
+
+	switch {
+	case schema.Ref != "":
+		return schema
+	case schema.Type == "object" && len(schema.Properties) > 0:
+		unique := s.uniqueName(name)
+		s.Types = append(s.Types, namedType{Name: unique, Schema: s.hoistProperties(schema, unique)})
+		return &openapi.Schema{Ref: schemaRefPrefix + unique, Nullable: schema.Nullable}
+	case schema.Type == "array" && schema.Items != nil:
+		copied := *schema
+		copied.Items = s.hoist(schema.Items, strings.TrimSuffix(name, "s")+"Item")
+		return &copied
+	case schema.Type == "object" && schema.AdditionalProperties != nil:
+		copied := *schema
+		copied.AdditionalProperties = s.hoist(schema.AdditionalProperties, name+"Value")
+		return &copied
+	}
+	return schema
+}
+
+// hoistProperties hoists inline objects nested in an object's properties
+func (s *spec) hoistProperties(schema *openapi.Schema, name string) *openapi.Schema {
+	copied := *schema
+	copied.Properties = make(map[string]*openapi.Schema, len(schema.Properties))
+	for _, prop := range sortedProperties(schema) {
+		copied.Properties[prop] = s.hoist(schema.Properties[prop], name+goName(prop))
+	}
+	return &copied
+}
+
+func (s *spec) uniqueName(name string) string {
+	unique := name
+	for i := 2; s.names[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	s.names[unique] = true
+	return unique
+}
+
+func refName(ref string) string {
+	return strings.TrimPrefix(ref, schemaRefPrefix)
+}
+
+func sortedProperties(schema *openapi.Schema) []string {
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isRequired(schema *openapi.Schema, prop string) bool {
+	for _, name := range schema.Required {
+		if name == prop {
+			return true
+		}
+	}
+	return false
+}
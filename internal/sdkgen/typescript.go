@@ -0,0 +1,384 @@
+package sdkgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"app/internal/openapi"
+)
+
+// TypeScript is the generated TypeScript client: an ES module and its type
+// declarations, so the package needs no compile step to publish or test
+type TypeScript struct {
+	JS          []byte // client.js
+	Declaration []byte // client.d.ts
+	Package     []byte // package.json
+}
+
+// tsPackageName is the npm package the TypeScript client is published as
+const tsPackageName = "@gigco/api-client"
+
+// GenerateTypeScript renders the TypeScript client for the document
+func GenerateTypeScript(doc *openapi.Document) (*TypeScript, error) {
+	s, err := buildSpec(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	header := fmt.Sprintf("// Code generated by cmd/sdkgen from the %s %s OpenAPI document. DO NOT EDIT.\n\n", s.Title, s.Version)
+
+	var js bytes.Buffer
+	js.WriteString(header)
+	fmt.Fprintf(&js, "export const API_VERSION = %q;\n", s.Version)
+	js.WriteString(tsRuntime)
+	for _, op := range s.Operations {
+		writeJSMethod(&js, op)
+	}
+	js.WriteString("}\n")
+
+	var dts bytes.Buffer
+	dts.WriteString(header)
+	fmt.Fprintf(&dts, "export declare const API_VERSION: %q;\n\n", s.Version)
+	for _, t := range s.Types {
+		writeTSType(&dts, t)
+	}
+	for _, op := range s.Operations {
+		if op.ParamsType != "" {
+			writeTSParams(&dts, op)
+		}
+	}
+	fmt.Fprintf(&dts, tsRuntimeDeclarations, s.ErrorType, s.ErrorType, s.ErrorType)
+	for _, op := range s.Operations {
+		writeTSMethod(&dts, op)
+	}
+	dts.WriteString("}\n")
+
+	pkg, err := packageJSON(doc, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TypeScript{JS: js.Bytes(), Declaration: dts.Bytes(), Package: pkg}, nil
+}
+
+// tsPackage is package.json, in npm's conventional key order
+type tsPackage struct {
+	Name        string            `json:"name"`
+	Version     string            `json:"version"`
+	Description string            `json:"description"`
+	License     string            `json:"license"`
+	Type        string            `json:"type"`
+	Main        string            `json:"main"`
+	Types       string            `json:"types"`
+	Files       []string          `json:"files"`
+	Engines     map[string]string `json:"engines"`
+	Scripts     map[string]string `json:"scripts"`
+}
+
+func packageJSON(doc *openapi.Document, s *spec) ([]byte, error) {
+	pkg := tsPackage{
+		Name:        tsPackageName,
+		Version:     s.Version,
+		Description: "TypeScript client for the " + s.Title,
+		License:     "UNLICENSED",
+		Type:        "module",
+		Main:        "client.js",
+		Types:       "client.d.ts",
+		Files:       []string{"client.js", "client.d.ts"},
+		Engines:     map[string]string{"node": ">=18"},
+		Scripts:     map[string]string{"test": "node --test test/"},
+	}
+	if doc.Info.License != nil {
+		pkg.License = doc.Info.License.Name
+	}
+
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(pkg); err != nil {
+		return nil, fmt.Errorf("failed to render package.json: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+func writeTSType(b *bytes.Buffer, t namedType) {
+	if t.Schema.Description != "" {
+		fmt.Fprintf(b, "/** %s */\n", t.Schema.Description)
+	}
+	if t.Schema.Type != "object" || len(t.Schema.Properties) == 0 {
+		fmt.Fprintf(b, "export type %s = %s;\n\n", t.Name, tsType(t.Schema))
+		return
+	}
+
+	fmt.Fprintf(b, "export interface %s {\n", t.Name)
+	for _, prop := range sortedProperties(t.Schema) {
+		schema := t.Schema.Properties[prop]
+		if schema.Description != "" {
+			fmt.Fprintf(b, "  /** %s */\n", schema.Description)
+		}
+		optional := "?"
+		if isRequired(t.Schema, prop) {
+			optional = ""
+		}
+		fmt.Fprintf(b, "  %s%s: %s;\n", tsPropertyName(prop), optional, tsType(schema))
+	}
+	b.WriteString("}\n\n")
+}
+
+func writeTSParams(b *bytes.Buffer, op operation) {
+	fmt.Fprintf(b, "/** Query parameters of %s */\n", tsMethodName(op.ID))
+	fmt.Fprintf(b, "export interface %s {\n", op.ParamsType)
+	for _, param := range op.QueryParams {
+		if param.Description != "" {
+			fmt.Fprintf(b, "  /** %s */\n", param.Description)
+		}
+		optional := "?"
+		if param.Required {
+			optional = ""
+		}
+		fmt.Fprintf(b, "  %s%s: %s;\n", tsPropertyName(param.Name), optional, tsType(param.Schema))
+	}
+	b.WriteString("}\n\n")
+}
+
+// tsArgs returns the method's arguments: path parameters, then the body, then the
+// query parameters, which are optional unless one of them is required
+func tsArgs(op operation) (names []string, typed []string) {
+	for _, param := range op.PathParams {
+		name := goParamName(param.Name)
+		names = append(names, name)
+		typed = append(typed, name+": "+tsType(param.Schema))
+	}
+	if op.Body != nil {
+		names = append(names, "body")
+		typed = append(typed, "body: "+tsType(op.Body))
+	}
+	if op.ParamsType != "" {
+		optional := "?"
+		for _, param := range op.QueryParams {
+			if param.Required {
+				optional = ""
+			}
+		}
+		names = append(names, "params")
+		typed = append(typed, "params"+optional+": "+op.ParamsType)
+	}
+	return names, typed
+}
+
+func writeTSMethod(b *bytes.Buffer, op operation) {
+	_, typed := tsArgs(op)
+	result := "void"
+	switch {
+	case op.Response == nil:
+	case op.ContentType != "application/json":
+		result = "string"
+	default:
+		result = tsType(op.Response)
+	}
+
+	writeTSDoc(b, op)
+	fmt.Fprintf(b, "  %s(%s): Promise<%s>;\n", tsMethodName(op.ID), strings.Join(typed, ", "), result)
+}
+
+func writeJSMethod(b *bytes.Buffer, op operation) {
+	names, _ := tsArgs(op)
+
+	var options []string
+	if op.ParamsType != "" {
+		options = append(options, "query: params")
+	}
+	if op.Body != nil {
+		options = append(options, "body")
+	}
+	if op.Response != nil && op.ContentType != "application/json" {
+		options = append(options, fmt.Sprintf("accept: %q", op.ContentType))
+	}
+	if op.Response == nil {
+		options = append(options, "accept: null")
+	}
+
+	b.WriteString("\n")
+	writeTSDoc(b, op)
+	fmt.Fprintf(b, "  %s(%s) {\n", tsMethodName(op.ID), strings.Join(names, ", "))
+	if len(options) == 0 {
+		fmt.Fprintf(b, "    return this.request(%q, %s);\n", op.Method, jsPathExpr(op))
+	} else {
+		fmt.Fprintf(b, "    return this.request(%q, %s, { %s });\n", op.Method, jsPathExpr(op), strings.Join(options, ", "))
+	}
+	b.WriteString("  }\n")
+}
+
+func writeTSDoc(b *bytes.Buffer, op operation) {
+	if op.Summary != "" {
+		fmt.Fprintf(b, "  /** %s (%s %s) */\n", strings.TrimSuffix(op.Summary, "."), op.Method, op.Path)
+		return
+	}
+	fmt.Fprintf(b, "  /** %s %s */\n", op.Method, op.Path)
+}
+
+// jsPathExpr builds a template literal for the request path, escaping path parameters
+func jsPathExpr(op operation) string {
+	if len(op.PathParams) == 0 {
+		return fmt.Sprintf("%q", op.Path)
+	}
+	path := op.Path
+	for _, param := range op.PathParams {
+		path = strings.ReplaceAll(path, "{"+param.Name+"}", "${encodeURIComponent(String("+goParamName(param.Name)+"))}")
+	}
+	return "`" + path + "`"
+}
+
+// tsType maps a schema to a TypeScript type. Dates are ISO 8601 strings on the wire
+// and are left as strings.
+func tsType(schema *openapi.Schema) string {
+	if schema == nil {
+		return "unknown"
+	}
+
+	var base string
+	switch {
+	case schema.Ref != "":
+		base = refName(schema.Ref)
+	case len(schema.Enum) > 0:
+		values := make([]string, len(schema.Enum))
+		for i, value := range schema.Enum {
+			values[i] = fmt.Sprintf("%q", value)
+		}
+		base = strings.Join(values, " | ")
+	case schema.Type == "string":
+		base = "string"
+	case schema.Type == "integer" || schema.Type == "number":
+		base = "number"
+	case schema.Type == "boolean":
+		base = "boolean"
+	case schema.Type == "array":
+		items := tsType(schema.Items)
+		if strings.ContainsAny(items, " |") {
+			items = "(" + items + ")"
+		}
+		base = items + "[]"
+	case schema.Type == "object" && schema.AdditionalProperties != nil:
+		base = "Record<string, " + tsType(schema.AdditionalProperties) + ">"
+	case schema.Type == "object":
+		base = "Record<string, unknown>"
+	default:
+		return "unknown"
+	}
+
+	if schema.Nullable {
+		return base + " | null"
+	}
+	return base
+}
+
+// tsRuntime is the hand-written part of client.js; generated methods are appended
+// to the GigcoClient class
+const tsRuntime = `
+export class GigcoApiError extends Error {
+  constructor(status, body) {
+    super(body.message ? ` + "`${status} ${body.error}: ${body.message}`" + ` : ` + "`${status} ${body.error}`" + `);
+    this.name = "GigcoApiError";
+    this.status = status;
+    this.body = body;
+  }
+}
+
+export class GigcoClient {
+  constructor(baseUrl, options = {}) {
+    this.baseUrl = baseUrl.replace(/\/+$/, "");
+    this.token = options.token;
+    this.fetch = options.fetch ?? globalThis.fetch.bind(globalThis);
+  }
+
+  withToken(token) {
+    return new GigcoClient(this.baseUrl, { token, fetch: this.fetch });
+  }
+
+  async request(method, path, { query, body, accept = "application/json" } = {}) {
+    let url = this.baseUrl + path;
+    if (query) {
+      const search = new URLSearchParams();
+      for (const [key, value] of Object.entries(query)) {
+        if (value !== undefined && value !== null) {
+          search.set(key, String(value));
+        }
+      }
+      const encoded = search.toString();
+      if (encoded) {
+        url += "?" + encoded;
+      }
+    }
+
+    const headers = { Accept: accept ?? "application/json" };
+    if (body !== undefined) {
+      headers["Content-Type"] = "application/json";
+    }
+    if (this.token) {
+      headers.Authorization = ` + "`Bearer ${this.token}`" + `;
+    }
+
+    const response = await this.fetch(url, {
+      method,
+      headers,
+      body: body === undefined ? undefined : JSON.stringify(body),
+    });
+    const text = await response.text();
+
+    if (!response.ok) {
+      let error;
+      try {
+        error = JSON.parse(text);
+      } catch {
+        error = undefined;
+      }
+      if (!error || !error.error) {
+        error = { error: response.statusText, message: text.trim() };
+      }
+      throw new GigcoApiError(response.status, error);
+    }
+
+    if (accept === null) {
+      return undefined;
+    }
+    if (accept !== "application/json") {
+      return text;
+    }
+    return text ? JSON.parse(text) : undefined;
+  }
+`
+
+// tsRuntimeDeclarations declares the runtime in client.d.ts; formatted with the
+// error type name
+const tsRuntimeDeclarations = `/** Thrown for non-2xx responses */
+export declare class GigcoApiError extends Error {
+  readonly status: number;
+  readonly body: %s;
+  constructor(status: number, body: %s);
+}
+
+export interface GigcoClientOptions {
+  /** JWT access token sent as a Bearer token */
+  token?: string;
+  /** fetch implementation; defaults to the global fetch */
+  fetch?: typeof fetch;
+}
+
+export interface RequestOptions {
+  query?: object;
+  body?: unknown;
+  accept?: string | null;
+}
+
+export declare class GigcoClient {
+  constructor(baseUrl: string, options?: GigcoClientOptions);
+  readonly baseUrl: string;
+  token?: string;
+  /** Returns a client that authenticates with token */
+  withToken(token: string): GigcoClient;
+  /** Sends a request; throws GigcoApiError with the decoded %s on failure */
+  request<T = unknown>(method: string, path: string, options?: RequestOptions): Promise<T>;
+`
@@ -0,0 +1,166 @@
+package shadow
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"app/internal/model"
+)
+
+// Report compares each candidate of a kind with production over evaluations
+// recorded in [from, to)
+func Report(ctx context.Context, db *sql.DB, kind string, from, to time.Time) (*model.ShadowReport, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT candidate, production, production_price, candidate_price,
+		       production_worker_id, candidate_worker_id, agrees, COALESCE(error, ''), latency_micros
+		FROM shadow_evaluations
+		WHERE kind = $1 AND updated_at >= $2 AND updated_at < $3
+		ORDER BY candidate, job_id
+	`, kind, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shadow evaluations: %w", err)
+	}
+	defer rows.Close()
+
+	var evals []Evaluation
+	for rows.Next() {
+		eval := Evaluation{Kind: kind}
+		var productionPrice, candidatePrice sql.NullFloat64
+		var productionWorkerID, candidateWorkerID sql.NullInt64
+		err := rows.Scan(&eval.Candidate, &eval.Production, &productionPrice, &candidatePrice,
+			&productionWorkerID, &candidateWorkerID, &eval.Agrees, &eval.Error, &eval.LatencyMicros)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shadow evaluation: %w", err)
+		}
+		if productionPrice.Valid {
+			eval.ProductionPrice = &productionPrice.Float64
+		}
+		if candidatePrice.Valid {
+			eval.CandidatePrice = &candidatePrice.Float64
+		}
+		if productionWorkerID.Valid {
+			id := int(productionWorkerID.Int64)
+			eval.ProductionWorkerID = &id
+		}
+		if candidateWorkerID.Valid {
+			id := int(candidateWorkerID.Int64)
+			eval.CandidateWorkerID = &id
+		}
+		evals = append(evals, eval)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read shadow evaluations: %w", err)
+	}
+
+	return &model.ShadowReport{
+		Kind:       kind,
+		From:       from,
+		To:         to,
+		Candidates: Summarize(evals),
+	}, nil
+}
+
+// Summarize aggregates evaluations per candidate and production pair, sorted by
+// candidate name
+func Summarize(evals []Evaluation) []model.ShadowCandidateReport {
+	type key struct{ candidate, production string }
+	groups := map[key][]Evaluation{}
+	for _, eval := range evals {
+		k := key{eval.Candidate, eval.Production}
+		groups[k] = append(groups[k], eval)
+	}
+
+	reports := []model.ShadowCandidateReport{}
+	for k, group := range groups {
+		report := model.ShadowCandidateReport{
+			Candidate:   k.candidate,
+			Production:  k.production,
+			Evaluations: len(group),
+		}
+
+		var latency int64
+		var deltaSum float64
+		var absPercent []float64
+		for _, eval := range group {
+			latency += eval.LatencyMicros
+			if eval.Error != "" {
+				report.Errors++
+				continue
+			}
+			if eval.Agrees {
+				report.Agreements++
+			}
+
+			switch eval.Kind {
+			case model.ShadowKindPricing:
+				if eval.ProductionPrice == nil || eval.CandidatePrice == nil {
+					continue
+				}
+				delta := *eval.CandidatePrice - *eval.ProductionPrice
+				deltaSum += delta
+				switch {
+				case eval.Agrees:
+				case delta > 0:
+					report.PricedHigher++
+				case delta < 0:
+					report.PricedLower++
+				}
+				if *eval.ProductionPrice != 0 {
+					absPercent = append(absPercent, math.Abs(delta) / *eval.ProductionPrice * 100)
+				}
+			case model.ShadowKindMatching:
+				if eval.CandidateWorkerID == nil {
+					report.CandidateNoMatch++
+				}
+				if eval.ProductionWorkerID == nil {
+					report.ProductionNoMatch++
+				}
+			}
+		}
+
+		report.MeanLatencyMicros = latency / int64(len(group))
+		if succeeded := len(group) - report.Errors; succeeded > 0 {
+			report.AgreementRate = round4(float64(report.Agreements) / float64(succeeded))
+			report.MeanPriceDelta = round2(deltaSum / float64(succeeded))
+		}
+		if len(absPercent) > 0 {
+			sort.Float64s(absPercent)
+			var sum float64
+			for _, p := range absPercent {
+				sum += p
+			}
+			report.MeanAbsPercentDelta = round2(sum / float64(len(absPercent)))
+			report.P95AbsPercentDelta = round2(percentile(absPercent, 0.95))
+		}
+		reports = append(reports, report)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].Candidate != reports[j].Candidate {
+			return reports[i].Candidate < reports[j].Candidate
+		}
+		return reports[i].Production < reports[j].Production
+	})
+	return reports
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func round4(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
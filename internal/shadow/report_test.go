@@ -0,0 +1,53 @@
+package shadow
+
+import (
+	"reflect"
+	"testing"
+
+	"app/internal/model"
+)
+
+func TestSummarize(t *testing.T) {
+	price := func(v float64) *float64 { return &v }
+	worker := func(id int) *int { return &id }
+
+	evals := []Evaluation{
+		{Kind: model.ShadowKindPricing, Candidate: "category_v2", Production: "hourly_v1", ProductionPrice: price(100), CandidatePrice: price(100), Agrees: true, LatencyMicros: 10},
+		{Kind: model.ShadowKindPricing, Candidate: "category_v2", Production: "hourly_v1", ProductionPrice: price(100), CandidatePrice: price(120), LatencyMicros: 20},
+		{Kind: model.ShadowKindPricing, Candidate: "category_v2", Production: "hourly_v1", ProductionPrice: price(50), CandidatePrice: price(45), LatencyMicros: 30},
+		{Kind: model.ShadowKindPricing, Candidate: "category_v2", Production: "hourly_v1", ProductionPrice: price(80), Error: "panic: boom", LatencyMicros: 40},
+		{Kind: model.ShadowKindMatching, Candidate: "skill_v2", Production: "rating_v1", ProductionWorkerID: worker(1), CandidateWorkerID: worker(1), Agrees: true},
+		{Kind: model.ShadowKindMatching, Candidate: "skill_v2", Production: "rating_v1", ProductionWorkerID: worker(1), CandidateWorkerID: worker(3)},
+		{Kind: model.ShadowKindMatching, Candidate: "skill_v2", Production: "rating_v1", Agrees: true},
+	}
+
+	want := []model.ShadowCandidateReport{
+		{
+			Candidate:           "category_v2",
+			Production:          "hourly_v1",
+			Evaluations:         4,
+			Errors:              1,
+			Agreements:          1,
+			AgreementRate:       0.3333,
+			MeanPriceDelta:      5,
+			MeanAbsPercentDelta: 10,
+			P95AbsPercentDelta:  20,
+			PricedHigher:        1,
+			PricedLower:         1,
+			MeanLatencyMicros:   25,
+		},
+		{
+			Candidate:         "skill_v2",
+			Production:        "rating_v1",
+			Evaluations:       3,
+			Agreements:        2,
+			AgreementRate:     0.6667,
+			CandidateNoMatch:  1,
+			ProductionNoMatch: 1,
+		},
+	}
+
+	if got := Summarize(evals); !reflect.DeepEqual(got, want) {
+		t.Errorf("Summarize() =\n%+v\nwant\n%+v", got, want)
+	}
+}
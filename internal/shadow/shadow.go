@@ -0,0 +1,192 @@
+// Package shadow runs candidate pricing rules and matching engines alongside the
+// production ones on real jobs. Candidate outputs are recorded in shadow_evaluations
+// for comparison reports and never change what the workflow does.
+package shadow
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"app/internal/dispatch"
+	"app/internal/model"
+)
+
+// Evaluation is one candidate's output for a job next to production's
+type Evaluation struct {
+	Kind               string
+	JobID              int
+	Candidate          string
+	Production         string
+	ProductionPrice    *float64
+	CandidatePrice     *float64
+	ProductionWorkerID *int
+	CandidateWorkerID  *int
+	CandidateCount     int
+	Agrees             bool
+	Error              string
+	LatencyMicros      int64
+	Inputs             interface{} // job and candidate snapshot, for replaying offline
+}
+
+// Harness runs the configured candidates. A nil Harness runs nothing, so callers
+// need not check whether shadow mode is enabled.
+type Harness struct {
+	db       *sql.DB
+	pricing  []dispatch.PricingRule
+	matching []dispatch.MatchingEngine
+}
+
+// NewHarness creates a harness for the given candidates
+func NewHarness(db *sql.DB, pricing []dispatch.PricingRule, matching []dispatch.MatchingEngine) *Harness {
+	return &Harness{db: db, pricing: pricing, matching: matching}
+}
+
+// NewHarnessFromEnv creates a harness for the candidates named in SHADOW_PRICING_RULES
+// and SHADOW_MATCHING_ENGINES (comma-separated). Returns nil when neither is set.
+func NewHarnessFromEnv(db *sql.DB) (*Harness, error) {
+	var pricing []dispatch.PricingRule
+	for _, name := range splitNames(os.Getenv("SHADOW_PRICING_RULES")) {
+		rule, ok := dispatch.LookupPricingRule(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown shadow pricing rule %q (registered: %s)", name, strings.Join(dispatch.PricingRuleNames(), ", "))
+		}
+		pricing = append(pricing, rule)
+	}
+
+	var matching []dispatch.MatchingEngine
+	for _, name := range splitNames(os.Getenv("SHADOW_MATCHING_ENGINES")) {
+		engine, ok := dispatch.LookupMatchingEngine(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown shadow matching engine %q (registered: %s)", name, strings.Join(dispatch.MatchingEngineNames(), ", "))
+		}
+		matching = append(matching, engine)
+	}
+
+	if len(pricing) == 0 && len(matching) == 0 {
+		return nil, nil
+	}
+	return NewHarness(db, pricing, matching), nil
+}
+
+func splitNames(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Price runs the candidate pricing rules for a job production priced with rule
+func (h *Harness) Price(ctx context.Context, job dispatch.Job, production dispatch.PricingRule, productionPrice float64) {
+	if h == nil {
+		return
+	}
+	for _, rule := range h.pricing {
+		eval := Evaluation{
+			Kind:            model.ShadowKindPricing,
+			JobID:           job.ID,
+			Candidate:       rule.Name(),
+			Production:      production.Name(),
+			ProductionPrice: &productionPrice,
+			Inputs:          map[string]interface{}{"job": job},
+		}
+		eval.LatencyMicros, eval.Error = run(func() {
+			price := rule.Price(job)
+			eval.CandidatePrice = &price
+			eval.Agrees = math.Abs(price-productionPrice) < 0.005
+		})
+		h.record(ctx, eval)
+	}
+}
+
+// Match runs the candidate matching engines for a job production matched with engine.
+// productionOK is false when production found no worker.
+func (h *Harness) Match(ctx context.Context, job dispatch.Job, candidates []dispatch.Candidate, production dispatch.MatchingEngine, productionWorkerID int, productionOK bool) {
+	if h == nil {
+		return
+	}
+	var productionWorker *int
+	if productionOK {
+		productionWorker = &productionWorkerID
+	}
+	for _, engine := range h.matching {
+		eval := Evaluation{
+			Kind:               model.ShadowKindMatching,
+			JobID:              job.ID,
+			Candidate:          engine.Name(),
+			Production:         production.Name(),
+			ProductionWorkerID: productionWorker,
+			CandidateCount:     len(candidates),
+			Inputs:             map[string]interface{}{"job": job, "candidates": candidates},
+		}
+		eval.LatencyMicros, eval.Error = run(func() {
+			workerID, ok := engine.Match(job, candidates)
+			if ok {
+				eval.CandidateWorkerID = &workerID
+			}
+			eval.Agrees = ok == productionOK && (!ok || workerID == productionWorkerID)
+		})
+		h.record(ctx, eval)
+	}
+}
+
+// run calls a candidate, timing it and turning a panic into an error message
+func run(candidate func()) (latencyMicros int64, errMessage string) {
+	start := time.Now()
+	defer func() {
+		latencyMicros = time.Since(start).Microseconds()
+		if r := recover(); r != nil {
+			errMessage = fmt.Sprintf("panic: %v", r)
+		}
+	}()
+	candidate()
+	return 0, ""
+}
+
+// record stores an evaluation, replacing an earlier one for the same job and
+// candidate so activity retries do not count twice. Failures are only logged.
+func (h *Harness) record(ctx context.Context, eval Evaluation) {
+	inputs, err := json.Marshal(eval.Inputs)
+	if err != nil {
+		slog.ErrorContext(ctx, "Shadow: failed to marshal inputs for job", "job_id", eval.JobID, "error", err)
+		return
+	}
+	var evalError sql.NullString
+	if eval.Error != "" {
+		evalError = sql.NullString{String: eval.Error, Valid: true}
+		slog.ErrorContext(ctx, "Shadow: candidate failed on job", "kind", eval.Kind, "candidate", eval.Candidate, "job_id", eval.JobID, "error", eval.Error)
+	}
+
+	_, err = h.db.ExecContext(ctx, `
+		INSERT INTO shadow_evaluations (
+			kind, job_id, candidate, production, production_price, candidate_price,
+			production_worker_id, candidate_worker_id, candidate_count, agrees, error,
+			latency_micros, inputs
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (kind, job_id, candidate) DO UPDATE SET
+			production = EXCLUDED.production,
+			production_price = EXCLUDED.production_price,
+			candidate_price = EXCLUDED.candidate_price,
+			production_worker_id = EXCLUDED.production_worker_id,
+			candidate_worker_id = EXCLUDED.candidate_worker_id,
+			candidate_count = EXCLUDED.candidate_count,
+			agrees = EXCLUDED.agrees,
+			error = EXCLUDED.error,
+			latency_micros = EXCLUDED.latency_micros,
+			inputs = EXCLUDED.inputs
+	`, eval.Kind, eval.JobID, eval.Candidate, eval.Production, eval.ProductionPrice, eval.CandidatePrice,
+		eval.ProductionWorkerID, eval.CandidateWorkerID, eval.CandidateCount, eval.Agrees, evalError,
+		eval.LatencyMicros, inputs)
+	if err != nil {
+		slog.ErrorContext(ctx, "Shadow: failed to record evaluation for job", "kind", eval.Kind, "candidate", eval.Candidate, "job_id", eval.JobID, "error", err)
+	}
+}
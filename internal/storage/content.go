@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// Attachment kinds
+const (
+	KindPhoto    = "photo"
+	KindDocument = "document"
+	KindReceipt  = "receipt"
+)
+
+var (
+	// ErrUnsupportedType is returned when an attachment's content is not allowed for its kind
+	ErrUnsupportedType = errors.New("unsupported content type")
+	// ErrTooLarge is returned when an attachment exceeds its kind's size limit
+	ErrTooLarge = errors.New("attachment too large")
+	// ErrTypeMismatch is returned when the declared content type disagrees with the content
+	ErrTypeMismatch = errors.New("declared content type does not match content")
+)
+
+// kindRule is what an attachment kind accepts
+type kindRule struct {
+	maxBytes int64
+	types    []string
+}
+
+var kindRules = map[string]kindRule{
+	KindPhoto:    {maxBytes: 10 << 20, types: []string{"image/jpeg", "image/png", "image/webp", "image/heic"}},
+	KindDocument: {maxBytes: 20 << 20, types: []string{"application/pdf", "image/jpeg", "image/png"}},
+	KindReceipt:  {maxBytes: 10 << 20, types: []string{"application/pdf", "image/jpeg", "image/png", "image/webp", "image/heic"}},
+}
+
+var extensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+	"image/heic":      ".heic",
+	"application/pdf": ".pdf",
+	"application/zip": ".zip",
+}
+
+// sniffLen is how much of a body is read to detect its type
+const sniffLen = 512
+
+// Detect sniffs the content type from the start of body. The returned reader replays
+// the sniffed bytes ahead of the rest of body, so the body is still read only once.
+func Detect(body io.Reader) (string, io.Reader, error) {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(body, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	head = head[:n]
+	return sniff(head), io.MultiReader(bytes.NewReader(head), body), nil
+}
+
+// sniff detects a content type, adding HEIC (iPhone photos) to what net/http knows
+func sniff(head []byte) string {
+	if len(head) >= 12 && string(head[4:8]) == "ftyp" {
+		switch string(head[8:12]) {
+		case "heic", "heix", "heim", "heis", "mif1":
+			return "image/heic"
+		}
+	}
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return "application/octet-stream"
+	}
+	return contentType
+}
+
+// MaxBytes is the size limit of an attachment kind, or 0 for unknown kinds
+func MaxBytes(kind string) int64 {
+	return kindRules[kind].maxBytes
+}
+
+// MaxUploadBytes is the size limit of the largest attachment kind, which bounds uploads
+// to signed URLs before their kind is checked
+func MaxUploadBytes() int64 {
+	var largest int64
+	for _, rule := range kindRules {
+		largest = max(largest, rule.maxBytes)
+	}
+	return largest
+}
+
+// Validate checks a sniffed content type and size against an attachment kind
+func Validate(kind, contentType string, size int64) error {
+	rule, ok := kindRules[kind]
+	if !ok {
+		return fmt.Errorf("unknown attachment kind %q", kind)
+	}
+	if size <= 0 {
+		return fmt.Errorf("%w: attachment is empty", ErrUnsupportedType)
+	}
+	if size > rule.maxBytes {
+		return fmt.Errorf("%w: %s attachments are limited to %d MB", ErrTooLarge, kind, rule.maxBytes>>20)
+	}
+	for _, allowed := range rule.types {
+		if contentType == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s attachments cannot be %s", ErrUnsupportedType, kind, contentType)
+}
+
+// Upload is an attachment to store
+type Upload struct {
+	Kind         string
+	Prefix       string // Groups the object's key, e.g. "jobs/42"
+	Body         io.Reader
+	Size         int64   // Exact size in bytes, e.g. from Content-Length or a multipart header
+	DeclaredType string  // Content-Type the client sent, if any
+	Scanner      Scanner // Scans the content before it is stored; nil skips scanning
+}
+
+// Save sniffs, validates, scans and streams an attachment into the store. This is the
+// one upload path for job photos, documents and receipts. Infected uploads are stored
+// under QuarantinePrefix; callers check the returned object's ScanStatus and must not
+// serve anything but clean or skipped objects.
+func Save(ctx context.Context, store Store, u Upload) (*Object, error) {
+	contentType, body, err := Detect(u.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := Validate(u.Kind, contentType, u.Size); err != nil {
+		return nil, err
+	}
+	if declared, _, err := mime.ParseMediaType(u.DeclaredType); err == nil &&
+		declared != "application/octet-stream" && declared != contentType {
+		return nil, fmt.Errorf("%w: declared %s, content is %s", ErrTypeMismatch, declared, contentType)
+	}
+
+	key, err := NewKey(u.Prefix, u.Kind, contentType)
+	if err != nil {
+		return nil, err
+	}
+	obj := &Object{Key: key, ContentType: contentType, Size: u.Size, ScanStatus: ScanSkipped}
+	if u.Scanner != nil {
+		content, cleanup, err := rewindable(u.Body, body)
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+
+		scanObject(ctx, u.Scanner, obj, content)
+		if _, err := content.Seek(0, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("failed to rewind attachment: %w", err)
+		}
+		body = content
+	}
+
+	if err := store.Put(ctx, obj.Key, body, u.Size, contentType); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
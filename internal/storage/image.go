@@ -0,0 +1,182 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+)
+
+// Photos are checked for their pixel dimensions and get a JPEG thumbnail. Only JPEG and
+// PNG can be decoded with the standard library; WebP and HEIC photos are stored without
+// dimensions or a thumbnail, and clients show the full image instead.
+const (
+	// ThumbnailMaxSide is the longer side of generated thumbnails, in pixels
+	ThumbnailMaxSide = 320
+	// MaxImagePixels bounds the images decoded for thumbnails, so small files that
+	// expand to huge bitmaps are refused before decoding
+	MaxImagePixels = 50_000_000
+
+	thumbnailSuffix  = "_thumb.jpg"
+	thumbnailQuality = 80
+
+	// thumbnailSamples is how many source pixels along each axis are averaged into one
+	// thumbnail pixel, which keeps scaling cost independent of the photo's size
+	thumbnailSamples = 4
+)
+
+// ErrImageDimensions is returned for photos that are too small or too large in pixels
+var ErrImageDimensions = errors.New("unsupported image dimensions")
+
+var imageDecoders = map[string]struct {
+	config func(io.Reader) (image.Config, error)
+	decode func(io.Reader) (image.Image, error)
+}{
+	"image/jpeg": {jpeg.DecodeConfig, jpeg.Decode},
+	"image/png":  {png.DecodeConfig, png.Decode},
+}
+
+// ThumbnailKey is where the thumbnail of the object at key is stored
+func ThumbnailKey(key string) string {
+	return strings.TrimSuffix(key, path.Ext(key)) + thumbnailSuffix
+}
+
+// CheckDimensions reads an image's header and checks that its shorter side is at least
+// minSide pixels and that it is at most MaxImagePixels. It returns 0, 0 and no error for
+// types it cannot decode.
+func CheckDimensions(r io.Reader, contentType string, minSide int) (width, height int, err error) {
+	decoder, ok := imageDecoders[contentType]
+	if !ok {
+		return 0, 0, nil
+	}
+	cfg, err := decoder.config(r)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %s is not a valid image", ErrUnsupportedType, contentType)
+	}
+	if min(cfg.Width, cfg.Height) < minSide {
+		return 0, 0, fmt.Errorf("%w: photos must be at least %dx%d pixels, got %dx%d", ErrImageDimensions, minSide, minSide, cfg.Width, cfg.Height)
+	}
+	if cfg.Width*cfg.Height > MaxImagePixels {
+		return 0, 0, fmt.Errorf("%w: photos are limited to %d megapixels", ErrImageDimensions, MaxImagePixels/1_000_000)
+	}
+	return cfg.Width, cfg.Height, nil
+}
+
+// Thumbnail scales an image down so its longer side is at most maxSide pixels and
+// encodes it as JPEG. Smaller images keep their size.
+func Thumbnail(r io.Reader, contentType string, maxSide int) ([]byte, error) {
+	decoder, ok := imageDecoders[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot decode %s", ErrUnsupportedType, contentType)
+	}
+	src, err := decoder.decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if longer := max(width, height); longer > maxSide {
+		width = max(1, width*maxSide/longer)
+		height = max(1, height*maxSide/longer)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			dst.Set(x, y, averagePixel(src, bounds, x, y, width, height))
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// averagePixel averages a grid of samples from the area of src that thumbnail pixel
+// (x, y) covers. Transparent areas are flattened onto white, since JPEG has no alpha.
+func averagePixel(src image.Image, bounds image.Rectangle, x, y, width, height int) color.Color {
+	x0, x1 := bounds.Min.X+x*bounds.Dx()/width, bounds.Min.X+(x+1)*bounds.Dx()/width
+	y0, y1 := bounds.Min.Y+y*bounds.Dy()/height, bounds.Min.Y+(y+1)*bounds.Dy()/height
+
+	var r, g, b, n uint64
+	for sy := 0; sy < thumbnailSamples; sy++ {
+		py := y0 + (y1-y0)*sy/thumbnailSamples
+		for sx := 0; sx < thumbnailSamples; sx++ {
+			px := x0 + (x1-x0)*sx/thumbnailSamples
+			cr, cg, cb, ca := src.At(px, py).RGBA()
+			white := 0xffff - uint64(ca)
+			r += uint64(cr) + white
+			g += uint64(cg) + white
+			b += uint64(cb) + white
+			n++
+		}
+	}
+	return color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: 0xffff}
+}
+
+// SavePhoto saves a photo like Save once its dimensions are checked, then stores a JPEG
+// thumbnail at ThumbnailKey. The thumbnail is re-encoded pixels, so it is made even when
+// the scan failed; infected photos get none. A thumbnail that cannot be made is logged
+// and the photo is kept without one.
+func SavePhoto(ctx context.Context, store Store, u Upload, minSide int) (*Object, error) {
+	contentType, body, err := Detect(u.Body)
+	if err != nil {
+		return nil, err
+	}
+	content, cleanup, err := rewindable(u.Body, body)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	width, height, err := CheckDimensions(content, contentType, minSide)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to rewind attachment: %w", err)
+	}
+
+	u.Body = content
+	obj, err := Save(ctx, store, u)
+	if err != nil {
+		return nil, err
+	}
+	obj.Width, obj.Height = width, height
+	if width == 0 || obj.ScanStatus == ScanInfected {
+		return obj, nil
+	}
+
+	if err := saveThumbnail(ctx, store, obj, content); err != nil {
+		slog.ErrorContext(ctx, "Failed to save thumbnail", "key", obj.Key, "error", err)
+	}
+	return obj, nil
+}
+
+// saveThumbnail stores the thumbnail of a saved photo and records its key on obj
+func saveThumbnail(ctx context.Context, store Store, obj *Object, content io.ReadSeeker) error {
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind attachment: %w", err)
+	}
+	thumbnail, err := Thumbnail(content, obj.ContentType, ThumbnailMaxSide)
+	if err != nil {
+		return err
+	}
+	key := ThumbnailKey(obj.Key)
+	if err := store.Put(ctx, key, bytes.NewReader(thumbnail), int64(len(thumbnail)), "image/jpeg"); err != nil {
+		return err
+	}
+	obj.ThumbnailKey = key
+	return nil
+}
@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalFilesPath is where the API serves local signed downloads
+const LocalFilesPath = "/files/"
+
+// ErrInvalidSignature is returned for local download URLs that are forged or expired
+var ErrInvalidSignature = errors.New("invalid or expired signature")
+
+// LocalStore keeps objects on local disk, for development and single-host deploys.
+// Signed URLs point at the API's /files/ route, which checks an HMAC over the key
+// and expiry before serving the file, or storing it for upload URLs.
+type LocalStore struct {
+	dir        string
+	publicURL  string
+	signingKey []byte
+	now        func() time.Time
+}
+
+// NewLocalStore creates a store rooted at dir
+func NewLocalStore(dir, publicURL string, signingKey []byte) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &LocalStore{
+		dir:        dir,
+		publicURL:  strings.TrimRight(publicURL, "/"),
+		signingKey: signingKey,
+		now:        time.Now,
+	}, nil
+}
+
+// Name returns the backend key
+func (s *LocalStore) Name() string {
+	return BackendLocal
+}
+
+// Put writes body to a temporary file and renames it into place, so readers never
+// see a partial object
+func (s *LocalStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	target := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return fmt.Errorf("failed to create object directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create object: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if size >= 0 {
+		body = io.LimitReader(body, size+1)
+	}
+	n, err := io.Copy(tmp, body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	if size >= 0 && n != size {
+		return fmt.Errorf("object is %d bytes, expected %d", n, size)
+	}
+
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return fmt.Errorf("failed to store object: %w", err)
+	}
+	return nil
+}
+
+// Get opens an object. The content type is sniffed again, which matches what Save
+// validated on upload.
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	if err := validateKey(key); err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open object: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, fmt.Errorf("failed to stat object: %w", err)
+	}
+	contentType, body, err := Detect(f)
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	return readCloser{Reader: body, Closer: f}, &Object{Key: key, ContentType: contentType, Size: info.Size()}, nil
+}
+
+// Delete removes an object's file
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	return nil
+}
+
+// SignedURL returns a /files/ URL carrying the key's expiry and signature
+func (s *LocalStore) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	if err := validateTTL(ttl); err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(s.now().Add(ttl).Unix(), 10)
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.signWith(s.signingKey, key, expires))
+	return s.publicURL + LocalFilesPath + escapeKey(key) + "?" + query.Encode(), nil
+}
+
+// SignedUploadURL returns a /files/ URL the API accepts a PUT of the object at. It is
+// signed with a key derived for uploads, so download links cannot be used to upload.
+func (s *LocalStore) SignedUploadURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	if err := validateTTL(ttl); err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(s.now().Add(ttl).Unix(), 10)
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.signWith(s.uploadKey(), key, expires))
+	return s.publicURL + LocalFilesPath + escapeKey(key) + "?" + query.Encode(), nil
+}
+
+// Verify checks a signed URL's expiry and signature for key
+func (s *LocalStore) Verify(key, expires, signature string) error {
+	return s.verify(s.signingKey, key, expires, signature)
+}
+
+// VerifyUpload checks a signed upload URL's expiry and signature for key
+func (s *LocalStore) VerifyUpload(key, expires, signature string) error {
+	return s.verify(s.uploadKey(), key, expires, signature)
+}
+
+func (s *LocalStore) verify(signingKey []byte, key, expires, signature string) error {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || s.now().Unix() > unix {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.signWith(signingKey, key, expires))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *LocalStore) signWith(signingKey []byte, key, expires string) string {
+	mac := hmac.New(sha256.New, signingKey)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// uploadKey is the signing key for upload URLs
+func (s *LocalStore) uploadKey() []byte {
+	return hmacSHA256(s.signingKey, "upload")
+}
+
+// escapeKey escapes each segment of a key for use in a URL path
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,300 @@
+package storage
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"app/config"
+)
+
+// Supported malware scanners, selected with STORAGE_SCAN_BACKEND
+const (
+	ScanBackendNone   = "none"
+	ScanBackendClamAV = "clamav"
+)
+
+// Scan statuses recorded for attachments. Only clean and skipped attachments may be
+// served; skipped means scanning is turned off.
+const (
+	ScanClean    = "clean"
+	ScanInfected = "infected"
+	ScanFailed   = "failed" // The scanner could not check the file; rescan it
+	ScanSkipped  = "skipped"
+)
+
+// QuarantinePrefix is where infected attachments are kept, apart from servable objects
+const QuarantinePrefix = "quarantine/"
+
+// Scanner checks attachment content for malware
+type Scanner interface {
+	// Name is the scanner's key
+	Name() string
+
+	// Scan reads body to the end and returns the name of the threat found, or ""
+	// when the content is clean
+	Scan(ctx context.Context, body io.Reader) (string, error)
+}
+
+// NewScanner creates the scanner selected by the configuration. It returns nil when
+// scanning is turned off.
+func NewScanner(cfg *config.StorageConfig) (Scanner, error) {
+	switch cfg.ScanBackend {
+	case ScanBackendNone, "":
+		return nil, nil
+	case ScanBackendClamAV:
+		return NewClamAVScanner(cfg.ClamAVAddress), nil
+	default:
+		return nil, fmt.Errorf("unknown scan backend %q", cfg.ScanBackend)
+	}
+}
+
+// NewScannerFromEnv creates the scanner configured by STORAGE_SCAN_BACKEND
+func NewScannerFromEnv() (Scanner, error) {
+	return NewScanner(config.LoadStorageConfig())
+}
+
+// QuarantineKey is where an infected object at key is moved
+func QuarantineKey(key string) string {
+	if strings.HasPrefix(key, QuarantinePrefix) {
+		return key
+	}
+	return QuarantinePrefix + key
+}
+
+// clamdChunkSize is how much of a file is sent to clamd per INSTREAM chunk
+const clamdChunkSize = 64 << 10
+
+// ClamAVScanner scans with a clamd daemon over TCP using the INSTREAM command
+type ClamAVScanner struct {
+	address string
+	timeout time.Duration
+}
+
+// NewClamAVScanner creates a scanner for the clamd listening at address
+func NewClamAVScanner(address string) *ClamAVScanner {
+	return &ClamAVScanner{
+		address: address,
+		timeout: 2 * time.Minute,
+	}
+}
+
+// Name returns the scanner key
+func (s *ClamAVScanner) Name() string {
+	return ScanBackendClamAV
+}
+
+// Scan streams body to clamd in length-prefixed chunks and reads its verdict
+func (s *ClamAVScanner) Scan(ctx context.Context, body io.Reader) (string, error) {
+	dialer := net.Dialer{Timeout: 10 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(s.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return "", fmt.Errorf("failed to set clamd deadline: %w", err)
+	}
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return "", fmt.Errorf("failed to start clamd scan: %w", err)
+	}
+	chunk := make([]byte, 4+clamdChunkSize)
+	for {
+		n, readErr := body.Read(chunk[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(chunk[:4], uint32(n))
+			if _, err := conn.Write(chunk[:4+n]); err != nil {
+				return "", fmt.Errorf("failed to send file to clamd: %w", err)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return "", fmt.Errorf("failed to read file for scanning: %w", readErr)
+		}
+	}
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return "", fmt.Errorf("failed to finish clamd scan: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil {
+		return "", fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+	return parseClamdReply(strings.TrimSuffix(reply, "\x00"))
+}
+
+// parseClamdReply reads a verdict such as "stream: OK" or "stream: Eicar-Test-Signature FOUND"
+func parseClamdReply(reply string) (string, error) {
+	verdict := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+	switch {
+	case verdict == "OK":
+		return "", nil
+	case strings.HasSuffix(verdict, " FOUND"):
+		return strings.TrimSuffix(verdict, " FOUND"), nil
+	default:
+		return "", fmt.Errorf("clamd error: %s", verdict)
+	}
+}
+
+// scanObject scans content and picks where it belongs: its own key when clean, the
+// quarantine when infected. Scanner errors are recorded as failed rather than
+// returned, so the upload is kept and can be rescanned.
+func scanObject(ctx context.Context, scanner Scanner, obj *Object, content io.Reader) {
+	threat, err := scanner.Scan(ctx, io.LimitReader(content, obj.Size))
+	switch {
+	case err != nil:
+		slog.ErrorContext(ctx, "Scan failed", "key", obj.Key, "scanner", scanner.Name(), "error", err)
+		obj.ScanStatus = ScanFailed
+	case threat != "":
+		slog.WarnContext(ctx, "Quarantining infected file", "key", obj.Key, "threat", threat)
+		obj.ScanStatus = ScanInfected
+		obj.Threat = threat
+		obj.Key = QuarantineKey(obj.Key)
+	default:
+		obj.ScanStatus = ScanClean
+	}
+}
+
+// Rescan scans a stored object again, e.g. after the scanner was unavailable on
+// upload. Infected objects are moved to the quarantine; the returned object has the
+// new key and status.
+func Rescan(ctx context.Context, store Store, scanner Scanner, key string) (*Object, error) {
+	body, obj, err := store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	content, err := spool(body)
+	body.Close()
+	if err != nil {
+		return nil, err
+	}
+	defer removeSpool(content)
+
+	scanObject(ctx, scanner, obj, content)
+	if obj.Key == key {
+		return obj, nil
+	}
+
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to rewind attachment: %w", err)
+	}
+	if err := store.Put(ctx, obj.Key, content, obj.Size, obj.ContentType); err != nil {
+		return nil, err
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// Accept checks an object a client uploaded with a signed upload URL as Save checks
+// uploads through the API, then moves it out of IncomingPrefix: to the same key
+// without the prefix, or to the quarantine when it is infected. Objects of the wrong
+// type or size for kind are deleted. scanner may be nil when scanning is turned off.
+func Accept(ctx context.Context, store Store, scanner Scanner, kind, key string) (*Object, error) {
+	if !strings.HasPrefix(key, IncomingPrefix) {
+		return nil, ErrInvalidKey
+	}
+	body, _, err := store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	content, err := spool(body)
+	body.Close()
+	if err != nil {
+		return nil, err
+	}
+	defer removeSpool(content)
+
+	info, err := content.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat attachment: %w", err)
+	}
+	contentType, _, err := Detect(content)
+	if err != nil {
+		return nil, err
+	}
+	err = Validate(kind, contentType, info.Size())
+	if err == nil && path.Ext(key) != extensions[contentType] {
+		err = fmt.Errorf("%w: uploaded for %s, content is %s", ErrTypeMismatch, path.Ext(key), contentType)
+	}
+	if err != nil {
+		if deleteErr := store.Delete(ctx, key); deleteErr != nil {
+			slog.WarnContext(ctx, "Failed to delete rejected upload", "key", key, "error", deleteErr)
+		}
+		return nil, err
+	}
+
+	obj := &Object{Key: strings.TrimPrefix(key, IncomingPrefix), ContentType: contentType, Size: info.Size(), ScanStatus: ScanSkipped}
+	if scanner != nil {
+		if _, err := content.Seek(0, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("failed to rewind attachment: %w", err)
+		}
+		scanObject(ctx, scanner, obj, content)
+	}
+
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to rewind attachment: %w", err)
+	}
+	if err := store.Put(ctx, obj.Key, content, obj.Size, obj.ContentType); err != nil {
+		return nil, err
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// rewindable returns the upload as a reader that can be read again after scanning.
+// Bodies that seek (e.g. multipart files) are rewound; others are spooled to a
+// temporary file, as the store and the scanner both need the whole content.
+func rewindable(original, sniffed io.Reader) (io.ReadSeeker, func(), error) {
+	if seeker, ok := original.(io.ReadSeeker); ok {
+		if _, err := seeker.Seek(0, io.SeekStart); err == nil {
+			return seeker, func() {}, nil
+		}
+	}
+	f, err := spool(sniffed)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, func() { removeSpool(f) }, nil
+}
+
+// spool copies body to a temporary file positioned at its start
+func spool(body io.Reader) (*os.File, error) {
+	f, err := os.CreateTemp("", "attachment-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to buffer attachment: %w", err)
+	}
+	if _, err := io.Copy(f, body); err != nil {
+		removeSpool(f)
+		return nil, fmt.Errorf("failed to buffer attachment: %w", err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		removeSpool(f)
+		return nil, fmt.Errorf("failed to buffer attachment: %w", err)
+	}
+	return f, nil
+}
+
+func removeSpool(f *os.File) {
+	f.Close()
+	os.Remove(f.Name())
+}
@@ -0,0 +1,150 @@
+// Package storage keeps attachments (job photos, documents, receipts) in an object
+// store. Backends are selected with STORAGE_BACKEND: local disk for development, S3,
+// or GCS. Uploads are streamed to the backend without buffering the file, after the
+// content type is sniffed and checked against what the attachment kind allows. With
+// STORAGE_SCAN_BACKEND set, uploads are also scanned for malware before they are
+// stored, and infected files are quarantined. Large files can instead be uploaded
+// straight to the store with a signed upload URL and checked afterwards with Accept.
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+
+	"app/config"
+)
+
+// Supported storage backends, selected with STORAGE_BACKEND
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+	BackendGCS   = "gcs"
+)
+
+// MaxSignedURLTTL is the longest a signed URL may stay valid (the S3 limit)
+const MaxSignedURLTTL = 7 * 24 * time.Hour
+
+var (
+	// ErrNotFound is returned when an object does not exist
+	ErrNotFound = errors.New("object not found")
+	// ErrInvalidKey is returned for keys that are empty or escape the store's root
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+// IncomingPrefix is where clients upload with signed upload URLs. Objects under it are
+// unchecked and never served.
+const IncomingPrefix = "incoming/"
+
+// Store is an object store for attachments
+type Store interface {
+	// Name is the backend's key
+	Name() string
+
+	// Put streams body to key. size is the exact number of bytes in body; S3 and
+	// GCS need it up front to stream without buffering.
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+
+	// Get opens an object for reading; the caller closes it
+	Get(ctx context.Context, key string) (io.ReadCloser, *Object, error)
+
+	// SignedURL returns a URL that downloads the object without other credentials
+	// until ttl has passed
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+
+	// SignedUploadURL returns a URL that accepts a PUT of the object's content without
+	// other credentials until ttl has passed. Keys given out for uploads must be under
+	// IncomingPrefix, since the URL can be replayed until it expires; Accept moves the
+	// upload out once it has been checked.
+	SignedUploadURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+
+	// Delete removes an object. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// Object describes a stored object
+type Object struct {
+	Key         string `json:"key"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+
+	// Set by Save and Rescan
+	ScanStatus string `json:"scan_status,omitempty"`
+	Threat     string `json:"threat,omitempty"`
+
+	// Set by SavePhoto for images it can decode
+	Width        int    `json:"width,omitempty"`
+	Height       int    `json:"height,omitempty"`
+	ThumbnailKey string `json:"thumbnail_key,omitempty"`
+}
+
+// NewStore creates the store selected by the configuration
+func NewStore(cfg *config.StorageConfig) (Store, error) {
+	switch cfg.Backend {
+	case BackendLocal, "":
+		key := []byte(cfg.SigningKey)
+		if len(key) == 0 {
+			slog.Warn("STORAGE_SIGNING_KEY not set, local download URLs will not survive a restart")
+			key = make([]byte, 32)
+			if _, err := rand.Read(key); err != nil {
+				return nil, fmt.Errorf("failed to generate signing key: %w", err)
+			}
+		}
+		return NewLocalStore(cfg.LocalDir, cfg.PublicURL, key)
+	case BackendS3:
+		return NewS3Store(cfg)
+	case BackendGCS:
+		return NewGCSStore(cfg)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Backend)
+	}
+}
+
+// NewStoreFromEnv creates the store configured by STORAGE_* environment variables
+func NewStoreFromEnv() (Store, error) {
+	return NewStore(config.LoadStorageConfig())
+}
+
+// NewKey returns a unique key for an attachment under prefix (e.g. "jobs/42"),
+// grouped by kind and carrying an extension for its content type
+func NewKey(prefix, kind, contentType string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate object key: %w", err)
+	}
+	return path.Join(prefix, kind+"s", hex.EncodeToString(b)+extensions[contentType]), nil
+}
+
+// NewUploadKey returns a unique key under IncomingPrefix for a client to upload an
+// attachment of kind and contentType to with a signed upload URL
+func NewUploadKey(prefix, kind, contentType string) (string, error) {
+	return NewKey(IncomingPrefix+prefix, kind, contentType)
+}
+
+// validateKey rejects keys that are empty, absolute or contain relative segments
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
+
+// validateTTL bounds signed URL lifetimes
+func validateTTL(ttl time.Duration) error {
+	if ttl <= 0 || ttl > MaxSignedURLTTL {
+		return fmt.Errorf("signed URL ttl must be between 0 and %s", MaxSignedURLTTL)
+	}
+	return nil
+}
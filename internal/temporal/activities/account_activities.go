@@ -0,0 +1,400 @@
+package activities
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+
+	"app/internal/audit"
+	"app/internal/clock"
+	"app/internal/dataexport"
+	"app/internal/email"
+	"app/internal/storage"
+	"app/internal/temporal/workflows"
+)
+
+// accountExportLinkTTL is how long the emailed data export link works
+const accountExportLinkTTL = storage.MaxSignedURLTTL
+
+// AccountActivities contains account lifecycle activities
+type AccountActivities struct {
+	db    *sql.DB
+	clock clock.Clock
+}
+
+// NewAccountActivities creates a new AccountActivities instance
+func NewAccountActivities(db *sql.DB) *AccountActivities {
+	return &AccountActivities{db: db, clock: clock.System}
+}
+
+// deletionPending reports whether the deletion request is still in its hold period
+func (a *AccountActivities) deletionPending(ctx context.Context, requestID int) (bool, error) {
+	var status string
+	err := a.db.QueryRowContext(ctx,
+		`SELECT status FROM account_deletion_requests WHERE id = $1`, requestID,
+	).Scan(&status)
+	if err == sql.ErrNoRows {
+		// Withdrawn because its workflow did not start, or never committed
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get deletion request: %w", err)
+	}
+	return status == "pending", nil
+}
+
+// SendAccountWinBack reminds a user their account will be erased and how to keep it
+func (a *AccountActivities) SendAccountWinBack(ctx context.Context, input workflows.AccountDeletionInput) error {
+	pending, err := a.deletionPending(ctx, input.RequestID)
+	if err != nil {
+		return err
+	}
+	if !pending {
+		slog.InfoContext(ctx, "Deletion request no longer pending, skipping win-back", "deletion_request_id", input.RequestID)
+		return nil
+	}
+
+	var to, name string
+	var purgeAfter sql.NullTime
+	err = a.db.QueryRowContext(ctx, `
+		SELECT p.email, p.name, d.purge_after
+		FROM account_deletion_requests d
+		JOIN people p ON p.id = d.user_id
+		WHERE d.id = $1
+	`, input.RequestID).Scan(&to, &name, &purgeAfter)
+	if err != nil {
+		return fmt.Errorf("failed to get user for win-back: %w", err)
+	}
+
+	emailService, err := email.NewServiceFromEnv()
+	if err != nil {
+		return fmt.Errorf("email service not configured: %w", err)
+	}
+
+	baseURL := os.Getenv("APP_BASE_URL")
+	if baseURL == "" {
+		baseURL = "https://app.gigco.com"
+	}
+
+	if err := emailService.SendAccountWinBack(to, name, purgeAfter.Time, baseURL+"/reactivate"); err != nil {
+		return fmt.Errorf("failed to send win-back email: %w", err)
+	}
+
+	_, err = a.db.ExecContext(ctx,
+		`UPDATE account_deletion_requests SET winback_sent_at = NOW() WHERE id = $1`, input.RequestID)
+	if err != nil {
+		return fmt.Errorf("failed to record win-back: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Win-back notification sent for user", "user_id", input.UserID)
+	return nil
+}
+
+// ExportAccountData stores an archive of the user's data and emails them a link to it,
+// before the hold ends and the data is erased. A retry reuses the stored archive and
+// only resends the link if it was not sent.
+func (a *AccountActivities) ExportAccountData(ctx context.Context, input workflows.AccountDeletionInput) error {
+	pending, err := a.deletionPending(ctx, input.RequestID)
+	if err != nil {
+		return err
+	}
+	if !pending {
+		slog.InfoContext(ctx, "Deletion request no longer pending, skipping data export", "deletion_request_id", input.RequestID)
+		return nil
+	}
+
+	var to, name string
+	var exportKey sql.NullString
+	var purgeAfter sql.NullTime
+	var sentAt sql.NullTime
+	err = a.db.QueryRowContext(ctx, `
+		SELECT p.email, p.name, d.export_key, d.purge_after, d.export_sent_at
+		FROM account_deletion_requests d
+		JOIN people p ON p.id = d.user_id
+		WHERE d.id = $1
+	`, input.RequestID).Scan(&to, &name, &exportKey, &purgeAfter, &sentAt)
+	if err != nil {
+		return fmt.Errorf("failed to get user for data export: %w", err)
+	}
+	if sentAt.Valid {
+		return nil
+	}
+
+	store, err := storage.NewStoreFromEnv()
+	if err != nil {
+		return fmt.Errorf("storage not configured: %w", err)
+	}
+
+	if !exportKey.Valid {
+		key, err := a.storeExport(ctx, store, input.UserID, dataexport.FormatJSON)
+		if err != nil {
+			return err
+		}
+		tx, err := a.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to start export transaction: %w", err)
+		}
+		defer tx.Rollback()
+		_, err = tx.ExecContext(ctx, `
+			UPDATE account_deletion_requests SET export_key = $2, exported_at = NOW() WHERE id = $1
+		`, input.RequestID, key)
+		if err != nil {
+			return fmt.Errorf("failed to record data export: %w", err)
+		}
+		err = audit.Record(ctx, tx, audit.Entry{
+			Action:     audit.ActionAccountDataExported,
+			EntityType: audit.EntityUser,
+			EntityID:   strconv.Itoa(input.UserID),
+			Metadata:   map[string]interface{}{"deletion_request_id": input.RequestID, "files": len(dataexport.Sections)},
+		})
+		if err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit data export: %w", err)
+		}
+		exportKey = sql.NullString{String: key, Valid: true}
+	}
+
+	link, err := store.SignedURL(ctx, exportKey.String, accountExportLinkTTL)
+	if err != nil {
+		return fmt.Errorf("failed to sign data export link: %w", err)
+	}
+	emailService, err := email.NewServiceFromEnv()
+	if err != nil {
+		return fmt.Errorf("email service not configured: %w", err)
+	}
+	if err := emailService.SendAccountDataExport(to, name, link, a.clock.Now().Add(accountExportLinkTTL), purgeAfter.Time); err != nil {
+		return fmt.Errorf("failed to send data export email: %w", err)
+	}
+
+	_, err = a.db.ExecContext(ctx,
+		`UPDATE account_deletion_requests SET export_sent_at = NOW() WHERE id = $1`, input.RequestID)
+	if err != nil {
+		return fmt.Errorf("failed to record data export email: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Data export sent for user", "user_id", input.UserID)
+	return nil
+}
+
+// storeExport writes the user's archive to a temporary file, since stores need its
+// size up front, and uploads it
+func (a *AccountActivities) storeExport(ctx context.Context, store storage.Store, userID int, format dataexport.Format) (string, error) {
+	f, err := os.CreateTemp("", "account-export-*.zip")
+	if err != nil {
+		return "", fmt.Errorf("failed to create data export file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if err := dataexport.Write(ctx, a.db, a.clock, userID, format, f); err != nil {
+		return "", err
+	}
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", fmt.Errorf("failed to size data export: %w", err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to rewind data export: %w", err)
+	}
+
+	key, err := storage.NewKey(fmt.Sprintf("users/%d", userID), "export", dataexport.ContentType)
+	if err != nil {
+		return "", err
+	}
+	if err := store.Put(ctx, key, f, size, dataexport.ContentType); err != nil {
+		return "", fmt.Errorf("failed to store data export: %w", err)
+	}
+	return key, nil
+}
+
+// personalAttachments selects the attachments holding a user's personal data: their
+// verification documents, profile photo and the job photos they took
+const personalAttachments = `
+	SELECT attachment_id FROM worker_documents WHERE worker_id = $1
+	UNION SELECT avatar_attachment_id FROM people WHERE id = $1 AND avatar_attachment_id IS NOT NULL
+	UNION SELECT attachment_id FROM job_photos WHERE uploaded_by = $1`
+
+// purgeStatements erase a user's personal data, given their ID, in one transaction.
+// The person is anonymized last since earlier statements look them up by email.
+var purgeStatements = []struct {
+	desc  string
+	query string
+}{
+	{"emergency contacts", `DELETE FROM gigworker_emergency_contacts WHERE gigworker_id = $1`},
+	{"waitlist signups", `
+		DELETE FROM waitlist_signups WHERE LOWER(email) = (SELECT LOWER(email) FROM people WHERE id = $1)`},
+	{"notifications", `DELETE FROM notifications WHERE user_id = $1`},
+	{"notification deliveries", `DELETE FROM notification_deliveries WHERE user_id = $1`},
+	{"notification preferences", `DELETE FROM notification_preferences WHERE user_id = $1`},
+	{"devices", `DELETE FROM user_devices WHERE user_id = $1`},
+	{"sessions", `DELETE FROM user_sessions WHERE user_id = $1`}, // Their refresh_tokens cascade
+	{"phone verification codes", `DELETE FROM phone_verification_codes WHERE user_id = $1`},
+	{"payment methods", `DELETE FROM user_payment_methods WHERE user_id = $1`},
+	{"accounting connections", `DELETE FROM accounting_connections WHERE user_id = $1`},
+	{"data exports", `DELETE FROM data_exports WHERE user_id = $1`},
+	{"job templates", `DELETE FROM job_templates WHERE consumer_id = $1`},
+	// Deleting the attachments deletes their worker_documents and job_photos rows and
+	// clears the avatar
+	{"documents and photos", `DELETE FROM attachments WHERE id IN (` + personalAttachments + `)`},
+	{"worker documents", `DELETE FROM worker_documents WHERE worker_id = $1`},
+	{"worker applications", `
+		UPDATE worker_applications SET
+			address = '[deleted]', bio = NULL, skills = NULL, availability_notes = NULL, decision_note = NULL
+		WHERE user_id = $1`},
+	{"worker application notes", `
+		UPDATE worker_application_events SET note = NULL
+		WHERE note IS NOT NULL AND application_id IN (SELECT id FROM worker_applications WHERE user_id = $1)`},
+	{"account merge emails", `
+		UPDATE account_merges SET
+			source_email = CASE WHEN source_user_id = $1 THEN 'deleted-' || uuid || '@deleted.invalid' ELSE source_email END,
+			target_email = CASE WHEN target_user_id = $1 THEN 'deleted-' || uuid || '@deleted.invalid' ELSE target_email END
+		WHERE source_user_id = $1 OR target_user_id = $1`},
+	{"expense origins", `UPDATE job_expenses SET origin_latitude = NULL, origin_longitude = NULL WHERE gig_worker_id = $1`},
+	{"availability", `DELETE FROM schedules WHERE gig_worker_id = $1 AND job_id IS NULL`},
+	{"worker profile", `DELETE FROM worker_profiles WHERE worker_id = $1`},
+	{"review text", `UPDATE job_reviews SET review_text = NULL WHERE reviewer_id = $1`},
+	{"legacy review comments", `UPDATE reviews SET comment = NULL WHERE reviewer_id = $1`},
+	{"messages", `UPDATE job_messages SET body = '[deleted]' WHERE sender_id = $1`},
+	{"support ticket descriptions", `UPDATE support_tickets SET description = '[deleted]' WHERE opened_by = $1`},
+	{"legacy worker record", `
+		UPDATE gigworkers SET
+			name = 'Deleted User', email = 'deleted-' || uuid || '@deleted.invalid',
+			phone = NULL, address = NULL, latitude = NULL, longitude = NULL, place_id = NULL, bio = NULL,
+			emergency_contact_name = NULL, emergency_contact_phone = NULL, emergency_contact_relationship = NULL,
+			is_active = false, updated_at = NOW()
+		WHERE LOWER(email) = (SELECT LOWER(email) FROM people WHERE id = $1)`},
+	{"person", `
+		UPDATE people SET
+			name = 'Deleted User', email = 'deleted-' || uuid || '@deleted.invalid',
+			phone = NULL, address = NULL, latitude = NULL, longitude = NULL, place_id = NULL,
+			password_hash = NULL, is_active = false, updated_at = NOW()
+		WHERE id = $1`},
+}
+
+// PurgeAccount erases a user's personal data once the deletion hold expires.
+// The people row is anonymized rather than deleted so jobs, payments and reviews
+// the other party relies on stay intact.
+func (a *AccountActivities) PurgeAccount(ctx context.Context, input workflows.AccountDeletionInput) error {
+	pending, err := a.deletionPending(ctx, input.RequestID)
+	if err != nil {
+		return err
+	}
+	if !pending {
+		slog.InfoContext(ctx, "Deletion request no longer pending, skipping purge", "deletion_request_id", input.RequestID)
+		return nil
+	}
+
+	// Exports hold the same personal data and attachments are the user's documents and
+	// photos, so their files go first: a failure here is retried before anything is
+	// erased
+	exportKeys, err := a.exportKeys(ctx, input)
+	if err != nil {
+		return err
+	}
+	attachmentKeys, err := a.attachmentKeys(ctx, input.UserID)
+	if err != nil {
+		return err
+	}
+	if keys := append(exportKeys, attachmentKeys...); len(keys) > 0 {
+		store, err := storage.NewStoreFromEnv()
+		if err != nil {
+			return fmt.Errorf("storage not configured: %w", err)
+		}
+		for _, key := range keys {
+			if err := store.Delete(ctx, key); err != nil {
+				return fmt.Errorf("failed to delete stored file: %w", err)
+			}
+		}
+	}
+
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start purge transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range purgeStatements {
+		if _, err := tx.ExecContext(ctx, stmt.query, input.UserID); err != nil {
+			return fmt.Errorf("failed to purge %s: %w", stmt.desc, err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE account_deletion_requests SET status = 'purged', purged_at = NOW(), reason = NULL, export_key = NULL
+		WHERE id = $1
+	`, input.RequestID)
+	if err != nil {
+		return fmt.Errorf("failed to mark deletion request purged: %w", err)
+	}
+
+	err = audit.Record(ctx, tx, audit.Entry{
+		Action:     audit.ActionAccountErased,
+		EntityType: audit.EntityUser,
+		EntityID:   strconv.Itoa(input.UserID),
+		Metadata:   map[string]interface{}{"deletion_request_id": input.RequestID},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit purge: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Account purged", "user_id", input.UserID)
+	return nil
+}
+
+// attachmentKeys lists the stored files of the user's personal attachments and their
+// thumbnails
+func (a *AccountActivities) attachmentKeys(ctx context.Context, userID int) ([]string, error) {
+	rows, err := a.db.QueryContext(ctx, `
+		SELECT object_key FROM attachments WHERE id IN (`+personalAttachments+`)
+		UNION ALL
+		SELECT thumbnail_key FROM attachments WHERE id IN (`+personalAttachments+`) AND thumbnail_key IS NOT NULL
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// exportKeys lists the stored archives of the user's data: the one sent before
+// erasure and any they requested
+func (a *AccountActivities) exportKeys(ctx context.Context, input workflows.AccountDeletionInput) ([]string, error) {
+	rows, err := a.db.QueryContext(ctx, `
+		SELECT export_key FROM account_deletion_requests WHERE id = $1 AND export_key IS NOT NULL
+		UNION ALL
+		SELECT storage_key FROM data_exports WHERE user_id = $2 AND storage_key IS NOT NULL
+	`, input.RequestID, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data exports: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("failed to scan data export: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
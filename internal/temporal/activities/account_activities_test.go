@@ -0,0 +1,59 @@
+package activities
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestPurgeStatementsCoverPersonalData lists the tables holding personal data. A
+// table added to this list, or a new one with personal data, needs a purge statement.
+func TestPurgeStatementsCoverPersonalData(t *testing.T) {
+	tables := []string{
+		"people",
+		"gigworkers",
+		"gigworker_emergency_contacts",
+		"waitlist_signups",
+		"notifications",
+		"notification_deliveries",
+		"notification_preferences",
+		"user_devices",
+		"user_sessions",
+		"phone_verification_codes",
+		"user_payment_methods",
+		"accounting_connections",
+		"data_exports",
+		"job_templates",
+		"attachments",
+		"worker_documents",
+		"worker_applications",
+		"worker_application_events",
+		"account_merges",
+		"job_expenses",
+		"schedules",
+		"worker_profiles",
+		"job_reviews",
+		"reviews",
+		"job_messages",
+		"support_tickets",
+	}
+
+	for _, table := range tables {
+		found := false
+		for _, stmt := range purgeStatements {
+			if strings.Contains(stmt.query, "UPDATE "+table+" ") || strings.Contains(stmt.query, "DELETE FROM "+table+" ") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("no purge statement erases %s", table)
+		}
+	}
+
+	// Attachments are found through the rows that link them to the user
+	for _, link := range []string{"worker_documents", "avatar_attachment_id", "job_photos"} {
+		if !strings.Contains(personalAttachments, link) {
+			t.Errorf("personal attachments do not include those linked by %s", link)
+		}
+	}
+}
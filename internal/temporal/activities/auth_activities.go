@@ -0,0 +1,37 @@
+package activities
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"app/internal/auth"
+	"app/internal/vault"
+)
+
+// AuthActivities contains scheduled authentication maintenance activities
+type AuthActivities struct {
+	db *sql.DB
+}
+
+// NewAuthActivities creates a new AuthActivities instance
+func NewAuthActivities(db *sql.DB) *AuthActivities {
+	return &AuthActivities{db: db}
+}
+
+// RotateSigningKey adds a new JWT signing key and returns its kid. API instances load
+// it on their next refresh, before it starts signing.
+func (a *AuthActivities) RotateSigningKey(ctx context.Context) (string, error) {
+	v, err := vault.NewVaultFromEnv()
+	if err != nil {
+		return "", err
+	}
+
+	key, err := auth.RotateSigningKey(ctx, a.db, v, auth.KeyOverlap())
+	if err != nil {
+		return "", err
+	}
+
+	slog.InfoContext(ctx, "JWT signing key rotated", "key_id", key.ID, "signing_from", key.SigningFrom.Format("2006-01-02 15:04:05"))
+	return key.ID, nil
+}
@@ -0,0 +1,184 @@
+package activities
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"app/internal/audit"
+	"app/internal/dataexport"
+	"app/internal/email"
+	"app/internal/model"
+	"app/internal/storage"
+	"app/internal/temporal/workflows"
+)
+
+// BuildDataExport stores the archive of a data export the user requested and marks it
+// ready. An export no longer pending, because it was built already or the account was
+// erased, is left alone.
+func (a *AccountActivities) BuildDataExport(ctx context.Context, input workflows.DataExportInput) error {
+	var status string
+	err := a.db.QueryRowContext(ctx, `SELECT status FROM data_exports WHERE id = $1`, input.ExportID).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get data export: %w", err)
+	}
+	if status != model.DataExportStatusPending {
+		slog.InfoContext(ctx, "Data export no longer pending, skipping build", "data_export_id", input.ExportID, "status", status)
+		return nil
+	}
+
+	store, err := storage.NewStoreFromEnv()
+	if err != nil {
+		return fmt.Errorf("storage not configured: %w", err)
+	}
+	key, err := a.storeExport(ctx, store, input.UserID, dataexport.Format(input.Format))
+	if err != nil {
+		return err
+	}
+
+	ready, err := a.markDataExportReady(ctx, input, key)
+	if err != nil || !ready {
+		// Nothing refers to the archive, so it must not outlive this attempt
+		if deleteErr := store.Delete(ctx, key); deleteErr != nil {
+			slog.ErrorContext(ctx, "Failed to delete unused data export", "data_export_id", input.ExportID, "error", deleteErr)
+		}
+		return err
+	}
+
+	slog.InfoContext(ctx, "Data export ready for user", "user_id", input.UserID, "data_export_id", input.ExportID, "format", input.Format)
+	return nil
+}
+
+// markDataExportReady records the stored archive and its expiry, and audits the
+// export. ready is false when the export stopped being pending while it was built.
+func (a *AccountActivities) markDataExportReady(ctx context.Context, input workflows.DataExportInput, key string) (bool, error) {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to start data export transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE data_exports
+		SET status = 'ready', storage_key = $2, completed_at = NOW(), expires_at = NOW() + $3 * INTERVAL '1 second'
+		WHERE id = $1 AND status = 'pending'
+	`, input.ExportID, key, workflows.DataExportTTL.Seconds())
+	if err != nil {
+		return false, fmt.Errorf("failed to record data export: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return false, nil
+	}
+
+	err = audit.Record(ctx, tx, audit.Entry{
+		Action:     audit.ActionAccountDataExported,
+		EntityType: audit.EntityUser,
+		EntityID:   strconv.Itoa(input.UserID),
+		Metadata:   map[string]interface{}{"data_export_id": input.ExportID, "format": input.Format, "files": len(dataexport.Sections)},
+	})
+	if err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit data export: %w", err)
+	}
+	return true, nil
+}
+
+// SendDataExportReady emails the user a link to their ready export, valid until it
+// expires
+func (a *AccountActivities) SendDataExportReady(ctx context.Context, input workflows.DataExportInput) error {
+	var to, name, status string
+	var key sql.NullString
+	var expiresAt sql.NullTime
+	err := a.db.QueryRowContext(ctx, `
+		SELECT p.email, p.name, d.status, d.storage_key, d.expires_at
+		FROM data_exports d
+		JOIN people p ON p.id = d.user_id
+		WHERE d.id = $1
+	`, input.ExportID).Scan(&to, &name, &status, &key, &expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user for data export: %w", err)
+	}
+	if status != model.DataExportStatusReady || !key.Valid || !expiresAt.Valid {
+		return nil
+	}
+	ttl := time.Until(expiresAt.Time)
+	if ttl <= 0 {
+		return nil
+	}
+
+	store, err := storage.NewStoreFromEnv()
+	if err != nil {
+		return fmt.Errorf("storage not configured: %w", err)
+	}
+	link, err := store.SignedURL(ctx, key.String, ttl)
+	if err != nil {
+		return fmt.Errorf("failed to sign data export link: %w", err)
+	}
+	emailService, err := email.NewServiceFromEnv()
+	if err != nil {
+		return fmt.Errorf("email service not configured: %w", err)
+	}
+	if err := emailService.SendDataExportReady(to, name, link, expiresAt.Time); err != nil {
+		return fmt.Errorf("failed to send data export email: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Data export link sent to user", "user_id", input.UserID, "data_export_id", input.ExportID)
+	return nil
+}
+
+// FailDataExport marks an export that could not be built failed, so the user can
+// request another
+func (a *AccountActivities) FailDataExport(ctx context.Context, input workflows.DataExportInput) error {
+	_, err := a.db.ExecContext(ctx, `
+		UPDATE data_exports SET status = 'failed', completed_at = NOW(), error = 'The export could not be built'
+		WHERE id = $1 AND status = 'pending'
+	`, input.ExportID)
+	if err != nil {
+		return fmt.Errorf("failed to mark data export failed: %w", err)
+	}
+	return nil
+}
+
+// ExpireDataExport deletes an export's archive once its link has expired
+func (a *AccountActivities) ExpireDataExport(ctx context.Context, input workflows.DataExportInput) error {
+	var key sql.NullString
+	err := a.db.QueryRowContext(ctx, `SELECT storage_key FROM data_exports WHERE id = $1`, input.ExportID).Scan(&key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get data export: %w", err)
+	}
+
+	if key.Valid {
+		store, err := storage.NewStoreFromEnv()
+		if err != nil {
+			return fmt.Errorf("storage not configured: %w", err)
+		}
+		if err := store.Delete(ctx, key.String); err != nil {
+			return fmt.Errorf("failed to delete data export: %w", err)
+		}
+	}
+
+	_, err = a.db.ExecContext(ctx, `
+		UPDATE data_exports SET status = 'expired', storage_key = NULL WHERE id = $1 AND status = 'ready'
+	`, input.ExportID)
+	if err != nil {
+		return fmt.Errorf("failed to mark data export expired: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Data export expired", "user_id", input.UserID, "data_export_id", input.ExportID)
+	return nil
+}
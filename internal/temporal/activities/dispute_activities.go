@@ -0,0 +1,94 @@
+package activities
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"app/internal/notifications"
+	"app/internal/temporal/workflows"
+)
+
+// DisputeActivities contains payment dispute activities
+type DisputeActivities struct {
+	db *sql.DB
+}
+
+// NewDisputeActivities creates a new DisputeActivities instance
+func NewDisputeActivities(db *sql.DB) *DisputeActivities {
+	return &DisputeActivities{db: db}
+}
+
+// AlertDisputeOpened tells the payments channel a job's payment is held by a dispute
+func (a *DisputeActivities) AlertDisputeOpened(ctx context.Context, input workflows.DisputeInput) error {
+	return a.alertDispute(ctx, input, false)
+}
+
+// EscalateDispute reminds the payments channel of a dispute that has outlived its review SLA
+func (a *DisputeActivities) EscalateDispute(ctx context.Context, input workflows.DisputeInput) error {
+	return a.alertDispute(ctx, input, true)
+}
+
+func (a *DisputeActivities) alertDispute(ctx context.Context, input workflows.DisputeInput, overdue bool) error {
+	var uuid, reason, description, status, jobTitle string
+	var totalPay float64
+	err := a.db.QueryRowContext(ctx, `
+		SELECT d.uuid, d.reason, d.description, d.status, j.title, j.total_pay
+		FROM job_disputes d
+		JOIN jobs j ON j.id = d.job_id
+		WHERE d.id = $1
+	`, input.DisputeID).Scan(&uuid, &reason, &description, &status, &jobTitle, &totalPay)
+	if err != nil {
+		return fmt.Errorf("failed to get dispute %d: %w", input.DisputeID, err)
+	}
+
+	title := fmt.Sprintf("Payment disputed on job %d", input.JobID)
+	severity := notifications.SeverityWarning
+	dedupKey := "dispute-opened-" + uuid
+	if overdue {
+		title = fmt.Sprintf("Dispute on job %d unresolved after %s", input.JobID, workflows.DisputeReviewSLA)
+		severity = notifications.SeverityError
+		dedupKey = "dispute-overdue-" + uuid
+	}
+
+	_, err = notifications.PublishOnce(ctx, a.db, notifications.EventPaymentReconciliation, notifications.OpsAlert{
+		Title:    title,
+		Summary:  description,
+		Severity: severity,
+		Source:   "dispute",
+		DedupKey: dedupKey,
+		Fields: map[string]string{
+			"Dispute": uuid,
+			"Job":     fmt.Sprintf("%d (%s)", input.JobID, jobTitle),
+			"Amount":  fmt.Sprintf("$%.2f", totalPay),
+			"Reason":  reason,
+			"Status":  status,
+		},
+		Link: adminLink("/disputes/%d", input.DisputeID),
+	}, opsAlertWindow)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to route dispute alert", "uuid", uuid, "error", err)
+	}
+	return nil
+}
+
+// JobHasOpenHolds reports whether an unresolved incident, support ticket or dispute is
+// still holding the job workflow
+func (a *DisputeActivities) JobHasOpenHolds(ctx context.Context, jobID int) (bool, error) {
+	return jobHasOpenHolds(ctx, a.db, jobID)
+}
+
+func jobHasOpenHolds(ctx context.Context, db *sql.DB, jobID int) (bool, error) {
+	var holds int
+	err := db.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM safety_incidents WHERE job_id = $1 AND workflow_paused = true AND status <> 'resolved') +
+			(SELECT COUNT(*) FROM support_tickets WHERE job_id = $1 AND workflow_paused = true AND status <> 'resolved') +
+			(SELECT COUNT(*) FROM job_disputes WHERE job_id = $1 AND status IN ('open', 'under_review'))
+	`, jobID).Scan(&holds)
+	if err != nil {
+		return false, fmt.Errorf("failed to check holds for job %d: %w", jobID, err)
+	}
+	return holds > 0, nil
+}
@@ -0,0 +1,159 @@
+package activities
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"app/internal/email"
+	"app/internal/model"
+	"app/internal/notifications"
+	"app/internal/temporal/workflows"
+)
+
+// DocumentActivities contains the worker verification document expiry activity
+type DocumentActivities struct {
+	db *sql.DB
+}
+
+// NewDocumentActivities creates a new DocumentActivities instance
+func NewDocumentActivities(db *sql.DB) *DocumentActivities {
+	return &DocumentActivities{db: db}
+}
+
+// expiringDocument is an approved document due a reminder or expiring today
+type expiringDocument struct {
+	id           int
+	workerID     int
+	documentType string
+	expiresOn    time.Time
+	to, name     string
+}
+
+// CheckDocumentExpiry marks approved documents past their expiry date as expired and
+// reminds workers of documents expiring within the reminder window. Each worker is
+// told in the app, and by email when an email provider is configured.
+func (a *DocumentActivities) CheckDocumentExpiry(ctx context.Context) (workflows.DocumentExpiryResult, error) {
+	var result workflows.DocumentExpiryResult
+
+	emailService, err := email.NewServiceFromEnv()
+	if err != nil {
+		slog.InfoContext(ctx, "Email service not configured, sending in-app document expiry notices only", "error", err)
+	}
+	baseURL := os.Getenv("APP_BASE_URL")
+	if baseURL == "" {
+		baseURL = "https://app.gigco.com"
+	}
+	uploadLink := baseURL + "/account/documents"
+
+	expired, err := a.expireDocuments(ctx)
+	if err != nil {
+		return result, err
+	}
+	for _, doc := range expired {
+		a.notify(ctx, emailService, doc, true, uploadLink)
+	}
+	result.Expired = len(expired)
+
+	rows, err := a.db.QueryContext(ctx, `
+		SELECT d.id, d.worker_id, d.document_type, d.expires_on, d.expires_on - CURRENT_DATE,
+			d.last_reminder_days, p.email, p.name
+		FROM worker_documents d
+		JOIN people p ON p.id = d.worker_id
+		WHERE d.status = $1 AND d.expires_on BETWEEN CURRENT_DATE AND CURRENT_DATE + $2::integer
+	`, model.DocumentStatusApproved, model.DocumentExpiryReminderDays[0])
+	if err != nil {
+		return result, fmt.Errorf("failed to get expiring documents: %w", err)
+	}
+	type reminder struct {
+		doc  expiringDocument
+		days int
+	}
+	var reminders []reminder
+	for rows.Next() {
+		var doc expiringDocument
+		var daysLeft int
+		var lastReminderDays *int
+		if err := rows.Scan(&doc.id, &doc.workerID, &doc.documentType, &doc.expiresOn, &daysLeft,
+			&lastReminderDays, &doc.to, &doc.name); err != nil {
+			rows.Close()
+			return result, fmt.Errorf("failed to scan expiring document: %w", err)
+		}
+		if days, due := model.DocumentExpiryReminderDue(daysLeft, lastReminderDays); due {
+			reminders = append(reminders, reminder{doc: doc, days: days})
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return result, fmt.Errorf("failed to read expiring documents: %w", err)
+	}
+
+	for _, r := range reminders {
+		// Recorded first so a retried activity does not remind the worker twice
+		_, err := a.db.ExecContext(ctx,
+			`UPDATE worker_documents SET last_reminder_days = $1 WHERE id = $2`, r.days, r.doc.id)
+		if err != nil {
+			return result, fmt.Errorf("failed to record document reminder: %w", err)
+		}
+		a.notify(ctx, emailService, r.doc, false, uploadLink)
+		result.Reminded++
+	}
+
+	return result, nil
+}
+
+// expireDocuments marks approved documents past their expiry date as expired
+func (a *DocumentActivities) expireDocuments(ctx context.Context) ([]expiringDocument, error) {
+	rows, err := a.db.QueryContext(ctx, `
+		UPDATE worker_documents d SET status = $1
+		FROM people p
+		WHERE p.id = d.worker_id AND d.status = $2 AND d.expires_on < CURRENT_DATE
+		RETURNING d.id, d.worker_id, d.document_type, d.expires_on, p.email, p.name
+	`, model.DocumentStatusExpired, model.DocumentStatusApproved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire documents: %w", err)
+	}
+	defer rows.Close()
+
+	var expired []expiringDocument
+	for rows.Next() {
+		var doc expiringDocument
+		if err := rows.Scan(&doc.id, &doc.workerID, &doc.documentType, &doc.expiresOn, &doc.to, &doc.name); err != nil {
+			return nil, fmt.Errorf("failed to scan expired document: %w", err)
+		}
+		expired = append(expired, doc)
+	}
+	return expired, rows.Err()
+}
+
+// notify tells a worker their document expires soon or has expired. Failures are
+// logged rather than returned, as the document's state has already been recorded.
+func (a *DocumentActivities) notify(ctx context.Context, emailService *email.Service, doc expiringDocument, expired bool, uploadLink string) {
+	documentName := model.DocumentTypeNames[doc.documentType]
+	title := "Document expiring soon"
+	message := fmt.Sprintf("Your %s expires on %s. Upload a current one to stay verified.", documentName, doc.expiresOn.Format("January 2, 2006"))
+	if expired {
+		title = "Document expired"
+		message = fmt.Sprintf("Your %s expired on %s. Upload a current one to stay verified.", documentName, doc.expiresOn.Format("January 2, 2006"))
+	}
+
+	_, err := notifications.NewStore(a.db).Create(ctx, model.Notification{
+		UserID:  doc.workerID,
+		Type:    model.NotificationSystemMessage,
+		Title:   title,
+		Message: message,
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to notify worker of document expiry", "document_id", doc.id, "error", err)
+	}
+
+	if emailService == nil {
+		return
+	}
+	if err := emailService.SendDocumentExpiryReminder(doc.to, doc.name, documentName, doc.expiresOn, expired, uploadLink); err != nil {
+		slog.WarnContext(ctx, "Failed to email worker about document expiry", "document_id", doc.id, "error", err)
+	}
+}
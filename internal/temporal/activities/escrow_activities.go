@@ -0,0 +1,272 @@
+package activities
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"app/internal/audit"
+	"app/internal/clock"
+	"app/internal/email"
+	"app/internal/invoice"
+	"app/internal/model"
+	"app/internal/payment"
+	"app/internal/temporal/workflows"
+)
+
+// escrowPayments is the part of the payment service escrow activities use
+type escrowPayments interface {
+	GetEscrowHold(transactionID int) (*payment.EscrowHold, error)
+	ReauthorizePayment(ctx context.Context, transactionID int) (*model.EnhancedTransaction, error)
+	AutoCaptureJobPayment(ctx context.Context, transactionID int) (*model.PaymentCaptureResponse, error)
+	VoidAuthorization(ctx context.Context, transactionID int, reason string) error
+	WorkerEarnings(ctx context.Context, transactionID int) (model.Money, error)
+}
+
+// notifier delivers notifications; notifications.Dispatcher implements it
+type notifier interface {
+	Dispatch(ctx context.Context, n model.Notification) (*model.Notification, error)
+}
+
+// EscrowActivities contains activities that renew, void and capture payment authorizations
+type EscrowActivities struct {
+	db            *sql.DB
+	payments      escrowPayments
+	notifications notifier
+	invoices      *invoice.Service
+	clock         clock.Clock
+}
+
+// NewEscrowActivities creates a new EscrowActivities instance
+func NewEscrowActivities(db *sql.DB, payments *payment.PaymentService) *EscrowActivities {
+	return &EscrowActivities{
+		db:            db,
+		payments:      payments,
+		notifications: email.NewNotificationDispatcher(db),
+		invoices:      invoice.NewServiceFromEnv(db),
+		clock:         clock.System,
+	}
+}
+
+// escrowVoidStatuses are job statuses in which no work will be paid for, so the hold is
+// released rather than renewed
+var escrowVoidStatuses = map[string]bool{
+	"cancelled":           true,
+	"rejected":            true,
+	"no_worker_available": true,
+}
+
+// escrowCaptureStatuses are job statuses in which the work is done and the payment may
+// be captured
+var escrowCaptureStatuses = map[string]bool{
+	"completed":      true,
+	"paid":           true,
+	"review_pending": true,
+}
+
+// CheckEscrow reports when an authorization next needs renewing and, once its job is
+// completed and not held by a dispute, incident or ticket, when it is auto-captured
+func (a *EscrowActivities) CheckEscrow(ctx context.Context, input workflows.EscrowInput) (workflows.EscrowState, error) {
+	hold, err := a.payments.GetEscrowHold(input.TransactionID)
+	if err == sql.ErrNoRows {
+		return workflows.EscrowState{Settled: true}, nil
+	}
+	if err != nil {
+		return workflows.EscrowState{}, fmt.Errorf("failed to get authorization %d: %w", input.TransactionID, err)
+	}
+	if hold.Settled {
+		return workflows.EscrowState{Settled: true}, nil
+	}
+
+	state := workflows.EscrowState{RenewAt: hold.ExpiresAt.Add(-payment.EscrowRenewalLead)}
+	if escrowVoidStatuses[hold.JobStatus] {
+		state.RenewAt = a.clock.Now()
+		return state, nil
+	}
+
+	if hold.CompletedAt != nil && escrowCaptureStatuses[hold.JobStatus] {
+		held, err := jobHasOpenHolds(ctx, a.db, hold.JobID)
+		if err != nil {
+			return workflows.EscrowState{}, err
+		}
+		if !held {
+			captureAt := hold.CompletedAt.Add(payment.EscrowAutoCaptureDelay())
+			state.CaptureAt = &captureAt
+		}
+	}
+	return state, nil
+}
+
+// RenewEscrowAuthorization replaces an authorization that is about to expire. Holds
+// for jobs that will not be paid for are voided instead, as are holds the card no
+// longer accepts, in which case the consumer is asked to authorize payment again.
+func (a *EscrowActivities) RenewEscrowAuthorization(ctx context.Context, input workflows.EscrowInput) error {
+	hold, err := a.payments.GetEscrowHold(input.TransactionID)
+	if err != nil {
+		return fmt.Errorf("failed to get authorization %d: %w", input.TransactionID, err)
+	}
+	if hold.Settled {
+		return nil
+	}
+
+	if escrowVoidStatuses[hold.JobStatus] {
+		return a.void(ctx, input, "job "+hold.JobStatus)
+	}
+
+	_, err = a.payments.ReauthorizePayment(ctx, input.TransactionID)
+	if err == nil || errors.Is(err, payment.ErrEscrowSettled) {
+		slog.InfoContext(ctx, "Reauthorized payment for job", "transaction_id", input.TransactionID, "job_id", input.JobID)
+		return nil
+	}
+	slog.ErrorContext(ctx, "Failed to reauthorize payment for job, voiding it", "transaction_id", input.TransactionID, "job_id", input.JobID, "error", err)
+
+	if err := a.void(ctx, input, "authorization_expired"); err != nil {
+		return err
+	}
+	a.notify(ctx, model.Notification{
+		UserID:               hold.ConsumerID,
+		Type:                 model.NotificationSystemMessage,
+		Title:                "Payment authorization expired",
+		Message:              fmt.Sprintf("We could not renew the hold of %s for job #%d. Please authorize payment again so the job can go ahead.", hold.Amount, input.JobID),
+		RelatedJobID:         &input.JobID,
+		RelatedTransactionID: &input.TransactionID,
+	})
+	return nil
+}
+
+// AutoCaptureEscrow captures a completed job's payment once the consumer has let the
+// auto-capture delay pass without releasing or disputing it, records in the audit log
+// that the capture was automatic and tells both the consumer and the worker
+func (a *EscrowActivities) AutoCaptureEscrow(ctx context.Context, input workflows.EscrowInput) error {
+	hold, err := a.payments.GetEscrowHold(input.TransactionID)
+	if err != nil {
+		return fmt.Errorf("failed to get authorization %d: %w", input.TransactionID, err)
+	}
+	if hold.Settled {
+		return nil
+	}
+
+	// A dispute or hold opened since the capture was scheduled waits for its resolution,
+	// which signals the workflow to schedule the capture again
+	held, err := jobHasOpenHolds(ctx, a.db, hold.JobID)
+	if err != nil {
+		return err
+	}
+	if held {
+		slog.InfoContext(ctx, "Skipped auto-capture of held job", "transaction_id", input.TransactionID, "job_id", input.JobID)
+		return nil
+	}
+
+	before := a.transactionSnapshot(ctx, input.TransactionID)
+	resp, err := a.payments.AutoCaptureJobPayment(ctx, input.TransactionID)
+	if errors.Is(err, payment.ErrJobOnHold) {
+		// Held since the check above
+		slog.InfoContext(ctx, "Skipped auto-capture of held job", "transaction_id", input.TransactionID, "job_id", input.JobID)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to auto-capture payment %d: %w", input.TransactionID, err)
+	}
+	if resp.Replayed {
+		return nil
+	}
+
+	days := int(payment.EscrowAutoCaptureDelay().Hours() / 24)
+	slog.InfoContext(ctx, "Auto-captured payment for job", "transaction_id", input.TransactionID, "job_id", input.JobID)
+	a.auditAutoCapture(ctx, hold, before, days)
+	a.notify(ctx, model.Notification{
+		UserID:               hold.ConsumerID,
+		Type:                 model.NotificationPaymentSent,
+		Title:                "Payment released",
+		Message:              fmt.Sprintf("Your payment for job #%d was released to the worker %d days after the job was completed.", input.JobID, days),
+		RelatedJobID:         &input.JobID,
+		RelatedTransactionID: &input.TransactionID,
+	})
+	if hold.GigWorkerID != nil {
+		// Quote what the worker is paid out, not what the consumer was charged
+		earnings, err := a.payments.WorkerEarnings(ctx, input.TransactionID)
+		message := fmt.Sprintf("The consumer didn't respond within %d days of completion, so your payment of %s for job #%d was released automatically.", days, earnings, input.JobID)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to get worker earnings for auto-capture notice", "transaction_id", input.TransactionID, "error", err)
+			message = fmt.Sprintf("The consumer didn't respond within %d days of completion, so your payment for job #%d was released automatically.", days, input.JobID)
+		}
+		a.notify(ctx, model.Notification{
+			UserID:               *hold.GigWorkerID,
+			Type:                 model.NotificationPaymentReceived,
+			Title:                "Payment released",
+			Message:              message,
+			RelatedJobID:         &input.JobID,
+			RelatedTransactionID: &input.TransactionID,
+		})
+	}
+	a.emailPaymentDocuments(ctx, input.TransactionID)
+	return nil
+}
+
+// transactionSnapshot reads the transaction's payment fields for the audit log's diff
+func (a *EscrowActivities) transactionSnapshot(ctx context.Context, transactionID int) map[string]interface{} {
+	snapshot, err := audit.Snapshot(ctx, a.db, `
+		SELECT status, amount, capture_amount, captured_at, refund_amount, refunded_at, refund_reason
+		FROM transactions
+		WHERE id = $1
+	`, transactionID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to read transaction for audit", "transaction_id", transactionID, "error", err)
+		return nil
+	}
+	return snapshot
+}
+
+// auditAutoCapture records that the workflow, not the consumer, captured the payment.
+// The capture has already happened, so a failure is logged rather than retried.
+func (a *EscrowActivities) auditAutoCapture(ctx context.Context, hold *payment.EscrowHold, before map[string]interface{}, days int) {
+	changes, err := audit.Diff(before, a.transactionSnapshot(ctx, hold.TransactionID))
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to diff transaction for audit", "transaction_id", hold.TransactionID, "error", err)
+	}
+	err = audit.Record(ctx, a.db, audit.Entry{
+		Action:     audit.ActionPaymentAutoCaptured,
+		EntityType: audit.EntityTransaction,
+		EntityID:   strconv.Itoa(hold.TransactionID),
+		Changes:    changes,
+		Metadata: map[string]interface{}{
+			"job_id":               hold.JobID,
+			"completed_at":         hold.CompletedAt,
+			"approval_window_days": days,
+		},
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to audit auto-capture", "transaction_id", hold.TransactionID, "error", err)
+	}
+}
+
+// emailPaymentDocuments emails the receipt and earnings statement for a captured
+// payment. Failures are logged; both can still be downloaded.
+func (a *EscrowActivities) emailPaymentDocuments(ctx context.Context, transactionID int) {
+	emailService, err := email.NewServiceFromEnv()
+	if err != nil {
+		slog.WarnContext(ctx, "Email not configured, payment receipt not sent", "transaction_id", transactionID, "error", err)
+		return
+	}
+	if err := a.invoices.EmailDocuments(ctx, transactionID, emailService); err != nil {
+		slog.ErrorContext(ctx, "Failed to email payment documents", "transaction_id", transactionID, "error", err)
+	}
+}
+
+func (a *EscrowActivities) void(ctx context.Context, input workflows.EscrowInput, reason string) error {
+	err := a.payments.VoidAuthorization(ctx, input.TransactionID, reason)
+	if err != nil && !errors.Is(err, payment.ErrEscrowSettled) {
+		return fmt.Errorf("failed to void payment %d: %w", input.TransactionID, err)
+	}
+	slog.InfoContext(ctx, "Voided payment for job", "transaction_id", input.TransactionID, "job_id", input.JobID, "reason", reason)
+	return nil
+}
+
+func (a *EscrowActivities) notify(ctx context.Context, n model.Notification) {
+	if _, err := a.notifications.Dispatch(ctx, n); err != nil {
+		slog.WarnContext(ctx, "Failed to notify user", "user_id", n.UserID, "type", n.Type, "error", err)
+	}
+}
@@ -0,0 +1,229 @@
+package activities
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"app/internal/audit"
+	"app/internal/clock"
+	"app/internal/model"
+	"app/internal/payment"
+	"app/internal/temporal/workflows"
+)
+
+// fakePayments is an escrow whose capture succeeds unless captureErr is set
+type fakePayments struct {
+	hold       payment.EscrowHold
+	replayed   bool
+	captureErr error
+	earnings   model.Money
+	captured   []int
+}
+
+func (p *fakePayments) GetEscrowHold(int) (*payment.EscrowHold, error) {
+	hold := p.hold
+	return &hold, nil
+}
+
+func (p *fakePayments) ReauthorizePayment(context.Context, int) (*model.EnhancedTransaction, error) {
+	return &model.EnhancedTransaction{}, nil
+}
+
+func (p *fakePayments) AutoCaptureJobPayment(_ context.Context, transactionID int) (*model.PaymentCaptureResponse, error) {
+	p.captured = append(p.captured, transactionID)
+	if p.captureErr != nil {
+		return nil, p.captureErr
+	}
+	return &model.PaymentCaptureResponse{Success: true, TransactionID: transactionID, Replayed: p.replayed}, nil
+}
+
+func (p *fakePayments) VoidAuthorization(context.Context, int, string) error { return nil }
+
+func (p *fakePayments) WorkerEarnings(context.Context, int) (model.Money, error) {
+	return p.earnings, nil
+}
+
+// fakeNotifier keeps the notifications it is given
+type fakeNotifier struct{ sent []model.Notification }
+
+func (n *fakeNotifier) Dispatch(_ context.Context, notification model.Notification) (*model.Notification, error) {
+	n.sent = append(n.sent, notification)
+	return &notification, nil
+}
+
+// execRecorder is a database that records the statements executed on it. Queries
+// for a job's holds count holds; other queries return no rows.
+type execRecorder struct {
+	execs []recordedExec
+	holds int64
+}
+
+type recordedExec struct {
+	query string
+	args  []driver.Value
+}
+
+func (r *execRecorder) Connect(context.Context) (driver.Conn, error) { return recorderConn{r}, nil }
+func (r *execRecorder) Driver() driver.Driver                        { return nil }
+
+type recorderConn struct{ r *execRecorder }
+
+func (c recorderConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c recorderConn) Close() error                        { return nil }
+func (c recorderConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (c recorderConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	c.r.execs = append(c.r.execs, recordedExec{query: query, args: values})
+	return driver.RowsAffected(1), nil
+}
+
+func (c recorderConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if strings.Contains(query, "FROM job_disputes") {
+		return &countRows{count: c.r.holds}, nil
+	}
+	return emptyRows{}, nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string         { return []string{"status"} }
+func (emptyRows) Close() error              { return nil }
+func (emptyRows) Next([]driver.Value) error { return io.EOF }
+
+// countRows is the single row of a COUNT query
+type countRows struct {
+	count int64
+	read  bool
+}
+
+func (r *countRows) Columns() []string { return []string{"count"} }
+func (r *countRows) Close() error      { return nil }
+
+func (r *countRows) Next(dest []driver.Value) error {
+	if r.read {
+		return io.EOF
+	}
+	dest[0], r.read = r.count, true
+	return nil
+}
+
+// auditActions lists the actions of the audit events recorded
+func (r *execRecorder) auditActions() []string {
+	var actions []string
+	for _, e := range r.execs {
+		if strings.Contains(e.query, "INSERT INTO audit_events") {
+			actions = append(actions, e.args[2].(string))
+		}
+	}
+	return actions
+}
+
+func TestAutoCaptureEscrow(t *testing.T) {
+	t.Setenv("SENDGRID_API_KEY", "")
+	t.Setenv("EMAIL_PROVIDER", "")
+	t.Setenv("ESCROW_AUTO_CAPTURE_DAYS", "")
+
+	workerID := 7
+	completedAt := time.Date(2026, 10, 13, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		hold          payment.EscrowHold
+		replayed      bool
+		captureErr    error
+		holds         int64
+		wantCaptured  bool
+		wantAudit     bool
+		wantNotified  []int  // User IDs, in order
+		wantWorkerMsg string // Part of the worker's notification
+	}{
+		{
+			name:          "worker is told their earnings",
+			hold:          payment.EscrowHold{ConsumerID: 3, GigWorkerID: &workerID},
+			wantCaptured:  true,
+			wantAudit:     true,
+			wantNotified:  []int{3, 7},
+			wantWorkerMsg: "your payment of 85.50 for job #42",
+		},
+		{
+			name:         "no worker assigned",
+			hold:         payment.EscrowHold{ConsumerID: 3},
+			wantCaptured: true,
+			wantAudit:    true,
+			wantNotified: []int{3},
+		},
+		{
+			name:         "capture replayed",
+			hold:         payment.EscrowHold{ConsumerID: 3, GigWorkerID: &workerID},
+			replayed:     true,
+			wantCaptured: true,
+		},
+		{
+			name:  "disputed before it was due",
+			hold:  payment.EscrowHold{ConsumerID: 3, GigWorkerID: &workerID},
+			holds: 1,
+		},
+		{
+			name:         "disputed as it was captured",
+			hold:         payment.EscrowHold{ConsumerID: 3, GigWorkerID: &workerID},
+			captureErr:   payment.ErrJobOnHold,
+			wantCaptured: true,
+		},
+		{
+			name: "already settled",
+			hold: payment.EscrowHold{ConsumerID: 3, GigWorkerID: &workerID, Settled: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hold := tt.hold
+			hold.TransactionID, hold.JobID, hold.CompletedAt = 5, 42, &completedAt
+			hold.Amount = model.USD(10000)
+			payments := &fakePayments{hold: hold, replayed: tt.replayed, captureErr: tt.captureErr, earnings: model.USD(8550)}
+			notifier := &fakeNotifier{}
+			recorder := &execRecorder{holds: tt.holds}
+			db := sql.OpenDB(recorder)
+			defer db.Close()
+
+			a := &EscrowActivities{db: db, payments: payments, notifications: notifier, clock: clock.NewFake(completedAt.Add(72 * time.Hour))}
+			if err := a.AutoCaptureEscrow(t.Context(), workflows.EscrowInput{TransactionID: 5, JobID: 42}); err != nil {
+				t.Fatalf("AutoCaptureEscrow() error = %v", err)
+			}
+
+			if got := len(payments.captured) == 1; got != tt.wantCaptured {
+				t.Errorf("captured = %v, want %v", payments.captured, tt.wantCaptured)
+			}
+			actions := recorder.auditActions()
+			if got := len(actions) == 1 && actions[0] == audit.ActionPaymentAutoCaptured; got != tt.wantAudit {
+				t.Errorf("audit actions = %v, want auto-capture recorded %v", actions, tt.wantAudit)
+			}
+			var notified []int
+			for _, n := range notifier.sent {
+				notified = append(notified, n.UserID)
+			}
+			if len(notified) != len(tt.wantNotified) {
+				t.Fatalf("notified users = %v, want %v", notified, tt.wantNotified)
+			}
+			for i, userID := range tt.wantNotified {
+				if notified[i] != userID {
+					t.Errorf("notified users = %v, want %v", notified, tt.wantNotified)
+				}
+			}
+			if tt.wantWorkerMsg != "" {
+				worker := notifier.sent[len(notifier.sent)-1]
+				if worker.Type != model.NotificationPaymentReceived || !strings.Contains(worker.Message, tt.wantWorkerMsg) {
+					t.Errorf("worker notification = %s %q, want %s containing %q", worker.Type, worker.Message, model.NotificationPaymentReceived, tt.wantWorkerMsg)
+				}
+			}
+		})
+	}
+}
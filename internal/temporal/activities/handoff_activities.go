@@ -0,0 +1,269 @@
+package activities
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"app/internal/jobevents"
+	"app/internal/model"
+	"app/internal/payment"
+	"app/internal/realtime"
+	"app/internal/temporal/workflows"
+)
+
+// handoffPayments is the part of the payment service hand-off activities use
+type handoffPayments interface {
+	ChargeHandoffPortion(ctx context.Context, jobID, workerID int, amount model.Money, idempotencyKey string) (*model.EnhancedTransaction, error)
+	RefundJobPayment(ctx context.Context, userID int, req model.PaymentRefundRequest) (*model.PaymentRefundResponse, error)
+	VoidAuthorization(ctx context.Context, transactionID int, reason string) error
+}
+
+// jobHandoff is the part of a hand-off the activities work with
+type jobHandoff struct {
+	ID               int
+	JobID            int
+	FromWorkerID     int
+	CompletedPercent int
+	Status           string
+	TransactionID    sql.NullInt64
+	PaidAmount       sql.NullFloat64
+	ConsumerID       int
+	JobTitle         string
+	TotalPay         model.Money
+}
+
+func (a *JobActivities) loadHandoff(ctx context.Context, handoffID int) (jobHandoff, error) {
+	var h jobHandoff
+	var currency string
+	err := a.db.QueryRowContext(ctx, `
+		SELECT h.id, h.job_id, h.from_worker_id, h.completed_percent, h.status, h.transaction_id, h.paid_amount,
+		       j.consumer_id, j.title, COALESCE(j.total_pay, 0), COALESCE(j.currency, 'USD')
+		FROM job_handoffs h
+		JOIN jobs j ON j.id = h.job_id
+		WHERE h.id = $1
+	`, handoffID).Scan(&h.ID, &h.JobID, &h.FromWorkerID, &h.CompletedPercent, &h.Status, &h.TransactionID, &h.PaidAmount,
+		&h.ConsumerID, &h.JobTitle, &h.TotalPay, &currency)
+	if err != nil {
+		return jobHandoff{}, fmt.Errorf("failed to get hand-off %d: %w", handoffID, err)
+	}
+	h.TotalPay = h.TotalPay.In(currency)
+	return h, nil
+}
+
+// handoffIdempotencyKey keys the payment calls for a hand-off, so a retried activity
+// charges or refunds the portion once
+func handoffIdempotencyKey(action string, handoffID int) string {
+	return fmt.Sprintf("handoff-%s-%d", action, handoffID)
+}
+
+// PayHandoffPortion pays the outgoing worker for the portion of the job they completed.
+// The consumer is charged for it on the job's card and the worker is paid it, less
+// fees, with their next payout. A retry after the payment was recorded pays nothing
+// more.
+func (a *JobActivities) PayHandoffPortion(ctx context.Context, handoffID int) (workflows.HandoffResult, error) {
+	h, err := a.loadHandoff(ctx, handoffID)
+	if err != nil {
+		return workflows.HandoffResult{}, err
+	}
+	if h.TransactionID.Valid {
+		return workflows.HandoffResult{HandoffID: h.ID, PaidAmount: h.PaidAmount.Float64}, nil
+	}
+	if h.Status != model.JobHandoffApproved {
+		return workflows.HandoffResult{}, fmt.Errorf("hand-off %d is %s, not approved", h.ID, h.Status)
+	}
+
+	amount := model.HandoffPortionAmount(h.TotalPay, h.CompletedPercent)
+	if !amount.IsPositive() {
+		if _, err := a.db.ExecContext(ctx, `UPDATE job_handoffs SET paid_amount = 0 WHERE id = $1`, h.ID); err != nil {
+			return workflows.HandoffResult{}, fmt.Errorf("failed to update hand-off: %w", err)
+		}
+		return workflows.HandoffResult{HandoffID: h.ID}, nil
+	}
+
+	transaction, err := a.payments.ChargeHandoffPortion(ctx, h.JobID, h.FromWorkerID, amount, handoffIdempotencyKey("portion", h.ID))
+	if err != nil {
+		return workflows.HandoffResult{}, fmt.Errorf("failed to charge hand-off portion: %w", err)
+	}
+	_, err = a.db.ExecContext(ctx, `
+		UPDATE job_handoffs SET paid_amount = $2, transaction_id = $3 WHERE id = $1
+	`, h.ID, amount, transaction.ID)
+	if err != nil {
+		return workflows.HandoffResult{}, fmt.Errorf("failed to update hand-off: %w", err)
+	}
+
+	earnings := amount
+	if transaction.NetAmount != nil {
+		earnings = *transaction.NetAmount
+	}
+	paid := amount.Dollars()
+	a.notify(ctx, model.Notification{
+		UserID:               h.FromWorkerID,
+		Type:                 model.NotificationPaymentReceived,
+		Title:                "Payment received",
+		Message:              fmt.Sprintf("You were paid %s for the %d%% of job #%d you completed. It is included in your next payout.", earnings, h.CompletedPercent, h.JobID),
+		RelatedJobID:         &h.JobID,
+		RelatedTransactionID: &transaction.ID,
+	})
+	a.publish(ctx, realtime.Event{
+		Type:   realtime.EventPayment,
+		JobID:  h.JobID,
+		Status: "completed",
+		Amount: &paid,
+		Data:   map[string]interface{}{"transaction_id": transaction.ID, "handoff_id": h.ID, "currency": amount.Currency},
+	})
+
+	slog.InfoContext(ctx, "Paid outgoing worker for handed-off job", "job_id", h.JobID, "handoff_id", h.ID, "amount", amount, "currency", amount.Currency)
+	return workflows.HandoffResult{HandoffID: h.ID, PaidAmount: paid}, nil
+}
+
+// ReleaseHandoffWorker takes the outgoing worker off the job: their booking is removed,
+// they are available again and the job goes back to accepted for the replacement's offer
+func (a *JobActivities) ReleaseHandoffWorker(ctx context.Context, handoffID int) error {
+	h, err := a.loadHandoff(ctx, handoffID)
+	if err != nil {
+		return err
+	}
+
+	_, err = jobevents.Record(ctx, a.db, jobevents.Transition{
+		JobID:  h.JobID,
+		Type:   jobevents.TypeHandedOff,
+		Source: jobevents.SourceWorkflow,
+		Data:   map[string]interface{}{"handoff_id": h.ID, "worker_id": h.FromWorkerID},
+		Allowed: func(s jobevents.State) bool {
+			return s.GigWorkerID != nil && *s.GigWorkerID == h.FromWorkerID
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update job status: %w", err)
+	}
+
+	_, err = a.db.ExecContext(ctx, `DELETE FROM schedules WHERE job_id = $1 AND gig_worker_id = $2`, h.JobID, h.FromWorkerID)
+	if err != nil {
+		return fmt.Errorf("failed to remove worker's schedule: %w", err)
+	}
+	_, err = a.db.ExecContext(ctx, "UPDATE worker_profiles SET is_available = true WHERE worker_id = $1", h.FromWorkerID)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to mark worker as available", "error", err)
+	}
+
+	a.publish(ctx, realtime.Event{Type: realtime.EventJobStatusChanged, JobID: h.JobID, Status: "accepted"})
+	a.notify(ctx, model.Notification{
+		UserID:       h.FromWorkerID,
+		Type:         model.NotificationSystemMessage,
+		Title:        "Hand-off approved",
+		Message:      fmt.Sprintf("You have been released from \"%s\". Get well soon.", h.JobTitle),
+		RelatedJobID: &h.JobID,
+	})
+
+	slog.InfoContext(ctx, "Released worker from handed-off job", "job_id", h.JobID, "handoff_id", h.ID, "worker_id", h.FromWorkerID)
+	return nil
+}
+
+// ReverseHandoff compensates a hand-off that could not be carried out: the portion
+// payment is refunded and the hand-off marked failed, leaving the worker on the job
+func (a *JobActivities) ReverseHandoff(ctx context.Context, handoffID int) error {
+	h, err := a.loadHandoff(ctx, handoffID)
+	if err != nil {
+		return err
+	}
+
+	// The consumer is refunded the portion at the provider too; the job goes on
+	if h.TransactionID.Valid {
+		_, err = a.payments.RefundJobPayment(ctx, h.ConsumerID, model.PaymentRefundRequest{
+			TransactionID:  int(h.TransactionID.Int64),
+			Reason:         "Job hand-off failed",
+			IdempotencyKey: handoffIdempotencyKey("reversal", h.ID),
+			KeepJobStatus:  true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to refund hand-off payment: %w", err)
+		}
+	}
+	_, err = a.db.ExecContext(ctx, `
+		UPDATE job_handoffs SET status = 'failed', completed_at = NOW() WHERE id = $1 AND status = 'approved'
+	`, h.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update hand-off: %w", err)
+	}
+
+	for _, userID := range []int{h.FromWorkerID, h.ConsumerID} {
+		a.notify(ctx, model.Notification{
+			UserID:       userID,
+			Type:         model.NotificationSystemMessage,
+			Title:        "Hand-off failed",
+			Message:      fmt.Sprintf("The hand-off of \"%s\" could not be completed; the worker stays on the job. Contact support if they can't continue.", h.JobTitle),
+			RelatedJobID: &h.JobID,
+		})
+	}
+
+	slog.WarnContext(ctx, "Reversed job hand-off", "job_id", h.JobID, "handoff_id", h.ID)
+	return nil
+}
+
+// FinishHandoff records the worker who took over a handed-off job, or that nobody did
+// when workerID is 0, and tells the consumer. When nobody did, the job's hold is voided
+// so the consumer pays only for the portion already charged.
+func (a *JobActivities) FinishHandoff(ctx context.Context, handoffID, workerID int) error {
+	h, err := a.loadHandoff(ctx, handoffID)
+	if err != nil {
+		return err
+	}
+	if workerID == 0 && h.Status == model.JobHandoffApproved {
+		if err := a.voidHandoffHold(ctx, h); err != nil {
+			return err
+		}
+	}
+
+	status, message := model.JobHandoffCompleted, fmt.Sprintf("A new worker has taken over \"%s\".", h.JobTitle)
+	var toWorkerID *int
+	if workerID > 0 {
+		toWorkerID = &workerID
+	} else {
+		status, message = model.JobHandoffUnfilled, fmt.Sprintf("No worker was available to take over \"%s\". You were only charged for the work completed.", h.JobTitle)
+	}
+	_, err = a.db.ExecContext(ctx, `
+		UPDATE job_handoffs SET status = $2, to_worker_id = $3, completed_at = NOW()
+		WHERE id = $1 AND status = 'approved'
+	`, h.ID, status, toWorkerID)
+	if err != nil {
+		return fmt.Errorf("failed to update hand-off: %w", err)
+	}
+
+	a.notify(ctx, model.Notification{
+		UserID:       h.ConsumerID,
+		Type:         model.NotificationSystemMessage,
+		Title:        "Job hand-off",
+		Message:      message,
+		RelatedJobID: &h.JobID,
+	})
+
+	slog.InfoContext(ctx, "Job hand-off finished", "job_id", h.JobID, "handoff_id", h.ID, "status", status)
+	return nil
+}
+
+// voidHandoffHold releases the authorization of a job nobody took over. A hold already
+// settled, e.g. by a retry of this activity, is left alone.
+func (a *JobActivities) voidHandoffHold(ctx context.Context, h jobHandoff) error {
+	var authorizationID int
+	err := a.db.QueryRowContext(ctx, `
+		SELECT id FROM transactions
+		WHERE job_id = $1 AND transaction_type = 'authorization' AND status <> 'failed'
+		ORDER BY id DESC
+		LIMIT 1
+	`, h.JobID).Scan(&authorizationID)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get job authorization: %w", err)
+	}
+
+	err = a.payments.VoidAuthorization(ctx, authorizationID, "Job hand-off unfilled")
+	if err != nil && !errors.Is(err, payment.ErrEscrowSettled) {
+		return fmt.Errorf("failed to void job authorization: %w", err)
+	}
+	return nil
+}
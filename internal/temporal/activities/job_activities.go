@@ -3,27 +3,53 @@ package activities
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"math"
 	"time"
 
+	"app/internal/analytics"
+	"app/internal/availability"
+	"app/internal/clock"
+	"app/internal/dispatch"
+	"app/internal/email"
+	"app/internal/favorites"
+	"app/internal/fraud"
+	"app/internal/jobevents"
+	"app/internal/links"
+	"app/internal/model"
+	"app/internal/notifications"
+	"app/internal/payment"
+	"app/internal/presence"
+	"app/internal/realtime"
+	"app/internal/recurrence"
+	"app/internal/shadow"
 	"app/internal/temporal/workflows"
 )
 
 // JobActivities contains all job-related activities
 type JobActivities struct {
-	db *sql.DB
+	db            *sql.DB
+	notifications *notifications.Dispatcher
+	shadow        *shadow.Harness // nil unless shadow candidates are configured
+	payments      handoffPayments
+	clock         clock.Clock
+	ids           clock.IDGenerator
 }
 
 // NewJobActivities creates a new JobActivities instance
-func NewJobActivities(db *sql.DB) *JobActivities {
-	return &JobActivities{db: db}
+func NewJobActivities(db *sql.DB, payments *payment.PaymentService) *JobActivities {
+	harness, err := shadow.NewHarnessFromEnv(db)
+	if err != nil {
+		slog.Warn("Shadow evaluation disabled", "error", err)
+	}
+	return &JobActivities{db: db, notifications: email.NewNotificationDispatcher(db), shadow: harness, payments: payments, clock: clock.System, ids: clock.UUIDs}
 }
 
 // PriceJob calculates the price for a job based on requirements
 func (a *JobActivities) PriceJob(ctx context.Context, jobID int) (workflows.PriceJobResult, error) {
-	log.Printf("Pricing job %d", jobID)
+	slog.InfoContext(ctx, "Pricing job", "job_id", jobID)
 
 	// Get job details from database
 	var job struct {
@@ -52,22 +78,15 @@ func (a *JobActivities) PriceJob(ctx context.Context, jobID int) (workflows.Pric
 		return workflows.PriceJobResult{}, fmt.Errorf("failed to get job details: %w", err)
 	}
 
-	// Calculate base price
-	baseRate := 25.0 // $25/hour base rate
-	totalPrice := baseRate * float64(job.Duration)
-
-	// Apply urgency multiplier
-	switch job.Urgency {
-	case "urgent":
-		totalPrice *= 1.5
-	case "high":
-		totalPrice *= 1.3
-	case "medium":
-		totalPrice *= 1.1
+	pricingJob := dispatch.Job{
+		ID:            job.ID,
+		Category:      job.Skills,
+		Location:      job.Location,
+		DurationHours: job.Duration,
+		Urgency:       job.Urgency,
 	}
-
-	// Round to nearest dollar
-	totalPrice = math.Round(totalPrice*100) / 100
+	totalPrice := dispatch.ProductionPricing.Price(pricingJob)
+	a.shadow.Price(ctx, pricingJob, dispatch.ProductionPricing, totalPrice)
 
 	// Update job with calculated price
 	updateQuery := `
@@ -80,7 +99,9 @@ func (a *JobActivities) PriceJob(ctx context.Context, jobID int) (workflows.Pric
 		return workflows.PriceJobResult{}, fmt.Errorf("failed to update job price: %w", err)
 	}
 
-	log.Printf("Job %d priced at $%.2f", jobID, totalPrice)
+	a.track(ctx, jobID, analytics.StagePriced, map[string]interface{}{"amount": totalPrice, "pricing_rule": dispatch.ProductionPricing.Name()})
+
+	slog.InfoContext(ctx, "Job priced", "job_id", jobID, "total_price", totalPrice)
 
 	return workflows.PriceJobResult{
 		JobID:  jobID,
@@ -90,146 +111,396 @@ func (a *JobActivities) PriceJob(ctx context.Context, jobID int) (workflows.Pric
 
 // SendJobOffer sends a job offer to the customer
 func (a *JobActivities) SendJobOffer(ctx context.Context, jobID int, amount float64) error {
-	log.Printf("Sending job offer for job %d with amount $%.2f", jobID, amount)
+	slog.InfoContext(ctx, "Sending job offer for job", "job_id", jobID, "amount", amount)
 
 	// Update job status to indicate offer sent
-	query := `
-		UPDATE jobs 
-		SET status = 'offer_sent', updated_at = CURRENT_TIMESTAMP 
-		WHERE id = $1
-	`
-	_, err := a.db.ExecContext(ctx, query, jobID)
+	var consumerID int
+	var title string
+	err := a.db.QueryRowContext(ctx, `SELECT consumer_id, title FROM jobs WHERE id = $1`, jobID).Scan(&consumerID, &title)
 	if err != nil {
+		return fmt.Errorf("failed to get job details: %w", err)
+	}
+	if err := a.transition(ctx, jobID, jobevents.TypeOfferSent, nil, map[string]interface{}{"amount": amount}); err != nil {
 		return fmt.Errorf("failed to update job status: %w", err)
 	}
 
+	a.publish(ctx, realtime.Event{Type: realtime.EventJobOffer, JobID: jobID, Status: "offer_sent", Amount: &amount})
+	a.track(ctx, jobID, analytics.StageOfferSent, map[string]interface{}{"amount": amount})
+
+	offer := model.Notification{
+		UserID:       consumerID,
+		Type:         model.NotificationJobOffer,
+		Title:        "Your job has been priced",
+		Message:      fmt.Sprintf("%s has been priced at $%.2f. Review the offer to continue.", title, amount),
+		RelatedJobID: &jobID,
+		Metadata:     model.JSONB{"amount": amount},
+	}
+	// The offer can be accepted from the notification with a single-use link
+	if link, err := links.NewService(a.db).WithClock(a.clock, a.ids).Create(ctx, links.ActionAcceptOffer, consumerID, jobID); err != nil {
+		slog.WarnContext(ctx, "Failed to create offer link", "job_id", jobID, "error", err)
+	} else {
+		offer.ActionURL = &link.URL
+	}
+	a.notify(ctx, offer)
+
 	// In a real implementation, you would:
 	// 1. Send email/SMS to customer
-	// 2. Create notification in app
-	// 3. Log the offer in audit table
+	// 2. Log the offer in audit table
 
-	log.Printf("Job offer sent successfully for job %d", jobID)
+	slog.InfoContext(ctx, "Job offer sent successfully for job", "job_id", jobID)
 	return nil
 }
 
+// AutoAcceptJob books a rebooked job with the favorited worker the consumer chose when
+// the worker auto-accepts it: both sides allow auto-accept for the pair, the price meets
+// the worker's floor and the worker is free. The job skips the offer and goes straight
+// to worker_assigned. A zero WorkerID sends the job through the offer as usual.
+func (a *JobActivities) AutoAcceptJob(ctx context.Context, jobID int, amount float64) (workflows.AutoAcceptResult, error) {
+	var consumerID int
+	var preferredID, assignedID sql.NullInt64
+	var status, title string
+	err := a.db.QueryRowContext(ctx, `
+		SELECT consumer_id, preferred_worker_id, gig_worker_id, COALESCE(status, 'posted'), title
+		FROM jobs WHERE id = $1
+	`, jobID).Scan(&consumerID, &preferredID, &assignedID, &status, &title)
+	if err != nil {
+		return workflows.AutoAcceptResult{}, fmt.Errorf("failed to get job details: %w", err)
+	}
+	if !preferredID.Valid {
+		return workflows.AutoAcceptResult{}, nil
+	}
+	workerID := int(preferredID.Int64)
+
+	// A retry after the job was booked returns the same worker
+	if status == "worker_assigned" && assignedID.Valid && int(assignedID.Int64) == workerID {
+		return workflows.AutoAcceptResult{JobID: jobID, WorkerID: workerID}, nil
+	}
+
+	settings, err := favorites.Load(ctx, a.db, consumerID, workerID)
+	if errors.Is(err, favorites.ErrNotFavorite) {
+		slog.InfoContext(ctx, "Preferred worker is not a favorite; sending offer", "job_id", jobID, "worker_id", workerID)
+		return workflows.AutoAcceptResult{}, nil
+	}
+	if err != nil {
+		return workflows.AutoAcceptResult{}, err
+	}
+	if !settings.Allows(amount) {
+		slog.InfoContext(ctx, "Preferred worker does not auto-accept job; sending offer", "job_id", jobID, "worker_id", workerID, "amount", amount)
+		return workflows.AutoAcceptResult{}, nil
+	}
+
+	window, err := a.jobWindow(ctx, jobID)
+	if err != nil {
+		return workflows.AutoAcceptResult{}, err
+	}
+	if _, ok, err := a.findWorkerSlot(ctx, workerID, jobID, window); err != nil {
+		return workflows.AutoAcceptResult{}, fmt.Errorf("failed to check worker availability: %w", err)
+	} else if !ok {
+		slog.InfoContext(ctx, "Preferred worker is not free for job; sending offer", "job_id", jobID, "worker_id", workerID)
+		return workflows.AutoAcceptResult{}, nil
+	}
+
+	// Accepted and assigned together, so a consumer cancelling meanwhile wins cleanly
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return workflows.AutoAcceptResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	_, err = jobevents.RecordTx(ctx, tx, jobevents.Transition{
+		JobID:   jobID,
+		Type:    jobevents.TypeAccepted,
+		Source:  jobevents.SourceWorkflow,
+		Data:    map[string]interface{}{"amount": amount, "auto_accept": true},
+		Allowed: jobevents.StatusIn("posted"),
+	})
+	if err == nil {
+		_, err = jobevents.RecordTx(ctx, tx, jobevents.Transition{
+			JobID:    jobID,
+			Type:     jobevents.TypeWorkerAssigned,
+			WorkerID: &workerID,
+			Source:   jobevents.SourceWorkflow,
+			Data:     map[string]interface{}{"auto_accept": true},
+			Allowed:  jobevents.StatusIn("accepted"),
+		})
+	}
+	if errors.Is(err, jobevents.ErrNotAllowed) {
+		slog.InfoContext(ctx, "Job changed before auto-accept; sending offer", "job_id", jobID, "error", err)
+		return workflows.AutoAcceptResult{}, nil
+	}
+	if err != nil {
+		return workflows.AutoAcceptResult{}, fmt.Errorf("failed to auto-accept job: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return workflows.AutoAcceptResult{}, fmt.Errorf("failed to auto-accept job: %w", err)
+	}
+
+	a.publish(ctx, realtime.Event{Type: realtime.EventJobStatusChanged, JobID: jobID, Status: "worker_assigned"})
+	a.track(ctx, jobID, analytics.StageAccepted, map[string]interface{}{"amount": amount, "auto_accept": true})
+
+	// Mark worker as unavailable, as matching does
+	_, err = a.db.ExecContext(ctx, `
+		INSERT INTO worker_profiles (worker_id, is_available) VALUES ($1, false)
+		ON CONFLICT (worker_id) DO UPDATE SET is_available = false`,
+		workerID)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to mark worker as unavailable", "error", err)
+	}
+
+	a.notify(ctx, model.Notification{
+		UserID:       consumerID,
+		Type:         model.NotificationJobAccepted,
+		Title:        "Your rebooking is confirmed",
+		Message:      fmt.Sprintf("%s was accepted automatically at $%.2f and is being scheduled.", title, amount),
+		RelatedJobID: &jobID,
+		Metadata:     model.JSONB{"amount": amount, "auto_accept": true},
+	})
+	a.notify(ctx, model.Notification{
+		UserID:       workerID,
+		Type:         model.NotificationJobAccepted,
+		Title:        "Job auto-accepted",
+		Message:      fmt.Sprintf("You auto-accepted %s from a repeat customer at $%.2f.", title, amount),
+		RelatedJobID: &jobID,
+		Metadata:     model.JSONB{"amount": amount, "auto_accept": true},
+	})
+
+	slog.InfoContext(ctx, "Job auto-accepted by favorited worker", "job_id", jobID, "worker_id", workerID)
+	return workflows.AutoAcceptResult{JobID: jobID, WorkerID: workerID}, nil
+}
+
 // FindMatchingWorker finds an available worker for the job
 func (a *JobActivities) FindMatchingWorker(ctx context.Context, jobID int) (workflows.MatchWorkerResult, error) {
-	log.Printf("Finding matching worker for job %d", jobID)
+	slog.InfoContext(ctx, "Finding matching worker for job", "job_id", jobID)
+
+	matchingJob, candidates, err := a.matchCandidates(ctx, jobID)
+	if err != nil {
+		return workflows.MatchWorkerResult{}, err
+	}
+	bestWorkerID, found := dispatch.ProductionMatching.Match(matchingJob, candidates)
+	a.shadow.Match(ctx, matchingJob, candidates, dispatch.ProductionMatching, bestWorkerID, found)
+
+	if !found {
+		return workflows.MatchWorkerResult{}, fmt.Errorf("no available workers found")
+	}
+
+	// Assign worker to job
+	err = a.transition(ctx, jobID, jobevents.TypeWorkerAssigned, &bestWorkerID, nil)
+	if err != nil {
+		return workflows.MatchWorkerResult{}, fmt.Errorf("failed to assign worker: %w", err)
+	}
+
+	a.publish(ctx, realtime.Event{Type: realtime.EventJobStatusChanged, JobID: jobID, Status: "worker_assigned"})
+
+	// Mark worker as unavailable
+	_, err = a.db.ExecContext(ctx, `
+		INSERT INTO worker_profiles (worker_id, is_available) VALUES ($1, false)
+		ON CONFLICT (worker_id) DO UPDATE SET is_available = false`,
+		bestWorkerID)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to mark worker as unavailable", "error", err)
+	}
+
+	slog.InfoContext(ctx, "Worker assigned to job", "best_worker_id", bestWorkerID, "job_id", jobID)
+
+	return workflows.MatchWorkerResult{
+		JobID:    jobID,
+		WorkerID: bestWorkerID,
+	}, nil
+}
 
+// matchCandidates returns the job as dispatch sees it and the workers free to do it
+func (a *JobActivities) matchCandidates(ctx context.Context, jobID int) (dispatch.Job, []dispatch.Candidate, error) {
 	// Get job requirements
 	var jobSkills, jobLocation string
-	err := a.db.QueryRowContext(ctx,
-		"SELECT COALESCE(category, '') as skills, COALESCE(location_address, '') as location FROM jobs WHERE id = $1",
-		jobID).Scan(&jobSkills, &jobLocation)
+	var completeness int
+	var handingOff bool
+	err := a.db.QueryRowContext(ctx, `
+		SELECT COALESCE(category, '') as skills, COALESCE(location_address, '') as location, COALESCE(completeness_score, 100),
+		       EXISTS (SELECT 1 FROM job_handoffs h WHERE h.job_id = jobs.id AND h.status = 'approved')
+		FROM jobs WHERE id = $1
+	`, jobID).Scan(&jobSkills, &jobLocation, &completeness, &handingOff)
+	if err != nil {
+		return dispatch.Job{}, nil, fmt.Errorf("failed to get job details: %w", err)
+	}
+	window, err := a.jobWindow(ctx, jobID)
 	if err != nil {
-		return workflows.MatchWorkerResult{}, fmt.Errorf("failed to get job details: %w", err)
+		return dispatch.Job{}, nil, err
 	}
 
-	// Find available workers
-	// This is a simplified matching algorithm
+	// Find available workers; dispatch.ProductionMatching picks among them. Only gig
+	// workers whose application was approved (a verified profile) are eligible; those
+	// without reviews still match. Workers active within presence.RecentTTL come first
+	// so they are among the candidates checked.
 	query := `
-		SELECT gw.id, gw.name, COALESCE(gw.bio, '') as skills, 
-		       COALESCE(gw.address, '') as location, 5.0 as rating
-		FROM gigworkers gw
-		WHERE gw.is_active = true
-		ORDER BY gw.created_at ASC
-		LIMIT 5
+		SELECT p.id, p.name, COALESCE(wp.skills, wp.bio, '') as skills,
+		       COALESCE(p.address, '') as location,
+		       COALESCE((SELECT AVG(r.rating)::float8 FROM job_reviews r WHERE r.reviewee_id = p.id AND r.is_public = true), 5.0) as rating,
+		       (SELECT COUNT(*) FROM job_offers o WHERE o.worker_id = p.id AND o.decline_reason = 'too_far'
+		          AND o.responded_at > NOW() - INTERVAL '90 days') as declined_too_far,
+		       (SELECT COUNT(*) FROM job_offers o WHERE o.worker_id = p.id AND o.decline_reason = 'pay_too_low'
+		          AND o.responded_at > NOW() - INTERVAL '90 days') as declined_pay_too_low,
+		       wp.last_seen_at
+		FROM people p
+		JOIN worker_profiles wp ON wp.worker_id = p.id
+		WHERE p.role = 'gig_worker' AND p.is_active = true
+		  AND wp.verification_status = 'verified' AND wp.is_available
+		ORDER BY COALESCE(wp.last_seen_at > $2, false) DESC, p.created_at ASC
+		LIMIT $1
 	`
 
-	rows, err := a.db.QueryContext(ctx, query)
+	now := a.clock.Now()
+	rows, err := a.db.QueryContext(ctx, query, matchCandidateLimit, now.Add(-presence.RecentTTL))
 	if err != nil {
-		return workflows.MatchWorkerResult{}, fmt.Errorf("failed to query workers: %w", err)
+		return dispatch.Job{}, nil, fmt.Errorf("failed to query workers: %w", err)
 	}
 	defer rows.Close()
 
-	var bestWorkerID int
-	var bestRating float64
-
+	var candidates []dispatch.Candidate
 	for rows.Next() {
 		var workerID int
 		var name, skills, location string
 		var rating float64
+		var declinedTooFar, declinedPayTooLow int
+		var lastSeen sql.NullTime
 
-		err := rows.Scan(&workerID, &name, &skills, &location, &rating)
+		err := rows.Scan(&workerID, &name, &skills, &location, &rating, &declinedTooFar, &declinedPayTooLow, &lastSeen)
 		if err != nil {
-			log.Printf("Error scanning worker row: %v", err)
+			slog.ErrorContext(ctx, "Error scanning worker row", "error", err)
 			continue
 		}
-
-		// Simple matching: take the highest rated available worker
-		if rating > bestRating {
-			bestWorkerID = workerID
-			bestRating = rating
+		var seen *time.Time
+		if lastSeen.Valid {
+			seen = &lastSeen.Time
 		}
+		candidates = append(candidates, dispatch.Candidate{
+			WorkerID: workerID,
+			Skills:   skills,
+			Location: location,
+			Rating:   rating,
+
+			DeclinedTooFar:    declinedTooFar,
+			DeclinedPayTooLow: declinedPayTooLow,
+			Presence:          presence.StatusAt(seen, now),
+		})
 	}
-
-	if bestWorkerID == 0 {
-		return workflows.MatchWorkerResult{}, fmt.Errorf("no available workers found")
+	rows.Close()
+
+	// Only workers free for the job are offered to dispatch
+	free := candidates[:0]
+	for _, c := range candidates {
+		if _, ok, err := a.findWorkerSlot(ctx, c.WorkerID, jobID, window); err != nil {
+			slog.ErrorContext(ctx, "Error checking availability of worker", "worker_id", c.WorkerID, "error", err)
+		} else if ok {
+			free = append(free, c)
+		}
 	}
 
-	// Assign worker to job
-	updateQuery := `
-		UPDATE jobs 
-		SET gig_worker_id = $1, status = 'worker_assigned', updated_at = CURRENT_TIMESTAMP 
-		WHERE id = $2
-	`
-	_, err = a.db.ExecContext(ctx, updateQuery, bestWorkerID, jobID)
-	if err != nil {
-		return workflows.MatchWorkerResult{}, fmt.Errorf("failed to assign worker: %w", err)
+	job := dispatch.Job{ID: jobID, Category: jobSkills, Location: jobLocation, Completeness: completeness}
+	if handingOff {
+		// The job is under way and its worker has left it
+		job.Urgency = urgencyHandoff
 	}
+	return job, free, nil
+}
 
-	// Mark worker as unavailable
-	_, err = a.db.ExecContext(ctx,
-		"UPDATE gigworkers SET is_active = false WHERE id = $1",
-		bestWorkerID)
+// matchCandidateLimit is how many eligible workers matching checks availability for
+const matchCandidateLimit = 20
+
+// jobWindow is when a job can be booked: its own scheduled time when it has one,
+// otherwise any working-hours slot of its duration
+type jobWindow struct {
+	fixed    *recurrence.Slot
+	duration time.Duration
+}
+
+func (a *JobActivities) jobWindow(ctx context.Context, jobID int) (jobWindow, error) {
+	var start, end sql.NullTime
+	var hours sql.NullFloat64
+	err := a.db.QueryRowContext(ctx,
+		`SELECT scheduled_start, scheduled_end, estimated_duration_hours FROM jobs WHERE id = $1`,
+		jobID).Scan(&start, &end, &hours)
 	if err != nil {
-		log.Printf("Warning: failed to mark worker as unavailable: %v", err)
+		return jobWindow{}, fmt.Errorf("failed to get job schedule: %w", err)
+	}
+	if start.Valid && end.Valid {
+		return jobWindow{fixed: &recurrence.Slot{Start: start.Time, End: end.Time}}, nil
 	}
+	w := jobWindow{duration: availability.DefaultJobDuration}
+	if hours.Valid && hours.Float64 > 0 {
+		w.duration = time.Duration(hours.Float64 * float64(time.Hour))
+	}
+	return w, nil
+}
 
-	log.Printf("Worker %d assigned to job %d", bestWorkerID, jobID)
+// findWorkerSlot returns the slot the worker would do the job in and whether the worker
+// is free then. For a job with its own time that slot is always the job's time.
+func (a *JobActivities) findWorkerSlot(ctx context.Context, workerID, jobID int, window jobWindow) (recurrence.Slot, bool, error) {
+	if window.fixed != nil {
+		avail, err := availability.ForWorker(ctx, a.db, availability.Query{
+			WorkerID: workerID, From: window.fixed.Start, To: window.fixed.End, ExcludeJobID: &jobID,
+		})
+		if err != nil {
+			return *window.fixed, false, err
+		}
+		return *window.fixed, avail.IsFree(*window.fixed), nil
+	}
 
-	return workflows.MatchWorkerResult{
-		JobID:    jobID,
-		WorkerID: bestWorkerID,
-	}, nil
+	from, to := availability.BookingRange(a.clock.Now())
+	avail, err := availability.ForWorker(ctx, a.db, availability.Query{
+		WorkerID: workerID, From: from, To: to, ExcludeJobID: &jobID,
+	})
+	if err != nil {
+		return recurrence.Slot{}, false, err
+	}
+	slot, ok := avail.FirstBookable(window.duration)
+	return slot, ok, nil
 }
 
 // ScheduleJob schedules the job with the assigned worker
 func (a *JobActivities) ScheduleJob(ctx context.Context, jobID, workerID int) error {
-	log.Printf("Scheduling job %d with worker %d", jobID, workerID)
+	slog.InfoContext(ctx, "Scheduling job with worker", "job_id", jobID, "worker_id", workerID)
 
-	// Create a schedule entry
-	// For now, schedule for tomorrow at 9 AM
-	scheduledTime := time.Now().AddDate(0, 0, 1).Truncate(24 * time.Hour).Add(9 * time.Hour)
+	window, err := a.jobWindow(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	slot, ok, err := a.findWorkerSlot(ctx, workerID, jobID, window)
+	if err != nil {
+		return fmt.Errorf("failed to check worker availability: %w", err)
+	}
+	if !ok && window.fixed == nil {
+		return fmt.Errorf("worker %d has no free slot for job %d", workerID, jobID)
+	}
+	if !ok {
+		// The job's own time was checked when the worker was matched or accepted it
+		slog.WarnContext(ctx, "Job's scheduled time overlaps worker's busy time", "job_id", jobID, "worker_id", workerID)
+	}
+	scheduledTime := slot.Start
 
 	query := `
 		INSERT INTO schedules (gig_worker_id, title, start_time, end_time, is_available, job_id, created_at)
 		VALUES ($1, $2, $3, $4, false, $5, CURRENT_TIMESTAMP)
 	`
-	endTime := scheduledTime.Add(2 * time.Hour) // 2 hour job duration
-	_, err := a.db.ExecContext(ctx, query, workerID, "Scheduled Job", scheduledTime, endTime, jobID)
+	_, err = a.db.ExecContext(ctx, query, workerID, "Scheduled Job", slot.Start, slot.End, jobID)
 	if err != nil {
 		return fmt.Errorf("failed to create schedule: %w", err)
 	}
 
 	// Update job status
-	updateQuery := `
-		UPDATE jobs 
-		SET status = 'scheduled', updated_at = CURRENT_TIMESTAMP 
-		WHERE id = $1
-	`
-	_, err = a.db.ExecContext(ctx, updateQuery, jobID)
+	err = a.transition(ctx, jobID, jobevents.TypeScheduled, nil, map[string]interface{}{"scheduled_start": scheduledTime})
 	if err != nil {
 		return fmt.Errorf("failed to update job status: %w", err)
 	}
 
-	log.Printf("Job %d scheduled for %v", jobID, scheduledTime)
+	a.publish(ctx, realtime.Event{Type: realtime.EventJobStatusChanged, JobID: jobID, Status: "scheduled"})
+	a.track(ctx, jobID, analytics.StageScheduled, map[string]interface{}{"worker_id": workerID})
+
+	slog.InfoContext(ctx, "Job scheduled", "job_id", jobID, "scheduled_time", scheduledTime)
 	return nil
 }
 
 // ProcessJobPayment processes payment for a completed job
 func (a *JobActivities) ProcessJobPayment(ctx context.Context, jobID int) (workflows.ProcessPaymentResult, error) {
-	log.Printf("Processing payment for job %d", jobID)
+	slog.InfoContext(ctx, "Processing payment for job", "job_id", jobID)
 
 	// Get job and payment details
 	var job struct {
@@ -239,55 +510,84 @@ func (a *JobActivities) ProcessJobPayment(ctx context.Context, jobID int) (workf
 		TotalPay   float64
 		Status     string
 	}
+	var handedOffPay float64
 
+	// Workers who handed the job off were paid for their portions already
 	query := `
-		SELECT id, consumer_id, gig_worker_id, total_pay, status
+		SELECT id, consumer_id, gig_worker_id, total_pay, status,
+		       COALESCE((SELECT SUM(h.paid_amount) FROM job_handoffs h
+		                 WHERE h.job_id = jobs.id AND h.transaction_id IS NOT NULL AND h.status <> 'failed'), 0)
 		FROM jobs WHERE id = $1
 	`
 	err := a.db.QueryRowContext(ctx, query, jobID).Scan(
-		&job.ID, &job.ConsumerID, &job.WorkerID, &job.TotalPay, &job.Status,
+		&job.ID, &job.ConsumerID, &job.WorkerID, &job.TotalPay, &job.Status, &handedOffPay,
 	)
 	if err != nil {
 		return workflows.ProcessPaymentResult{}, fmt.Errorf("failed to get job details: %w", err)
 	}
+	job.TotalPay = math.Round((job.TotalPay-handedOffPay)*100) / 100
 
 	if job.Status != "completed" {
 		return workflows.ProcessPaymentResult{}, fmt.Errorf("job not completed, cannot process payment")
 	}
 
-	// Create transaction record
-	transactionID := fmt.Sprintf("txn_%d_%d", jobID, time.Now().Unix())
+	// Create transaction record; its UUID is the transaction ID reported back
+	transactionID := a.ids.NewID()
 
 	insertQuery := `
-		INSERT INTO transactions (job_id, consumer_id, gig_worker_id, amount, status, created_at)
-		VALUES ($1, $2, $3, $4, 'completed', CURRENT_TIMESTAMP)
+		INSERT INTO transactions (uuid, job_id, consumer_id, gig_worker_id, amount, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, 'completed', $6)
+		RETURNING id
 	`
-	_, err = a.db.ExecContext(ctx, insertQuery,
-		job.ID, job.ConsumerID, job.WorkerID, job.TotalPay)
+	var transactionRowID int
+	err = a.db.QueryRowContext(ctx, insertQuery,
+		transactionID, job.ID, job.ConsumerID, job.WorkerID, job.TotalPay, a.clock.Now()).Scan(&transactionRowID)
 	if err != nil {
 		return workflows.ProcessPaymentResult{}, fmt.Errorf("failed to create transaction: %w", err)
 	}
 
 	// Update job status
-	updateQuery := `
-		UPDATE jobs 
-		SET status = 'paid', updated_at = CURRENT_TIMESTAMP 
-		WHERE id = $1
-	`
-	_, err = a.db.ExecContext(ctx, updateQuery, jobID)
+	err = a.transition(ctx, jobID, jobevents.TypePaid, nil, map[string]interface{}{"transaction_id": transactionRowID})
 	if err != nil {
 		return workflows.ProcessPaymentResult{}, fmt.Errorf("failed to update job status: %w", err)
 	}
 
+	a.publish(ctx, realtime.Event{Type: realtime.EventJobStatusChanged, JobID: jobID, Status: "paid"})
+	a.track(ctx, jobID, analytics.StagePaid, map[string]interface{}{"amount": job.TotalPay})
+
 	// Mark worker as available again
 	_, err = a.db.ExecContext(ctx,
-		"UPDATE gigworkers SET is_active = true WHERE id = $1",
+		"UPDATE worker_profiles SET is_available = true WHERE worker_id = $1",
 		job.WorkerID)
 	if err != nil {
-		log.Printf("Warning: failed to mark worker as available: %v", err)
+		slog.WarnContext(ctx, "Failed to mark worker as available", "error", err)
 	}
 
-	log.Printf("Payment processed for job %d, transaction %s", jobID, transactionID)
+	a.notify(ctx, model.Notification{
+		UserID:               job.WorkerID,
+		Type:                 model.NotificationPaymentReceived,
+		Title:                "Payment received",
+		Message:              fmt.Sprintf("You were paid $%.2f for job #%d.", job.TotalPay, jobID),
+		RelatedJobID:         &job.ID,
+		RelatedTransactionID: &transactionRowID,
+	})
+	a.notify(ctx, model.Notification{
+		UserID:               job.ConsumerID,
+		Type:                 model.NotificationPaymentSent,
+		Title:                "Payment sent",
+		Message:              fmt.Sprintf("Your payment of $%.2f for job #%d has been processed.", job.TotalPay, jobID),
+		RelatedJobID:         &job.ID,
+		RelatedTransactionID: &transactionRowID,
+	})
+	a.publish(ctx, realtime.Event{
+		Type:   realtime.EventPayment,
+		JobID:  jobID,
+		Status: "completed",
+		Amount: &job.TotalPay,
+		Data:   map[string]interface{}{"transaction_id": transactionRowID},
+	})
+
+	slog.InfoContext(ctx, "Payment processed for job, transaction", "job_id", jobID, "transaction_id", transactionID)
 
 	return workflows.ProcessPaymentResult{
 		TransactionID: transactionID,
@@ -297,114 +597,243 @@ func (a *JobActivities) ProcessJobPayment(ctx context.Context, jobID int) (workf
 
 // RequestReviews sends review requests to both consumer and worker
 func (a *JobActivities) RequestReviews(ctx context.Context, jobID int) error {
-	log.Printf("Requesting reviews for job %d", jobID)
+	slog.InfoContext(ctx, "Requesting reviews for job", "job_id", jobID)
 
 	// Update job status
-	query := `
-		UPDATE jobs 
-		SET status = 'review_pending', updated_at = CURRENT_TIMESTAMP 
-		WHERE id = $1
-	`
-	_, err := a.db.ExecContext(ctx, query, jobID)
+	err := a.transition(ctx, jobID, jobevents.TypeReviewRequested, nil, nil)
 	if err != nil {
 		return fmt.Errorf("failed to update job status: %w", err)
 	}
 
-	// In a real implementation, you would:
-	// 1. Send review request emails/notifications
-	// 2. Create review reminder tasks
-	// 3. Set up review deadline tracking
+	a.publish(ctx, realtime.Event{Type: realtime.EventJobStatusChanged, JobID: jobID, Status: "review_pending"})
+
+	if err := a.sendReviewRequests(ctx, jobID); err != nil {
+		// The job can still be reviewed in the app
+		slog.ErrorContext(ctx, "Failed to email review requests for job", "job_id", jobID, "error", err)
+		return nil
+	}
 
-	log.Printf("Review requests sent for job %d", jobID)
+	slog.InfoContext(ctx, "Review requests sent for job", "job_id", jobID)
+	return nil
+}
+
+// sendReviewRequests emails the consumer and worker a deep link that submits their
+// review of the job. Each link is signed for the job and its recipient.
+func (a *JobActivities) sendReviewRequests(ctx context.Context, jobID int) error {
+	emailService, err := email.NewServiceFromEnv()
+	if err != nil {
+		return fmt.Errorf("email service not configured: %w", err)
+	}
+	linkService := links.NewService(a.db).WithClock(a.clock, a.ids)
+
+	rows, err := a.db.QueryContext(ctx, `
+		SELECT j.title, p.id, p.email, p.name
+		FROM jobs j
+		JOIN people p ON p.id IN (j.consumer_id, j.gig_worker_id)
+		WHERE j.id = $1 AND p.is_active = true
+	`, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to get job participants: %w", err)
+	}
+	defer rows.Close()
+
+	type recipient struct {
+		userID      int
+		email, name string
+	}
+	var title string
+	var recipients []recipient
+	for rows.Next() {
+		var rcpt recipient
+		if err := rows.Scan(&title, &rcpt.userID, &rcpt.email, &rcpt.name); err != nil {
+			return fmt.Errorf("failed to scan job participant: %w", err)
+		}
+		recipients = append(recipients, rcpt)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, rcpt := range recipients {
+		link, err := linkService.Create(ctx, links.ActionReviewJob, rcpt.userID, jobID)
+		if err != nil {
+			return err
+		}
+		if err := emailService.SendReviewRequest(rcpt.email, rcpt.name, title, link.URL); err != nil {
+			return fmt.Errorf("failed to email user %d: %w", rcpt.userID, err)
+		}
+	}
 	return nil
 }
 
 // CloseJob finalizes the job
 func (a *JobActivities) CloseJob(ctx context.Context, jobID int) error {
-	log.Printf("Closing job %d", jobID)
+	slog.InfoContext(ctx, "Closing job", "job_id", jobID)
 
-	query := `
-		UPDATE jobs
-		SET status = 'closed', workflow_completed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $1
-	`
-	_, err := a.db.ExecContext(ctx, query, jobID)
+	err := a.transition(ctx, jobID, jobevents.TypeClosed, nil, nil)
 	if err != nil {
 		return fmt.Errorf("failed to close job: %w", err)
 	}
 
-	log.Printf("Job %d closed successfully", jobID)
+	a.publish(ctx, realtime.Event{Type: realtime.EventJobStatusChanged, JobID: jobID, Status: "closed"})
+	a.track(ctx, jobID, analytics.StageClosed, nil)
+	a.sendSurvey(ctx, jobID)
+
+	slog.InfoContext(ctx, "Job closed successfully", "job_id", jobID)
 	return nil
 }
 
+// sendSurvey asks the consumer the one-question survey set by JOB_SURVEY. A retried
+// activity finds the job's survey already recorded and sends nothing. Failures are
+// logged; the survey is optional.
+func (a *JobActivities) sendSurvey(ctx context.Context, jobID int) {
+	surveyType := analytics.SurveyTypeFromEnv()
+	if surveyType == "" {
+		return
+	}
+
+	var consumerID int
+	var title string
+	err := a.db.QueryRowContext(ctx, `
+		INSERT INTO job_surveys (job_id, consumer_id, gig_worker_id, survey_type)
+		SELECT j.id, j.consumer_id, j.gig_worker_id, $2
+		FROM jobs j
+		JOIN people p ON p.id = j.consumer_id AND p.is_active = true
+		WHERE j.id = $1
+		ON CONFLICT (job_id) DO NOTHING
+		RETURNING consumer_id, (SELECT title FROM jobs WHERE id = $1)
+	`, jobID, surveyType).Scan(&consumerID, &title)
+	if err == sql.ErrNoRows {
+		return
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to record job survey", "job_id", jobID, "error", err)
+		return
+	}
+
+	question, minScore, maxScore := analytics.SurveyQuestion(surveyType)
+	actionURL := fmt.Sprintf("/jobs/%d/survey", jobID)
+	a.notify(ctx, model.Notification{
+		UserID:       consumerID,
+		Type:         model.NotificationSurveyRequest,
+		Title:        "How did it go?",
+		Message:      fmt.Sprintf("%s is closed. %s It only takes a second.", title, question),
+		RelatedJobID: &jobID,
+		ActionURL:    &actionURL,
+		Metadata:     model.JSONB{"survey_type": surveyType, "min_score": minScore, "max_score": maxScore},
+	})
+}
+
 // HandleJobRejection handles when a customer rejects a job offer
 func (a *JobActivities) HandleJobRejection(ctx context.Context, jobID int) error {
-	log.Printf("Handling job rejection for job %d", jobID)
+	slog.InfoContext(ctx, "Handling job rejection for job", "job_id", jobID)
 
-	query := `
-		UPDATE jobs 
-		SET status = 'rejected', updated_at = CURRENT_TIMESTAMP 
-		WHERE id = $1
-	`
-	_, err := a.db.ExecContext(ctx, query, jobID)
+	err := a.transition(ctx, jobID, jobevents.TypeRejected, nil, nil)
 	if err != nil {
 		return fmt.Errorf("failed to update job status: %w", err)
 	}
 
-	log.Printf("Job %d marked as rejected", jobID)
+	a.publish(ctx, realtime.Event{Type: realtime.EventJobStatusChanged, JobID: jobID, Status: "rejected"})
+
+	slog.InfoContext(ctx, "Job marked as rejected", "job_id", jobID)
 	return nil
 }
 
 // HandleNoWorkerAvailable handles when no worker is available
 func (a *JobActivities) HandleNoWorkerAvailable(ctx context.Context, jobID int) error {
-	log.Printf("Handling no worker available for job %d", jobID)
+	slog.InfoContext(ctx, "Handling no worker available for job", "job_id", jobID)
 
-	query := `
-		UPDATE jobs 
-		SET status = 'no_worker_available', updated_at = CURRENT_TIMESTAMP 
-		WHERE id = $1
-	`
-	_, err := a.db.ExecContext(ctx, query, jobID)
+	err := a.transition(ctx, jobID, jobevents.TypeNoWorkerAvailable, nil, nil)
 	if err != nil {
 		return fmt.Errorf("failed to update job status: %w", err)
 	}
 
-	log.Printf("Job %d marked as no worker available", jobID)
+	a.publish(ctx, realtime.Event{Type: realtime.EventJobStatusChanged, JobID: jobID, Status: "no_worker_available"})
+
+	slog.InfoContext(ctx, "Job marked as no worker available", "job_id", jobID)
 	return nil
 }
 
 // HandlePaymentFailure handles payment processing failures
 func (a *JobActivities) HandlePaymentFailure(ctx context.Context, jobID int) error {
-	log.Printf("Handling payment failure for job %d", jobID)
+	slog.InfoContext(ctx, "Handling payment failure for job", "job_id", jobID)
 
-	query := `
-		UPDATE jobs 
-		SET status = 'payment_failed', updated_at = CURRENT_TIMESTAMP 
-		WHERE id = $1
-	`
-	_, err := a.db.ExecContext(ctx, query, jobID)
+	var consumerID int
+	err := a.db.QueryRowContext(ctx, `SELECT consumer_id FROM jobs WHERE id = $1`, jobID).Scan(&consumerID)
 	if err != nil {
+		return fmt.Errorf("failed to get job details: %w", err)
+	}
+	if err := a.transition(ctx, jobID, jobevents.TypePaymentFailed, nil, nil); err != nil {
 		return fmt.Errorf("failed to update job status: %w", err)
 	}
 
-	log.Printf("Job %d marked as payment failed", jobID)
+	a.publish(ctx, realtime.Event{Type: realtime.EventJobStatusChanged, JobID: jobID, Status: "payment_failed"})
+
+	slog.InfoContext(ctx, "Job marked as payment failed", "job_id", jobID)
+
+	// Repeated failures from one consumer are a card-testing signal
+	if _, err := fraud.CheckPaymentFailureVelocity(ctx, a.db, consumerID, jobID); err != nil {
+		slog.WarnContext(ctx, "Failed to check payment failure velocity for consumer", "consumer_id", consumerID, "error", err)
+	}
 	return nil
 }
 
 // UpdateJobPaymentStatus updates job payment status after successful retry
 func (a *JobActivities) UpdateJobPaymentStatus(ctx context.Context, jobID int, transactionID string) error {
-	log.Printf("Updating payment status for job %d with transaction %s", jobID, transactionID)
+	slog.InfoContext(ctx, "Updating payment status for job with transaction", "job_id", jobID, "transaction_id", transactionID)
 
-	query := `
-		UPDATE jobs 
-		SET status = 'paid', updated_at = CURRENT_TIMESTAMP 
-		WHERE id = $1
-	`
-	_, err := a.db.ExecContext(ctx, query, jobID)
+	err := a.transition(ctx, jobID, jobevents.TypePaid, nil, map[string]interface{}{"transaction_id": transactionID, "retried": true})
 	if err != nil {
 		return fmt.Errorf("failed to update job payment status: %w", err)
 	}
 
-	log.Printf("Job %d payment status updated", jobID)
+	a.publish(ctx, realtime.Event{Type: realtime.EventJobStatusChanged, JobID: jobID, Status: "paid"})
+	a.track(ctx, jobID, analytics.StagePaid, map[string]interface{}{"transaction_id": transactionID, "retried": true})
+
+	slog.InfoContext(ctx, "Job payment status updated", "job_id", jobID)
 	return nil
 }
+
+// transition records a lifecycle event on the job workflow's behalf. A retried activity
+// repeats the job's latest event, which records nothing.
+func (a *JobActivities) transition(ctx context.Context, jobID int, eventType string, workerID *int, data map[string]interface{}) error {
+	_, err := jobevents.Record(ctx, a.db, jobevents.Transition{
+		JobID:    jobID,
+		Type:     eventType,
+		WorkerID: workerID,
+		Source:   jobevents.SourceWorkflow,
+		Data:     data,
+	})
+	return err
+}
+
+// notify records an in-app notification and sends it over the channels the user's
+// preferences allow. Failures are logged rather than failing the
+// activity, since a retry would repeat the work the notification reports.
+func (a *JobActivities) notify(ctx context.Context, n model.Notification) {
+	if _, err := a.notifications.Dispatch(ctx, n); err != nil {
+		slog.WarnContext(ctx, "Failed to notify user", "user_id", n.UserID, "type", n.Type, "error", err)
+	}
+}
+
+// publish pushes an event to the job's participants' connected clients. Failures are
+// logged; clients fall back to polling the job.
+func (a *JobActivities) publish(ctx context.Context, event realtime.Event) {
+	if err := realtime.PublishJobEvent(ctx, a.db, a.clock, event); err != nil {
+		slog.WarnContext(ctx, "Failed to publish job event", "type", event.Type, "job_id", event.JobID, "error", err)
+	}
+}
+
+// track records a funnel event for the job. Failures are logged; they only leave a
+// gap in the funnel report.
+func (a *JobActivities) track(ctx context.Context, jobID int, stage string, properties map[string]interface{}) {
+	err := analytics.Track(ctx, a.db, analytics.FunnelEvent{
+		JobID:      jobID,
+		Stage:      stage,
+		Source:     analytics.SourceWorkflow,
+		Properties: properties,
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to track funnel event", "error", err)
+	}
+}
@@ -0,0 +1,53 @@
+package activities
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"app/internal/email"
+	"app/internal/notifications"
+	"app/internal/temporal/workflows"
+)
+
+// notificationRetryBatch caps the deliveries retried in one pass
+const notificationRetryBatch = 200
+
+// NotificationActivities contains the notification delivery retry activity
+type NotificationActivities struct {
+	db *sql.DB
+}
+
+// NewNotificationActivities creates a new NotificationActivities instance
+func NewNotificationActivities(db *sql.DB) *NotificationActivities {
+	return &NotificationActivities{db: db}
+}
+
+// RetryNotificationDeliveries resends deliveries whose retry is due through the
+// providers configured on this worker
+func (a *NotificationActivities) RetryNotificationDeliveries(ctx context.Context) (workflows.NotificationRetryResult, error) {
+	senders := map[string]notifications.Sender{}
+	if emailService, err := email.NewServiceFromEnv(); err == nil {
+		// Email payloads are provider-neutral, so the service retries deliveries recorded
+		// against its fallback too
+		for _, provider := range emailService.Providers() {
+			senders[provider] = emailService
+		}
+	}
+	if pushService, err := notifications.NewPushServiceFromEnv(); err == nil {
+		senders[pushService.Provider()] = pushService
+	}
+	if smsService, err := notifications.NewSMSServiceFromEnv(); err == nil {
+		senders[smsService.Provider()] = smsService
+	}
+	if len(senders) == 0 {
+		slog.InfoContext(ctx, "No notification providers configured, skipping delivery retries")
+		return workflows.NotificationRetryResult{}, nil
+	}
+
+	retried, sent, err := notifications.NewLedger(a.db).RetryDue(ctx, senders, notificationRetryBatch)
+	if err != nil {
+		return workflows.NotificationRetryResult{}, err
+	}
+	return workflows.NotificationRetryResult{Retried: retried, Sent: sent}, nil
+}
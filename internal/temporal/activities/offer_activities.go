@@ -0,0 +1,173 @@
+package activities
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"app/internal/dispatch"
+	"app/internal/model"
+	"app/internal/temporal/workflows"
+)
+
+// defaultOfferFanout is how many workers each offer round goes to unless
+// WORKER_OFFER_FANOUT says otherwise
+const defaultOfferFanout = 3
+
+// urgencyHandoff is the urgency of a job being handed off mid-engagement. Its offer
+// rounds go to handoffFanoutBoost times as many workers.
+const (
+	urgencyHandoff     = "urgent"
+	handoffFanoutBoost = 2
+)
+
+// offerFanout reads WORKER_OFFER_FANOUT, falling back when unset or invalid
+func offerFanout() int {
+	if n, err := strconv.Atoi(os.Getenv("WORKER_OFFER_FANOUT")); err == nil && n > 0 {
+		return n
+	}
+	return defaultOfferFanout
+}
+
+// RankWorkersForOffer returns the workers the job's next offer round goes to, best
+// match first. Workers offered the job in an earlier round are left out.
+func (a *JobActivities) RankWorkersForOffer(ctx context.Context, jobID int) ([]int, error) {
+	matchingJob, candidates, err := a.matchCandidates(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := a.db.QueryContext(ctx, `SELECT worker_id FROM job_offers WHERE job_id = $1`, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query job offers: %w", err)
+	}
+	defer rows.Close()
+	var offered []int
+	for rows.Next() {
+		var workerID int
+		if err := rows.Scan(&workerID); err != nil {
+			return nil, fmt.Errorf("failed to scan job offer: %w", err)
+		}
+		offered = append(offered, workerID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	candidates = slices.DeleteFunc(candidates, func(c dispatch.Candidate) bool {
+		return slices.Contains(offered, c.WorkerID)
+	})
+
+	fanout := offerFanout()
+	if matchingJob.Urgency == urgencyHandoff {
+		fanout *= handoffFanoutBoost
+	}
+	workerIDs := dispatch.Rank(dispatch.ProductionMatching, matchingJob, candidates, fanout)
+	slog.InfoContext(ctx, "Ranked workers for job offer", "job_id", jobID, "workers", workerIDs)
+	return workerIDs, nil
+}
+
+// SendWorkerOffer records an offer of the job to a worker and notifies them. A retry
+// after the offer was recorded sends nothing.
+func (a *JobActivities) SendWorkerOffer(ctx context.Context, offer workflows.WorkerOffer) error {
+	var title string
+	err := a.db.QueryRowContext(ctx, `
+		INSERT INTO job_offers (job_id, worker_id, round, rank, amount, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (job_id, worker_id) DO NOTHING
+		RETURNING (SELECT title FROM jobs WHERE id = $1)
+	`, offer.JobID, offer.WorkerID, offer.Round, offer.Rank, offer.Amount, offer.ExpiresAt).Scan(&title)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store job offer: %w", err)
+	}
+
+	a.notify(ctx, model.Notification{
+		UserID:       offer.WorkerID,
+		Type:         model.NotificationJobOffer,
+		Title:        "New job offer",
+		Message:      fmt.Sprintf("%s is available at $%.2f. The first worker to accept gets the job.", title, offer.Amount),
+		RelatedJobID: &offer.JobID,
+		Metadata:     model.JSONB{"amount": offer.Amount, "expires_at": offer.ExpiresAt, "round": offer.Round},
+	})
+
+	slog.InfoContext(ctx, "Job offered to worker", "job_id", offer.JobID, "worker_id", offer.WorkerID, "round", offer.Round, "rank", offer.Rank)
+	return nil
+}
+
+// RemindSnoozedOffer reopens an offer whose snooze ended and reminds the worker of it,
+// unless the offer was answered or expired in the meantime
+func (a *JobActivities) RemindSnoozedOffer(ctx context.Context, offer workflows.WorkerOffer) error {
+	var title string
+	var amount sql.NullFloat64
+	var expiresAt time.Time
+	err := a.db.QueryRowContext(ctx, `
+		UPDATE job_offers o SET status = 'pending', snoozed_until = NULL
+		FROM jobs j
+		WHERE j.id = o.job_id AND o.job_id = $1 AND o.worker_id = $2
+		  AND o.status = 'snoozed' AND o.expires_at > NOW()
+		RETURNING j.title, o.amount, o.expires_at
+	`, offer.JobID, offer.WorkerID).Scan(&title, &amount, &expiresAt)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reopen snoozed job offer: %w", err)
+	}
+
+	minutes := int(expiresAt.Sub(a.clock.Now()).Minutes())
+	a.notify(ctx, model.Notification{
+		UserID:       offer.WorkerID,
+		Type:         model.NotificationJobOffer,
+		Title:        "Job offer reminder",
+		Message:      fmt.Sprintf("%s is still available at $%.2f for about %d more minutes.", title, amount.Float64, minutes),
+		RelatedJobID: &offer.JobID,
+		Metadata:     model.JSONB{"amount": amount.Float64, "expires_at": expiresAt, "round": offer.Round},
+	})
+
+	slog.InfoContext(ctx, "Reminded worker of snoozed job offer", "job_id", offer.JobID, "worker_id", offer.WorkerID)
+	return nil
+}
+
+// CloseWorkerOffers ends an offer round: offers still pending or snoozed expire, and
+// the worker who accepted one, if any, is returned. The job row is locked as accepting
+// an offer locks it, so a worker accepting as the round closes either wins or finds the
+// offer expired.
+func (a *JobActivities) CloseWorkerOffers(ctx context.Context, jobID int) (workflows.MatchWorkerResult, error) {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return workflows.MatchWorkerResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM jobs WHERE id = $1 FOR UPDATE`, jobID); err != nil {
+		return workflows.MatchWorkerResult{}, fmt.Errorf("failed to lock job: %w", err)
+	}
+	expired, err := tx.ExecContext(ctx, `
+		UPDATE job_offers SET status = 'expired', snoozed_until = NULL
+		WHERE job_id = $1 AND status IN ('pending', 'snoozed')
+	`, jobID)
+	if err != nil {
+		return workflows.MatchWorkerResult{}, fmt.Errorf("failed to expire job offers: %w", err)
+	}
+	var workerID int
+	err = tx.QueryRowContext(ctx, `
+		SELECT worker_id FROM job_offers WHERE job_id = $1 AND status = 'accepted'
+	`, jobID).Scan(&workerID)
+	if err != nil && err != sql.ErrNoRows {
+		return workflows.MatchWorkerResult{}, fmt.Errorf("failed to load accepted job offer: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return workflows.MatchWorkerResult{}, fmt.Errorf("failed to close job offers: %w", err)
+	}
+
+	n, _ := expired.RowsAffected()
+	slog.InfoContext(ctx, "Closed job offers", "job_id", jobID, "accepted_by", workerID, "expired", n)
+	return workflows.MatchWorkerResult{JobID: jobID, WorkerID: workerID}, nil
+}
@@ -0,0 +1,462 @@
+package activities
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	"app/internal/kpi"
+	"app/internal/notifications"
+	"app/internal/temporal/workflows"
+)
+
+// opsAlertWindow suppresses repeat alerts for the same exception
+const opsAlertWindow = 24 * time.Hour
+
+// reconcileSettleDelay gives a capture time to finish its splits before it is reconciled
+const reconcileSettleDelay = time.Hour
+
+// fillRateWindow is the length of each period compared by the fill-rate check
+const fillRateWindow = 7 * 24 * time.Hour
+
+// kpiPeriods buckets the last $1 + 1 days into 24-hour periods counted back from now;
+// period 0 is the last 24 hours
+const kpiPeriods = `
+	SELECT p.period, NOW() - make_interval(days => p.period + 1) AS period_start, NOW() - make_interval(days => p.period) AS period_end
+	FROM generate_series(0, $1) AS p(period)`
+
+// kpiQueries measure each KPI per period as a value and the volume it was measured over
+var kpiQueries = map[string]string{
+	kpi.MetricJobCreation: `
+		WITH periods AS (` + kpiPeriods + `)
+		SELECT p.period, COUNT(j.id)::float8, COUNT(j.id)
+		FROM periods p
+		LEFT JOIN jobs j ON j.created_at > p.period_start AND j.created_at <= p.period_end
+		GROUP BY p.period`,
+	kpi.MetricPaymentFailure: `
+		WITH periods AS (` + kpiPeriods + `)
+		SELECT p.period, COALESCE(AVG(CASE WHEN t.status = 'failed' THEN 1.0 ELSE 0.0 END), 0)::float8, COUNT(t.id)
+		FROM periods p
+		LEFT JOIN transactions t ON t.created_at > p.period_start AND t.created_at <= p.period_end
+		GROUP BY p.period`,
+	kpi.MetricOfferAcceptance: `
+		WITH periods AS (` + kpiPeriods + `)
+		SELECT p.period, COALESCE(AVG(CASE WHEN e.event_type = 'accepted' THEN 1.0 ELSE 0.0 END), 0)::float8, COUNT(e.id)
+		FROM periods p
+		LEFT JOIN job_events e ON e.event_type IN ('accepted', 'rejected')
+		 AND e.occurred_at > p.period_start AND e.occurred_at <= p.period_end
+		GROUP BY p.period`,
+}
+
+// OpsActivities contains scheduled operations monitoring activities
+type OpsActivities struct {
+	db *sql.DB
+}
+
+// NewOpsActivities creates a new OpsActivities instance
+func NewOpsActivities(db *sql.DB) *OpsActivities {
+	return &OpsActivities{db: db}
+}
+
+// adminLink builds a link into the admin console when ADMIN_BASE_URL is set
+func adminLink(format string, args ...interface{}) string {
+	adminURL := os.Getenv("ADMIN_BASE_URL")
+	if adminURL == "" {
+		return ""
+	}
+	return adminURL + fmt.Sprintf(format, args...)
+}
+
+// envFloat reads a float setting, falling back when unset or invalid
+func envFloat(key string, fallback float64) float64 {
+	if v, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil && v > 0 {
+		return v
+	}
+	return fallback
+}
+
+// reconciliationException is a payment record that does not add up
+type reconciliationException struct {
+	Kind          string
+	JobID         int
+	TransactionID sql.NullInt64
+	Detail        string
+}
+
+// ReconcilePayments marks settled captures as reconciled and routes any payment
+// records that do not add up to the reconciliation channel
+func (a *OpsActivities) ReconcilePayments(ctx context.Context) (workflows.ReconciliationResult, error) {
+	var result workflows.ReconciliationResult
+
+	checks := []struct {
+		kind  string
+		query string
+		args  []interface{}
+	}{
+		{
+			// The job was marked paid but no money was ever settled for it
+			kind: "paid_without_capture",
+			query: `
+				SELECT j.id, NULL::INTEGER, 'Job is ' || j.status || ' but has no settled transaction'
+				FROM jobs j
+				WHERE j.status IN ('paid', 'review_pending')
+				  AND j.updated_at < NOW() - make_interval(secs => $1)
+				  AND NOT EXISTS (
+					SELECT 1 FROM transactions t
+					WHERE t.job_id = j.id AND (t.captured_at IS NOT NULL OR t.status = 'completed')
+				  )`,
+			args: []interface{}{reconcileSettleDelay.Seconds()},
+		},
+		{
+			// Worker payouts, reimbursements and materials exceed what was charged
+			kind: "splits_exceed_capture",
+			query: `
+				SELECT t.job_id, t.id,
+				       'Splits total ' || SUM(ps.amount) || ' against a capture of ' || t.capture_amount
+				FROM transactions t
+				JOIN payment_splits ps ON ps.transaction_id = t.id
+				WHERE t.captured_at IS NOT NULL AND t.reconciled_at IS NULL
+				GROUP BY t.id
+				HAVING SUM(ps.amount) > t.capture_amount`,
+		},
+		{
+			kind: "refund_exceeds_capture",
+			query: `
+				SELECT t.job_id, t.id,
+				       'Refunded ' || t.refund_amount || ' against a capture of ' || COALESCE(t.capture_amount, 0)
+				FROM transactions t
+				WHERE t.refund_amount > COALESCE(t.capture_amount, 0) AND t.reconciled_at IS NULL`,
+		},
+		{
+			// Work was finished but the hold lapsed before anyone captured it
+			kind: "authorization_expired",
+			query: `
+				SELECT t.job_id, t.id, 'Authorization expired ' || t.authorization_expires_at || ' without capture'
+				FROM transactions t
+				JOIN jobs j ON j.id = t.job_id
+				WHERE t.captured_at IS NULL AND t.refunded_at IS NULL
+				  AND t.authorization_expires_at < NOW()
+				  AND t.status NOT IN ('failed', 'refunded')
+				  AND j.status IN ('completed', 'paid', 'review_pending')`,
+		},
+	}
+
+	var exceptions []reconciliationException
+	flagged := make(map[int64]bool)
+	for _, check := range checks {
+		rows, err := a.db.QueryContext(ctx, check.query, check.args...)
+		if err != nil {
+			return result, fmt.Errorf("failed to run %s check: %w", check.kind, err)
+		}
+		for rows.Next() {
+			e := reconciliationException{Kind: check.kind}
+			if err := rows.Scan(&e.JobID, &e.TransactionID, &e.Detail); err != nil {
+				rows.Close()
+				return result, fmt.Errorf("failed to scan %s exception: %w", check.kind, err)
+			}
+			if e.TransactionID.Valid {
+				flagged[e.TransactionID.Int64] = true
+			}
+			exceptions = append(exceptions, e)
+		}
+		rows.Close()
+	}
+	result.Exceptions = len(exceptions)
+
+	// Reconcile settled captures that showed up in no exception
+	rows, err := a.db.QueryContext(ctx, `
+		SELECT id FROM transactions
+		WHERE captured_at IS NOT NULL AND reconciled_at IS NULL
+		  AND captured_at < NOW() - make_interval(secs => $1)
+	`, reconcileSettleDelay.Seconds())
+	if err != nil {
+		return result, fmt.Errorf("failed to query unreconciled transactions: %w", err)
+	}
+	var clean []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return result, fmt.Errorf("failed to scan transaction: %w", err)
+		}
+		result.TransactionsChecked++
+		if !flagged[id] {
+			clean = append(clean, id)
+		}
+	}
+	rows.Close()
+
+	for _, id := range clean {
+		if _, err := a.db.ExecContext(ctx, `UPDATE transactions SET reconciled_at = NOW() WHERE id = $1`, id); err != nil {
+			return result, fmt.Errorf("failed to reconcile transaction %d: %w", id, err)
+		}
+		result.Reconciled++
+	}
+
+	for _, e := range exceptions {
+		fields := map[string]string{
+			"Exception": e.Kind,
+			"Job":       strconv.Itoa(e.JobID),
+		}
+		dedupKey := fmt.Sprintf("reconciliation-%s-job-%d", e.Kind, e.JobID)
+		if e.TransactionID.Valid {
+			fields["Transaction"] = strconv.FormatInt(e.TransactionID.Int64, 10)
+			dedupKey = fmt.Sprintf("reconciliation-%s-txn-%d", e.Kind, e.TransactionID.Int64)
+		}
+
+		published, err := notifications.PublishOnce(ctx, a.db, notifications.EventPaymentReconciliation, notifications.OpsAlert{
+			Title:    "Payment reconciliation exception",
+			Summary:  e.Detail,
+			Severity: notifications.SeverityError,
+			Source:   "payments",
+			DedupKey: dedupKey,
+			Fields:   fields,
+			Link:     adminLink("/jobs/%d", e.JobID),
+		}, opsAlertWindow)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to route reconciliation exception", "dedup_key", dedupKey, "error", err)
+		}
+		if published {
+			result.Alerted++
+		}
+	}
+
+	slog.InfoContext(ctx, "Payment reconciliation complete", "transactions_checked", result.TransactionsChecked, "reconciled", result.Reconciled, "exceptions", result.Exceptions)
+	return result, nil
+}
+
+// CheckMarketFillRates compares each live market's fill rate this week with the week
+// before and alerts when it falls below the floor or drops sharply. Jobs are matched
+// to a market by the city in their address.
+func (a *OpsActivities) CheckMarketFillRates(ctx context.Context) (workflows.FillRateResult, error) {
+	var result workflows.FillRateResult
+
+	floor := envFloat("FILL_RATE_ALERT_THRESHOLD", 0.6)
+	maxDrop := envFloat("FILL_RATE_DROP_THRESHOLD", 0.15)
+	minJobs := int(envFloat("FILL_RATE_MIN_JOBS", 10))
+
+	rows, err := a.db.QueryContext(ctx, `
+		SELECT m.id, m.name,
+		       COUNT(*) FILTER (WHERE j.created_at >= NOW() - make_interval(secs => $1)),
+		       COUNT(*) FILTER (WHERE j.created_at >= NOW() - make_interval(secs => $1) AND j.gig_worker_id IS NOT NULL),
+		       COUNT(*) FILTER (WHERE j.created_at < NOW() - make_interval(secs => $1)),
+		       COUNT(*) FILTER (WHERE j.created_at < NOW() - make_interval(secs => $1) AND j.gig_worker_id IS NOT NULL)
+		FROM markets m
+		JOIN jobs j ON j.location_address ILIKE '%' || m.city || '%'
+		WHERE m.is_live AND m.city IS NOT NULL
+		  AND j.created_at >= NOW() - make_interval(secs => $1 * 2)
+		  AND j.created_at < NOW() - INTERVAL '1 day'
+		  AND j.status <> 'cancelled'
+		GROUP BY m.id, m.name
+	`, fillRateWindow.Seconds())
+	if err != nil {
+		return result, fmt.Errorf("failed to query market fill rates: %w", err)
+	}
+
+	type marketFill struct {
+		ID                     int
+		Name                   string
+		Posted, Filled         int
+		PriorPosted, PriorFill int
+	}
+	var markets []marketFill
+	for rows.Next() {
+		var m marketFill
+		if err := rows.Scan(&m.ID, &m.Name, &m.Posted, &m.Filled, &m.PriorPosted, &m.PriorFill); err != nil {
+			rows.Close()
+			return result, fmt.Errorf("failed to scan market fill rate: %w", err)
+		}
+		markets = append(markets, m)
+	}
+	rows.Close()
+
+	for _, m := range markets {
+		result.MarketsChecked++
+		if m.Posted < minJobs {
+			continue
+		}
+
+		rate := float64(m.Filled) / float64(m.Posted)
+		drop := 0.0
+		if m.PriorPosted >= minJobs {
+			drop = float64(m.PriorFill)/float64(m.PriorPosted) - rate
+		}
+		if rate >= floor && drop < maxDrop {
+			continue
+		}
+		result.Drops++
+
+		severity := notifications.SeverityWarning
+		if rate < floor/2 {
+			severity = notifications.SeverityCritical
+		}
+
+		fields := map[string]string{
+			"Market":    m.Name,
+			"Fill rate": fmt.Sprintf("%.0f%% (%d of %d jobs)", rate*100, m.Filled, m.Posted),
+		}
+		if m.PriorPosted > 0 {
+			fields["Prior week"] = fmt.Sprintf("%.0f%% (%d of %d jobs)",
+				float64(m.PriorFill)/float64(m.PriorPosted)*100, m.PriorFill, m.PriorPosted)
+		}
+
+		published, err := notifications.PublishOnce(ctx, a.db, notifications.EventFillRateDrop, notifications.OpsAlert{
+			Title:    fmt.Sprintf("Fill rate drop in %s", m.Name),
+			Summary:  "Fewer posted jobs are being matched with a worker. Check worker supply and pricing in this market.",
+			Severity: severity,
+			Source:   "marketplace",
+			DedupKey: fmt.Sprintf("fill-rate-market-%d", m.ID),
+			Fields:   fields,
+			Link:     adminLink("/markets/%d", m.ID),
+		}, opsAlertWindow)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to route fill-rate drop for market", "market_id", m.ID, "error", err)
+		}
+		if published {
+			result.Alerted++
+		}
+	}
+
+	return result, nil
+}
+
+// CheckKPIAnomalies compares the last 24 hours of each platform KPI with its trailing
+// daily baseline (KPI_BASELINE_DAYS, 14 by default) and alerts on deviations past the
+// metric's sensitivity
+func (a *OpsActivities) CheckKPIAnomalies(ctx context.Context) (workflows.KPIAnomalyResult, error) {
+	var result workflows.KPIAnomalyResult
+
+	baselineDays := int(envFloat("KPI_BASELINE_DAYS", 14))
+
+	for _, metric := range kpi.Metrics() {
+		current, baseline, err := a.measureKPI(ctx, metric, baselineDays)
+		if err != nil {
+			return result, err
+		}
+		result.MetricsChecked++
+
+		anomaly, ok := kpi.Detect(metric, current, baseline)
+		if !ok {
+			continue
+		}
+		result.Anomalies++
+
+		direction := "dropped"
+		if anomaly.Up() {
+			direction = "rose"
+		}
+		severity := notifications.SeverityWarning
+		if math.Abs(anomaly.Deviation) >= metric.Threshold*2 {
+			severity = notifications.SeverityCritical
+		}
+
+		fields := map[string]string{
+			"Metric":   metric.Label,
+			"Last 24h": formatKPI(metric, anomaly.Current),
+			"Baseline": fmt.Sprintf("%s ± %s over %d days", formatKPI(metric, anomaly.Baseline), formatKPI(metric, anomaly.StdDev), baselineDays),
+		}
+		if metric.Rate {
+			fields["Volume"] = strconv.Itoa(anomaly.Volume)
+		}
+		if !math.IsInf(anomaly.Deviation, 0) {
+			fields["Deviation"] = fmt.Sprintf("%+.1f standard deviations", anomaly.Deviation)
+		}
+		if !math.IsInf(anomaly.Change, 0) {
+			fields["Change"] = fmt.Sprintf("%+.0f%%", anomaly.Change*100)
+		}
+
+		published, err := notifications.PublishOnce(ctx, a.db, notifications.EventKPIAnomaly, notifications.OpsAlert{
+			Title:    fmt.Sprintf("%s %s sharply", metric.Label, direction),
+			Summary:  fmt.Sprintf("%s over the last 24 hours is outside its normal range for the past %d days.", metric.Label, baselineDays),
+			Severity: severity,
+			Source:   "kpi",
+			DedupKey: fmt.Sprintf("kpi-anomaly-%s-%s", metric.Name, direction),
+			Fields:   fields,
+			Link:     adminLink("/overview"),
+		}, opsAlertWindow)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to route KPI anomaly", "metric", metric.Name, "error", err)
+		}
+		if published {
+			result.Alerted++
+		}
+	}
+
+	return result, nil
+}
+
+// measureKPI returns a metric's last 24 hours and its trailing daily baseline
+func (a *OpsActivities) measureKPI(ctx context.Context, metric kpi.Metric, baselineDays int) (kpi.Period, []kpi.Period, error) {
+	var current kpi.Period
+	var baseline []kpi.Period
+
+	rows, err := a.db.QueryContext(ctx, kpiQueries[metric.Name], baselineDays)
+	if err != nil {
+		return current, nil, fmt.Errorf("failed to measure %s: %w", metric.Name, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var period int
+		var p kpi.Period
+		if err := rows.Scan(&period, &p.Value, &p.Volume); err != nil {
+			return current, nil, fmt.Errorf("failed to scan %s: %w", metric.Name, err)
+		}
+		if period == 0 {
+			current = p
+		} else {
+			baseline = append(baseline, p)
+		}
+	}
+	return current, baseline, rows.Err()
+}
+
+// formatKPI formats a metric value as a count or a percentage
+func formatKPI(metric kpi.Metric, value float64) string {
+	if metric.Rate {
+		return fmt.Sprintf("%.1f%%", value*100)
+	}
+	return fmt.Sprintf("%.0f", value)
+}
+
+// RefreshAdminOverview refreshes the daily rollups behind the admin overview
+func (a *OpsActivities) RefreshAdminOverview(ctx context.Context) error {
+	if _, err := a.db.ExecContext(ctx, `SELECT refresh_admin_overview()`); err != nil {
+		return fmt.Errorf("failed to refresh admin overview views: %w", err)
+	}
+	return nil
+}
+
+// ReportWorkflowDeadLetter routes a workflow that exhausted its retries to ops
+func (a *OpsActivities) ReportWorkflowDeadLetter(ctx context.Context, deadLetter workflows.DeadLetter) error {
+	fields := map[string]string{
+		"Workflow": deadLetter.WorkflowType,
+		"ID":       deadLetter.WorkflowID,
+		"Run":      deadLetter.RunID,
+	}
+	link := ""
+	if deadLetter.JobID > 0 {
+		fields["Job"] = strconv.Itoa(deadLetter.JobID)
+		link = adminLink("/jobs/%d", deadLetter.JobID)
+	}
+
+	_, err := notifications.PublishOnce(ctx, a.db, notifications.EventWorkflowDeadLetter, notifications.OpsAlert{
+		Title:    fmt.Sprintf("%s dead-lettered", deadLetter.WorkflowType),
+		Summary:  deadLetter.Error,
+		Severity: notifications.SeverityError,
+		Source:   "temporal",
+		DedupKey: fmt.Sprintf("dead-letter-%s-%s", deadLetter.WorkflowID, deadLetter.RunID),
+		Fields:   fields,
+		Link:     link,
+	}, opsAlertWindow)
+	if err != nil {
+		// Delivery problems are recorded in the event log; don't fail the workflow over them
+		slog.ErrorContext(ctx, "Failed to route dead-lettered workflow", "workflow_id", deadLetter.WorkflowID, "error", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package activities
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"app/internal/model"
+	"app/internal/notifications"
+	"app/internal/payment"
+	"app/internal/temporal/workflows"
+)
+
+// PayoutActivities contains worker payout settlement activities
+type PayoutActivities struct {
+	db      *sql.DB
+	payouts *payment.PayoutService
+}
+
+// NewPayoutActivities creates a new PayoutActivities instance
+func NewPayoutActivities(db *sql.DB, payouts *payment.PayoutService) *PayoutActivities {
+	return &PayoutActivities{db: db, payouts: payouts}
+}
+
+// CreateSettlementBatch batches captures made before cutoff and returns the batch ID,
+// or 0 when there is nothing to settle
+func (a *PayoutActivities) CreateSettlementBatch(ctx context.Context, cutoff time.Time) (int, error) {
+	batch, err := a.payouts.CreateBatch(ctx, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	if batch == nil {
+		return 0, nil
+	}
+
+	slog.InfoContext(ctx, "Settlement batch created", "batch_id", batch.ID, "transaction_count", batch.TransactionCount, "payout_count", batch.PayoutCount, "total_amount", batch.TotalAmount)
+	return batch.ID, nil
+}
+
+// ProcessSettlementBatch pays out the batch's pending and failed payouts
+func (a *PayoutActivities) ProcessSettlementBatch(ctx context.Context, batchID int) (workflows.SettlementResult, error) {
+	batch, err := a.payouts.ProcessBatch(ctx, batchID)
+	if err != nil {
+		return workflows.SettlementResult{}, fmt.Errorf("failed to process settlement batch %d: %w", batchID, err)
+	}
+
+	result := workflows.SettlementResult{
+		SettlementBatchID: batch.ID,
+		Status:            batch.Status,
+		TotalAmount:       batch.TotalAmount,
+		Payouts:           len(batch.Payouts),
+	}
+	for _, p := range batch.Payouts {
+		switch p.Status {
+		case model.PayoutStatusPaid:
+			result.Paid++
+		case model.PayoutStatusFailed:
+			result.Failed++
+		case model.PayoutStatusManual:
+			result.Manual++
+		}
+	}
+
+	if result.Failed > 0 {
+		a.alertFailedPayouts(ctx, batch, result.Failed)
+	}
+
+	slog.InfoContext(ctx, "Settlement batch processed", "batch_id", batch.ID, "paid", result.Paid, "failed", result.Failed, "manual", result.Manual)
+	return result, nil
+}
+
+// alertFailedPayouts routes failed payouts to the payments channel, once per batch a day
+func (a *PayoutActivities) alertFailedPayouts(ctx context.Context, batch *model.SettlementBatch, failed int) {
+	_, err := notifications.PublishOnce(ctx, a.db, notifications.EventPaymentReconciliation, notifications.OpsAlert{
+		Title:    "Worker payouts failed",
+		Summary:  fmt.Sprintf("%d of %d payouts in settlement batch %d failed; retry them from the admin console once fixed", failed, len(batch.Payouts), batch.ID),
+		Severity: notifications.SeverityError,
+		Source:   "payouts",
+		DedupKey: fmt.Sprintf("payouts-failed-batch-%d", batch.ID),
+		Fields: map[string]string{
+			"Batch":  fmt.Sprint(batch.ID),
+			"Failed": fmt.Sprint(failed),
+		},
+		Link: adminLink("/payouts/batches/%d", batch.ID),
+	}, opsAlertWindow)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to route payout failures for batch", "batch_id", batch.ID, "error", err)
+	}
+}
@@ -0,0 +1,71 @@
+package activities
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"go.temporal.io/sdk/activity"
+
+	"app/internal/model"
+	"app/internal/notifications"
+	"app/internal/payment"
+	"app/internal/temporal/workflows"
+)
+
+// RefundActivities contains bulk refund activities
+type RefundActivities struct {
+	db       *sql.DB
+	payments *payment.PaymentService
+}
+
+// NewRefundActivities creates a new RefundActivities instance
+func NewRefundActivities(db *sql.DB, payments *payment.PaymentService) *RefundActivities {
+	return &RefundActivities{db: db, payments: payments}
+}
+
+// ProcessRefundBatch refunds the batch's pending items, heartbeating after each one
+func (a *RefundActivities) ProcessRefundBatch(ctx context.Context, batchID int) (workflows.RefundBatchResult, error) {
+	batch, err := a.payments.ProcessRefundBatch(ctx, batchID, func(done int) {
+		activity.RecordHeartbeat(ctx, done)
+	})
+	if err != nil {
+		return workflows.RefundBatchResult{}, fmt.Errorf("failed to process refund batch %d: %w", batchID, err)
+	}
+
+	result := workflows.RefundBatchResult{
+		RefundBatchID: batch.ID,
+		Status:        batch.Status,
+		Refunded:      batch.Summary.Refunded,
+		Failed:        batch.Summary.Failed,
+		Skipped:       batch.Summary.Skipped,
+	}
+	if batch.Status == model.RefundBatchCompletedWithErrors {
+		a.alertFailedRefunds(ctx, batch)
+	}
+
+	slog.InfoContext(ctx, "Refund batch processed", "batch_id", batch.ID, "refunded", result.Refunded, "failed", result.Failed, "skipped", result.Skipped)
+	return result, nil
+}
+
+// alertFailedRefunds routes failed refunds to the payments channel, once per batch a day
+func (a *RefundActivities) alertFailedRefunds(ctx context.Context, batch *model.RefundBatch) {
+	_, err := notifications.PublishOnce(ctx, a.db, notifications.EventPaymentReconciliation, notifications.OpsAlert{
+		Title:    "Bulk refunds failed",
+		Summary:  fmt.Sprintf("%d of %d refunds in refund batch %d failed; retry them from the admin console once fixed", batch.Summary.Failed, batch.Summary.Items-batch.Summary.Skipped, batch.ID),
+		Severity: notifications.SeverityError,
+		Source:   "refunds",
+		DedupKey: fmt.Sprintf("refunds-failed-batch-%d", batch.ID),
+		Fields: map[string]string{
+			"Batch":    fmt.Sprint(batch.ID),
+			"Reason":   batch.Reason,
+			"Failed":   fmt.Sprintf("%d (%s)", batch.Summary.Failed, batch.Summary.FailedAmount),
+			"Refunded": fmt.Sprintf("%d (%s)", batch.Summary.Refunded, batch.Summary.RefundedAmount),
+		},
+		Link: adminLink("/refund-batches/%d", batch.ID),
+	}, opsAlertWindow)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to route refund failures for batch", "batch_id", batch.ID, "error", err)
+	}
+}
@@ -0,0 +1,174 @@
+package activities
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/lib/pq"
+
+	"app/internal/email"
+	"app/internal/temporal/workflows"
+	"app/internal/weather"
+)
+
+// weatherLookaheadDays is how far ahead scheduled jobs are checked
+const weatherLookaheadDays = 7
+
+// CheckOutdoorJobWeather refreshes the forecast advisory for every scheduled outdoor job
+// in the lookahead window and notifies both parties the first time severe weather is forecast
+func (a *JobActivities) CheckOutdoorJobWeather(ctx context.Context) (workflows.WeatherCheckResult, error) {
+	var result workflows.WeatherCheckResult
+
+	query := `
+		SELECT j.id, j.title, j.location_latitude, j.location_longitude,
+		       j.scheduled_start, COALESCE(j.scheduled_end, j.scheduled_start + INTERVAL '2 hours'),
+		       wa.severe_notified_at
+		FROM jobs j
+		LEFT JOIN job_weather_advisories wa ON wa.job_id = j.id
+		WHERE j.status IN ('accepted', 'worker_assigned', 'scheduled')
+		  AND j.category = ANY($1)
+		  AND j.location_latitude IS NOT NULL AND j.location_longitude IS NOT NULL
+		  AND j.scheduled_start BETWEEN NOW() AND NOW() + make_interval(days => $2)
+	`
+	rows, err := a.db.QueryContext(ctx, query, pq.Array(weather.OutdoorCategories()), weatherLookaheadDays)
+	if err != nil {
+		return result, fmt.Errorf("failed to query outdoor jobs: %w", err)
+	}
+
+	type outdoorJob struct {
+		ID             int
+		Title          string
+		Lat, Lon       float64
+		Start, End     time.Time
+		SevereNotified sql.NullTime
+	}
+	var jobs []outdoorJob
+	for rows.Next() {
+		var j outdoorJob
+		if err := rows.Scan(&j.ID, &j.Title, &j.Lat, &j.Lon, &j.Start, &j.End, &j.SevereNotified); err != nil {
+			rows.Close()
+			return result, fmt.Errorf("failed to scan outdoor job: %w", err)
+		}
+		jobs = append(jobs, j)
+	}
+	rows.Close()
+
+	client := weather.NewClient()
+	for _, j := range jobs {
+		// Fetch through the following week so an alternative day can be suggested
+		forecast, err := client.GetForecast(ctx, j.Lat, j.Lon, j.Start, j.End.AddDate(0, 0, weatherLookaheadDays))
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to fetch forecast for job", "id", j.ID, "error", err)
+			continue
+		}
+		result.JobsChecked++
+
+		window := &weather.Forecast{Latitude: forecast.Latitude, Longitude: forecast.Longitude}
+		for _, h := range forecast.Hours {
+			if !h.Time.Before(j.Start.Truncate(time.Hour)) && !h.Time.After(j.End) {
+				window.Hours = append(window.Hours, h)
+			}
+		}
+		advisory := weather.Assess(window)
+
+		var suggestedStart *time.Time
+		if advisory.Level == weather.LevelSevere {
+			result.SevereJobs++
+			suggestedStart = weather.SuggestAlternative(forecast, j.Start, j.End, weatherLookaheadDays)
+		}
+
+		forecastJSON, _ := json.Marshal(window.Hours)
+		_, err = a.db.ExecContext(ctx, `
+			INSERT INTO job_weather_advisories (job_id, level, summary, reasons, forecast, suggested_start, checked_at)
+			VALUES ($1, $2, $3, $4, $5, $6, NOW())
+			ON CONFLICT (job_id) DO UPDATE SET
+				level = EXCLUDED.level,
+				summary = EXCLUDED.summary,
+				reasons = EXCLUDED.reasons,
+				forecast = EXCLUDED.forecast,
+				suggested_start = EXCLUDED.suggested_start,
+				checked_at = EXCLUDED.checked_at
+		`, j.ID, advisory.Level, advisory.Summary, pq.Array(advisory.Reasons), forecastJSON, suggestedStart)
+		if err != nil {
+			return result, fmt.Errorf("failed to save weather advisory for job %d: %w", j.ID, err)
+		}
+
+		if advisory.Level != weather.LevelSevere || j.SevereNotified.Valid {
+			continue
+		}
+
+		if err := a.notifySevereWeather(ctx, j.ID, j.Title, advisory, suggestedStart); err != nil {
+			slog.ErrorContext(ctx, "Failed to send severe weather notification for job", "id", j.ID, "error", err)
+			continue
+		}
+		result.Notified++
+	}
+
+	slog.InfoContext(ctx, "Weather check complete", "jobs_checked", result.JobsChecked, "severe_jobs", result.SevereJobs, "notified", result.Notified)
+	return result, nil
+}
+
+// notifySevereWeather emails the consumer and assigned worker about severe weather
+// with a link to propose the suggested reschedule
+func (a *JobActivities) notifySevereWeather(ctx context.Context, jobID int, title string, advisory weather.Advisory, suggestedStart *time.Time) error {
+	rows, err := a.db.QueryContext(ctx, `
+		SELECT p.email, p.name
+		FROM jobs j
+		JOIN people p ON p.id = j.consumer_id OR p.id = j.gig_worker_id
+		WHERE j.id = $1
+	`, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to get job participants: %w", err)
+	}
+	defer rows.Close()
+
+	emailService, err := email.NewServiceFromEnv()
+	if err != nil {
+		return fmt.Errorf("email service not configured: %w", err)
+	}
+
+	baseURL := os.Getenv("APP_BASE_URL")
+	if baseURL == "" {
+		baseURL = "https://app.gigco.com"
+	}
+
+	message := advisory.Summary + "."
+	actionLabel := "View job"
+	actionLink := fmt.Sprintf("%s/jobs/%d", baseURL, jobID)
+	if suggestedStart != nil {
+		message += fmt.Sprintf(" The forecast looks better on %s.", suggestedStart.Format("Monday, Jan 2 at 3:04 PM MST"))
+		actionLabel = "Propose this time"
+		actionLink = fmt.Sprintf("%s/jobs/%d/reschedule?suggested=1", baseURL, jobID)
+	}
+
+	for rows.Next() {
+		var to, name string
+		if err := rows.Scan(&to, &name); err != nil {
+			return fmt.Errorf("failed to scan participant: %w", err)
+		}
+		err := emailService.SendJobNotification(to, name, email.JobNotificationData{
+			UserName:    name,
+			JobTitle:    title,
+			JobID:       fmt.Sprintf("%d", jobID),
+			Message:     message,
+			ActionLink:  actionLink,
+			ActionLabel: actionLabel,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to email %s: %w", to, err)
+		}
+	}
+
+	_, err = a.db.ExecContext(ctx,
+		`UPDATE job_weather_advisories SET severe_notified_at = NOW() WHERE job_id = $1`, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to mark severe weather notified: %w", err)
+	}
+
+	return nil
+}
@@ -3,7 +3,7 @@ package temporal
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 
 	"go.temporal.io/sdk/client"
@@ -20,14 +20,12 @@ type Client struct {
 func NewClient() (*Client, error) {
 	temporalHost := getEnv("TEMPORAL_HOST", "localhost:7233")
 
-	c, err := client.Dial(client.Options{
-		HostPort: temporalHost,
-	})
+	c, err := client.Dial(ClientOptions(temporalHost))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Temporal client: %w", err)
 	}
 
-	log.Printf("Connected to Temporal server at %s", temporalHost)
+	slog.Info("Connected to Temporal server", "temporal_host", temporalHost)
 
 	return &Client{Client: c}, nil
 }
@@ -52,7 +50,7 @@ func (c *Client) StartJobWorkflow(ctx context.Context, jobID, consumerID int) (c
 		return nil, fmt.Errorf("failed to start job workflow: %w", err)
 	}
 
-	log.Printf("Started job workflow for job %d with ID: %s", jobID, we.GetID())
+	slog.InfoContext(ctx, "Started job workflow", "job_id", jobID, "workflow_id", we.GetID())
 	return we, nil
 }
 
@@ -69,7 +67,24 @@ func (c *Client) SignalJobOfferResponse(ctx context.Context, workflowID string,
 		return fmt.Errorf("failed to signal offer response: %w", err)
 	}
 
-	log.Printf("Signaled offer response for workflow %s: accepted=%t", workflowID, accepted)
+	slog.InfoContext(ctx, "Signaled offer response for workflow", "workflow_id", workflowID, "accepted", accepted)
+	return nil
+}
+
+// SignalWorkerOfferResponse signals the workflow with a worker's answer to its job offer
+func (c *Client) SignalWorkerOfferResponse(ctx context.Context, workflowID string, response workflows.WorkerOfferResponse) error {
+	err := c.SignalWorkflow(
+		ctx,
+		workflowID,
+		"",
+		workflows.WorkerOfferSignal,
+		response,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to signal worker offer response: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Signaled worker offer response for workflow", "workflow_id", workflowID, "worker_id", response.WorkerID, "accepted", response.Accepted)
 	return nil
 }
 
@@ -86,7 +101,7 @@ func (c *Client) SignalJobStarted(ctx context.Context, workflowID string) error
 		return fmt.Errorf("failed to signal job started: %w", err)
 	}
 
-	log.Printf("Signaled job started for workflow %s", workflowID)
+	slog.InfoContext(ctx, "Signaled job started for workflow", "workflow_id", workflowID)
 	return nil
 }
 
@@ -103,7 +118,7 @@ func (c *Client) SignalJobCompleted(ctx context.Context, workflowID string) erro
 		return fmt.Errorf("failed to signal job completed: %w", err)
 	}
 
-	log.Printf("Signaled job completed for workflow %s", workflowID)
+	slog.InfoContext(ctx, "Signaled job completed for workflow", "workflow_id", workflowID)
 	return nil
 }
 
@@ -120,10 +135,216 @@ func (c *Client) SignalReviewSubmitted(ctx context.Context, workflowID string, r
 		return fmt.Errorf("failed to signal review submitted: %w", err)
 	}
 
-	log.Printf("Signaled review submitted for workflow %s", workflowID)
+	slog.InfoContext(ctx, "Signaled review submitted for workflow", "workflow_id", workflowID)
+	return nil
+}
+
+// SignalJobPaused asks the job workflow to hold before its next step
+func (c *Client) SignalJobPaused(ctx context.Context, workflowID string, req workflows.PauseRequest) error {
+	err := c.SignalWorkflow(
+		ctx,
+		workflowID,
+		"",
+		"job-paused",
+		req,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to signal job paused: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Signaled job paused for workflow", "workflow_id", workflowID, "reason", req.Reason)
+	return nil
+}
+
+// SignalJobHandoff tells the job workflow the consumer approved its worker's hand-off
+func (c *Client) SignalJobHandoff(ctx context.Context, workflowID string, req workflows.HandoffRequest) error {
+	err := c.SignalWorkflow(
+		ctx,
+		workflowID,
+		"",
+		workflows.JobHandoffSignal,
+		req,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to signal job hand-off: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Signaled job hand-off for workflow", "workflow_id", workflowID, "handoff_id", req.HandoffID)
 	return nil
 }
 
+// SignalJobResumed releases a paused job workflow
+func (c *Client) SignalJobResumed(ctx context.Context, workflowID string) error {
+	err := c.SignalWorkflow(
+		ctx,
+		workflowID,
+		"",
+		"job-resumed",
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to signal job resumed: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Signaled job resumed for workflow", "workflow_id", workflowID)
+	return nil
+}
+
+// StartAccountDeletionWorkflow starts the deletion hold for a deactivated account
+func (c *Client) StartAccountDeletionWorkflow(ctx context.Context, userID, requestID int) (client.WorkflowRun, error) {
+	workflowOptions := client.StartWorkflowOptions{
+		ID:        fmt.Sprintf("account-deletion-%d", requestID),
+		TaskQueue: "gigco-jobs",
+	}
+
+	we, err := c.ExecuteWorkflow(
+		ctx,
+		workflowOptions,
+		workflows.AccountDeletionWorkflow,
+		workflows.AccountDeletionInput{
+			UserID:    userID,
+			RequestID: requestID,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start account deletion workflow: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Started account deletion workflow", "user_id", userID, "workflow_id", we.GetID())
+	return we, nil
+}
+
+// StartDataExportWorkflow starts building a data export the user requested
+func (c *Client) StartDataExportWorkflow(ctx context.Context, input workflows.DataExportInput) (client.WorkflowRun, error) {
+	workflowOptions := client.StartWorkflowOptions{
+		ID:        workflows.DataExportWorkflowID(input.ExportID),
+		TaskQueue: "gigco-jobs",
+	}
+
+	we, err := c.ExecuteWorkflow(ctx, workflowOptions, workflows.DataExportWorkflow, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start data export workflow: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Started data export workflow", "user_id", input.UserID, "workflow_id", we.GetID())
+	return we, nil
+}
+
+// SignalAccountReactivated cancels a pending account deletion
+func (c *Client) SignalAccountReactivated(ctx context.Context, workflowID string) error {
+	err := c.SignalWorkflow(
+		ctx,
+		workflowID,
+		"",
+		"account-reactivated",
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to signal account reactivated: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Signaled account reactivated for workflow", "workflow_id", workflowID)
+	return nil
+}
+
+// StartDisputeWorkflow starts the payment hold for a disputed job
+func (c *Client) StartDisputeWorkflow(ctx context.Context, input workflows.DisputeInput) (client.WorkflowRun, error) {
+	workflowOptions := client.StartWorkflowOptions{
+		ID:        fmt.Sprintf("dispute-%d", input.DisputeID),
+		TaskQueue: "gigco-jobs",
+	}
+
+	we, err := c.ExecuteWorkflow(ctx, workflowOptions, workflows.DisputeWorkflow, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start dispute workflow: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Started dispute workflow", "job_id", input.JobID, "workflow_id", we.GetID())
+	return we, nil
+}
+
+// SignalDisputeResolved releases the hold on a disputed job
+func (c *Client) SignalDisputeResolved(ctx context.Context, workflowID string, resolution workflows.DisputeResolution) error {
+	err := c.SignalWorkflow(
+		ctx,
+		workflowID,
+		"",
+		"dispute-resolved",
+		resolution,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to signal dispute resolved: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Signaled dispute resolved for workflow", "workflow_id", workflowID)
+	return nil
+}
+
+// StartEscrowWorkflow starts looking after a payment authorization until it is settled
+func (c *Client) StartEscrowWorkflow(ctx context.Context, input workflows.EscrowInput) (client.WorkflowRun, error) {
+	workflowOptions := client.StartWorkflowOptions{
+		ID:        workflows.EscrowWorkflowID(input.TransactionID),
+		TaskQueue: "gigco-jobs",
+	}
+
+	we, err := c.ExecuteWorkflow(ctx, workflowOptions, workflows.EscrowWorkflow, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start escrow workflow: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Started escrow workflow", "job_id", input.JobID, "workflow_id", we.GetID())
+	return we, nil
+}
+
+// StartRefundBatchWorkflow starts refunding a batch's pending items. While a run for
+// the batch is in progress, that run is returned instead.
+func (c *Client) StartRefundBatchWorkflow(ctx context.Context, batchID int) (client.WorkflowRun, error) {
+	workflowOptions := client.StartWorkflowOptions{
+		ID:        workflows.RefundBatchWorkflowID(batchID),
+		TaskQueue: "gigco-jobs",
+	}
+
+	we, err := c.ExecuteWorkflow(ctx, workflowOptions, workflows.RefundBatchWorkflow, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start refund batch workflow: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Started refund batch workflow", "batch_id", batchID, "workflow_id", we.GetID())
+	return we, nil
+}
+
+// SignalEscrowUpdated asks an escrow workflow to re-check its authorization
+func (c *Client) SignalEscrowUpdated(ctx context.Context, workflowID string) error {
+	err := c.SignalWorkflow(
+		ctx,
+		workflowID,
+		"",
+		workflows.EscrowUpdatedSignal,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to signal escrow updated: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Signaled escrow updated for workflow", "workflow_id", workflowID)
+	return nil
+}
+
+// QueryJobWorkflowState asks a job workflow for its current state. Closed workflows
+// still answer from their history while it is retained.
+func (c *Client) QueryJobWorkflowState(ctx context.Context, workflowID string) (*workflows.JobWorkflowState, error) {
+	response, err := c.QueryWorkflow(ctx, workflowID, "", workflows.JobStateQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query job workflow state: %w", err)
+	}
+
+	var state workflows.JobWorkflowState
+	if err := response.Get(&state); err != nil {
+		return nil, fmt.Errorf("failed to decode job workflow state: %w", err)
+	}
+	return &state, nil
+}
+
 // GetWorkflowStatus retrieves the workflow status
 func (c *Client) GetWorkflowStatus(ctx context.Context, workflowID string) error {
 	// This is a utility method for debugging workflows
@@ -132,11 +353,11 @@ func (c *Client) GetWorkflowStatus(ctx context.Context, workflowID string) error
 	var result interface{}
 	err := workflowRun.Get(ctx, &result)
 	if err != nil {
-		log.Printf("Workflow %s is still running or failed: %v", workflowID, err)
+		slog.ErrorContext(ctx, "Workflow is still running or failed", "workflow_id", workflowID, "error", err)
 		return err
 	}
 
-	log.Printf("Workflow %s completed with result: %v", workflowID, result)
+	slog.InfoContext(ctx, "Workflow completed with result", "workflow_id", workflowID, "result", result)
 	return nil
 }
 
@@ -0,0 +1,28 @@
+package temporal
+
+import (
+	"context"
+	"sync"
+
+	"go.temporal.io/sdk/client"
+)
+
+var (
+	healthClient     client.Client
+	healthClientErr  error
+	healthClientOnce sync.Once
+)
+
+// CheckHealth reports whether the Temporal frontend at TEMPORAL_HOST is reachable. One
+// lazily connected client is shared between calls, so readiness probes don't dial a new
+// connection each time.
+func CheckHealth(ctx context.Context) error {
+	healthClientOnce.Do(func() {
+		healthClient, healthClientErr = client.NewLazyClient(ClientOptions(getEnv("TEMPORAL_HOST", "localhost:7233")))
+	})
+	if healthClientErr != nil {
+		return healthClientErr
+	}
+	_, err := healthClient.CheckHealth(ctx, &client.CheckHealthRequest{})
+	return err
+}
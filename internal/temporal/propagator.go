@@ -0,0 +1,97 @@
+package temporal
+
+import (
+	"context"
+	"log/slog"
+
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/contrib/opentelemetry"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/interceptor"
+	tlog "go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/workflow"
+
+	"app/internal/logger"
+)
+
+// requestIDHeader is the Temporal header carrying the ID of the API request that
+// started or signalled a workflow
+const requestIDHeader = "request-id"
+
+type workflowRequestIDKey struct{}
+
+// RequestIDPropagator carries the request ID from the API into the workflows it starts
+// and on to their activities, so their log lines share the request's ID
+type RequestIDPropagator struct{}
+
+var _ workflow.ContextPropagator = RequestIDPropagator{}
+
+// ClientOptions are the Temporal client options shared by the API and the worker:
+// slog logging, request ID propagation and tracing. The tracing interceptor continues
+// the caller's trace into the workflow and its activities; workers created from the
+// client inherit it.
+func ClientOptions(hostPort string) client.Options {
+	options := client.Options{
+		HostPort:           hostPort,
+		Logger:             tlog.NewStructuredLogger(slog.Default()),
+		ContextPropagators: []workflow.ContextPropagator{RequestIDPropagator{}},
+	}
+	if tracing, err := opentelemetry.NewTracingInterceptor(opentelemetry.TracerOptions{}); err == nil {
+		options.Interceptors = []interceptor.ClientInterceptor{tracing}
+	}
+	return options
+}
+
+// Inject adds the request ID of a client or activity context to the headers
+func (RequestIDPropagator) Inject(ctx context.Context, w workflow.HeaderWriter) error {
+	return injectRequestID(logger.RequestID(ctx), w)
+}
+
+// Extract adds the request ID in the headers to an activity context
+func (RequestIDPropagator) Extract(ctx context.Context, r workflow.HeaderReader) (context.Context, error) {
+	id, err := extractRequestID(r)
+	if err != nil || id == "" {
+		return ctx, err
+	}
+	return logger.WithRequestID(ctx, id), nil
+}
+
+// InjectFromWorkflow passes a workflow's request ID on to its activities and child
+// workflows
+func (RequestIDPropagator) InjectFromWorkflow(ctx workflow.Context, w workflow.HeaderWriter) error {
+	id, _ := ctx.Value(workflowRequestIDKey{}).(string)
+	return injectRequestID(id, w)
+}
+
+// ExtractToWorkflow adds the request ID in the headers to a workflow context
+func (RequestIDPropagator) ExtractToWorkflow(ctx workflow.Context, r workflow.HeaderReader) (workflow.Context, error) {
+	id, err := extractRequestID(r)
+	if err != nil || id == "" {
+		return ctx, err
+	}
+	return workflow.WithValue(ctx, workflowRequestIDKey{}, id), nil
+}
+
+func injectRequestID(id string, w workflow.HeaderWriter) error {
+	if id == "" {
+		return nil
+	}
+	payload, err := converter.GetDefaultDataConverter().ToPayload(id)
+	if err != nil {
+		return err
+	}
+	w.Set(requestIDHeader, payload)
+	return nil
+}
+
+func extractRequestID(r workflow.HeaderReader) (string, error) {
+	payload, ok := r.Get(requestIDHeader)
+	if !ok {
+		return "", nil
+	}
+	var id string
+	if err := converter.GetDefaultDataConverter().FromPayload(payload, &id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
@@ -0,0 +1,64 @@
+package temporal
+
+import (
+	"context"
+	"testing"
+
+	commonpb "go.temporal.io/api/common/v1"
+
+	"app/internal/logger"
+)
+
+// headers is an in-memory Temporal header
+type headers map[string]*commonpb.Payload
+
+func (h headers) Set(key string, value *commonpb.Payload) { h[key] = value }
+
+func (h headers) Get(key string) (*commonpb.Payload, bool) {
+	value, ok := h[key]
+	return value, ok
+}
+
+func (h headers) ForEachKey(handler func(string, *commonpb.Payload) error) error {
+	for k, v := range h {
+		if err := handler(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestRequestIDPropagator(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID string
+	}{
+		{"from an API request", "3f2b9c1e7d4a4b8e"},
+		{"without a request", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.requestID != "" {
+				ctx = logger.WithRequestID(ctx, tt.requestID)
+			}
+
+			h := headers{}
+			if err := (RequestIDPropagator{}).Inject(ctx, h); err != nil {
+				t.Fatalf("Inject() error = %v", err)
+			}
+			if _, ok := h[requestIDHeader]; ok != (tt.requestID != "") {
+				t.Errorf("header set = %v, want %v", ok, tt.requestID != "")
+			}
+
+			extracted, err := (RequestIDPropagator{}).Extract(context.Background(), h)
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			if got := logger.RequestID(extracted); got != tt.requestID {
+				t.Errorf("RequestID() = %q, want %q", got, tt.requestID)
+			}
+		})
+	}
+}
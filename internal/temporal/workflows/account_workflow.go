@@ -0,0 +1,107 @@
+package workflows
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// AccountDeletionHold is how long a deactivated account is retained before erasure
+	AccountDeletionHold = 14 * 24 * time.Hour
+	// AccountWinBackAfter is when during the hold the win-back notification is sent
+	AccountWinBackAfter = 10 * 24 * time.Hour
+)
+
+// AccountDeletionInput contains the input for an account deletion workflow
+type AccountDeletionInput struct {
+	UserID    int `json:"user_id"`
+	RequestID int `json:"request_id"`
+}
+
+// AccountDeletionWorkflow exports the user's data and emails them a link to it, holds
+// the deactivated account, sends a win-back notification partway through the hold, and
+// purges the account when the hold expires. A "account-reactivated" signal ends the
+// workflow without purging.
+func AccountDeletionWorkflow(ctx workflow.Context, input AccountDeletionInput) error {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting account deletion hold", "userID", input.UserID, "requestID", input.RequestID)
+
+	// Purging must eventually succeed, so retry without an attempt limit
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 5 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Minute,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    time.Hour,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	reactivated := false
+	reactivatedChan := workflow.GetSignalChannel(ctx, "account-reactivated")
+
+	// waitFor blocks until d elapses or the account is reactivated
+	waitFor := func(d time.Duration) {
+		timerCtx, cancel := workflow.WithCancel(ctx)
+		defer cancel()
+
+		selector := workflow.NewSelector(ctx)
+		selector.AddFuture(workflow.NewTimer(timerCtx, d), func(f workflow.Future) {})
+		selector.AddReceive(reactivatedChan, func(c workflow.ReceiveChannel, more bool) {
+			c.Receive(ctx, nil)
+			reactivated = true
+		})
+		selector.Select(ctx)
+	}
+
+	// Step 1: Export the user's data before anything is erased. A failed export does not
+	// keep the account: erasure goes ahead at the end of the hold regardless.
+	if workflow.GetVersion(ctx, "export-before-erasure", workflow.DefaultVersion, 1) == 1 {
+		exportCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+			StartToCloseTimeout: 10 * time.Minute,
+			RetryPolicy: &temporal.RetryPolicy{
+				InitialInterval:    time.Minute,
+				BackoffCoefficient: 2.0,
+				MaximumInterval:    time.Hour,
+				MaximumAttempts:    10,
+			},
+		})
+		if err := workflow.ExecuteActivity(exportCtx, "ExportAccountData", input).Get(ctx, nil); err != nil {
+			logger.Error("Failed to export account data", "userID", input.UserID, "error", err)
+		}
+	}
+
+	// Step 2: Hold until the win-back point
+	waitFor(AccountWinBackAfter)
+	if reactivated {
+		logger.Info("Account reactivated before win-back", "userID", input.UserID)
+		return nil
+	}
+
+	// Step 3: Win-back notification (best effort)
+	winBackCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 5 * time.Minute,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 3},
+	})
+	if err := workflow.ExecuteActivity(winBackCtx, "SendAccountWinBack", input).Get(ctx, nil); err != nil {
+		logger.Error("Failed to send win-back notification", "userID", input.UserID, "error", err)
+	}
+
+	// Step 4: Hold for the remainder of the period
+	waitFor(AccountDeletionHold - AccountWinBackAfter)
+	if reactivated {
+		logger.Info("Account reactivated after win-back", "userID", input.UserID)
+		return nil
+	}
+
+	// Step 5: Erase the account
+	if err := workflow.ExecuteActivity(ctx, "PurgeAccount", input).Get(ctx, nil); err != nil {
+		logger.Error("Account purge failed", "userID", input.UserID, "error", err)
+		return err
+	}
+
+	logger.Info("Account deletion completed", "userID", input.UserID)
+	return nil
+}
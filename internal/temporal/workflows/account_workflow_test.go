@@ -0,0 +1,73 @@
+package workflows
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestAccountDeletionWorkflow(t *testing.T) {
+	tests := []struct {
+		name         string
+		exportFails  bool
+		reactivateIn time.Duration // Sends account-reactivated when set
+		wantSteps    []string
+	}{
+		{
+			name:      "exports before the hold and purges after it",
+			wantSteps: []string{"ExportAccountData", "SendAccountWinBack", "PurgeAccount"},
+		},
+		{
+			name:        "failed export does not stop erasure",
+			exportFails: true,
+			wantSteps:   []string{"ExportAccountData", "SendAccountWinBack", "PurgeAccount"},
+		},
+		{
+			name:         "reactivated during the hold",
+			reactivateIn: 24 * time.Hour,
+			wantSteps:    []string{"ExportAccountData"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var suite testsuite.WorkflowTestSuite
+			env := suite.NewTestWorkflowEnvironment()
+
+			var steps []string
+			for _, name := range []string{"ExportAccountData", "SendAccountWinBack", "PurgeAccount"} {
+				name := name
+				env.RegisterActivityWithOptions(func(ctx context.Context, in AccountDeletionInput) error {
+					steps = append(steps, name)
+					if name == "ExportAccountData" && tt.exportFails {
+						return temporal.NewNonRetryableApplicationError("storage unavailable", "export", errors.New("storage unavailable"))
+					}
+					return nil
+				}, activity.RegisterOptions{Name: name})
+			}
+
+			if tt.reactivateIn > 0 {
+				env.RegisterDelayedCallback(func() {
+					env.SignalWorkflow("account-reactivated", nil)
+				}, tt.reactivateIn)
+			}
+			env.ExecuteWorkflow(AccountDeletionWorkflow, AccountDeletionInput{UserID: 7, RequestID: 3})
+
+			if !env.IsWorkflowCompleted() {
+				t.Fatal("workflow did not complete")
+			}
+			if err := env.GetWorkflowError(); err != nil {
+				t.Fatalf("workflow error: %v", err)
+			}
+			if !reflect.DeepEqual(steps, tt.wantSteps) {
+				t.Errorf("steps = %v, want %v", steps, tt.wantSteps)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package workflows
+
+import (
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// DataExportTTL is how long a requested data export can be downloaded before it is
+// deleted; signed URLs last at most this long
+const DataExportTTL = 7 * 24 * time.Hour
+
+// DataExportInput contains the input for a data export workflow
+type DataExportInput struct {
+	ExportID int    `json:"export_id"`
+	UserID   int    `json:"user_id"`
+	Format   string `json:"format"`
+}
+
+// DataExportWorkflowID is the ID of the workflow building an export
+func DataExportWorkflowID(exportID int) string {
+	return fmt.Sprintf("data-export-%d", exportID)
+}
+
+// DataExportWorkflow builds the archive of a user's data they requested, emails them a
+// link to it, and deletes it when the link expires. An export that cannot be built is
+// marked failed so the user can request another.
+func DataExportWorkflow(ctx workflow.Context, input DataExportInput) error {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting data export", "userID", input.UserID, "exportID", input.ExportID)
+
+	buildCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 10 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    30 * time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    10 * time.Minute,
+			MaximumAttempts:    5,
+		},
+	})
+	// Marking the export failed and deleting it must eventually succeed
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 5 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Minute,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    time.Hour,
+		},
+	})
+
+	// Step 1: Build and store the archive
+	if err := workflow.ExecuteActivity(buildCtx, "BuildDataExport", input).Get(ctx, nil); err != nil {
+		logger.Error("Failed to build data export", "exportID", input.ExportID, "error", err)
+		if err := workflow.ExecuteActivity(ctx, "FailDataExport", input).Get(ctx, nil); err != nil {
+			logger.Error("Failed to mark data export failed", "exportID", input.ExportID, "error", err)
+		}
+		return err
+	}
+
+	// Step 2: Email the link (best effort; the export endpoint returns it too)
+	emailCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 5 * time.Minute,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 3},
+	})
+	if err := workflow.ExecuteActivity(emailCtx, "SendDataExportReady", input).Get(ctx, nil); err != nil {
+		logger.Error("Failed to email data export link", "exportID", input.ExportID, "error", err)
+	}
+
+	// Step 3: Delete the archive once its link has expired
+	if err := workflow.Sleep(ctx, DataExportTTL); err != nil {
+		return err
+	}
+	if err := workflow.ExecuteActivity(ctx, "ExpireDataExport", input).Get(ctx, nil); err != nil {
+		logger.Error("Failed to expire data export", "exportID", input.ExportID, "error", err)
+		return err
+	}
+
+	logger.Info("Data export expired", "userID", input.UserID, "exportID", input.ExportID)
+	return nil
+}
@@ -0,0 +1,69 @@
+package workflows
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestDataExportWorkflow(t *testing.T) {
+	tests := []struct {
+		name       string
+		buildFails bool
+		emailFails bool
+		wantSteps  []string
+		wantErr    bool
+	}{
+		{
+			name:      "builds, emails and expires",
+			wantSteps: []string{"BuildDataExport", "SendDataExportReady", "ExpireDataExport"},
+		},
+		{
+			name:       "failed email still expires",
+			emailFails: true,
+			wantSteps:  []string{"BuildDataExport", "SendDataExportReady", "ExpireDataExport"},
+		},
+		{
+			name:       "failed build is marked failed",
+			buildFails: true,
+			wantSteps:  []string{"BuildDataExport", "FailDataExport"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var suite testsuite.WorkflowTestSuite
+			env := suite.NewTestWorkflowEnvironment()
+
+			var steps []string
+			for _, name := range []string{"BuildDataExport", "SendDataExportReady", "FailDataExport", "ExpireDataExport"} {
+				name := name
+				env.RegisterActivityWithOptions(func(ctx context.Context, in DataExportInput) error {
+					steps = append(steps, name)
+					if (name == "BuildDataExport" && tt.buildFails) || (name == "SendDataExportReady" && tt.emailFails) {
+						return temporal.NewNonRetryableApplicationError("unavailable", "export", errors.New("unavailable"))
+					}
+					return nil
+				}, activity.RegisterOptions{Name: name})
+			}
+
+			env.ExecuteWorkflow(DataExportWorkflow, DataExportInput{ExportID: 5, UserID: 7, Format: "csv"})
+
+			if !env.IsWorkflowCompleted() {
+				t.Fatal("workflow did not complete")
+			}
+			if err := env.GetWorkflowError(); (err != nil) != tt.wantErr {
+				t.Fatalf("workflow error = %v, want error %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(steps, tt.wantSteps) {
+				t.Errorf("steps = %v, want %v", steps, tt.wantSteps)
+			}
+		})
+	}
+}
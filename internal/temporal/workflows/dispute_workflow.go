@@ -0,0 +1,100 @@
+package workflows
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// DisputeReviewSLA is how long a dispute may stay unresolved before ops is reminded
+const DisputeReviewSLA = 72 * time.Hour
+
+// DisputeInput contains the input for a dispute workflow
+type DisputeInput struct {
+	DisputeID     int    `json:"dispute_id"`
+	JobID         int    `json:"job_id"`
+	JobWorkflowID string `json:"job_workflow_id,omitempty"` // Empty when the job has no workflow
+}
+
+// DisputeResolution is sent with the "dispute-resolved" signal
+type DisputeResolution struct {
+	Status   string `json:"status"`
+	Refunded bool   `json:"refunded"`
+}
+
+// DisputeWorkflow holds a disputed job's payment until support resolves the dispute.
+// It pauses the job workflow so the payment is not captured, alerts ops, and reminds
+// them when the dispute outlives DisputeReviewSLA. On resolution the job workflow is
+// resumed, or cancelled when the payment was refunded, since the refund cancels the job.
+func DisputeWorkflow(ctx workflow.Context, input DisputeInput) error {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting dispute hold", "disputeID", input.DisputeID, "jobID", input.JobID)
+
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 5 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 3,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	// Step 1: Hold the job workflow before it captures payment. The job workflow may
+	// already have finished, in which case the settlement hold on the dispute is enough.
+	if input.JobWorkflowID != "" {
+		pause := PauseRequest{Reason: "payment dispute", DisputeID: input.DisputeID}
+		if err := workflow.SignalExternalWorkflow(ctx, input.JobWorkflowID, "", "job-paused", pause).Get(ctx, nil); err != nil {
+			logger.Warn("Could not pause job workflow", "jobID", input.JobID, "error", err)
+		}
+	}
+
+	// Step 2: Alert ops (best effort)
+	if err := workflow.ExecuteActivity(ctx, "AlertDisputeOpened", input).Get(ctx, nil); err != nil {
+		logger.Error("Failed to alert ops of dispute", "disputeID", input.DisputeID, "error", err)
+	}
+
+	// Step 3: Wait for resolution, reminding ops once the review SLA passes
+	var resolution DisputeResolution
+	resolvedChan := workflow.GetSignalChannel(ctx, "dispute-resolved")
+
+	timerCtx, cancelTimer := workflow.WithCancel(ctx)
+	selector := workflow.NewSelector(ctx)
+	selector.AddReceive(resolvedChan, func(c workflow.ReceiveChannel, more bool) {
+		c.Receive(ctx, &resolution)
+	})
+	selector.AddFuture(workflow.NewTimer(timerCtx, DisputeReviewSLA), func(f workflow.Future) {
+		if err := workflow.ExecuteActivity(ctx, "EscalateDispute", input).Get(ctx, nil); err != nil {
+			logger.Error("Failed to escalate dispute", "disputeID", input.DisputeID, "error", err)
+		}
+		resolvedChan.Receive(ctx, &resolution)
+	})
+	selector.Select(ctx)
+	cancelTimer()
+
+	logger.Info("Dispute resolved", "disputeID", input.DisputeID, "status", resolution.Status, "refunded", resolution.Refunded)
+	if input.JobWorkflowID == "" {
+		return nil
+	}
+
+	// Step 4: Release the job workflow
+	if resolution.Refunded {
+		if err := workflow.RequestCancelExternalWorkflow(ctx, input.JobWorkflowID, "").Get(ctx, nil); err != nil {
+			logger.Warn("Could not cancel refunded job workflow", "jobID", input.JobID, "error", err)
+		}
+		return nil
+	}
+
+	var held bool
+	if err := workflow.ExecuteActivity(ctx, "JobHasOpenHolds", input.JobID).Get(ctx, &held); err != nil {
+		logger.Error("Failed to check job holds", "jobID", input.JobID, "error", err)
+		return err
+	}
+	if held {
+		logger.Info("Job still held by another incident, ticket or dispute", "jobID", input.JobID)
+		return nil
+	}
+	if err := workflow.SignalExternalWorkflow(ctx, input.JobWorkflowID, "", "job-resumed", nil).Get(ctx, nil); err != nil {
+		logger.Warn("Could not resume job workflow", "jobID", input.JobID, "error", err)
+	}
+	return nil
+}
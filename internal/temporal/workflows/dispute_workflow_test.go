@@ -0,0 +1,85 @@
+package workflows
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestDisputeWorkflow(t *testing.T) {
+	tests := []struct {
+		name        string
+		resolution  DisputeResolution
+		otherHolds  bool
+		resolveIn   time.Duration
+		wantResume  bool
+		wantCancel  bool
+		wantOverdue bool
+	}{
+		{name: "resolved resumes job", resolution: DisputeResolution{Status: "resolved"}, resolveIn: time.Hour, wantResume: true},
+		{name: "resolved with other holds stays paused", resolution: DisputeResolution{Status: "resolved"}, otherHolds: true, resolveIn: time.Hour},
+		{name: "refund cancels job", resolution: DisputeResolution{Status: "refunded", Refunded: true}, resolveIn: time.Hour, wantCancel: true},
+		{name: "overdue dispute escalates", resolution: DisputeResolution{Status: "resolved"}, resolveIn: DisputeReviewSLA + time.Hour, wantResume: true, wantOverdue: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var suite testsuite.WorkflowTestSuite
+			env := suite.NewTestWorkflowEnvironment()
+			input := DisputeInput{DisputeID: 7, JobID: 42, JobWorkflowID: "job-42"}
+
+			var signals []string
+			env.OnSignalExternalWorkflow(mock.Anything, "job-42", "", mock.Anything, mock.Anything).
+				Return(func(_, _, _, name string, _ interface{}) error {
+					signals = append(signals, name)
+					return nil
+				})
+			cancelled := false
+			env.OnRequestCancelExternalWorkflow(mock.Anything, "job-42", "").
+				Return(func(_, _, _ string) error {
+					cancelled = true
+					return nil
+				})
+
+			escalated := false
+			env.RegisterActivityWithOptions(func(ctx context.Context, in DisputeInput) error { return nil }, activity.RegisterOptions{Name: "AlertDisputeOpened"})
+			env.RegisterActivityWithOptions(func(ctx context.Context, in DisputeInput) error {
+				escalated = true
+				return nil
+			}, activity.RegisterOptions{Name: "EscalateDispute"})
+			env.RegisterActivityWithOptions(func(ctx context.Context, jobID int) (bool, error) {
+				return tt.otherHolds, nil
+			}, activity.RegisterOptions{Name: "JobHasOpenHolds"})
+
+			env.RegisterDelayedCallback(func() {
+				env.SignalWorkflow("dispute-resolved", tt.resolution)
+			}, tt.resolveIn)
+			env.ExecuteWorkflow(DisputeWorkflow, input)
+
+			if !env.IsWorkflowCompleted() {
+				t.Fatal("workflow did not complete")
+			}
+			if err := env.GetWorkflowError(); err != nil {
+				t.Fatalf("workflow error: %v", err)
+			}
+
+			resumed := len(signals) == 2 && signals[1] == "job-resumed"
+			if len(signals) == 0 || signals[0] != "job-paused" {
+				t.Errorf("signals = %v, want job-paused first", signals)
+			}
+			if resumed != tt.wantResume {
+				t.Errorf("resumed = %v, want %v (signals %v)", resumed, tt.wantResume, signals)
+			}
+			if cancelled != tt.wantCancel {
+				t.Errorf("cancelled = %v, want %v", cancelled, tt.wantCancel)
+			}
+			if escalated != tt.wantOverdue {
+				t.Errorf("escalated = %v, want %v", escalated, tt.wantOverdue)
+			}
+		})
+	}
+}
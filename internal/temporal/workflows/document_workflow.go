@@ -0,0 +1,40 @@
+package workflows
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// DocumentExpiryWorkflowID is the fixed ID of the scheduled document expiry check
+const DocumentExpiryWorkflowID = "document-expiry"
+
+// DocumentExpiryResult summarizes one pass over approved verification documents
+type DocumentExpiryResult struct {
+	Reminded int `json:"reminded"`
+	Expired  int `json:"expired"`
+}
+
+// DocumentExpiryWorkflow reminds workers of verification documents expiring soon and
+// marks expired ones. It is started with a cron schedule so each run is a single pass.
+func DocumentExpiryWorkflow(ctx workflow.Context) (DocumentExpiryResult, error) {
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 10 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts:    3,
+			InitialInterval:    time.Minute,
+			BackoffCoefficient: 2.0,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var result DocumentExpiryResult
+	if err := workflow.ExecuteActivity(ctx, "CheckDocumentExpiry").Get(ctx, &result); err != nil {
+		workflow.GetLogger(ctx).Error("Document expiry check failed", "error", err)
+		return result, err
+	}
+
+	workflow.GetLogger(ctx).Info("Document expiry check completed", "reminded", result.Reminded, "expired", result.Expired)
+	return result, nil
+}
@@ -0,0 +1,95 @@
+package workflows
+
+import (
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// EscrowUpdatedSignal wakes an escrow workflow to re-check its authorization, e.g. when
+// the job completes or a dispute holding it is resolved
+const EscrowUpdatedSignal = "escrow-updated"
+
+// EscrowWorkflowID is the ID of the escrow workflow managing an authorization
+func EscrowWorkflowID(transactionID int) string {
+	return fmt.Sprintf("escrow-%d", transactionID)
+}
+
+// EscrowInput contains the input for an escrow workflow
+type EscrowInput struct {
+	TransactionID int `json:"transaction_id"`
+	JobID         int `json:"job_id"`
+}
+
+// EscrowState is what the escrow workflow waits for next
+type EscrowState struct {
+	Settled   bool       `json:"settled"`              // Captured, refunded or voided; nothing left to do
+	RenewAt   time.Time  `json:"renew_at"`             // When the authorization is renewed or voided
+	CaptureAt *time.Time `json:"capture_at,omitempty"` // When a completed job's payment is captured; nil until then or while held
+}
+
+// EscrowWorkflow looks after a payment authorization until it is settled. Holds expire
+// after seven days, so shortly before expiry it re-authorizes the payment, or voids it
+// when the job was cancelled. Once the job completes, the payment is captured if the
+// consumer has not released it within the auto-capture delay. Captures, refunds and
+// voids made elsewhere are picked up the next time the workflow wakes.
+func EscrowWorkflow(ctx workflow.Context, input EscrowInput) error {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting escrow hold", "transactionID", input.TransactionID, "jobID", input.JobID)
+
+	// The hold lapses if renewal gives up, so a provider outage is retried without an
+	// attempt limit until it passes
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 5 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    30 * time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    10 * time.Minute,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	updatedChan := workflow.GetSignalChannel(ctx, EscrowUpdatedSignal)
+	for {
+		var state EscrowState
+		if err := workflow.ExecuteActivity(ctx, "CheckEscrow", input).Get(ctx, &state); err != nil {
+			logger.Error("Failed to check escrow", "transactionID", input.TransactionID, "error", err)
+			return err
+		}
+		if state.Settled {
+			logger.Info("Escrow settled", "transactionID", input.TransactionID)
+			return nil
+		}
+
+		wakeAt, step := state.RenewAt, "RenewEscrowAuthorization"
+		if state.CaptureAt != nil && !state.CaptureAt.After(wakeAt) {
+			wakeAt, step = *state.CaptureAt, "AutoCaptureEscrow"
+		}
+		wait := wakeAt.Sub(workflow.Now(ctx))
+		if wait < 0 {
+			wait = 0
+		}
+
+		due := false
+		timerCtx, cancelTimer := workflow.WithCancel(ctx)
+		selector := workflow.NewSelector(ctx)
+		selector.AddReceive(updatedChan, func(c workflow.ReceiveChannel, more bool) {
+			c.Receive(ctx, nil)
+		})
+		selector.AddFuture(workflow.NewTimer(timerCtx, wait), func(f workflow.Future) {
+			due = true
+		})
+		selector.Select(ctx)
+		cancelTimer()
+		if !due {
+			continue
+		}
+
+		if err := workflow.ExecuteActivity(ctx, step, input).Get(ctx, nil); err != nil {
+			logger.Error("Escrow step failed", "step", step, "transactionID", input.TransactionID, "error", err)
+			return err
+		}
+	}
+}
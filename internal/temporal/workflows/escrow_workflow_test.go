@@ -0,0 +1,142 @@
+package workflows
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestEscrowWorkflow(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		name      string
+		states    []func(now time.Time) EscrowState // CheckEscrow results, in order
+		signals   []time.Duration                   // When escrow-updated is sent
+		wantSteps []string
+		wantAt    []time.Duration // When each step ran, from the start, if checked
+	}{
+		{
+			name: "renews before expiry then captures after completion",
+			states: []func(time.Time) EscrowState{
+				func(now time.Time) EscrowState { return EscrowState{RenewAt: now.Add(6 * day)} },
+				func(now time.Time) EscrowState {
+					capture := now.Add(2 * day)
+					return EscrowState{RenewAt: now.Add(6 * day), CaptureAt: &capture}
+				},
+				func(now time.Time) EscrowState { return EscrowState{Settled: true} },
+			},
+			wantSteps: []string{"RenewEscrowAuthorization", "AutoCaptureEscrow"},
+		},
+		{
+			name: "overdue renewal runs at once",
+			states: []func(time.Time) EscrowState{
+				func(now time.Time) EscrowState { return EscrowState{RenewAt: now.Add(-time.Hour)} },
+				func(now time.Time) EscrowState { return EscrowState{Settled: true} },
+			},
+			wantSteps: []string{"RenewEscrowAuthorization"},
+		},
+		{
+			name: "completion signal schedules capture",
+			states: []func(time.Time) EscrowState{
+				func(now time.Time) EscrowState { return EscrowState{RenewAt: now.Add(6 * day)} },
+				func(now time.Time) EscrowState {
+					capture := now.Add(3 * day)
+					return EscrowState{RenewAt: now.Add(6 * day), CaptureAt: &capture}
+				},
+				func(now time.Time) EscrowState { return EscrowState{Settled: true} },
+			},
+			signals:   []time.Duration{time.Hour},
+			wantSteps: []string{"AutoCaptureEscrow"},
+		},
+		{
+			name: "consumer releases within the approval window",
+			states: []func(time.Time) EscrowState{
+				func(now time.Time) EscrowState {
+					capture := now.Add(3 * day)
+					return EscrowState{RenewAt: now.Add(6 * day), CaptureAt: &capture}
+				},
+				func(now time.Time) EscrowState { return EscrowState{Settled: true} },
+			},
+			signals: []time.Duration{day},
+		},
+		{
+			name: "dispute opened after completion holds the capture",
+			states: []func(time.Time) EscrowState{
+				func(now time.Time) EscrowState {
+					capture := now.Add(3 * day)
+					return EscrowState{RenewAt: now.Add(6 * day), CaptureAt: &capture}
+				},
+				// Held by the dispute
+				func(now time.Time) EscrowState { return EscrowState{RenewAt: now.Add(5 * day)} },
+				// Resolved past the original capture time
+				func(now time.Time) EscrowState {
+					return EscrowState{RenewAt: now.Add(2 * day), CaptureAt: &now}
+				},
+				func(now time.Time) EscrowState { return EscrowState{Settled: true} },
+			},
+			signals:   []time.Duration{day, 4 * day},
+			wantSteps: []string{"AutoCaptureEscrow"},
+			wantAt:    []time.Duration{4 * day},
+		},
+		{
+			name: "settled elsewhere",
+			states: []func(time.Time) EscrowState{
+				func(now time.Time) EscrowState { return EscrowState{Settled: true} },
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var suite testsuite.WorkflowTestSuite
+			env := suite.NewTestWorkflowEnvironment()
+			input := EscrowInput{TransactionID: 5, JobID: 42}
+
+			checks := 0
+			env.RegisterActivityWithOptions(func(ctx context.Context, in EscrowInput) (EscrowState, error) {
+				state := tt.states[checks](env.Now())
+				checks++
+				return state, nil
+			}, activity.RegisterOptions{Name: "CheckEscrow"})
+
+			start := env.Now()
+			var steps []string
+			var at []time.Duration
+			for _, name := range []string{"RenewEscrowAuthorization", "AutoCaptureEscrow"} {
+				name := name
+				env.RegisterActivityWithOptions(func(ctx context.Context, in EscrowInput) error {
+					steps = append(steps, name)
+					at = append(at, env.Now().Sub(start))
+					return nil
+				}, activity.RegisterOptions{Name: name})
+			}
+
+			for _, signalIn := range tt.signals {
+				env.RegisterDelayedCallback(func() {
+					env.SignalWorkflow(EscrowUpdatedSignal, nil)
+				}, signalIn)
+			}
+			env.ExecuteWorkflow(EscrowWorkflow, input)
+
+			if !env.IsWorkflowCompleted() {
+				t.Fatal("workflow did not complete")
+			}
+			if err := env.GetWorkflowError(); err != nil {
+				t.Fatalf("workflow error: %v", err)
+			}
+			if !reflect.DeepEqual(steps, tt.wantSteps) {
+				t.Errorf("steps = %v, want %v", steps, tt.wantSteps)
+			}
+			if tt.wantAt != nil && !reflect.DeepEqual(at, tt.wantAt) {
+				t.Errorf("steps ran at %v, want %v", at, tt.wantAt)
+			}
+			if checks != len(tt.states) {
+				t.Errorf("CheckEscrow ran %d times, want %d", checks, len(tt.states))
+			}
+		})
+	}
+}
@@ -13,6 +13,9 @@ type JobWorkflowInput struct {
 	ConsumerID int `json:"consumer_id"`
 }
 
+// JobStateQuery is the query that returns a running job workflow's JobWorkflowState
+const JobStateQuery = "job-state"
+
 // JobWorkflowState tracks the current state of the job
 type JobWorkflowState struct {
 	JobID            int     `json:"job_id"`
@@ -21,6 +24,8 @@ type JobWorkflowState struct {
 	AssignedWorkerID int     `json:"assigned_worker_id"`
 	PaymentID        string  `json:"payment_id"`
 	ReviewsReceived  int     `json:"reviews_received"`
+	Paused           bool    `json:"paused"`
+	PauseReason      string  `json:"pause_reason,omitempty"`
 }
 
 // PriceJobResult contains the result of pricing a job
@@ -35,6 +40,61 @@ type MatchWorkerResult struct {
 	WorkerID int `json:"worker_id"`
 }
 
+// AutoAcceptResult names the favorited worker who auto-accepted a rebooked job; WorkerID
+// is 0 when the job goes through the offer instead
+type AutoAcceptResult struct {
+	JobID    int `json:"job_id"`
+	WorkerID int `json:"worker_id"`
+}
+
+// WorkerOfferSignal is the signal a job workflow receives when a worker accepts or
+// declines its offer of the job
+const WorkerOfferSignal = "worker-offer-response"
+
+// WorkerOffer is one worker's offer of a job in an offer round. Rank is the worker's
+// place in the round's matching, 1 being the best match.
+type WorkerOffer struct {
+	JobID     int       `json:"job_id"`
+	WorkerID  int       `json:"worker_id"`
+	Round     int       `json:"round"`
+	Rank      int       `json:"rank"`
+	Amount    float64   `json:"amount"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// WorkerOfferResponse is a worker's answer to a job offer. The API assigns a worker who
+// accepts before signalling, so the signal only ends the wait. A worker who snoozed the
+// offer (SnoozedUntil set) has not answered yet and is reminded when the snooze ends.
+type WorkerOfferResponse struct {
+	WorkerID     int        `json:"worker_id"`
+	Accepted     bool       `json:"accepted"`
+	SnoozedUntil *time.Time `json:"snoozed_until,omitempty"`
+}
+
+// workerOfferWindow is how long workers have to answer an offer round
+const workerOfferWindow = 30 * time.Minute
+
+// JobHandoffSignal is the signal a job workflow receives when the consumer approves
+// the assigned worker handing the job off
+const JobHandoffSignal = "job-handoff"
+
+// HandoffRequest is an approved hand-off of a job by its assigned worker. The activities
+// read the portion the worker completed from the hand-off itself.
+type HandoffRequest struct {
+	HandoffID int `json:"handoff_id"`
+	WorkerID  int `json:"worker_id"`
+}
+
+// HandoffResult is what the outgoing worker was paid for the portion they completed
+type HandoffResult struct {
+	HandoffID  int     `json:"handoff_id"`
+	PaidAmount float64 `json:"paid_amount"`
+}
+
+// handoffOfferWindow is how long workers have to answer the offer of a handed-off job,
+// which is already under way
+const handoffOfferWindow = 10 * time.Minute
+
 // ProcessPaymentResult contains the result of payment processing
 type ProcessPaymentResult struct {
 	TransactionID string  `json:"transaction_id"`
@@ -54,10 +114,55 @@ type ReviewSubmission struct {
 	Comment    string `json:"comment"`
 }
 
+// PauseRequest asks a running job workflow to hold before its next step
+type PauseRequest struct {
+	Reason     string `json:"reason"`
+	IncidentID int    `json:"incident_id,omitempty"`
+	TicketID   int    `json:"ticket_id,omitempty"`
+	DisputeID  int    `json:"dispute_id,omitempty"`
+}
+
+// handlePauseSignals toggles the paused flag as pause/resume signals arrive
+func handlePauseSignals(ctx workflow.Context, state *JobWorkflowState) {
+	logger := workflow.GetLogger(ctx)
+	pauseChannel := workflow.GetSignalChannel(ctx, "job-paused")
+	resumeChannel := workflow.GetSignalChannel(ctx, "job-resumed")
+
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		for {
+			selector := workflow.NewSelector(ctx)
+			selector.AddReceive(pauseChannel, func(c workflow.ReceiveChannel, more bool) {
+				var req PauseRequest
+				c.Receive(ctx, &req)
+				state.Paused = true
+				state.PauseReason = req.Reason
+				logger.Info("Job workflow paused", "jobID", state.JobID, "reason", req.Reason)
+			})
+			selector.AddReceive(resumeChannel, func(c workflow.ReceiveChannel, more bool) {
+				c.Receive(ctx, nil)
+				state.Paused = false
+				state.PauseReason = ""
+				logger.Info("Job workflow resumed", "jobID", state.JobID)
+			})
+			selector.Select(ctx)
+		}
+	})
+}
+
+// awaitResume blocks until the workflow is not paused
+func awaitResume(ctx workflow.Context, state *JobWorkflowState) error {
+	return workflow.Await(ctx, func() bool { return !state.Paused })
+}
+
 // JobLifecycleWorkflow orchestrates the entire job lifecycle
-func JobLifecycleWorkflow(ctx workflow.Context, input JobWorkflowInput) error {
+func JobLifecycleWorkflow(ctx workflow.Context, input JobWorkflowInput) (err error) {
 	logger := workflow.GetLogger(ctx)
 	logger.Info("Starting job workflow", "jobID", input.JobID)
+	defer func() {
+		if err != nil && !temporal.IsCanceledError(err) {
+			reportDeadLetter(ctx, input.JobID, err)
+		}
+	}()
 
 	// Set workflow options
 	ao := workflow.ActivityOptions{
@@ -72,10 +177,18 @@ func JobLifecycleWorkflow(ctx workflow.Context, input JobWorkflowInput) error {
 		JobID:        input.JobID,
 		CurrentState: "draft",
 	}
+	err = workflow.SetQueryHandler(ctx, JobStateQuery, func() (JobWorkflowState, error) {
+		return *state, nil
+	})
+	if err != nil {
+		logger.Error("Failed to register state query", "error", err)
+		return err
+	}
+	handlePauseSignals(ctx, state)
 
 	// Step 1: Price the job
 	var priceResult PriceJobResult
-	err := workflow.ExecuteActivity(ctx, "PriceJob", input.JobID).Get(ctx, &priceResult)
+	err = workflow.ExecuteActivity(ctx, "PriceJob", input.JobID).Get(ctx, &priceResult)
 	if err != nil {
 		logger.Error("Failed to price job", "error", err)
 		return err
@@ -84,71 +197,29 @@ func JobLifecycleWorkflow(ctx workflow.Context, input JobWorkflowInput) error {
 	state.CurrentState = "priced"
 	logger.Info("Job priced", "jobID", input.JobID, "amount", priceResult.Amount)
 
-	// Step 2: Send offer to customer and wait for response
-	err = workflow.ExecuteActivity(ctx, "SendJobOffer", input.JobID, priceResult.Amount).Get(ctx, nil)
-	if err != nil {
-		logger.Error("Failed to send job offer", "error", err)
-		return err
-	}
-
-	// Wait for customer decision (with timeout)
-	selector := workflow.NewSelector(ctx)
-	var offerAccepted bool
-
-	offerChannel := workflow.GetSignalChannel(ctx, "offer-response")
-	selector.AddReceive(offerChannel, func(c workflow.ReceiveChannel, more bool) {
-		var response OfferResponse
-		c.Receive(ctx, &response)
-		offerAccepted = response.Accepted
-	})
-
-	// Add timeout for offer response (24 hours)
-	timerFuture := workflow.NewTimer(ctx, 24*time.Hour)
-	selector.AddFuture(timerFuture, func(f workflow.Future) {
-		offerAccepted = false
-		logger.Info("Offer timeout reached", "jobID", input.JobID)
-	})
-
-	selector.Select(ctx)
-
-	if !offerAccepted {
-		state.CurrentState = "rejected"
-		logger.Info("Job offer rejected or timed out", "jobID", input.JobID)
-		return workflow.ExecuteActivity(ctx, "HandleJobRejection", input.JobID).Get(ctx, nil)
-	}
-
-	state.CurrentState = "accepted"
-	logger.Info("Job offer accepted", "jobID", input.JobID)
-
-	// Step 3: Find and assign worker
-	retryCount := 0
-	maxRetries := 5
-
-	for retryCount < maxRetries {
-		var matchResult MatchWorkerResult
-		err = workflow.ExecuteActivity(ctx, "FindMatchingWorker", input.JobID).Get(ctx, &matchResult)
-
-		if err == nil && matchResult.WorkerID > 0 {
-			state.AssignedWorkerID = matchResult.WorkerID
-			state.CurrentState = "worker_assigned"
-			logger.Info("Worker assigned", "jobID", input.JobID, "workerID", matchResult.WorkerID)
-			break
+	// Step 2: A rebooked job goes straight to the favorited worker when they auto-accept
+	// it; otherwise the customer gets the offer and a worker is matched
+	var autoAccept AutoAcceptResult
+	// Workflows started before auto-accept replay without the activity
+	if workflow.GetVersion(ctx, "auto-accept", workflow.DefaultVersion, 1) == 1 {
+		err = workflow.ExecuteActivity(ctx, "AutoAcceptJob", input.JobID, priceResult.Amount).Get(ctx, &autoAccept)
+		if err != nil {
+			// The offer still works, so the job is not failed over it
+			logger.Warn("Failed to check auto-accept", "jobID", input.JobID, "error", err)
 		}
-
-		// Wait before retry with exponential backoff
-		retryDelay := time.Duration(retryCount+1) * 5 * time.Minute
-		workflow.Sleep(ctx, retryDelay)
-		retryCount++
-		logger.Info("Retrying worker assignment", "jobID", input.JobID, "attempt", retryCount)
 	}
-
-	if state.AssignedWorkerID == 0 {
-		logger.Error("No worker found after retries", "jobID", input.JobID)
-		state.CurrentState = "no_worker_available"
-		return workflow.ExecuteActivity(ctx, "HandleNoWorkerAvailable", input.JobID).Get(ctx, nil)
+	if autoAccept.WorkerID > 0 {
+		state.AssignedWorkerID = autoAccept.WorkerID
+		state.CurrentState = "worker_assigned"
+		logger.Info("Job auto-accepted", "jobID", input.JobID, "workerID", autoAccept.WorkerID)
+	} else if assigned, err := offerAndAssign(ctx, input, state, priceResult.Amount); !assigned {
+		return err
 	}
 
 	// Step 4: Schedule the job
+	if err := awaitResume(ctx, state); err != nil {
+		return err
+	}
 	err = workflow.ExecuteActivity(ctx, "ScheduleJob", input.JobID, state.AssignedWorkerID).Get(ctx, nil)
 	if err != nil {
 		logger.Error("Failed to schedule job", "error", err)
@@ -157,19 +228,28 @@ func JobLifecycleWorkflow(ctx workflow.Context, input JobWorkflowInput) error {
 	state.CurrentState = "scheduled"
 	logger.Info("Job scheduled", "jobID", input.JobID)
 
-	// Step 5: Wait for job to start
-	startSignal := workflow.GetSignalChannel(ctx, "job-started")
-	startSignal.Receive(ctx, nil)
-	state.CurrentState = "in_progress"
-	logger.Info("Job started", "jobID", input.JobID)
-
-	// Step 6: Wait for job completion
-	completionSignal := workflow.GetSignalChannel(ctx, "job-completed")
-	completionSignal.Receive(ctx, nil)
-	state.CurrentState = "completed"
-	logger.Info("Job completed", "jobID", input.JobID)
+	// Steps 5 and 6: Wait for the job to start and complete. Workflows started before
+	// hand-offs replay without listening for them.
+	if workflow.GetVersion(ctx, "job-handoff", workflow.DefaultVersion, 1) == 1 {
+		if assigned, err := awaitCompletion(ctx, input, state); !assigned {
+			return err
+		}
+	} else {
+		startSignal := workflow.GetSignalChannel(ctx, "job-started")
+		startSignal.Receive(ctx, nil)
+		state.CurrentState = "in_progress"
+		logger.Info("Job started", "jobID", input.JobID)
+
+		completionSignal := workflow.GetSignalChannel(ctx, "job-completed")
+		completionSignal.Receive(ctx, nil)
+		state.CurrentState = "completed"
+		logger.Info("Job completed", "jobID", input.JobID)
+	}
 
-	// Step 7: Process payment
+	// Step 7: Process payment (held while an incident or dispute is open)
+	if err := awaitResume(ctx, state); err != nil {
+		return err
+	}
 	var paymentResult ProcessPaymentResult
 	err = workflow.ExecuteActivity(ctx, "ProcessJobPayment", input.JobID).Get(ctx, &paymentResult)
 	if err != nil {
@@ -217,6 +297,9 @@ func JobLifecycleWorkflow(ctx workflow.Context, input JobWorkflowInput) error {
 	}
 
 	// Step 10: Close the job
+	if err := awaitResume(ctx, state); err != nil {
+		return err
+	}
 	err = workflow.ExecuteActivity(ctx, "CloseJob", input.JobID).Get(ctx, nil)
 	if err != nil {
 		logger.Error("Failed to close job", "error", err)
@@ -228,10 +311,297 @@ func JobLifecycleWorkflow(ctx workflow.Context, input JobWorkflowInput) error {
 	return nil
 }
 
+// offerAndAssign sends the customer the priced offer, waits for their answer and then
+// matches a worker. It reports false when the job ended instead, with the error the
+// workflow ends with.
+func offerAndAssign(ctx workflow.Context, input JobWorkflowInput, state *JobWorkflowState, amount float64) (bool, error) {
+	logger := workflow.GetLogger(ctx)
+
+	// Send offer to customer and wait for response
+	err := workflow.ExecuteActivity(ctx, "SendJobOffer", input.JobID, amount).Get(ctx, nil)
+	if err != nil {
+		logger.Error("Failed to send job offer", "error", err)
+		return false, err
+	}
+
+	// Wait for customer decision (with timeout)
+	selector := workflow.NewSelector(ctx)
+	var offerAccepted bool
+
+	offerChannel := workflow.GetSignalChannel(ctx, "offer-response")
+	selector.AddReceive(offerChannel, func(c workflow.ReceiveChannel, more bool) {
+		var response OfferResponse
+		c.Receive(ctx, &response)
+		offerAccepted = response.Accepted
+	})
+
+	// Add timeout for offer response (24 hours)
+	timerFuture := workflow.NewTimer(ctx, 24*time.Hour)
+	selector.AddFuture(timerFuture, func(f workflow.Future) {
+		offerAccepted = false
+		logger.Info("Offer timeout reached", "jobID", input.JobID)
+	})
+
+	selector.Select(ctx)
+
+	if !offerAccepted {
+		state.CurrentState = "rejected"
+		logger.Info("Job offer rejected or timed out", "jobID", input.JobID)
+		return false, workflow.ExecuteActivity(ctx, "HandleJobRejection", input.JobID).Get(ctx, nil)
+	}
+
+	state.CurrentState = "accepted"
+	logger.Info("Job offer accepted", "jobID", input.JobID)
+
+	// Find and assign worker. Workflows started before offer fan-out replay the
+	// one-at-a-time matching.
+	if workflow.GetVersion(ctx, "worker-offers", workflow.DefaultVersion, 1) == 1 {
+		workerID, err := offerToWorkers(ctx, input.JobID, amount, workerOfferWindow)
+		if err != nil {
+			logger.Error("Failed to offer job to workers", "error", err)
+			return false, err
+		}
+		if workerID > 0 {
+			state.AssignedWorkerID = workerID
+			state.CurrentState = "worker_assigned"
+			logger.Info("Worker accepted job offer", "jobID", input.JobID, "workerID", workerID)
+		}
+	} else {
+		retryCount := 0
+		maxRetries := 5
+
+		for retryCount < maxRetries {
+			var matchResult MatchWorkerResult
+			err := workflow.ExecuteActivity(ctx, "FindMatchingWorker", input.JobID).Get(ctx, &matchResult)
+
+			if err == nil && matchResult.WorkerID > 0 {
+				state.AssignedWorkerID = matchResult.WorkerID
+				state.CurrentState = "worker_assigned"
+				logger.Info("Worker assigned", "jobID", input.JobID, "workerID", matchResult.WorkerID)
+				break
+			}
+
+			// Wait before retry with exponential backoff
+			retryDelay := time.Duration(retryCount+1) * 5 * time.Minute
+			workflow.Sleep(ctx, retryDelay)
+			retryCount++
+			logger.Info("Retrying worker assignment", "jobID", input.JobID, "attempt", retryCount)
+		}
+	}
+
+	if state.AssignedWorkerID == 0 {
+		logger.Error("No worker found after retries", "jobID", input.JobID)
+		state.CurrentState = "no_worker_available"
+		return false, workflow.ExecuteActivity(ctx, "HandleNoWorkerAvailable", input.JobID).Get(ctx, nil)
+	}
+	return true, nil
+}
+
+// awaitCompletion waits for the job to start and then complete, handing it to another
+// worker whenever the consumer approves a hand-off. It reports false when the job ended
+// without being completed, with the error the workflow ends with.
+func awaitCompletion(ctx workflow.Context, input JobWorkflowInput, state *JobWorkflowState) (bool, error) {
+	logger := workflow.GetLogger(ctx)
+	startSignal := workflow.GetSignalChannel(ctx, "job-started")
+	completionSignal := workflow.GetSignalChannel(ctx, "job-completed")
+	handoffSignal := workflow.GetSignalChannel(ctx, JobHandoffSignal)
+
+	started := false
+	for state.CurrentState != "completed" {
+		var handoff *HandoffRequest
+		selector := workflow.NewSelector(ctx)
+		if !started {
+			selector.AddReceive(startSignal, func(c workflow.ReceiveChannel, more bool) {
+				c.Receive(ctx, nil)
+				started = true
+				state.CurrentState = "in_progress"
+				logger.Info("Job started", "jobID", input.JobID)
+			})
+		} else {
+			selector.AddReceive(completionSignal, func(c workflow.ReceiveChannel, more bool) {
+				c.Receive(ctx, nil)
+				state.CurrentState = "completed"
+				logger.Info("Job completed", "jobID", input.JobID)
+			})
+		}
+		selector.AddReceive(handoffSignal, func(c workflow.ReceiveChannel, more bool) {
+			var req HandoffRequest
+			c.Receive(ctx, &req)
+			handoff = &req
+		})
+		selector.Select(ctx)
+
+		if handoff == nil {
+			continue
+		}
+		if handoff.WorkerID != state.AssignedWorkerID {
+			logger.Warn("Ignoring hand-off by a worker no longer on the job", "jobID", input.JobID, "workerID", handoff.WorkerID)
+			continue
+		}
+		replaced, assigned, err := handOff(ctx, input, state, *handoff)
+		if !assigned {
+			return false, err
+		}
+		if replaced {
+			// The replacement starts the job again
+			started = false
+		}
+	}
+	return true, nil
+}
+
+// handOff pays the outgoing worker for their portion of the job, releases them and
+// offers the rest of the job to other workers. When the worker can't be released the
+// payment is reversed and they stay on the job (replaced is false). assigned is false
+// when nobody took the job over, with the error the workflow ends with.
+func handOff(ctx workflow.Context, input JobWorkflowInput, state *JobWorkflowState, req HandoffRequest) (replaced, assigned bool, err error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Handing off job", "jobID", input.JobID, "handoffID", req.HandoffID, "workerID", req.WorkerID)
+	if err := awaitResume(ctx, state); err != nil {
+		return false, false, err
+	}
+
+	var paid HandoffResult
+	err = workflow.ExecuteActivity(ctx, "PayHandoffPortion", req.HandoffID).Get(ctx, &paid)
+	if err == nil {
+		err = workflow.ExecuteActivity(ctx, "ReleaseHandoffWorker", req.HandoffID).Get(ctx, nil)
+	}
+	if err != nil {
+		logger.Error("Failed to hand off job, keeping the worker on it", "jobID", input.JobID, "handoffID", req.HandoffID, "error", err)
+		if cerr := workflow.ExecuteActivity(ctx, "ReverseHandoff", req.HandoffID).Get(ctx, nil); cerr != nil {
+			logger.Error("Failed to reverse hand-off", "jobID", input.JobID, "handoffID", req.HandoffID, "error", cerr)
+		}
+		return false, true, nil
+	}
+	previousState := state.CurrentState
+	state.AssignedWorkerID = 0
+	state.CurrentState = "accepted"
+
+	// The replacement is paid for the rest of the job, and the offer goes out with a
+	// shorter window since the job is already under way
+	workerID, err := offerToWorkers(ctx, input.JobID, state.PricedAmount-paid.PaidAmount, handoffOfferWindow)
+	if err != nil {
+		logger.Error("Failed to offer handed-off job to workers", "error", err)
+		return true, false, err
+	}
+	if err := workflow.ExecuteActivity(ctx, "FinishHandoff", req.HandoffID, workerID).Get(ctx, nil); err != nil {
+		logger.Warn("Failed to finish hand-off", "jobID", input.JobID, "handoffID", req.HandoffID, "error", err)
+	}
+	if workerID == 0 {
+		logger.Error("No worker took over handed-off job", "jobID", input.JobID, "previousState", previousState)
+		state.CurrentState = "no_worker_available"
+		return true, false, workflow.ExecuteActivity(ctx, "HandleNoWorkerAvailable", input.JobID).Get(ctx, nil)
+	}
+	state.AssignedWorkerID = workerID
+	state.CurrentState = "worker_assigned"
+	logger.Info("Worker took over handed-off job", "jobID", input.JobID, "workerID", workerID)
+
+	if err := awaitResume(ctx, state); err != nil {
+		return true, false, err
+	}
+	err = workflow.ExecuteActivity(ctx, "ScheduleJob", input.JobID, workerID).Get(ctx, nil)
+	if err != nil {
+		logger.Error("Failed to schedule job", "error", err)
+		return true, false, err
+	}
+	state.CurrentState = "scheduled"
+	return true, true, nil
+}
+
+// offerToWorkers offers the job to its top matched workers a round at a time and
+// returns the worker who accepted, or 0 when nobody did within maxRetries rounds.
+// Workers have window to answer each round. The API assigns the first worker to accept; CloseWorkerOffers reports who that was
+// and expires the offers nobody answered, snoozed ones included.
+func offerToWorkers(ctx workflow.Context, jobID int, amount float64, window time.Duration) (int, error) {
+	logger := workflow.GetLogger(ctx)
+	responses := workflow.GetSignalChannel(ctx, WorkerOfferSignal)
+	maxRetries := 5
+
+	for round := 1; round <= maxRetries; round++ {
+		var workerIDs []int
+		err := workflow.ExecuteActivity(ctx, "RankWorkersForOffer", jobID).Get(ctx, &workerIDs)
+		if err != nil {
+			logger.Warn("Failed to rank workers for offer", "jobID", jobID, "error", err)
+		}
+		if len(workerIDs) == 0 {
+			// Wait for workers to free up, backing off between rounds
+			workflow.Sleep(ctx, time.Duration(round)*5*time.Minute)
+			continue
+		}
+
+		// Every offer in the round goes out at once, so no worker gets a head start
+		expiresAt := workflow.Now(ctx).Add(window)
+		sends := make([]workflow.Future, len(workerIDs))
+		for i, workerID := range workerIDs {
+			offer := WorkerOffer{JobID: jobID, WorkerID: workerID, Round: round, Rank: i + 1, Amount: amount, ExpiresAt: expiresAt}
+			sends[i] = workflow.ExecuteActivity(ctx, "SendWorkerOffer", offer)
+		}
+		pending := make(map[int]bool, len(workerIDs))
+		for i, send := range sends {
+			if err := send.Get(ctx, nil); err != nil {
+				logger.Warn("Failed to send worker offer", "jobID", jobID, "workerID", workerIDs[i], "error", err)
+				continue
+			}
+			pending[workerIDs[i]] = true
+		}
+		logger.Info("Job offered to workers", "jobID", jobID, "round", round, "workers", len(pending))
+
+		// Wait for an acceptance, every worker declining or the offers expiring
+		timerCtx, cancelTimer := workflow.WithCancel(ctx)
+		timer := workflow.NewTimer(timerCtx, expiresAt.Sub(workflow.Now(ctx)))
+		accepted, expired := false, false
+		for !accepted && !expired && len(pending) > 0 {
+			selector := workflow.NewSelector(ctx)
+			selector.AddReceive(responses, func(c workflow.ReceiveChannel, more bool) {
+				var response WorkerOfferResponse
+				c.Receive(ctx, &response)
+				if !pending[response.WorkerID] {
+					return // An answer to an earlier round
+				}
+				if response.SnoozedUntil != nil {
+					// Still open; the reminder is dropped if the round closes first
+					offer := WorkerOffer{JobID: jobID, WorkerID: response.WorkerID, Round: round, ExpiresAt: expiresAt}
+					delay := response.SnoozedUntil.Sub(workflow.Now(ctx))
+					workflow.Go(timerCtx, func(gctx workflow.Context) {
+						if workflow.Sleep(gctx, delay) == nil {
+							_ = workflow.ExecuteActivity(gctx, "RemindSnoozedOffer", offer).Get(gctx, nil)
+						}
+					})
+					return
+				}
+				if response.Accepted {
+					accepted = true
+				}
+				delete(pending, response.WorkerID)
+			})
+			selector.AddFuture(timer, func(f workflow.Future) {
+				expired = true
+			})
+			selector.Select(ctx)
+		}
+		cancelTimer()
+
+		var result MatchWorkerResult
+		if err := workflow.ExecuteActivity(ctx, "CloseWorkerOffers", jobID).Get(ctx, &result); err != nil {
+			return 0, err
+		}
+		if result.WorkerID > 0 {
+			return result.WorkerID, nil
+		}
+		logger.Info("No worker accepted job offers", "jobID", jobID, "round", round)
+	}
+	return 0, nil
+}
+
 // PaymentRetryWorkflow handles payment retry logic
-func PaymentRetryWorkflow(ctx workflow.Context, input JobWorkflowInput) error {
+func PaymentRetryWorkflow(ctx workflow.Context, input JobWorkflowInput) (err error) {
 	logger := workflow.GetLogger(ctx)
 	logger.Info("Starting payment retry workflow", "jobID", input.JobID)
+	defer func() {
+		if err != nil && !temporal.IsCanceledError(err) {
+			reportDeadLetter(ctx, input.JobID, err)
+		}
+	}()
 
 	ao := workflow.ActivityOptions{
 		StartToCloseTimeout: 10 * time.Minute,
@@ -244,9 +614,11 @@ func PaymentRetryWorkflow(ctx workflow.Context, input JobWorkflowInput) error {
 	ctx = workflow.WithActivityOptions(ctx, ao)
 
 	var paymentResult ProcessPaymentResult
-	err := workflow.ExecuteActivity(ctx, "ProcessJobPayment", input.JobID).Get(ctx, &paymentResult)
+	err = workflow.ExecuteActivity(ctx, "ProcessJobPayment", input.JobID).Get(ctx, &paymentResult)
 	if err != nil {
 		logger.Error("Payment retry failed", "jobID", input.JobID, "error", err)
+		// Retries are exhausted; ops should know even though the job is marked payment_failed
+		reportDeadLetter(ctx, input.JobID, err)
 		return workflow.ExecuteActivity(ctx, "HandlePaymentFailure", input.JobID).Get(ctx, nil)
 	}
 
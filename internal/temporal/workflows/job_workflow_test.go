@@ -0,0 +1,218 @@
+package workflows
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestJobWorkflowStateQuery(t *testing.T) {
+	tests := []struct {
+		name         string
+		autoAcceptBy int  // Worker AutoAcceptJob returns
+		acceptedBy   int  // Worker who accepts the offer sent to workers 3, 5 and 8
+		snoozeFirst  bool // acceptedBy snoozes the offer for 10 minutes before accepting
+		queryAt      time.Duration
+		wantState    string
+		wantWorker   int
+	}{
+		{name: "waiting on offer", queryAt: time.Hour, wantState: "priced"},
+		{name: "offer timed out", wantState: "rejected"}, // Queried after the workflow closes
+		{name: "auto-accepted rebooking", autoAcceptBy: 9, queryAt: time.Hour, wantState: "scheduled", wantWorker: 9},
+		{name: "first worker to accept offer", acceptedBy: 5, queryAt: 10 * time.Minute, wantState: "scheduled", wantWorker: 5},
+		{name: "worker accepts after snoozing", acceptedBy: 5, snoozeFirst: true, queryAt: 20 * time.Minute, wantState: "scheduled", wantWorker: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var suite testsuite.WorkflowTestSuite
+			env := suite.NewTestWorkflowEnvironment()
+			env.RegisterActivityWithOptions(func(ctx context.Context, jobID int) (PriceJobResult, error) {
+				return PriceJobResult{JobID: jobID, Amount: 120}, nil
+			}, activity.RegisterOptions{Name: "PriceJob"})
+			env.RegisterActivityWithOptions(func(ctx context.Context, jobID int, amount float64) (AutoAcceptResult, error) {
+				return AutoAcceptResult{WorkerID: tt.autoAcceptBy}, nil
+			}, activity.RegisterOptions{Name: "AutoAcceptJob"})
+			env.RegisterActivityWithOptions(func(ctx context.Context, jobID int, amount float64) error { return nil }, activity.RegisterOptions{Name: "SendJobOffer"})
+			env.RegisterActivityWithOptions(func(ctx context.Context, jobID int) error { return nil }, activity.RegisterOptions{Name: "HandleJobRejection"})
+			env.RegisterActivityWithOptions(func(ctx context.Context, jobID int) ([]int, error) {
+				return []int{3, 5, 8}, nil
+			}, activity.RegisterOptions{Name: "RankWorkersForOffer"})
+			env.RegisterActivityWithOptions(func(ctx context.Context, offer WorkerOffer) error { return nil }, activity.RegisterOptions{Name: "SendWorkerOffer"})
+			env.RegisterActivityWithOptions(func(ctx context.Context, jobID int) (MatchWorkerResult, error) {
+				return MatchWorkerResult{JobID: jobID, WorkerID: tt.acceptedBy}, nil
+			}, activity.RegisterOptions{Name: "CloseWorkerOffers"})
+			env.RegisterActivityWithOptions(func(ctx context.Context, jobID, workerID int) error { return nil }, activity.RegisterOptions{Name: "ScheduleJob"})
+			reminded := 0
+			env.RegisterActivityWithOptions(func(ctx context.Context, offer WorkerOffer) error {
+				reminded++
+				return nil
+			}, activity.RegisterOptions{Name: "RemindSnoozedOffer"})
+
+			var got JobWorkflowState
+			query := func() {
+				value, err := env.QueryWorkflow(JobStateQuery)
+				if err != nil {
+					t.Errorf("QueryWorkflow() error = %v", err)
+					return
+				}
+				if err := value.Get(&got); err != nil {
+					t.Errorf("Get() error = %v", err)
+				}
+			}
+			acceptAt := 2 * time.Minute
+			if tt.acceptedBy > 0 {
+				env.RegisterDelayedCallback(func() {
+					env.SignalWorkflow("offer-response", OfferResponse{Accepted: true})
+				}, time.Minute)
+				if tt.snoozeFirst {
+					env.RegisterDelayedCallback(func() {
+						until := env.Now().Add(10 * time.Minute)
+						env.SignalWorkflow(WorkerOfferSignal, WorkerOfferResponse{WorkerID: tt.acceptedBy, SnoozedUntil: &until})
+					}, acceptAt)
+					acceptAt = 15 * time.Minute
+				}
+				env.RegisterDelayedCallback(func() {
+					env.SignalWorkflow(WorkerOfferSignal, WorkerOfferResponse{WorkerID: tt.acceptedBy, Accepted: true})
+				}, acceptAt)
+			}
+			if tt.queryAt > 0 {
+				env.RegisterDelayedCallback(query, tt.queryAt)
+			}
+			env.ExecuteWorkflow(JobLifecycleWorkflow, JobWorkflowInput{JobID: 42, ConsumerID: 7})
+
+			// A scheduled job waits for the worker to start it until the workflow times out
+			if err := env.GetWorkflowError(); err != nil && !(tt.wantWorker > 0 && temporal.IsTimeoutError(err)) {
+				t.Fatalf("workflow error: %v", err)
+			}
+			if tt.queryAt == 0 {
+				query()
+			}
+			if got.JobID != 42 || got.CurrentState != tt.wantState || got.PricedAmount != 120 || got.AssignedWorkerID != tt.wantWorker {
+				t.Errorf("state = %+v, want job 42 %s priced at 120 with worker %d", got, tt.wantState, tt.wantWorker)
+			}
+			wantReminded := 0
+			if tt.snoozeFirst {
+				wantReminded = 1
+			}
+			if reminded != wantReminded {
+				t.Errorf("snoozed offer reminders = %d, want %d", reminded, wantReminded)
+			}
+		})
+	}
+}
+
+func TestJobWorkflowHandoff(t *testing.T) {
+	tests := []struct {
+		name         string
+		releaseFails bool
+		takenOverBy  int // Worker who accepts the handed-off job; 0 for nobody
+		wantState    string
+		wantWorker   int
+		wantReversed bool
+		wantFinished int // Worker FinishHandoff records, -1 when it is not called
+	}{
+		{name: "replacement takes over", takenOverBy: 5, wantState: "scheduled", wantWorker: 5, wantFinished: 5},
+		{name: "worker cannot be released", releaseFails: true, wantState: "in_progress", wantWorker: 9, wantReversed: true, wantFinished: -1},
+		{name: "nobody takes over", wantState: "no_worker_available", wantFinished: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var suite testsuite.WorkflowTestSuite
+			env := suite.NewTestWorkflowEnvironment()
+			env.RegisterActivityWithOptions(func(ctx context.Context, jobID int) (PriceJobResult, error) {
+				return PriceJobResult{JobID: jobID, Amount: 120}, nil
+			}, activity.RegisterOptions{Name: "PriceJob"})
+			env.RegisterActivityWithOptions(func(ctx context.Context, jobID int, amount float64) (AutoAcceptResult, error) {
+				return AutoAcceptResult{WorkerID: 9}, nil
+			}, activity.RegisterOptions{Name: "AutoAcceptJob"})
+			env.RegisterActivityWithOptions(func(ctx context.Context, jobID, workerID int) error { return nil }, activity.RegisterOptions{Name: "ScheduleJob"})
+			env.RegisterActivityWithOptions(func(ctx context.Context, handoffID int) (HandoffResult, error) {
+				return HandoffResult{HandoffID: handoffID, PaidAmount: 48}, nil
+			}, activity.RegisterOptions{Name: "PayHandoffPortion"})
+			env.RegisterActivityWithOptions(func(ctx context.Context, handoffID int) error {
+				if tt.releaseFails {
+					return temporal.NewNonRetryableApplicationError("worker left the job", "test", nil)
+				}
+				return nil
+			}, activity.RegisterOptions{Name: "ReleaseHandoffWorker"})
+			reversed := false
+			env.RegisterActivityWithOptions(func(ctx context.Context, handoffID int) error {
+				reversed = true
+				return nil
+			}, activity.RegisterOptions{Name: "ReverseHandoff"})
+			finished := -1
+			env.RegisterActivityWithOptions(func(ctx context.Context, handoffID, workerID int) error {
+				finished = workerID
+				return nil
+			}, activity.RegisterOptions{Name: "FinishHandoff"})
+			env.RegisterActivityWithOptions(func(ctx context.Context, jobID int) ([]int, error) {
+				return []int{3, 5}, nil
+			}, activity.RegisterOptions{Name: "RankWorkersForOffer"})
+			var offered []float64
+			env.RegisterActivityWithOptions(func(ctx context.Context, offer WorkerOffer) error {
+				offered = append(offered, offer.Amount)
+				return nil
+			}, activity.RegisterOptions{Name: "SendWorkerOffer"})
+			env.RegisterActivityWithOptions(func(ctx context.Context, jobID int) (MatchWorkerResult, error) {
+				return MatchWorkerResult{JobID: jobID, WorkerID: tt.takenOverBy}, nil
+			}, activity.RegisterOptions{Name: "CloseWorkerOffers"})
+			env.RegisterActivityWithOptions(func(ctx context.Context, jobID int) error { return nil }, activity.RegisterOptions{Name: "HandleNoWorkerAvailable"})
+
+			env.RegisterDelayedCallback(func() { env.SignalWorkflow("job-started", nil) }, time.Hour)
+			env.RegisterDelayedCallback(func() {
+				env.SignalWorkflow(JobHandoffSignal, HandoffRequest{HandoffID: 11, WorkerID: 9})
+			}, 2*time.Hour)
+			if tt.takenOverBy > 0 {
+				env.RegisterDelayedCallback(func() {
+					env.SignalWorkflow(WorkerOfferSignal, WorkerOfferResponse{WorkerID: tt.takenOverBy, Accepted: true})
+				}, 2*time.Hour+time.Minute)
+			}
+			var got JobWorkflowState
+			query := func() {
+				value, err := env.QueryWorkflow(JobStateQuery)
+				if err != nil {
+					t.Errorf("QueryWorkflow() error = %v", err)
+					return
+				}
+				if err := value.Get(&got); err != nil {
+					t.Errorf("Get() error = %v", err)
+				}
+			}
+			if tt.wantWorker > 0 {
+				env.RegisterDelayedCallback(query, 3*time.Hour)
+			}
+			env.ExecuteWorkflow(JobLifecycleWorkflow, JobWorkflowInput{JobID: 42, ConsumerID: 7})
+
+			// A job with a worker waits for them until the workflow times out
+			if err := env.GetWorkflowError(); err != nil && !(tt.wantWorker > 0 && temporal.IsTimeoutError(err)) {
+				t.Fatalf("workflow error: %v", err)
+			}
+			if tt.wantWorker == 0 {
+				query()
+			}
+			if got.CurrentState != tt.wantState || got.AssignedWorkerID != tt.wantWorker {
+				t.Errorf("state = %+v, want %s with worker %d", got, tt.wantState, tt.wantWorker)
+			}
+			if reversed != tt.wantReversed {
+				t.Errorf("hand-off reversed = %v, want %v", reversed, tt.wantReversed)
+			}
+			if finished != tt.wantFinished {
+				t.Errorf("FinishHandoff worker = %d, want %d", finished, tt.wantFinished)
+			}
+			if len(offered) == 0 && !tt.releaseFails {
+				t.Error("handed-off job was not offered to other workers")
+			}
+			for _, amount := range offered {
+				if amount != 72 {
+					t.Errorf("replacement offered %v, want the 72 left after the outgoing worker's 48", amount)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package workflows
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// SigningKeyRotationWorkflowID is the fixed ID of the scheduled JWT signing key rotation
+const SigningKeyRotationWorkflowID = "signing-key-rotation"
+
+// SigningKeyRotationWorkflow adds a new JWT signing key. It is started with a cron
+// schedule; replaced keys keep validating for the overlap window, so sessions survive.
+func SigningKeyRotationWorkflow(ctx workflow.Context) (string, error) {
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts:    3,
+			InitialInterval:    time.Minute,
+			BackoffCoefficient: 2.0,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var kid string
+	if err := workflow.ExecuteActivity(ctx, "RotateSigningKey").Get(ctx, &kid); err != nil {
+		workflow.GetLogger(ctx).Error("Failed to rotate signing key", "error", err)
+		return "", err
+	}
+
+	workflow.GetLogger(ctx).Info("Signing key rotated", "kid", kid)
+	return kid, nil
+}
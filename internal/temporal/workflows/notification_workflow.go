@@ -0,0 +1,42 @@
+package workflows
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// NotificationRetryWorkflowID is the fixed ID of the scheduled notification retry workflow
+const NotificationRetryWorkflowID = "notification-retry"
+
+// NotificationRetryResult summarizes one pass over deliveries due for a retry
+type NotificationRetryResult struct {
+	Retried int `json:"retried"`
+	Sent    int `json:"sent"`
+}
+
+// NotificationRetryWorkflow resends emails and push notifications whose last attempt
+// failed transiently. It is started with a cron schedule so each run is a single pass.
+func NotificationRetryWorkflow(ctx workflow.Context) (NotificationRetryResult, error) {
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 5 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts:    3,
+			InitialInterval:    30 * time.Second,
+			BackoffCoefficient: 2.0,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var result NotificationRetryResult
+	if err := workflow.ExecuteActivity(ctx, "RetryNotificationDeliveries").Get(ctx, &result); err != nil {
+		workflow.GetLogger(ctx).Error("Notification retry pass failed", "error", err)
+		return result, err
+	}
+
+	if result.Retried > 0 {
+		workflow.GetLogger(ctx).Info("Retried notification deliveries", "retried", result.Retried, "sent", result.Sent)
+	}
+	return result, nil
+}
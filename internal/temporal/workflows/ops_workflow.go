@@ -0,0 +1,131 @@
+package workflows
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// OpsMonitorWorkflowID is the fixed ID of the scheduled ops monitoring workflow
+const OpsMonitorWorkflowID = "ops-monitor"
+
+// ReconciliationResult summarizes one payment reconciliation pass
+type ReconciliationResult struct {
+	TransactionsChecked int `json:"transactions_checked"`
+	Reconciled          int `json:"reconciled"`
+	Exceptions          int `json:"exceptions"`
+	Alerted             int `json:"alerted"`
+}
+
+// FillRateResult summarizes one market fill-rate check
+type FillRateResult struct {
+	MarketsChecked int `json:"markets_checked"`
+	Drops          int `json:"drops"`
+	Alerted        int `json:"alerted"`
+}
+
+// KPIAnomalyResult summarizes one KPI anomaly check
+type KPIAnomalyResult struct {
+	MetricsChecked int `json:"metrics_checked"`
+	Anomalies      int `json:"anomalies"`
+	Alerted        int `json:"alerted"`
+}
+
+// OpsMonitorResult summarizes one pass of the ops monitoring checks
+type OpsMonitorResult struct {
+	Reconciliation ReconciliationResult `json:"reconciliation"`
+	FillRate       FillRateResult       `json:"fill_rate"`
+	KPIAnomalies   KPIAnomalyResult     `json:"kpi_anomalies"`
+}
+
+// DeadLetter describes a workflow that gave up after exhausting its retries
+type DeadLetter struct {
+	WorkflowType string `json:"workflow_type"`
+	WorkflowID   string `json:"workflow_id"`
+	RunID        string `json:"run_id"`
+	JobID        int    `json:"job_id,omitempty"`
+	Error        string `json:"error"`
+}
+
+// OpsMonitorWorkflow reconciles payments, checks market fill rates and platform KPIs,
+// and refreshes the admin overview rollups.
+// It is started with a cron schedule so each run is a single pass.
+func OpsMonitorWorkflow(ctx workflow.Context) (OpsMonitorResult, error) {
+	logger := workflow.GetLogger(ctx)
+
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 15 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts:    3,
+			InitialInterval:    time.Minute,
+			BackoffCoefficient: 2.0,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var result OpsMonitorResult
+
+	// Run every check even if one fails so a bad reconciliation pass never hides a fill-rate drop
+	reconcileErr := workflow.ExecuteActivity(ctx, "ReconcilePayments").Get(ctx, &result.Reconciliation)
+	if reconcileErr != nil {
+		logger.Error("Payment reconciliation failed", "error", reconcileErr)
+	}
+
+	fillRateErr := workflow.ExecuteActivity(ctx, "CheckMarketFillRates").Get(ctx, &result.FillRate)
+	if fillRateErr != nil {
+		logger.Error("Market fill-rate check failed", "error", fillRateErr)
+	}
+
+	kpiErr := workflow.ExecuteActivity(ctx, "CheckKPIAnomalies").Get(ctx, &result.KPIAnomalies)
+	if kpiErr != nil {
+		logger.Error("KPI anomaly check failed", "error", kpiErr)
+	}
+
+	refreshErr := workflow.ExecuteActivity(ctx, "RefreshAdminOverview").Get(ctx, nil)
+	if refreshErr != nil {
+		logger.Error("Admin overview refresh failed", "error", refreshErr)
+	}
+
+	logger.Info("Ops monitor completed",
+		"reconciled", result.Reconciliation.Reconciled,
+		"exceptions", result.Reconciliation.Exceptions,
+		"fillRateDrops", result.FillRate.Drops,
+		"kpiAnomalies", result.KPIAnomalies.Anomalies)
+
+	if reconcileErr != nil {
+		return result, reconcileErr
+	}
+	if fillRateErr != nil {
+		return result, fillRateErr
+	}
+	if kpiErr != nil {
+		return result, kpiErr
+	}
+	return result, refreshErr
+}
+
+// reportDeadLetter routes a terminal workflow failure to ops. It runs on a disconnected
+// context so it is still delivered when the workflow was cancelled.
+func reportDeadLetter(ctx workflow.Context, jobID int, cause error) {
+	info := workflow.GetInfo(ctx)
+
+	ctx, _ = workflow.NewDisconnectedContext(ctx)
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 3,
+		},
+	})
+
+	deadLetter := DeadLetter{
+		WorkflowType: info.WorkflowType.Name,
+		WorkflowID:   info.WorkflowExecution.ID,
+		RunID:        info.WorkflowExecution.RunID,
+		JobID:        jobID,
+		Error:        cause.Error(),
+	}
+	if err := workflow.ExecuteActivity(ctx, "ReportWorkflowDeadLetter", deadLetter).Get(ctx, nil); err != nil {
+		workflow.GetLogger(ctx).Error("Failed to report dead-lettered workflow", "error", err)
+	}
+}
@@ -0,0 +1,64 @@
+package workflows
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// PayoutSettlementWorkflowID is the fixed ID of the scheduled payout settlement workflow
+const PayoutSettlementWorkflowID = "payout-settlement"
+
+// SettlementResult summarizes one payout settlement run
+type SettlementResult struct {
+	SettlementBatchID int     `json:"settlement_batch_id"` // 0 when there was nothing to settle
+	Status            string  `json:"status"`
+	TotalAmount       float64 `json:"total_amount"`
+	Payouts           int     `json:"payouts"`
+	Paid              int     `json:"paid"`
+	Failed            int     `json:"failed"`
+	Manual            int     `json:"manual"`
+}
+
+// PayoutSettlementWorkflow batches captured job payments and pays workers out.
+// It is started with a cron schedule so each run settles one batch.
+func PayoutSettlementWorkflow(ctx workflow.Context) (SettlementResult, error) {
+	logger := workflow.GetLogger(ctx)
+
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 15 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts:    3,
+			InitialInterval:    time.Minute,
+			BackoffCoefficient: 2.0,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	// The cutoff is fixed by the workflow so a retried activity reuses the same batch
+	cutoff := workflow.Now(ctx)
+
+	var batchID int
+	if err := workflow.ExecuteActivity(ctx, "CreateSettlementBatch", cutoff).Get(ctx, &batchID); err != nil {
+		logger.Error("Failed to create settlement batch", "error", err)
+		return SettlementResult{}, err
+	}
+	if batchID == 0 {
+		logger.Info("No captured payments to settle")
+		return SettlementResult{}, nil
+	}
+
+	var result SettlementResult
+	if err := workflow.ExecuteActivity(ctx, "ProcessSettlementBatch", batchID).Get(ctx, &result); err != nil {
+		logger.Error("Failed to process settlement batch", "batchID", batchID, "error", err)
+		return SettlementResult{SettlementBatchID: batchID}, err
+	}
+
+	logger.Info("Payout settlement completed",
+		"batchID", batchID,
+		"paid", result.Paid,
+		"failed", result.Failed,
+		"manual", result.Manual)
+	return result, nil
+}
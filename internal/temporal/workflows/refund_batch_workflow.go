@@ -0,0 +1,55 @@
+package workflows
+
+import (
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// RefundBatchResult summarizes one refund batch run
+type RefundBatchResult struct {
+	RefundBatchID int    `json:"refund_batch_id"`
+	Status        string `json:"status"`
+	Refunded      int    `json:"refunded"`
+	Failed        int    `json:"failed"`
+	Skipped       int    `json:"skipped"`
+}
+
+// RefundBatchWorkflowID is the ID of the workflow refunding a batch. A batch has one
+// workflow at a time; retrying a finished batch starts a new run with the same ID.
+func RefundBatchWorkflowID(batchID int) string {
+	return fmt.Sprintf("refund-batch-%d", batchID)
+}
+
+// RefundBatchWorkflow refunds a batch's pending items. Each refund has its own
+// idempotency key and result, so when the activity is retried, for instance after the
+// provider rate limited it, it carries on from the first item still pending.
+func RefundBatchWorkflow(ctx workflow.Context, batchID int) (RefundBatchResult, error) {
+	logger := workflow.GetLogger(ctx)
+
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 2 * time.Hour,
+		HeartbeatTimeout:    5 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts:    5,
+			InitialInterval:    time.Minute,
+			BackoffCoefficient: 2.0,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var result RefundBatchResult
+	if err := workflow.ExecuteActivity(ctx, "ProcessRefundBatch", batchID).Get(ctx, &result); err != nil {
+		logger.Error("Failed to process refund batch", "batchID", batchID, "error", err)
+		return RefundBatchResult{RefundBatchID: batchID}, err
+	}
+
+	logger.Info("Refund batch completed",
+		"batchID", batchID,
+		"refunded", result.Refunded,
+		"failed", result.Failed,
+		"skipped", result.Skipped)
+	return result, nil
+}
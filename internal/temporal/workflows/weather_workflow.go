@@ -0,0 +1,43 @@
+package workflows
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// WeatherAdvisoryWorkflowID is the fixed ID of the scheduled weather check workflow
+const WeatherAdvisoryWorkflowID = "weather-advisories"
+
+// WeatherCheckResult summarizes one pass of the outdoor job weather check
+type WeatherCheckResult struct {
+	JobsChecked int `json:"jobs_checked"`
+	SevereJobs  int `json:"severe_jobs"`
+	Notified    int `json:"notified"`
+}
+
+// WeatherAdvisoryWorkflow refreshes forecast advisories for scheduled outdoor jobs.
+// It is started with a cron schedule so each run is a single pass.
+func WeatherAdvisoryWorkflow(ctx workflow.Context) (WeatherCheckResult, error) {
+	logger := workflow.GetLogger(ctx)
+
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 15 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts:    3,
+			InitialInterval:    time.Minute,
+			BackoffCoefficient: 2.0,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var result WeatherCheckResult
+	if err := workflow.ExecuteActivity(ctx, "CheckOutdoorJobWeather").Get(ctx, &result); err != nil {
+		logger.Error("Weather check failed", "error", err)
+		return result, err
+	}
+
+	logger.Info("Weather check completed", "jobsChecked", result.JobsChecked, "severe", result.SevereJobs, "notified", result.Notified)
+	return result, nil
+}
@@ -0,0 +1,101 @@
+// Package tracing sets up OpenTelemetry tracing for the API and the worker, so one trace
+// follows a request from the HTTP handler through its database queries, the Temporal
+// workflows it starts and their activities, to the payment provider calls they make.
+// Spans are exported over OTLP/HTTP when OTEL_EXPORTER_OTLP_ENDPOINT (or
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT) is set; without an endpoint nothing is recorded,
+// but incoming trace context is still passed on. The standard OTEL_* variables, such as
+// OTEL_TRACES_SAMPLER, configure the exporter and sampling.
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"os"
+
+	"github.com/uptrace/opentelemetry-go-extra/otelsql"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Init installs the global tracer provider and propagators for service. The returned
+// function flushes buffered spans and should be called before the process exits.
+func Init(ctx context.Context, service string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if !Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(semconv.ServiceName(service)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Enabled reports whether an OTLP endpoint is configured for exporting spans
+func Enabled() bool {
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Tracer returns the named tracer from the global provider
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// HasSpan reports whether ctx is part of a trace
+func HasSpan(ctx context.Context) bool {
+	return trace.SpanContextFromContext(ctx).IsValid()
+}
+
+// OpenDB opens a database over connector whose queries are recorded as spans. Only
+// queries made with a context that is already part of a trace get a span; the many
+// background queries made without one would otherwise each start a trace of their own.
+func OpenDB(connector driver.Connector) *sql.DB {
+	return otelsql.OpenDB(connector,
+		otelsql.WithTracerProvider(childSpanProvider{otel.GetTracerProvider()}),
+		otelsql.WithAttributes(semconv.DBSystemNamePostgreSQL),
+	)
+}
+
+// childSpanProvider hands out tracers that only start spans under an existing one
+type childSpanProvider struct {
+	trace.TracerProvider
+}
+
+func (p childSpanProvider) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	return childSpanTracer{p.TracerProvider.Tracer(name, opts...)}
+}
+
+type childSpanTracer struct {
+	trace.Tracer
+}
+
+func (t childSpanTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if !HasSpan(ctx) {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	return t.Tracer.Start(ctx, name, opts...)
+}
@@ -0,0 +1,148 @@
+// Package validate checks request bodies field by field. Handlers collect every
+// problem with a request in a Validator and return them together, so clients can
+// show each message next to the field it belongs to.
+package validate
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	phoneRegex = regexp.MustCompile(`^\+?1?[-.\s]?\(?[0-9]{3}\)?[-.\s]?[0-9]{3}[-.\s]?[0-9]{4}$`)
+)
+
+// FieldError is a problem with one request field. Field is the JSON name; Value is
+// the rejected input, when echoing it back helps.
+type FieldError struct {
+	Field   string
+	Message string
+	Value   string
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// Errors lists the invalid fields of a request in the order they were checked
+type Errors []*FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fe := range e {
+		messages[i] = fe.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Fields maps each invalid field to its message
+func (e Errors) Fields() map[string]string {
+	fields := make(map[string]string, len(e))
+	for _, fe := range e {
+		fields[fe.Field] = fe.Message
+	}
+	return fields
+}
+
+// Validator collects field errors. Only the first problem with each field is kept,
+// so later checks on a field that is already invalid (e.g. a format check after a
+// failed required check) are skipped.
+type Validator struct {
+	errs Errors
+}
+
+// Err returns the collected errors as Errors, or nil when the request is valid
+func (v *Validator) Err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+// Valid reports whether field has no error so far
+func (v *Validator) Valid(field string) bool {
+	for _, fe := range v.errs {
+		if fe.Field == field {
+			return false
+		}
+	}
+	return true
+}
+
+// Add records a problem with field
+func (v *Validator) Add(field, message string) {
+	v.AddValue(field, message, "")
+}
+
+// AddValue records a problem with field, echoing the rejected value
+func (v *Validator) AddValue(field, message, value string) {
+	if v.Valid(field) {
+		v.errs = append(v.errs, &FieldError{Field: field, Message: message, Value: value})
+	}
+}
+
+// Check records message against field unless ok
+func (v *Validator) Check(ok bool, field, message string) {
+	if !ok {
+		v.Add(field, message)
+	}
+}
+
+// Required checks that value is not blank
+func (v *Validator) Required(field, value string) {
+	v.Check(strings.TrimSpace(value) != "", field, "is required")
+}
+
+// Length checks that a non-empty value is between min and max characters; max 0
+// means no upper limit
+func (v *Validator) Length(field, value string, min, max int) {
+	if value == "" {
+		return
+	}
+	n := utf8.RuneCountInString(value)
+	switch {
+	case max > 0 && min > 0 && (n < min || n > max):
+		v.Add(field, "must be between "+strconv.Itoa(min)+" and "+strconv.Itoa(max)+" characters")
+	case n < min:
+		v.Add(field, "must be at least "+strconv.Itoa(min)+" characters")
+	case max > 0 && n > max:
+		v.Add(field, "must be at most "+strconv.Itoa(max)+" characters")
+	}
+}
+
+// MaxLength checks that an optional value is at most max characters
+func (v *Validator) MaxLength(field string, value *string, max int) {
+	if value != nil {
+		v.Length(field, *value, 0, max)
+	}
+}
+
+// Email checks that a non-empty value is an email address
+func (v *Validator) Email(field, value string) {
+	if value != "" && !emailRegex.MatchString(value) {
+		v.AddValue(field, "must be a valid email address", value)
+	}
+}
+
+// Phone checks that a non-empty value is a North American phone number
+func (v *Validator) Phone(field, value string) {
+	if value != "" && !phoneRegex.MatchString(value) {
+		v.AddValue(field, "must be a valid phone number", value)
+	}
+}
+
+// OneOf checks that a non-empty value is one of allowed
+func (v *Validator) OneOf(field, value string, allowed ...string) {
+	if value == "" {
+		return
+	}
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.AddValue(field, "must be one of: "+strings.Join(allowed, ", "), value)
+}
@@ -0,0 +1,99 @@
+package validate
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidator(t *testing.T) {
+	tests := []struct {
+		name  string
+		check func(v *Validator)
+		want  map[string]string
+	}{
+		{
+			name: "valid",
+			check: func(v *Validator) {
+				v.Required("title", "Fix sink")
+				v.Length("title", "Fix sink", 3, 255)
+				v.Email("email", "jane@example.com")
+				v.OneOf("role", "consumer", "consumer", "gig_worker")
+			},
+		},
+		{
+			name: "every invalid field reported",
+			check: func(v *Validator) {
+				v.Required("title", " ")
+				v.Email("email", "jane@")
+				v.OneOf("role", "owner", "consumer", "gig_worker")
+			},
+			want: map[string]string{
+				"title": "is required",
+				"email": "must be a valid email address",
+				"role":  "must be one of: consumer, gig_worker",
+			},
+		},
+		{
+			name: "first problem with a field wins",
+			check: func(v *Validator) {
+				v.Required("email", "")
+				v.Email("email", "")
+				v.Check(false, "email", "is taken")
+			},
+			want: map[string]string{"email": "is required"},
+		},
+		{
+			name: "length bounds",
+			check: func(v *Validator) {
+				v.Length("name", "J", 2, 255)
+				v.Length("description", "short", 10, 0)
+				v.Length("note", "too long", 0, 3)
+				v.Length("skipped", "", 2, 10)
+			},
+			want: map[string]string{
+				"name":        "must be between 2 and 255 characters",
+				"description": "must be at least 10 characters",
+				"note":        "must be at most 3 characters",
+			},
+		},
+		{
+			name: "length counts characters, not bytes",
+			check: func(v *Validator) {
+				v.Length("name", "Zoë", 3, 3)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v Validator
+			tt.check(&v)
+			err := v.Err()
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Err() = %v, want nil", err)
+				}
+				return
+			}
+			var errs Errors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Err() = %v, want Errors", err)
+			}
+			if got := errs.Fields(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Fields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorsError(t *testing.T) {
+	var v Validator
+	v.Required("title", "")
+	v.Check(false, "total_pay", "must be greater than 0")
+
+	want := "title: is required; total_pay: must be greater than 0"
+	if got := v.Err().Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
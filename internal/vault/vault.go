@@ -0,0 +1,175 @@
+// Package vault encrypts sensitive fields (e.g. emergency contacts) so they
+// are never stored in plaintext.
+package vault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var (
+	ErrNotConfigured = errors.New("vault encryption key is not configured")
+	ErrUnknownKey    = errors.New("sealed value was encrypted with an unknown key")
+	ErrMalformed     = errors.New("sealed value is malformed")
+)
+
+// Vault seals and opens values with AES-256-GCM
+type Vault struct {
+	keyID string
+	keys  map[string]cipher.AEAD // Keyring by ID, including the sealing key
+}
+
+// NewVault creates a vault from a 32-byte key. keyID is stored alongside each
+// sealed value so keys can be rotated without re-encrypting everything at once:
+// the replaced key is added with AddKey and keeps opening what it sealed.
+func NewVault(key []byte, keyID string) (*Vault, error) {
+	aead, err := newAEAD(key, keyID)
+	if err != nil {
+		return nil, err
+	}
+	return &Vault{keyID: keyID, keys: map[string]cipher.AEAD{keyID: aead}}, nil
+}
+
+// AddKey adds a key that only opens values, such as one replaced by rotation
+func (v *Vault) AddKey(key []byte, keyID string) error {
+	if _, ok := v.keys[keyID]; ok {
+		return fmt.Errorf("vault key %q is already in the keyring", keyID)
+	}
+	aead, err := newAEAD(key, keyID)
+	if err != nil {
+		return err
+	}
+	v.keys[keyID] = aead
+	return nil
+}
+
+func newAEAD(key []byte, keyID string) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("vault key must be 32 bytes, got %d", len(key))
+	}
+	if keyID == "" || strings.Contains(keyID, ":") {
+		return nil, fmt.Errorf("vault key ID must be non-empty and must not contain ':'")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return aead, nil
+}
+
+// NewVaultFromEnv creates a vault from VAULT_ENCRYPTION_KEY (base64) and VAULT_KEY_ID.
+// After a rotation, VAULT_PREVIOUS_ENCRYPTION_KEY and VAULT_PREVIOUS_KEY_ID hold the
+// replaced key so values it sealed still open.
+func NewVaultFromEnv() (*Vault, error) {
+	encoded := os.Getenv("VAULT_ENCRYPTION_KEY")
+	if encoded == "" {
+		return nil, ErrNotConfigured
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("VAULT_ENCRYPTION_KEY must be base64 encoded: %w", err)
+	}
+
+	keyID := os.Getenv("VAULT_KEY_ID")
+	if keyID == "" {
+		keyID = "v1"
+	}
+
+	v, err := NewVault(key, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if encoded := os.Getenv("VAULT_PREVIOUS_ENCRYPTION_KEY"); encoded != "" {
+		previous, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("VAULT_PREVIOUS_ENCRYPTION_KEY must be base64 encoded: %w", err)
+		}
+		if err := v.AddKey(previous, os.Getenv("VAULT_PREVIOUS_KEY_ID")); err != nil {
+			return nil, fmt.Errorf("invalid previous vault key: %w", err)
+		}
+	}
+
+	return v, nil
+}
+
+// KeyID returns the identifier of the key used for sealing
+func (v *Vault) KeyID() string {
+	return v.keyID
+}
+
+// Seal encrypts plaintext and returns "<keyID>:<base64(nonce|ciphertext)>".
+// additionalData binds the ciphertext to its owner (e.g. the row ID) so sealed
+// values can't be swapped between records.
+func (v *Vault) Seal(plaintext, additionalData []byte) (string, error) {
+	aead := v.keys[v.keyID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := aead.Seal(nonce, nonce, plaintext, additionalData)
+	return v.keyID + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a value produced by Seal with the same additionalData, using
+// whichever key in the keyring sealed it
+func (v *Vault) Open(sealed string, additionalData []byte) ([]byte, error) {
+	keyID, payload, ok := strings.Cut(sealed, ":")
+	if !ok {
+		return nil, ErrMalformed
+	}
+	aead, ok := v.keys[keyID]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil || len(data) < aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt sealed value: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+// SealJSON marshals v to JSON and seals it
+func (v *Vault) SealJSON(value interface{}, additionalData []byte) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal value: %w", err)
+	}
+	return v.Seal(data, additionalData)
+}
+
+// OpenJSON opens a sealed value and unmarshals it into dest
+func (v *Vault) OpenJSON(sealed string, additionalData []byte, dest interface{}) error {
+	data, err := v.Open(sealed, additionalData)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dest)
+}
+
+// RecordAAD builds additional data that ties a sealed value to a specific row
+func RecordAAD(table string, id int) []byte {
+	return []byte(fmt.Sprintf("%s:%d", table, id))
+}
@@ -0,0 +1,148 @@
+package vault
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 32)
+}
+
+func TestSealOpen(t *testing.T) {
+	v, err := NewVault(testKey(1), "v1")
+	if err != nil {
+		t.Fatalf("NewVault() error = %v", err)
+	}
+
+	sealed, err := v.Seal([]byte("Jane Doe, 555-0100"), []byte("gigworker:42"))
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		vault   *Vault
+		sealed  string
+		aad     []byte
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "round trip",
+			vault:  v,
+			sealed: sealed,
+			aad:    []byte("gigworker:42"),
+			want:   "Jane Doe, 555-0100",
+		},
+		{
+			name:    "wrong additional data",
+			vault:   v,
+			sealed:  sealed,
+			aad:     []byte("gigworker:43"),
+			wantErr: true,
+		},
+		{
+			name:    "malformed value",
+			vault:   v,
+			sealed:  "not-sealed",
+			aad:     []byte("gigworker:42"),
+			wantErr: true,
+		},
+		{
+			name:    "unknown key ID",
+			vault:   mustVault(t, testKey(1), "v2"),
+			sealed:  sealed,
+			aad:     []byte("gigworker:42"),
+			wantErr: true,
+		},
+		{
+			name:    "different key same ID",
+			vault:   mustVault(t, testKey(2), "v1"),
+			sealed:  sealed,
+			aad:     []byte("gigworker:42"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.vault.Open(tt.sealed, tt.aad)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Open() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != tt.want {
+				t.Errorf("Open() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenAfterRotation(t *testing.T) {
+	old := mustVault(t, testKey(1), "v1")
+	sealed, err := old.Seal([]byte("Jane Doe, 555-0100"), []byte("gigworker:42"))
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+
+	rotated := mustVault(t, testKey(2), "v2")
+	if _, err := rotated.Open(sealed, []byte("gigworker:42")); err != ErrUnknownKey {
+		t.Fatalf("Open() before adding the old key error = %v, want ErrUnknownKey", err)
+	}
+	if err := rotated.AddKey(testKey(1), "v1"); err != nil {
+		t.Fatalf("AddKey() error = %v", err)
+	}
+
+	got, err := rotated.Open(sealed, []byte("gigworker:42"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if string(got) != "Jane Doe, 555-0100" {
+		t.Errorf("Open() = %q, want %q", got, "Jane Doe, 555-0100")
+	}
+
+	resealed, err := rotated.Seal(got, []byte("gigworker:42"))
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	if !strings.HasPrefix(resealed, "v2:") {
+		t.Errorf("Seal() = %q, want it sealed with the new key", resealed)
+	}
+
+	if err := rotated.AddKey(testKey(3), "v2"); err == nil {
+		t.Error("AddKey() replaced the sealing key")
+	}
+}
+
+func TestNewVaultKeyValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     []byte
+		keyID   string
+		wantErr bool
+	}{
+		{name: "valid", key: testKey(1), keyID: "v1"},
+		{name: "short key", key: []byte("short"), keyID: "v1", wantErr: true},
+		{name: "empty key ID", key: testKey(1), keyID: "", wantErr: true},
+		{name: "key ID with separator", key: testKey(1), keyID: "v:1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewVault(tt.key, tt.keyID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewVault() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func mustVault(t *testing.T, key []byte, keyID string) *Vault {
+	t.Helper()
+	v, err := NewVault(key, keyID)
+	if err != nil {
+		t.Fatalf("NewVault() error = %v", err)
+	}
+	return v
+}
@@ -0,0 +1,249 @@
+// Package weather fetches forecasts for job locations and turns them into
+// advisories for outdoor work.
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Advisory levels
+const (
+	LevelClear    = "clear"
+	LevelAdvisory = "advisory"
+	LevelSevere   = "severe"
+)
+
+// DefaultOutdoorCategories are job categories that get weather advisories
+var DefaultOutdoorCategories = []string{"lawn_care", "exterior_painting"}
+
+// Client fetches hourly forecasts from the Open-Meteo API
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a new weather client
+func NewClient() *Client {
+	baseURL := os.Getenv("WEATHER_API_URL")
+	if baseURL == "" {
+		baseURL = "https://api.open-meteo.com/v1/forecast"
+	}
+
+	return &Client{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// OutdoorCategories returns the categories that get advisories, from
+// WEATHER_OUTDOOR_CATEGORIES (comma separated) or the defaults
+func OutdoorCategories() []string {
+	configured := os.Getenv("WEATHER_OUTDOOR_CATEGORIES")
+	if configured == "" {
+		return DefaultOutdoorCategories
+	}
+
+	var categories []string
+	for _, c := range strings.Split(configured, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			categories = append(categories, c)
+		}
+	}
+	return categories
+}
+
+// IsOutdoorCategory reports whether a job category gets weather advisories
+func IsOutdoorCategory(category string) bool {
+	for _, c := range OutdoorCategories() {
+		if strings.EqualFold(c, category) {
+			return true
+		}
+	}
+	return false
+}
+
+// HourlyForecast is the forecast for a single hour
+type HourlyForecast struct {
+	Time                     time.Time `json:"time"`
+	TemperatureC             float64   `json:"temperature_c"`
+	PrecipitationProbability float64   `json:"precipitation_probability"`
+	WindSpeedKmh             float64   `json:"wind_speed_kmh"`
+	WeatherCode              int       `json:"weather_code"`
+}
+
+// Forecast is an hourly forecast for a location
+type Forecast struct {
+	Latitude  float64          `json:"latitude"`
+	Longitude float64          `json:"longitude"`
+	Hours     []HourlyForecast `json:"hours"`
+}
+
+// openMeteoResponse is the subset of the Open-Meteo response we use
+type openMeteoResponse struct {
+	Hourly struct {
+		Time                     []string  `json:"time"`
+		Temperature              []float64 `json:"temperature_2m"`
+		PrecipitationProbability []float64 `json:"precipitation_probability"`
+		WindSpeed                []float64 `json:"wind_speed_10m"`
+		WeatherCode              []int     `json:"weather_code"`
+	} `json:"hourly"`
+}
+
+// GetForecast returns the hourly forecast covering [start, end] at a location
+func (c *Client) GetForecast(ctx context.Context, lat, lon float64, start, end time.Time) (*Forecast, error) {
+	params := url.Values{}
+	params.Set("latitude", fmt.Sprintf("%.4f", lat))
+	params.Set("longitude", fmt.Sprintf("%.4f", lon))
+	params.Set("hourly", "temperature_2m,precipitation_probability,wind_speed_10m,weather_code")
+	params.Set("timezone", "UTC")
+	params.Set("start_date", start.UTC().Format("2006-01-02"))
+	params.Set("end_date", end.UTC().Format("2006-01-02"))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch forecast: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("weather API returned status %d", resp.StatusCode)
+	}
+
+	var data openMeteoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode forecast: %w", err)
+	}
+
+	forecast := &Forecast{Latitude: lat, Longitude: lon}
+	for i, ts := range data.Hourly.Time {
+		t, err := time.Parse("2006-01-02T15:04", ts)
+		if err != nil {
+			continue
+		}
+		if t.Before(start.Truncate(time.Hour)) || t.After(end) {
+			continue
+		}
+
+		hour := HourlyForecast{Time: t}
+		if i < len(data.Hourly.Temperature) {
+			hour.TemperatureC = data.Hourly.Temperature[i]
+		}
+		if i < len(data.Hourly.PrecipitationProbability) {
+			hour.PrecipitationProbability = data.Hourly.PrecipitationProbability[i]
+		}
+		if i < len(data.Hourly.WindSpeed) {
+			hour.WindSpeedKmh = data.Hourly.WindSpeed[i]
+		}
+		if i < len(data.Hourly.WeatherCode) {
+			hour.WeatherCode = data.Hourly.WeatherCode[i]
+		}
+		forecast.Hours = append(forecast.Hours, hour)
+	}
+
+	return forecast, nil
+}
+
+// Advisory summarizes how the forecast affects an outdoor job
+type Advisory struct {
+	Level   string   `json:"level"`
+	Summary string   `json:"summary"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// Assess turns a forecast into an advisory for outdoor work.
+// Thunderstorms, heavy precipitation, high winds, and extreme temperatures are severe;
+// likely rain or strong wind is an advisory.
+func Assess(forecast *Forecast) Advisory {
+	if forecast == nil || len(forecast.Hours) == 0 {
+		return Advisory{Level: LevelClear, Summary: "No forecast available for the scheduled time"}
+	}
+
+	level := LevelClear
+	seen := make(map[string]bool)
+	var reasons []string
+	flag := func(l, reason string) {
+		if l == LevelSevere || level == LevelClear {
+			level = l
+		}
+		if !seen[reason] {
+			seen[reason] = true
+			reasons = append(reasons, reason)
+		}
+	}
+
+	for _, h := range forecast.Hours {
+		switch {
+		case h.WeatherCode >= 95:
+			flag(LevelSevere, "thunderstorms forecast")
+		case h.WeatherCode == 65 || h.WeatherCode == 75 || h.WeatherCode == 82 || h.WeatherCode == 86:
+			flag(LevelSevere, "heavy precipitation forecast")
+		case h.PrecipitationProbability >= 60:
+			flag(LevelAdvisory, "rain likely")
+		}
+
+		switch {
+		case h.WindSpeedKmh >= 50:
+			flag(LevelSevere, "high winds forecast")
+		case h.WindSpeedKmh >= 30:
+			flag(LevelAdvisory, "strong winds forecast")
+		}
+
+		switch {
+		case h.TemperatureC >= 38:
+			flag(LevelSevere, "extreme heat forecast")
+		case h.TemperatureC <= -10:
+			flag(LevelSevere, "extreme cold forecast")
+		}
+	}
+
+	summary := "Weather looks suitable for outdoor work"
+	switch level {
+	case LevelSevere:
+		summary = "Severe weather is forecast during this job: " + strings.Join(reasons, ", ")
+	case LevelAdvisory:
+		summary = "Weather may affect this job: " + strings.Join(reasons, ", ")
+	}
+
+	return Advisory{Level: level, Summary: summary, Reasons: reasons}
+}
+
+// SuggestAlternative finds the first later day whose forecast for the same
+// time window is not severe. The forecast must cover the days searched.
+func SuggestAlternative(forecast *Forecast, start, end time.Time, days int) *time.Time {
+	if forecast == nil {
+		return nil
+	}
+
+	for d := 1; d <= days; d++ {
+		candidateStart := start.AddDate(0, 0, d)
+		candidateEnd := end.AddDate(0, 0, d)
+
+		window := &Forecast{Latitude: forecast.Latitude, Longitude: forecast.Longitude}
+		for _, h := range forecast.Hours {
+			if !h.Time.Before(candidateStart.Truncate(time.Hour)) && !h.Time.After(candidateEnd) {
+				window.Hours = append(window.Hours, h)
+			}
+		}
+		if len(window.Hours) == 0 {
+			continue
+		}
+
+		if Assess(window).Level != LevelSevere {
+			return &candidateStart
+		}
+	}
+
+	return nil
+}
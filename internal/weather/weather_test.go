@@ -0,0 +1,119 @@
+package weather
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+var jobStart = time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+// hours returns a forecast of n calm hours from start
+func hours(start time.Time, n int) []HourlyForecast {
+	forecast := make([]HourlyForecast, n)
+	for i := range forecast {
+		forecast[i] = HourlyForecast{Time: start.Add(time.Duration(i) * time.Hour), TemperatureC: 18, WindSpeedKmh: 10}
+	}
+	return forecast
+}
+
+func TestAssess(t *testing.T) {
+	tests := []struct {
+		name        string
+		hour        func(*HourlyForecast)
+		noForecast  bool
+		wantLevel   string
+		wantReasons []string
+	}{
+		{name: "no forecast", noForecast: true, wantLevel: LevelClear},
+		{name: "calm", hour: func(*HourlyForecast) {}, wantLevel: LevelClear},
+		{name: "rain likely", hour: func(h *HourlyForecast) { h.PrecipitationProbability = 70 }, wantLevel: LevelAdvisory, wantReasons: []string{"rain likely"}},
+		{name: "strong wind", hour: func(h *HourlyForecast) { h.WindSpeedKmh = 35 }, wantLevel: LevelAdvisory, wantReasons: []string{"strong winds forecast"}},
+		{name: "thunderstorm", hour: func(h *HourlyForecast) { h.WeatherCode = 95 }, wantLevel: LevelSevere, wantReasons: []string{"thunderstorms forecast"}},
+		{name: "heavy rain", hour: func(h *HourlyForecast) { h.WeatherCode = 65; h.PrecipitationProbability = 90 }, wantLevel: LevelSevere, wantReasons: []string{"heavy precipitation forecast"}},
+		{name: "high wind", hour: func(h *HourlyForecast) { h.WindSpeedKmh = 55 }, wantLevel: LevelSevere, wantReasons: []string{"high winds forecast"}},
+		{name: "extreme heat", hour: func(h *HourlyForecast) { h.TemperatureC = 40 }, wantLevel: LevelSevere, wantReasons: []string{"extreme heat forecast"}},
+		{name: "extreme cold", hour: func(h *HourlyForecast) { h.TemperatureC = -12 }, wantLevel: LevelSevere, wantReasons: []string{"extreme cold forecast"}},
+		{
+			name:        "severe outranks an earlier advisory",
+			hour:        func(h *HourlyForecast) { h.PrecipitationProbability = 70; h.WindSpeedKmh = 55 },
+			wantLevel:   LevelSevere,
+			wantReasons: []string{"rain likely", "high winds forecast"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forecast := &Forecast{}
+			if !tt.noForecast {
+				// The weather turns in the second of three hours
+				forecast.Hours = hours(jobStart, 3)
+				tt.hour(&forecast.Hours[1])
+			}
+
+			got := Assess(forecast)
+			if got.Level != tt.wantLevel {
+				t.Errorf("Assess() level = %q, want %q", got.Level, tt.wantLevel)
+			}
+			if !slices.Equal(got.Reasons, tt.wantReasons) {
+				t.Errorf("Assess() reasons = %q, want %q", got.Reasons, tt.wantReasons)
+			}
+			if got.Summary == "" {
+				t.Error("Assess() summary is empty")
+			}
+		})
+	}
+}
+
+func TestAssessAdvisoryAfterSevere(t *testing.T) {
+	forecast := &Forecast{Hours: hours(jobStart, 2)}
+	forecast.Hours[0].WeatherCode = 95
+	forecast.Hours[1].PrecipitationProbability = 70
+
+	if got := Assess(forecast).Level; got != LevelSevere {
+		t.Errorf("Assess() level = %q, want severe to stick", got)
+	}
+}
+
+func TestSuggestAlternative(t *testing.T) {
+	jobEnd := jobStart.Add(2 * time.Hour)
+	storm := func(forecast []HourlyForecast, day int) {
+		forecast[day*24+1].WeatherCode = 95
+	}
+
+	tests := []struct {
+		name       string
+		stormDays  []int // Days from the job's, whose job window has a storm
+		days       int
+		forecasted int // Days of forecast from the job's
+		want       *time.Time
+	}{
+		{name: "next day clear", stormDays: []int{0}, days: 3, forecasted: 4, want: ptr(jobStart.AddDate(0, 0, 1))},
+		{name: "skips stormy days", stormDays: []int{0, 1, 2}, days: 5, forecasted: 6, want: ptr(jobStart.AddDate(0, 0, 3))},
+		{name: "every day stormy", stormDays: []int{0, 1, 2, 3}, days: 3, forecasted: 4},
+		{name: "forecast too short", stormDays: []int{0}, days: 3, forecasted: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forecast := &Forecast{Hours: hours(jobStart, tt.forecasted*24)}
+			for _, day := range tt.stormDays {
+				storm(forecast.Hours, day)
+			}
+
+			got := SuggestAlternative(forecast, jobStart, jobEnd, tt.days)
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("SuggestAlternative() = %s, want none", got)
+			case tt.want != nil && (got == nil || !got.Equal(*tt.want)):
+				t.Errorf("SuggestAlternative() = %v, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if got := SuggestAlternative(nil, jobStart, jobEnd, 3); got != nil {
+		t.Errorf("SuggestAlternative(nil) = %s, want none", got)
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -0,0 +1,26 @@
+-- Migration: Query diagnostics
+-- EXPLAIN ANALYZE plans captured for queries that are slow again and again, when
+-- DB_EXPLAIN_SLOW_QUERIES=true. Each row is one capture; the query is stored with its
+-- literals replaced by ? and string literals in the plan are redacted the same way.
+
+CREATE TABLE IF NOT EXISTS query_diagnostics (
+    id SERIAL PRIMARY KEY,
+    fingerprint VARCHAR(16) NOT NULL,
+    query TEXT NOT NULL,
+    duration_ms DOUBLE PRECISION NOT NULL,
+    slow_count INTEGER NOT NULL,
+    plan TEXT NOT NULL,
+    captured_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_query_diagnostics_fingerprint ON query_diagnostics(fingerprint, captured_at DESC);
+CREATE INDEX IF NOT EXISTS idx_query_diagnostics_captured_at ON query_diagnostics(captured_at DESC);
+
+COMMENT ON COLUMN query_diagnostics.fingerprint IS 'Identifies the normalized query across captures and slow-query log lines';
+COMMENT ON COLUMN query_diagnostics.duration_ms IS 'Duration of the slow execution that triggered the capture';
+COMMENT ON COLUMN query_diagnostics.slow_count IS 'Slow executions of the query in the capturing process so far';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Query diagnostics table created successfully!';
+END $$;
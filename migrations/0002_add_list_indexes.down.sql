@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_jobs_created_at;
+DROP INDEX IF EXISTS idx_jobs_consumer_created;
+DROP INDEX IF EXISTS idx_jobs_gig_worker_created;
+DROP INDEX IF EXISTS idx_jobs_category_created;
+DROP INDEX IF EXISTS idx_transactions_status_created;
+DROP INDEX IF EXISTS idx_transactions_consumer_created;
+DROP INDEX IF EXISTS idx_transactions_gig_worker_created;
+DROP INDEX IF EXISTS idx_people_created_at;
+DROP INDEX IF EXISTS idx_people_role_created;
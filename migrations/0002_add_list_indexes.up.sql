@@ -0,0 +1,27 @@
+-- Migration: List indexes
+-- Composite indexes for the list endpoints, which filter jobs, transactions and people
+-- by one column and page through the matches newest first. Each index serves the
+-- filter and the ORDER BY created_at DESC together, so a page no longer sorts every
+-- match. The index check (GET /api/v1/admin/index-advisories) reports any of these
+-- missing.
+--
+-- Migrations run in a transaction, so these are not built CONCURRENTLY. On a large
+-- production table, create the index by hand with CREATE INDEX CONCURRENTLY first;
+-- IF NOT EXISTS then makes this migration a no-op for it.
+
+CREATE INDEX IF NOT EXISTS idx_jobs_created_at ON jobs(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_jobs_consumer_created ON jobs(consumer_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_jobs_gig_worker_created ON jobs(gig_worker_id, created_at DESC) WHERE gig_worker_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_jobs_category_created ON jobs(category, created_at DESC) WHERE category IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_status_created ON transactions(status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_transactions_consumer_created ON transactions(consumer_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_transactions_gig_worker_created ON transactions(gig_worker_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_people_created_at ON people(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_people_role_created ON people(role, created_at DESC);
+
+DO $$
+BEGIN
+    RAISE NOTICE 'List indexes created successfully!';
+END $$;
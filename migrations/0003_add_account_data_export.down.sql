@@ -0,0 +1,3 @@
+ALTER TABLE account_deletion_requests DROP COLUMN IF EXISTS export_sent_at;
+ALTER TABLE account_deletion_requests DROP COLUMN IF EXISTS exported_at;
+ALTER TABLE account_deletion_requests DROP COLUMN IF EXISTS export_key;
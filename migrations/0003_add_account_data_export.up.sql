@@ -0,0 +1,16 @@
+-- Migration: Account data export before erasure
+-- The deletion workflow exports the user's data to object storage and emails them a
+-- download link before the hold starts; the archive is deleted when the account is
+-- erased.
+
+ALTER TABLE account_deletion_requests ADD COLUMN IF NOT EXISTS export_key TEXT;
+ALTER TABLE account_deletion_requests ADD COLUMN IF NOT EXISTS exported_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE account_deletion_requests ADD COLUMN IF NOT EXISTS export_sent_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN account_deletion_requests.export_key IS 'Object storage key of the data export archive, deleted on erasure';
+COMMENT ON COLUMN account_deletion_requests.export_sent_at IS 'When the download link was emailed to the user';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Account data export columns added successfully!';
+END $$;
@@ -0,0 +1 @@
+ALTER TABLE worker_profiles DROP COLUMN IF EXISTS last_seen_at;
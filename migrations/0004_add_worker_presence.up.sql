@@ -0,0 +1,12 @@
+-- Migration: Worker presence
+-- The worker app sends a heartbeat every few minutes while open; last_seen_at is the
+-- latest one. Workers seen recently are shown as online and matched first.
+
+ALTER TABLE worker_profiles ADD COLUMN IF NOT EXISTS last_seen_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN worker_profiles.last_seen_at IS 'Latest heartbeat from the worker app; online while within the presence TTL';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Worker presence column added successfully!';
+END $$;
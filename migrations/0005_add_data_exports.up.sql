@@ -0,0 +1,27 @@
+-- Migration: Data exports
+-- Archives of a user's data requested at GET /api/v1/users/me/export. A workflow
+-- builds each one, emails the user a download link and deletes the archive when the
+-- link expires.
+
+CREATE TABLE IF NOT EXISTS data_exports (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    format VARCHAR(10) NOT NULL CHECK (format IN ('json', 'csv')),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'ready', 'failed', 'expired')),
+    storage_key TEXT,
+    temporal_workflow_id VARCHAR(255),
+    error TEXT,
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_user_requested ON data_exports(user_id, requested_at DESC);
+
+COMMENT ON COLUMN data_exports.storage_key IS 'Object storage key of the archive while it is ready';
+COMMENT ON COLUMN data_exports.expires_at IS 'When the download link stops working and the archive is deleted';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Data exports table created successfully!';
+END $$;
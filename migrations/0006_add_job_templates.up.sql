@@ -0,0 +1,35 @@
+-- Migration: Job templates
+-- Jobs a consumer saved to post again, e.g. a weekly house cleaning. Templates keep
+-- the posting fields but no schedule; POST /api/v1/jobs/from-template/{id} posts a
+-- job from one with the time given then.
+
+CREATE TABLE IF NOT EXISTS job_templates (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    consumer_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    category VARCHAR(100),
+    location_address TEXT,
+    location_latitude DECIMAL(10, 8),
+    location_longitude DECIMAL(11, 8),
+    estimated_duration_hours DECIMAL(4, 2),
+    pay_rate_per_hour DECIMAL(10, 2),
+    total_pay DECIMAL(10, 2),
+    notes TEXT,
+    access_instructions TEXT,
+    preferred_worker_id INTEGER REFERENCES people(id) ON DELETE SET NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+    source_job_id INTEGER REFERENCES jobs(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (consumer_id, name)
+);
+
+COMMENT ON COLUMN job_templates.source_job_id IS 'Job the template was saved from, if any';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Job templates table created successfully!';
+END $$;
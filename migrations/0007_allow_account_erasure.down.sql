@@ -0,0 +1,13 @@
+CREATE OR REPLACE FUNCTION prevent_account_merge_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'account_merges is append-only';
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE FUNCTION prevent_worker_application_event_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'worker_application_events is append-only';
+END;
+$$ language 'plpgsql';
@@ -0,0 +1,32 @@
+-- Migration: Erasure of append-only records
+-- account_merges and worker_application_events are append-only, but erasing an
+-- account must anonymize the emails recorded for it in merges and clear the screening
+-- notes on its applications. Those updates are allowed; every other change still
+-- raises.
+
+CREATE OR REPLACE FUNCTION prevent_account_merge_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+       AND to_jsonb(NEW) - 'source_email' - 'target_email' = to_jsonb(OLD) - 'source_email' - 'target_email' THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'account_merges is append-only';
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE FUNCTION prevent_worker_application_event_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.note IS NULL
+       AND to_jsonb(NEW) - 'note' = to_jsonb(OLD) - 'note' THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'worker_application_events is append-only';
+END;
+$$ language 'plpgsql';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Append-only tables updated to allow account erasure!';
+END $$;
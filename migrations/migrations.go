@@ -0,0 +1,15 @@
+// Package migrations embeds the versioned schema migrations applied by cmd/migrate and,
+// with MIGRATE_ON_STARTUP=true, by the API on startup (see internal/migrate).
+//
+// The schema built by scripts/init.sql and the scripts/add_*.sql migrations applied by
+// hand before this directory existed is the baseline; every schema change since is a
+// file here named <version>_<name>.up.sql, with an optional .down.sql reverting it.
+// Each runs in a transaction, so CREATE INDEX CONCURRENTLY cannot be used.
+package migrations
+
+import "embed"
+
+// FS holds the migration files
+//
+//go:embed *.sql
+var FS embed.FS
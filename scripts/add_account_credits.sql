@@ -0,0 +1,31 @@
+-- Migration: Account credit for consumers
+-- Ledger of credit granted to consumers (goodwill, promotions) and applied to job
+-- payments. The balance is the sum of amounts; the price breakdown applies it before
+-- tax and authorization records a negative 'redeemed' entry. Full refunds restore it.
+-- Requires add_support_tickets.sql.
+
+CREATE TABLE IF NOT EXISTS account_credits (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount <> 0),
+    reason VARCHAR(50) NOT NULL,                -- e.g. goodwill, promotion, redeemed, restored
+    note TEXT,
+    transaction_id INTEGER REFERENCES transactions(id) ON DELETE SET NULL,
+    support_ticket_id INTEGER REFERENCES support_tickets(id) ON DELETE SET NULL,
+    granted_by INTEGER REFERENCES people(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_credits_user_id ON account_credits(user_id);
+CREATE INDEX IF NOT EXISTS idx_account_credits_transaction_id ON account_credits(transaction_id) WHERE transaction_id IS NOT NULL;
+
+CREATE TRIGGER update_account_credits_updated_at BEFORE UPDATE ON account_credits FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN account_credits.amount IS 'Positive for credit granted, negative for credit redeemed against a payment';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Account credits table created successfully!';
+END $$;
@@ -0,0 +1,31 @@
+-- Migration: Self-serve account deletion
+-- Deletion requests hold the account deactivated for 14 days before erasure.
+-- The hold, win-back email and final purge are driven by AccountDeletionWorkflow.
+
+CREATE TABLE IF NOT EXISTS account_deletion_requests (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'reactivated', 'purged')),
+    reason TEXT,
+    requested_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    purge_after TIMESTAMP WITH TIME ZONE NOT NULL,
+    winback_sent_at TIMESTAMP WITH TIME ZONE,
+    reactivated_at TIMESTAMP WITH TIME ZONE,
+    purged_at TIMESTAMP WITH TIME ZONE,
+    temporal_workflow_id VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_deletion_requests_user_id ON account_deletion_requests(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_account_deletion_requests_pending ON account_deletion_requests(user_id) WHERE status = 'pending';
+
+CREATE TRIGGER update_account_deletion_requests_updated_at BEFORE UPDATE ON account_deletion_requests FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN account_deletion_requests.purge_after IS 'End of the deactivation hold; the account is erased after this time unless reactivated';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Account deletion requests table created successfully!';
+END $$;
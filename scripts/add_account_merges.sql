@@ -0,0 +1,48 @@
+-- Migration: Merging duplicate user accounts
+-- Support merges a duplicate account into the one the user keeps. Jobs, reviews,
+-- transactions, payment methods, notifications and account credit move to the
+-- surviving account and the duplicate is deactivated. Every merge, including dry runs,
+-- is recorded here.
+
+CREATE TABLE IF NOT EXISTS account_merges (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    admin_id INTEGER NOT NULL REFERENCES people(id),
+    source_user_id INTEGER NOT NULL REFERENCES people(id),
+    target_user_id INTEGER NOT NULL REFERENCES people(id),
+    source_email VARCHAR(255) NOT NULL,
+    target_email VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    dry_run BOOLEAN NOT NULL DEFAULT false,
+    moved JSONB NOT NULL DEFAULT '{}',
+    ip_address VARCHAR(64),
+    user_agent TEXT,
+    merged_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_merges_source ON account_merges(source_user_id, merged_at);
+CREATE INDEX IF NOT EXISTS idx_account_merges_target ON account_merges(target_user_id, merged_at);
+
+-- An account can only be merged away once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_account_merges_one_per_source ON account_merges(source_user_id) WHERE dry_run = false;
+
+-- The merge log is append-only
+CREATE OR REPLACE FUNCTION prevent_account_merge_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'account_merges is append-only';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER account_merges_append_only BEFORE UPDATE OR DELETE ON account_merges FOR EACH ROW EXECUTE FUNCTION prevent_account_merge_changes();
+
+COMMENT ON COLUMN account_merges.source_user_id IS 'Duplicate account merged away; it is deactivated but kept for the audit trail';
+COMMENT ON COLUMN account_merges.target_user_id IS 'Surviving account the records were moved to';
+COMMENT ON COLUMN account_merges.dry_run IS 'True when the merge was previewed and rolled back';
+COMMENT ON COLUMN account_merges.moved IS 'Rows moved (or that would move) per table.column, e.g. {"jobs.consumer_id": 3}';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Account merges table created successfully!';
+END $$;
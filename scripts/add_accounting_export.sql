@@ -0,0 +1,66 @@
+-- Migration: Accounting export (QuickBooks Online / Xero)
+-- Consumers connect their books via OAuth; captured GigCo payments are pushed as
+-- expenses using per-category account mappings. OAuth tokens are sealed with the
+-- vault key (VAULT_ENCRYPTION_KEY) before storage.
+
+CREATE TABLE IF NOT EXISTS accounting_connections (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    provider VARCHAR(20) NOT NULL CHECK (provider IN ('quickbooks', 'xero')),
+    tenant_id VARCHAR(255) NOT NULL,
+    sealed_token TEXT NOT NULL,
+    key_id VARCHAR(50) NOT NULL,
+    is_active BOOLEAN DEFAULT true,
+    connected_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    last_synced_at TIMESTAMP WITH TIME ZONE,
+    last_sync_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(user_id, provider)
+);
+
+CREATE TABLE IF NOT EXISTS accounting_oauth_states (
+    state VARCHAR(64) PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    provider VARCHAR(20) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS accounting_category_mappings (
+    id SERIAL PRIMARY KEY,
+    connection_id INTEGER NOT NULL REFERENCES accounting_connections(id) ON DELETE CASCADE,
+    job_category VARCHAR(100) NOT NULL,
+    account_ref VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(connection_id, job_category)
+);
+
+CREATE TABLE IF NOT EXISTS accounting_sync_log (
+    id SERIAL PRIMARY KEY,
+    connection_id INTEGER NOT NULL REFERENCES accounting_connections(id) ON DELETE CASCADE,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    external_id VARCHAR(255),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('synced', 'failed')),
+    error_message TEXT,
+    synced_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(connection_id, transaction_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_accounting_oauth_states_expires ON accounting_oauth_states(expires_at);
+CREATE INDEX IF NOT EXISTS idx_accounting_sync_log_transaction ON accounting_sync_log(transaction_id);
+
+CREATE TRIGGER update_accounting_connections_updated_at BEFORE UPDATE ON accounting_connections FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_accounting_category_mappings_updated_at BEFORE UPDATE ON accounting_category_mappings FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_accounting_sync_log_updated_at BEFORE UPDATE ON accounting_sync_log FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN accounting_category_mappings.account_ref IS 'QuickBooks account name/ID or Xero account code for this job category';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Accounting export tables created successfully!';
+END $$;
@@ -0,0 +1,90 @@
+-- Migration: Admin overview materialized views
+-- Daily rollups behind GET /api/v1/admin/overview. The ops monitor workflow refreshes
+-- them hourly with refresh_admin_overview(); days are UTC.
+
+-- Jobs posted each day and how many of them found a worker; cancelled jobs are left out
+CREATE MATERIALIZED VIEW IF NOT EXISTS admin_daily_jobs AS
+SELECT
+    (created_at AT TIME ZONE 'UTC')::date AS day,
+    COUNT(*) AS jobs_posted,
+    COUNT(*) FILTER (WHERE gig_worker_id IS NOT NULL) AS jobs_filled
+FROM jobs
+WHERE status <> 'cancelled'
+GROUP BY 1;
+
+-- Payment attempts and failures by the day they were made, and captures by the day
+-- they were captured
+CREATE MATERIALIZED VIEW IF NOT EXISTS admin_daily_payments AS
+WITH attempts AS (
+    SELECT
+        (created_at AT TIME ZONE 'UTC')::date AS day,
+        COUNT(*) AS attempts,
+        COUNT(*) FILTER (WHERE status = 'failed') AS failed
+    FROM transactions
+    GROUP BY 1
+), captures AS (
+    SELECT
+        (captured_at AT TIME ZONE 'UTC')::date AS day,
+        COUNT(*) AS captured,
+        SUM(COALESCE(capture_amount, amount)) AS gmv,
+        COALESCE(SUM(platform_fee), 0) AS platform_fees,
+        COALESCE(SUM(refund_amount), 0) AS refunds
+    FROM transactions
+    WHERE captured_at IS NOT NULL
+    GROUP BY 1
+)
+SELECT
+    COALESCE(a.day, c.day) AS day,
+    COALESCE(a.attempts, 0) AS attempts,
+    COALESCE(a.failed, 0) AS failed,
+    COALESCE(c.captured, 0) AS captured,
+    COALESCE(c.gmv, 0) AS gmv,
+    COALESCE(c.platform_fees, 0) AS platform_fees,
+    COALESCE(c.refunds, 0) AS refunds
+FROM attempts a
+FULL OUTER JOIN captures c ON c.day = a.day;
+
+-- Accounts created each day by role
+CREATE MATERIALIZED VIEW IF NOT EXISTS admin_daily_signups AS
+SELECT
+    (created_at AT TIME ZONE 'UTC')::date AS day,
+    role::text AS role,
+    COUNT(*) AS signups
+FROM people
+GROUP BY 1, 2;
+
+-- Unique indexes let the views refresh concurrently without blocking the dashboard
+CREATE UNIQUE INDEX IF NOT EXISTS idx_admin_daily_jobs_day ON admin_daily_jobs(day);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_admin_daily_payments_day ON admin_daily_payments(day);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_admin_daily_signups_day_role ON admin_daily_signups(day, role);
+
+-- When each view was last refreshed
+CREATE TABLE IF NOT EXISTS materialized_view_refreshes (
+    view_name VARCHAR(100) PRIMARY KEY,
+    refreshed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO materialized_view_refreshes (view_name)
+VALUES ('admin_daily_jobs'), ('admin_daily_payments'), ('admin_daily_signups')
+ON CONFLICT (view_name) DO NOTHING;
+
+CREATE OR REPLACE FUNCTION refresh_admin_overview() RETURNS VOID AS $$
+BEGIN
+    REFRESH MATERIALIZED VIEW CONCURRENTLY admin_daily_jobs;
+    REFRESH MATERIALIZED VIEW CONCURRENTLY admin_daily_payments;
+    REFRESH MATERIALIZED VIEW CONCURRENTLY admin_daily_signups;
+
+    UPDATE materialized_view_refreshes SET refreshed_at = NOW()
+    WHERE view_name IN ('admin_daily_jobs', 'admin_daily_payments', 'admin_daily_signups');
+END;
+$$ LANGUAGE plpgsql;
+
+COMMENT ON MATERIALIZED VIEW admin_daily_jobs IS 'Jobs posted per UTC day and how many were filled; refreshed by refresh_admin_overview()';
+COMMENT ON MATERIALIZED VIEW admin_daily_payments IS 'Payment attempts and failures by creation day, captures by capture day; refreshed by refresh_admin_overview()';
+COMMENT ON MATERIALIZED VIEW admin_daily_signups IS 'New accounts per UTC day and role; refreshed by refresh_admin_overview()';
+COMMENT ON FUNCTION refresh_admin_overview() IS 'Refreshes the admin overview views; run hourly by the ops monitor workflow';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Admin overview materialized views created successfully!';
+END $$;
@@ -0,0 +1,39 @@
+-- Migration: Attachment records with malware scan status
+-- One row per uploaded file (expense receipts, and later verification documents and
+-- photos). Files are scanned before they are stored when STORAGE_SCAN_BACKEND is set;
+-- infected files are moved under quarantine/ in the object store and only clean or
+-- skipped (scanning turned off) attachments are ever served.
+
+CREATE TABLE IF NOT EXISTS attachments (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    owner_type VARCHAR(30) NOT NULL,
+    owner_id INTEGER NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('photo', 'document', 'receipt')),
+    object_key TEXT NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    uploaded_by INTEGER REFERENCES people(id) ON DELETE SET NULL,
+
+    -- Malware scan
+    scan_status VARCHAR(20) NOT NULL CHECK (scan_status IN ('clean', 'infected', 'failed', 'skipped')),
+    scanner VARCHAR(20),
+    scan_threat TEXT,
+    scanned_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_owner ON attachments(owner_type, owner_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_needs_scan ON attachments(created_at) WHERE scan_status = 'failed';
+
+CREATE TRIGGER update_attachments_updated_at BEFORE UPDATE ON attachments FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN attachments.scan_status IS 'clean/skipped = servable; infected = quarantined; failed = scanner unavailable, rescan before serving';
+COMMENT ON COLUMN attachments.object_key IS 'Key in the attachment store; infected files live under quarantine/';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Attachments table created successfully!';
+END $$;
@@ -0,0 +1,46 @@
+-- Migration: Audit log of state-changing operations
+-- One row per job status change, payment authorization, capture and refund, profile
+-- edit and admin request: who did it, through which route, from which address, and the
+-- fields that changed. Rows are never updated (see internal/audit).
+
+CREATE TABLE IF NOT EXISTS audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    actor_id INTEGER REFERENCES people(id),
+    actor_role VARCHAR(20),
+    action VARCHAR(50) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id VARCHAR(64),
+    method VARCHAR(10),
+    route VARCHAR(255),
+    status_code INTEGER,
+    ip_address VARCHAR(64),
+    changes JSONB NOT NULL DEFAULT '{}',
+    metadata JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_entity ON audit_events(entity_type, entity_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor ON audit_events(actor_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_action ON audit_events(action, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_created ON audit_events(created_at);
+
+CREATE OR REPLACE FUNCTION prevent_audit_event_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+CREATE TRIGGER audit_events_append_only BEFORE UPDATE ON audit_events FOR EACH ROW EXECUTE FUNCTION prevent_audit_event_update();
+
+COMMENT ON TABLE audit_events IS 'Append-only audit log of state-changing operations';
+COMMENT ON COLUMN audit_events.action IS 'job.status_changed, payment.authorized/captured/refunded, profile.updated or admin.request';
+COMMENT ON COLUMN audit_events.route IS 'Route pattern the request matched, e.g. /api/v1/jobs/{id}/start';
+COMMENT ON COLUMN audit_events.changes IS 'Changed fields as {"field": {"from": ..., "to": ...}}; sensitive values are redacted';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Audit events table created successfully!';
+END $$;
@@ -0,0 +1,35 @@
+-- Migration: Signed deep links
+-- Links in emails and pushes are signed, expiring tokens naming a deep_links row. The
+-- row counts clicks on GET /l/{token} and, for sensitive actions (accepting an offer,
+-- resetting a password), records when the link was used so it works only once.
+
+CREATE TABLE IF NOT EXISTS deep_links (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    resource_id INTEGER NOT NULL,
+    single_use BOOLEAN NOT NULL DEFAULT false,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    click_count INTEGER NOT NULL DEFAULT 0,
+    first_clicked_at TIMESTAMP WITH TIME ZONE,
+    last_clicked_at TIMESTAMP WITH TIME ZONE,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_deep_links_action ON deep_links(action, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_deep_links_user ON deep_links(user_id, created_at DESC);
+
+CREATE TRIGGER update_deep_links_updated_at BEFORE UPDATE ON deep_links FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN deep_links.action IS 'accept_offer, review_job or reset_password';
+COMMENT ON COLUMN deep_links.resource_id IS 'The job for accept_offer and review_job; the password_reset_tokens row for reset_password';
+COMMENT ON COLUMN deep_links.click_count IS 'Follows of the link, including mail scanners prefetching it; clicking never uses a link';
+COMMENT ON COLUMN deep_links.used_at IS 'When a single-use link performed its action; NULL while unused';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Deep link table created successfully!';
+END $$;
@@ -0,0 +1,40 @@
+-- Migration: Job payment disputes
+-- A consumer can dispute a completed job. While a dispute is open or under review the
+-- job workflow is held before capturing payment and payout settlement skips the job.
+-- Support resolves the dispute, optionally refunding the payment. Worker payout
+-- settlement (add_worker_payouts.sql) reads this table, so run both.
+
+CREATE TABLE IF NOT EXISTS job_disputes (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    opened_by INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    reason VARCHAR(50) NOT NULL,
+    description TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'under_review', 'resolved', 'refunded')),
+    temporal_workflow_id VARCHAR(255),
+    assigned_to INTEGER REFERENCES people(id),
+    resolved_by INTEGER REFERENCES people(id),
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    resolution_notes TEXT,
+    refund_transaction_id INTEGER REFERENCES transactions(id),
+    refund_amount DECIMAL(10, 2),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_disputes_job_id ON job_disputes(job_id);
+CREATE INDEX IF NOT EXISTS idx_job_disputes_open ON job_disputes(status, created_at) WHERE status IN ('open', 'under_review');
+
+-- One unresolved dispute per job
+CREATE UNIQUE INDEX IF NOT EXISTS idx_job_disputes_one_open_per_job ON job_disputes(job_id) WHERE status IN ('open', 'under_review');
+
+CREATE TRIGGER update_job_disputes_updated_at BEFORE UPDATE ON job_disputes FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN job_disputes.temporal_workflow_id IS 'DisputeWorkflow holding the job; NULL if it could not be started';
+COMMENT ON COLUMN job_disputes.refund_transaction_id IS 'Refund transaction created when the dispute was resolved with a refund';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Job disputes table created successfully!';
+END $$;
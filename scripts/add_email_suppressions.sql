@@ -0,0 +1,31 @@
+-- Migration: Email suppression list
+-- Addresses that hard bounce or report an email as spam are added from the SendGrid
+-- event webhook and SES bounce and complaint notifications (via SNS), and are skipped
+-- by every later send until an admin removes them. Requires
+-- scripts/add_notification_deliveries.sql.
+
+CREATE TABLE IF NOT EXISTS email_suppressions (
+    id SERIAL PRIMARY KEY,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    reason VARCHAR(20) NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    detail TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+ALTER TABLE email_suppressions DROP CONSTRAINT IF EXISTS email_suppressions_reason_check;
+ALTER TABLE email_suppressions ADD CONSTRAINT email_suppressions_reason_check
+    CHECK (reason IN ('bounce', 'complaint'));
+
+DROP TRIGGER IF EXISTS update_email_suppressions_updated_at ON email_suppressions;
+CREATE TRIGGER update_email_suppressions_updated_at BEFORE UPDATE ON email_suppressions FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN email_suppressions.email IS 'Lowercased address';
+COMMENT ON COLUMN email_suppressions.reason IS 'bounce: a permanent bounce; complaint: the recipient marked an email as spam';
+COMMENT ON COLUMN email_suppressions.provider IS 'The email provider that reported it: sendgrid or ses';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Email suppression list created successfully!';
+END $$;
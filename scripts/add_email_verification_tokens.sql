@@ -0,0 +1,22 @@
+-- Migration: Email verification tokens
+-- Registration emails a single-use token that expires after 24 hours; only its SHA-256
+-- hash is stored. Resending supersedes earlier tokens.
+
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_user_id ON email_verification_tokens(user_id) WHERE used_at IS NULL;
+
+COMMENT ON COLUMN email_verification_tokens.token_hash IS 'Hex SHA-256 of the emailed token; the token itself is never stored';
+COMMENT ON COLUMN email_verification_tokens.used_at IS 'Set when the token verifies the email or is superseded by a resend';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Email verification tokens table created successfully!';
+END $$;
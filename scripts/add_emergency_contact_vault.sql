@@ -0,0 +1,54 @@
+-- Migration: Move gig worker emergency contacts into encrypted storage
+-- Contacts are sealed with AES-256-GCM by the API (VAULT_ENCRYPTION_KEY) and can
+-- only be read through the audited break-glass admin endpoint.
+--
+-- After applying this migration, run the backfill to encrypt existing contacts:
+--   VAULT_ENCRYPTION_KEY=... go run ./cmd/backfill_emergency_contacts
+-- The backfill clears the plaintext columns on gigworkers once each row is sealed.
+
+CREATE TABLE IF NOT EXISTS gigworker_emergency_contacts (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    gigworker_id INTEGER UNIQUE NOT NULL REFERENCES gigworkers(id) ON DELETE CASCADE,
+    sealed_contact TEXT NOT NULL,
+    key_id VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS break_glass_access_log (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    admin_id INTEGER NOT NULL REFERENCES people(id),
+    gigworker_id INTEGER NOT NULL REFERENCES gigworkers(id) ON DELETE CASCADE,
+    incident_id INTEGER NOT NULL REFERENCES safety_incidents(id),
+    reason TEXT NOT NULL,
+    ip_address VARCHAR(64),
+    user_agent TEXT,
+    accessed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_break_glass_access_log_gigworker ON break_glass_access_log(gigworker_id, accessed_at);
+CREATE INDEX IF NOT EXISTS idx_break_glass_access_log_admin ON break_glass_access_log(admin_id, accessed_at);
+
+CREATE TRIGGER update_gigworker_emergency_contacts_updated_at BEFORE UPDATE ON gigworker_emergency_contacts FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- The access log is append-only
+CREATE OR REPLACE FUNCTION prevent_break_glass_log_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'break_glass_access_log is append-only';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER break_glass_access_log_append_only BEFORE UPDATE OR DELETE ON break_glass_access_log FOR EACH ROW EXECUTE FUNCTION prevent_break_glass_log_changes();
+
+COMMENT ON COLUMN gigworker_emergency_contacts.sealed_contact IS 'AES-256-GCM sealed JSON contact, format <key_id>:<base64(nonce|ciphertext)>';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Emergency contact vault tables created successfully!';
+    RAISE NOTICE 'Run cmd/backfill_emergency_contacts to encrypt existing contacts';
+END $$;
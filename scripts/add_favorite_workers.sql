@@ -0,0 +1,51 @@
+-- Migration: Favorite workers and auto-accept
+-- Consumers favorite workers they want to book again and can rebook one by posting a
+-- job with preferred_worker_id. A worker who opts into auto-accept takes such jobs
+-- without the offer wait when they are free and the price meets their floor. Either
+-- side can turn auto-accept off for one pair.
+
+CREATE TABLE IF NOT EXISTS favorite_workers (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    consumer_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    worker_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    consumer_auto_accept BOOLEAN NOT NULL DEFAULT true,
+    worker_auto_accept BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (consumer_id, worker_id),
+    CHECK (consumer_id <> worker_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_favorite_workers_worker ON favorite_workers(worker_id);
+
+CREATE TRIGGER update_favorite_workers_updated_at BEFORE UPDATE ON favorite_workers FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_name = 'worker_profiles' AND column_name = 'auto_accept_enabled') THEN
+        ALTER TABLE worker_profiles ADD COLUMN auto_accept_enabled BOOLEAN NOT NULL DEFAULT false;
+    END IF;
+
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_name = 'worker_profiles' AND column_name = 'auto_accept_min_price') THEN
+        ALTER TABLE worker_profiles ADD COLUMN auto_accept_min_price DECIMAL(10, 2) CHECK (auto_accept_min_price IS NULL OR auto_accept_min_price > 0);
+    END IF;
+
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_name = 'jobs' AND column_name = 'preferred_worker_id') THEN
+        ALTER TABLE jobs ADD COLUMN preferred_worker_id INTEGER REFERENCES people(id) ON DELETE SET NULL;
+    END IF;
+END $$;
+
+COMMENT ON COLUMN favorite_workers.consumer_auto_accept IS 'False when the consumer wants rebookings with this worker to go through the offer as usual';
+COMMENT ON COLUMN favorite_workers.worker_auto_accept IS 'False when the worker opted out of auto-accepting this consumer''s rebookings';
+COMMENT ON COLUMN worker_profiles.auto_accept_enabled IS 'Worker takes rebookings from consumers who favorited them without the offer wait';
+COMMENT ON COLUMN worker_profiles.auto_accept_min_price IS 'Lowest job price the worker auto-accepts; null accepts any price';
+COMMENT ON COLUMN jobs.preferred_worker_id IS 'Favorited worker the consumer rebooked; offered the job first through auto-accept';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Favorite workers and auto-accept added successfully!';
+END $$;
@@ -0,0 +1,46 @@
+-- Migration: Platform fee rules
+-- Admin-managed overrides of the platform fee (PLATFORM_FEE_PERCENT) for a job
+-- category, a worker fee tier, a promotional window, or any combination. Card
+-- processing fees are the provider's and are not affected. The matching rule with the
+-- highest priority wins; see payment.SelectFeeRule.
+
+CREATE TABLE IF NOT EXISTS fee_rules (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+
+    -- Conditions; NULL matches any job
+    category VARCHAR(100),
+    worker_tier VARCHAR(50),
+    starts_at TIMESTAMP WITH TIME ZONE,
+    ends_at TIMESTAMP WITH TIME ZONE,
+
+    -- Platform fee charged when the rule applies
+    platform_fee_percent DECIMAL(5, 2) NOT NULL DEFAULT 0 CHECK (platform_fee_percent >= 0 AND platform_fee_percent <= 100),
+    platform_fee_fixed DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (platform_fee_fixed >= 0),
+
+    priority INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_by INTEGER REFERENCES people(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CHECK (ends_at IS NULL OR starts_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fee_rules_active ON fee_rules(priority DESC) WHERE is_active;
+
+CREATE TRIGGER update_fee_rules_updated_at BEFORE UPDATE ON fee_rules FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Workers' fee tier, set by admins; NULL is the standard tier
+ALTER TABLE worker_profiles
+ADD COLUMN IF NOT EXISTS fee_tier VARCHAR(50);
+
+COMMENT ON COLUMN fee_rules.worker_tier IS 'Matches worker_profiles.fee_tier of the job''s assigned worker when the job is priced';
+COMMENT ON COLUMN fee_rules.priority IS 'Highest priority matching rule wins; ties go to the rule with more conditions, then the newest';
+COMMENT ON COLUMN worker_profiles.fee_tier IS 'Fee tier matched by fee_rules.worker_tier; NULL for the standard fee';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Fee rules table created successfully!';
+END $$;
@@ -0,0 +1,25 @@
+-- Migration: Job posting completeness
+-- Access instructions for workers and the completeness score computed when a job is
+-- posted or edited (see internal/jobquality). Jobs posted before this migration have
+-- no score and are ranked as if complete.
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_name = 'jobs' AND column_name = 'access_instructions') THEN
+        ALTER TABLE jobs ADD COLUMN access_instructions TEXT;
+    END IF;
+
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_name = 'jobs' AND column_name = 'completeness_score') THEN
+        ALTER TABLE jobs ADD COLUMN completeness_score SMALLINT CHECK (completeness_score BETWEEN 0 AND 100);
+    END IF;
+END $$;
+
+COMMENT ON COLUMN jobs.access_instructions IS 'How the worker gets in (codes, parking, keys); only shown to the consumer, the assigned worker and admins';
+COMMENT ON COLUMN jobs.completeness_score IS '0-100 share of the posting checklist met; postings below 60 are ranked after the rest';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Job completeness columns added successfully!';
+END $$;
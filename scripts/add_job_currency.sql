@@ -0,0 +1,18 @@
+-- Migration: Job currency
+-- Jobs are priced, authorized, captured and refunded in one currency from the registry
+-- in internal/currency. Existing jobs and transactions without a currency are USD.
+
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_currency_check;
+ALTER TABLE jobs ADD CONSTRAINT jobs_currency_check CHECK (currency ~ '^[A-Z]{3}$');
+
+UPDATE transactions SET currency = 'USD' WHERE currency IS NULL;
+
+COMMENT ON COLUMN jobs.currency IS 'ISO 4217 code the job is priced and paid in; captures and refunds must use the same currency';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Job currency column added successfully!';
+END $$;
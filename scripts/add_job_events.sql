@@ -0,0 +1,51 @@
+-- Migration: Job lifecycle event store
+-- job_events is the append-only source of truth for job lifecycle transitions. The
+-- status, gig_worker_id, actual_start and workflow_completed_at columns on jobs are a
+-- projection of it, written in the same transaction as each event and rebuildable with
+-- cmd/replay_job_events. Existing jobs are imported as a single snapshot event.
+
+CREATE TABLE IF NOT EXISTS job_events (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    sequence INTEGER NOT NULL CHECK (sequence > 0),
+    event_type VARCHAR(50) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    gig_worker_id INTEGER REFERENCES people(id),
+    source VARCHAR(20) NOT NULL,
+    actor_id INTEGER REFERENCES people(id),
+    data JSONB,
+    occurred_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (job_id, sequence)
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_events_type ON job_events(event_type, occurred_at);
+
+-- Events are never rewritten; deleting a job still cascades to its history
+CREATE OR REPLACE FUNCTION prevent_job_event_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'job_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS job_events_append_only ON job_events;
+CREATE TRIGGER job_events_append_only BEFORE UPDATE ON job_events FOR EACH ROW EXECUTE FUNCTION prevent_job_event_update();
+
+-- Import existing jobs so replay starts from their current state
+INSERT INTO job_events (job_id, sequence, event_type, status, gig_worker_id, source, data, occurred_at)
+SELECT j.id, 1, 'imported', COALESCE(j.status::text, 'posted'), j.gig_worker_id, 'migration',
+       jsonb_strip_nulls(jsonb_build_object('actual_start', j.actual_start, 'workflow_completed_at', j.workflow_completed_at)),
+       COALESCE(j.updated_at, NOW())
+FROM jobs j
+WHERE NOT EXISTS (SELECT 1 FROM job_events e WHERE e.job_id = j.id);
+
+COMMENT ON TABLE job_events IS 'Append-only job lifecycle history; jobs lifecycle columns are projected from it';
+COMMENT ON COLUMN job_events.status IS 'Job status after the event';
+COMMENT ON COLUMN job_events.source IS 'api, workflow or migration';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Job events table created successfully!';
+END $$;
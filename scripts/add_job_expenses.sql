@@ -0,0 +1,50 @@
+-- Migration: Worker mileage and expense tracking
+-- Per-job expenses logged by gig workers. Reimbursable expenses need consumer
+-- approval and are added to the job's payment capture as an expense_reimbursement split.
+
+ALTER TYPE payment_split_type ADD VALUE IF NOT EXISTS 'expense_reimbursement';
+
+CREATE TABLE IF NOT EXISTS job_expenses (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    gig_worker_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    expense_type VARCHAR(20) NOT NULL CHECK (expense_type IN ('mileage', 'materials', 'other')),
+    description TEXT NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount >= 0),
+
+    -- Mileage
+    miles DECIMAL(8, 2),
+    rate_per_mile DECIMAL(6, 3),
+    origin_latitude DECIMAL(10, 8),
+    origin_longitude DECIMAL(11, 8),
+    distance_method VARCHAR(20),
+
+    receipt_url TEXT,
+    incurred_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    -- Reimbursement
+    reimbursable BOOLEAN NOT NULL DEFAULT false,
+    status VARCHAR(20) NOT NULL DEFAULT 'logged' CHECK (status IN ('logged', 'pending', 'approved', 'rejected')),
+    reviewed_by INTEGER REFERENCES people(id),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_note TEXT,
+    transaction_id INTEGER REFERENCES transactions(id) ON DELETE SET NULL,
+    billed_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_expenses_job_id ON job_expenses(job_id);
+CREATE INDEX IF NOT EXISTS idx_job_expenses_worker_incurred ON job_expenses(gig_worker_id, incurred_at);
+CREATE INDEX IF NOT EXISTS idx_job_expenses_unbilled ON job_expenses(job_id) WHERE status = 'approved' AND billed_at IS NULL;
+
+CREATE TRIGGER update_job_expenses_updated_at BEFORE UPDATE ON job_expenses FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN job_expenses.status IS 'logged = tax record only; pending/approved/rejected = reimbursement requested from the consumer';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Job expenses table created successfully!';
+END $$;
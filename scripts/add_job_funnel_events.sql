@@ -0,0 +1,49 @@
+-- Migration: Job funnel events
+-- One row per job per lifecycle stage (posted, priced, offer_sent, accepted, scheduled,
+-- started, completed, paid, reviewed, closed), emitted by the API handlers and the job
+-- workflow. GET /api/v1/analytics/funnel and time-in-stage dashboards read it.
+
+CREATE TABLE IF NOT EXISTS job_funnel_events (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    stage VARCHAR(20) NOT NULL CHECK (stage IN ('posted', 'priced', 'offer_sent', 'accepted', 'scheduled', 'started', 'completed', 'paid', 'reviewed', 'closed')),
+    stage_order INTEGER NOT NULL,
+    source VARCHAR(20) NOT NULL CHECK (source IN ('api', 'workflow')),
+    actor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    properties JSONB,
+    previous_stage VARCHAR(20),
+    seconds_in_previous_stage NUMERIC(14, 3),
+    seconds_since_posted NUMERIC(14, 3),
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (job_id, stage)
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_funnel_events_stage_occurred ON job_funnel_events(stage, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_job_funnel_events_job_occurred ON job_funnel_events(job_id, occurred_at);
+
+CREATE TRIGGER update_job_funnel_events_updated_at BEFORE UPDATE ON job_funnel_events FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Time-in-stage by day, for dashboards
+CREATE OR REPLACE VIEW job_funnel_stage_durations AS
+SELECT
+    date_trunc('day', occurred_at) AS day,
+    stage,
+    stage_order,
+    COUNT(*) AS jobs,
+    percentile_cont(0.5) WITHIN GROUP (ORDER BY seconds_in_previous_stage) AS median_seconds_in_previous_stage,
+    percentile_cont(0.9) WITHIN GROUP (ORDER BY seconds_in_previous_stage) AS p90_seconds_in_previous_stage,
+    percentile_cont(0.5) WITHIN GROUP (ORDER BY seconds_since_posted) AS median_seconds_since_posted
+FROM job_funnel_events
+GROUP BY 1, stage, stage_order;
+
+COMMENT ON COLUMN job_funnel_events.seconds_in_previous_stage IS 'Time since the job''s previous funnel event; null for its first event';
+COMMENT ON COLUMN job_funnel_events.seconds_since_posted IS 'Time since the job was posted';
+COMMENT ON COLUMN job_funnel_events.actor_id IS 'User whose action moved the job; null for workflow events';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Job funnel events table created successfully!';
+END $$;
@@ -0,0 +1,38 @@
+-- Migration: Job hand-offs between workers
+-- An assigned worker who can't finish a job (e.g. falls sick) asks to be released and
+-- says how much of it they completed. Once the consumer approves, the job workflow pays
+-- the outgoing worker for that portion, releases them and offers the rest of the job to
+-- other workers with a shorter offer window.
+
+CREATE TABLE IF NOT EXISTS job_handoffs (
+    id SERIAL PRIMARY KEY,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    from_worker_id INTEGER NOT NULL REFERENCES people(id),
+    to_worker_id INTEGER REFERENCES people(id),
+    reason TEXT NOT NULL,
+    completed_percent INTEGER NOT NULL CHECK (completed_percent BETWEEN 0 AND 99),
+    status VARCHAR(20) NOT NULL DEFAULT 'requested',
+    paid_amount DECIMAL(10,2),
+    transaction_id INTEGER REFERENCES transactions(id),
+    responded_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+ALTER TABLE job_handoffs DROP CONSTRAINT IF EXISTS job_handoffs_status_check;
+ALTER TABLE job_handoffs ADD CONSTRAINT job_handoffs_status_check
+    CHECK (status IN ('requested', 'approved', 'declined', 'completed', 'unfilled', 'failed'));
+
+-- One open hand-off per job
+CREATE UNIQUE INDEX IF NOT EXISTS idx_job_handoffs_open ON job_handoffs(job_id)
+    WHERE status IN ('requested', 'approved');
+CREATE INDEX IF NOT EXISTS idx_job_handoffs_job_id ON job_handoffs(job_id);
+
+COMMENT ON COLUMN job_handoffs.completed_percent IS 'Portion of the job the outgoing worker finished; the consumer may correct it when approving';
+COMMENT ON COLUMN job_handoffs.paid_amount IS 'What the outgoing worker was paid for their portion; the final payment for the job is reduced by it';
+COMMENT ON COLUMN job_handoffs.transaction_id IS 'Transaction paying the outgoing worker; refunded if the hand-off fails';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Job hand-offs table created successfully!';
+END $$;
@@ -0,0 +1,36 @@
+-- Migration: Job offers to workers
+-- The job workflow offers an accepted job to its top matched workers at once. The first
+-- to accept gets the job; the other offers are cancelled in the same transaction.
+-- Offers nobody answers expire when the workflow's offer window ends, and the next round
+-- goes to workers not offered the job yet.
+
+CREATE TABLE IF NOT EXISTS job_offers (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    worker_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled', 'expired')),
+    round INTEGER NOT NULL DEFAULT 1,
+    rank INTEGER NOT NULL,
+    amount DECIMAL(10, 2),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    responded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (job_id, worker_id)
+);
+
+-- At most one worker wins each job
+CREATE UNIQUE INDEX IF NOT EXISTS idx_job_offers_one_accepted ON job_offers(job_id) WHERE status = 'accepted';
+CREATE INDEX IF NOT EXISTS idx_job_offers_worker ON job_offers(worker_id, status);
+
+CREATE TRIGGER update_job_offers_updated_at BEFORE UPDATE ON job_offers FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN job_offers.status IS 'pending until answered; cancelled when another worker accepted first or the job moved on; expired when the offer window ended';
+COMMENT ON COLUMN job_offers.round IS 'Workflow offer round; each round goes to workers not offered the job before';
+COMMENT ON COLUMN job_offers.rank IS 'Position in the matching engine''s ranking for the round, 1 being its first pick';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Job offers table created successfully!';
+END $$;
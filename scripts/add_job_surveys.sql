@@ -0,0 +1,37 @@
+-- Migration: Post-job satisfaction surveys
+-- When the job workflow closes a job it asks the consumer one CSAT (1-5) or NPS (0-10)
+-- question through an in-app notification. One survey per job, linked to the job's
+-- worker; GET /api/v1/analytics/surveys aggregates the answers by category and market.
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'survey_request';
+
+CREATE TABLE IF NOT EXISTS job_surveys (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    job_id INTEGER NOT NULL UNIQUE REFERENCES jobs(id) ON DELETE CASCADE,
+    consumer_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    gig_worker_id INTEGER REFERENCES people(id) ON DELETE SET NULL,
+    survey_type VARCHAR(10) NOT NULL CHECK (survey_type IN ('csat', 'nps')),
+    score INTEGER,
+    comment TEXT,
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    responded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (score IS NULL OR (survey_type = 'csat' AND score BETWEEN 1 AND 5) OR (survey_type = 'nps' AND score BETWEEN 0 AND 10)),
+    CHECK ((score IS NULL) = (responded_at IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_surveys_consumer ON job_surveys(consumer_id);
+CREATE INDEX IF NOT EXISTS idx_job_surveys_worker ON job_surveys(gig_worker_id) WHERE gig_worker_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_job_surveys_sent ON job_surveys(sent_at);
+
+CREATE TRIGGER update_job_surveys_updated_at BEFORE UPDATE ON job_surveys FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN job_surveys.survey_type IS 'csat: satisfaction with the job, 1-5; nps: likelihood to recommend GigCo, 0-10. Set by JOB_SURVEY when sent';
+COMMENT ON COLUMN job_surveys.score IS 'The consumer''s answer; null until they respond. Surveys are optional and most are never answered';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Job surveys table created successfully!';
+END $$;
@@ -0,0 +1,56 @@
+-- Migration: Tips at job completion
+-- A tip is a separate captured charge, stored as an 'adjustment' transaction whose
+-- parent is the job's authorization, with a 'tip' payment split paid to the worker in
+-- full. Payouts settle tips with the worker's other earnings.
+-- Requires clover_payment_schema.sql and add_stripe_provider.sql.
+
+-- One tip per job; a refunded tip frees the job to be tipped again
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_one_tip
+    ON transactions(job_id)
+    WHERE transaction_type = 'adjustment' AND metadata->>'type' = 'tip' AND refunded_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_payment_splits_tips ON payment_splits(transaction_id) WHERE split_type = 'tip';
+
+-- The summary gains a column, which CREATE OR REPLACE cannot add
+DROP FUNCTION IF EXISTS get_job_payment_summary(INTEGER);
+
+CREATE FUNCTION get_job_payment_summary(job_id_param INTEGER)
+RETURNS TABLE(
+    total_authorized DECIMAL,
+    total_captured DECIMAL,
+    total_refunded DECIMAL,
+    platform_fees DECIMAL,
+    worker_payment DECIMAL,
+    escrow_status TEXT,
+    total_tips DECIMAL
+) AS $$
+DECLARE
+    tips DECIMAL;
+BEGIN
+    SELECT COALESCE(SUM(ps.amount), 0) INTO tips
+    FROM payment_splits ps
+    JOIN transactions t ON t.id = ps.transaction_id
+    WHERE t.job_id = job_id_param AND ps.split_type = 'tip' AND t.refunded_at IS NULL;
+
+    RETURN QUERY
+    SELECT
+        COALESCE(SUM(CASE WHEN t.transaction_type = 'authorization' THEN t.amount ELSE 0 END), 0) as total_authorized,
+        COALESCE(SUM(CASE WHEN t.transaction_type IN ('capture', 'charge') THEN t.capture_amount ELSE 0 END), 0) as total_captured,
+        COALESCE(SUM(CASE WHEN t.transaction_type = 'refund' THEN t.refund_amount ELSE 0 END), 0) as total_refunded,
+        COALESCE(SUM(t.platform_fee), 0) as platform_fees,
+        COALESCE(SUM(t.net_amount), 0) + tips as worker_payment,
+        CASE
+            WHEN MAX(t.escrow_held_at) IS NOT NULL AND MAX(t.escrow_released_at) IS NULL THEN 'held'
+            WHEN MAX(t.escrow_released_at) IS NOT NULL THEN 'released'
+            ELSE 'none'
+        END as escrow_status,
+        tips as total_tips
+    FROM transactions t
+    WHERE t.job_id = job_id_param;
+END;
+$$ LANGUAGE plpgsql;
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Job tips index and payment summary created successfully!';
+END $$;
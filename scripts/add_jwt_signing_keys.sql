@@ -0,0 +1,23 @@
+-- Migration: JWT signing key rotation
+-- Access tokens carry the kid of the key that signed them. A rotated key validates from
+-- creation, signs once signing_from passes, and replaced keys keep validating until
+-- retires_at so sessions survive rotation. Secrets are sealed with the vault
+-- (VAULT_ENCRYPTION_KEY); the 'env' row stands for JWT_SECRET and has no stored secret.
+
+CREATE TABLE IF NOT EXISTS jwt_signing_keys (
+    id SERIAL PRIMARY KEY,
+    kid VARCHAR(32) UNIQUE NOT NULL,
+    sealed_secret TEXT,
+    signing_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    retires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    CHECK (kid = 'env' OR sealed_secret IS NOT NULL)
+);
+
+COMMENT ON COLUMN jwt_signing_keys.signing_from IS 'New tokens are signed with the newest key past this time, giving every instance time to load it';
+COMMENT ON COLUMN jwt_signing_keys.retires_at IS 'Tokens signed with the key are rejected after this time';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'JWT signing keys table created successfully!';
+END $$;
@@ -0,0 +1,19 @@
+-- Migration: KPI anomaly alerts
+-- The ops monitor workflow compares the last 24 hours of job creation, payment failure
+-- rate and offer acceptance rate with their trailing daily baselines and routes
+-- deviations as kpi_anomaly events. Widens the ops event log's event types to match
+-- the event router, which also routes payment invariant violations.
+
+ALTER TABLE ops_event_log DROP CONSTRAINT IF EXISTS ops_event_log_event_type_check;
+ALTER TABLE ops_event_log ADD CONSTRAINT ops_event_log_event_type_check
+    CHECK (event_type IN ('payment_reconciliation', 'workflow_dead_letter', 'fraud_flag', 'fill_rate_drop', 'payment_invariant', 'kpi_anomaly'));
+
+-- The baselines scan transactions and offer responses by time
+CREATE INDEX IF NOT EXISTS idx_transactions_created_at ON transactions(created_at);
+
+COMMENT ON COLUMN ops_event_log.event_type IS 'Routed event type; OPS_WEBHOOK_<EVENT_TYPE> sends each type to its own channel';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'KPI anomaly alert event type added successfully!';
+END $$;
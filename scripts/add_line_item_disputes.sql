@@ -0,0 +1,44 @@
+-- Migration: Line item disputes
+-- A consumer can dispute one charge on a receipt (the service charge, a parts charge or
+-- a reimbursed expense) instead of the whole payment. The worker either accepts, which
+-- refunds the disputed amount, or contests it, which opens a job dispute for support.
+-- Requires scripts/add_disputes.sql.
+
+CREATE TABLE IF NOT EXISTS line_item_disputes (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    consumer_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    gig_worker_id INTEGER REFERENCES people(id) ON DELETE SET NULL,
+    line_type VARCHAR(20) NOT NULL CHECK (line_type IN ('service', 'materials', 'expense')),
+    line_id INTEGER,
+    line_description TEXT NOT NULL,
+    line_amount DECIMAL(10, 2) NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount > 0),
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'contested')),
+    worker_note TEXT,
+    responded_at TIMESTAMP WITH TIME ZONE,
+    refund_transaction_id INTEGER REFERENCES transactions(id),
+    dispute_id INTEGER REFERENCES job_disputes(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_line_item_disputes_transaction ON line_item_disputes(transaction_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_line_item_disputes_worker_pending ON line_item_disputes(gig_worker_id) WHERE status = 'pending';
+
+-- A payment is refunded at most once, so one line item is disputed at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_line_item_disputes_one_pending ON line_item_disputes(transaction_id) WHERE status = 'pending';
+
+CREATE TRIGGER update_line_item_disputes_updated_at BEFORE UPDATE ON line_item_disputes FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN line_item_disputes.line_id IS 'job_parts_requests.id for materials or job_expenses.id for expenses; NULL for the service charge';
+COMMENT ON COLUMN line_item_disputes.line_amount IS 'The line''s amount on the receipt when it was disputed';
+COMMENT ON COLUMN line_item_disputes.dispute_id IS 'Job dispute opened, or joined, when the worker contested';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Line item disputes table created successfully!';
+END $$;
@@ -0,0 +1,52 @@
+-- Migration: Notification delivery ledger
+-- Every email and push notification sent is recorded with its provider message ID and
+-- status. Each send attempt and each provider receipt (SendGrid event webhook) is
+-- appended to notification_delivery_events. Transient failures are retried by the
+-- worker's NotificationRetryWorkflow until they succeed or run out of attempts.
+
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    user_id INTEGER REFERENCES people(id) ON DELETE CASCADE,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('email', 'push')),
+    provider VARCHAR(50) NOT NULL,
+    kind VARCHAR(50) NOT NULL,
+    recipient VARCHAR(512) NOT NULL,
+    subject VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'sent', 'retrying', 'delivered', 'bounced', 'failed')),
+    provider_message_id VARCHAR(255),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE,
+    payload JSONB,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS notification_delivery_events (
+    id SERIAL PRIMARY KEY,
+    delivery_id INTEGER NOT NULL REFERENCES notification_deliveries(id) ON DELETE CASCADE,
+    event VARCHAR(50) NOT NULL,
+    detail TEXT,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_user ON notification_deliveries(user_id, created_at DESC) WHERE user_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_retry ON notification_deliveries(next_attempt_at) WHERE status = 'retrying';
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_message ON notification_deliveries(provider, provider_message_id) WHERE provider_message_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_notification_delivery_events_delivery ON notification_delivery_events(delivery_id, occurred_at);
+
+CREATE TRIGGER update_notification_deliveries_updated_at BEFORE UPDATE ON notification_deliveries FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN notification_deliveries.kind IS 'What was sent, e.g. password_reset, job_notification, waitlist_invite';
+COMMENT ON COLUMN notification_deliveries.status IS 'pending: not attempted yet; sent: accepted by the provider; retrying: a transient failure, due again at next_attempt_at; delivered/bounced: from provider receipts; failed: gave up';
+COMMENT ON COLUMN notification_deliveries.payload IS 'The provider request, kept only while a retry is pending; cleared once the message is sent or abandoned since it can hold one-time links';
+COMMENT ON COLUMN notification_delivery_events.event IS 'attempt, attempt_failed, or a provider receipt event such as delivered, deferred, bounce or dropped';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Notification delivery ledger tables created successfully!';
+END $$;
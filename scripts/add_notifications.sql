@@ -0,0 +1,17 @@
+-- Migration: In-app notifications
+-- The notifications table is created by init.sql; this adds the notification types
+-- written by workflow activities and an index for the inbox and unread count queries.
+-- ALTER TYPE ... ADD VALUE cannot run inside a transaction block on PostgreSQL < 12.
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'job_offer';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'payment_sent';
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created ON notifications(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_unread ON notifications(user_id) WHERE status = 'unread';
+
+COMMENT ON COLUMN notifications.scheduled_for IS 'Hidden from the inbox and unread count until this time';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Notification types and indexes added successfully!';
+END $$;
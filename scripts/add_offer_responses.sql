@@ -0,0 +1,27 @@
+-- Migration: Job offer snoozes and decline reasons
+-- Workers can decline an offer with a reason (too far, pay too low, schedule conflict or
+-- other), which feeds the offer decline report and the decline-aware matching engine, or
+-- snooze it to be reminded before it expires. Snoozed offers stay open and expire with
+-- their round like pending ones. Requires scripts/add_job_offers.sql.
+
+ALTER TABLE job_offers DROP CONSTRAINT IF EXISTS job_offers_status_check;
+ALTER TABLE job_offers ADD CONSTRAINT job_offers_status_check
+    CHECK (status IN ('pending', 'snoozed', 'accepted', 'declined', 'cancelled', 'expired'));
+
+ALTER TABLE job_offers ADD COLUMN IF NOT EXISTS decline_reason VARCHAR(30)
+    CHECK (decline_reason IN ('too_far', 'pay_too_low', 'schedule_conflict', 'other'));
+ALTER TABLE job_offers ADD COLUMN IF NOT EXISTS decline_note TEXT;
+ALTER TABLE job_offers ADD COLUMN IF NOT EXISTS snoozed_until TIMESTAMP WITH TIME ZONE;
+ALTER TABLE job_offers ADD COLUMN IF NOT EXISTS snooze_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_job_offers_created ON job_offers(created_at);
+CREATE INDEX IF NOT EXISTS idx_job_offers_worker_declines ON job_offers(worker_id, responded_at) WHERE decline_reason IS NOT NULL;
+
+COMMENT ON COLUMN job_offers.decline_reason IS 'Why the worker declined, when they said; NULL for declines without a reason';
+COMMENT ON COLUMN job_offers.snoozed_until IS 'When a snoozed offer''s reminder is due; cleared when the worker is reminded';
+COMMENT ON COLUMN job_offers.snooze_count IS 'Times the worker snoozed the offer';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Job offer snoozes and decline reasons added successfully!';
+END $$;
@@ -0,0 +1,57 @@
+-- Migration: Ops event routing for critical business events
+-- Records every routed alert (reconciliation exceptions, workflow dead-letters,
+-- fraud flags, market fill-rate drops) so repeats can be suppressed, and stores
+-- fraud flags raised by the risk heuristics for admin review.
+
+CREATE TABLE IF NOT EXISTS ops_event_log (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    dedup_key VARCHAR(255) NOT NULL,
+    severity VARCHAR(20) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    summary TEXT,
+    fields JSONB NOT NULL DEFAULT '{}',
+    delivered BOOLEAN DEFAULT false,
+    delivery_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (event_type IN ('payment_reconciliation', 'workflow_dead_letter', 'fraud_flag', 'fill_rate_drop'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_ops_event_log_dedup ON ops_event_log(dedup_key, created_at);
+CREATE INDEX IF NOT EXISTS idx_ops_event_log_type ON ops_event_log(event_type, created_at);
+
+CREATE TRIGGER update_ops_event_log_updated_at BEFORE UPDATE ON ops_event_log FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS fraud_flags (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    job_id INTEGER REFERENCES jobs(id) ON DELETE SET NULL,
+    rule VARCHAR(100) NOT NULL,
+    score DECIMAL(4, 3) NOT NULL,
+    details TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    alerted_at TIMESTAMP WITH TIME ZONE,
+    reviewed_by INTEGER REFERENCES people(id),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (status IN ('open', 'dismissed', 'confirmed')),
+    CHECK (score >= 0 AND score <= 1)
+);
+
+-- One open flag per user and rule; repeat signals raise the existing flag's score
+CREATE UNIQUE INDEX IF NOT EXISTS idx_fraud_flags_one_open ON fraud_flags(user_id, rule) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_fraud_flags_status ON fraud_flags(status, score DESC);
+
+CREATE TRIGGER update_fraud_flags_updated_at BEFORE UPDATE ON fraud_flags FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN fraud_flags.score IS 'Risk score from 0 to 1; flags at or above FRAUD_ALERT_THRESHOLD are routed to ops';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Ops event log and fraud flags tables created successfully!';
+END $$;
@@ -0,0 +1,36 @@
+-- Migration: Materials/parts billing with consumer pre-approval
+-- Workers request approval before buying parts mid-job. Approved parts are added
+-- to the job's payment capture as a 'materials' payment split.
+
+ALTER TYPE payment_split_type ADD VALUE IF NOT EXISTS 'materials';
+
+CREATE TABLE IF NOT EXISTS job_parts_requests (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    gig_worker_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    item_name VARCHAR(255) NOT NULL,
+    quantity INTEGER NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    unit_price DECIMAL(10, 2) NOT NULL CHECK (unit_price > 0),
+    total_amount DECIMAL(10, 2) NOT NULL CHECK (total_amount > 0),
+    photo_url TEXT NOT NULL,
+    note TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'declined', 'cancelled')),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_note TEXT,
+    transaction_id INTEGER REFERENCES transactions(id) ON DELETE SET NULL,
+    billed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_parts_requests_job_id ON job_parts_requests(job_id);
+CREATE INDEX IF NOT EXISTS idx_job_parts_requests_transaction ON job_parts_requests(transaction_id) WHERE transaction_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_job_parts_requests_unbilled ON job_parts_requests(job_id) WHERE status = 'approved' AND billed_at IS NULL;
+
+CREATE TRIGGER update_job_parts_requests_updated_at BEFORE UPDATE ON job_parts_requests FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Job parts requests table created successfully!';
+END $$;
@@ -0,0 +1,23 @@
+-- Migration: Password reset tokens
+-- ForgotPassword emails a single-use token; only its SHA-256 hash is stored, so a
+-- leaked table cannot be used to reset passwords. Tokens expire after 30 minutes.
+
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    requested_ip VARCHAR(64),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user_id ON password_reset_tokens(user_id) WHERE used_at IS NULL;
+
+COMMENT ON COLUMN password_reset_tokens.token_hash IS 'Hex SHA-256 of the emailed token; the token itself is never stored';
+COMMENT ON COLUMN password_reset_tokens.used_at IS 'Set when the token resets the password or is superseded by a newer request';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Password reset tokens table created successfully!';
+END $$;
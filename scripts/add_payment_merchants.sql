@@ -0,0 +1,18 @@
+-- Migration: Per-market merchant accounts
+-- Jobs in markets routed to another merchant account (PAYMENT_MERCHANTS) are
+-- authorized, captured and refunded through that account. Each transaction records
+-- the merchant it went to, so captures and refunds follow the authorization and
+-- reconciliation can be done per merchant. Transactions recorded before this migration
+-- have no merchant and went to the default account.
+
+ALTER TABLE transactions
+ADD COLUMN IF NOT EXISTS merchant_id VARCHAR(100);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_merchant ON transactions(merchant_id, created_at) WHERE merchant_id IS NOT NULL;
+
+COMMENT ON COLUMN transactions.merchant_id IS 'Provider merchant or account ID the transaction was processed for; NULL for older transactions, which went to the default account';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Transaction merchant column added successfully!';
+END $$;
@@ -0,0 +1,27 @@
+-- Migration: Phone verification codes
+-- Users verify their phone number by typing in a 6-digit code texted to it; the code
+-- expires after 10 minutes and allows 5 attempts. Only its SHA-256 hash is stored.
+-- Sending a new code supersedes earlier ones. Verifying sets people.phone_verified,
+-- which SMS notifications require. Requires scripts/add_sms_notifications.sql.
+
+CREATE TABLE IF NOT EXISTS phone_verification_codes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    phone VARCHAR(20) NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_phone_verification_codes_user_id ON phone_verification_codes(user_id, created_at DESC);
+
+COMMENT ON COLUMN phone_verification_codes.phone IS 'The number the code was texted to; the code only verifies that number';
+COMMENT ON COLUMN phone_verification_codes.code_hash IS 'Hex SHA-256 of the texted code; the code itself is never stored';
+COMMENT ON COLUMN phone_verification_codes.used_at IS 'Set when the code verifies the phone or is superseded by a new one';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Phone verification codes table created successfully!';
+END $$;
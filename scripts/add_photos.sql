@@ -0,0 +1,34 @@
+-- Migration: Avatars and job photos
+-- Photos are attachments of kind 'photo' with their pixel dimensions and, for JPEG and
+-- PNG, a JPEG thumbnail stored next to them. A user's avatar is one such attachment;
+-- job photos are posted by the consumer or taken by the worker before and after the
+-- work. Requires scripts/add_attachments.sql.
+
+ALTER TABLE attachments
+ADD COLUMN IF NOT EXISTS width INTEGER,
+ADD COLUMN IF NOT EXISTS height INTEGER,
+ADD COLUMN IF NOT EXISTS thumbnail_key TEXT;
+
+ALTER TABLE people
+ADD COLUMN IF NOT EXISTS avatar_attachment_id INTEGER REFERENCES attachments(id) ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS job_photos (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    attachment_id INTEGER UNIQUE NOT NULL REFERENCES attachments(id) ON DELETE CASCADE,
+    stage VARCHAR(20) NOT NULL CHECK (stage IN ('posting', 'before', 'after')),
+    uploaded_by INTEGER REFERENCES people(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_photos_job ON job_photos(job_id, created_at);
+
+COMMENT ON COLUMN attachments.thumbnail_key IS 'JPEG thumbnail of a photo; NULL when the format cannot be decoded (WebP, HEIC)';
+COMMENT ON COLUMN people.avatar_attachment_id IS 'Profile photo; only served once it passed the malware scan';
+COMMENT ON COLUMN job_photos.stage IS 'posting = added by the consumer; before/after = taken by the worker around the work';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Photo columns and job_photos table created successfully!';
+END $$;
@@ -0,0 +1,38 @@
+-- Migration: Login sessions and refresh tokens
+-- Each login starts a session; its refresh tokens are opaque, single-use and stored only
+-- as SHA-256 hashes. Refreshing rotates the token, and presenting a used token again
+-- revokes the session, since it has probably been stolen.
+
+CREATE TABLE IF NOT EXISTS user_sessions (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    user_agent TEXT,
+    ip_address VARCHAR(64),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    last_used_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_sessions_user_id ON user_sessions(user_id) WHERE revoked_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id SERIAL PRIMARY KEY,
+    session_id INTEGER NOT NULL REFERENCES user_sessions(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    used_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_session_id ON refresh_tokens(session_id);
+
+COMMENT ON COLUMN user_sessions.expires_at IS 'Moves forward on each refresh; idle sessions expire';
+COMMENT ON COLUMN user_sessions.revoked_at IS 'Set on logout, revocation from another session, password reset or token reuse';
+COMMENT ON COLUMN refresh_tokens.token_hash IS 'Hex SHA-256 of the refresh token; the token itself is never stored';
+COMMENT ON COLUMN refresh_tokens.used_at IS 'Set when the token is exchanged; a used token is never accepted again';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Session and refresh token tables created successfully!';
+END $$;
@@ -0,0 +1,58 @@
+-- Migration: Bulk refund batches
+-- Incident response refunds of many captured payments at once, e.g. after an outage
+-- double-charged consumers. An admin creates a batch from a list of transactions or a
+-- filter (POST /api/v1/admin/refund-batches); the RefundBatchWorkflow refunds each
+-- item and records its result. Every item refunds with its own idempotency key, so a
+-- retried batch never refunds a payment twice.
+-- Requires clover_payment_schema.sql.
+
+CREATE TABLE IF NOT EXISTS refund_batches (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    idempotency_key VARCHAR(255) UNIQUE NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+    filter JSONB, -- NULL when the batch was given transaction IDs
+    status VARCHAR(30) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'completed_with_errors')),
+    workflow_id VARCHAR(255),
+    created_by INTEGER NOT NULL REFERENCES people(id),
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_refund_batches_status ON refund_batches(status, created_at);
+
+CREATE TABLE IF NOT EXISTS refund_batch_items (
+    id SERIAL PRIMARY KEY,
+    batch_id INTEGER NOT NULL REFERENCES refund_batches(id) ON DELETE CASCADE,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+    job_id INTEGER NOT NULL REFERENCES jobs(id),
+    consumer_id INTEGER NOT NULL REFERENCES people(id),
+    amount DECIMAL(10, 2) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'refunded', 'failed', 'skipped')),
+    refund_transaction_id INTEGER REFERENCES transactions(id),
+    error TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    processed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (batch_id, transaction_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_refund_batch_items_batch_status ON refund_batch_items(batch_id, status);
+CREATE INDEX IF NOT EXISTS idx_refund_batch_items_transaction ON refund_batch_items(transaction_id);
+
+CREATE TRIGGER update_refund_batches_updated_at BEFORE UPDATE ON refund_batches FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_refund_batch_items_updated_at BEFORE UPDATE ON refund_batch_items FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN refund_batches.request_hash IS 'SHA-256 of the request; the idempotency key may only be repeated with the same request';
+COMMENT ON COLUMN refund_batches.currency IS 'Every item in a batch is in this currency, so the summary totals add up';
+COMMENT ON COLUMN refund_batch_items.status IS 'skipped when the payment was not captured or already refunded when the batch was created';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Refund batch tables created successfully!';
+END $$;
@@ -0,0 +1,49 @@
+-- Migration: Safety incident reporting
+-- In-job SOS/incident reports raised by workers or consumers for trust & safety review
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'incident_status') THEN
+        CREATE TYPE incident_status AS ENUM ('open', 'acknowledged', 'resolved');
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'incident_severity') THEN
+        CREATE TYPE incident_severity AS ENUM ('low', 'medium', 'high', 'emergency');
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS safety_incidents (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    reporter_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    other_party_id INTEGER REFERENCES people(id) ON DELETE SET NULL,
+    incident_type VARCHAR(50) NOT NULL,
+    severity incident_severity NOT NULL DEFAULT 'high',
+    description TEXT NOT NULL,
+    latitude DECIMAL(10, 8),
+    longitude DECIMAL(11, 8),
+    status incident_status NOT NULL DEFAULT 'open',
+    other_party_notified BOOLEAN DEFAULT false,
+    workflow_paused BOOLEAN DEFAULT false,
+    ops_notified_at TIMESTAMP WITH TIME ZONE,
+    acknowledged_by INTEGER REFERENCES people(id),
+    acknowledged_at TIMESTAMP WITH TIME ZONE,
+    resolved_by INTEGER REFERENCES people(id),
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    resolution_notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_safety_incidents_job_id ON safety_incidents(job_id);
+CREATE INDEX IF NOT EXISTS idx_safety_incidents_reporter_id ON safety_incidents(reporter_id);
+CREATE INDEX IF NOT EXISTS idx_safety_incidents_open ON safety_incidents(status, created_at) WHERE status <> 'resolved';
+
+CREATE TRIGGER update_safety_incidents_updated_at BEFORE UPDATE ON safety_incidents FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN safety_incidents.workflow_paused IS 'Whether the job workflow was paused pending resolution of this incident';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Safety incidents table created successfully!';
+END $$;
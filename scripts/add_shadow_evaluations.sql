@@ -0,0 +1,37 @@
+-- Migration: Shadow evaluation of matching and pricing algorithms
+-- Candidate algorithms named in SHADOW_PRICING_RULES / SHADOW_MATCHING_ENGINES run
+-- alongside production on real jobs. Each row is one candidate's output for a job next
+-- to production's; GET /api/v1/shadow/report aggregates them.
+
+CREATE TABLE IF NOT EXISTS shadow_evaluations (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('pricing', 'matching')),
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    candidate VARCHAR(50) NOT NULL,
+    production VARCHAR(50) NOT NULL,
+    production_price DECIMAL(10, 2),
+    candidate_price DECIMAL(10, 2),
+    production_worker_id INTEGER,
+    candidate_worker_id INTEGER,
+    candidate_count INTEGER NOT NULL DEFAULT 0,
+    agrees BOOLEAN NOT NULL DEFAULT false,
+    error TEXT,
+    latency_micros BIGINT NOT NULL DEFAULT 0,
+    inputs JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (kind, job_id, candidate)
+);
+
+CREATE INDEX IF NOT EXISTS idx_shadow_evaluations_kind_updated ON shadow_evaluations(kind, updated_at);
+
+CREATE TRIGGER update_shadow_evaluations_updated_at BEFORE UPDATE ON shadow_evaluations FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN shadow_evaluations.inputs IS 'Job and worker candidates the algorithms saw, for replaying offline';
+COMMENT ON COLUMN shadow_evaluations.error IS 'Set when the candidate panicked; production was unaffected';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Shadow evaluations table created successfully!';
+END $$;
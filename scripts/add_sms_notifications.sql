@@ -0,0 +1,15 @@
+-- Migration: SMS notifications
+-- Notifications are texted through Twilio to users who turn on sms_enabled for a type in
+-- notification_preferences and have a verified phone number. Texts are recorded in the
+-- delivery ledger like email and push. Requires scripts/add_notification_deliveries.sql.
+
+ALTER TABLE notification_deliveries DROP CONSTRAINT IF EXISTS notification_deliveries_channel_check;
+ALTER TABLE notification_deliveries ADD CONSTRAINT notification_deliveries_channel_check
+    CHECK (channel IN ('email', 'push', 'sms'));
+
+COMMENT ON COLUMN notification_preferences.sms_enabled IS 'Text this type to the user''s phone; only sent once the phone is verified';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'SMS notification channel added successfully!';
+END $$;
@@ -0,0 +1,32 @@
+-- Migration: Stripe payment provider
+-- Transactions record which provider processed them and the provider's own IDs, so
+-- captures and refunds go back to the provider that took the payment.
+-- Requires clover_payment_schema.sql.
+
+ALTER TABLE transactions
+ADD COLUMN IF NOT EXISTS payment_provider VARCHAR(50) NOT NULL DEFAULT 'clover',
+ADD COLUMN IF NOT EXISTS provider_charge_id VARCHAR(255),          -- Clover charge ID or Stripe PaymentIntent ID
+ADD COLUMN IF NOT EXISTS provider_source_token VARCHAR(255),       -- Card token or Stripe PaymentMethod ID
+ADD COLUMN IF NOT EXISTS provider_refund_id VARCHAR(255);
+
+-- Existing rows were all processed by Clover
+UPDATE transactions
+SET provider_charge_id = clover_charge_id,
+    provider_source_token = clover_source_token,
+    provider_refund_id = clover_refund_id
+WHERE payment_provider = 'clover'
+  AND provider_charge_id IS NULL
+  AND provider_refund_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_provider_charge_id ON transactions(payment_provider, provider_charge_id) WHERE provider_charge_id IS NOT NULL;
+
+INSERT INTO payment_providers (name, display_name, is_active, api_endpoint)
+VALUES ('stripe', 'Stripe', true, 'https://api.stripe.com')
+ON CONFLICT (name) DO NOTHING;
+
+COMMENT ON COLUMN transactions.payment_provider IS 'Provider that processed the transaction (clover or stripe); set from PAYMENT_PROVIDER at authorization';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Stripe payment provider columns created successfully!';
+END $$;
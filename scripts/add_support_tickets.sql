@@ -0,0 +1,51 @@
+-- Migration: Job message threads and chat-to-ticket escalation
+-- Either job participant can escalate the in-app thread to support. The ticket keeps
+-- a snapshot of the thread at escalation time and may pause the job workflow.
+
+CREATE TABLE IF NOT EXISTS job_messages (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    sender_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_messages_job_created ON job_messages(job_id, created_at);
+
+CREATE TRIGGER update_job_messages_updated_at BEFORE UPDATE ON job_messages FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS support_tickets (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    opened_by INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    subject VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'in_progress', 'resolved')),
+    thread_snapshot JSONB NOT NULL DEFAULT '[]',
+    workflow_paused BOOLEAN DEFAULT false,
+    ops_notified_at TIMESTAMP WITH TIME ZONE,
+    assigned_to INTEGER REFERENCES people(id),
+    resolved_by INTEGER REFERENCES people(id),
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    resolution_notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_support_tickets_job_id ON support_tickets(job_id);
+CREATE INDEX IF NOT EXISTS idx_support_tickets_open ON support_tickets(status, created_at) WHERE status <> 'resolved';
+
+-- One unresolved escalation per job
+CREATE UNIQUE INDEX IF NOT EXISTS idx_support_tickets_one_open_per_job ON support_tickets(job_id) WHERE status <> 'resolved';
+
+CREATE TRIGGER update_support_tickets_updated_at BEFORE UPDATE ON support_tickets FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN support_tickets.thread_snapshot IS 'Copy of the job message thread when the ticket was opened';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Job messages and support tickets tables created successfully!';
+END $$;
@@ -0,0 +1,27 @@
+-- Migration: Push notification devices
+-- Apps register their FCM registration token after login; push notifications go to
+-- every active device of the user. Tokens FCM reports as unregistered or invalid are
+-- disabled, and a token registered by another user moves to them.
+
+CREATE TABLE IF NOT EXISTS user_devices (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    token TEXT UNIQUE NOT NULL,
+    platform VARCHAR(20) NOT NULL CHECK (platform IN ('ios', 'android', 'web')),
+    app_version VARCHAR(50),
+    device_model VARCHAR(100),
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    disabled_at TIMESTAMP WITH TIME ZONE,
+    disabled_reason VARCHAR(50),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_devices_user_id ON user_devices(user_id) WHERE disabled_at IS NULL;
+
+COMMENT ON COLUMN user_devices.token IS 'FCM registration token; replaced when FCM returns a canonical token for it';
+COMMENT ON COLUMN user_devices.disabled_reason IS 'FCM error that disabled the token, e.g. NotRegistered; registering the token again re-enables it';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'User devices table created successfully!';
+END $$;
@@ -0,0 +1,49 @@
+-- Migration: Waitlist signups for unlaunched markets
+-- Captures interest from consumers and workers in markets that are not live yet
+-- and tracks when each signup was sent an invite after the market launches.
+
+CREATE TABLE IF NOT EXISTS markets (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    slug VARCHAR(100) UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    city VARCHAR(100),
+    state VARCHAR(50),
+    is_live BOOLEAN DEFAULT false,
+    launched_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS waitlist_signups (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    role user_role NOT NULL DEFAULT 'consumer',
+    market_id INTEGER REFERENCES markets(id) ON DELETE SET NULL,
+    city VARCHAR(100),
+    state VARCHAR(50),
+    postal_code VARCHAR(20),
+    latitude DECIMAL(10, 8),
+    longitude DECIMAL(11, 8),
+    invited_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (role IN ('consumer', 'gig_worker'))
+);
+
+-- One signup per email; repeat submissions update the existing row
+CREATE UNIQUE INDEX IF NOT EXISTS idx_waitlist_signups_email ON waitlist_signups(LOWER(email));
+CREATE INDEX IF NOT EXISTS idx_waitlist_signups_market ON waitlist_signups(market_id) WHERE market_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_waitlist_signups_pending_invite ON waitlist_signups(market_id) WHERE invited_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_markets_city_state ON markets(LOWER(city), LOWER(state));
+
+CREATE TRIGGER update_markets_updated_at BEFORE UPDATE ON markets FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_waitlist_signups_updated_at BEFORE UPDATE ON waitlist_signups FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN waitlist_signups.invited_at IS 'Timestamp when the launch invite email was sent for this signup';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Waitlist tables created successfully!';
+END $$;
@@ -0,0 +1,50 @@
+-- Migration: Weather advisories for outdoor jobs
+-- Forecast advisories attached to scheduled outdoor jobs, and reschedule proposals
+-- raised by either party when severe weather is forecast
+
+CREATE TABLE IF NOT EXISTS job_weather_advisories (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    level VARCHAR(20) NOT NULL,
+    summary TEXT NOT NULL,
+    reasons TEXT[],
+    forecast JSONB,
+    suggested_start TIMESTAMP WITH TIME ZONE,
+    checked_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    severe_notified_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(job_id)
+);
+
+CREATE TABLE IF NOT EXISTS job_reschedule_proposals (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    proposed_by INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    reason VARCHAR(50) NOT NULL DEFAULT 'weather',
+    original_start TIMESTAMP WITH TIME ZONE,
+    original_end TIMESTAMP WITH TIME ZONE,
+    proposed_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    proposed_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'superseded')),
+    responded_by INTEGER REFERENCES people(id),
+    responded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_weather_advisories_level ON job_weather_advisories(level);
+CREATE INDEX IF NOT EXISTS idx_job_reschedule_proposals_job_id ON job_reschedule_proposals(job_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_job_reschedule_proposals_pending ON job_reschedule_proposals(job_id) WHERE status = 'pending';
+
+CREATE TRIGGER update_job_weather_advisories_updated_at BEFORE UPDATE ON job_weather_advisories FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_job_reschedule_proposals_updated_at BEFORE UPDATE ON job_reschedule_proposals FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN job_weather_advisories.suggested_start IS 'First later start time with a non-severe forecast, offered as the one-tap reschedule option';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Weather advisory tables created successfully!';
+END $$;
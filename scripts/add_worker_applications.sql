@@ -0,0 +1,67 @@
+-- Migration: Worker application and approval pipeline
+-- Users apply to become gig workers; admins screen each application through
+-- submitted -> docs_pending -> background_check -> approved/denied. Approval makes the
+-- account a gig_worker with a verified worker profile, and only verified workers are
+-- eligible for matching. Workers whose profile is not verified must apply.
+-- Requires unify_worker_profiles.sql.
+
+CREATE TABLE IF NOT EXISTS worker_applications (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'submitted'
+        CHECK (status IN ('submitted', 'docs_pending', 'background_check', 'approved', 'denied')),
+    address TEXT NOT NULL,
+    bio TEXT,
+    skills TEXT,
+    hourly_rate DECIMAL(10, 2) CHECK (hourly_rate IS NULL OR hourly_rate > 0),
+    experience_years INTEGER CHECK (experience_years IS NULL OR (experience_years >= 0 AND experience_years <= 50)),
+    service_radius_miles DECIMAL(5, 2) CHECK (service_radius_miles IS NULL OR (service_radius_miles >= 1 AND service_radius_miles <= 100)),
+    availability_notes TEXT,
+    reviewer_id INTEGER REFERENCES people(id),
+    decision_note TEXT,
+    submitted_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    decided_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- One application in screening per user
+CREATE UNIQUE INDEX IF NOT EXISTS idx_worker_applications_one_open
+ON worker_applications(user_id)
+WHERE status NOT IN ('approved', 'denied');
+
+CREATE INDEX IF NOT EXISTS idx_worker_applications_status ON worker_applications(status, submitted_at);
+
+CREATE TRIGGER update_worker_applications_updated_at BEFORE UPDATE ON worker_applications FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS worker_application_events (
+    id SERIAL PRIMARY KEY,
+    application_id INTEGER NOT NULL REFERENCES worker_applications(id) ON DELETE CASCADE,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    actor_id INTEGER NOT NULL REFERENCES people(id),
+    note TEXT,
+    occurred_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_worker_application_events_application ON worker_application_events(application_id, occurred_at);
+
+-- Screening history is append-only
+CREATE OR REPLACE FUNCTION prevent_worker_application_event_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'worker_application_events is append-only';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER worker_application_events_append_only BEFORE UPDATE OR DELETE ON worker_application_events FOR EACH ROW EXECUTE FUNCTION prevent_worker_application_event_changes();
+
+COMMENT ON COLUMN worker_applications.decision_note IS 'Latest screening note; required when an application is denied';
+COMMENT ON COLUMN worker_application_events.from_status IS 'NULL for the submission itself';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Worker application tables created successfully!';
+    RAISE NOTICE 'Workers eligible for matching: %', (SELECT count(*) FROM worker_profiles WHERE verification_status = 'verified');
+END $$;
@@ -0,0 +1,24 @@
+-- Migration: Worker blackout dates
+-- Days a gig worker is not taking work (holidays, time off). Together with booked
+-- schedules and accepted jobs they make up the worker's busy time, which matching and
+-- job scheduling avoid. Dates are whole days in UTC, end date included.
+
+CREATE TABLE IF NOT EXISTS worker_blackout_dates (
+    id SERIAL PRIMARY KEY,
+    worker_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT chk_worker_blackout_dates_order CHECK (end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_worker_blackout_dates_worker ON worker_blackout_dates(worker_id, start_date, end_date);
+
+COMMENT ON COLUMN worker_blackout_dates.end_date IS 'Last day of the blackout, inclusive';
+COMMENT ON COLUMN worker_blackout_dates.reason IS 'Visible only to the worker and admins';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Worker blackout dates table created successfully!';
+END $$;
@@ -0,0 +1,39 @@
+-- Migration: Worker verification documents
+-- Gig workers and applicants upload government IDs, licenses and insurance
+-- certificates straight to the attachment store with signed upload URLs; the API then
+-- checks and scans each file and records it here for an admin to approve or reject.
+-- A daily workflow reminds workers 30 and 7 days before an approved document expires
+-- and marks it expired once its date has passed. Requires add_attachments.sql.
+
+CREATE TABLE IF NOT EXISTS worker_documents (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    worker_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    document_type VARCHAR(20) NOT NULL CHECK (document_type IN ('government_id', 'license', 'insurance')),
+    attachment_id INTEGER NOT NULL UNIQUE REFERENCES attachments(id) ON DELETE CASCADE,
+    expires_on DATE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'approved', 'rejected', 'expired')),
+    reviewer_id INTEGER REFERENCES people(id) ON DELETE SET NULL,
+    review_note TEXT,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    last_reminder_days INTEGER,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT chk_worker_documents_rejection_note CHECK (status <> 'rejected' OR review_note IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_worker_documents_worker ON worker_documents(worker_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_worker_documents_review_queue ON worker_documents(created_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_worker_documents_expiry ON worker_documents(expires_on) WHERE status = 'approved' AND expires_on IS NOT NULL;
+
+CREATE TRIGGER update_worker_documents_updated_at BEFORE UPDATE ON worker_documents FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN worker_documents.expires_on IS 'Last day the document is valid; required for licenses and insurance';
+COMMENT ON COLUMN worker_documents.last_reminder_days IS 'Days before expiry of the last reminder sent (30 or 7); NULL before the first';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Worker documents table created successfully!';
+END $$;
@@ -0,0 +1,55 @@
+-- Migration: Worker payouts
+-- Settlement batches claim captured job payments (transactions.settlement_batch_id)
+-- and pay each worker their earnings in one payout. Batches are created by the
+-- PayoutSettlementWorkflow schedule or POST /api/v1/payouts/batches.
+-- Requires clover_payment_schema.sql and add_stripe_provider.sql.
+
+ALTER TABLE settlement_batches
+ADD COLUMN IF NOT EXISTS payment_provider VARCHAR(50),
+ADD COLUMN IF NOT EXISTS cutoff_at TIMESTAMP WITH TIME ZONE,
+ADD COLUMN IF NOT EXISTS payout_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_settlement_batches_cutoff_at ON settlement_batches(cutoff_at) WHERE cutoff_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_settlement_batches_status ON settlement_batches(status, created_at);
+
+-- Stripe Connect account the worker is paid out to
+ALTER TABLE gigworkers
+ADD COLUMN IF NOT EXISTS payout_account_id VARCHAR(255);
+
+CREATE TABLE IF NOT EXISTS worker_payouts (
+    id SERIAL PRIMARY KEY,
+    uuid UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    settlement_batch_id INTEGER NOT NULL REFERENCES settlement_batches(id) ON DELETE CASCADE,
+    gig_worker_id INTEGER NOT NULL REFERENCES people(id),
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount > 0),
+    paid_amount DECIMAL(10, 2),
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+    transaction_count INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'paid', 'failed', 'manual')),
+    payment_provider VARCHAR(50) NOT NULL,
+    provider_payout_id VARCHAR(255),
+    failure_reason TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    paid_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (settlement_batch_id, gig_worker_id, currency)
+);
+
+CREATE INDEX IF NOT EXISTS idx_worker_payouts_worker ON worker_payouts(gig_worker_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_worker_payouts_batch_status ON worker_payouts(settlement_batch_id, status);
+
+-- Captures waiting for a settlement batch
+CREATE INDEX IF NOT EXISTS idx_transactions_unsettled_captures ON transactions(captured_at)
+WHERE captured_at IS NOT NULL AND settlement_batch_id IS NULL;
+
+CREATE TRIGGER update_worker_payouts_updated_at BEFORE UPDATE ON worker_payouts FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN settlement_batches.cutoff_at IS 'Batch claims captures made before this time minus PAYOUT_HOLD_HOURS; unique so scheduled retries reuse the batch';
+COMMENT ON COLUMN worker_payouts.status IS 'manual when the provider (Clover) cannot pay workers and earnings are settled outside the platform';
+COMMENT ON COLUMN worker_payouts.amount IS 'Worker share of the job price plus reimbursed expenses and materials';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Worker payouts table created successfully!';
+END $$;
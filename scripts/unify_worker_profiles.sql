@@ -0,0 +1,123 @@
+-- Migration: Unify gig workers into people + worker_profiles
+-- Workers used to exist twice: as people accounts (registration with the gig_worker
+-- role) and as rows in a separate gigworkers table, linked only by email, that
+-- matching, payouts and the emergency contact vault read. This moves every gigworkers
+-- row onto its people account, creating the account when there is none (without a
+-- password; the worker signs in through password reset), keeps worker-specific data
+-- in worker_profiles and re-points the vault tables at people ids. Gig worker ids in
+-- the API become user ids.
+--
+-- Run after add_temporal_columns.sql, add_worker_payouts.sql and
+-- add_emergency_contact_vault.sql. Afterwards re-run the backfill to seal any
+-- plaintext emergency contacts copied into worker_profiles:
+--   VAULT_ENCRYPTION_KEY=... go run ./cmd/backfill_emergency_contacts
+-- gigworkers is renamed to gigworkers_legacy; drop it once the migration is verified.
+
+BEGIN;
+
+-- Role is enforced by the API; a subquery CHECK cannot be created in PostgreSQL
+ALTER TABLE worker_profiles DROP CONSTRAINT IF EXISTS chk_worker_profiles_role;
+
+ALTER TABLE worker_profiles
+ADD COLUMN IF NOT EXISTS legacy_gigworker_id INTEGER UNIQUE,
+ADD COLUMN IF NOT EXISTS skills TEXT,
+ADD COLUMN IF NOT EXISTS payout_account_id VARCHAR(255),
+ADD COLUMN IF NOT EXISTS is_available BOOLEAN NOT NULL DEFAULT true;
+
+CREATE INDEX IF NOT EXISTS idx_worker_profiles_is_available ON worker_profiles(is_available);
+
+-- Accounts for workers who never registered
+INSERT INTO people (email, name, phone, address, latitude, longitude, place_id, role,
+                    is_active, email_verified, phone_verified, created_at, updated_at)
+SELECT DISTINCT ON (LOWER(g.email))
+       g.email, g.name, g.phone, g.address, g.latitude, g.longitude, g.place_id, 'gig_worker',
+       g.is_active, g.email_verified, g.phone_verified, g.created_at, g.updated_at
+FROM gigworkers g
+WHERE NOT EXISTS (SELECT 1 FROM people p WHERE LOWER(p.email) = LOWER(g.email))
+ORDER BY LOWER(g.email), g.id;
+
+-- Legacy gigworkers id -> people id. Rows differing only in email case share an
+-- account; the oldest one is primary and supplies the profile.
+CREATE TEMP TABLE gigworker_accounts ON COMMIT DROP AS
+SELECT g.id AS gigworker_id, p.id AS person_id,
+       ROW_NUMBER() OVER (PARTITION BY p.id ORDER BY g.id) = 1 AS is_primary
+FROM gigworkers g
+JOIN people p ON LOWER(p.email) = LOWER(g.email);
+
+INSERT INTO worker_profiles (
+    worker_id, legacy_gigworker_id, bio, hourly_rate, experience_years, verification_status,
+    background_check_date, service_radius_miles, availability_notes, emergency_contact_name,
+    emergency_contact_phone, emergency_contact_relationship, skills, payout_account_id,
+    created_at, updated_at
+)
+SELECT a.person_id, g.id, g.bio, g.hourly_rate, g.experience_years, g.verification_status,
+       g.background_check_date, g.service_radius_miles, g.availability_notes, g.emergency_contact_name,
+       g.emergency_contact_phone, g.emergency_contact_relationship, g.skills, g.payout_account_id,
+       g.created_at, g.updated_at
+FROM gigworker_accounts a
+JOIN gigworkers g ON g.id = a.gigworker_id
+WHERE a.is_primary
+ON CONFLICT (worker_id) DO UPDATE SET
+    legacy_gigworker_id = EXCLUDED.legacy_gigworker_id,
+    bio = COALESCE(EXCLUDED.bio, worker_profiles.bio),
+    hourly_rate = COALESCE(EXCLUDED.hourly_rate, worker_profiles.hourly_rate),
+    experience_years = COALESCE(EXCLUDED.experience_years, worker_profiles.experience_years),
+    verification_status = COALESCE(EXCLUDED.verification_status, worker_profiles.verification_status),
+    background_check_date = COALESCE(EXCLUDED.background_check_date, worker_profiles.background_check_date),
+    service_radius_miles = COALESCE(EXCLUDED.service_radius_miles, worker_profiles.service_radius_miles),
+    availability_notes = COALESCE(EXCLUDED.availability_notes, worker_profiles.availability_notes),
+    emergency_contact_name = COALESCE(EXCLUDED.emergency_contact_name, worker_profiles.emergency_contact_name),
+    emergency_contact_phone = COALESCE(EXCLUDED.emergency_contact_phone, worker_profiles.emergency_contact_phone),
+    emergency_contact_relationship = COALESCE(EXCLUDED.emergency_contact_relationship, worker_profiles.emergency_contact_relationship),
+    skills = EXCLUDED.skills,
+    payout_account_id = EXCLUDED.payout_account_id;
+
+-- Sealed contacts are bound to the id they were sealed under, so that id is kept in
+-- sealed_record_id until the contact is next saved
+ALTER TABLE gigworker_emergency_contacts
+ADD COLUMN IF NOT EXISTS sealed_record_id INTEGER;
+
+ALTER TABLE gigworker_emergency_contacts DROP CONSTRAINT IF EXISTS gigworker_emergency_contacts_gigworker_id_fkey;
+
+DELETE FROM gigworker_emergency_contacts c
+USING gigworker_accounts a
+WHERE a.gigworker_id = c.gigworker_id AND NOT a.is_primary;
+
+-- Negated first so swapped ids never collide on the unique gigworker_id
+UPDATE gigworker_emergency_contacts SET sealed_record_id = gigworker_id, gigworker_id = -gigworker_id;
+UPDATE gigworker_emergency_contacts c SET gigworker_id = a.person_id
+FROM gigworker_accounts a
+WHERE a.gigworker_id = c.sealed_record_id;
+
+ALTER TABLE gigworker_emergency_contacts
+ADD CONSTRAINT gigworker_emergency_contacts_gigworker_id_fkey FOREIGN KEY (gigworker_id) REFERENCES people(id) ON DELETE CASCADE;
+
+-- The access log is append-only; re-pointing its worker ids is the one sanctioned rewrite
+ALTER TABLE break_glass_access_log DROP CONSTRAINT IF EXISTS break_glass_access_log_gigworker_id_fkey;
+ALTER TABLE break_glass_access_log DISABLE TRIGGER break_glass_access_log_append_only;
+
+UPDATE break_glass_access_log l SET gigworker_id = a.person_id
+FROM gigworker_accounts a
+WHERE a.gigworker_id = l.gigworker_id;
+
+ALTER TABLE break_glass_access_log ENABLE TRIGGER break_glass_access_log_append_only;
+ALTER TABLE break_glass_access_log
+ADD CONSTRAINT break_glass_access_log_gigworker_id_fkey FOREIGN KEY (gigworker_id) REFERENCES people(id) ON DELETE CASCADE;
+
+ALTER TABLE gigworkers RENAME TO gigworkers_legacy;
+
+COMMIT;
+
+COMMENT ON COLUMN worker_profiles.legacy_gigworker_id IS 'id of the gigworkers_legacy row this profile was migrated from';
+COMMENT ON COLUMN worker_profiles.is_available IS 'False while matching has the worker assigned to a job; separate from people.is_active';
+COMMENT ON COLUMN worker_profiles.payout_account_id IS 'Stripe Connect account the worker is paid out to';
+COMMENT ON COLUMN gigworker_emergency_contacts.gigworker_id IS 'people.id of the worker';
+COMMENT ON COLUMN gigworker_emergency_contacts.sealed_record_id IS 'Legacy gigworkers id the contact was sealed under; NULL once re-sealed under gigworker_id';
+COMMENT ON COLUMN break_glass_access_log.gigworker_id IS 'people.id of the worker';
+
+DO $$
+BEGIN
+    RAISE NOTICE 'Gig workers unified into people and worker_profiles successfully!';
+    RAISE NOTICE 'Worker profiles: %', (SELECT count(*) FROM worker_profiles);
+    RAISE NOTICE 'Run cmd/backfill_emergency_contacts to seal plaintext emergency contacts';
+END $$;
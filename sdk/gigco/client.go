@@ -0,0 +1,129 @@
+// Package gigco is a typed client for the GigCo API. The types and endpoint methods
+// in client_gen.go are generated from the OpenAPI document by cmd/sdkgen; run
+// go generate ./sdk/... after changing routes or their catalog entries.
+package gigco
+
+//go:generate go run ../../cmd/sdkgen -go-out client_gen.go -ts-out ../typescript -spec-out ../openapi.json
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Client calls the GigCo API
+type Client struct {
+	BaseURL    string
+	Token      string // JWT access token sent as a Bearer token, if set
+	HTTPClient *http.Client
+}
+
+// NewClient creates a client for the API served at baseURL
+func NewClient(baseURL string) *Client {
+	return &Client{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		HTTPClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// WithToken returns a copy of the client that authenticates with token
+func (c *Client) WithToken(token string) *Client {
+	copied := *c
+	copied.Token = token
+	return &copied
+}
+
+// APIError is returned for non-2xx responses
+type APIError struct {
+	StatusCode int
+	Body       ErrorResponse
+}
+
+func (e *APIError) Error() string {
+	if e.Body.Message != "" {
+		return fmt.Sprintf("gigco: %d %s: %s", e.StatusCode, e.Body.Error, e.Body.Message)
+	}
+	return fmt.Sprintf("gigco: %d %s", e.StatusCode, e.Body.Error)
+}
+
+// do sends a request and decodes the response into out. A *[]byte out receives the
+// raw body, for non-JSON responses such as CSV exports.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	endpoint := c.BaseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("gigco: failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("gigco: failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("gigco: %s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("gigco: failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if json.Unmarshal(data, &apiErr.Body) != nil || apiErr.Body.Error == "" {
+			apiErr.Body.Error = http.StatusText(resp.StatusCode)
+			apiErr.Body.Message = strings.TrimSpace(string(data))
+		}
+		return apiErr
+	}
+
+	switch target := out.(type) {
+	case nil:
+		return nil
+	case *[]byte:
+		*target = data
+		return nil
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("gigco: failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// pathParam formats and escapes a path parameter
+func pathParam(value interface{}) string {
+	return url.PathEscape(fmt.Sprint(value))
+}
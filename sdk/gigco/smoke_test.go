@@ -0,0 +1,131 @@
+package gigco_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"testing"
+
+	"app/api"
+	"app/handler"
+	"app/internal/auth"
+	"app/internal/middleware"
+	"app/sdk/gigco"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// newTestServer serves the API router without a database, so the smoke tests only
+// call endpoints that answer before reaching it
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	auth.InitJWT()
+
+	router := chi.NewRouter()
+	router.Use(middleware.SecurityHeaders)
+	handler.RegisterRoutes(router, api.NewApp())
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newToken(t *testing.T, role string) string {
+	t.Helper()
+	token, err := auth.GenerateJWT(1, "00000000-0000-0000-0000-000000000001", "smoke@example.com", role)
+	if err != nil {
+		t.Fatalf("GenerateJWT() error = %v", err)
+	}
+	return token
+}
+
+func TestGoClientSmoke(t *testing.T) {
+	server := newTestServer(t)
+	client := gigco.NewClient(server.URL)
+	ctx := context.Background()
+
+	health, err := client.LivenessCheck(ctx)
+	if err != nil {
+		t.Fatalf("LivenessCheck() error = %v", err)
+	}
+	if health.Status != "alive" {
+		t.Errorf("LivenessCheck().Status = %q, want alive", health.Status)
+	}
+
+	spec, err := client.GetOpenAPISpec(ctx)
+	if err != nil {
+		t.Fatalf("GetOpenAPISpec() error = %v", err)
+	}
+	if info, _ := spec["info"].(map[string]interface{}); info["version"] != gigco.APIVersion {
+		t.Errorf("served spec version = %v, client generated from %s; run go generate ./sdk/...", info["version"], gigco.APIVersion)
+	}
+
+	tests := []struct {
+		name   string
+		call   func() error
+		status int
+	}{
+		{
+			name: "invalid registration",
+			call: func() error {
+				_, err := client.RegisterUser(ctx, gigco.RegisterRequest{Email: "not-an-email"})
+				return err
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "missing token",
+			call: func() error {
+				_, err := client.GetUserProfile(ctx, nil)
+				return err
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "wrong role",
+			call: func() error {
+				_, err := client.WithToken(newToken(t, "consumer")).GetUserByID(ctx, 1)
+				return err
+			},
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var apiErr *gigco.APIError
+			if err := tt.call(); !errors.As(err, &apiErr) {
+				t.Fatalf("error = %v, want *gigco.APIError", err)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, tt.status)
+			}
+			if apiErr.Body.Error == "" {
+				t.Error("APIError.Body.Error is empty")
+			}
+		})
+	}
+}
+
+// TestTypeScriptClientSmoke runs the TypeScript client's smoke tests against the same
+// server
+func TestTypeScriptClientSmoke(t *testing.T) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node not installed")
+	}
+
+	server := newTestServer(t)
+	cmd := exec.Command(node, "--test", "test/")
+	cmd.Dir = "../typescript"
+	cmd.Env = append(os.Environ(),
+		"GIGCO_API_URL="+server.URL,
+		"GIGCO_CONSUMER_TOKEN="+newToken(t, "consumer"),
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("node --test failed: %v\n%s", err, output)
+	}
+}
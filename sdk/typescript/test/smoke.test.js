@@ -0,0 +1,45 @@
+// Smoke tests for the generated client. They need a running API:
+//
+//   GIGCO_API_URL=http://localhost:8080 GIGCO_CONSUMER_TOKEN=... npm test
+//
+// go test ./sdk/... runs them against a test server when node is installed.
+import assert from "node:assert/strict";
+import { test } from "node:test";
+
+import { API_VERSION, GigcoApiError, GigcoClient } from "../client.js";
+
+const baseUrl = process.env.GIGCO_API_URL;
+const consumerToken = process.env.GIGCO_CONSUMER_TOKEN;
+const skip = baseUrl ? false : "GIGCO_API_URL not set";
+
+test("liveness check", { skip }, async () => {
+  const client = new GigcoClient(baseUrl);
+  const health = await client.livenessCheck();
+  assert.equal(health.status, "alive");
+});
+
+test("served spec matches the client version", { skip }, async () => {
+  const client = new GigcoClient(baseUrl);
+  const spec = await client.getOpenAPISpec();
+  assert.equal(spec.info.version, API_VERSION, "client is stale; run go generate ./sdk/...");
+});
+
+test("validation errors reject with GigcoApiError", { skip }, async () => {
+  const client = new GigcoClient(baseUrl);
+  await assert.rejects(client.registerUser({ email: "not-an-email" }), (err) => {
+    assert.ok(err instanceof GigcoApiError);
+    assert.equal(err.status, 400);
+    assert.ok(err.body.error);
+    return true;
+  });
+});
+
+test("protected endpoints require a token", { skip }, async () => {
+  const client = new GigcoClient(baseUrl);
+  await assert.rejects(client.getUserProfile(), { name: "GigcoApiError", status: 401 });
+});
+
+test("role checks apply to the token", { skip: skip || (consumerToken ? false : "GIGCO_CONSUMER_TOKEN not set") }, async () => {
+  const client = new GigcoClient(baseUrl).withToken(consumerToken);
+  await assert.rejects(client.getUserByID(1), { name: "GigcoApiError", status: 403 });
+});